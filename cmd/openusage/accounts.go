@@ -0,0 +1,371 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/detect"
+	"github.com/janekbaraniewski/openusage/internal/export"
+	"github.com/janekbaraniewski/openusage/internal/providers"
+)
+
+// Account origins: where an account entry itself comes from, as opposed to
+// where its secret comes from.
+const (
+	accountOriginConfig      = "config"
+	accountOriginDetected    = "detected"
+	accountOriginCredentials = "credentials"
+)
+
+// accountRow is one line of `openusage accounts list`.
+type accountRow struct {
+	AccountID          string     `json:"account_id"`
+	Provider           string     `json:"provider"`
+	ProviderRegistered bool       `json:"provider_registered"`
+	Auth               string     `json:"auth,omitempty"`
+	Origin             string     `json:"origin"`
+	CredentialSource   string     `json:"credential_source,omitempty"`
+	Credential         string     `json:"credential,omitempty"`
+	Status             string     `json:"status,omitempty"`
+	Message            string     `json:"message,omitempty"`
+	FetchedAt          *time.Time `json:"fetched_at,omitempty"`
+}
+
+func newAccountsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "accounts",
+		Short: "Inspect the accounts openusage polls",
+	}
+	cmd.AddCommand(newAccountsListCommand())
+	cmd.AddCommand(newAccountsOptionsCommand())
+	return cmd
+}
+
+func newAccountsListCommand() *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List configured and detected accounts with their credential source and last status",
+		Long: `List every account openusage would poll: accounts from settings.json,
+auto-detected ones, and accounts created from the credentials store.
+
+For each account the list shows its provider and whether openusage has an
+adapter for it, the auth type, where the account and its credential come from
+(env var, file, keychain, credentials store), the masked credential, and the
+last fetch status from the running telemetry daemon. Nothing is written to
+disk and no provider is polled; without a daemon the status column is empty.`,
+		Example: strings.Join([]string{
+			"  openusage accounts list",
+			"  openusage accounts list --json",
+		}, "\n"),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("accounts: loading config: %w", err)
+			}
+			detected := cfg.AutoDetectedAccounts
+			if cfg.AutoDetect {
+				detected = detect.AutoDetectWith(cfg.Detect).Accounts
+			}
+			creds, err := config.LoadCredentials()
+			if err != nil {
+				return fmt.Errorf("accounts: loading credentials: %w", err)
+			}
+			// Same merge and credential pass as the daemon, minus its
+			// persisting of the auto-detected set.
+			result := detect.Result{Accounts: core.MergeAccounts(cfg.Accounts, detected)}
+			detect.ApplyCredentials(&result)
+
+			ctx, cancel := context.WithTimeout(context.Background(), quickDaemonTimeout)
+			defer cancel()
+			snaps, _, daemonErr := export.Collect(ctx, export.SourceDaemon)
+
+			registered := make(map[string]bool)
+			for _, p := range providers.AllProviders() {
+				registered[p.ID()] = true
+			}
+			rows := buildAccountRows(result.Accounts, cfg.Accounts, detected, creds.Keys, registered, snaps)
+			if jsonOutput {
+				return writeAccountsJSON(os.Stdout, rows)
+			}
+			return writeAccountsTable(os.Stdout, rows, daemonErr == nil)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Emit machine-readable JSON instead of a table")
+	return cmd
+}
+
+// buildAccountRows describes each resolved account. manual and detected are
+// the inputs of the merge, used to tell where an account came from; stored is
+// the credentials store, used to attribute tokens it filled in.
+func buildAccountRows(
+	accounts, manual, detected []core.AccountConfig,
+	stored map[string]string,
+	registered map[string]bool,
+	snaps []core.UsageSnapshot,
+) []accountRow {
+	manualIDs := make(map[string]bool, len(manual))
+	for _, a := range manual {
+		manualIDs[a.ID] = true
+	}
+	detectedIDs := make(map[string]bool, len(detected))
+	for _, a := range detected {
+		detectedIDs[a.ID] = true
+	}
+	snapByAccount := make(map[string]core.UsageSnapshot, len(snaps))
+	for _, s := range snaps {
+		snapByAccount[s.AccountID] = s
+	}
+
+	rows := make([]accountRow, 0, len(accounts))
+	for _, a := range accounts {
+		row := accountRow{
+			AccountID:          a.ID,
+			Provider:           a.Provider,
+			ProviderRegistered: registered[a.Provider],
+			Auth:               a.Auth,
+			CredentialSource:   accountCredentialSource(a, stored),
+		}
+		switch {
+		case manualIDs[a.ID]:
+			row.Origin = accountOriginConfig
+		case detectedIDs[a.ID]:
+			row.Origin = accountOriginDetected
+		default:
+			row.Origin = accountOriginCredentials
+		}
+		if key := a.ResolveAPIKey(); key != "" {
+			row.Credential = detect.MaskKey(key)
+		}
+		if s, ok := snapByAccount[a.ID]; ok {
+			row.Status = string(s.Status)
+			row.Message = strings.TrimSpace(s.Message)
+			if !s.Timestamp.IsZero() {
+				ts := s.Timestamp
+				row.FetchedAt = &ts
+			}
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Provider != rows[j].Provider {
+			return rows[i].Provider < rows[j].Provider
+		}
+		return rows[i].AccountID < rows[j].AccountID
+	})
+	return rows
+}
+
+// accountCredentialSource names where the account's secret comes from, or ""
+// for accounts that need none (local tools read their own files).
+func accountCredentialSource(a core.AccountConfig, stored map[string]string) string {
+	if v := a.Hint("credential_source", ""); v != "" {
+		return v
+	}
+	if a.Token != "" && stored[a.ID] == a.Token {
+		return "credentials_store"
+	}
+	if a.APIKeyEnv != "" {
+		if os.Getenv(a.APIKeyEnv) != "" {
+			return "env:" + a.APIKeyEnv
+		}
+		if a.Token == "" {
+			return "env:" + a.APIKeyEnv + " (unset)"
+		}
+	}
+	if a.Token != "" {
+		return "detected"
+	}
+	return ""
+}
+
+func writeAccountsJSON(out io.Writer, rows []accountRow) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rows)
+}
+
+func writeAccountsTable(out io.Writer, rows []accountRow, haveStatus bool) error {
+	if len(rows) == 0 {
+		fmt.Fprintln(out, "No accounts configured or detected. Run `openusage detect` to see what was checked.")
+		return nil
+	}
+	dash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACCOUNT\tPROVIDER\tMAPPED\tAUTH\tORIGIN\tCREDENTIAL\tSOURCE\tSTATUS")
+	for _, r := range rows {
+		mapped := "yes"
+		if !r.ProviderRegistered {
+			mapped = "no"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.AccountID, r.Provider, mapped, dash(r.Auth), r.Origin,
+			dash(r.Credential), dash(r.CredentialSource), dash(r.Status))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if !haveStatus {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Last fetch status unavailable: the telemetry daemon is not running.")
+	}
+	return nil
+}
+
+// providerOptionRow is one line of `openusage accounts options`.
+type providerOptionRow struct {
+	Provider    string   `json:"provider"`
+	Key         string   `json:"key"`
+	Type        string   `json:"type"`
+	Default     string   `json:"default,omitempty"`
+	Values      []string `json:"values,omitempty"`
+	Description string   `json:"description"`
+}
+
+func newAccountsOptionsCommand() *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "options [provider]",
+		Short: "List the provider_options each provider accepts and check configured accounts against them",
+		Long: `List the keys each provider accepts in an account's provider_options
+table, with their type, default and meaning, then check the accounts in
+settings.json against them.
+
+Accounts with unknown keys or mistyped values are not polled; their tiles
+show the problem instead. The command exits non-zero when any configured
+account has invalid options.`,
+		Example: strings.Join([]string{
+			"  openusage accounts options",
+			"  openusage accounts options openrouter",
+			"  openusage accounts options --json",
+		}, "\n"),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter := ""
+			if len(args) == 1 {
+				filter = strings.TrimSpace(args[0])
+			}
+			all := providers.AllProviders()
+			rows, err := buildProviderOptionRows(all, filter)
+			if err != nil {
+				return err
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("accounts: loading config: %w", err)
+			}
+			problems := checkAccountOptions(cfg.Accounts, all, filter)
+
+			if jsonOutput {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(rows); err != nil {
+					return err
+				}
+			} else if err := writeProviderOptionsTable(os.Stdout, rows); err != nil {
+				return err
+			}
+			if len(problems) == 0 {
+				return nil
+			}
+			out := cmd.ErrOrStderr()
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "Invalid provider_options:")
+			for _, p := range problems {
+				fmt.Fprintln(out, "  "+p)
+			}
+			return fmt.Errorf("accounts: %d account(s) have invalid provider_options", len(problems))
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Emit machine-readable JSON instead of a table")
+	return cmd
+}
+
+// buildProviderOptionRows lists the options declared by provs, or only by
+// the provider with ID filter when it is set.
+func buildProviderOptionRows(provs []core.UsageProvider, filter string) ([]providerOptionRow, error) {
+	var rows []providerOptionRow
+	found := filter == ""
+	for _, p := range provs {
+		if filter != "" && p.ID() != filter {
+			continue
+		}
+		found = true
+		for _, opt := range p.Spec().Options {
+			rows = append(rows, providerOptionRow{
+				Provider:    p.ID(),
+				Key:         opt.Key,
+				Type:        string(opt.Type),
+				Default:     opt.Default,
+				Values:      opt.Values,
+				Description: opt.Description,
+			})
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("accounts: unknown provider %q", filter)
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Provider < rows[j].Provider })
+	return rows, nil
+}
+
+// checkAccountOptions validates each account's provider_options and
+// returns one line per account that fails.
+func checkAccountOptions(accounts []core.AccountConfig, provs []core.UsageProvider, filter string) []string {
+	specs := make(map[string][]core.ProviderOption, len(provs))
+	for _, p := range provs {
+		specs[p.ID()] = p.Spec().Options
+	}
+	var problems []string
+	for _, acct := range accounts {
+		if filter != "" && acct.Provider != filter {
+			continue
+		}
+		declared, ok := specs[acct.Provider]
+		if !ok {
+			continue
+		}
+		if err := core.ValidateProviderOptions(acct.Provider, declared, acct.ProviderOptions); err != nil {
+			problems = append(problems, acct.ID+": "+err.Error())
+		}
+	}
+	return problems
+}
+
+func writeProviderOptionsTable(out io.Writer, rows []providerOptionRow) error {
+	if len(rows) == 0 {
+		fmt.Fprintln(out, "No provider options declared.")
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tOPTION\tTYPE\tDEFAULT\tDESCRIPTION")
+	for _, r := range rows {
+		typ := r.Type
+		if len(r.Values) > 0 {
+			typ = strings.Join(r.Values, "|")
+		}
+		def := r.Default
+		if def == "" {
+			def = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Provider, r.Key, typ, def, r.Description)
+	}
+	return w.Flush()
+}
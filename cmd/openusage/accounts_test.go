@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers"
+)
+
+func TestBuildAccountRows(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-openai-from-env-1234")
+	t.Setenv("GROQ_API_KEY", "")
+
+	manual := []core.AccountConfig{
+		{ID: "openai", Provider: "openai", Auth: "api_key", APIKeyEnv: "OPENAI_API_KEY"},
+		{ID: "groq", Provider: "groq", Auth: "api_key", APIKeyEnv: "GROQ_API_KEY"},
+	}
+	detected := []core.AccountConfig{
+		{ID: "cursor-ide", Provider: "cursor", Auth: "local",
+			RuntimeHints: map[string]string{"credential_source": "file:state.vscdb"}},
+	}
+	// What the merge plus credential pass hands over: the manual and detected
+	// accounts, plus one created from the credentials store.
+	resolved := []core.AccountConfig{
+		manual[0],
+		manual[1],
+		detected[0],
+		{ID: "xai", Provider: "xai", Auth: "api_key", Token: "xai-stored-key-5678"},
+		{ID: "mystery", Provider: "not-a-provider", Auth: "api_key", Token: "tok-from-somewhere"},
+	}
+	stored := map[string]string{"xai": "xai-stored-key-5678"}
+	registered := map[string]bool{"openai": true, "groq": true, "cursor": true, "xai": true}
+	fetched := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	snaps := []core.UsageSnapshot{
+		{AccountID: "openai", Status: core.StatusOK, Timestamp: fetched},
+		{AccountID: "groq", Status: core.StatusAuth, Message: " missing key \n"},
+	}
+
+	rows := buildAccountRows(resolved, manual, detected, stored, registered, snaps)
+	byID := make(map[string]accountRow, len(rows))
+	for _, r := range rows {
+		byID[r.AccountID] = r
+	}
+
+	tests := []struct {
+		id         string
+		origin     string
+		source     string
+		status     string
+		registered bool
+	}{
+		{"openai", accountOriginConfig, "env:OPENAI_API_KEY", string(core.StatusOK), true},
+		{"groq", accountOriginConfig, "env:GROQ_API_KEY (unset)", string(core.StatusAuth), true},
+		{"cursor-ide", accountOriginDetected, "file:state.vscdb", "", true},
+		{"xai", accountOriginCredentials, "credentials_store", "", true},
+		{"mystery", accountOriginCredentials, "detected", "", false},
+	}
+	for _, tt := range tests {
+		r, ok := byID[tt.id]
+		if !ok {
+			t.Errorf("%s: missing row", tt.id)
+			continue
+		}
+		if r.Origin != tt.origin || r.CredentialSource != tt.source || r.Status != tt.status || r.ProviderRegistered != tt.registered {
+			t.Errorf("%s: got origin=%q source=%q status=%q registered=%v, want %q %q %q %v",
+				tt.id, r.Origin, r.CredentialSource, r.Status, r.ProviderRegistered,
+				tt.origin, tt.source, tt.status, tt.registered)
+		}
+	}
+
+	if got := byID["openai"]; got.FetchedAt == nil || !got.FetchedAt.Equal(fetched) {
+		t.Errorf("openai fetched_at = %v, want %v", got.FetchedAt, fetched)
+	}
+	if got := byID["groq"].Message; got != "missing key" {
+		t.Errorf("groq message = %q", got)
+	}
+	if got := byID["xai"].Credential; got == "" || strings.Contains(got, "stored-key") {
+		t.Errorf("xai credential should be masked, got %q", got)
+	}
+	if rows[0].Provider != "cursor" || rows[len(rows)-1].Provider != "xai" {
+		t.Errorf("rows not sorted by provider: first=%s last=%s", rows[0].Provider, rows[len(rows)-1].Provider)
+	}
+}
+
+func TestWriteAccountsTable_NoDaemon(t *testing.T) {
+	var out bytes.Buffer
+	rows := []accountRow{{AccountID: "openai", Provider: "openai", ProviderRegistered: true, Origin: accountOriginConfig}}
+	if err := writeAccountsTable(&out, rows, false); err != nil {
+		t.Fatal(err)
+	}
+	text := out.String()
+	for _, want := range []string{"ACCOUNT", "MAPPED", "openai", "daemon is not running"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("table missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestProviderOptionRowsAndAccountCheck(t *testing.T) {
+	provs := providers.AllProviders()
+
+	rows, err := buildProviderOptionRows(provs, "openrouter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) == 0 || rows[0].Key != "include_byok" || rows[0].Type != "bool" {
+		t.Fatalf("openrouter options = %+v, want include_byok", rows)
+	}
+	if _, err := buildProviderOptionRows(provs, "nope"); err == nil {
+		t.Fatal("expected an unknown provider to be rejected")
+	}
+
+	accounts := []core.AccountConfig{
+		{ID: "or-ok", Provider: "openrouter", ProviderOptions: map[string]any{"include_byok": false}},
+		{ID: "or-bad", Provider: "openrouter", ProviderOptions: map[string]any{"include_byok": "no"}},
+		{ID: "mystery", Provider: "not-a-provider", ProviderOptions: map[string]any{"x": 1.0}},
+	}
+	problems := checkAccountOptions(accounts, provs, "")
+	if len(problems) != 1 || !strings.HasPrefix(problems[0], "or-bad: provider_options.include_byok") {
+		t.Fatalf("problems = %q, want only or-bad", problems)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/audit"
+	"github.com/janekbaraniewski/openusage/internal/telemetry"
+)
+
+// auditFilter selects the entries `openusage audit` prints.
+type auditFilter struct {
+	Since   time.Time
+	Account string
+	Kind    audit.Kind
+}
+
+func newAuditCommand() *cobra.Command {
+	var (
+		since      time.Duration
+		account    string
+		kind       string
+		jsonOutput bool
+	)
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show which credentials openusage read and which endpoints it called",
+		Long: `Show the local access audit log: every credential openusage read (an
+environment variable, a keychain item, a token or cookie file) and every
+endpoint it called, with the account it was for.
+
+The log names where a credential came from, never its value. Repeated uses
+are folded into one line per hour with a count. The log is kept in the state
+directory (see ` + "`openusage paths`" + `); set ` + audit.EnvPath + ` to move it, or to
+"off" to stop recording.`,
+		Example: strings.Join([]string{
+			"  openusage audit",
+			"  openusage audit --since 168h --kind credential",
+			"  openusage audit --account openai --json",
+		}, "\n"),
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			switch audit.Kind(kind) {
+			case "", audit.KindCredential, audit.KindEndpoint:
+			default:
+				return fmt.Errorf("audit: --kind must be %s or %s", audit.KindCredential, audit.KindEndpoint)
+			}
+			path, err := telemetry.DefaultAuditLogPath()
+			if err != nil {
+				return fmt.Errorf("audit: resolving log path: %w", err)
+			}
+			if path == "" {
+				return fmt.Errorf("audit: the audit log is off (%s=off)", audit.EnvPath)
+			}
+			entries, err := audit.Read(path)
+			if err != nil {
+				return err
+			}
+			filter := auditFilter{Account: strings.TrimSpace(account), Kind: audit.Kind(kind)}
+			if since > 0 {
+				filter.Since = time.Now().Add(-since)
+			}
+			entries = filterAuditEntries(entries, filter)
+			if jsonOutput {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(entries)
+			}
+			if len(entries) == 0 {
+				fmt.Fprintf(os.Stdout, "No audit entries in %s\n", path)
+				return nil
+			}
+			return writeAuditTable(os.Stdout, entries)
+		},
+	}
+	cmd.Flags().DurationVar(&since, "since", 24*time.Hour, "show entries from this far back (0 shows everything)")
+	cmd.Flags().StringVar(&account, "account", "", "show only this account's entries")
+	cmd.Flags().StringVar(&kind, "kind", "", "show only credential or endpoint entries")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Emit machine-readable JSON instead of a table")
+	return cmd
+}
+
+func filterAuditEntries(entries []audit.Entry, filter auditFilter) []audit.Entry {
+	out := make([]audit.Entry, 0, len(entries))
+	for _, e := range entries {
+		if !filter.Since.IsZero() && e.Time.Before(filter.Since) {
+			continue
+		}
+		if filter.Account != "" && e.Account != filter.Account {
+			continue
+		}
+		if filter.Kind != "" && e.Kind != filter.Kind {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func writeAuditTable(out io.Writer, entries []audit.Entry) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tKIND\tACCOUNT\tTARGET\tCOUNT")
+	for _, e := range entries {
+		count := fmt.Sprintf("%d", e.Count)
+		if e.Errors > 0 {
+			count += fmt.Sprintf(" (%d failed)", e.Errors)
+		}
+		account := e.Account
+		if account == "" {
+			account = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.Time.Local().Format("2006-01-02 15:04:05"), e.Kind, account, e.Target, count)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/audit"
+)
+
+func TestFilterAuditEntries(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	entries := []audit.Entry{
+		{Time: now.Add(-48 * time.Hour), Kind: audit.KindCredential, Account: "openai", Target: "env:OPENAI_API_KEY", Count: 1},
+		{Time: now.Add(-time.Hour), Kind: audit.KindEndpoint, Account: "openai", Target: "GET api.openai.com/v1/models", Count: 3},
+		{Time: now.Add(-time.Hour), Kind: audit.KindCredential, Account: "claude-code", Target: "keychain:Claude Safe Storage", Count: 1},
+	}
+	tests := []struct {
+		name   string
+		filter auditFilter
+		want   int
+	}{
+		{name: "everything", want: 3},
+		{name: "since", filter: auditFilter{Since: now.Add(-24 * time.Hour)}, want: 2},
+		{name: "account", filter: auditFilter{Account: "openai"}, want: 2},
+		{name: "kind", filter: auditFilter{Kind: audit.KindCredential}, want: 2},
+		{name: "combined", filter: auditFilter{Since: now.Add(-24 * time.Hour), Account: "openai", Kind: audit.KindCredential}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filterAuditEntries(entries, tt.filter); len(got) != tt.want {
+				t.Fatalf("filterAuditEntries() = %d entries, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteAuditTable(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeAuditTable(&buf, []audit.Entry{
+		{Time: time.Now(), Kind: audit.KindEndpoint, Account: "openai", Target: "GET api.openai.com/v1/models", Count: 4, Errors: 1},
+	})
+	if err != nil {
+		t.Fatalf("writeAuditTable() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"TARGET", "GET api.openai.com/v1/models", "4 (1 failed)"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("table missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/bugreport"
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/daemon"
+	"github.com/janekbaraniewski/openusage/internal/providers"
+)
+
+func newBugreportCommand() *cobra.Command {
+	var (
+		output     string
+		socketPath string
+		noDaemon   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "bugreport <account>",
+		Short: "Record one traced fetch of an account for a bug report",
+		Long: `Fetch one account once with full HTTP tracing and bundle what a maintainer
+needs to reproduce a parsing bug into a .tar.gz: every request and response
+with headers and bodies, the snapshot the provider built from them, the
+account's config entry, the log output of the fetch, and the daemon's log
+lines for the account when it is running.
+
+Credentials are redacted before anything is written: the account's API key
+and token wherever they appear, Authorization/Cookie/API-key headers,
+credential query parameters, credential-named JSON fields and key-shaped
+strings. Email addresses are masked and your home directory is shown as ~.
+Usage numbers and dollar amounts are kept. Review the files before
+attaching them to an issue.
+
+Calls a provider makes through its own HTTP transport are not traced.`,
+		Example: strings.Join([]string{
+			"  openusage bugreport openrouter",
+			"  openusage bugreport claude-code --output /tmp/report.tar.gz",
+		}, "\n"),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeAccountArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("bugreport: loading config: %w", err)
+			}
+			acct, ok := findAccount(daemon.ResolveAccounts(&cfg), args[0])
+			if !ok {
+				return fmt.Errorf("bugreport: no account %q (see `openusage accounts list`)", args[0])
+			}
+			var provider core.UsageProvider
+			for _, p := range providers.AllProviders() {
+				if p.ID() == acct.Provider {
+					provider = p
+					break
+				}
+			}
+			if provider == nil {
+				return fmt.Errorf("bugreport: account %q uses unknown provider %q", acct.ID, acct.Provider)
+			}
+
+			opts := bugreport.Options{Account: acct, Provider: provider, ReadOnly: cfg.ReadOnlyEnabled()}
+			if !noDaemon {
+				client := daemon.NewClient(strings.TrimSpace(socketPath))
+				opts.DaemonLog = client.Logs
+			}
+			fmt.Fprintf(os.Stderr, "Fetching %s (%s) with tracing…\n", acct.ID, acct.Provider)
+			// Only now, so account detection doesn't log to the terminal;
+			// the fetch's trace lines go into the report.
+			core.EnableDebug()
+			report := bugreport.Collect(cmd.Context(), opts)
+
+			name := "openusage-bugreport-" + sanitizeFileName(acct.ID) + "-" + report.CreatedAt.Format("20060102-150405")
+			if output == "" {
+				output = name + ".tar.gz"
+			}
+			f, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+			if err != nil {
+				return fmt.Errorf("bugreport: %w", err)
+			}
+			files, err := report.WriteTarGz(f, name, bugreport.NewRedactor(bugreport.Secrets(acct)...))
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				_ = os.Remove(output)
+				return err
+			}
+			return writeBugreportSummary(os.Stdout, output, report, files)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "tarball path (default ./openusage-bugreport-<account>-<time>.tar.gz)")
+	cmd.Flags().StringVar(&socketPath, "socket-path", daemon.ResolveSocketPath(), "path to telemetry daemon unix socket")
+	cmd.Flags().BoolVar(&noDaemon, "no-daemon-log", false, "don't include the daemon's log lines")
+	return cmd
+}
+
+func findAccount(accounts []core.AccountConfig, id string) (core.AccountConfig, bool) {
+	id = strings.TrimSpace(id)
+	for _, acct := range accounts {
+		if strings.EqualFold(acct.ID, id) {
+			return acct, true
+		}
+	}
+	return core.AccountConfig{}, false
+}
+
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
+
+func writeBugreportSummary(w io.Writer, path string, report *bugreport.Report, files []bugreport.File) error {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	status := string(report.Snapshot.Status)
+	if report.FetchErr != nil {
+		status = "fetch failed"
+	}
+	fmt.Fprintf(w, "Wrote %s\n", path)
+	fmt.Fprintf(w, "Fetch: %s in %s, %d HTTP request(s)\n\n", status, report.Duration.Round(time.Millisecond), len(report.Exchanges))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FILE\tSIZE\tCONTENTS")
+	for _, f := range files {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", f.Name, formatBytes(f.Size), f.Description)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if report.DaemonErr != nil {
+		fmt.Fprintln(w, "\nDaemon log not included: the daemon is not reachable.")
+	}
+	fmt.Fprintln(w, "\nCredentials are redacted and emails masked. Check the files before sharing:")
+	_, err := fmt.Fprintf(w, "  tar -xzf %s\n", path)
+	return err
+}
+
+func formatBytes(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
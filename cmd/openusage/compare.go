@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/export"
+	"github.com/janekbaraniewski/openusage/internal/report"
+)
+
+func newCompareCommand() *cobra.Command {
+	var (
+		sourceFlag string
+		asJSON     bool
+		timeout    time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "compare <account> <account>",
+		Short: "Compare two accounts side by side",
+		Long: `Show two accounts side by side with their metrics aligned, whatever keys each
+provider reports them under: cost today and over 7 days, tokens today and over
+7 days, requests over 7 days, $/1k output tokens, cache hits, and the
+remaining share of every quota either account has. The DIFF column gives the
+second account relative to the first.
+
+Use it to weigh a personal against a work account on the same provider, or
+OpenRouter against the direct API for the same models.`,
+		Example: strings.Join([]string{
+			"  openusage compare anthropic-personal anthropic-work",
+			"  openusage compare openrouter openai --json",
+		}, "\n"),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeAccountArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			snaps, _, err := export.Collect(ctx, export.Source(strings.ToLower(strings.TrimSpace(sourceFlag))))
+			if err != nil {
+				return fmt.Errorf("compare: collecting snapshots: %w", err)
+			}
+			return writeCompare(os.Stdout, snaps, args[0], args[1], asJSON)
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVar(&sourceFlag, "source", string(export.SourceAuto), "snapshot source: auto (default), direct, or daemon")
+	fl.BoolVar(&asJSON, "json", false, "emit JSON instead of text")
+	fl.DurationVar(&timeout, "timeout", 30*time.Second, "timeout for snapshot collection")
+	return cmd
+}
+
+func writeCompare(w io.Writer, snaps []core.UsageSnapshot, first, second string, asJSON bool) error {
+	var pair [2]core.UsageSnapshot
+	for i, id := range []string{first, second} {
+		snap, ok := report.FindSnapshot(snaps, id)
+		if !ok {
+			return fmt.Errorf("compare: no account %q (available: %s)", id, availableAccounts(snaps))
+		}
+		pair[i] = snap
+	}
+	c := report.BuildComparison(pair[0], pair[1])
+	if asJSON {
+		return c.WriteJSON(w)
+	}
+	return c.WriteText(w)
+}
+
+func availableAccounts(snaps []core.UsageSnapshot) string {
+	if len(snaps) == 0 {
+		return "none"
+	}
+	ids := make([]string, 0, len(snaps))
+	for _, s := range snaps {
+		ids = append(ids, s.AccountID)
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ", ")
+}
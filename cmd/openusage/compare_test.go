@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestWriteCompare(t *testing.T) {
+	snaps := []core.UsageSnapshot{
+		{AccountID: "openrouter", ProviderID: "openrouter", Status: core.StatusOK},
+		{AccountID: "openai", ProviderID: "openai", Status: core.StatusOK},
+	}
+	var out bytes.Buffer
+	if err := writeCompare(&out, snaps, "OpenRouter", "openai", false); err != nil {
+		t.Fatalf("writeCompare: %v", err)
+	}
+	if !strings.Contains(out.String(), "METRIC") || !strings.Contains(out.String(), "openrouter") {
+		t.Errorf("output = %q", out.String())
+	}
+
+	err := writeCompare(&out, snaps, "openrouter", "anthropic", false)
+	if err == nil || !strings.Contains(err.Error(), `no account "anthropic" (available: openai, openrouter)`) {
+		t.Errorf("missing account error = %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/dashboardapp"
+	"github.com/janekbaraniewski/openusage/internal/export"
+	"github.com/janekbaraniewski/openusage/internal/providers"
+)
+
+// Cobra generates the shell scripts themselves (`openusage completion
+// bash|zsh|fish|powershell`). The functions here supply the values only this
+// binary knows — account IDs, provider IDs, metric keys — from settings.json
+// and the dashboard's snapshot cache, so pressing Tab never polls a provider.
+
+// registerCompletions wires completion for flags shared across commands,
+// matched by name over the whole command tree.
+func registerCompletions(root *cobra.Command) {
+	byFlag := map[string]cobra.CompletionFunc{
+		"account":  completeAccounts,
+		"provider": completeProviders,
+		"source": cobra.FixedCompletions([]string{
+			string(export.SourceAuto), string(export.SourceDirect), string(export.SourceDaemon),
+		}, cobra.ShellCompDirectiveNoFileComp),
+		"segment": completeTmuxVariables,
+	}
+	var walk func(*cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for name, fn := range byFlag {
+			if cmd.LocalNonPersistentFlags().Lookup(name) != nil {
+				_ = cmd.RegisterFlagCompletionFunc(name, fn)
+			}
+		}
+		for _, sub := range cmd.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+}
+
+// completeAccountArgs completes up to n positional account IDs, skipping
+// those already given.
+func completeAccountArgs(n int) cobra.CompletionFunc {
+	return func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= n {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var out []string
+		for _, id := range accountIDCandidates(loadCompletionConfig(), cachedSnapshots()) {
+			if !slices.Contains(args, id) {
+				out = append(out, id)
+			}
+		}
+		return out, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func completeAccounts(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return accountIDCandidates(loadCompletionConfig(), cachedSnapshots()), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeProviders(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	var ids []string
+	for _, p := range providers.AllProviders() {
+		ids = append(ids, p.ID())
+	}
+	sort.Strings(ids)
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTmuxVariables offers the template variables a tmux segment can
+// name: built-in segments, semantic aliases, and every metric key seen in
+// the snapshot cache.
+func completeTmuxVariables(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	vars := collectKnownVariables()
+	for _, key := range metricKeyCandidates(cachedSnapshots()) {
+		if !slices.Contains(vars, key) {
+			vars = append(vars, key)
+		}
+	}
+	sort.Strings(vars)
+	return vars, cobra.ShellCompDirectiveNoFileComp
+}
+
+// accountIDCandidates lists configured, auto-detected and cached account
+// IDs, sorted and de-duplicated.
+func accountIDCandidates(cfg config.Config, cached map[string]core.UsageSnapshot) []string {
+	seen := map[string]bool{}
+	var ids []string
+	add := func(id string) {
+		id = strings.TrimSpace(id)
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, acct := range cfg.Accounts {
+		add(acct.ID)
+	}
+	for _, acct := range cfg.AutoDetectedAccounts {
+		add(acct.ID)
+	}
+	for id, snap := range cached {
+		add(core.FirstNonEmpty(snap.AccountID, id))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// metricKeyCandidates lists the metric keys of the cached snapshots, sorted.
+func metricKeyCandidates(cached map[string]core.UsageSnapshot) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, snap := range cached {
+		for key := range snap.Metrics {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func loadCompletionConfig() config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		return config.Config{}
+	}
+	return cfg
+}
+
+func cachedSnapshots() map[string]core.UsageSnapshot {
+	return dashboardapp.NewSnapshotCache(dashboardapp.SnapshotCachePath()).Peek()
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestAccountIDCandidates(t *testing.T) {
+	cfg := config.Config{
+		Accounts:             []core.AccountConfig{{ID: "openai-work"}, {ID: "anthropic"}},
+		AutoDetectedAccounts: []core.AccountConfig{{ID: "claude-code"}, {ID: "anthropic"}},
+	}
+	cached := map[string]core.UsageSnapshot{
+		"cursor":  {AccountID: "cursor"},
+		"legacy":  {},
+		"openai2": {AccountID: "openai-work"},
+	}
+	got := accountIDCandidates(cfg, cached)
+	want := []string{"anthropic", "claude-code", "cursor", "legacy", "openai-work"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("accountIDCandidates() = %v, want %v", got, want)
+	}
+}
+
+func TestMetricKeyCandidates(t *testing.T) {
+	cached := map[string]core.UsageSnapshot{
+		"a": {Metrics: map[string]core.Metric{"rpm": {}, "today_cost": {}}},
+		"b": {Metrics: map[string]core.Metric{"rpm": {}, "credits": {}}},
+	}
+	got := metricKeyCandidates(cached)
+	if want := []string{"credits", "rpm", "today_cost"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("metricKeyCandidates() = %v, want %v", got, want)
+	}
+}
+
+func TestRegisterCompletions(t *testing.T) {
+	root := &cobra.Command{Use: "openusage"}
+	sub := &cobra.Command{Use: "report", Run: func(*cobra.Command, []string) {}}
+	sub.Flags().String("source", "", "")
+	root.AddCommand(sub)
+	registerCompletions(root)
+
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetArgs([]string{cobra.ShellCompRequestCmd, "report", "--source", ""})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	for _, want := range []string{"auto\n", "direct\n", "daemon\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("--source completions missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestCompleteAccountArgs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	complete := completeAccountArgs(2)
+	if got, _ := complete(nil, []string{"a", "b"}, ""); got != nil {
+		t.Errorf("completions after both accounts = %v, want none", got)
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -20,19 +21,38 @@ import (
 	"github.com/janekbaraniewski/openusage/internal/version"
 )
 
-func runDashboard(cfg config.Config) {
+// runDashboard runs the TUI against the telemetry daemon. With offline set it
+// shows only the snapshots cached by the last online session, whatever their
+// age, and never touches the daemon or the network.
+func runDashboard(cfg config.Config, focusAccount string, offline bool) {
 	verbose := core.DebugEnabled()
 
 	if err := tui.LoadThemes(config.ConfigDir()); err != nil && verbose {
 		log.Printf("theme load: %v", err)
 	}
 	tui.SetThemeByName(cfg.Theme)
+	installLocale(cfg)
 
 	cachedAccounts := core.MergeAccounts(cfg.Accounts, cfg.AutoDetectedAccounts)
 	interval := time.Duration(cfg.UI.RefreshIntervalSeconds) * time.Second
 
 	timeWindow := core.ParseTimeWindow(cfg.Data.TimeWindow)
 
+	snapshotCache := dashboardapp.NewSnapshotCache(dashboardapp.SnapshotCachePath())
+	var offlineSnaps map[string]core.UsageSnapshot
+	var offlineAsOf time.Time
+	if offline {
+		var window core.TimeWindow
+		offlineSnaps, window, offlineAsOf = snapshotCache.Latest()
+		if len(offlineSnaps) == 0 {
+			fmt.Fprintln(os.Stderr, "offline: no cached snapshots yet — run openusage once while online to populate the cache")
+			os.Exit(1)
+		}
+		if window != "" {
+			timeWindow = window
+		}
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -45,6 +65,25 @@ func runDashboard(cfg config.Config) {
 		timeWindow,
 	)
 	model.SetServices(dashboardapp.NewService(ctx))
+	model.SetReadOnly(cfg.ReadOnlyEnabled())
+	model.SetNoWrite(config.NoWriteFromEnv())
+	model.SetEnergyConfig(cfg.Energy)
+	model.SetFocusAccount(focusAccount)
+	if verbose {
+		// The TUI owns stderr; keep the log in memory for the L pane.
+		log.SetOutput(core.DebugLog)
+		model.SetLogSource(core.DebugLog.Lines)
+	}
+
+	if offline {
+		model.SetOffline(offlineAsOf)
+		model.SetCachedSnapshots(offlineSnaps)
+		runProgram(tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithFPS(30)), cancel)
+		return
+	}
+
+	model.SetRefreshInterval(interval)
+	model.SetCachedSnapshots(snapshotCache.Load(timeWindow, time.Now()))
 
 	socketPath := daemon.ResolveSocketPath()
 
@@ -56,7 +95,7 @@ func runDashboard(cfg config.Config) {
 	viewRuntime.SetTimeWindow(timeWindow)
 
 	var program *tea.Program
-	dispatcher := &snapshotDispatcher{}
+	dispatcher := &snapshotDispatcher{cache: snapshotCache}
 
 	model.SetOnAddAccount(func(acct core.AccountConfig) {
 		if strings.TrimSpace(acct.ID) == "" || strings.TrimSpace(acct.Provider) == "" {
@@ -117,6 +156,18 @@ func runDashboard(cfg config.Config) {
 		dispatcher.refresh(ctx, viewRuntime, window)
 	})
 
+	model.SetOnRefreshAccounts(func(accountIDs []string) {
+		dispatcher.pollNow(ctx, viewRuntime, accountIDs)
+	})
+
+	model.SetOnSetSpendLimit(func(accountID string, limitUSD *float64) error {
+		return viewRuntime.SetSpendLimit(ctx, accountID, limitUSD)
+	})
+
+	model.SetOnPreviewSpendLimit(func(accountID string) (string, error) {
+		return viewRuntime.PreviewSpendLimit(ctx, accountID)
+	})
+
 	model.SetOnTimeWindowChange(func(tw core.TimeWindow) {
 		viewRuntime.SetTimeWindow(tw)
 	})
@@ -132,13 +183,17 @@ func runDashboard(cfg config.Config) {
 	program = tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithFPS(30))
 	dispatcher.bind(program)
 
+	updateChannel := appupdate.ParseChannel(cfg.Update.Channel)
 	go func() {
 		runStartupUpdateCheck(
 			ctx,
 			strings.TrimSpace(version.Version),
 			1200*time.Millisecond,
 			verbose,
-			appupdate.Check,
+			func(ctx context.Context, opts appupdate.CheckOptions) (appupdate.Result, error) {
+				opts.Channel = updateChannel
+				return appupdate.Check(ctx, opts)
+			},
 			func(msg tui.AppUpdateMsg) {
 				if program == nil {
 					return
@@ -173,6 +228,11 @@ func runDashboard(cfg config.Config) {
 		},
 	)
 
+	runProgram(program, cancel)
+}
+
+// runProgram runs the TUI until it exits or the process is signalled.
+func runProgram(program *tea.Program, cancel context.CancelFunc) {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
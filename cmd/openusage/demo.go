@@ -0,0 +1,80 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/demo"
+	"github.com/janekbaraniewski/openusage/internal/tui"
+)
+
+func newDemoCommand() *cobra.Command {
+	opts := demo.DefaultOptions()
+	var scenario string
+
+	cmd := &cobra.Command{
+		Use:   "demo",
+		Short: "Preview the dashboard with synthetic accounts",
+		Long: `Open the dashboard on synthetic data for a handful of providers. Nothing is
+read from settings.json, no credentials or daemon are needed, and no provider
+is contacted, so it is a safe way to try the dashboard and themes, and the
+data is deterministic for screenshots.
+
+Scenarios:
+  healthy      curated fixtures, usage climbing over a few frames (default)
+  near-limit   every limited metric at 91–100% of its limit
+  auth-errors  two of every three accounts fail authentication
+  huge-fleet   each provider cloned into a fleet of accounts
+  stress       --accounts accounts with --metrics extra metrics each, --churn
+               of them changing every frame, for checking rendering and
+               scrolling at scale`,
+		Example: strings.Join([]string{
+			"  openusage demo",
+			"  openusage demo --scenario near-limit --theme Nord",
+			"  openusage demo --scenario huge-fleet --interval 2s --loop",
+			"  openusage demo --scenario stress --accounts 500 --metrics 100 --churn 0.5 --interval 1s",
+		}, "\n"),
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			s, err := demo.ParseScenario(scenario)
+			if err != nil {
+				return err
+			}
+			opts.Scenario = s
+			if err := tui.LoadThemes(config.ConfigDir()); err != nil && core.DebugEnabled() {
+				log.Printf("theme load: %v", err)
+			}
+			return demo.Run(opts)
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVar(&scenario, "scenario", string(demo.ScenarioHealthy), "fleet state: healthy, near-limit, auth-errors, huge-fleet, or stress")
+	fl.DurationVar(&opts.Interval, "interval", opts.Interval, "how often playback advances to the next frame")
+	fl.BoolVar(&opts.Loop, "loop", false, "restart playback from the first frame after the final frame")
+	fl.StringVar(&opts.Theme, "theme", "", "theme to start with (t cycles themes while running)")
+	fl.IntVar(&opts.Stress.Accounts, "accounts", opts.Stress.Accounts, "stress: number of accounts")
+	fl.IntVar(&opts.Stress.Metrics, "metrics", opts.Stress.Metrics, "stress: synthetic metrics per account")
+	fl.Float64Var(&opts.Stress.Churn, "churn", opts.Stress.Churn, "stress: share of metrics changing every frame (0-1)")
+
+	_ = cmd.RegisterFlagCompletionFunc("scenario", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		var names []string
+		for _, s := range demo.Scenarios() {
+			names = append(names, string(s))
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+	_ = cmd.RegisterFlagCompletionFunc("theme", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		_ = tui.LoadThemes(config.ConfigDir())
+		var names []string
+		for _, theme := range tui.AvailableThemes() {
+			names = append(names, theme.Name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
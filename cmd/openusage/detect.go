@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/janekbaraniewski/openusage/internal/config"
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/detect"
 	"github.com/janekbaraniewski/openusage/internal/providers"
@@ -33,7 +34,11 @@ func newDetectCommand() *cobra.Command {
 what it found, including which file, env var, or keychain entry each
 credential came from. Tokens are masked. Nothing is written to disk.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			result := detect.AutoDetect()
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("detect: loading config: %w", err)
+			}
+			result := detect.AutoDetectWith(cfg.Detect)
 			detect.ApplyCredentials(&result)
 			return printDetectReport(os.Stdout, result, showAll)
 		},
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+
+	"github.com/janekbaraniewski/openusage/internal/version"
+)
+
+func newDocsCommand() *cobra.Command {
+	var (
+		dir    string
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "docs",
+		Short: "Generate man pages or Markdown reference for every command",
+		Long: `Write one page per command, generated from the same help text the CLI
+prints, so the reference never drifts from the binary.
+
+The man format writes openusage.1, openusage-compare.1, and so on; point
+MANPATH at the parent of the directory, or copy the files into a man1
+directory, to read them with man.`,
+		Example: strings.Join([]string{
+			"  openusage docs --dir ./man/man1",
+			"  openusage docs --format markdown --dir ./docs/cli",
+		}, "\n"),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return fmt.Errorf("docs: %w", err)
+			}
+			root := cmd.Root()
+			root.DisableAutoGenTag = true
+			switch strings.ToLower(strings.TrimSpace(format)) {
+			case "man":
+				header := &doc.GenManHeader{Title: "OPENUSAGE", Section: "1", Source: "openusage " + version.Version}
+				if err := doc.GenManTree(root, header, dir); err != nil {
+					return fmt.Errorf("docs: %w", err)
+				}
+			case "markdown", "md":
+				if err := doc.GenMarkdownTree(root, dir); err != nil {
+					return fmt.Errorf("docs: %w", err)
+				}
+			default:
+				return fmt.Errorf("docs: unsupported --format %q (use man or markdown)", format)
+			}
+			fmt.Fprintf(os.Stdout, "Wrote %s pages to %s\n", format, dir)
+			return nil
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVar(&dir, "dir", "man", "directory to write the pages to")
+	fl.StringVar(&format, "format", "man", "page format: man or markdown")
+	_ = cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"man", "markdown"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.MarkFlagDirname("dir")
+	return cmd
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// resolveDuplicateAccounts asks, before the dashboard starts, what to do with
+// auto-detected accounts that duplicate configured ones, and returns the
+// config reloaded with the answers applied. Without a terminal to ask on, the
+// duplicates stay until account_dedupe settles them.
+func resolveDuplicateAccounts(cfg config.Config) config.Config {
+	dups := cfg.PendingDuplicateAccounts()
+	if len(dups) == 0 || !isStdinTerminal() {
+		return cfg
+	}
+	d := accountDeduper{out: os.Stdout, in: bufio.NewReader(os.Stdin), save: config.SaveAccountDedupeDecision}
+	if err := d.run(dups); err != nil {
+		fmt.Fprintf(os.Stderr, "accounts: %v\n", err)
+	}
+	reloaded, err := config.Load()
+	if err != nil {
+		return cfg
+	}
+	return reloaded
+}
+
+// accountDeduper prompts merge-or-skip per duplicate and records each answer.
+// Persistence is injected so tests never touch the real settings file.
+type accountDeduper struct {
+	out  io.Writer
+	in   *bufio.Reader
+	save func(duplicateID string, merge bool) error
+}
+
+func (d accountDeduper) run(dups []core.AccountDuplicate) error {
+	fmt.Fprintf(d.out, "Found %d auto-detected account(s) duplicating configured ones:\n", len(dups))
+	for _, dup := range dups {
+		fmt.Fprintf(d.out, "\n  %q duplicates %q (%s, $%s)\n", dup.Duplicate.ID, dup.Of.ID, dup.Of.Provider, dup.Of.APIKeyEnv)
+		merge, answered := d.ask(fmt.Sprintf("  Merge it into %q? [Y/n] ", dup.Of.ID))
+		if !answered {
+			return nil
+		}
+		if err := d.save(dup.Duplicate.ID, merge); err != nil {
+			return fmt.Errorf("saving decision for %s: %w", dup.Duplicate.ID, err)
+		}
+	}
+	fmt.Fprintln(d.out, "\nSaved. Set \"account_dedupe\": {\"auto\": true} in settings.json to merge duplicates without asking.")
+	return nil
+}
+
+// ask reads a yes/no answer defaulting to yes. answered is false on EOF, so
+// an aborted prompt records nothing.
+func (d accountDeduper) ask(prompt string) (yes, answered bool) {
+	fmt.Fprint(d.out, prompt)
+	line, err := d.in.ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Fprintln(d.out)
+		return false, false
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "", "y", "yes":
+		return true, true
+	default:
+		return false, true
+	}
+}
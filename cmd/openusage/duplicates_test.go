@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestAccountDeduper_Run(t *testing.T) {
+	dup := func(id, of, provider, env string) core.AccountDuplicate {
+		return core.AccountDuplicate{
+			Duplicate: core.AccountConfig{ID: id, Provider: provider, APIKeyEnv: env},
+			Of:        core.AccountConfig{ID: of, Provider: provider, APIKeyEnv: env},
+		}
+	}
+	dups := []core.AccountDuplicate{
+		dup("openai", "openai-work", "openai", "OPENAI_API_KEY"),
+		dup("groq", "groq-main", "groq", "GROQ_API_KEY"),
+		dup("xai", "xai-main", "xai", "XAI_API_KEY"),
+	}
+
+	var out bytes.Buffer
+	decisions := map[string]bool{}
+	d := accountDeduper{
+		out: &out,
+		// Enter accepts the merge default; input ends before the third prompt.
+		in: bufio.NewReader(strings.NewReader("\nn\n")),
+		save: func(id string, merge bool) error {
+			decisions[id] = merge
+			return nil
+		},
+	}
+	if err := d.run(dups); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(decisions) != 2 || !decisions["openai"] || decisions["groq"] {
+		t.Errorf("decisions = %v, want openai merged, groq kept, xai undecided", decisions)
+	}
+	text := out.String()
+	for _, want := range []string{`"openai" duplicates "openai-work" (openai, $OPENAI_API_KEY)`, `Merge it into "xai-main"? [Y/n]`} {
+		if !strings.Contains(text, want) {
+			t.Errorf("output missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "Saved.") {
+		t.Errorf("aborted prompt should not report saving:\n%s", text)
+	}
+}
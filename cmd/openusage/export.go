@@ -5,6 +5,8 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/export"
 )
 
@@ -22,6 +24,8 @@ func newExportCommand() *cobra.Command {
 		outputFlag string
 		formatFlag string
 		sourceFlag string
+		recordFlag string
+		windowFlag string
 	)
 
 	cmd := &cobra.Command{
@@ -36,18 +40,35 @@ the daemon is not running. Use --source to force a specific path.
 
 API keys and tokens are never written to the output file. The envelope
 contains schema_version, generated_at, openusage_version, source, and the
-collected snapshots.`,
+collected snapshots.
+
+--record-fixtures DIR is a developer mode for provider contributors: it polls
+providers directly and also writes each account's HTTP traffic to
+DIR/<provider>_<account>.json as a replayable test cassette. Credentials,
+emails and IDs are stripped and numbers are scaled by a random factor, but
+review the files before committing them under the provider's testdata/.
+
+--window aggregates the daemon's history over a built-in window (1d, 3d, 7d,
+30d, all) or one defined in data.custom_windows, instead of the configured
+time_window. It needs the daemon.`,
 		Example: strings.Join([]string{
 			"  openusage export --output ~/usage.json",
 			"  openusage export --output - --format json",
 			"  openusage export --output /tmp/usage.csv --format csv",
 			"  openusage export --output ~/usage.json --source direct",
+			"  openusage export --output - --record-fixtures /tmp/fixtures",
+			"  openusage export --output - --window sprint",
 		}, "\n"),
 		RunE: func(_ *cobra.Command, _ []string) error {
+			if strings.TrimSpace(windowFlag) != "" {
+				_, _ = config.Load() // registers data.custom_windows
+			}
 			opts := export.Options{
-				Output: strings.TrimSpace(outputFlag),
-				Format: export.Format(strings.ToLower(strings.TrimSpace(formatFlag))),
-				Source: export.Source(strings.ToLower(strings.TrimSpace(sourceFlag))),
+				Output:         strings.TrimSpace(outputFlag),
+				Format:         export.Format(strings.ToLower(strings.TrimSpace(formatFlag))),
+				Source:         export.Source(strings.ToLower(strings.TrimSpace(sourceFlag))),
+				RecordFixtures: strings.TrimSpace(recordFlag),
+				TimeWindow:     core.TimeWindow(strings.TrimSpace(windowFlag)),
 			}
 			return export.Run(opts)
 		},
@@ -59,7 +80,12 @@ collected snapshots.`,
 		"output format: json (default) or csv")
 	cmd.Flags().StringVar(&sourceFlag, "source", string(export.SourceAuto),
 		"collection source: auto (default), direct, or daemon")
+	cmd.Flags().StringVar(&recordFlag, "record-fixtures", "",
+		"developer mode: also write sanitized provider HTTP cassettes into this directory")
+	cmd.Flags().StringVar(&windowFlag, "window", "",
+		"aggregate over this time window (built-in or data.custom_windows id); needs the daemon")
 	_ = cmd.MarkFlagRequired("output")
+	_ = cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"json", "csv"}, cobra.ShellCompDirectiveNoFileComp))
 
 	return cmd
 }
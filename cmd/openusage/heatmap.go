@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/report"
+	"github.com/janekbaraniewski/openusage/internal/telemetry"
+)
+
+func newHeatmapCommand() *cobra.Command {
+	var (
+		metric     string
+		by         string
+		weeks      int
+		provider   string
+		byProvider bool
+		asJSON     bool
+		dbPath     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "heatmap",
+		Short: "Show a heatmap of cost, tokens, or requests by day or by hour of the week",
+		Long: `Show a GitHub-style calendar of daily cost, tokens, or requests for the
+trailing weeks (12 by default), aggregated across providers and optionally per
+provider.
+
+With --by hour, show a weekday × hour-of-day grid in local time instead, summed
+over the same weeks, with the share of usage outside working hours (Mon–Fri
+09:00–18:00). Use it to spot scheduled jobs and after-hours agents.
+
+Data comes from the telemetry daemon's persisted history: settled days from the
+daily rollup, which outlives raw-event retention, and the current day from the
+raw events. Days are UTC; weeks start on the locale's first day of the week.
+The hourly view reads raw events only, so it covers at most data.retention_days.`,
+		Example: strings.Join([]string{
+			"  openusage heatmap",
+			"  openusage heatmap --metric tokens --by-provider",
+			"  openusage heatmap --provider claude_code --json",
+			"  openusage heatmap --by hour --weeks 4 --by-provider",
+			"  openusage heatmap --by hour --metric requests",
+		}, "\n"),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			m := report.HeatmapMetric(strings.ToLower(strings.TrimSpace(metric)))
+			if m != report.HeatmapCost && m != report.HeatmapTokens && m != report.HeatmapRequests {
+				return fmt.Errorf("unsupported --metric %q (use cost, tokens, or requests)", metric)
+			}
+			by = strings.ToLower(strings.TrimSpace(by))
+			if by != "day" && by != "hour" {
+				return fmt.Errorf("unsupported --by %q (use day or hour)", by)
+			}
+			if weeks < 1 || weeks > 52 {
+				return fmt.Errorf("--weeks must be between 1 and 52")
+			}
+			cfg, _ := config.Load()
+			loc := installLocale(cfg)
+
+			now := time.Now()
+			var providerIDs []string
+			if p := strings.TrimSpace(provider); p != "" {
+				providerIDs = []string{p}
+			}
+			if by == "hour" {
+				return runHourlyHeatmap(dbPath, providerIDs, report.HourlyOptions{
+					Metric:       m,
+					FirstWeekday: loc.FirstWeekday,
+					Provider:     strings.TrimSpace(provider),
+					ByProvider:   byProvider,
+					Since:        now.AddDate(0, 0, -7*weeks),
+					Until:        now,
+				}, asJSON)
+			}
+
+			opts := report.HeatmapOptions{
+				Metric:       m,
+				Weeks:        weeks,
+				FirstWeekday: loc.FirstWeekday,
+				Provider:     strings.TrimSpace(provider),
+				ByProvider:   byProvider,
+				Now:          now,
+			}
+			rows, err := telemetry.LoadDailyUsageHistory(context.Background(), dbPath, providerIDs, now.AddDate(0, 0, -7*weeks))
+			if err != nil {
+				return err
+			}
+			h := report.BuildHeatmap(heatmapDays(rows, m), opts)
+			if asJSON {
+				return h.WriteJSON(os.Stdout)
+			}
+			if len(rows) == 0 {
+				fmt.Fprintf(os.Stderr, "no persisted usage history in %s (is the telemetry daemon running?)\n", dbPath)
+			}
+			return h.WriteText(os.Stdout)
+		},
+	}
+
+	defaultDBPath, _ := telemetry.DefaultDBPath()
+	fl := cmd.Flags()
+	fl.StringVar(&metric, "metric", string(report.HeatmapCost), "value to plot: cost, tokens, or requests")
+	fl.StringVar(&by, "by", "day", "layout: day (calendar) or hour (weekday × hour of day, local time)")
+	fl.IntVar(&weeks, "weeks", report.DefaultHeatmapWeeks, "number of trailing weeks to show")
+	fl.StringVar(&provider, "provider", "", "limit to a single provider id (e.g. claude_code)")
+	fl.BoolVar(&byProvider, "by-provider", false, "add one heatmap per provider after the aggregate")
+	fl.BoolVar(&asJSON, "json", false, "emit JSON instead of text")
+	fl.StringVar(&dbPath, "db-path", defaultDBPath, "path to telemetry sqlite database")
+	_ = cmd.RegisterFlagCompletionFunc("metric", cobra.FixedCompletions([]string{"cost", "tokens", "requests"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("by", cobra.FixedCompletions([]string{"day", "hour"}, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+func runHourlyHeatmap(dbPath string, providerIDs []string, opts report.HourlyOptions, asJSON bool) error {
+	rows, err := telemetry.LoadHourlyUsage(context.Background(), dbPath, providerIDs, opts.Since)
+	if err != nil {
+		return err
+	}
+	h := report.BuildHourlyHeatmap(heatmapHours(rows), opts)
+	if asJSON {
+		return h.WriteJSON(os.Stdout)
+	}
+	if len(rows) == 0 {
+		fmt.Fprintf(os.Stderr, "no persisted usage events in %s (is the telemetry daemon running?)\n", dbPath)
+	}
+	return h.WriteText(os.Stdout)
+}
+
+func heatmapHours(rows []telemetry.HourlyUsage) []report.HourlyValue {
+	out := make([]report.HourlyValue, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, report.HourlyValue{Hour: r.Hour, Provider: r.ProviderID, Cost: r.CostUSD, Tokens: r.Tokens, Requests: r.Requests})
+	}
+	return out
+}
+
+func heatmapDays(rows []telemetry.DailyUsage, metric report.HeatmapMetric) []report.HeatmapDay {
+	days := make([]report.HeatmapDay, 0, len(rows))
+	for _, r := range rows {
+		v := r.CostUSD
+		switch metric {
+		case report.HeatmapTokens:
+			v = r.Tokens
+		case report.HeatmapRequests:
+			v = r.Requests
+		}
+		days = append(days, report.HeatmapDay{Day: r.Day, Provider: r.ProviderID, Value: v})
+	}
+	return days
+}
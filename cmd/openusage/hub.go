@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"net"
 	"os"
 	"os/signal"
 	"strings"
@@ -116,7 +115,7 @@ func validateHubExposure(addr, authToken string, allowPublic bool) error {
 	if allowPublic {
 		return nil // explicit opt-in → operator accepts the risk
 	}
-	if isLoopbackAddr(addr) {
+	if core.IsLoopbackAddr(addr) {
 		return nil // bound to loopback → not externally reachable
 	}
 	return fmt.Errorf(
@@ -129,35 +128,6 @@ func validateHubExposure(addr, authToken string, allowPublic bool) error {
 	)
 }
 
-// isLoopbackAddr reports whether addr binds only to a loopback interface.
-// Accepts ":port" (empty host = all interfaces, NOT loopback), "host:port",
-// and bare "host". Hostnames other than "localhost" are treated conservatively
-// as non-loopback because we can't resolve them deterministically at startup.
-func isLoopbackAddr(addr string) bool {
-	host, _, err := net.SplitHostPort(addr)
-	if err != nil {
-		// Could be a bare host or a malformed addr. ":port" form (e.g. ":9190")
-		// trips SplitHostPort because it returns host="" → we treat that as
-		// all-interfaces.
-		if addr == "" || strings.HasPrefix(addr, ":") {
-			return false
-		}
-		host = addr
-	}
-	if host == "" {
-		return false
-	}
-	if host == "localhost" {
-		return true
-	}
-	ip := net.ParseIP(host)
-	if ip == nil {
-		// Unresolvable hostname — be conservative.
-		return false
-	}
-	return ip.IsLoopback()
-}
-
 func runHub(cfg config.Config, allowPublic bool) {
 	verbose := os.Getenv("OPENUSAGE_DEBUG") != ""
 
@@ -165,6 +135,7 @@ func runHub(cfg config.Config, allowPublic bool) {
 		log.Printf("theme load: %v", err)
 	}
 	tui.SetThemeByName(cfg.Theme)
+	installLocale(cfg)
 
 	rt := resolveHubRuntime(cfg)
 	if err := validateHubExposure(rt.addr, rt.authToken, allowPublic); err != nil {
@@ -78,6 +78,7 @@ func runHubView(cfg config.Config, hubURL, token string) {
 		log.Printf("theme load: %v", err)
 	}
 	tui.SetThemeByName(cfg.Theme)
+	installLocale(cfg)
 
 	pollInterval := time.Duration(cfg.UI.RefreshIntervalSeconds) * time.Second
 	if pollInterval <= 0 {
@@ -94,6 +95,11 @@ func runHubView(cfg config.Config, hubURL, token string) {
 		nil,
 		timeWindow,
 	)
+	if verbose {
+		// The TUI owns stderr; keep the log in memory for the L pane.
+		log.SetOutput(core.DebugLog)
+		model.SetLogSource(core.DebugLog.Lines)
+	}
 
 	program := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithFPS(30))
 	dispatcher := &snapshotDispatcher{}
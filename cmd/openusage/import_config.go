@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/detect"
+)
+
+func newImportConfigCommand() *cobra.Command {
+	var yes, dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "import-config <" + strings.Join(detect.ImportSources, "|") + ">",
+		Short: "Import API keys already configured in opencode, llm, or aider",
+		Long: `Read the API keys another tool already has configured and turn them into
+openusage accounts, so you don't have to declare every key twice.
+
+Sources:
+  opencode  auth.json (opencode auth login) and provider.<id>.options.apiKey
+            in opencode.json / opencode.jsonc
+  llm       keys.json (llm keys set <name>); honours LLM_USER_PATH
+  aider     .aider.conf.yml and .env in the current dir, git root, and home
+
+Each key is shown masked and imported only after you confirm it. Imported keys
+go to the credentials store (never settings.json) and a matching account is
+added to settings.json unless one with the same ID already exists. Keys for
+providers openusage doesn't support, OAuth logins, and {env:…} references are
+skipped.`,
+		Example: strings.Join([]string{
+			"  openusage import-config opencode",
+			"  openusage import-config llm --dry-run",
+			"  openusage import-config aider --yes",
+		}, "\n"),
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			candidates, err := detect.ImportCandidates(args[0])
+			if err != nil {
+				return err
+			}
+			if !yes && !dryRun && len(candidates) > 0 && !isStdinTerminal() {
+				return errors.New("import-config: stdin is not a terminal; pass --yes to import without prompting")
+			}
+			creds, err := config.LoadCredentials()
+			if err != nil {
+				return fmt.Errorf("import-config: loading credentials: %w", err)
+			}
+			im := configImporter{
+				out:            os.Stdout,
+				in:             bufio.NewReader(os.Stdin),
+				yes:            yes,
+				dryRun:         dryRun,
+				stored:         creds.Keys,
+				saveCredential: config.SaveCredential,
+				addAccount:     config.AddAccount,
+			}
+			return im.run(args[0], candidates)
+		},
+	}
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "import every key without prompting")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "list what would be imported and exit")
+	return cmd
+}
+
+// configImporter walks import candidates, asks for consent per key, and
+// persists the accepted ones. Persistence is injected so tests never touch
+// the real credentials store.
+type configImporter struct {
+	out    io.Writer
+	in     *bufio.Reader
+	yes    bool
+	dryRun bool
+	// stored maps account ID to the key already in the credentials store.
+	stored         map[string]string
+	saveCredential func(accountID, apiKey string) error
+	addAccount     func(core.AccountConfig) error
+}
+
+func (im configImporter) run(tool string, candidates []detect.ImportCandidate) error {
+	if len(candidates) == 0 {
+		fmt.Fprintf(im.out, "No API keys for supported providers found in %s's config.\n", tool)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(im.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tACCOUNT\tKEY\tFROM\tSTATUS")
+	for _, c := range candidates {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			c.Account.Provider, c.Account.ID, detect.MaskKey(c.Account.Token), c.Path, im.status(c))
+	}
+	_ = w.Flush()
+	if im.dryRun {
+		return nil
+	}
+
+	fmt.Fprintln(im.out)
+	imported := 0
+	for _, c := range candidates {
+		stored, ok := im.stored[c.Account.ID]
+		if ok && stored == c.Account.Token {
+			continue
+		}
+		if !im.yes {
+			prompt := fmt.Sprintf("Import %s key %s as account %q", c.Account.Provider, detect.MaskKey(c.Account.Token), c.Account.ID)
+			if ok {
+				prompt += " (replaces the stored key)"
+			}
+			if !im.confirm(prompt + "? [y/N] ") {
+				continue
+			}
+		}
+		if err := im.saveCredential(c.Account.ID, c.Account.Token); err != nil {
+			return fmt.Errorf("import-config: saving key for %s: %w", c.Account.ID, err)
+		}
+		acct := core.AccountConfig{ID: c.Account.ID, Provider: c.Account.Provider, Auth: c.Account.Auth}
+		if err := im.addAccount(acct); err != nil {
+			return fmt.Errorf("import-config: adding account %s: %w", c.Account.ID, err)
+		}
+		imported++
+	}
+	fmt.Fprintf(im.out, "Imported %d of %d account(s) from %s.\n", imported, len(candidates), tool)
+	return nil
+}
+
+func (im configImporter) status(c detect.ImportCandidate) string {
+	stored, ok := im.stored[c.Account.ID]
+	switch {
+	case !ok:
+		return "new"
+	case stored == c.Account.Token:
+		return "already imported"
+	default:
+		return "differs from stored key"
+	}
+}
+
+// confirm asks a yes/no question, defaulting to no. It reads from the shared
+// reader so consecutive prompts don't lose buffered input.
+func (im configImporter) confirm(prompt string) bool {
+	fmt.Fprint(im.out, prompt)
+	line, err := im.in.ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Fprintln(im.out)
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
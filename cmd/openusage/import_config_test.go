@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/detect"
+)
+
+func importCandidate(provider, id, key string) detect.ImportCandidate {
+	return detect.ImportCandidate{
+		Account: core.AccountConfig{ID: id, Provider: provider, Auth: "api_key", Token: key},
+		ToolKey: provider,
+		Path:    "/home/u/.config/io.datasette.llm/keys.json",
+	}
+}
+
+func TestConfigImporter_Run(t *testing.T) {
+	candidates := []detect.ImportCandidate{
+		importCandidate("anthropic", "anthropic", "sk-ant-already-stored-000"),
+		importCandidate("groq", "groq", "gsk_new_key_declined_111"),
+		importCandidate("openai", "openai", "sk-openai-replacement-222"),
+		importCandidate("xai", "xai", "xai-brand-new-key-333"),
+	}
+	var out bytes.Buffer
+	saved := map[string]string{}
+	var added []core.AccountConfig
+	im := configImporter{
+		out: &out,
+		// anthropic is skipped without a prompt, so answers go to groq,
+		// openai and xai in order.
+		in:     bufio.NewReader(strings.NewReader("n\ny\nyes\n")),
+		stored: map[string]string{"anthropic": "sk-ant-already-stored-000", "openai": "sk-old"},
+		saveCredential: func(id, key string) error {
+			saved[id] = key
+			return nil
+		},
+		addAccount: func(acct core.AccountConfig) error {
+			added = append(added, acct)
+			return nil
+		},
+	}
+	if err := im.run("llm", candidates); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	if len(saved) != 2 || saved["openai"] != "sk-openai-replacement-222" || saved["xai"] != "xai-brand-new-key-333" {
+		t.Errorf("saved = %v, want openai and xai only", saved)
+	}
+	if len(added) != 2 || added[0].Token != "" || added[1].ID != "xai" || added[1].Provider != "xai" {
+		t.Errorf("added accounts = %+v", added)
+	}
+	text := out.String()
+	for _, want := range []string{"already imported", "differs from stored key", "(replaces the stored key)", "Imported 2 of 4 account(s) from llm."} {
+		if !strings.Contains(text, want) {
+			t.Errorf("output missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "gsk_new_key_declined_111") {
+		t.Errorf("output leaks an unmasked key:\n%s", text)
+	}
+}
+
+func TestConfigImporter_DryRunWritesNothing(t *testing.T) {
+	var out bytes.Buffer
+	im := configImporter{
+		out:            &out,
+		dryRun:         true,
+		saveCredential: func(string, string) error { t.Fatal("dry run saved a key"); return nil },
+		addAccount:     func(core.AccountConfig) error { t.Fatal("dry run added an account"); return nil },
+	}
+	if err := im.run("aider", []detect.ImportCandidate{importCandidate("openai", "openai", "sk-openai-dry-run-1234")}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "new") {
+		t.Errorf("dry run should list the candidate:\n%s", out.String())
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/daemon"
+)
+
+func newLogsCommand() *cobra.Command {
+	var (
+		socketPath string
+		tail       int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Print the telemetry daemon's recent log lines",
+		Long: `Print the log lines the telemetry daemon keeps in memory (the last 2000).
+
+The daemon always buffers its log, so provider polls and errors can be inspected
+without restarting it in debug mode. Set OPENUSAGE_DEBUG=1 on the daemon for
+per-request trace lines. Inside the dashboard, L shows the dashboard's own log.`,
+		Example: strings.Join([]string{
+			"  openusage logs",
+			"  openusage logs --tail 50",
+		}, "\n"),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if tail < 0 {
+				return fmt.Errorf("--tail must be zero or positive")
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			lines, err := daemon.NewClient(strings.TrimSpace(socketPath)).Logs(ctx)
+			if err != nil {
+				return fmt.Errorf("read daemon logs: %w (is the daemon running? see `openusage telemetry daemon status`)", err)
+			}
+			return writeLogLines(os.Stdout, lines, tail)
+		},
+	}
+	cmd.Flags().StringVar(&socketPath, "socket-path", daemon.ResolveSocketPath(), "path to telemetry daemon unix socket")
+	cmd.Flags().IntVar(&tail, "tail", 0, "print only the last N lines (0 prints everything)")
+	return cmd
+}
+
+func writeLogLines(w io.Writer, lines []string, tail int) error {
+	if tail > 0 && len(lines) > tail {
+		lines = lines[len(lines)-tail:]
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
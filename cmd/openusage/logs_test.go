@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteLogLines(t *testing.T) {
+	lines := []string{"one", "two", "three"}
+	tests := []struct {
+		name string
+		tail int
+		want string
+	}{
+		{name: "all", tail: 0, want: "one\ntwo\nthree\n"},
+		{name: "tail", tail: 2, want: "two\nthree\n"},
+		{name: "tail beyond buffer", tail: 10, want: "one\ntwo\nthree\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeLogLines(&buf, lines, tt.tail); err != nil {
+				t.Fatalf("writeLogLines() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Fatalf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
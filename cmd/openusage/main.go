@@ -5,9 +5,13 @@ import (
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 
+	"github.com/janekbaraniewski/openusage/internal/audit"
 	"github.com/janekbaraniewski/openusage/internal/config"
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
+	"github.com/janekbaraniewski/openusage/internal/telemetry"
 	"github.com/janekbaraniewski/openusage/internal/version"
 	"github.com/spf13/cobra"
 )
@@ -19,21 +23,50 @@ func main() {
 		log.SetOutput(io.Discard)
 	}
 
-	cfg, err := config.Load()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
-		fmt.Fprintf(os.Stderr, "Config path: %s\n", config.ConfigPath())
+	if err := applyConfigFlags(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	if path, err := telemetry.DefaultAuditLogPath(); err == nil {
+		audit.Configure(path)
+	}
 
+	var cfg config.Config
+	var focusAccount string
+	var offline bool
 	root := cobra.Command{
 		Use:     "openusage",
 		Short:   "OpenUsage is a terminal dashboard for monitoring AI coding tool usage and spend.",
 		Version: version.Version,
 		Run: func(_ *cobra.Command, _ []string) {
-			runDashboard(cfg)
+			runDashboard(resolveDuplicateAccounts(cfg), focusAccount, offline || config.OfflineFromEnv())
 		},
 	}
+	root.Flags().StringVar(&focusAccount, "account", "", "open the dashboard on this account's detail view")
+	root.Flags().BoolVar(&offline, "offline", false,
+		"show the last cached data without contacting the daemon or any provider (also: "+config.EnvOffline+"=1)")
+
+	addConfigFlags(&root)
+
+	var readOnly, noWrite bool
+	root.PersistentFlags().BoolVar(&readOnly, "read-only", false,
+		"never issue provider requests that cost money or mutate state (also: "+config.EnvReadOnly+"=1 or \"read_only\": true)")
+	root.PersistentFlags().BoolVar(&noWrite, "no-write", false,
+		"never write settings.json or credentials.json; settings changes last for the session (also: "+config.EnvNoWrite+"=1)")
+	root.PersistentPreRun = func(cmd *cobra.Command, _ []string) {
+		// Exported via the environment so every later config.Load in this
+		// process (daemon poll loop, export collectors) sees the override,
+		// and `telemetry daemon install` bakes it into the service env.
+		if readOnly {
+			_ = os.Setenv(config.EnvReadOnly, "1")
+		}
+		if noWrite {
+			_ = os.Setenv(config.EnvNoWrite, "1")
+		}
+		if !commandSkipsConfig(cmd) {
+			cfg = loadConfig()
+		}
+	}
 
 	root.AddCommand(&cobra.Command{
 		Use:   "version",
@@ -45,17 +78,89 @@ func main() {
 	root.AddCommand(newTelemetryCommand())
 	root.AddCommand(newIntegrationsCommand())
 	root.AddCommand(newDetectCommand())
+	root.AddCommand(newAccountsCommand())
+	root.AddCommand(newProfilesCommand())
+	root.AddCommand(newPathsCommand())
+	root.AddCommand(newPresetCommand())
+	root.AddCommand(newImportConfigCommand())
 	root.AddCommand(newPricingCommand())
+	root.AddCommand(newPlanCommand())
+	root.AddCommand(newCompareCommand())
 	root.AddCommand(newExportCommand())
 	root.AddCommand(newHubCommand())
 	root.AddCommand(newHubViewCommand())
+	root.AddCommand(newServeCommand())
 	root.AddCommand(newStatuslineCommand())
+	root.AddCommand(newQuickCommand())
+	root.AddCommand(newHeatmapCommand())
 	root.AddCommand(newTmuxCommand())
+	root.AddCommand(newUpdateCommand())
+	root.AddCommand(newLogsCommand())
+	root.AddCommand(newAuditCommand())
+	root.AddCommand(newBugreportCommand())
+	root.AddCommand(newNotifyCommand())
 	for _, c := range newReportCommands() {
 		root.AddCommand(c)
 	}
+	root.AddCommand(newDemoCommand())
+	root.AddCommand(newDocsCommand())
+	registerCompletions(&root)
 
 	if err := root.Execute(); err != nil {
 		os.Exit(1)
 	}
 }
+
+// configFreeCommands never read settings.json, so they neither load it nor
+// migrate legacy config.
+var configFreeCommands = map[string]bool{
+	"version":                       true,
+	"docs":                          true,
+	"completion":                    true,
+	"help":                          true,
+	cobra.ShellCompRequestCmd:       true,
+	cobra.ShellCompNoDescRequestCmd: true,
+}
+
+// commandSkipsConfig reports whether cmd, or the top-level command it sits
+// under, is one of configFreeCommands.
+func commandSkipsConfig(cmd *cobra.Command) bool {
+	for c := cmd; c != nil && c.HasParent(); c = c.Parent() {
+		if configFreeCommands[c.Name()] {
+			return true
+		}
+	}
+	return false
+}
+
+// loadConfig copies config left in the platform directory by earlier
+// releases into the XDG one, then loads settings and installs the locale.
+// A config that fails to load ends the process.
+func loadConfig() config.Config {
+	migrated, err := config.MigrateLegacyConfig()
+	for _, path := range migrated {
+		fmt.Fprintf(os.Stderr, "Copied %s into %s\n", filepath.Base(path), filepath.Dir(path))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Config path: %s\n", config.ConfigPath())
+		os.Exit(1)
+	}
+	installLocale(cfg)
+	return cfg
+}
+
+// installLocale activates the configured locale and number precision for
+// every renderer in the process: TUI, reports, status lines, and launchers.
+func installLocale(cfg config.Config) locale.Locale {
+	loc := locale.Resolve(cfg.Locale)
+	if cfg.NumberPrecision != nil {
+		loc = loc.WithPrecision(*cfg.NumberPrecision)
+	}
+	locale.Set(loc)
+	return loc
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/notify"
+)
+
+func newNotifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Check and test alert notification routing",
+		Long: `The telemetry daemon sends alerts (limits reached, gauges past the warn/crit
+thresholds, auth failures) to the sinks configured under "notifications" in
+settings.json, and runs the optional on_event hook for status changes,
+threshold breaches, and resets. These commands validate that configuration and
+send test alerts.`,
+	}
+	cmd.AddCommand(newNotifyCheckCommand(), newNotifyTestCommand())
+	return cmd
+}
+
+func newNotifyCheckCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "check",
+		Short: "Validate notification sinks, rules, and the on_event hook and print the routing",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			router, errs := notify.NewRouter(cfg.Notifications)
+			var hook *notify.Hook
+			if cfg.Notifications.OnEvent != nil {
+				if hook, err = notify.NewHook(*cfg.Notifications.OnEvent); err != nil {
+					errs = append(errs, fmt.Errorf("on_event: %w", err))
+				}
+			}
+			return writeNotifyCheck(os.Stdout, router, hook, errs)
+		},
+	}
+}
+
+func writeNotifyCheck(w io.Writer, router *notify.Router, hook *notify.Hook, errs []error) error {
+	if router.Enabled() || len(errs) > 0 {
+		for _, line := range router.Describe() {
+			fmt.Fprintln(w, line)
+		}
+	} else {
+		fmt.Fprintln(w, "No notification routes configured.")
+	}
+	if hook != nil {
+		fmt.Fprintln(w, hook.Describe())
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w)
+	for _, err := range errs {
+		fmt.Fprintln(w, "problem: "+err.Error())
+	}
+	return fmt.Errorf("%d notification config problem(s); the daemon skips the affected sinks, rules, and hook", len(errs))
+}
+
+func newNotifyTestCommand() *cobra.Command {
+	var severity string
+	cmd := &cobra.Command{
+		Use:   "test [sink...]",
+		Short: "Send a test alert to sinks (all when none are named)",
+		Example: strings.Join([]string{
+			"  openusage notify test",
+			"  openusage notify test phone --severity critical",
+		}, "\n"),
+		RunE: func(_ *cobra.Command, args []string) error {
+			sev, err := notify.ParseSeverity(severity, notify.SeverityWarning)
+			if err != nil {
+				return err
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			router, errs := notify.NewRouter(cfg.Notifications)
+			for _, err := range errs {
+				fmt.Fprintln(os.Stderr, "problem: "+err.Error())
+			}
+			sinks := args
+			if len(sinks) == 0 {
+				sinks = router.SinkNames()
+			}
+			if len(sinks) == 0 {
+				return fmt.Errorf("no usable notification sinks configured")
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			alert := notify.Alert{
+				Key:      "openusage/test",
+				Severity: sev,
+				Title:    "openusage: test alert",
+				Body:     fmt.Sprintf("Test %s alert sent by `openusage notify test`.", sev),
+				At:       time.Now(),
+			}
+			failed := 0
+			for _, name := range sinks {
+				if err := router.Send(ctx, name, alert); err != nil {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+					failed++
+					continue
+				}
+				fmt.Printf("%s: sent\n", name)
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d sinks failed", failed, len(sinks))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&severity, "severity", "warning", "severity of the test alert: info, warning, or critical")
+	return cmd
+}
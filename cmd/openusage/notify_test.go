@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/notify"
+)
+
+func TestWriteNotifyCheck(t *testing.T) {
+	var buf bytes.Buffer
+	router, errs := notify.NewRouter(config.NotificationsConfig{})
+	if err := writeNotifyCheck(&buf, router, nil, errs); err != nil {
+		t.Fatalf("empty config: error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "No notification routes configured") {
+		t.Errorf("empty config output = %q", buf.String())
+	}
+
+	buf.Reset()
+	router, _ = notify.NewRouter(config.NotificationsConfig{
+		Sinks:        map[string]config.NotificationSink{"desktop": {Type: "desktop"}},
+		DefaultSinks: []string{"desktop"},
+		Rules:        []config.NotificationRule{{Providers: []string{"copilot"}, Sinks: []string{}}},
+	})
+	hook, err := notify.NewHook(config.EventHook{Command: []string{"/usr/local/bin/pause-agents"}, Events: []string{"threshold"}})
+	if err != nil {
+		t.Fatalf("NewHook() error: %v", err)
+	}
+	err = writeNotifyCheck(&buf, router, hook, []error{errors.New(`sink "phone": ntfy sink needs a topic`)})
+	if err == nil {
+		t.Fatal("problems did not fail the check")
+	}
+	for _, want := range []string{
+		"sink desktop: warning and above",
+		"default: desktop",
+		"rule 1: providers copilot, info and above → muted",
+		"on_event: /usr/local/bin/pause-agents for threshold (timeout 30s)",
+		`problem: sink "phone": ntfy sink needs a topic`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
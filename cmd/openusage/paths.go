@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/audit"
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/daemon"
+	"github.com/janekbaraniewski/openusage/internal/dashboardapp"
+	"github.com/janekbaraniewski/openusage/internal/integrations"
+	"github.com/janekbaraniewski/openusage/internal/pricing"
+	"github.com/janekbaraniewski/openusage/internal/telemetry"
+)
+
+// pathRow is one line of `openusage paths`.
+type pathRow struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Source string `json:"source"`
+	Exists bool   `json:"exists"`
+}
+
+func newPathsCommand() *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "paths",
+		Short: "Show the files and directories openusage uses and why",
+		Long: `Show every file and directory openusage reads or writes for the current
+invocation, with the setting that chose it (a flag, an environment variable,
+an XDG base directory or the platform default) and whether it exists.
+
+Config that earlier releases kept in the platform config directory is copied
+into $XDG_CONFIG_HOME/openusage automatically; the legacy directory is left in
+place and listed last.`,
+		Example: strings.Join([]string{
+			"  openusage paths",
+			"  openusage --profile work paths",
+			"  openusage paths --json",
+		}, "\n"),
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			rows := buildPathRows()
+			if jsonOutput {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(rows)
+			}
+			return writePathsTable(os.Stdout, rows)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Emit machine-readable JSON instead of a table")
+	return cmd
+}
+
+func buildPathRows() []pathRow {
+	var rows []pathRow
+	add := func(name, path, source string) {
+		if path == "" {
+			return
+		}
+		_, err := os.Stat(path)
+		rows = append(rows, pathRow{Name: name, Path: path, Source: source, Exists: err == nil})
+	}
+
+	configSource := xdgSource(config.EnvXDGConfigHome)
+	profileSource := configSource
+	if profile := config.ActiveProfile(); profile != "" {
+		profileSource = "profile " + profile
+	}
+	add("config dir", config.ConfigDir(), configSource)
+	add("settings", config.ConfigPath(), envSource(config.EnvConfig, profileSource))
+	add("credentials", config.CredentialsPath(), envSource(config.EnvCredentials, profileSource))
+	if path, err := pricing.CustomOverridesPath(); err == nil {
+		add("custom pricing", path, envSource("OPENUSAGE_CUSTOM_PRICING", xdgSource(config.EnvXDGConfigHome)))
+	}
+	add("themes", filepath.Join(config.ConfigDir(), "themes"), configSource)
+	for _, dir := range filepath.SplitList(os.Getenv("OPENUSAGE_THEME_DIR")) {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			add("themes", dir, "$OPENUSAGE_THEME_DIR")
+		}
+	}
+	add("custom providers", filepath.Join(config.ConfigDir(), "providers"), configSource)
+	add("hooks", integrations.NewDefaultDirs().HooksDir, xdgSource(config.EnvXDGConfigHome))
+
+	stateSource := xdgSource("XDG_STATE_HOME")
+	if dir, err := telemetry.DefaultStateDir(); err == nil {
+		add("state dir", dir, stateSource)
+	}
+	if path, err := telemetry.DefaultDBPath(); err == nil {
+		add("telemetry db", path, stateSource)
+	}
+	add("daemon socket", daemon.ResolveSocketPath(), envSource("OPENUSAGE_TELEMETRY_SOCKET", stateSource))
+	if dir, err := telemetry.DefaultSpoolDir(); err == nil {
+		add("hook spool", dir, stateSource)
+	}
+	if path, err := telemetry.DefaultAuditLogPath(); err == nil {
+		add("audit log", path, envSource(audit.EnvPath, stateSource))
+	}
+
+	cacheSource := xdgSource(config.EnvXDGCacheHome)
+	add("cache dir", config.CacheDir(), cacheSource)
+	add("snapshot cache", dashboardapp.SnapshotCachePath(), cacheSource)
+	add("data dir", config.DataDir(), xdgSource(config.EnvXDGDataHome))
+
+	if legacy := config.PlatformConfigDir(); filepath.Clean(legacy) != filepath.Clean(config.ConfigDir()) {
+		if _, err := os.Stat(legacy); err == nil {
+			add("legacy config dir", legacy, "copied, kept for older daemons")
+		}
+	}
+	return rows
+}
+
+// envSource names env as the source when it is set, else fallback.
+func envSource(env, fallback string) string {
+	if strings.TrimSpace(os.Getenv(env)) != "" {
+		return "$" + env
+	}
+	return fallback
+}
+
+// xdgSource reports whether an XDG base directory variable chose a path.
+// Relative values are ignored by the resolvers, so they count as unset here.
+func xdgSource(env string) string {
+	if base := strings.TrimSpace(os.Getenv(env)); base != "" && filepath.IsAbs(base) {
+		return "$" + env
+	}
+	return "default"
+}
+
+func writePathsTable(out io.Writer, rows []pathRow) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPATH\tSOURCE\tEXISTS")
+	for _, r := range rows {
+		exists := "no"
+		if r.Exists {
+			exists = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Name, r.Path, r.Source, exists)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/export"
+	"github.com/janekbaraniewski/openusage/internal/pricing"
+	"github.com/janekbaraniewski/openusage/internal/report"
+)
+
+func newPlanCommand() *cobra.Command {
+	var (
+		model        string
+		tokensPerDay string
+		outputShare  float64
+		days         int
+		sourceFlag   string
+		asJSON       bool
+		timeout      time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Estimate budget runway and the cheapest allocation for a daily token load",
+		Long: `Estimate how long your remaining budgets last at a steady daily token rate
+on one model, and the cheapest way to spread that load across accounts.
+
+The model is priced with the same catalog as ` + "`openusage pricing`" + `, blended
+by --output-share. Budgets come from the current snapshots: USD credits and
+spend limits, and token quotas. For each account the budget that runs out
+first is shown with its runway if it carried the whole load.
+
+The allocation covers --days: prepaid token quotas first (no extra spend),
+then USD budgets, largest first. Load no budget covers is priced
+pay-as-you-go. Accounts whose provider can't bill the model are listed but
+not allocated. Percent-only plan windows (e.g. 5-hour subscription limits)
+don't translate to tokens and are not counted.`,
+		Example: strings.Join([]string{
+			"  openusage plan --model claude-sonnet --tokens-per-day 5M",
+			"  openusage plan --model gpt-4o --tokens-per-day 750k --output-share 0.3 --days 14",
+			"  openusage plan --model claude-sonnet --tokens-per-day 2M --json",
+		}, "\n"),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if strings.TrimSpace(model) == "" {
+				return fmt.Errorf("--model is required")
+			}
+			tokens, err := parseTokenCount(tokensPerDay)
+			if err != nil {
+				return fmt.Errorf("--tokens-per-day: %w", err)
+			}
+			if tokens <= 0 {
+				return fmt.Errorf("--tokens-per-day must be positive")
+			}
+			if outputShare < 0 || outputShare > 1 {
+				return fmt.Errorf("--output-share must be between 0 and 1")
+			}
+			if days < 1 {
+				return fmt.Errorf("--days must be at least 1")
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			price, err := pricing.DefaultResolver().Lookup(ctx, model, 0)
+			if err != nil {
+				return err
+			}
+			snaps, _, err := export.Collect(ctx, export.Source(strings.ToLower(strings.TrimSpace(sourceFlag))))
+			if err != nil {
+				return fmt.Errorf("plan: collecting snapshots: %w", err)
+			}
+
+			p := report.BuildPlan(snaps, report.PlanOptions{
+				Model:        model,
+				Price:        price,
+				TokensPerDay: tokens,
+				OutputShare:  outputShare,
+				HorizonDays:  days,
+			})
+			if asJSON {
+				return p.WriteJSON(os.Stdout)
+			}
+			return p.WriteText(os.Stdout)
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVar(&model, "model", "", "model to plan for (any name `openusage pricing` resolves)")
+	fl.StringVar(&tokensPerDay, "tokens-per-day", "", "steady daily token load, e.g. 5M, 750k, 1.5B")
+	fl.Float64Var(&outputShare, "output-share", report.DefaultPlanOutputShare, "fraction of tokens billed as output")
+	fl.IntVar(&days, "days", report.DefaultPlanHorizonDays, "days the allocation has to last")
+	fl.StringVar(&sourceFlag, "source", string(export.SourceAuto), "snapshot source: auto (default), direct, or daemon")
+	fl.BoolVar(&asJSON, "json", false, "emit JSON instead of text")
+	fl.DurationVar(&timeout, "timeout", 30*time.Second, "timeout for pricing lookup and snapshot collection")
+	return cmd
+}
+
+// parseTokenCount parses a token amount with an optional k, M, or B suffix.
+func parseTokenCount(s string) (float64, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "_", "")
+	s = strings.ReplaceAll(s, ",", "")
+	if s == "" {
+		return 0, fmt.Errorf("missing value")
+	}
+	mult := 1.0
+	switch s[len(s)-1] {
+	case 'k', 'K':
+		mult = 1e3
+	case 'm', 'M':
+		mult = 1e6
+	case 'b', 'B', 'g', 'G':
+		mult = 1e9
+	}
+	if mult != 1 {
+		s = s[:len(s)-1]
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid token count %q", s)
+	}
+	return v * mult, nil
+}
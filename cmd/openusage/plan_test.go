@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestParseTokenCount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{in: "5M", want: 5e6},
+		{in: "750k", want: 750e3},
+		{in: "1.5B", want: 1.5e9},
+		{in: "2_000_000", want: 2e6},
+		{in: " 12,500 ", want: 12500},
+		{in: "", wantErr: true},
+		{in: "lots", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseTokenCount(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseTokenCount(%q) = %v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseTokenCount(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
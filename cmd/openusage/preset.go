@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+)
+
+func newPresetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "preset",
+		Short: "Export or import a shareable dashboard layout preset",
+		Long: `Share a curated dashboard layout as a TOML snippet. A preset holds the
+view, the visible tile and detail sections, per-provider gauge priorities and
+compact rows, and the warn/crit thresholds. It never includes accounts or
+credentials.`,
+	}
+	cmd.AddCommand(newPresetExportCommand(), newPresetImportCommand())
+	return cmd
+}
+
+func newPresetExportCommand() *cobra.Command {
+	var name, output string
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write the current dashboard layout as a TOML preset",
+		Example: strings.Join([]string{
+			"  openusage preset export --name platform-team > layout.toml",
+			"  openusage preset export -o layout.toml",
+		}, "\n"),
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			out := io.Writer(os.Stdout)
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("preset export: %w", err)
+				}
+				defer f.Close()
+				out = f
+			}
+			return exportPreset(out, config.ConfigPath(), name)
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "", "name recorded in the preset")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "write to a file instead of stdout")
+	return cmd
+}
+
+func newPresetImportCommand() *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "import <file|->",
+		Short: "Replace the dashboard layout with a TOML preset",
+		Long: `Replace the dashboard layout in settings.json with the preset's. Sections,
+provider gauge priorities and compact rows the preset doesn't set go back to
+the built-in defaults, so everyone importing it ends up with the same layout.
+Accounts and per-account settings are kept. Pass "-" to read from stdin.`,
+		Example: strings.Join([]string{
+			"  openusage preset import layout.toml",
+			"  curl -s https://example.com/layout.toml | openusage preset import -",
+			"  openusage preset import layout.toml --dry-run",
+		}, "\n"),
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			var data []byte
+			var err error
+			if args[0] == "-" {
+				data, err = io.ReadAll(os.Stdin)
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return fmt.Errorf("preset import: %w", err)
+			}
+			return importPreset(os.Stdout, config.ConfigPath(), data, dryRun)
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "validate and summarize the preset without saving it")
+	return cmd
+}
+
+func exportPreset(w io.Writer, settingsPath, name string) error {
+	cfg, err := config.LoadFrom(settingsPath)
+	if err != nil {
+		return fmt.Errorf("preset export: %w", err)
+	}
+	_, err = w.Write(config.ExportLayoutPreset(cfg, name).TOML())
+	return err
+}
+
+func importPreset(w io.Writer, settingsPath string, data []byte, dryRun bool) error {
+	preset, err := config.ParseLayoutPreset(data)
+	if err != nil {
+		return fmt.Errorf("preset import: %w", err)
+	}
+	label := "preset"
+	if preset.Name != "" {
+		label = fmt.Sprintf("preset %q", preset.Name)
+	}
+	summary := presetSummary(preset)
+	if dryRun {
+		fmt.Fprintf(w, "%s is valid: %s\n", label, summary)
+		return nil
+	}
+	if err := config.ImportLayoutPresetTo(settingsPath, preset); err != nil {
+		return fmt.Errorf("preset import: %w", err)
+	}
+	fmt.Fprintf(w, "Applied %s to %s: %s\n", label, settingsPath, summary)
+	return nil
+}
+
+func presetSummary(p config.LayoutPreset) string {
+	parts := []string{"view " + p.View}
+	countOn := func(n, total int, what string) string {
+		if total == 0 {
+			return "default " + what
+		}
+		return fmt.Sprintf("%d of %d %s shown", n, total, what)
+	}
+	tileOn := 0
+	for _, s := range p.WidgetSections {
+		if s.Enabled {
+			tileOn++
+		}
+	}
+	detailOn := 0
+	for _, s := range p.DetailSections {
+		if s.Enabled {
+			detailOn++
+		}
+	}
+	parts = append(parts,
+		countOn(tileOn, len(p.WidgetSections), "tile sections"),
+		countOn(detailOn, len(p.DetailSections), "detail sections"),
+		fmt.Sprintf("%d provider overrides", len(p.ProviderWidgets)))
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestImportPreset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(path, []byte(`{"dashboard": {"view": "grid"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	preset := []byte("name = \"team\"\nview = \"tabs\"\n[tile_sections]\nshow = [\"model_burn\"]\n")
+
+	var buf bytes.Buffer
+	if err := importPreset(&buf, path, preset, true); err != nil {
+		t.Fatalf("dry run error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), `preset "team" is valid: view tabs, 1 of `) {
+		t.Errorf("dry run output = %q", buf.String())
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), "tabs") {
+		t.Fatalf("dry run wrote settings: %s", data)
+	}
+
+	buf.Reset()
+	if err := importPreset(&buf, path, preset, false); err != nil {
+		t.Fatalf("import error: %v", err)
+	}
+	buf.Reset()
+	if err := exportPreset(&buf, path, "team"); err != nil {
+		t.Fatalf("export error: %v", err)
+	}
+	for _, want := range []string{`name = "team"`, `view = "tabs"`, `show = ["model_burn"]`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("export missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestImportPreset_RejectsInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	if err := importPreset(&bytes.Buffer{}, path, []byte("view = 3\n"), false); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("settings written for an invalid preset: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"text/tabwriter"
 	"time"
@@ -24,12 +25,15 @@ func newPricingCommand() *cobra.Command {
 		Short: "Look up published per-million-token pricing for a model",
 		Long: `pricing fetches model pricing from public sources (LiteLLM and OpenRouter),
 caches the table on disk under the user cache directory, and prints the
-resolved rates for the supplied model.
+resolved rates for the supplied model. Image and transcription models are
+shown with their per-image or per-minute rate.
 
 Examples:
   openusage pricing claude-3-5-sonnet
   openusage pricing gpt-4o --context 250000
+  openusage pricing dall-e-3
   openusage pricing gemini-1.5-pro --json
+  openusage pricing refresh
 `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -50,9 +54,60 @@ Examples:
 	cmd.Flags().IntVar(&contextLen, "context", 0, "Apply tiered pricing for this context length (input tokens)")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Emit machine-readable JSON instead of a table")
 	cmd.Flags().DurationVar(&timeout, "timeout", 15*time.Second, "Network timeout for fetching upstream pricing")
+	cmd.AddCommand(newPricingRefreshCommand())
 	return cmd
 }
 
+func newPricingRefreshCommand() *cobra.Command {
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "refresh",
+		Short: "Re-fetch the pricing catalog now, ignoring the cache TTL",
+		Long: `refresh re-downloads the LiteLLM and OpenRouter pricing tables into the
+on-disk cache and reports how many models were added, removed, or repriced.
+The telemetry daemon does the same once per cache TTL (OPENUSAGE_PRICING_TTL,
+default 24h). Rates in custom-pricing.json always take precedence.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			results := pricing.DefaultResolver().Refresh(ctx)
+			overrides, _ := pricing.LoadCustomOverrides()
+			return writePricingRefresh(cmd.OutOrStdout(), results, len(overrides))
+		},
+	}
+	cmd.Flags().DurationVar(&timeout, "timeout", 60*time.Second, "Network timeout for fetching upstream pricing")
+	return cmd
+}
+
+func writePricingRefresh(w io.Writer, results []pricing.RefreshResult, overrides int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SOURCE\tMODELS\tADDED\tREMOVED\tCHANGED\tVERIFIED")
+	failed := 0
+	for _, res := range results {
+		verified := "never"
+		if !res.VerifiedAt.IsZero() {
+			verified = res.VerifiedAt.Local().Format(time.RFC3339)
+		}
+		if res.Err != nil {
+			failed++
+			fmt.Fprintf(tw, "%s\t%d\t-\t-\t-\t%s (refresh failed: %v)\n", res.Source, res.Models, verified, res.Err)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%s\n", res.Source, res.Models, res.Added, res.Removed, res.Changed, verified)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if overrides > 0 {
+		fmt.Fprintf(w, "\n%d custom override(s) in %s take precedence.\n", overrides, pricing.CustomOverridesFilename)
+	}
+	if failed == len(results) {
+		return fmt.Errorf("every pricing source failed to refresh; keeping the cached tables")
+	}
+	return nil
+}
+
 func writePricingJSON(w interface{ Write([]byte) (int, error) }, p *pricing.Price) error {
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
@@ -71,8 +126,11 @@ func writePricingTable(w interface{ Write([]byte) (int, error) }, query string,
 		fmt.Fprintf(tw, "Provider:\t%s\n", p.Provider)
 	}
 	fmt.Fprintf(tw, "Source:\t%s\n", p.Source)
+	if p.Mode != "" {
+		fmt.Fprintf(tw, "Mode:\t%s\n", p.Mode)
+	}
 	if !p.LastUpdated.IsZero() {
-		fmt.Fprintf(tw, "Last updated:\t%s\n", p.LastUpdated.Format(time.RFC3339))
+		fmt.Fprintf(tw, "Last verified:\t%s\n", p.LastUpdated.Format(time.RFC3339))
 	}
 	if p.ContextWindow > 0 {
 		fmt.Fprintf(tw, "Context window:\t%d tokens\n", p.ContextWindow)
@@ -80,6 +138,15 @@ func writePricingTable(w interface{ Write([]byte) (int, error) }, query string,
 	if contextLen > 0 {
 		fmt.Fprintf(tw, "Tier at context:\t%d tokens\n", contextLen)
 	}
+	if p.CostPerImage > 0 {
+		fmt.Fprintf(tw, "Per image:\t$%.4f\n", p.CostPerImage)
+	}
+	if p.AudioCostPerMinute > 0 {
+		fmt.Fprintf(tw, "Per audio minute:\t$%.4f\n", p.AudioCostPerMinute)
+	}
+	if p.InputCostPerMillion == 0 && p.OutputCostPerMillion == 0 && (p.CostPerImage > 0 || p.AudioCostPerMinute > 0) {
+		return tw.Flush()
+	}
 	fmt.Fprintln(tw, "----\tUSD per 1M tokens")
 	fmt.Fprintf(tw, "Input:\t$%.4f\n", p.InputCostPerMillion)
 	fmt.Fprintf(tw, "Output:\t$%.4f\n", p.OutputCostPerMillion)
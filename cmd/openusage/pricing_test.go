@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/pricing"
+)
+
+func TestWritePricingRefresh(t *testing.T) {
+	verified := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	err := writePricingRefresh(&buf, []pricing.RefreshResult{
+		{Source: pricing.SourceLiteLLM, Models: 2100, Added: 3, Changed: 12, VerifiedAt: verified},
+		{Source: pricing.SourceOpenRouter, Err: errors.New("upstream status 503")},
+	}, 2)
+	if err != nil {
+		t.Fatalf("one source refreshed: error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"litellm     2100    3      0        12",
+		"openrouter  0       -      -        -        never (refresh failed: upstream status 503)",
+		"2 custom override(s) in custom-pricing.json take precedence.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	err = writePricingRefresh(&buf, []pricing.RefreshResult{{Source: pricing.SourceLiteLLM, Err: errors.New("offline")}}, 0)
+	if err == nil {
+		t.Error("all sources failing did not return an error")
+	}
+}
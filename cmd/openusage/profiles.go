@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+)
+
+// applyConfigFlags exports --config, --profile and --no-write to the
+// environment before main migrates and loads settings, which happens ahead of
+// cobra's flag parsing. Going
+// through the environment lets every later config.Load in the process, and a
+// daemon installed from it, see the same selection.
+func applyConfigFlags(args []string) error {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		var name, value string
+		switch {
+		case arg == "--no-write" || arg == "--no-write=true":
+			_ = os.Setenv(config.EnvNoWrite, "1")
+			continue
+		case arg == "--config" || arg == "--profile":
+			if i+1 >= len(args) {
+				return fmt.Errorf("flag needs an argument: %s", arg)
+			}
+			name, value = arg, args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--config="), strings.HasPrefix(arg, "--profile="):
+			name, value, _ = strings.Cut(arg, "=")
+		default:
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if name == "--config" {
+			if value == "" {
+				return fmt.Errorf("--config: empty path")
+			}
+			if abs, err := filepath.Abs(value); err == nil {
+				value = abs
+			}
+			_ = os.Setenv(config.EnvConfig, value)
+		} else {
+			_ = os.Setenv(config.EnvProfile, value)
+		}
+	}
+	if profile := config.ActiveProfile(); profile != "" {
+		if err := config.ValidateProfileName(profile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addConfigFlags registers --config and --profile so cobra accepts and
+// documents them; applyConfigFlags has already acted on their values.
+func addConfigFlags(root *cobra.Command) {
+	fl := root.PersistentFlags()
+	fl.String("config", "", "settings file to use instead of the profile's settings.json (also: "+config.EnvConfig+")")
+	fl.String("profile", "", "named config profile with its own settings and credentials (also: "+config.EnvProfile+")")
+	_ = root.MarkPersistentFlagFilename("config", "json")
+	_ = root.RegisterFlagCompletionFunc("profile", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		names, _ := config.ListProfiles()
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+func newProfilesCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "profiles",
+		Short: "List config profiles and show which one is active",
+		Long: `List the named config profiles under the config directory. Each profile
+is a directory holding its own settings.json and credentials.json; select one
+with --profile NAME or OPENUSAGE_PROFILE. Running any command with a new
+profile name creates it on first save.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			names, err := config.ListProfiles()
+			if err != nil {
+				return err
+			}
+			active := config.ActiveProfile()
+			mark := func(on bool) string {
+				if on {
+					return "*"
+				}
+				return " "
+			}
+			fmt.Printf("%s %-20s %s\n", mark(active == ""), "(default)", config.ConfigDir())
+			for _, name := range names {
+				fmt.Printf("%s %-20s %s\n", mark(active == name), name, filepath.Join(config.ProfilesDir(), name))
+			}
+			if active != "" && !slices.Contains(names, active) {
+				fmt.Printf("* %-20s %s (not created yet)\n", active, config.ProfileDir())
+			}
+			fmt.Printf("\nsettings: %s\n", config.ConfigPath())
+			return nil
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestApplyConfigFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantProfile string
+		wantConfig  string
+		wantErr     bool
+	}{
+		{name: "none", args: []string{"daily", "--since", "7d"}},
+		{name: "separate values", args: []string{"--profile", "work", "export", "--config", "/etc/ou.json"}, wantProfile: "work", wantConfig: "/etc/ou.json"},
+		{name: "equals form", args: []string{"--profile=client-a", "--config=/tmp/c.json"}, wantProfile: "client-a", wantConfig: "/tmp/c.json"},
+		{name: "after terminator ignored", args: []string{"telemetry", "hook", "--", "--profile", "x"}},
+		{name: "invalid profile", args: []string{"--profile", "../etc"}, wantErr: true},
+		{name: "missing value", args: []string{"--config"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.EnvProfile, "")
+			t.Setenv(config.EnvConfig, "")
+			err := applyConfigFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyConfigFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := os.Getenv(config.EnvProfile); got != tt.wantProfile {
+				t.Errorf("%s = %q, want %q", config.EnvProfile, got, tt.wantProfile)
+			}
+			if got := os.Getenv(config.EnvConfig); got != tt.wantConfig {
+				t.Errorf("%s = %q, want %q", config.EnvConfig, got, tt.wantConfig)
+			}
+		})
+	}
+}
+
+func TestApplyConfigFlags_RelativeConfigIsAbsolute(t *testing.T) {
+	t.Setenv(config.EnvProfile, "")
+	t.Setenv(config.EnvConfig, "")
+	if err := applyConfigFlags([]string{"--config", "settings.work.json"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := os.Getenv(config.EnvConfig); !filepath.IsAbs(got) {
+		t.Errorf("%s = %q, want an absolute path so the daemon resolves it too", config.EnvConfig, got)
+	}
+}
+
+func TestApplyConfigFlags_NoWriteBeforeMigration(t *testing.T) {
+	t.Setenv(config.EnvProfile, "")
+	t.Setenv(config.EnvConfig, "")
+	t.Setenv(config.EnvNoWrite, "")
+	if err := applyConfigFlags([]string{"paths", "--no-write"}); err != nil {
+		t.Fatal(err)
+	}
+	if !config.NoWriteFromEnv() {
+		t.Errorf("--no-write was not exported before settings are migrated and loaded")
+	}
+}
+
+func TestCommandSkipsConfig(t *testing.T) {
+	root := &cobra.Command{Use: "openusage"}
+	daemon := &cobra.Command{Use: "daemon"}
+	telemetry := &cobra.Command{Use: "telemetry"}
+	telemetry.AddCommand(daemon)
+	completion := &cobra.Command{Use: "completion"}
+	bash := &cobra.Command{Use: "bash"}
+	completion.AddCommand(bash)
+	version := &cobra.Command{Use: "version"}
+	root.AddCommand(telemetry, completion, version)
+
+	for _, tt := range []struct {
+		cmd  *cobra.Command
+		want bool
+	}{
+		{cmd: root, want: false},
+		{cmd: daemon, want: false},
+		{cmd: version, want: true},
+		{cmd: bash, want: true},
+	} {
+		if got := commandSkipsConfig(tt.cmd); got != tt.want {
+			t.Errorf("commandSkipsConfig(%s) = %v, want %v", tt.cmd.CommandPath(), got, tt.want)
+		}
+	}
+}
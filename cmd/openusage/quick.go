@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/export"
+)
+
+// quickDaemonTimeout bounds the daemon read so launcher UIs (Raycast, Alfred)
+// that run the command on every keystroke never hang.
+const quickDaemonTimeout = 1500 * time.Millisecond
+
+// quickItem is one account row: its key gauge plus the data the launcher
+// actions need.
+type quickItem struct {
+	AccountID  string     `json:"account_id"`
+	ProviderID string     `json:"provider_id"`
+	Status     string     `json:"status"`
+	Gauge      string     `json:"gauge,omitempty"`
+	UsedPct    *float64   `json:"used_percent,omitempty"`
+	ResetAt    *time.Time `json:"reset_at,omitempty"`
+	Message    string     `json:"message,omitempty"`
+}
+
+func newQuickCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "quick",
+		Short: "Print a one-glance summary of every account for launchers and scripts",
+		Long: `Print each account with its most-used quota gauge.
+
+Data comes from the running telemetry daemon's cache, so the command answers
+instantly and never polls providers itself. When the daemon is not running the
+raycast and alfred formats return a single explanatory item instead of failing,
+so the launcher's result list stays readable.
+
+Formats:
+  text     one line per account (default)
+  json     a plain JSON array of accounts
+  raycast  Raycast list items with "Open in Terminal" and "Copy Reset Time" actions
+  alfred   an Alfred Script Filter document; ↩ opens the account in the
+           dashboard, ⌘↩ passes the reset time on (e.g. to Copy to Clipboard)`,
+		Example: strings.Join([]string{
+			"  openusage quick",
+			"  openusage quick --format alfred",
+			"  openusage quick --format raycast",
+		}, "\n"),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			format = strings.ToLower(strings.TrimSpace(format))
+			switch format {
+			case "text", "json", "raycast", "alfred":
+			default:
+				return fmt.Errorf("unsupported --format %q (use text, json, raycast, or alfred)", format)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), quickDaemonTimeout)
+			defer cancel()
+			snaps, _, err := export.Collect(ctx, export.SourceDaemon)
+			return writeQuick(os.Stdout, format, buildQuickItems(snaps), err, time.Now())
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "text", "output format: text, json, raycast, or alfred")
+	_ = cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"text", "json", "raycast", "alfred"}, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+// buildQuickItems picks each account's key gauge: the quota with the highest
+// used percentage. Per-session context windows are not quotas and are skipped.
+func buildQuickItems(snaps []core.UsageSnapshot) []quickItem {
+	items := make([]quickItem, 0, len(snaps))
+	for _, snap := range snaps {
+		item := quickItem{
+			AccountID:  snap.AccountID,
+			ProviderID: snap.ProviderID,
+			Status:     string(snap.Status),
+			Message:    strings.TrimSpace(snap.Message),
+		}
+		bestKey, best := "", -1.0
+		for key, met := range snap.Metrics {
+			if met.Window == "session" {
+				continue
+			}
+			if pct := core.MetricUsedPercent(key, met); pct > best || (pct == best && pct >= 0 && key < bestKey) {
+				bestKey, best = key, pct
+			}
+		}
+		if best >= 0 {
+			pct := best
+			item.Gauge = core.NormalizeMetricLabel(core.PrettifyMetricKey(bestKey))
+			item.UsedPct = &pct
+			for _, resetKey := range []string{bestKey, bestKey + "_reset"} {
+				if at, ok := snap.Resets[resetKey]; ok && !at.IsZero() {
+					item.ResetAt = &at
+					break
+				}
+			}
+		}
+		items = append(items, item)
+	}
+	// Fullest accounts first: the launcher's top hit is the one to worry about.
+	sort.SliceStable(items, func(i, j int) bool {
+		pi, pj := -1.0, -1.0
+		if items[i].UsedPct != nil {
+			pi = *items[i].UsedPct
+		}
+		if items[j].UsedPct != nil {
+			pj = *items[j].UsedPct
+		}
+		if pi != pj {
+			return pi > pj
+		}
+		return items[i].AccountID < items[j].AccountID
+	})
+	return items
+}
+
+func writeQuick(w io.Writer, format string, items []quickItem, collectErr error, now time.Time) error {
+	// Launcher formats render the failure as a result row; they show nothing
+	// useful for a non-zero exit.
+	switch format {
+	case "raycast":
+		return writeQuickJSON(w, quickRaycastItems(items, collectErr, now))
+	case "alfred":
+		return writeQuickJSON(w, quickAlfredDocument(items, collectErr, now))
+	}
+	if collectErr != nil {
+		return fmt.Errorf("read daemon cache: %w", collectErr)
+	}
+	if format == "json" {
+		if items == nil {
+			items = []quickItem{}
+		}
+		return writeQuickJSON(w, items)
+	}
+	for _, item := range items {
+		fmt.Fprintln(w, quickTitle(item)+"  "+quickSubtitle(item, now))
+	}
+	return nil
+}
+
+func writeQuickJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func quickTitle(item quickItem) string {
+	if item.UsedPct == nil {
+		return item.AccountID
+	}
+	return fmt.Sprintf("%s  %.0f%%", item.AccountID, *item.UsedPct)
+}
+
+func quickSubtitle(item quickItem, now time.Time) string {
+	parts := []string{item.ProviderID}
+	if item.Gauge != "" {
+		parts = append(parts, item.Gauge)
+	}
+	if item.ResetAt != nil && item.ResetAt.After(now) {
+		parts = append(parts, "resets in "+fmtStatusDuration(item.ResetAt.Sub(now)))
+	}
+	if item.Status != "" && item.Status != string(core.StatusOK) {
+		parts = append(parts, strings.ToLower(item.Status))
+	}
+	if item.UsedPct == nil && item.Message != "" {
+		parts = append(parts, item.Message)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// quickOpenCommand is the shell command that opens the dashboard on the
+// account's detail view.
+func quickOpenCommand(accountID string) string {
+	return "openusage --account " + shellQuote(accountID)
+}
+
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_.") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func quickResetText(item quickItem) string {
+	if item.ResetAt == nil {
+		return ""
+	}
+	return item.ResetAt.Local().Format(time.RFC1123)
+}
+
+type raycastAction struct {
+	Type    string `json:"type"` // "open-in-terminal" or "copy"
+	Title   string `json:"title"`
+	Command string `json:"command,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+type raycastItem struct {
+	ID          string              `json:"id"`
+	Title       string              `json:"title"`
+	Subtitle    string              `json:"subtitle"`
+	Accessories []map[string]string `json:"accessories,omitempty"`
+	Actions     []raycastAction     `json:"actions,omitempty"`
+}
+
+func quickRaycastItems(items []quickItem, collectErr error, now time.Time) []raycastItem {
+	if collectErr != nil {
+		return []raycastItem{{ID: "daemon", Title: "OpenUsage daemon not reachable", Subtitle: "Run: openusage telemetry daemon install"}}
+	}
+	out := make([]raycastItem, 0, len(items))
+	for _, item := range items {
+		ri := raycastItem{
+			ID:       item.AccountID,
+			Title:    item.AccountID,
+			Subtitle: quickSubtitle(item, now),
+			Actions: []raycastAction{{
+				Type: "open-in-terminal", Title: "Open in Terminal", Command: quickOpenCommand(item.AccountID),
+			}},
+		}
+		if item.UsedPct != nil {
+			ri.Accessories = []map[string]string{{"text": fmt.Sprintf("%.0f%%", *item.UsedPct)}}
+		}
+		if reset := quickResetText(item); reset != "" {
+			ri.Actions = append(ri.Actions, raycastAction{Type: "copy", Title: "Copy Reset Time", Content: reset})
+		}
+		out = append(out, ri)
+	}
+	return out
+}
+
+type alfredMod struct {
+	Arg      string `json:"arg"`
+	Subtitle string `json:"subtitle"`
+	Valid    bool   `json:"valid"`
+}
+
+type alfredItem struct {
+	UID      string               `json:"uid,omitempty"`
+	Title    string               `json:"title"`
+	Subtitle string               `json:"subtitle"`
+	Arg      string               `json:"arg,omitempty"`
+	Valid    bool                 `json:"valid"`
+	Text     map[string]string    `json:"text,omitempty"`
+	Mods     map[string]alfredMod `json:"mods,omitempty"`
+}
+
+type alfredDocument struct {
+	Items []alfredItem `json:"items"`
+}
+
+func quickAlfredDocument(items []quickItem, collectErr error, now time.Time) alfredDocument {
+	if collectErr != nil {
+		return alfredDocument{Items: []alfredItem{{
+			Title:    "OpenUsage daemon not reachable",
+			Subtitle: "Run: openusage telemetry daemon install",
+		}}}
+	}
+	doc := alfredDocument{Items: make([]alfredItem, 0, len(items))}
+	for _, item := range items {
+		subtitle := quickSubtitle(item, now)
+		ai := alfredItem{
+			UID:      item.AccountID,
+			Title:    quickTitle(item),
+			Subtitle: subtitle,
+			Arg:      quickOpenCommand(item.AccountID),
+			Valid:    true,
+			Text:     map[string]string{"copy": quickTitle(item) + " · " + subtitle, "largetype": quickTitle(item)},
+		}
+		if reset := quickResetText(item); reset != "" {
+			ai.Mods = map[string]alfredMod{"cmd": {Arg: reset, Subtitle: "Copy reset time: " + reset, Valid: true}}
+		}
+		doc.Items = append(doc.Items, ai)
+	}
+	return doc
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func quickTestSnapshots(now time.Time) []core.UsageSnapshot {
+	return []core.UsageSnapshot{
+		{
+			AccountID:  "openai-team",
+			ProviderID: "openai",
+			Status:     core.StatusOK,
+			Metrics: map[string]core.Metric{
+				"rpm": {Limit: core.Float64Ptr(100), Remaining: core.Float64Ptr(90)},
+			},
+		},
+		{
+			AccountID:  "claude code",
+			ProviderID: "claude_code",
+			Status:     core.StatusNearLimit,
+			Metrics: map[string]core.Metric{
+				"usage_five_hour": {Used: core.Float64Ptr(82), Unit: "%"},
+				"usage_weekly":    {Used: core.Float64Ptr(40), Unit: "%"},
+				"context_window":  {Used: core.Float64Ptr(95), Unit: "%", Window: "session"},
+			},
+			Resets: map[string]time.Time{"usage_five_hour": now.Add(90 * time.Minute)},
+		},
+		{AccountID: "copilot", ProviderID: "copilot", Status: core.StatusAuth, Message: "token expired"},
+	}
+}
+
+func TestBuildQuickItems_PicksFullestQuotaAndSorts(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	items := buildQuickItems(quickTestSnapshots(now))
+
+	if len(items) != 3 {
+		t.Fatalf("items = %d, want 3", len(items))
+	}
+	first := items[0]
+	if first.AccountID != "claude code" || first.Gauge != "Usage Five Hour" || *first.UsedPct != 82 {
+		t.Fatalf("first item = %+v, want claude code five-hour gauge at 82%%", first)
+	}
+	if first.ResetAt == nil || !first.ResetAt.Equal(now.Add(90*time.Minute)) {
+		t.Fatalf("reset = %v, want five-hour reset", first.ResetAt)
+	}
+	if items[1].AccountID != "openai-team" || *items[1].UsedPct != 10 {
+		t.Fatalf("second item = %+v, want openai-team at 10%%", items[1])
+	}
+	if items[2].UsedPct != nil {
+		t.Fatalf("copilot has no quota, got %v", *items[2].UsedPct)
+	}
+	if got := quickSubtitle(items[2], now); got != "copilot · auth_required · token expired" {
+		t.Fatalf("copilot subtitle = %q", got)
+	}
+}
+
+func TestWriteQuick_Alfred(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	if err := writeQuick(&buf, "alfred", buildQuickItems(quickTestSnapshots(now)), nil, now); err != nil {
+		t.Fatal(err)
+	}
+	var doc alfredDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("decode: %v\n%s", err, buf.String())
+	}
+	top := doc.Items[0]
+	if top.Arg != "openusage --account 'claude code'" {
+		t.Fatalf("arg = %q", top.Arg)
+	}
+	if !strings.Contains(top.Subtitle, "resets in 1h30m") {
+		t.Fatalf("subtitle = %q", top.Subtitle)
+	}
+	if top.Mods["cmd"].Arg == "" {
+		t.Fatal("expected a cmd modifier carrying the reset time")
+	}
+	if _, ok := doc.Items[1].Mods["cmd"]; ok {
+		t.Fatal("accounts without a reset should not get a copy modifier")
+	}
+}
+
+func TestWriteQuick_DaemonDown(t *testing.T) {
+	down := errors.New("dial unix: no such file")
+	var buf bytes.Buffer
+	if err := writeQuick(&buf, "raycast", nil, down, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "daemon not reachable") {
+		t.Fatalf("raycast output = %s", buf.String())
+	}
+	if err := writeQuick(&buf, "text", nil, down, time.Now()); err == nil {
+		t.Fatal("text format should surface the daemon error")
+	}
+}
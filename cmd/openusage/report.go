@@ -10,8 +10,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/janekbaraniewski/openusage/internal/ccevents"
+	"github.com/janekbaraniewski/openusage/internal/config"
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/export"
+	"github.com/janekbaraniewski/openusage/internal/locale"
 	"github.com/janekbaraniewski/openusage/internal/providers"
 	"github.com/janekbaraniewski/openusage/internal/providers/claude_code"
 	"github.com/janekbaraniewski/openusage/internal/providers/shared"
@@ -28,6 +30,7 @@ type reportFlags struct {
 	json      bool
 	since     string
 	until     string
+	window    string
 	breakdown bool
 	provider  string
 	project   string
@@ -86,6 +89,7 @@ func bindReportFlags(cmd *cobra.Command, f *reportFlags, kind report.Kind) {
 	fl.BoolVar(&f.json, "json", false, "emit JSON instead of a table")
 	fl.StringVar(&f.since, "since", "", "only include usage on/after this date (YYYY-MM-DD)")
 	fl.StringVar(&f.until, "until", "", "only include usage on/before this date (YYYY-MM-DD)")
+	fl.StringVar(&f.window, "window", "", "only include usage inside this time window (built-in or data.custom_windows id)")
 	fl.BoolVarP(&f.breakdown, "breakdown", "b", false, "add a per-model breakdown under each row")
 	fl.StringVar(&f.provider, "provider", "", "limit to a single provider id (e.g. claude_code)")
 	fl.StringVar(&f.project, "project", "", "limit to a single project/workspace label")
@@ -98,17 +102,20 @@ func bindReportFlags(cmd *cobra.Command, f *reportFlags, kind report.Kind) {
 			"snapshot source for non-Claude providers: auto, direct, or daemon")
 	}
 	if kind == report.KindWeekly {
-		fl.StringVar(&f.weekStart, "week-start", "monday", "week boundary: monday or sunday")
+		fl.StringVar(&f.weekStart, "week-start", "", "week boundary: monday or sunday (default from locale)")
 	}
 }
 
 func runReport(kind report.Kind, f *reportFlags) error {
+	cfg, _ := config.Load()
+	loc := installLocale(cfg)
+
 	opts := report.Options{
 		Kind:            kind,
 		Breakdown:       f.breakdown,
 		Provider:        strings.TrimSpace(f.provider),
 		Project:         strings.TrimSpace(f.project),
-		WeekStartMonday: !strings.EqualFold(strings.TrimSpace(f.weekStart), "sunday"),
+		WeekStartMonday: reportWeekStartMonday(f.weekStart, loc),
 		TopModels:       f.topModels,
 		Now:             time.Now(),
 	}
@@ -120,6 +127,16 @@ func runReport(kind report.Kind, f *reportFlags) error {
 	if opts.Until, err = parseReportDate(f.until, true); err != nil {
 		return fmt.Errorf("invalid --until: %w", err)
 	}
+	if window := strings.TrimSpace(f.window); window != "" {
+		if f.since != "" {
+			return fmt.Errorf("--window and --since both set the start; use one")
+		}
+		tw, ok := core.LookupTimeWindow(window)
+		if !ok {
+			return fmt.Errorf("invalid --window: unknown window %q", window)
+		}
+		opts.Since = tw.Since()
+	}
 
 	sp := startSpinner(fmt.Sprintf("collecting %s usage…", kind))
 	events, note, err := gatherReportEvents(kind, f)
@@ -129,6 +146,7 @@ func runReport(kind report.Kind, f *reportFlags) error {
 	}
 
 	rep := report.Build(events, opts)
+	rep.Energy = report.EstimateEnergy(events, opts, cfg.Energy)
 	if note != "" {
 		if rep.Note != "" {
 			rep.Note = note + "; " + rep.Note
@@ -143,6 +161,19 @@ func runReport(kind report.Kind, f *reportFlags) error {
 	return rep.WriteTable(os.Stdout)
 }
 
+// reportWeekStartMonday resolves --week-start, falling back to the locale's
+// first day of the week when the flag is unset.
+func reportWeekStartMonday(flag string, loc locale.Locale) bool {
+	switch strings.ToLower(strings.TrimSpace(flag)) {
+	case "sunday":
+		return false
+	case "monday":
+		return true
+	default:
+		return loc.FirstWeekday != time.Sunday
+	}
+}
+
 // gatherReportEvents assembles the unified event stream for a report.
 //
 // Three itemized sources contribute per-turn events (timestamp + tokens + model
@@ -281,6 +312,7 @@ func reportExamples(use string) string {
 		"  openusage " + use + " --json",
 		"  openusage " + use + " --breakdown",
 		"  openusage " + use + " --since 2026-05-01 --until 2026-05-31",
+		"  openusage " + use + " --window sprint",
 		"  openusage " + use + " --provider claude_code --offline",
 	}, "\n")
 }
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/export"
+	"github.com/janekbaraniewski/openusage/internal/providers"
+	"github.com/janekbaraniewski/openusage/internal/web"
+)
+
+func newServeCommand() *cobra.Command {
+	var (
+		webAddr     string
+		allowPublic bool
+		privacy     bool
+		refresh     time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a read-only web dashboard of the daemon's usage data",
+		Long: strings.Join([]string{
+			"Serve the dashboard's tiles and details to a browser, for teammates who want to check shared quota",
+			"without running the TUI. Data comes from the running telemetry daemon; the page never polls providers",
+			"and offers no actions.",
+			"",
+			"Security: the page has no authentication. It refuses to bind to a non-loopback interface unless you",
+			"pass --allow-public; consider --privacy to mask emails, org names, and dollar amounts. Without",
+			"--allow-public it also only answers requests addressed to localhost, a loopback IP or the --web host,",
+			"so other web pages can't reach it through DNS rebinding.",
+		}, "\n"),
+		Example: strings.Join([]string{
+			"  openusage serve --web 127.0.0.1:8080",
+			"  openusage serve --web :8080 --allow-public --privacy",
+		}, "\n"),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			addr := strings.TrimSpace(webAddr)
+			if addr == "" {
+				return errors.New("serve: --web address is required (e.g. --web 127.0.0.1:8080)")
+			}
+			if !allowPublic && !core.IsLoopbackAddr(addr) {
+				return fmt.Errorf("serve: refusing to listen on %q without --allow-public; the web dashboard has no authentication", addr)
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				log.Printf("warning: config load failed, using defaults: %v", err)
+				cfg = config.DefaultConfig()
+			}
+			if !cmd.Flags().Changed("privacy") {
+				privacy = cfg.Dashboard.PrivacyMode
+			}
+
+			server := web.NewServer(web.Options{
+				Addr: addr,
+				Source: func(ctx context.Context) ([]core.UsageSnapshot, error) {
+					snaps, _, err := export.Collect(ctx, export.SourceDaemon)
+					return snaps, err
+				},
+				Providers:       providers.AllProviders(),
+				Locale:          installLocale(cfg),
+				Privacy:         privacy,
+				RefreshInterval: refresh,
+				AllowPublic:     allowPublic,
+			})
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			log.Printf("web dashboard listening on http://%s (privacy=%t)", displayListenAddr(addr), privacy)
+			if err := server.ListenAndServe(ctx); err != nil && ctx.Err() == nil {
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&webAddr, "web", "", "TCP address to serve the web dashboard on (e.g. 127.0.0.1:8080 or :8080)")
+	cmd.Flags().BoolVar(&allowPublic, "allow-public", false, "allow binding to a non-loopback interface (the page has no authentication)")
+	cmd.Flags().BoolVar(&privacy, "privacy", false, "mask emails, org and key names, and dollar amounts (default: the dashboard's privacy mode)")
+	cmd.Flags().DurationVar(&refresh, "refresh", 30*time.Second, "how often the page reloads data from the daemon")
+	return cmd
+}
+
+// displayListenAddr turns ":8080" into "localhost:8080" for the startup log
+// so the printed URL can be opened as is.
+func displayListenAddr(addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		return "localhost" + addr
+	}
+	return addr
+}
@@ -3,16 +3,21 @@ package main
 import (
 	"context"
 	"sync/atomic"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/daemon"
+	"github.com/janekbaraniewski/openusage/internal/dashboardapp"
 	"github.com/janekbaraniewski/openusage/internal/tui"
 )
 
 type snapshotDispatcher struct {
 	program *tea.Program
 	nextID  atomic.Uint64
+	// cache, when set, persists each frame so the next launch can paint
+	// immediately.
+	cache *dashboardapp.SnapshotCache
 }
 
 func (d *snapshotDispatcher) bind(program *tea.Program) {
@@ -31,6 +36,21 @@ func (d *snapshotDispatcher) refresh(ctx context.Context, rt *daemon.ViewRuntime
 	}()
 }
 
+// pollNow has the daemon fetch accountIDs immediately, pushes the resulting
+// frame and then reports completion so the dashboard can stop its spinners.
+func (d *snapshotDispatcher) pollNow(ctx context.Context, rt *daemon.ViewRuntime, accountIDs []string) {
+	go func() {
+		err := rt.PollNow(ctx, accountIDs)
+		if err == nil {
+			requestID := d.nextID.Add(1)
+			d.send(rt.ReadWithFallbackForWindow(ctx, rt.TimeWindow()), requestID)
+		}
+		if d.program != nil {
+			d.program.Send(tui.AccountsRefreshedMsg{AccountIDs: accountIDs, Err: err})
+		}
+	}()
+}
+
 func (d *snapshotDispatcher) send(frame daemon.SnapshotFrame, requestID uint64) {
 	if d == nil || d.program == nil || len(frame.Snapshots) == 0 {
 		return
@@ -40,4 +60,7 @@ func (d *snapshotDispatcher) send(frame daemon.SnapshotFrame, requestID uint64)
 		TimeWindow: frame.TimeWindow,
 		RequestID:  requestID,
 	})
+	if d.cache != nil {
+		d.cache.Save(frame.TimeWindow, frame.Snapshots, time.Now())
+	}
 }
@@ -14,6 +14,7 @@ import (
 
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/export"
+	"github.com/janekbaraniewski/openusage/internal/locale"
 	"github.com/janekbaraniewski/openusage/internal/providers/claude_code"
 	"github.com/janekbaraniewski/openusage/internal/report"
 )
@@ -565,7 +566,7 @@ func assembleStatusline(v statuslineValues, opts statuslineOptions) string {
 		parts = append(parts, colorize(fmt.Sprintf("🕔 5h %.0f%%", v.fiveHourPct), contextColor(v.fiveHourPct, opts), opts.color))
 	}
 	if opts.segmentEnabled("context") && v.contextTok > 0 {
-		ctxStr := "🧠 " + fmtTokensShort(v.contextTok)
+		ctxStr := "🧠 " + locale.Current().Quantity(float64(v.contextTok), "tokens")
 		if v.ctxPct > 0 {
 			ctxStr += fmt.Sprintf(" (%.0f%%)", v.ctxPct)
 		}
@@ -633,14 +634,3 @@ func fmtStatusDuration(d time.Duration) string {
 	}
 	return fmt.Sprintf("%dm", m)
 }
-
-func fmtTokensShort(n int) string {
-	switch {
-	case n >= 1_000_000:
-		return fmt.Sprintf("%.1fM", float64(n)/1e6)
-	case n >= 1_000:
-		return fmt.Sprintf("%dk", n/1000)
-	default:
-		return fmt.Sprintf("%d", n)
-	}
-}
@@ -9,11 +9,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/janekbaraniewski/openusage/internal/capacity"
 	"github.com/janekbaraniewski/openusage/internal/config"
 	"github.com/janekbaraniewski/openusage/internal/daemon"
 	"github.com/janekbaraniewski/openusage/internal/detect"
 	"github.com/janekbaraniewski/openusage/internal/integrations"
+	"github.com/janekbaraniewski/openusage/internal/notify"
 	"github.com/janekbaraniewski/openusage/internal/providers"
+	"github.com/janekbaraniewski/openusage/internal/providers/chaos"
 	"github.com/janekbaraniewski/openusage/internal/telemetry"
 	"github.com/spf13/cobra"
 )
@@ -180,9 +183,19 @@ func newTelemetryDaemonCommand() *cobra.Command {
 		collectInterval time.Duration
 		pollInterval    time.Duration
 		verbose         bool
+		chaosSpec       string
 	)
 
 	runDaemon := func(_ *cobra.Command, _ []string) error {
+		var chaosCfg *chaos.Config
+		if spec := strings.TrimSpace(chaosSpec); spec != "" {
+			parsed, err := chaos.ParseSpec(spec)
+			if err != nil {
+				return err
+			}
+			chaosCfg = &parsed
+		}
+
 		cfgFile, loadErr := config.Load()
 		if loadErr != nil {
 			log.Printf("warning: failed to load config, using defaults: %v", loadErr)
@@ -229,6 +242,13 @@ func newTelemetryDaemonCommand() *cobra.Command {
 			PollInterval:    resolvedPoll,
 			Verbose:         verbose,
 			Export:          cfgFile.Export,
+			Polling:         cfgFile.Polling,
+			Notifications:   cfgFile.Notifications,
+			AlertThresholds: notify.Thresholds{Warn: cfgFile.UI.WarnThreshold, Crit: cfgFile.UI.CritThreshold},
+			CapacityFile:    capacity.ResolvePath(cfgFile.CapacityFile.Path),
+			Chaos:           chaosCfg,
+			IngestListen:    strings.TrimSpace(cfgFile.Telemetry.IngestListen),
+			IngestToken:     strings.TrimSpace(os.Getenv(daemon.EnvIngestToken)),
 		})
 	}
 
@@ -252,10 +272,10 @@ func newTelemetryDaemonCommand() *cobra.Command {
 	defaultSpoolDir, _ := telemetry.DefaultSpoolDir()
 
 	cmd.PersistentFlags().StringVar(&socketPath, "socket-path", defaultSocketPath, "path to telemetry daemon unix socket")
-	addDaemonRunFlags(cmd, &dbPath, &spoolDir, &interval, &collectInterval, &pollInterval, &verbose, defaultDBPath, defaultSpoolDir)
+	addDaemonRunFlags(cmd, &dbPath, &spoolDir, &interval, &collectInterval, &pollInterval, &verbose, &chaosSpec, defaultDBPath, defaultSpoolDir)
 
 	runCmd := newDaemonRunCommand(runDaemon)
-	addDaemonRunFlags(runCmd, &dbPath, &spoolDir, &interval, &collectInterval, &pollInterval, &verbose, defaultDBPath, defaultSpoolDir)
+	addDaemonRunFlags(runCmd, &dbPath, &spoolDir, &interval, &collectInterval, &pollInterval, &verbose, &chaosSpec, defaultDBPath, defaultSpoolDir)
 	cmd.AddCommand(runCmd)
 	cmd.AddCommand(newDaemonInstallCommand())
 	cmd.AddCommand(newDaemonUninstallCommand())
@@ -272,6 +292,7 @@ func addDaemonRunFlags(
 	collectInterval *time.Duration,
 	pollInterval *time.Duration,
 	verbose *bool,
+	chaosSpec *string,
 	defaultDBPath string,
 	defaultSpoolDir string,
 ) {
@@ -281,6 +302,7 @@ func addDaemonRunFlags(
 	cmd.Flags().DurationVar(collectInterval, "collect-interval", 0, "collector interval override (0 uses --interval)")
 	cmd.Flags().DurationVar(pollInterval, "poll-interval", 0, "provider poll interval override (0 uses --interval)")
 	cmd.Flags().BoolVar(verbose, "verbose", false, "enable daemon logs")
+	cmd.Flags().StringVar(chaosSpec, "chaos", os.Getenv(chaos.EnvVar), "development: inject provider failures, e.g. seed=42,rate=0.3,faults=timeout+auth+limited+malformed (default from "+chaos.EnvVar+")")
 }
 
 func newDaemonRunCommand(runE func(cmd *cobra.Command, args []string) error) *cobra.Command {
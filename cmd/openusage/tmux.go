@@ -291,11 +291,11 @@ const lastStatusTTL = 10 * time.Minute
 
 // lastStatusPath is the cache file for the most recent successful render.
 func lastStatusPath() string {
-	home, err := os.UserHomeDir()
-	if err != nil || home == "" {
+	dir := config.CacheDir()
+	if !filepath.IsAbs(dir) {
 		return ""
 	}
-	return filepath.Join(home, ".cache", "openusage", "tmux-laststatus")
+	return filepath.Join(dir, "tmux-laststatus")
 }
 
 // readLastStatus returns the last good rendered status if it exists and is
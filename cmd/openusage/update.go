@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/janekbaraniewski/openusage/internal/appupdate"
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/version"
+)
+
+func newUpdateCommand() *cobra.Command {
+	var (
+		checkOnly bool
+		channel   string
+		force     bool
+		timeout   time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update openusage to the latest release",
+		Long: `update checks GitHub releases for a newer openusage on the configured
+channel ("update.channel" in settings.json: stable or nightly), verifies the
+release archive against checksums.txt — and the checksums' sigstore signature
+when cosign is installed — then atomically replaces the running binary.
+
+Installs managed by Homebrew, Scoop, Chocolatey, or go install are not
+replaced; the matching upgrade command is printed instead (override with
+--force).
+
+Examples:
+  openusage update
+  openusage update --check
+  openusage update --channel nightly
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			selected := appupdate.ParseChannel(cfg.Update.Channel)
+			if cmd.Flags().Changed("channel") {
+				selected = appupdate.ParseChannel(channel)
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			return runUpdate(ctx, cmd.OutOrStdout(), updateOptions{
+				currentVersion: strings.TrimSpace(version.Version),
+				channel:        selected,
+				checkOnly:      checkOnly,
+				force:          force,
+				check:          appupdate.Check,
+				apply:          appupdate.Apply,
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&checkOnly, "check", false, "Only report whether an update is available")
+	cmd.Flags().StringVar(&channel, "channel", string(appupdate.ChannelStable), "Release channel: stable or nightly (default from settings)")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace the binary even when a package manager owns it")
+	cmd.Flags().DurationVar(&timeout, "timeout", 3*time.Minute, "Overall timeout for the check and download")
+	return cmd
+}
+
+type updateOptions struct {
+	currentVersion string
+	channel        appupdate.Channel
+	checkOnly      bool
+	force          bool
+	check          func(context.Context, appupdate.CheckOptions) (appupdate.Result, error)
+	apply          func(context.Context, appupdate.ApplyOptions) (appupdate.ApplyResult, error)
+}
+
+func runUpdate(ctx context.Context, out io.Writer, opts updateOptions) error {
+	result, err := opts.check(ctx, appupdate.CheckOptions{
+		CurrentVersion: opts.currentVersion,
+		Channel:        opts.channel,
+		Timeout:        15 * time.Second,
+	})
+	if err != nil {
+		return fmt.Errorf("update check: %w", err)
+	}
+	if result.CurrentVersion == "" {
+		return fmt.Errorf("update: running a development build (%q); install a %s release first", opts.currentVersion, result.Channel)
+	}
+	if !result.UpdateAvailable {
+		fmt.Fprintf(out, "openusage %s is up to date (%s channel)\n", result.CurrentVersion, result.Channel)
+		return nil
+	}
+
+	fmt.Fprintf(out, "Update available: %s -> %s (%s channel)\n", result.CurrentVersion, result.LatestVersion, result.Channel)
+	if opts.checkOnly {
+		return nil
+	}
+	if !appupdate.SelfUpdateSupported(result.InstallMethod) && !opts.force {
+		fmt.Fprintf(out, "openusage is managed by %s; run: %s\n", result.InstallMethod, result.UpgradeHint)
+		return nil
+	}
+
+	applied, err := opts.apply(ctx, appupdate.ApplyOptions{
+		Release:         result.Release,
+		Version:         result.LatestVersion,
+		VerifySignature: appupdate.CosignVerifier(),
+	})
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	fmt.Fprintf(out, "Verified %s against checksums.txt", applied.Archive)
+	if applied.SignatureVerified {
+		fmt.Fprint(out, " (sigstore signature OK)")
+	} else if applied.SignatureNote != "" {
+		fmt.Fprintf(out, " (%s)", applied.SignatureNote)
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Updated %s to %s\n", applied.ExecutablePath, result.LatestVersion)
+	fmt.Fprintln(out, "Restart the daemon to pick up the new binary: openusage telemetry daemon install")
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/appupdate"
+)
+
+func stubUpdateCheck(result appupdate.Result) func(context.Context, appupdate.CheckOptions) (appupdate.Result, error) {
+	return func(_ context.Context, opts appupdate.CheckOptions) (appupdate.Result, error) {
+		result.Channel = opts.Channel
+		return result, nil
+	}
+}
+
+func TestRunUpdate(t *testing.T) {
+	available := appupdate.Result{
+		UpdateAvailable: true,
+		CurrentVersion:  "v1.2.0",
+		LatestVersion:   "v1.3.0",
+		InstallMethod:   appupdate.InstallMethodInstallScript,
+	}
+
+	tests := []struct {
+		name       string
+		result     appupdate.Result
+		checkOnly  bool
+		force      bool
+		wantApply  bool
+		wantOutput string
+	}{
+		{
+			name:       "up to date",
+			result:     appupdate.Result{CurrentVersion: "v1.3.0", LatestVersion: "v1.3.0"},
+			wantOutput: "openusage v1.3.0 is up to date (nightly channel)",
+		},
+		{
+			name:       "check only",
+			result:     available,
+			checkOnly:  true,
+			wantOutput: "Update available: v1.2.0 -> v1.3.0",
+		},
+		{
+			name: "package manager install",
+			result: appupdate.Result{
+				UpdateAvailable: true,
+				CurrentVersion:  "v1.2.0",
+				LatestVersion:   "v1.3.0",
+				InstallMethod:   appupdate.InstallMethodHomebrew,
+				UpgradeHint:     "brew upgrade janekbaraniewski/tap/openusage",
+			},
+			wantOutput: "run: brew upgrade janekbaraniewski/tap/openusage",
+		},
+		{
+			name: "forced package manager install",
+			result: appupdate.Result{
+				UpdateAvailable: true,
+				CurrentVersion:  "v1.2.0",
+				LatestVersion:   "v1.3.0",
+				InstallMethod:   appupdate.InstallMethodHomebrew,
+			},
+			force:      true,
+			wantApply:  true,
+			wantOutput: "Updated /usr/local/bin/openusage to v1.3.0",
+		},
+		{
+			name:       "self update",
+			result:     available,
+			wantApply:  true,
+			wantOutput: "(sigstore signature OK)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied := false
+			var out bytes.Buffer
+			err := runUpdate(context.Background(), &out, updateOptions{
+				currentVersion: "v1.2.0",
+				channel:        appupdate.ChannelNightly,
+				checkOnly:      tt.checkOnly,
+				force:          tt.force,
+				check:          stubUpdateCheck(tt.result),
+				apply: func(_ context.Context, opts appupdate.ApplyOptions) (appupdate.ApplyResult, error) {
+					applied = true
+					if opts.Version != "v1.3.0" {
+						t.Fatalf("apply Version = %q, want v1.3.0", opts.Version)
+					}
+					return appupdate.ApplyResult{
+						ExecutablePath:    "/usr/local/bin/openusage",
+						Archive:           "openusage_1.3.0_linux_amd64.tar.gz",
+						SignatureVerified: true,
+					}, nil
+				},
+			})
+			if err != nil {
+				t.Fatalf("runUpdate() error = %v", err)
+			}
+			if applied != tt.wantApply {
+				t.Fatalf("apply called = %v, want %v", applied, tt.wantApply)
+			}
+			if !strings.Contains(out.String(), tt.wantOutput) {
+				t.Fatalf("output = %q, want substring %q", out.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestRunUpdateRejectsDevBuild(t *testing.T) {
+	err := runUpdate(context.Background(), &bytes.Buffer{}, updateOptions{
+		currentVersion: "dev",
+		channel:        appupdate.ChannelStable,
+		check:          stubUpdateCheck(appupdate.Result{}),
+	})
+	if err == nil || !strings.Contains(err.Error(), "development build") {
+		t.Fatalf("runUpdate() error = %v, want development build error", err)
+	}
+}
@@ -86,6 +86,8 @@ const sidebars: SidebarsConfig = {
             'providers/alibaba-cloud',
           ],
         },
+        'providers/webhook',
+        'providers/openusage',
       ],
     },
     {
@@ -124,6 +126,7 @@ const sidebars: SidebarsConfig = {
         'reference/env-vars',
         'reference/paths',
         'reference/keybindings',
+        'reference/go-sdk',
       ],
     },
     {
@@ -133,6 +136,7 @@ const sidebars: SidebarsConfig = {
       link: {type: 'generated-index', slug: '/guides'},
       items: [
         'guides/multi-account',
+        'guides/custom-providers',
         'guides/team-tracking',
         'guides/cost-attribution',
         'guides/usage-projections',
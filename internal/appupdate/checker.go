@@ -16,6 +16,7 @@ import (
 
 const (
 	defaultLatestReleaseURL = "https://api.github.com/repos/janekbaraniewski/openusage/releases/latest"
+	defaultReleasesURL      = "https://api.github.com/repos/janekbaraniewski/openusage/releases?per_page=30"
 	defaultInstallScriptURL = "https://github.com/janekbaraniewski/openusage/releases/latest/download/install.sh"
 	defaultRequestTimeout   = 1500 * time.Millisecond
 )
@@ -31,54 +32,155 @@ const (
 	InstallMethodChocolatey    InstallMethod = "chocolatey"
 )
 
+// Channel selects which GitHub releases are considered. Stable only looks at
+// the latest non-prerelease; nightly also accepts prerelease tags
+// (`-rc`, `-nightly`, ...), which goreleaser publishes as GitHub prereleases.
+type Channel string
+
+const (
+	ChannelStable  Channel = "stable"
+	ChannelNightly Channel = "nightly"
+)
+
+// ParseChannel maps a config/flag value to a Channel, defaulting to stable.
+func ParseChannel(value string) Channel {
+	switch Channel(strings.ToLower(strings.TrimSpace(value))) {
+	case ChannelNightly:
+		return ChannelNightly
+	default:
+		return ChannelStable
+	}
+}
+
 type CheckOptions struct {
 	CurrentVersion   string
 	ExecutablePath   string
 	LatestReleaseURL string
-	Timeout          time.Duration
-	HTTPClient       *http.Client
+	// ReleasesURL lists releases for the nightly channel; empty uses the
+	// GitHub releases endpoint.
+	ReleasesURL string
+	Channel     Channel
+	Timeout     time.Duration
+	HTTPClient  *http.Client
+}
+
+// Asset is a downloadable file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Release is the subset of the GitHub release payload the updater needs.
+type Release struct {
+	TagName    string  `json:"tag_name"`
+	Draft      bool    `json:"draft"`
+	Prerelease bool    `json:"prerelease"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset returns the release asset with the given file name.
+func (r Release) Asset(name string) (Asset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return Asset{}, false
 }
 
 type Result struct {
 	UpdateAvailable bool
 	CurrentVersion  string
 	LatestVersion   string
+	Channel         Channel
 	InstallMethod   InstallMethod
 	UpgradeHint     string
 	ExecutablePath  string
+	// Release is the newest release on the channel; populated whenever the
+	// check reached GitHub, and consumed by Apply.
+	Release Release
 }
 
 func Check(ctx context.Context, opts CheckOptions) (Result, error) {
-	currentVersion := normalizeReleaseVersion(opts.CurrentVersion)
+	channel := ParseChannel(string(opts.Channel))
+	currentVersion := normalizeChannelVersion(opts.CurrentVersion, channel)
 	executablePath := resolveExecutablePath(opts.ExecutablePath)
 	method := detectInstallMethod(executablePath)
 
 	result := Result{
 		CurrentVersion: currentVersion,
+		Channel:        channel,
 		InstallMethod:  method,
 		UpgradeHint:    upgradeHint(method),
 		ExecutablePath: executablePath,
 	}
 
-	// Only check updates for stable semver releases.
+	// Only check updates for semver release builds (stable-only unless the
+	// nightly channel is selected).
 	if currentVersion == "" {
 		return result, nil
 	}
 
-	latestVersion, err := fetchLatestReleaseVersion(ctx, opts, currentVersion)
+	release, latestVersion, err := fetchLatestRelease(ctx, opts, channel, currentVersion)
 	if err != nil {
 		return result, err
 	}
 
+	result.Release = release
 	result.LatestVersion = latestVersion
 	result.UpdateAvailable = semver.Compare(latestVersion, currentVersion) > 0
 	return result, nil
 }
 
-func fetchLatestReleaseVersion(ctx context.Context, opts CheckOptions, currentVersion string) (string, error) {
-	latestURL := strings.TrimSpace(opts.LatestReleaseURL)
+func fetchLatestRelease(ctx context.Context, opts CheckOptions, channel Channel, currentVersion string) (Release, string, error) {
+	if channel == ChannelNightly {
+		return fetchNewestRelease(ctx, opts, currentVersion)
+	}
+
+	var release Release
+	if err := fetchReleaseJSON(ctx, opts, opts.LatestReleaseURL, defaultLatestReleaseURL, currentVersion, &release); err != nil {
+		return Release{}, "", err
+	}
+	latest := normalizeReleaseVersion(release.TagName)
+	if latest == "" {
+		return Release{}, "", fmt.Errorf("latest release tag is not a stable semver: %q", release.TagName)
+	}
+	return release, latest, nil
+}
+
+// fetchNewestRelease lists recent releases and picks the highest semver tag,
+// prereleases included. Drafts are never returned by the public API but are
+// skipped defensively.
+func fetchNewestRelease(ctx context.Context, opts CheckOptions, currentVersion string) (Release, string, error) {
+	var releases []Release
+	if err := fetchReleaseJSON(ctx, opts, opts.ReleasesURL, defaultReleasesURL, currentVersion, &releases); err != nil {
+		return Release{}, "", err
+	}
+
+	var best Release
+	bestVersion := ""
+	for _, release := range releases {
+		if release.Draft {
+			continue
+		}
+		version := normalizeChannelVersion(release.TagName, ChannelNightly)
+		if version == "" {
+			continue
+		}
+		if bestVersion == "" || semver.Compare(version, bestVersion) > 0 {
+			best, bestVersion = release, version
+		}
+	}
+	if bestVersion == "" {
+		return Release{}, "", fmt.Errorf("no semver releases found")
+	}
+	return best, bestVersion, nil
+}
+
+func fetchReleaseJSON(ctx context.Context, opts CheckOptions, rawURL, defaultURL, currentVersion string, out any) error {
+	latestURL := strings.TrimSpace(rawURL)
 	if latestURL == "" {
-		latestURL = defaultLatestReleaseURL
+		latestURL = defaultURL
 	}
 
 	timeout := opts.Timeout
@@ -96,7 +198,7 @@ func fetchLatestReleaseVersion(ctx context.Context, opts CheckOptions, currentVe
 
 	req, err := http.NewRequestWithContext(requestCtx, http.MethodGet, latestURL, nil)
 	if err != nil {
-		return "", fmt.Errorf("build latest release request: %w", err)
+		return fmt.Errorf("build latest release request: %w", err)
 	}
 	req.Header.Set("Accept", "application/vnd.github+json")
 	req.Header.Set("User-Agent", "openusage/"+currentVersion)
@@ -106,26 +208,18 @@ func fetchLatestReleaseVersion(ctx context.Context, opts CheckOptions, currentVe
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("fetch latest release: %w", err)
+		return fmt.Errorf("fetch latest release: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("fetch latest release: HTTP %d", resp.StatusCode)
+		return fmt.Errorf("fetch latest release: HTTP %d", resp.StatusCode)
 	}
 
-	var payload struct {
-		TagName string `json:"tag_name"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return "", fmt.Errorf("decode latest release payload: %w", err)
-	}
-
-	latest := normalizeReleaseVersion(payload.TagName)
-	if latest == "" {
-		return "", fmt.Errorf("latest release tag is not a stable semver: %q", payload.TagName)
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode latest release payload: %w", err)
 	}
-	return latest, nil
+	return nil
 }
 
 func resolveExecutablePath(explicitPath string) string {
@@ -229,7 +323,7 @@ func upgradeHint(method InstallMethod) string {
 	case InstallMethodGoInstall:
 		return "go install github.com/janekbaraniewski/openusage/cmd/openusage@latest"
 	case InstallMethodInstallScript:
-		return "curl -fsSL " + defaultInstallScriptURL + " | bash"
+		return "openusage update"
 	case InstallMethodScoop:
 		return "scoop update openusage"
 	case InstallMethodChocolatey:
@@ -240,6 +334,13 @@ func upgradeHint(method InstallMethod) string {
 }
 
 func normalizeReleaseVersion(value string) string {
+	return normalizeChannelVersion(value, ChannelStable)
+}
+
+// normalizeChannelVersion canonicalizes a release tag. Stable rejects
+// prerelease and build metadata; nightly accepts prereleases so `-rc`/
+// `-nightly` builds compare against each other.
+func normalizeChannelVersion(value string, channel Channel) string {
 	v := strings.TrimSpace(value)
 	if v == "" {
 		return ""
@@ -250,7 +351,10 @@ func normalizeReleaseVersion(value string) string {
 	if !semver.IsValid(v) {
 		return ""
 	}
-	if semver.Prerelease(v) != "" || semver.Build(v) != "" {
+	if semver.Build(v) != "" {
+		return ""
+	}
+	if semver.Prerelease(v) != "" && channel != ChannelNightly {
 		return ""
 	}
 	return semver.Canonical(v)
@@ -277,3 +277,61 @@ func TestShouldAttachGitHubToken(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckNightlyChannelConsidersPrereleases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"tag_name":"v1.3.0"},
+			{"tag_name":"v1.4.0-nightly.20261014","prerelease":true,"assets":[{"name":"checksums.txt","browser_download_url":"https://example.com/c"}]},
+			{"tag_name":"v1.5.0-rc.1","draft":true},
+			{"tag_name":"not-a-version"}
+		]`))
+	}))
+	defer server.Close()
+
+	result, err := Check(context.Background(), CheckOptions{
+		CurrentVersion: "v1.4.0-nightly.20261001",
+		Channel:        ChannelNightly,
+		ReleasesURL:    server.URL,
+		HTTPClient:     server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !result.UpdateAvailable {
+		t.Fatal("expected UpdateAvailable=true")
+	}
+	if result.LatestVersion != "v1.4.0-nightly.20261014" {
+		t.Fatalf("LatestVersion = %q, want v1.4.0-nightly.20261014", result.LatestVersion)
+	}
+	if _, ok := result.Release.Asset("checksums.txt"); !ok {
+		t.Fatal("expected release assets to be carried through")
+	}
+}
+
+func TestCheckStableChannelSkipsPrereleaseCurrentVersion(t *testing.T) {
+	result, err := Check(context.Background(), CheckOptions{
+		CurrentVersion:   "v1.4.0-rc.1",
+		LatestReleaseURL: "http://127.0.0.1:0/does-not-matter",
+	})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if result.CurrentVersion != "" || result.UpdateAvailable {
+		t.Fatalf("stable channel should ignore prerelease builds, got %+v", result)
+	}
+}
+
+func TestParseChannel(t *testing.T) {
+	tests := map[string]Channel{
+		"":         ChannelStable,
+		"stable":   ChannelStable,
+		" Nightly": ChannelNightly,
+		"beta":     ChannelStable,
+	}
+	for in, want := range tests {
+		if got := ParseChannel(in); got != want {
+			t.Errorf("ParseChannel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,389 @@
+package appupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	checksumsAssetName     = "checksums.txt"
+	signatureBundleSuffix  = ".sigstore.json"
+	defaultDownloadTimeout = 2 * time.Minute
+	maxArchiveBytes        = 256 << 20
+	binaryName             = "openusage"
+
+	cosignIdentityRegexp = `https://github\.com/janekbaraniewski/openusage/\.github/workflows/`
+	cosignOIDCIssuer     = "https://token.actions.githubusercontent.com"
+)
+
+// ErrNoSignatureVerifier is returned by a SignatureVerifier when no verifier
+// is available on this machine. Apply treats it as "signature not checked"
+// rather than a failure; the SHA-256 checksum is still mandatory.
+var ErrNoSignatureVerifier = errors.New("signature verifier unavailable")
+
+// SignatureVerifier checks a sigstore bundle for a downloaded artifact.
+type SignatureVerifier func(ctx context.Context, artifactPath, bundlePath string) error
+
+type ApplyOptions struct {
+	Release Release
+	Version string // canonical version of Release (Result.LatestVersion)
+
+	// ExecutablePath is the binary to replace; empty uses os.Executable().
+	ExecutablePath string
+	GOOS           string
+	GOARCH         string
+	HTTPClient     *http.Client
+	Timeout        time.Duration
+	// VerifySignature checks checksums.txt against its sigstore bundle. Nil
+	// skips signature verification (the checksum is still enforced).
+	VerifySignature SignatureVerifier
+}
+
+type ApplyResult struct {
+	ExecutablePath    string
+	Archive           string
+	SignatureVerified bool
+	// SignatureNote explains why the signature was not verified, if it was not.
+	SignatureNote string
+}
+
+// ArchiveName returns the goreleaser archive name for a version/platform,
+// e.g. openusage_1.2.3_linux_amd64.tar.gz.
+func ArchiveName(version, goos, goarch string) string {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("%s_%s_%s_%s%s", binaryName, strings.TrimPrefix(version, "v"), goos, goarch, ext)
+}
+
+// SelfUpdateSupported reports whether Apply may replace the binary for the
+// given install method. Package-manager installs must be upgraded through
+// their manager so its bookkeeping stays consistent.
+func SelfUpdateSupported(method InstallMethod) bool {
+	switch method {
+	case InstallMethodInstallScript, InstallMethodUnknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// Apply downloads the release archive for this platform, verifies it against
+// the release checksums (and, when a verifier is supplied, the sigstore
+// signature of the checksums file), and atomically replaces the executable.
+func Apply(ctx context.Context, opts ApplyOptions) (ApplyResult, error) {
+	goos, goarch := opts.GOOS, opts.GOARCH
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultDownloadTimeout
+	}
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: timeout}
+	}
+
+	exePath, err := resolveReplaceTarget(opts.ExecutablePath)
+	if err != nil {
+		return ApplyResult{}, err
+	}
+	result := ApplyResult{ExecutablePath: exePath}
+
+	archiveName := ArchiveName(opts.Version, goos, goarch)
+	result.Archive = archiveName
+	archiveAsset, ok := opts.Release.Asset(archiveName)
+	if !ok {
+		return result, fmt.Errorf("release %s has no asset %s for %s/%s", opts.Release.TagName, archiveName, goos, goarch)
+	}
+	checksumsAsset, ok := opts.Release.Asset(checksumsAssetName)
+	if !ok {
+		return result, fmt.Errorf("release %s has no %s; refusing to install an unverified binary", opts.Release.TagName, checksumsAssetName)
+	}
+
+	workDir, err := os.MkdirTemp("", "openusage-update-*")
+	if err != nil {
+		return result, fmt.Errorf("create update work dir: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	checksumsPath := filepath.Join(workDir, checksumsAssetName)
+	if err := download(ctx, client, checksumsAsset.URL, checksumsPath); err != nil {
+		return result, fmt.Errorf("download %s: %w", checksumsAssetName, err)
+	}
+
+	result.SignatureVerified, result.SignatureNote, err = verifyChecksumsSignature(ctx, client, opts, checksumsPath, workDir)
+	if err != nil {
+		return result, fmt.Errorf("verify %s signature: %w", checksumsAssetName, err)
+	}
+
+	wantSum, err := lookupChecksum(checksumsPath, archiveName)
+	if err != nil {
+		return result, err
+	}
+
+	archivePath := filepath.Join(workDir, archiveName)
+	if err := download(ctx, client, archiveAsset.URL, archivePath); err != nil {
+		return result, fmt.Errorf("download %s: %w", archiveName, err)
+	}
+	gotSum, err := fileSHA256(archivePath)
+	if err != nil {
+		return result, err
+	}
+	if !strings.EqualFold(gotSum, wantSum) {
+		return result, fmt.Errorf("checksum mismatch for %s: got %s, want %s", archiveName, gotSum, wantSum)
+	}
+
+	binary, err := extractBinary(archivePath, goos)
+	if err != nil {
+		return result, err
+	}
+	if err := replaceExecutable(exePath, binary, goos); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// verifyChecksumsSignature returns whether the checksums file signature was
+// verified, a note explaining a benign skip, and an error only when a
+// signature was checked and rejected.
+func verifyChecksumsSignature(ctx context.Context, client *http.Client, opts ApplyOptions, checksumsPath, workDir string) (bool, string, error) {
+	if opts.VerifySignature == nil {
+		return false, "signature not checked", nil
+	}
+	bundleAsset, ok := opts.Release.Asset(checksumsAssetName + signatureBundleSuffix)
+	if !ok {
+		return false, "release has no signature bundle", nil
+	}
+	bundlePath := filepath.Join(workDir, bundleAsset.Name)
+	if err := download(ctx, client, bundleAsset.URL, bundlePath); err != nil {
+		return false, "", fmt.Errorf("download signature bundle: %w", err)
+	}
+	if err := opts.VerifySignature(ctx, checksumsPath, bundlePath); err != nil {
+		if errors.Is(err, ErrNoSignatureVerifier) {
+			return false, "signature not checked (install cosign to enable)", nil
+		}
+		return false, "", err
+	}
+	return true, "", nil
+}
+
+// CosignVerifier verifies sigstore bundles with the cosign CLI against the
+// release workflow's keyless identity. It returns ErrNoSignatureVerifier when
+// cosign is not on PATH.
+func CosignVerifier() SignatureVerifier {
+	return func(ctx context.Context, artifactPath, bundlePath string) error {
+		cosign, err := exec.LookPath("cosign")
+		if err != nil {
+			return ErrNoSignatureVerifier
+		}
+		cmd := exec.CommandContext(ctx, cosign, "verify-blob",
+			"--bundle", bundlePath,
+			"--certificate-identity-regexp", cosignIdentityRegexp,
+			"--certificate-oidc-issuer", cosignOIDCIssuer,
+			artifactPath,
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("cosign verify-blob: %w: %s", err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+}
+
+func resolveReplaceTarget(explicitPath string) (string, error) {
+	path := strings.TrimSpace(explicitPath)
+	if path == "" {
+		exe, err := os.Executable()
+		if err != nil {
+			return "", fmt.Errorf("resolve executable: %w", err)
+		}
+		path = exe
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil && resolved != "" {
+		path = resolved
+	}
+	return path, nil
+}
+
+func download(ctx context.Context, client *http.Client, rawURL, dest string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "openusage-updater")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	n, copyErr := io.Copy(f, io.LimitReader(resp.Body, maxArchiveBytes+1))
+	closeErr := f.Close()
+	if copyErr != nil {
+		return copyErr
+	}
+	if n > maxArchiveBytes {
+		return fmt.Errorf("response exceeds %d bytes", maxArchiveBytes)
+	}
+	return closeErr
+}
+
+// lookupChecksum finds the SHA-256 for name in a goreleaser checksums file
+// ("<hex>  <name>" per line).
+func lookupChecksum(checksumsPath, name string) (string, error) {
+	f, err := os.Open(checksumsPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return fields[0], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("read %s: %w", checksumsAssetName, err)
+	}
+	return "", fmt.Errorf("%s has no entry for %s", checksumsAssetName, name)
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func extractBinary(archivePath, goos string) ([]byte, error) {
+	want := binaryName
+	if goos == "windows" {
+		want += ".exe"
+	}
+	if strings.HasSuffix(archivePath, ".zip") {
+		return extractFromZip(archivePath, want)
+	}
+	return extractFromTarGz(archivePath, want)
+}
+
+func extractFromTarGz(archivePath, want string) ([]byte, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || filepath.Base(hdr.Name) != want {
+			continue
+		}
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, io.LimitReader(tr, maxArchiveBytes)); err != nil {
+			return nil, fmt.Errorf("extract %s: %w", want, err)
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("archive has no %s binary", want)
+}
+
+func extractFromZip(archivePath, want string) ([]byte, error) {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+	defer zr.Close()
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() || filepath.Base(file.Name) != want {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("extract %s: %w", want, err)
+		}
+		var buf bytes.Buffer
+		_, copyErr := io.Copy(&buf, io.LimitReader(rc, maxArchiveBytes))
+		rc.Close()
+		if copyErr != nil {
+			return nil, fmt.Errorf("extract %s: %w", want, copyErr)
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("archive has no %s binary", want)
+}
+
+// replaceExecutable writes the new binary next to the old one and renames it
+// into place so a crash never leaves a half-written executable. Windows
+// cannot overwrite a running .exe, so the old one is moved aside first.
+func replaceExecutable(exePath string, binary []byte, goos string) error {
+	mode := os.FileMode(0o755)
+	if info, err := os.Stat(exePath); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmpPath := exePath + ".new"
+	if err := os.WriteFile(tmpPath, binary, mode); err != nil {
+		return fmt.Errorf("write new binary: %w", err)
+	}
+	defer os.Remove(tmpPath) // no-op after a successful rename
+
+	if goos == "windows" {
+		oldPath := exePath + ".old"
+		_ = os.Remove(oldPath)
+		if err := os.Rename(exePath, oldPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("move old binary aside: %w", err)
+		}
+	}
+	if err := os.Rename(tmpPath, exePath); err != nil {
+		return fmt.Errorf("replace binary: %w", err)
+	}
+	return nil
+}
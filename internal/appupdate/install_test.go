@@ -0,0 +1,215 @@
+package appupdate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func buildTarGz(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+type releaseFixture struct {
+	server  *httptest.Server
+	release Release
+}
+
+func newReleaseFixture(t *testing.T, archive []byte, checksum string, withBundle bool) releaseFixture {
+	t.Helper()
+	archiveName := ArchiveName("v1.3.0", "linux", "amd64")
+	checksums := checksum + "  " + archiveName + "\n" + strings.Repeat("0", 64) + "  other.zip\n"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/"+archiveName, func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write(archive) })
+	mux.HandleFunc("/checksums.txt", func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte(checksums)) })
+	mux.HandleFunc("/checksums.txt.sigstore.json", func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte(`{}`)) })
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	release := Release{
+		TagName: "v1.3.0",
+		Assets: []Asset{
+			{Name: archiveName, URL: server.URL + "/" + archiveName},
+			{Name: "checksums.txt", URL: server.URL + "/checksums.txt"},
+		},
+	}
+	if withBundle {
+		release.Assets = append(release.Assets, Asset{Name: "checksums.txt.sigstore.json", URL: server.URL + "/checksums.txt.sigstore.json"})
+	}
+	return releaseFixture{server: server, release: release}
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestApplyReplacesBinaryAfterChecksumVerification(t *testing.T) {
+	archive := buildTarGz(t, "openusage", []byte("new-binary"))
+	fx := newReleaseFixture(t, archive, sha256Hex(archive), true)
+
+	exePath := filepath.Join(t.TempDir(), "openusage")
+	if err := os.WriteFile(exePath, []byte("old-binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	verifierCalled := false
+	result, err := Apply(context.Background(), ApplyOptions{
+		Release:        fx.release,
+		Version:        "v1.3.0",
+		ExecutablePath: exePath,
+		GOOS:           "linux",
+		GOARCH:         "amd64",
+		HTTPClient:     fx.server.Client(),
+		VerifySignature: func(_ context.Context, artifactPath, bundlePath string) error {
+			verifierCalled = true
+			if filepath.Base(artifactPath) != "checksums.txt" || !strings.HasSuffix(bundlePath, ".sigstore.json") {
+				t.Errorf("verifier got artifact=%q bundle=%q", artifactPath, bundlePath)
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if !verifierCalled || !result.SignatureVerified {
+		t.Fatalf("expected signature verification, result = %+v", result)
+	}
+	got, err := os.ReadFile(exePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "new-binary" {
+		t.Fatalf("binary content = %q, want new-binary", got)
+	}
+	info, err := os.Stat(exePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Fatalf("replaced binary lost executable bit: %v", info.Mode())
+	}
+}
+
+func TestApplyRejectsChecksumMismatch(t *testing.T) {
+	archive := buildTarGz(t, "openusage", []byte("tampered"))
+	fx := newReleaseFixture(t, archive, strings.Repeat("a", 64), false)
+
+	exePath := filepath.Join(t.TempDir(), "openusage")
+	if err := os.WriteFile(exePath, []byte("old-binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Apply(context.Background(), ApplyOptions{
+		Release:        fx.release,
+		Version:        "v1.3.0",
+		ExecutablePath: exePath,
+		GOOS:           "linux",
+		GOARCH:         "amd64",
+		HTTPClient:     fx.server.Client(),
+	})
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("Apply() error = %v, want checksum mismatch", err)
+	}
+	got, _ := os.ReadFile(exePath)
+	if string(got) != "old-binary" {
+		t.Fatalf("binary was replaced despite mismatch: %q", got)
+	}
+}
+
+func TestApplyRejectsFailedSignature(t *testing.T) {
+	archive := buildTarGz(t, "openusage", []byte("new-binary"))
+	fx := newReleaseFixture(t, archive, sha256Hex(archive), true)
+	exePath := filepath.Join(t.TempDir(), "openusage")
+	if err := os.WriteFile(exePath, []byte("old-binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Apply(context.Background(), ApplyOptions{
+		Release:         fx.release,
+		Version:         "v1.3.0",
+		ExecutablePath:  exePath,
+		GOOS:            "linux",
+		GOARCH:          "amd64",
+		HTTPClient:      fx.server.Client(),
+		VerifySignature: func(context.Context, string, string) error { return errors.New("bad signature") },
+	})
+	if err == nil || !strings.Contains(err.Error(), "bad signature") {
+		t.Fatalf("Apply() error = %v, want signature failure", err)
+	}
+}
+
+func TestApplyMissingVerifierIsNotFatal(t *testing.T) {
+	archive := buildTarGz(t, "openusage", []byte("new-binary"))
+	fx := newReleaseFixture(t, archive, sha256Hex(archive), true)
+	exePath := filepath.Join(t.TempDir(), "openusage")
+	if err := os.WriteFile(exePath, []byte("old-binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Apply(context.Background(), ApplyOptions{
+		Release:         fx.release,
+		Version:         "v1.3.0",
+		ExecutablePath:  exePath,
+		GOOS:            "linux",
+		GOARCH:          "amd64",
+		HTTPClient:      fx.server.Client(),
+		VerifySignature: func(context.Context, string, string) error { return ErrNoSignatureVerifier },
+	})
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if result.SignatureVerified || result.SignatureNote == "" {
+		t.Fatalf("expected skipped-signature note, got %+v", result)
+	}
+}
+
+func TestApplyRequiresChecksums(t *testing.T) {
+	release := Release{TagName: "v1.3.0", Assets: []Asset{{Name: ArchiveName("v1.3.0", "linux", "amd64"), URL: "http://127.0.0.1:0/x"}}}
+	_, err := Apply(context.Background(), ApplyOptions{
+		Release:        release,
+		Version:        "v1.3.0",
+		ExecutablePath: filepath.Join(t.TempDir(), "openusage"),
+		GOOS:           "linux",
+		GOARCH:         "amd64",
+	})
+	if err == nil || !strings.Contains(err.Error(), "checksums.txt") {
+		t.Fatalf("Apply() error = %v, want missing checksums error", err)
+	}
+}
+
+func TestArchiveName(t *testing.T) {
+	if got := ArchiveName("v1.2.3", "darwin", "arm64"); got != "openusage_1.2.3_darwin_arm64.tar.gz" {
+		t.Fatalf("ArchiveName(darwin) = %q", got)
+	}
+	if got := ArchiveName("v1.2.3", "windows", "amd64"); got != "openusage_1.2.3_windows_amd64.zip" {
+		t.Fatalf("ArchiveName(windows) = %q", got)
+	}
+}
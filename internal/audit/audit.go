@@ -0,0 +1,277 @@
+// Package audit keeps a local, append-only record of the credentials
+// openusage reads and the endpoints it calls, so users can check it only
+// touches what it claims to. Secrets never reach the log: entries name where
+// a credential came from ("env:OPENAI_API_KEY", "keychain:Claude Safe
+// Storage"), never its value.
+//
+// Uses are aggregated per account and target: the first use in a process is
+// written straight away, later ones at most once per FlushInterval as a
+// single line carrying the count. `openusage audit` reads the log back.
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kind says what was accessed.
+type Kind string
+
+const (
+	// KindCredential is a secret read: an API key from the environment,
+	// a keychain item, a token or cookie file.
+	KindCredential Kind = "credential"
+	// KindEndpoint is an HTTP call, as "METHOD host/path".
+	KindEndpoint Kind = "endpoint"
+)
+
+// EnvPath overrides where the log is written. "off" disables it.
+const EnvPath = "OPENUSAGE_AUDIT_LOG"
+
+const (
+	// FlushInterval bounds how often repeated uses of one target are
+	// written.
+	FlushInterval = time.Hour
+	// maxLogBytes is the size at which the log is moved to Path()+".1",
+	// replacing the previous generation.
+	maxLogBytes = 10 << 20
+)
+
+// Entry is one line of the log.
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Kind     Kind      `json:"kind"`
+	Provider string    `json:"provider,omitempty"`
+	Account  string    `json:"account,omitempty"`
+	Target   string    `json:"target"`
+	// Count is the number of uses since the previous entry for the same
+	// account and target; Errors how many of those failed.
+	Count  int `json:"count"`
+	Errors int `json:"errors,omitempty"`
+}
+
+// Path returns the log file under stateDir, or "" when auditing is off.
+func Path(stateDir string) string {
+	if env := strings.TrimSpace(os.Getenv(EnvPath)); env != "" {
+		if strings.EqualFold(env, "off") {
+			return ""
+		}
+		return env
+	}
+	return filepath.Join(stateDir, "audit.jsonl")
+}
+
+// Log appends entries to one file. It is safe for concurrent use, and
+// several processes may append to the same file.
+type Log struct {
+	path string
+	now  func() time.Time
+
+	mu      sync.Mutex
+	pending map[entryKey]*pendingUse
+}
+
+type entryKey struct {
+	kind     Kind
+	provider string
+	account  string
+	target   string
+}
+
+type pendingUse struct {
+	lastWritten time.Time
+	count       int
+	errors      int
+}
+
+// New returns a log writing to path. An empty path discards everything.
+func New(path string) *Log {
+	return &Log{path: path, now: time.Now, pending: make(map[entryKey]*pendingUse)}
+}
+
+var (
+	defaultMu  sync.RWMutex
+	defaultLog = New("")
+)
+
+// Configure points the process-wide log at path. Until it is called, uses
+// are not recorded.
+func Configure(path string) {
+	defaultMu.Lock()
+	defaultLog = New(path)
+	defaultMu.Unlock()
+}
+
+// Default returns the process-wide log.
+func Default() *Log {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultLog
+}
+
+// Record notes count uses of target, failed of which failed.
+func (l *Log) Record(kind Kind, provider, account, target string, count, failed int) {
+	if l == nil || l.path == "" || target == "" || count <= 0 {
+		return
+	}
+	key := entryKey{kind: kind, provider: provider, account: account, target: target}
+	now := l.now()
+
+	l.mu.Lock()
+	use, seen := l.pending[key]
+	if !seen {
+		use = &pendingUse{}
+		l.pending[key] = use
+	}
+	use.count += count
+	use.errors += failed
+	if seen && now.Sub(use.lastWritten) < FlushInterval {
+		l.mu.Unlock()
+		return
+	}
+	entry := takeEntry(key, use, now)
+	l.mu.Unlock()
+
+	_ = l.write([]Entry{entry})
+}
+
+// Flush writes the uses not written yet, as when the process exits.
+func (l *Log) Flush() error {
+	if l == nil || l.path == "" {
+		return nil
+	}
+	now := l.now()
+	l.mu.Lock()
+	var entries []Entry
+	for key, use := range l.pending {
+		if use.count > 0 {
+			entries = append(entries, takeEntry(key, use, now))
+		}
+	}
+	l.mu.Unlock()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Target < entries[j].Target })
+	return l.write(entries)
+}
+
+func takeEntry(key entryKey, use *pendingUse, now time.Time) Entry {
+	entry := Entry{
+		Time:     now.UTC(),
+		Kind:     key.kind,
+		Provider: key.provider,
+		Account:  key.account,
+		Target:   key.target,
+		Count:    use.count,
+		Errors:   use.errors,
+	}
+	use.lastWritten, use.count, use.errors = now, 0, 0
+	return entry
+}
+
+// write appends entries, one JSON object per line. Each line goes out in a
+// single O_APPEND write, so lines from concurrent processes don't interleave.
+func (l *Log) write(entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return fmt.Errorf("audit: creating log dir: %w", err)
+	}
+	if info, err := os.Stat(l.path); err == nil && info.Size() >= maxLogBytes {
+		_ = os.Rename(l.path, l.path+".1")
+	}
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("audit: opening log: %w", err)
+	}
+	defer f.Close()
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("audit: writing log: %w", err)
+		}
+	}
+	return nil
+}
+
+// Read returns the entries at path and its previous generation, oldest
+// first, skipping lines it can't parse. A missing log reads as empty.
+func Read(path string) ([]Entry, error) {
+	var out []Entry
+	for _, p := range []string{path + ".1", path} {
+		entries, err := readFile(p)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, entries...)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Time.Before(out[j].Time) })
+	return out, nil
+}
+
+func readFile(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("audit: reading %s: %w", path, err)
+	}
+	defer f.Close()
+	var out []Entry
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		var entry Entry
+		if len(line) > 0 && json.Unmarshal(line, &entry) == nil && entry.Target != "" {
+			out = append(out, entry)
+		}
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("audit: reading %s: %w", path, err)
+		}
+	}
+}
+
+type accountKey struct{}
+
+type accountRef struct{ provider, account string }
+
+// WithAccount attributes the credential reads made under ctx to an account.
+func WithAccount(ctx context.Context, provider, account string) context.Context {
+	return context.WithValue(ctx, accountKey{}, accountRef{provider: provider, account: account})
+}
+
+// Credential records a credential read from source, attributed to the
+// account ctx carries, if any.
+func Credential(ctx context.Context, source string) {
+	ref, _ := ctx.Value(accountKey{}).(accountRef)
+	Default().Record(KindCredential, ref.provider, ref.account, source, 1, 0)
+}
+
+// ReadFile reads a file holding a credential and, when the read succeeds,
+// records it as "file:"+path under the account ctx carries.
+func ReadFile(ctx context.Context, path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		Credential(ctx, "file:"+path)
+	}
+	return data, err
+}
@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogAggregatesRepeatedUses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log := New(path)
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	log.now = func() time.Time { return now }
+
+	log.Record(KindEndpoint, "openai", "openai", "GET api.openai.com/v1/models", 1, 0)
+	now = now.Add(10 * time.Minute)
+	log.Record(KindEndpoint, "openai", "openai", "GET api.openai.com/v1/models", 2, 1)
+	now = now.Add(10 * time.Minute)
+	log.Record(KindEndpoint, "openai", "openai", "GET api.openai.com/v1/models", 1, 0)
+
+	entries, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Count != 1 {
+		t.Fatalf("entries before flush = %+v, want the first use only", entries)
+	}
+
+	now = now.Add(FlushInterval)
+	log.Record(KindEndpoint, "openai", "openai", "GET api.openai.com/v1/models", 1, 0)
+	entries, _ = Read(path)
+	if len(entries) != 2 {
+		t.Fatalf("entries after an hour = %d, want 2", len(entries))
+	}
+	if got := entries[1]; got.Count != 4 || got.Errors != 1 {
+		t.Fatalf("aggregated entry = %+v, want count 4 with 1 error", got)
+	}
+
+	log.Record(KindEndpoint, "openai", "openai", "GET api.openai.com/v1/models", 3, 0)
+	if err := log.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	entries, _ = Read(path)
+	if len(entries) != 3 || entries[2].Count != 3 {
+		t.Fatalf("entries after flush = %+v, want a third entry with count 3", entries)
+	}
+}
+
+func TestLogFilePermissionsAndRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	if err := os.WriteFile(path, make([]byte, maxLogBytes), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	log := New(path)
+	log.Record(KindCredential, "openai", "openai", "env:OPENAI_API_KEY", 1, 0)
+
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Fatalf("rotated log missing: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("log mode = %o, want 600", perm)
+	}
+	entries, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Target != "env:OPENAI_API_KEY" {
+		t.Fatalf("entries = %+v, want the credential entry", entries)
+	}
+}
+
+func TestCredentialAttributesAccount(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	Configure(path)
+	t.Cleanup(func() { Configure("") })
+
+	ctx := WithAccount(context.Background(), "claude_code", "claude-code")
+	Credential(ctx, "keychain:Claude Safe Storage")
+
+	entries, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("entries = %d, want 1", len(entries))
+	}
+	got := entries[0]
+	if got.Kind != KindCredential || got.Provider != "claude_code" || got.Account != "claude-code" {
+		t.Fatalf("entry = %+v, want a claude-code credential entry", got)
+	}
+}
+
+func TestPath(t *testing.T) {
+	t.Setenv(EnvPath, "")
+	if got, want := Path("/state"), filepath.Join("/state", "audit.jsonl"); got != want {
+		t.Fatalf("Path() = %q, want %q", got, want)
+	}
+	t.Setenv(EnvPath, "/tmp/custom.jsonl")
+	if got := Path("/state"); got != "/tmp/custom.jsonl" {
+		t.Fatalf("Path() with override = %q", got)
+	}
+	t.Setenv(EnvPath, "off")
+	if got := Path("/state"); got != "" {
+		t.Fatalf("Path() with off = %q, want empty", got)
+	}
+}
+
+func TestReadFileRecordsSuccessfulReads(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	Configure(logPath)
+	t.Cleanup(func() { Configure("") })
+
+	dir := t.TempDir()
+	credsPath := filepath.Join(dir, "auth.json")
+	if err := os.WriteFile(credsPath, []byte(`{"token":"secret-value"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctx := WithAccount(context.Background(), "codex", "codex-cli")
+	if _, err := ReadFile(ctx, credsPath); err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if _, err := ReadFile(ctx, filepath.Join(dir, "missing.json")); err == nil {
+		t.Fatal("ReadFile() of a missing file succeeded")
+	}
+
+	entries, err := Read(logPath)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Target != "file:"+credsPath || entries[0].Account != "codex-cli" {
+		t.Fatalf("entries = %+v, want one read of %s", entries, credsPath)
+	}
+	raw, _ := os.ReadFile(logPath)
+	if strings.Contains(string(raw), "secret-value") {
+		t.Fatalf("audit log contains the credential value:\n%s", raw)
+	}
+}
@@ -0,0 +1,244 @@
+// Package bugreport builds the tarball `openusage bugreport` writes: one
+// traced fetch of an account with credentials and emails redacted, so a
+// provider-parsing bug can be reproduced by someone without the account.
+package bugreport
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
+	"github.com/janekbaraniewski/openusage/internal/version"
+)
+
+// fetchTimeout matches the daemon's per-fetch budget so the report shows
+// what the daemon would see.
+const fetchTimeout = 8 * time.Second
+
+// Options describe the fetch to record.
+type Options struct {
+	Account  core.AccountConfig
+	Provider core.UsageProvider
+	// ReadOnly is recorded with the environment.
+	ReadOnly bool
+	// DaemonLog, when set, returns the running daemon's log lines; the ones
+	// mentioning the account are included.
+	DaemonLog func(ctx context.Context) ([]string, error)
+	Now       func() time.Time
+}
+
+// Report is one recorded fetch, not yet redacted.
+type Report struct {
+	CreatedAt time.Time
+	Account   core.AccountConfig
+	ReadOnly  bool
+	Snapshot  core.UsageSnapshot
+	FetchErr  error
+	Duration  time.Duration
+	Exchanges []shared.HTTPExchange
+	Log       []string
+	DaemonLog []string
+	DaemonErr error
+}
+
+// Collect fetches the account once with every HTTP exchange and log line
+// captured. The global logger writes to the report for the duration of the
+// fetch; callers should enable debug logging first (core.EnableDebug) to
+// get provider trace lines.
+func Collect(ctx context.Context, opts Options) *Report {
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
+	r := &Report{CreatedAt: now(), Account: opts.Account, ReadOnly: opts.ReadOnly}
+
+	shared.InstallEndpointInstrumentation()
+	trace := shared.NewHTTPTrace()
+	logs := core.NewLogRing(core.DebugLogCapacity)
+	prevOutput, prevFlags := log.Writer(), log.Flags()
+	log.SetOutput(logs)
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
+
+	fetchCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	fetchCtx = shared.WithHTTPTrace(fetchCtx, trace)
+	fetchCtx = shared.WithNetwork(fetchCtx, opts.Account.Network)
+	started := time.Now()
+	r.Snapshot, r.FetchErr = opts.Provider.Fetch(fetchCtx, opts.Account)
+	r.Duration = time.Since(started)
+	cancel()
+
+	log.SetOutput(prevOutput)
+	log.SetFlags(prevFlags)
+	r.Exchanges = trace.Exchanges()
+	r.Log = logs.Lines()
+
+	if opts.DaemonLog != nil {
+		logCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		lines, err := opts.DaemonLog(logCtx)
+		cancel()
+		r.DaemonErr = err
+		for _, line := range lines {
+			if strings.Contains(line, opts.Account.ID) {
+				r.DaemonLog = append(r.DaemonLog, line)
+			}
+		}
+	}
+	return r
+}
+
+// File is one entry of the tarball, as listed to the user.
+type File struct {
+	Name        string
+	Size        int
+	Description string
+}
+
+type environment struct {
+	Version   string    `json:"version"`
+	OS        string    `json:"os"`
+	Arch      string    `json:"arch"`
+	GoVersion string    `json:"go_version"`
+	CreatedAt time.Time `json:"created_at"`
+	Provider  string    `json:"provider"`
+	AccountID string    `json:"account_id"`
+	ReadOnly  bool      `json:"read_only"`
+	FetchMs   int64     `json:"fetch_ms"`
+	FetchErr  string    `json:"fetch_error,omitempty"`
+	Requests  int       `json:"http_requests"`
+}
+
+// Files renders the redacted contents of the report, in tarball order.
+func (r *Report) Files(red *Redactor) ([]File, map[string][]byte, error) {
+	var files []File
+	contents := make(map[string][]byte)
+	add := func(name, description string, data []byte) {
+		files = append(files, File{Name: name, Size: len(data), Description: description})
+		contents[name] = data
+	}
+	addJSON := func(name, description string, v any) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("bugreport: encoding %s: %w", name, err)
+		}
+		add(name, description, append(data, '\n'))
+		return nil
+	}
+
+	env := environment{
+		Version:   version.String(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		GoVersion: runtime.Version(),
+		CreatedAt: r.CreatedAt.UTC(),
+		Provider:  r.Account.Provider,
+		AccountID: r.Account.ID,
+		ReadOnly:  r.ReadOnly,
+		FetchMs:   r.Duration.Milliseconds(),
+		Requests:  len(r.Exchanges),
+	}
+	if r.FetchErr != nil {
+		env.FetchErr = red.String(r.FetchErr.Error())
+	}
+	if err := addJSON("environment.json", "openusage version, OS and fetch outcome", env); err != nil {
+		return nil, nil, err
+	}
+
+	acct := r.Account
+	acct.BaseURL = red.URL(acct.BaseURL)
+	acct.Binary = red.String(acct.Binary)
+	acct.ProviderPaths = redactPaths(red, acct.ProviderPaths)
+	acct.Paths = redactPaths(red, acct.Paths)
+	if acct.Network != nil {
+		n := *acct.Network
+		n.Proxy = red.URL(n.Proxy)
+		n.CABundle = red.String(n.CABundle)
+		acct.Network = &n
+	}
+	if err := addJSON("account.json", "the account's config entry and effective network settings", acct); err != nil {
+		return nil, nil, err
+	}
+
+	if err := addJSON("snapshot.json", "the snapshot the provider returned, before daemon post-processing", red.Snapshot(r.Snapshot)); err != nil {
+		return nil, nil, err
+	}
+
+	exchanges := make([]shared.HTTPExchange, len(r.Exchanges))
+	for i, ex := range r.Exchanges {
+		exchanges[i] = red.Exchange(ex)
+	}
+	if err := addJSON("http.json", fmt.Sprintf("%d HTTP request(s) with headers and bodies", len(exchanges)), exchanges); err != nil {
+		return nil, nil, err
+	}
+
+	add("fetch.log", "log output during the fetch", redactLines(red, r.Log))
+	if len(r.DaemonLog) > 0 {
+		add("daemon.log", "daemon log lines mentioning the account", redactLines(red, r.DaemonLog))
+	}
+	return files, contents, nil
+}
+
+// WriteTarGz writes the redacted report as a gzipped tarball whose entries
+// sit under dir, and returns what it contains.
+func (r *Report) WriteTarGz(w io.Writer, dir string, red *Redactor) ([]File, error) {
+	files, contents, err := r.Files(red)
+	if err != nil {
+		return nil, err
+	}
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, f := range files {
+		hdr := &tar.Header{
+			Name:    dir + "/" + f.Name,
+			Mode:    0o600,
+			Size:    int64(f.Size),
+			ModTime: r.CreatedAt,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, fmt.Errorf("bugreport: writing %s: %w", f.Name, err)
+		}
+		if _, err := tw.Write(contents[f.Name]); err != nil {
+			return nil, fmt.Errorf("bugreport: writing %s: %w", f.Name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("bugreport: closing tarball: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("bugreport: closing tarball: %w", err)
+	}
+	return files, nil
+}
+
+// Secrets returns the account's credential values, for NewRedactor.
+func Secrets(acct core.AccountConfig) []string {
+	return []string{acct.Token, acct.ResolveAPIKey()}
+}
+
+func redactPaths(red *Redactor, in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = red.String(v)
+	}
+	return out
+}
+
+func redactLines(red *Redactor, lines []string) []byte {
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(red.String(line))
+		b.WriteByte('\n')
+	}
+	return []byte(b.String())
+}
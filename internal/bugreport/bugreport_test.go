@@ -0,0 +1,199 @@
+package bugreport
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
+)
+
+const testKey = "sk-or-v1-0123456789abcdef0123456789abcdef"
+
+type fetchFunc struct {
+	core.UsageProvider
+	fetch func(ctx context.Context, acct core.AccountConfig) (core.UsageSnapshot, error)
+}
+
+func (p fetchFunc) Fetch(ctx context.Context, acct core.AccountConfig) (core.UsageSnapshot, error) {
+	return p.fetch(ctx, acct)
+}
+
+func TestCollect_WritesRedactedTarball(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ratelimit-Remaining-Tokens", "9000")
+		w.Header().Set("Set-Cookie", "session=abcdef0123456789")
+		_, _ = w.Write([]byte(`{"email":"jane.doe@example.com","usage":12.5,"refresh_token":"rt-0123456789","input_tokens":"42"}`))
+	}))
+	defer server.Close()
+
+	acct := core.AccountConfig{ID: "openrouter", Provider: "openrouter", Token: testKey}
+	provider := fetchFunc{fetch: func(ctx context.Context, acct core.AccountConfig) (core.UsageSnapshot, error) {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/key?api_key="+acct.Token, nil)
+		req.Header.Set("Authorization", "Bearer "+acct.Token)
+		resp, err := shared.NewHTTPClient(5 * time.Second).Do(req)
+		if err != nil {
+			return core.UsageSnapshot{}, err
+		}
+		resp.Body.Close()
+		log.Printf("fetched with key %s for jane.doe@example.com", acct.Token)
+		return core.UsageSnapshot{
+			ProviderID: "openrouter",
+			AccountID:  acct.ID,
+			Status:     core.StatusOK,
+			Message:    "$12.50 used by jane.doe@example.com",
+			Attributes: map[string]string{"account_email": "jane.doe@example.com", "tier": "paid"},
+		}, nil
+	}}
+
+	report := Collect(context.Background(), Options{Account: acct, Provider: provider})
+	if report.FetchErr != nil || len(report.Exchanges) != 1 {
+		t.Fatalf("fetch err = %v, exchanges = %d; want one traced request", report.FetchErr, len(report.Exchanges))
+	}
+
+	var buf bytes.Buffer
+	files, err := report.WriteTarGz(&buf, "report", NewRedactor(Secrets(acct)...))
+	if err != nil {
+		t.Fatalf("WriteTarGz: %v", err)
+	}
+	contents := readTarball(t, &buf)
+	if len(contents) != len(files) {
+		t.Fatalf("tarball has %d entries, summary lists %d", len(contents), len(files))
+	}
+	for _, name := range []string{"environment.json", "account.json", "snapshot.json", "http.json", "fetch.log"} {
+		if _, ok := contents["report/"+name]; !ok {
+			t.Fatalf("tarball missing %s; has %v", name, files)
+		}
+	}
+
+	all := strings.Join(mapValues(contents), "\n")
+	for _, leaked := range []string{testKey, "jane.doe@example.com", "rt-0123456789", "abcdef0123456789"} {
+		if strings.Contains(all, leaked) {
+			t.Errorf("report leaks %q", leaked)
+		}
+	}
+	for _, kept := range []string{"12.5", "$12.50", `"9000"`, `\"input_tokens\":\"42\"`, "fetched with key"} {
+		if !strings.Contains(all, kept) {
+			t.Errorf("report lost %q", kept)
+		}
+	}
+
+	var snap core.UsageSnapshot
+	if err := json.Unmarshal([]byte(contents["report/snapshot.json"]), &snap); err != nil {
+		t.Fatalf("snapshot.json: %v", err)
+	}
+	if snap.Attributes["tier"] != "paid" || snap.Attributes["account_email"] != "j•••@e•••.com" {
+		t.Fatalf("attributes = %v, want tier kept and email masked", snap.Attributes)
+	}
+}
+
+func TestCollect_RedactsFormEncodedRefresh(t *testing.T) {
+	const refreshToken = "1//0gRefreshTokenValue0123456789"
+	const clientSecret = "GOCSPX-clientsecret0123"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"ya29.fresh-access-token","expires_in":3599}`))
+	}))
+	defer server.Close()
+
+	acct := core.AccountConfig{ID: "gemini-cli", Provider: "gemini_cli"}
+	provider := fetchFunc{fetch: func(ctx context.Context, acct core.AccountConfig) (core.UsageSnapshot, error) {
+		form := url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {refreshToken},
+			"client_id":     {"client-id.apps.googleusercontent.com"},
+			"client_secret": {clientSecret},
+		}
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/token", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		resp, err := shared.NewHTTPClient(5 * time.Second).Do(req)
+		if err != nil {
+			return core.UsageSnapshot{}, err
+		}
+		resp.Body.Close()
+		return core.UsageSnapshot{ProviderID: "gemini_cli", AccountID: acct.ID, Status: core.StatusOK}, nil
+	}}
+
+	report := Collect(context.Background(), Options{Account: acct, Provider: provider})
+	if report.FetchErr != nil || len(report.Exchanges) != 1 {
+		t.Fatalf("fetch err = %v, exchanges = %d; want one traced request", report.FetchErr, len(report.Exchanges))
+	}
+	var buf bytes.Buffer
+	if _, err := report.WriteTarGz(&buf, "report", NewRedactor(Secrets(acct)...)); err != nil {
+		t.Fatalf("WriteTarGz: %v", err)
+	}
+	httpJSON := readTarball(t, &buf)["report/http.json"]
+	for _, leaked := range []string{refreshToken, url.QueryEscape(refreshToken), clientSecret, "ya29.fresh-access-token"} {
+		if strings.Contains(httpJSON, leaked) {
+			t.Errorf("http.json leaks %q:\n%s", leaked, httpJSON)
+		}
+	}
+	if !strings.Contains(httpJSON, "grant_type=refresh_token") {
+		t.Errorf("http.json lost the non-secret form fields:\n%s", httpJSON)
+	}
+}
+
+func TestRedactor_Header(t *testing.T) {
+	red := NewRedactor()
+	h := red.Header(http.Header{
+		"Authorization":                          {"Bearer abc"},
+		"X-Api-Key":                              {"abc"},
+		"Anthropic-Ratelimit-Tokens-Remaining":   {"1000"},
+		"Anthropic-Ratelimit-Requests-Reset":     {"2026-03-01T00:00:00Z"},
+		"X-Goog-Api-Key":                         {"AIza"},
+		"X-Csrf-Token":                           {"t"},
+		"Content-Type":                           {"application/json"},
+		"Openai-Organization":                    {"org-1"},
+		"Anthropic-Ratelimit-Input-Tokens-Reset": {"soon"},
+	})
+	for _, name := range []string{"Authorization", "X-Api-Key", "X-Goog-Api-Key", "X-Csrf-Token"} {
+		if got := h.Get(name); got != Redacted {
+			t.Errorf("%s = %q, want redacted", name, got)
+		}
+	}
+	for _, name := range []string{"Anthropic-Ratelimit-Tokens-Remaining", "Anthropic-Ratelimit-Requests-Reset", "Content-Type", "Anthropic-Ratelimit-Input-Tokens-Reset"} {
+		if got := h.Get(name); got == Redacted {
+			t.Errorf("%s was redacted, want it kept", name)
+		}
+	}
+}
+
+func readTarball(t *testing.T, r io.Reader) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	out := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return out
+		}
+		if err != nil {
+			t.Fatalf("tar: %v", err)
+		}
+		data, _ := io.ReadAll(tr)
+		out[hdr.Name] = string(data)
+	}
+}
+
+func mapValues(m map[string]string) []string {
+	out := make([]string, 0, len(m))
+	for _, v := range m {
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,195 @@
+package bugreport
+
+import (
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
+)
+
+// Redacted replaces every credential value in a report.
+const Redacted = "[REDACTED]"
+
+// minSecretLen keeps short values such as "1" or "on" out of the literal
+// secret list, where replacing them would shred unrelated text.
+const minSecretLen = 8
+
+var (
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer|basic|token)\s+[A-Za-z0-9._~+/=-]{8,}`)
+	// jsonSecretPattern matches a string member whose name says it holds a
+	// credential, e.g. "access_token": "…" or "apiKey":"…".
+	// "tokens" members are usage counts and are left alone.
+	jsonSecretPattern = regexp.MustCompile(`(?i)("[a-z0-9_-]*(?:token(?:[^s"][a-z0-9_-]*)?|(?:secret|password|api_?key|cookie|session|credential)[a-z0-9_-]*)"\s*:\s*)"[^"]*"`)
+	// keyLikePattern matches the shapes of common provider API keys.
+	keyLikePattern = regexp.MustCompile(`\b(?:sk|pk|rk|xai|gsk|pplx|nvapi|csk)-[A-Za-z0-9_-]{16,}|\bAIza[A-Za-z0-9_-]{30,}|\bgh[pousr]_[A-Za-z0-9]{30,}`)
+)
+
+// Redactor scrubs credentials, email addresses and the home directory from
+// everything written into a bug report. Dollar amounts and usage numbers
+// are kept: they are usually what the report is about.
+type Redactor struct {
+	secrets []string
+	home    string
+}
+
+// NewRedactor returns a redactor that also replaces the given literal
+// values, typically the account's resolved API key and token, wherever
+// they appear.
+func NewRedactor(secrets ...string) *Redactor {
+	r := &Redactor{}
+	for _, s := range secrets {
+		if s = strings.TrimSpace(s); len(s) >= minSecretLen {
+			r.secrets = append(r.secrets, s)
+		}
+	}
+	// Longest first, so a key isn't half-replaced by a secret it contains.
+	sort.Slice(r.secrets, func(i, j int) bool { return len(r.secrets[i]) > len(r.secrets[j]) })
+	if home, err := os.UserHomeDir(); err == nil && len(home) > 1 {
+		r.home = home
+	}
+	return r
+}
+
+// String redacts free text: known secrets, bearer tokens, credential-named
+// JSON members, key-shaped strings and emails. The home directory becomes ~.
+func (r *Redactor) String(s string) string {
+	if s == "" {
+		return s
+	}
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, Redacted)
+	}
+	s = bearerPattern.ReplaceAllString(s, "$1 "+Redacted)
+	s = jsonSecretPattern.ReplaceAllString(s, `$1"`+Redacted+`"`)
+	s = keyLikePattern.ReplaceAllString(s, Redacted)
+	if strings.Contains(s, "@") {
+		s = emailPattern.ReplaceAllStringFunc(s, core.MaskSensitiveValue)
+	}
+	if r.home != "" {
+		s = strings.ReplaceAll(s, r.home, "~")
+	}
+	return s
+}
+
+// Header redacts a header set: credential-bearing headers lose their value
+// entirely, the rest go through String.
+func (r *Redactor) Header(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+	out := make(http.Header, len(h))
+	for name, values := range h {
+		redacted := make([]string, len(values))
+		for i, v := range values {
+			if sensitiveName(name) {
+				redacted[i] = Redacted
+			} else {
+				redacted[i] = r.String(v)
+			}
+		}
+		out[name] = redacted
+	}
+	return out
+}
+
+// URL redacts credential query parameters and user info.
+func (r *Redactor) URL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return r.String(raw)
+	}
+	if u.User != nil {
+		u.User = url.User(Redacted)
+	}
+	if q := u.Query(); len(q) > 0 {
+		redactValues(q)
+		u.RawQuery = q.Encode()
+	}
+	return r.String(u.String())
+}
+
+// Body redacts a request or response body. Form-encoded bodies, such as an
+// OAuth refresh exchange, have their credential fields cleared like query
+// parameters; everything else goes through String.
+func (r *Redactor) Body(h http.Header, body string) string {
+	if body == "" {
+		return body
+	}
+	if mediaType, _, err := mime.ParseMediaType(h.Get("Content-Type")); err == nil && mediaType == "application/x-www-form-urlencoded" {
+		if form, err := url.ParseQuery(body); err == nil {
+			redactValues(form)
+			body = form.Encode()
+		}
+	}
+	return r.String(body)
+}
+
+// Exchange redacts one recorded HTTP exchange.
+func (r *Redactor) Exchange(ex shared.HTTPExchange) shared.HTTPExchange {
+	ex.URL = r.URL(ex.URL)
+	ex.RequestBody = r.Body(ex.RequestHeader, ex.RequestBody)
+	ex.RequestHeader = r.Header(ex.RequestHeader)
+	ex.ResponseBody = r.Body(ex.ResponseHeader, ex.ResponseBody)
+	ex.ResponseHeader = r.Header(ex.ResponseHeader)
+	ex.Error = r.String(ex.Error)
+	return ex
+}
+
+// redactValues clears every credential-named query or form field.
+func redactValues(v url.Values) {
+	for name := range v {
+		if sensitiveName(name) || name == "key" || name == "code" {
+			v.Set(name, Redacted)
+		}
+	}
+}
+
+// Snapshot redacts a snapshot: metadata that identifies a person or key is
+// masked as in privacy mode, everything else goes through String. Metrics
+// are kept as they are.
+func (r *Redactor) Snapshot(s core.UsageSnapshot) core.UsageSnapshot {
+	s.Attributes = r.meta(s.Attributes)
+	s.Diagnostics = r.meta(s.Diagnostics)
+	s.Raw = r.meta(s.Raw)
+	s.Message = r.String(s.Message)
+	return s
+}
+
+func (r *Redactor) meta(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		if core.IsSensitiveMetaKey(k) {
+			out[k] = core.MaskSensitiveValue(v)
+		} else {
+			out[k] = r.String(v)
+		}
+	}
+	return out
+}
+
+// sensitiveName reports whether a header or query parameter names a
+// credential. Rate-limit headers counting "tokens" are kept.
+func sensitiveName(name string) bool {
+	n := strings.ToLower(name)
+	switch n {
+	case "authorization", "proxy-authorization", "cookie", "set-cookie":
+		return true
+	}
+	n = strings.ReplaceAll(n, "tokens", "")
+	for _, part := range []string{"token", "secret", "password", "api-key", "api_key", "apikey", "session", "credential"} {
+		if strings.Contains(n, part) {
+			return true
+		}
+	}
+	return false
+}
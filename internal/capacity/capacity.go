@@ -0,0 +1,174 @@
+// Package capacity builds and writes the capacity file: a small JSON summary
+// of remaining quota per account and provider that agent frameworks poll to
+// throttle concurrency without talking to openusage.
+package capacity
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// Version is bumped on incompatible changes to the file layout.
+const Version = 1
+
+// File is the capacity file document. Remaining values are fractions (0–1)
+// of quota left; null means the account reports no quota gauge.
+type File struct {
+	Version   int                 `json:"version"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	Providers map[string]Provider `json:"providers"`
+	Accounts  map[string]Account  `json:"accounts"`
+}
+
+// Provider is the tightest remaining fraction across the provider's
+// accounts.
+type Provider struct {
+	Remaining *float64 `json:"remaining"`
+	Accounts  []string `json:"accounts"`
+}
+
+// Account summarizes one account. Remaining and ResetsAt come from its
+// tightest gauge, named by Limiting; a limited account reports 0.
+type Account struct {
+	ProviderID string           `json:"provider_id"`
+	Status     string           `json:"status"`
+	Remaining  *float64         `json:"remaining"`
+	Limiting   string           `json:"limiting,omitempty"`
+	ResetsAt   *time.Time       `json:"resets_at,omitempty"`
+	Gauges     map[string]Gauge `json:"gauges,omitempty"`
+}
+
+// Gauge is one quota metric.
+type Gauge struct {
+	Remaining float64    `json:"remaining"`
+	Window    string     `json:"window,omitempty"`
+	ResetsAt  *time.Time `json:"resets_at,omitempty"`
+}
+
+// Build summarizes snaps. Metrics flagged as implausible by snapshot
+// validation are left out.
+func Build(snaps map[string]core.UsageSnapshot, now time.Time) File {
+	f := File{
+		Version:   Version,
+		UpdatedAt: now.UTC(),
+		Providers: make(map[string]Provider),
+		Accounts:  make(map[string]Account, len(snaps)),
+	}
+	for id, snap := range snaps {
+		accountID := snap.AccountID
+		if accountID == "" {
+			accountID = id
+		}
+		acct := Account{ProviderID: snap.ProviderID, Status: string(snap.Status)}
+		for key, met := range snap.Metrics {
+			if _, suspect := snap.Diagnostics[core.AnomalyDiagnosticPrefix+key]; suspect {
+				continue
+			}
+			used := core.MetricUsedPercent(key, met)
+			if used < 0 {
+				continue
+			}
+			g := Gauge{Remaining: fraction(100 - used), Window: met.Window, ResetsAt: resetFor(snap, key)}
+			if acct.Gauges == nil {
+				acct.Gauges = make(map[string]Gauge)
+			}
+			acct.Gauges[key] = g
+			if acct.Remaining == nil || g.Remaining < *acct.Remaining || (g.Remaining == *acct.Remaining && key < acct.Limiting) {
+				remaining := g.Remaining
+				acct.Remaining, acct.Limiting, acct.ResetsAt = &remaining, key, g.ResetsAt
+			}
+		}
+		if snap.Status == core.StatusLimited {
+			zero := 0.0
+			acct.Remaining = &zero
+		}
+		f.Accounts[accountID] = acct
+
+		p := f.Providers[snap.ProviderID]
+		p.Accounts = append(p.Accounts, accountID)
+		if acct.Remaining != nil && (p.Remaining == nil || *acct.Remaining < *p.Remaining) {
+			remaining := *acct.Remaining
+			p.Remaining = &remaining
+		}
+		f.Providers[snap.ProviderID] = p
+	}
+	for id, p := range f.Providers {
+		sort.Strings(p.Accounts)
+		f.Providers[id] = p
+	}
+	return f
+}
+
+// fraction converts a remaining percentage to a 0–1 fraction rounded to four
+// places.
+func fraction(pct float64) float64 {
+	return math.Round(math.Max(0, math.Min(100, pct))*100) / 10000
+}
+
+func resetFor(snap core.UsageSnapshot, key string) *time.Time {
+	t, ok := snap.Resets[key+"_reset"]
+	if !ok {
+		t = snap.Resets[key]
+	}
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+// ResolvePath trims the configured path and expands a leading "~/".
+func ResolvePath(path string) string {
+	path = strings.TrimSpace(path)
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// Write replaces path with f atomically, so pollers never read a partial
+// file.
+func Write(path string, f File) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create capacity file dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".openusage-capacity-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create capacity temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("write capacity file: %w", err)
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("chmod capacity file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("write capacity file: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("replace capacity file: %w", err)
+	}
+	return nil
+}
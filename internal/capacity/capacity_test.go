@@ -0,0 +1,103 @@
+package capacity
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestBuild(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	reset := now.Add(2 * time.Hour)
+	snaps := map[string]core.UsageSnapshot{
+		"claude": {
+			AccountID: "claude", ProviderID: "claude_code", Status: core.StatusOK,
+			Metrics: map[string]core.Metric{
+				"usage_five_hour": {Used: f(80), Unit: "%", Window: "5h"},
+				"usage_seven_day": {Used: f(35), Unit: "%", Window: "7d"},
+				"context_window":  {Used: f(90), Limit: f(100)},
+				"today_cost":      {Used: f(4.2), Unit: "USD"},
+				"rpm":             {Used: f(500), Limit: f(50), Window: "1m"},
+			},
+			Resets:      map[string]time.Time{"usage_five_hour": reset},
+			Diagnostics: map[string]string{core.AnomalyDiagnosticPrefix + "rpm": "used exceeds limit"},
+		},
+		"openrouter-work": {
+			ProviderID: "openrouter", Status: core.StatusOK,
+			Metrics: map[string]core.Metric{"credits": {Limit: f(100), Remaining: f(60)}},
+		},
+		"openrouter-home": {
+			ProviderID: "openrouter", Status: core.StatusLimited,
+			Metrics: map[string]core.Metric{"credits": {Limit: f(10), Remaining: f(5)}},
+		},
+		"ollama": {ProviderID: "ollama", Status: core.StatusOK},
+	}
+
+	got := Build(snaps, now)
+	if got.Version != Version || !got.UpdatedAt.Equal(now) {
+		t.Errorf("header = v%d %s", got.Version, got.UpdatedAt)
+	}
+
+	claude := got.Accounts["claude"]
+	if claude.Remaining == nil || *claude.Remaining != 0.2 || claude.Limiting != "usage_five_hour" {
+		t.Errorf("claude = %+v, want 0.2 limited by usage_five_hour", claude)
+	}
+	if claude.ResetsAt == nil || !claude.ResetsAt.Equal(reset) {
+		t.Errorf("claude resets_at = %v, want %s", claude.ResetsAt, reset)
+	}
+	if keys := reflect.ValueOf(claude.Gauges).MapKeys(); len(keys) != 2 {
+		t.Errorf("claude gauges = %v, want the two usage windows only", claude.Gauges)
+	}
+
+	if home := got.Accounts["openrouter-home"]; home.Remaining == nil || *home.Remaining != 0 {
+		t.Errorf("limited account remaining = %v, want 0", home.Remaining)
+	}
+	if ollama := got.Accounts["ollama"]; ollama.Remaining != nil || ollama.Status != "OK" {
+		t.Errorf("gaugeless account = %+v, want null remaining", ollama)
+	}
+
+	or := got.Providers["openrouter"]
+	if or.Remaining == nil || *or.Remaining != 0 || !reflect.DeepEqual(or.Accounts, []string{"openrouter-home", "openrouter-work"}) {
+		t.Errorf("openrouter provider = %+v", or)
+	}
+	if p := got.Providers["ollama"]; p.Remaining != nil {
+		t.Errorf("ollama provider remaining = %v, want null", *p.Remaining)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "capacity.json")
+	remaining := 0.5
+	want := File{
+		Version:   Version,
+		UpdatedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Providers: map[string]Provider{"copilot": {Remaining: &remaining, Accounts: []string{"copilot"}}},
+		Accounts:  map[string]Account{"copilot": {ProviderID: "copilot", Status: "OK", Remaining: &remaining}},
+	}
+	for range 2 { // the second write replaces the first
+		if err := Write(path, want); err != nil {
+			t.Fatalf("Write() error: %v", err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got File
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the capacity file", len(entries))
+	}
+}
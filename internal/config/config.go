@@ -2,11 +2,15 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/samber/lo"
@@ -26,11 +30,20 @@ type TelemetryConfig struct {
 	// ProviderLinks maps source telemetry provider IDs to configured provider IDs.
 	// Example: {"anthropic":"claude_code"}.
 	ProviderLinks map[string]string `json:"provider_links"`
+	// IngestListen is a TCP address (e.g. "127.0.0.1:9310") on which the
+	// daemon accepts pushed usage events at POST /v1/ingest. Empty serves the
+	// endpoint on the daemon socket only. The optional Bearer token comes
+	// from OPENUSAGE_INGEST_TOKEN, never from settings.json.
+	IngestListen string `json:"ingest_listen,omitempty"`
 }
 
 type DataConfig struct {
 	TimeWindow    string `json:"time_window"`    // "1d", "3d", "7d", "30d"
 	RetentionDays int    `json:"retention_days"` // max days to keep in SQLite
+	// CustomWindows adds user-defined windows ("sprint", "quarter", ...)
+	// that time_window, the dashboard and the CLI accept alongside the
+	// built-in ones.
+	CustomWindows []core.CustomTimeWindow `json:"custom_windows,omitempty"`
 }
 
 type DashboardProviderConfig struct {
@@ -40,6 +53,10 @@ type DashboardProviderConfig struct {
 	// nil means "fall through to DashboardConfig.HideCosts (and then to the
 	// plan-aware auto policy)".
 	HideCosts *bool `json:"hide_costs,omitempty"`
+	// FreeTier switches the tile to free-tier tracking (daily allowance and
+	// reset countdown up front). nil means "auto": on when the provider
+	// reports the account as free (see core.ResolveFreeTier).
+	FreeTier *bool `json:"free_tier,omitempty"`
 }
 
 type DashboardWidgetSection struct {
@@ -61,6 +78,7 @@ func (p *DashboardProviderConfig) UnmarshalJSON(data []byte) error {
 		AccountID string `json:"account_id"`
 		Enabled   *bool  `json:"enabled"`
 		HideCosts *bool  `json:"hide_costs"`
+		FreeTier  *bool  `json:"free_tier"`
 	}
 
 	var raw rawDashboardProviderConfig
@@ -74,6 +92,7 @@ func (p *DashboardProviderConfig) UnmarshalJSON(data []byte) error {
 		p.Enabled = *raw.Enabled
 	}
 	p.HideCosts = raw.HideCosts
+	p.FreeTier = raw.FreeTier
 	return nil
 }
 
@@ -130,6 +149,90 @@ type DashboardConfig struct {
 	// nil means "fall through to the plan-aware auto policy" (see
 	// core.ResolveHideCosts).
 	HideCosts *bool `json:"hide_costs,omitempty"`
+	// SnoozeHours overrides how long an acknowledged warning stays quiet,
+	// keyed by rule (SnoozeRuleLimit, SnoozeRuleNearLimit).
+	SnoozeHours map[string]int `json:"snooze_hours,omitempty"`
+	// Snoozes are the currently acknowledged warnings, written by the TUI.
+	Snoozes []WarningSnooze `json:"snoozes,omitempty"`
+	// PrivacyMode masks emails, key labels, org names, and dollar amounts
+	// for screen sharing. Toggled with "P" in the dashboard.
+	PrivacyMode bool `json:"privacy_mode,omitempty"`
+	// LimitPools group accounts that share one org-level limit so the
+	// dashboard shows a single gauge with per-account attribution.
+	LimitPools []core.LimitPoolConfig `json:"limit_pools,omitempty"`
+	// ModelRetirementWarnDays is how far ahead a scheduled shutdown of a
+	// model the account spends on is flagged on its tile. 0 uses
+	// DefaultModelRetirementWarnDays; negative turns the warning off.
+	ModelRetirementWarnDays int `json:"model_retirement_warn_days,omitempty"`
+	// ProviderWidgets override parts of a provider's built-in tile layout,
+	// keyed by provider ID.
+	ProviderWidgets map[string]ProviderWidgetConfig `json:"provider_widgets,omitempty"`
+}
+
+// ProviderWidgetConfig replaces a provider's gauge priority and compact
+// rows. Empty fields keep the provider's defaults.
+type ProviderWidgetConfig struct {
+	// GaugePriority lists the metric keys drawn as gauges, most important
+	// first.
+	GaugePriority []string           `json:"gauge_priority,omitempty"`
+	CompactRows   []CompactRowConfig `json:"compact_rows,omitempty"`
+}
+
+// CompactRowConfig is one labelled summary row on a tile, showing up to
+// MaxSegments of Keys that have values.
+type CompactRowConfig struct {
+	Label       string   `json:"label"`
+	Keys        []string `json:"keys"`
+	MaxSegments int      `json:"max_segments,omitempty"`
+}
+
+// DefaultModelRetirementWarnDays is how far ahead model shutdowns are
+// flagged when ModelRetirementWarnDays is unset.
+const DefaultModelRetirementWarnDays = 30
+
+// ModelRetirementWarnWindow returns how far ahead model shutdowns are
+// flagged, or 0 when the warning is off.
+func (d DashboardConfig) ModelRetirementWarnWindow() time.Duration {
+	switch {
+	case d.ModelRetirementWarnDays < 0:
+		return 0
+	case d.ModelRetirementWarnDays == 0:
+		return DefaultModelRetirementWarnDays * 24 * time.Hour
+	default:
+		return time.Duration(d.ModelRetirementWarnDays) * 24 * time.Hour
+	}
+}
+
+// Warning rules that can be snoozed from the dashboard.
+const (
+	SnoozeRuleLimit     = "limit"      // a tile at LIMIT (quota or budget exhausted)
+	SnoozeRuleNearLimit = "near_limit" // a tile past the warn threshold
+)
+
+// DefaultSnoozeHours is the snooze period per rule when SnoozeHours does not
+// set one.
+var DefaultSnoozeHours = map[string]int{
+	SnoozeRuleLimit:     24,
+	SnoozeRuleNearLimit: 8,
+}
+
+// WarningSnooze silences one rule on one account until Until.
+type WarningSnooze struct {
+	AccountID string    `json:"account_id"`
+	Rule      string    `json:"rule"`
+	Until     time.Time `json:"until"`
+}
+
+// SnoozeDuration returns the snooze period for rule: the configured hours,
+// else the default, else 24h.
+func (d DashboardConfig) SnoozeDuration(rule string) time.Duration {
+	if h, ok := d.SnoozeHours[rule]; ok && h > 0 {
+		return time.Duration(h) * time.Hour
+	}
+	if h, ok := DefaultSnoozeHours[rule]; ok {
+		return time.Duration(h) * time.Hour
+	}
+	return 24 * time.Hour
 }
 
 type ExportConfig struct {
@@ -151,6 +254,168 @@ type HubConfig struct {
 	AuthToken string `json:"-"`
 }
 
+// PollingConfig tunes the daemon's adaptive polling: accounts whose
+// snapshots stop changing are polled less often, and snap back to the base
+// interval on a change or when a quota reset is due.
+type PollingConfig struct {
+	// Adaptive enables the slowdown. nil means on.
+	Adaptive *bool `json:"adaptive,omitempty"`
+	// IdleCycles is the number of unchanged polls before the first slowdown;
+	// later tiers keep their spacing. 0 uses the default (3).
+	IdleCycles int `json:"idle_cycles,omitempty"`
+	// MaxIntervalSeconds caps the slowed-down interval. 0 keeps the built-in
+	// caps (4x the base interval for API providers, 16x for local ones).
+	MaxIntervalSeconds int `json:"max_interval_seconds,omitempty"`
+	// LowPriorityEvery is how many ticks apart accounts with priority "low"
+	// are polled. 0 uses the default (4).
+	LowPriorityEvery int `json:"low_priority_every,omitempty"`
+	// Schedule varies the poll rate by time of day and day of week, or
+	// pauses polling, for every account without its own poll_schedule.
+	Schedule *core.PollSchedule `json:"schedule,omitempty"`
+}
+
+// AdaptiveEnabled reports whether adaptive polling is on (the default).
+func (p PollingConfig) AdaptiveEnabled() bool {
+	return p.Adaptive == nil || *p.Adaptive
+}
+
+// NotificationsConfig routes the daemon's alerts (limits reached, gauges past
+// the warn/crit thresholds, auth failures) to notification sinks. The notify
+// package validates it; invalid sinks and rules are reported and skipped.
+type NotificationsConfig struct {
+	// Sinks are the named destinations rules refer to.
+	Sinks map[string]NotificationSink `json:"sinks,omitempty"`
+	// DefaultSinks receive alerts no rule matches.
+	DefaultSinks []string `json:"default_sinks,omitempty"`
+	// Rules override where alerts go per account or provider. The most
+	// specific matching rule wins: account rules, then provider rules, then
+	// rules matching everything; ties go to the first in the list.
+	Rules []NotificationRule `json:"rules,omitempty"`
+	// QuietHours holds back alerts below its severity during a daily window.
+	QuietHours *NotificationQuietHours `json:"quiet_hours,omitempty"`
+	// DedupWindow is how long an alert already sent to a sink is not sent to
+	// it again unless its severity rises (Go duration, default "1h").
+	DedupWindow string `json:"dedup_window,omitempty"`
+	// OnEvent runs a local command for each usage event, for automations
+	// beyond notifications.
+	OnEvent *EventHook `json:"on_event,omitempty"`
+}
+
+// EventHook runs Command (argv, no shell) with one event as JSON on stdin.
+type EventHook struct {
+	Command []string `json:"command"`
+	// Events limits the hook to "status_change", "threshold", and/or
+	// "reset"; empty means all.
+	Events []string `json:"events,omitempty"`
+	// Timeout kills a hook that runs too long (Go duration, default "30s").
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// NotificationSink is one destination: "slack" (incoming webhook), "webhook"
+// (JSON POST), "ntfy" (push to a topic), "pushover", "telegram" (bot), or
+// "desktop" (notify-send / osascript).
+type NotificationSink struct {
+	Type string `json:"type"`
+	// MinSeverity is the lowest severity sent: "info", "warning" (default),
+	// or "critical".
+	MinSeverity string `json:"min_severity,omitempty"`
+	// WebhookEnv names the env var holding the Slack or generic webhook URL;
+	// webhooks are secrets and are never stored in settings.json.
+	WebhookEnv string `json:"webhook_env,omitempty"`
+	Channel    string `json:"channel,omitempty"` // slack: override the webhook's channel
+	Server     string `json:"server,omitempty"`  // ntfy, telegram: API base URL
+	Topic      string `json:"topic,omitempty"`   // ntfy
+	// TokenEnv names the env var holding the ntfy access token, Pushover
+	// application token, or Telegram bot token.
+	TokenEnv string `json:"token_env,omitempty"`
+	UserEnv  string `json:"user_env,omitempty"` // pushover: env var holding the user or group key
+	ChatID   string `json:"chat_id,omitempty"`  // telegram
+}
+
+// NotificationRule sends matching alerts at or above MinSeverity to Sinks.
+// Empty Accounts and Providers match every alert; empty Sinks mutes them.
+type NotificationRule struct {
+	Accounts    []string `json:"accounts,omitempty"`
+	Providers   []string `json:"providers,omitempty"`
+	MinSeverity string   `json:"min_severity,omitempty"`
+	Sinks       []string `json:"sinks"`
+}
+
+// NotificationQuietHours is a daily local-time window ("22:00"–"07:00") in
+// which only alerts at or above MinSeverity (default "critical") are sent.
+type NotificationQuietHours struct {
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	MinSeverity string `json:"min_severity,omitempty"`
+}
+
+// CapacityFileConfig makes the daemon keep a small JSON file of remaining
+// quota per account and provider, for agent frameworks to poll.
+type CapacityFileConfig struct {
+	Path string `json:"path,omitempty"` // empty disables; "~/" is expanded
+}
+
+// AccountDedupeConfig decides what happens to auto-detected accounts that
+// duplicate a configured one (see core.FindDuplicateAccounts). Merged
+// duplicates are dropped so only the configured account's tile remains.
+type AccountDedupeConfig struct {
+	// Auto merges every duplicate without asking.
+	Auto bool `json:"auto,omitempty"`
+	// Merged and Kept list auto-detected account IDs the user chose to merge
+	// or to keep alongside their configured twin.
+	Merged []string `json:"merged,omitempty"`
+	Kept   []string `json:"kept,omitempty"`
+}
+
+// Apply returns autoDetected without the duplicates Auto or Merged drop.
+func (d AccountDedupeConfig) Apply(manual, autoDetected []core.AccountConfig) []core.AccountConfig {
+	drop := make(map[string]bool)
+	for _, dup := range core.FindDuplicateAccounts(manual, autoDetected) {
+		if d.Auto || slices.Contains(d.Merged, dup.Duplicate.ID) {
+			drop[dup.Duplicate.ID] = true
+		}
+	}
+	if len(drop) == 0 {
+		return autoDetected
+	}
+	return lo.Filter(autoDetected, func(acct core.AccountConfig, _ int) bool { return !drop[acct.ID] })
+}
+
+// PendingDuplicateAccounts lists duplicates among the loaded accounts that
+// no setting has decided yet.
+func (c Config) PendingDuplicateAccounts() []core.AccountDuplicate {
+	if c.AccountDedupe.Auto {
+		return nil
+	}
+	return lo.Filter(core.FindDuplicateAccounts(c.Accounts, c.AutoDetectedAccounts), func(dup core.AccountDuplicate, _ int) bool {
+		return !slices.Contains(c.AccountDedupe.Merged, dup.Duplicate.ID) && !slices.Contains(c.AccountDedupe.Kept, dup.Duplicate.ID)
+	})
+}
+
+// UpdateConfig controls the startup update check and `openusage update`.
+type UpdateConfig struct {
+	Channel string `json:"channel,omitempty"` // "stable" (default) or "nightly"
+}
+
+const (
+	UpdateChannelStable  = "stable"
+	UpdateChannelNightly = "nightly"
+)
+
+// DetectConfig tunes auto-detection.
+type DetectConfig struct {
+	// CloudSources turns individual cloud credential sources on or off by
+	// ID ("aws", "gcloud", "azure_cli"). Sources not listed are read.
+	CloudSources map[string]bool `json:"cloud_sources,omitempty"`
+}
+
+// CloudSourceEnabled reports whether auto-detection may read the cloud
+// credential source with the given ID.
+func (c DetectConfig) CloudSourceEnabled(id string) bool {
+	enabled, ok := c.CloudSources[id]
+	return !ok || enabled
+}
+
 type IntegrationState struct {
 	Installed   bool   `json:"installed"`
 	Version     string `json:"version,omitempty"`
@@ -208,12 +473,85 @@ type Config struct {
 	Dashboard            DashboardConfig               `json:"dashboard"`
 	ModelNormalization   core.ModelNormalizationConfig `json:"model_normalization"`
 	AutoDetect           bool                          `json:"auto_detect"`
+	Detect               DetectConfig                  `json:"detect,omitempty"`
 	Accounts             []core.AccountConfig          `json:"accounts"`
 	AutoDetectedAccounts []core.AccountConfig          `json:"auto_detected_accounts"`
+	AccountDedupe        AccountDedupeConfig           `json:"account_dedupe,omitempty"`
 	Integrations         map[string]IntegrationState   `json:"integrations,omitempty"`
 	Export               ExportConfig                  `json:"export,omitempty"`
 	Hub                  HubConfig                     `json:"hub,omitempty"`
 	Tmux                 TmuxConfig                    `json:"tmux,omitempty"`
+	Update               UpdateConfig                  `json:"update,omitempty"`
+	Polling              PollingConfig                 `json:"polling,omitempty"`
+	Notifications        NotificationsConfig           `json:"notifications,omitempty"`
+	CapacityFile         CapacityFileConfig            `json:"capacity_file,omitempty"`
+	// Network sets the proxy, extra CA bundle, and TLS verification for
+	// provider requests, with per-provider overrides under `providers`;
+	// accounts can override it with their own `network`.
+	Network core.NetworkSettings `json:"network,omitempty"`
+	// DerivedMetrics are user-defined metrics computed from each snapshot
+	// after every fetch. Invalid definitions are dropped on load.
+	DerivedMetrics []core.DerivedMetricConfig `json:"derived_metrics,omitempty"`
+	// Energy opts into approximate energy/CO2 figures for token usage in
+	// detail views and reports.
+	Energy core.EnergyConfig `json:"energy,omitempty"`
+	// Locale selects number, currency, time, and week-start conventions
+	// (e.g. "de_DE"). Empty or "auto" follows LC_ALL / LANG.
+	Locale string `json:"locale,omitempty"`
+	// NumberPrecision is the fractional digits kept on scaled numbers
+	// ("1.2M", "3.4 GB"), 0–3. nil keeps locale.DefaultPrecision.
+	NumberPrecision *int `json:"number_precision,omitempty"`
+	// ReadOnly guarantees no provider issues requests that cost money or
+	// mutate remote state. See ReadOnlyEnabled for the env override.
+	ReadOnly bool `json:"read_only,omitempty"`
+}
+
+// EnvReadOnly forces read-only mode for the process (and for a daemon
+// installed while it is set). The --read-only flag sets it.
+const EnvReadOnly = "OPENUSAGE_READ_ONLY"
+
+// ReadOnlyEnabled reports whether read-only mode is on, either persisted in
+// settings or forced through OPENUSAGE_READ_ONLY. The env override is kept
+// out of LoadFrom so read-modify-write helpers never persist it.
+func (c Config) ReadOnlyEnabled() bool {
+	return c.ReadOnly || ReadOnlyFromEnv()
+}
+
+// ReadOnlyFromEnv reports whether OPENUSAGE_READ_ONLY holds a truthy value.
+func ReadOnlyFromEnv() bool {
+	return envEnabled(EnvReadOnly)
+}
+
+// EnvNoWrite turns on pure-config mode: nothing in the process (or in a
+// daemon installed while it is set) writes settings.json or
+// credentials.json, so a config managed declaratively, e.g. by Nix or
+// home-manager, is never rewritten. The --no-write flag sets it.
+const EnvNoWrite = "OPENUSAGE_NO_WRITE"
+
+// ErrNoWrite is returned by every settings and credentials write in
+// pure-config mode.
+var ErrNoWrite = errors.New("config files are read-only (--no-write)")
+
+// NoWriteFromEnv reports whether OPENUSAGE_NO_WRITE holds a truthy value.
+func NoWriteFromEnv() bool {
+	return envEnabled(EnvNoWrite)
+}
+
+// EnvOffline starts the dashboard in offline mode, like --offline.
+const EnvOffline = "OPENUSAGE_OFFLINE"
+
+// OfflineFromEnv reports whether OPENUSAGE_OFFLINE holds a truthy value.
+func OfflineFromEnv() bool {
+	return envEnabled(EnvOffline)
+}
+
+func envEnabled(name string) bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(name))) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
 }
 
 // DefaultProviderLinks returns built-in telemetry provider-id to dashboard provider-id mappings.
@@ -253,15 +591,80 @@ func DefaultConfig() Config {
 	}
 }
 
-// ConfigDir returns the directory that holds settings.json and other OpenUsage
-// config. The per-OS base is provided by osConfigDir() in the platform-specific
-// config_dir_*.go files.
-func ConfigDir() string {
-	return osConfigDir()
+// EnvConfig points every settings read and write of the process at an
+// explicit file instead of the profile's settings.json. The --config flag
+// sets it.
+const EnvConfig = "OPENUSAGE_CONFIG"
+
+// EnvProfile selects a named profile: its settings.json and
+// credentials.json live in ConfigDir()/profiles/<name>. The --profile flag
+// sets it; empty means the default profile in ConfigDir() itself.
+const EnvProfile = "OPENUSAGE_PROFILE"
+
+// ActiveProfile returns the profile selected through OPENUSAGE_PROFILE, or ""
+// for the default profile.
+func ActiveProfile() string {
+	return strings.TrimSpace(os.Getenv(EnvProfile))
 }
 
+// ValidateProfileName rejects names that cannot be used as a single
+// directory under ConfigDir()/profiles.
+func ValidateProfileName(name string) error {
+	if name == "" || name == "." || name == ".." {
+		return fmt.Errorf("invalid profile name %q", name)
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return fmt.Errorf("invalid profile name %q: use letters, digits, '-', '_' or '.'", name)
+		}
+	}
+	return nil
+}
+
+// ProfilesDir returns the directory holding one subdirectory per named
+// profile.
+func ProfilesDir() string {
+	return filepath.Join(ConfigDir(), "profiles")
+}
+
+// ProfileDir returns the directory holding the active profile's
+// settings.json and credentials.json.
+func ProfileDir() string {
+	if name := ActiveProfile(); name != "" {
+		return filepath.Join(ProfilesDir(), name)
+	}
+	return ConfigDir()
+}
+
+// ListProfiles returns the names of the profiles under ProfilesDir(),
+// sorted. The default profile is not listed.
+func ListProfiles() ([]string, error) {
+	entries, err := os.ReadDir(ProfilesDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading profiles: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && ValidateProfileName(entry.Name()) == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ConfigPath returns the settings file of the process: OPENUSAGE_CONFIG when
+// set, otherwise the active profile's settings.json.
 func ConfigPath() string {
-	return filepath.Join(ConfigDir(), "settings.json")
+	if path := strings.TrimSpace(os.Getenv(EnvConfig)); path != "" {
+		return path
+	}
+	return filepath.Join(ProfileDir(), "settings.json")
 }
 
 func Load() (Config, error) {
@@ -291,11 +694,17 @@ func LoadFrom(path string) (Config, error) {
 	cfg.ModelNormalization = core.NormalizeModelNormalizationConfig(cfg.ModelNormalization)
 	cfg.Telemetry = normalizeTelemetryConfig(cfg.Telemetry)
 	cfg.Accounts = normalizeAccounts(cfg.Accounts)
-	cfg.AutoDetectedAccounts = normalizeAccounts(cfg.AutoDetectedAccounts)
+	cfg.AutoDetectedAccounts = cfg.AccountDedupe.Apply(cfg.Accounts, normalizeAccounts(cfg.AutoDetectedAccounts))
 	cfg.Dashboard.Providers = normalizeDashboardProviders(cfg.Dashboard.Providers)
 	cfg.Dashboard.View = normalizeDashboardView(cfg.Dashboard.View)
 	cfg.Dashboard.WidgetSections = normalizeDashboardWidgetSections(cfg.Dashboard.WidgetSections)
 	cfg.Dashboard.DetailSections = normalizeDetailWidgetSections(cfg.Dashboard.DetailSections)
+	cfg.Dashboard.LimitPools = core.NormalizeLimitPools(cfg.Dashboard.LimitPools)
+	cfg.Dashboard.ProviderWidgets = normalizeProviderWidgets(cfg.Dashboard.ProviderWidgets)
+	cfg.Update = normalizeUpdateConfig(cfg.Update)
+	cfg.Polling = normalizePollingConfig(cfg.Polling)
+	cfg.DerivedMetrics = normalizeDerivedMetrics(cfg.DerivedMetrics)
+	cfg.Energy = core.NormalizeEnergyConfig(cfg.Energy)
 
 	return cfg, nil
 }
@@ -339,6 +748,23 @@ func normalizeUIConfig(in UIConfig) UIConfig {
 const defaultRetentionDays = 90
 
 func normalizeDataConfig(in DataConfig) DataConfig {
+	var windows []core.CustomTimeWindow
+	for _, w := range in.CustomWindows {
+		w.ID = strings.ToLower(strings.TrimSpace(w.ID))
+		w.Start = strings.ToLower(strings.TrimSpace(w.Start))
+		if err := w.Validate(); err != nil {
+			core.Tracef("config: dropping data.custom_windows entry: %v", err)
+			continue
+		}
+		windows = append(windows, w)
+	}
+	// Register before parsing time_window so it can name a custom window.
+	core.SetCustomTimeWindows(windows)
+	windows = core.CustomTimeWindows() // repeated IDs dropped
+	if len(windows) == 0 {
+		windows = nil
+	}
+
 	tw := core.ParseTimeWindow(in.TimeWindow)
 	retention := in.RetentionDays
 	if retention <= 0 {
@@ -363,9 +789,85 @@ func normalizeDataConfig(in DataConfig) DataConfig {
 	return DataConfig{
 		TimeWindow:    string(tw),
 		RetentionDays: retention,
+		CustomWindows: windows,
 	}
 }
 
+func normalizeUpdateConfig(in UpdateConfig) UpdateConfig {
+	switch channel := strings.ToLower(strings.TrimSpace(in.Channel)); channel {
+	case "", UpdateChannelStable:
+		return UpdateConfig{}
+	case UpdateChannelNightly:
+		return UpdateConfig{Channel: channel}
+	default:
+		core.Tracef("config: update.channel=%q is invalid, using %q", in.Channel, UpdateChannelStable)
+		return UpdateConfig{}
+	}
+}
+
+func normalizePollingConfig(in PollingConfig) PollingConfig {
+	if in.IdleCycles < 0 {
+		core.Tracef("config: polling.idle_cycles=%d is invalid, using default", in.IdleCycles)
+		in.IdleCycles = 0
+	}
+	if in.MaxIntervalSeconds < 0 {
+		core.Tracef("config: polling.max_interval_seconds=%d is invalid, using default", in.MaxIntervalSeconds)
+		in.MaxIntervalSeconds = 0
+	}
+	if in.LowPriorityEvery < 0 {
+		core.Tracef("config: polling.low_priority_every=%d is invalid, using default", in.LowPriorityEvery)
+		in.LowPriorityEvery = 0
+	}
+	in.Schedule = normalizePollSchedule("polling.schedule", in.Schedule)
+	if in.Schedule != nil && len(in.Schedule.Rules) == 0 {
+		in.Schedule = nil
+	}
+	return in
+}
+
+// normalizePollSchedule drops invalid rules, and the whole schedule when its
+// timezone is unknown, so the accounts poll as if it weren't set.
+func normalizePollSchedule(scope string, in *core.PollSchedule) *core.PollSchedule {
+	if in == nil {
+		return nil
+	}
+	if err := in.Validate(); err != nil {
+		core.Tracef("config: dropping %s: %v", scope, err)
+		return nil
+	}
+	out := &core.PollSchedule{Timezone: strings.TrimSpace(in.Timezone), Rules: []core.PollScheduleRule{}}
+	for i, rule := range in.Rules {
+		if err := rule.Validate(); err != nil {
+			core.Tracef("config: dropping %s rule %d: %v", scope, i+1, err)
+			continue
+		}
+		out.Rules = append(out.Rules, rule)
+	}
+	return out
+}
+
+func normalizeDerivedMetrics(in []core.DerivedMetricConfig) []core.DerivedMetricConfig {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]core.DerivedMetricConfig, 0, len(in))
+	seen := make(map[string]bool, len(in))
+	for _, def := range in {
+		compiled, err := core.CompileDerivedMetric(def)
+		if err != nil {
+			core.Tracef("config: dropping derived metric: %v", err)
+			continue
+		}
+		if seen[compiled.Config.Name] {
+			core.Tracef("config: dropping duplicate derived metric %q", compiled.Config.Name)
+			continue
+		}
+		seen[compiled.Config.Name] = true
+		out = append(out, compiled.Config)
+	}
+	return out
+}
+
 func normalizeAccountID(id string) string {
 	return strings.TrimSpace(id)
 }
@@ -388,6 +890,15 @@ func normalizeAccounts(in []core.AccountConfig) []core.AccountConfig {
 			}
 		}
 		acct.Paths = nil
+		priority, ok := core.ParsePollPriority(acct.Priority)
+		if !ok {
+			core.Tracef("config: account %q priority=%q is invalid, using %q", acct.ID, acct.Priority, priority)
+		}
+		acct.Priority = ""
+		if priority != core.PollPriorityNormal {
+			acct.Priority = string(priority)
+		}
+		acct.PollSchedule = normalizePollSchedule(fmt.Sprintf("account %q poll_schedule", acct.ID), acct.PollSchedule)
 		return acct
 	})
 	filtered := lo.Filter(normalized, func(acct core.AccountConfig, _ int) bool { return acct.ID != "" })
@@ -419,6 +930,7 @@ func normalizeDashboardProviders(in []DashboardProviderConfig) []DashboardProvid
 			AccountID: normalizeAccountID(entry.AccountID),
 			Enabled:   entry.Enabled,
 			HideCosts: entry.HideCosts,
+			FreeTier:  entry.FreeTier,
 		}
 	})
 	filtered := lo.Filter(normalized, func(entry DashboardProviderConfig, _ int) bool { return entry.AccountID != "" })
@@ -490,6 +1002,50 @@ func normalizeDetailWidgetSections(in []DetailWidgetSection) []DetailWidgetSecti
 	return normalized
 }
 
+func normalizeProviderWidgets(in map[string]ProviderWidgetConfig) map[string]ProviderWidgetConfig {
+	out := make(map[string]ProviderWidgetConfig, len(in))
+	for providerID, widget := range in {
+		providerID = strings.ToLower(strings.TrimSpace(providerID))
+		if providerID == "" {
+			continue
+		}
+		normalized := ProviderWidgetConfig{GaugePriority: normalizeMetricKeys(widget.GaugePriority)}
+		for _, row := range widget.CompactRows {
+			row.Label = strings.TrimSpace(row.Label)
+			row.Keys = normalizeMetricKeys(row.Keys)
+			if row.Label == "" || len(row.Keys) == 0 {
+				continue
+			}
+			if row.MaxSegments < 0 {
+				row.MaxSegments = 0
+			}
+			normalized.CompactRows = append(normalized.CompactRows, row)
+		}
+		if len(normalized.GaugePriority) == 0 && len(normalized.CompactRows) == 0 {
+			continue
+		}
+		out[providerID] = normalized
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func normalizeMetricKeys(in []string) []string {
+	var out []string
+	seen := make(map[string]bool, len(in))
+	for _, key := range in {
+		key = strings.TrimSpace(key)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, key)
+	}
+	return out
+}
+
 // saveMu guards every code path that writes the config file. Both modifyConfig
 // (read-modify-write helpers like SaveTheme) and direct Save/SaveTo callers
 // must take it; otherwise a Save() can race a concurrent modifyConfig and
@@ -508,6 +1064,9 @@ func SaveTo(path string, cfg Config) error {
 
 // saveLocked is the actual write path; callers MUST hold saveMu.
 func saveLocked(path string, cfg Config) error {
+	if NoWriteFromEnv() {
+		return ErrNoWrite
+	}
 	target := path
 	if _, err := os.Lstat(path); err == nil {
 		// Resolve the full symlink chain so the write lands on the real file
@@ -625,6 +1184,18 @@ func SaveDashboardHideSectionsWithNoDataTo(path string, hide bool) error {
 	})
 }
 
+// SaveDashboardPrivacyMode persists whether the dashboard starts in privacy
+// mode.
+func SaveDashboardPrivacyMode(on bool) error {
+	return SaveDashboardPrivacyModeTo(ConfigPath(), on)
+}
+
+func SaveDashboardPrivacyModeTo(path string, on bool) error {
+	return modifyConfig(path, func(cfg *Config) {
+		cfg.Dashboard.PrivacyMode = on
+	})
+}
+
 // SaveDashboardHideCosts persists the global hide_costs toggle. Pass nil to clear
 // the override (return to plan-aware auto behavior).
 func SaveDashboardHideCosts(hide *bool) error {
@@ -651,23 +1222,77 @@ func SaveDashboardProviderHideCostsTo(path string, accountID string, hide *bool)
 	if accountID == "" {
 		return fmt.Errorf("save dashboard provider hide_costs: account_id must be non-empty")
 	}
+	return modifyDashboardProvider(path, accountID, func(p *DashboardProviderConfig) {
+		p.HideCosts = hide
+	})
+}
+
+// SaveDashboardProviderFreeTier persists the per-account free_tier override.
+// Pass nil to clear the override (fall through to the provider's own tier
+// signal). Like hide_costs, a missing entry is appended with Enabled=true.
+func SaveDashboardProviderFreeTier(accountID string, free *bool) error {
+	return SaveDashboardProviderFreeTierTo(ConfigPath(), accountID, free)
+}
+
+func SaveDashboardProviderFreeTierTo(path string, accountID string, free *bool) error {
+	accountID = normalizeAccountID(accountID)
+	if accountID == "" {
+		return fmt.Errorf("save dashboard provider free_tier: account_id must be non-empty")
+	}
+	return modifyDashboardProvider(path, accountID, func(p *DashboardProviderConfig) {
+		p.FreeTier = free
+	})
+}
+
+// modifyDashboardProvider applies fn to the dashboard entry for accountID,
+// appending an enabled entry first when there is none.
+func modifyDashboardProvider(path, accountID string, fn func(*DashboardProviderConfig)) error {
 	return modifyConfig(path, func(cfg *Config) {
-		found := false
 		for i := range cfg.Dashboard.Providers {
 			if cfg.Dashboard.Providers[i].AccountID == accountID {
-				cfg.Dashboard.Providers[i].HideCosts = hide
-				found = true
-				break
+				fn(&cfg.Dashboard.Providers[i])
+				return
 			}
 		}
-		if !found {
-			cfg.Dashboard.Providers = append(cfg.Dashboard.Providers, DashboardProviderConfig{
-				AccountID: accountID,
-				Enabled:   true,
-				HideCosts: hide,
-			})
+		entry := DashboardProviderConfig{AccountID: accountID, Enabled: true}
+		fn(&entry)
+		cfg.Dashboard.Providers = append(cfg.Dashboard.Providers, entry)
+	})
+}
+
+// SaveDashboardSnoozes persists the acknowledged warnings, dropping entries
+// that have already expired (read-modify-write).
+func SaveDashboardSnoozes(snoozes []WarningSnooze) error {
+	return SaveDashboardSnoozesTo(ConfigPath(), snoozes)
+}
+
+func SaveDashboardSnoozesTo(path string, snoozes []WarningSnooze) error {
+	return modifyConfig(path, func(cfg *Config) {
+		cfg.Dashboard.Snoozes = normalizeWarningSnoozes(snoozes, time.Now())
+	})
+}
+
+func normalizeWarningSnoozes(snoozes []WarningSnooze, now time.Time) []WarningSnooze {
+	out := make([]WarningSnooze, 0, len(snoozes))
+	for _, sn := range snoozes {
+		sn.AccountID = normalizeAccountID(sn.AccountID)
+		sn.Rule = strings.TrimSpace(sn.Rule)
+		if sn.AccountID == "" || sn.Rule == "" || !sn.Until.After(now) {
+			continue
+		}
+		sn.Until = sn.Until.UTC()
+		out = append(out, sn)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].AccountID != out[j].AccountID {
+			return out[i].AccountID < out[j].AccountID
 		}
+		return out[i].Rule < out[j].Rule
 	})
+	if len(out) == 0 {
+		return nil
+	}
+	return out
 }
 
 // SaveAutoDetected persists auto-detected accounts into the config file (read-modify-write).
@@ -679,6 +1304,53 @@ func SaveAutoDetectedTo(path string, accounts []core.AccountConfig) error {
 	return modifyConfig(path, func(cfg *Config) { cfg.AutoDetectedAccounts = accounts })
 }
 
+// SaveAccountDedupeDecision records whether the auto-detected account
+// duplicateID is merged into its configured twin or kept beside it
+// (read-modify-write).
+func SaveAccountDedupeDecision(duplicateID string, merge bool) error {
+	return SaveAccountDedupeDecisionTo(ConfigPath(), duplicateID, merge)
+}
+
+func SaveAccountDedupeDecisionTo(path, duplicateID string, merge bool) error {
+	duplicateID = normalizeAccountID(duplicateID)
+	if duplicateID == "" {
+		return fmt.Errorf("account ID is empty")
+	}
+	return modifyConfig(path, func(cfg *Config) {
+		d := &cfg.AccountDedupe
+		d.Merged = slices.DeleteFunc(d.Merged, func(id string) bool { return id == duplicateID })
+		d.Kept = slices.DeleteFunc(d.Kept, func(id string) bool { return id == duplicateID })
+		if merge {
+			d.Merged = append(d.Merged, duplicateID)
+			cfg.AutoDetectedAccounts = d.Apply(cfg.Accounts, cfg.AutoDetectedAccounts)
+		} else {
+			d.Kept = append(d.Kept, duplicateID)
+		}
+	})
+}
+
+// AddAccount appends a manual account to the config file (read-modify-write).
+// An existing account with the same ID is left untouched so user
+// customizations (base URL, env var, paths) survive re-imports.
+func AddAccount(acct core.AccountConfig) error {
+	return AddAccountTo(ConfigPath(), acct)
+}
+
+func AddAccountTo(path string, acct core.AccountConfig) error {
+	acct.ID = normalizeAccountID(acct.ID)
+	if acct.ID == "" {
+		return fmt.Errorf("account ID is empty")
+	}
+	return modifyConfig(path, func(cfg *Config) {
+		for _, existing := range cfg.Accounts {
+			if existing.ID == acct.ID {
+				return
+			}
+		}
+		cfg.Accounts = append(cfg.Accounts, acct)
+	})
+}
+
 // SaveTimeWindow persists a time window into the config file (read-modify-write).
 func SaveTimeWindow(window string) error {
 	return SaveTimeWindowTo(ConfigPath(), window)
@@ -7,9 +7,22 @@ import (
 	"path/filepath"
 )
 
-// osConfigDir returns the OpenUsage config directory on Unix: ~/.config/openusage.
-// XDG_CONFIG_HOME is intentionally not honored (see docs/reference/paths.md).
+// osConfigDir returns the OpenUsage config directory on Unix without
+// XDG_CONFIG_HOME: ~/.config/openusage.
 func osConfigDir() string {
 	home, _ := os.UserHomeDir()
 	return filepath.Join(home, ".config", "openusage")
 }
+
+// osCacheDir returns ~/.cache/openusage, on macOS too, where earlier
+// releases already kept the snapshot cache.
+func osCacheDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".cache", "openusage")
+}
+
+// osDataDir returns ~/.local/share/openusage.
+func osDataDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".local", "share", "openusage")
+}
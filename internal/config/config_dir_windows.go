@@ -11,3 +11,13 @@ import (
 func osConfigDir() string {
 	return filepath.Join(os.Getenv("APPDATA"), "openusage")
 }
+
+// osCacheDir returns %LOCALAPPDATA%\openusage\cache, which does not roam.
+func osCacheDir() string {
+	return filepath.Join(os.Getenv("LOCALAPPDATA"), "openusage", "cache")
+}
+
+// osDataDir returns %LOCALAPPDATA%\openusage\data.
+func osDataDir() string {
+	return filepath.Join(os.Getenv("LOCALAPPDATA"), "openusage", "data")
+}
@@ -1,11 +1,14 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
@@ -190,6 +193,43 @@ func TestLoadFrom_NegativeRetentionDaysGetDefault(t *testing.T) {
 	}
 }
 
+func TestLoadFrom_CustomWindows(t *testing.T) {
+	t.Cleanup(func() { core.SetCustomTimeWindows(nil) })
+	cfg := loadConfigJSON(t, `{"data":{"time_window":"sprint","custom_windows":[
+		{"id":"Sprint","days":14},
+		{"id":"quarter","start":"Quarter","label":"This quarter"},
+		{"id":"7d","days":7},
+		{"id":"forever","days":0}
+	]}}`)
+	if len(cfg.Data.CustomWindows) != 2 || cfg.Data.CustomWindows[0].ID != "sprint" || cfg.Data.CustomWindows[1].Start != "quarter" {
+		t.Fatalf("custom_windows = %+v, want sprint and quarter", cfg.Data.CustomWindows)
+	}
+	if cfg.Data.TimeWindow != "sprint" {
+		t.Errorf("time_window = %q, want custom window sprint", cfg.Data.TimeWindow)
+	}
+	if !core.TimeWindow("quarter").IsCustom() {
+		t.Error("quarter was not registered")
+	}
+}
+
+func TestLoadFrom_PollPriority(t *testing.T) {
+	cfg := loadConfigJSON(t, `{"polling":{"low_priority_every":-2},"accounts":[
+		{"id":"a","provider":"openai","priority":" High "},
+		{"id":"b","provider":"openai","priority":"normal"},
+		{"id":"c","provider":"openai","priority":"urgent"},
+		{"id":"d","provider":"openai","priority":"low"}
+	]}`)
+	if cfg.Polling.LowPriorityEvery != 0 {
+		t.Errorf("low_priority_every = %d, want 0 (default)", cfg.Polling.LowPriorityEvery)
+	}
+	want := map[string]string{"a": "high", "b": "", "c": "", "d": "low"}
+	for _, acct := range cfg.Accounts {
+		if acct.Priority != want[acct.ID] {
+			t.Errorf("account %s priority = %q, want %q", acct.ID, acct.Priority, want[acct.ID])
+		}
+	}
+}
+
 func TestSaveTo_CreatesFileAndDir(t *testing.T) {
 	dir := filepath.Join(t.TempDir(), "nested", "dir")
 	path := filepath.Join(dir, "settings.json")
@@ -442,6 +482,39 @@ func TestSaveAutoDetectedTo(t *testing.T) {
 	}
 }
 
+func TestAddAccountTo_KeepsExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	cfg := DefaultConfig()
+	cfg.Accounts = []core.AccountConfig{{ID: "openrouter", Provider: "openrouter", BaseURL: "https://proxy.example/api/v1"}}
+	if err := SaveTo(path, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := AddAccountTo(path, core.AccountConfig{ID: "openrouter", Provider: "openrouter", Auth: "api_key"}); err != nil {
+		t.Fatalf("AddAccountTo existing: %v", err)
+	}
+	if err := AddAccountTo(path, core.AccountConfig{ID: " groq ", Provider: "groq", Auth: "api_key", Token: "gsk_secret"}); err != nil {
+		t.Fatalf("AddAccountTo new: %v", err)
+	}
+	if err := AddAccountTo(path, core.AccountConfig{Provider: "groq"}); err == nil {
+		t.Error("empty account ID should be rejected")
+	}
+
+	loaded, err := LoadFrom(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Accounts) != 2 {
+		t.Fatalf("accounts = %+v, want 2", loaded.Accounts)
+	}
+	if loaded.Accounts[0].BaseURL != "https://proxy.example/api/v1" {
+		t.Errorf("existing account was overwritten: %+v", loaded.Accounts[0])
+	}
+	if got := loaded.Accounts[1]; got.ID != "groq" || got.Token != "" {
+		t.Errorf("added account = %+v, want trimmed ID and no persisted token", got)
+	}
+}
+
 func TestSaveThemeTo_ThreadSafety(t *testing.T) {
 	path := filepath.Join(t.TempDir(), "settings.json")
 
@@ -682,6 +755,88 @@ func TestLoadFrom_DashboardViewDefaultsToGrid(t *testing.T) {
 	}
 }
 
+func TestSaveDashboardSnoozesTo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	cfg := DefaultConfig()
+	cfg.Dashboard.SnoozeHours = map[string]int{SnoozeRuleLimit: 168}
+	if err := SaveTo(path, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	future := time.Now().Add(3 * time.Hour).UTC().Truncate(time.Second)
+	err := SaveDashboardSnoozesTo(path, []WarningSnooze{
+		{AccountID: "codex", Rule: SnoozeRuleLimit, Until: future},
+		{AccountID: "claude", Rule: SnoozeRuleNearLimit, Until: time.Now().Add(-time.Minute)}, // expired
+		{AccountID: "", Rule: SnoozeRuleLimit, Until: future},                                 // no account
+	})
+	if err != nil {
+		t.Fatalf("SaveDashboardSnoozesTo error: %v", err)
+	}
+
+	loaded, err := LoadFrom(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := loaded.Dashboard.Snoozes
+	if len(got) != 1 || got[0].AccountID != "codex" || got[0].Rule != SnoozeRuleLimit || !got[0].Until.Equal(future) {
+		t.Errorf("snoozes = %#v, want only the active codex limit snooze", got)
+	}
+	if d := loaded.Dashboard.SnoozeDuration(SnoozeRuleLimit); d != 168*time.Hour {
+		t.Errorf("limit snooze duration = %s, want 168h from snooze_hours", d)
+	}
+	if d := loaded.Dashboard.SnoozeDuration(SnoozeRuleNearLimit); d != 8*time.Hour {
+		t.Errorf("near_limit snooze duration = %s, want default 8h", d)
+	}
+}
+
+func TestSaveDashboardProviderFreeTierTo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	hide := true
+	cfg := DefaultConfig()
+	cfg.Dashboard.Providers = []DashboardProviderConfig{{AccountID: "groq", Enabled: false, HideCosts: &hide}}
+	if err := SaveTo(path, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	on := true
+	if err := SaveDashboardProviderFreeTierTo(path, "groq", &on); err != nil {
+		t.Fatalf("SaveDashboardProviderFreeTierTo existing: %v", err)
+	}
+	off := false
+	if err := SaveDashboardProviderFreeTierTo(path, "gemini-api", &off); err != nil {
+		t.Fatalf("SaveDashboardProviderFreeTierTo new: %v", err)
+	}
+	if err := SaveDashboardProviderFreeTierTo(path, " ", &on); err == nil {
+		t.Error("empty account ID should be rejected")
+	}
+
+	loaded, err := LoadFrom(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := loaded.Dashboard.Providers
+	if len(got) != 2 {
+		t.Fatalf("providers = %+v, want groq and gemini-api", got)
+	}
+	if got[0].FreeTier == nil || !*got[0].FreeTier || got[0].Enabled || got[0].HideCosts == nil {
+		t.Errorf("groq entry = %+v, want free_tier on with enabled/hide_costs kept", got[0])
+	}
+	if got[1].AccountID != "gemini-api" || !got[1].Enabled || got[1].FreeTier == nil || *got[1].FreeTier {
+		t.Errorf("gemini-api entry = %+v, want appended, enabled, free_tier off", got[1])
+	}
+
+	if err := SaveDashboardProviderFreeTierTo(path, "groq", nil); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err = LoadFrom(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Dashboard.Providers[0].FreeTier != nil {
+		t.Errorf("nil should clear the override, got %v", *loaded.Dashboard.Providers[0].FreeTier)
+	}
+}
+
 func TestSaveDashboardViewTo(t *testing.T) {
 	path := filepath.Join(t.TempDir(), "settings.json")
 
@@ -1281,3 +1436,256 @@ func TestExportTargetPreservedAcrossModifyConfig(t *testing.T) {
 		t.Errorf("export.machine_name = %q after SaveAutoDetected, want mybox", cfg.Export.MachineName)
 	}
 }
+
+func TestReadOnlyEnabled(t *testing.T) {
+	t.Setenv(EnvReadOnly, "")
+	cfg := loadConfigJSON(t, `{"read_only":true}`)
+	if !cfg.ReadOnlyEnabled() {
+		t.Fatal("read_only=true in settings should enable read-only mode")
+	}
+
+	cfg = loadConfigJSON(t, `{}`)
+	if cfg.ReadOnlyEnabled() {
+		t.Fatal("read-only mode should default to off")
+	}
+
+	t.Setenv(EnvReadOnly, "1")
+	if !cfg.ReadOnlyEnabled() {
+		t.Fatalf("%s=1 should force read-only mode", EnvReadOnly)
+	}
+}
+
+func TestLoadFrom_Network(t *testing.T) {
+	cfg := loadConfigJSON(t, `{
+		"network": {
+			"proxy": "http://proxy.corp:3128",
+			"ca_bundle": "/etc/corp-ca.pem",
+			"providers": {"moonshot": {"proxy": "socks5://cn-gateway:1080"}}
+		},
+		"accounts": [{"id": "deepseek", "provider": "deepseek", "network": {"proxy": "direct"}}]
+	}`)
+
+	if cfg.Network.Proxy != "http://proxy.corp:3128" || cfg.Network.CABundle != "/etc/corp-ca.pem" {
+		t.Fatalf("network defaults = %+v", cfg.Network.NetworkConfig)
+	}
+	if got := cfg.Network.ForProvider("moonshot").Proxy; got != "socks5://cn-gateway:1080" {
+		t.Fatalf("moonshot proxy = %q", got)
+	}
+	if len(cfg.Accounts) != 1 || cfg.Accounts[0].Network == nil || cfg.Accounts[0].Network.Proxy != core.ProxyDirect {
+		t.Fatalf("account network = %+v", cfg.Accounts)
+	}
+}
+
+func TestReadOnlyEnvNotPersistedByModifyConfig(t *testing.T) {
+	t.Setenv(EnvReadOnly, "1")
+	path := writeSettingsJSON(t, `{"theme":"Gruvbox"}`)
+
+	if err := SaveThemeTo(path, "Dracula"); err != nil {
+		t.Fatalf("SaveThemeTo: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "read_only") {
+		t.Fatalf("env override leaked into settings.json:\n%s", data)
+	}
+}
+
+func TestNoWrite_LeavesFilesUntouched(t *testing.T) {
+	t.Setenv(EnvNoWrite, "1")
+	const settings = `{"theme":"Gruvbox"}`
+	path := writeSettingsJSON(t, settings)
+	credsPath := filepath.Join(filepath.Dir(path), "credentials.json")
+
+	if err := SaveThemeTo(path, "Dracula"); !errors.Is(err, ErrNoWrite) {
+		t.Errorf("SaveThemeTo() error = %v, want ErrNoWrite", err)
+	}
+	if err := SaveTo(path, DefaultConfig()); !errors.Is(err, ErrNoWrite) {
+		t.Errorf("SaveTo() error = %v, want ErrNoWrite", err)
+	}
+	if err := SaveCredentialTo(credsPath, "openai", "sk-test"); !errors.Is(err, ErrNoWrite) {
+		t.Errorf("SaveCredentialTo() error = %v, want ErrNoWrite", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != settings {
+		t.Errorf("settings.json rewritten:\n%s", data)
+	}
+	if _, err := os.Stat(credsPath); !os.IsNotExist(err) {
+		t.Errorf("credentials.json created (stat err = %v)", err)
+	}
+}
+
+func TestNormalizeUpdateChannel(t *testing.T) {
+	tests := map[string]string{
+		`{}`:                               "",
+		`{"update":{"channel":"stable"}}`:  "",
+		`{"update":{"channel":"Nightly"}}`: UpdateChannelNightly,
+		`{"update":{"channel":"beta"}}`:    "",
+	}
+	for raw, want := range tests {
+		if got := loadConfigJSON(t, raw).Update.Channel; got != want {
+			t.Errorf("%s: Update.Channel = %q, want %q", raw, got, want)
+		}
+	}
+}
+
+func TestNormalizePollingConfig(t *testing.T) {
+	if cfg := loadConfigJSON(t, `{}`); !cfg.Polling.AdaptiveEnabled() || cfg.Polling.IdleCycles != 0 {
+		t.Fatalf("default Polling = %+v, want adaptive with default idle cycles", cfg.Polling)
+	}
+	cfg := loadConfigJSON(t, `{"polling":{"adaptive":false,"idle_cycles":-2,"max_interval_seconds":300}}`)
+	if cfg.Polling.AdaptiveEnabled() {
+		t.Error("adaptive=false should disable adaptive polling")
+	}
+	if cfg.Polling.IdleCycles != 0 {
+		t.Errorf("IdleCycles = %d, want negative values reset to 0", cfg.Polling.IdleCycles)
+	}
+	if cfg.Polling.MaxIntervalSeconds != 300 {
+		t.Errorf("MaxIntervalSeconds = %d, want 300", cfg.Polling.MaxIntervalSeconds)
+	}
+}
+
+func TestNormalizePollSchedule(t *testing.T) {
+	cfg := loadConfigJSON(t, `{
+		"polling":{"schedule":{"timezone":"Europe/Warsaw","rules":[
+			{"days":["weekdays"],"from":"09:00","to":"18:00","interval_seconds":30},
+			{"from":"25:00","interval_seconds":60},
+			{"days":["weekends"],"pause":true}
+		]}},
+		"accounts":[
+			{"id":"quiet","provider":"openai","poll_schedule":{"rules":[]}},
+			{"id":"mars","provider":"openai","poll_schedule":{"timezone":"Mars/Olympus","rules":[{"pause":true}]}}
+		]
+	}`)
+	schedule := cfg.Polling.Schedule
+	if schedule == nil || schedule.Timezone != "Europe/Warsaw" || len(schedule.Rules) != 2 || !schedule.Rules[1].Pause {
+		t.Fatalf("Polling.Schedule = %+v, want the two valid rules", schedule)
+	}
+	for _, acct := range cfg.Accounts {
+		switch acct.ID {
+		case "quiet":
+			if acct.PollSchedule == nil || len(acct.PollSchedule.Rules) != 0 {
+				t.Errorf("empty account schedule = %+v, want kept to opt out of the global one", acct.PollSchedule)
+			}
+		case "mars":
+			if acct.PollSchedule != nil {
+				t.Errorf("schedule with unknown timezone = %+v, want dropped", acct.PollSchedule)
+			}
+		}
+	}
+	if cfg := loadConfigJSON(t, `{"polling":{"schedule":{"rules":[{"pause":true,"interval_seconds":5}]}}}`); cfg.Polling.Schedule != nil {
+		t.Errorf("global schedule without valid rules = %+v, want nil", cfg.Polling.Schedule)
+	}
+}
+
+func TestNormalizeDerivedMetricsDropsInvalid(t *testing.T) {
+	cfg := loadConfigJSON(t, `{"derived_metrics":[
+		{"name":" cost_per_message ","expr":"today_api_cost / messages_today","unit":"USD"},
+		{"name":"broken","expr":"today_api_cost /"},
+		{"name":"Bad Name","expr":"1"},
+		{"name":"cost_per_message","expr":"1"}
+	]}`)
+	if len(cfg.DerivedMetrics) != 1 {
+		t.Fatalf("DerivedMetrics = %+v, want only the first valid definition", cfg.DerivedMetrics)
+	}
+	if got := cfg.DerivedMetrics[0]; got.Name != "cost_per_message" || got.Unit != "USD" {
+		t.Fatalf("DerivedMetrics[0] = %+v", got)
+	}
+}
+
+func TestSaveDashboardPrivacyModeTo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+
+	cfg := DefaultConfig()
+	cfg.Theme = "Nord"
+	if err := SaveTo(path, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SaveDashboardPrivacyModeTo(path, true); err != nil {
+		t.Fatalf("SaveDashboardPrivacyModeTo error: %v", err)
+	}
+	loaded, err := LoadFrom(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Theme != "Nord" {
+		t.Errorf("theme should be preserved, got %q", loaded.Theme)
+	}
+	if !loaded.Dashboard.PrivacyMode {
+		t.Fatal("dashboard.privacy_mode = false, want true")
+	}
+
+	if err := SaveDashboardPrivacyModeTo(path, false); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err = LoadFrom(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Dashboard.PrivacyMode {
+		t.Fatal("dashboard.privacy_mode = true after turning it off")
+	}
+}
+
+func TestAccountDedupe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	cfg := DefaultConfig()
+	cfg.Accounts = []core.AccountConfig{
+		{ID: "openai-work", Provider: "openai", APIKeyEnv: "OPENAI_API_KEY"},
+		{ID: "groq-main", Provider: "groq", APIKeyEnv: "GROQ_API_KEY"},
+	}
+	cfg.AutoDetectedAccounts = []core.AccountConfig{
+		{ID: "openai", Provider: "openai", APIKeyEnv: "OPENAI_API_KEY"},
+		{ID: "groq", Provider: "groq", APIKeyEnv: "GROQ_API_KEY"},
+		{ID: "xai", Provider: "xai", APIKeyEnv: "XAI_API_KEY"},
+	}
+	if err := SaveTo(path, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadFrom(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending := loaded.PendingDuplicateAccounts(); len(pending) != 2 {
+		t.Fatalf("pending duplicates = %+v, want openai and groq", pending)
+	}
+
+	if err := SaveAccountDedupeDecisionTo(path, "openai", true); err != nil {
+		t.Fatalf("merge openai: %v", err)
+	}
+	if err := SaveAccountDedupeDecisionTo(path, "groq", false); err != nil {
+		t.Fatalf("keep groq: %v", err)
+	}
+	loaded, err = LoadFrom(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending := loaded.PendingDuplicateAccounts(); len(pending) != 0 {
+		t.Errorf("pending after decisions = %+v, want none", pending)
+	}
+	var ids []string
+	for _, acct := range loaded.AutoDetectedAccounts {
+		ids = append(ids, acct.ID)
+	}
+	if strings.Join(ids, ",") != "groq,xai" {
+		t.Errorf("auto-detected after merge = %v, want groq,xai", ids)
+	}
+
+	// A later re-detection brings the merged duplicate back; Apply drops it
+	// again, and auto drops the kept one too.
+	redetected := append([]core.AccountConfig{{ID: "openai", Provider: "openai", APIKeyEnv: "OPENAI_API_KEY"}}, loaded.AutoDetectedAccounts...)
+	if got := loaded.AccountDedupe.Apply(loaded.Accounts, redetected); len(got) != 2 {
+		t.Errorf("Apply() = %+v, want merged openai dropped", got)
+	}
+	auto := AccountDedupeConfig{Auto: true, Kept: []string{"groq"}}
+	if got := auto.Apply(loaded.Accounts, redetected); len(got) != 1 || got[0].ID != "xai" {
+		t.Errorf("auto Apply() = %+v, want only xai", got)
+	}
+}
@@ -46,8 +46,20 @@ type BrowserSession struct {
 // credMu guards read-modify-write cycles on the credentials file.
 var credMu sync.Mutex
 
+// EnvCredentials points credential reads and writes at an explicit file
+// instead of the profile's credentials.json, e.g. a secret managed outside
+// the config directory.
+const EnvCredentials = "OPENUSAGE_CREDENTIALS"
+
+// CredentialsPath returns the credentials file of the process:
+// OPENUSAGE_CREDENTIALS when set, otherwise the active profile's
+// credentials.json. It is not affected by OPENUSAGE_CONFIG, so --config swaps
+// settings only.
 func CredentialsPath() string {
-	return filepath.Join(ConfigDir(), "credentials.json")
+	if path := strings.TrimSpace(os.Getenv(EnvCredentials)); path != "" {
+		return path
+	}
+	return filepath.Join(ProfileDir(), "credentials.json")
 }
 
 func LoadCredentials() (Credentials, error) {
@@ -234,6 +246,9 @@ func LoadSessionFrom(path, accountID string) (BrowserSession, bool, error) {
 }
 
 func writeCredentials(path string, creds Credentials) error {
+	if NoWriteFromEnv() {
+		return ErrNoWrite
+	}
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return fmt.Errorf("creating credentials dir: %w", err)
@@ -0,0 +1,598 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// LayoutPreset is the shareable part of the dashboard configuration: view,
+// visible sections, provider gauge priorities and compact rows, and the
+// warn/crit thresholds. It carries no accounts or credentials, so a team can
+// pass one around as a TOML snippet.
+type LayoutPreset struct {
+	Name                   string
+	View                   string
+	HideSectionsWithNoData bool
+	WarnThreshold          float64
+	CritThreshold          float64
+	// WidgetSections and DetailSections are nil when the preset keeps the
+	// built-in section layout.
+	WidgetSections  []DashboardWidgetSection
+	DetailSections  []DetailWidgetSection
+	ProviderWidgets map[string]ProviderWidgetConfig
+}
+
+// ExportLayoutPreset captures cfg's dashboard layout as a preset.
+func ExportLayoutPreset(cfg Config, name string) LayoutPreset {
+	return LayoutPreset{
+		Name:                   strings.TrimSpace(name),
+		View:                   normalizeDashboardView(cfg.Dashboard.View),
+		HideSectionsWithNoData: cfg.Dashboard.HideSectionsWithNoData,
+		WarnThreshold:          cfg.UI.WarnThreshold,
+		CritThreshold:          cfg.UI.CritThreshold,
+		WidgetSections:         normalizeDashboardWidgetSections(cfg.Dashboard.WidgetSections),
+		DetailSections:         normalizeDetailWidgetSections(cfg.Dashboard.DetailSections),
+		ProviderWidgets:        normalizeProviderWidgets(cfg.Dashboard.ProviderWidgets),
+	}
+}
+
+// ApplyTo replaces cfg's dashboard layout with the preset's. Accounts,
+// per-account settings and everything outside the layout are left alone.
+func (p LayoutPreset) ApplyTo(cfg *Config) {
+	cfg.Dashboard.View = normalizeDashboardView(p.View)
+	cfg.Dashboard.HideSectionsWithNoData = p.HideSectionsWithNoData
+	if p.WarnThreshold > 0 {
+		cfg.UI.WarnThreshold = p.WarnThreshold
+	}
+	if p.CritThreshold > 0 {
+		cfg.UI.CritThreshold = p.CritThreshold
+	}
+	cfg.UI = normalizeUIConfig(cfg.UI)
+	cfg.Dashboard.WidgetSections = normalizeDashboardWidgetSections(p.WidgetSections)
+	cfg.Dashboard.DetailSections = normalizeDetailWidgetSections(p.DetailSections)
+	cfg.Dashboard.ProviderWidgets = normalizeProviderWidgets(p.ProviderWidgets)
+}
+
+// ImportLayoutPreset applies a preset to the config file
+// (read-modify-write).
+func ImportLayoutPreset(p LayoutPreset) error {
+	return ImportLayoutPresetTo(ConfigPath(), p)
+}
+
+func ImportLayoutPresetTo(path string, p LayoutPreset) error {
+	return modifyConfig(path, p.ApplyTo)
+}
+
+// TOML renders the preset in the format ParseLayoutPreset reads.
+func (p LayoutPreset) TOML() []byte {
+	var b strings.Builder
+	b.WriteString("# openusage dashboard layout preset\n")
+	b.WriteString("# Apply with: openusage preset import <file>\n\n")
+	if p.Name != "" {
+		fmt.Fprintf(&b, "name = %s\n", strconv.Quote(p.Name))
+	}
+	fmt.Fprintf(&b, "view = %s\n", strconv.Quote(p.View))
+	fmt.Fprintf(&b, "hide_sections_with_no_data = %t\n", p.HideSectionsWithNoData)
+	if p.WarnThreshold > 0 {
+		fmt.Fprintf(&b, "warn_threshold = %s\n", strconv.FormatFloat(p.WarnThreshold, 'f', -1, 64))
+	}
+	if p.CritThreshold > 0 {
+		fmt.Fprintf(&b, "crit_threshold = %s\n", strconv.FormatFloat(p.CritThreshold, 'f', -1, 64))
+	}
+
+	if len(p.WidgetSections) > 0 {
+		var show, hide []string
+		for _, s := range p.WidgetSections {
+			if s.Enabled {
+				show = append(show, string(s.ID))
+			} else {
+				hide = append(hide, string(s.ID))
+			}
+		}
+		writeTOMLSections(&b, "tile_sections", show, hide)
+	}
+	if len(p.DetailSections) > 0 {
+		var show, hide []string
+		for _, s := range p.DetailSections {
+			if s.Enabled {
+				show = append(show, string(s.ID))
+			} else {
+				hide = append(hide, string(s.ID))
+			}
+		}
+		writeTOMLSections(&b, "detail_sections", show, hide)
+	}
+
+	providerIDs := make([]string, 0, len(p.ProviderWidgets))
+	for id := range p.ProviderWidgets {
+		providerIDs = append(providerIDs, id)
+	}
+	sort.Strings(providerIDs)
+	for _, id := range providerIDs {
+		widget := p.ProviderWidgets[id]
+		fmt.Fprintf(&b, "\n[providers.%s]\n", tomlKey(id))
+		if len(widget.GaugePriority) > 0 {
+			fmt.Fprintf(&b, "gauge_priority = %s\n", tomlStringArray(widget.GaugePriority))
+		}
+		for _, row := range widget.CompactRows {
+			fmt.Fprintf(&b, "\n[[providers.%s.compact_rows]]\n", tomlKey(id))
+			fmt.Fprintf(&b, "label = %s\n", strconv.Quote(row.Label))
+			fmt.Fprintf(&b, "keys = %s\n", tomlStringArray(row.Keys))
+			if row.MaxSegments > 0 {
+				fmt.Fprintf(&b, "max_segments = %d\n", row.MaxSegments)
+			}
+		}
+	}
+	return []byte(b.String())
+}
+
+func writeTOMLSections(b *strings.Builder, table string, show, hide []string) {
+	fmt.Fprintf(b, "\n[%s]\n", table)
+	fmt.Fprintf(b, "show = %s\n", tomlStringArray(show))
+	if len(hide) > 0 {
+		fmt.Fprintf(b, "hide = %s\n", tomlStringArray(hide))
+	}
+}
+
+func tomlStringArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func tomlKey(key string) string {
+	for _, r := range key {
+		if !isTOMLBareKeyRune(r) {
+			return strconv.Quote(key)
+		}
+	}
+	return key
+}
+
+func isTOMLBareKeyRune(r rune) bool {
+	return r == '_' || r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// ParseLayoutPreset reads a preset written by LayoutPreset.TOML or by hand.
+// It understands the TOML subset presets use: tables, arrays of tables,
+// strings, numbers, booleans and string arrays. Unknown keys are errors so
+// a typo doesn't silently drop part of the layout.
+func ParseLayoutPreset(data []byte) (LayoutPreset, error) {
+	entries, err := parseTOMLEntries(string(data))
+	if err != nil {
+		return LayoutPreset{}, err
+	}
+
+	var p LayoutPreset
+	var tileShow, tileHide, detailShow, detailHide []string
+	sawTile, sawDetail := false, false
+	rows := map[string]map[int]*CompactRowConfig{}
+	for _, e := range entries {
+		bad := func(want string) error {
+			return fmt.Errorf("preset line %d: %s must be %s", e.line, e.key, want)
+		}
+		path := strings.Join(e.table, ".")
+		switch {
+		case path == "":
+			switch e.key {
+			case "name", "view":
+				v, ok := e.value.(string)
+				if !ok {
+					return LayoutPreset{}, bad("a string")
+				}
+				if e.key == "name" {
+					p.Name = v
+				} else {
+					p.View = v
+				}
+			case "hide_sections_with_no_data":
+				v, ok := e.value.(bool)
+				if !ok {
+					return LayoutPreset{}, bad("true or false")
+				}
+				p.HideSectionsWithNoData = v
+			case "warn_threshold", "crit_threshold":
+				v, ok := e.value.(float64)
+				if !ok || v <= 0 || v > 1 {
+					return LayoutPreset{}, bad("a number in (0, 1]")
+				}
+				if e.key == "warn_threshold" {
+					p.WarnThreshold = v
+				} else {
+					p.CritThreshold = v
+				}
+			default:
+				return LayoutPreset{}, fmt.Errorf("preset line %d: unknown key %q", e.line, e.key)
+			}
+		case path == "tile_sections" || path == "detail_sections":
+			v, ok := e.value.([]string)
+			if !ok || (e.key != "show" && e.key != "hide") {
+				return LayoutPreset{}, fmt.Errorf("preset line %d: [%s] takes show and hide string arrays", e.line, path)
+			}
+			switch {
+			case path == "tile_sections" && e.key == "show":
+				tileShow, sawTile = v, true
+			case path == "tile_sections":
+				tileHide, sawTile = v, true
+			case e.key == "show":
+				detailShow, sawDetail = v, true
+			default:
+				detailHide, sawDetail = v, true
+			}
+		case len(e.table) == 2 && e.table[0] == "providers":
+			if e.key != "gauge_priority" {
+				return LayoutPreset{}, fmt.Errorf("preset line %d: unknown key %q in [%s]", e.line, e.key, path)
+			}
+			v, ok := e.value.([]string)
+			if !ok {
+				return LayoutPreset{}, bad("a string array")
+			}
+			widget := ensureProviderWidget(&p, e.table[1])
+			widget.GaugePriority = v
+			p.ProviderWidgets[e.table[1]] = widget
+		case len(e.table) == 3 && e.table[0] == "providers" && e.table[2] == "compact_rows" && e.arrayIndex >= 0:
+			providerID := e.table[1]
+			if rows[providerID] == nil {
+				rows[providerID] = map[int]*CompactRowConfig{}
+			}
+			row := rows[providerID][e.arrayIndex]
+			if row == nil {
+				row = &CompactRowConfig{}
+				rows[providerID][e.arrayIndex] = row
+			}
+			switch e.key {
+			case "label":
+				v, ok := e.value.(string)
+				if !ok {
+					return LayoutPreset{}, bad("a string")
+				}
+				row.Label = v
+			case "keys":
+				v, ok := e.value.([]string)
+				if !ok {
+					return LayoutPreset{}, bad("a string array")
+				}
+				row.Keys = v
+			case "max_segments":
+				v, ok := e.value.(float64)
+				if !ok || v < 0 || v != float64(int(v)) {
+					return LayoutPreset{}, bad("a whole number")
+				}
+				row.MaxSegments = int(v)
+			default:
+				return LayoutPreset{}, fmt.Errorf("preset line %d: unknown key %q in [[%s]]", e.line, e.key, path)
+			}
+		default:
+			return LayoutPreset{}, fmt.Errorf("preset line %d: unknown table [%s]", e.line, path)
+		}
+	}
+
+	// Sections a preset doesn't show are hidden, including ones it doesn't
+	// list at all; otherwise the dashboard would fill them in as visible.
+	for _, id := range append(append([]string(nil), tileShow...), tileHide...) {
+		section := core.NormalizeDashboardStandardSection(core.DashboardStandardSection(strings.ToLower(strings.TrimSpace(id))))
+		if !core.IsKnownDashboardStandardSection(section) {
+			return LayoutPreset{}, fmt.Errorf("preset: unknown tile section %q", id)
+		}
+	}
+	for _, id := range append(append([]string(nil), detailShow...), detailHide...) {
+		if !core.IsKnownDetailStandardSection(core.DetailStandardSection(strings.ToLower(strings.TrimSpace(id)))) {
+			return LayoutPreset{}, fmt.Errorf("preset: unknown detail section %q", id)
+		}
+	}
+	if sawTile {
+		for _, id := range tileShow {
+			p.WidgetSections = append(p.WidgetSections, DashboardWidgetSection{ID: core.DashboardStandardSection(id), Enabled: true})
+		}
+		for _, id := range tileHide {
+			p.WidgetSections = append(p.WidgetSections, DashboardWidgetSection{ID: core.DashboardStandardSection(id)})
+		}
+		for _, id := range core.DashboardStandardSections() {
+			p.WidgetSections = append(p.WidgetSections, DashboardWidgetSection{ID: id})
+		}
+		p.WidgetSections = normalizeDashboardWidgetSections(p.WidgetSections)
+	}
+	if sawDetail {
+		for _, id := range detailShow {
+			p.DetailSections = append(p.DetailSections, DetailWidgetSection{ID: core.DetailStandardSection(id), Enabled: true})
+		}
+		for _, id := range detailHide {
+			p.DetailSections = append(p.DetailSections, DetailWidgetSection{ID: core.DetailStandardSection(id)})
+		}
+		for _, id := range core.DefaultDetailSectionOrder() {
+			p.DetailSections = append(p.DetailSections, DetailWidgetSection{ID: id})
+		}
+		p.DetailSections = normalizeDetailWidgetSections(p.DetailSections)
+	}
+	for providerID, byIndex := range rows {
+		widget := ensureProviderWidget(&p, providerID)
+		indexes := make([]int, 0, len(byIndex))
+		for i := range byIndex {
+			indexes = append(indexes, i)
+		}
+		sort.Ints(indexes)
+		for _, i := range indexes {
+			widget.CompactRows = append(widget.CompactRows, *byIndex[i])
+		}
+		p.ProviderWidgets[providerID] = widget
+	}
+	p.View = normalizeDashboardView(p.View)
+	p.ProviderWidgets = normalizeProviderWidgets(p.ProviderWidgets)
+	return p, nil
+}
+
+func ensureProviderWidget(p *LayoutPreset, providerID string) ProviderWidgetConfig {
+	if p.ProviderWidgets == nil {
+		p.ProviderWidgets = map[string]ProviderWidgetConfig{}
+	}
+	return p.ProviderWidgets[providerID]
+}
+
+// tomlEntry is one key = value assignment and the table it sits in.
+// arrayIndex counts repeats of an [[array table]] header and is -1 in
+// ordinary tables.
+type tomlEntry struct {
+	table      []string
+	arrayIndex int
+	key        string
+	value      any // string, float64, bool or []string
+	line       int
+}
+
+func parseTOMLEntries(src string) ([]tomlEntry, error) {
+	s := &tomlScanner{src: src, line: 1}
+	var entries []tomlEntry
+	var table []string
+	arrayIndex := -1
+	arrayCounts := map[string]int{}
+	seen := map[string]bool{}
+	for {
+		s.skipBlankLines()
+		if s.done() {
+			return entries, nil
+		}
+		line := s.line
+		if s.peek() == '[' {
+			s.pos++
+			isArray := !s.done() && s.peek() == '['
+			if isArray {
+				s.pos++
+			}
+			parts, err := s.dottedKey()
+			if err != nil {
+				return nil, err
+			}
+			closing := "]"
+			if isArray {
+				closing = "]]"
+			}
+			s.skipSpaces()
+			if !strings.HasPrefix(s.src[s.pos:], closing) {
+				return nil, fmt.Errorf("preset line %d: expected %q", line, closing)
+			}
+			s.pos += len(closing)
+			if err := s.endOfLine(); err != nil {
+				return nil, err
+			}
+			table = parts
+			path := strings.Join(parts, ".")
+			arrayIndex = -1
+			if isArray {
+				arrayIndex = arrayCounts[path]
+				arrayCounts[path]++
+			}
+			continue
+		}
+
+		key, err := s.key()
+		if err != nil {
+			return nil, err
+		}
+		s.skipSpaces()
+		if s.done() || s.peek() != '=' {
+			return nil, fmt.Errorf("preset line %d: expected '=' after %q", line, key)
+		}
+		s.pos++
+		s.skipSpaces()
+		value, err := s.value()
+		if err != nil {
+			return nil, err
+		}
+		if err := s.endOfLine(); err != nil {
+			return nil, err
+		}
+		id := fmt.Sprintf("%s#%d.%s", strings.Join(table, "."), arrayIndex, key)
+		if seen[id] {
+			return nil, fmt.Errorf("preset line %d: %q is set twice", line, key)
+		}
+		seen[id] = true
+		entries = append(entries, tomlEntry{table: table, arrayIndex: arrayIndex, key: key, value: value, line: line})
+	}
+}
+
+type tomlScanner struct {
+	src  string
+	pos  int
+	line int
+}
+
+func (s *tomlScanner) done() bool { return s.pos >= len(s.src) }
+func (s *tomlScanner) peek() byte { return s.src[s.pos] }
+
+func (s *tomlScanner) skipSpaces() {
+	for !s.done() && (s.peek() == ' ' || s.peek() == '\t') {
+		s.pos++
+	}
+}
+
+func (s *tomlScanner) skipComment() {
+	if !s.done() && s.peek() == '#' {
+		for !s.done() && s.peek() != '\n' {
+			s.pos++
+		}
+	}
+}
+
+// skipBlankLines skips whitespace, comments and newlines.
+func (s *tomlScanner) skipBlankLines() {
+	for {
+		s.skipSpaces()
+		s.skipComment()
+		if s.done() {
+			return
+		}
+		switch s.peek() {
+		case '\n':
+			s.line++
+			s.pos++
+		case '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *tomlScanner) endOfLine() error {
+	s.skipSpaces()
+	s.skipComment()
+	if s.done() {
+		return nil
+	}
+	if s.peek() == '\r' {
+		s.pos++
+	}
+	if s.done() || s.peek() == '\n' {
+		return nil
+	}
+	return fmt.Errorf("preset line %d: unexpected %q", s.line, s.src[s.pos:s.lineEnd()])
+}
+
+func (s *tomlScanner) lineEnd() int {
+	if i := strings.IndexByte(s.src[s.pos:], '\n'); i >= 0 {
+		return s.pos + i
+	}
+	return len(s.src)
+}
+
+func (s *tomlScanner) key() (string, error) {
+	s.skipSpaces()
+	if !s.done() && (s.peek() == '"' || s.peek() == '\'') {
+		return s.str()
+	}
+	start := s.pos
+	for !s.done() && isTOMLBareKeyRune(rune(s.peek())) {
+		s.pos++
+	}
+	if s.pos == start {
+		return "", fmt.Errorf("preset line %d: expected a key", s.line)
+	}
+	return s.src[start:s.pos], nil
+}
+
+func (s *tomlScanner) dottedKey() ([]string, error) {
+	var parts []string
+	for {
+		part, err := s.key()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+		s.skipSpaces()
+		if s.done() || s.peek() != '.' {
+			return parts, nil
+		}
+		s.pos++
+	}
+}
+
+func (s *tomlScanner) str() (string, error) {
+	quote := s.peek()
+	end := s.pos + 1
+	for end < len(s.src) && s.src[end] != quote && s.src[end] != '\n' {
+		if quote == '"' && s.src[end] == '\\' {
+			end++
+		}
+		end++
+	}
+	if end >= len(s.src) || s.src[end] != quote {
+		return "", fmt.Errorf("preset line %d: unterminated string", s.line)
+	}
+	raw := s.src[s.pos : end+1]
+	s.pos = end + 1
+	if quote == '\'' {
+		return raw[1 : len(raw)-1], nil
+	}
+	v, err := strconv.Unquote(raw)
+	if err != nil {
+		return "", fmt.Errorf("preset line %d: invalid string %s", s.line, raw)
+	}
+	return v, nil
+}
+
+func (s *tomlScanner) value() (any, error) {
+	if s.done() {
+		return nil, fmt.Errorf("preset line %d: missing value", s.line)
+	}
+	switch c := s.peek(); {
+	case c == '"' || c == '\'':
+		return s.str()
+	case c == '[':
+		return s.stringArray()
+	}
+	end := s.pos
+	for end < len(s.src) && !strings.ContainsRune(" \t\r\n#,]", rune(s.src[end])) {
+		end++
+	}
+	word := s.src[s.pos:end]
+	s.pos = end
+	switch word {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	v, err := strconv.ParseFloat(strings.ReplaceAll(word, "_", ""), 64)
+	if err != nil {
+		return nil, fmt.Errorf("preset line %d: unsupported value %q", s.line, word)
+	}
+	return v, nil
+}
+
+// stringArray reads a possibly multi-line array of strings.
+func (s *tomlScanner) stringArray() ([]string, error) {
+	s.pos++ // [
+	out := []string{}
+	for {
+		s.skipBlankLines()
+		if s.done() {
+			return nil, fmt.Errorf("preset line %d: unterminated array", s.line)
+		}
+		if s.peek() == ']' {
+			s.pos++
+			return out, nil
+		}
+		if s.peek() != '"' && s.peek() != '\'' {
+			return nil, fmt.Errorf("preset line %d: arrays may only hold strings", s.line)
+		}
+		v, err := s.str()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+		s.skipBlankLines()
+		switch {
+		case !s.done() && s.peek() == ',':
+			s.pos++
+		case !s.done() && s.peek() == ']':
+		default:
+			return nil, fmt.Errorf("preset line %d: expected ',' or ']' in array", s.line)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestLayoutPreset_RoundTrip(t *testing.T) {
+	cfg := loadConfigJSON(t, `{
+		"ui": {"warn_threshold": 0.4, "crit_threshold": 0.15},
+		"dashboard": {
+			"view": "stacked",
+			"hide_sections_with_no_data": true,
+			"widget_sections": [
+				{"id": "top_usage_progress", "enabled": true},
+				{"id": "model_burn", "enabled": false}
+			],
+			"detail_sections": [{"id": "usage", "enabled": true}],
+			"provider_widgets": {
+				"claude_code": {
+					"gauge_priority": ["usage_five_hour", "usage_seven_day"],
+					"compact_rows": [{"label": "Spend", "keys": ["today_api_cost", "7d_api_cost"], "max_segments": 2}]
+				}
+			}
+		}
+	}`)
+
+	preset := ExportLayoutPreset(cfg, "platform team")
+	parsed, err := ParseLayoutPreset(preset.TOML())
+	if err != nil {
+		t.Fatalf("ParseLayoutPreset() error: %v\n%s", err, preset.TOML())
+	}
+	if parsed.Name != "platform team" || parsed.View != DashboardViewStacked || !parsed.HideSectionsWithNoData {
+		t.Errorf("parsed header = %+v", parsed)
+	}
+	if parsed.WarnThreshold != 0.4 || parsed.CritThreshold != 0.15 {
+		t.Errorf("thresholds = %v/%v", parsed.WarnThreshold, parsed.CritThreshold)
+	}
+	if !reflect.DeepEqual(parsed.ProviderWidgets, cfg.Dashboard.ProviderWidgets) {
+		t.Errorf("provider widgets = %+v, want %+v", parsed.ProviderWidgets, cfg.Dashboard.ProviderWidgets)
+	}
+	if got := parsed.WidgetSections[0]; got.ID != core.DashboardSectionTopUsageProgress || !got.Enabled {
+		t.Errorf("first tile section = %+v", got)
+	}
+	for _, s := range parsed.WidgetSections[1:] {
+		if s.Enabled {
+			t.Errorf("tile section %q shown, want only top_usage_progress", s.ID)
+		}
+	}
+	if len(parsed.WidgetSections) != len(core.DashboardStandardSections())-1 {
+		t.Errorf("tile sections = %d, want every section but the header", len(parsed.WidgetSections))
+	}
+}
+
+func TestParseLayoutPreset_HandWritten(t *testing.T) {
+	preset, err := ParseLayoutPreset([]byte(`
+# team layout
+view = "list" # legacy name for split
+
+[tile_sections]
+show = [
+  "top_usage_progress",  # gauges first
+  'daily_usage',
+]
+
+[providers.openrouter]
+gauge_priority = ["credit_balance"]
+
+[[providers.openrouter.compact_rows]]
+label = "Spend"
+keys = ["today_cost"]
+
+[[providers.openrouter.compact_rows]]
+label = "Usage"
+keys = ["requests_today"]
+max_segments = 3
+`))
+	if err != nil {
+		t.Fatalf("ParseLayoutPreset() error: %v", err)
+	}
+	if preset.View != DashboardViewSplit {
+		t.Errorf("view = %q, want split", preset.View)
+	}
+	if preset.DetailSections != nil {
+		t.Errorf("detail sections = %+v, want defaults", preset.DetailSections)
+	}
+	want := ProviderWidgetConfig{
+		GaugePriority: []string{"credit_balance"},
+		CompactRows: []CompactRowConfig{
+			{Label: "Spend", Keys: []string{"today_cost"}},
+			{Label: "Usage", Keys: []string{"requests_today"}, MaxSegments: 3},
+		},
+	}
+	if got := preset.ProviderWidgets["openrouter"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("openrouter widget = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseLayoutPreset_Errors(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":     `veiw = "grid"`,
+		"unknown table":   "[colors]\naccent = \"red\"",
+		"bad threshold":   `warn_threshold = 7`,
+		"unknown section": "[tile_sections]\nshow = [\"gauges\"]",
+		"repeated key":    "view = \"grid\"\nview = \"tabs\"",
+		"unterminated":    `view = "grid`,
+		"mixed array":     "[providers.x]\ngauge_priority = [\"a\", 1]",
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseLayoutPreset([]byte(input)); err == nil {
+				t.Errorf("ParseLayoutPreset(%q) succeeded", input)
+			}
+		})
+	}
+}
+
+func TestImportLayoutPresetTo_KeepsAccounts(t *testing.T) {
+	path := writeSettingsJSON(t, `{
+		"accounts": [{"id": "openai-personal", "provider": "openai", "api_key_env": "OPENAI_API_KEY"}],
+		"dashboard": {
+			"providers": [{"account_id": "openai-personal", "enabled": false}],
+			"widget_sections": [{"id": "model_burn", "enabled": true}],
+			"provider_widgets": {"openai": {"gauge_priority": ["rpm"]}}
+		}
+	}`)
+	preset, err := ParseLayoutPreset([]byte("view = \"tabs\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ImportLayoutPresetTo(path, preset); err != nil {
+		t.Fatalf("ImportLayoutPresetTo() error: %v", err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Dashboard.View != DashboardViewTabs {
+		t.Errorf("view = %q, want tabs", cfg.Dashboard.View)
+	}
+	if cfg.Dashboard.WidgetSections != nil || cfg.Dashboard.ProviderWidgets != nil {
+		t.Errorf("layout not reset to defaults: sections=%+v widgets=%+v", cfg.Dashboard.WidgetSections, cfg.Dashboard.ProviderWidgets)
+	}
+	if len(cfg.Accounts) != 1 || len(cfg.Dashboard.Providers) != 1 || cfg.Dashboard.Providers[0].Enabled {
+		t.Errorf("accounts or provider prefs changed: %+v / %+v", cfg.Accounts, cfg.Dashboard.Providers)
+	}
+}
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OpenUsage's own directories follow the XDG Base Directory Specification on
+// every platform: an absolute XDG_CONFIG_HOME, XDG_CACHE_HOME or
+// XDG_DATA_HOME wins, otherwise the per-OS default from config_dir_*.go is
+// used. Relative values are ignored, as the specification requires.
+const (
+	EnvXDGConfigHome = "XDG_CONFIG_HOME"
+	EnvXDGCacheHome  = "XDG_CACHE_HOME"
+	EnvXDGDataHome   = "XDG_DATA_HOME"
+)
+
+// ConfigDir returns the directory that holds settings.json and other OpenUsage
+// config: $XDG_CONFIG_HOME/openusage, else PlatformConfigDir().
+func ConfigDir() string {
+	if dir, ok := xdgDir(EnvXDGConfigHome); ok {
+		return dir
+	}
+	return osConfigDir()
+}
+
+// PlatformConfigDir returns the per-OS config directory, ignoring
+// XDG_CONFIG_HOME. Releases before XDG support kept all config there, and
+// MigrateLegacyConfig moves it from there into ConfigDir().
+func PlatformConfigDir() string {
+	return osConfigDir()
+}
+
+// CacheDir returns the directory for disposable caches such as the dashboard
+// snapshot cache: $XDG_CACHE_HOME/openusage, else ~/.cache/openusage
+// (%LOCALAPPDATA%\openusage\cache on Windows).
+func CacheDir() string {
+	if dir, ok := xdgDir(EnvXDGCacheHome); ok {
+		return dir
+	}
+	return osCacheDir()
+}
+
+// DataDir returns the directory for user data worth keeping, such as usage
+// history: $XDG_DATA_HOME/openusage, else ~/.local/share/openusage
+// (%LOCALAPPDATA%\openusage\data on Windows).
+func DataDir() string {
+	if dir, ok := xdgDir(EnvXDGDataHome); ok {
+		return dir
+	}
+	return osDataDir()
+}
+
+func xdgDir(env string) (string, bool) {
+	base := strings.TrimSpace(os.Getenv(env))
+	if base == "" || !filepath.IsAbs(base) {
+		return "", false
+	}
+	return filepath.Join(base, "openusage"), true
+}
+
+// legacyConfigEntries are the files and directories MigrateLegacyConfig
+// copies. Hook scripts and custom-pricing.json are not listed: they already
+// honoured XDG_CONFIG_HOME before the config directory did.
+var legacyConfigEntries = []string{
+	"settings.json",
+	"credentials.json",
+	"profiles",
+	"themes",
+	"providers",
+}
+
+// MigrateLegacyConfig copies config written before XDG_CONFIG_HOME was
+// honoured from PlatformConfigDir() into ConfigDir(). The originals stay
+// where they are, so a daemon installed before the change, whose service
+// environment has no XDG_CONFIG_HOME, keeps reading them. Each entry is
+// copied only when the new directory has none of its own, so running it
+// again, or after the user copied files by hand, changes nothing. Nothing
+// is copied under --no-write or when --config names the settings file, and
+// credentials.json is left alone when OPENUSAGE_CREDENTIALS is set. It
+// returns the destination paths it wrote.
+func MigrateLegacyConfig() ([]string, error) {
+	from, to := PlatformConfigDir(), ConfigDir()
+	if NoWriteFromEnv() || strings.TrimSpace(os.Getenv(EnvConfig)) != "" || filepath.Clean(from) == filepath.Clean(to) {
+		return nil, nil
+	}
+	var copied []string
+	var errs []error
+	for _, name := range legacyConfigEntries {
+		if name == "credentials.json" && strings.TrimSpace(os.Getenv(EnvCredentials)) != "" {
+			continue
+		}
+		src, dst := filepath.Join(from, name), filepath.Join(to, name)
+		if _, err := os.Lstat(src); err != nil {
+			continue
+		}
+		if _, err := os.Lstat(dst); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(to, 0o755); err != nil {
+			return copied, fmt.Errorf("migrate config: %w", err)
+		}
+		// Copy beside the destination and rename into place, so an
+		// interrupted copy is never mistaken for a finished one.
+		tmp := dst + ".migrating"
+		_ = os.RemoveAll(tmp)
+		if err := copyTree(src, tmp); err != nil {
+			_ = os.RemoveAll(tmp)
+			errs = append(errs, fmt.Errorf("migrate %s: %w", src, err))
+			continue
+		}
+		if err := os.Rename(tmp, dst); err != nil {
+			_ = os.RemoveAll(tmp)
+			errs = append(errs, fmt.Errorf("migrate %s: %w", src, err))
+			continue
+		}
+		copied = append(copied, dst)
+	}
+	return copied, errors.Join(errs...)
+}
+
+// copyTree copies a file, symlink or directory tree, keeping permissions.
+func copyTree(src, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(target, dst)
+	case info.IsDir():
+		if err := os.Mkdir(dst, info.Mode().Perm()); err != nil {
+			return err
+		}
+		entries, err := os.ReadDir(src)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := copyTree(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name())); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		in, err := os.Open(src)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	}
+}
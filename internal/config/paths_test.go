@@ -0,0 +1,123 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestXDGDirs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", filepath.Join(home, "AppData"))
+	t.Setenv("LOCALAPPDATA", filepath.Join(home, "LocalAppData"))
+	t.Setenv(EnvXDGConfigHome, "")
+	t.Setenv(EnvXDGCacheHome, "")
+	t.Setenv(EnvXDGDataHome, "")
+
+	if got, want := ConfigDir(), PlatformConfigDir(); got != want {
+		t.Errorf("ConfigDir() without XDG = %q, want platform dir %q", got, want)
+	}
+	defaultCache, defaultData := CacheDir(), DataDir()
+
+	xdg := t.TempDir()
+	t.Setenv(EnvXDGConfigHome, filepath.Join(xdg, "config"))
+	t.Setenv(EnvXDGCacheHome, filepath.Join(xdg, "cache"))
+	t.Setenv(EnvXDGDataHome, filepath.Join(xdg, "data"))
+	for name, got := range map[string][2]string{
+		"ConfigDir": {ConfigDir(), filepath.Join(xdg, "config", "openusage")},
+		"CacheDir":  {CacheDir(), filepath.Join(xdg, "cache", "openusage")},
+		"DataDir":   {DataDir(), filepath.Join(xdg, "data", "openusage")},
+	} {
+		if got[0] != got[1] {
+			t.Errorf("%s() = %q, want %q", name, got[0], got[1])
+		}
+	}
+	if got := ConfigPath(); got != filepath.Join(xdg, "config", "openusage", "settings.json") {
+		t.Errorf("ConfigPath() = %q, want it under XDG_CONFIG_HOME", got)
+	}
+
+	t.Setenv(EnvXDGCacheHome, "relative/cache")
+	t.Setenv(EnvXDGDataHome, "relative/data")
+	if got := CacheDir(); got != defaultCache {
+		t.Errorf("CacheDir() with a relative XDG_CACHE_HOME = %q, want default %q", got, defaultCache)
+	}
+	if got := DataDir(); got != defaultData {
+		t.Errorf("DataDir() with a relative XDG_DATA_HOME = %q, want default %q", got, defaultData)
+	}
+}
+
+func TestMigrateLegacyConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", filepath.Join(home, "AppData"))
+	t.Setenv(EnvNoWrite, "")
+	t.Setenv(EnvConfig, "")
+	t.Setenv(EnvCredentials, "")
+	t.Setenv(EnvXDGConfigHome, "")
+
+	legacy := PlatformConfigDir()
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(legacy, "settings.json"), `{"legacy":true}`)
+	write(filepath.Join(legacy, "credentials.json"), `{"keys":{}}`)
+	write(filepath.Join(legacy, "profiles", "work", "settings.json"), `{}`)
+
+	if moved, err := MigrateLegacyConfig(); err != nil || len(moved) != 0 {
+		t.Fatalf("without XDG_CONFIG_HOME: moved %v, err %v; want nothing", moved, err)
+	}
+
+	t.Setenv(EnvXDGConfigHome, filepath.Join(home, "xdg"))
+	current := ConfigDir()
+	write(filepath.Join(current, "credentials.json"), `{"keys":{"openai":"sk-new"}}`)
+
+	t.Setenv(EnvNoWrite, "1")
+	if moved, _ := MigrateLegacyConfig(); len(moved) != 0 {
+		t.Fatalf("--no-write moved %v", moved)
+	}
+	t.Setenv(EnvNoWrite, "")
+
+	t.Setenv(EnvConfig, filepath.Join(home, "explicit.json"))
+	if moved, _ := MigrateLegacyConfig(); len(moved) != 0 {
+		t.Fatalf("--config moved %v", moved)
+	}
+	t.Setenv(EnvConfig, "")
+
+	moved, err := MigrateLegacyConfig()
+	if err != nil {
+		t.Fatalf("MigrateLegacyConfig() error: %v", err)
+	}
+	want := []string{filepath.Join(current, "settings.json"), filepath.Join(current, "profiles")}
+	if len(moved) != len(want) || moved[0] != want[0] || moved[1] != want[1] {
+		t.Errorf("moved = %v, want %v", moved, want)
+	}
+	if data, _ := os.ReadFile(filepath.Join(current, "settings.json")); string(data) != `{"legacy":true}` {
+		t.Errorf("migrated settings.json = %q", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(current, "credentials.json")); string(data) != `{"keys":{"openai":"sk-new"}}` {
+		t.Errorf("existing credentials.json was overwritten: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(legacy, "credentials.json")); err != nil {
+		t.Errorf("legacy credentials.json should be left in place when the new dir has one: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(current, "profiles", "work", "settings.json")); err != nil {
+		t.Errorf("profiles were not migrated: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(legacy, "settings.json")); string(data) != `{"legacy":true}` {
+		t.Errorf("legacy settings.json should stay for daemons still reading it: %q", data)
+	}
+	if info, err := os.Stat(filepath.Join(current, "settings.json")); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("migrated settings.json should keep mode 600: %v", err)
+	}
+
+	if again, err := MigrateLegacyConfig(); err != nil || len(again) != 0 {
+		t.Errorf("second run: moved %v, err %v; want nothing", again, err)
+	}
+}
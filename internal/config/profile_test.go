@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestConfigPath_ProfileAndOverride(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvConfig, "")
+	t.Setenv(EnvCredentials, "")
+	base := ConfigDir()
+
+	if got, want := ConfigPath(), filepath.Join(base, "settings.json"); got != want {
+		t.Errorf("default ConfigPath() = %q, want %q", got, want)
+	}
+
+	t.Setenv(EnvProfile, "client-a")
+	profileDir := filepath.Join(base, "profiles", "client-a")
+	if got := ProfileDir(); got != profileDir {
+		t.Errorf("ProfileDir() = %q, want %q", got, profileDir)
+	}
+	if got, want := ConfigPath(), filepath.Join(profileDir, "settings.json"); got != want {
+		t.Errorf("profile ConfigPath() = %q, want %q", got, want)
+	}
+	if got, want := CredentialsPath(), filepath.Join(profileDir, "credentials.json"); got != want {
+		t.Errorf("profile CredentialsPath() = %q, want %q", got, want)
+	}
+
+	explicit := filepath.Join(t.TempDir(), "other.json")
+	t.Setenv(EnvConfig, explicit)
+	if got := ConfigPath(); got != explicit {
+		t.Errorf("ConfigPath() with %s = %q, want %q", EnvConfig, got, explicit)
+	}
+	if got, want := CredentialsPath(), filepath.Join(profileDir, "credentials.json"); got != want {
+		t.Errorf("--config must not move credentials: got %q, want %q", got, want)
+	}
+
+	secret := filepath.Join(t.TempDir(), "openusage-credentials.json")
+	t.Setenv(EnvCredentials, secret)
+	if got := CredentialsPath(); got != secret {
+		t.Errorf("CredentialsPath() with %s = %q, want %q", EnvCredentials, got, secret)
+	}
+}
+
+func TestProfileSaveAndList(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(EnvConfig, "")
+	t.Setenv(EnvProfile, "work")
+
+	if err := SaveTheme("Nord"); err != nil {
+		t.Fatalf("SaveTheme: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(ProfilesDir(), "bad name"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(ProfilesDir(), "home"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles: %v", err)
+	}
+	if want := []string{"home", "work"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ListProfiles() = %v, want %v", names, want)
+	}
+
+	cfg, err := Load()
+	if err != nil || cfg.Theme != "Nord" {
+		t.Errorf("profile Load() theme = %q, err %v; want Nord", cfg.Theme, err)
+	}
+	t.Setenv(EnvProfile, "")
+	if cfg, _ := Load(); cfg.Theme == "Nord" {
+		t.Error("default profile picked up the work profile's settings")
+	}
+}
+
+func TestValidateProfileName(t *testing.T) {
+	for _, name := range []string{"work", "client_a", "acme.prod", "v2-eu"} {
+		if err := ValidateProfileName(name); err != nil {
+			t.Errorf("ValidateProfileName(%q) = %v, want nil", name, err)
+		}
+	}
+	for _, name := range []string{"", ".", "..", "../x", "a/b", "with space"} {
+		if err := ValidateProfileName(name); err == nil {
+			t.Errorf("ValidateProfileName(%q) = nil, want error", name)
+		}
+	}
+}
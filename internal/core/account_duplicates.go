@@ -0,0 +1,46 @@
+package core
+
+import "strings"
+
+// AccountDuplicate pairs an auto-detected account with the configured account
+// it duplicates.
+type AccountDuplicate struct {
+	Duplicate AccountConfig
+	Of        AccountConfig
+}
+
+// FindDuplicateAccounts reports auto-detected accounts that read the same
+// credentials as a configured account under a different ID: same provider,
+// same API key env var, and same base URL. Such pairs render as two tiles
+// with identical data.
+func FindDuplicateAccounts(manual, autoDetected []AccountConfig) []AccountDuplicate {
+	key := func(acct AccountConfig) string {
+		env := strings.TrimSpace(acct.APIKeyEnv)
+		if env == "" {
+			return ""
+		}
+		return strings.ToLower(strings.TrimSpace(acct.Provider)) + "|" + env + "|" +
+			strings.TrimRight(strings.TrimSpace(acct.BaseURL), "/")
+	}
+	byKey := make(map[string]AccountConfig, len(manual))
+	manualIDs := make(map[string]bool, len(manual))
+	for _, acct := range manual {
+		manualIDs[acct.ID] = true
+		if k := key(acct); k != "" {
+			if _, seen := byKey[k]; !seen {
+				byKey[k] = acct
+			}
+		}
+	}
+	var out []AccountDuplicate
+	for _, acct := range autoDetected {
+		k := key(acct)
+		if k == "" || manualIDs[acct.ID] {
+			continue
+		}
+		if of, ok := byKey[k]; ok {
+			out = append(out, AccountDuplicate{Duplicate: acct, Of: of})
+		}
+	}
+	return out
+}
@@ -0,0 +1,22 @@
+package core
+
+import "testing"
+
+func TestFindDuplicateAccounts(t *testing.T) {
+	manual := []AccountConfig{
+		{ID: "openai-work", Provider: "openai", APIKeyEnv: "OPENAI_API_KEY"},
+		{ID: "openrouter", Provider: "openrouter", APIKeyEnv: "OPENROUTER_API_KEY", BaseURL: "https://proxy.example/api/v1"},
+		{ID: "claude-code", Provider: "claude_code"},
+	}
+	auto := []AccountConfig{
+		{ID: "openai", Provider: "openai", APIKeyEnv: "OPENAI_API_KEY"},                  // duplicate
+		{ID: "openrouter-auto", Provider: "openrouter", APIKeyEnv: "OPENROUTER_API_KEY"}, // different endpoint
+		{ID: "openai-work", Provider: "openai", APIKeyEnv: "OPENAI_API_KEY"},             // same ID, merged by ID already
+		{ID: "claude-code-2", Provider: "claude_code"},                                   // no env var to compare
+		{ID: "groq", Provider: "groq", APIKeyEnv: "GROQ_API_KEY"},
+	}
+	got := FindDuplicateAccounts(manual, auto)
+	if len(got) != 1 || got[0].Duplicate.ID != "openai" || got[0].Of.ID != "openai-work" {
+		t.Errorf("FindDuplicateAccounts() = %+v, want openai duplicating openai-work", got)
+	}
+}
@@ -0,0 +1,17 @@
+package core
+
+// CacheSavingsMetricKey is the USD prompt caching saved: what the same
+// traffic would have cost with every cache read and write billed as fresh
+// input, minus what it did cost. Cache writes cost more than fresh input on
+// some providers, so a cache that is written but rarely read comes out
+// negative.
+const CacheSavingsMetricKey = "cache_savings_usd"
+
+// CacheSavingsMetric builds the cache_savings_usd metric for the window, or
+// returns (zero, false) when caching made no difference.
+func CacheSavingsMetric(savedUSD float64, window string) (Metric, bool) {
+	if savedUSD == 0 {
+		return Metric{}, false
+	}
+	return Metric{Used: &savedUSD, Unit: "USD", Window: window}, true
+}
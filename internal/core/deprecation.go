@@ -0,0 +1,59 @@
+package core
+
+import (
+	"sort"
+	"strings"
+)
+
+// Deprecation is a notice that a provider hit an upstream endpoint or field
+// the vendor has deprecated or changed, and fell back to reduced data. The
+// dashboard shows it on the tile, with the migration hint in the detail view,
+// instead of leaving it in Raw where nobody looks.
+type Deprecation struct {
+	// Feature is a short snake_case ID for what changed, e.g.
+	// "generation_list". One notice is kept per feature.
+	Feature string `json:"feature"`
+	// Message says what stopped working and what data is missing.
+	Message string `json:"message"`
+	// Migration says what the user can do about it, if anything.
+	Migration string `json:"migration,omitempty"`
+}
+
+// Deprecation notices live in Diagnostics so they survive the telemetry
+// store: the message under deprecation_<feature>, the hint under
+// deprecation_<feature>_migration.
+const (
+	deprecationPrefix    = "deprecation_"
+	deprecationMigration = "_migration"
+)
+
+// AddDeprecation records a deprecation notice on the snapshot.
+func (s *UsageSnapshot) AddDeprecation(d Deprecation) {
+	feature := strings.TrimSpace(d.Feature)
+	if feature == "" || strings.TrimSpace(d.Message) == "" {
+		return
+	}
+	s.SetDiagnostic(deprecationPrefix+feature, d.Message)
+	if d.Migration != "" {
+		s.SetDiagnostic(deprecationPrefix+feature+deprecationMigration, d.Migration)
+	}
+}
+
+// Deprecations returns the snapshot's deprecation notices, ordered by
+// feature.
+func (s UsageSnapshot) Deprecations() []Deprecation {
+	var out []Deprecation
+	for key, message := range s.Diagnostics {
+		feature, ok := strings.CutPrefix(key, deprecationPrefix)
+		if !ok || feature == "" || strings.HasSuffix(feature, deprecationMigration) {
+			continue
+		}
+		out = append(out, Deprecation{
+			Feature:   feature,
+			Message:   message,
+			Migration: s.Diagnostics[key+deprecationMigration],
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Feature < out[j].Feature })
+	return out
+}
@@ -0,0 +1,24 @@
+package core
+
+import "testing"
+
+func TestDeprecations_RoundTrip(t *testing.T) {
+	var snap UsageSnapshot
+	snap.AddDeprecation(Deprecation{Feature: "usage_v1", Message: "v1 usage endpoint retired"})
+	snap.AddDeprecation(Deprecation{Feature: "generation_list", Message: "list retired", Migration: "use activity"})
+	snap.AddDeprecation(Deprecation{Feature: "ignored"})
+
+	got := snap.Deprecations()
+	want := []Deprecation{
+		{Feature: "generation_list", Message: "list retired", Migration: "use activity"},
+		{Feature: "usage_v1", Message: "v1 usage endpoint retired"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Deprecations() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Deprecations()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
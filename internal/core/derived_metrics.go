@@ -0,0 +1,334 @@
+package core
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DerivedMetricConfig is a user-defined metric computed from a snapshot's
+// other metrics after every fetch, e.g.
+//
+//	{"name": "cost_per_message", "expr": "today_api_cost / messages_today", "unit": "USD"}
+//
+// The result is stored under DerivedMetricPrefix+Name (derived_cost_per_message)
+// so it can never shadow a metric the provider emitted.
+//
+// Expressions support numbers, + - * /, parentheses, and metric keys.
+// A key reads the metric's Used value; append .limit, .remaining, or .used
+// to pick a field explicitly. A key containing * (e.g.
+// model_claude_opus_*_cost_usd) sums every matching metric.
+type DerivedMetricConfig struct {
+	Name      string   `json:"name"`
+	Expr      string   `json:"expr"`
+	Unit      string   `json:"unit,omitempty"`
+	Window    string   `json:"window,omitempty"`
+	Providers []string `json:"providers,omitempty"` // empty = every provider
+}
+
+// DerivedMetric is a compiled DerivedMetricConfig.
+type DerivedMetric struct {
+	Config DerivedMetricConfig
+	root   exprNode
+}
+
+// DerivedMetricPrefix namespaces derived metric keys away from
+// provider-emitted ones.
+const DerivedMetricPrefix = "derived_"
+
+var derivedMetricNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_]*$`)
+
+// CompileDerivedMetric validates the name and parses the expression. A name
+// written with the derived_ prefix is accepted and stored without it.
+func CompileDerivedMetric(cfg DerivedMetricConfig) (DerivedMetric, error) {
+	cfg.Name = strings.TrimPrefix(strings.TrimSpace(cfg.Name), DerivedMetricPrefix)
+	cfg.Expr = strings.TrimSpace(cfg.Expr)
+	if !derivedMetricNameRe.MatchString(cfg.Name) {
+		return DerivedMetric{}, fmt.Errorf("derived metric name %q must match [a-z0-9_]+", cfg.Name)
+	}
+	p := exprParser{src: cfg.Expr}
+	root, err := p.parse()
+	if err != nil {
+		return DerivedMetric{}, fmt.Errorf("derived metric %s: %w", cfg.Name, err)
+	}
+	return DerivedMetric{Config: cfg, root: root}, nil
+}
+
+// CompileDerivedMetrics compiles every valid definition, skipping (and
+// tracing) invalid ones so one typo does not disable the rest.
+func CompileDerivedMetrics(cfgs []DerivedMetricConfig) []DerivedMetric {
+	out := make([]DerivedMetric, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		dm, err := CompileDerivedMetric(cfg)
+		if err != nil {
+			Tracef("[derived_metrics] %v", err)
+			continue
+		}
+		out = append(out, dm)
+	}
+	return out
+}
+
+// Key is the metric key the result is stored under.
+func (d DerivedMetric) Key() string { return DerivedMetricPrefix + d.Config.Name }
+
+// ApplyDerivedMetrics evaluates defs in order against the snapshot and stores
+// each result as a regular metric under its Key, so later definitions can
+// build on earlier ones. A definition whose inputs are missing, or that
+// divides by zero, is left out rather than reported as 0.
+func ApplyDerivedMetrics(s UsageSnapshot, defs []DerivedMetric) UsageSnapshot {
+	if len(defs) == 0 || len(s.Metrics) == 0 {
+		return s
+	}
+	s.Metrics = deepCloneMetrics(s.Metrics)
+	for _, def := range defs {
+		if !def.appliesTo(s.ProviderID) {
+			continue
+		}
+		v, ok := def.root.eval(s.Metrics)
+		if !ok {
+			continue
+		}
+		s.Metrics[def.Key()] = Metric{Used: Float64Ptr(v), Unit: def.Config.Unit, Window: def.Config.Window}
+	}
+	return s
+}
+
+func (d DerivedMetric) appliesTo(providerID string) bool {
+	if len(d.Config.Providers) == 0 {
+		return true
+	}
+	for _, p := range d.Config.Providers {
+		if strings.EqualFold(strings.TrimSpace(p), providerID) {
+			return true
+		}
+	}
+	return false
+}
+
+type exprNode interface {
+	eval(metrics map[string]Metric) (float64, bool)
+}
+
+type numberNode float64
+
+func (n numberNode) eval(map[string]Metric) (float64, bool) { return float64(n), true }
+
+type negNode struct{ x exprNode }
+
+func (n negNode) eval(m map[string]Metric) (float64, bool) {
+	v, ok := n.x.eval(m)
+	return -v, ok
+}
+
+type binaryNode struct {
+	op   byte
+	l, r exprNode
+}
+
+func (n binaryNode) eval(m map[string]Metric) (float64, bool) {
+	l, ok := n.l.eval(m)
+	if !ok {
+		return 0, false
+	}
+	r, ok := n.r.eval(m)
+	if !ok {
+		return 0, false
+	}
+	switch n.op {
+	case '+':
+		return l + r, true
+	case '-':
+		return l - r, true
+	case '*':
+		return l * r, true
+	default:
+		if r == 0 {
+			return 0, false
+		}
+		return l / r, true
+	}
+}
+
+type metricNode struct {
+	key   string
+	field string
+}
+
+func (n metricNode) eval(m map[string]Metric) (float64, bool) {
+	if !strings.Contains(n.key, "*") {
+		return metricField(m[n.key], n.field)
+	}
+	sum, found := 0.0, false
+	for key, met := range m {
+		if matched, _ := path.Match(n.key, key); !matched {
+			continue
+		}
+		if v, ok := metricField(met, n.field); ok {
+			sum += v
+			found = true
+		}
+	}
+	return sum, found
+}
+
+func metricField(m Metric, field string) (float64, bool) {
+	var p *float64
+	switch field {
+	case "limit":
+		p = m.Limit
+	case "remaining":
+		p = m.Remaining
+	default:
+		p = m.Used
+	}
+	if p == nil {
+		return 0, false
+	}
+	return *p, true
+}
+
+// exprParser is a recursive-descent parser for:
+//
+//	expr   = term { ("+" | "-") term }
+//	term   = unary { ("*" | "/") unary }
+//	unary  = "-" unary | factor
+//	factor = number | metric | "(" expr ")"
+type exprParser struct {
+	src string
+	pos int
+}
+
+func (p *exprParser) parse() (exprNode, error) {
+	if p.src == "" {
+		return nil, fmt.Errorf("empty expression")
+	}
+	n, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.src[p.pos], p.pos)
+	}
+	return n, nil
+}
+
+func (p *exprParser) expr() (exprNode, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("+-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, l: left, r: right}
+	}
+}
+
+func (p *exprParser) term() (exprNode, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("*/")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, l: left, r: right}
+	}
+}
+
+func (p *exprParser) unary() (exprNode, error) {
+	if _, ok := p.accept("-"); ok {
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return negNode{x: x}, nil
+	}
+	return p.factor()
+}
+
+func (p *exprParser) factor() (exprNode, error) {
+	if _, ok := p.accept("("); ok {
+		n, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.accept(")"); !ok {
+			return nil, fmt.Errorf("missing ) at offset %d", p.pos)
+		}
+		return n, nil
+	}
+
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.src) && isExprIdentByte(p.src[p.pos]) {
+		p.pos++
+	}
+	word := p.src[start:p.pos]
+	if word == "" {
+		if p.pos >= len(p.src) {
+			return nil, fmt.Errorf("unexpected end of expression")
+		}
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.src[p.pos], p.pos)
+	}
+	if c := word[0]; c == '.' || (c >= '0' && c <= '9') {
+		// Metric keys may start with a digit (7d_api_cost), so only a word
+		// that parses completely is a number.
+		if v, err := strconv.ParseFloat(word, 64); err == nil {
+			return numberNode(v), nil
+		}
+	}
+
+	key, field, hasField := strings.Cut(word, ".")
+	if key == "" || strings.Contains(field, ".") {
+		return nil, fmt.Errorf("invalid metric reference %q", word)
+	}
+	if hasField {
+		switch field {
+		case "used", "limit", "remaining":
+		default:
+			return nil, fmt.Errorf("unknown metric field %q in %q (want used, limit, or remaining)", field, word)
+		}
+	}
+	if _, err := path.Match(key, ""); err != nil {
+		return nil, fmt.Errorf("invalid metric pattern %q: %w", key, err)
+	}
+	return metricNode{key: key, field: field}, nil
+}
+
+func (p *exprParser) accept(ops string) (byte, bool) {
+	p.skipSpace()
+	if p.pos < len(p.src) && strings.IndexByte(ops, p.src[p.pos]) >= 0 {
+		op := p.src[p.pos]
+		p.pos++
+		return op, true
+	}
+	return 0, false
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func isExprIdentByte(c byte) bool {
+	return c == '_' || c == '*' || c == '.' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+func TestApplyDerivedMetrics(t *testing.T) {
+	snap := UsageSnapshot{
+		ProviderID: "claude_code",
+		Metrics: map[string]Metric{
+			"today_api_cost":                 {Used: Float64Ptr(12), Unit: "USD"},
+			"messages_today":                 {Used: Float64Ptr(48)},
+			"7d_api_cost":                    {Used: Float64Ptr(100), Unit: "USD"},
+			"model_claude_opus_4_cost_usd":   {Used: Float64Ptr(30)},
+			"model_claude_opus_4_5_cost_usd": {Used: Float64Ptr(20)},
+			"model_claude_sonnet_4_cost_usd": {Used: Float64Ptr(50)},
+			"spend_limit":                    {Used: Float64Ptr(25), Limit: Float64Ptr(200)},
+			"zero":                           {Used: Float64Ptr(0)},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		expr   string
+		want   float64
+		absent bool
+	}{
+		{name: "cost_per_message", expr: "today_api_cost / messages_today", want: 0.25},
+		{name: "opus_share", expr: "model_claude_opus_*_cost_usd / 7d_api_cost", want: 0.5},
+		{name: "headroom", expr: "spend_limit.limit - spend_limit", want: 175},
+		{name: "precedence", expr: "2 + 3 * -(1 - 5) / 2", want: 8},
+		{name: "chained", expr: "derived_cost_per_message * 100", want: 25},
+		{name: "missing_input", expr: "nope / messages_today", absent: true},
+		{name: "divide_by_zero", expr: "today_api_cost / zero", absent: true},
+		{name: "no_glob_match", expr: "model_gpt_*_cost_usd", absent: true},
+	}
+
+	cfgs := make([]DerivedMetricConfig, 0, len(tests))
+	for _, tt := range tests {
+		cfgs = append(cfgs, DerivedMetricConfig{Name: tt.name, Expr: tt.expr, Unit: "USD"})
+	}
+	defs := CompileDerivedMetrics(cfgs)
+	if len(defs) != len(tests) {
+		t.Fatalf("compiled %d definitions, want %d", len(defs), len(tests))
+	}
+
+	got := ApplyDerivedMetrics(snap, defs)
+	for _, tt := range tests {
+		m, ok := got.Metrics[DerivedMetricPrefix+tt.name]
+		if tt.absent {
+			if ok {
+				t.Errorf("%s: expected no metric, got %+v", tt.name, m)
+			}
+			continue
+		}
+		if !ok || m.Used == nil {
+			t.Errorf("%s: metric missing", tt.name)
+			continue
+		}
+		if math.Abs(*m.Used-tt.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", tt.name, *m.Used, tt.want)
+		}
+		if m.Unit != "USD" {
+			t.Errorf("%s unit = %q, want USD", tt.name, m.Unit)
+		}
+	}
+	if _, ok := snap.Metrics["derived_cost_per_message"]; ok {
+		t.Fatal("ApplyDerivedMetrics mutated the input snapshot")
+	}
+}
+
+func TestApplyDerivedMetricsNeverShadowsProviderMetrics(t *testing.T) {
+	defs := CompileDerivedMetrics([]DerivedMetricConfig{
+		{Name: "spend", Expr: "x * 2"},
+		{Name: "derived_ratio", Expr: "x / missing"},
+	})
+	snap := UsageSnapshot{Metrics: map[string]Metric{
+		"x":     {Used: Float64Ptr(3)},
+		"spend": {Used: Float64Ptr(42), Unit: "USD"},
+		"ratio": {Used: Float64Ptr(7)},
+	}}
+	got := ApplyDerivedMetrics(snap, defs).Metrics
+	if m := got["spend"]; m.Used == nil || *m.Used != 42 {
+		t.Fatalf("provider spend = %+v, want it untouched", m)
+	}
+	if m := got["derived_spend"]; m.Used == nil || *m.Used != 6 {
+		t.Fatalf("derived_spend = %+v, want 6", m)
+	}
+	if m := got["ratio"]; m.Used == nil || *m.Used != 7 {
+		t.Fatalf("ratio = %+v, want it kept when the derived definition fails", m)
+	}
+	if _, ok := got["derived_ratio"]; ok {
+		t.Fatal("failed derived metric was stored")
+	}
+}
+
+func TestApplyDerivedMetricsProviderFilter(t *testing.T) {
+	defs := CompileDerivedMetrics([]DerivedMetricConfig{{Name: "double", Expr: "x * 2", Providers: []string{"openai"}}})
+	snap := UsageSnapshot{ProviderID: "anthropic", Metrics: map[string]Metric{"x": {Used: Float64Ptr(2)}}}
+	if _, ok := ApplyDerivedMetrics(snap, defs).Metrics["derived_double"]; ok {
+		t.Fatal("derived metric applied to a provider outside its filter")
+	}
+	snap.ProviderID = "openai"
+	if m := ApplyDerivedMetrics(snap, defs).Metrics["derived_double"]; m.Used == nil || *m.Used != 4 {
+		t.Fatalf("double = %+v, want 4", m)
+	}
+}
+
+func TestCompileDerivedMetricErrors(t *testing.T) {
+	bad := []DerivedMetricConfig{
+		{Name: "", Expr: "a"},
+		{Name: "Bad Name", Expr: "a"},
+		{Name: "ok", Expr: ""},
+		{Name: "ok", Expr: "a +"},
+		{Name: "ok", Expr: "(a"},
+		{Name: "ok", Expr: "a b"},
+		{Name: "ok", Expr: "a.total"},
+		{Name: "ok", Expr: "a $ b"},
+		{Name: "ok", Expr: "a[.used"},
+	}
+	for _, cfg := range bad {
+		if _, err := CompileDerivedMetric(cfg); err == nil {
+			t.Errorf("CompileDerivedMetric(%+v) succeeded, want error", cfg)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// EndpointMetricPrefix marks the per-endpoint HTTP health metrics the poll
+// engine attaches to every snapshot. They're hidden from tiles and the
+// generic metric lists; the detail view renders them in the Endpoints
+// section.
+const EndpointMetricPrefix = "endpoint_"
+
+// endpointLabelRawPrefix keys the human-readable "METHOD host/path" label of
+// each endpoint in snap.Raw; endpointErrorDiagPrefix keys the last error in
+// snap.Diagnostics.
+const (
+	endpointLabelRawPrefix  = "endpoint_label_"
+	endpointErrorDiagPrefix = "endpoint_error_"
+)
+
+// EndpointStat aggregates the HTTP calls a provider made to one endpoint
+// during a single fetch.
+type EndpointStat struct {
+	// Key is the metric-safe identifier, e.g. "get_api_openai_com_v1_usage".
+	Key string
+	// Label is the display form, e.g. "GET api.openai.com/v1/usage".
+	Label string
+
+	Requests     int
+	Errors       int
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+	// LastError describes the most recent failure (transport error or
+	// "HTTP <code>"); empty when every call succeeded.
+	LastError string
+}
+
+// AvgLatency returns the mean latency across the recorded calls.
+func (s EndpointStat) AvgLatency() time.Duration {
+	if s.Requests == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Requests)
+}
+
+// IsEndpointMetricKey reports whether key is one of the per-endpoint HTTP
+// health metrics.
+func IsEndpointMetricKey(key string) bool {
+	return strings.HasPrefix(strings.TrimSpace(key), EndpointMetricPrefix)
+}
+
+// IsEndpointRawKey reports whether a Raw entry holds an endpoint label.
+func IsEndpointRawKey(key string) bool {
+	return strings.HasPrefix(key, endpointLabelRawPrefix)
+}
+
+// ApplyEndpointStats writes stats onto the snapshot as
+// endpoint_<key>_requests, endpoint_<key>_errors (Used errors of Limit
+// requests), endpoint_<key>_latency_ms (mean) and
+// endpoint_<key>_latency_max_ms, plus the endpoint's label in Raw and its
+// last error in Diagnostics.
+func ApplyEndpointStats(snap *UsageSnapshot, stats []EndpointStat) {
+	if snap == nil || len(stats) == 0 {
+		return
+	}
+	snap.EnsureMaps()
+	for _, s := range stats {
+		if s.Key == "" || s.Requests == 0 {
+			continue
+		}
+		prefix := EndpointMetricPrefix + s.Key + "_"
+		requests := float64(s.Requests)
+		errs := float64(s.Errors)
+		avg := float64(s.AvgLatency().Milliseconds())
+		peak := float64(s.MaxLatency.Milliseconds())
+		snap.Metrics[prefix+"requests"] = Metric{Used: &requests, Unit: "requests", Window: "poll"}
+		snap.Metrics[prefix+"errors"] = Metric{Used: &errs, Limit: Float64Ptr(requests), Unit: "requests", Window: "poll"}
+		snap.Metrics[prefix+"latency_ms"] = Metric{Used: &avg, Unit: "ms", Window: "poll"}
+		snap.Metrics[prefix+"latency_max_ms"] = Metric{Used: &peak, Unit: "ms", Window: "poll"}
+		snap.Raw[endpointLabelRawPrefix+s.Key] = s.Label
+		if s.LastError != "" {
+			snap.SetDiagnostic(endpointErrorDiagPrefix+s.Key, s.Label+": "+s.LastError)
+		}
+	}
+}
+
+// ExtractEndpointStats reads back what ApplyEndpointStats wrote, slowest
+// endpoint first.
+func ExtractEndpointStats(snap UsageSnapshot) []EndpointStat {
+	var stats []EndpointStat
+	for rawKey, label := range snap.Raw {
+		key, ok := strings.CutPrefix(rawKey, endpointLabelRawPrefix)
+		if !ok || key == "" {
+			continue
+		}
+		prefix := EndpointMetricPrefix + key + "_"
+		s := EndpointStat{Key: key, Label: label}
+		if m, ok := snap.Metrics[prefix+"requests"]; ok && m.Used != nil {
+			s.Requests = int(*m.Used)
+		}
+		if m, ok := snap.Metrics[prefix+"errors"]; ok && m.Used != nil {
+			s.Errors = int(*m.Used)
+		}
+		if m, ok := snap.Metrics[prefix+"latency_ms"]; ok && m.Used != nil {
+			s.TotalLatency = time.Duration(*m.Used*float64(s.Requests)) * time.Millisecond
+		}
+		if m, ok := snap.Metrics[prefix+"latency_max_ms"]; ok && m.Used != nil {
+			s.MaxLatency = time.Duration(*m.Used) * time.Millisecond
+		}
+		if diag := snap.Diagnostics[endpointErrorDiagPrefix+key]; diag != "" {
+			s.LastError = strings.TrimPrefix(diag, label+": ")
+		}
+		if s.Requests > 0 {
+			stats = append(stats, s)
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].MaxLatency != stats[j].MaxLatency {
+			return stats[i].MaxLatency > stats[j].MaxLatency
+		}
+		return stats[i].Key < stats[j].Key
+	})
+	return stats
+}
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyEndpointStats_RoundTrip(t *testing.T) {
+	snap := NewUsageSnapshot("openai", "openai")
+	ApplyEndpointStats(&snap, []EndpointStat{
+		{Key: "get_api_example_com_v1_usage", Label: "GET api.example.com/v1/usage", Requests: 2, TotalLatency: 400 * time.Millisecond, MaxLatency: 300 * time.Millisecond},
+		{Key: "get_api_example_com_v1_billing", Label: "GET api.example.com/v1/billing", Requests: 1, Errors: 1, TotalLatency: 8 * time.Second, MaxLatency: 8 * time.Second, LastError: "timeout"},
+		{Key: "unused", Label: "GET unused", Requests: 0},
+	})
+
+	if m := snap.Metrics["endpoint_get_api_example_com_v1_usage_latency_ms"]; m.Used == nil || *m.Used != 200 {
+		t.Errorf("usage latency_ms = %+v, want 200", m)
+	}
+	if m := snap.Metrics["endpoint_get_api_example_com_v1_billing_errors"]; m.Used == nil || *m.Used != 1 || m.Limit == nil || *m.Limit != 1 {
+		t.Errorf("billing errors = %+v, want 1/1", m)
+	}
+	if _, ok := snap.Raw["endpoint_label_unused"]; ok {
+		t.Error("endpoint without requests should be skipped")
+	}
+
+	got := ExtractEndpointStats(snap)
+	if len(got) != 2 {
+		t.Fatalf("ExtractEndpointStats = %+v, want 2", got)
+	}
+	if got[0].Label != "GET api.example.com/v1/billing" || got[0].LastError != "timeout" || got[0].Errors != 1 {
+		t.Errorf("slowest endpoint = %+v", got[0])
+	}
+	if got[1].AvgLatency() != 200*time.Millisecond {
+		t.Errorf("usage avg = %s, want 200ms", got[1].AvgLatency())
+	}
+	if !IsEndpointMetricKey("endpoint_get_x_requests") || IsEndpointMetricKey("rpm") {
+		t.Error("IsEndpointMetricKey mismatch")
+	}
+	if IncludeDetailMetricKey("endpoint_get_x_requests") {
+		t.Error("endpoint metrics should be excluded from detail metric lists")
+	}
+}
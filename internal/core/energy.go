@@ -0,0 +1,152 @@
+package core
+
+import (
+	"maps"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// EnergyConfig opts into rough energy and CO2 estimates for token usage.
+// Published per-token figures differ by an order of magnitude between
+// studies, so everything derived from it is presented as an estimate.
+type EnergyConfig struct {
+	Enabled bool `json:"enabled"`
+	// GridIntensity is the grams of CO2e emitted per kWh drawn. Zero uses
+	// DefaultGridIntensity.
+	GridIntensity float64 `json:"grid_g_co2_per_kwh,omitempty"`
+	// Models maps model IDs or path.Match globs ("*opus*") to energy
+	// factors. They take precedence over the built-in size-class defaults;
+	// the longest matching pattern wins.
+	Models map[string]EnergyFactor `json:"models,omitempty"`
+}
+
+// EnergyFactor is the energy, datacenter overhead included, spent per 1,000
+// tokens a model reads and writes.
+type EnergyFactor struct {
+	InputWhPer1K  float64 `json:"input_wh_per_1k"`
+	OutputWhPer1K float64 `json:"output_wh_per_1k"`
+}
+
+// DefaultGridIntensity approximates the global average grid, in gCO2e/kWh.
+const DefaultGridIntensity = 400.0
+
+// defaultEnergyFactors are coarse size-class estimates, checked in order.
+// Small-model markers come first so "o3-mini" is not billed as "o3".
+var defaultEnergyFactors = []struct {
+	pattern string
+	factor  EnergyFactor
+}{
+	{"*mini*", EnergyFactor{InputWhPer1K: 0.015, OutputWhPer1K: 0.15}},
+	{"*nano*", EnergyFactor{InputWhPer1K: 0.015, OutputWhPer1K: 0.15}},
+	{"*haiku*", EnergyFactor{InputWhPer1K: 0.015, OutputWhPer1K: 0.15}},
+	{"*flash*", EnergyFactor{InputWhPer1K: 0.015, OutputWhPer1K: 0.15}},
+	{"*opus*", EnergyFactor{InputWhPer1K: 0.2, OutputWhPer1K: 2}},
+	{"gpt-4.5*", EnergyFactor{InputWhPer1K: 0.2, OutputWhPer1K: 2}},
+	{"o1*", EnergyFactor{InputWhPer1K: 0.2, OutputWhPer1K: 2}},
+	{"o3*", EnergyFactor{InputWhPer1K: 0.2, OutputWhPer1K: 2}},
+}
+
+// defaultEnergyFactor covers mid-size models and anything unrecognised.
+var defaultEnergyFactor = EnergyFactor{InputWhPer1K: 0.06, OutputWhPer1K: 0.6}
+
+// NormalizeEnergyConfig lower-cases model patterns and drops malformed
+// globs and negative factors.
+func NormalizeEnergyConfig(c EnergyConfig) EnergyConfig {
+	if c.GridIntensity < 0 {
+		c.GridIntensity = 0
+	}
+	if len(c.Models) == 0 {
+		c.Models = nil
+		return c
+	}
+	models := make(map[string]EnergyFactor, len(c.Models))
+	for pattern, factor := range c.Models {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" || factor.InputWhPer1K < 0 || factor.OutputWhPer1K < 0 {
+			Tracef("config: dropping energy factor %q", pattern)
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			Tracef("config: dropping energy factor %q: %v", pattern, err)
+			continue
+		}
+		models[pattern] = factor
+	}
+	c.Models = models
+	return c
+}
+
+// Grid returns the effective grid intensity in gCO2e/kWh.
+func (c EnergyConfig) Grid() float64 {
+	if c.GridIntensity > 0 {
+		return c.GridIntensity
+	}
+	return DefaultGridIntensity
+}
+
+// Factor returns the energy factor for model: a configured override, else
+// the built-in size class, else the mid-size default. Routed IDs such as
+// "anthropic/claude-opus-4" also match on their last path segment.
+func (c EnergyConfig) Factor(model string) EnergyFactor {
+	model = strings.ToLower(strings.TrimSpace(model))
+	names := []string{model}
+	if i := strings.LastIndex(model, "/"); i >= 0 && i < len(model)-1 {
+		names = append(names, model[i+1:])
+	}
+	matches := func(pattern string) bool {
+		for _, name := range names {
+			if matched, _ := path.Match(pattern, name); matched {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, name := range names {
+		if factor, ok := c.Models[name]; ok {
+			return factor
+		}
+	}
+	patterns := slices.Collect(maps.Keys(c.Models))
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+	for _, pattern := range patterns {
+		if matches(pattern) {
+			return c.Models[pattern]
+		}
+	}
+	for _, d := range defaultEnergyFactors {
+		if matches(d.pattern) {
+			return d.factor
+		}
+	}
+	return defaultEnergyFactor
+}
+
+// EnergyEstimate is the approximate footprint of some token usage.
+type EnergyEstimate struct {
+	Wh       float64
+	CO2Grams float64
+}
+
+// Add returns the sum of two estimates.
+func (e EnergyEstimate) Add(o EnergyEstimate) EnergyEstimate {
+	return EnergyEstimate{Wh: e.Wh + o.Wh, CO2Grams: e.CO2Grams + o.CO2Grams}
+}
+
+// Estimate converts a model's input and output token counts into energy and
+// emissions.
+func (c EnergyConfig) Estimate(model string, inputTokens, outputTokens float64) EnergyEstimate {
+	factor := c.Factor(model)
+	wh := inputTokens/1000*factor.InputWhPer1K + outputTokens/1000*factor.OutputWhPer1K
+	if wh <= 0 {
+		return EnergyEstimate{}
+	}
+	return EnergyEstimate{Wh: wh, CO2Grams: wh / 1000 * c.Grid()}
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEnergyConfigFactor(t *testing.T) {
+	cfg := NormalizeEnergyConfig(EnergyConfig{
+		Enabled: true,
+		Models: map[string]EnergyFactor{
+			"Claude-*":        {InputWhPer1K: 0.1, OutputWhPer1K: 1},
+			"claude-sonnet-*": {InputWhPer1K: 0.05, OutputWhPer1K: 0.5},
+			"bad[":            {InputWhPer1K: 1, OutputWhPer1K: 1},
+			"negative":        {InputWhPer1K: -1, OutputWhPer1K: 1},
+		},
+	})
+	if len(cfg.Models) != 2 {
+		t.Fatalf("normalized models = %v, want the two valid globs", cfg.Models)
+	}
+
+	tests := []struct {
+		model string
+		want  EnergyFactor
+	}{
+		{"claude-sonnet-4", EnergyFactor{InputWhPer1K: 0.05, OutputWhPer1K: 0.5}},      // longest pattern wins
+		{"anthropic/claude-opus-4", EnergyFactor{InputWhPer1K: 0.1, OutputWhPer1K: 1}}, // matches on the last segment
+		{"o3-mini", EnergyFactor{InputWhPer1K: 0.015, OutputWhPer1K: 0.15}},            // small class before o3
+		{"gpt-4.5-preview", EnergyFactor{InputWhPer1K: 0.2, OutputWhPer1K: 2}},         // large class
+		{"some-new-model", defaultEnergyFactor},                                        // fallback
+	}
+	for _, tt := range tests {
+		if got := cfg.Factor(tt.model); got != tt.want {
+			t.Errorf("Factor(%q) = %+v, want %+v", tt.model, got, tt.want)
+		}
+	}
+}
+
+func TestEnergyConfigEstimate(t *testing.T) {
+	cfg := EnergyConfig{
+		Enabled:       true,
+		GridIntensity: 500,
+		Models:        map[string]EnergyFactor{"m": {InputWhPer1K: 0.1, OutputWhPer1K: 1}},
+	}
+	got := cfg.Estimate("m", 10_000, 2_000)
+	if math.Abs(got.Wh-3) > 1e-9 || math.Abs(got.CO2Grams-1.5) > 1e-9 {
+		t.Fatalf("Estimate = %+v, want 3 Wh / 1.5 g", got)
+	}
+	if zero := cfg.Estimate("m", 0, 0); zero != (EnergyEstimate{}) {
+		t.Fatalf("Estimate with no tokens = %+v, want zero", zero)
+	}
+	if grid := (EnergyConfig{GridIntensity: -5}).Grid(); grid != DefaultGridIntensity {
+		t.Fatalf("Grid() = %v, want default", grid)
+	}
+}
@@ -0,0 +1,193 @@
+package core
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// EngineProviderID is the provider that reports the poll engine's own
+// health as a dashboard tile. It is never auto-detected; adding an account
+// with this provider turns the tile on.
+const EngineProviderID = "openusage"
+
+// engineFetchWindow is how many recent fetches the error rate and latency
+// figures cover, so they track current behaviour rather than the whole
+// uptime.
+const engineFetchWindow = 200
+
+// EngineStats holds what the poll engine records about itself. The
+// daemon feeds it; the openusage provider reads it. All methods are safe
+// for concurrent use.
+type EngineStats struct {
+	mu        sync.Mutex
+	startedAt time.Time
+
+	pollCycles     int
+	accountsPolled int
+	lastPollAt     time.Time
+
+	fetches      int
+	recent       []engineFetch // ring of the last engineFetchWindow fetches
+	recentNext   int
+	cacheHits    int
+	cacheLookups int
+
+	catalog           EngineCatalog
+	catalogReloads    int
+	catalogReloadedAt time.Time
+}
+
+// EngineCatalog describes the pricing catalog and custom provider
+// definitions the engine is running with.
+type EngineCatalog struct {
+	// PricingVersion fingerprints the loaded rates; it changes whenever a
+	// price does.
+	PricingVersion   string
+	PricingUpdatedAt time.Time // when the newest upstream table was fetched
+	PricingModels    int
+	PricingOverrides int
+	CustomProviders  int
+}
+
+type engineFetch struct {
+	latency time.Duration
+	failed  bool
+}
+
+// Engine is the process-wide EngineStats.
+var Engine = NewEngineStats(time.Now())
+
+// NewEngineStats returns empty stats for an engine started at startedAt.
+func NewEngineStats(startedAt time.Time) *EngineStats {
+	return &EngineStats{startedAt: startedAt}
+}
+
+// RecordFetch records one provider fetch and whether it failed.
+func (e *EngineStats) RecordFetch(latency time.Duration, failed bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.fetches++
+	f := engineFetch{latency: latency, failed: failed}
+	if len(e.recent) < engineFetchWindow {
+		e.recent = append(e.recent, f)
+		return
+	}
+	e.recent[e.recentNext] = f
+	e.recentNext = (e.recentNext + 1) % engineFetchWindow
+}
+
+// RecordPoll records a completed poll cycle over accounts accounts.
+func (e *EngineStats) RecordPoll(accounts int, at time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.pollCycles++
+	e.accountsPolled = accounts
+	e.lastPollAt = at
+}
+
+// RecordCacheLookup records a read-model cache lookup.
+func (e *EngineStats) RecordCacheLookup(hit bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cacheLookups++
+	if hit {
+		e.cacheHits++
+	}
+}
+
+// RecordCatalog records the catalogs currently in use.
+func (e *EngineStats) RecordCatalog(c EngineCatalog) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.catalog = c
+}
+
+// RecordCatalogReload records that edited catalog files were reloaded.
+func (e *EngineStats) RecordCatalogReload(at time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.catalogReloads++
+	e.catalogReloadedAt = at
+}
+
+// EngineStatsSnapshot is a point-in-time copy of EngineStats plus the
+// process's memory figures.
+type EngineStatsSnapshot struct {
+	StartedAt      time.Time
+	PollCycles     int
+	AccountsPolled int
+	LastPollAt     time.Time
+
+	Fetches       int
+	RecentFetches int
+	RecentErrors  int
+	AvgLatency    time.Duration
+	MaxLatency    time.Duration
+
+	CacheHits    int
+	CacheLookups int
+
+	Catalog           EngineCatalog
+	CatalogReloads    int
+	CatalogReloadedAt time.Time
+
+	HeapBytes  uint64
+	SysBytes   uint64
+	Goroutines int
+}
+
+// ErrorRate is the share of recent fetches that failed, 0–1.
+func (s EngineStatsSnapshot) ErrorRate() float64 {
+	if s.RecentFetches == 0 {
+		return 0
+	}
+	return float64(s.RecentErrors) / float64(s.RecentFetches)
+}
+
+// CacheHitRate is the share of read-model cache lookups served from the
+// cache, 0–1.
+func (s EngineStatsSnapshot) CacheHitRate() float64 {
+	if s.CacheLookups == 0 {
+		return 0
+	}
+	return float64(s.CacheHits) / float64(s.CacheLookups)
+}
+
+// Snapshot copies the current stats and reads the runtime's memory stats.
+func (e *EngineStats) Snapshot() EngineStatsSnapshot {
+	e.mu.Lock()
+	out := EngineStatsSnapshot{
+		StartedAt:      e.startedAt,
+		PollCycles:     e.pollCycles,
+		AccountsPolled: e.accountsPolled,
+		LastPollAt:     e.lastPollAt,
+		Fetches:        e.fetches,
+		RecentFetches:  len(e.recent),
+		CacheHits:      e.cacheHits,
+		CacheLookups:   e.cacheLookups,
+
+		Catalog:           e.catalog,
+		CatalogReloads:    e.catalogReloads,
+		CatalogReloadedAt: e.catalogReloadedAt,
+	}
+	var total time.Duration
+	for _, f := range e.recent {
+		total += f.latency
+		out.MaxLatency = max(out.MaxLatency, f.latency)
+		if f.failed {
+			out.RecentErrors++
+		}
+	}
+	if len(e.recent) > 0 {
+		out.AvgLatency = total / time.Duration(len(e.recent))
+	}
+	e.mu.Unlock()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	out.HeapBytes = mem.HeapAlloc
+	out.SysBytes = mem.Sys
+	out.Goroutines = runtime.NumGoroutine()
+	return out
+}
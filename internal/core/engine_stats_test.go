@@ -0,0 +1,60 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEngineStats_RecentWindow(t *testing.T) {
+	e := NewEngineStats(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	// An early burst of failures falls out of the window once enough
+	// healthy fetches follow.
+	for range 50 {
+		e.RecordFetch(time.Second, true)
+	}
+	for range engineFetchWindow {
+		e.RecordFetch(100*time.Millisecond, false)
+	}
+	s := e.Snapshot()
+	if s.Fetches != 50+engineFetchWindow {
+		t.Errorf("Fetches = %d, want %d", s.Fetches, 50+engineFetchWindow)
+	}
+	if s.RecentFetches != engineFetchWindow || s.RecentErrors != 0 {
+		t.Errorf("recent = %d fetches / %d errors, want %d / 0", s.RecentFetches, s.RecentErrors, engineFetchWindow)
+	}
+	if s.AvgLatency != 100*time.Millisecond || s.MaxLatency != 100*time.Millisecond {
+		t.Errorf("latency avg %s max %s, want 100ms", s.AvgLatency, s.MaxLatency)
+	}
+
+	e.RecordFetch(2*time.Second, true)
+	s = e.Snapshot()
+	if s.RecentErrors != 1 || s.MaxLatency != 2*time.Second {
+		t.Errorf("after failure: errors %d max %s", s.RecentErrors, s.MaxLatency)
+	}
+	if got, want := s.ErrorRate(), 1.0/engineFetchWindow; got != want {
+		t.Errorf("ErrorRate() = %v, want %v", got, want)
+	}
+}
+
+func TestEngineStats_PollsAndCache(t *testing.T) {
+	e := NewEngineStats(time.Now())
+	if got := e.Snapshot().CacheHitRate(); got != 0 {
+		t.Errorf("empty CacheHitRate() = %v, want 0", got)
+	}
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	e.RecordPoll(7, at.Add(-time.Minute))
+	e.RecordPoll(9, at)
+	for _, hit := range []bool{true, true, true, false} {
+		e.RecordCacheLookup(hit)
+	}
+	s := e.Snapshot()
+	if s.PollCycles != 2 || s.AccountsPolled != 9 || !s.LastPollAt.Equal(at) {
+		t.Errorf("polls = %d accounts = %d last = %s", s.PollCycles, s.AccountsPolled, s.LastPollAt)
+	}
+	if got := s.CacheHitRate(); got != 0.75 {
+		t.Errorf("CacheHitRate() = %v, want 0.75", got)
+	}
+	if s.HeapBytes == 0 || s.Goroutines == 0 {
+		t.Error("expected runtime memory and goroutine figures")
+	}
+}
@@ -0,0 +1,74 @@
+package core
+
+import "strings"
+
+// FetchHistoryDiagnostic holds the outcomes of an account's recent fetches,
+// oldest first, as a string of '+' (succeeded) and '-' (failed). The daemon
+// sets it on every snapshot it fetches so the dashboard can tell a flaky
+// provider from one that is consistently broken.
+const FetchHistoryDiagnostic = "fetch_history"
+
+// FetchHistoryLen is how many fetch outcomes are kept per account.
+const FetchHistoryLen = 10
+
+// FetchHistory is a sequence of fetch outcomes, oldest first; true means the
+// fetch succeeded.
+type FetchHistory []bool
+
+// Record appends an outcome, dropping the oldest past FetchHistoryLen.
+func (h FetchHistory) Record(ok bool) FetchHistory {
+	h = append(h, ok)
+	if len(h) > FetchHistoryLen {
+		h = append(FetchHistory(nil), h[len(h)-FetchHistoryLen:]...)
+	}
+	return h
+}
+
+// Failures counts the failed fetches.
+func (h FetchHistory) Failures() int {
+	n := 0
+	for _, ok := range h {
+		if !ok {
+			n++
+		}
+	}
+	return n
+}
+
+func (h FetchHistory) String() string {
+	var b strings.Builder
+	for _, ok := range h {
+		if ok {
+			b.WriteByte('+')
+		} else {
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
+
+// SetFetchHistory records h on the snapshot.
+func (s *UsageSnapshot) SetFetchHistory(h FetchHistory) {
+	if len(h) == 0 {
+		return
+	}
+	s.SetDiagnostic(FetchHistoryDiagnostic, h.String())
+}
+
+// FetchHistory returns the fetch outcomes recorded on the snapshot, or nil.
+func (s UsageSnapshot) FetchHistory() FetchHistory {
+	value := s.Diagnostics[FetchHistoryDiagnostic]
+	if value == "" {
+		return nil
+	}
+	h := make(FetchHistory, 0, len(value))
+	for _, c := range value {
+		switch c {
+		case '+':
+			h = append(h, true)
+		case '-':
+			h = append(h, false)
+		}
+	}
+	return h
+}
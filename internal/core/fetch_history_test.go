@@ -0,0 +1,25 @@
+package core
+
+import "testing"
+
+func TestFetchHistory_RecordAndRoundTrip(t *testing.T) {
+	var h FetchHistory
+	for i := 0; i < FetchHistoryLen+2; i++ {
+		h = h.Record(i%3 != 0)
+	}
+	if len(h) != FetchHistoryLen {
+		t.Fatalf("len(history) = %d, want %d", len(h), FetchHistoryLen)
+	}
+	if got, want := h.String(), "+-++-++-++"; got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+	if got := h.Failures(); got != 3 {
+		t.Fatalf("Failures() = %d, want 3", got)
+	}
+
+	var snap UsageSnapshot
+	snap.SetFetchHistory(h)
+	if got := snap.FetchHistory().String(); got != h.String() {
+		t.Fatalf("FetchHistory() = %q, want %q", got, h.String())
+	}
+}
@@ -0,0 +1,45 @@
+package core
+
+import "time"
+
+// RetryAtDiagnostic holds when the daemon will re-fetch an account whose
+// fetch just failed for the first time after succeeding, as RFC 3339. The
+// dashboard shows "retrying…" instead of an error while it is pending.
+const RetryAtDiagnostic = "retry_at"
+
+// RetryErrorDiagnostic holds the error of the failed fetch when the daemon
+// keeps serving the previous snapshot until the retry lands.
+const RetryErrorDiagnostic = "retry_error"
+
+// retryGrace is how long past its scheduled time a retry still counts as
+// pending: the fetch timeout plus time to ingest the result.
+const retryGrace = 20 * time.Second
+
+// SetRetryAt records when the daemon will retry the failed fetch.
+func (s *UsageSnapshot) SetRetryAt(at time.Time) {
+	if at.IsZero() {
+		return
+	}
+	s.SetDiagnostic(RetryAtDiagnostic, at.UTC().Format(time.RFC3339))
+}
+
+// RetryAt returns the scheduled retry recorded on the snapshot, if any.
+func (s UsageSnapshot) RetryAt() (time.Time, bool) {
+	value := s.Diagnostics[RetryAtDiagnostic]
+	if value == "" {
+		return time.Time{}, false
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return at, true
+}
+
+// RetryPending reports whether a retry is scheduled and has not had time to
+// land yet. A retry that never reported back (the daemon stopped) stops
+// counting as pending shortly after its scheduled time.
+func (s UsageSnapshot) RetryPending(now time.Time) bool {
+	at, ok := s.RetryAt()
+	return ok && now.Before(at.Add(retryGrace))
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryPending(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var snap UsageSnapshot
+	if snap.RetryPending(now) {
+		t.Fatal("pending without a scheduled retry")
+	}
+
+	snap.SetRetryAt(now.Add(10 * time.Second))
+	if at, ok := snap.RetryAt(); !ok || !at.Equal(now.Add(10*time.Second)) {
+		t.Fatalf("RetryAt = %s, %v", at, ok)
+	}
+	for _, tc := range []struct {
+		at   time.Time
+		want bool
+	}{
+		{now, true},
+		{now.Add(25 * time.Second), true},
+		{now.Add(time.Minute), false},
+	} {
+		if got := snap.RetryPending(tc.at); got != tc.want {
+			t.Errorf("RetryPending(%s) = %v, want %v", tc.at.Sub(now), got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// freeCapacityKeys are the metrics that carry a daily free allowance, most
+// specific first: OpenRouter's free-model request cap, header-reported
+// requests-per-day limits (Groq, etc.), and Gemini CLI's daily quota.
+var freeCapacityKeys = []string{"free_rpd", "rpd", "quota"}
+
+// FreeCapacity is what a free-tier account has left of its daily allowance.
+type FreeCapacity struct {
+	MetricKey string
+	Unit      string // "requests" or "%"
+	Remaining float64
+	Limit     float64
+	ResetsAt  time.Time // zero when the provider doesn't report it
+}
+
+// RemainingPercent returns the share of the allowance left (0–100).
+func (c FreeCapacity) RemainingPercent() float64 {
+	if c.Limit <= 0 {
+		return 0
+	}
+	return c.Remaining / c.Limit * 100
+}
+
+// ResolveFreeTier decides whether a snapshot is shown in free-tier tracking
+// mode: perAccount (DashboardProviderConfig.FreeTier) wins when set,
+// otherwise the provider's own tier signal decides.
+func ResolveFreeTier(snap UsageSnapshot, perAccount *bool) bool {
+	if perAccount != nil {
+		return *perAccount
+	}
+	return autoFreeTier(snap)
+}
+
+// autoFreeTier recognises accounts the provider itself reports as free.
+func autoFreeTier(snap UsageSnapshot) bool {
+	switch snap.ProviderID {
+	case "openrouter":
+		return snap.Raw["is_free_tier"] == "true"
+	case "gemini_cli":
+		return strings.EqualFold(strings.TrimSpace(snap.Raw["tier_id"]), "free-tier")
+	}
+	return false
+}
+
+// FreeDailyCapacity returns the account's remaining daily allowance, or
+// false when the snapshot carries no capped daily metric.
+func FreeDailyCapacity(snap UsageSnapshot) (FreeCapacity, bool) {
+	for _, key := range freeCapacityKeys {
+		m, ok := snap.Metrics[key]
+		if !ok || m.Limit == nil || *m.Limit <= 0 {
+			continue
+		}
+		c := FreeCapacity{MetricKey: key, Unit: m.Unit, Limit: *m.Limit}
+		switch {
+		case m.Remaining != nil:
+			c.Remaining = *m.Remaining
+		case m.Used != nil:
+			c.Remaining = *m.Limit - *m.Used
+		default:
+			continue
+		}
+		c.Remaining = max(c.Remaining, 0)
+		if t, ok := snap.Resets[key+"_reset"]; ok {
+			c.ResetsAt = t
+		} else {
+			c.ResetsAt = snap.Resets[key]
+		}
+		return c, true
+	}
+	return FreeCapacity{}, false
+}
+
+// FreeAccountCapacity pairs an account with its remaining free allowance.
+type FreeAccountCapacity struct {
+	AccountID  string
+	ProviderID string
+	FreeCapacity
+}
+
+// SummarizeFreeCapacity lists the remaining daily allowance of every
+// free-tier snapshot, roomiest first, so the caller can point at where the
+// next request should go. isFree reports whether a snapshot is in free-tier
+// mode (see ResolveFreeTier).
+func SummarizeFreeCapacity(snaps map[string]UsageSnapshot, isFree func(UsageSnapshot) bool) []FreeAccountCapacity {
+	var out []FreeAccountCapacity
+	for _, id := range SortedStringKeys(snaps) {
+		snap := snaps[id]
+		if !isFree(snap) {
+			continue
+		}
+		c, ok := FreeDailyCapacity(snap)
+		if !ok {
+			continue
+		}
+		out = append(out, FreeAccountCapacity{AccountID: snap.AccountID, ProviderID: snap.ProviderID, FreeCapacity: c})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].RemainingPercent() > out[j].RemainingPercent()
+	})
+	return out
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveFreeTier(t *testing.T) {
+	on, off := true, false
+	tests := []struct {
+		name       string
+		snap       UsageSnapshot
+		perAccount *bool
+		want       bool
+	}{
+		{name: "openrouter free key", snap: UsageSnapshot{ProviderID: "openrouter", Raw: map[string]string{"is_free_tier": "true"}}, want: true},
+		{name: "openrouter paid key", snap: UsageSnapshot{ProviderID: "openrouter", Raw: map[string]string{"is_free_tier": "false"}}, want: false},
+		{name: "gemini cli free tier", snap: UsageSnapshot{ProviderID: "gemini_cli", Raw: map[string]string{"tier_id": "free-tier"}}, want: true},
+		{name: "no signal", snap: UsageSnapshot{ProviderID: "groq"}, want: false},
+		{name: "override on", snap: UsageSnapshot{ProviderID: "groq"}, perAccount: &on, want: true},
+		{name: "override off", snap: UsageSnapshot{ProviderID: "openrouter", Raw: map[string]string{"is_free_tier": "true"}}, perAccount: &off, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveFreeTier(tt.snap, tt.perAccount); got != tt.want {
+				t.Errorf("ResolveFreeTier = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFreeDailyCapacity(t *testing.T) {
+	reset := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	snap := UsageSnapshot{
+		Metrics: map[string]Metric{
+			// free_rpd wins over the generic rpd metric.
+			"free_rpd": {Limit: Float64Ptr(50), Used: Float64Ptr(13), Unit: "requests"},
+			"rpd":      {Limit: Float64Ptr(1000), Remaining: Float64Ptr(900), Unit: "requests"},
+		},
+		Resets: map[string]time.Time{"free_rpd_reset": reset},
+	}
+	c, ok := FreeDailyCapacity(snap)
+	if !ok || c.MetricKey != "free_rpd" || c.Remaining != 37 || c.Limit != 50 || !c.ResetsAt.Equal(reset) {
+		t.Fatalf("capacity = %+v, %v; want free_rpd 37/50 resetting at %s", c, ok, reset)
+	}
+	if got := c.RemainingPercent(); got != 74 {
+		t.Errorf("RemainingPercent = %v, want 74", got)
+	}
+
+	if _, ok := FreeDailyCapacity(UsageSnapshot{Metrics: map[string]Metric{"rpm": {Limit: Float64Ptr(30)}}}); ok {
+		t.Error("snapshot without a daily allowance should report no capacity")
+	}
+}
+
+func TestSummarizeFreeCapacity(t *testing.T) {
+	snaps := map[string]UsageSnapshot{
+		"openrouter": {AccountID: "openrouter", ProviderID: "openrouter", Metrics: map[string]Metric{
+			"free_rpd": {Limit: Float64Ptr(50), Remaining: Float64Ptr(10)},
+		}},
+		"groq": {AccountID: "groq", ProviderID: "groq", Metrics: map[string]Metric{
+			"rpd": {Limit: Float64Ptr(1000), Remaining: Float64Ptr(900)},
+		}},
+		"openai": {AccountID: "openai", ProviderID: "openai", Metrics: map[string]Metric{
+			"rpd": {Limit: Float64Ptr(1000), Remaining: Float64Ptr(1000)},
+		}},
+	}
+	got := SummarizeFreeCapacity(snaps, func(s UsageSnapshot) bool { return s.ProviderID != "openai" })
+	if len(got) != 2 || got[0].AccountID != "groq" || got[1].AccountID != "openrouter" {
+		t.Fatalf("summary = %+v, want groq then openrouter", got)
+	}
+}
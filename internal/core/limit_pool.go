@@ -0,0 +1,260 @@
+package core
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LimitPoolConfig groups accounts that draw on one shared limit, e.g. two
+// API keys in the same OpenAI or Anthropic organization. Every member's
+// header probe reports the whole org's rate limit, so without a pool the
+// dashboard shows the same quota once per key.
+type LimitPoolConfig struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name,omitempty"` // label on the shared gauge; defaults to ID
+	Accounts []string `json:"accounts"`
+	// Metrics are the shared metric keys. Empty means every metric with a
+	// limit that at least two members report.
+	Metrics []string `json:"metrics,omitempty"`
+	// AttributeBy is the per-account metric whose Used value splits the
+	// pool's consumption between members. Empty uses
+	// DefaultLimitPoolAttributeBy.
+	AttributeBy string `json:"attribute_by,omitempty"`
+}
+
+// DefaultLimitPoolAttributeBy attributes pool consumption by each account's
+// request count over the selected time window.
+const DefaultLimitPoolAttributeBy = "window_requests"
+
+// Label is the name shown on the shared gauge.
+func (p LimitPoolConfig) Label() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.ID
+}
+
+// NormalizeLimitPools trims the definitions and drops pools without an ID,
+// with fewer than two accounts, or with an ID already used. An account
+// belongs to at most one pool; later pools lose it.
+func NormalizeLimitPools(in []LimitPoolConfig) []LimitPoolConfig {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]LimitPoolConfig, 0, len(in))
+	seenPools := make(map[string]bool, len(in))
+	pooled := make(map[string]bool)
+	for _, pool := range in {
+		pool.ID = strings.TrimSpace(pool.ID)
+		pool.Name = strings.TrimSpace(pool.Name)
+		pool.AttributeBy = strings.TrimSpace(pool.AttributeBy)
+		if pool.ID == "" || seenPools[pool.ID] {
+			Tracef("config: dropping limit pool with empty or duplicate id %q", pool.ID)
+			continue
+		}
+		accounts := make([]string, 0, len(pool.Accounts))
+		for _, id := range pool.Accounts {
+			id = strings.TrimSpace(id)
+			if id == "" || pooled[id] || slices.Contains(accounts, id) {
+				continue
+			}
+			accounts = append(accounts, id)
+		}
+		if len(accounts) < 2 {
+			Tracef("config: dropping limit pool %q: needs at least two accounts", pool.ID)
+			continue
+		}
+		for _, id := range accounts {
+			pooled[id] = true
+		}
+		pool.Accounts = accounts
+		metrics := make([]string, 0, len(pool.Metrics))
+		for _, key := range pool.Metrics {
+			if key = strings.TrimSpace(key); key != "" {
+				metrics = append(metrics, key)
+			}
+		}
+		pool.Metrics = metrics
+		seenPools[pool.ID] = true
+		out = append(out, pool)
+	}
+	return out
+}
+
+// Pool membership lives in Diagnostics so it is covered by the dashboard's
+// snapshot render hash like any other field.
+const (
+	limitPoolDiagnostic        = "limit_pool"
+	limitPoolOwnerDiagnostic   = "limit_pool_owner"
+	limitPoolMetricsDiagnostic = "limit_pool_metrics"
+	limitPoolSharePrefix       = "limit_pool_share_"
+)
+
+// LimitPoolShare is one account's consumption of a shared pool, in the
+// units of the pool's AttributeBy metric.
+type LimitPoolShare struct {
+	AccountID string
+	Used      float64
+}
+
+// LimitPoolMembership describes the pool a snapshot's account belongs to.
+// The owner's snapshot carries the shared gauges and the per-account
+// shares; the other members' snapshots only point at it.
+type LimitPoolMembership struct {
+	Name    string
+	Owner   string
+	Metrics []string
+	Shares  []LimitPoolShare // owner only, largest first
+}
+
+// IsOwner reports whether the snapshot for accountID shows the pool's
+// shared gauges.
+func (p LimitPoolMembership) IsOwner(accountID string) bool {
+	return p.Owner == accountID
+}
+
+// ApplyLimitPools folds each pool's shared metrics into one set of gauges on
+// the pool's first reporting account and removes them from the others, so
+// the org limit is shown once. The freshest member's reading wins. Each
+// member's AttributeBy value is recorded on the owner as its share of the
+// pool. Snapshots in snaps are replaced, never modified in place.
+func ApplyLimitPools(snaps map[string]UsageSnapshot, pools []LimitPoolConfig) {
+	for _, pool := range pools {
+		members := make([]string, 0, len(pool.Accounts))
+		for _, id := range pool.Accounts {
+			if snap, ok := snaps[id]; ok && len(snap.Metrics) > 0 {
+				members = append(members, id)
+			}
+		}
+		if len(members) < 2 {
+			continue
+		}
+		keys := limitPoolMetricKeys(pool, snaps, members)
+		if len(keys) == 0 {
+			continue
+		}
+
+		attributeBy := pool.AttributeBy
+		if attributeBy == "" {
+			attributeBy = DefaultLimitPoolAttributeBy
+		}
+		owner := members[0]
+		for _, id := range members {
+			snap := cloneLimitPoolSnapshot(snaps[id])
+			for _, key := range keys {
+				delete(snap.Metrics, key)
+				delete(snap.Resets, key+"_reset")
+			}
+			snap.SetDiagnostic(limitPoolDiagnostic, pool.Label())
+			snap.SetDiagnostic(limitPoolOwnerDiagnostic, owner)
+			snap.SetDiagnostic(limitPoolMetricsDiagnostic, strings.Join(keys, ","))
+			if id == owner {
+				for _, key := range keys {
+					src := freshestWithMetric(snaps, members, key)
+					snap.Metrics[key] = src.Metrics[key]
+					if reset, ok := src.Resets[key+"_reset"]; ok {
+						snap.Resets[key+"_reset"] = reset
+					}
+				}
+				for _, member := range members {
+					if met, ok := snaps[member].Metrics[attributeBy]; ok && met.Used != nil {
+						snap.SetDiagnostic(limitPoolSharePrefix+member, strconv.FormatFloat(*met.Used, 'f', -1, 64))
+					}
+				}
+			}
+			snaps[id] = snap
+		}
+	}
+}
+
+// LimitPool returns the pool the snapshot's account belongs to, if any.
+func (s UsageSnapshot) LimitPool() (LimitPoolMembership, bool) {
+	name := s.Diagnostics[limitPoolDiagnostic]
+	if name == "" {
+		return LimitPoolMembership{}, false
+	}
+	p := LimitPoolMembership{
+		Name:  name,
+		Owner: s.Diagnostics[limitPoolOwnerDiagnostic],
+	}
+	if metrics := s.Diagnostics[limitPoolMetricsDiagnostic]; metrics != "" {
+		p.Metrics = strings.Split(metrics, ",")
+	}
+	for key, value := range s.Diagnostics {
+		id, ok := strings.CutPrefix(key, limitPoolSharePrefix)
+		if !ok || id == "" {
+			continue
+		}
+		used, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		p.Shares = append(p.Shares, LimitPoolShare{AccountID: id, Used: used})
+	}
+	sort.Slice(p.Shares, func(i, j int) bool {
+		if p.Shares[i].Used != p.Shares[j].Used {
+			return p.Shares[i].Used > p.Shares[j].Used
+		}
+		return p.Shares[i].AccountID < p.Shares[j].AccountID
+	})
+	return p, true
+}
+
+func limitPoolMetricKeys(pool LimitPoolConfig, snaps map[string]UsageSnapshot, members []string) []string {
+	if len(pool.Metrics) > 0 {
+		var keys []string
+		for _, key := range pool.Metrics {
+			for _, id := range members {
+				if _, ok := snaps[id].Metrics[key]; ok {
+					keys = append(keys, key)
+					break
+				}
+			}
+		}
+		return keys
+	}
+	counts := make(map[string]int)
+	for _, id := range members {
+		for key, met := range snaps[id].Metrics {
+			if met.Limit != nil {
+				counts[key]++
+			}
+		}
+	}
+	var keys []string
+	for key, n := range counts {
+		if n >= 2 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func freshestWithMetric(snaps map[string]UsageSnapshot, members []string, key string) UsageSnapshot {
+	var best UsageSnapshot
+	found := false
+	for _, id := range members {
+		snap := snaps[id]
+		if _, ok := snap.Metrics[key]; !ok {
+			continue
+		}
+		if !found || snap.Timestamp.After(best.Timestamp) {
+			best, found = snap, true
+		}
+	}
+	return best
+}
+
+// cloneLimitPoolSnapshot copies the maps ApplyLimitPools writes to, since
+// snapshots share them with the caller's previous frame.
+func cloneLimitPoolSnapshot(snap UsageSnapshot) UsageSnapshot {
+	snap.Metrics = maps.Clone(snap.Metrics)
+	snap.Resets = maps.Clone(snap.Resets)
+	snap.Diagnostics = maps.Clone(snap.Diagnostics)
+	snap.EnsureMaps()
+	return snap
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyLimitPools_SharedGaugeAndAttribution(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	limit := func(lim, rem float64) Metric {
+		return Metric{Limit: &lim, Remaining: &rem, Unit: "requests", Window: "1m"}
+	}
+	used := func(v float64) Metric { return Metric{Used: &v, Unit: "requests"} }
+
+	snaps := map[string]UsageSnapshot{
+		"work": {AccountID: "work", Timestamp: now.Add(-time.Minute), Metrics: map[string]Metric{
+			"rpm": limit(500, 400), "window_requests": used(30),
+		}},
+		"personal": {AccountID: "personal", Timestamp: now, Metrics: map[string]Metric{
+			"rpm": limit(500, 350), "window_requests": used(90),
+		}},
+		"other": {AccountID: "other", Timestamp: now, Metrics: map[string]Metric{"rpm": limit(60, 60)}},
+	}
+	before := snaps["personal"]
+
+	pools := NormalizeLimitPools([]LimitPoolConfig{{ID: "acme", Name: "Acme org", Accounts: []string{"work", " personal ", ""}}})
+	ApplyLimitPools(snaps, pools)
+
+	owner := snaps["work"]
+	if got := *owner.Metrics["rpm"].Remaining; got != 350 {
+		t.Fatalf("owner rpm remaining = %v, want freshest member's 350", got)
+	}
+	pool, ok := owner.LimitPool()
+	if !ok || pool.Name != "Acme org" || !pool.IsOwner("work") {
+		t.Fatalf("owner pool = %+v, %v", pool, ok)
+	}
+	if len(pool.Shares) != 2 || pool.Shares[0] != (LimitPoolShare{AccountID: "personal", Used: 90}) || pool.Shares[1] != (LimitPoolShare{AccountID: "work", Used: 30}) {
+		t.Fatalf("shares = %+v", pool.Shares)
+	}
+
+	member := snaps["personal"]
+	if _, ok := member.Metrics["rpm"]; ok {
+		t.Fatal("member still reports the shared rpm gauge")
+	}
+	if pool, ok := member.LimitPool(); !ok || pool.Owner != "work" || len(pool.Shares) != 0 {
+		t.Fatalf("member pool = %+v, %v", pool, ok)
+	}
+	if _, ok := before.Metrics["rpm"]; !ok {
+		t.Fatal("ApplyLimitPools modified the caller's snapshot maps")
+	}
+	if _, ok := snaps["other"].LimitPool(); ok {
+		t.Fatal("account outside the pool was marked as pooled")
+	}
+}
+
+func TestNormalizeLimitPools_DropsInvalid(t *testing.T) {
+	got := NormalizeLimitPools([]LimitPoolConfig{
+		{ID: "", Accounts: []string{"a", "b"}},
+		{ID: "one", Accounts: []string{"a"}},
+		{ID: "ok", Accounts: []string{"a", "b"}},
+		{ID: "ok", Accounts: []string{"c", "d"}},
+		{ID: "overlap", Accounts: []string{"b", "e"}},
+		{ID: "dupes", Accounts: []string{"f", "f"}},
+	})
+	if len(got) != 1 || got[0].ID != "ok" || len(got[0].Accounts) != 2 || got[0].Accounts[0] != "a" {
+		t.Fatalf("NormalizeLimitPools() = %+v", got)
+	}
+}
@@ -0,0 +1,12 @@
+package core
+
+// LiveSessionRunning is the "live_session_status" raw value of a tile whose
+// latest session had activity in the last couple of minutes: an agent run
+// still in progress.
+const LiveSessionRunning = "running"
+
+// HasRunningSession reports whether the snapshot carries a live session that
+// is still in progress.
+func HasRunningSession(s UsageSnapshot) bool {
+	return s.Raw["live_session_status"] == LiveSessionRunning
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalDataLayout describes where a local provider's files live under one
+// data root, so a per-account data_path — or the tool's own environment
+// override such as CLAUDE_CONFIG_DIR — relocates all of them at once.
+type LocalDataLayout struct {
+	// EnvVar is the tool's own data-root override, honored when an account
+	// sets no data_path. Leave empty when the tool has none.
+	EnvVar string
+	// RootKey is the runtime hint the provider reads the root itself from
+	// ("claude_dir", "config_dir"), for files it locates on its own.
+	RootKey string
+	// Paths maps path keys (see AccountConfig.Path) to candidate locations
+	// relative to the root. The first candidate that exists is used; when
+	// none does the key is left to the provider's default.
+	Paths map[string][]string
+}
+
+// IsZero reports whether the provider declares no local data layout.
+func (l LocalDataLayout) IsZero() bool {
+	return l.EnvVar == "" && l.RootKey == "" && len(l.Paths) == 0
+}
+
+// ExpandDataPath expands a leading ~ and environment variables in pattern.
+// A glob resolves to its most recently modified match, so a pattern like
+// "~/Apps/Cursor*/data" follows whichever install was used last.
+func ExpandDataPath(pattern string) (string, error) {
+	path := os.ExpandEnv(strings.TrimSpace(pattern))
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, `~\`) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("expanding %q: %w", pattern, err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	if path == "" || !strings.ContainsAny(path, "*?[") {
+		return path, nil
+	}
+
+	matches, err := filepath.Glob(path)
+	if err != nil {
+		return "", fmt.Errorf("data_path %q: %w", pattern, err)
+	}
+	var newest string
+	var newestMod int64
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			continue
+		}
+		if mod := info.ModTime().UnixNano(); newest == "" || mod > newestMod {
+			newest, newestMod = match, mod
+		}
+	}
+	if newest == "" {
+		return "", fmt.Errorf("data_path %q matches nothing", pattern)
+	}
+	return newest, nil
+}
+
+// ResolveLocalData points acct's path hints at its data root: the account's
+// data_path when set, otherwise the layout's EnvVar. Paths set explicitly
+// in provider_paths still win, since Path reads them before hints. A
+// data_path on a provider without a layout is an error, as is one that
+// matches nothing.
+func ResolveLocalData(acct AccountConfig, layout LocalDataLayout) (AccountConfig, error) {
+	pattern := strings.TrimSpace(acct.DataPath)
+	fromEnv := false
+	if pattern == "" {
+		if layout.EnvVar == "" {
+			return acct, nil
+		}
+		pattern = strings.TrimSpace(os.Getenv(layout.EnvVar))
+		fromEnv = true
+	} else if layout.IsZero() {
+		return acct, fmt.Errorf("provider %q reads no local data; data_path is not supported", acct.Provider)
+	}
+	if pattern == "" {
+		return acct, nil
+	}
+
+	root, err := ExpandDataPath(pattern)
+	if err != nil {
+		return acct, err
+	}
+
+	// Copy before writing so the caller's account, which may be shared
+	// across polls, keeps its own hints.
+	hints := make(map[string]string, len(acct.RuntimeHints)+len(layout.Paths)+1)
+	for k, v := range acct.RuntimeHints {
+		hints[k] = v
+	}
+	set := func(key, value string) {
+		// The environment only fills gaps; an explicit data_path replaces
+		// whatever detection found.
+		if fromEnv && hints[key] != "" {
+			return
+		}
+		hints[key] = value
+	}
+	if layout.RootKey != "" {
+		set(layout.RootKey, root)
+	}
+	for _, key := range SortedStringKeys(layout.Paths) {
+		for _, rel := range layout.Paths[key] {
+			candidate := filepath.Join(root, filepath.FromSlash(rel))
+			if _, err := os.Stat(candidate); err == nil {
+				set(key, candidate)
+				break
+			}
+		}
+	}
+	acct.RuntimeHints = hints
+	return acct, nil
+}
@@ -0,0 +1,111 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testLayout = LocalDataLayout{
+	EnvVar:  "OPENUSAGE_TEST_DATA_ROOT",
+	RootKey: "root_dir",
+	Paths: map[string][]string{
+		"state_db": {"User/state.db", "user-data/User/state.db"},
+		"missing":  {"nope.json"},
+	},
+}
+
+func touch(t *testing.T, path string, mod time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExpandDataPath_GlobPicksNewest(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	touch(t, filepath.Join(dir, "Cursor-1.0", "data"), now.Add(-time.Hour))
+	touch(t, filepath.Join(dir, "Cursor-1.2", "data"), now)
+
+	t.Setenv("APPS", dir)
+	got, err := ExpandDataPath("$APPS/Cursor-*/data")
+	if err != nil || got != filepath.Join(dir, "Cursor-1.2", "data") {
+		t.Fatalf("ExpandDataPath = %q, %v", got, err)
+	}
+	if _, err := ExpandDataPath(filepath.Join(dir, "Windsurf*")); err == nil || !strings.Contains(err.Error(), "matches nothing") {
+		t.Fatalf("unmatched glob error = %v", err)
+	}
+}
+
+func TestResolveLocalData_DataPath(t *testing.T) {
+	root := t.TempDir()
+	touch(t, filepath.Join(root, "user-data", "User", "state.db"), time.Now())
+
+	acct := AccountConfig{
+		ID:           "portable",
+		Provider:     "cursor",
+		DataPath:     root,
+		RuntimeHints: map[string]string{"state_db": "/default/state.db", "email": "me@example.com"},
+	}
+	got, err := ResolveLocalData(acct, testLayout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := got.Path("state_db", ""); p != filepath.Join(root, "user-data", "User", "state.db") {
+		t.Errorf("state_db = %q, want the portable candidate", p)
+	}
+	if got.Hint("root_dir", "") != root || got.Hint("email", "") != "me@example.com" {
+		t.Errorf("hints = %v", got.RuntimeHints)
+	}
+	if _, ok := got.RuntimeHints["missing"]; ok {
+		t.Error("a key with no existing candidate should fall back to the provider default")
+	}
+	if acct.RuntimeHints["state_db"] != "/default/state.db" {
+		t.Error("the caller's hints were modified")
+	}
+
+	acct.ProviderPaths = map[string]string{"state_db": "/explicit/state.db"}
+	got, _ = ResolveLocalData(acct, testLayout)
+	if p := got.Path("state_db", ""); p != "/explicit/state.db" {
+		t.Errorf("state_db = %q, want the explicit provider_paths entry", p)
+	}
+}
+
+func TestResolveLocalData_EnvFillsGaps(t *testing.T) {
+	root := t.TempDir()
+	touch(t, filepath.Join(root, "User", "state.db"), time.Now())
+	t.Setenv(testLayout.EnvVar, root)
+
+	got, err := ResolveLocalData(AccountConfig{Provider: "cursor"}, testLayout)
+	if err != nil || got.Hint("state_db", "") != filepath.Join(root, "User", "state.db") {
+		t.Fatalf("env root: hints = %v, err = %v", got.RuntimeHints, err)
+	}
+
+	detected := AccountConfig{Provider: "cursor", RuntimeHints: map[string]string{"state_db": "/detected/state.db"}}
+	got, _ = ResolveLocalData(detected, testLayout)
+	if got.Hint("state_db", "") != "/detected/state.db" {
+		t.Errorf("the environment replaced a detected path: %v", got.RuntimeHints)
+	}
+}
+
+func TestResolveLocalData_Errors(t *testing.T) {
+	if _, err := ResolveLocalData(AccountConfig{Provider: "openai", DataPath: "/tmp"}, LocalDataLayout{}); err == nil {
+		t.Error("data_path on a provider without a layout should fail")
+	}
+	acct := AccountConfig{Provider: "cursor", DataPath: filepath.Join(t.TempDir(), "gone*")}
+	if _, err := ResolveLocalData(acct, testLayout); err == nil {
+		t.Error("a data_path glob that matches nothing should fail")
+	}
+	if got, err := ResolveLocalData(AccountConfig{Provider: "openai"}, LocalDataLayout{}); err != nil || got.RuntimeHints != nil {
+		t.Errorf("no data_path, no layout: %v, %v", got.RuntimeHints, err)
+	}
+}
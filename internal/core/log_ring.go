@@ -0,0 +1,75 @@
+package core
+
+import (
+	"bytes"
+	"sync"
+)
+
+// DebugLogCapacity is how many lines DebugLog keeps.
+const DebugLogCapacity = 2000
+
+// DebugLog collects the process's log output when it must not reach stderr
+// (the TUI owns the terminal) so it can still be shown in-app or dumped with
+// `openusage logs`.
+var DebugLog = NewLogRing(DebugLogCapacity)
+
+// LogRing is an io.Writer that keeps the last N complete lines written to
+// it. Safe for concurrent use.
+type LogRing struct {
+	mu      sync.Mutex
+	lines   []string
+	start   int
+	partial []byte
+	written uint64
+}
+
+// NewLogRing returns a ring holding up to capacity lines (at least one).
+func NewLogRing(capacity int) *LogRing {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &LogRing{lines: make([]string, 0, capacity)}
+}
+
+func (r *LogRing) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data := append(r.partial, p...)
+	for {
+		idx := bytes.IndexByte(data, '\n')
+		if idx < 0 {
+			break
+		}
+		r.push(string(data[:idx]))
+		data = data[idx+1:]
+	}
+	r.partial = append([]byte(nil), data...)
+	return len(p), nil
+}
+
+func (r *LogRing) push(line string) {
+	r.written++
+	if len(r.lines) < cap(r.lines) {
+		r.lines = append(r.lines, line)
+		return
+	}
+	r.lines[r.start] = line
+	r.start = (r.start + 1) % len(r.lines)
+}
+
+// Lines returns the buffered lines, oldest first.
+func (r *LogRing) Lines() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]string, 0, len(r.lines))
+	out = append(out, r.lines[r.start:]...)
+	return append(out, r.lines[:r.start]...)
+}
+
+// Written counts every line ever pushed, including ones since evicted, so
+// readers can cheaply tell whether anything new arrived.
+func (r *LogRing) Written() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.written
+}
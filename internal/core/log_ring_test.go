@@ -0,0 +1,49 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"testing"
+)
+
+func TestLogRingKeepsLastLines(t *testing.T) {
+	ring := NewLogRing(3)
+	for i := 1; i <= 5; i++ {
+		fmt.Fprintf(ring, "line %d\n", i)
+	}
+
+	want := []string{"line 3", "line 4", "line 5"}
+	if got := ring.Lines(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Lines() = %q, want %q", got, want)
+	}
+	if got := ring.Written(); got != 5 {
+		t.Fatalf("Written() = %d, want 5", got)
+	}
+}
+
+func TestLogRingJoinsPartialWrites(t *testing.T) {
+	ring := NewLogRing(10)
+	_, _ = ring.Write([]byte("first half, "))
+	_, _ = ring.Write([]byte("second half\nnext"))
+
+	if got := ring.Lines(); !reflect.DeepEqual(got, []string{"first half, second half"}) {
+		t.Fatalf("Lines() = %q", got)
+	}
+	_, _ = ring.Write([]byte(" line\n"))
+	if got := ring.Lines(); len(got) != 2 || got[1] != "next line" {
+		t.Fatalf("Lines() = %q, want the buffered tail completed", got)
+	}
+}
+
+func TestLogRingAsLogOutput(t *testing.T) {
+	ring := NewLogRing(10)
+	logger := log.New(ring, "", 0)
+	logger.Printf("poll %s", "openai")
+	logger.Print("multi\nline")
+
+	want := []string{"poll openai", "multi", "line"}
+	if got := ring.Lines(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Lines() = %q, want %q", got, want)
+	}
+}
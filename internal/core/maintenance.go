@@ -0,0 +1,58 @@
+package core
+
+import (
+	"errors"
+	"time"
+)
+
+// MaintenanceResetKey is the Resets key holding the announced end of a
+// maintenance window, when the provider gave one.
+const MaintenanceResetKey = "maintenance_end"
+
+// MaintenanceError is returned by a provider whose upstream is down for
+// planned maintenance (a 503 with a maintenance body, a status-page
+// incident). The poller turns it into StatusMaintenance instead of
+// StatusError, so the outage neither alerts nor paints the dashboard red.
+// A zero Until means the provider did not say when it ends.
+type MaintenanceError struct {
+	Message string
+	Until   time.Time
+}
+
+func (e *MaintenanceError) Error() string {
+	if e.Message == "" {
+		return "scheduled maintenance"
+	}
+	return e.Message
+}
+
+// SetMaintenance marks the snapshot as in planned maintenance and records
+// the announced end, if any.
+func (s *UsageSnapshot) SetMaintenance(message string, until time.Time) {
+	if message == "" {
+		message = "scheduled maintenance"
+	}
+	s.Status = StatusMaintenance
+	s.Message = message
+	if !until.IsZero() {
+		s.EnsureMaps()
+		s.Resets[MaintenanceResetKey] = until
+	}
+}
+
+// FetchErrorSnapshot builds the snapshot recorded when a provider's Fetch
+// fails: StatusMaintenance for a *MaintenanceError, StatusError otherwise.
+func FetchErrorSnapshot(providerID, accountID string, at time.Time, err error) UsageSnapshot {
+	snap := UsageSnapshot{
+		ProviderID: providerID,
+		AccountID:  accountID,
+		Timestamp:  at,
+		Status:     StatusError,
+		Message:    err.Error(),
+	}
+	var maint *MaintenanceError
+	if errors.As(err, &maint) {
+		snap.SetMaintenance(maint.Message, maint.Until)
+	}
+	return snap
+}
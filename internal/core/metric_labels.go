@@ -8,6 +8,7 @@ var prettifyKeyOverrides = map[string]string{
 	"spend_limit":          "Spend Limit",
 	"individual_spend":     "Individual Spend",
 	"context_window":       "Context Window",
+	CacheSavingsMetricKey:  "Cache Savings",
 }
 
 func MetricLabel(widget DashboardWidget, key string) string {
@@ -60,3 +61,21 @@ func PrettifyMetricKey(key string) string {
 	}
 	return result
 }
+
+// Snapshot metric labels live in Diagnostics as metric_label_<key>, for
+// names only the fetch can know, such as a plan tier ("Pro 5h window").
+const metricLabelPrefix = "metric_label_"
+
+// SetMetricLabel overrides the display label of metric key on this snapshot.
+func (s *UsageSnapshot) SetMetricLabel(key, label string) {
+	key, label = strings.TrimSpace(key), strings.TrimSpace(label)
+	if key == "" || label == "" {
+		return
+	}
+	s.SetDiagnostic(metricLabelPrefix+key, label)
+}
+
+// MetricLabel returns the snapshot's own label for metric key, or "".
+func (s UsageSnapshot) MetricLabel(key string) string {
+	return s.Diagnostics[metricLabelPrefix+key]
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+)
+
+// vendorSlugAliases maps the vendor prefixes routers use in model IDs
+// (OpenRouter's "x-ai/grok-4", "meta-llama/llama-3.1-70b") to the vendor
+// names used in canonical lineages.
+var vendorSlugAliases = map[string]string{
+	"x-ai":        "xai",
+	"meta-llama":  "meta",
+	"mistralai":   "mistral",
+	"deepseek-ai": "deepseek",
+	"google-ai":   "google",
+	"openai":      "openai",
+	"anthropic":   "anthropic",
+}
+
+// modelAliases maps provider-specific model IDs that the family heuristics
+// cannot place (or would place under the reporting tool's vendor) to their
+// canonical lineage. Keys are lower-case, vendor- and date-stripped, in the
+// form produced by normalizeModelToken.
+var modelAliases = map[string]string{
+	"gpt-4o":            "openai/gpt-4o",
+	"gpt-4o-mini":       "openai/gpt-4o-mini",
+	"chatgpt-4o":        "openai/gpt-4o",
+	"chatgpt-4o-latest": "openai/gpt-4o",
+	"o1":                "openai/o1",
+	"o1-mini":           "openai/o1-mini",
+	"o1-pro":            "openai/o1-pro",
+	"o3":                "openai/o3",
+	"o3-mini":           "openai/o3-mini",
+	"o3-pro":            "openai/o3-pro",
+	"o4-mini":           "openai/o4-mini",
+	"deepseek-chat":     "deepseek/deepseek-v3",
+	"deepseek-reasoner": "deepseek/deepseek-r1",
+}
+
+var (
+	// Bedrock: "us.anthropic.claude-3-5-sonnet-20240620-v1:0".
+	reBedrockRegion  = regexp.MustCompile(`^(?:us|eu|apac|global)\.`)
+	reBedrockVersion = regexp.MustCompile(`-v\d+(?::\d+)?$`)
+)
+
+// stripModelRouting removes hosting decorations that do not change which
+// model ran: Bedrock region and vendor prefixes and version suffixes, Vertex
+// "@date" separators, and OpenRouter ":free" / ":thinking" variants.
+func stripModelRouting(model string) string {
+	model = reBedrockRegion.ReplaceAllString(model, "")
+	for _, vendor := range []string{"anthropic.", "meta.", "mistral.", "amazon."} {
+		model = strings.TrimPrefix(model, vendor)
+	}
+	model = reBedrockVersion.ReplaceAllString(model, "")
+	for _, suffix := range []string{":free", ":thinking", ":beta", ":extended"} {
+		model = strings.TrimSuffix(model, suffix)
+	}
+	return strings.ReplaceAll(model, "@", "-")
+}
+
+// lookupModelAlias resolves a date-stripped, normalized model token against
+// modelAliases.
+func lookupModelAlias(norm string) (canonicalModelIdentity, bool) {
+	lineage, ok := modelAliases[norm]
+	if !ok {
+		return canonicalModelIdentity{}, false
+	}
+	vendor, family := parseVendorFamilyFromCanonical(lineage)
+	return canonicalModelIdentity{
+		LineageID:  lineage,
+		Vendor:     vendor,
+		Family:     family,
+		Variant:    parseVariantFromCanonical(lineage),
+		Confidence: 0.97,
+		Reason:     "alias_table",
+		Canonical:  lineage,
+	}, true
+}
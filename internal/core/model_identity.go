@@ -59,10 +59,14 @@ func normalizeCanonicalModel(providerID, rawModelID string, cfg ModelNormalizati
 	model := strings.ToLower(strings.TrimSpace(raw))
 	model = strings.TrimPrefix(model, "models/")
 	model = strings.Trim(model, "/")
+	model = stripModelRouting(model)
 
 	explicitVendor := ""
 	if parts := strings.SplitN(model, "/", 2); len(parts) == 2 {
-		if isKnownVendor(parts[0]) {
+		if alias, ok := vendorSlugAliases[parts[0]]; ok {
+			explicitVendor = alias
+			model = parts[1]
+		} else if isKnownVendor(parts[0]) {
 			explicitVendor = parts[0]
 			model = parts[1]
 		}
@@ -77,6 +81,12 @@ func normalizeCanonicalModel(providerID, rawModelID string, cfg ModelNormalizati
 	if norm == "" {
 		norm = "unknown"
 	}
+	if identity, ok := lookupModelAlias(norm); ok {
+		if releaseDate != "" {
+			identity.ReleaseID = identity.LineageID + "@" + releaseDate
+		}
+		return identity
+	}
 	tokens := splitModelTokens(norm)
 
 	vendor := explicitVendor
@@ -290,7 +300,7 @@ func isKnownVendor(v string) bool {
 }
 
 func detectVendorFromModel(tokens []string, fallback string) string {
-	if containsToken(tokens, "claude") {
+	if detectFamily(tokens) == "claude" {
 		return "anthropic"
 	}
 	if containsToken(tokens, "gpt") || containsToken(tokens, "codex") {
@@ -319,7 +329,8 @@ func detectVendorFromModel(tokens []string, fallback string) string {
 
 func detectFamily(tokens []string) string {
 	switch {
-	case containsToken(tokens, "claude"):
+	case containsToken(tokens, "claude"),
+		containsToken(tokens, "opus"), containsToken(tokens, "sonnet"), containsToken(tokens, "haiku"):
 		return "claude"
 	case containsToken(tokens, "gemini"):
 		return "gemini"
@@ -440,11 +451,11 @@ func extractVersionNearVariant(tokens []string, variant string) string {
 			return tokens[i]
 		}
 	}
-	// then left side
+	// then left side; "3-5-sonnet" splits the version into two tokens
 	for i := idx - 1; i >= 0; i-- {
 		if reVersionToken.MatchString(tokens[i]) {
-			if !strings.Contains(tokens[i], ".") && i+1 < len(tokens) && isAllDigits(tokens[i+1]) && i+1 == idx-0 {
-				return tokens[i] + "." + tokens[i+1]
+			if isAllDigits(tokens[i]) && i > 0 && isAllDigits(tokens[i-1]) {
+				return tokens[i-1] + "." + tokens[i]
 			}
 			return tokens[i]
 		}
@@ -81,3 +81,56 @@ func TestNormalizeUsageSnapshotWithConfig_BuildsModelUsage(t *testing.T) {
 		t.Fatalf("provider dimension = %q", rec.Dimensions["provider_id"])
 	}
 }
+
+func TestNormalizeCanonicalModel_CrossProviderIDs(t *testing.T) {
+	cfg := DefaultModelNormalizationConfig()
+	tests := []struct {
+		provider string
+		raw      string
+		want     string
+	}{
+		{"claude_code", "claude-sonnet-4-5-20250929", "anthropic/claude-sonnet-4.5"},
+		{"copilot", "claude_sonnet_4_5", "anthropic/claude-sonnet-4.5"},
+		{"cursor", "claude-4.5-sonnet-thinking", "anthropic/claude-sonnet-4.5"},
+		{"cursor", "sonnet-4.5", "anthropic/claude-sonnet-4.5"},
+		{"openrouter", "anthropic/claude-sonnet-4.5", "anthropic/claude-sonnet-4.5"},
+		{"openrouter", "anthropic/claude-3.5-sonnet", "anthropic/claude-sonnet-3.5"},
+		{"anthropic", "claude-3-5-sonnet-20241022", "anthropic/claude-sonnet-3.5"},
+		{"", "us.anthropic.claude-3-5-sonnet-20240620-v1:0", "anthropic/claude-sonnet-3.5"},
+		{"", "claude-3-5-sonnet@20240620", "anthropic/claude-sonnet-3.5"},
+		{"openai", "chatgpt-4o-latest", "openai/gpt-4o"},
+		{"cursor", "o3", "openai/o3"},
+		{"openrouter", "openai/o4-mini", "openai/o4-mini"},
+		{"openrouter", "x-ai/grok-4", "xai/grok-4"},
+		{"openrouter", "deepseek/deepseek-chat:free", "deepseek/deepseek-v3"},
+		{"ollama", "qwen2.5-coder:7b", "unknown/qwen2.5-coder-7b"},
+	}
+	for _, tt := range tests {
+		got := normalizeCanonicalModel(tt.provider, tt.raw, cfg)
+		if got.LineageID != tt.want {
+			t.Errorf("normalizeCanonicalModel(%q, %q) lineage = %q, want %q", tt.provider, tt.raw, got.LineageID, tt.want)
+		}
+		if got.Confidence < cfg.MinConfidence && got.Vendor != "unknown" {
+			t.Errorf("normalizeCanonicalModel(%q, %q) confidence = %.2f, below grouping threshold", tt.provider, tt.raw, got.Confidence)
+		}
+	}
+}
+
+func TestNormalizeUsageSnapshotWithConfig_GroupByModel(t *testing.T) {
+	cfg := DefaultModelNormalizationConfig()
+	cfg.GroupBy = ModelNormalizationGroupModel
+	s := UsageSnapshot{
+		ProviderID: "openrouter",
+		AccountID:  "or",
+		ModelUsage: []ModelUsageRecord{
+			{RawModelID: "anthropic/claude-sonnet-4.5", CostUSD: Float64Ptr(1)},
+			{RawModelID: "anthropic/claude-3.7-sonnet", CostUSD: Float64Ptr(2)},
+		},
+	}
+	got := NormalizeUsageSnapshotWithConfig(s, cfg)
+	for _, rec := range got.ModelUsage {
+		if rec.Dimensions["canonical_group_id"] != "anthropic/claude-sonnet" {
+			t.Fatalf("group id for %s = %q, want anthropic/claude-sonnet", rec.RawModelID, rec.Dimensions["canonical_group_id"])
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ModelRetirement is a model the account spends on that its vendor has
+// scheduled for shutdown.
+type ModelRetirement struct {
+	Model string
+	Date  time.Time
+	// SpendShare is the model's fraction (0..1) of the snapshot's model
+	// spend, i.e. how much of the bill has to move elsewhere.
+	SpendShare float64
+}
+
+// Retirements live in Diagnostics as model_retirement_<model> =
+// "<date>|<share>" so they survive the telemetry store.
+const modelRetirementPrefix = "model_retirement_"
+
+// AddModelRetirement records a scheduled model shutdown on the snapshot.
+func (s *UsageSnapshot) AddModelRetirement(r ModelRetirement) {
+	model := strings.TrimSpace(r.Model)
+	if model == "" || r.Date.IsZero() {
+		return
+	}
+	s.SetDiagnostic(modelRetirementPrefix+model, r.Date.Format(time.DateOnly)+"|"+strconv.FormatFloat(r.SpendShare, 'f', 4, 64))
+}
+
+// ModelRetirements returns the snapshot's scheduled model shutdowns,
+// soonest first.
+func (s UsageSnapshot) ModelRetirements() []ModelRetirement {
+	var out []ModelRetirement
+	for key, value := range s.Diagnostics {
+		model, ok := strings.CutPrefix(key, modelRetirementPrefix)
+		if !ok || model == "" {
+			continue
+		}
+		dateStr, shareStr, _ := strings.Cut(value, "|")
+		date, err := time.Parse(time.DateOnly, dateStr)
+		if err != nil {
+			continue
+		}
+		share, _ := strconv.ParseFloat(shareStr, 64)
+		out = append(out, ModelRetirement{Model: model, Date: date, SpendShare: share})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Date.Equal(out[j].Date) {
+			return out[i].Date.Before(out[j].Date)
+		}
+		if out[i].SpendShare != out[j].SpendShare {
+			return out[i].SpendShare > out[j].SpendShare
+		}
+		return out[i].Model < out[j].Model
+	})
+	return out
+}
+
+// ModelSpendShares returns each model's fraction of the total model spend
+// in records, keyed by raw model ID. Models without spend are left out.
+func ModelSpendShares(records []ModelUsageRecord) map[string]float64 {
+	costs := make(map[string]float64)
+	total := 0.0
+	for _, rec := range records {
+		if rec.RawModelID == "" || rec.CostUSD == nil || *rec.CostUSD <= 0 {
+			continue
+		}
+		costs[rec.RawModelID] += *rec.CostUSD
+		total += *rec.CostUSD
+	}
+	if total <= 0 {
+		return nil
+	}
+	for model, cost := range costs {
+		costs[model] = cost / total
+	}
+	return costs
+}
@@ -0,0 +1,29 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestModelRetirements_RoundTrip(t *testing.T) {
+	cost := func(v float64) *float64 { return &v }
+	shares := ModelSpendShares([]ModelUsageRecord{
+		{RawModelID: "claude-3-opus-20240229", CostUSD: cost(30)},
+		{RawModelID: "claude-sonnet-4-5", CostUSD: cost(60)},
+		{RawModelID: "claude-3-opus-20240229", CostUSD: cost(10)},
+		{RawModelID: "free-model", CostUSD: cost(0)},
+	})
+	if len(shares) != 2 || shares["claude-3-opus-20240229"] != 0.4 {
+		t.Fatalf("ModelSpendShares() = %v", shares)
+	}
+
+	var snap UsageSnapshot
+	snap.AddModelRetirement(ModelRetirement{Model: "gpt-4.5-preview", Date: time.Date(2026, 7, 14, 0, 0, 0, 0, time.UTC), SpendShare: 0.1})
+	snap.AddModelRetirement(ModelRetirement{Model: "claude-3-opus-20240229", Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), SpendShare: shares["claude-3-opus-20240229"]})
+	snap.AddModelRetirement(ModelRetirement{Model: "undated"})
+
+	got := snap.ModelRetirements()
+	if len(got) != 2 || got[0].Model != "claude-3-opus-20240229" || got[0].SpendShare != 0.4 || got[1].Model != "gpt-4.5-preview" {
+		t.Fatalf("ModelRetirements() = %+v", got)
+	}
+}
@@ -5,6 +5,9 @@ import "strings"
 const (
 	ModelNormalizationGroupLineage = "lineage"
 	ModelNormalizationGroupRelease = "release"
+	// ModelNormalizationGroupModel groups every version of a model line
+	// together, e.g. all Claude Sonnet releases as anthropic/claude-sonnet.
+	ModelNormalizationGroupModel = "model"
 )
 
 type ModelNormalizationOverride struct {
@@ -17,7 +20,7 @@ type ModelNormalizationOverride struct {
 
 type ModelNormalizationConfig struct {
 	Enabled       bool                         `json:"enabled"`
-	GroupBy       string                       `json:"group_by,omitempty"`       // lineage | release
+	GroupBy       string                       `json:"group_by,omitempty"`       // lineage | release | model
 	MinConfidence float64                      `json:"min_confidence,omitempty"` // 0..1
 	Overrides     []ModelNormalizationOverride `json:"overrides,omitempty"`
 }
@@ -34,7 +37,9 @@ func NormalizeModelNormalizationConfig(cfg ModelNormalizationConfig) ModelNormal
 	if cfg.GroupBy == "" {
 		cfg.GroupBy = ModelNormalizationGroupLineage
 	}
-	if cfg.GroupBy != ModelNormalizationGroupLineage && cfg.GroupBy != ModelNormalizationGroupRelease {
+	switch cfg.GroupBy {
+	case ModelNormalizationGroupLineage, ModelNormalizationGroupRelease, ModelNormalizationGroupModel:
+	default:
 		cfg.GroupBy = ModelNormalizationGroupLineage
 	}
 	if cfg.MinConfidence <= 0 {
@@ -0,0 +1,15 @@
+package core
+
+// Metric keys and units for usage that isn't chat tokens. Embeddings are
+// still tokens, but billed at the embedding model's input rate only, so
+// they are kept apart from chat input. Images and audio are billed per
+// unit, not per token, and carry their own units so they are never summed
+// into token totals.
+const (
+	MetricEmbeddingTokens  = "embedding_tokens"
+	MetricImageGenerations = "image_generations"
+	MetricAudioMinutes     = "audio_minutes"
+
+	UnitImages  = "images"
+	UnitMinutes = "minutes"
+)
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"net"
+	"strings"
+)
+
+// ProxyDirect as a proxy sends requests straight to the provider, ignoring
+// both the proxy environment and any proxy set at a broader level.
+const ProxyDirect = "direct"
+
+// NetworkConfig routes provider HTTP traffic through corporate proxies and
+// TLS-intercepting middleboxes. It is set globally under settings `network`
+// and can be overridden per provider and per account.
+type NetworkConfig struct {
+	// Proxy is the proxy URL (http, https, socks5 or socks5h) for provider
+	// requests, or ProxyDirect to bypass any proxy. Empty falls back to the
+	// HTTPS_PROXY / HTTP_PROXY / NO_PROXY environment.
+	Proxy string `json:"proxy,omitempty"`
+	// CABundle is a PEM file of extra root certificates, trusted on top of
+	// the system pool — typically the corporate proxy's CA.
+	CABundle string `json:"ca_bundle,omitempty"`
+	// InsecureSkipVerify disables TLS certificate verification. It exposes
+	// API keys to anyone on the path and is only meant for diagnosing a
+	// proxy; prefer CABundle.
+	InsecureSkipVerify bool `json:"insecure_skip_verify,omitempty"`
+}
+
+// IsZero reports whether n leaves provider traffic on the defaults.
+func (n NetworkConfig) IsZero() bool {
+	return strings.TrimSpace(n.Proxy) == "" && strings.TrimSpace(n.CABundle) == "" && !n.InsecureSkipVerify
+}
+
+// Merge fills the fields n leaves empty from fallback. Skip-verify is on
+// when either side turns it on.
+func (n NetworkConfig) Merge(fallback NetworkConfig) NetworkConfig {
+	if strings.TrimSpace(n.Proxy) == "" {
+		n.Proxy = fallback.Proxy
+	}
+	if strings.TrimSpace(n.CABundle) == "" {
+		n.CABundle = fallback.CABundle
+	}
+	n.InsecureSkipVerify = n.InsecureSkipVerify || fallback.InsecureSkipVerify
+	return n
+}
+
+// NetworkSettings is the settings `network` block: defaults for every
+// account plus overrides keyed by provider ID, so for example every Moonshot
+// account, auto-detected ones included, can go through its own proxy.
+type NetworkSettings struct {
+	NetworkConfig
+	Providers map[string]NetworkConfig `json:"providers,omitempty"`
+}
+
+// ForProvider is the provider's override merged over the defaults.
+func (s NetworkSettings) ForProvider(providerID string) NetworkConfig {
+	if override, ok := s.Providers[providerID]; ok {
+		return override.Merge(s.NetworkConfig)
+	}
+	return s.NetworkConfig
+}
+
+// EffectiveNetwork is the account's network settings: its own override,
+// then its provider's, then the defaults.
+func (c AccountConfig) EffectiveNetwork(global NetworkSettings) NetworkConfig {
+	base := global.ForProvider(c.Provider)
+	if c.Network == nil {
+		return base
+	}
+	return c.Network.Merge(base)
+}
+
+// ApplyNetwork resolves every account's network settings against global, so
+// fetch paths only need the account. It is a no-op when nothing is
+// configured. Accounts are copied so the caller's config is not mutated.
+func ApplyNetwork(accounts []AccountConfig, global NetworkSettings) []AccountConfig {
+	if len(accounts) == 0 {
+		return accounts
+	}
+	out := make([]AccountConfig, len(accounts))
+	for i, acct := range accounts {
+		if n := acct.EffectiveNetwork(global); !n.IsZero() {
+			acct.Network = &n
+		} else {
+			acct.Network = nil
+		}
+		out[i] = acct
+	}
+	return out
+}
+
+// IsLoopbackAddr reports whether addr binds only to a loopback interface.
+// Accepts ":port" (empty host = all interfaces, NOT loopback), "host:port",
+// and bare "host". Hostnames other than "localhost" are treated conservatively
+// as non-loopback because we can't resolve them deterministically at startup.
+func IsLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		// Could be a bare host or a malformed addr. ":port" form (e.g. ":9190")
+		// trips SplitHostPort because it returns host="" → we treat that as
+		// all-interfaces.
+		if addr == "" || strings.HasPrefix(addr, ":") {
+			return false
+		}
+		host = addr
+	}
+	if host == "" {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		// Unresolvable hostname — be conservative.
+		return false
+	}
+	return ip.IsLoopback()
+}
+
+// IsLocalHostHeader reports whether a request's Host header names a server
+// listening on listenAddr: localhost, a loopback IP, or the listen address's
+// own host. Ports are ignored. Local HTTP listeners check it to turn away DNS
+// rebinding, where a web page resolves its own name to 127.0.0.1.
+func IsLocalHostHeader(hostport, listenAddr string) bool {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return true
+	}
+	listenHost, _, err := net.SplitHostPort(listenAddr)
+	return err == nil && listenHost != "" && strings.EqualFold(host, listenHost)
+}
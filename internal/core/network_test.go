@@ -0,0 +1,71 @@
+package core
+
+import "testing"
+
+func TestApplyNetwork(t *testing.T) {
+	override := &NetworkConfig{Proxy: "http://eu-proxy:3128"}
+	accounts := []AccountConfig{
+		{ID: "a", Provider: "openai"},
+		{ID: "b", Provider: "anthropic", Network: override},
+	}
+	defaults := NetworkConfig{Proxy: "http://proxy:3128", CABundle: "/etc/corp-ca.pem"}
+
+	got := ApplyNetwork(accounts, NetworkSettings{NetworkConfig: defaults})
+	if got[0].Network == nil || *got[0].Network != defaults {
+		t.Errorf("account without override = %+v, want the defaults", got[0].Network)
+	}
+	want := NetworkConfig{Proxy: "http://eu-proxy:3128", CABundle: "/etc/corp-ca.pem"}
+	if got[1].Network == nil || *got[1].Network != want {
+		t.Errorf("account with override = %+v, want %+v", got[1].Network, want)
+	}
+	if accounts[0].Network != nil || *override != (NetworkConfig{Proxy: "http://eu-proxy:3128"}) {
+		t.Error("ApplyNetwork mutated its input")
+	}
+
+	if got := ApplyNetwork(accounts[:1], NetworkSettings{}); got[0].Network != nil {
+		t.Errorf("nothing configured should leave Network nil, got %+v", got[0].Network)
+	}
+}
+
+func TestApplyNetwork_ProviderOverrides(t *testing.T) {
+	settings := NetworkSettings{
+		NetworkConfig: NetworkConfig{Proxy: "http://proxy:3128", CABundle: "/etc/corp-ca.pem"},
+		Providers: map[string]NetworkConfig{
+			"moonshot": {Proxy: "socks5://cn-gateway:1080"},
+			"deepseek": {Proxy: "socks5://cn-gateway:1080"},
+			"ollama":   {Proxy: ProxyDirect},
+		},
+	}
+	accounts := []AccountConfig{
+		{ID: "openai", Provider: "openai"},
+		{ID: "moonshot-cn", Provider: "moonshot"},
+		{ID: "deepseek-hk", Provider: "deepseek", Network: &NetworkConfig{Proxy: "socks5://hk-gateway:1080"}},
+		{ID: "ollama", Provider: "ollama"},
+	}
+
+	got := ApplyNetwork(accounts, settings)
+	wantProxy := map[string]string{
+		"openai":      "http://proxy:3128",
+		"moonshot-cn": "socks5://cn-gateway:1080",
+		"deepseek-hk": "socks5://hk-gateway:1080",
+		"ollama":      ProxyDirect,
+	}
+	for _, acct := range got {
+		if acct.Network == nil || acct.Network.Proxy != wantProxy[acct.ID] {
+			t.Errorf("%s: network = %+v, want proxy %q", acct.ID, acct.Network, wantProxy[acct.ID])
+			continue
+		}
+		if acct.Network.CABundle != "/etc/corp-ca.pem" {
+			t.Errorf("%s: ca_bundle %q not inherited from the defaults", acct.ID, acct.Network.CABundle)
+		}
+	}
+}
+
+func TestNetworkConfig_MergeSkipVerify(t *testing.T) {
+	if !(NetworkConfig{}).Merge(NetworkConfig{InsecureSkipVerify: true}).InsecureSkipVerify {
+		t.Error("global skip-verify not inherited")
+	}
+	if !(NetworkConfig{InsecureSkipVerify: true}).Merge(NetworkConfig{}).InsecureSkipVerify {
+		t.Error("account skip-verify dropped")
+	}
+}
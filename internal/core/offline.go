@@ -0,0 +1,31 @@
+package core
+
+import "time"
+
+// OfflineDiagnostic is set on snapshots the daemon serves while provider
+// endpoints are unreachable. Its value is the RFC 3339 time of the last
+// successful provider request, i.e. how old the data may be.
+const OfflineDiagnostic = "offline_data_as_of"
+
+// MarkOffline flags snap as served offline, with data as of dataAsOf.
+func MarkOffline(snap *UsageSnapshot, dataAsOf time.Time) {
+	if snap == nil {
+		return
+	}
+	value := "unknown"
+	if !dataAsOf.IsZero() {
+		value = dataAsOf.UTC().Format(time.RFC3339)
+	}
+	snap.SetDiagnostic(OfflineDiagnostic, value)
+}
+
+// OfflineDataAsOf reports whether snap was served offline and, when known,
+// the time its data dates from.
+func OfflineDataAsOf(snap UsageSnapshot) (time.Time, bool) {
+	value, ok := snap.Diagnostics[OfflineDiagnostic]
+	if !ok {
+		return time.Time{}, false
+	}
+	at, _ := time.Parse(time.RFC3339, value)
+	return at, true
+}
@@ -0,0 +1,25 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMarkOffline(t *testing.T) {
+	var snap UsageSnapshot
+	if _, ok := OfflineDataAsOf(snap); ok {
+		t.Fatal("unmarked snapshot reported as offline")
+	}
+
+	asOf := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	MarkOffline(&snap, asOf)
+	got, ok := OfflineDataAsOf(snap)
+	if !ok || !got.Equal(asOf) {
+		t.Fatalf("OfflineDataAsOf = %s, %v; want %s, true", got, ok, asOf)
+	}
+
+	MarkOffline(&snap, time.Time{})
+	if got, ok := OfflineDataAsOf(snap); !ok || !got.IsZero() {
+		t.Fatalf("unknown age = %s, %v; want zero time, true", got, ok)
+	}
+}
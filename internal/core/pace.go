@@ -0,0 +1,108 @@
+package core
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// TodayPaceMetricKey is the metric comparing spend so far today with a
+// typical day. Used is the ratio (1 = usual pace, 3 = three times hotter).
+const TodayPaceMetricKey = "today_pace"
+
+const (
+	// paceBaselineDays is how many complete days the typical-day median
+	// covers.
+	paceBaselineDays = 14
+	// paceMinBaselineDays is the least history a pace is computed from.
+	paceMinBaselineDays = 3
+	// paceMinDayFraction floors the prorating so the first minutes after
+	// midnight don't turn a single request into a huge multiple.
+	paceMinDayFraction = 1.0 / 24
+)
+
+// paceSeriesKeys are the daily cost series a pace can be computed from, best
+// first. history_cost is window-independent (UTC days); the others follow
+// the selected time window and may be too short.
+var paceSeriesKeys = []string{"history_cost", "analytics_cost", "cost"}
+
+// ComputeTodayPace compares spend so far today, prorated by the elapsed part
+// of the day, against the median daily spend of the previous 14 days. It
+// reports false when there is too little history or no typical spend to
+// compare against.
+func ComputeTodayPace(s UsageSnapshot, now time.Time) (Metric, bool) {
+	for _, key := range paceSeriesKeys {
+		pts, ok := s.DailySeries[key]
+		if !ok || len(pts) == 0 {
+			continue
+		}
+		clock := now
+		if strings.HasPrefix(key, "history_") {
+			clock = now.UTC()
+		}
+		if ratio, ok := todayPaceRatio(pts, clock); ok {
+			return Metric{Used: Float64Ptr(ratio), Unit: "x", Window: "today"}, true
+		}
+	}
+	return Metric{}, false
+}
+
+func todayPaceRatio(pts []TimePoint, now time.Time) (float64, bool) {
+	byDay := make(map[string]float64, len(pts))
+	first := ""
+	for _, p := range pts {
+		byDay[p.Date] += p.Value
+		if first == "" || p.Date < first {
+			first = p.Date
+		}
+	}
+
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var baseline []float64
+	for i := 1; i <= paceBaselineDays; i++ {
+		day := midnight.AddDate(0, 0, -i).Format("2006-01-02")
+		if day < first {
+			break // before the series starts: unknown, not zero
+		}
+		baseline = append(baseline, byDay[day])
+	}
+	if len(baseline) < paceMinBaselineDays {
+		return 0, false
+	}
+	typical := median(baseline)
+	if typical <= 0 {
+		return 0, false
+	}
+
+	fraction := now.Sub(midnight).Hours() / 24
+	if fraction < paceMinDayFraction {
+		fraction = paceMinDayFraction
+	}
+	today := byDay[midnight.Format("2006-01-02")]
+	return today / (typical * fraction), true
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// ApplyTodayPace stores ComputeTodayPace as the today_pace metric, leaving the
+// snapshot unchanged when no pace can be computed.
+func ApplyTodayPace(s UsageSnapshot, now time.Time) UsageSnapshot {
+	m, ok := ComputeTodayPace(s, now)
+	if !ok {
+		return s
+	}
+	s.Metrics = deepCloneMetrics(s.Metrics)
+	if s.Metrics == nil {
+		s.Metrics = make(map[string]Metric)
+	}
+	s.Metrics[TodayPaceMetricKey] = m
+	return s
+}
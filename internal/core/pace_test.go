@@ -0,0 +1,98 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestComputeTodayPace(t *testing.T) {
+	noon := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	series := func(today float64, history ...float64) []TimePoint {
+		pts := []TimePoint{{Date: "2026-10-14", Value: today}}
+		for i, v := range history {
+			pts = append(pts, TimePoint{Date: noon.AddDate(0, 0, -(i + 1)).Format("2006-01-02"), Value: v})
+		}
+		return pts
+	}
+
+	tests := []struct {
+		name   string
+		series map[string][]TimePoint
+		now    time.Time
+		want   float64
+		ok     bool
+	}{
+		{
+			name:   "three times hotter at noon",
+			series: map[string][]TimePoint{"history_cost": series(3, 2, 2, 2, 2)},
+			now:    noon,
+			want:   3, // $3 by noon vs $1 expected by noon
+			ok:     true,
+		},
+		{
+			name: "median ignores one spike",
+			series: map[string][]TimePoint{"history_cost": series(1,
+				2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 100)},
+			now:  noon,
+			want: 1,
+			ok:   true,
+		},
+		{
+			name:   "idle days inside the series count as zero",
+			series: map[string][]TimePoint{"cost": {{Date: "2026-10-10", Value: 4}, {Date: "2026-10-13", Value: 4}, {Date: "2026-10-14", Value: 2}}},
+			now:    noon,
+			want:   2, // baseline 4,0,0,4 → median 2; $2 by noon vs $1 expected
+			ok:     true,
+		},
+		{
+			name:   "early morning is floored to one hour",
+			series: map[string][]TimePoint{"history_cost": series(1, 24, 24, 24)},
+			now:    time.Date(2026, 10, 14, 0, 5, 0, 0, time.UTC),
+			want:   1,
+			ok:     true,
+		},
+		{
+			name:   "too little history",
+			series: map[string][]TimePoint{"history_cost": series(3, 2, 2)},
+			now:    noon,
+		},
+		{
+			name:   "no typical spend",
+			series: map[string][]TimePoint{"history_cost": series(3, 0, 0, 0, 0)},
+			now:    noon,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ok := ComputeTodayPace(UsageSnapshot{DailySeries: tt.series}, tt.now)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if m.Used == nil || math.Abs(*m.Used-tt.want) > 1e-9 {
+				t.Errorf("pace = %v, want %v", m.Used, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyTodayPace_DoesNotMutateInput(t *testing.T) {
+	noon := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	in := UsageSnapshot{
+		Metrics: map[string]Metric{"today_api_cost": {Used: Float64Ptr(3), Unit: "USD"}},
+		DailySeries: map[string][]TimePoint{"history_cost": {
+			{Date: "2026-10-11", Value: 2}, {Date: "2026-10-12", Value: 2},
+			{Date: "2026-10-13", Value: 2}, {Date: "2026-10-14", Value: 3},
+		}},
+	}
+	out := ApplyTodayPace(in, noon)
+	if _, ok := out.Metrics[TodayPaceMetricKey]; !ok {
+		t.Fatal("today_pace missing from output")
+	}
+	if _, ok := in.Metrics[TodayPaceMetricKey]; ok {
+		t.Error("ApplyTodayPace mutated the input metrics map")
+	}
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"strings"
+	"time"
+)
+
+// PollPriority sets how often the daemon polls an account.
+type PollPriority string
+
+const (
+	// PollPriorityHigh polls on every tick and never backs off.
+	PollPriorityHigh PollPriority = "high"
+	// PollPriorityNormal polls on every tick until adaptive backoff slows
+	// an idle account down. It is the default.
+	PollPriorityNormal PollPriority = "normal"
+	// PollPriorityLow polls at most every polling.low_priority_every ticks.
+	PollPriorityLow PollPriority = "low"
+)
+
+// ParsePollPriority resolves a configured priority. Empty means normal.
+func ParsePollPriority(s string) (PollPriority, bool) {
+	switch p := PollPriority(strings.ToLower(strings.TrimSpace(s))); p {
+	case "":
+		return PollPriorityNormal, true
+	case PollPriorityHigh, PollPriorityNormal, PollPriorityLow:
+		return p, true
+	}
+	return PollPriorityNormal, false
+}
+
+// PollPriority returns the account's priority, normal when unset or invalid.
+func (c AccountConfig) PollPriority() PollPriority {
+	p, _ := ParsePollPriority(c.Priority)
+	return p
+}
+
+// NextPollDiagnostic holds when the daemon will next poll the account, as
+// RFC 3339. The tile footer shows it as "next in 3m".
+const NextPollDiagnostic = "next_poll_at"
+
+// SetNextPollAt records when the daemon will next poll the account.
+func (s *UsageSnapshot) SetNextPollAt(at time.Time) {
+	if at.IsZero() {
+		return
+	}
+	s.SetDiagnostic(NextPollDiagnostic, at.UTC().Format(time.RFC3339))
+}
+
+// NextPollAt returns the next scheduled poll recorded on the snapshot.
+func (s UsageSnapshot) NextPollAt() (time.Time, bool) {
+	value := s.Diagnostics[NextPollDiagnostic]
+	if value == "" {
+		return time.Time{}, false
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return at, true
+}
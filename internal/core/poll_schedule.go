@@ -0,0 +1,210 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PollSchedule varies how often the daemon polls by time of day and day of
+// week: every 30s during working hours, every 10m overnight, not at all on
+// weekends. The first rule matching the current time applies; outside every
+// rule the account polls as usual.
+type PollSchedule struct {
+	// Timezone is an IANA zone name ("Europe/Warsaw"). Empty means the
+	// machine's local time.
+	Timezone string             `json:"timezone,omitempty"`
+	Rules    []PollScheduleRule `json:"rules"`
+}
+
+// PollScheduleRule is one window of a PollSchedule.
+type PollScheduleRule struct {
+	// Days the window starts on: "mon".."sun", "weekdays" or "weekends".
+	// Empty means every day.
+	Days []string `json:"days,omitempty"`
+	// From and To bound the window as "HH:MM". Empty From is midnight,
+	// empty To is the end of the day. A To earlier than From runs past
+	// midnight into the next day.
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+	// IntervalSeconds is the least time between polls inside the window.
+	IntervalSeconds int `json:"interval_seconds,omitempty"`
+	// Pause stops polling inside the window.
+	Pause bool `json:"pause,omitempty"`
+}
+
+const minutesPerDay = 24 * 60
+
+var scheduleDays = map[string][]time.Weekday{
+	"mon":      {time.Monday},
+	"tue":      {time.Tuesday},
+	"wed":      {time.Wednesday},
+	"thu":      {time.Thursday},
+	"fri":      {time.Friday},
+	"sat":      {time.Saturday},
+	"sun":      {time.Sunday},
+	"weekdays": {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	"weekends": {time.Saturday, time.Sunday},
+}
+
+// Validate reports why the schedule can't be used, or nil.
+func (s PollSchedule) Validate() error {
+	if _, err := loadScheduleLocation(s.Timezone); err != nil {
+		return fmt.Errorf("poll schedule timezone %q: %w", s.Timezone, err)
+	}
+	return nil
+}
+
+// Validate reports why the rule can't be used, or nil.
+func (r PollScheduleRule) Validate() error {
+	for _, day := range r.Days {
+		if _, ok := scheduleDays[strings.ToLower(strings.TrimSpace(day))]; !ok {
+			return fmt.Errorf("poll schedule day %q must be mon..sun, weekdays or weekends", day)
+		}
+	}
+	from, err := parseScheduleClock(r.From, 0)
+	if err != nil {
+		return err
+	}
+	to, err := parseScheduleClock(r.To, minutesPerDay)
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return fmt.Errorf("poll schedule window %s-%s is empty", r.From, r.To)
+	}
+	switch {
+	case r.Pause && r.IntervalSeconds != 0:
+		return fmt.Errorf("poll schedule rule: set pause or interval_seconds, not both")
+	case !r.Pause && r.IntervalSeconds <= 0:
+		return fmt.Errorf("poll schedule rule: interval_seconds must be positive, or set pause")
+	}
+	return nil
+}
+
+// Interval is the rule's minimum time between polls.
+func (r PollScheduleRule) Interval() time.Duration {
+	return time.Duration(r.IntervalSeconds) * time.Second
+}
+
+// Match returns the first rule covering t. Invalid rules never match.
+func (s *PollSchedule) Match(t time.Time) (PollScheduleRule, bool) {
+	if s == nil {
+		return PollScheduleRule{}, false
+	}
+	loc, err := loadScheduleLocation(s.Timezone)
+	if err != nil {
+		return PollScheduleRule{}, false
+	}
+	t = t.In(loc)
+	for _, rule := range s.Rules {
+		if rule.covers(t) {
+			return rule, true
+		}
+	}
+	return PollScheduleRule{}, false
+}
+
+// ResumesAt returns the first time at or after t that no pause rule covers,
+// or zero when polling stays paused for the whole week ahead.
+func (s *PollSchedule) ResumesAt(t time.Time) time.Time {
+	if rule, ok := s.Match(t); !ok || !rule.Pause {
+		return t
+	}
+	loc, _ := loadScheduleLocation(s.Timezone)
+	local := t.In(loc)
+	// Rules only change at their From and To marks, so those are the only
+	// moments polling can resume.
+	var next time.Time
+	for offset := 0; offset <= 8; offset++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, loc)
+		for _, rule := range s.Rules {
+			from, _ := parseScheduleClock(rule.From, 0)
+			to, _ := parseScheduleClock(rule.To, minutesPerDay)
+			for _, mark := range []int{from, to} {
+				at := day.Add(time.Duration(mark) * time.Minute)
+				if !at.After(t) || (!next.IsZero() && !at.Before(next)) {
+					continue
+				}
+				if covering, ok := s.Match(at); !ok || !covering.Pause {
+					next = at
+				}
+			}
+		}
+		if !next.IsZero() {
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+func (r PollScheduleRule) covers(t time.Time) bool {
+	from, err := parseScheduleClock(r.From, 0)
+	if err != nil {
+		return false
+	}
+	to, err := parseScheduleClock(r.To, minutesPerDay)
+	if err != nil || from == to {
+		return false
+	}
+	minute := t.Hour()*60 + t.Minute()
+	if from < to {
+		return r.onDay(t.Weekday()) && minute >= from && minute < to
+	}
+	// Overnight: the window belongs to the day it starts on.
+	if minute >= from {
+		return r.onDay(t.Weekday())
+	}
+	return minute < to && r.onDay((t.Weekday()+6)%7)
+}
+
+func (r PollScheduleRule) onDay(day time.Weekday) bool {
+	if len(r.Days) == 0 {
+		return true
+	}
+	for _, name := range r.Days {
+		for _, d := range scheduleDays[strings.ToLower(strings.TrimSpace(name))] {
+			if d == day {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseScheduleClock parses "HH:MM" into minutes after midnight. "24:00" is
+// accepted as the end of the day.
+func parseScheduleClock(value string, fallback int) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fallback, nil
+	}
+	hh, mm, ok := strings.Cut(value, ":")
+	hours, errH := strconv.Atoi(hh)
+	minutes, errM := strconv.Atoi(mm)
+	if !ok || errH != nil || errM != nil || hours < 0 || minutes < 0 || minutes > 59 ||
+		hours > 24 || (hours == 24 && minutes != 0) {
+		return 0, fmt.Errorf("poll schedule time %q must be HH:MM", value)
+	}
+	return hours*60 + minutes, nil
+}
+
+var scheduleLocations sync.Map // zone name -> *time.Location
+
+func loadScheduleLocation(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+	if loc, ok := scheduleLocations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	scheduleLocations.Store(name, loc)
+	return loc, nil
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPollScheduleMatch(t *testing.T) {
+	schedule := &PollSchedule{Timezone: "UTC", Rules: []PollScheduleRule{
+		{Days: []string{"weekends"}, Pause: true},
+		{Days: []string{"weekdays"}, From: "09:00", To: "18:00", IntervalSeconds: 30},
+		{Days: []string{"mon", "tue", "wed", "thu", "fri"}, From: "18:00", To: "09:00", IntervalSeconds: 600},
+	}}
+	// 2026-10-16 is a Friday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		at        time.Time
+		wantOK    bool
+		wantPause bool
+		wantEvery time.Duration
+	}{
+		{"working hours", at(16, 12, 0), true, false, 30 * time.Second},
+		{"evening", at(16, 18, 0), true, false, 10 * time.Minute},
+		{"saturday", at(17, 3, 0), true, true, 0},
+		{"early monday", at(19, 7, 59), false, false, 0},
+		{"tuesday before work", at(20, 8, 30), true, false, 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		rule, ok := schedule.Match(tt.at)
+		if ok != tt.wantOK || rule.Pause != tt.wantPause || rule.Interval() != tt.wantEvery {
+			t.Errorf("%s: Match = %+v, %v", tt.name, rule, ok)
+		}
+	}
+
+	// Friday night's window runs into Saturday, but the weekend pause is
+	// listed first.
+	if rule, _ := schedule.Match(at(17, 2, 0)); !rule.Pause {
+		t.Errorf("early saturday = %+v, want the first matching rule, the pause", rule)
+	}
+	if got, want := schedule.ResumesAt(at(17, 12, 0)), at(19, 0, 0); !got.Equal(want) {
+		t.Errorf("ResumesAt saturday = %s, want %s", got, want)
+	}
+	if got := schedule.ResumesAt(at(16, 12, 0)); !got.Equal(at(16, 12, 0)) {
+		t.Errorf("ResumesAt while not paused = %s, want now", got)
+	}
+	if got := (&PollSchedule{Rules: []PollScheduleRule{{Pause: true}}}).ResumesAt(at(16, 12, 0)); !got.IsZero() {
+		t.Errorf("ResumesAt for a schedule that never resumes = %s, want zero", got)
+	}
+}
+
+func TestPollScheduleValidate(t *testing.T) {
+	invalid := []PollScheduleRule{
+		{Days: []string{"someday"}, IntervalSeconds: 60},
+		{From: "9am", IntervalSeconds: 60},
+		{From: "24:30", IntervalSeconds: 60},
+		{From: "08:00", To: "08:00", IntervalSeconds: 60},
+		{IntervalSeconds: 60, Pause: true},
+		{},
+	}
+	for _, rule := range invalid {
+		if rule.Validate() == nil {
+			t.Errorf("Validate(%+v) = nil, want error", rule)
+		}
+	}
+	if err := (PollScheduleRule{Days: []string{"Sat"}, From: "22:00", To: "24:00", Pause: true}).Validate(); err != nil {
+		t.Errorf("Validate(valid rule) = %v", err)
+	}
+	if (PollSchedule{Timezone: "Mars/Olympus"}).Validate() == nil {
+		t.Error("Validate(unknown timezone) = nil, want error")
+	}
+}
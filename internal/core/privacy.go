@@ -0,0 +1,127 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// RedactedMark replaces the hidden part of a masked value.
+const RedactedMark = "•••"
+
+// sensitiveMetaKeys are Attributes/Raw/Diagnostics keys whose values identify
+// the person, organization, or credential behind an account.
+var sensitiveMetaKeys = map[string]bool{
+	"access_key_suffix": true,
+	"account_email":     true,
+	"account_name":      true,
+	"api_key_name":      true,
+	"api_org_id":        true,
+	"copilot_orgs":      true,
+	"device_id":         true,
+	"github_login":      true,
+	"github_name":       true,
+	"installation_id":   true,
+	"key_hash_prefix":   true,
+	"key_label":         true,
+	"key_name":          true,
+	"org_display_name":  true,
+	"org_id":            true,
+	"organization_name": true,
+	"organization_uuid": true,
+	"project_id":        true,
+	"team_id":           true,
+	"team_name":         true,
+	"tier_user_project": true,
+	"user_id":           true,
+	"user_name":         true,
+	"username":          true,
+	"workos_user_id":    true,
+	"workspace_id":      true,
+	"workspace_name":    true,
+}
+
+var (
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+	dollarPattern = regexp.MustCompile(`-?\$\s?-?[0-9][0-9.,]*[KMBkmb]?`)
+)
+
+// IsSensitiveMetaKey reports whether the metadata value stored under key
+// identifies a person, organization, or key and should be masked in privacy
+// mode.
+func IsSensitiveMetaKey(key string) bool {
+	k := strings.ToLower(strings.TrimSpace(key))
+	if sensitiveMetaKeys[k] {
+		return true
+	}
+	return strings.Contains(k, "email") ||
+		strings.HasSuffix(k, "_login") ||
+		strings.HasSuffix(k, "key_label") ||
+		strings.HasSuffix(k, "key_name")
+}
+
+// MaskSensitiveValue keeps just enough of v to tell values apart: an email
+// becomes "j•••@e•••.com", anything else its first character and RedactedMark.
+func MaskSensitiveValue(v string) string {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return ""
+	}
+	if local, domain, ok := strings.Cut(v, "@"); ok && local != "" && domain != "" {
+		tld := ""
+		if i := strings.LastIndex(domain, "."); i > 0 {
+			tld = domain[i:]
+			domain = domain[:i]
+		}
+		return maskHead(local) + "@" + maskHead(domain) + tld
+	}
+	return maskHead(v)
+}
+
+func maskHead(s string) string {
+	r, _ := utf8.DecodeRuneInString(s)
+	return string(r) + RedactedMark
+}
+
+// RedactText masks email addresses and dollar amounts inside free text such
+// as status messages and raw provider notes.
+func RedactText(s string) string {
+	if s == "" {
+		return s
+	}
+	if strings.Contains(s, "@") {
+		s = emailPattern.ReplaceAllStringFunc(s, MaskSensitiveValue)
+	}
+	if strings.Contains(s, "$") {
+		s = dollarPattern.ReplaceAllString(s, "$$"+RedactedMark)
+	}
+	return s
+}
+
+// RedactSnapshot returns a copy of s safe to show while screen sharing:
+// sensitive metadata values are masked and emails and dollar amounts are
+// scrubbed from the remaining metadata and the message. Metrics are left
+// alone; callers hide currency amounts when formatting them. The input's maps
+// are not modified.
+func RedactSnapshot(s UsageSnapshot) UsageSnapshot {
+	s.Attributes = redactMeta(s.Attributes)
+	s.Diagnostics = redactMeta(s.Diagnostics)
+	s.Raw = redactMeta(s.Raw)
+	s.Message = RedactText(s.Message)
+	return s
+}
+
+func redactMeta(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		if IsSensitiveMetaKey(k) {
+			out[k] = MaskSensitiveValue(v)
+			continue
+		}
+		out[k] = RedactText(v)
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+package core
+
+import "testing"
+
+func TestMaskSensitiveValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"jane@example.com", "j•••@e•••.com"},
+		{"jane@mail.example.co", "j•••@m•••.co"},
+		{"Acme Corp", "A•••"},
+		{"łukasz", "ł•••"},
+	}
+	for _, tt := range tests {
+		if got := MaskSensitiveValue(tt.in); got != tt.want {
+			t.Errorf("MaskSensitiveValue(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIsSensitiveMetaKey(t *testing.T) {
+	for _, key := range []string{"account_email", "billing_email", "github_login", "org_display_name", "key_label", "api_key_name"} {
+		if !IsSensitiveMetaKey(key) {
+			t.Errorf("IsSensitiveMetaKey(%q) = false, want true", key)
+		}
+	}
+	for _, key := range []string{"plan_name", "tier", "key_type", "cli_version"} {
+		if IsSensitiveMetaKey(key) {
+			t.Errorf("IsSensitiveMetaKey(%q) = true, want false", key)
+		}
+	}
+}
+
+func TestRedactText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"signed in as jane@example.com", "signed in as j•••@e•••.com"},
+		{"$12.34 / $1,000 spent", "$••• / $••• spent"},
+		{"balance -$3.5K left", "balance $••• left"},
+		{"82% used", "82% used"},
+	}
+	for _, tt := range tests {
+		if got := RedactText(tt.in); got != tt.want {
+			t.Errorf("RedactText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRedactSnapshotLeavesInputUntouched(t *testing.T) {
+	s := NewUsageSnapshot("openai", "work")
+	s.Attributes["account_email"] = "jane@example.com"
+	s.Attributes["plan_name"] = "Pro"
+	s.Raw["org_display_name"] = "Acme"
+	s.Raw["note"] = "spent $40 this week"
+	s.Message = "jane@example.com: $5.00 left"
+
+	got := RedactSnapshot(s)
+
+	if got.Attributes["account_email"] != "j•••@e•••.com" {
+		t.Errorf("account_email = %q", got.Attributes["account_email"])
+	}
+	if got.Attributes["plan_name"] != "Pro" {
+		t.Errorf("plan_name = %q, want unchanged", got.Attributes["plan_name"])
+	}
+	if got.Raw["org_display_name"] != "A•••" {
+		t.Errorf("org_display_name = %q", got.Raw["org_display_name"])
+	}
+	if got.Raw["note"] != "spent $••• this week" {
+		t.Errorf("note = %q", got.Raw["note"])
+	}
+	if got.Message != "j•••@e•••.com: $••• left" {
+		t.Errorf("Message = %q", got.Message)
+	}
+	if s.Attributes["account_email"] != "jane@example.com" || s.Raw["note"] != "spent $40 this week" {
+		t.Error("RedactSnapshot modified the input snapshot")
+	}
+}
@@ -14,6 +14,11 @@ type AccountConfig struct {
 	APIKeyEnv  string `json:"api_key_env,omitempty"` // env var name holding the API key
 	ProbeModel string `json:"probe_model,omitempty"` // model to use for probe requests
 
+	// Plan names the subscription tier for providers that budget against a
+	// plan's caps rather than dollars (claude_code: "pro", "max5x",
+	// "max20x"). Empty lets the provider detect it.
+	Plan string `json:"plan,omitempty"`
+
 	// BrowserCookie identifies the (domain, cookie_name, source_browser)
 	// triple used for browser-session-auth providers. Persisted alongside
 	// the account config. The actual cookie value is never stored here —
@@ -40,6 +45,29 @@ type AccountConfig struct {
 	// should use ProviderPaths through Path/SetPath helpers.
 	Paths map[string]string `json:"paths,omitempty"`
 
+	// DataPath relocates a local provider's data root for non-standard
+	// installs (a portable Cursor, a custom CLAUDE_CONFIG_DIR). It accepts
+	// ~, environment variables and globs; see ResolveLocalData.
+	DataPath string `json:"data_path,omitempty"`
+
+	// ProviderOptions holds provider-specific settings (OpenRouter's
+	// include_byok, OpenAI's organization, ...) as typed JSON values. Each
+	// provider declares the keys it accepts in ProviderSpec.Options, and
+	// accounts whose options don't match are not fetched.
+	ProviderOptions map[string]any `json:"provider_options,omitempty"`
+
+	// Network overrides the global `network` settings (proxy, CA bundle,
+	// skip-verify) for this account's requests.
+	Network *NetworkConfig `json:"network,omitempty"`
+
+	// Priority is the account's polling tier: "high", "normal" (the
+	// default) or "low". See PollPriority.
+	Priority string `json:"priority,omitempty"`
+
+	// PollSchedule replaces the global polling.schedule for this account.
+	// An empty rule list opts the account out of it.
+	PollSchedule *PollSchedule `json:"poll_schedule,omitempty"`
+
 	Token        string            `json:"-"` // runtime-only: access token (never persisted)
 	RuntimeHints map[string]string `json:"-"` // runtime-only: detection metadata + local hints (never persisted)
 }
@@ -167,3 +195,20 @@ type UsageProvider interface {
 type ChangeDetector interface {
 	HasChanged(acct AccountConfig, since time.Time) (bool, error)
 }
+
+// SpendLimitSetter is an optional interface for providers whose API can
+// change the account's spending cap. SetSpendLimit sets the cap to limitUSD
+// dollars, or removes it when limitUSD is nil. Callers check read-only mode
+// before calling; providers that declare a ProviderActionSetSpendLimit
+// action must implement it.
+type SpendLimitSetter interface {
+	SetSpendLimit(ctx context.Context, acct AccountConfig, limitUSD *float64) error
+}
+
+// SpendLimitTargeter is an optional companion to SpendLimitSetter for
+// providers whose cap belongs to one of several objects, such as one API
+// key among an account's keys. SpendLimitTarget names the object
+// SetSpendLimit would change, so the user can check it before confirming.
+type SpendLimitTargeter interface {
+	SpendLimitTarget(ctx context.Context, acct AccountConfig) (string, error)
+}
@@ -0,0 +1,179 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ProviderOptionType is the JSON type a provider option takes.
+type ProviderOptionType string
+
+const (
+	ProviderOptionString ProviderOptionType = "string"
+	ProviderOptionBool   ProviderOptionType = "bool"
+	ProviderOptionInt    ProviderOptionType = "int"
+)
+
+// ProviderOption describes one key a provider accepts in an account's
+// provider_options table.
+type ProviderOption struct {
+	Key         string
+	Type        ProviderOptionType
+	Description string
+	// Default documents the value the provider uses when the option is
+	// unset; the provider applies it itself.
+	Default string
+	// Values restricts a string option to a fixed set.
+	Values []string
+}
+
+// Option returns the raw value of a provider option.
+func (c AccountConfig) Option(key string) (any, bool) {
+	v, ok := c.ProviderOptions[key]
+	return v, ok && v != nil
+}
+
+// OptionString returns a string provider option, or fallback when it is
+// unset or blank.
+func (c AccountConfig) OptionString(key, fallback string) string {
+	v, ok := c.Option(key)
+	if !ok {
+		return fallback
+	}
+	var s string
+	switch t := v.(type) {
+	case string:
+		s = t
+	case float64:
+		s = strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		s = strconv.FormatBool(t)
+	}
+	if s = strings.TrimSpace(s); s == "" {
+		return fallback
+	}
+	return s
+}
+
+// OptionBool returns a boolean provider option, or fallback when it is
+// unset or not a boolean.
+func (c AccountConfig) OptionBool(key string, fallback bool) bool {
+	if b, ok := optionBool(c.ProviderOptions[key]); ok {
+		return b
+	}
+	return fallback
+}
+
+// OptionInt returns an integer provider option, or fallback when it is
+// unset or not a whole number.
+func (c AccountConfig) OptionInt(key string, fallback int) int {
+	if n, ok := optionInt(c.ProviderOptions[key]); ok {
+		return n
+	}
+	return fallback
+}
+
+// ValidateProviderOptions checks an account's provider_options against the
+// options its provider declares: every key must be declared, and every value
+// must have the declared type and, for enumerated strings, an allowed value.
+func ValidateProviderOptions(providerID string, declared []ProviderOption, opts map[string]any) error {
+	if len(opts) == 0 {
+		return nil
+	}
+	byKey := make(map[string]ProviderOption, len(declared))
+	for _, opt := range declared {
+		byKey[opt.Key] = opt
+	}
+	keys := make([]string, 0, len(opts))
+	for key := range opts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	for _, key := range keys {
+		opt, ok := byKey[key]
+		if !ok {
+			if len(declared) == 0 {
+				problems = append(problems, fmt.Sprintf("provider_options.%s: %s takes no provider options", key, providerID))
+			} else {
+				problems = append(problems, fmt.Sprintf("provider_options.%s: unknown option for %s (known: %s)", key, providerID, strings.Join(providerOptionKeys(declared), ", ")))
+			}
+			continue
+		}
+		if err := opt.check(opts[key]); err != nil {
+			problems = append(problems, fmt.Sprintf("provider_options.%s: %v", key, err))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	// One line, so the error reads well as a tile message.
+	return errors.New(strings.Join(problems, "; "))
+}
+
+func (o ProviderOption) check(v any) error {
+	switch o.Type {
+	case ProviderOptionBool:
+		if _, ok := optionBool(v); !ok {
+			return fmt.Errorf("want true or false, got %s", describeOptionValue(v))
+		}
+	case ProviderOptionInt:
+		if _, ok := optionInt(v); !ok {
+			return fmt.Errorf("want a whole number, got %s", describeOptionValue(v))
+		}
+	default:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("want a string, got %s", describeOptionValue(v))
+		}
+		if len(o.Values) > 0 && !slices.Contains(o.Values, s) {
+			return fmt.Errorf("want one of %s, got %q", strings.Join(o.Values, ", "), s)
+		}
+	}
+	return nil
+}
+
+func optionBool(v any) (bool, bool) {
+	b, ok := v.(bool)
+	return b, ok
+}
+
+func optionInt(v any) (int, bool) {
+	switch t := v.(type) {
+	case float64:
+		if t == math.Trunc(t) && math.Abs(t) <= math.MaxInt32 {
+			return int(t), true
+		}
+	case int:
+		return t, true
+	}
+	return 0, false
+}
+
+func describeOptionValue(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strconv.Quote(t)
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func providerOptionKeys(opts []ProviderOption) []string {
+	keys := make([]string, len(opts))
+	for i, opt := range opts {
+		keys[i] = opt.Key
+	}
+	return keys
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAccountConfigOptionAccessors(t *testing.T) {
+	var acct AccountConfig
+	if err := json.Unmarshal([]byte(`{"id":"a","provider":"p","provider_options":{"org":" acme ","include_byok":false,"limit":25,"blank":""}}`), &acct); err != nil {
+		t.Fatal(err)
+	}
+	if got := acct.OptionString("org", "x"); got != "acme" {
+		t.Errorf("OptionString(org) = %q, want acme", got)
+	}
+	if got := acct.OptionString("blank", "fallback"); got != "fallback" {
+		t.Errorf("OptionString(blank) = %q, want fallback", got)
+	}
+	if acct.OptionBool("include_byok", true) {
+		t.Error("OptionBool(include_byok) = true, want false")
+	}
+	if !acct.OptionBool("missing", true) {
+		t.Error("OptionBool(missing) should use the fallback")
+	}
+	if got := acct.OptionInt("limit", 0); got != 25 {
+		t.Errorf("OptionInt(limit) = %d, want 25", got)
+	}
+}
+
+func TestValidateProviderOptions(t *testing.T) {
+	declared := []ProviderOption{
+		{Key: "include_byok", Type: ProviderOptionBool},
+		{Key: "limit", Type: ProviderOptionInt},
+		{Key: "region", Type: ProviderOptionString, Values: []string{"us", "eu"}},
+	}
+	valid := map[string]any{"include_byok": false, "limit": float64(3), "region": "eu"}
+	if err := ValidateProviderOptions("p", declared, valid); err != nil {
+		t.Fatalf("valid options rejected: %v", err)
+	}
+
+	err := ValidateProviderOptions("p", declared, map[string]any{
+		"include_byok": "false",
+		"limit":        2.5,
+		"region":       "apac",
+		"org":          "acme",
+	})
+	if err == nil {
+		t.Fatal("expected invalid options to be rejected")
+	}
+	for _, want := range []string{
+		`provider_options.include_byok: want true or false, got "false"`,
+		"provider_options.limit: want a whole number, got 2.5",
+		`provider_options.region: want one of us, eu, got "apac"`,
+		"provider_options.org: unknown option for p (known: include_byok, limit, region)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "\n") {
+		t.Errorf("error should be one line, got %q", err)
+	}
+
+	if err := ValidateProviderOptions("q", nil, map[string]any{"org": "acme"}); err == nil || !strings.Contains(err.Error(), "q takes no provider options") {
+		t.Errorf("options on a provider without any: %v", err)
+	}
+}
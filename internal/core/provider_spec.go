@@ -112,6 +112,141 @@ type ProviderSpec struct {
 	// must know whether a value is a monotonic counter, a draining balance, or a
 	// fixed cap. Leave empty for providers that expose no money metrics.
 	CreditMetrics map[string]BalanceSemantics
+
+	// Actions are the quick actions offered from the provider's tile (the
+	// "a" menu): billing and status pages, the API base URL, local data
+	// files. A refresh action is always offered and needn't be declared.
+	Actions []ProviderAction
+
+	// Reference is documented tier and limit data shown in the detail
+	// view's reference pane. Leave zero for providers without published
+	// tiers.
+	Reference ProviderReference
+
+	// Options declares the keys accounts of this provider may set in
+	// provider_options. Leave empty for providers without options.
+	Options []ProviderOption
+
+	// LocalData lays out the provider's files under its data root so an
+	// account's data_path can relocate them. Leave zero for providers that
+	// read no local files.
+	LocalData LocalDataLayout
+}
+
+// ProviderReference is static, documented data about a provider's usage
+// tiers and limit windows, so a raw limit such as "rpd 14400" can be read
+// against the tier it belongs to and what an upgrade would change.
+type ProviderReference struct {
+	// Tiers lists the provider's usage tiers from lowest to highest.
+	Tiers []ProviderTier
+	// TierKey names a snapshot metadata entry (see MetaValue) in which the
+	// provider reports the account's tier outright. It is matched against
+	// ProviderTier.Match and wins over matching by limits.
+	TierKey string
+	// LimitsNote says what the tier limits are quoted for, since most
+	// providers document them per model (e.g. "for gpt-4.1-mini").
+	LimitsNote string
+	// Windows explains how the provider's limit windows roll and reset.
+	Windows []string
+	// Links point at the provider's limit and pricing documentation.
+	Links []ProviderLink
+}
+
+// ProviderTier is one documented usage tier.
+type ProviderTier struct {
+	Name string
+	// Match is the TierKey value the provider reports for this tier.
+	Match string
+	// Qualification is what it takes to reach the tier.
+	Qualification string
+	// Limits holds the documented limit per metric key ("rpm", "tpm",
+	// "rpd", ...). Tiers without published numbers leave it nil and are
+	// never matched.
+	Limits map[string]float64
+}
+
+// ProviderLink is a labelled documentation URL.
+type ProviderLink struct {
+	Label string
+	URL   string
+}
+
+// IsZero reports whether the provider declares no reference data.
+func (r ProviderReference) IsZero() bool {
+	return len(r.Tiers) == 0 && len(r.Windows) == 0 && len(r.Links) == 0
+}
+
+// MatchTier returns the index of the account's tier: the one named by the
+// TierKey value when the provider reports it, otherwise the one whose
+// documented limits agree with the limits snap reports. A tier matches when every limit it documents that
+// the snapshot also reports is equal, and at least one is compared. The tier
+// agreeing on the most limits wins; ties go to the lower tier, since an
+// upgrade the user already has is the cheaper mistake to show.
+func (r ProviderReference) MatchTier(snap UsageSnapshot) (int, bool) {
+	if v, ok := snap.MetaValue(r.TierKey); ok {
+		for i, tier := range r.Tiers {
+			if tier.Match != "" && strings.EqualFold(tier.Match, strings.TrimSpace(v)) {
+				return i, true
+			}
+		}
+	}
+	best, bestCompared := -1, 0
+	for i, tier := range r.Tiers {
+		compared := 0
+		for key, want := range tier.Limits {
+			m, ok := snap.Metrics[key]
+			if !ok || m.Limit == nil {
+				continue
+			}
+			if *m.Limit != want {
+				compared = -1
+				break
+			}
+			compared++
+		}
+		if compared > bestCompared {
+			best, bestCompared = i, compared
+		}
+	}
+	return best, best >= 0
+}
+
+// ProviderActionKind says what a tile quick action does with its target.
+type ProviderActionKind string
+
+const (
+	ProviderActionOpenURL  ProviderActionKind = "open_url"  // open the target in the default browser
+	ProviderActionCopy     ProviderActionKind = "copy"      // copy the target to the clipboard
+	ProviderActionOpenPath ProviderActionKind = "open_path" // open a local file or directory
+	ProviderActionRefresh  ProviderActionKind = "refresh"   // re-fetch usage now
+	// ProviderActionSetSpendLimit raises or lowers the account's spending
+	// cap through the provider API (see SpendLimitSetter). Target names the
+	// metric whose Limit is the current cap.
+	ProviderActionSetSpendLimit ProviderActionKind = "set_spend_limit"
+)
+
+// ProviderAction is one entry of a tile's quick-action menu.
+type ProviderAction struct {
+	Label string
+	Kind  ProviderActionKind
+	// Target is the fixed URL, path or text the action uses.
+	Target string
+	// SnapshotKey names a snapshot metadata entry (see MetaValue) that
+	// supplies the target at poll time: a configured base URL, a discovered
+	// data file. When the snapshot has it, it wins over Target.
+	SnapshotKey string
+}
+
+// ResolveTarget returns the action's target for snap: the SnapshotKey value
+// when the snapshot carries one, otherwise Target. Empty means the action
+// has nothing to act on for this account and should be hidden.
+func (a ProviderAction) ResolveTarget(snap UsageSnapshot) string {
+	if a.SnapshotKey != "" {
+		if v, ok := snap.MetaValue(a.SnapshotKey); ok {
+			return strings.TrimSpace(v)
+		}
+	}
+	return strings.TrimSpace(a.Target)
 }
 
 // BalanceSemantics classifies how a money metric's value moves over time, which
@@ -128,3 +128,68 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestProviderAction_ResolveTarget(t *testing.T) {
+	snap := UsageSnapshot{
+		Attributes: map[string]string{"api_base_url": "https://proxy.example/v1"},
+		Raw:        map[string]string{"db_path": " /home/me/.local/share/app.db "},
+	}
+	tests := []struct {
+		name   string
+		action ProviderAction
+		want   string
+	}{
+		{name: "fixed target", action: ProviderAction{Target: "https://status.example.com"}, want: "https://status.example.com"},
+		{name: "snapshot key wins", action: ProviderAction{Target: "https://api.example.com", SnapshotKey: "api_base_url"}, want: "https://proxy.example/v1"},
+		{name: "raw key trimmed", action: ProviderAction{SnapshotKey: "db_path"}, want: "/home/me/.local/share/app.db"},
+		{name: "missing key falls back", action: ProviderAction{Target: "https://api.example.com", SnapshotKey: "base"}, want: "https://api.example.com"},
+		{name: "missing key no fallback", action: ProviderAction{SnapshotKey: "ledger_path"}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.action.ResolveTarget(snap); got != tt.want {
+				t.Errorf("ResolveTarget() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderReference_MatchTier(t *testing.T) {
+	ref := ProviderReference{Tiers: []ProviderTier{
+		{Name: "Tier 1", Limits: map[string]float64{"rpm": 500, "tpm": 200000}},
+		{Name: "Tier 2", Limits: map[string]float64{"rpm": 5000, "tpm": 2000000}},
+		{Name: "Tier 3", Limits: map[string]float64{"rpm": 5000, "tpm": 4000000}},
+		{Name: "Scale", Match: "scale"},
+	}, TierKey: "tier"}
+	limits := func(kv map[string]float64) UsageSnapshot {
+		snap := UsageSnapshot{Metrics: map[string]Metric{}}
+		for k, v := range kv {
+			snap.Metrics[k] = Metric{Limit: Float64Ptr(v)}
+		}
+		return snap
+	}
+	tests := []struct {
+		name   string
+		snap   UsageSnapshot
+		want   int
+		wantOK bool
+	}{
+		{name: "both limits", snap: limits(map[string]float64{"rpm": 5000, "tpm": 4000000}), want: 2, wantOK: true},
+		{name: "ambiguous goes low", snap: limits(map[string]float64{"rpm": 5000}), want: 1, wantOK: true},
+		{name: "single limit", snap: limits(map[string]float64{"tpm": 200000}), want: 0, wantOK: true},
+		{name: "conflicting limit", snap: limits(map[string]float64{"rpm": 500, "tpm": 2000000}), want: -1},
+		{name: "no limits", snap: UsageSnapshot{}, want: -1},
+		{name: "reported tier wins", snap: UsageSnapshot{
+			Metrics: map[string]Metric{"rpm": {Limit: Float64Ptr(500)}},
+			Raw:     map[string]string{"tier": "SCALE"},
+		}, want: 3, wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ref.MatchTier(tt.snap)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("MatchTier() = %d, %v; want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
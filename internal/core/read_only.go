@@ -0,0 +1,38 @@
+package core
+
+// ReadOnlyHint is the RuntimeHints key set on every account when read-only
+// mode is active (settings `read_only`, `--read-only`, or
+// OPENUSAGE_READ_ONLY). Providers must check AccountConfig.ReadOnly() before
+// issuing any request that costs money or mutates remote state (token
+// refreshes, billable probe completions, etc.).
+const ReadOnlyHint = "read_only"
+
+// ReadOnlySkipped is the Raw value providers record in place of a probe that
+// was suppressed by read-only mode, so the detail view shows why a value is
+// missing instead of silently dropping it.
+const ReadOnlySkipped = "skipped (read-only)"
+
+// ReadOnly reports whether the account is being fetched in read-only mode.
+func (c AccountConfig) ReadOnly() bool {
+	return c.Hint(ReadOnlyHint, "") == "true"
+}
+
+// ApplyReadOnly marks every account with the read-only runtime hint. It is a
+// no-op when readOnly is false. Accounts are copied so shared RuntimeHints
+// maps from the caller's config are not mutated.
+func ApplyReadOnly(accounts []AccountConfig, readOnly bool) []AccountConfig {
+	if !readOnly || len(accounts) == 0 {
+		return accounts
+	}
+	out := make([]AccountConfig, len(accounts))
+	for i, acct := range accounts {
+		hints := make(map[string]string, len(acct.RuntimeHints)+1)
+		for k, v := range acct.RuntimeHints {
+			hints[k] = v
+		}
+		acct.RuntimeHints = hints
+		acct.SetHint(ReadOnlyHint, "true")
+		out[i] = acct
+	}
+	return out
+}
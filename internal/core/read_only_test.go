@@ -0,0 +1,31 @@
+package core
+
+import "testing"
+
+func TestApplyReadOnly(t *testing.T) {
+	shared := map[string]string{"config_dir": "/tmp/x"}
+	accounts := []AccountConfig{
+		{ID: "a", Provider: "openai"},
+		{ID: "b", Provider: "gemini_cli", RuntimeHints: shared},
+	}
+
+	if got := ApplyReadOnly(accounts, false); got[0].ReadOnly() || got[1].ReadOnly() {
+		t.Fatal("ApplyReadOnly(false) marked accounts read-only")
+	}
+
+	got := ApplyReadOnly(accounts, true)
+	for _, acct := range got {
+		if !acct.ReadOnly() {
+			t.Errorf("account %s not marked read-only", acct.ID)
+		}
+	}
+	if got[1].Hint("config_dir", "") != "/tmp/x" {
+		t.Errorf("existing hints lost: %v", got[1].RuntimeHints)
+	}
+	if _, ok := shared[ReadOnlyHint]; ok {
+		t.Error("ApplyReadOnly mutated the caller's RuntimeHints map")
+	}
+	if accounts[0].ReadOnly() {
+		t.Error("ApplyReadOnly mutated the input slice")
+	}
+}
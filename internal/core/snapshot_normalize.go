@@ -87,8 +87,11 @@ func normalizeModelUsageRecords(s UsageSnapshot, cfg ModelNormalizationConfig) [
 		rec.Confidence = identity.Confidence
 		rec.Reason = identity.Reason
 		groupID := rec.CanonicalLineageID
-		if cfg.GroupBy == ModelNormalizationGroupRelease && rec.CanonicalReleaseID != "" {
+		switch {
+		case cfg.GroupBy == ModelNormalizationGroupRelease && rec.CanonicalReleaseID != "":
 			groupID = rec.CanonicalReleaseID
+		case cfg.GroupBy == ModelNormalizationGroupModel && rec.Canonical != "":
+			groupID = rec.Canonical
 		}
 		if groupID != "" && rec.Confidence >= cfg.MinConfidence {
 			rec.SetDimension("canonical_group_id", groupID)
@@ -0,0 +1,215 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AnomalyKind classifies a value ValidateSnapshot considers implausible.
+type AnomalyKind string
+
+const (
+	AnomalyUsedExceedsLimit  AnomalyKind = "used_exceeds_limit"
+	AnomalyNegativeRemaining AnomalyKind = "negative_remaining"
+	AnomalyStaleReset        AnomalyKind = "stale_reset"
+	AnomalySuddenJump        AnomalyKind = "sudden_jump"
+)
+
+// AnomalyDiagnosticPrefix prefixes the Diagnostics key ApplySnapshotValidation
+// records for each flagged metric or reset: "anomaly_<key>".
+const AnomalyDiagnosticPrefix = "anomaly_"
+
+const (
+	// anomalyOverLimitSlack tolerates small overages (rounding, requests
+	// in flight when the limit was read) before Used > Limit is suspicious.
+	anomalyOverLimitSlack = 1.1
+	// anomalyJumpFactor is how many times larger Used may grow between two
+	// polls before the jump is flagged.
+	anomalyJumpFactor = 100
+	// anomalyJumpFloor ignores jumps from tiny starting values, where a
+	// 100x increase is ordinary (a fresh day going from 0.01 to 5).
+	anomalyJumpFloor = 1
+	// anomalyResetFallbackWindow bounds a stale reset whose metric window
+	// is unknown.
+	anomalyResetFallbackWindow = 24 * time.Hour
+)
+
+// SnapshotAnomaly is one implausible value in a snapshot. Key names the
+// metric (or reset) it was found on.
+type SnapshotAnomaly struct {
+	Key    string
+	Kind   AnomalyKind
+	Detail string
+}
+
+// ValidateSnapshot sanity-checks s and returns what looks like a provider or
+// parser bug rather than real usage: Used well above Limit, negative
+// Remaining, resets further in the past than their window, and Used jumping
+// more than 100x since prev (the previous snapshot of the same account; nil
+// skips that check). Results are sorted by key.
+func ValidateSnapshot(s UsageSnapshot, prev *UsageSnapshot) []SnapshotAnomaly {
+	var out []SnapshotAnomaly
+	for key, met := range s.Metrics {
+		if met.Used != nil && met.Limit != nil && *met.Limit > 0 && *met.Used > *met.Limit*anomalyOverLimitSlack {
+			out = append(out, SnapshotAnomaly{
+				Key:    key,
+				Kind:   AnomalyUsedExceedsLimit,
+				Detail: fmt.Sprintf("used %s exceeds limit %s", formatAnomalyValue(*met.Used), formatAnomalyValue(*met.Limit)),
+			})
+		}
+		if met.Remaining != nil && *met.Remaining < 0 {
+			out = append(out, SnapshotAnomaly{
+				Key:    key,
+				Kind:   AnomalyNegativeRemaining,
+				Detail: fmt.Sprintf("remaining %s is negative", formatAnomalyValue(*met.Remaining)),
+			})
+		}
+		if prev == nil || met.Used == nil {
+			continue
+		}
+		before, ok := prev.Metrics[key]
+		if !ok || before.Used == nil || *before.Used < anomalyJumpFloor || before.Unit != met.Unit {
+			continue
+		}
+		if *met.Used >= *before.Used*anomalyJumpFactor {
+			out = append(out, SnapshotAnomaly{
+				Key:    key,
+				Kind:   AnomalySuddenJump,
+				Detail: fmt.Sprintf("used jumped from %s to %s", formatAnomalyValue(*before.Used), formatAnomalyValue(*met.Used)),
+			})
+		}
+	}
+
+	if !s.Timestamp.IsZero() {
+		for key, resetAt := range s.Resets {
+			if resetAt.IsZero() {
+				continue
+			}
+			window := resetWindow(s, key)
+			if age := s.Timestamp.Sub(resetAt); age > window {
+				out = append(out, SnapshotAnomaly{
+					Key:    key,
+					Kind:   AnomalyStaleReset,
+					Detail: fmt.Sprintf("reset %s is %s in the past (window %s)", resetAt.UTC().Format(time.RFC3339), age.Round(time.Minute), window),
+				})
+			}
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Key != out[j].Key {
+			return out[i].Key < out[j].Key
+		}
+		return out[i].Kind < out[j].Kind
+	})
+	return out
+}
+
+// ApplySnapshotValidation runs ValidateSnapshot and records each anomaly in
+// s.Diagnostics under AnomalyDiagnosticPrefix+key, so the UI can flag the
+// value and bug reports carry the evidence. Anomaly diagnostics left over
+// from an earlier validation are replaced.
+func ApplySnapshotValidation(s UsageSnapshot, prev *UsageSnapshot) (UsageSnapshot, []SnapshotAnomaly) {
+	anomalies := ValidateSnapshot(s, prev)
+	if len(anomalies) == 0 && len(s.Diagnostics) == 0 {
+		return s, nil
+	}
+	s.EnsureMaps()
+	for key := range s.Diagnostics {
+		if strings.HasPrefix(key, AnomalyDiagnosticPrefix) {
+			delete(s.Diagnostics, key)
+		}
+	}
+	for _, a := range anomalies {
+		diagKey := AnomalyDiagnosticPrefix + a.Key
+		note := string(a.Kind) + ": " + a.Detail
+		if existing := s.Diagnostics[diagKey]; existing != "" {
+			note = existing + "; " + note
+		}
+		s.Diagnostics[diagKey] = note
+	}
+	return s, anomalies
+}
+
+// MetricAnomaly returns the anomaly note ApplySnapshotValidation recorded for
+// key, if any.
+func (s UsageSnapshot) MetricAnomaly(key string) (string, bool) {
+	note, ok := s.Diagnostics[AnomalyDiagnosticPrefix+key]
+	return note, ok && note != ""
+}
+
+// HasMetricAnomaly reports whether ApplySnapshotValidation flagged key with
+// any of kinds.
+func (s UsageSnapshot) HasMetricAnomaly(key string, kinds ...AnomalyKind) bool {
+	note, ok := s.MetricAnomaly(key)
+	if !ok {
+		return false
+	}
+	for _, part := range strings.Split(note, "; ") {
+		kind, _, _ := strings.Cut(part, ":")
+		for _, k := range kinds {
+			if AnomalyKind(kind) == k {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resetWindow is how far in the past the reset key may sit before it is
+// stale: the window of the metric it belongs to ("<metric>" or
+// "<metric>_reset"), or a day when that is unknown.
+func resetWindow(s UsageSnapshot, key string) time.Duration {
+	met, ok := s.Metrics[key]
+	if !ok {
+		met, ok = s.Metrics[strings.TrimSuffix(key, "_reset")]
+	}
+	if ok {
+		if d, known := ParseWindowDuration(met.Window); known {
+			return d
+		}
+	}
+	return anomalyResetFallbackWindow
+}
+
+// ParseWindowDuration converts a Metric.Window label ("1m", "5h", "7d",
+// "rolling 7 days", "today", "billing-cycle", ...) to a duration. Calendar
+// windows use their longest length (a month or billing cycle is 31 days).
+func ParseWindowDuration(window string) (time.Duration, bool) {
+	w := strings.ToLower(strings.TrimSpace(window))
+	w = strings.TrimPrefix(strings.TrimPrefix(w, "rolling-"), "rolling ")
+	switch w {
+	case "":
+		return 0, false
+	case "hour", "hourly":
+		return time.Hour, true
+	case "day", "daily", "today", "since midnight":
+		return 24 * time.Hour, true
+	case "week", "weekly", "1w":
+		return 7 * 24 * time.Hour, true
+	case "month", "monthly", "1mo", "billing-cycle", "cycle", "current-period", "current_period":
+		return 31 * 24 * time.Hour, true
+	}
+	if n, ok := strings.CutSuffix(w, " days"); ok {
+		w = n + "d"
+	}
+	if n, ok := strings.CutSuffix(w, "d"); ok {
+		days, err := strconv.Atoi(n)
+		if err != nil || days <= 0 {
+			return 0, false
+		}
+		return time.Duration(days) * 24 * time.Hour, true
+	}
+	d, err := time.ParseDuration(w)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+func formatAnomalyValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateSnapshot(t *testing.T) {
+	now := time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		metrics map[string]Metric
+		resets  map[string]time.Time
+		prev    map[string]Metric
+		want    []AnomalyKind
+	}{
+		{
+			name:    "healthy",
+			metrics: map[string]Metric{"rpm": {Used: Float64Ptr(40), Limit: Float64Ptr(100), Remaining: Float64Ptr(60), Unit: "requests", Window: "1m"}},
+			resets:  map[string]time.Time{"rpm_reset": now.Add(30 * time.Second)},
+		},
+		{
+			name:    "small overage tolerated",
+			metrics: map[string]Metric{"spend": {Used: Float64Ptr(105), Limit: Float64Ptr(100), Unit: "USD"}},
+		},
+		{
+			name:    "used far above limit",
+			metrics: map[string]Metric{"spend": {Used: Float64Ptr(4000), Limit: Float64Ptr(100), Unit: "USD"}},
+			want:    []AnomalyKind{AnomalyUsedExceedsLimit},
+		},
+		{
+			name:    "negative remaining",
+			metrics: map[string]Metric{"credits": {Remaining: Float64Ptr(-3), Limit: Float64Ptr(10)}},
+			want:    []AnomalyKind{AnomalyNegativeRemaining},
+		},
+		{
+			name:    "reset a minute ago is fine",
+			metrics: map[string]Metric{"usage_five_hour": {Used: Float64Ptr(10), Unit: "%", Window: "5h"}},
+			resets:  map[string]time.Time{"usage_five_hour": now.Add(-time.Minute)},
+		},
+		{
+			name:    "reset older than its window",
+			metrics: map[string]Metric{"usage_five_hour": {Used: Float64Ptr(10), Unit: "%", Window: "5h"}},
+			resets:  map[string]time.Time{"usage_five_hour": now.Add(-6 * time.Hour)},
+			want:    []AnomalyKind{AnomalyStaleReset},
+		},
+		{
+			name:   "orphan reset uses a day",
+			resets: map[string]time.Time{"quota_reset": now.Add(-25 * time.Hour)},
+			want:   []AnomalyKind{AnomalyStaleReset},
+		},
+		{
+			name:    "sudden jump",
+			metrics: map[string]Metric{"tokens_today": {Used: Float64Ptr(500000), Unit: "tokens"}},
+			prev:    map[string]Metric{"tokens_today": {Used: Float64Ptr(2000), Unit: "tokens"}},
+			want:    []AnomalyKind{AnomalySuddenJump},
+		},
+		{
+			name:    "jump from a tiny value ignored",
+			metrics: map[string]Metric{"cost_today": {Used: Float64Ptr(5), Unit: "USD"}},
+			prev:    map[string]Metric{"cost_today": {Used: Float64Ptr(0.01), Unit: "USD"}},
+		},
+		{
+			name:    "jump across units ignored",
+			metrics: map[string]Metric{"usage": {Used: Float64Ptr(5000), Unit: "tokens"}},
+			prev:    map[string]Metric{"usage": {Used: Float64Ptr(2), Unit: "USD"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap := NewUsageSnapshot("openai", "openai-1")
+			snap.Timestamp = now
+			for k, v := range tt.metrics {
+				snap.Metrics[k] = v
+			}
+			for k, v := range tt.resets {
+				snap.Resets[k] = v
+			}
+			var prev *UsageSnapshot
+			if tt.prev != nil {
+				p := NewUsageSnapshot("openai", "openai-1")
+				p.Metrics = tt.prev
+				prev = &p
+			}
+
+			got := ValidateSnapshot(snap, prev)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ValidateSnapshot() = %+v, want kinds %v", got, tt.want)
+			}
+			for i, kind := range tt.want {
+				if got[i].Kind != kind {
+					t.Fatalf("anomaly %d kind = %s, want %s (%+v)", i, got[i].Kind, kind, got)
+				}
+			}
+		})
+	}
+}
+
+func TestApplySnapshotValidationRecordsDiagnostics(t *testing.T) {
+	snap := NewUsageSnapshot("openai", "openai-1")
+	snap.Metrics["spend"] = Metric{Used: Float64Ptr(4000), Limit: Float64Ptr(100), Unit: "USD"}
+	snap.Diagnostics["anomaly_old"] = "stale note"
+
+	snap, anomalies := ApplySnapshotValidation(snap, nil)
+	if len(anomalies) != 1 {
+		t.Fatalf("anomalies = %+v, want one", anomalies)
+	}
+	note, ok := snap.MetricAnomaly("spend")
+	if !ok || !strings.Contains(note, "used_exceeds_limit: used 4000 exceeds limit 100") {
+		t.Fatalf("MetricAnomaly(spend) = %q, %v", note, ok)
+	}
+	if _, ok := snap.MetricAnomaly("old"); ok {
+		t.Fatal("earlier anomaly diagnostics should be replaced")
+	}
+	if !snap.HasMetricAnomaly("spend", AnomalySuddenJump, AnomalyUsedExceedsLimit) || snap.HasMetricAnomaly("spend", AnomalySuddenJump) {
+		t.Fatal("HasMetricAnomaly should match the recorded kind only")
+	}
+
+	snap.Metrics["spend"] = Metric{Used: Float64Ptr(40), Limit: Float64Ptr(100), Unit: "USD"}
+	snap, _ = ApplySnapshotValidation(snap, nil)
+	if _, ok := snap.MetricAnomaly("spend"); ok {
+		t.Fatal("anomaly should clear once the value is sane again")
+	}
+}
+
+func TestParseWindowDuration(t *testing.T) {
+	tests := []struct {
+		window string
+		want   time.Duration
+		ok     bool
+	}{
+		{"1m", time.Minute, true},
+		{"5h", 5 * time.Hour, true},
+		{"rolling-5h", 5 * time.Hour, true},
+		{"7d", 7 * 24 * time.Hour, true},
+		{"rolling 7 days", 7 * 24 * time.Hour, true},
+		{"today", 24 * time.Hour, true},
+		{"billing-cycle", 31 * 24 * time.Hour, true},
+		{"1mo", 31 * 24 * time.Hour, true},
+		{"all-time", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseWindowDuration(tt.window)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseWindowDuration(%q) = %v, %v; want %v, %v", tt.window, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+)
+
+// StatusReasonDiagnostic names what DeriveStatus based a derived status on,
+// e.g. "usage_seven_day 96% used".
+const StatusReasonDiagnostic = "status_reason"
+
+// StatusSeverity orders statuses by how much attention they need, for
+// sorting and for picking the worst of several: 0 for OK, higher is worse.
+func StatusSeverity(s Status) int {
+	switch s {
+	case StatusOK:
+		return 0
+	case StatusUnknown, StatusUnsupported, "":
+		return 1
+	case StatusStale:
+		return 2
+	case StatusDegraded:
+		return 3
+	case StatusNearLimit:
+		return 4
+	case StatusMaintenance:
+		return 5
+	case StatusExhausted:
+		return 6
+	case StatusLimited:
+		return 7
+	case StatusAuth:
+		return 8
+	case StatusError:
+		return 9
+	default:
+		return 1
+	}
+}
+
+// StatusThresholds are the remaining fractions of a quota at or below which
+// it counts as near its limit (Warn) and exhausted (Crit), as in the
+// dashboard's warn_threshold and crit_threshold.
+type StatusThresholds struct {
+	Warn float64
+	Crit float64
+}
+
+// DefaultStatusThresholds match the dashboard's default thresholds.
+var DefaultStatusThresholds = StatusThresholds{Warn: 0.20, Crit: 0.05}
+
+// quotaWindowKeys are the well-known limit metrics that reset on a
+// schedule. Other metrics count as quota windows when the snapshot carries
+// a reset time for them.
+var quotaWindowKeys = map[string]bool{
+	"rpm": true, "tpm": true, "rpd": true, "tpd": true,
+	"usage_five_hour": true, "usage_one_day": true, "usage_seven_day": true, "usage_weekly": true,
+	"spend_limit": true, "quota": true,
+}
+
+// DeriveStatus refines an OK or NEAR_LIMIT status from the snapshot itself:
+//
+//   - EXHAUSTED when a quota window has at most t.Crit of it remaining,
+//     NEAR_LIMIT when it has at most t.Warn;
+//   - otherwise DEGRADED when part of the data failed to load (a non-empty
+//     "*_error" raw value), or STALE when the data is from an earlier fetch.
+//
+// It only ever raises the status: errors, auth, maintenance and a LIMITED
+// reported by the provider are left as they are.
+func DeriveStatus(s UsageSnapshot, t StatusThresholds) UsageSnapshot {
+	if s.Status != StatusOK && s.Status != StatusNearLimit {
+		return s
+	}
+	if t.Warn <= 0 || t.Crit <= 0 {
+		t = DefaultStatusThresholds
+	}
+
+	derived, reason := s.Status, ""
+	worst := -1.0
+	for _, key := range SortedStringKeys(s.Metrics) {
+		if !isQuotaWindowMetric(s, key) {
+			continue
+		}
+		used := MetricUsedPercent(key, s.Metrics[key])
+		if used < 0 || used <= worst {
+			continue
+		}
+		worst = used
+		reason = fmt.Sprintf("%s %.0f%% used", key, used)
+	}
+	switch {
+	case worst >= (1-t.Crit)*100:
+		derived = StatusExhausted
+	case worst >= (1-t.Warn)*100:
+		derived = StatusNearLimit
+	}
+
+	if derived == StatusOK {
+		if key, ok := partialDataError(s); ok {
+			derived, reason = StatusDegraded, key
+		} else if _, offline := s.Diagnostics[OfflineDiagnostic]; offline {
+			derived, reason = StatusStale, "offline"
+		} else if _, retained := s.Diagnostics[RetryErrorDiagnostic]; retained {
+			derived, reason = StatusStale, "last fetch failed"
+		}
+	}
+	if StatusSeverity(derived) <= StatusSeverity(s.Status) {
+		return s
+	}
+	s.Status = derived
+	s.Diagnostics = maps.Clone(s.Diagnostics)
+	s.SetDiagnostic(StatusReasonDiagnostic, reason)
+	return s
+}
+
+// QuotaBucket is one per-model quota (a quota_model_* metric) and how much
+// of it is used.
+type QuotaBucket struct {
+	Key         string
+	UsedPercent float64
+}
+
+// QuotaBucketsAtRisk returns the per-model quota buckets with at most t.Warn
+// remaining, most-used first. DeriveStatus already raises the status for
+// them; this names which ones, so a nearly exhausted model is not hidden
+// behind an aggregate quota.
+func QuotaBucketsAtRisk(s UsageSnapshot, t StatusThresholds) []QuotaBucket {
+	if t.Warn <= 0 || t.Crit <= 0 {
+		t = DefaultStatusThresholds
+	}
+	var out []QuotaBucket
+	for key, met := range s.Metrics {
+		if !strings.HasPrefix(key, "quota_model_") {
+			continue
+		}
+		if used := MetricUsedPercent(key, met); used >= (1-t.Warn)*100 {
+			out = append(out, QuotaBucket{Key: key, UsedPercent: used})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].UsedPercent != out[j].UsedPercent {
+			return out[i].UsedPercent > out[j].UsedPercent
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+func isQuotaWindowMetric(s UsageSnapshot, key string) bool {
+	if key == "context_window" {
+		return false
+	}
+	if quotaWindowKeys[key] || strings.HasPrefix(key, "rate_limit_") || strings.HasPrefix(key, "quota_") || strings.HasSuffix(key, "_quota") {
+		return true
+	}
+	if _, ok := s.Resets[key]; ok {
+		return true
+	}
+	_, ok := s.Resets[key+"_reset"]
+	return ok
+}
+
+// partialDataError returns the first raw "*_error" key with a value.
+func partialDataError(s UsageSnapshot) (string, bool) {
+	for _, key := range SortedStringKeys(s.Raw) {
+		if strings.HasSuffix(key, "_error") && strings.TrimSpace(s.Raw[key]) != "" {
+			return key, true
+		}
+	}
+	return "", false
+}
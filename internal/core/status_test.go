@@ -0,0 +1,165 @@
+package core
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestDeriveStatus(t *testing.T) {
+	percent := func(used float64) Metric { return Metric{Used: Float64Ptr(used), Unit: "%"} }
+	limit := func(used, limit float64) Metric { return Metric{Used: Float64Ptr(used), Limit: Float64Ptr(limit)} }
+	reset := map[string]time.Time{"weekly_requests": time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name       string
+		snap       UsageSnapshot
+		wantStatus Status
+		wantReason string
+	}{
+		{
+			name:       "plenty left",
+			snap:       UsageSnapshot{Status: StatusOK, Metrics: map[string]Metric{"usage_seven_day": percent(5)}},
+			wantStatus: StatusOK,
+		},
+		{
+			name:       "near limit",
+			snap:       UsageSnapshot{Status: StatusOK, Metrics: map[string]Metric{"usage_seven_day": percent(85)}},
+			wantStatus: StatusNearLimit,
+			wantReason: "usage_seven_day 85% used",
+		},
+		{
+			name: "worst window wins",
+			snap: UsageSnapshot{Status: StatusOK, Metrics: map[string]Metric{
+				"usage_five_hour": percent(30),
+				"usage_seven_day": percent(96),
+			}},
+			wantStatus: StatusExhausted,
+			wantReason: "usage_seven_day 96% used",
+		},
+		{
+			name:       "provider near limit escalates",
+			snap:       UsageSnapshot{Status: StatusNearLimit, Metrics: map[string]Metric{"rpm": limit(99, 100)}},
+			wantStatus: StatusExhausted,
+			wantReason: "rpm 99% used",
+		},
+		{
+			name:       "window with a reset time",
+			snap:       UsageSnapshot{Status: StatusOK, Metrics: map[string]Metric{"weekly_requests": limit(90, 100)}, Resets: reset},
+			wantStatus: StatusNearLimit,
+			wantReason: "weekly_requests 90% used",
+		},
+		{
+			name: "limits that are not quota windows",
+			snap: UsageSnapshot{Status: StatusOK, Metrics: map[string]Metric{
+				"context_window": limit(190000, 200000),
+				"active_seats":   limit(10, 10),
+			}},
+			wantStatus: StatusOK,
+		},
+		{
+			name:       "partial data error",
+			snap:       UsageSnapshot{Status: StatusOK, Raw: map[string]string{"billing_error": "HTTP 500", "usage_error": ""}},
+			wantStatus: StatusDegraded,
+			wantReason: "billing_error",
+		},
+		{
+			name:       "offline",
+			snap:       UsageSnapshot{Status: StatusOK, Diagnostics: map[string]string{OfflineDiagnostic: "2026-03-01T09:30:00Z"}},
+			wantStatus: StatusStale,
+			wantReason: "offline",
+		},
+		{
+			name:       "retained after a failed fetch",
+			snap:       UsageSnapshot{Status: StatusOK, Diagnostics: map[string]string{RetryErrorDiagnostic: "timeout"}},
+			wantStatus: StatusStale,
+			wantReason: "last fetch failed",
+		},
+		{
+			name: "quota beats stale",
+			snap: UsageSnapshot{Status: StatusOK, Metrics: map[string]Metric{"usage_five_hour": percent(100)},
+				Diagnostics: map[string]string{OfflineDiagnostic: "unknown"}},
+			wantStatus: StatusExhausted,
+			wantReason: "usage_five_hour 100% used",
+		},
+		{
+			name:       "provider status kept",
+			snap:       UsageSnapshot{Status: StatusLimited, Metrics: map[string]Metric{"rpm": limit(100, 100)}},
+			wantStatus: StatusLimited,
+		},
+		{
+			name:       "error kept",
+			snap:       UsageSnapshot{Status: StatusError, Raw: map[string]string{"billing_error": "HTTP 500"}},
+			wantStatus: StatusError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DeriveStatus(tt.snap, StatusThresholds{Warn: 0.20, Crit: 0.05})
+			if got.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", got.Status, tt.wantStatus)
+			}
+			if reason := got.Diagnostics[StatusReasonDiagnostic]; reason != tt.wantReason {
+				t.Errorf("reason = %q, want %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestDeriveStatus_Thresholds(t *testing.T) {
+	snap := UsageSnapshot{Status: StatusOK, Metrics: map[string]Metric{
+		"usage_seven_day": {Used: Float64Ptr(70), Unit: "%"},
+	}}
+	if got := DeriveStatus(snap, StatusThresholds{Warn: 0.40, Crit: 0.30}).Status; got != StatusExhausted {
+		t.Errorf("custom thresholds: status = %s, want %s", got, StatusExhausted)
+	}
+	if got := DeriveStatus(snap, StatusThresholds{}).Status; got != StatusOK {
+		t.Errorf("default thresholds: status = %s, want %s", got, StatusOK)
+	}
+	if snap.Status != StatusOK || snap.Diagnostics != nil {
+		t.Error("DeriveStatus modified its input")
+	}
+}
+
+func TestDeriveStatus_QuotaBuckets(t *testing.T) {
+	bucket := func(used float64) Metric { return Metric{Used: Float64Ptr(used), Limit: Float64Ptr(100)} }
+	snap := UsageSnapshot{ProviderID: "gemini_cli", Status: StatusOK, Metrics: map[string]Metric{
+		"quota":                                 bucket(10),
+		"quota_model_gemini_2_5_pro_requests":   bucket(90),
+		"quota_model_gemini_2_5_flash_requests": bucket(1),
+		"quota_model_gemini_3_pro_requests":     bucket(85),
+	}}
+
+	got := DeriveStatus(snap, DefaultStatusThresholds)
+	if got.Status != StatusNearLimit || got.Diagnostics[StatusReasonDiagnostic] != "quota_model_gemini_2_5_pro_requests 90% used" {
+		t.Fatalf("status = %s (%q), want NEAR_LIMIT from the pro bucket", got.Status, got.Diagnostics[StatusReasonDiagnostic])
+	}
+
+	risk := QuotaBucketsAtRisk(snap, DefaultStatusThresholds)
+	if len(risk) != 2 || risk[0].Key != "quota_model_gemini_2_5_pro_requests" || risk[1].Key != "quota_model_gemini_3_pro_requests" {
+		t.Fatalf("QuotaBucketsAtRisk = %+v, want both pro buckets, most-used first", risk)
+	}
+
+	snap.Metrics["quota_model_gemini_2_5_pro_requests"] = bucket(100)
+	if got := DeriveStatus(snap, DefaultStatusThresholds).Status; got != StatusExhausted {
+		t.Fatalf("exhausted bucket status = %s, want EXHAUSTED", got)
+	}
+	snap.Status = StatusAuth
+	if got := DeriveStatus(snap, DefaultStatusThresholds).Status; got != StatusAuth {
+		t.Fatalf("auth status overridden with %s", got)
+	}
+}
+
+func TestStatusSeverity_Order(t *testing.T) {
+	want := []Status{
+		StatusOK, StatusUnknown, StatusStale, StatusDegraded, StatusNearLimit,
+		StatusMaintenance, StatusExhausted, StatusLimited, StatusAuth, StatusError,
+	}
+	got := append([]Status(nil), want...)
+	sort.SliceStable(got, func(i, j int) bool { return StatusSeverity(got[i]) > StatusSeverity(got[j]) })
+	for i := range got {
+		if got[i] != want[len(want)-1-i] {
+			t.Fatalf("severity order = %v, want reverse of %v", got, want)
+		}
+	}
+}
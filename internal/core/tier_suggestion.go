@@ -0,0 +1,133 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LimitPressureAttrPrefix prefixes the snapshot attributes, one per limit
+// metric, recording how often the limit ran nearly dry over the last
+// LimitPressureDays days, as "<days near the limit>/<days observed>".
+const LimitPressureAttrPrefix = "limit_pressure_"
+
+const (
+	// LimitPressureDays is how far back limit pressure is measured.
+	LimitPressureDays = 7
+	// LimitPressureThreshold is the remaining fraction of a limit at or
+	// below which a poll counts as bumping against it.
+	LimitPressureThreshold = 0.10
+	// tierSuggestionMinDays is the fewest days near a limit that count as
+	// consistent rather than a one-off burst.
+	tierSuggestionMinDays = 3
+)
+
+// LimitPressure counts the days a limit ran nearly dry.
+type LimitPressure struct {
+	HitDays int
+	Days    int
+}
+
+// String formats the pressure as stored in the snapshot attribute.
+func (p LimitPressure) String() string {
+	return fmt.Sprintf("%d/%d", p.HitDays, p.Days)
+}
+
+// Consistent reports whether the limit was hit on at least three days and on
+// at least half the days observed.
+func (p LimitPressure) Consistent() bool {
+	return p.HitDays >= tierSuggestionMinDays && p.HitDays*2 >= p.Days
+}
+
+// LimitPressures parses the limit pressure attributes of s, keyed by metric.
+func LimitPressures(s UsageSnapshot) map[string]LimitPressure {
+	out := make(map[string]LimitPressure)
+	for key, value := range s.Attributes {
+		metric, ok := strings.CutPrefix(key, LimitPressureAttrPrefix)
+		if !ok || metric == "" {
+			continue
+		}
+		hits, days, ok := strings.Cut(value, "/")
+		if !ok {
+			continue
+		}
+		h, err1 := strconv.Atoi(strings.TrimSpace(hits))
+		d, err2 := strconv.Atoi(strings.TrimSpace(days))
+		if err1 != nil || err2 != nil || d <= 0 || h < 0 || h > d {
+			continue
+		}
+		out[metric] = LimitPressure{HitDays: h, Days: d}
+	}
+	return out
+}
+
+// TierSuggestion recommends moving to the next documented tier because a
+// limit keeps running out.
+type TierSuggestion struct {
+	Current  ProviderTier
+	Next     ProviderTier
+	Metric   string
+	Pressure LimitPressure
+	// CurrentLimit is the limit the account reports for Metric; NextLimit
+	// is the next tier's documented limit, nil when it publishes none.
+	CurrentLimit *float64
+	NextLimit    *float64
+}
+
+// SuggestTierUpgrade reports a TierSuggestion when the account's tier is
+// known, a higher tier exists, and a request or token limit has been hit
+// consistently (see LimitPressure.Consistent). Of several pressured limits
+// the one hit on the most days is named.
+func SuggestTierUpgrade(ref ProviderReference, s UsageSnapshot) (TierSuggestion, bool) {
+	current, ok := ref.MatchTier(s)
+	if !ok || current+1 >= len(ref.Tiers) {
+		return TierSuggestion{}, false
+	}
+	pressures := LimitPressures(s)
+	keys := make([]string, 0, len(pressures))
+	for key := range pressures {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var best string
+	for _, key := range keys {
+		p := pressures[key]
+		if !p.Consistent() || !tierLimitMetric(ref, s, key) {
+			continue
+		}
+		if best == "" || p.HitDays > pressures[best].HitDays {
+			best = key
+		}
+	}
+	if best == "" {
+		return TierSuggestion{}, false
+	}
+
+	next := ref.Tiers[current+1]
+	sug := TierSuggestion{Current: ref.Tiers[current], Next: next, Metric: best, Pressure: pressures[best]}
+	if m, ok := s.Metrics[best]; ok && m.Limit != nil {
+		sug.CurrentLimit = Float64Ptr(*m.Limit)
+	}
+	if v, ok := next.Limits[best]; ok {
+		sug.NextLimit = Float64Ptr(v)
+	}
+	return sug, true
+}
+
+// tierLimitMetric reports whether key is a limit tiers govern: one a tier
+// documents, or a request or token rate limit. Spend caps are the user's own
+// setting, not a tier's.
+func tierLimitMetric(ref ProviderReference, s UsageSnapshot, key string) bool {
+	for _, tier := range ref.Tiers {
+		if _, ok := tier.Limits[key]; ok {
+			return true
+		}
+	}
+	switch s.Metrics[key].Unit {
+	case "requests", "tokens":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package core
+
+import "testing"
+
+func TestSuggestTierUpgrade(t *testing.T) {
+	ref := ProviderReference{Tiers: []ProviderTier{
+		{Name: "Tier 1", Qualification: "$5 paid", Limits: map[string]float64{"rpm": 500, "tpm": 200000}},
+		{Name: "Tier 2", Qualification: "$50 paid", Limits: map[string]float64{"rpm": 5000, "tpm": 2000000}},
+	}}
+	snap := func(attrs map[string]string) UsageSnapshot {
+		return UsageSnapshot{
+			Metrics: map[string]Metric{
+				"rpm":         {Limit: Float64Ptr(500), Unit: "requests"},
+				"tpm":         {Limit: Float64Ptr(200000), Unit: "tokens"},
+				"spend_limit": {Limit: Float64Ptr(100), Unit: "USD"},
+			},
+			Attributes: attrs,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		snap       UsageSnapshot
+		wantMetric string
+		wantOK     bool
+	}{
+		{name: "consistent rpm pressure", snap: snap(map[string]string{"limit_pressure_rpm": "5/7"}), wantMetric: "rpm", wantOK: true},
+		{name: "most pressured wins", snap: snap(map[string]string{"limit_pressure_rpm": "3/6", "limit_pressure_tpm": "4/7"}), wantMetric: "tpm", wantOK: true},
+		{name: "one-off burst", snap: snap(map[string]string{"limit_pressure_rpm": "2/2"})},
+		{name: "rare hits", snap: snap(map[string]string{"limit_pressure_rpm": "3/7"})},
+		{name: "spend cap ignored", snap: snap(map[string]string{"limit_pressure_spend_limit": "7/7"})},
+		{name: "malformed", snap: snap(map[string]string{"limit_pressure_rpm": "9/7"})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := SuggestTierUpgrade(ref, tt.snap)
+			if ok != tt.wantOK || got.Metric != tt.wantMetric {
+				t.Fatalf("SuggestTierUpgrade() = %+v, %v; want metric %q, %v", got, ok, tt.wantMetric, tt.wantOK)
+			}
+		})
+	}
+
+	got, _ := SuggestTierUpgrade(ref, snap(map[string]string{"limit_pressure_rpm": "5/7"}))
+	if got.Current.Name != "Tier 1" || got.Next.Name != "Tier 2" {
+		t.Errorf("tiers = %s → %s, want Tier 1 → Tier 2", got.Current.Name, got.Next.Name)
+	}
+	if got.CurrentLimit == nil || *got.CurrentLimit != 500 || got.NextLimit == nil || *got.NextLimit != 5000 {
+		t.Errorf("limits = %v → %v, want 500 → 5000", got.CurrentLimit, got.NextLimit)
+	}
+	if got.Pressure != (LimitPressure{HitDays: 5, Days: 7}) || got.Pressure.String() != "5/7" {
+		t.Errorf("pressure = %+v", got.Pressure)
+	}
+
+	top := snap(map[string]string{"limit_pressure_rpm": "7/7"})
+	top.Metrics["rpm"] = Metric{Limit: Float64Ptr(5000), Unit: "requests"}
+	top.Metrics["tpm"] = Metric{Limit: Float64Ptr(2000000), Unit: "tokens"}
+	if _, ok := SuggestTierUpgrade(ref, top); ok {
+		t.Error("no suggestion expected on the highest tier")
+	}
+}
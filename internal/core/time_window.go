@@ -1,6 +1,9 @@
 package core
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // TimeWindow represents a configurable time window for filtering usage data.
 type TimeWindow string
@@ -23,6 +26,9 @@ var ValidTimeWindows = []TimeWindow{
 
 // Hours returns the window size in hours. Returns 0 for TimeWindowAll (no filter).
 func (tw TimeWindow) Hours() int {
+	if w, ok := lookupCustomTimeWindow(tw); ok {
+		return w.days(time.Now()) * 24
+	}
 	switch tw {
 	case TimeWindowAll:
 		return 0
@@ -45,6 +51,9 @@ func (tw TimeWindow) Days() int {
 }
 
 func (tw TimeWindow) Label() string {
+	if w, ok := lookupCustomTimeWindow(tw); ok {
+		return w.label()
+	}
 	switch tw {
 	case TimeWindowAll:
 		return "All Time"
@@ -64,6 +73,9 @@ func (tw TimeWindow) Label() string {
 // SQLiteOffset returns the SQLite datetime offset string for this window
 // (e.g., "-7 day"). Returns empty string for TimeWindowAll (no filter).
 func (tw TimeWindow) SQLiteOffset() string {
+	if w, ok := lookupCustomTimeWindow(tw); ok {
+		return fmt.Sprintf("-%d day", w.days(time.Now()))
+	}
 	switch tw {
 	case TimeWindowAll:
 		return ""
@@ -90,8 +102,12 @@ func LocalMidnight() time.Time {
 // For "1d" (Today): local midnight (calendar day boundary).
 // For "3d", "7d", "30d": rolling N*24 hours from now.
 // For "all": zero time (no filter).
+// For custom windows: see CustomTimeWindow.
 func (tw TimeWindow) Since() time.Time {
 	now := time.Now()
+	if w, ok := lookupCustomTimeWindow(tw); ok {
+		return w.since(now)
+	}
 	switch tw {
 	case TimeWindowAll:
 		return time.Time{}
@@ -108,11 +124,11 @@ func (tw TimeWindow) Since() time.Time {
 	}
 }
 
+// ParseTimeWindow resolves s to a built-in or custom window, falling back
+// to 30d for unknown names.
 func ParseTimeWindow(s string) TimeWindow {
-	for _, tw := range ValidTimeWindows {
-		if string(tw) == s {
-			return tw
-		}
+	if tw, ok := LookupTimeWindow(s); ok {
+		return tw
 	}
 	return TimeWindow30d
 }
@@ -137,12 +153,14 @@ func LargestWindowFitting(maxDays int) TimeWindow {
 	return best
 }
 
-// NextTimeWindow returns the next time window in the cycle.
+// NextTimeWindow returns the next time window in the cycle, custom windows
+// included.
 func NextTimeWindow(current TimeWindow) TimeWindow {
-	for i, tw := range ValidTimeWindows {
+	windows := TimeWindows()
+	for i, tw := range windows {
 		if tw == current {
-			return ValidTimeWindows[(i+1)%len(ValidTimeWindows)]
+			return windows[(i+1)%len(windows)]
 		}
 	}
-	return ValidTimeWindows[0]
+	return windows[0]
 }
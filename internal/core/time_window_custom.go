@@ -0,0 +1,164 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Calendar periods a custom window can run from the start of.
+const (
+	CalendarWeek    = "week"
+	CalendarMonth   = "month"
+	CalendarQuarter = "quarter"
+	CalendarYear    = "year"
+)
+
+// maxCustomWindowDays bounds rolling custom windows to the longest retention
+// the config accepts.
+const maxCustomWindowDays = 3650
+
+// CustomTimeWindow is a user-defined aggregation window from
+// data.custom_windows: either the last Days days, rolling like the built-in
+// 7d and 30d windows, or the current calendar Start period to date. Weeks
+// start on Monday.
+type CustomTimeWindow struct {
+	ID    string `json:"id"`
+	Label string `json:"label,omitempty"`
+	Days  int    `json:"days,omitempty"`
+	Start string `json:"start,omitempty"`
+}
+
+var customWindowIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Validate reports why the window can't be used, or nil.
+func (w CustomTimeWindow) Validate() error {
+	if !customWindowIDPattern.MatchString(w.ID) {
+		return fmt.Errorf("custom window id %q must be lowercase letters, digits, '-' or '_'", w.ID)
+	}
+	for _, builtin := range ValidTimeWindows {
+		if w.ID == string(builtin) {
+			return fmt.Errorf("custom window id %q is a built-in window", w.ID)
+		}
+	}
+	switch {
+	case w.Days != 0 && w.Start != "":
+		return fmt.Errorf("custom window %q: set days or start, not both", w.ID)
+	case w.Start != "":
+		switch w.Start {
+		case CalendarWeek, CalendarMonth, CalendarQuarter, CalendarYear:
+		default:
+			return fmt.Errorf("custom window %q: start must be week, month, quarter or year", w.ID)
+		}
+	case w.Days < 1 || w.Days > maxCustomWindowDays:
+		return fmt.Errorf("custom window %q: days must be between 1 and %d", w.ID, maxCustomWindowDays)
+	}
+	return nil
+}
+
+// since returns the window's cutoff at now.
+func (w CustomTimeWindow) since(now time.Time) time.Time {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch w.Start {
+	case CalendarWeek:
+		offset := (int(now.Weekday()) + 6) % 7 // days since Monday
+		return midnight.AddDate(0, 0, -offset)
+	case CalendarMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	case CalendarQuarter:
+		first := time.Month((int(now.Month())-1)/3*3 + 1)
+		return time.Date(now.Year(), first, 1, 0, 0, 0, 0, now.Location())
+	case CalendarYear:
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+	}
+	return now.Add(-time.Duration(w.Days) * 24 * time.Hour)
+}
+
+// days is the window's length in whole days, counting today for calendar
+// windows.
+func (w CustomTimeWindow) days(now time.Time) int {
+	if w.Start == "" {
+		return w.Days
+	}
+	return int(now.Sub(w.since(now)).Hours()/24) + 1
+}
+
+func (w CustomTimeWindow) label() string {
+	if w.Label != "" {
+		return w.Label
+	}
+	return strings.ToUpper(w.ID[:1]) + w.ID[1:]
+}
+
+var customWindows struct {
+	mu   sync.RWMutex
+	list []CustomTimeWindow
+}
+
+// SetCustomTimeWindows replaces the custom windows TimeWindow resolves.
+// Invalid windows and repeated IDs are dropped. Config loading calls it, so
+// every process that reads settings.json knows the user's windows.
+func SetCustomTimeWindows(windows []CustomTimeWindow) {
+	kept := make([]CustomTimeWindow, 0, len(windows))
+	seen := map[string]bool{}
+	for _, w := range windows {
+		if w.Validate() != nil || seen[w.ID] {
+			continue
+		}
+		seen[w.ID] = true
+		kept = append(kept, w)
+	}
+	customWindows.mu.Lock()
+	customWindows.list = kept
+	customWindows.mu.Unlock()
+}
+
+// CustomTimeWindows returns the registered custom windows.
+func CustomTimeWindows() []CustomTimeWindow {
+	customWindows.mu.RLock()
+	defer customWindows.mu.RUnlock()
+	return append([]CustomTimeWindow(nil), customWindows.list...)
+}
+
+func lookupCustomTimeWindow(tw TimeWindow) (CustomTimeWindow, bool) {
+	customWindows.mu.RLock()
+	defer customWindows.mu.RUnlock()
+	for _, w := range customWindows.list {
+		if w.ID == string(tw) {
+			return w, true
+		}
+	}
+	return CustomTimeWindow{}, false
+}
+
+// TimeWindows returns the built-in windows followed by the custom ones, in
+// the order the dashboard cycles through them.
+func TimeWindows() []TimeWindow {
+	custom := CustomTimeWindows()
+	out := make([]TimeWindow, 0, len(ValidTimeWindows)+len(custom))
+	out = append(out, ValidTimeWindows...)
+	for _, w := range custom {
+		out = append(out, TimeWindow(w.ID))
+	}
+	return out
+}
+
+// IsCustom reports whether tw is a registered custom window.
+func (tw TimeWindow) IsCustom() bool {
+	_, ok := lookupCustomTimeWindow(tw)
+	return ok
+}
+
+// LookupTimeWindow resolves s to a built-in or custom window. Unlike
+// ParseTimeWindow it reports unknown names instead of falling back to 30d.
+func LookupTimeWindow(s string) (TimeWindow, bool) {
+	s = strings.TrimSpace(s)
+	for _, tw := range TimeWindows() {
+		if string(tw) == s {
+			return tw, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCustomTimeWindowValidate(t *testing.T) {
+	valid := []CustomTimeWindow{
+		{ID: "sprint", Days: 14},
+		{ID: "q", Start: CalendarQuarter},
+		{ID: "last_90d", Days: 90, Label: "Last 90 days"},
+	}
+	for _, w := range valid {
+		if err := w.Validate(); err != nil {
+			t.Errorf("Validate(%+v) = %v", w, err)
+		}
+	}
+	invalid := []CustomTimeWindow{
+		{ID: "Sprint", Days: 14},
+		{ID: "7d", Days: 7},
+		{ID: "sprint"},
+		{ID: "sprint", Days: 14, Start: CalendarMonth},
+		{ID: "sprint", Start: "fortnight"},
+		{ID: "sprint", Days: maxCustomWindowDays + 1},
+	}
+	for _, w := range invalid {
+		if err := w.Validate(); err == nil {
+			t.Errorf("Validate(%+v) succeeded", w)
+		}
+	}
+}
+
+func TestCustomTimeWindowSince(t *testing.T) {
+	now := time.Date(2026, time.May, 14, 15, 30, 0, 0, time.UTC) // a Thursday
+	tests := []struct {
+		w        CustomTimeWindow
+		wantFrom time.Time
+		wantDays int
+	}{
+		{CustomTimeWindow{ID: "sprint", Days: 14}, now.Add(-14 * 24 * time.Hour), 14},
+		{CustomTimeWindow{ID: "wk", Start: CalendarWeek}, time.Date(2026, time.May, 11, 0, 0, 0, 0, time.UTC), 4},
+		{CustomTimeWindow{ID: "mo", Start: CalendarMonth}, time.Date(2026, time.May, 1, 0, 0, 0, 0, time.UTC), 14},
+		{CustomTimeWindow{ID: "q", Start: CalendarQuarter}, time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC), 44},
+		{CustomTimeWindow{ID: "yr", Start: CalendarYear}, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), 134},
+	}
+	for _, tt := range tests {
+		t.Run(tt.w.ID, func(t *testing.T) {
+			if got := tt.w.since(now); !got.Equal(tt.wantFrom) {
+				t.Errorf("since = %v, want %v", got, tt.wantFrom)
+			}
+			if got := tt.w.days(now); got != tt.wantDays {
+				t.Errorf("days = %d, want %d", got, tt.wantDays)
+			}
+		})
+	}
+}
+
+func TestSetCustomTimeWindows(t *testing.T) {
+	t.Cleanup(func() { SetCustomTimeWindows(nil) })
+	SetCustomTimeWindows([]CustomTimeWindow{
+		{ID: "sprint", Days: 14},
+		{ID: "sprint", Days: 21},
+		{ID: "30d", Days: 30},
+		{ID: "quarter", Start: CalendarQuarter, Label: "This quarter"},
+	})
+
+	want := []TimeWindow{TimeWindow1d, TimeWindow3d, TimeWindow7d, TimeWindow30d, TimeWindowAll, "sprint", "quarter"}
+	got := TimeWindows()
+	if len(got) != len(want) {
+		t.Fatalf("TimeWindows() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("TimeWindows() = %v, want %v", got, want)
+		}
+	}
+
+	sprint := ParseTimeWindow("sprint")
+	if sprint != "sprint" || !sprint.IsCustom() {
+		t.Fatalf("ParseTimeWindow(sprint) = %q", sprint)
+	}
+	if sprint.Days() != 14 || sprint.SQLiteOffset() != "-14 day" || sprint.Label() != "Sprint" {
+		t.Errorf("sprint: days=%d offset=%q label=%q", sprint.Days(), sprint.SQLiteOffset(), sprint.Label())
+	}
+	if since := sprint.Since(); time.Since(since) < 14*24*time.Hour-time.Minute {
+		t.Errorf("sprint.Since() = %v, want ~14 days ago", since)
+	}
+	if TimeWindow("quarter").Label() != "This quarter" {
+		t.Errorf("quarter label = %q", TimeWindow("quarter").Label())
+	}
+
+	if NextTimeWindow(TimeWindowAll) != "sprint" || NextTimeWindow("quarter") != TimeWindow1d {
+		t.Errorf("cycle: all -> %q, quarter -> %q", NextTimeWindow(TimeWindowAll), NextTimeWindow("quarter"))
+	}
+	if _, ok := LookupTimeWindow("fortnight"); ok {
+		t.Error("LookupTimeWindow(fortnight) resolved")
+	}
+
+	SetCustomTimeWindows(nil)
+	if ParseTimeWindow("sprint") != TimeWindow30d {
+		t.Error("sprint still resolves after clearing custom windows")
+	}
+}
@@ -0,0 +1,100 @@
+package core
+
+// TokenEfficiency holds unit-economics ratios derived from a snapshot's
+// per-model token and cost metrics, for comparing accounts and models by
+// what a dollar buys rather than by raw spend. Nil ratios mean the snapshot
+// lacks the inputs for them.
+type TokenEfficiency struct {
+	// CostPer1kOutput is USD per 1,000 output tokens, over models that report
+	// both cost and output.
+	CostPer1kOutput *float64 `json:"cost_per_1k_output_usd,omitempty"`
+	// CacheReadPercent is the share (0–100) of prompt tokens served from the
+	// prompt cache, as CacheHitRatio defines it.
+	CacheReadPercent *float64 `json:"cache_read_percent,omitempty"`
+	// ReasoningPercent is the share (0–100) of generated tokens spent on
+	// reasoning.
+	ReasoningPercent *float64 `json:"reasoning_percent,omitempty"`
+
+	CostUSD         float64 `json:"cost_usd"`
+	PromptTokens    float64 `json:"prompt_tokens"`
+	OutputTokens    float64 `json:"output_tokens"`
+	CacheReadTokens float64 `json:"cache_read_tokens"`
+	ReasoningTokens float64 `json:"reasoning_tokens"`
+}
+
+// ComputeTokenEfficiency derives TokenEfficiency from the model_* metrics of
+// s. It reports false when no ratio can be computed.
+//
+// Cached and cache-read tokens are both counted as cache hits beside
+// non-cached input, matching CacheHitRatio. Providers disagree on whether
+// reasoning is part of output: it is treated as included unless it exceeds
+// output, in which case the provider evidently counts it separately.
+func ComputeTokenEfficiency(s UsageSnapshot) (TokenEfficiency, bool) {
+	type agg struct {
+		input, output, cacheRead, cacheWrite, reasoning, cost float64
+	}
+	byModel := make(map[string]*agg)
+	for key, metric := range s.Metrics {
+		if metric.Used == nil || *metric.Used <= 0 {
+			continue
+		}
+		model, kind, ok := parseModelMetricKey(key)
+		if !ok {
+			continue
+		}
+		a := byModel[model]
+		if a == nil {
+			a = &agg{}
+			byModel[model] = a
+		}
+		v := *metric.Used
+		switch kind {
+		case modelMetricInput:
+			a.input += v
+		case modelMetricOutput:
+			a.output += v
+		case modelMetricCached, modelMetricCacheRead:
+			a.cacheRead += v
+		case modelMetricCacheWrite:
+			a.cacheWrite += v
+		case modelMetricReasoning:
+			a.reasoning += v
+		case modelMetricCostUSD:
+			a.cost += v
+		}
+	}
+
+	var e TokenEfficiency
+	var cacheWrite, pricedCost, pricedOutput, generated float64
+	for _, a := range byModel {
+		e.CostUSD += a.cost
+		e.PromptTokens += a.input + a.cacheRead + a.cacheWrite
+		e.CacheReadTokens += a.cacheRead
+		cacheWrite += a.cacheWrite
+		e.OutputTokens += a.output
+		e.ReasoningTokens += a.reasoning
+		if a.reasoning > a.output {
+			generated += a.output + a.reasoning
+		} else {
+			generated += a.output
+		}
+		if a.cost > 0 && a.output > 0 {
+			pricedCost += a.cost
+			pricedOutput += a.output
+		}
+	}
+
+	if pricedOutput > 0 {
+		v := pricedCost / pricedOutput * 1000
+		e.CostPer1kOutput = &v
+	}
+	if pct, ok := CacheHitRatio(e.PromptTokens-e.CacheReadTokens-cacheWrite, e.CacheReadTokens, cacheWrite); ok {
+		e.CacheReadPercent = &pct
+	}
+	if generated > 0 && e.ReasoningTokens > 0 {
+		v := min(e.ReasoningTokens/generated*100, 100)
+		e.ReasoningPercent = &v
+	}
+	ok := e.CostPer1kOutput != nil || e.CacheReadPercent != nil || e.ReasoningPercent != nil
+	return e, ok
+}
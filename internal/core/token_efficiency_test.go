@@ -0,0 +1,72 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeTokenEfficiency(t *testing.T) {
+	approx := func(p *float64, want float64) bool {
+		return p != nil && math.Abs(*p-want) < 1e-9
+	}
+
+	t.Run("anthropic style counts cache reads beside input", func(t *testing.T) {
+		snap := UsageSnapshot{Metrics: map[string]Metric{
+			"model_claude_sonnet_input_tokens":       {Used: Float64Ptr(1_000)},
+			"model_claude_sonnet_cache_read_tokens":  {Used: Float64Ptr(8_000)},
+			"model_claude_sonnet_cache_write_tokens": {Used: Float64Ptr(1_000)},
+			"model_claude_sonnet_output_tokens":      {Used: Float64Ptr(2_000)},
+			"model_claude_sonnet_cost_usd":           {Used: Float64Ptr(0.05)},
+			"model_claude_haiku_cost_usd":            {Used: Float64Ptr(9)}, // no output: kept out of $/1k
+		}}
+		e, ok := ComputeTokenEfficiency(snap)
+		if !ok {
+			t.Fatal("ComputeTokenEfficiency() = false, want true")
+		}
+		if !approx(e.CostPer1kOutput, 0.025) {
+			t.Errorf("CostPer1kOutput = %v, want 0.025", e.CostPer1kOutput)
+		}
+		if !approx(e.CacheReadPercent, 80) {
+			t.Errorf("CacheReadPercent = %v, want 80", e.CacheReadPercent)
+		}
+		if e.ReasoningPercent != nil {
+			t.Errorf("ReasoningPercent = %v, want nil", *e.ReasoningPercent)
+		}
+		if e.CostUSD != 9.05 || e.PromptTokens != 10_000 {
+			t.Errorf("totals = cost %v prompt %v, want 9.05 / 10000", e.CostUSD, e.PromptTokens)
+		}
+	})
+
+	t.Run("lumped cached tokens and reasoning within output", func(t *testing.T) {
+		snap := UsageSnapshot{Metrics: map[string]Metric{
+			"model_gpt_5_input_tokens":     {Used: Float64Ptr(3_000)},
+			"model_gpt_5_cached_tokens":    {Used: Float64Ptr(1_000)},
+			"model_gpt_5_output_tokens":    {Used: Float64Ptr(1_000)},
+			"model_gpt_5_reasoning_tokens": {Used: Float64Ptr(400)},
+		}}
+		e, ok := ComputeTokenEfficiency(snap)
+		if !ok || e.CostPer1kOutput != nil {
+			t.Fatalf("ComputeTokenEfficiency() = %+v, %v; want no cost ratio", e, ok)
+		}
+		if !approx(e.CacheReadPercent, 25) || !approx(e.ReasoningPercent, 40) {
+			t.Errorf("shares = cache %v reasoning %v, want 25 / 40", e.CacheReadPercent, e.ReasoningPercent)
+		}
+	})
+
+	t.Run("reasoning reported beside output", func(t *testing.T) {
+		snap := UsageSnapshot{Metrics: map[string]Metric{
+			"model_gemini_output_tokens":    {Used: Float64Ptr(100)},
+			"model_gemini_reasoning_tokens": {Used: Float64Ptr(300)},
+		}}
+		if e, _ := ComputeTokenEfficiency(snap); !approx(e.ReasoningPercent, 75) {
+			t.Errorf("ReasoningPercent = %v, want 75", e.ReasoningPercent)
+		}
+	})
+
+	t.Run("no model metrics", func(t *testing.T) {
+		snap := UsageSnapshot{Metrics: map[string]Metric{"today_cost": {Used: Float64Ptr(3)}}}
+		if _, ok := ComputeTokenEfficiency(snap); ok {
+			t.Error("ComputeTokenEfficiency() = true for a snapshot without model metrics")
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"sort"
+	"strings"
+)
+
+// Tool categories model-turn cost is attributed to. ToolCategoryChat holds
+// turns that read no tool results, such as answering a prompt directly.
+const (
+	ToolCategoryWeb   = "web"
+	ToolCategoryShell = "shell"
+	ToolCategoryEdit  = "edit"
+	ToolCategoryRead  = "read"
+	ToolCategoryAgent = "agent"
+	ToolCategoryMCP   = "mcp"
+	ToolCategoryOther = "other"
+	ToolCategoryChat  = "chat"
+)
+
+// ToolCostMetricPrefix starts the per-category cost metrics,
+// toolcost_<category>_usd. It deliberately avoids the tool_ prefix, which
+// ExtractActualToolUsage reads as call counts.
+const ToolCostMetricPrefix = "toolcost_"
+
+// ToolCostMetricKey is the metric holding a category's attributed cost.
+func ToolCostMetricKey(category string) string {
+	return ToolCostMetricPrefix + category + "_usd"
+}
+
+// toolCategoryByName maps tool names, lowercased with '_' and '-' removed,
+// across Claude Code, Codex and Gemini CLI.
+var toolCategoryByName = map[string]string{
+	"websearch":       ToolCategoryWeb,
+	"webfetch":        ToolCategoryWeb,
+	"googlewebsearch": ToolCategoryWeb,
+	"websearchcall":   ToolCategoryWeb,
+
+	"bash":            ToolCategoryShell,
+	"bashoutput":      ToolCategoryShell,
+	"killshell":       ToolCategoryShell,
+	"shell":           ToolCategoryShell,
+	"localshell":      ToolCategoryShell,
+	"execcommand":     ToolCategoryShell,
+	"writestdin":      ToolCategoryShell,
+	"runshellcommand": ToolCategoryShell,
+
+	"edit":         ToolCategoryEdit,
+	"multiedit":    ToolCategoryEdit,
+	"write":        ToolCategoryEdit,
+	"notebookedit": ToolCategoryEdit,
+	"applypatch":   ToolCategoryEdit,
+	"writefile":    ToolCategoryEdit,
+	"replace":      ToolCategoryEdit,
+
+	"read":              ToolCategoryRead,
+	"grep":              ToolCategoryRead,
+	"glob":              ToolCategoryRead,
+	"ls":                ToolCategoryRead,
+	"notebookread":      ToolCategoryRead,
+	"readfile":          ToolCategoryRead,
+	"readmanyfiles":     ToolCategoryRead,
+	"listdirectory":     ToolCategoryRead,
+	"searchfilecontent": ToolCategoryRead,
+	"viewimage":         ToolCategoryRead,
+
+	"task":  ToolCategoryAgent,
+	"agent": ToolCategoryAgent,
+}
+
+// ToolCategory classifies a tool call by name. Unknown tools fall into
+// ToolCategoryOther.
+func ToolCategory(name string) string {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if IsMCPToolMetricName(normalized) {
+		return ToolCategoryMCP
+	}
+	normalized = strings.NewReplacer("_", "", "-", "").Replace(normalized)
+	if category, ok := toolCategoryByName[normalized]; ok {
+		return category
+	}
+	return ToolCategoryOther
+}
+
+// ToolCostAttributor splits model-turn cost between tool categories. A
+// turn's cost goes to the tool calls whose results it reads, those made
+// since the session's previous turn, split evenly per call: a fetched page
+// or command output is paid for as input by the turn after the call. Turns
+// that read no tool results count as ToolCategoryChat.
+type ToolCostAttributor struct {
+	pending map[string][]string
+	costs   map[string]float64
+}
+
+func NewToolCostAttributor() *ToolCostAttributor {
+	return &ToolCostAttributor{
+		pending: make(map[string][]string),
+		costs:   make(map[string]float64),
+	}
+}
+
+// Call records a tool call whose result the session's next turn reads.
+func (a *ToolCostAttributor) Call(session, toolName string) {
+	a.pending[session] = append(a.pending[session], ToolCategory(toolName))
+}
+
+// Turn attributes one turn's cost and clears the session's pending calls.
+func (a *ToolCostAttributor) Turn(session string, costUSD float64) {
+	calls := a.pending[session]
+	delete(a.pending, session)
+	if costUSD <= 0 {
+		return
+	}
+	if len(calls) == 0 {
+		a.costs[ToolCategoryChat] += costUSD
+		return
+	}
+	share := costUSD / float64(len(calls))
+	for _, category := range calls {
+		a.costs[category] += share
+	}
+}
+
+// Emit writes a toolcost_<category>_usd metric per category with cost.
+func (a *ToolCostAttributor) Emit(snap *UsageSnapshot, window string) {
+	for category, cost := range a.costs {
+		if cost <= 0 {
+			continue
+		}
+		v := cost
+		snap.Metrics[ToolCostMetricKey(category)] = Metric{Used: &v, Unit: "USD", Window: window}
+	}
+}
+
+type ToolCostEntry struct {
+	Category string
+	CostUSD  float64
+	Window   string
+}
+
+// ExtractToolCostBreakdown returns the attributed cost per tool category,
+// largest first.
+func ExtractToolCostBreakdown(s UsageSnapshot) ([]ToolCostEntry, map[string]bool) {
+	usedKeys := make(map[string]bool)
+	var out []ToolCostEntry
+	for key, metric := range s.Metrics {
+		if !strings.HasPrefix(key, ToolCostMetricPrefix) || !strings.HasSuffix(key, "_usd") {
+			continue
+		}
+		usedKeys[key] = true
+		category := strings.TrimSuffix(strings.TrimPrefix(key, ToolCostMetricPrefix), "_usd")
+		if category == "" || metric.Used == nil || *metric.Used <= 0 {
+			continue
+		}
+		out = append(out, ToolCostEntry{Category: category, CostUSD: *metric.Used, Window: metric.Window})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CostUSD != out[j].CostUSD {
+			return out[i].CostUSD > out[j].CostUSD
+		}
+		return out[i].Category < out[j].Category
+	})
+	return out, usedKeys
+}
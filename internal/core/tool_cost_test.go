@@ -0,0 +1,67 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+func TestToolCategory(t *testing.T) {
+	tests := map[string]string{
+		"WebFetch":               ToolCategoryWeb,
+		"google_web_search":      ToolCategoryWeb,
+		"Bash":                   ToolCategoryShell,
+		"exec_command":           ToolCategoryShell,
+		"run_shell_command":      ToolCategoryShell,
+		"MultiEdit":              ToolCategoryEdit,
+		"apply_patch":            ToolCategoryEdit,
+		"write_file":             ToolCategoryEdit,
+		"read_many_files":        ToolCategoryRead,
+		"Task":                   ToolCategoryAgent,
+		"mcp__github__get_issue": ToolCategoryMCP,
+		"TodoWrite":              ToolCategoryOther,
+	}
+	for name, want := range tests {
+		if got := ToolCategory(name); got != want {
+			t.Errorf("ToolCategory(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestToolCostAttributor(t *testing.T) {
+	a := NewToolCostAttributor()
+	a.Turn("s1", 1) // answers the prompt
+	a.Call("s1", "WebFetch")
+	a.Call("s1", "WebFetch")
+	a.Call("s1", "Bash")
+	a.Turn("s1", 3) // reads two pages and a command's output
+	a.Call("s2", "Edit")
+	a.Turn("s1", 0.5)
+	a.Turn("s2", 2)
+
+	snap := UsageSnapshot{Metrics: map[string]Metric{
+		"tool_webfetch": {Used: Float64Ptr(2), Unit: "calls"},
+	}}
+	a.Emit(&snap, "all-time")
+
+	entries, used := ExtractToolCostBreakdown(snap)
+	want := []ToolCostEntry{
+		{Category: ToolCategoryEdit, CostUSD: 2},
+		{Category: ToolCategoryWeb, CostUSD: 2},
+		{Category: ToolCategoryChat, CostUSD: 1.5},
+		{Category: ToolCategoryShell, CostUSD: 1},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("entries = %+v, want %+v", entries, want)
+	}
+	for i, w := range want {
+		if entries[i].Category != w.Category || math.Abs(entries[i].CostUSD-w.CostUSD) > 1e-9 || entries[i].Window != "all-time" {
+			t.Errorf("entries[%d] = %+v, want %+v", i, entries[i], w)
+		}
+	}
+	if len(used) != len(want) || used["tool_webfetch"] {
+		t.Errorf("usedKeys = %v", used)
+	}
+	if tools, _ := ExtractActualToolUsage(snap); len(tools) != 1 || tools[0].RawName != "webfetch" {
+		t.Errorf("tool cost metrics leaked into tool usage: %+v", tools)
+	}
+}
@@ -31,3 +31,10 @@ func Tracef(format string, args ...any) {
 	}
 	log.Printf("[trace] "+format, args...)
 }
+
+// EnableDebug turns debug logging on for the rest of the process, as if
+// OPENUSAGE_DEBUG were set. Call it before starting goroutines that log.
+func EnableDebug() {
+	traceEnabledOnce.Do(func() {})
+	traceEnabled = true
+}
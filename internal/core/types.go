@@ -16,7 +16,16 @@ const (
 	StatusAuth        Status = "AUTH_REQUIRED"
 	StatusUnsupported Status = "UNSUPPORTED"
 	StatusError       Status = "ERROR"
+	StatusMaintenance Status = "MAINTENANCE"
 	StatusUnknown     Status = "UNKNOWN"
+	// StatusDegraded: the fetch succeeded but part of the data failed to load.
+	StatusDegraded Status = "DEGRADED"
+	// StatusStale: the data shown is not from the latest fetch (offline, or
+	// kept while a failed fetch is retried).
+	StatusStale Status = "STALE"
+	// StatusExhausted: a quota window is used up to the critical threshold.
+	// Unlike StatusLimited, the provider has not started refusing requests.
+	StatusExhausted Status = "EXHAUSTED"
 )
 
 type Metric struct {
@@ -119,6 +128,19 @@ func (s *UsageSnapshot) SetDiagnostic(key, value string) {
 	s.Diagnostics[key] = value
 }
 
+// HasData reports whether the snapshot carries anything worth showing: a
+// resolved status or any metrics, resets, series or model rows. The daemon's
+// placeholder snapshots for accounts it hasn't polled yet don't.
+func (s UsageSnapshot) HasData() bool {
+	if s.Status != StatusUnknown {
+		return true
+	}
+	return len(s.Metrics) > 0 ||
+		len(s.Resets) > 0 ||
+		len(s.DailySeries) > 0 ||
+		len(s.ModelUsage) > 0
+}
+
 func (s UsageSnapshot) MetaValue(key string) (string, bool) {
 	if key == "" {
 		return "", false
@@ -16,7 +16,7 @@ func HasMCPUsage(s UsageSnapshot) bool {
 }
 
 func IncludeDetailMetricKey(key string) bool {
-	return !strings.HasPrefix(strings.TrimSpace(key), "mcp_")
+	return !strings.HasPrefix(strings.TrimSpace(key), "mcp_") && !IsEndpointMetricKey(key)
 }
 
 func ExtractMCPBreakdown(s UsageSnapshot) ([]MCPServerUsageEntry, map[string]bool) {
@@ -400,9 +400,12 @@ const (
 	DetailSectionActivityHeatmap DetailStandardSection = "activity_heatmap"
 	DetailSectionCostRequests    DetailStandardSection = "cost_requests"
 	DetailSectionForecast        DetailStandardSection = "forecast"
+	DetailSectionEnergy          DetailStandardSection = "energy"
+	DetailSectionSeats           DetailStandardSection = "seats"
 	DetailSectionUpstream        DetailStandardSection = "upstream"
 	DetailSectionProviderBurn    DetailStandardSection = "provider_burn"
 	DetailSectionOtherData       DetailStandardSection = "other_data"
+	DetailSectionEndpoints       DetailStandardSection = "endpoints"
 	DetailSectionTimers          DetailStandardSection = "timers"
 	DetailSectionInfo            DetailStandardSection = "info"
 )
@@ -422,9 +425,12 @@ func defaultDetailSectionOrder() []DetailStandardSection {
 		DetailSectionActivityHeatmap,
 		DetailSectionCostRequests,
 		DetailSectionForecast,
+		DetailSectionEnergy,
+		DetailSectionSeats,
 		DetailSectionUpstream,
 		DetailSectionProviderBurn,
 		DetailSectionOtherData,
+		DetailSectionEndpoints,
 		DetailSectionTimers,
 		DetailSectionInfo,
 	}
@@ -451,9 +457,12 @@ func isKnownDetailSection(section DetailStandardSection) bool {
 		DetailSectionActivityHeatmap,
 		DetailSectionCostRequests,
 		DetailSectionForecast,
+		DetailSectionEnergy,
+		DetailSectionSeats,
 		DetailSectionUpstream,
 		DetailSectionProviderBurn,
 		DetailSectionOtherData,
+		DetailSectionEndpoints,
 		DetailSectionTimers,
 		DetailSectionInfo:
 		return true
@@ -497,12 +506,18 @@ func DetailSectionLabel(s DetailStandardSection) string {
 		return "Cost & Requests"
 	case DetailSectionForecast:
 		return "Forecast"
+	case DetailSectionEnergy:
+		return "Energy & CO2"
+	case DetailSectionSeats:
+		return "Seat Activity"
 	case DetailSectionUpstream:
 		return "Upstream Providers"
 	case DetailSectionProviderBurn:
 		return "Provider Burn"
 	case DetailSectionOtherData:
 		return "Other Data"
+	case DetailSectionEndpoints:
+		return "Endpoints"
 	case DetailSectionTimers:
 		return "Timers"
 	case DetailSectionInfo:
@@ -1,7 +1,6 @@
 package daemon
 
 import (
-	"log"
 	"os"
 	"sort"
 	"strings"
@@ -13,91 +12,14 @@ import (
 	"github.com/janekbaraniewski/openusage/internal/telemetry"
 )
 
+// ResolveAccounts resolves the accounts to poll and saves newly detected
+// ones to settings.json. See detect.ResolveAccounts.
 func ResolveAccounts(cfg *config.Config) []core.AccountConfig {
-	allAccounts := core.MergeAccounts(cfg.Accounts, cfg.AutoDetectedAccounts)
-
-	if cfg.AutoDetect {
-		result := detect.AutoDetect()
-
-		manualIDs := make(map[string]bool, len(cfg.Accounts))
-		for _, acct := range cfg.Accounts {
-			manualIDs[acct.ID] = true
-		}
-		var autoDetected []core.AccountConfig
-		for _, acct := range result.Accounts {
-			if !manualIDs[acct.ID] {
-				autoDetected = append(autoDetected, acct)
-			}
-		}
-
-		// Only persist when the auto-detected set actually changed. Without
-		// this guard we'd take saveMu and rewrite settings.json on every
-		// poll cycle (~30s), even when nothing about the workstation has
-		// moved.
-		if !sameAutoDetectedAccounts(cfg.AutoDetectedAccounts, autoDetected) {
-			if err := config.SaveAutoDetected(autoDetected); err != nil {
-				log.Printf("Warning: could not persist auto-detected accounts: %v", err)
-			}
-		}
-		cfg.AutoDetectedAccounts = autoDetected
-
-		allAccounts = core.MergeAccounts(cfg.Accounts, cfg.AutoDetectedAccounts)
-
-		if core.DebugEnabled() {
-			if len(result.Tools) > 0 || len(result.Accounts) > 0 {
-				log.Print(result.Summary())
-			}
-		}
-	}
-
-	return ApplyCredentials(allAccounts)
+	return detect.ResolveAccounts(cfg)
 }
 
 func ApplyCredentials(accounts []core.AccountConfig) []core.AccountConfig {
-	credResult := detect.Result{Accounts: accounts}
-	detect.ApplyCredentials(&credResult)
-	return credResult.Accounts
-}
-
-// sameAutoDetectedAccounts compares two slices of auto-detected accounts by
-// the persisted-fields subset (ID, Provider, Auth, APIKeyEnv, BaseURL, Binary,
-// ProviderPaths, Paths). Runtime-only fields (Token, RuntimeHints) are
-// ignored — they change every run for sources like Cursor's vscdb token.
-func sameAutoDetectedAccounts(a, b []core.AccountConfig) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	keyOf := func(acc core.AccountConfig) string {
-		return acc.ID + "|" + acc.Provider + "|" + acc.Auth + "|" + acc.APIKeyEnv +
-			"|" + acc.BaseURL + "|" + acc.Binary
-	}
-	indexA := make(map[string]core.AccountConfig, len(a))
-	for _, acc := range a {
-		indexA[keyOf(acc)] = acc
-	}
-	for _, acc := range b {
-		other, ok := indexA[keyOf(acc)]
-		if !ok {
-			return false
-		}
-		if !samePathMap(other.PathMap(), acc.PathMap()) {
-			return false
-		}
-	}
-	return true
-}
-
-// samePathMap reports map-equality, treating nil and empty as equal.
-func samePathMap(a, b map[string]string) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for k, v := range a {
-		if b[k] != v {
-			return false
-		}
-	}
-	return true
+	return detect.ApplyAccountCredentials(accounts)
 }
 
 func ResolveSocketPath() string {
@@ -179,16 +101,37 @@ func resolveConfigAccounts(
 
 	accounts := core.MergeAccounts(cfg.Accounts, cfg.AutoDetectedAccounts)
 	accounts = FilterAccountsByDashboard(accounts, cfg.Dashboard)
-	return ApplyCredentials(accounts)
+	accounts = core.ApplyReadOnly(ApplyCredentials(accounts), cfg.ReadOnlyEnabled())
+	return core.ApplyNetwork(accounts, cfg.Network)
 }
 
-func LoadAccountsAndNorm() ([]core.AccountConfig, core.ModelNormalizationConfig, error) {
+func LoadAccountsAndNorm() ([]core.AccountConfig, core.ModelNormalizationConfig, []core.DerivedMetric, error) {
 	cfg, err := config.Load()
 	if err != nil {
-		return nil, core.DefaultModelNormalizationConfig(), err
+		return nil, core.DefaultModelNormalizationConfig(), nil, err
 	}
 	accounts := resolveConfigAccounts(&cfg, ResolveAccounts)
-	return accounts, core.NormalizeModelNormalizationConfig(cfg.ModelNormalization), nil
+	return accounts, core.NormalizeModelNormalizationConfig(cfg.ModelNormalization), core.CompileDerivedMetrics(cfg.DerivedMetrics), nil
+}
+
+// DerivedMetricsFromConfig compiles the user-defined derived metrics from
+// settings, or returns nil when the config cannot be read.
+func DerivedMetricsFromConfig() []core.DerivedMetric {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	return core.CompileDerivedMetrics(cfg.DerivedMetrics)
+}
+
+// StatusThresholdsFromConfig returns the dashboard's warn/crit thresholds
+// for deriving statuses, or the defaults when the config cannot be read.
+func StatusThresholdsFromConfig() core.StatusThresholds {
+	cfg, err := config.Load()
+	if err != nil {
+		return core.DefaultStatusThresholds
+	}
+	return core.StatusThresholds{Warn: cfg.UI.WarnThreshold, Crit: cfg.UI.CritThreshold}
 }
 
 func BuildReadModelRequest(
@@ -71,6 +71,35 @@ func (c *Client) HealthInfo(ctx context.Context) (HealthResponse, error) {
 	return out, nil
 }
 
+// Logs returns the daemon's buffered log lines, oldest first.
+func (c *Client) Logs(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://unix/v1/logs", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("daemon: reading logs response body: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("daemon does not expose logs; restart it to upgrade")
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("daemon logs failed: %s", strings.TrimSpace(string(body)))
+	}
+	var out LogsResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, fmt.Errorf("decode daemon logs response: %w", err)
+	}
+	return out.Lines, nil
+}
+
 func (c *Client) ReadModel(
 	ctx context.Context,
 	request ReadModelRequest,
@@ -116,6 +145,109 @@ func (c *Client) ReadModel(
 	return out.Snapshots, nil
 }
 
+// Poll asks the daemon to fetch request.AccountIDs (all enabled accounts
+// when empty) right away, bypassing the poll interval and backoff. It
+// returns once the results are ingested.
+func (c *Client) Poll(ctx context.Context, request PollRequest) (PollResponse, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return PollResponse{}, fmt.Errorf("marshal daemon poll request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://unix/v1/poll", bytes.NewReader(payload))
+	if err != nil {
+		return PollResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Provider fetches outlast the client's default timeout.
+	httpClient := *c.http
+	httpClient.Timeout = forcedPollTimeout + 5*time.Second
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return PollResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return PollResponse{}, fmt.Errorf("daemon: reading poll response body: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		switch {
+		case json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "":
+			return PollResponse{}, fmt.Errorf("daemon poll failed: %s", apiErr.Error)
+		case resp.StatusCode == http.StatusNotFound:
+			// Daemons predating /v1/poll answer with the mux's plain 404.
+			return PollResponse{}, fmt.Errorf("daemon does not support on-demand polling; restart it to upgrade")
+		}
+		return PollResponse{}, fmt.Errorf("daemon poll failed: %s", strings.TrimSpace(string(body)))
+	}
+
+	var out PollResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return PollResponse{}, fmt.Errorf("decode daemon poll response: %w", err)
+	}
+	return out, nil
+}
+
+// SetSpendLimit asks the daemon to change accountID's spending cap; nil
+// removes it.
+func (c *Client) SetSpendLimit(ctx context.Context, accountID string, limitUSD *float64) error {
+	_, err := c.postSpendLimit(ctx, SpendLimitRequest{AccountID: accountID, LimitUSD: limitUSD})
+	return err
+}
+
+// PreviewSpendLimit asks the daemon what a change to accountID's spending
+// cap would apply to, such as the provider key it would update. Empty when
+// the provider has nothing to choose between.
+func (c *Client) PreviewSpendLimit(ctx context.Context, accountID string) (string, error) {
+	resp, err := c.postSpendLimit(ctx, SpendLimitRequest{AccountID: accountID, Preview: true})
+	return resp.Target, err
+}
+
+func (c *Client) postSpendLimit(ctx context.Context, request SpendLimitRequest) (SpendLimitResponse, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return SpendLimitResponse{}, fmt.Errorf("marshal daemon spend limit request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://unix/v1/spend-limit", bytes.NewReader(payload))
+	if err != nil {
+		return SpendLimitResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := *c.http
+	httpClient.Timeout = spendLimitTimeout + 5*time.Second
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return SpendLimitResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return SpendLimitResponse{}, fmt.Errorf("daemon: reading spend limit response body: %w", err)
+	}
+	if resp.StatusCode < 300 {
+		var out SpendLimitResponse
+		_ = json.Unmarshal(body, &out)
+		return out, nil
+	}
+	var apiErr struct {
+		Error string `json:"error"`
+	}
+	switch {
+	case json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "":
+		return SpendLimitResponse{}, fmt.Errorf("%s", apiErr.Error)
+	case resp.StatusCode == http.StatusNotFound:
+		return SpendLimitResponse{}, fmt.Errorf("daemon does not support changing spend limits; restart it to upgrade")
+	}
+	return SpendLimitResponse{}, fmt.Errorf("daemon spend limit failed: %s", strings.TrimSpace(string(body)))
+}
+
 func (c *Client) IngestHook(
 	ctx context.Context,
 	source string,
@@ -2,10 +2,14 @@ package daemon
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"strings"
 
+	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/providers"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 	"github.com/janekbaraniewski/openusage/internal/telemetry"
 )
 
@@ -23,7 +27,11 @@ func ParseHookRequests(sourceName, accountID string, payload []byte) (HookParseR
 		return HookParseResult{}, fmt.Errorf("unknown hook source %q", sourceName)
 	}
 
-	options, effectiveAccountID, warnings := ResolveTelemetrySourceOptions(source, strings.TrimSpace(accountID))
+	accountID = strings.TrimSpace(accountID)
+	if accountID == "" {
+		accountID = hookAccountFromTranscript(source, payload)
+	}
+	options, effectiveAccountID, warnings := ResolveTelemetrySourceOptions(source, accountID)
 	reqs, err := telemetry.ParseSourceHookPayload(source, payload, options, effectiveAccountID)
 	if err != nil {
 		return HookParseResult{}, fmt.Errorf("parse hook payload: %w", err)
@@ -37,6 +45,63 @@ func ParseHookRequests(sourceName, accountID string, payload []byte) (HookParseR
 	}, nil
 }
 
+// hookAccountFromTranscript attributes a hook event that names no account by
+// the transcript it reports (Claude Code's transcript_path). With several
+// installs of one tool configured, the account whose data directories hold
+// the transcript wins; "" when none or more than one matches equally.
+func hookAccountFromTranscript(source shared.TelemetrySource, payload []byte) string {
+	var hook struct {
+		TranscriptPath string `json:"transcript_path"`
+	}
+	if json.Unmarshal(payload, &hook) != nil || strings.TrimSpace(hook.TranscriptPath) == "" {
+		return ""
+	}
+	accounts, err := loadTelemetrySourceAccounts()
+	if err != nil {
+		return ""
+	}
+	return accountForTranscript(source, accounts, hook.TranscriptPath)
+}
+
+func accountForTranscript(source shared.TelemetrySource, accounts []core.AccountConfig, transcript string) string {
+	candidates := telemetryAccountsForSource(source, accounts)
+	if len(candidates) < 2 {
+		// One account gets the event anyway; none leaves it source-scoped.
+		return ""
+	}
+	transcript = filepath.Clean(shared.ExpandHome(transcript))
+
+	// The hook runs in the tool's environment, so an account without
+	// data_path follows the caller's CLAUDE_CONFIG_DIR and can match the
+	// same directory as the account that names it explicitly; the explicit
+	// one wins that tie.
+	best, bestLen, bestExplicit, tied := "", 0, false, false
+	for _, acct := range candidates {
+		explicit := strings.TrimSpace(acct.DataPath) != ""
+		opts := collectOptionsForAccount(source, acct)
+		for key, dir := range opts.Paths {
+			if key == "account_id" || dir == "" {
+				continue
+			}
+			dir = filepath.Clean(shared.ExpandHome(dir))
+			rel, err := filepath.Rel(dir, transcript)
+			if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+				continue
+			}
+			switch {
+			case len(dir) > bestLen, len(dir) == bestLen && explicit && !bestExplicit:
+				best, bestLen, bestExplicit, tied = acct.ID, len(dir), explicit, false
+			case len(dir) == bestLen && acct.ID != best && explicit == bestExplicit:
+				tied = true
+			}
+		}
+	}
+	if tied {
+		return ""
+	}
+	return best
+}
+
 func IngestHookLocally(
 	ctx context.Context,
 	sourceName string,
@@ -3,10 +3,15 @@ package daemon
 import (
 	"context"
 	"database/sql"
+	"os"
 	"path/filepath"
 	"testing"
 
 	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers"
+	"github.com/janekbaraniewski/openusage/internal/telemetry"
 )
 
 func TestIngestHookLocally_IngestsHookPayload(t *testing.T) {
@@ -75,3 +80,68 @@ func TestIngestHookLocally_SpoolOnly(t *testing.T) {
 		t.Fatalf("usage events count = %d, want 0 in spool-only mode", eventCount)
 	}
 }
+
+func TestIngestHookLocally_WebhookEventsFoldIntoSnapshot(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "telemetry.db")
+	spoolDir := filepath.Join(t.TempDir(), "spool")
+	payload := []byte(`{"events":[
+		{"id":"req-1","timestamp":"2026-02-26T20:00:00Z","model":"gpt-4o","provider":"openai","input_tokens":1000,"output_tokens":200,"cost_usd":0.5},
+		{"id":"req-2","timestamp":"2026-02-26T20:01:00Z","model":"gpt-4o","provider":"openai","input_tokens":500,"output_tokens":100,"cost_usd":0.25}
+	]}`)
+
+	for range 2 { // a retried POST must not double count
+		if _, err := IngestHookLocally(context.Background(), "webhook", "", payload, dbPath, spoolDir, false); err != nil {
+			t.Fatalf("ingest webhook events: %v", err)
+		}
+	}
+
+	snaps := map[string]core.UsageSnapshot{"webhook": core.NewUsageSnapshot("webhook", "webhook")}
+	got, err := telemetry.ApplyCanonicalTelemetryViewWithOptions(context.Background(), dbPath, snaps, telemetry.ReadModelOptions{})
+	if err != nil {
+		t.Fatalf("apply read model: %v", err)
+	}
+	cost := got["webhook"].Metrics["model_gpt_4o_cost_usd"]
+	if cost.Used == nil || *cost.Used != 0.75 {
+		t.Fatalf("model_gpt_4o_cost_usd = %+v, want 0.75", cost)
+	}
+	if reqs := got["webhook"].Metrics["window_requests"]; reqs.Used == nil || *reqs.Used != 2 {
+		t.Fatalf("window_requests = %+v, want 2", reqs)
+	}
+}
+
+func TestAccountForTranscript_PicksInstallHoldingTranscript(t *testing.T) {
+	source, ok := providers.TelemetrySourceBySystem("claude_code")
+	if !ok {
+		t.Fatal("claude_code telemetry source not found")
+	}
+	home := t.TempDir()
+	stable := filepath.Join(home, ".claude")
+	nightly := filepath.Join(home, ".claude-nightly")
+	for _, dir := range []string{stable, nightly} {
+		if err := os.MkdirAll(filepath.Join(dir, "projects", "proj"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The hook runs under the nightly build's CLAUDE_CONFIG_DIR, which the
+	// account without data_path follows too.
+	t.Setenv("CLAUDE_CONFIG_DIR", nightly)
+
+	accounts := []core.AccountConfig{
+		{ID: "claude-stable", Provider: "claude_code", DataPath: stable},
+		{ID: "claude-nightly", Provider: "claude_code", DataPath: nightly},
+		{ID: "claude-code", Provider: "claude_code"},
+	}
+	tests := map[string]string{
+		filepath.Join(stable, "projects", "proj", "s1.jsonl"):  "claude-stable",
+		filepath.Join(nightly, "projects", "proj", "s2.jsonl"): "claude-nightly",
+		filepath.Join(home, "elsewhere", "s3.jsonl"):           "",
+	}
+	for transcript, want := range tests {
+		if got := accountForTranscript(source, accounts, transcript); got != want {
+			t.Errorf("accountForTranscript(%s) = %q, want %q", transcript, got, want)
+		}
+	}
+	if got := accountForTranscript(source, accounts[:1], filepath.Join(stable, "projects", "proj", "s1.jsonl")); got != "" {
+		t.Errorf("single account: got %q, want no override", got)
+	}
+}
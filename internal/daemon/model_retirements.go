@@ -0,0 +1,26 @@
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/pricing"
+)
+
+// modelRetirementDate resolves a model's announced shutdown date. Tests
+// swap it to avoid the pricing catalog.
+var modelRetirementDate = func(ctx context.Context, model string) (time.Time, bool) {
+	return pricing.DefaultResolver().RetirementDate(ctx, model)
+}
+
+// applyModelRetirements records every model the account spends on that has
+// a scheduled shutdown, with its share of the spend. The dashboard decides
+// how close a date must be to warn.
+func applyModelRetirements(ctx context.Context, snap *core.UsageSnapshot) {
+	for model, share := range core.ModelSpendShares(snap.ModelUsage) {
+		if date, ok := modelRetirementDate(ctx, model); ok {
+			snap.AddModelRetirement(core.ModelRetirement{Model: model, Date: date, SpendShare: share})
+		}
+	}
+}
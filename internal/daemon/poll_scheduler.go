@@ -6,23 +6,38 @@ import (
 	"sync"
 	"time"
 
+	"github.com/janekbaraniewski/openusage/internal/config"
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
 
 // PollScheduler manages per-provider adaptive backoff to reduce CPU usage when data
 // sources are idle. Each account gets its own backoff state: when consecutive polls
 // detect no changes, the effective interval increases in tiers up to a configurable cap.
+// A change, or a quota reset falling inside the backed-off wait, snaps the account
+// back to the base interval. An account's priority overrides this: high-priority
+// accounts poll every tick, low-priority ones at most every lowEvery ticks. On
+// top of all that, a poll schedule can stretch the wait or pause polling at
+// certain times of day.
 type PollScheduler struct {
 	mu           sync.Mutex
 	states       map[string]*pollBackoffState
+	schedules    map[string]*core.PollSchedule // per-account overrides of schedule
 	baseInterval time.Duration
+
+	adaptive    bool
+	idleCycles  int           // unchanged polls before the first slowdown
+	maxInterval time.Duration // 0 = per-kind multiplier caps
+	lowEvery    int           // ticks between polls of low-priority accounts
+	schedule    *core.PollSchedule
 }
 
 type pollBackoffState struct {
 	lastPollAt          time.Time
 	consecutiveNoChange int
 	lastSnapshotHash    string
-	hasLocalDetector    bool // true if provider implements ChangeDetector
+	hasLocalDetector    bool      // true if provider implements ChangeDetector
+	nextResetAt         time.Time // earliest future reset in the last snapshot
+	priority            core.PollPriority
 }
 
 // backoff tier thresholds and multipliers
@@ -38,37 +53,124 @@ var backoffTiers = []struct {
 }
 
 const (
+	// defaultIdleCycles matches the first backoff tier.
+	defaultIdleCycles = 3
 	// HTTP-only providers cap at 4x (they can't do cheap local change detection).
 	maxMultiplierHTTP = 4
 	// Local providers (with ChangeDetector) can back off further since stat() is cheap.
 	maxMultiplierLocal = 16
+	// defaultLowPriorityEvery spaces low-priority polls like the 4x backoff tier.
+	defaultLowPriorityEvery = 4
 )
 
 func newPollScheduler(baseInterval time.Duration) *PollScheduler {
 	return &PollScheduler{
 		states:       make(map[string]*pollBackoffState),
+		schedules:    make(map[string]*core.PollSchedule),
 		baseInterval: baseInterval,
+		adaptive:     true,
+		idleCycles:   defaultIdleCycles,
+		lowEvery:     defaultLowPriorityEvery,
+	}
+}
+
+// withPolling applies the user's polling settings.
+func (ps *PollScheduler) withPolling(cfg config.PollingConfig) *PollScheduler {
+	ps.adaptive = cfg.AdaptiveEnabled()
+	if cfg.IdleCycles > 0 {
+		ps.idleCycles = cfg.IdleCycles
+	}
+	ps.maxInterval = time.Duration(cfg.MaxIntervalSeconds) * time.Second
+	if cfg.LowPriorityEvery > 0 {
+		ps.lowEvery = cfg.LowPriorityEvery
+	}
+	ps.schedule = cfg.Schedule
+	return ps
+}
+
+// SetSchedule records the account's own poll schedule, which replaces the
+// global one. nil falls back to the global schedule.
+func (ps *PollScheduler) SetSchedule(accountID string, schedule *core.PollSchedule) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if schedule == nil {
+		delete(ps.schedules, accountID)
+		return
 	}
+	ps.schedules[accountID] = schedule
+}
+
+func (ps *PollScheduler) scheduleLocked(accountID string) *core.PollSchedule {
+	if schedule, ok := ps.schedules[accountID]; ok {
+		return schedule
+	}
+	return ps.schedule
 }
 
 // ShouldPoll returns true if enough time has elapsed for this account's current
-// backoff tier. If the provider implements ChangeDetector, mark it accordingly
-// for the correct cap.
-func (ps *PollScheduler) ShouldPoll(accountID string, hasLocalDetector bool) bool {
+// backoff tier and its schedule doesn't pause it. If the provider implements
+// ChangeDetector, mark it accordingly for the correct cap.
+func (ps *PollScheduler) ShouldPoll(accountID string, hasLocalDetector bool, priority core.PollPriority) bool {
 	ps.mu.Lock()
 	defer ps.mu.Unlock()
 
+	now := time.Now()
+	if rule, ok := ps.scheduleLocked(accountID).Match(now); ok && rule.Pause {
+		return false
+	}
 	state, ok := ps.states[accountID]
 	if !ok {
 		ps.states[accountID] = &pollBackoffState{
 			hasLocalDetector: hasLocalDetector,
+			priority:         priority,
 		}
 		return true // first poll always runs
 	}
 	state.hasLocalDetector = hasLocalDetector
+	state.priority = priority
+
+	return now.Sub(state.lastPollAt) >= ps.waitLocked(accountID, state, now)
+}
+
+// NextPollAt returns when the account is next due, or zero before its first
+// poll. Polls run on ticks, so the actual poll lands on the first tick after.
+// While the schedule pauses the account, that's when the pause ends.
+func (ps *PollScheduler) NextPollAt(accountID string) time.Time {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	state, ok := ps.states[accountID]
+	if !ok || state.lastPollAt.IsZero() {
+		return time.Time{}
+	}
+	now := time.Now()
+	due := state.lastPollAt.Add(ps.waitLocked(accountID, state, now))
+	if schedule := ps.scheduleLocked(accountID); schedule != nil {
+		// The schedule can only hold a due poll back, never bring it forward.
+		from := due
+		if from.Before(now) {
+			from = now
+		}
+		if resume := schedule.ResumesAt(from); resume.After(due) {
+			due = resume
+		}
+	}
+	return due
+}
 
+// waitLocked is how long after its last poll the account is due again.
+func (ps *PollScheduler) waitLocked(accountID string, state *pollBackoffState, now time.Time) time.Duration {
 	interval := ps.effectiveIntervalLocked(state)
-	return time.Since(state.lastPollAt) >= interval
+	// Don't sleep through a reset: once one is due within the backed-off
+	// wait, fall back to the base cadence so the fresh quota shows promptly.
+	if !state.nextResetAt.IsZero() && state.nextResetAt.Sub(now) <= interval {
+		interval = min(interval, ps.baseInterval)
+	}
+	// A scheduled interval is a floor: quiet hours win over a due reset.
+	if rule, ok := ps.scheduleLocked(accountID).Match(now); ok && !rule.Pause {
+		interval = max(interval, rule.Interval())
+	}
+	return interval
 }
 
 // RecordPoll records that a poll was executed. changed indicates whether the data
@@ -105,6 +207,8 @@ func (ps *PollScheduler) SnapshotChanged(accountID string, snap core.UsageSnapsh
 		ps.states[accountID] = state
 	}
 
+	state.nextResetAt = earliestFutureReset(snap, time.Now())
+
 	if state.lastSnapshotHash == "" || state.lastSnapshotHash != hash {
 		state.lastSnapshotHash = hash
 		return true
@@ -113,13 +217,37 @@ func (ps *PollScheduler) SnapshotChanged(accountID string, snap core.UsageSnapsh
 }
 
 func (ps *PollScheduler) effectiveIntervalLocked(state *pollBackoffState) time.Duration {
+	switch state.priority {
+	case core.PollPriorityHigh:
+		return ps.baseInterval
+	case core.PollPriorityLow:
+		// Backoff can stretch a low-priority account further, never less.
+		return max(ps.backoffIntervalLocked(state), ps.baseInterval*time.Duration(ps.lowEvery))
+	}
+	return ps.backoffIntervalLocked(state)
+}
+
+func (ps *PollScheduler) backoffIntervalLocked(state *pollBackoffState) time.Duration {
+	if !ps.adaptive {
+		return ps.baseInterval
+	}
+	// idle_cycles moves the first slowdown; later tiers keep their spacing.
+	shift := ps.idleCycles - defaultIdleCycles
 	multiplier := 1
 	for _, tier := range backoffTiers {
-		if state.consecutiveNoChange >= tier.minNoChange {
+		threshold := tier.minNoChange
+		if threshold > 0 {
+			threshold += shift
+		}
+		if state.consecutiveNoChange >= threshold {
 			multiplier = tier.multiplier
 		}
 	}
 
+	if ps.maxInterval > 0 {
+		return max(min(ps.baseInterval*time.Duration(multiplier), ps.maxInterval), ps.baseInterval)
+	}
+
 	maxMult := maxMultiplierHTTP
 	if state.hasLocalDetector {
 		maxMult = maxMultiplierLocal
@@ -131,6 +259,17 @@ func (ps *PollScheduler) effectiveIntervalLocked(state *pollBackoffState) time.D
 	return ps.baseInterval * time.Duration(multiplier)
 }
 
+// earliestFutureReset returns the soonest reset after now, or zero.
+func earliestFutureReset(snap core.UsageSnapshot, now time.Time) time.Time {
+	var next time.Time
+	for _, at := range snap.Resets {
+		if at.After(now) && (next.IsZero() || at.Before(next)) {
+			next = at
+		}
+	}
+	return next
+}
+
 func hashSnapshotMetrics(snap core.UsageSnapshot) string {
 	// Non-cryptographic hash for lightweight diff comparison (not security-sensitive).
 	h := fnv.New128a()
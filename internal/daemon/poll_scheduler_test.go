@@ -4,12 +4,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/janekbaraniewski/openusage/internal/config"
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
 
 func TestPollScheduler_ShouldPoll_FirstPollAlwaysRuns(t *testing.T) {
 	ps := newPollScheduler(30 * time.Second)
-	if !ps.ShouldPoll("acct1", false) {
+	if !ps.ShouldPoll("acct1", false, core.PollPriorityNormal) {
 		t.Error("first poll should always run")
 	}
 }
@@ -18,11 +19,11 @@ func TestPollScheduler_ShouldPoll_RespectsBaseInterval(t *testing.T) {
 	ps := newPollScheduler(30 * time.Second)
 
 	// First poll runs and records.
-	ps.ShouldPoll("acct1", false)
+	ps.ShouldPoll("acct1", false, core.PollPriorityNormal)
 	ps.RecordPoll("acct1", true) // changed
 
 	// Immediately after: should not poll again.
-	if ps.ShouldPoll("acct1", false) {
+	if ps.ShouldPoll("acct1", false, core.PollPriorityNormal) {
 		t.Error("should not poll immediately after previous poll")
 	}
 }
@@ -30,7 +31,7 @@ func TestPollScheduler_ShouldPoll_RespectsBaseInterval(t *testing.T) {
 func TestPollScheduler_BackoffTiers(t *testing.T) {
 	ps := newPollScheduler(30 * time.Second)
 
-	ps.ShouldPoll("acct1", false) // init
+	ps.ShouldPoll("acct1", false, core.PollPriorityNormal) // init
 
 	tests := []struct {
 		noChangeCount int
@@ -59,7 +60,7 @@ func TestPollScheduler_BackoffTiers(t *testing.T) {
 func TestPollScheduler_BackoffTiers_LocalProvider(t *testing.T) {
 	ps := newPollScheduler(30 * time.Second)
 
-	ps.ShouldPoll("acct1", true) // hasLocalDetector=true
+	ps.ShouldPoll("acct1", true, core.PollPriorityNormal) // hasLocalDetector=true
 
 	tests := []struct {
 		noChangeCount int
@@ -83,10 +84,67 @@ func TestPollScheduler_BackoffTiers_LocalProvider(t *testing.T) {
 	}
 }
 
+func TestPollScheduler_PollingConfig(t *testing.T) {
+	adaptive := false
+	tests := []struct {
+		name          string
+		cfg           config.PollingConfig
+		local         bool
+		noChangeCount int
+		wantInterval  time.Duration
+	}{
+		{"idle cycles delay first slowdown", config.PollingConfig{IdleCycles: 5}, false, 4, 30 * time.Second},
+		{"idle cycles first tier", config.PollingConfig{IdleCycles: 5}, false, 5, 60 * time.Second},
+		{"idle cycles keep tier spacing", config.PollingConfig{IdleCycles: 5}, false, 8, 120 * time.Second},
+		{"max interval lifts HTTP cap", config.PollingConfig{MaxIntervalSeconds: 300}, false, 21, 300 * time.Second},
+		{"max interval caps local", config.PollingConfig{MaxIntervalSeconds: 90}, true, 21, 90 * time.Second},
+		{"max interval below base", config.PollingConfig{MaxIntervalSeconds: 10}, false, 21, 30 * time.Second},
+		{"adaptive off", config.PollingConfig{Adaptive: &adaptive}, true, 21, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		ps := newPollScheduler(30 * time.Second).withPolling(tt.cfg)
+		ps.ShouldPoll("acct1", tt.local, core.PollPriorityNormal)
+		ps.mu.Lock()
+		ps.states["acct1"].consecutiveNoChange = tt.noChangeCount
+		got := ps.effectiveIntervalLocked(ps.states["acct1"])
+		ps.mu.Unlock()
+		if got != tt.wantInterval {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.wantInterval)
+		}
+	}
+}
+
+func TestPollScheduler_SnapsBackBeforeReset(t *testing.T) {
+	ps := newPollScheduler(30 * time.Second)
+	ps.ShouldPoll("acct1", true, core.PollPriorityNormal)
+
+	snap := core.UsageSnapshot{Status: core.StatusOK, Resets: map[string]time.Time{
+		"usage_five_hour": time.Now().Add(3 * time.Hour),
+	}}
+	ps.SnapshotChanged("acct1", snap)
+
+	// Backed off to 16x (8m); the last poll was 1m ago.
+	ps.mu.Lock()
+	ps.states["acct1"].consecutiveNoChange = 21
+	ps.states["acct1"].lastPollAt = time.Now().Add(-time.Minute)
+	ps.mu.Unlock()
+	if ps.ShouldPoll("acct1", true, core.PollPriorityNormal) {
+		t.Fatal("distant reset should not interrupt the backoff")
+	}
+
+	// A reset due inside the backed-off wait restores the base cadence.
+	snap.Resets["usage_five_hour"] = time.Now().Add(2 * time.Minute)
+	ps.SnapshotChanged("acct1", snap)
+	if !ps.ShouldPoll("acct1", true, core.PollPriorityNormal) {
+		t.Error("reset within the backoff window should snap back to the base interval")
+	}
+}
+
 func TestPollScheduler_ResetOnChange(t *testing.T) {
 	ps := newPollScheduler(30 * time.Second)
 
-	ps.ShouldPoll("acct1", false)
+	ps.ShouldPoll("acct1", false, core.PollPriorityNormal)
 
 	// Simulate 10 no-change polls.
 	for i := 0; i < 10; i++ {
@@ -156,4 +214,88 @@ func TestPollScheduler_UnknownAccount(t *testing.T) {
 	}
 }
 
+func TestPollScheduler_Priority(t *testing.T) {
+	ps := newPollScheduler(30 * time.Second).withPolling(config.PollingConfig{LowPriorityEvery: 5})
+	for _, id := range []string{"high", "normal", "low"} {
+		ps.ShouldPoll(id, false, core.PollPriority(id))
+		ps.RecordPoll(id, false)
+	}
+
+	tests := []struct {
+		id           string
+		noChange     int
+		wantInterval time.Duration
+	}{
+		{"high", 21, 30 * time.Second},    // never backs off
+		{"normal", 6, 120 * time.Second},  // adaptive 4x
+		{"low", 0, 150 * time.Second},     // every 5th tick
+		{"low", 21, 150 * time.Second},    // 4x HTTP backoff stays under 5x
+		{"normal", 0, 30 * time.Second},   // fresh change: base
+		{"high", 0, 30 * time.Second},     // fresh change: base
+		{"low", 3, 150 * time.Second},     // 2x backoff under 5x
+		{"normal", 21, 120 * time.Second}, // HTTP cap
+	}
+	for _, tt := range tests {
+		ps.mu.Lock()
+		ps.states[tt.id].consecutiveNoChange = tt.noChange
+		got := ps.effectiveIntervalLocked(ps.states[tt.id])
+		ps.mu.Unlock()
+		if got != tt.wantInterval {
+			t.Errorf("%s noChange=%d: got %s, want %s", tt.id, tt.noChange, got, tt.wantInterval)
+		}
+	}
+
+	ps.mu.Lock()
+	ps.states["low"].consecutiveNoChange = 0
+	lastPoll := ps.states["low"].lastPollAt
+	ps.mu.Unlock()
+	if got := ps.NextPollAt("low"); !got.Equal(lastPoll.Add(150 * time.Second)) {
+		t.Errorf("NextPollAt(low) = %v, want last poll + 150s", got)
+	}
+	if ps.ShouldPoll("low", false, core.PollPriorityLow) {
+		t.Error("low-priority account polled before its tick")
+	}
+	if !ps.NextPollAt("unknown").IsZero() {
+		t.Error("NextPollAt of an unpolled account should be zero")
+	}
+}
+
 func ptr(f float64) *float64 { return &f }
+
+func TestPollScheduler_Schedule(t *testing.T) {
+	allDay := func(rule core.PollScheduleRule) *core.PollSchedule {
+		return &core.PollSchedule{Rules: []core.PollScheduleRule{rule}}
+	}
+	ps := newPollScheduler(30 * time.Second).withPolling(config.PollingConfig{
+		Schedule: allDay(core.PollScheduleRule{IntervalSeconds: 600}),
+	})
+	ps.ShouldPoll("acct1", false, core.PollPriorityHigh)
+	ps.RecordPoll("acct1", true)
+
+	// The scheduled interval is a floor, even for high-priority accounts.
+	ps.mu.Lock()
+	ps.states["acct1"].lastPollAt = time.Now().Add(-5 * time.Minute)
+	ps.mu.Unlock()
+	if ps.ShouldPoll("acct1", false, core.PollPriorityHigh) {
+		t.Error("poll 5m after the last one, want the 10m scheduled interval to hold it back")
+	}
+	if wait := time.Until(ps.NextPollAt("acct1")); wait < 4*time.Minute || wait > 5*time.Minute {
+		t.Errorf("next poll in %s, want about 5m", wait)
+	}
+
+	// A paused account isn't polled however long it has waited.
+	ps.SetSchedule("acct1", allDay(core.PollScheduleRule{Pause: true}))
+	if ps.ShouldPoll("acct1", false, core.PollPriorityHigh) {
+		t.Error("paused account was due")
+	}
+
+	// An empty account schedule opts out of the global one.
+	ps.SetSchedule("acct1", &core.PollSchedule{Rules: []core.PollScheduleRule{}})
+	if !ps.ShouldPoll("acct1", false, core.PollPriorityHigh) {
+		t.Error("account without schedule rules was held back by the global schedule")
+	}
+	ps.SetSchedule("acct1", nil)
+	if ps.ShouldPoll("acct1", false, core.PollPriorityHigh) {
+		t.Error("clearing the account schedule should restore the global one")
+	}
+}
@@ -169,6 +169,56 @@ func (r *ViewRuntime) ReadWithFallbackForWindow(ctx context.Context, timeWindow
 	return frame
 }
 
+// PollNow has the daemon fetch accountIDs (every enabled account when
+// empty) immediately. Callers re-read the read model afterwards to pick up
+// the results.
+func (r *ViewRuntime) PollNow(ctx context.Context, accountIDs []string) error {
+	if r == nil {
+		return errDaemonUnavailable
+	}
+	client := r.CurrentClient()
+	if client == nil {
+		client = r.EnsureClient(ctx)
+	}
+	if client == nil {
+		return errDaemonUnavailable
+	}
+	_, err := client.Poll(ctx, PollRequest{AccountIDs: accountIDs})
+	return err
+}
+
+// SetSpendLimit has the daemon change accountID's spending cap; nil removes
+// it.
+func (r *ViewRuntime) SetSpendLimit(ctx context.Context, accountID string, limitUSD *float64) error {
+	if r == nil {
+		return errDaemonUnavailable
+	}
+	client := r.CurrentClient()
+	if client == nil {
+		client = r.EnsureClient(ctx)
+	}
+	if client == nil {
+		return errDaemonUnavailable
+	}
+	return client.SetSpendLimit(ctx, accountID, limitUSD)
+}
+
+// PreviewSpendLimit has the daemon name what a change to accountID's
+// spending cap would apply to.
+func (r *ViewRuntime) PreviewSpendLimit(ctx context.Context, accountID string) (string, error) {
+	if r == nil {
+		return "", errDaemonUnavailable
+	}
+	client := r.CurrentClient()
+	if client == nil {
+		client = r.EnsureClient(ctx)
+	}
+	if client == nil {
+		return "", errDaemonUnavailable
+	}
+	return client.PreviewSpendLimit(ctx, accountID)
+}
+
 func (r *ViewRuntime) fetchReadModel(
 	ctx context.Context,
 	client *Client,
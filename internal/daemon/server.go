@@ -17,9 +17,13 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/janekbaraniewski/openusage/internal/audit"
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/exporter"
+	"github.com/janekbaraniewski/openusage/internal/notify"
 	"github.com/janekbaraniewski/openusage/internal/providers"
+	"github.com/janekbaraniewski/openusage/internal/providers/chaos"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 	"github.com/janekbaraniewski/openusage/internal/telemetry"
 )
 
@@ -30,20 +34,39 @@ type Service struct {
 	store        *telemetry.Store
 	pipeline     *telemetry.Pipeline
 	quotaIngest  *telemetry.QuotaSnapshotIngestor
+	providersMu  sync.RWMutex // guards providerByID, swapped when custom providers are reloaded
 	providerByID map[string]core.UsageProvider
 	exp          *exporter.Exporter
+	notifier     *notify.Router // nil when no notification routes are configured
+	hook         *notify.Hook   // nil when no on_event hook is configured
+	hookMu       sync.Mutex     // serializes event tracking and hook runs
+	events       *notify.EventTracker
+	capacityMu   sync.Mutex // serializes capacity file writes
 
 	spoolMu     sync.Mutex // guards spool filesystem operations (read/write/cleanup)
 	logThrottle *core.LogThrottle
 
 	rmCache       *readModelCache
+	hub           *snapshotHub // /v1/stream subscribers
 	dataIngested  atomic.Bool  // set when new data is ingested; read model loop skips refresh when clean
 	lastIngestAt  atomic.Int64 // UnixNano of the most recent ingest; lets readers refresh only when data changed
+	liveRunning   atomic.Bool  // the last read model had a session in progress; collect and refresh run faster
 	pollScheduler *PollScheduler
 
 	pollStateMu sync.Mutex
 	pollState   map[string]*providerPollState // per-account change detection state
 
+	derivedMu sync.RWMutex
+	derived   []core.DerivedMetric // compiled at start, replaced when a poll reloads the config
+
+	retryMu      sync.Mutex
+	retryPending map[string]bool // accounts with a fetch retry scheduled
+
+	// conn reports whether provider endpoints are reachable; nil means
+	// shared.Connectivity. offline is the state last logged.
+	conn    *shared.ConnectivityTracker
+	offline atomic.Bool
+
 	// clock provides the wall-clock used for snapshot timestamps and any
 	// state that needs to be reproducible in tests. Defaults to
 	// core.SystemClock{}; tests can override via WithClock.
@@ -78,8 +101,12 @@ func (s *Service) ingestedSince(t time.Time) bool {
 }
 
 func RunServer(cfg Config) error {
-	if !cfg.Verbose {
-		log.SetOutput(io.Discard)
+	// The ring always captures the log so `openusage logs` works without
+	// restarting the daemon in debug mode; stderr only gets it when verbose.
+	if cfg.Verbose {
+		log.SetOutput(io.MultiWriter(os.Stderr, core.DebugLog))
+	} else {
+		log.SetOutput(core.DebugLog)
 	}
 
 	// Pull in API keys / settings captured at `daemon install` time. No-op when
@@ -88,6 +115,10 @@ func RunServer(cfg Config) error {
 	// task, which can't inject an env file itself.
 	LoadServiceEnv()
 
+	// Time provider HTTP calls per endpoint; polls attach the results to
+	// each snapshot as endpoint_* metrics.
+	shared.InstallEndpointInstrumentation()
+
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
@@ -154,13 +185,15 @@ func startService(ctx context.Context, cfg Config) (*Service, error) {
 		store:         store,
 		pipeline:      telemetry.NewPipeline(store, telemetry.NewSpool(cfg.SpoolDir)),
 		quotaIngest:   telemetry.NewQuotaSnapshotIngestor(store),
-		providerByID:  providersByID(),
+		providerByID:  providersByID(cfg.Chaos),
 		exp:           exp,
 		logThrottle:   core.NewLogThrottle(200, 10*time.Minute),
 		rmCache:       newReadModelCache(),
-		pollScheduler: newPollScheduler(cfg.PollInterval),
+		hub:           newSnapshotHub(),
+		pollScheduler: newPollScheduler(cfg.PollInterval).withPolling(cfg.Polling),
 		pollState:     make(map[string]*providerPollState),
 		clock:         core.SystemClock{},
+		derived:       DerivedMetricsFromConfig(),
 	}
 
 	svc.infof(
@@ -175,10 +208,14 @@ func startService(ctx context.Context, cfg Config) (*Service, error) {
 		len(svc.providerByID),
 	)
 
+	svc.notifier = svc.buildNotifier()
+	svc.hook = svc.buildHook()
+
 	if err := svc.startSocketServer(ctx); err != nil {
 		_ = store.Close()
 		return nil, err
 	}
+	svc.startIngestServer(ctx)
 
 	go telemetry.RunWALCheckpointLoop(ctx, store.DB(), cfg.DBPath, func(key, level, msg string) {
 		switch level {
@@ -195,6 +232,7 @@ func startService(ctx context.Context, cfg Config) (*Service, error) {
 	go svc.runSpoolMaintenanceLoop(ctx)
 	go svc.runHookSpoolLoop(ctx)
 	go svc.runRetentionLoop(ctx)
+	go svc.runPricingRefreshLoop(ctx)
 
 	if svc.exp != nil {
 		go svc.exp.Start(ctx)
@@ -204,6 +242,7 @@ func startService(ctx context.Context, cfg Config) (*Service, error) {
 }
 
 func (s *Service) Close() error {
+	_ = audit.Default().Flush()
 	if s == nil || s.store == nil {
 		return nil
 	}
@@ -238,7 +277,7 @@ func (s *Service) recordBalanceObservations(ctx context.Context, snapshots map[s
 		return
 	}
 	for _, snap := range snapshots {
-		provider, ok := s.providerByID[snap.ProviderID]
+		provider, ok := s.provider(snap.ProviderID)
 		if !ok {
 			continue
 		}
@@ -339,7 +378,12 @@ func (s *Service) startSocketServer(ctx context.Context) error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthz", s.handleHealth)
 	mux.HandleFunc("/v1/hook/", s.handleHook)
+	mux.HandleFunc("/v1/ingest", s.handleIngest)
 	mux.HandleFunc("/v1/read-model", s.handleReadModel)
+	mux.HandleFunc("/v1/stream", s.handleStream)
+	mux.HandleFunc("/v1/poll", s.handlePoll)
+	mux.HandleFunc("/v1/logs", s.handleLogs)
+	mux.HandleFunc("/v1/spend-limit", s.handleSpendLimit)
 
 	server := &http.Server{
 		Handler:           mux,
@@ -405,9 +449,22 @@ func EnsureSocketPathAvailable(socketPath string) error {
 
 // --- Helpers ---
 
-func providersByID() map[string]core.UsageProvider {
+// provider returns the registered provider with the given ID.
+func (s *Service) provider(id string) (core.UsageProvider, bool) {
+	s.providersMu.RLock()
+	defer s.providersMu.RUnlock()
+	provider, ok := s.providerByID[id]
+	return provider, ok
+}
+
+func providersByID(chaosCfg *chaos.Config) map[string]core.UsageProvider {
+	all := providers.AllProviders()
+	if chaosCfg != nil {
+		log.Printf("[daemon] chaos mode: injecting provider failures (%s)", chaosCfg)
+		all = chaos.Wrap(all, *chaosCfg)
+	}
 	out := make(map[string]core.UsageProvider)
-	for _, provider := range providers.AllProviders() {
+	for _, provider := range all {
 		out[provider.ID()] = provider
 	}
 	return out
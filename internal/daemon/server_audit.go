@@ -0,0 +1,30 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/janekbaraniewski/openusage/internal/audit"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// auditCredentials records where the account's configured credential comes
+// from before a fetch. Reads providers make on their own (keychains, cookie
+// stores, IDE credential files) are recorded by the provider under ctx.
+func auditCredentials(ctx context.Context, account core.AccountConfig) {
+	switch {
+	case account.Token != "":
+		audit.Credential(ctx, account.Hint("credential_source", "detected token"))
+	case account.APIKeyEnv != "" && strings.TrimSpace(os.Getenv(account.APIKeyEnv)) != "":
+		audit.Credential(ctx, "env:"+account.APIKeyEnv)
+	}
+}
+
+// auditEndpoints records the calls a fetch made, one entry per endpoint.
+func auditEndpoints(account core.AccountConfig, stats []core.EndpointStat) {
+	log := audit.Default()
+	for _, stat := range stats {
+		log.Record(audit.KindEndpoint, account.Provider, account.ID, stat.Label, stat.Requests, stat.Errors)
+	}
+}
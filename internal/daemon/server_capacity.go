@@ -0,0 +1,24 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/capacity"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// writeCapacityFile rewrites the capacity file from a freshly refreshed
+// snapshot set. Failures are logged at most once a minute; the next refresh
+// tries again.
+func (s *Service) writeCapacityFile(snaps map[string]core.UsageSnapshot) {
+	if s.cfg.CapacityFile == "" || len(snaps) == 0 {
+		return
+	}
+	s.capacityMu.Lock()
+	defer s.capacityMu.Unlock()
+	if err := capacity.Write(s.cfg.CapacityFile, capacity.Build(snaps, s.now())); err != nil {
+		if s.shouldLog("capacity_file_error", time.Minute) {
+			s.warnf("capacity_file_error", "path=%s error=%v", s.cfg.CapacityFile, err)
+		}
+	}
+}
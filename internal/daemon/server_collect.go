@@ -22,7 +22,7 @@ func (s *Service) runCollectLoop(ctx context.Context) {
 		case <-ctx.Done():
 			s.infof("collect_loop_stop", "reason=context_done")
 			return
-		case <-time.After(interval):
+		case <-time.After(s.collectWait(interval)):
 			collected := s.collectAndFlush(ctx)
 			if collected == 0 {
 				consecutiveEmpty++
@@ -47,6 +47,15 @@ func (s *Service) runCollectLoop(ctx context.Context) {
 	}
 }
 
+// collectWait is the wait before the next collect cycle: interval, or the
+// live cadence while a session is in progress.
+func (s *Service) collectWait(interval time.Duration) time.Duration {
+	if s.liveRunning.Load() {
+		return min(interval, liveSessionInterval)
+	}
+	return interval
+}
+
 // pushToExporter forwards a freshly-computed snapshot set to the remote-hub
 // exporter, if one is configured.
 //
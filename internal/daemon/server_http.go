@@ -3,6 +3,7 @@ package daemon
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -24,8 +25,15 @@ func (s *Service) handleHealth(w http.ResponseWriter, _ *http.Request) {
 	})
 }
 
+func (s *Service) handleLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, LogsResponse{Lines: core.DebugLog.Lines()})
+}
+
 func (s *Service) handleHook(w http.ResponseWriter, r *http.Request) {
-	started := time.Now()
 	if r.Method != http.MethodPost {
 		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
@@ -37,7 +45,13 @@ func (s *Service) handleHook(w http.ResponseWriter, r *http.Request) {
 		writeJSONError(w, http.StatusBadRequest, "missing hook source")
 		return
 	}
+	s.serveHookIngest(w, r, sourceName)
+}
 
+// serveHookIngest parses a POSTed payload as sourceName's hook format and
+// ingests the resulting events.
+func (s *Service) serveHookIngest(w http.ResponseWriter, r *http.Request, sourceName string) {
+	started := time.Now()
 	payload, err := io.ReadAll(r.Body)
 	if err != nil {
 		writeJSONError(w, http.StatusBadRequest, "read payload failed")
@@ -141,6 +155,7 @@ func (s *Service) handleReadModel(w http.ResponseWriter, r *http.Request) {
 	cancel()
 	if err == nil && len(snapshots) > 0 {
 		s.rmCache.set(cacheKey, snapshots)
+		s.hub.publish(cacheKey, snapshots)
 		writeJSON(w, http.StatusOK, ReadModelResponse{Snapshots: snapshots})
 		return
 	}
@@ -170,6 +185,43 @@ func (s *Service) handleReadModel(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// forcedPollTimeout bounds a user-requested poll: one provider fetch (8s)
+// plus ingest.
+const forcedPollTimeout = 25 * time.Second
+
+func (s *Service) handlePoll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var req PollRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("decode poll request: %v", err))
+		return
+	}
+
+	// The server's write timeout is sized for reads; a forced poll waits on
+	// providers, so give this response longer.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(forcedPollTimeout + 5*time.Second))
+	ctx, cancel := context.WithTimeout(r.Context(), forcedPollTimeout)
+	defer cancel()
+
+	polled, err := s.pollAccounts(ctx, req.AccountIDs, true)
+	if errors.Is(err, errNoMatchingAccounts) {
+		writeJSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	// Cached read models predate this poll; drop them so the caller's next
+	// read sees the fresh snapshots.
+	s.rmCache.invalidate()
+	s.infof("poll_forced", "accounts=%d polled=%d", len(req.AccountIDs), polled)
+	writeJSON(w, http.StatusOK, PollResponse{Polled: polled})
+}
+
 func writeJSON(w http.ResponseWriter, status int, payload any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -0,0 +1,123 @@
+package daemon
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/webhook"
+)
+
+// EnvIngestToken is the Bearer token the ingest listener requires. Like the
+// hub token it is read from the environment only.
+const EnvIngestToken = "OPENUSAGE_INGEST_TOKEN"
+
+// maxIngestBodyBytes bounds one pushed payload.
+const maxIngestBodyBytes = 4 << 20
+
+// handleIngest accepts usage events pushed by gateways and proxies in the
+// webhook provider's schema. ?account_id= picks the webhook account when
+// several are configured.
+func (s *Service) handleIngest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxIngestBodyBytes)
+	s.serveHookIngest(w, r, webhook.ID)
+}
+
+// startIngestServer serves /v1/ingest on cfg.IngestListen so gateways that
+// can't reach the unix socket can push events. A listener on a non-loopback
+// address without a token would let anyone write usage into the store, so
+// that configuration is refused with a warning rather than served.
+func (s *Service) startIngestServer(ctx context.Context) {
+	addr := strings.TrimSpace(s.cfg.IngestListen)
+	if addr == "" {
+		return
+	}
+	token := strings.TrimSpace(s.cfg.IngestToken)
+	if token == "" && !core.IsLoopbackAddr(addr) {
+		s.warnf("ingest_refused", "addr=%s reason=non-loopback listener needs %s", addr, EnvIngestToken)
+		return
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		s.warnf("ingest_listen_failed", "addr=%s error=%v", addr, err)
+		return
+	}
+	s.infof("ingest_listening", "addr=%s auth=%t", listener.Addr(), token != "")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.handleHealth)
+	ingest := requireBearer(token, http.HandlerFunc(s.handleIngest))
+	mux.Handle("/v1/ingest", rejectBrowserRequests(addr, token == "", ingest))
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      15 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.warnf("ingest_server_error", "error=%v", err)
+		}
+	}()
+}
+
+// rejectBrowserRequests keeps web pages from writing into the store through
+// the ingest listener. Gateways send JSON without an Origin, while a
+// cross-site form or fetch either carries an Origin or, for a JSON body,
+// needs a CORS preflight the listener never answers. Without a token the
+// Host must also name this listener, which defeats DNS rebinding.
+func rejectBrowserRequests(listenAddr string, checkHost bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Origin") != "" {
+			writeJSONError(w, http.StatusForbidden, "cross-origin requests are not accepted")
+			return
+		}
+		if checkHost && !core.IsLocalHostHeader(r.Host, listenAddr) {
+			writeJSONError(w, http.StatusForbidden, "unexpected Host header")
+			return
+		}
+		if r.Method == http.MethodPost {
+			if mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mt != "application/json" {
+				writeJSONError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireBearer rejects requests without "Authorization: Bearer <token>".
+// An empty token disables the check.
+func requireBearer(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(got)), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="openusage-ingest"`)
+			writeJSONError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
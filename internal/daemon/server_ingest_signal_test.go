@@ -3,6 +3,8 @@ package daemon
 import (
 	"testing"
 	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
 )
 
 func TestIngestedSinceReportsFreshDataOnly(t *testing.T) {
@@ -43,3 +45,28 @@ func TestIngestedSinceRequiresStrictlyNewerData(t *testing.T) {
 		t.Fatal("ingestedSince(older timestamp) = false, want true")
 	}
 }
+
+func TestLiveSessionSpeedsUpCollection(t *testing.T) {
+	s := &Service{}
+	idle := map[string]core.UsageSnapshot{"codex": {Raw: map[string]string{"live_session_status": "idle"}}}
+	running := map[string]core.UsageSnapshot{
+		"codex":       {Raw: map[string]string{"live_session_status": "idle"}},
+		"claude-code": {Raw: map[string]string{"live_session_status": core.LiveSessionRunning}},
+	}
+
+	s.setLiveRunning(idle)
+	if got := s.collectWait(20 * time.Second); got != 20*time.Second {
+		t.Errorf("collectWait without a running session = %s, want 20s", got)
+	}
+	s.setLiveRunning(running)
+	if got := s.collectWait(20 * time.Second); got != liveSessionInterval {
+		t.Errorf("collectWait with a running session = %s, want %s", got, liveSessionInterval)
+	}
+	if got := s.collectWait(time.Second); got != time.Second {
+		t.Errorf("collectWait must not slow down a faster interval: got %s", got)
+	}
+	s.setLiveRunning(idle)
+	if s.liveRunning.Load() {
+		t.Error("liveRunning still set after the session stopped")
+	}
+}
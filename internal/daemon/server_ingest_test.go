@@ -0,0 +1,70 @@
+package daemon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireBearer(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	h := requireBearer("s3cret", ok)
+
+	for header, want := range map[string]int{
+		"":              http.StatusUnauthorized,
+		"Bearer wrong":  http.StatusUnauthorized,
+		"s3cret":        http.StatusUnauthorized,
+		"Bearer s3cret": http.StatusNoContent,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/ingest", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("Authorization %q: status = %d, want %d", header, rec.Code, want)
+		}
+	}
+
+	if requireBearer("", ok) == nil {
+		t.Fatal("an empty token should pass requests through")
+	}
+}
+
+func TestRejectBrowserRequests(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })
+
+	tests := []struct {
+		name        string
+		checkHost   bool
+		host        string
+		origin      string
+		contentType string
+		want        int
+	}{
+		{name: "gateway", checkHost: true, host: "127.0.0.1:9310", contentType: "application/json", want: http.StatusNoContent},
+		{name: "charset", checkHost: true, host: "localhost:9310", contentType: "application/json; charset=utf-8", want: http.StatusNoContent},
+		{name: "origin", checkHost: true, host: "127.0.0.1:9310", origin: "https://evil.example", contentType: "application/json", want: http.StatusForbidden},
+		{name: "form post", checkHost: true, host: "127.0.0.1:9310", contentType: "text/plain", want: http.StatusUnsupportedMediaType},
+		{name: "no content type", checkHost: true, host: "127.0.0.1:9310", want: http.StatusUnsupportedMediaType},
+		{name: "rebinding", checkHost: true, host: "evil.example:9310", contentType: "application/json", want: http.StatusForbidden},
+		{name: "token listener", host: "gateway.internal:9310", contentType: "application/json", want: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		h := rejectBrowserRequests("127.0.0.1:9310", tt.checkHost, ok)
+		req := httptest.NewRequest(http.MethodPost, "/v1/ingest", nil)
+		req.Host = tt.host
+		if tt.origin != "" {
+			req.Header.Set("Origin", tt.origin)
+		}
+		if tt.contentType != "" {
+			req.Header.Set("Content-Type", tt.contentType)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.want)
+		}
+	}
+}
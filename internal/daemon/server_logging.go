@@ -1,31 +1,37 @@
 package daemon
 
 import (
+	"fmt"
 	"log"
 	"strings"
 	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
 )
 
 func (s *Service) infof(event, format string, args ...any) {
-	if s == nil || !s.cfg.Verbose {
-		return
-	}
-	if strings.TrimSpace(format) == "" {
-		log.Printf("daemon level=info event=%s", event)
-		return
-	}
-	log.Printf("daemon level=info event=%s "+format, append([]any{event}, args...)...)
+	s.logEvent("info", event, format, args...)
 }
 
 func (s *Service) warnf(event, format string, args ...any) {
-	if s == nil || !s.cfg.Verbose {
+	s.logEvent("warn", event, format, args...)
+}
+
+// logEvent writes a structured daemon log line. Verbose daemons log it as
+// usual; quiet ones still keep it in core.DebugLog for `openusage logs`.
+func (s *Service) logEvent(level, event, format string, args ...any) {
+	if s == nil {
 		return
 	}
-	if strings.TrimSpace(format) == "" {
-		log.Printf("daemon level=warn event=%s", event)
+	line := fmt.Sprintf("daemon level=%s event=%s", level, event)
+	if strings.TrimSpace(format) != "" {
+		line += " " + fmt.Sprintf(format, args...)
+	}
+	if s.cfg.Verbose {
+		log.Print(line)
 		return
 	}
-	log.Printf("daemon level=warn event=%s "+format, append([]any{event}, args...)...)
+	fmt.Fprintf(core.DebugLog, "%s %s\n", time.Now().Format("2006/01/02 15:04:05"), line)
 }
 
 func (s *Service) shouldLog(key string, interval time.Duration) bool {
@@ -0,0 +1,62 @@
+package daemon
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestHandleLogs_RejectsPost(t *testing.T) {
+	s := &Service{}
+	rec := httptest.NewRecorder()
+	s.handleLogs(rec, httptest.NewRequest(http.MethodPost, "/v1/logs", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestClientLogs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets are not supported in this test")
+	}
+
+	socketPath := shortSocketPath(t, "logs")
+	_ = os.Remove(socketPath)
+	t.Cleanup(func() { _ = os.Remove(socketPath) })
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("listen unix socket: %v", err)
+	}
+	want := []string{"poll_start accounts=2", "poll_complete fetched=2"}
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, LogsResponse{Lines: want})
+	})}
+	go func() { _ = srv.Serve(listener) }()
+	defer srv.Close()
+
+	got, err := NewClient(socketPath).Logs(context.Background())
+	if err != nil {
+		t.Fatalf("Logs() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Logs() = %q, want %q", got, want)
+	}
+}
+
+func TestQuietDaemonKeepsEventsInDebugLog(t *testing.T) {
+	s := &Service{}
+	s.warnf("poll_ingest_warning", "error=%s", "quiet-daemon-marker")
+
+	lines := core.DebugLog.Lines()
+	if len(lines) == 0 || !strings.Contains(lines[len(lines)-1], "event=poll_ingest_warning error=quiet-daemon-marker") {
+		t.Fatalf("DebugLog tail = %q, want the warn event", lines)
+	}
+}
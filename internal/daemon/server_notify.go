@@ -0,0 +1,104 @@
+package daemon
+
+import (
+	"context"
+	"sort"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/notify"
+)
+
+// buildNotifier compiles the notification routing rules. Invalid pieces are
+// logged and skipped; nil means nothing is routed anywhere.
+func (s *Service) buildNotifier() *notify.Router {
+	router, errs := notify.NewRouter(s.cfg.Notifications)
+	for _, err := range errs {
+		s.warnf("notify_config", "error=%v", err)
+	}
+	if !router.Enabled() {
+		return nil
+	}
+	s.infof("notify_start", "sinks=%d rules=%d", len(s.cfg.Notifications.Sinks), len(s.cfg.Notifications.Rules))
+	return router
+}
+
+// notifyAlerts evaluates a freshly refreshed snapshot set and sends any new
+// alerts. Like pushToExporter it runs from the read-model refresh, so it sees
+// exactly what the dashboard shows.
+func (s *Service) notifyAlerts(ctx context.Context, snaps map[string]core.UsageSnapshot) {
+	if s.notifier == nil || len(snaps) == 0 {
+		return
+	}
+	now := s.now()
+	accounts := make([]string, 0, len(snaps))
+	for id := range snaps {
+		accounts = append(accounts, id)
+	}
+	sort.Strings(accounts)
+	alerts := notify.Evaluate(snaps, s.cfg.AlertThresholds, now)
+	if len(alerts) > 0 {
+		alerts = notify.DropSnoozedAlerts(alerts, warningSnoozes(), now)
+	}
+	for _, d := range s.notifier.Dispatch(ctx, alerts, accounts, now) {
+		if d.Err != nil {
+			s.warnf("notify_failed", "sink=%s alert=%s error=%v", d.Sink, d.Alert.Key, d.Err)
+			continue
+		}
+		s.infof("notify_sent", "sink=%s alert=%s severity=%s", d.Sink, d.Alert.Key, d.Alert.Severity)
+	}
+}
+
+// buildHook compiles the on_event hook; nil when none is configured or the
+// configuration is invalid.
+func (s *Service) buildHook() *notify.Hook {
+	cfg := s.cfg.Notifications.OnEvent
+	if cfg == nil {
+		return nil
+	}
+	hook, err := notify.NewHook(*cfg)
+	if err != nil {
+		s.warnf("hook_config", "error=%v", err)
+		return nil
+	}
+	s.events = notify.NewEventTracker(s.cfg.AlertThresholds)
+	s.infof("hook_start", "command=%s", cfg.Command[0])
+	return hook
+}
+
+// runEventHook diffs snaps against the previous refresh and runs the hook for
+// each resulting event. Runs are serialized so a script sees an account's
+// events in order.
+func (s *Service) runEventHook(ctx context.Context, snaps map[string]core.UsageSnapshot) {
+	if s.hook == nil || len(snaps) == 0 {
+		return
+	}
+	s.hookMu.Lock()
+	defer s.hookMu.Unlock()
+	now := s.now()
+	events := s.events.Observe(snaps, now)
+	if len(events) > 0 {
+		events = notify.DropSnoozedEvents(events, warningSnoozes(), now)
+	}
+	for _, e := range events {
+		if !s.hook.Wants(e.Type) {
+			continue
+		}
+		if err := s.hook.Run(ctx, e); err != nil {
+			s.warnf("hook_failed", "event=%s account=%s metric=%s error=%v", e.Type, e.AccountID, e.Metric, err)
+			continue
+		}
+		s.infof("hook_ran", "event=%s account=%s metric=%s", e.Type, e.AccountID, e.Metric)
+	}
+}
+
+// warningSnoozes returns the warnings acknowledged on the dashboard. The TUI
+// writes them while the daemon runs, so they are read from the config on
+// every dispatch rather than taken from the daemon's startup config.
+func warningSnoozes() []config.WarningSnooze {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	return cfg.Dashboard.Snoozes
+}
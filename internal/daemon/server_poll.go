@@ -2,11 +2,15 @@ package daemon
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/janekbaraniewski/openusage/internal/audit"
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
 
 func (s *Service) runPollLoop(ctx context.Context) {
@@ -26,29 +30,51 @@ func (s *Service) runPollLoop(ctx context.Context) {
 	}
 }
 
+// pollProviders runs one poll cycle. When pricing or provider files were
+// edited since the last tick, every account is fetched so costs and derived
+// metrics reflect them straight away.
 func (s *Service) pollProviders(ctx context.Context) {
+	reloaded := s.reloadCatalogs()
+	_, _ = s.pollAccounts(ctx, nil, reloaded)
+	s.recordCatalog()
+}
+
+// pollAccounts fetches the enabled accounts, or only those listed in only,
+// and ingests the results. force skips adaptive backoff and change
+// detection; it is set for user-requested refreshes, which must hit the
+// provider even when the schedule says the data can't have moved. Returns
+// how many accounts were fetched.
+func (s *Service) pollAccounts(ctx context.Context, only []string, force bool) (int, error) {
 	if s == nil || s.quotaIngest == nil {
-		return
+		return 0, fmt.Errorf("quota ingestor unavailable")
 	}
 	started := time.Now()
 
-	accounts, modelNorm, err := LoadAccountsAndNorm()
+	accounts, modelNorm, derived, err := LoadAccountsAndNorm()
 	if err != nil {
 		if s.shouldLog("poll_config_warning", 20*time.Second) {
 			s.warnf("poll_config_warning", "error=%v", err)
 		}
-		return
+		return 0, err
+	}
+	s.setDerivedMetrics(derived)
+	if len(only) > 0 {
+		accounts = filterAccountsByID(accounts, only)
+		if len(accounts) == 0 {
+			return 0, fmt.Errorf("%w %s", errNoMatchingAccounts, strings.Join(only, ", "))
+		}
 	}
 	if len(accounts) == 0 {
 		if s.shouldLog("poll_no_accounts", 30*time.Second) {
 			s.infof("poll_skipped", "reason=no_enabled_accounts")
 		}
-		return
+		return 0, nil
 	}
 
 	type providerResult struct {
 		accountID string
 		snapshot  core.UsageSnapshot
+		fetched   bool // false when served from cache (backoff or unchanged source)
 	}
 
 	results := make(chan providerResult, len(accounts))
@@ -69,7 +95,7 @@ func (s *Service) pollProviders(ctx context.Context) {
 			default:
 			}
 
-			provider, ok := s.providerByID[account.Provider]
+			provider, ok := s.provider(account.Provider)
 			if !ok {
 				results <- providerResult{
 					accountID: account.ID,
@@ -84,10 +110,28 @@ func (s *Service) pollProviders(ctx context.Context) {
 				return
 			}
 
+			if err := core.ValidateProviderOptions(account.Provider, provider.Spec().Options, account.ProviderOptions); err != nil {
+				results <- providerResult{
+					accountID: account.ID,
+					snapshot:  core.FetchErrorSnapshot(account.Provider, account.ID, s.now().UTC(), err),
+				}
+				return
+			}
+			account, err := core.ResolveLocalData(account, provider.Spec().LocalData)
+			if err != nil {
+				results <- providerResult{
+					accountID: account.ID,
+					snapshot:  core.FetchErrorSnapshot(account.Provider, account.ID, s.now().UTC(), err),
+				}
+				return
+			}
+
 			_, hasDetector := provider.(core.ChangeDetector)
 
-			// Adaptive backoff: skip providers that are in a backoff window.
-			if !s.pollScheduler.ShouldPoll(account.ID, hasDetector) {
+			// Adaptive backoff and the poll schedule: skip providers that
+			// are in a backoff window or paused for quiet hours.
+			s.pollScheduler.SetSchedule(account.ID, account.PollSchedule)
+			if !force && !s.pollScheduler.ShouldPoll(account.ID, hasDetector, account.PollPriority()) {
 				s.pollStateMu.Lock()
 				state := s.pollState[account.ID]
 				s.pollStateMu.Unlock()
@@ -99,41 +143,106 @@ func (s *Service) pollProviders(ctx context.Context) {
 			}
 
 			// Check if provider data has changed since last fetch (optional interface).
-			if cached := s.skipUnchangedProvider(provider, account); cached != nil {
-				s.pollScheduler.RecordPoll(account.ID, false)
-				results <- providerResult{accountID: account.ID, snapshot: *cached}
-				return
+			if !force {
+				if cached := s.skipUnchangedProvider(provider, account); cached != nil {
+					s.pollScheduler.RecordPoll(account.ID, false)
+					results <- providerResult{accountID: account.ID, snapshot: *cached}
+					return
+				}
 			}
 
 			fetchCtx, cancel := context.WithTimeout(ctx, 8*time.Second)
 			defer cancel()
+			endpoints := shared.NewEndpointRecorder()
+			fetchCtx = shared.WithEndpointRecorder(fetchCtx, endpoints)
+			fetchCtx = shared.WithNetwork(fetchCtx, account.Network)
+			fetchCtx = audit.WithAccount(fetchCtx, account.Provider, account.ID)
+			auditCredentials(fetchCtx, account)
 
+			fetchStarted := time.Now()
 			snap, fetchErr := provider.Fetch(fetchCtx, account)
 			if fetchErr != nil {
-				snap = core.UsageSnapshot{
-					ProviderID: account.Provider,
-					AccountID:  account.ID,
-					Timestamp:  s.now().UTC(),
-					Status:     core.StatusError,
-					Message:    fetchErr.Error(),
+				snap = core.FetchErrorSnapshot(account.Provider, account.ID, s.now().UTC(), fetchErr)
+			}
+			// The engine tile reading its own stats is not a provider fetch.
+			if account.Provider != core.EngineProviderID {
+				core.Engine.RecordFetch(time.Since(fetchStarted), snap.Status == core.StatusError)
+				auditEndpoints(account, endpoints.Stats())
+			}
+
+			// Without a network every remote fetch fails. Keep serving what
+			// was fetched last — or, after a restart, what the store holds —
+			// rather than replacing good data with errors.
+			if snap.Status == core.StatusError && s.connectivity().State().Offline {
+				s.pollStateMu.Lock()
+				state := s.pollState[account.ID]
+				s.pollStateMu.Unlock()
+				if state != nil && state.hasSnap {
+					results <- providerResult{accountID: account.ID, snapshot: state.lastSnap}
 				}
+				return
 			}
 			snap = core.NormalizeUsageSnapshotWithConfig(snap, modelNorm)
+			snap = core.ApplyDerivedMetrics(snap, derived)
+			applyModelRetirements(ctx, &snap)
+
+			s.pollStateMu.Lock()
+			var prev *core.UsageSnapshot
+			var history core.FetchHistory
+			if state := s.pollState[account.ID]; state != nil {
+				history = state.history
+				if state.hasSnap {
+					prevSnap := state.lastSnap
+					prev = &prevSnap
+				}
+			}
+			s.pollStateMu.Unlock()
+			snap, anomalies := core.ApplySnapshotValidation(snap, prev)
+			for _, a := range anomalies {
+				if s.shouldLog("snapshot_anomaly:"+account.ID+":"+a.Key, 10*time.Minute) {
+					s.warnf("snapshot_anomaly", "provider=%s account=%s key=%s kind=%s detail=%q", account.Provider, account.ID, a.Key, a.Kind, a.Detail)
+				}
+			}
+			for _, d := range snap.Deprecations() {
+				if s.shouldLog("provider_deprecation:"+account.ID+":"+d.Feature, time.Hour) {
+					s.warnf("provider_deprecation", "provider=%s account=%s feature=%s message=%q", account.Provider, account.ID, d.Feature, d.Message)
+				}
+			}
 
 			// Track whether data actually changed for adaptive backoff.
 			changed := s.pollScheduler.SnapshotChanged(account.ID, snap)
 			s.pollScheduler.RecordPoll(account.ID, changed)
 
+			// Endpoint latency differs on every poll, so it's attached after
+			// the change check to keep it from defeating the backoff.
+			core.ApplyEndpointStats(&snap, endpoints.Stats())
+			failedBefore := len(history) > 0 && !history[len(history)-1]
+			history = history.Record(snap.Status != core.StatusError)
+			snap.SetFetchHistory(history)
+
+			// A first failure is often transient: retry the account soon and
+			// keep showing its last good data meanwhile. A failed retry is
+			// reported as an error.
+			kept := snap
+			if snap.Status == core.StatusError && !failedBefore && s.scheduleFetchRetry(ctx, account.ID) {
+				if prev != nil && prev.Status != core.StatusError {
+					kept = *prev
+					snap = retainedSnapshot(*prev, snap)
+				}
+				snap.SetRetryAt(s.now().Add(fetchRetryDelay))
+			}
+
 			// Record successful fetch for future change detection.
 			s.pollStateMu.Lock()
 			s.pollState[account.ID] = &providerPollState{
 				lastFetchAt: s.now(),
-				lastSnap:    snap,
+				lastSnap:    kept,
 				hasSnap:     true,
+				history:     history,
 			}
 			s.pollStateMu.Unlock()
 
-			results <- providerResult{accountID: account.ID, snapshot: snap}
+			results <- providerResult{accountID: account.ID, snapshot: snap, fetched: true}
 		}(acct)
 	}
 
@@ -145,15 +254,21 @@ func (s *Service) pollProviders(ctx context.Context) {
 	snapshots := make(map[string]core.UsageSnapshot, len(accounts))
 	statusCounts := map[core.Status]int{}
 	errorCount := 0
+	fetchedCount := 0
 	for result := range results {
-		snapshots[result.accountID] = result.snapshot
+		snapshots[result.accountID] = withNextPollAt(result.snapshot, s.pollScheduler.NextPollAt(result.accountID))
+		if result.fetched {
+			fetchedCount++
+		}
 		statusCounts[result.snapshot.Status]++
 		if result.snapshot.Status == core.StatusError {
 			errorCount++
 		}
 	}
+	core.Engine.RecordPoll(len(accounts), s.now())
+	s.noteConnectivity()
 	if len(snapshots) == 0 {
-		return
+		return fetchedCount, nil
 	}
 
 	ingestCtx, cancel := context.WithTimeout(ctx, 12*time.Second)
@@ -170,18 +285,75 @@ func (s *Service) pollProviders(ctx context.Context) {
 	if ingestErr != nil || errorCount > 0 || s.shouldLog("poll_cycle_info", 45*time.Second) {
 		s.infof(
 			"poll_cycle",
-			"duration_ms=%d accounts=%d snapshots=%d status_ok=%d status_auth=%d status_limited=%d status_error=%d status_unknown=%d ingest_error=%t",
+			"duration_ms=%d accounts=%d fetched=%d snapshots=%d status_ok=%d status_auth=%d status_limited=%d status_error=%d status_maintenance=%d status_unknown=%d ingest_error=%t forced=%t",
 			durationMs,
 			len(accounts),
+			fetchedCount,
 			len(snapshots),
 			statusCounts[core.StatusOK],
 			statusCounts[core.StatusAuth],
 			statusCounts[core.StatusLimited],
 			statusCounts[core.StatusError],
+			statusCounts[core.StatusMaintenance],
 			statusCounts[core.StatusUnknown],
 			ingestErr != nil,
+			force,
 		)
 	}
+	return fetchedCount, ingestErr
+}
+
+func (s *Service) connectivity() *shared.ConnectivityTracker {
+	if s.conn != nil {
+		return s.conn
+	}
+	return shared.Connectivity
+}
+
+// noteConnectivity logs going offline and coming back, and arms a read
+// model refresh so clients see the change without waiting for new data.
+func (s *Service) noteConnectivity() {
+	state := s.connectivity().State()
+	if s.offline.Swap(state.Offline) == state.Offline {
+		return
+	}
+	if state.Offline {
+		s.warnf("network_offline", "since=%s last_online=%s", state.Since.Format(time.RFC3339), state.LastOnline.Format(time.RFC3339))
+	} else {
+		s.infof("network_online", "")
+	}
+	s.markDataIngested()
+}
+
+// withNextPollAt stamps the account's next scheduled poll on a copy of snap,
+// leaving the diagnostics of the cached snapshot it may share untouched.
+func withNextPollAt(snap core.UsageSnapshot, next time.Time) core.UsageSnapshot {
+	if next.IsZero() {
+		return snap
+	}
+	diagnostics := make(map[string]string, len(snap.Diagnostics)+1)
+	for k, v := range snap.Diagnostics {
+		diagnostics[k] = v
+	}
+	snap.Diagnostics = diagnostics
+	snap.SetNextPollAt(next)
+	return snap
+}
+
+var errNoMatchingAccounts = errors.New("no enabled account matches")
+
+func filterAccountsByID(accounts []core.AccountConfig, ids []string) []core.AccountConfig {
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[strings.TrimSpace(id)] = true
+	}
+	out := make([]core.AccountConfig, 0, len(ids))
+	for _, acct := range accounts {
+		if want[acct.ID] {
+			out = append(out, acct)
+		}
+	}
+	return out
 }
 
 // skipUnchangedProvider checks if a provider's data source has changed since the last
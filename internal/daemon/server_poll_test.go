@@ -0,0 +1,103 @@
+package daemon
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestFilterAccountsByID(t *testing.T) {
+	accounts := []core.AccountConfig{{ID: "openai"}, {ID: "anthropic"}, {ID: "cursor"}}
+	got := filterAccountsByID(accounts, []string{" cursor ", "openai", "missing"})
+	if len(got) != 2 || got[0].ID != "openai" || got[1].ID != "cursor" {
+		t.Fatalf("filterAccountsByID() = %+v, want openai and cursor in config order", got)
+	}
+}
+
+func TestHandlePoll_RejectsBadRequests(t *testing.T) {
+	svc := &Service{rmCache: newReadModelCache(), logThrottle: core.NewLogThrottle(10, time.Minute)}
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{name: "wrong method", method: http.MethodGet, want: http.StatusMethodNotAllowed},
+		{name: "malformed body", method: http.MethodPost, body: "{", want: http.StatusBadRequest},
+		{name: "no ingestor", method: http.MethodPost, body: `{"account_ids":["openai"]}`, want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			svc.handlePoll(rec, httptest.NewRequest(tt.method, "/v1/poll", strings.NewReader(tt.body)))
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestClientPoll(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets are not supported in this test")
+	}
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr string
+	}{
+		{
+			name: "ok",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, http.StatusOK, PollResponse{Polled: 1})
+			},
+		},
+		{
+			name: "daemon error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				writeJSONError(w, http.StatusNotFound, "no enabled account matches nope")
+			},
+			wantErr: "daemon poll failed: no enabled account matches nope",
+		},
+		{
+			name:    "old daemon",
+			handler: http.NotFound,
+			wantErr: "does not support on-demand polling",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			socketPath := shortSocketPath(t, "poll")
+			_ = os.Remove(socketPath)
+			t.Cleanup(func() { _ = os.Remove(socketPath) })
+			listener, err := net.Listen("unix", socketPath)
+			if err != nil {
+				t.Fatalf("listen unix socket: %v", err)
+			}
+			srv := &http.Server{Handler: tt.handler}
+			go func() { _ = srv.Serve(listener) }()
+			defer srv.Close()
+
+			out, err := NewClient(socketPath).Poll(context.Background(), PollRequest{AccountIDs: []string{"openai"}})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Poll() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || out.Polled != 1 {
+				t.Fatalf("Poll() = %+v, %v", out, err)
+			}
+		})
+	}
+}
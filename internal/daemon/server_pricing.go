@@ -0,0 +1,77 @@
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/pricing"
+	"github.com/janekbaraniewski/openusage/internal/providers"
+)
+
+// runPricingRefreshLoop re-fetches the shared pricing catalog once per cache
+// TTL. Providers resolve prices lazily and would otherwise keep the table
+// they loaded at startup for the daemon's whole lifetime.
+func (s *Service) runPricingRefreshLoop(ctx context.Context) {
+	interval := pricing.ResolveTTL()
+	s.infof("pricing_refresh_loop_start", "interval=%s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			s.infof("pricing_refresh_loop_stop", "reason=context_done")
+			return
+		case <-ticker.C:
+			s.refreshPricing(ctx)
+		}
+	}
+}
+
+func (s *Service) refreshPricing(ctx context.Context) {
+	refreshCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	for _, res := range pricing.DefaultResolver().Refresh(refreshCtx) {
+		if res.Err != nil {
+			s.warnf("pricing_refresh_error", "source=%s models=%d error=%v", res.Source, res.Models, res.Err)
+			continue
+		}
+		s.infof("pricing_refresh", "source=%s models=%d added=%d removed=%d changed=%d",
+			res.Source, res.Models, res.Added, res.Removed, res.Changed)
+	}
+}
+
+// reloadCatalogs picks up the pricing catalog, custom-pricing.json and
+// custom provider definitions when they change on disk, so editing them
+// doesn't need a daemon restart. It reports whether anything was reloaded,
+// in which case every account should be fetched again to recompute costs
+// and derived metrics.
+func (s *Service) reloadCatalogs() bool {
+	sources := pricing.DefaultResolver().ReloadIfChanged()
+	customChanged := providers.ReloadCustomProviders()
+	if customChanged {
+		byID := providersByID(s.cfg.Chaos)
+		s.providersMu.Lock()
+		s.providerByID = byID
+		s.providersMu.Unlock()
+	}
+	if len(sources) == 0 && !customChanged {
+		return false
+	}
+	s.infof("catalog_reload", "pricing=%v custom_providers_changed=%t custom_providers=%d",
+		sources, customChanged, providers.CustomProviderCount())
+	core.Engine.RecordCatalogReload(s.now())
+	return true
+}
+
+// recordCatalog reports the catalogs in use to the engine tile.
+func (s *Service) recordCatalog() {
+	info := pricing.DefaultResolver().Catalog()
+	core.Engine.RecordCatalog(core.EngineCatalog{
+		PricingVersion:   info.Version,
+		PricingUpdatedAt: info.UpdatedAt,
+		PricingModels:    info.Models,
+		PricingOverrides: info.Overrides,
+		CustomProviders:  providers.CustomProviderCount(),
+	})
+}
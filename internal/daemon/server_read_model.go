@@ -24,6 +24,22 @@ func (s *Service) computeReadModel(
 		TodaySince:    core.LocalMidnight(),
 		TimeWindow:    tw,
 	})
+	// Telemetry can replace the polled metrics and attaches the daily
+	// history, so derive pace, user metrics and the status again from the
+	// final view.
+	now := s.now()
+	derived := s.derivedMetrics()
+	thresholds := StatusThresholdsFromConfig()
+	conn := s.connectivity().State()
+	for id, snap := range result {
+		snap = core.ApplyTodayPace(snap, now)
+		snap = core.ApplyDerivedMetrics(snap, derived)
+		if conn.Offline {
+			core.MarkOffline(&snap, conn.LastOnline)
+		}
+		snap = core.DeriveStatus(snap, thresholds)
+		result[id] = snap
+	}
 	core.Tracef("[read_model_perf] computeReadModel TOTAL: %dms (window=%s, accounts=%d, results=%d)",
 		time.Since(start).Milliseconds(), tw, len(req.Accounts), len(result))
 	return result, err
@@ -50,7 +66,12 @@ func (s *Service) refreshReadModelCacheAsync(
 			return
 		}
 		s.rmCache.set(cacheKey, snapshots)
+		s.setLiveRunning(snapshots)
+		s.hub.publish(cacheKey, snapshots)
 		s.pushToExporter(refreshCtx, snapshots)
+		s.notifyAlerts(refreshCtx, snapshots)
+		s.runEventHook(refreshCtx, snapshots)
+		s.writeCapacityFile(snapshots)
 	}()
 }
 
@@ -75,14 +96,21 @@ func (s *Service) runReadModelCacheLoop(ctx context.Context) {
 	s.markDataIngested() // ensure first boot always computes
 	s.refreshReadModelCacheFromConfig(ctx)
 
-	ticker := time.NewTicker(interval)
+	// Tick at the live cadence so a session in progress refreshes promptly;
+	// otherwise only every interval-th tick is considered.
+	ticker := time.NewTicker(min(interval, liveSessionInterval))
 	defer ticker.Stop()
+	lastCheck := time.Now()
 	for {
 		select {
 		case <-ctx.Done():
 			s.infof("read_model_cache_loop_stop", "reason=context_done")
 			return
-		case <-ticker.C:
+		case now := <-ticker.C:
+			if !s.liveRunning.Load() && now.Sub(lastCheck) < interval {
+				continue
+			}
+			lastCheck = now
 			if !s.dataIngested.Swap(false) {
 				continue // no new data ingested since last refresh
 			}
@@ -115,3 +143,37 @@ func readModelCacheInterval(pollInterval time.Duration) time.Duration {
 	}
 	return pollInterval
 }
+
+// liveSessionInterval is how often the daemon collects local session files
+// and refreshes the read model while a session is in progress, so the
+// dashboard's session ticker keeps up with the agent.
+const liveSessionInterval = 5 * time.Second
+
+// setLiveRunning records whether any tile has a session in progress, and
+// logs when that changes.
+func (s *Service) setLiveRunning(snaps map[string]core.UsageSnapshot) {
+	running := false
+	for _, snap := range snaps {
+		if core.HasRunningSession(snap) {
+			running = true
+			break
+		}
+	}
+	if s.liveRunning.Swap(running) != running {
+		s.infof("live_session", "running=%t", running)
+	}
+}
+
+// derivedMetrics returns the compiled derived metrics from the config the
+// daemon last loaded.
+func (s *Service) derivedMetrics() []core.DerivedMetric {
+	s.derivedMu.RLock()
+	defer s.derivedMu.RUnlock()
+	return s.derived
+}
+
+func (s *Service) setDerivedMetrics(derived []core.DerivedMetric) {
+	s.derivedMu.Lock()
+	s.derived = derived
+	s.derivedMu.Unlock()
+}
@@ -0,0 +1,55 @@
+package daemon
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// fetchRetryDelay is how soon an account whose fetch failed for the first
+// time is fetched again, instead of waiting out the poll interval.
+var fetchRetryDelay = 10 * time.Second
+
+// scheduleFetchRetry re-polls accountID alone after fetchRetryDelay. It
+// reports false when a retry for the account is already pending.
+func (s *Service) scheduleFetchRetry(ctx context.Context, accountID string) bool {
+	s.retryMu.Lock()
+	defer s.retryMu.Unlock()
+	if s.retryPending[accountID] {
+		return false
+	}
+	if s.retryPending == nil {
+		s.retryPending = make(map[string]bool)
+	}
+	s.retryPending[accountID] = true
+
+	// The poll that failed may run on a request context that ends before
+	// the retry is due, so the retry runs on the service's.
+	ctx = s.serviceContext(ctx)
+	time.AfterFunc(fetchRetryDelay, func() {
+		s.retryMu.Lock()
+		delete(s.retryPending, accountID)
+		s.retryMu.Unlock()
+		if ctx.Err() != nil {
+			return
+		}
+		core.Tracef("[poll] %s: retrying after a failed fetch", accountID)
+		if _, err := s.pollAccounts(ctx, []string{accountID}, true); err != nil && s.shouldLog("fetch_retry_failed:"+accountID, time.Minute) {
+			s.warnf("fetch_retry_failed", "account=%s error=%v", accountID, err)
+		}
+	})
+	return true
+}
+
+// retainedSnapshot is prev, served in place of failed while its retry is
+// pending so the tile keeps its data. It carries failed's fetch history and
+// error.
+func retainedSnapshot(prev, failed core.UsageSnapshot) core.UsageSnapshot {
+	out := prev
+	out.Diagnostics = maps.Clone(prev.Diagnostics)
+	out.SetDiagnostic(core.FetchHistoryDiagnostic, failed.Diagnostics[core.FetchHistoryDiagnostic])
+	out.SetDiagnostic(core.RetryErrorDiagnostic, failed.Message)
+	return out
+}
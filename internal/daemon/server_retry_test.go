@@ -0,0 +1,59 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestScheduleFetchRetry_OnePendingPerAccount(t *testing.T) {
+	prev := fetchRetryDelay
+	fetchRetryDelay = 10 * time.Millisecond
+	t.Cleanup(func() { fetchRetryDelay = prev })
+
+	// A cancelled context lets the retry fire without polling.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := &Service{}
+
+	if !s.scheduleFetchRetry(ctx, "openai") {
+		t.Fatal("first retry was not scheduled")
+	}
+	if s.scheduleFetchRetry(ctx, "openai") {
+		t.Fatal("second retry scheduled while the first is pending")
+	}
+	if !s.scheduleFetchRetry(ctx, "anthropic") {
+		t.Fatal("retry for another account was refused")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !s.scheduleFetchRetry(ctx, "openai") {
+		if time.Now().After(deadline) {
+			t.Fatal("retry never cleared its pending mark")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRetainedSnapshot(t *testing.T) {
+	good := core.NewUsageSnapshot("openai", "openai")
+	good.Status = core.StatusOK
+	good.Metrics["rpm"] = core.Metric{Limit: core.Float64Ptr(60)}
+	good.SetFetchHistory(core.FetchHistory{true})
+
+	failed := core.FetchErrorSnapshot("openai", "openai", time.Now(), context.DeadlineExceeded)
+	failed.SetFetchHistory(core.FetchHistory{true, false})
+
+	got := retainedSnapshot(good, failed)
+	if got.Status != core.StatusOK || got.Metrics["rpm"].Limit == nil {
+		t.Fatalf("retained snapshot lost the previous data: %+v", got)
+	}
+	if got.Diagnostics[core.FetchHistoryDiagnostic] != "+-" || got.Diagnostics[core.RetryErrorDiagnostic] != failed.Message {
+		t.Fatalf("diagnostics = %v", got.Diagnostics)
+	}
+	if good.Diagnostics[core.FetchHistoryDiagnostic] != "+" {
+		t.Fatal("retainedSnapshot modified the previous snapshot")
+	}
+}
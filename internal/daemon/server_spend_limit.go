@@ -0,0 +1,125 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// spendLimitTimeout bounds a provider's limit change, which may take a few
+// API round trips (look up the key, then update it).
+const spendLimitTimeout = 20 * time.Second
+
+var (
+	errSpendLimitUnsupported = errors.New("provider cannot change spend limits")
+	errSpendLimitReadOnly    = errors.New("read-only mode is on; spend limits cannot be changed")
+)
+
+func (s *Service) handleSpendLimit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var req SpendLimitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("decode spend limit request: %v", err))
+		return
+	}
+	req.AccountID = strings.TrimSpace(req.AccountID)
+	if req.AccountID == "" {
+		writeJSONError(w, http.StatusBadRequest, "account_id is required")
+		return
+	}
+	if req.LimitUSD != nil && *req.LimitUSD < 0 {
+		writeJSONError(w, http.StatusBadRequest, "limit_usd must not be negative")
+		return
+	}
+
+	accounts, _, _, err := LoadAccountsAndNorm()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), spendLimitTimeout)
+	defer cancel()
+
+	var target string
+	if req.Preview {
+		target, err = s.spendLimitTarget(ctx, accounts, req.AccountID)
+	} else {
+		err = s.setSpendLimit(ctx, accounts, req)
+	}
+	switch {
+	case errors.Is(err, errNoMatchingAccounts):
+		writeJSONError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, errSpendLimitUnsupported):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, errSpendLimitReadOnly):
+		writeJSONError(w, http.StatusForbidden, err.Error())
+	case err != nil:
+		s.warnf("spend_limit_failed", "account=%s error=%v", req.AccountID, err)
+		writeJSONError(w, http.StatusBadGateway, err.Error())
+	case req.Preview:
+		writeJSON(w, http.StatusOK, SpendLimitResponse{Status: "ok", Target: target})
+	default:
+		s.infof("spend_limit_set", "account=%s limit=%s", req.AccountID, formatSpendLimit(req.LimitUSD))
+		writeJSON(w, http.StatusOK, SpendLimitResponse{Status: "ok"})
+	}
+}
+
+// setSpendLimit changes the cap of req.AccountID through its provider's
+// core.SpendLimitSetter.
+func (s *Service) setSpendLimit(ctx context.Context, accounts []core.AccountConfig, req SpendLimitRequest) error {
+	acct, setter, err := s.spendLimitSetter(accounts, req.AccountID)
+	if err != nil {
+		return err
+	}
+	return setter.SetSpendLimit(ctx, acct, req.LimitUSD)
+}
+
+// spendLimitTarget names what a change to accountID's cap would apply to,
+// or "" when the provider has nothing to choose between.
+func (s *Service) spendLimitTarget(ctx context.Context, accounts []core.AccountConfig, accountID string) (string, error) {
+	acct, setter, err := s.spendLimitSetter(accounts, accountID)
+	if err != nil {
+		return "", err
+	}
+	targeter, ok := setter.(core.SpendLimitTargeter)
+	if !ok {
+		return "", nil
+	}
+	return targeter.SpendLimitTarget(ctx, acct)
+}
+
+// spendLimitSetter returns accountID and its provider's setter, refusing
+// unknown accounts, providers that cannot change limits, and read-only mode.
+func (s *Service) spendLimitSetter(accounts []core.AccountConfig, accountID string) (core.AccountConfig, core.SpendLimitSetter, error) {
+	matched := filterAccountsByID(accounts, []string{accountID})
+	if len(matched) == 0 {
+		return core.AccountConfig{}, nil, fmt.Errorf("%w %s", errNoMatchingAccounts, accountID)
+	}
+	acct := matched[0]
+	provider, _ := s.provider(acct.Provider)
+	setter, ok := provider.(core.SpendLimitSetter)
+	if !ok {
+		return core.AccountConfig{}, nil, fmt.Errorf("%w: %s", errSpendLimitUnsupported, acct.Provider)
+	}
+	if acct.ReadOnly() {
+		return core.AccountConfig{}, nil, errSpendLimitReadOnly
+	}
+	return acct, setter, nil
+}
+
+func formatSpendLimit(limit *float64) string {
+	if limit == nil {
+		return "none"
+	}
+	return strconv.FormatFloat(*limit, 'f', 2, 64)
+}
@@ -0,0 +1,184 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+type fakeSpendLimitProvider struct {
+	core.UsageProvider
+	calls []*float64
+	err   error
+}
+
+func (p *fakeSpendLimitProvider) SetSpendLimit(_ context.Context, _ core.AccountConfig, limitUSD *float64) error {
+	p.calls = append(p.calls, limitUSD)
+	return p.err
+}
+
+type fakeSpendLimitTargetProvider struct {
+	fakeSpendLimitProvider
+}
+
+func (p *fakeSpendLimitTargetProvider) SpendLimitTarget(context.Context, core.AccountConfig) (string, error) {
+	return "Primary · abc123", nil
+}
+
+func TestSpendLimitTarget(t *testing.T) {
+	targeter := &fakeSpendLimitTargetProvider{}
+	svc := &Service{providerByID: map[string]core.UsageProvider{
+		"openrouter": targeter,
+		"cursor":     &fakeSpendLimitProvider{},
+	}}
+	accounts := []core.AccountConfig{
+		{ID: "openrouter", Provider: "openrouter"},
+		{ID: "cursor", Provider: "cursor"},
+	}
+
+	if got, err := svc.spendLimitTarget(context.Background(), accounts, "openrouter"); err != nil || got != "Primary · abc123" {
+		t.Fatalf("spendLimitTarget() = %q, %v; want the provider's key", got, err)
+	}
+	if got, err := svc.spendLimitTarget(context.Background(), accounts, "cursor"); err != nil || got != "" {
+		t.Fatalf("spendLimitTarget() without a targeter = %q, %v; want empty", got, err)
+	}
+	if _, err := svc.spendLimitTarget(context.Background(), core.ApplyReadOnly(accounts, true), "openrouter"); !errors.Is(err, errSpendLimitReadOnly) {
+		t.Fatalf("spendLimitTarget() in read-only mode = %v, want %v", err, errSpendLimitReadOnly)
+	}
+	if len(targeter.calls) != 0 {
+		t.Fatal("a preview must not change the limit")
+	}
+}
+
+func TestSetSpendLimit(t *testing.T) {
+	setter := &fakeSpendLimitProvider{}
+	svc := &Service{providerByID: map[string]core.UsageProvider{
+		"openrouter": setter,
+		"openai":     struct{ core.UsageProvider }{},
+	}}
+	accounts := []core.AccountConfig{
+		{ID: "openrouter", Provider: "openrouter"},
+		{ID: "openai", Provider: "openai"},
+	}
+	limit := 25.0
+
+	if err := svc.setSpendLimit(context.Background(), accounts, SpendLimitRequest{AccountID: "openrouter", LimitUSD: &limit}); err != nil {
+		t.Fatalf("setSpendLimit() error = %v", err)
+	}
+	if len(setter.calls) != 1 || setter.calls[0] == nil || *setter.calls[0] != 25 {
+		t.Fatalf("provider calls = %v, want one call with 25", setter.calls)
+	}
+
+	tests := []struct {
+		name     string
+		accounts []core.AccountConfig
+		id       string
+		want     error
+	}{
+		{name: "unknown account", accounts: accounts, id: "missing", want: errNoMatchingAccounts},
+		{name: "provider without setter", accounts: accounts, id: "openai", want: errSpendLimitUnsupported},
+		{name: "read-only", accounts: core.ApplyReadOnly(accounts, true), id: "openrouter", want: errSpendLimitReadOnly},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.setSpendLimit(context.Background(), tt.accounts, SpendLimitRequest{AccountID: tt.id, LimitUSD: &limit})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("setSpendLimit() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if len(setter.calls) != 1 {
+		t.Fatalf("refused requests must not reach the provider, calls = %d", len(setter.calls))
+	}
+}
+
+func TestHandleSpendLimit_RejectsBadRequests(t *testing.T) {
+	svc := &Service{rmCache: newReadModelCache(), logThrottle: core.NewLogThrottle(10, time.Minute)}
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{name: "wrong method", method: http.MethodGet, want: http.StatusMethodNotAllowed},
+		{name: "malformed body", method: http.MethodPost, body: "{", want: http.StatusBadRequest},
+		{name: "missing account", method: http.MethodPost, body: `{"limit_usd": 5}`, want: http.StatusBadRequest},
+		{name: "negative limit", method: http.MethodPost, body: `{"account_id": "openrouter", "limit_usd": -1}`, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			svc.handleSpendLimit(rec, httptest.NewRequest(tt.method, "/v1/spend-limit", strings.NewReader(tt.body)))
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestClientSetSpendLimit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets are not supported in this test")
+	}
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr string
+	}{
+		{
+			name: "ok",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+			},
+		},
+		{
+			name: "provider error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				writeJSONError(w, http.StatusBadGateway, "openrouter: HTTP 403")
+			},
+			wantErr: "openrouter: HTTP 403",
+		},
+		{
+			name:    "old daemon",
+			handler: http.NotFound,
+			wantErr: "does not support changing spend limits",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			socketPath := shortSocketPath(t, "limit")
+			_ = os.Remove(socketPath)
+			t.Cleanup(func() { _ = os.Remove(socketPath) })
+			listener, err := net.Listen("unix", socketPath)
+			if err != nil {
+				t.Fatalf("listen unix socket: %v", err)
+			}
+			srv := &http.Server{Handler: tt.handler}
+			go func() { _ = srv.Serve(listener) }()
+			defer srv.Close()
+
+			limit := 10.0
+			err = NewClient(socketPath).SetSpendLimit(context.Background(), "openrouter", &limit)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SetSpendLimit() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetSpendLimit() error = %v", err)
+			}
+		})
+	}
+}
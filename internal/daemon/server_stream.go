@@ -0,0 +1,237 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// streamKeepalive is how often an idle stream gets a comment line so proxies
+// and clients can tell a quiet daemon from a dead connection. Each keepalive
+// also re-checks for ingested data, so windows the background loop does not
+// refresh still update.
+const streamKeepalive = 15 * time.Second
+
+// snapshotHub fans read-model refreshes out to /v1/stream subscribers. Each
+// subscriber listens on one read-model cache key and keeps only the latest
+// frame: a slow reader skips intermediate updates instead of blocking the
+// refresh path.
+type snapshotHub struct {
+	mu   sync.Mutex
+	subs map[*streamSubscriber]struct{}
+}
+
+type streamSubscriber struct {
+	cacheKey string
+	updates  chan map[string]core.UsageSnapshot
+}
+
+func newSnapshotHub() *snapshotHub {
+	return &snapshotHub{subs: make(map[*streamSubscriber]struct{})}
+}
+
+func (h *snapshotHub) subscribe(cacheKey string) *streamSubscriber {
+	sub := &streamSubscriber{cacheKey: cacheKey, updates: make(chan map[string]core.UsageSnapshot, 1)}
+	h.mu.Lock()
+	h.subs[sub] = struct{}{}
+	h.mu.Unlock()
+	return sub
+}
+
+func (h *snapshotHub) unsubscribe(sub *streamSubscriber) {
+	h.mu.Lock()
+	delete(h.subs, sub)
+	h.mu.Unlock()
+}
+
+// publish hands snapshots to every subscriber of cacheKey. The map is shared
+// read-only, the same contract as readModelCache.get.
+func (h *snapshotHub) publish(cacheKey string, snapshots map[string]core.UsageSnapshot) {
+	if h == nil || len(snapshots) == 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs {
+		if sub.cacheKey != cacheKey {
+			continue
+		}
+		select {
+		case <-sub.updates: // drop the unread frame; latest wins
+		default:
+		}
+		sub.updates <- snapshots
+	}
+}
+
+func (h *snapshotHub) subscriberCount() int {
+	if h == nil {
+		return 0
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs)
+}
+
+// streamFilter narrows a stream to some accounts and metric key prefixes.
+// Empty fields match everything.
+type streamFilter struct {
+	accounts       map[string]bool
+	metricPrefixes []string
+}
+
+// parseStreamFilter reads repeatable or comma-separated ?account= and
+// ?metric_prefix= query parameters.
+func parseStreamFilter(r *http.Request) streamFilter {
+	var f streamFilter
+	for _, id := range splitQueryValues(r.URL.Query()["account"]) {
+		if f.accounts == nil {
+			f.accounts = make(map[string]bool)
+		}
+		f.accounts[id] = true
+	}
+	f.metricPrefixes = splitQueryValues(r.URL.Query()["metric_prefix"])
+	return f
+}
+
+func splitQueryValues(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+// apply returns the filtered view. Snapshots are shallow-copied and get a new
+// Metrics map only when metric prefixes are set, so cached entries are never
+// mutated.
+func (f streamFilter) apply(snapshots map[string]core.UsageSnapshot) map[string]core.UsageSnapshot {
+	out := make(map[string]core.UsageSnapshot, len(snapshots))
+	for id, snap := range snapshots {
+		if len(f.accounts) > 0 && !f.accounts[id] && !f.accounts[snap.AccountID] {
+			continue
+		}
+		if len(f.metricPrefixes) > 0 {
+			metrics := make(map[string]core.Metric)
+			for key, met := range snap.Metrics {
+				if f.matchesMetric(key) {
+					metrics[key] = met
+				}
+			}
+			snap.Metrics = metrics
+		}
+		out[id] = snap
+	}
+	return out
+}
+
+func (f streamFilter) matchesMetric(key string) bool {
+	for _, prefix := range f.metricPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleStream serves GET /v1/stream as Server-Sent Events. The stream opens
+// with the current snapshots and then sends a "snapshots" event after every
+// read-model refresh whose filtered view changed. Query parameters:
+//
+//	account=<id>          repeatable or comma-separated; default all accounts
+//	metric_prefix=<p>     repeatable or comma-separated; default all metrics
+//	window=<tw>           time window (1d, 7d, 30d, …); default data.time_window
+func (s *Service) handleStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	req, err := BuildReadModelRequestFromConfig()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("load accounts: %v", err))
+		return
+	}
+	if window := strings.TrimSpace(r.URL.Query().Get("window")); window != "" {
+		req.TimeWindow = normalizeReadModelTimeWindow(core.TimeWindow(window))
+	}
+	filter := parseStreamFilter(r)
+	cacheKey := ReadModelRequestKey(req)
+
+	rc := http.NewResponseController(w)
+	// The socket server's WriteTimeout would cut the stream after 10s.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	sub := s.hub.subscribe(cacheKey)
+	defer s.hub.unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	_ = rc.Flush()
+
+	s.infof("stream_open", "window=%s accounts=%d metric_prefixes=%d subscribers=%d",
+		req.TimeWindow, len(filter.accounts), len(filter.metricPrefixes), s.hub.subscriberCount())
+	defer s.infof("stream_close", "window=%s", req.TimeWindow)
+
+	var lastFingerprint string
+	send := func(snapshots map[string]core.UsageSnapshot) bool {
+		view := filter.apply(snapshots)
+		fp := snapshotFingerprint(view)
+		if fp == lastFingerprint {
+			return true
+		}
+		lastFingerprint = fp
+		payload, err := json.Marshal(ReadModelResponse{Snapshots: view})
+		if err != nil {
+			return false
+		}
+		if _, err := fmt.Fprintf(w, "event: snapshots\ndata: %s\n\n", payload); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	var lastRefresh time.Time
+	if cached, cachedAt, ok := s.rmCache.get(cacheKey); ok {
+		lastRefresh = cachedAt
+		if !send(cached) {
+			return
+		}
+	}
+	if len(req.Accounts) > 0 && (lastRefresh.IsZero() || s.ingestedSince(lastRefresh)) {
+		s.refreshReadModelCacheAsync(s.serviceContext(r.Context()), cacheKey, req, 60*time.Second)
+	}
+
+	ticker := time.NewTicker(streamKeepalive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case snapshots := <-sub.updates:
+			lastRefresh = time.Now()
+			if !send(snapshots) {
+				return
+			}
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil || rc.Flush() != nil {
+				return
+			}
+			if len(req.Accounts) > 0 && s.ingestedSince(lastRefresh) {
+				s.refreshReadModelCacheAsync(s.serviceContext(r.Context()), cacheKey, req, 60*time.Second)
+			}
+		}
+	}
+}
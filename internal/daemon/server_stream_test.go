@@ -0,0 +1,137 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestSnapshotHub_PublishKeepsLatestPerKey(t *testing.T) {
+	hub := newSnapshotHub()
+	a := hub.subscribe("key-a")
+	b := hub.subscribe("key-b")
+	defer hub.unsubscribe(a)
+	defer hub.unsubscribe(b)
+
+	first := map[string]core.UsageSnapshot{"x": {AccountID: "x", Status: core.StatusOK}}
+	second := map[string]core.UsageSnapshot{"x": {AccountID: "x", Status: core.StatusLimited}}
+	hub.publish("key-a", first)
+	hub.publish("key-a", second)
+
+	select {
+	case got := <-a.updates:
+		if got["x"].Status != core.StatusLimited {
+			t.Fatalf("status = %q, want latest frame", got["x"].Status)
+		}
+	default:
+		t.Fatal("subscriber of key-a got no frame")
+	}
+	select {
+	case <-b.updates:
+		t.Fatal("subscriber of key-b got a frame for key-a")
+	default:
+	}
+}
+
+func TestStreamFilter_Apply(t *testing.T) {
+	snaps := map[string]core.UsageSnapshot{
+		"claude": {AccountID: "claude", Metrics: map[string]core.Metric{
+			"rpm":            {Used: core.Float64Ptr(1)},
+			"rpm_remaining":  {Used: core.Float64Ptr(2)},
+			"today_api_cost": {Used: core.Float64Ptr(3)},
+		}},
+		"openai": {AccountID: "openai", Metrics: map[string]core.Metric{"rpm": {Used: core.Float64Ptr(4)}}},
+	}
+	r := httptest.NewRequest(http.MethodGet, "/v1/stream?account=claude&metric_prefix=rpm,tpm", nil)
+	got := parseStreamFilter(r).apply(snaps)
+
+	if len(got) != 1 {
+		t.Fatalf("accounts = %d, want 1", len(got))
+	}
+	if n := len(got["claude"].Metrics); n != 2 {
+		t.Fatalf("claude metrics = %d, want 2 (rpm, rpm_remaining)", n)
+	}
+	if len(snaps["claude"].Metrics) != 3 {
+		t.Fatal("filter mutated the source snapshot")
+	}
+}
+
+func TestHandleStream_SendsFilteredUpdates(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	svc := &Service{
+		rmCache:     newReadModelCache(),
+		hub:         newSnapshotHub(),
+		logThrottle: core.NewLogThrottle(10, time.Minute),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(svc.handleStream))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/v1/stream?account=a&metric_prefix=rpm", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /v1/stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content type = %q", ct)
+	}
+
+	var cacheKey string
+	for cacheKey == "" {
+		svc.hub.mu.Lock()
+		for sub := range svc.hub.subs {
+			cacheKey = sub.cacheKey
+		}
+		svc.hub.mu.Unlock()
+		if ctx.Err() != nil {
+			t.Fatal("stream never subscribed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	svc.hub.publish(cacheKey, map[string]core.UsageSnapshot{
+		"a": {AccountID: "a", Metrics: map[string]core.Metric{
+			"rpm":  {Used: core.Float64Ptr(5)},
+			"cost": {Used: core.Float64Ptr(9)},
+		}},
+		"b": {AccountID: "b"},
+	})
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	var event string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if name, ok := strings.CutPrefix(line, "event: "); ok {
+			event = name
+			continue
+		}
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok {
+			continue
+		}
+		if event != "snapshots" {
+			t.Fatalf("event = %q, want snapshots", event)
+		}
+		var payload ReadModelResponse
+		if err := json.Unmarshal([]byte(data), &payload); err != nil {
+			t.Fatalf("decode event: %v", err)
+		}
+		if len(payload.Snapshots) != 1 || len(payload.Snapshots["a"].Metrics) != 1 {
+			t.Fatalf("payload = %+v, want only account a with rpm", payload.Snapshots)
+		}
+		return
+	}
+	t.Fatalf("stream ended without an event: %v", scanner.Err())
+}
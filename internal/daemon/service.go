@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/janekbaraniewski/openusage/internal/config"
 	"github.com/janekbaraniewski/openusage/internal/telemetry"
 )
 
@@ -315,15 +316,53 @@ var daemonInstallEnvVars = []string{
 	"OLLAMA_HOST",
 	"ALIBABA_CLOUD_API_KEY",
 	"OPENUSAGE_DEBUG",
+	// Read-only mode captured at install time so `openusage --read-only
+	// telemetry daemon install` yields a daemon that never issues
+	// state-mutating or billable provider requests.
+	"OPENUSAGE_READ_ONLY",
+	// Pure-config mode and an explicit credentials file, so a daemon
+	// installed from a Nix/home-manager setup never rewrites the managed
+	// settings and reads the same secrets.
+	"OPENUSAGE_NO_WRITE",
+	"OPENUSAGE_CREDENTIALS",
+	// Config profile and explicit settings file, so `openusage --profile
+	// work telemetry daemon install` yields a daemon polling that profile.
+	"OPENUSAGE_PROFILE",
+	"OPENUSAGE_CONFIG",
+	// XDG base directories, so the daemon reads the same config and writes
+	// the same state and caches as the shell that installed it.
+	"XDG_CONFIG_HOME",
+	"XDG_STATE_HOME",
+	"XDG_CACHE_HOME",
+	"XDG_DATA_HOME",
+	// Audit log location (or "off"), so `openusage audit` in the shell
+	// reads the log the daemon writes.
+	"OPENUSAGE_AUDIT_LOG",
 	// Hub exporter Bearer token. Captured at install time so the daemon's
 	// exporter can authenticate to a remote hub without the operator having
 	// to hand-edit the platform service file.
 	"OPENUSAGE_HUB_TOKEN",
+	// Bearer token for the pushed-event ingest listener (telemetry.
+	// ingest_listen), kept out of settings.json like the hub token.
+	"OPENUSAGE_INGEST_TOKEN",
+	// Proxy environment. Services start without the login shell's
+	// environment, so without these a daemon behind a corporate proxy
+	// would fail every fetch unless `network.proxy` is set.
+	"HTTPS_PROXY",
+	"https_proxy",
+	"HTTP_PROXY",
+	"http_proxy",
+	"NO_PROXY",
+	"no_proxy",
 }
 
 func currentServiceEnvSnapshot() map[string]string {
+	keys := append([]string(nil), daemonInstallEnvVars...)
+	if cfg, err := config.Load(); err == nil {
+		keys = append(keys, notificationEnvVars(cfg.Notifications)...)
+	}
 	env := make(map[string]string)
-	for _, key := range daemonInstallEnvVars {
+	for _, key := range keys {
 		if value, ok := os.LookupEnv(key); ok && strings.TrimSpace(value) != "" {
 			env[key] = value
 		}
@@ -331,6 +370,22 @@ func currentServiceEnvSnapshot() map[string]string {
 	return env
 }
 
+// notificationEnvVars are the env vars notification sinks name for their
+// webhook URLs and tokens. Their names are user-chosen, so they are read from
+// settings.json rather than listed in daemonInstallEnvVars.
+func notificationEnvVars(cfg config.NotificationsConfig) []string {
+	var out []string
+	for _, sink := range cfg.Sinks {
+		for _, name := range []string{sink.WebhookEnv, sink.TokenEnv, sink.UserEnv} {
+			if name = strings.TrimSpace(name); name != "" {
+				out = append(out, name)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
 func writeServiceEnvFile(path string, env map[string]string) error {
 	if strings.TrimSpace(path) == "" {
 		return nil
@@ -3,8 +3,11 @@ package daemon
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
 )
 
 func TestCurrentServiceEnvSnapshot_IncludesKnownConfiguredVars(t *testing.T) {
@@ -51,3 +54,18 @@ func TestWriteServiceEnvFile_WritesQuotedSnapshot(t *testing.T) {
 		t.Fatalf("env file missing quoted OLLAMA_HOST:\n%s", text)
 	}
 }
+
+func TestNotificationEnvVars(t *testing.T) {
+	got := notificationEnvVars(config.NotificationsConfig{
+		Sinks: map[string]config.NotificationSink{
+			"dev-slack": {Type: "slack", WebhookEnv: "OPENUSAGE_SLACK_WEBHOOK"},
+			"phone":     {Type: "ntfy", Topic: "alerts", TokenEnv: " NTFY_TOKEN "},
+			"pushover":  {Type: "pushover", TokenEnv: "PUSHOVER_TOKEN", UserEnv: "PUSHOVER_USER"},
+			"desktop":   {Type: "desktop"},
+		},
+	})
+	want := []string{"NTFY_TOKEN", "OPENUSAGE_SLACK_WEBHOOK", "PUSHOVER_TOKEN", "PUSHOVER_USER"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("notificationEnvVars() = %v, want %v", got, want)
+	}
+}
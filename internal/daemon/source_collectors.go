@@ -201,6 +201,13 @@ func telemetryAccountsForSource(source shared.TelemetrySource, accounts []core.A
 }
 
 func collectOptionsForAccount(source shared.TelemetrySource, acct core.AccountConfig) shared.TelemetryCollectOptions {
+	if spec, ok := source.(interface{ Spec() core.ProviderSpec }); ok {
+		// A data_path that matches nothing leaves the account on its
+		// default paths here; the poll reports the error on its tile.
+		if resolved, err := core.ResolveLocalData(acct, spec.Spec().LocalData); err == nil {
+			acct = resolved
+		}
+	}
 	opts := cloneCollectOptions(source.DefaultCollectOptions())
 	if opts.Paths == nil {
 		opts.Paths = make(map[string]string)
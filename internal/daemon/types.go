@@ -7,6 +7,8 @@ import (
 
 	"github.com/janekbaraniewski/openusage/internal/config"
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/notify"
+	"github.com/janekbaraniewski/openusage/internal/providers/chaos"
 )
 
 const APIVersion = "v1"
@@ -21,6 +23,22 @@ type Config struct {
 	PollInterval    time.Duration
 	Verbose         bool
 	Export          config.ExportConfig
+	Polling         config.PollingConfig
+	Notifications   config.NotificationsConfig
+	// AlertThresholds are the gauge levels that raise notifications; they
+	// follow the dashboard's warn/crit thresholds.
+	AlertThresholds notify.Thresholds
+	// CapacityFile is where the capacity summary is written after every
+	// read-model refresh; empty disables it.
+	CapacityFile string
+	// Chaos, when set, wraps every provider with failure injection; it is
+	// a development aid for exercising backoff and degraded states.
+	Chaos *chaos.Config
+	// IngestListen is the TCP address for pushed usage events (POST
+	// /v1/ingest); empty keeps the endpoint socket-only. IngestToken, when
+	// set, is the Bearer token the TCP listener requires.
+	IngestListen string
+	IngestToken  string
 }
 
 type ReadModelAccount struct {
@@ -38,6 +56,37 @@ type ReadModelResponse struct {
 	Snapshots map[string]core.UsageSnapshot `json:"snapshots"`
 }
 
+// PollRequest asks the daemon to fetch accounts now instead of waiting for
+// the next poll cycle. An empty AccountIDs polls every enabled account.
+type PollRequest struct {
+	AccountIDs []string `json:"account_ids,omitempty"`
+}
+
+type PollResponse struct {
+	Polled int `json:"polled"`
+}
+
+// SpendLimitRequest asks the daemon to change an account's spending cap
+// through its provider. A nil LimitUSD removes the cap. Preview changes
+// nothing and only reports what the change would apply to.
+type SpendLimitRequest struct {
+	AccountID string   `json:"account_id"`
+	LimitUSD  *float64 `json:"limit_usd"`
+	Preview   bool     `json:"preview,omitempty"`
+}
+
+// SpendLimitResponse is the reply to a SpendLimitRequest. Target names the
+// key or object whose cap changes, when the provider can tell.
+type SpendLimitResponse struct {
+	Status string `json:"status"`
+	Target string `json:"target,omitempty"`
+}
+
+// LogsResponse carries the daemon's buffered log lines, oldest first.
+type LogsResponse struct {
+	Lines []string `json:"lines"`
+}
+
 type HookResponse struct {
 	Source    string   `json:"source"`
 	Enqueued  int      `json:"enqueued"`
@@ -82,8 +131,10 @@ func (c *readModelCache) get(cacheKey string) (map[string]core.UsageSnapshot, ti
 	entry, ok := c.entries[cacheKey]
 	if !ok || len(entry.snapshots) == 0 {
 		c.mu.RUnlock()
+		core.Engine.RecordCacheLookup(false)
 		return nil, time.Time{}, false
 	}
+	core.Engine.RecordCacheLookup(true)
 	// Return direct reference — snapshots are deep-cloned on set() and
 	// treated as immutable once cached. Consumers must not mutate.
 	c.mu.RUnlock()
@@ -129,6 +180,14 @@ func (c *readModelCache) set(cacheKey string, snapshots map[string]core.UsageSna
 	c.mu.Unlock()
 }
 
+// invalidate drops every cached entry so the next read recomputes from the
+// store. In-flight refreshes are left alone.
+func (c *readModelCache) invalidate() {
+	c.mu.Lock()
+	c.entries = make(map[string]cachedReadModelEntry)
+	c.mu.Unlock()
+}
+
 func (c *readModelCache) beginRefresh(cacheKey string) bool {
 	if cacheKey == "" {
 		return false
@@ -160,6 +219,8 @@ type providerPollState struct {
 	lastFetchAt time.Time
 	lastSnap    core.UsageSnapshot
 	hasSnap     bool
+	// history holds the outcomes of the account's recent fetches.
+	history core.FetchHistory
 }
 
 type SnapshotFrame struct {
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
 	"strings"
 	"time"
 
+	"github.com/aymanbagabas/go-osc52/v2"
 	"github.com/janekbaraniewski/openusage/internal/browsercookies"
 	"github.com/janekbaraniewski/openusage/internal/config"
 	"github.com/janekbaraniewski/openusage/internal/core"
@@ -19,7 +21,8 @@ import (
 type Service struct {
 	ctx           context.Context
 	cookieReader  browsercookies.Reader
-	browserOpener func(url string) error // overridable for tests
+	browserOpener func(url string) error  // overridable for tests
+	clipboard     func(text string) error // overridable for tests
 }
 
 func NewService(ctx context.Context) *Service {
@@ -30,6 +33,7 @@ func NewService(ctx context.Context) *Service {
 		ctx:           ctx,
 		cookieReader:  browsercookies.New(),
 		browserOpener: openInDefaultBrowser,
+		clipboard:     copyToSystemClipboard,
 	}
 }
 
@@ -48,6 +52,13 @@ func (s *Service) SetBrowserOpener(fn func(string) error) {
 	}
 }
 
+// SetClipboard is exposed for tests so we don't touch the real clipboard.
+func (s *Service) SetClipboard(fn func(string) error) {
+	if fn != nil {
+		s.clipboard = fn
+	}
+}
+
 func (s *Service) SaveTheme(themeName string) error {
 	return config.SaveTheme(themeName)
 }
@@ -60,6 +71,10 @@ func (s *Service) SaveDashboardProviderHideCosts(accountID string, hide *bool) e
 	return config.SaveDashboardProviderHideCosts(accountID, hide)
 }
 
+func (s *Service) SaveDashboardProviderFreeTier(accountID string, free *bool) error {
+	return config.SaveDashboardProviderFreeTier(accountID, free)
+}
+
 func (s *Service) SaveDashboardView(view string) error {
 	return config.SaveDashboardView(view)
 }
@@ -76,6 +91,14 @@ func (s *Service) SaveDashboardHideSectionsWithNoData(hide bool) error {
 	return config.SaveDashboardHideSectionsWithNoData(hide)
 }
 
+func (s *Service) SaveDashboardPrivacyMode(on bool) error {
+	return config.SaveDashboardPrivacyMode(on)
+}
+
+func (s *Service) SaveDashboardSnoozes(snoozes []config.WarningSnooze) error {
+	return config.SaveDashboardSnoozes(snoozes)
+}
+
 func (s *Service) SaveTimeWindow(window string) error {
 	return config.SaveTimeWindow(window)
 }
@@ -239,6 +262,31 @@ func (s *Service) OpenProviderConsole(url string) error {
 	return s.browserOpener(url)
 }
 
+// OpenExternal hands a URL or local path to the OS default handler. Used by
+// the tile quick-action menu.
+func (s *Service) OpenExternal(target string) error {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return errors.New("nothing to open")
+	}
+	if s.browserOpener == nil {
+		return errors.New("browser opener unavailable")
+	}
+	return s.browserOpener(target)
+}
+
+// CopyToClipboard writes text to the system clipboard. Used by the tile
+// quick-action menu.
+func (s *Service) CopyToClipboard(text string) error {
+	if text == "" {
+		return errors.New("nothing to copy")
+	}
+	if s.clipboard == nil {
+		return errors.New("clipboard unavailable")
+	}
+	return s.clipboard(text)
+}
+
 // AvailableBrowsers reports which browsers have a readable cookie store on
 // this machine. Used by the connect modal to show "we'll look in: Chrome,
 // Firefox" before the user commits.
@@ -261,3 +309,61 @@ func openInDefaultBrowser(url string) error {
 		return exec.Command("xdg-open", url).Start()
 	}
 }
+
+// copyToSystemClipboard pipes text into the platform clipboard tool. On
+// Linux it uses whichever of wl-copy, xclip or xsel is installed. Over SSH,
+// or when no tool is found, it falls back to an OSC52 escape so the local
+// terminal sets its own clipboard.
+func copyToSystemClipboard(text string) error {
+	if os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != "" {
+		return copyViaOSC52(text)
+	}
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("pbcopy")
+	case "windows":
+		cmd = exec.Command("clip")
+	default:
+		for _, candidate := range [][]string{
+			{"wl-copy"},
+			{"xclip", "-selection", "clipboard"},
+			{"xsel", "--clipboard", "--input"},
+		} {
+			if _, err := exec.LookPath(candidate[0]); err == nil {
+				cmd = exec.Command(candidate[0], candidate[1:]...)
+				break
+			}
+		}
+		if cmd == nil {
+			if err := copyViaOSC52(text); err != nil {
+				return errors.New("no clipboard tool found (install wl-copy, xclip or xsel)")
+			}
+			return nil
+		}
+	}
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
+
+// copyViaOSC52 asks the terminal to set the clipboard. The sequence goes to
+// the controlling tty rather than stdout so it can't interleave with a frame
+// being drawn; inside tmux or screen it is wrapped in their passthrough.
+// Terminals that don't support OSC52 ignore it silently.
+func copyViaOSC52(text string) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("opening terminal for OSC52: %w", err)
+	}
+	defer tty.Close()
+
+	seq := osc52.New(text)
+	switch term := os.Getenv("TERM"); {
+	case os.Getenv("TMUX") != "" || strings.HasPrefix(term, "tmux"):
+		seq = seq.Tmux()
+	case strings.HasPrefix(term, "screen"):
+		seq = seq.Screen()
+	}
+	_, err = seq.WriteTo(tty)
+	return err
+}
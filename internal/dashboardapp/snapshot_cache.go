@@ -0,0 +1,173 @@
+package dashboardapp
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// snapshotCacheMaxAge bounds how old a cached frame may be and still be shown
+// at startup; older data is more misleading than a loading screen.
+const snapshotCacheMaxAge = 7 * 24 * time.Hour
+
+// SnapshotCache persists the last snapshot per account so the dashboard can
+// paint immediately on startup (marked stale) instead of waiting for the
+// daemon's first frame.
+type SnapshotCache struct {
+	path string
+	mu   sync.Mutex
+}
+
+type snapshotCacheFile struct {
+	SavedAt    time.Time                     `json:"saved_at"`
+	TimeWindow core.TimeWindow               `json:"time_window"`
+	Snapshots  map[string]core.UsageSnapshot `json:"snapshots"`
+}
+
+// NewSnapshotCache returns a cache at path; an empty path yields a cache
+// whose Load and Save are no-ops.
+func NewSnapshotCache(path string) *SnapshotCache {
+	return &SnapshotCache{path: path}
+}
+
+// SnapshotCachePath is the default cache location,
+// <cache dir>/dashboard-snapshots.json (see config.CacheDir), or
+// dashboard-snapshots-<profile>.json under a named config profile so one
+// profile's accounts never paint another's startup frame. Empty when the
+// cache directory can't be resolved.
+func SnapshotCachePath() string {
+	dir := config.CacheDir()
+	if !filepath.IsAbs(dir) {
+		return ""
+	}
+	name := "dashboard-snapshots.json"
+	if profile := config.ActiveProfile(); profile != "" {
+		name = "dashboard-snapshots-" + profile + ".json"
+	}
+	return filepath.Join(dir, name)
+}
+
+// Load returns the cached snapshots for window, or nil when there is no
+// usable cache (missing, unreadable, another window, or too old).
+func (c *SnapshotCache) Load(window core.TimeWindow, now time.Time) map[string]core.UsageSnapshot {
+	if c == nil || c.path == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.readLocked(window, now)
+}
+
+// Peek returns whatever the cache holds, whatever its window or age. It is
+// for lookups such as shell completion that want any known account or metric
+// name, never for display.
+func (c *SnapshotCache) Peek() map[string]core.UsageSnapshot {
+	if c == nil || c.path == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	file, _ := c.readFileLocked()
+	return file.Snapshots
+}
+
+// Latest returns the cached snapshots however old they are, with the window
+// they were saved for and when. Offline mode shows them as the only data
+// there is, with their age in the header. Snapshots are nil when the cache
+// is missing or unreadable.
+func (c *SnapshotCache) Latest() (map[string]core.UsageSnapshot, core.TimeWindow, time.Time) {
+	if c == nil || c.path == "" {
+		return nil, "", time.Time{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	file, ok := c.readFileLocked()
+	if !ok || len(file.Snapshots) == 0 {
+		return nil, "", time.Time{}
+	}
+	return file.Snapshots, file.TimeWindow, file.SavedAt
+}
+
+func (c *SnapshotCache) readFileLocked() (snapshotCacheFile, bool) {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return snapshotCacheFile{}, false
+	}
+	var file snapshotCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		core.Tracef("snapshot cache: ignoring unreadable %s: %v", c.path, err)
+		return snapshotCacheFile{}, false
+	}
+	return file, true
+}
+
+func (c *SnapshotCache) readLocked(window core.TimeWindow, now time.Time) map[string]core.UsageSnapshot {
+	file, ok := c.readFileLocked()
+	if !ok {
+		return nil
+	}
+	if file.TimeWindow != window || now.Sub(file.SavedAt) > snapshotCacheMaxAge || len(file.Snapshots) == 0 {
+		return nil
+	}
+	return file.Snapshots
+}
+
+// Save stores the data-bearing snapshots of a frame. Accounts that are still
+// placeholders in the frame keep their previously cached snapshot, so a
+// half-ready frame doesn't evict good data. Best-effort: errors are traced,
+// not returned. The write is atomic (temp file + rename).
+func (c *SnapshotCache) Save(window core.TimeWindow, snaps map[string]core.UsageSnapshot, now time.Time) {
+	if c == nil || c.path == "" || len(snaps) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	previous := c.readLocked(window, now)
+	keep := make(map[string]core.UsageSnapshot, len(snaps))
+	for id, snap := range snaps {
+		if snap.HasData() {
+			keep[id] = snap
+		} else if old, ok := previous[id]; ok {
+			keep[id] = old
+		}
+	}
+	if len(keep) == 0 {
+		return
+	}
+	data, err := json.Marshal(snapshotCacheFile{SavedAt: now, TimeWindow: window, Snapshots: keep})
+	if err != nil {
+		core.Tracef("snapshot cache: marshal: %v", err)
+		return
+	}
+
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		core.Tracef("snapshot cache: %v", err)
+		return
+	}
+	tmp, err := os.CreateTemp(dir, ".dashboard-snapshots-*.tmp")
+	if err != nil {
+		core.Tracef("snapshot cache: %v", err)
+		return
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		_ = os.Remove(tmpName)
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return
+	}
+	if err := os.Rename(tmpName, c.path); err != nil {
+		core.Tracef("snapshot cache: %v", err)
+		_ = os.Remove(tmpName)
+	}
+}
@@ -0,0 +1,90 @@
+package dashboardapp
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestSnapshotCache_RoundTrip(t *testing.T) {
+	cache := NewSnapshotCache(filepath.Join(t.TempDir(), "cache", "dashboard-snapshots.json"))
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	used := 42.0
+
+	cache.Save(core.TimeWindow7d, map[string]core.UsageSnapshot{
+		"openai": {
+			ProviderID: "openai",
+			AccountID:  "openai",
+			Status:     core.StatusOK,
+			Metrics:    map[string]core.Metric{"spend": {Used: &used, Unit: "USD"}},
+		},
+		"anthropic": {ProviderID: "anthropic", AccountID: "anthropic", Status: core.StatusUnknown},
+	}, now)
+
+	got := cache.Load(core.TimeWindow7d, now.Add(time.Hour))
+	if len(got) != 1 {
+		t.Fatalf("Load() = %d snapshots, want 1 (placeholders are not cached)", len(got))
+	}
+	if m := got["openai"].Metrics["spend"]; m.Used == nil || *m.Used != 42 {
+		t.Fatalf("spend = %+v, want 42", m)
+	}
+
+	if got := cache.Load(core.TimeWindow30d, now); got != nil {
+		t.Fatalf("Load(other window) = %v, want nil", got)
+	}
+	if got := cache.Load(core.TimeWindow7d, now.Add(snapshotCacheMaxAge+time.Hour)); got != nil {
+		t.Fatalf("Load(expired) = %v, want nil", got)
+	}
+	if got := cache.Peek(); len(got) != 1 || got["openai"].AccountID != "openai" {
+		t.Fatalf("Peek() = %v, want the cached account whatever the window", got)
+	}
+}
+
+func TestSnapshotCache_LatestIgnoresAge(t *testing.T) {
+	cache := NewSnapshotCache(filepath.Join(t.TempDir(), "dashboard-snapshots.json"))
+	if snaps, _, _ := cache.Latest(); snaps != nil {
+		t.Fatalf("Latest() on a missing cache = %v, want nil", snaps)
+	}
+
+	saved := time.Date(2026, 1, 2, 8, 0, 0, 0, time.UTC)
+	cache.Save(core.TimeWindow1d, map[string]core.UsageSnapshot{
+		"openai": {ProviderID: "openai", AccountID: "openai", Status: core.StatusOK, Message: "ok"},
+	}, saved)
+
+	snaps, window, savedAt := cache.Latest()
+	if len(snaps) != 1 || window != core.TimeWindow1d || !savedAt.Equal(saved) {
+		t.Fatalf("Latest() = %d snapshots, %q, %s; want 1, 1d, %s", len(snaps), window, savedAt, saved)
+	}
+}
+
+func TestSnapshotCache_PlaceholderKeepsPreviousEntry(t *testing.T) {
+	cache := NewSnapshotCache(filepath.Join(t.TempDir(), "dashboard-snapshots.json"))
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	cache.Save(core.TimeWindow30d, map[string]core.UsageSnapshot{
+		"openai":    {AccountID: "openai", Status: core.StatusOK},
+		"anthropic": {AccountID: "anthropic", Status: core.StatusLimited},
+	}, now)
+	cache.Save(core.TimeWindow30d, map[string]core.UsageSnapshot{
+		"openai":    {AccountID: "openai", Status: core.StatusUnknown},
+		"anthropic": {AccountID: "anthropic", Status: core.StatusOK},
+	}, now.Add(time.Minute))
+
+	got := cache.Load(core.TimeWindow30d, now.Add(time.Minute))
+	if got["openai"].Status != core.StatusOK {
+		t.Errorf("openai status = %q, want cached %q", got["openai"].Status, core.StatusOK)
+	}
+	if got["anthropic"].Status != core.StatusOK {
+		t.Errorf("anthropic status = %q, want fresh %q", got["anthropic"].Status, core.StatusOK)
+	}
+}
+
+func TestSnapshotCache_EmptyPathIsNoop(t *testing.T) {
+	cache := NewSnapshotCache("")
+	cache.Save(core.TimeWindow30d, map[string]core.UsageSnapshot{"a": {Status: core.StatusOK}}, time.Now())
+	if got := cache.Load(core.TimeWindow30d, time.Now()); got != nil {
+		t.Fatalf("Load() = %v, want nil", got)
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package demo
 
 import (
 	"context"
@@ -112,7 +112,7 @@ func TestBuildDemoAccounts_IncludesAllDemoProviders(t *testing.T) {
 }
 
 func TestBuildDemoProviders_FetchesMockedSnapshots(t *testing.T) {
-	scenario := newDemoScenario(time.Date(2026, 4, 16, 10, 0, 0, 0, time.UTC), defaultDemoConfig())
+	scenario := newDemoScenario(time.Date(2026, 4, 16, 10, 0, 0, 0, time.UTC), DefaultOptions())
 	wrapped := buildDemoProviders(providers.AllProviders(), scenario)
 	if len(wrapped) == 0 {
 		t.Fatal("buildDemoProviders returned no providers")
@@ -205,7 +205,7 @@ func TestBuildDemoSnapshotsForPhase_ProgressesDeterministically(t *testing.T) {
 }
 
 func TestDemoScenario_StopsAtFinalFrame(t *testing.T) {
-	scenario := newDemoScenario(time.Date(2026, 4, 16, 10, 0, 0, 0, time.UTC), defaultDemoConfig())
+	scenario := newDemoScenario(time.Date(2026, 4, 16, 10, 0, 0, 0, time.UTC), DefaultOptions())
 	last := len(demoPhaseShares) - 1
 
 	for range len(demoPhaseShares) + 3 {
@@ -238,9 +238,9 @@ func TestDemoScenario_StopsAtFinalFrame(t *testing.T) {
 }
 
 func TestDemoScenario_LoopsWhenEnabled(t *testing.T) {
-	cfg := defaultDemoConfig()
-	cfg.interval = 2 * time.Second
-	cfg.loop = true
+	cfg := DefaultOptions()
+	cfg.Interval = 2 * time.Second
+	cfg.Loop = true
 	scenario := newDemoScenario(time.Date(2026, 4, 16, 10, 0, 0, 0, time.UTC), cfg)
 	account := core.AccountConfig{ID: "codex-cli", Provider: "codex"}
 
@@ -273,22 +273,115 @@ func TestDemoScenario_LoopsWhenEnabled(t *testing.T) {
 	}
 }
 
-func TestParseDemoConfig(t *testing.T) {
-	cfg, err := parseDemoConfig([]string{"-interval", "750ms", "-loop"})
-	if err != nil {
-		t.Fatalf("parseDemoConfig returned error: %v", err)
+func TestOptionsValidate(t *testing.T) {
+	opts := DefaultOptions()
+	if err := opts.Validate(); err != nil {
+		t.Fatalf("default options rejected: %v", err)
 	}
-	if cfg.interval != 750*time.Millisecond {
-		t.Fatalf("unexpected interval: got %s want %s", cfg.interval, 750*time.Millisecond)
+	opts.Interval = 0
+	if err := opts.Validate(); err == nil {
+		t.Fatal("expected zero interval to be rejected")
 	}
-	if !cfg.loop {
-		t.Fatal("expected loop flag to be true")
+	opts = DefaultOptions()
+	opts.Scenario = "on-fire"
+	if err := opts.Validate(); err == nil {
+		t.Fatal("expected unknown scenario to be rejected")
 	}
 }
 
-func TestParseDemoConfig_RejectsZeroInterval(t *testing.T) {
-	if _, err := parseDemoConfig([]string{"-interval", "0s"}); err == nil {
-		t.Fatal("expected zero interval to be rejected")
+func TestParseScenario(t *testing.T) {
+	for _, s := range Scenarios() {
+		got, err := ParseScenario(" " + strings.ToUpper(string(s)) + " ")
+		if err != nil || got != s {
+			t.Errorf("ParseScenario(%q) = %q, %v", s, got, err)
+		}
+	}
+	if got, err := ParseScenario(""); err != nil || got != ScenarioHealthy {
+		t.Errorf("ParseScenario(\"\") = %q, %v; want healthy", got, err)
+	}
+}
+
+func TestScenarioNearLimit(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Scenario = ScenarioNearLimit
+	scenario := newDemoScenario(time.Date(2026, 4, 16, 10, 0, 0, 0, time.UTC), opts)
+
+	limited := 0
+	for _, acct := range buildDemoAccounts() {
+		snap, ok := scenario.Snapshot(acct.ID, acct.Provider)
+		if !ok {
+			t.Fatalf("missing snapshot for %s", acct.ID)
+		}
+		if snap.Status != core.StatusNearLimit && snap.Status != core.StatusLimited {
+			t.Errorf("%s status = %s, want near limit or limited", acct.ID, snap.Status)
+		}
+		if snap.Status == core.StatusLimited {
+			limited++
+		}
+		for key, m := range snap.Metrics {
+			if m.Limit == nil || *m.Limit <= 0 || m.Used == nil || shouldKeepDemoMetricConstant(key, m) {
+				continue
+			}
+			if share := *m.Used / *m.Limit; share < 0.9 {
+				t.Errorf("%s %s used share = %.2f, want >= 0.9", acct.ID, key, share)
+			}
+		}
+	}
+	if limited == 0 {
+		t.Error("expected at least one account at its limit")
+	}
+}
+
+func TestScenarioAuthErrors(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Scenario = ScenarioAuthErrors
+	scenario := newDemoScenario(time.Date(2026, 4, 16, 10, 0, 0, 0, time.UTC), opts)
+
+	var auth, ok int
+	for _, acct := range buildDemoAccounts() {
+		snap, _ := scenario.Snapshot(acct.ID, acct.Provider)
+		switch snap.Status {
+		case core.StatusAuth:
+			auth++
+			if snap.Message == "" || len(snap.Metrics) != 0 {
+				t.Errorf("%s auth failure should carry a message and no metrics: %+v", acct.ID, snap)
+			}
+		default:
+			ok++
+		}
+	}
+	if auth == 0 || ok == 0 {
+		t.Fatalf("expected a mix of failing and working accounts, got %d auth / %d other", auth, ok)
+	}
+}
+
+func TestScenarioHugeFleet(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Scenario = ScenarioHugeFleet
+	scenario := newDemoScenario(time.Date(2026, 4, 16, 10, 0, 0, 0, time.UTC), opts)
+
+	base := buildDemoAccounts()
+	accounts := scenarioAccounts(opts, base)
+	if len(accounts) != len(base)*fleetCopies {
+		t.Fatalf("fleet has %d accounts, want %d", len(accounts), len(base)*fleetCopies)
+	}
+	seen := map[string]bool{}
+	for _, acct := range accounts {
+		if seen[acct.ID] {
+			t.Fatalf("duplicate fleet account %s", acct.ID)
+		}
+		seen[acct.ID] = true
+		snap, found := scenario.Snapshot(acct.ID, acct.Provider)
+		if !found || snap.AccountID != acct.ID {
+			t.Fatalf("fleet account %s: found=%v account=%q", acct.ID, found, snap.AccountID)
+		}
+	}
+
+	again := newDemoScenario(time.Date(2026, 4, 16, 10, 0, 0, 0, time.UTC), opts)
+	a, _ := scenario.Snapshot("openrouter-03", "openrouter")
+	b, _ := again.Snapshot("openrouter-03", "openrouter")
+	if a.Message != b.Message {
+		t.Fatalf("fleet data is not reproducible: %q != %q", a.Message, b.Message)
 	}
 }
 
@@ -0,0 +1,231 @@
+package demo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// Scenario selects which state the demo fleet is shown in.
+type Scenario string
+
+const (
+	// ScenarioHealthy plays back the curated fixtures used for screenshots.
+	ScenarioHealthy Scenario = "healthy"
+	// ScenarioNearLimit pushes every limited metric to 91–100% of its limit.
+	ScenarioNearLimit Scenario = "near-limit"
+	// ScenarioAuthErrors fails authentication for two of every three accounts.
+	ScenarioAuthErrors Scenario = "auth-errors"
+	// ScenarioHugeFleet clones each demo account into a fleet of
+	// fleetCopies accounts with varied usage.
+	ScenarioHugeFleet Scenario = "huge-fleet"
+)
+
+// fleetCopies is how many accounts each demo provider gets in
+// ScenarioHugeFleet, the original included.
+const fleetCopies = 6
+
+// nearLimitTargets are the used shares ScenarioNearLimit assigns, cycling
+// through the accounts in ID order.
+var nearLimitTargets = []float64{0.91, 0.96, 1.0}
+
+// Scenarios lists every scenario in display order.
+func Scenarios() []Scenario {
+	return []Scenario{ScenarioHealthy, ScenarioNearLimit, ScenarioAuthErrors, ScenarioHugeFleet, ScenarioStress}
+}
+
+// ParseScenario resolves a scenario name; empty means ScenarioHealthy.
+func ParseScenario(name string) (Scenario, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return ScenarioHealthy, nil
+	}
+	for _, s := range Scenarios() {
+		if string(s) == name {
+			return s, nil
+		}
+	}
+	names := make([]string, 0, len(Scenarios()))
+	for _, s := range Scenarios() {
+		names = append(names, string(s))
+	}
+	return "", fmt.Errorf("unknown demo scenario %q (use %s)", name, strings.Join(names, ", "))
+}
+
+// Options configures a demo run.
+type Options struct {
+	// Interval is how often playback advances to the next frame.
+	Interval time.Duration
+	// Loop restarts playback from the first frame after the final one.
+	Loop bool
+	// Scenario selects the fleet state; empty means ScenarioHealthy.
+	Scenario Scenario
+	// Theme names the theme to start with; empty keeps the default.
+	Theme string
+	// Stress sizes ScenarioStress and is ignored by the other scenarios.
+	Stress StressOptions
+}
+
+// DefaultOptions returns the options `openusage demo` starts from.
+func DefaultOptions() Options {
+	return Options{
+		Interval: defaultDemoRefreshInterval,
+		Scenario: ScenarioHealthy,
+		Stress:   DefaultStressOptions(),
+	}
+}
+
+// Validate rejects options Run cannot honor.
+func (o Options) Validate() error {
+	if o.Interval <= 0 {
+		return fmt.Errorf("interval must be greater than zero")
+	}
+	if _, err := ParseScenario(string(o.Scenario)); err != nil {
+		return err
+	}
+	if o.Scenario == ScenarioStress {
+		return o.Stress.Validate()
+	}
+	return nil
+}
+
+// applyScenario reshapes one playback frame for the selected scenario.
+func applyScenario(variant Scenario, frame map[string]core.UsageSnapshot) map[string]core.UsageSnapshot {
+	switch variant {
+	case ScenarioNearLimit:
+		for i, id := range sortedAccountIDs(frame) {
+			frame[id] = pushTowardLimits(frame[id], nearLimitTargets[i%len(nearLimitTargets)])
+		}
+	case ScenarioAuthErrors:
+		for i, id := range sortedAccountIDs(frame) {
+			switch i % 3 {
+			case 0:
+				frame[id] = failAuth(frame[id], "401 Unauthorized: API key rejected")
+			case 1:
+				frame[id] = failAuth(frame[id], "session expired — sign in to "+frame[id].ProviderID+" again")
+			}
+		}
+	case ScenarioHugeFleet:
+		for _, id := range sortedAccountIDs(frame) {
+			base := frame[id]
+			for copyIdx := 1; copyIdx < fleetCopies; copyIdx++ {
+				cloneID := fleetAccountID(id, copyIdx)
+				frame[cloneID] = scaleFleetSnapshot(base, cloneID, fleetShare(id, copyIdx))
+			}
+		}
+	}
+	return frame
+}
+
+// scenarioAccounts returns the accounts a scenario polls, starting from the
+// demo accounts.
+func scenarioAccounts(opts Options, accounts []core.AccountConfig) []core.AccountConfig {
+	switch opts.Scenario {
+	case ScenarioStress:
+		return stressAccounts(opts.Stress, accounts)
+	case ScenarioHugeFleet:
+		return fleetAccounts(accounts)
+	default:
+		return accounts
+	}
+}
+
+func fleetAccounts(accounts []core.AccountConfig) []core.AccountConfig {
+	out := make([]core.AccountConfig, 0, len(accounts)*fleetCopies)
+	for _, acct := range accounts {
+		out = append(out, acct)
+		for copyIdx := 1; copyIdx < fleetCopies; copyIdx++ {
+			clone := acct
+			clone.ID = fleetAccountID(acct.ID, copyIdx)
+			out = append(out, clone)
+		}
+	}
+	return out
+}
+
+func sortedAccountIDs(frame map[string]core.UsageSnapshot) []string {
+	ids := make([]string, 0, len(frame))
+	for id := range frame {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// pushTowardLimits sets every limited metric of snap to target of its limit
+// and marks the account near its limit, or limited at 100%.
+func pushTowardLimits(snap core.UsageSnapshot, target float64) core.UsageSnapshot {
+	for key, metric := range snap.Metrics {
+		if metric.Limit == nil || *metric.Limit <= 0 || shouldKeepDemoMetricConstant(key, metric) {
+			continue
+		}
+		used := *metric.Limit * target
+		if metric.Used != nil {
+			metric.Used = ptr(roundLike(*metric.Used, used))
+		}
+		if metric.Remaining != nil {
+			metric.Remaining = ptr(roundLike(*metric.Remaining, *metric.Limit-used))
+		}
+		snap.Metrics[key] = metric
+	}
+	snap.Status = core.StatusNearLimit
+	if target >= 1 {
+		snap.Status = core.StatusLimited
+	}
+	snap.Message = demoMessageForSnapshot(snap)
+	return snap
+}
+
+// failAuth turns snap into what a provider returns when its credential is
+// rejected: an auth status with no usage data.
+func failAuth(snap core.UsageSnapshot, message string) core.UsageSnapshot {
+	return core.UsageSnapshot{
+		ProviderID: snap.ProviderID,
+		AccountID:  snap.AccountID,
+		Timestamp:  snap.Timestamp,
+		Status:     core.StatusAuth,
+		Message:    message,
+		Metrics:    map[string]core.Metric{},
+		Resets:     map[string]time.Time{},
+		Raw:        map[string]string{},
+	}
+}
+
+func fleetAccountID(baseID string, copyIdx int) string {
+	return fmt.Sprintf("%s-%02d", baseID, copyIdx+1)
+}
+
+// fleetShare is a deterministic usage share in [0.35, 1) for one fleet
+// clone, so screenshots of the fleet are reproducible.
+func fleetShare(baseID string, copyIdx int) float64 {
+	h := uint32(copyIdx) * 37
+	for _, r := range baseID {
+		h = h*31 + uint32(r)
+	}
+	return 0.35 + 0.65*float64(h%100)/100
+}
+
+// scaleFleetSnapshot scales a copy of base by share and files it under
+// accountID.
+func scaleFleetSnapshot(base core.UsageSnapshot, accountID string, share float64) core.UsageSnapshot {
+	snap := base.DeepClone()
+	snap.AccountID = accountID
+	for key, metric := range snap.Metrics {
+		snap.Metrics[key] = scaleDemoMetric(key, metric, share)
+	}
+	snap.ModelUsage = scaleDemoModelUsage(snap.ModelUsage, share)
+	for key, pts := range snap.DailySeries {
+		scaled := make([]core.TimePoint, len(pts))
+		for i, pt := range pts {
+			pt.Value = roundDemoSeriesValue(pt.Value * share)
+			scaled[i] = pt
+		}
+		snap.DailySeries[key] = scaled
+	}
+	snap.Status = demoStatusForSnapshot(snap)
+	snap.Message = demoMessageForSnapshot(snap)
+	return snap
+}
@@ -1,12 +1,8 @@
-package main
+package demo
 
 import (
 	"context"
-	"flag"
 	"fmt"
-	"io"
-	"log"
-	"os"
 	"sync/atomic"
 	"time"
 
@@ -17,22 +13,19 @@ import (
 	"github.com/janekbaraniewski/openusage/internal/tui"
 )
 
-func main() {
-	log.SetOutput(io.Discard)
-
-	cfg, err := parseDemoConfig(os.Args[1:])
-	if err != nil {
-		if err == flag.ErrHelp {
-			fmt.Fprintf(os.Stderr, "Usage: %s [-interval 5s] [-loop]\n", os.Args[0])
-			os.Exit(0)
-		}
-		fmt.Fprintf(os.Stderr, "demo config error: %v\n", err)
-		os.Exit(2)
+// Run opens the dashboard on synthetic accounts that need no credentials,
+// daemon, or network, and blocks until the user quits.
+func Run(opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if opts.Theme != "" && !tui.SetThemeByName(opts.Theme) {
+		return fmt.Errorf("unknown theme %q", opts.Theme)
 	}
 
-	interval := cfg.interval
-	accounts := buildDemoAccounts()
-	scenario := newDemoScenario(time.Now(), cfg)
+	interval := opts.Interval
+	accounts := scenarioAccounts(opts, buildDemoAccounts())
+	scenario := newDemoScenario(time.Now(), opts)
 	demoProviders := buildDemoProviders(providers.AllProviders(), scenario)
 
 	providersByID := make(map[string]core.UsageProvider, len(demoProviders))
@@ -118,7 +111,7 @@ func main() {
 	}()
 
 	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "TUI error: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("TUI error: %w", err)
 	}
+	return nil
 }
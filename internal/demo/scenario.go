@@ -1,8 +1,6 @@
-package main
+package demo
 
 import (
-	"flag"
-	"fmt"
 	"math"
 	"strings"
 	"sync"
@@ -15,60 +13,36 @@ const defaultDemoRefreshInterval = 5 * time.Second
 
 var demoPhaseShares = []float64{0.24, 0.36, 0.49, 0.63, 0.76, 0.87, 0.95, 1.0}
 
-type demoConfig struct {
-	interval time.Duration
-	loop     bool
-}
-
-func defaultDemoConfig() demoConfig {
-	return demoConfig{
-		interval: defaultDemoRefreshInterval,
-	}
-}
-
-func parseDemoConfig(args []string) (demoConfig, error) {
-	cfg := defaultDemoConfig()
-	fs := flag.NewFlagSet("demo", flag.ContinueOnError)
-	fs.SetOutput(ioDiscard{})
-	fs.DurationVar(&cfg.interval, "interval", cfg.interval, "how often demo playback advances to the next frame")
-	fs.BoolVar(&cfg.loop, "loop", cfg.loop, "restart playback from the first frame after the final frame")
-	if err := fs.Parse(args); err != nil {
-		return demoConfig{}, err
-	}
-	if cfg.interval <= 0 {
-		return demoConfig{}, fmt.Errorf("interval must be greater than zero")
-	}
-	return cfg, nil
-}
-
-type ioDiscard struct{}
-
-func (ioDiscard) Write(p []byte) (int, error) {
-	return len(p), nil
-}
-
 type demoScenario struct {
 	mu       sync.RWMutex
 	anchor   time.Time
 	interval time.Duration
 	loop     bool
+	variant  Scenario
 	phase    int
 	frames   []map[string]core.UsageSnapshot
+	stress   *stressFleet
 }
 
-func newDemoScenario(startedAt time.Time, cfg demoConfig) *demoScenario {
+func newDemoScenario(startedAt time.Time, opts Options) *demoScenario {
 	anchor := startedAt.UTC().Truncate(time.Second)
 	if anchor.IsZero() {
 		anchor = time.Now().UTC().Truncate(time.Second)
 	}
-	if cfg.interval <= 0 {
-		cfg.interval = defaultDemoRefreshInterval
+	if opts.Interval <= 0 {
+		opts.Interval = defaultDemoRefreshInterval
 	}
 
 	scenario := &demoScenario{
 		anchor:   anchor,
-		interval: cfg.interval,
-		loop:     cfg.loop,
+		interval: opts.Interval,
+		loop:     opts.Loop,
+		variant:  opts.Scenario,
+	}
+	if opts.Scenario == ScenarioStress {
+		fixtures := buildDemoSnapshotsForPhaseWithInterval(anchor, opts.Interval, len(demoPhaseShares)-1)
+		scenario.stress = newStressFleet(opts.Stress, stressAccounts(opts.Stress, buildDemoAccounts()), fixtures)
+		return scenario
 	}
 	scenario.rebuildFramesLocked()
 	return scenario
@@ -84,6 +58,12 @@ func (s *demoScenario) Advance() bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.stress != nil {
+		s.phase++
+		s.stress.advance(s.anchor.Add(time.Duration(s.phase) * s.interval))
+		return true
+	}
+
 	last := len(s.frames) - 1
 	if s.phase >= last {
 		if s.loop {
@@ -102,11 +82,15 @@ func (s *demoScenario) Snapshot(accountID, providerID string) (core.UsageSnapsho
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if len(s.frames) == 0 {
+	var frame map[string]core.UsageSnapshot
+	switch {
+	case s.stress != nil:
+		frame = s.stress.frame
+	case len(s.frames) > 0:
+		frame = s.frames[s.phase]
+	default:
 		return core.UsageSnapshot{}, false
 	}
-
-	frame := s.frames[s.phase]
 	if snap, ok := frame[accountID]; ok && snap.ProviderID == providerID {
 		return snap.DeepClone(), true
 	}
@@ -155,7 +139,7 @@ func buildDemoSnapshotsForPhaseWithInterval(anchor time.Time, interval time.Dura
 func (s *demoScenario) rebuildFramesLocked() {
 	s.frames = make([]map[string]core.UsageSnapshot, len(demoPhaseShares))
 	for phase := range demoPhaseShares {
-		s.frames[phase] = buildDemoSnapshotsForPhaseWithInterval(s.anchor, s.interval, phase)
+		s.frames[phase] = applyScenario(s.variant, buildDemoSnapshotsForPhaseWithInterval(s.anchor, s.interval, phase))
 	}
 }
 
@@ -1,4 +1,4 @@
-package main
+package demo
 
 import (
 	"time"
@@ -48,7 +48,13 @@ func buildCopilotDemoSnapshot(now time.Time) core.UsageSnapshot {
 				Used: ptr(56), Unit: "seats", Window: "current",
 			},
 			"org_demo_active_seats": {
-				Used: ptr(44), Unit: "seats", Window: "current",
+				Limit: ptr(56), Used: ptr(44), Unit: "seats", Window: "30d",
+			},
+			"org_demo_inactive_seats": {
+				Limit: ptr(56), Used: ptr(12), Unit: "seats", Window: "30d",
+			},
+			"org_demo_unused_seat_cost": {
+				Used: ptr(228), Unit: "USD", Window: "month",
 			},
 			"model_claude_haiku_4_5_input_tokens": {
 				Used: ptr(161200), Unit: "tokens", Window: "7d",
@@ -179,13 +185,17 @@ func buildCopilotDemoSnapshot(now time.Time) core.UsageSnapshot {
 			"access_type_sku": "business",
 			"copilot_plan":    "business",
 			"premium_interactions_quota_overage_permitted": "true",
-			"model_usage":      "claude-haiku-4-5: 72%, claude-sonnet-4.6: 22%, gpt-5-mini: 6%",
-			"client_usage":     "vscode 78%, cli 17%, jetbrains 5%",
-			"model_turns":      "claude-haiku-4-5: 730, claude-sonnet-4.6: 410, gpt-5-mini: 120",
-			"model_sessions":   "claude-haiku-4-5: 28, claude-sonnet-4.6: 17, gpt-5-mini: 9",
-			"model_tool_calls": "claude-haiku-4-5: 112, claude-sonnet-4.6: 49",
-			"tool_usage":       "bash: 70 calls, view: 19 calls, web_fetch: 19 calls, edit: 14 calls",
-			"language_usage":   "go: 122 req, typescript: 67 req, yaml: 31 req, sql: 16 req",
+			"model_usage":                   "claude-haiku-4-5: 72%, claude-sonnet-4.6: 22%, gpt-5-mini: 6%",
+			"client_usage":                  "vscode 78%, cli 17%, jetbrains 5%",
+			"model_turns":                   "claude-haiku-4-5: 730, claude-sonnet-4.6: 410, gpt-5-mini: 120",
+			"model_sessions":                "claude-haiku-4-5: 28, claude-sonnet-4.6: 17, gpt-5-mini: 9",
+			"model_tool_calls":              "claude-haiku-4-5: 112, claude-sonnet-4.6: 49",
+			"tool_usage":                    "bash: 70 calls, view: 19 calls, web_fetch: 19 calls, edit: 14 calls",
+			"language_usage":                "go: 122 req, typescript: 67 req, yaml: 31 req, sql: 16 req",
+			"seat_activity_demo/demo-user":  now.Add(-2*time.Hour).UTC().Format(time.RFC3339) + " vscode",
+			"seat_activity_demo/ana-dev":    now.Add(-26*time.Hour).UTC().Format(time.RFC3339) + " JetBrains-IU",
+			"seat_activity_demo/ops-bot":    now.Add(-41*24*time.Hour).UTC().Format(time.RFC3339) + " copilot-cli",
+			"seat_activity_demo/contractor": "never",
 		},
 		ModelUsage: []core.ModelUsageRecord{
 			{
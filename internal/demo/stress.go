@@ -0,0 +1,172 @@
+package demo
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// ScenarioStress generates a fleet sized by StressOptions whose metrics keep
+// changing, for benchmarks and for checking rendering and scrolling at scale.
+const ScenarioStress Scenario = "stress"
+
+// Bounds keep a mistyped flag from exhausting memory.
+const (
+	maxStressAccounts = 5000
+	maxStressMetrics  = 1000
+)
+
+// StressOptions sizes ScenarioStress.
+type StressOptions struct {
+	// Accounts is how many accounts are generated, spread over the demo
+	// providers.
+	Accounts int
+	// Metrics is how many synthetic metrics each account carries on top of
+	// its provider fixture's own.
+	Metrics int
+	// Churn is the share, 0–1, of each account's metrics given new values
+	// on every frame.
+	Churn float64
+}
+
+// DefaultStressOptions is a few hundred accounts with ten thousand-odd
+// metrics, a fifth of them changing per frame.
+func DefaultStressOptions() StressOptions {
+	return StressOptions{Accounts: 300, Metrics: 40, Churn: 0.2}
+}
+
+// Validate rejects sizes outside the supported bounds.
+func (o StressOptions) Validate() error {
+	if o.Accounts < 1 || o.Accounts > maxStressAccounts {
+		return fmt.Errorf("stress accounts must be between 1 and %d", maxStressAccounts)
+	}
+	if o.Metrics < 0 || o.Metrics > maxStressMetrics {
+		return fmt.Errorf("stress metrics must be between 0 and %d", maxStressMetrics)
+	}
+	if o.Churn < 0 || o.Churn > 1 {
+		return fmt.Errorf("stress churn must be between 0 and 1")
+	}
+	return nil
+}
+
+// stressAccounts spreads opts.Accounts accounts round-robin over the
+// providers of base, keeping each provider's auth settings.
+func stressAccounts(opts StressOptions, base []core.AccountConfig) []core.AccountConfig {
+	if len(base) == 0 {
+		return nil
+	}
+	templates := append([]core.AccountConfig(nil), base...)
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Provider < templates[j].Provider })
+	out := make([]core.AccountConfig, 0, opts.Accounts)
+	for i := range opts.Accounts {
+		acct := templates[i%len(templates)]
+		acct.ID = stressAccountID(acct.Provider, i)
+		out = append(out, acct)
+	}
+	return out
+}
+
+func stressAccountID(providerID string, i int) string {
+	return fmt.Sprintf("stress-%s-%04d", providerID, i+1)
+}
+
+func stressMetricKey(i int) string {
+	return fmt.Sprintf("stress_metric_%04d", i+1)
+}
+
+// stressFleet is the live state of ScenarioStress. Unlike the fixture
+// scenarios it has no final frame: every advance re-rolls opts.Churn of each
+// account's metrics, from an RNG with a fixed seed so runs are reproducible.
+type stressFleet struct {
+	opts  StressOptions
+	rng   *rand.Rand
+	frame map[string]core.UsageSnapshot
+}
+
+// newStressFleet builds the first frame: each account a scaled copy of its
+// provider's fixture in fixtures, plus opts.Metrics synthetic metrics.
+func newStressFleet(opts StressOptions, accounts []core.AccountConfig, fixtures map[string]core.UsageSnapshot) *stressFleet {
+	f := &stressFleet{
+		opts:  opts,
+		rng:   rand.New(rand.NewPCG(uint64(opts.Accounts), uint64(opts.Metrics))),
+		frame: make(map[string]core.UsageSnapshot, len(accounts)),
+	}
+	for i, acct := range accounts {
+		fixture, ok := snapshotForProvider(fixtures, acct.Provider)
+		if !ok {
+			continue
+		}
+		snap := scaleFleetSnapshot(fixture, acct.ID, 0.2+0.8*f.rng.Float64())
+		for m := range opts.Metrics {
+			snap.Metrics[stressMetricKey(m)] = randomStressMetric(f.rng, m)
+		}
+		if i%17 == 16 {
+			snap.Status = core.StatusNearLimit
+		}
+		f.frame[acct.ID] = snap
+	}
+	return f
+}
+
+// advance moves the fleet to the next frame, stamped at.
+func (f *stressFleet) advance(at time.Time) {
+	for _, id := range sortedAccountIDs(f.frame) {
+		snap := f.frame[id]
+		snap.Timestamp = at
+		keys := make([]string, 0, len(snap.Metrics))
+		for key := range snap.Metrics {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if f.rng.Float64() < f.opts.Churn {
+				snap.Metrics[key] = churnStressMetric(f.rng, snap.Metrics[key])
+			}
+		}
+		f.frame[id] = snap
+	}
+}
+
+func snapshotForProvider(frame map[string]core.UsageSnapshot, providerID string) (core.UsageSnapshot, bool) {
+	for _, id := range sortedAccountIDs(frame) {
+		if frame[id].ProviderID == providerID {
+			return frame[id], true
+		}
+	}
+	return core.UsageSnapshot{}, false
+}
+
+// randomStressMetric alternates limited gauges, counters and costs so every
+// metric renderer is exercised.
+func randomStressMetric(rng *rand.Rand, i int) core.Metric {
+	switch i % 3 {
+	case 0:
+		limit := float64(100 * (1 + rng.IntN(50)))
+		used := roundLike(1, limit*rng.Float64())
+		return core.Metric{Used: ptr(used), Limit: ptr(limit), Remaining: ptr(limit - used), Unit: "requests", Window: "1d"}
+	case 1:
+		return core.Metric{Used: ptr(float64(rng.IntN(5_000_000))), Unit: "tokens", Window: "30d"}
+	default:
+		return core.Metric{Used: ptr(roundLike(0.5, rng.Float64()*250)), Unit: "USD", Window: "30d"}
+	}
+}
+
+// churnStressMetric moves a metric's value by up to ±25%, keeping gauges
+// within their limit.
+func churnStressMetric(rng *rand.Rand, m core.Metric) core.Metric {
+	if m.Used == nil {
+		return m
+	}
+	used := *m.Used * (0.75 + 0.5*rng.Float64())
+	if m.Limit != nil && *m.Limit > 0 {
+		used = min(used, *m.Limit)
+		if m.Remaining != nil {
+			m.Remaining = ptr(roundLike(*m.Remaining, *m.Limit-used))
+		}
+	}
+	m.Used = ptr(roundLike(*m.Used, used))
+	return m
+}
@@ -0,0 +1,161 @@
+package demo
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/tui"
+)
+
+var stressAnchor = time.Date(2026, 4, 16, 10, 0, 0, 0, time.UTC)
+
+func stressOptions(accounts, metrics int, churn float64) Options {
+	opts := DefaultOptions()
+	opts.Scenario = ScenarioStress
+	opts.Stress = StressOptions{Accounts: accounts, Metrics: metrics, Churn: churn}
+	return opts
+}
+
+func TestScenarioStress(t *testing.T) {
+	opts := stressOptions(120, 30, 0.25)
+	scenario := newDemoScenario(stressAnchor, opts)
+	accounts := scenarioAccounts(opts, buildDemoAccounts())
+	if len(accounts) != 120 {
+		t.Fatalf("stress fleet has %d accounts, want 120", len(accounts))
+	}
+
+	before := make(map[string]core.UsageSnapshot, len(accounts))
+	for _, acct := range accounts {
+		snap, ok := scenario.Snapshot(acct.ID, acct.Provider)
+		if !ok || snap.AccountID != acct.ID {
+			t.Fatalf("stress account %s: found=%v account=%q", acct.ID, ok, snap.AccountID)
+		}
+		if _, ok := snap.Metrics[stressMetricKey(29)]; !ok {
+			t.Fatalf("stress account %s missing synthetic metrics", acct.ID)
+		}
+		before[acct.ID] = snap
+	}
+
+	// Stress playback never runs out of frames.
+	for range len(demoPhaseShares) + 2 {
+		if !scenario.Advance() {
+			t.Fatal("stress scenario stopped advancing")
+		}
+	}
+
+	var total, changed int
+	for _, acct := range accounts {
+		after, _ := scenario.Snapshot(acct.ID, acct.Provider)
+		if !after.Timestamp.After(before[acct.ID].Timestamp) {
+			t.Fatalf("%s timestamp did not move: %s", acct.ID, after.Timestamp)
+		}
+		for key := range opts.Stress.Metrics {
+			k := stressMetricKey(key)
+			total++
+			if *after.Metrics[k].Used != *before[acct.ID].Metrics[k].Used {
+				changed++
+			}
+			if m := after.Metrics[k]; m.Limit != nil && *m.Used > *m.Limit {
+				t.Fatalf("%s %s used %.0f beyond limit %.0f", acct.ID, k, *m.Used, *m.Limit)
+			}
+		}
+	}
+	if changed == 0 || changed == total {
+		t.Fatalf("churn changed %d of %d metrics, want some but not all", changed, total)
+	}
+}
+
+func TestScenarioStress_Reproducible(t *testing.T) {
+	opts := stressOptions(20, 5, 0.5)
+	a := newDemoScenario(stressAnchor, opts)
+	b := newDemoScenario(stressAnchor, opts)
+	a.Advance()
+	b.Advance()
+	var id string
+	for _, acct := range stressAccounts(opts.Stress, buildDemoAccounts()) {
+		if acct.Provider == "openrouter" {
+			id = acct.ID
+			break
+		}
+	}
+	snapA, _ := a.Snapshot(id, "openrouter")
+	snapB, _ := b.Snapshot(id, "openrouter")
+	if *snapA.Metrics[stressMetricKey(0)].Used != *snapB.Metrics[stressMetricKey(0)].Used {
+		t.Fatal("stress runs with the same options diverged")
+	}
+}
+
+func TestStressOptionsValidate(t *testing.T) {
+	for _, bad := range []StressOptions{
+		{Accounts: 0, Metrics: 10, Churn: 0.1},
+		{Accounts: maxStressAccounts + 1, Metrics: 10, Churn: 0.1},
+		{Accounts: 10, Metrics: -1, Churn: 0.1},
+		{Accounts: 10, Metrics: 10, Churn: 1.5},
+	} {
+		opts := DefaultOptions()
+		opts.Scenario = ScenarioStress
+		opts.Stress = bad
+		if err := opts.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want error", bad)
+		}
+	}
+}
+
+// stressModel returns a dashboard sized w×h holding the first stress frame.
+func stressModel(b *testing.B, opts Options, w, h int) (tea.Model, *demoScenario, []core.AccountConfig) {
+	b.Helper()
+	accounts := scenarioAccounts(opts, buildDemoAccounts())
+	scenario := newDemoScenario(stressAnchor, opts)
+	var model tea.Model = tui.NewModel(0.20, 0.05, false, config.DashboardConfig{}, accounts, core.TimeWindow30d)
+	model, _ = model.Update(tea.WindowSizeMsg{Width: w, Height: h})
+	model, _ = model.Update(tui.SnapshotsMsg{Snapshots: stressFrame(scenario, accounts), TimeWindow: core.TimeWindow30d, RequestID: 1})
+	return model, scenario, accounts
+}
+
+func stressFrame(scenario *demoScenario, accounts []core.AccountConfig) map[string]core.UsageSnapshot {
+	snaps := make(map[string]core.UsageSnapshot, len(accounts))
+	for _, acct := range accounts {
+		if snap, ok := scenario.Snapshot(acct.ID, acct.Provider); ok {
+			snaps[acct.ID] = snap
+		}
+	}
+	return snaps
+}
+
+func BenchmarkStressAdvance(b *testing.B) {
+	scenario := newDemoScenario(stressAnchor, stressOptions(300, 40, 0.2))
+	for b.Loop() {
+		scenario.Advance()
+	}
+}
+
+func BenchmarkStressView(b *testing.B) {
+	model, _, _ := stressModel(b, stressOptions(300, 40, 0.2), 200, 60)
+	for b.Loop() {
+		_ = model.View()
+	}
+}
+
+func BenchmarkStressChurnAndView(b *testing.B) {
+	model, scenario, accounts := stressModel(b, stressOptions(300, 40, 0.2), 200, 60)
+	var requestID uint64 = 1
+	for b.Loop() {
+		scenario.Advance()
+		requestID++
+		model, _ = model.Update(tui.SnapshotsMsg{Snapshots: stressFrame(scenario, accounts), TimeWindow: core.TimeWindow30d, RequestID: requestID})
+		_ = model.View()
+	}
+}
+
+func BenchmarkStressScroll(b *testing.B) {
+	model, _, _ := stressModel(b, stressOptions(300, 40, 0.2), 200, 60)
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	for b.Loop() {
+		model, _ = model.Update(down)
+		_ = model.View()
+	}
+}
@@ -38,9 +38,22 @@ func detectAiderConfig(result *Result) {
 		return
 	}
 
+	for _, p := range aiderConfigPaths() {
+		switch filepath.Base(p) {
+		case ".aider.conf.yml":
+			adoptAiderYAML(result, p)
+		case ".env":
+			adoptAiderDotenv(result, p)
+		}
+	}
+}
+
+// aiderConfigPaths returns the existing .aider.conf.yml and .env files in
+// Aider's search scopes, highest precedence (cwd) first.
+func aiderConfigPaths() []string {
 	home := homeDir()
 	if home == "" {
-		return
+		return nil
 	}
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -59,15 +72,7 @@ func detectAiderConfig(result *Result) {
 			filepath.Join(scope, ".env"),
 		)
 	}
-
-	for _, p := range uniqueExisting(paths) {
-		switch filepath.Base(p) {
-		case ".aider.conf.yml":
-			adoptAiderYAML(result, p)
-		case ".env":
-			adoptAiderDotenv(result, p)
-		}
-	}
+	return uniqueExisting(paths)
 }
 
 // aiderToolDetected reports whether detectAider already added the Aider
@@ -131,20 +136,29 @@ type aiderYAML struct {
 }
 
 func adoptAiderYAML(result *Result, path string) {
-	data, err := os.ReadFile(path)
+	for _, d := range aiderYAMLKeys(path) {
+		adoptAPIKey(result, d.envKeyMappingEntry, d.Value, "aider_yaml:"+path)
+	}
+}
+
+// aiderYAMLKeys returns every recognised provider key in one
+// .aider.conf.yml, in file order. Read and parse errors are logged and yield
+// no keys.
+func aiderYAMLKeys(path string) []shellRCDiscovery {
+	data, err := readCredentialFile("", "", path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
 			log.Printf("[detect] aider %s read error: %v", path, err)
 		}
-		return
+		return nil
 	}
 	var cfg aiderYAML
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		log.Printf("[detect] aider %s parse error: %v", path, err)
-		return
+		return nil
 	}
-	source := "aider_yaml:" + path
 
+	var out []shellRCDiscovery
 	for _, d := range []struct {
 		envVar string
 		value  string
@@ -159,7 +173,7 @@ func adoptAiderYAML(result *Result, path string) {
 		if !ok {
 			continue
 		}
-		adoptAPIKey(result, mapping, d.value, source)
+		out = append(out, shellRCDiscovery{envKeyMappingEntry: mapping, Value: d.value, Path: path})
 	}
 
 	// List form: each entry is "<provider>=<key>" where <provider> is
@@ -185,21 +199,30 @@ func adoptAiderYAML(result *Result, path string) {
 		if value == "" {
 			continue
 		}
-		adoptAPIKey(result, mapping, value, source)
+		out = append(out, shellRCDiscovery{envKeyMappingEntry: mapping, Value: value, Path: path})
 	}
+	return out
 }
 
 func adoptAiderDotenv(result *Result, path string) {
+	for _, d := range aiderDotenvKeys(path) {
+		adoptAPIKey(result, d.envKeyMappingEntry, d.Value, "aider_dotenv:"+path)
+	}
+}
+
+// aiderDotenvKeys returns every recognised provider key in one .env file.
+func aiderDotenvKeys(path string) []shellRCDiscovery {
 	f, err := os.Open(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
 			log.Printf("[detect] aider %s read error: %v", path, err)
 		}
-		return
+		return nil
 	}
 	defer f.Close()
-	source := "aider_dotenv:" + path
+	recordCredentialRead("", "", "file:"+path)
 
+	var out []shellRCDiscovery
 	scanner := bufio.NewScanner(f)
 	scanner.Buffer(make([]byte, 64*1024), 1<<20)
 	for scanner.Scan() {
@@ -211,9 +234,10 @@ func adoptAiderDotenv(result *Result, path string) {
 		if !known {
 			continue
 		}
-		adoptAPIKey(result, mapping, value, source)
+		out = append(out, shellRCDiscovery{envKeyMappingEntry: mapping, Value: value, Path: path})
 	}
 	if err := scanner.Err(); err != nil {
 		log.Printf("[detect] aider %s scan error: %v", path, err)
 	}
+	return out
 }
@@ -2,11 +2,19 @@ package detect
 
 import (
 	"log"
+	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/claude_code"
 )
 
+// claudeInstallSuffix turns a sibling config dir name (".claude-nightly")
+// into an account ID suffix.
+var claudeInstallSuffix = regexp.MustCompile(`[^a-z0-9]+`)
+
 func detectClaudeCode(result *Result) {
 	bin := findBinary("claude")
 	if bin == "" {
@@ -15,8 +23,14 @@ func detectClaudeCode(result *Result) {
 
 	home := homeDir()
 	configDir := filepath.Join(home, ".claude")
-	statsFile := filepath.Join(configDir, "stats-cache.json")
 	accountFile := filepath.Join(home, ".claude.json")
+	// CLAUDE_CONFIG_DIR moves the whole config directory, .claude.json
+	// included.
+	if dir := envDataRoot(claude_code.LocalData); dir != "" {
+		configDir = dir
+		accountFile = filepath.Join(dir, ".claude.json")
+	}
+	statsFile := filepath.Join(configDir, "stats-cache.json")
 
 	tool := DetectedTool{
 		Name:       "Claude Code CLI",
@@ -49,4 +63,63 @@ func detectClaudeCode(result *Result) {
 	} else {
 		log.Printf("[detect] Claude Code found but no stats data at expected locations")
 	}
+
+	detectExtraClaudeInstalls(result, bin, home, configDir)
+}
+
+// detectExtraClaudeInstalls adds an account per additional Claude Code
+// config directory, such as a preview build run with
+// CLAUDE_CONFIG_DIR=~/.claude-nightly. Each gets a data_path, so its stats,
+// conversations and login stay separate from the primary install's. The
+// default ~/.claude counts as extra when CLAUDE_CONFIG_DIR points elsewhere.
+func detectExtraClaudeInstalls(result *Result, bin, home, primaryDir string) {
+	if home == "" {
+		return
+	}
+	candidates := map[string]string{} // dir -> account ID
+	if dir := filepath.Join(home, ".claude"); dir != primaryDir {
+		candidates[dir] = "claude-code-default"
+	}
+	siblings, _ := filepath.Glob(filepath.Join(home, ".claude-*"))
+	for _, dir := range siblings {
+		name := strings.ToLower(strings.TrimPrefix(filepath.Base(dir), ".claude-"))
+		suffix := strings.Trim(claudeInstallSuffix.ReplaceAllString(name, "-"), "-")
+		if suffix == "" || dir == primaryDir {
+			continue
+		}
+		candidates[dir] = "claude-code-" + suffix
+	}
+
+	for _, dir := range core.SortedStringKeys(candidates) {
+		if !isClaudeConfigDir(dir) {
+			continue
+		}
+		id := candidates[dir]
+		log.Printf("[detect] Found additional Claude Code config dir %s (account %s)", dir, id)
+		result.Tools = append(result.Tools, DetectedTool{
+			Name:       "Claude Code CLI (" + strings.TrimPrefix(id, "claude-code-") + ")",
+			BinaryPath: bin,
+			ConfigDir:  dir,
+			Type:       "cli",
+		})
+		addAccount(result, core.AccountConfig{
+			ID:       id,
+			Provider: "claude_code",
+			Auth:     "local",
+			DataPath: dir,
+		})
+	}
+}
+
+// isClaudeConfigDir reports whether dir holds Claude Code data rather than
+// being an unrelated directory that happens to share the prefix.
+func isClaudeConfigDir(dir string) bool {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return false
+	}
+	if fileExists(filepath.Join(dir, "stats-cache.json")) || fileExists(filepath.Join(dir, ".claude.json")) {
+		return true
+	}
+	info, err := os.Stat(filepath.Join(dir, "projects"))
+	return err == nil && info.IsDir()
 }
@@ -0,0 +1,99 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestDetectClaudeCode_HonorsConfigDirEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses shell scripts")
+	}
+
+	bin := t.TempDir()
+	writeExe(t, bin, "claude", "exit 0")
+	t.Setenv("PATH", bin)
+	t.Setenv("OPENUSAGE_DETECT_BIN_DIRS", "")
+	setHome(t, t.TempDir())
+
+	configDir := t.TempDir()
+	for _, name := range []string{"stats-cache.json", ".claude.json"} {
+		if err := os.WriteFile(filepath.Join(configDir, name), []byte("{}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("CLAUDE_CONFIG_DIR", configDir)
+
+	var result Result
+	detectClaudeCode(&result)
+
+	if len(result.Accounts) != 1 {
+		t.Fatalf("expected 1 account, got %+v", result.Accounts)
+	}
+	acct := result.Accounts[0]
+	if got := acct.Path("stats_cache", ""); got != filepath.Join(configDir, "stats-cache.json") {
+		t.Errorf("stats_cache = %q, want it under CLAUDE_CONFIG_DIR", got)
+	}
+	if got := acct.Path("account_config", ""); got != filepath.Join(configDir, ".claude.json") {
+		t.Errorf("account_config = %q, want it under CLAUDE_CONFIG_DIR", got)
+	}
+	if result.Tools[0].ConfigDir != configDir {
+		t.Errorf("ConfigDir = %q, want %q", result.Tools[0].ConfigDir, configDir)
+	}
+}
+
+func TestDetectClaudeCode_FindsSeparateInstalls(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses shell scripts")
+	}
+
+	bin := t.TempDir()
+	writeExe(t, bin, "claude", "exit 0")
+	t.Setenv("PATH", bin)
+	t.Setenv("OPENUSAGE_DETECT_BIN_DIRS", "")
+	t.Setenv("CLAUDE_CONFIG_DIR", "")
+	home := t.TempDir()
+	setHome(t, home)
+
+	mkdir := func(parts ...string) string {
+		t.Helper()
+		dir := filepath.Join(append([]string{home}, parts...)...)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	mkdir(".claude", "projects")
+	if err := os.WriteFile(filepath.Join(home, ".claude.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	nightly := mkdir(".claude-Nightly", "projects")
+	nightly = filepath.Dir(nightly)
+	mkdir(".claude-empty") // no Claude Code data: not an install
+
+	var result Result
+	detectClaudeCode(&result)
+
+	if len(result.Accounts) != 2 {
+		t.Fatalf("expected 2 accounts, got %+v", result.Accounts)
+	}
+	if result.Accounts[0].ID != "claude-code" || result.Accounts[0].DataPath != "" {
+		t.Errorf("primary = %+v", result.Accounts[0])
+	}
+	if got := result.Accounts[1]; got.ID != "claude-code-nightly" || got.DataPath != nightly {
+		t.Errorf("nightly = %+v, want data_path %s", got, nightly)
+	}
+	if len(result.Tools) != 2 || result.Tools[1].ConfigDir != nightly {
+		t.Errorf("tools = %+v", result.Tools)
+	}
+
+	// With CLAUDE_CONFIG_DIR on the nightly dir, ~/.claude is the extra one.
+	t.Setenv("CLAUDE_CONFIG_DIR", nightly)
+	result = Result{}
+	detectClaudeCode(&result)
+	if len(result.Accounts) != 1 || result.Accounts[0].ID != "claude-code-default" {
+		t.Fatalf("accounts = %+v, want only claude-code-default (nightly has no stats or .claude.json)", result.Accounts)
+	}
+}
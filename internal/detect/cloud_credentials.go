@@ -0,0 +1,220 @@
+package detect
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+)
+
+// Cloud credential source IDs, as used under detect.cloud_sources in
+// settings.json.
+const (
+	CloudSourceAWS      = "aws"
+	CloudSourceGcloud   = "gcloud"
+	CloudSourceAzureCLI = "azure_cli"
+)
+
+// cloudSource is one cloud SDK credential store. Each detector reports the
+// store as a tool and turns what it finds into accounts, or into hints on
+// accounts earlier phases registered, for the providers that can use it.
+type cloudSource struct {
+	ID     string
+	detect func(result *Result)
+}
+
+// cloudSources lists the stores in the order they are read. A source for a
+// cloud whose model provider OpenUsage doesn't have yet (Bedrock, Vertex AI)
+// only reports the store; its detector is where that provider's accounts
+// get proposed once it exists.
+var cloudSources = []cloudSource{
+	{ID: CloudSourceAWS, detect: detectAWSCredentials},
+	{ID: CloudSourceGcloud, detect: detectGcloudADC},
+	{ID: CloudSourceAzureCLI, detect: detectAzureCLI},
+}
+
+// detectCloudCredentials runs the cloud credential sources the user hasn't
+// turned off.
+func detectCloudCredentials(result *Result, cfg config.DetectConfig) {
+	for _, src := range cloudSources {
+		if !cfg.CloudSourceEnabled(src.ID) {
+			log.Printf("[detect] cloud source %s disabled in settings", src.ID)
+			continue
+		}
+		src.detect(result)
+	}
+}
+
+// detectAWSCredentials reads the profile names from the AWS shared
+// credentials and config files. No key material is kept.
+func detectAWSCredentials(result *Result) {
+	home := homeDir()
+	credsPath := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	configPath := os.Getenv("AWS_CONFIG_FILE")
+	if home != "" {
+		if credsPath == "" {
+			credsPath = filepath.Join(home, ".aws", "credentials")
+		}
+		if configPath == "" {
+			configPath = filepath.Join(home, ".aws", "config")
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, path := range []string{credsPath, configPath} {
+		if path == "" || !fileExists(path) {
+			continue
+		}
+		profiles, err := awsProfiles(path, path == configPath)
+		if err != nil {
+			log.Printf("[detect] aws profiles read error: %v", err)
+			continue
+		}
+		for _, p := range profiles {
+			seen[p] = true
+		}
+	}
+	if len(seen) == 0 {
+		return
+	}
+
+	profiles := make([]string, 0, len(seen))
+	for p := range seen {
+		profiles = append(profiles, p)
+	}
+	sort.Strings(profiles)
+	result.Tools = append(result.Tools, DetectedTool{
+		Name:      "AWS credentials",
+		ConfigDir: filepath.Dir(credsPath),
+		Type:      "cloud",
+	})
+	log.Printf("[detect] AWS profiles found: %s", strings.Join(profiles, ", "))
+}
+
+// awsProfiles returns the profile names declared in an AWS INI file. The
+// config file prefixes every profile but the default with "profile ".
+func awsProfiles(path string, isConfig bool) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	recordCredentialRead("", "", "file:"+path)
+
+	var profiles []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "[") || !strings.HasSuffix(line, "]") {
+			continue
+		}
+		name := strings.TrimSpace(line[1 : len(line)-1])
+		if isConfig && name != "default" {
+			var ok bool
+			if name, ok = strings.CutPrefix(name, "profile "); !ok {
+				// [sso-session …] and [services …] blocks aren't profiles.
+				continue
+			}
+			name = strings.TrimSpace(name)
+		}
+		if name != "" {
+			profiles = append(profiles, name)
+		}
+	}
+	return profiles, scanner.Err()
+}
+
+// detectGcloudADC reports Application Default Credentials and annotates
+// the Gemini accounts they can serve.
+func detectGcloudADC(result *Result) {
+	if !probeGcloudADCFile(result) {
+		return
+	}
+	result.Tools = append(result.Tools, DetectedTool{
+		Name:      "Google Cloud ADC",
+		ConfigDir: filepath.Dir(gcloudADCPath(homeDir())),
+		Type:      "cloud",
+	})
+}
+
+// azureProfile is the subset of the Azure CLI's azureProfile.json we read.
+type azureProfile struct {
+	Subscriptions []struct {
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		TenantID  string `json:"tenantId"`
+		IsDefault bool   `json:"isDefault"`
+	} `json:"subscriptions"`
+}
+
+// detectAzureCLI reads the Azure CLI's signed-in subscriptions. Azure
+// OpenAI still needs a resource API key, so the default subscription is
+// attached to the azure_openai accounts as a hint rather than creating an
+// account that could never authenticate.
+func detectAzureCLI(result *Result) {
+	dir := azureConfigDir()
+	if dir == "" {
+		return
+	}
+	path := filepath.Join(dir, "azureProfile.json")
+	if !fileExists(path) {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Printf("[detect] azure profile read error: %v", err)
+		return
+	}
+	var profile azureProfile
+	// The CLI writes the file with a UTF-8 byte order mark.
+	if err := json.Unmarshal(bytes.TrimPrefix(data, []byte("\ufeff")), &profile); err != nil {
+		log.Printf("[detect] azure profile parse error: %v", err)
+		return
+	}
+	if len(profile.Subscriptions) == 0 {
+		return
+	}
+
+	result.Tools = append(result.Tools, DetectedTool{
+		Name:      "Azure CLI",
+		ConfigDir: dir,
+		Type:      "cloud",
+	})
+
+	sub := profile.Subscriptions[0]
+	for _, s := range profile.Subscriptions {
+		if s.IsDefault {
+			sub = s
+			break
+		}
+	}
+	for i := range result.Accounts {
+		if result.Accounts[i].Provider != "azure_openai" {
+			continue
+		}
+		result.Accounts[i].SetHint("azure_subscription", sub.ID)
+		if sub.TenantID != "" {
+			result.Accounts[i].SetHint("azure_tenant", sub.TenantID)
+		}
+	}
+	log.Printf("[detect] Azure CLI signed in to %d subscription(s), default %q", len(profile.Subscriptions), sub.Name)
+}
+
+// azureConfigDir returns the Azure CLI config directory, honouring
+// AZURE_CONFIG_DIR like the CLI does.
+func azureConfigDir() string {
+	if v := os.Getenv("AZURE_CONFIG_DIR"); v != "" {
+		return v
+	}
+	home := homeDir()
+	if home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".azure")
+}
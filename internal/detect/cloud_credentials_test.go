@@ -0,0 +1,98 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func writeTestFile(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+func TestAWSProfiles(t *testing.T) {
+	dir := t.TempDir()
+	creds := filepath.Join(dir, "credentials")
+	writeTestFile(t, creds, "[default]\naws_access_key_id = AKIAFAKE\n\n[ work ]\naws_access_key_id = AKIAFAKE2\n")
+	cfg := filepath.Join(dir, "config")
+	writeTestFile(t, cfg, "[default]\nregion = us-east-1\n[profile sso-dev]\nsso_session = corp\n[sso-session corp]\nsso_region = us-east-1\n")
+
+	got, err := awsProfiles(creds, false)
+	if err != nil || !reflect.DeepEqual(got, []string{"default", "work"}) {
+		t.Fatalf("credentials profiles = %v, %v", got, err)
+	}
+	got, err = awsProfiles(cfg, true)
+	if err != nil || !reflect.DeepEqual(got, []string{"default", "sso-dev"}) {
+		t.Fatalf("config profiles = %v, %v", got, err)
+	}
+}
+
+func TestDetectAzureCLI_AnnotatesAzureOpenAI(t *testing.T) {
+	home := withCleanCredentialEnv(t)
+	t.Setenv("AZURE_CONFIG_DIR", "")
+	writeTestFile(t, filepath.Join(home, ".azure", "azureProfile.json"), "\ufeff"+`{"subscriptions": [
+		{"id": "sub-1", "name": "Dev", "tenantId": "tenant-1", "isDefault": false},
+		{"id": "sub-2", "name": "Prod", "tenantId": "tenant-2", "isDefault": true}
+	]}`)
+
+	result := Result{Accounts: []core.AccountConfig{
+		{ID: "azure_openai", Provider: "azure_openai"},
+		{ID: "openai", Provider: "openai"},
+	}}
+	detectAzureCLI(&result)
+
+	if len(result.Tools) != 1 || result.Tools[0].Name != "Azure CLI" {
+		t.Fatalf("tools = %+v, want Azure CLI", result.Tools)
+	}
+	if got := result.Accounts[0].Hint("azure_subscription", ""); got != "sub-2" {
+		t.Errorf("azure_subscription = %q, want the default subscription", got)
+	}
+	if got := result.Accounts[0].Hint("azure_tenant", ""); got != "tenant-2" {
+		t.Errorf("azure_tenant = %q", got)
+	}
+	if got := result.Accounts[1].Hint("azure_subscription", ""); got != "" {
+		t.Errorf("openai account annotated with %q", got)
+	}
+}
+
+func TestDetectCloudCredentials_RespectsOptOut(t *testing.T) {
+	home := withCleanCredentialEnv(t)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "")
+	t.Setenv("AWS_CONFIG_FILE", "")
+	t.Setenv("AZURE_CONFIG_DIR", "")
+	writeTestFile(t, filepath.Join(home, ".aws", "credentials"), "[default]\naws_access_key_id = AKIAFAKE\n")
+	writeTestFile(t, filepath.Join(home, ".azure", "azureProfile.json"), `{"subscriptions": [{"id": "sub-1", "isDefault": true}]}`)
+
+	tests := []struct {
+		name string
+		cfg  config.DetectConfig
+		want []string
+	}{
+		{"all enabled", config.DetectConfig{}, []string{"AWS credentials", "Azure CLI"}},
+		{"aws off", config.DetectConfig{CloudSources: map[string]bool{CloudSourceAWS: false}}, []string{"Azure CLI"}},
+		{"explicitly on", config.DetectConfig{CloudSources: map[string]bool{CloudSourceAzureCLI: true}}, []string{"AWS credentials", "Azure CLI"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result Result
+			detectCloudCredentials(&result, tt.cfg)
+			var got []string
+			for _, tool := range result.Tools {
+				got = append(got, tool.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("tools = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
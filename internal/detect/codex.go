@@ -117,7 +117,7 @@ type codexTokens struct {
 }
 
 func extractCodexAuth(authFile string) (email, accountID, planType, openaiAPIKey string) {
-	data, err := os.ReadFile(authFile)
+	data, err := readCredentialFile("codex", "codex-cli", authFile)
 	if err != nil {
 		log.Printf("[detect] Cannot read Codex auth.json: %v", err)
 		return "", "", "", ""
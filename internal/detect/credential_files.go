@@ -1,6 +1,7 @@
 package detect
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"os"
@@ -9,6 +10,7 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/janekbaraniewski/openusage/internal/audit"
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
 
@@ -22,8 +24,9 @@ import (
 //   - GitHub CLI: ~/.config/gh/hosts.yml (Linux/macOS) or
 //     %APPDATA%/GitHub CLI/hosts.yml (Windows). Plaintext fallback when the
 //     system keychain is unavailable; contains a usable OAuth token.
-//   - Google Cloud ADC: ~/.config/gcloud/application_default_credentials.json
-//     (or %APPDATA%/gcloud/...) — refresh token usable for Gemini / Vertex.
+//
+// Google Cloud ADC is probed with the other cloud SDK stores in
+// cloud_credentials.go so it can be opted out of.
 //
 // We never extract OAuth refresh values into Token here — those need a
 // provider-specific refresh exchange before they're usable. We surface
@@ -32,7 +35,6 @@ import (
 func detectCredentialFiles(result *Result) {
 	probeClaudeCodeCredentialsFile(result)
 	probeGHHostsFile(result)
-	probeGcloudADCFile(result)
 }
 
 // probeClaudeCodeCredentialsFile annotates / creates a claude-code account
@@ -53,7 +55,7 @@ func probeClaudeCodeCredentialsFile(result *Result) {
 
 	// Quick parse to confirm it has an accessToken — avoids annotating on a
 	// truncated / aborted-login file. We don't expose the value.
-	data, err := os.ReadFile(path)
+	data, err := readCredentialFile("claude_code", "claude-code", path)
 	if err != nil {
 		log.Printf("[detect] claude code credentials read error: %v", err)
 		return
@@ -84,7 +86,7 @@ func probeGHHostsFile(result *Result) {
 		return
 	}
 
-	data, err := os.ReadFile(path)
+	data, err := readCredentialFile("copilot", "copilot", path)
 	if err != nil {
 		log.Printf("[detect] gh hosts.yml read error: %v", err)
 		return
@@ -113,21 +115,21 @@ func probeGHHostsFile(result *Result) {
 // a Vertex provider account — the file's mere presence is informational.
 // When the gemini_api or gemini_cli account is already registered we
 // annotate it with "you also have ADC" so users can see the relationship in
-// `openusage detect`.
-func probeGcloudADCFile(result *Result) {
+// `openusage detect`. It reports whether usable user credentials were found.
+func probeGcloudADCFile(result *Result) bool {
 	home := homeDir()
 	if home == "" {
-		return
+		return false
 	}
 	path := gcloudADCPath(home)
 	if path == "" || !fileExists(path) {
-		return
+		return false
 	}
 
-	data, err := os.ReadFile(path)
+	data, err := readCredentialFile("", "", path)
 	if err != nil {
 		log.Printf("[detect] gcloud ADC read error: %v", err)
-		return
+		return false
 	}
 	var creds struct {
 		Type         string `json:"type"`
@@ -135,11 +137,11 @@ func probeGcloudADCFile(result *Result) {
 	}
 	if err := json.Unmarshal(data, &creds); err != nil {
 		log.Printf("[detect] gcloud ADC parse error: %v", err)
-		return
+		return false
 	}
 	if creds.Type != "authorized_user" || creds.RefreshToken == "" {
 		// Service-account JSON or partial file; skip.
-		return
+		return false
 	}
 
 	hint := "file:" + path
@@ -155,6 +157,7 @@ func probeGcloudADCFile(result *Result) {
 	} else {
 		log.Printf("[detect] gcloud ADC present at %s (no gemini account to annotate)", path)
 	}
+	return true
 }
 
 // ghHostsPath returns the platform-specific path to gh CLI's hosts.yml.
@@ -205,3 +208,16 @@ func annotateOrCreateAccount(result *Result, accountID, provider, auth, source,
 	}
 	addAccount(result, acct)
 }
+
+// readCredentialFile reads a file holding credentials and records the read
+// in the access audit log under the account it is for. Files shared by
+// several providers are recorded with empty IDs.
+func readCredentialFile(provider, accountID, path string) ([]byte, error) {
+	return audit.ReadFile(audit.WithAccount(context.Background(), provider, accountID), path)
+}
+
+// recordCredentialRead records a credential read that does not go through
+// readCredentialFile: a streamed file, a SQLite value, a keychain probe.
+func recordCredentialRead(provider, accountID, source string) {
+	audit.Default().Record(audit.KindCredential, provider, accountID, source, 1, 0)
+}
@@ -1,14 +1,12 @@
 package detect
 
 import (
-	"database/sql"
-	"fmt"
+	"context"
 	"log"
 	"path/filepath"
 
-	_ "github.com/mattn/go-sqlite3"
-
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
 
 func detectCursor(result *Result) {
@@ -83,7 +81,7 @@ func detectCursor(result *Result) {
 }
 
 func extractCursorAuth(stateDBPath string) (token, email, membership string) {
-	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=ro", stateDBPath))
+	db, err := shared.OpenSQLiteReadOnly(context.Background(), stateDBPath)
 	if err != nil {
 		log.Printf("[detect] Cannot open state.vscdb: %v", err)
 		return "", "", ""
@@ -95,6 +93,8 @@ func extractCursorAuth(stateDBPath string) (token, email, membership string) {
 	if err != nil {
 		log.Printf("[detect] No Cursor access token found: %v", err)
 		token = ""
+	} else {
+		recordCredentialRead("cursor", "cursor-ide", "sqlite:"+stateDBPath+"#cursorAuth/accessToken")
 	}
 
 	err = db.QueryRow(
@@ -34,7 +34,14 @@ type Result struct {
 	accountIDs map[string]struct{} `json:"-"`
 }
 
+// AutoDetect runs every detector with the default settings.
 func AutoDetect() Result {
+	return AutoDetectWith(config.DetectConfig{})
+}
+
+// AutoDetectWith runs every detector, skipping the cloud credential sources
+// cfg turns off.
+func AutoDetectWith(cfg config.DetectConfig) Result {
 	var result Result
 
 	// Phase 1: tool-binding detectors. These may populate Token directly
@@ -82,6 +89,7 @@ func AutoDetect() Result {
 	// the secret value at fetch time.
 	detectMacOSKeychainCredentials(&result)
 	detectCredentialFiles(&result)
+	detectCloudCredentials(&result, cfg)
 
 	return result
 }
@@ -94,6 +102,25 @@ func homeDir() string {
 	return h
 }
 
+// envDataRoot returns the data root a tool's own environment variable
+// points at (CLAUDE_CONFIG_DIR, OLLAMA_HOME), or "" when it is unset or
+// can't be expanded.
+func envDataRoot(layout core.LocalDataLayout) string {
+	if layout.EnvVar == "" {
+		return ""
+	}
+	value := strings.TrimSpace(os.Getenv(layout.EnvVar))
+	if value == "" {
+		return ""
+	}
+	dir, err := core.ExpandDataPath(value)
+	if err != nil {
+		log.Printf("[detect] ignoring %s: %v", layout.EnvVar, err)
+		return ""
+	}
+	return dir
+}
+
 func cursorAppSupportDir() string {
 	home := homeDir()
 	if home == "" {
@@ -0,0 +1,274 @@
+package detect
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// ImportSources lists the tools `openusage import-config` can read keys from.
+var ImportSources = []string{"opencode", "llm", "aider"}
+
+// ImportCandidate is an API key found in another tool's config that can be
+// adopted as an openusage account. Account.Token holds the key.
+type ImportCandidate struct {
+	Account core.AccountConfig
+	// ToolKey is the tool's own name for the provider ("moonshotai",
+	// "gemini", "OPENAI_API_KEY").
+	ToolKey string
+	// Path is the file the key was read from.
+	Path string
+}
+
+// ImportCandidates reads every API key the given tool has configured and maps
+// it to an openusage provider account. Unlike auto-detection it runs whether
+// or not the tool's binary is installed and ignores the process env: the
+// user asked for this tool's keys explicitly. Keys for providers openusage
+// does not support are dropped; when several files hold a key for the same
+// account, the one the tool itself would use wins.
+func ImportCandidates(tool string) ([]ImportCandidate, error) {
+	var found []ImportCandidate
+	switch strings.ToLower(strings.TrimSpace(tool)) {
+	case "opencode":
+		found = opencodeImportCandidates()
+	case "llm":
+		found = llmImportCandidates()
+	case "aider":
+		found = aiderImportCandidates()
+	default:
+		return nil, fmt.Errorf("unsupported tool %q (use %s)", tool, strings.Join(ImportSources, ", "))
+	}
+
+	seen := make(map[string]bool, len(found))
+	out := found[:0]
+	for _, c := range found {
+		if seen[c.Account.ID] {
+			continue
+		}
+		seen[c.Account.ID] = true
+		out = append(out, c)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Account.ID < out[j].Account.ID })
+	return out, nil
+}
+
+func importCandidate(mapping envKeyMappingEntry, toolKey, value, path, source string) ImportCandidate {
+	acct := core.AccountConfig{
+		ID:       mapping.AccountID,
+		Provider: mapping.Provider,
+		Auth:     "api_key",
+		Token:    strings.TrimSpace(value),
+	}
+	acct.SetHint("credential_source", source)
+	return ImportCandidate{Account: acct, ToolKey: toolKey, Path: path}
+}
+
+// toolProviderMapping resolves a tool's provider name. OpenCode's own ids
+// take precedence, then the short names Aider and llm share with most tools.
+func toolProviderMapping(name string) (envKeyMappingEntry, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if target, ok := opencodeAuthMapping[name]; ok {
+		for _, m := range envKeyMapping {
+			if m.Provider == target.Provider && m.AccountID == target.AccountID {
+				return m, true
+			}
+		}
+		return envKeyMappingEntry{Provider: target.Provider, AccountID: target.AccountID}, true
+	}
+	if alias, ok := llmKeyAliases[name]; ok {
+		name = alias
+	}
+	m, ok := envKeyByAiderShortName[name]
+	return m, ok
+}
+
+// opencodeImportCandidates reads API-key entries from OpenCode's auth.json
+// (written by `opencode auth login`) and literal provider.<id>.options.apiKey
+// values from its opencode.json(c). OAuth entries and {env:…}/{file:…}
+// references are skipped: the former only work against chat endpoints, the
+// latter are picked up by env-var detection already.
+func opencodeImportCandidates() []ImportCandidate {
+	var out []ImportCandidate
+	if path := opencodeAuthPath(); path != "" && fileExists(path) {
+		var raw map[string]opencodeAuthEntry
+		if data, err := readCredentialFile("", "", path); err == nil && json.Unmarshal(data, &raw) == nil {
+			for _, key := range sortedKeys(raw) {
+				entry := raw[key]
+				mapping, ok := toolProviderMapping(key)
+				if !ok || entry.Type != "api" || strings.TrimSpace(entry.Key) == "" {
+					continue
+				}
+				out = append(out, importCandidate(mapping, key, entry.Key, path, "opencode_auth_json"))
+			}
+		}
+	}
+
+	for _, path := range opencodeConfigPaths() {
+		data, err := readCredentialFile("", "", path)
+		if err != nil {
+			continue
+		}
+		var cfg struct {
+			Provider map[string]struct {
+				Options struct {
+					APIKey string `json:"apiKey"`
+				} `json:"options"`
+			} `json:"provider"`
+		}
+		if err := json.Unmarshal(stripJSONC(data), &cfg); err != nil {
+			continue
+		}
+		for _, key := range sortedKeys(cfg.Provider) {
+			value := strings.TrimSpace(cfg.Provider[key].Options.APIKey)
+			mapping, ok := toolProviderMapping(key)
+			if !ok || value == "" || strings.HasPrefix(value, "{env:") || strings.HasPrefix(value, "{file:") {
+				continue
+			}
+			out = append(out, importCandidate(mapping, key, value, path, "opencode_config"))
+		}
+	}
+	return out
+}
+
+// opencodeConfigPaths returns OpenCode's global config files. Like auth.json
+// they live under the xdg-basedir config dir on every platform.
+func opencodeConfigPaths() []string {
+	home := homeDir()
+	if home == "" {
+		return nil
+	}
+	dir := filepath.Join(home, ".config", "opencode")
+	if xdg := strings.TrimSpace(os.Getenv("XDG_CONFIG_HOME")); xdg != "" {
+		dir = filepath.Join(xdg, "opencode")
+	}
+	return uniqueExisting([]string{
+		filepath.Join(dir, "opencode.json"),
+		filepath.Join(dir, "opencode.jsonc"),
+		filepath.Join(dir, "config.json"),
+	})
+}
+
+// llmKeyAliases maps `llm` plugin key names that differ from the common
+// short names.
+var llmKeyAliases = map[string]string{
+	"claude": "anthropic",
+}
+
+// llmImportCandidates reads keys.json, written by `llm keys set <name>`.
+// LLM_USER_PATH overrides the directory, as it does for llm itself.
+func llmImportCandidates() []ImportCandidate {
+	dir := strings.TrimSpace(os.Getenv("LLM_USER_PATH"))
+	if dir == "" {
+		home := homeDir()
+		if home == "" {
+			return nil
+		}
+		dir = llmUserDirPlatform(home)
+	}
+	path := filepath.Join(dir, "keys.json")
+	data, err := readCredentialFile("", "", path)
+	if err != nil {
+		return nil
+	}
+	// Values are strings, except the "// Note" banner llm writes first.
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+	var out []ImportCandidate
+	for _, key := range sortedKeys(raw) {
+		value, _ := raw[key].(string)
+		mapping, ok := toolProviderMapping(key)
+		if !ok || strings.TrimSpace(value) == "" {
+			continue
+		}
+		out = append(out, importCandidate(mapping, key, value, path, "llm_keys_json"))
+	}
+	return out
+}
+
+// aiderImportCandidates reads .aider.conf.yml and .env in Aider's search
+// scopes, cwd first, matching Aider's own precedence.
+func aiderImportCandidates() []ImportCandidate {
+	var out []ImportCandidate
+	for _, path := range aiderConfigPaths() {
+		var keys []shellRCDiscovery
+		source := "aider_dotenv"
+		if filepath.Base(path) == ".aider.conf.yml" {
+			keys, source = aiderYAMLKeys(path), "aider_yaml"
+		} else {
+			keys = aiderDotenvKeys(path)
+		}
+		for _, d := range keys {
+			out = append(out, importCandidate(d.envKeyMappingEntry, d.EnvVar, d.Value, path, source))
+		}
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// stripJSONC removes // and /* */ comments and trailing commas so OpenCode's
+// JSONC config parses with encoding/json. String contents are left intact.
+func stripJSONC(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString, escaped := false, false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++
+		case c == '}' || c == ']':
+			// Drop a trailing comma before the closing bracket.
+			j := len(out) - 1
+			for j >= 0 && (out[j] == ' ' || out[j] == '\t' || out[j] == '\n' || out[j] == '\r') {
+				j--
+			}
+			if j >= 0 && out[j] == ',' {
+				out = append(out[:j], out[j+1:]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
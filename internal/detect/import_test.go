@@ -0,0 +1,123 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeImportFile(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+// importedKeys flattens candidates into account ID → key for assertions.
+func importedKeys(t *testing.T, tool string) map[string]string {
+	t.Helper()
+	candidates, err := ImportCandidates(tool)
+	if err != nil {
+		t.Fatalf("ImportCandidates(%s): %v", tool, err)
+	}
+	out := make(map[string]string, len(candidates))
+	for _, c := range candidates {
+		if c.Account.Auth != "api_key" || c.Path == "" {
+			t.Errorf("candidate %+v missing auth or path", c)
+		}
+		out[c.Account.ID] = c.Account.Token
+	}
+	return out
+}
+
+func TestImportCandidates_OpenCode(t *testing.T) {
+	home := withFakeOpenCodeAuth(t, `{
+		"moonshotai": {"type": "api", "key": "sk-moonshot-1234567890abcdef"},
+		"anthropic":  {"type": "api", "key": "sk-ant-auth-json-0000"},
+		"openai":     {"type": "oauth", "refresh": "r", "access": "a", "expires": 1},
+		"someday":    {"type": "api", "key": "unsupported-provider"}
+	}`)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	writeImportFile(t, filepath.Join(home, ".config", "opencode", "opencode.jsonc"), `{
+		// keys configured by hand
+		"$schema": "https://opencode.ai/config.json",
+		"provider": {
+			"groq": {"options": {"apiKey": "gsk_from_config_1234", /* inline */ }},
+			"openrouter": {"options": {"apiKey": "{env:OPENROUTER_API_KEY}"}},
+			"anthropic": {"options": {"apiKey": "sk-ant-loses-to-auth-json"}},
+			"url": {"options": {"baseURL": "http://x//y"}},
+		},
+	}`)
+
+	got := importedKeys(t, "opencode")
+	want := map[string]string{
+		"moonshot-ai": "sk-moonshot-1234567890abcdef",
+		"anthropic":   "sk-ant-auth-json-0000",
+		"groq":        "gsk_from_config_1234",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("imported = %v, want %v", got, want)
+	}
+	for id, key := range want {
+		if got[id] != key {
+			t.Errorf("%s = %q, want %q", id, got[id], key)
+		}
+	}
+}
+
+func TestImportCandidates_LLM(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("LLM_USER_PATH", dir)
+	writeImportFile(t, filepath.Join(dir, "keys.json"), `{
+  "// Note": "This file stores secret API credentials. Do not share!",
+  "openai": "sk-llm-openai-1234",
+  "claude": "sk-ant-llm-5678",
+  "gemini": "AIza-llm-gemini",
+  "my-private-model": "whatever"
+}`)
+
+	got := importedKeys(t, "llm")
+	want := map[string]string{
+		"openai":     "sk-llm-openai-1234",
+		"anthropic":  "sk-ant-llm-5678",
+		"gemini-api": "AIza-llm-gemini",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("imported = %v, want %v", got, want)
+	}
+	for id, key := range want {
+		if got[id] != key {
+			t.Errorf("%s = %q, want %q", id, got[id], key)
+		}
+	}
+}
+
+func TestImportCandidates_AiderIgnoresBinaryAndEnv(t *testing.T) {
+	home := withAiderHome(t) // no aider binary on PATH
+	chdirTo(t, home)
+	t.Setenv("OPENAI_API_KEY", "sk-from-env")
+	writeImportFile(t, filepath.Join(home, ".aider.conf.yml"), "openai-api-key: sk-aider-yaml-12345\napi-key:\n  - deepseek=sk-ds-777\n")
+	writeImportFile(t, filepath.Join(home, ".env"), "OPENAI_API_KEY=sk-aider-dotenv-loses\n")
+
+	got := importedKeys(t, "aider")
+	if got["openai"] != "sk-aider-yaml-12345" || got["deepseek"] != "sk-ds-777" || len(got) != 2 {
+		t.Errorf("imported = %v", got)
+	}
+}
+
+func TestImportCandidates_UnknownTool(t *testing.T) {
+	if _, err := ImportCandidates("cursor"); err == nil {
+		t.Fatal("expected an error for an unsupported tool")
+	}
+}
+
+func TestStripJSONC(t *testing.T) {
+	in := "{\"a\": \"http://x\", // trailing\n/* block */ \"b\": [1, 2,], \"c\": \"/* not a comment */\",}"
+	want := "{\"a\": \"http://x\", \n \"b\": [1, 2], \"c\": \"/* not a comment */\"}"
+	if got := string(stripJSONC([]byte(in))); got != want {
+		t.Errorf("stripJSONC = %q, want %q", got, want)
+	}
+}
@@ -71,6 +71,7 @@ func detectMacOSKeychainCredentials(result *Result) {
 			continue
 		}
 		log.Printf("[detect] macOS keychain entry present: %s", p.Service)
+		recordCredentialRead(p.Provider, p.AccountID, p.ProvenanceSource)
 
 		// Annotate the existing account if file-based detection already
 		// registered it.
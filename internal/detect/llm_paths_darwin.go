@@ -0,0 +1,11 @@
+//go:build darwin
+
+package detect
+
+import "path/filepath"
+
+// llmUserDirPlatform returns the default data directory of Simon Willison's
+// `llm` CLI on macOS (click's get_app_dir).
+func llmUserDirPlatform(home string) string {
+	return filepath.Join(home, "Library", "Application Support", "io.datasette.llm")
+}
@@ -0,0 +1,19 @@
+//go:build !windows && !darwin
+
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// llmUserDirPlatform returns the default data directory of Simon Willison's
+// `llm` CLI on Linux and other Unix. llm resolves it through click's
+// get_app_dir, which honours XDG_CONFIG_HOME.
+func llmUserDirPlatform(home string) string {
+	if xdg := strings.TrimSpace(os.Getenv("XDG_CONFIG_HOME")); xdg != "" {
+		return filepath.Join(xdg, "io.datasette.llm")
+	}
+	return filepath.Join(home, ".config", "io.datasette.llm")
+}
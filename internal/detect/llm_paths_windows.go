@@ -0,0 +1,18 @@
+//go:build windows
+
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// llmUserDirPlatform returns the default data directory of Simon Willison's
+// `llm` CLI on Windows: click's get_app_dir uses the roaming %APPDATA%.
+func llmUserDirPlatform(home string) string {
+	if appData := strings.TrimSpace(os.Getenv("APPDATA")); appData != "" {
+		return filepath.Join(appData, "io.datasette.llm")
+	}
+	return filepath.Join(home, "AppData", "Roaming", "io.datasette.llm")
+}
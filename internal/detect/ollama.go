@@ -7,6 +7,7 @@ import (
 	"runtime"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/ollama"
 )
 
 func detectOllama(result *Result) {
@@ -17,6 +18,9 @@ func detectOllama(result *Result) {
 
 	home := homeDir()
 	configDir := filepath.Join(home, ".ollama")
+	if dir := envDataRoot(ollama.LocalData); dir != "" {
+		configDir = dir
+	}
 	logsDir := filepath.Join(configDir, "logs")
 	serverConfig := filepath.Join(configDir, "server.json")
 	dbPath := defaultOllamaDBPath(home)
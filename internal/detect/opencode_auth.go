@@ -109,7 +109,7 @@ func detectOpenCodeAuth(result *Result) {
 	if path == "" {
 		return
 	}
-	data, err := os.ReadFile(path)
+	data, err := readCredentialFile("", "", path)
 	if err != nil {
 		if !os.IsNotExist(err) {
 			log.Printf("[detect] OpenCode auth.json read error: %v", err)
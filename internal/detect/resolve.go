@@ -0,0 +1,138 @@
+package detect
+
+import (
+	"log"
+	"strings"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// ResolveAccounts returns the accounts OpenUsage polls: the configured ones
+// plus, when auto-detection is on, detected tools and keys, with stored
+// credentials, read-only mode and network settings applied. A changed
+// detected set is saved to settings.json.
+func ResolveAccounts(cfg *config.Config) []core.AccountConfig {
+	return resolveAccounts(cfg, true)
+}
+
+// ResolveAccountsWithoutSaving resolves accounts like ResolveAccounts but
+// never writes newly detected accounts back to settings.json, for callers
+// that only read the user's setup.
+func ResolveAccountsWithoutSaving(cfg *config.Config) []core.AccountConfig {
+	return resolveAccounts(cfg, false)
+}
+
+func resolveAccounts(cfg *config.Config, persist bool) []core.AccountConfig {
+	allAccounts := core.MergeAccounts(cfg.Accounts, dropRelocatedProviders(cfg.Accounts, cfg.AutoDetectedAccounts))
+
+	if cfg.AutoDetect {
+		result := AutoDetectWith(cfg.Detect)
+
+		manualIDs := make(map[string]bool, len(cfg.Accounts))
+		for _, acct := range cfg.Accounts {
+			manualIDs[acct.ID] = true
+		}
+		var autoDetected []core.AccountConfig
+		for _, acct := range dropRelocatedProviders(cfg.Accounts, result.Accounts) {
+			if !manualIDs[acct.ID] {
+				autoDetected = append(autoDetected, acct)
+			}
+		}
+		autoDetected = cfg.AccountDedupe.Apply(cfg.Accounts, autoDetected)
+
+		// Only persist when the auto-detected set actually changed. Without
+		// this guard we'd take saveMu and rewrite settings.json on every
+		// poll cycle (~30s), even when nothing about the workstation has
+		// moved. In pure-config mode the set is only kept in memory.
+		if persist && !config.NoWriteFromEnv() && !sameAutoDetectedAccounts(cfg.AutoDetectedAccounts, autoDetected) {
+			if err := config.SaveAutoDetected(autoDetected); err != nil {
+				log.Printf("Warning: could not persist auto-detected accounts: %v", err)
+			}
+		}
+		cfg.AutoDetectedAccounts = autoDetected
+
+		allAccounts = core.MergeAccounts(cfg.Accounts, cfg.AutoDetectedAccounts)
+
+		if core.DebugEnabled() {
+			if len(result.Tools) > 0 || len(result.Accounts) > 0 {
+				log.Print(result.Summary())
+			}
+		}
+	}
+
+	accounts := core.ApplyReadOnly(ApplyAccountCredentials(allAccounts), cfg.ReadOnlyEnabled())
+	return core.ApplyNetwork(accounts, cfg.Network)
+}
+
+// ApplyAccountCredentials fills in stored credentials for accounts, as
+// ApplyCredentials does for a detection Result.
+func ApplyAccountCredentials(accounts []core.AccountConfig) []core.AccountConfig {
+	credResult := Result{Accounts: accounts}
+	ApplyCredentials(&credResult)
+	return credResult.Accounts
+}
+
+// dropRelocatedProviders removes detected accounts for providers whose data
+// a configured account has moved with data_path. Detection looks in the
+// tool's standard location, so it would otherwise add a second account
+// reading the install the user pointed away from.
+func dropRelocatedProviders(manual, detected []core.AccountConfig) []core.AccountConfig {
+	relocated := make(map[string]bool)
+	for _, acct := range manual {
+		if strings.TrimSpace(acct.DataPath) != "" {
+			relocated[acct.Provider] = true
+		}
+	}
+	if len(relocated) == 0 {
+		return detected
+	}
+	out := make([]core.AccountConfig, 0, len(detected))
+	for _, acct := range detected {
+		if !relocated[acct.Provider] {
+			out = append(out, acct)
+		}
+	}
+	return out
+}
+
+// sameAutoDetectedAccounts compares two slices of auto-detected accounts by
+// the persisted-fields subset (ID, Provider, Auth, APIKeyEnv, BaseURL, Binary,
+// ProviderPaths, Paths). Runtime-only fields (Token, RuntimeHints) are
+// ignored — they change every run for sources like Cursor's vscdb token.
+func sameAutoDetectedAccounts(a, b []core.AccountConfig) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	keyOf := func(acc core.AccountConfig) string {
+		return acc.ID + "|" + acc.Provider + "|" + acc.Auth + "|" + acc.APIKeyEnv +
+			"|" + acc.BaseURL + "|" + acc.Binary
+	}
+	indexA := make(map[string]core.AccountConfig, len(a))
+	for _, acc := range a {
+		indexA[keyOf(acc)] = acc
+	}
+	for _, acc := range b {
+		other, ok := indexA[keyOf(acc)]
+		if !ok {
+			return false
+		}
+		if !samePathMap(other.PathMap(), acc.PathMap()) {
+			return false
+		}
+	}
+	return true
+}
+
+// samePathMap reports map-equality, treating nil and empty as equal.
+func samePathMap(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,26 @@
+package detect
+
+import (
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestDropRelocatedProviders(t *testing.T) {
+	manual := []core.AccountConfig{
+		{ID: "cursor-portable", Provider: "cursor", DataPath: "~/Apps/Cursor*/data"},
+		{ID: "openai", Provider: "openai"},
+	}
+	detected := []core.AccountConfig{
+		{ID: "cursor-ide", Provider: "cursor"},
+		{ID: "claude-code", Provider: "claude_code"},
+	}
+
+	got := dropRelocatedProviders(manual, detected)
+	if len(got) != 1 || got[0].ID != "claude-code" {
+		t.Fatalf("dropRelocatedProviders = %+v, want only claude-code", got)
+	}
+	if got := dropRelocatedProviders(manual[1:], detected); len(got) != 2 {
+		t.Fatalf("without data_path nothing should be dropped, got %+v", got)
+	}
+}
@@ -45,7 +45,7 @@ func detectShellRC(result *Result) {
 }
 
 // shellRCDiscovery is a parsed (var, value, source-file) triple from a single
-// shell rc line.
+// shell rc line, dotenv line or Aider YAML entry.
 type shellRCDiscovery struct {
 	envKeyMappingEntry
 	Value string
@@ -103,6 +103,7 @@ func parseShellRCFile(path string, knownVars map[string]envKeyMappingEntry) ([]s
 		return nil, err
 	}
 	defer f.Close()
+	recordCredentialRead("", "", "file:"+path)
 
 	var out []shellRCDiscovery
 	scanner := bufio.NewScanner(f)
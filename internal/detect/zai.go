@@ -2,7 +2,6 @@ package detect
 
 import (
 	"log"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -21,7 +20,7 @@ func detectZAICodingHelper(result *Result) {
 		return
 	}
 
-	content, err := os.ReadFile(configFile)
+	content, err := readCredentialFile("zai", "zai-coding-plan-auto", configFile)
 	if err != nil {
 		log.Printf("[detect] Failed reading Z.AI coding-helper config: %v", err)
 		return
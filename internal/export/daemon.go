@@ -32,6 +32,8 @@ type daemonCollector struct {
 	socketPath  string
 	newClient   func(socketPath string) daemonClient
 	buildReqCfg func() (daemon.ReadModelRequest, error)
+	// window overrides the configured time window when set.
+	window core.TimeWindow
 }
 
 func newDaemonCollector(socketPath string) *daemonCollector {
@@ -82,6 +84,9 @@ func (c *daemonCollector) collect(ctx context.Context) ([]core.UsageSnapshot, er
 	if reqErr != nil {
 		return nil, fmt.Errorf("export: building read-model request: %w", reqErr)
 	}
+	if c.window != "" {
+		req.TimeWindow = c.window
+	}
 
 	readCtx, readCancel := context.WithTimeout(ctx, daemonReadModelTimeout)
 	defer readCancel()
@@ -11,6 +11,8 @@ import (
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/daemon"
 	"github.com/janekbaraniewski/openusage/internal/providers"
+	"github.com/janekbaraniewski/openusage/internal/providers/fixtures"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
 
 // fetchTimeout caps a single provider Fetch() call. Matches the daemon's
@@ -53,7 +55,14 @@ func (c *directCollector) collect(ctx context.Context) ([]core.UsageSnapshot, er
 		providerByID[p.ID()] = p
 	}
 
-	return collectSnapshots(ctx, accounts, providerByID, cfg.ModelNormalization, time.Now), nil
+	snaps := collectSnapshots(ctx, accounts, providerByID, cfg.ModelNormalization, core.CompileDerivedMetrics(cfg.DerivedMetrics), time.Now)
+	// Derive statuses with the dashboard's thresholds, as the daemon's read
+	// model does, so exports flag the same near-limit quotas.
+	thresholds := core.StatusThresholds{Warn: cfg.UI.WarnThreshold, Crit: cfg.UI.CritThreshold}
+	for i := range snaps {
+		snaps[i] = core.DeriveStatus(snaps[i], thresholds)
+	}
+	return snaps, nil
 }
 
 // collectSnapshots is the pure fan-out helper. Exposed so tests can drive it
@@ -63,6 +72,7 @@ func collectSnapshots(
 	accounts []core.AccountConfig,
 	providerByID map[string]core.UsageProvider,
 	modelNorm core.ModelNormalizationConfig,
+	derived []core.DerivedMetric,
 	now func() time.Time,
 ) []core.UsageSnapshot {
 	if now == nil {
@@ -92,20 +102,28 @@ func collectSnapshots(
 				return
 			}
 
+			if err := core.ValidateProviderOptions(account.Provider, provider.Spec().Options, account.ProviderOptions); err != nil {
+				results <- fetchResult{snap: core.FetchErrorSnapshot(account.Provider, account.ID, now().UTC(), err)}
+				return
+			}
+			account, err := core.ResolveLocalData(account, provider.Spec().LocalData)
+			if err != nil {
+				results <- fetchResult{snap: core.FetchErrorSnapshot(account.Provider, account.ID, now().UTC(), err)}
+				return
+			}
+
 			fetchCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
 			defer cancel()
+			fetchCtx = fixtures.WithAccount(fetchCtx, account.Provider, account.ID)
+			fetchCtx = shared.WithNetwork(fetchCtx, account.Network)
 
 			snap, fetchErr := provider.Fetch(fetchCtx, account)
 			if fetchErr != nil {
-				snap = core.UsageSnapshot{
-					ProviderID: account.Provider,
-					AccountID:  account.ID,
-					Timestamp:  now().UTC(),
-					Status:     core.StatusError,
-					Message:    fetchErr.Error(),
-				}
+				snap = core.FetchErrorSnapshot(account.Provider, account.ID, now().UTC(), fetchErr)
 			}
 			snap = core.NormalizeUsageSnapshotWithConfig(snap, modelNorm)
+			snap = core.ApplyTodayPace(snap, now())
+			snap = core.ApplyDerivedMetrics(snap, derived)
 
 			results <- fetchResult{snap: snap}
 		}(acct)
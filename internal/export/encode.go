@@ -52,6 +52,9 @@ func encodeJSON(w io.Writer, env ExportEnvelope) error {
 //	schema_version, generated_at, openusage_version, source,
 //	provider_id, account_id, snapshot_timestamp, status, message,
 //	metric, used, limit, remaining, unit, window
+//
+// Values are raw numbers, never locale-formatted. Token-efficiency KPIs
+// follow each snapshot's metrics as efficiency_* rows.
 func encodeCSV(w io.Writer, env ExportEnvelope) error {
 	buf := bytes.NewBuffer(nil)
 	cw := csv.NewWriter(buf)
@@ -97,8 +100,13 @@ func encodeCSV(w io.Writer, env ExportEnvelope) error {
 			continue
 		}
 
+		rows := make([]metricRow, 0, len(keys)+3)
 		for _, key := range keys {
-			m := snap.Metrics[key]
+			rows = append(rows, metricRow{key, snap.Metrics[key]})
+		}
+		rows = append(rows, efficiencyRows(env.Efficiency[snap.AccountID])...)
+		for _, r := range rows {
+			key, m := r.key, r.metric
 			row := append(append([]string{}, envFields...), baseSnap...)
 			row = append(row,
 				key,
@@ -123,6 +131,43 @@ func encodeCSV(w io.Writer, env ExportEnvelope) error {
 	return nil
 }
 
+type metricRow struct {
+	key    string
+	metric core.Metric
+}
+
+// efficiencyRows flattens e into pseudo-metric rows for the CSV export.
+func efficiencyRows(e core.TokenEfficiency) []metricRow {
+	var rows []metricRow
+	if e.CostPer1kOutput != nil {
+		rows = append(rows, metricRow{"efficiency_cost_per_1k_output", core.Metric{Used: core.Float64Ptr(*e.CostPer1kOutput), Unit: "USD"}})
+	}
+	if e.CacheReadPercent != nil {
+		rows = append(rows, metricRow{"efficiency_cache_read_percent", core.Metric{Used: core.Float64Ptr(*e.CacheReadPercent), Unit: "%"}})
+	}
+	if e.ReasoningPercent != nil {
+		rows = append(rows, metricRow{"efficiency_reasoning_percent", core.Metric{Used: core.Float64Ptr(*e.ReasoningPercent), Unit: "%"}})
+	}
+	return rows
+}
+
+// efficiencyByAccount computes token-efficiency KPIs per account, or nil when
+// no snapshot has the per-model data for them.
+func efficiencyByAccount(snaps []core.UsageSnapshot) map[string]core.TokenEfficiency {
+	var out map[string]core.TokenEfficiency
+	for _, snap := range snaps {
+		e, ok := core.ComputeTokenEfficiency(snap)
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]core.TokenEfficiency)
+		}
+		out[snap.AccountID] = e
+	}
+	return out
+}
+
 func floatPtrString(p *float64) string {
 	if p == nil {
 		return ""
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/daemon"
+	"github.com/janekbaraniewski/openusage/internal/providers/fixtures"
 	"github.com/janekbaraniewski/openusage/internal/version"
 )
 
@@ -32,7 +34,22 @@ func Run(opts Options) error {
 		return err
 	}
 	r := newRunner(opts)
-	return r.run(context.Background(), opts)
+	if opts.RecordFixtures == "" {
+		return r.run(context.Background(), opts)
+	}
+
+	rec := fixtures.NewRecorder(http.DefaultTransport)
+	prev := http.DefaultTransport
+	http.DefaultTransport = rec
+	defer func() { http.DefaultTransport = prev }()
+	if err := r.run(context.Background(), opts); err != nil {
+		return err
+	}
+	paths, err := rec.WriteDir(opts.RecordFixtures)
+	for _, p := range paths {
+		fmt.Fprintf(r.stderr, "export: recorded %s\n", p)
+	}
+	return err
 }
 
 // Collect resolves the requested source and returns the current usage
@@ -63,9 +80,11 @@ func newRunner(opts Options) *runner {
 		}
 		return time.Now().UTC()
 	}
+	dmn := newDaemonCollector(daemon.ResolveSocketPath())
+	dmn.window = opts.TimeWindow
 	return &runner{
 		direct:     newDirectCollector(),
-		dmn:        newDaemonCollector(daemon.ResolveSocketPath()),
+		dmn:        dmn,
 		stderr:     stderr,
 		now:        now,
 		openOutput: defaultOpenOutput,
@@ -92,9 +111,35 @@ func validateOptions(opts *Options) error {
 	default:
 		return fmt.Errorf("export: unsupported --source %q (use auto, direct, or daemon)", opts.Source)
 	}
+	if opts.RecordFixtures != "" {
+		// Recording needs the provider traffic in this process.
+		if opts.Source == SourceDaemon {
+			return errors.New("export: --record-fixtures needs a direct poll; drop --source daemon")
+		}
+		opts.Source = SourceDirect
+	}
+	if opts.TimeWindow != "" {
+		if _, ok := core.LookupTimeWindow(string(opts.TimeWindow)); !ok {
+			return fmt.Errorf("export: unknown --window %q (use %s)", opts.TimeWindow, windowNames())
+		}
+		if opts.Source == SourceDirect {
+			return errors.New("export: --window aggregates the daemon's history; it can't be used with a direct poll")
+		}
+		// Auto must not silently fall back to a poll that ignores the window.
+		opts.Source = SourceDaemon
+	}
 	return nil
 }
 
+func windowNames() string {
+	windows := core.TimeWindows()
+	names := make([]string, 0, len(windows))
+	for _, tw := range windows {
+		names = append(names, string(tw))
+	}
+	return strings.Join(names, ", ")
+}
+
 func (r *runner) run(ctx context.Context, opts Options) error {
 	snaps, resolvedSource, err := r.collect(ctx, opts.Source)
 	if err != nil {
@@ -108,9 +153,13 @@ func (r *runner) run(ctx context.Context, opts Options) error {
 		Source:           resolvedSource,
 		Snapshots:        snaps,
 	}
+	if resolvedSource == SourceDaemon {
+		env.TimeWindow = opts.TimeWindow
+	}
 	if env.Snapshots == nil {
 		env.Snapshots = []core.UsageSnapshot{}
 	}
+	env.Efficiency = efficiencyByAccount(env.Snapshots)
 
 	writer, err := r.openOutput(opts.Output)
 	if err != nil {
@@ -54,11 +54,18 @@ const (
 // encoder also strips snapshot Raw maps defensively since they sometimes carry
 // credential hints from provider probes.
 type ExportEnvelope struct {
-	SchemaVersion    string               `json:"schema_version"`
-	GeneratedAt      time.Time            `json:"generated_at"`
-	OpenUsageVersion string               `json:"openusage_version"`
-	Source           Source               `json:"source"`
-	Snapshots        []core.UsageSnapshot `json:"snapshots"`
+	SchemaVersion    string    `json:"schema_version"`
+	GeneratedAt      time.Time `json:"generated_at"`
+	OpenUsageVersion string    `json:"openusage_version"`
+	Source           Source    `json:"source"`
+	// TimeWindow is the aggregation window requested with --window. Empty
+	// when the configured time_window applied.
+	TimeWindow core.TimeWindow      `json:"time_window,omitempty"`
+	Snapshots  []core.UsageSnapshot `json:"snapshots"`
+	// Efficiency maps account IDs to token-efficiency KPIs derived from
+	// each snapshot's per-model metrics. Accounts without per-model token
+	// data are absent.
+	Efficiency map[string]core.TokenEfficiency `json:"efficiency,omitempty"`
 }
 
 // Options captures the parameters parsed from CLI flags. The orchestrator
@@ -71,6 +78,16 @@ type Options struct {
 	// Source selects the collection path. Defaults to auto.
 	Source Source
 
+	// RecordFixtures, when set, forces a direct poll and writes each
+	// account's sanitized HTTP traffic as a test cassette into this
+	// directory. Developer mode for provider contributors.
+	RecordFixtures string
+
+	// TimeWindow, when set, asks the daemon to aggregate history over this
+	// built-in or custom window instead of the configured time_window.
+	// Direct polls have no history and reject it.
+	TimeWindow core.TimeWindow
+
 	// Now overrides the GeneratedAt timestamp. Tests use it to assert
 	// deterministic envelopes. Zero value means time.Now().UTC().
 	Now time.Time
@@ -8,9 +8,11 @@ import (
 	"github.com/janekbaraniewski/openusage/internal/config"
 )
 
-// platformHooksDir places OpenUsage's hook scripts beside settings.json under
-// %APPDATA%\openusage\hooks on Windows. The configRoot argument (the XDG-style
-// base used for third-party tool dirs) is intentionally ignored here.
+// platformHooksDir places OpenUsage's hook scripts under
+// %APPDATA%\openusage\hooks on Windows, even when XDG_CONFIG_HOME moves
+// settings.json, since installed integrations point at these paths. The
+// configRoot argument (the XDG-style base used for third-party tool dirs) is
+// intentionally ignored here.
 func platformHooksDir(_ string) string {
-	return filepath.Join(config.ConfigDir(), "hooks")
+	return filepath.Join(config.PlatformConfigDir(), "hooks")
 }
@@ -0,0 +1,237 @@
+// Package locale formats numbers, USD amounts, clock times, and dates the way
+// the user's locale expects. The active locale is resolved once at startup
+// from the `locale` setting (or LC_ALL / LANG when the setting is "auto") and
+// installed with Set; rendering code reads it back with Current.
+//
+// All amounts openusage tracks are USD, so only the placement of the "$"
+// symbol follows the locale — values are never converted.
+package locale
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Auto is the setting value that resolves the locale from the environment.
+const Auto = "auto"
+
+// Locale describes how numbers, currency, times, and dates are rendered.
+type Locale struct {
+	// Tag is the normalized locale name (e.g. "de_DE"), or "" for the
+	// neutral default.
+	Tag string
+	// Decimal separates the integer and fractional parts.
+	Decimal string
+	// Group separates thousands; empty disables grouping.
+	Group string
+	// Currency is a fmt-style pattern with a single %s for the amount,
+	// e.g. "$%s" or "%s $".
+	Currency string
+	// Clock24 selects 15:04 over 3:04 PM.
+	Clock24 bool
+	// FirstWeekday is the first day of the week for weekly bucketing and
+	// the activity heatmap.
+	FirstWeekday time.Weekday
+	// DateLayout is the Go time layout for a numeric calendar date.
+	DateLayout string
+	// Precision is the number of fractional digits kept when a value is
+	// scaled to a unit suffix ("1.2M", "3.4 GB", "1.5s"). It is a user
+	// preference rather than a regional convention; see WithPrecision.
+	Precision int
+	// HideAmounts replaces every currency amount with HiddenAmount, for
+	// privacy mode while screen sharing; see WithHiddenAmounts.
+	HideAmounts bool
+}
+
+// HiddenAmount stands in for a currency amount when HideAmounts is set.
+const HiddenAmount = "•••"
+
+// Default is the neutral locale used when nothing is configured or the
+// environment is C/POSIX. It matches openusage's historical output: no digit
+// grouping, "$" prefix, 24-hour clock, ISO dates, Monday-first weeks.
+var Default = Locale{
+	Decimal:      ".",
+	Currency:     "$%s",
+	Clock24:      true,
+	FirstWeekday: time.Monday,
+	DateLayout:   "2006-01-02",
+	Precision:    DefaultPrecision,
+}
+
+var current atomic.Pointer[Locale]
+
+// Current returns the active locale.
+func Current() Locale {
+	if l := current.Load(); l != nil {
+		return *l
+	}
+	return Default
+}
+
+// Set installs l as the active locale.
+func Set(l Locale) {
+	current.Store(&l)
+}
+
+// WithPrecision returns l with Precision set to p, clamped to
+// [0, MaxPrecision].
+func (l Locale) WithPrecision(p int) Locale {
+	l.Precision = max(0, min(p, MaxPrecision))
+	return l
+}
+
+// WithHiddenAmounts returns l with HideAmounts set to hide.
+func (l Locale) WithHiddenAmounts(hide bool) Locale {
+	l.HideAmounts = hide
+	return l
+}
+
+// Resolve maps a `locale` setting to a Locale. An empty setting or "auto"
+// reads LC_ALL then LANG from the environment.
+func Resolve(setting string) Locale {
+	return resolve(setting, os.Getenv)
+}
+
+func resolve(setting string, getenv func(string) string) Locale {
+	name := strings.TrimSpace(setting)
+	if name == "" || strings.EqualFold(name, Auto) {
+		name = strings.TrimSpace(getenv("LC_ALL"))
+		if name == "" {
+			name = strings.TrimSpace(getenv("LANG"))
+		}
+	}
+	l, _ := Lookup(name)
+	return l
+}
+
+// Lookup returns the locale for a POSIX-style name such as "de_DE.UTF-8",
+// "en-GB", or "fr". Unknown regions fall back to the language; unknown
+// languages, "C", and "POSIX" return Default with ok=false.
+func Lookup(name string) (Locale, bool) {
+	lang, region := splitName(name)
+	if lang == "" {
+		return Default, false
+	}
+	if region != "" {
+		if l, ok := known[lang+"_"+region]; ok {
+			return l.WithPrecision(DefaultPrecision), true
+		}
+	}
+	if tag, ok := languageDefault[lang]; ok {
+		return known[tag].WithPrecision(DefaultPrecision), true
+	}
+	return Default, false
+}
+
+func splitName(name string) (lang, region string) {
+	name = strings.TrimSpace(name)
+	if i := strings.IndexAny(name, ".@"); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.ReplaceAll(name, "-", "_")
+	if name == "" || strings.EqualFold(name, "C") || strings.EqualFold(name, "POSIX") {
+		return "", ""
+	}
+	lang, region, _ = strings.Cut(name, "_")
+	return strings.ToLower(lang), strings.ToUpper(region)
+}
+
+// Int formats n with the locale's digit grouping.
+func (l Locale) Int(n int64) string {
+	if n < 0 {
+		return "-" + l.group(strconv.FormatUint(uint64(-n), 10))
+	}
+	return l.group(strconv.FormatInt(n, 10))
+}
+
+// Float formats v with prec fractional digits using the locale's grouping
+// and decimal separator.
+func (l Locale) Float(v float64, prec int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', prec, 64)
+	}
+	s := strconv.FormatFloat(math.Abs(v), 'f', prec, 64)
+	intPart, frac, _ := strings.Cut(s, ".")
+	out := l.group(intPart)
+	if frac != "" {
+		out += l.Decimal + frac
+	}
+	if v < 0 && strings.Trim(s, "0.") != "" {
+		out = "-" + out
+	}
+	return out
+}
+
+// USD formats a dollar amount with prec fractional digits, placing the "$"
+// where the locale puts its currency symbol.
+func (l Locale) USD(v float64, prec int) string {
+	return l.Money(l.Float(v, prec))
+}
+
+// Money wraps an already-formatted amount (e.g. "1.2K") in the locale's
+// currency pattern, or HiddenAmount when amounts are hidden.
+func (l Locale) Money(amount string) string {
+	if l.HideAmounts {
+		amount = HiddenAmount
+	}
+	neg := strings.HasPrefix(amount, "-")
+	amount = strings.TrimPrefix(amount, "-")
+	out := strings.Replace(l.Currency, "%s", amount, 1)
+	if neg {
+		out = "-" + out
+	}
+	return out
+}
+
+// Clock formats the time of day, with or without seconds.
+func (l Locale) Clock(t time.Time, seconds bool) string {
+	switch {
+	case l.Clock24 && seconds:
+		return t.Format("15:04:05")
+	case l.Clock24:
+		return t.Format("15:04")
+	case seconds:
+		return t.Format("3:04:05 PM")
+	default:
+		return t.Format("3:04 PM")
+	}
+}
+
+// Date formats a calendar date.
+func (l Locale) Date(t time.Time) string {
+	return t.Format(l.DateLayout)
+}
+
+// DateTime formats a calendar date followed by the time of day.
+func (l Locale) DateTime(t time.Time) string {
+	return l.Date(t) + " " + l.Clock(t, false)
+}
+
+// StartOfWeek returns local midnight of the first day of t's week.
+func (l Locale) StartOfWeek(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	back := (int(day.Weekday()) - int(l.FirstWeekday) + 7) % 7
+	return day.AddDate(0, 0, -back)
+}
+
+func (l Locale) group(digits string) string {
+	if l.Group == "" || len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
@@ -0,0 +1,156 @@
+package locale
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolve(t *testing.T) {
+	env := func(vals map[string]string) func(string) string {
+		return func(k string) string { return vals[k] }
+	}
+
+	tests := []struct {
+		name    string
+		setting string
+		env     map[string]string
+		wantTag string
+	}{
+		{name: "unset", wantTag: ""},
+		{name: "C locale", env: map[string]string{"LANG": "C.UTF-8"}, wantTag: ""},
+		{name: "LANG", env: map[string]string{"LANG": "de_DE.UTF-8"}, wantTag: "de_DE"},
+		{name: "LC_ALL wins", env: map[string]string{"LC_ALL": "fr_FR.UTF-8", "LANG": "de_DE.UTF-8"}, wantTag: "fr_FR"},
+		{name: "setting wins", setting: "en-GB", env: map[string]string{"LANG": "de_DE.UTF-8"}, wantTag: "en_GB"},
+		{name: "auto setting", setting: "auto", env: map[string]string{"LANG": "pl_PL.UTF-8"}, wantTag: "pl_PL"},
+		{name: "language fallback", setting: "de_LU", wantTag: "de_DE"},
+		{name: "modifier", setting: "en_US.UTF-8@posix", wantTag: "en_US"},
+		{name: "unknown", setting: "xx_YY", wantTag: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolve(tt.setting, env(tt.env)); got.Tag != tt.wantTag {
+				t.Fatalf("resolve(%q) tag = %q, want %q", tt.setting, got.Tag, tt.wantTag)
+			}
+		})
+	}
+}
+
+func TestNumberFormatting(t *testing.T) {
+	us, _ := Lookup("en_US")
+	de, _ := Lookup("de_DE")
+	fr, _ := Lookup("fr_FR")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"default int", Default.Int(1234567), "1234567"},
+		{"us int", us.Int(1234567), "1,234,567"},
+		{"us negative int", us.Int(-1234), "-1,234"},
+		{"de float", de.Float(1234.5, 2), "1.234,50"},
+		{"fr float", fr.Float(-9876543.21, 1), "-9 876 543,2"},
+		{"zero stays unsigned", de.Float(-0.001, 2), "0,00"},
+		{"us usd", us.USD(1234.5, 2), "$1,234.50"},
+		{"de usd", de.USD(1234.5, 2), "1.234,50 $"},
+		{"negative money", us.USD(-3, 2), "-$3.00"},
+		{"default usd", Default.USD(1234.5, 2), "$1234.50"},
+		{"hidden usd", us.WithHiddenAmounts(true).USD(-1234.5, 2), "$•••"},
+		{"hidden de usd", de.WithHiddenAmounts(true).Money("1,2K"), "••• $"},
+		{"hidden quantity", us.WithHiddenAmounts(true).Quantity(5000, "USD"), "$•••"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestTimeFormatting(t *testing.T) {
+	ts := time.Date(2026, 3, 4, 15, 4, 5, 0, time.UTC)
+	us, _ := Lookup("en_US")
+	de, _ := Lookup("de_DE")
+
+	if got := us.Clock(ts, false); got != "3:04 PM" {
+		t.Errorf("us clock = %q", got)
+	}
+	if got := de.Clock(ts, true); got != "15:04:05" {
+		t.Errorf("de clock = %q", got)
+	}
+	if got := us.DateTime(ts); got != "03/04/2026 3:04 PM" {
+		t.Errorf("us datetime = %q", got)
+	}
+	if got := de.Date(ts); got != "04.03.2026" {
+		t.Errorf("de date = %q", got)
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	wed := time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC) // Wednesday
+	us, _ := Lookup("en_US")
+	de, _ := Lookup("de_DE")
+
+	if got := us.StartOfWeek(wed); got.Weekday() != time.Sunday || got.Day() != 1 {
+		t.Errorf("us week start = %v", got)
+	}
+	if got := de.StartOfWeek(wed); got.Weekday() != time.Monday || got.Day() != 2 {
+		t.Errorf("de week start = %v", got)
+	}
+	sun := time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)
+	if got := de.StartOfWeek(sun); got.Day() != 2 {
+		t.Errorf("de week start for Sunday = %v", got)
+	}
+}
+
+func TestCurrentDefaultsAndSet(t *testing.T) {
+	t.Cleanup(func() { Set(Default) })
+	if Current().Tag != "" {
+		t.Fatalf("Current() before Set = %q, want default", Current().Tag)
+	}
+	de, _ := Lookup("de")
+	Set(de)
+	if Current().Tag != "de_DE" {
+		t.Fatalf("Current() = %q, want de_DE", Current().Tag)
+	}
+}
+
+func TestUnitFormatting(t *testing.T) {
+	de, _ := Lookup("de_DE")
+
+	tests := []struct {
+		name string
+		loc  Locale
+		got  func(Locale) string
+		want string
+	}{
+		{"compact small", Default, func(l Locale) string { return l.Compact(250) }, "250"},
+		{"compact k trims zero", Default, func(l Locale) string { return l.Compact(84_000) }, "84k"},
+		{"compact M", Default, func(l Locale) string { return l.Compact(1_500_000) }, "1.5M"},
+		{"compact B", Default, func(l Locale) string { return l.Compact(2_340_000_000) }, "2.3B"},
+		{"compact rounds up a scale", Default, func(l Locale) string { return l.Compact(999_960) }, "1M"},
+		{"compact negative", Default, func(l Locale) string { return l.Compact(-12_500) }, "-12.5k"},
+		{"compact locale decimal", de, func(l Locale) string { return l.Compact(1_500_000) }, "1,5M"},
+		{"compact precision 2", Default.WithPrecision(2), func(l Locale) string { return l.Compact(1_234_567) }, "1.23M"},
+		{"compact precision 0", Default.WithPrecision(0), func(l Locale) string { return l.Compact(1_534_567) }, "2M"},
+		{"bytes", Default, func(l Locale) string { return l.Bytes(4_700_000_000) }, "4.7 GB"},
+		{"bytes small", Default, func(l Locale) string { return l.Bytes(512) }, "512 B"},
+		{"energy", Default, func(l Locale) string { return l.Energy(1_260) }, "1.3 kWh"},
+		{"co2 small", Default, func(l Locale) string { return l.CO2(420) }, "420 g"},
+		{"millis", Default, func(l Locale) string { return l.Millis(850) }, "850ms"},
+		{"millis seconds", Default, func(l Locale) string { return l.Millis(2340) }, "2.3s"},
+		{"millis minutes", Default, func(l Locale) string { return l.Millis(125_000) }, "2m05s"},
+		{"quantity tokens", Default, func(l Locale) string { return l.Quantity(12_000, "tokens") }, "12k"},
+		{"quantity other unit", Default, func(l Locale) string { return l.Quantity(3, "models") }, "3 models"},
+		{"quantity usd", de, func(l Locale) string { return l.Quantity(12.5, "USD") }, "12,50 $"},
+		{"quantity usd sub-cent", Default, func(l Locale) string { return l.Quantity(0.0042, "USD") }, "$0.0042"},
+		{"quantity usd large", Default, func(l Locale) string { return l.Quantity(12_345, "USD") }, "$12.3k"},
+		{"precision clamped", Default.WithPrecision(9), func(l Locale) string { return l.Compact(1_234_567) }, "1.235M"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got(tt.loc); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
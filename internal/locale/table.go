@@ -0,0 +1,64 @@
+package locale
+
+import "time"
+
+// known holds the supported locales keyed by language_REGION. Conventions
+// follow CLDR, simplified to what a terminal renders well (plain spaces
+// instead of narrow no-break spaces, numeric dates only).
+var known = map[string]Locale{
+	"en_US": {Tag: "en_US", Decimal: ".", Group: ",", Currency: "$%s", FirstWeekday: time.Sunday, DateLayout: "01/02/2006"},
+	"en_CA": {Tag: "en_CA", Decimal: ".", Group: ",", Currency: "$%s", FirstWeekday: time.Sunday, DateLayout: "2006-01-02"},
+	"en_GB": {Tag: "en_GB", Decimal: ".", Group: ",", Currency: "$%s", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02/01/2006"},
+	"en_IE": {Tag: "en_IE", Decimal: ".", Group: ",", Currency: "$%s", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02/01/2006"},
+	"en_AU": {Tag: "en_AU", Decimal: ".", Group: ",", Currency: "$%s", FirstWeekday: time.Monday, DateLayout: "02/01/2006"},
+	"en_NZ": {Tag: "en_NZ", Decimal: ".", Group: ",", Currency: "$%s", FirstWeekday: time.Monday, DateLayout: "02/01/2006"},
+	"de_DE": {Tag: "de_DE", Decimal: ",", Group: ".", Currency: "%s $", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02.01.2006"},
+	"de_AT": {Tag: "de_AT", Decimal: ",", Group: " ", Currency: "$ %s", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02.01.2006"},
+	"de_CH": {Tag: "de_CH", Decimal: ".", Group: "'", Currency: "$ %s", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02.01.2006"},
+	"fr_FR": {Tag: "fr_FR", Decimal: ",", Group: " ", Currency: "%s $", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02/01/2006"},
+	"fr_CA": {Tag: "fr_CA", Decimal: ",", Group: " ", Currency: "%s $", Clock24: true, FirstWeekday: time.Sunday, DateLayout: "2006-01-02"},
+	"es_ES": {Tag: "es_ES", Decimal: ",", Group: ".", Currency: "%s $", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02/01/2006"},
+	"es_MX": {Tag: "es_MX", Decimal: ".", Group: ",", Currency: "$%s", Clock24: true, FirstWeekday: time.Sunday, DateLayout: "02/01/2006"},
+	"it_IT": {Tag: "it_IT", Decimal: ",", Group: ".", Currency: "%s $", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02/01/2006"},
+	"pt_PT": {Tag: "pt_PT", Decimal: ",", Group: " ", Currency: "%s $", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02/01/2006"},
+	"pt_BR": {Tag: "pt_BR", Decimal: ",", Group: ".", Currency: "$ %s", Clock24: true, FirstWeekday: time.Sunday, DateLayout: "02/01/2006"},
+	"nl_NL": {Tag: "nl_NL", Decimal: ",", Group: ".", Currency: "$ %s", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02-01-2006"},
+	"pl_PL": {Tag: "pl_PL", Decimal: ",", Group: " ", Currency: "%s $", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02.01.2006"},
+	"cs_CZ": {Tag: "cs_CZ", Decimal: ",", Group: " ", Currency: "%s $", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02.01.2006"},
+	"sv_SE": {Tag: "sv_SE", Decimal: ",", Group: " ", Currency: "%s $", Clock24: true, FirstWeekday: time.Monday, DateLayout: "2006-01-02"},
+	"da_DK": {Tag: "da_DK", Decimal: ",", Group: ".", Currency: "%s $", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02.01.2006"},
+	"nb_NO": {Tag: "nb_NO", Decimal: ",", Group: " ", Currency: "%s $", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02.01.2006"},
+	"fi_FI": {Tag: "fi_FI", Decimal: ",", Group: " ", Currency: "%s $", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02.01.2006"},
+	"ru_RU": {Tag: "ru_RU", Decimal: ",", Group: " ", Currency: "%s $", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02.01.2006"},
+	"uk_UA": {Tag: "uk_UA", Decimal: ",", Group: " ", Currency: "%s $", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02.01.2006"},
+	"tr_TR": {Tag: "tr_TR", Decimal: ",", Group: ".", Currency: "$%s", Clock24: true, FirstWeekday: time.Monday, DateLayout: "02.01.2006"},
+	"ja_JP": {Tag: "ja_JP", Decimal: ".", Group: ",", Currency: "$%s", Clock24: true, FirstWeekday: time.Sunday, DateLayout: "2006/01/02"},
+	"zh_CN": {Tag: "zh_CN", Decimal: ".", Group: ",", Currency: "$%s", Clock24: true, FirstWeekday: time.Monday, DateLayout: "2006/01/02"},
+	"zh_TW": {Tag: "zh_TW", Decimal: ".", Group: ",", Currency: "$%s", FirstWeekday: time.Sunday, DateLayout: "2006/01/02"},
+	"ko_KR": {Tag: "ko_KR", Decimal: ".", Group: ",", Currency: "$%s", FirstWeekday: time.Sunday, DateLayout: "2006.01.02"},
+}
+
+// languageDefault picks the locale used when only the language is known
+// (e.g. LANG=de or an unlisted region such as de_LU).
+var languageDefault = map[string]string{
+	"en": "en_US",
+	"de": "de_DE",
+	"fr": "fr_FR",
+	"es": "es_ES",
+	"it": "it_IT",
+	"pt": "pt_PT",
+	"nl": "nl_NL",
+	"pl": "pl_PL",
+	"cs": "cs_CZ",
+	"sv": "sv_SE",
+	"da": "da_DK",
+	"nb": "nb_NO",
+	"no": "nb_NO",
+	"fi": "fi_FI",
+	"ru": "ru_RU",
+	"uk": "uk_UA",
+	"tr": "tr_TR",
+	"ja": "ja_JP",
+	"zh": "zh_CN",
+	"ko": "ko_KR",
+}
@@ -0,0 +1,131 @@
+package locale
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// DefaultPrecision keeps one fractional digit on scaled values: "1.2M".
+	DefaultPrecision = 1
+	// MaxPrecision bounds the user-configurable precision.
+	MaxPrecision = 3
+)
+
+var (
+	countSuffixes = []string{"", "k", "M", "B", "T"}
+	byteSuffixes  = []string{"B", "KB", "MB", "GB", "TB", "PB"}
+	whSuffixes    = []string{"Wh", "kWh", "MWh", "GWh"}
+	gramSuffixes  = []string{"g", "kg", "t", "kt"}
+)
+
+// Compact scales v to a k/M/B/T suffix ("12k", "1.5M") with the locale's
+// Precision. Trailing fractional zeros are dropped, so round values stay
+// short. Values under 1000 keep their own digits.
+func (l Locale) Compact(v float64) string {
+	return l.scaled(v, countSuffixes, "")
+}
+
+// Bytes scales a byte count to decimal (SI) units: "512 B", "1.2 GB".
+func (l Locale) Bytes(v float64) string {
+	return l.scaled(v, byteSuffixes, " ")
+}
+
+// Energy scales watt-hours: "850 Wh", "1.2 kWh".
+func (l Locale) Energy(wh float64) string {
+	return l.scaled(wh, whSuffixes, " ")
+}
+
+// CO2 scales a mass given in grams: "420 g", "3.1 kg", "1.2 t".
+func (l Locale) CO2(grams float64) string {
+	return l.scaled(grams, gramSuffixes, " ")
+}
+
+// Millis renders a duration given in milliseconds: "850ms" below a second,
+// "1.5s" below a minute, then "2m05s".
+func (l Locale) Millis(ms float64) string {
+	abs := math.Abs(ms)
+	switch {
+	case abs < 1000:
+		return l.trimmed(ms, 0) + "ms"
+	case abs < 60_000:
+		return l.trimmed(ms/1000, l.Precision) + "s"
+	default:
+		secs := int64(math.Round(abs / 1000))
+		out := fmt.Sprintf("%sm%02ds", l.Int(secs/60), secs%60)
+		if ms < 0 {
+			out = "-" + out
+		}
+		return out
+	}
+}
+
+// Quantity renders v in the given metric unit: bytes and milliseconds are
+// converted, USD gets the locale's currency pattern (four digits below a
+// cent, compacted from 1000), counted units (tokens, requests, …) are
+// compacted without a suffix, and any other unit is appended after the
+// compacted value.
+func (l Locale) Quantity(v float64, unit string) string {
+	switch unit {
+	case "bytes":
+		return l.Bytes(v)
+	case "ms":
+		return l.Millis(v)
+	case "%":
+		return l.trimmed(v, 0) + "%"
+	case "USD":
+		if v != 0 && math.Abs(v) < 0.01 {
+			return l.USD(v, 4)
+		}
+		if math.Abs(v) < 1000 {
+			return l.USD(v, 2)
+		}
+		return l.Money(l.Compact(v))
+	case "", "tokens", "requests", "messages", "completions", "conversations", "seats", "quota", "lines":
+		return l.Compact(v)
+	default:
+		return l.Compact(v) + " " + unit
+	}
+}
+
+func (l Locale) scaled(v float64, suffixes []string, sep string) string {
+	abs := math.Abs(v)
+	i := 0
+	for i < len(suffixes)-1 && math.Round(abs) >= 1000 {
+		abs /= 1000
+		i++
+	}
+	// 999.96k rounds to "1000k" at one digit; promote it to "1M".
+	if i > 0 && i < len(suffixes)-1 && roundTo(abs, l.Precision) >= 1000 {
+		abs /= 1000
+		i++
+	}
+	prec := l.Precision
+	if i == 0 {
+		prec = 0
+		if abs != math.Floor(abs) {
+			prec = l.Precision
+		}
+	}
+	if v < 0 {
+		abs = -abs
+	}
+	return l.trimmed(abs, prec) + sep + suffixes[i]
+}
+
+// trimmed formats v with at most prec fractional digits, dropping trailing
+// zeros and a dangling decimal separator.
+func (l Locale) trimmed(v float64, prec int) string {
+	out := l.Float(v, prec)
+	if prec > 0 {
+		out = strings.TrimRight(out, "0")
+		out = strings.TrimSuffix(out, l.Decimal)
+	}
+	return out
+}
+
+func roundTo(v float64, prec int) float64 {
+	scale := math.Pow(10, float64(prec))
+	return math.Round(v*scale) / scale
+}
@@ -0,0 +1,174 @@
+// Package notify turns usage snapshots into alerts and routes them to
+// notification sinks (Slack, webhooks, ntfy, Pushover, Telegram, desktop)
+// according to the user's rules.
+package notify
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// Severity orders alerts; higher is more urgent.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota + 1
+	SeverityWarning
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseSeverity reads a configured severity. Empty returns def.
+func ParseSeverity(s string, def Severity) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "":
+		return def, nil
+	case "info":
+		return SeverityInfo, nil
+	case "warning", "warn":
+		return SeverityWarning, nil
+	case "critical", "crit":
+		return SeverityCritical, nil
+	default:
+		return 0, fmt.Errorf("unknown severity %q (want info, warning, or critical)", s)
+	}
+}
+
+// Alert is one condition worth telling the user about. Key identifies the
+// condition (account plus status or metric) for deduplication.
+type Alert struct {
+	Key        string
+	AccountID  string
+	ProviderID string
+	Severity   Severity
+	Title      string
+	Body       string
+	At         time.Time
+	// SnoozeRule is the dashboard snooze rule (config.SnoozeRuleLimit,
+	// config.SnoozeRuleNearLimit) that silences the alert; empty when it
+	// can't be snoozed.
+	SnoozeRule string
+}
+
+// Thresholds are the remaining fractions (0–1) at which a gauge warns and
+// turns critical, matching the dashboard's ui.warn_threshold and
+// ui.crit_threshold.
+type Thresholds struct {
+	Warn float64
+	Crit float64
+}
+
+// minGaugeWindow skips gauges on short windows (per-minute rate limits):
+// they drain and refill within a poll and would alert constantly.
+const minGaugeWindow = time.Hour
+
+// Evaluate derives the current alerts from snaps: one per account whose
+// status needs attention, plus one per gauge at or below the thresholds.
+// Metrics flagged as implausible by snapshot validation are skipped. The
+// result is sorted by key.
+func Evaluate(snaps map[string]core.UsageSnapshot, th Thresholds, now time.Time) []Alert {
+	var out []Alert
+	for id, snap := range snaps {
+		accountID := snap.AccountID
+		if accountID == "" {
+			accountID = id
+		}
+		base := Alert{AccountID: accountID, ProviderID: snap.ProviderID, At: now}
+
+		if sev, title, ok := statusAlert(snap.Status); ok {
+			a := base
+			a.Key = accountID + "/status"
+			a.Severity = sev
+			a.SnoozeRule = snoozeRuleForStatus(snap.Status)
+			a.Title = fmt.Sprintf("%s: %s", accountID, title)
+			a.Body = snap.Message
+			out = append(out, a)
+		}
+
+		for key, met := range snap.Metrics {
+			used, ok := gaugeUsed(snap, key, met)
+			if !ok {
+				continue
+			}
+			pct := 100 - used
+			sev := th.severity(pct)
+			if sev == 0 {
+				continue
+			}
+			a := base
+			a.Key = accountID + "/" + key
+			a.Severity = sev
+			a.SnoozeRule = snoozeRuleForSeverity(sev)
+			a.Title = fmt.Sprintf("%s: %s at %.0f%% remaining", accountID, key, pct)
+			if met.Window != "" {
+				a.Body = "window " + met.Window
+			}
+			out = append(out, a)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+// gaugeUsed returns the used percentage of a gauge worth alerting on. Metrics
+// without a percentage, on windows shorter than minGaugeWindow, or whose
+// value jumped implausibly or sits behind a stale reset report false. Used
+// past the limit is kept: that is a real overage as often as a parser bug,
+// and the one case an alert must not miss.
+func gaugeUsed(snap core.UsageSnapshot, key string, met core.Metric) (float64, bool) {
+	if snap.HasMetricAnomaly(key, core.AnomalySuddenJump, core.AnomalyStaleReset) {
+		return 0, false
+	}
+	if d, err := time.ParseDuration(met.Window); err == nil && d < minGaugeWindow {
+		return 0, false
+	}
+	used := core.MetricUsedPercent(key, met)
+	return used, used >= 0
+}
+
+// severity classifies a remaining percentage; 0 means above both thresholds.
+func (th Thresholds) severity(remaining float64) Severity {
+	switch {
+	case remaining <= th.Crit*100:
+		return SeverityCritical
+	case remaining <= th.Warn*100:
+		return SeverityWarning
+	default:
+		return 0
+	}
+}
+
+func statusAlert(status core.Status) (Severity, string, bool) {
+	switch status {
+	case core.StatusLimited:
+		return SeverityCritical, "limit reached", true
+	case core.StatusExhausted:
+		return SeverityCritical, "limit nearly exhausted", true
+	case core.StatusNearLimit:
+		return SeverityWarning, "near limit", true
+	case core.StatusAuth:
+		return SeverityWarning, "authentication required", true
+	case core.StatusError:
+		return SeverityWarning, "fetch failing", true
+	case core.StatusMaintenance:
+		return SeverityInfo, "provider maintenance", true
+	default:
+		return 0, "", false
+	}
+}
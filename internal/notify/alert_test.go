@@ -0,0 +1,73 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestEvaluate(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	snaps := map[string]core.UsageSnapshot{
+		"copilot": {
+			AccountID: "copilot", ProviderID: "copilot", Status: core.StatusOK,
+			Metrics: map[string]core.Metric{
+				"premium": {Limit: f(300), Used: f(250), Window: "month"}, // 16.7% left → warning
+				"chat":    {Limit: f(100), Used: f(98), Window: "month"},  // 2% left → critical
+				"rpm":     {Limit: f(60), Remaining: f(0), Window: "1m"},  // short window, skipped
+				"healthy": {Limit: f(100), Used: f(10), Window: "month"},
+				"odd":     {Limit: f(100), Used: f(99), Window: "month"},
+				"overage": {Limit: f(300), Used: f(420), Window: "month"},
+			},
+			Diagnostics: map[string]string{
+				core.AnomalyDiagnosticPrefix + "odd":     "sudden_jump: used 99 is 99x the previous 1",
+				core.AnomalyDiagnosticPrefix + "overage": "used_exceeds_limit: used 420 exceeds limit 300",
+			},
+		},
+		"openrouter": {AccountID: "openrouter", ProviderID: "openrouter", Status: core.StatusLimited, Message: "credits exhausted"},
+		"claude": {
+			AccountID: "claude", ProviderID: "claude_code", Status: core.StatusOK,
+			Metrics: map[string]core.Metric{
+				"usage_five_hour": {Used: f(97), Unit: "%", Window: "5h"},
+				"context_window":  {Used: f(99), Limit: f(100), Window: "session"},
+			},
+		},
+	}
+
+	got := Evaluate(snaps, Thresholds{Warn: 0.20, Crit: 0.05}, now)
+	want := []struct {
+		key string
+		sev Severity
+	}{
+		{"claude/usage_five_hour", SeverityCritical},
+		{"copilot/chat", SeverityCritical},
+		{"copilot/overage", SeverityCritical},
+		{"copilot/premium", SeverityWarning},
+		{"openrouter/status", SeverityCritical},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Evaluate() = %+v, want %d alerts", got, len(want))
+	}
+	for i, w := range want {
+		if got[i].Key != w.key || got[i].Severity != w.sev {
+			t.Errorf("alert %d = %s/%s, want %s/%s", i, got[i].Key, got[i].Severity, w.key, w.sev)
+		}
+	}
+	if got[4].Body != "credits exhausted" || got[4].ProviderID != "openrouter" {
+		t.Errorf("status alert = %+v", got[4])
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	for in, want := range map[string]Severity{"": SeverityWarning, "info": SeverityInfo, "WARN": SeverityWarning, "critical": SeverityCritical} {
+		got, err := ParseSeverity(in, SeverityWarning)
+		if err != nil || got != want {
+			t.Errorf("ParseSeverity(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseSeverity("loud", SeverityWarning); err == nil {
+		t.Error("ParseSeverity(loud) succeeded")
+	}
+}
@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// Event types passed to the on_event hook.
+const (
+	EventStatusChange = "status_change"
+	EventThreshold    = "threshold"
+	EventReset        = "reset"
+)
+
+// EventTypes lists every event type, in the order they are documented.
+var EventTypes = []string{EventStatusChange, EventThreshold, EventReset}
+
+// resetDropPercent is how far a gauge's used percentage must fall between
+// two observations to count as a reset. Smaller dips are refunds or noise.
+const resetDropPercent = 25
+
+// Event is a change between two observations of an account, serialized as
+// JSON on the hook's stdin. Fields that don't apply to Type are omitted.
+type Event struct {
+	Type       string    `json:"type"`
+	AccountID  string    `json:"account_id"`
+	ProviderID string    `json:"provider_id,omitempty"`
+	At         time.Time `json:"at"`
+
+	// status_change
+	Status         string `json:"status,omitempty"`
+	PreviousStatus string `json:"previous_status,omitempty"`
+	Message        string `json:"message,omitempty"`
+
+	// threshold and reset
+	Metric              string   `json:"metric,omitempty"`
+	Window              string   `json:"window,omitempty"`
+	Severity            string   `json:"severity,omitempty"`
+	UsedPercent         *float64 `json:"used_percent,omitempty"`
+	RemainingPercent    *float64 `json:"remaining_percent,omitempty"`
+	PreviousUsedPercent *float64 `json:"previous_used_percent,omitempty"`
+}
+
+type gaugeState struct {
+	used     float64
+	severity Severity
+}
+
+// EventTracker turns successive snapshot sets into events by remembering the
+// last status and gauge level of every account.
+type EventTracker struct {
+	th Thresholds
+
+	mu     sync.Mutex
+	status map[string]core.Status // account ID
+	gauges map[string]gaugeState  // account ID + "/" + metric key
+}
+
+func NewEventTracker(th Thresholds) *EventTracker {
+	return &EventTracker{
+		th:     th,
+		status: make(map[string]core.Status),
+		gauges: make(map[string]gaugeState),
+	}
+}
+
+// Observe records snaps and returns what changed since the previous call:
+//   - status_change when an account's status differs from last time;
+//   - threshold when a gauge crosses into warning or critical, including
+//     the first time an account is seen;
+//   - reset when a gauge's used percentage falls by resetDropPercent or more.
+//
+// Accounts missing from snaps keep their state. The result is sorted by
+// account, then metric.
+func (t *EventTracker) Observe(snaps map[string]core.UsageSnapshot, now time.Time) []Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var out []Event
+	for id, snap := range snaps {
+		accountID := snap.AccountID
+		if accountID == "" {
+			accountID = id
+		}
+		base := Event{AccountID: accountID, ProviderID: snap.ProviderID, At: now}
+
+		if prev, seen := t.status[accountID]; seen && prev != snap.Status {
+			e := base
+			e.Type = EventStatusChange
+			e.Status = string(snap.Status)
+			e.PreviousStatus = string(prev)
+			e.Message = snap.Message
+			out = append(out, e)
+		}
+		t.status[accountID] = snap.Status
+
+		for key, met := range snap.Metrics {
+			used, ok := gaugeUsed(snap, key, met)
+			if !ok {
+				continue
+			}
+			remaining := 100 - used
+			state := gaugeState{used: used, severity: t.th.severity(remaining)}
+			gaugeKey := accountID + "/" + key
+			prev, seen := t.gauges[gaugeKey]
+			t.gauges[gaugeKey] = state
+
+			e := base
+			e.Metric = key
+			e.Window = met.Window
+			e.UsedPercent = &used
+			switch {
+			case seen && used <= prev.used-resetDropPercent:
+				e.Type = EventReset
+				prevUsed := prev.used
+				e.PreviousUsedPercent = &prevUsed
+			case state.severity > prev.severity:
+				e.Type = EventThreshold
+				e.Severity = state.severity.String()
+				e.RemainingPercent = &remaining
+			default:
+				continue
+			}
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].AccountID != out[j].AccountID {
+			return out[i].AccountID < out[j].AccountID
+		}
+		return out[i].Metric < out[j].Metric
+	})
+	return out
+}
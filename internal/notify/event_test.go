@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestEventTrackerObserve(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	snap := func(status core.Status, fiveHourUsed float64) map[string]core.UsageSnapshot {
+		return map[string]core.UsageSnapshot{
+			"claude": {
+				AccountID: "claude", ProviderID: "claude_code", Status: status,
+				Metrics: map[string]core.Metric{
+					"usage_five_hour": {Used: f(fiveHourUsed), Unit: "%", Window: "5h"},
+					"rpm":             {Used: f(99), Limit: f(100), Window: "1m"},
+				},
+			},
+		}
+	}
+	tracker := NewEventTracker(Thresholds{Warn: 0.3, Crit: 0.1})
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	type want struct {
+		typ, metric, detail string
+	}
+	steps := []struct {
+		name  string
+		snaps map[string]core.UsageSnapshot
+		want  []want
+	}{
+		{"first sighting below no threshold", snap(core.StatusOK, 40), nil},
+		{"unchanged", snap(core.StatusOK, 45), nil},
+		{"warning crossed", snap(core.StatusOK, 75), []want{{EventThreshold, "usage_five_hour", "warning"}}},
+		{"still warning", snap(core.StatusOK, 80), nil},
+		{"critical and limited", snap(core.StatusLimited, 95), []want{
+			{EventStatusChange, "", "LIMITED"},
+			{EventThreshold, "usage_five_hour", "critical"},
+		}},
+		{"window reset", snap(core.StatusOK, 2), []want{
+			{EventStatusChange, "", "OK"},
+			{EventReset, "usage_five_hour", ""},
+		}},
+	}
+	for _, step := range steps {
+		got := tracker.Observe(step.snaps, now)
+		if len(got) != len(step.want) {
+			t.Fatalf("%s: events = %+v, want %d", step.name, got, len(step.want))
+		}
+		for i, w := range step.want {
+			e := got[i]
+			detail := e.Severity
+			if e.Type == EventStatusChange {
+				detail = e.Status
+			}
+			if e.Type != w.typ || e.Metric != w.metric || detail != w.detail || e.AccountID != "claude" || e.ProviderID != "claude_code" {
+				t.Errorf("%s: event %d = %+v, want %+v", step.name, i, e, w)
+			}
+		}
+	}
+}
+
+func TestEventTrackerFirstSightingBreach(t *testing.T) {
+	used := 96.0
+	tracker := NewEventTracker(Thresholds{Warn: 0.3, Crit: 0.1})
+	got := tracker.Observe(map[string]core.UsageSnapshot{
+		"copilot": {ProviderID: "copilot", Status: core.StatusNearLimit, Metrics: map[string]core.Metric{
+			"chat": {Used: &used, Unit: "%", Window: "30d"},
+		}},
+	}, time.Now())
+	if len(got) != 1 || got[0].Type != EventThreshold || got[0].AccountID != "copilot" {
+		t.Fatalf("events = %+v, want one threshold event", got)
+	}
+	if got[0].RemainingPercent == nil || *got[0].RemainingPercent != 4 || got[0].Severity != "critical" {
+		t.Errorf("threshold event = %+v", got[0])
+	}
+}
@@ -0,0 +1,126 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+)
+
+const (
+	defaultHookTimeout = 30 * time.Second
+	hookStderrLimit    = 512
+)
+
+// hookRunner executes the hook command with stdin and returns its stderr;
+// tests replace it.
+type hookRunner func(ctx context.Context, argv []string, env []string, stdin []byte) (stderr []byte, err error)
+
+func runHookCommand(ctx context.Context, argv []string, env []string, stdin []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stderr.Bytes(), err
+}
+
+// Hook runs the user's on_event command once per event.
+type Hook struct {
+	command []string
+	events  map[string]bool // nil means every event
+	timeout time.Duration
+	run     hookRunner
+}
+
+// NewHook validates cfg. A leading "~/" in the command path is expanded.
+func NewHook(cfg config.EventHook) (*Hook, error) {
+	if len(cfg.Command) == 0 || strings.TrimSpace(cfg.Command[0]) == "" {
+		return nil, fmt.Errorf("command is required")
+	}
+	command := slices.Clone(cfg.Command)
+	command[0] = strings.TrimSpace(command[0])
+	if rest, ok := strings.CutPrefix(command[0], "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			command[0] = filepath.Join(home, rest)
+		}
+	}
+
+	h := &Hook{command: command, timeout: defaultHookTimeout, run: runHookCommand}
+	for _, name := range cfg.Events {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(EventTypes, name) {
+			return nil, fmt.Errorf("unknown event %q (want %s)", name, strings.Join(EventTypes, ", "))
+		}
+		if h.events == nil {
+			h.events = make(map[string]bool)
+		}
+		h.events[name] = true
+	}
+	if s := strings.TrimSpace(cfg.Timeout); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("timeout: invalid duration %q", cfg.Timeout)
+		}
+		h.timeout = d
+	}
+	return h, nil
+}
+
+// Describe summarizes the hook for `openusage notify check`.
+func (h *Hook) Describe() string {
+	events := EventTypes
+	if h.events != nil {
+		events = nil
+		for _, typ := range EventTypes {
+			if h.events[typ] {
+				events = append(events, typ)
+			}
+		}
+	}
+	return fmt.Sprintf("on_event: %s for %s (timeout %s)", strings.Join(h.command, " "), strings.Join(events, ", "), h.timeout)
+}
+
+// Wants reports whether the hook subscribes to events of type typ.
+func (h *Hook) Wants(typ string) bool {
+	return h != nil && (h.events == nil || h.events[typ])
+}
+
+// Run executes the command with e as JSON on stdin and OPENUSAGE_EVENT set to
+// its type. A non-zero exit is returned with the tail of the command's stderr.
+func (h *Hook) Run(ctx context.Context, e Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	runCtx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	env := []string{
+		"OPENUSAGE_EVENT=" + e.Type,
+		"OPENUSAGE_ACCOUNT_ID=" + e.AccountID,
+	}
+	stderr, err := h.run(runCtx, h.command, env, payload)
+	if err == nil {
+		return nil
+	}
+	if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", h.timeout)
+	}
+	if msg := strings.TrimSpace(string(stderr)); msg != "" {
+		if len(msg) > hookStderrLimit {
+			msg = "…" + msg[len(msg)-hookStderrLimit:]
+		}
+		return fmt.Errorf("%w: %s", err, msg)
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+)
+
+func TestHookRun(t *testing.T) {
+	hook, err := NewHook(config.EventHook{Command: []string{"/opt/pause-agents", "--floor", "10"}, Events: []string{"Threshold"}})
+	if err != nil {
+		t.Fatalf("NewHook() error: %v", err)
+	}
+	var gotArgv, gotEnv []string
+	var got map[string]any
+	hook.run = func(_ context.Context, argv, env []string, stdin []byte) ([]byte, error) {
+		gotArgv, gotEnv = argv, env
+		return nil, json.Unmarshal(stdin, &got)
+	}
+
+	if hook.Wants(EventReset) || !hook.Wants(EventThreshold) {
+		t.Errorf("Wants() does not follow the events filter")
+	}
+	remaining := 4.0
+	e := Event{
+		Type: EventThreshold, AccountID: "claude", ProviderID: "claude_code",
+		At: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), Metric: "usage_five_hour",
+		Severity: "critical", RemainingPercent: &remaining,
+	}
+	if err := hook.Run(context.Background(), e); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if !reflect.DeepEqual(gotArgv, []string{"/opt/pause-agents", "--floor", "10"}) {
+		t.Errorf("argv = %q", gotArgv)
+	}
+	if !reflect.DeepEqual(gotEnv, []string{"OPENUSAGE_EVENT=threshold", "OPENUSAGE_ACCOUNT_ID=claude"}) {
+		t.Errorf("env = %q", gotEnv)
+	}
+	want := map[string]any{
+		"type": "threshold", "account_id": "claude", "provider_id": "claude_code",
+		"at": "2026-03-01T12:00:00Z", "metric": "usage_five_hour",
+		"severity": "critical", "remaining_percent": 4.0,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("stdin = %v, want %v", got, want)
+	}
+}
+
+func TestHookRunErrors(t *testing.T) {
+	hook, err := NewHook(config.EventHook{Command: []string{"hook"}, Timeout: "10ms"})
+	if err != nil {
+		t.Fatalf("NewHook() error: %v", err)
+	}
+
+	hook.run = func(context.Context, []string, []string, []byte) ([]byte, error) {
+		return []byte("agentd: not running\n"), errors.New("exit status 3")
+	}
+	if err := hook.Run(context.Background(), Event{Type: EventReset}); err == nil || err.Error() != "exit status 3: agentd: not running" {
+		t.Errorf("Run() error = %v, want exit status with stderr", err)
+	}
+
+	hook.run = func(ctx context.Context, _ []string, _ []string, _ []byte) ([]byte, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if err := hook.Run(context.Background(), Event{Type: EventReset}); err == nil || !strings.Contains(err.Error(), "timed out after 10ms") {
+		t.Errorf("Run() error = %v, want timeout", err)
+	}
+}
+
+func TestNewHookValidation(t *testing.T) {
+	for _, tt := range []struct {
+		cfg  config.EventHook
+		want string
+	}{
+		{config.EventHook{}, "command is required"},
+		{config.EventHook{Command: []string{" "}}, "command is required"},
+		{config.EventHook{Command: []string{"x"}, Events: []string{"refill"}}, `unknown event "refill"`},
+		{config.EventHook{Command: []string{"x"}, Timeout: "soon"}, `invalid duration "soon"`},
+	} {
+		_, err := NewHook(tt.cfg)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("NewHook(%+v) error = %v, want %q", tt.cfg, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+)
+
+const defaultDedupWindow = time.Hour
+
+type sinkEntry struct {
+	sink Sink
+	min  Severity
+}
+
+type rule struct {
+	index     int
+	accounts  map[string]bool
+	providers map[string]bool
+	min       Severity
+	sinks     []string
+}
+
+// specificity ranks rules for "most specific wins": account rules beat
+// provider rules, which beat catch-all rules.
+func (r rule) specificity() int {
+	switch {
+	case len(r.accounts) > 0:
+		return 2
+	case len(r.providers) > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (r rule) matches(a Alert) bool {
+	if len(r.accounts) > 0 && !r.accounts[a.AccountID] {
+		return false
+	}
+	if len(r.providers) > 0 && !r.providers[a.ProviderID] {
+		return false
+	}
+	return true
+}
+
+type quietHours struct {
+	start, end int // minutes after local midnight
+	min        Severity
+}
+
+func (q quietHours) contains(t time.Time) bool {
+	m := t.Hour()*60 + t.Minute()
+	if q.start < q.end {
+		return m >= q.start && m < q.end
+	}
+	return m >= q.start || m < q.end
+}
+
+type sentEntry struct {
+	at       time.Time
+	severity Severity
+}
+
+// Delivery is one alert sent (or failed to send) to one sink.
+type Delivery struct {
+	Sink  string
+	Alert Alert
+	Err   error
+}
+
+// Router decides which sinks receive an alert and delivers it, suppressing
+// repeats within the dedup window.
+type Router struct {
+	sinks    map[string]sinkEntry
+	defaults []string
+	rules    []rule
+	quiet    *quietHours
+	dedup    time.Duration
+
+	mu   sync.Mutex
+	sent map[string]sentEntry // alert key + "\x00" + sink name
+}
+
+// NewRouter compiles cfg. Problems are returned alongside a router built
+// from the valid parts: a broken sink, an unknown sink name, or a bad
+// severity drops just that piece.
+func NewRouter(cfg config.NotificationsConfig) (*Router, []error) {
+	return newRouter(cfg, NewSink)
+}
+
+func newRouter(cfg config.NotificationsConfig, build func(config.NotificationSink) (Sink, error)) (*Router, []error) {
+	var errs []error
+	r := &Router{
+		sinks: make(map[string]sinkEntry, len(cfg.Sinks)),
+		dedup: defaultDedupWindow,
+		sent:  make(map[string]sentEntry),
+	}
+
+	for name, sc := range cfg.Sinks {
+		minSev, err := ParseSeverity(sc.MinSeverity, SeverityWarning)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("sink %q: %w", name, err))
+			continue
+		}
+		sink, err := build(sc)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("sink %q: %w", name, err))
+			continue
+		}
+		r.sinks[name] = sinkEntry{sink: sink, min: minSev}
+	}
+
+	r.defaults, errs = r.knownSinks(cfg.DefaultSinks, "default_sinks", errs)
+
+	for i, rc := range cfg.Rules {
+		where := fmt.Sprintf("rule %d", i+1)
+		minSev, err := ParseSeverity(rc.MinSeverity, SeverityInfo)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", where, err))
+			continue
+		}
+		sinks, ruleErrs := r.knownSinks(rc.Sinks, where, nil)
+		errs = append(errs, ruleErrs...)
+		if len(rc.Sinks) > 0 && len(sinks) == 0 {
+			// Every sink was unknown; keeping the rule would silently mute.
+			errs = append(errs, fmt.Errorf("%s: dropped, no usable sinks", where))
+			continue
+		}
+		r.rules = append(r.rules, rule{
+			index:     i,
+			accounts:  stringSet(rc.Accounts),
+			providers: stringSet(rc.Providers),
+			min:       minSev,
+			sinks:     sinks,
+		})
+	}
+
+	if q := cfg.QuietHours; q != nil {
+		qh, err := parseQuietHours(*q)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("quiet_hours: %w", err))
+		} else {
+			r.quiet = &qh
+		}
+	}
+
+	if w := strings.TrimSpace(cfg.DedupWindow); w != "" {
+		d, err := time.ParseDuration(w)
+		if err != nil || d < 0 {
+			errs = append(errs, fmt.Errorf("dedup_window: invalid duration %q", cfg.DedupWindow))
+		} else {
+			r.dedup = d
+		}
+	}
+	return r, errs
+}
+
+func (r *Router) knownSinks(names []string, where string, errs []error) ([]string, []error) {
+	var out []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if _, ok := r.sinks[name]; !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown or unusable sink %q", where, name))
+			continue
+		}
+		out = append(out, name)
+	}
+	return out, errs
+}
+
+func stringSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make(map[string]bool, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out[v] = true
+		}
+	}
+	return out
+}
+
+func parseQuietHours(q config.NotificationQuietHours) (quietHours, error) {
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return quietHours{}, fmt.Errorf("start: %w", err)
+	}
+	end, err := parseClock(q.End)
+	if err != nil {
+		return quietHours{}, fmt.Errorf("end: %w", err)
+	}
+	if start == end {
+		return quietHours{}, fmt.Errorf("start and end are both %s", q.Start)
+	}
+	minSev, err := ParseSeverity(q.MinSeverity, SeverityCritical)
+	if err != nil {
+		return quietHours{}, err
+	}
+	return quietHours{start: start, end: end, min: minSev}, nil
+}
+
+// parseClock reads "HH:MM" as minutes after midnight.
+func parseClock(s string) (int, error) {
+	h, m, ok := strings.Cut(strings.TrimSpace(s), ":")
+	hour, herr := strconv.Atoi(h)
+	minute, merr := strconv.Atoi(m)
+	if !ok || herr != nil || merr != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("invalid time %q (want HH:MM)", s)
+	}
+	return hour*60 + minute, nil
+}
+
+// Enabled reports whether any alert could reach a sink.
+func (r *Router) Enabled() bool {
+	return r != nil && len(r.sinks) > 0 && (len(r.defaults) > 0 || len(r.rules) > 0)
+}
+
+// Targets returns the sinks a should go to at now, before deduplication: the
+// most specific matching rule's sinks (or the defaults), filtered by the
+// rule's and each sink's minimum severity and by quiet hours.
+func (r *Router) Targets(a Alert, now time.Time) []string {
+	if r == nil {
+		return nil
+	}
+	if r.quiet != nil && r.quiet.contains(now) && a.Severity < r.quiet.min {
+		return nil
+	}
+	sinks := r.defaults
+	if match, ok := r.matchRule(a); ok {
+		if a.Severity < match.min {
+			return nil
+		}
+		sinks = match.sinks
+	}
+	var out []string
+	for _, name := range sinks {
+		if entry, ok := r.sinks[name]; ok && a.Severity >= entry.min {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func (r *Router) matchRule(a Alert) (rule, bool) {
+	var best rule
+	found := false
+	for _, candidate := range r.rules {
+		if !candidate.matches(a) {
+			continue
+		}
+		if !found || candidate.specificity() > best.specificity() {
+			best, found = candidate, true
+		}
+	}
+	return best, found
+}
+
+// Dispatch sends alerts to their targets. An alert already delivered to a
+// sink within the dedup window is skipped unless its severity rose. accounts
+// lists the accounts the alerts were evaluated for; conditions on those
+// accounts that are no longer alerting are forgotten, so they notify again
+// if they come back.
+func (r *Router) Dispatch(ctx context.Context, alerts []Alert, accounts []string, now time.Time) []Delivery {
+	if !r.Enabled() {
+		return nil
+	}
+	r.mu.Lock()
+	r.forgetCleared(alerts, accounts)
+	type pending struct {
+		name  string
+		alert Alert
+	}
+	var queue []pending
+	for _, a := range alerts {
+		for _, name := range r.Targets(a, now) {
+			key := a.Key + "\x00" + name
+			if prev, ok := r.sent[key]; ok && now.Sub(prev.at) < r.dedup && a.Severity <= prev.severity {
+				continue
+			}
+			queue = append(queue, pending{name: name, alert: a})
+		}
+	}
+	r.mu.Unlock()
+
+	var out []Delivery
+	for _, p := range queue {
+		sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+		err := r.sinks[p.name].sink.Send(sendCtx, p.alert)
+		cancel()
+		if err == nil {
+			r.mu.Lock()
+			r.sent[p.alert.Key+"\x00"+p.name] = sentEntry{at: now, severity: p.alert.Severity}
+			r.mu.Unlock()
+		}
+		out = append(out, Delivery{Sink: p.name, Alert: p.alert, Err: err})
+	}
+	return out
+}
+
+func (r *Router) forgetCleared(alerts []Alert, accounts []string) {
+	active := make(map[string]bool, len(alerts))
+	for _, a := range alerts {
+		active[a.Key] = true
+	}
+	evaluated := stringSet(accounts)
+	for key := range r.sent {
+		alertKey, _, _ := strings.Cut(key, "\x00")
+		accountID, _, _ := strings.Cut(alertKey, "/")
+		if evaluated[accountID] && !active[alertKey] {
+			delete(r.sent, key)
+		}
+	}
+}
+
+// SinkNames lists the usable sinks, sorted.
+func (r *Router) SinkNames() []string {
+	if r == nil {
+		return nil
+	}
+	names := make([]string, 0, len(r.sinks))
+	for name := range r.sinks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Send delivers a directly to the named sink, bypassing rules, quiet hours,
+// and deduplication. Used by `openusage notify test`.
+func (r *Router) Send(ctx context.Context, sinkName string, a Alert) error {
+	entry, ok := r.sinks[sinkName]
+	if !ok {
+		return fmt.Errorf("unknown or unusable sink %q", sinkName)
+	}
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	return entry.sink.Send(sendCtx, a)
+}
+
+// Describe summarizes the compiled routing for `openusage notify check`.
+func (r *Router) Describe() []string {
+	if r == nil {
+		return nil
+	}
+	var lines []string
+	for _, name := range r.SinkNames() {
+		lines = append(lines, fmt.Sprintf("sink %s: %s and above", name, r.sinks[name].min))
+	}
+	if len(r.defaults) > 0 {
+		lines = append(lines, "default: "+strings.Join(r.defaults, ", "))
+	} else {
+		lines = append(lines, "default: (none, unmatched alerts are dropped)")
+	}
+	for _, rl := range r.rules {
+		match := "everything"
+		switch {
+		case len(rl.accounts) > 0:
+			match = "accounts " + strings.Join(sortedKeys(rl.accounts), ", ")
+			if len(rl.providers) > 0 {
+				match += " (providers " + strings.Join(sortedKeys(rl.providers), ", ") + ")"
+			}
+		case len(rl.providers) > 0:
+			match = "providers " + strings.Join(sortedKeys(rl.providers), ", ")
+		}
+		target := "muted"
+		if len(rl.sinks) > 0 {
+			target = strings.Join(rl.sinks, ", ")
+		}
+		lines = append(lines, fmt.Sprintf("rule %d: %s, %s and above → %s", rl.index+1, match, rl.min, target))
+	}
+	if r.quiet != nil {
+		lines = append(lines, fmt.Sprintf("quiet hours: %02d:%02d–%02d:%02d, only %s gets through",
+			r.quiet.start/60, r.quiet.start%60, r.quiet.end/60, r.quiet.end%60, r.quiet.min))
+	}
+	lines = append(lines, "dedup window: "+r.dedup.String())
+	return lines
+}
+
+func sortedKeys(m map[string]bool) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
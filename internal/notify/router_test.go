@@ -0,0 +1,215 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+)
+
+type recordingSink struct {
+	name string
+	sent *[]string
+	err  error
+}
+
+func (s *recordingSink) Send(_ context.Context, a Alert) error {
+	if s.err != nil {
+		return s.err
+	}
+	*s.sent = append(*s.sent, s.name+":"+a.Key)
+	return nil
+}
+
+func testRouter(t *testing.T, cfg config.NotificationsConfig) (*Router, *[]string) {
+	t.Helper()
+	var sent []string
+	r, errs := newRouter(cfg, func(sc config.NotificationSink) (Sink, error) {
+		if sc.Type == "broken" {
+			return nil, errors.New("broken sink")
+		}
+		return &recordingSink{name: sc.Topic, sent: &sent}, nil
+	})
+	if len(errs) > 0 {
+		t.Fatalf("newRouter() errors: %v", errs)
+	}
+	return r, &sent
+}
+
+func exampleConfig() config.NotificationsConfig {
+	return config.NotificationsConfig{
+		Sinks: map[string]config.NotificationSink{
+			"desktop":   {Type: "desktop", Topic: "desktop", MinSeverity: "info"},
+			"dev-slack": {Type: "slack", Topic: "dev-slack"},
+			"phone":     {Type: "ntfy", Topic: "phone", MinSeverity: "critical"},
+		},
+		DefaultSinks: []string{"desktop"},
+		Rules: []config.NotificationRule{
+			{Providers: []string{"copilot"}, MinSeverity: "warning", Sinks: []string{"dev-slack"}},
+			{Providers: []string{"openrouter"}, Sinks: []string{}},
+			{Accounts: []string{"openrouter-personal"}, MinSeverity: "critical", Sinks: []string{"desktop", "phone"}},
+		},
+	}
+}
+
+func TestRouterTargets(t *testing.T) {
+	r, _ := testRouter(t, exampleConfig())
+	noon := time.Date(2026, 3, 2, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name  string
+		alert Alert
+		want  []string
+	}{
+		{"provider rule", Alert{AccountID: "copilot", ProviderID: "copilot", Severity: SeverityWarning}, []string{"dev-slack"}},
+		{"provider rule below its severity", Alert{AccountID: "copilot", ProviderID: "copilot", Severity: SeverityInfo}, nil},
+		{"account rule beats provider mute", Alert{AccountID: "openrouter-personal", ProviderID: "openrouter", Severity: SeverityCritical}, []string{"desktop", "phone"}},
+		{"account rule drops warnings", Alert{AccountID: "openrouter-personal", ProviderID: "openrouter", Severity: SeverityWarning}, nil},
+		{"provider mute", Alert{AccountID: "openrouter-work", ProviderID: "openrouter", Severity: SeverityCritical}, nil},
+		{"unmatched goes to defaults", Alert{AccountID: "claude", ProviderID: "claude_code", Severity: SeverityInfo}, []string{"desktop"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Targets(tt.alert, noon); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Targets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouterQuietHours(t *testing.T) {
+	cfg := exampleConfig()
+	cfg.QuietHours = &config.NotificationQuietHours{Start: "22:00", End: "07:00"}
+	r, _ := testRouter(t, cfg)
+
+	warning := Alert{AccountID: "claude", ProviderID: "claude_code", Severity: SeverityWarning}
+	critical := Alert{AccountID: "openrouter-personal", ProviderID: "openrouter", Severity: SeverityCritical}
+	night := time.Date(2026, 3, 2, 23, 30, 0, 0, time.Local)
+	morning := time.Date(2026, 3, 3, 7, 0, 0, 0, time.Local)
+
+	if got := r.Targets(warning, night); got != nil {
+		t.Errorf("warning during quiet hours went to %v", got)
+	}
+	if got := r.Targets(critical, night); len(got) != 2 {
+		t.Errorf("critical during quiet hours = %v, want desktop and phone", got)
+	}
+	if got := r.Targets(warning, morning); len(got) != 1 {
+		t.Errorf("warning after quiet hours = %v, want desktop", got)
+	}
+}
+
+func TestRouterDispatchDedup(t *testing.T) {
+	cfg := exampleConfig()
+	cfg.DedupWindow = "30m"
+	r, sent := testRouter(t, cfg)
+	start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.Local)
+	accounts := []string{"claude"}
+	warn := Alert{Key: "claude/status", AccountID: "claude", ProviderID: "claude_code", Severity: SeverityWarning}
+
+	r.Dispatch(context.Background(), []Alert{warn}, accounts, start)
+	r.Dispatch(context.Background(), []Alert{warn}, accounts, start.Add(10*time.Minute))
+	if len(*sent) != 1 {
+		t.Fatalf("repeat within dedup window sent: %v", *sent)
+	}
+
+	crit := warn
+	crit.Severity = SeverityCritical
+	r.Dispatch(context.Background(), []Alert{crit}, accounts, start.Add(15*time.Minute))
+	if len(*sent) != 2 {
+		t.Fatalf("escalation was deduplicated: %v", *sent)
+	}
+
+	r.Dispatch(context.Background(), []Alert{crit}, accounts, start.Add(50*time.Minute))
+	if len(*sent) != 3 {
+		t.Fatalf("repeat after the dedup window was not sent: %v", *sent)
+	}
+
+	// Clearing and re-raising the condition notifies again at once.
+	r.Dispatch(context.Background(), nil, accounts, start.Add(51*time.Minute))
+	r.Dispatch(context.Background(), []Alert{crit}, accounts, start.Add(52*time.Minute))
+	if len(*sent) != 4 {
+		t.Fatalf("re-raised alert was deduplicated: %v", *sent)
+	}
+}
+
+func TestRouterDispatchRetriesFailedSends(t *testing.T) {
+	var sent []string
+	failing := &recordingSink{name: "desktop", sent: &sent, err: errors.New("offline")}
+	r, errs := newRouter(config.NotificationsConfig{
+		Sinks:        map[string]config.NotificationSink{"desktop": {Type: "desktop"}},
+		DefaultSinks: []string{"desktop"},
+	}, func(config.NotificationSink) (Sink, error) { return failing, nil })
+	if len(errs) > 0 {
+		t.Fatalf("newRouter() errors: %v", errs)
+	}
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.Local)
+	a := Alert{Key: "claude/status", AccountID: "claude", Severity: SeverityWarning}
+
+	if d := r.Dispatch(context.Background(), []Alert{a}, []string{"claude"}, now); len(d) != 1 || d[0].Err == nil {
+		t.Fatalf("Dispatch() = %+v, want one failed delivery", d)
+	}
+	failing.err = nil
+	r.Dispatch(context.Background(), []Alert{a}, []string{"claude"}, now.Add(time.Minute))
+	if len(sent) != 1 {
+		t.Errorf("failed alert was not retried: %v", sent)
+	}
+}
+
+func TestNewRouterReportsProblems(t *testing.T) {
+	cfg := config.NotificationsConfig{
+		Sinks: map[string]config.NotificationSink{
+			"ok":     {Type: "desktop"},
+			"bad":    {Type: "broken"},
+			"loud":   {Type: "desktop", MinSeverity: "extreme"},
+			"second": {Type: "desktop"},
+		},
+		DefaultSinks: []string{"ok", "missing"},
+		Rules: []config.NotificationRule{
+			{Providers: []string{"copilot"}, Sinks: []string{"bad"}},
+			{Providers: []string{"cursor"}, MinSeverity: "loud", Sinks: []string{"ok"}},
+			{Accounts: []string{"a"}, Sinks: []string{"ok", "nope"}},
+		},
+		QuietHours:  &config.NotificationQuietHours{Start: "25:00", End: "07:00"},
+		DedupWindow: "soon",
+	}
+	r, errs := newRouter(cfg, func(sc config.NotificationSink) (Sink, error) {
+		if sc.Type == "broken" {
+			return nil, errors.New("broken sink")
+		}
+		return &recordingSink{sent: new([]string)}, nil
+	})
+
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	joined := strings.Join(msgs, "\n")
+	for _, want := range []string{
+		`sink "bad": broken sink`,
+		`sink "loud": unknown severity "extreme"`,
+		`default_sinks: unknown or unusable sink "missing"`,
+		"rule 1: dropped, no usable sinks",
+		`rule 2: unknown severity "loud"`,
+		`rule 3: unknown or unusable sink "nope"`,
+		"quiet_hours: start: invalid time",
+		`dedup_window: invalid duration "soon"`,
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("errors missing %q:\n%s", want, joined)
+		}
+	}
+
+	if !reflect.DeepEqual(r.SinkNames(), []string{"ok", "second"}) {
+		t.Errorf("SinkNames() = %v", r.SinkNames())
+	}
+	if len(r.rules) != 1 || !reflect.DeepEqual(r.rules[0].sinks, []string{"ok"}) {
+		t.Errorf("rules = %+v, want only rule 3 with sink ok", r.rules)
+	}
+	if r.quiet != nil || r.dedup != defaultDedupWindow {
+		t.Errorf("invalid quiet hours/dedup window were applied: %+v %s", r.quiet, r.dedup)
+	}
+}
@@ -0,0 +1,345 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+)
+
+const (
+	SinkSlack    = "slack"
+	SinkWebhook  = "webhook"
+	SinkNtfy     = "ntfy"
+	SinkPushover = "pushover"
+	SinkTelegram = "telegram"
+	SinkDesktop  = "desktop"
+
+	sinkTypes = "slack, webhook, ntfy, pushover, telegram, or desktop"
+
+	defaultNtfyServer     = "https://ntfy.sh"
+	defaultTelegramServer = "https://api.telegram.org"
+	pushoverMessagesURL   = "https://api.pushover.net/1/messages.json"
+	sendTimeout           = 10 * time.Second
+)
+
+// Sink delivers alerts to one destination.
+type Sink interface {
+	Send(ctx context.Context, a Alert) error
+}
+
+// commandRunner runs a local command; tests replace it.
+type commandRunner func(ctx context.Context, name string, args ...string) error
+
+func runCommand(ctx context.Context, name string, args ...string) error {
+	return exec.CommandContext(ctx, name, args...).Run()
+}
+
+// NewSink builds the sink described by cfg. Secrets are read from the
+// environment now, so a missing webhook or token fails at startup rather
+// than on the first alert.
+func NewSink(cfg config.NotificationSink) (Sink, error) {
+	client := &http.Client{Timeout: sendTimeout}
+	kind := strings.ToLower(strings.TrimSpace(cfg.Type))
+	switch kind {
+	case SinkSlack:
+		url, err := requiredEnv(kind, "webhook_env", cfg.WebhookEnv)
+		if err != nil {
+			return nil, err
+		}
+		return &slackSink{webhookURL: url, channel: strings.TrimSpace(cfg.Channel), http: client}, nil
+	case SinkWebhook:
+		url, err := requiredEnv(kind, "webhook_env", cfg.WebhookEnv)
+		if err != nil {
+			return nil, err
+		}
+		return &webhookSink{url: url, http: client}, nil
+	case SinkNtfy:
+		topic := strings.Trim(strings.TrimSpace(cfg.Topic), "/")
+		if topic == "" {
+			return nil, fmt.Errorf("ntfy sink needs a topic")
+		}
+		server := strings.TrimRight(strings.TrimSpace(cfg.Server), "/")
+		if server == "" {
+			server = defaultNtfyServer
+		}
+		token := ""
+		if env := strings.TrimSpace(cfg.TokenEnv); env != "" {
+			if token = strings.TrimSpace(os.Getenv(env)); token == "" {
+				return nil, fmt.Errorf("ntfy sink: %s is not set", env)
+			}
+		}
+		return &ntfySink{url: server + "/" + topic, token: token, http: client}, nil
+	case SinkPushover:
+		token, err := requiredEnv(kind, "token_env", cfg.TokenEnv)
+		if err != nil {
+			return nil, err
+		}
+		user, err := requiredEnv(kind, "user_env", cfg.UserEnv)
+		if err != nil {
+			return nil, err
+		}
+		return &pushoverSink{url: pushoverMessagesURL, token: token, user: user, http: client}, nil
+	case SinkTelegram:
+		token, err := requiredEnv(kind, "token_env", cfg.TokenEnv)
+		if err != nil {
+			return nil, err
+		}
+		chatID := strings.TrimSpace(cfg.ChatID)
+		if chatID == "" {
+			return nil, fmt.Errorf("telegram sink needs chat_id")
+		}
+		server := strings.TrimRight(strings.TrimSpace(cfg.Server), "/")
+		if server == "" {
+			server = defaultTelegramServer
+		}
+		return &telegramSink{url: server + "/bot" + token + "/sendMessage", chatID: chatID, http: client}, nil
+	case SinkDesktop:
+		return &desktopSink{goos: runtime.GOOS, run: runCommand}, nil
+	case "":
+		return nil, fmt.Errorf("sink type is required (%s)", sinkTypes)
+	default:
+		return nil, fmt.Errorf("unknown sink type %q (want %s)", cfg.Type, sinkTypes)
+	}
+}
+
+// requiredEnv resolves the secret named by a sink's *_env field.
+func requiredEnv(kind, field, env string) (string, error) {
+	env = strings.TrimSpace(env)
+	if env == "" {
+		return "", fmt.Errorf("%s sink needs %s", kind, field)
+	}
+	value := strings.TrimSpace(os.Getenv(env))
+	if value == "" {
+		return "", fmt.Errorf("%s sink: %s is not set", kind, env)
+	}
+	return value, nil
+}
+
+type slackSink struct {
+	webhookURL string
+	channel    string
+	http       *http.Client
+}
+
+var slackEmoji = map[Severity]string{
+	SeverityInfo:     ":information_source:",
+	SeverityWarning:  ":warning:",
+	SeverityCritical: ":rotating_light:",
+}
+
+func (s *slackSink) Send(ctx context.Context, a Alert) error {
+	text := slackEmoji[a.Severity] + " *" + a.Title + "*"
+	if a.Body != "" {
+		text += "\n" + a.Body
+	}
+	payload := map[string]string{"text": text}
+	if s.channel != "" {
+		payload["channel"] = s.channel
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("slack: creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return doSend(s.http, req, "slack")
+}
+
+// webhookSink posts the alert as JSON to an arbitrary URL, for Discord-style
+// relays, Home Assistant, or a user's own script.
+type webhookSink struct {
+	url  string
+	http *http.Client
+}
+
+type webhookPayload struct {
+	Key        string    `json:"key"`
+	AccountID  string    `json:"account_id"`
+	ProviderID string    `json:"provider_id,omitempty"`
+	Severity   string    `json:"severity"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body,omitempty"`
+	At         time.Time `json:"at"`
+}
+
+func (s *webhookSink) Send(ctx context.Context, a Alert) error {
+	body, err := json.Marshal(webhookPayload{
+		Key:        a.Key,
+		AccountID:  a.AccountID,
+		ProviderID: a.ProviderID,
+		Severity:   a.Severity.String(),
+		Title:      a.Title,
+		Body:       a.Body,
+		At:         a.At.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook: creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return doSend(s.http, req, "webhook")
+}
+
+type ntfySink struct {
+	url   string
+	token string
+	http  *http.Client
+}
+
+// ntfyPriority maps severities onto ntfy's 1–5 priority scale.
+var ntfyPriority = map[Severity]string{
+	SeverityInfo:     "2",
+	SeverityWarning:  "3",
+	SeverityCritical: "5",
+}
+
+func (s *ntfySink) Send(ctx context.Context, a Alert) error {
+	body := a.Body
+	if body == "" {
+		body = a.Title
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, strings.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("ntfy: creating request: %w", err)
+	}
+	req.Header.Set("Title", a.Title)
+	req.Header.Set("Priority", ntfyPriority[a.Severity])
+	req.Header.Set("Tags", a.Severity.String())
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	return doSend(s.http, req, "ntfy")
+}
+
+type pushoverSink struct {
+	url   string
+	token string
+	user  string
+	http  *http.Client
+}
+
+// pushoverPriority maps severities onto Pushover priorities. Emergency (2)
+// is avoided: it repeats until acknowledged.
+var pushoverPriority = map[Severity]string{
+	SeverityInfo:     "-1",
+	SeverityWarning:  "0",
+	SeverityCritical: "1",
+}
+
+func (s *pushoverSink) Send(ctx context.Context, a Alert) error {
+	message := a.Body
+	if message == "" {
+		message = a.Title
+	}
+	form := url.Values{
+		"token":    {s.token},
+		"user":     {s.user},
+		"title":    {a.Title},
+		"message":  {message},
+		"priority": {pushoverPriority[a.Severity]},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("pushover: creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doSend(s.http, req, "pushover")
+}
+
+type telegramSink struct {
+	url    string // includes the bot token
+	chatID string
+	http   *http.Client
+}
+
+var telegramEmoji = map[Severity]string{
+	SeverityInfo:     "ℹ️",
+	SeverityWarning:  "⚠️",
+	SeverityCritical: "🚨",
+}
+
+func (s *telegramSink) Send(ctx context.Context, a Alert) error {
+	text := telegramEmoji[a.Severity] + " " + a.Title
+	if a.Body != "" {
+		text += "\n" + a.Body
+	}
+	body, err := json.Marshal(map[string]any{
+		"chat_id":              s.chatID,
+		"text":                 text,
+		"disable_notification": a.Severity == SeverityInfo,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("telegram: creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return doSend(s.http, req, "telegram")
+}
+
+func doSend(client *http.Client, req *http.Request, name string) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		// Drop the URL from the error: webhook URLs and Telegram's bot
+		// path are secrets and errors end up in the daemon log.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("%s: request failed: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("%s: HTTP %d: %s", name, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+type desktopSink struct {
+	goos string
+	run  commandRunner
+}
+
+func (s *desktopSink) Send(ctx context.Context, a Alert) error {
+	switch s.goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(a.Body), appleScriptString(a.Title))
+		return s.run(ctx, "osascript", "-e", script)
+	case "linux", "freebsd", "openbsd", "netbsd":
+		urgency := "normal"
+		switch a.Severity {
+		case SeverityCritical:
+			urgency = "critical"
+		case SeverityInfo:
+			urgency = "low"
+		}
+		return s.run(ctx, "notify-send", "--app-name=openusage", "--urgency="+urgency, a.Title, a.Body)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", s.goos)
+	}
+}
+
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
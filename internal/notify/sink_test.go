@@ -0,0 +1,237 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+)
+
+func TestSlackSink(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding payload: %v", err)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("TEST_SLACK_WEBHOOK", server.URL)
+
+	sink, err := NewSink(config.NotificationSink{Type: "slack", WebhookEnv: "TEST_SLACK_WEBHOOK", Channel: "#dev"})
+	if err != nil {
+		t.Fatalf("NewSink() error: %v", err)
+	}
+	err = sink.Send(context.Background(), Alert{Severity: SeverityWarning, Title: "copilot: near limit", Body: "premium 85%"})
+	if err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+	want := map[string]string{"channel": "#dev", "text": ":warning: *copilot: near limit*\npremium 85%"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("payload = %v, want %v", got, want)
+	}
+}
+
+func TestNtfySink(t *testing.T) {
+	var path, body string
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, header = r.URL.Path, r.Header
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+	t.Setenv("TEST_NTFY_TOKEN", "tk_secret")
+
+	sink, err := NewSink(config.NotificationSink{Type: "ntfy", Server: server.URL + "/", Topic: "my-usage", TokenEnv: "TEST_NTFY_TOKEN"})
+	if err != nil {
+		t.Fatalf("NewSink() error: %v", err)
+	}
+	if err := sink.Send(context.Background(), Alert{Severity: SeverityCritical, Title: "openrouter: limit reached", Body: "credits exhausted"}); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+	if path != "/my-usage" || body != "credits exhausted" {
+		t.Errorf("request = %s %q", path, body)
+	}
+	if header.Get("Title") != "openrouter: limit reached" || header.Get("Priority") != "5" || header.Get("Authorization") != "Bearer tk_secret" {
+		t.Errorf("headers = %v", header)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding payload: %v", err)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("TEST_WEBHOOK", server.URL)
+
+	sink, err := NewSink(config.NotificationSink{Type: "webhook", WebhookEnv: "TEST_WEBHOOK"})
+	if err != nil {
+		t.Fatalf("NewSink() error: %v", err)
+	}
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	a := Alert{Key: "claude/usage_five_hour", AccountID: "claude", ProviderID: "claude_code", Severity: SeverityCritical, Title: "claude: usage_five_hour at 4% remaining", At: at}
+	if err := sink.Send(context.Background(), a); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+	want := map[string]string{
+		"key":         "claude/usage_five_hour",
+		"account_id":  "claude",
+		"provider_id": "claude_code",
+		"severity":    "critical",
+		"title":       "claude: usage_five_hour at 4% remaining",
+		"at":          "2026-03-01T12:00:00Z",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("payload = %v, want %v", got, want)
+	}
+}
+
+func TestPushoverSink(t *testing.T) {
+	var form map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parsing form: %v", err)
+		}
+		form = r.PostForm
+		w.Write([]byte(`{"status":1}`))
+	}))
+	defer server.Close()
+	t.Setenv("TEST_PUSHOVER_TOKEN", "app-token")
+	t.Setenv("TEST_PUSHOVER_USER", "user-key")
+
+	sink, err := NewSink(config.NotificationSink{Type: "pushover", TokenEnv: "TEST_PUSHOVER_TOKEN", UserEnv: "TEST_PUSHOVER_USER"})
+	if err != nil {
+		t.Fatalf("NewSink() error: %v", err)
+	}
+	sink.(*pushoverSink).url = server.URL
+	if err := sink.Send(context.Background(), Alert{Severity: SeverityCritical, Title: "claude: usage_five_hour at 4% remaining"}); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+	want := map[string][]string{
+		"token":    {"app-token"},
+		"user":     {"user-key"},
+		"title":    {"claude: usage_five_hour at 4% remaining"},
+		"message":  {"claude: usage_five_hour at 4% remaining"},
+		"priority": {"1"},
+	}
+	if !reflect.DeepEqual(form, want) {
+		t.Errorf("form = %v, want %v", form, want)
+	}
+}
+
+func TestTelegramSink(t *testing.T) {
+	var path string
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding payload: %v", err)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("TEST_TELEGRAM_TOKEN", "123:abc")
+
+	sink, err := NewSink(config.NotificationSink{Type: "telegram", TokenEnv: "TEST_TELEGRAM_TOKEN", ChatID: "-10042", Server: server.URL})
+	if err != nil {
+		t.Fatalf("NewSink() error: %v", err)
+	}
+	if err := sink.Send(context.Background(), Alert{Severity: SeverityInfo, Title: "gemini: provider maintenance", Body: "scheduled"}); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+	if path != "/bot123:abc/sendMessage" {
+		t.Errorf("path = %q", path)
+	}
+	want := map[string]any{"chat_id": "-10042", "text": "ℹ️ gemini: provider maintenance\nscheduled", "disable_notification": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("payload = %v, want %v", got, want)
+	}
+}
+
+func TestSinkSendHidesSecretURLs(t *testing.T) {
+	t.Setenv("TEST_TELEGRAM_TOKEN", "123:secret")
+	sink, err := NewSink(config.NotificationSink{Type: "telegram", TokenEnv: "TEST_TELEGRAM_TOKEN", ChatID: "1", Server: "http://127.0.0.1:1"})
+	if err != nil {
+		t.Fatalf("NewSink() error: %v", err)
+	}
+	err = sink.Send(context.Background(), Alert{Severity: SeverityWarning, Title: "x"})
+	if err == nil || strings.Contains(err.Error(), "secret") {
+		t.Errorf("Send() error = %v, want a failure without the token", err)
+	}
+}
+
+func TestSinkSendReportsHTTPErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no_service", http.StatusNotFound)
+	}))
+	defer server.Close()
+	t.Setenv("TEST_SLACK_WEBHOOK", server.URL)
+
+	sink, err := NewSink(config.NotificationSink{Type: "slack", WebhookEnv: "TEST_SLACK_WEBHOOK"})
+	if err != nil {
+		t.Fatalf("NewSink() error: %v", err)
+	}
+	err = sink.Send(context.Background(), Alert{Severity: SeverityInfo, Title: "x"})
+	if err == nil || !strings.Contains(err.Error(), "HTTP 404: no_service") {
+		t.Errorf("Send() error = %v, want HTTP 404", err)
+	}
+}
+
+func TestDesktopSink(t *testing.T) {
+	var calls [][]string
+	run := func(_ context.Context, name string, args ...string) error {
+		calls = append(calls, append([]string{name}, args...))
+		return nil
+	}
+	a := Alert{Severity: SeverityCritical, Title: `cursor: "limit" reached`, Body: "spend limit hit"}
+
+	if err := (&desktopSink{goos: "linux", run: run}).Send(context.Background(), a); err != nil {
+		t.Fatalf("linux Send() error: %v", err)
+	}
+	if err := (&desktopSink{goos: "darwin", run: run}).Send(context.Background(), a); err != nil {
+		t.Fatalf("darwin Send() error: %v", err)
+	}
+	want := [][]string{
+		{"notify-send", "--app-name=openusage", "--urgency=critical", `cursor: "limit" reached`, "spend limit hit"},
+		{"osascript", "-e", `display notification "spend limit hit" with title "cursor: \"limit\" reached"`},
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("commands = %q, want %q", calls, want)
+	}
+	if err := (&desktopSink{goos: "plan9", run: run}).Send(context.Background(), a); err == nil {
+		t.Error("unsupported OS did not fail")
+	}
+}
+
+func TestNewSinkValidation(t *testing.T) {
+	t.Setenv("TEST_UNSET_WEBHOOK", "")
+	t.Setenv("TEST_SET_TOKEN", "token")
+	for _, tt := range []struct {
+		cfg  config.NotificationSink
+		want string
+	}{
+		{config.NotificationSink{}, "sink type is required"},
+		{config.NotificationSink{Type: "email"}, `unknown sink type "email"`},
+		{config.NotificationSink{Type: "slack"}, "needs webhook_env"},
+		{config.NotificationSink{Type: "slack", WebhookEnv: "TEST_UNSET_WEBHOOK"}, "TEST_UNSET_WEBHOOK is not set"},
+		{config.NotificationSink{Type: "ntfy"}, "needs a topic"},
+		{config.NotificationSink{Type: "webhook"}, "webhook sink needs webhook_env"},
+		{config.NotificationSink{Type: "pushover", TokenEnv: "TEST_UNSET_WEBHOOK"}, "TEST_UNSET_WEBHOOK is not set"},
+		{config.NotificationSink{Type: "pushover", TokenEnv: "TEST_SET_TOKEN"}, "needs user_env"},
+		{config.NotificationSink{Type: "telegram", TokenEnv: "TEST_SET_TOKEN"}, "needs chat_id"},
+	} {
+		_, err := NewSink(tt.cfg)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("NewSink(%+v) error = %v, want %q", tt.cfg, err, tt.want)
+		}
+	}
+}
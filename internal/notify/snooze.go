@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// snoozeRuleForStatus maps a status to the dashboard snooze rule that
+// acknowledges it, or "" when it can't be snoozed. It matches the TUI: only
+// limit warnings are snoozable, errors and auth failures stay loud.
+func snoozeRuleForStatus(status core.Status) string {
+	switch status {
+	case core.StatusLimited, core.StatusExhausted:
+		return config.SnoozeRuleLimit
+	case core.StatusNearLimit:
+		return config.SnoozeRuleNearLimit
+	default:
+		return ""
+	}
+}
+
+// snoozeRuleForSeverity maps a gauge's severity to its snooze rule.
+func snoozeRuleForSeverity(sev Severity) string {
+	switch sev {
+	case SeverityCritical:
+		return config.SnoozeRuleLimit
+	case SeverityWarning:
+		return config.SnoozeRuleNearLimit
+	default:
+		return ""
+	}
+}
+
+// snoozed reports whether rule is acknowledged for accountID at now. As on
+// the dashboard, a snooze only covers the rule it was set for.
+func snoozed(snoozes []config.WarningSnooze, accountID, rule string, now time.Time) bool {
+	if rule == "" {
+		return false
+	}
+	for _, sn := range snoozes {
+		if sn.AccountID == accountID && sn.Rule == rule && sn.Until.After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// DropSnoozedAlerts removes the alerts the user has acknowledged on the
+// dashboard. Dropped alerts don't reach the router, so they fire again once
+// the snooze ends.
+func DropSnoozedAlerts(alerts []Alert, snoozes []config.WarningSnooze, now time.Time) []Alert {
+	if len(snoozes) == 0 {
+		return alerts
+	}
+	out := alerts[:0:0]
+	for _, a := range alerts {
+		if !snoozed(snoozes, a.AccountID, a.SnoozeRule, now) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// DropSnoozedEvents removes threshold and status_change events whose warning
+// the user has acknowledged. Resets always pass.
+func DropSnoozedEvents(events []Event, snoozes []config.WarningSnooze, now time.Time) []Event {
+	if len(snoozes) == 0 {
+		return events
+	}
+	out := events[:0:0]
+	for _, e := range events {
+		var rule string
+		switch e.Type {
+		case EventStatusChange:
+			rule = snoozeRuleForStatus(core.Status(e.Status))
+		case EventThreshold:
+			sev, _ := ParseSeverity(e.Severity, 0)
+			rule = snoozeRuleForSeverity(sev)
+		}
+		if !snoozed(snoozes, e.AccountID, rule, now) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestDropSnoozedAlerts(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	snaps := map[string]core.UsageSnapshot{
+		"claude": {
+			AccountID: "claude", Status: core.StatusLimited,
+			Metrics: map[string]core.Metric{
+				"usage_seven_day": {Used: f(100), Unit: "%", Window: "7d"},
+				"usage_five_hour": {Used: f(85), Unit: "%", Window: "5h"},
+			},
+		},
+		"copilot": {AccountID: "copilot", Status: core.StatusAuth},
+	}
+	alerts := Evaluate(snaps, Thresholds{Warn: 0.20, Crit: 0.05}, now)
+	if len(alerts) != 4 {
+		t.Fatalf("Evaluate() = %+v, want 4 alerts", alerts)
+	}
+
+	snoozes := []config.WarningSnooze{
+		{AccountID: "claude", Rule: config.SnoozeRuleLimit, Until: now.Add(time.Hour)},
+		{AccountID: "copilot", Rule: config.SnoozeRuleLimit, Until: now.Add(time.Hour)},
+	}
+	got := DropSnoozedAlerts(alerts, snoozes, now)
+	if len(got) != 2 || got[0].Key != "claude/usage_five_hour" || got[1].Key != "copilot/status" {
+		t.Fatalf("alerts after snooze = %+v, want the near-limit gauge and the auth alert", got)
+	}
+
+	if got := DropSnoozedAlerts(alerts, snoozes, now.Add(2*time.Hour)); len(got) != len(alerts) {
+		t.Fatalf("expired snooze dropped %d alerts", len(alerts)-len(got))
+	}
+}
+
+func TestDropSnoozedEvents(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	events := []Event{
+		{Type: EventStatusChange, AccountID: "claude", Status: string(core.StatusLimited)},
+		{Type: EventThreshold, AccountID: "claude", Metric: "usage_five_hour", Severity: "warning"},
+		{Type: EventReset, AccountID: "claude", Metric: "usage_seven_day"},
+		{Type: EventThreshold, AccountID: "openai", Metric: "spend", Severity: "critical"},
+	}
+	snoozes := []config.WarningSnooze{{AccountID: "claude", Rule: config.SnoozeRuleNearLimit, Until: now.Add(time.Hour)}}
+
+	got := DropSnoozedEvents(events, snoozes, now)
+	if len(got) != 3 || got[0].Type != EventStatusChange || got[1].Type != EventReset || got[2].AccountID != "openai" {
+		t.Fatalf("events after snooze = %+v, want the limit change, the reset and openai's threshold", got)
+	}
+}
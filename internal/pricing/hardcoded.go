@@ -5,8 +5,9 @@ import "time"
 // hardcodedTable is the last-resort fallback when both LiteLLM and
 // OpenRouter are unreachable / unparseable. It intentionally covers only
 // the ~20 most-used models across Anthropic, OpenAI, Google, DeepSeek and
-// popular OSS lineups. Rates are USD per 1,000,000 tokens, captured from
-// each vendor's public pricing pages.
+// popular OSS lineups, plus the common embedding, image and transcription
+// models. Token rates are USD per 1,000,000 tokens, captured from each
+// vendor's public pricing pages.
 //
 // When upstream pricing changes, prefer the dynamic fetchers; this table
 // exists so the dashboard keeps producing reasonable cost numbers when
@@ -186,6 +187,57 @@ var hardcodedTable = map[string]Price{
 		OutputCostPerMillion: 0.90,
 		ContextWindow:        128_000,
 	},
+	"text-embedding-3-small": {
+		ModelID:             "text-embedding-3-small",
+		Provider:            "openai",
+		Mode:                ModeEmbedding,
+		InputCostPerMillion: 0.02,
+		ContextWindow:       8_191,
+	},
+	"text-embedding-3-large": {
+		ModelID:             "text-embedding-3-large",
+		Provider:            "openai",
+		Mode:                ModeEmbedding,
+		InputCostPerMillion: 0.13,
+		ContextWindow:       8_191,
+	},
+	"gemini-embedding-001": {
+		ModelID:             "gemini-embedding-001",
+		Provider:            "google",
+		Mode:                ModeEmbedding,
+		InputCostPerMillion: 0.15,
+		ContextWindow:       2_048,
+	},
+	"dall-e-3": {
+		ModelID:      "dall-e-3",
+		Provider:     "openai",
+		Mode:         ModeImageGeneration,
+		CostPerImage: 0.04,
+	},
+	"imagen-3-0-generate-002": {
+		ModelID:      "imagen-3.0-generate-002",
+		Provider:     "google",
+		Mode:         ModeImageGeneration,
+		CostPerImage: 0.03,
+	},
+	"imagen-4-0-generate-001": {
+		ModelID:      "imagen-4.0-generate-001",
+		Provider:     "google",
+		Mode:         ModeImageGeneration,
+		CostPerImage: 0.04,
+	},
+	"grok-2-image-1212": {
+		ModelID:      "grok-2-image-1212",
+		Provider:     "xai",
+		Mode:         ModeImageGeneration,
+		CostPerImage: 0.07,
+	},
+	"whisper-1": {
+		ModelID:            "whisper-1",
+		Provider:           "openai",
+		Mode:               ModeAudioTranscription,
+		AudioCostPerMinute: 0.006,
+	},
 }
 
 // hardcodedRevision is the date the hardcoded prices above were last
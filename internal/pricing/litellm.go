@@ -35,9 +35,13 @@ type liteLLMRaw struct {
 	OutputCostPerTokenAbove256kTokens    *float64 `json:"output_cost_per_token_above_256k_tokens,omitempty"`
 	InputCostPerTokenAbove272kTokens     *float64 `json:"input_cost_per_token_above_272k_tokens,omitempty"`
 	OutputCostPerTokenAbove272kTokens    *float64 `json:"output_cost_per_token_above_272k_tokens,omitempty"`
+	OutputCostPerImage                   *float64 `json:"output_cost_per_image,omitempty"`
+	InputCostPerSecond                   *float64 `json:"input_cost_per_second,omitempty"`
 	MaxInputTokens                       *int     `json:"max_input_tokens,omitempty"`
 	MaxTokens                            *int     `json:"max_tokens,omitempty"`
 	LiteLLMProvider                      string   `json:"litellm_provider,omitempty"`
+	DeprecationDate                      string   `json:"deprecation_date,omitempty"`
+	Mode                                 string   `json:"mode,omitempty"`
 }
 
 // LiteLLMFetcher fetches and parses the LiteLLM pricing table.
@@ -142,7 +146,8 @@ func (f *LiteLLMFetcher) doOnce(ctx context.Context, client *http.Client) ([]byt
 //
 // LiteLLM ships a `"sample_spec"` documentation entry at the top of the
 // file that has no real pricing -- we filter it out alongside any entry
-// missing both base input and output rates.
+// with no rate at all. Image and audio models often publish only a
+// per-image or per-second rate, so those count too.
 func ParseLiteLLM(data []byte) (map[string]Price, error) {
 	if len(data) == 0 {
 		return nil, errors.New("pricing: empty litellm payload")
@@ -163,14 +168,20 @@ func ParseLiteLLM(data []byte) (map[string]Price, error) {
 			// the whole table.
 			continue
 		}
-		if entry.InputCostPerToken == nil && entry.OutputCostPerToken == nil {
+		if entry.InputCostPerToken == nil && entry.OutputCostPerToken == nil &&
+			entry.OutputCostPerImage == nil && entry.InputCostPerSecond == nil {
 			continue
 		}
 		p := Price{
-			ModelID:     id,
-			Provider:    entry.LiteLLMProvider,
-			Source:      SourceLiteLLM,
-			LastUpdated: now,
+			ModelID:        id,
+			Provider:       entry.LiteLLMProvider,
+			Source:         SourceLiteLLM,
+			LastUpdated:    now,
+			RetirementDate: parseRetirementDate(entry.DeprecationDate),
+			Mode:           entry.Mode,
+		}
+		if entry.Mode == "chat" || entry.Mode == "completion" {
+			p.Mode = ""
 		}
 		if entry.MaxInputTokens != nil {
 			p.ContextWindow = *entry.MaxInputTokens
@@ -192,6 +203,12 @@ func ParseLiteLLM(data []byte) (map[string]Price, error) {
 		if entry.OutputCostPerReasoningToken != nil {
 			p.ReasoningCostPerMillion = *entry.OutputCostPerReasoningToken * 1_000_000
 		}
+		if entry.OutputCostPerImage != nil {
+			p.CostPerImage = *entry.OutputCostPerImage
+		}
+		if entry.InputCostPerSecond != nil {
+			p.AudioCostPerMinute = *entry.InputCostPerSecond * 60
+		}
 		p.Tiers = liteLLMTiers(entry)
 		out[id] = p
 	}
@@ -2,6 +2,7 @@ package pricing
 
 import (
 	"context"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -74,6 +75,25 @@ func TestParseLiteLLM_Golden(t *testing.T) {
 	if gem.Tiers.Above128k == nil || *gem.Tiers.Above128k.InputCostPerMillion != 2.5 {
 		t.Errorf("gemini above-128k tier missing or wrong: %+v", gem.Tiers.Above128k)
 	}
+
+	// Image and audio models publish no token rates but are still priced.
+	grok, ok := table["xai/grok-2-image-1212"]
+	if !ok {
+		t.Fatalf("expected per-image entry to be kept")
+	}
+	if grok.Mode != ModeImageGeneration || grok.CostPerImage != 0.07 {
+		t.Errorf("grok-2-image = mode %q, %v per image; want image_generation, 0.07", grok.Mode, grok.CostPerImage)
+	}
+	whisper, ok := table["whisper-1"]
+	if !ok {
+		t.Fatalf("expected per-second audio entry to be kept")
+	}
+	if math.Abs(whisper.AudioCostPerMinute-0.006) > 1e-12 {
+		t.Errorf("whisper-1 audio = %v per minute, want 0.006", whisper.AudioCostPerMinute)
+	}
+	if sonnet.Mode != "" {
+		t.Errorf("chat model mode = %q, want empty", sonnet.Mode)
+	}
 }
 
 func TestLiteLLMFetcher_RetriesOn5xxAnd429(t *testing.T) {
@@ -27,12 +27,26 @@ type Resolver struct {
 
 	overrides customOverridesCache
 
+	// liteLLMLoadMu / openRouterLoadMu serialise the first load of each
+	// upstream table, so accounts fetched concurrently wait for a single
+	// download instead of each pulling the full catalog.
+	liteLLMLoadMu    sync.Mutex
+	openRouterLoadMu sync.Mutex
+
 	mu             sync.Mutex
 	liteLLMTable   map[string]Price
 	openRouter     map[string]Price
 	liteLLMLoaded  bool
 	openRouterDone bool
 
+	// liteLLMFileMTime / openRouterFileMTime are the cache files' mtimes
+	// when each table was loaded, so ReloadIfChanged can spot a catalog
+	// another process refreshed. catalogVersion memoises Catalog's
+	// fingerprint until a table changes.
+	liteLLMFileMTime    time.Time
+	openRouterFileMTime time.Time
+	catalogVersion      string
+
 	// liteLLMKeysCache and openRouterKeysCache hold the (model-key list,
 	// normalized-key index) pair for each upstream so bestFuzzyMatch does
 	// not rebuild the index on every Lookup. A typical hot caller burns
@@ -58,7 +72,7 @@ type lookupCacheKey struct {
 // or NewResolver pick the table up from disk on first Lookup.
 func WithCustomOverrides(table map[string]Price) ResolverOption {
 	return func(r *Resolver) {
-		r.overrides.once.Do(func() { r.overrides.loaded = table })
+		r.overrides.seed(table)
 	}
 }
 
@@ -200,13 +214,14 @@ func (r *Resolver) fuzzyIndexFor(table map[string]Price, slot **fuzzyKeyIndex) *
 }
 
 func (r *Resolver) loadLiteLLM(ctx context.Context) (map[string]Price, error) {
-	r.mu.Lock()
-	if r.liteLLMLoaded {
-		t := r.liteLLMTable
-		r.mu.Unlock()
+	if t, ok := r.loadedLiteLLM(); ok {
+		return t, nil
+	}
+	r.liteLLMLoadMu.Lock()
+	defer r.liteLLMLoadMu.Unlock()
+	if t, ok := r.loadedLiteLLM(); ok {
 		return t, nil
 	}
-	r.mu.Unlock()
 
 	// fresh cache hit?
 	if data, mtime, fresh, err := r.cache.Load(litellmCacheName); err == nil && fresh && len(data) > 0 {
@@ -237,13 +252,14 @@ func (r *Resolver) loadLiteLLM(ctx context.Context) (map[string]Price, error) {
 }
 
 func (r *Resolver) loadOpenRouter(ctx context.Context) (map[string]Price, error) {
-	r.mu.Lock()
-	if r.openRouterDone {
-		t := r.openRouter
-		r.mu.Unlock()
+	if t, ok := r.loadedOpenRouter(); ok {
+		return t, nil
+	}
+	r.openRouterLoadMu.Lock()
+	defer r.openRouterLoadMu.Unlock()
+	if t, ok := r.loadedOpenRouter(); ok {
 		return t, nil
 	}
-	r.mu.Unlock()
 
 	if data, mtime, fresh, err := r.cache.Load(openrouterCacheName); err == nil && fresh && len(data) > 0 {
 		if table, perr := ParseOpenRouter(data); perr == nil {
@@ -271,6 +287,18 @@ func (r *Resolver) loadOpenRouter(ctx context.Context) (map[string]Price, error)
 	return table, nil
 }
 
+func (r *Resolver) loadedLiteLLM() (map[string]Price, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.liteLLMTable, r.liteLLMLoaded
+}
+
+func (r *Resolver) loadedOpenRouter() (map[string]Price, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.openRouter, r.openRouterDone
+}
+
 func (r *Resolver) storeLiteLLM(t map[string]Price, mtime time.Time) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -278,6 +306,8 @@ func (r *Resolver) storeLiteLLM(t map[string]Price, mtime time.Time) {
 	r.liteLLMLoaded = true
 	r.liteLLMKeysCache = nil
 	r.lookupCache = nil
+	r.liteLLMFileMTime = r.cacheFileMTime(litellmCacheName)
+	r.catalogVersion = ""
 	if !mtime.IsZero() {
 		for k, p := range t {
 			p.LastUpdated = mtime
@@ -293,6 +323,8 @@ func (r *Resolver) storeOpenRouter(t map[string]Price, mtime time.Time) {
 	r.openRouterDone = true
 	r.openRouterKeysCache = nil
 	r.lookupCache = nil
+	r.openRouterFileMTime = r.cacheFileMTime(openrouterCacheName)
+	r.catalogVersion = ""
 	if !mtime.IsZero() {
 		for k, p := range t {
 			p.LastUpdated = mtime
@@ -307,12 +339,18 @@ func (r *Resolver) storeOpenRouter(t map[string]Price, mtime time.Time) {
 //
 // If price is nil this returns 0 (so callers can chain Lookup -> EstimateCost
 // without a nil check for fall-through fallback paths).
+//
+// Embedding requests are InputTokens against the embedding model's price.
+// Images and AudioMinutes are billed at the price's per-image and
+// per-minute rates.
 type Usage struct {
 	InputTokens      int
 	OutputTokens     int
 	CacheReadTokens  int
 	CacheWriteTokens int
 	ReasoningTokens  int
+	Images           int
+	AudioMinutes     float64
 }
 
 // Estimate returns the projected cost in USD for a single usage record at
@@ -337,9 +375,24 @@ func Estimate(price *Price, contextLen int, u Usage) float64 {
 		}
 		cost += float64(u.ReasoningTokens) * rate / 1_000_000
 	}
+	cost += float64(u.Images) * p.CostPerImage
+	cost += u.AudioMinutes * p.AudioCostPerMinute
 	return cost
 }
 
+// CacheSavings returns what prompt caching saved on u at price: Estimate
+// with the cache reads and writes billed as fresh input, minus Estimate of u.
+// It is negative when cache-write premiums outweigh the discounted reads.
+func CacheSavings(price *Price, contextLen int, u Usage) float64 {
+	if price == nil || u.CacheReadTokens+u.CacheWriteTokens == 0 {
+		return 0
+	}
+	uncached := u
+	uncached.InputTokens += u.CacheReadTokens + u.CacheWriteTokens
+	uncached.CacheReadTokens, uncached.CacheWriteTokens = 0, 0
+	return Estimate(price, contextLen, uncached) - Estimate(price, contextLen, u)
+}
+
 // DefaultResolver returns a process-wide lazy Resolver singleton. The
 // first call constructs the resolver; subsequent calls reuse it. On
 // construction failure (e.g. no writable cache dir), Lookups still work
@@ -2,9 +2,11 @@ package pricing
 
 import (
 	"context"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -87,6 +89,24 @@ func TestLookup_CacheHitSkipsNetworkOnSecondCall(t *testing.T) {
 	}
 }
 
+func TestLookup_ConcurrentCallersShareOneDownload(t *testing.T) {
+	r, litellmHits, _ := newTestResolver(t, "litellm_subset.json", "openrouter_subset.json")
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := r.Lookup(context.Background(), "gpt-4o", 0); err != nil {
+				t.Errorf("Lookup: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := atomic.LoadInt32(litellmHits); got != 1 {
+		t.Errorf("expected concurrent lookups to share one download, got %d", got)
+	}
+}
+
 func TestLookup_FallsThroughToOpenRouter(t *testing.T) {
 	// LiteLLM fixture omits gemini-2.0-flash; OpenRouter has it.
 	r, litellmHits, openrouterHits := newTestResolver(t, "litellm_subset.json", "openrouter_subset.json")
@@ -167,6 +187,55 @@ func TestEstimate(t *testing.T) {
 	}
 }
 
+func TestEstimate_Multimodal(t *testing.T) {
+	embedding := &Price{Mode: ModeEmbedding, InputCostPerMillion: 0.02}
+	if got := Estimate(embedding, 0, Usage{InputTokens: 50_000_000}); math.Abs(got-1) > 1e-9 {
+		t.Errorf("embedding Estimate = %v, want 1", got)
+	}
+	image := &Price{Mode: ModeImageGeneration, CostPerImage: 0.04}
+	if got := Estimate(image, 0, Usage{Images: 25}); math.Abs(got-1) > 1e-9 {
+		t.Errorf("image Estimate = %v, want 1", got)
+	}
+	audio := &Price{Mode: ModeAudioTranscription, AudioCostPerMinute: 0.006}
+	if got := Estimate(audio, 0, Usage{AudioMinutes: 90}); math.Abs(got-0.54) > 1e-9 {
+		t.Errorf("audio Estimate = %v, want 0.54", got)
+	}
+}
+
+func TestLookupHardcoded_MultimodalModels(t *testing.T) {
+	for model, check := range map[string]func(Price) bool{
+		"text-embedding-3-small":  func(p Price) bool { return p.Mode == ModeEmbedding && p.InputCostPerMillion == 0.02 },
+		"dall-e-3":                func(p Price) bool { return p.Mode == ModeImageGeneration && p.CostPerImage == 0.04 },
+		"imagen-4.0-generate-001": func(p Price) bool { return p.Provider == "google" && p.CostPerImage == 0.04 },
+		"grok-2-image-1212":       func(p Price) bool { return p.Provider == "xai" && p.CostPerImage == 0.07 },
+		"whisper-1":               func(p Price) bool { return p.AudioCostPerMinute == 0.006 },
+	} {
+		p, ok := lookupHardcoded(model)
+		if !ok {
+			t.Errorf("lookupHardcoded(%q) found nothing", model)
+			continue
+		}
+		if !check(p) {
+			t.Errorf("lookupHardcoded(%q) = %+v", model, p)
+		}
+	}
+}
+
+func TestCacheSavings(t *testing.T) {
+	p := &Price{InputCostPerMillion: 3, OutputCostPerMillion: 15, CacheReadCostPerMillion: 0.3, CacheWriteCostPerMillion: 3.75}
+	got := CacheSavings(p, 0, Usage{InputTokens: 100_000, OutputTokens: 10_000, CacheReadTokens: 1_000_000, CacheWriteTokens: 200_000})
+	// reads save 1M * (3 - 0.3) / 1M = 2.7; writes cost 200k * (3.75 - 3) / 1M = 0.15 extra
+	if want := 2.7 - 0.15; math.Abs(got-want) > 1e-9 {
+		t.Errorf("CacheSavings = %v, want %v", got, want)
+	}
+	if got := CacheSavings(p, 0, Usage{CacheWriteTokens: 100_000}); got >= 0 {
+		t.Errorf("cold cache savings = %v, want negative", got)
+	}
+	if CacheSavings(p, 0, Usage{InputTokens: 1_000}) != 0 || CacheSavings(nil, 0, Usage{CacheReadTokens: 1}) != 0 {
+		t.Errorf("no cache traffic or no price should save nothing")
+	}
+}
+
 func TestLookup_RepeatedQueriesAreCached(t *testing.T) {
 	r, litellmHits, _ := newTestResolver(t, "litellm_subset.json", "openrouter_subset.json")
 
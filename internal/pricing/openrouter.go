@@ -30,6 +30,8 @@ type openRouterModelRaw struct {
 	Name          string               `json:"name,omitempty"`
 	ContextLength int                  `json:"context_length,omitempty"`
 	Pricing       openRouterPricingRaw `json:"pricing"`
+	// ExpirationDate is set on models OpenRouter is about to remove.
+	ExpirationDate string `json:"expiration_date,omitempty"`
 }
 
 type openRouterModelsResponse struct {
@@ -162,6 +164,7 @@ func ParseOpenRouter(data []byte) (map[string]Price, error) {
 			ContextWindow:        m.ContextLength,
 			InputCostPerMillion:  input,
 			OutputCostPerMillion: output,
+			RetirementDate:       parseRetirementDate(m.ExpirationDate),
 		}
 		if cr := parseOpenRouterRate(m.Pricing.InputCacheRead); cr > 0 {
 			price.CacheReadCostPerMillion = cr
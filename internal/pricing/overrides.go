@@ -42,8 +42,13 @@ type overrideEntry struct {
 	CacheReadPerToken   *float64 `json:"cache_read_input_token_cost,omitempty"`
 	CacheCreatePerToken *float64 `json:"cache_creation_input_token_cost,omitempty"`
 	ReasoningPerToken   *float64 `json:"reasoning_cost_per_token,omitempty"`
+	PerImage            *float64 `json:"output_cost_per_image,omitempty"`
+	AudioPerMinute      *float64 `json:"audio_cost_per_minute,omitempty"`
+	AudioPerSecond      *float64 `json:"input_cost_per_second,omitempty"`
+	Mode                string   `json:"mode,omitempty"`
 	ContextWindow       int      `json:"context_window,omitempty"`
 	Provider            string   `json:"provider,omitempty"`
+	DeprecationDate     string   `json:"deprecation_date,omitempty"`
 }
 
 // LoadCustomOverrides parses the user's custom-pricing.json file, if any.
@@ -51,7 +56,7 @@ type overrideEntry struct {
 // (non-finite, negative, or missing both input and output rates) are
 // silently dropped so a single typo cannot poison the table.
 func LoadCustomOverrides() (map[string]Price, error) {
-	path, err := customOverridesPath()
+	path, err := CustomOverridesPath()
 	if err != nil {
 		return nil, err
 	}
@@ -100,16 +105,25 @@ func parseCustomOverrides(raw []byte, ts time.Time) (map[string]Price, error) {
 func (e overrideEntry) toPrice(id string, ts time.Time) (Price, bool) {
 	input, inputOK := resolveRate(e.InputPerM, e.InputPerToken)
 	output, outputOK := resolveRate(e.OutputPerM, e.OutputPerToken)
-	if !inputOK && !outputOK {
+	perImage, audioPerMinute := e.unitRates()
+	// Image and audio models may be priced per unit only, and embedding
+	// models have no output rate; other token models need both token rates.
+	unitPriced := finitePositive(perImage) || finitePositive(audioPerMinute)
+	if !inputOK && !outputOK && !unitPriced {
 		return Price{}, false
 	}
 	cacheRead, _ := resolveRate(e.CacheReadPerM, e.CacheReadPerToken)
 	cacheCreate, _ := resolveRate(e.CacheCreatePerM, e.CacheCreatePerToken)
 	reasoning, _ := resolveRate(e.ReasoningPerM, e.ReasoningPerToken)
 
-	if !finitePositive(input) || !finitePositive(output) ||
+	tokenRateOK := finitePositive
+	if unitPriced || strings.TrimSpace(e.Mode) == ModeEmbedding {
+		tokenRateOK = finiteNonNegative
+	}
+	if !tokenRateOK(input) || !tokenRateOK(output) ||
 		!finiteNonNegative(cacheRead) || !finiteNonNegative(cacheCreate) ||
-		!finiteNonNegative(reasoning) {
+		!finiteNonNegative(reasoning) || !finiteNonNegative(perImage) ||
+		!finiteNonNegative(audioPerMinute) {
 		return Price{}, false
 	}
 
@@ -119,14 +133,33 @@ func (e overrideEntry) toPrice(id string, ts time.Time) (Price, bool) {
 		Source:                   SourceCustom,
 		ContextWindow:            e.ContextWindow,
 		LastUpdated:              ts,
+		RetirementDate:           parseRetirementDate(e.DeprecationDate),
 		InputCostPerMillion:      input,
 		OutputCostPerMillion:     output,
 		CacheReadCostPerMillion:  cacheRead,
 		CacheWriteCostPerMillion: cacheCreate,
 		ReasoningCostPerMillion:  reasoning,
+		Mode:                     strings.TrimSpace(e.Mode),
+		CostPerImage:             perImage,
+		AudioCostPerMinute:       audioPerMinute,
 	}, true
 }
 
+// unitRates returns the per-image and per-minute audio rates, accepting the
+// per-second spelling LiteLLM uses.
+func (e overrideEntry) unitRates() (perImage, audioPerMinute float64) {
+	if e.PerImage != nil {
+		perImage = *e.PerImage
+	}
+	switch {
+	case e.AudioPerMinute != nil:
+		audioPerMinute = *e.AudioPerMinute
+	case e.AudioPerSecond != nil:
+		audioPerMinute = *e.AudioPerSecond * 60
+	}
+	return perImage, audioPerMinute
+}
+
 func resolveRate(perMillion, perToken *float64) (float64, bool) {
 	if perMillion != nil {
 		return *perMillion, true
@@ -145,7 +178,9 @@ func finiteNonNegative(v float64) bool {
 	return v >= 0 && !math.IsNaN(v) && !math.IsInf(v, 0)
 }
 
-func customOverridesPath() (string, error) {
+// CustomOverridesPath resolves custom-pricing.json: OPENUSAGE_CUSTOM_PRICING,
+// then $XDG_CONFIG_HOME/openusage, then the platform config directory.
+func CustomOverridesPath() (string, error) {
 	if env := strings.TrimSpace(os.Getenv("OPENUSAGE_CUSTOM_PRICING")); env != "" {
 		return env, nil
 	}
@@ -156,21 +191,59 @@ func customOverridesPath() (string, error) {
 	return platformCustomOverridesPath()
 }
 
-// loadCustomOverridesOnce wraps LoadCustomOverrides behind a sync.Once
-// guard so each resolver only touches disk for the override file once.
+// customOverridesCache holds the parsed custom-pricing.json so lookups
+// don't touch disk. It remembers the file's mtime so ReloadIfChanged can
+// tell when the user edited it.
 type customOverridesCache struct {
-	once   sync.Once
+	mu     sync.Mutex
+	done   bool
+	seeded bool // set by WithCustomOverrides; never re-read from disk
+	mtime  time.Time
 	loaded map[string]Price
 }
 
 func (c *customOverridesCache) get() map[string]Price {
-	c.once.Do(func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.done {
 		table, _ := LoadCustomOverrides()
-		c.loaded = table
-	})
+		c.loaded, c.mtime, c.done = table, customOverridesMTime(), true
+	}
 	return c.loaded
 }
 
+func (c *customOverridesCache) seed(table map[string]Price) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loaded, c.done, c.seeded = table, true, true
+}
+
+// stale reports whether the file changed since it was read, and if so
+// marks the table for reloading on the next lookup.
+func (c *customOverridesCache) stale() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.done || c.seeded || customOverridesMTime().Equal(c.mtime) {
+		return false
+	}
+	c.done = false
+	return true
+}
+
+// customOverridesMTime is the override file's mtime, zero when it doesn't
+// exist.
+func customOverridesMTime() time.Time {
+	path, err := CustomOverridesPath()
+	if err != nil || path == "" {
+		return time.Time{}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
 // lookupCustomOverride checks the user's overrides for an exact match
 // (case-insensitive) against the raw and normalised model id. Custom
 // overrides bypass fuzzy matching by design — they exist precisely to fix
@@ -42,6 +42,33 @@ func TestParseCustomOverrides_PerMillion(t *testing.T) {
 	}
 }
 
+func TestParseCustomOverrides_UnitPriced(t *testing.T) {
+	raw := []byte(`{
+	  "models": {
+	    "flux-pro": {"output_cost_per_image": 0.05, "mode": "image_generation"},
+	    "my-whisper": {"input_cost_per_second": 0.0002},
+	    "my-embed": {"input_cost_per_million_tokens": 0.1, "mode": "embedding"},
+	    "no-rates": {"mode": "image_generation"}
+	  }
+	}`)
+	table, err := parseCustomOverrides(raw, time.Now())
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if p := table["flux-pro"]; p.CostPerImage != 0.05 || p.Mode != ModeImageGeneration {
+		t.Errorf("flux-pro = %+v, want 0.05 per image", p)
+	}
+	if p := table["my-whisper"]; p.AudioCostPerMinute < 0.01199 || p.AudioCostPerMinute > 0.01201 {
+		t.Errorf("my-whisper audio = %v per minute, want 0.012", p.AudioCostPerMinute)
+	}
+	if p, ok := table["my-embed"]; !ok || p.InputCostPerMillion != 0.1 {
+		t.Errorf("embedding override with no output rate = %+v, %v", p, ok)
+	}
+	if _, ok := table["no-rates"]; ok {
+		t.Error("entry without any rate should be dropped")
+	}
+}
+
 func TestParseCustomOverrides_PerToken(t *testing.T) {
 	raw := []byte(`{
 	  "models": {
@@ -0,0 +1,131 @@
+package pricing
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// RefreshResult reports what a Refresh did to one upstream table.
+type RefreshResult struct {
+	Source Source
+	// Models is the size of the table now in use.
+	Models int
+	// Added, Removed, and Changed compare against the table in use before
+	// the refresh; Changed counts models whose rates moved.
+	Added   int
+	Removed int
+	Changed int
+	// VerifiedAt is when the table now in use was fetched from upstream.
+	// Zero when there is no table at all.
+	VerifiedAt time.Time
+	// Err is the fetch failure, if any. The previous table stays in use.
+	Err error
+}
+
+// upstreamTable wires one upstream source into Refresh.
+type upstreamTable struct {
+	source    Source
+	cacheName string
+	fetch     func(context.Context) (map[string]Price, []byte, error)
+	parse     func([]byte) (map[string]Price, error)
+	current   func() (map[string]Price, bool)
+	store     func(map[string]Price, time.Time)
+}
+
+// Refresh re-fetches the LiteLLM and OpenRouter tables regardless of cache
+// freshness, so a long-running process picks up price changes instead of
+// keeping the table it loaded at startup. Custom overrides are untouched and
+// keep precedence over both upstreams.
+func (r *Resolver) Refresh(ctx context.Context) []RefreshResult {
+	sources := []upstreamTable{
+		{
+			source:    SourceLiteLLM,
+			cacheName: litellmCacheName,
+			fetch:     r.litellm.Fetch,
+			parse:     ParseLiteLLM,
+			current:   r.loadedLiteLLM,
+			store:     r.storeLiteLLM,
+		},
+		{
+			source:    SourceOpenRouter,
+			cacheName: openrouterCacheName,
+			fetch:     r.openrouter.Fetch,
+			parse:     ParseOpenRouter,
+			current:   r.loadedOpenRouter,
+			store:     r.storeOpenRouter,
+		},
+	}
+	out := make([]RefreshResult, 0, len(sources))
+	for _, src := range sources {
+		out = append(out, r.refreshTable(ctx, src))
+	}
+	return out
+}
+
+func (r *Resolver) refreshTable(ctx context.Context, src upstreamTable) RefreshResult {
+	res := RefreshResult{Source: src.source}
+
+	prev, loaded := src.current()
+	var prevVerified time.Time
+	if loaded {
+		prevVerified = tableVerifiedAt(prev)
+	} else if data, mtime, _, err := r.cache.Load(src.cacheName); err == nil && len(data) > 0 {
+		if table, perr := src.parse(data); perr == nil {
+			prev, prevVerified = table, mtime
+		}
+	}
+
+	table, body, err := src.fetch(ctx)
+	if err != nil {
+		res.Err = err
+		res.Models = len(prev)
+		res.VerifiedAt = prevVerified
+		return res
+	}
+	if len(body) > 0 {
+		_ = r.cache.Store(src.cacheName, body)
+	}
+	now := time.Now().UTC()
+	src.store(table, now)
+
+	res.Models = len(table)
+	res.VerifiedAt = now
+	res.Added, res.Removed, res.Changed = diffTables(prev, table)
+	return res
+}
+
+// tableVerifiedAt returns the fetch time stamped on a loaded table.
+func tableVerifiedAt(table map[string]Price) time.Time {
+	for _, p := range table {
+		return p.LastUpdated
+	}
+	return time.Time{}
+}
+
+func diffTables(prev, next map[string]Price) (added, removed, changed int) {
+	for id, p := range next {
+		old, ok := prev[id]
+		switch {
+		case !ok:
+			added++
+		case !sameRates(old, p):
+			changed++
+		}
+	}
+	for id := range prev {
+		if _, ok := next[id]; !ok {
+			removed++
+		}
+	}
+	return added, removed, changed
+}
+
+func sameRates(a, b Price) bool {
+	return a.InputCostPerMillion == b.InputCostPerMillion &&
+		a.OutputCostPerMillion == b.OutputCostPerMillion &&
+		a.CacheReadCostPerMillion == b.CacheReadCostPerMillion &&
+		a.CacheWriteCostPerMillion == b.CacheWriteCostPerMillion &&
+		a.ReasoningCostPerMillion == b.ReasoningCostPerMillion &&
+		reflect.DeepEqual(a.Tiers, b.Tiers)
+}
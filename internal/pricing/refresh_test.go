@@ -0,0 +1,106 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRefresh_PicksUpPriceChanges(t *testing.T) {
+	var prompt atomic.Value
+	prompt.Store("0.000003")
+	openrouterSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"data":[
+			{"id":"acme/fast-1","pricing":{"prompt":%q,"completion":"0.000015"}},
+			{"id":"acme/slow-1","pricing":{"prompt":"0.000001","completion":"0.000002"}}
+		]}`, prompt.Load().(string))
+	}))
+	defer openrouterSrv.Close()
+	litellmSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusBadGateway)
+	}))
+	defer litellmSrv.Close()
+
+	cache := NewDiskCacheAt(t.TempDir())
+	cache.SetTTL(time.Hour)
+	custom := Price{ModelID: "acme/slow-1", Source: SourceCustom, InputCostPerMillion: 9, OutputCostPerMillion: 9}
+	r, err := NewResolver(
+		WithCache(cache),
+		WithLiteLLMFetcher(&LiteLLMFetcher{URL: litellmSrv.URL, Client: litellmSrv.Client(), Retries: 1, Backoff: 1}),
+		WithOpenRouterFetcher(&OpenRouterFetcher{URL: openrouterSrv.URL, Client: openrouterSrv.Client(), Retries: 1, Backoff: 1}),
+		WithCustomOverrides(map[string]Price{"acme/slow-1": custom}),
+	)
+	if err != nil {
+		t.Fatalf("NewResolver: %v", err)
+	}
+
+	ctx := context.Background()
+	p, err := r.Lookup(ctx, "acme/fast-1", 0)
+	if err != nil || p.InputCostPerMillion != 3 {
+		t.Fatalf("initial Lookup = %+v, %v; want input 3", p, err)
+	}
+
+	prompt.Store("0.000004")
+	results := r.Refresh(ctx)
+	if len(results) != 2 {
+		t.Fatalf("Refresh returned %d results, want 2", len(results))
+	}
+	lite, or := results[0], results[1]
+	if lite.Source != SourceLiteLLM || lite.Err == nil || lite.Models != 0 || !lite.VerifiedAt.IsZero() {
+		t.Errorf("litellm result = %+v, want a failure with no table", lite)
+	}
+	if or.Source != SourceOpenRouter || or.Err != nil || or.Models != 2 || or.Changed != 1 || or.Added != 0 || or.Removed != 0 {
+		t.Errorf("openrouter result = %+v, want 2 models with 1 changed", or)
+	}
+	if time.Since(or.VerifiedAt) > time.Minute {
+		t.Errorf("openrouter verified at %s, want now", or.VerifiedAt)
+	}
+
+	p, err = r.Lookup(ctx, "acme/fast-1", 0)
+	if err != nil || p.InputCostPerMillion != 4 || !p.LastUpdated.Equal(or.VerifiedAt) {
+		t.Errorf("Lookup after refresh = %+v, %v; want input 4 verified at %s", p, err, or.VerifiedAt)
+	}
+	if p, _ := r.Lookup(ctx, "acme/slow-1", 0); p == nil || p.Source != SourceCustom {
+		t.Errorf("custom override lost precedence after refresh: %+v", p)
+	}
+}
+
+func TestRefresh_KeepsTableOnFailure(t *testing.T) {
+	var fail atomic.Bool
+	openrouterSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"id":"acme/fast-1","pricing":{"prompt":"0.000003","completion":"0.000015"}}]}`)
+	}))
+	defer openrouterSrv.Close()
+	litellmSrv := httptest.NewServer(http.NotFoundHandler())
+	defer litellmSrv.Close()
+
+	r, err := NewResolver(
+		WithCache(NewDiskCacheAt(t.TempDir())),
+		WithLiteLLMFetcher(&LiteLLMFetcher{URL: litellmSrv.URL, Client: litellmSrv.Client(), Retries: 1, Backoff: 1}),
+		WithOpenRouterFetcher(&OpenRouterFetcher{URL: openrouterSrv.URL, Client: openrouterSrv.Client(), Retries: 1, Backoff: 1}),
+	)
+	if err != nil {
+		t.Fatalf("NewResolver: %v", err)
+	}
+	first := r.Refresh(context.Background())[1]
+	if first.Err != nil || first.Added != 1 {
+		t.Fatalf("first refresh = %+v", first)
+	}
+
+	fail.Store(true)
+	second := r.Refresh(context.Background())[1]
+	if second.Err == nil || second.Models != 1 || !second.VerifiedAt.Equal(first.VerifiedAt) {
+		t.Errorf("failed refresh = %+v, want the previous table kept", second)
+	}
+	if p, err := r.Lookup(context.Background(), "acme/fast-1", 0); err != nil || p.Source != SourceOpenRouter {
+		t.Errorf("Lookup after failed refresh = %+v, %v", p, err)
+	}
+}
@@ -0,0 +1,130 @@
+package pricing
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sort"
+	"time"
+)
+
+// CatalogInfo describes the pricing tables a Resolver is using.
+type CatalogInfo struct {
+	// Version is a short fingerprint of every loaded rate. It changes
+	// when any model's price does, and only then.
+	Version string
+	// UpdatedAt is when the newest loaded upstream table was fetched.
+	UpdatedAt time.Time
+	// Sources lists the upstream tables loaded so far.
+	Sources []Source
+	// Models counts the upstream models; Overrides the custom ones.
+	Models    int
+	Overrides int
+}
+
+// ReloadIfChanged drops the tables whose files changed on disk since they
+// were loaded: the cached upstream catalogs, which `openusage pricing
+// refresh` or another process may have rewritten, and custom-pricing.json.
+// The next Lookup reads them again. It returns the sources that changed,
+// and is cheap enough to call on every poll: a few stats, no reads.
+func (r *Resolver) ReloadIfChanged() []Source {
+	var changed []Source
+	if r.overrides.stale() {
+		changed = append(changed, SourceCustom)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.liteLLMLoaded && !r.cacheFileMTime(litellmCacheName).Equal(r.liteLLMFileMTime) {
+		r.liteLLMTable, r.liteLLMLoaded, r.liteLLMKeysCache = nil, false, nil
+		changed = append(changed, SourceLiteLLM)
+	}
+	if r.openRouterDone && !r.cacheFileMTime(openrouterCacheName).Equal(r.openRouterFileMTime) {
+		r.openRouter, r.openRouterDone, r.openRouterKeysCache = nil, false, nil
+		changed = append(changed, SourceOpenRouter)
+	}
+	if len(changed) > 0 {
+		r.lookupCache = nil
+		r.catalogVersion = ""
+	}
+	return changed
+}
+
+// Catalog describes the tables loaded so far. Upstream tables load on the
+// first Lookup, so a resolver that hasn't priced anything reports none.
+func (r *Resolver) Catalog() CatalogInfo {
+	overrides := r.overrides.get()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	info := CatalogInfo{Overrides: len(overrides)}
+	var tables []map[string]Price
+	if r.liteLLMLoaded && len(r.liteLLMTable) > 0 {
+		info.Sources = append(info.Sources, SourceLiteLLM)
+		tables = append(tables, r.liteLLMTable)
+	}
+	if r.openRouterDone && len(r.openRouter) > 0 {
+		info.Sources = append(info.Sources, SourceOpenRouter)
+		tables = append(tables, r.openRouter)
+	}
+	for _, table := range tables {
+		info.Models += len(table)
+		if at := tableVerifiedAt(table); at.After(info.UpdatedAt) {
+			info.UpdatedAt = at
+		}
+	}
+	if r.catalogVersion == "" {
+		r.catalogVersion = catalogFingerprint(append(tables, overrides))
+	}
+	info.Version = r.catalogVersion
+	return info
+}
+
+// catalogFingerprint hashes the model IDs and rates of the tables, leaving
+// out fetch times so re-downloading identical prices keeps the version.
+func catalogFingerprint(tables []map[string]Price) string {
+	h := fnv.New32a()
+	for _, table := range tables {
+		ids := make([]string, 0, len(table))
+		for id := range table {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			p := table[id]
+			fmt.Fprintf(h, "%s|%g|%g|%g|%g|%g|%g|%g", id,
+				p.InputCostPerMillion, p.OutputCostPerMillion,
+				p.CacheReadCostPerMillion, p.CacheWriteCostPerMillion,
+				p.ReasoningCostPerMillion, p.CostPerImage, p.AudioCostPerMinute)
+			for _, tier := range []*TierRates{p.Tiers.Above128k, p.Tiers.Above200k, p.Tiers.Above256k, p.Tiers.Above272k} {
+				if tier == nil {
+					h.Write([]byte("|-"))
+					continue
+				}
+				for _, rate := range []*float64{tier.InputCostPerMillion, tier.OutputCostPerMillion, tier.CacheReadCostPerMillion, tier.CacheWriteCostPerMillion} {
+					if rate == nil {
+						h.Write([]byte("|-"))
+					} else {
+						fmt.Fprintf(h, "|%g", *rate)
+					}
+				}
+			}
+			h.Write([]byte{'\n'})
+		}
+		h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// cacheFileMTime is the cache slot's mtime, zero when it doesn't exist or
+// the resolver has no disk cache.
+func (r *Resolver) cacheFileMTime(name string) time.Time {
+	if r.cache == nil || r.cache.Dir() == "" {
+		return time.Time{}
+	}
+	info, err := os.Stat(r.cache.Path(name))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,85 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestReloadIfChanged_PicksUpCatalogAndOverrideEdits(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusBadGateway)
+	}))
+	defer down.Close()
+
+	overridesPath := filepath.Join(t.TempDir(), CustomOverridesFilename)
+	t.Setenv("OPENUSAGE_CUSTOM_PRICING", overridesPath)
+	cache := NewDiskCacheAt(t.TempDir())
+	cache.SetTTL(time.Hour)
+	writeCatalog := func(inputPerToken string, mtime time.Time) {
+		t.Helper()
+		body := fmt.Sprintf(`{"acme-fast-1":{"input_cost_per_token":%s,"output_cost_per_token":1e-05,"litellm_provider":"acme"}}`, inputPerToken)
+		if err := cache.Store(litellmCacheName, []byte(body)); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(cache.Path(litellmCacheName), mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	loadedAt := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	writeCatalog("3e-06", loadedAt)
+
+	r, err := NewResolver(
+		WithCache(cache),
+		WithLiteLLMFetcher(&LiteLLMFetcher{URL: down.URL, Client: down.Client(), Retries: 1, Backoff: 1}),
+		WithOpenRouterFetcher(&OpenRouterFetcher{URL: down.URL, Client: down.Client(), Retries: 1, Backoff: 1}),
+	)
+	if err != nil {
+		t.Fatalf("NewResolver: %v", err)
+	}
+	ctx := context.Background()
+	if p, err := r.Lookup(ctx, "acme-fast-1", 0); err != nil || p.InputCostPerMillion != 3 {
+		t.Fatalf("initial Lookup = %+v, %v; want input 3", p, err)
+	}
+	before := r.Catalog()
+	if before.Models != 1 || !slices.Equal(before.Sources, []Source{SourceLiteLLM}) || !before.UpdatedAt.Equal(loadedAt) || before.Version == "" {
+		t.Fatalf("Catalog = %+v, want one LiteLLM model fetched at %s", before, loadedAt)
+	}
+	if changed := r.ReloadIfChanged(); len(changed) != 0 {
+		t.Fatalf("ReloadIfChanged with nothing edited = %v, want none", changed)
+	}
+
+	// Another process refreshes the cached catalog.
+	writeCatalog("4e-06", loadedAt.Add(5*time.Minute))
+	if changed := r.ReloadIfChanged(); !slices.Equal(changed, []Source{SourceLiteLLM}) {
+		t.Fatalf("ReloadIfChanged after catalog refresh = %v, want [litellm]", changed)
+	}
+	if p, err := r.Lookup(ctx, "acme-fast-1", 0); err != nil || p.InputCostPerMillion != 4 {
+		t.Errorf("Lookup after reload = %+v, %v; want input 4", p, err)
+	}
+	after := r.Catalog()
+	if after.Version == before.Version || !after.UpdatedAt.Equal(loadedAt.Add(5*time.Minute)) {
+		t.Errorf("Catalog after reload = %+v, want a new version and date (was %+v)", after, before)
+	}
+
+	// The user adds a custom override.
+	override := `{"models":{"acme-fast-1":{"input_cost_per_million_tokens":7,"output_cost_per_million_tokens":8}}}`
+	if err := os.WriteFile(overridesPath, []byte(override), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if changed := r.ReloadIfChanged(); !slices.Equal(changed, []Source{SourceCustom}) {
+		t.Fatalf("ReloadIfChanged after override edit = %v, want [custom]", changed)
+	}
+	if p, err := r.Lookup(ctx, "acme-fast-1", 0); err != nil || p.InputCostPerMillion != 7 || p.Source != SourceCustom {
+		t.Errorf("Lookup after override edit = %+v, %v; want the custom rate", p, err)
+	}
+	if got := r.Catalog(); got.Overrides != 1 || got.Version == after.Version {
+		t.Errorf("Catalog with override = %+v, want 1 override and a new version", got)
+	}
+}
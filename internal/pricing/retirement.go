@@ -0,0 +1,58 @@
+package pricing
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// bundledRetirements lists announced model shutdown dates, keyed by
+// normalizeModelKey. Dates published upstream (LiteLLM's deprecation_date,
+// OpenRouter's expiration_date) and in custom-pricing.json take precedence;
+// this table covers models those sources miss and keeps working offline.
+var bundledRetirements = map[string]string{
+	"claude-2":            "2025-07-21",
+	"claude-2-0":          "2025-07-21",
+	"claude-2-1":          "2025-07-21",
+	"claude-3-sonnet":     "2025-07-21",
+	"claude-3-5-sonnet":   "2025-10-22",
+	"claude-3-opus":       "2026-01-05",
+	"gpt-4-5":             "2025-07-14",
+	"gemini-1-5-pro":      "2025-09-24",
+	"gemini-1-5-flash":    "2025-09-24",
+	"gemini-1-5-flash-8b": "2025-09-24",
+}
+
+// RetirementDate returns the date the vendor shuts model down, if one has
+// been announced. Upstream dates only count when the price table resolved
+// the model itself rather than a fuzzy neighbour, since shutdowns are
+// announced per release.
+func (r *Resolver) RetirementDate(ctx context.Context, model string) (time.Time, bool) {
+	key := normalizeModelKey(model)
+	if key == "" {
+		return time.Time{}, false
+	}
+	if p, err := r.Lookup(ctx, model, 0); err == nil && !p.RetirementDate.IsZero() && normalizeModelKey(p.ModelID) == key {
+		return p.RetirementDate, true
+	}
+	if date := parseRetirementDate(bundledRetirements[key]); !date.IsZero() {
+		return date, true
+	}
+	return time.Time{}, false
+}
+
+// parseRetirementDate accepts the "2006-01-02" dates LiteLLM and OpenRouter
+// publish, or a full RFC 3339 timestamp. Anything else yields zero.
+func parseRetirementDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC()
+	}
+	return time.Time{}
+}
@@ -0,0 +1,43 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRetirementDate(t *testing.T) {
+	r, _, _ := newTestResolver(t, "litellm_subset.json", "openrouter_subset.json")
+	ctx := context.Background()
+
+	cases := []struct {
+		model string
+		want  string
+	}{
+		{"gemini-1.5-pro", "2025-09-24"},         // LiteLLM deprecation_date
+		{"claude-3-opus-20240229", "2026-01-05"}, // bundled table
+		{"anthropic/claude-3-opus-latest", "2026-01-05"},
+		{"deepseek-chat", ""},
+	}
+	for _, tc := range cases {
+		got, ok := r.RetirementDate(ctx, tc.model)
+		if tc.want == "" {
+			if ok {
+				t.Errorf("RetirementDate(%q) = %s, want none", tc.model, got.Format(time.DateOnly))
+			}
+			continue
+		}
+		if !ok || got.Format(time.DateOnly) != tc.want {
+			t.Errorf("RetirementDate(%q) = %s, %v; want %s", tc.model, got.Format(time.DateOnly), ok, tc.want)
+		}
+	}
+}
+
+func TestParseRetirementDate(t *testing.T) {
+	if got := parseRetirementDate("2026-03-01T12:00:00Z"); got.Format(time.DateOnly) != "2026-03-01" {
+		t.Errorf("RFC 3339 date parsed as %v", got)
+	}
+	if got := parseRetirementDate("soon"); !got.IsZero() {
+		t.Errorf("malformed date parsed as %v", got)
+	}
+}
@@ -6,7 +6,9 @@
 //   - OpenRouter /api/v1/models (fallback / cross-check)
 //   - Hardcoded last-resort table (a small set of common models)
 //
-// All rates are normalised to USD per 1,000,000 tokens.
+// Token rates are normalised to USD per 1,000,000 tokens. Image and audio
+// models that are billed per unit carry a per-image or per-minute rate
+// instead.
 package pricing
 
 import (
@@ -16,6 +18,15 @@ import (
 // Source identifies which upstream produced a Price.
 type Source string
 
+// Model modes, as LiteLLM classifies them. Chat models usually leave Mode
+// empty; the others are billed differently: embeddings on input tokens
+// only, image generation per image, transcription per minute of audio.
+const (
+	ModeEmbedding          = "embedding"
+	ModeImageGeneration    = "image_generation"
+	ModeAudioTranscription = "audio_transcription"
+)
+
 const (
 	SourceLiteLLM    Source = "litellm"
 	SourceOpenRouter Source = "openrouter"
@@ -23,9 +34,9 @@ const (
 	SourceCache      Source = "cache"
 )
 
-// Price represents the resolved per-million-token rates for a single model.
-// All numeric fields are USD per 1,000,000 tokens. Optional rates are zero
-// when the upstream did not publish them.
+// Price represents the resolved rates for a single model. Token rates are
+// USD per 1,000,000 tokens. Optional rates are zero when the upstream did
+// not publish them.
 type Price struct {
 	// ModelID is the canonical upstream identifier the price was resolved
 	// against (after normalisation / fuzzy matching).
@@ -39,6 +50,9 @@ type Price struct {
 	ContextWindow int `json:"context_window,omitempty"`
 	// LastUpdated is the time the upstream data was fetched / refreshed.
 	LastUpdated time.Time `json:"last_updated"`
+	// RetirementDate is the announced date the vendor shuts the model
+	// down. Zero when none has been published.
+	RetirementDate time.Time `json:"retirement_date,omitzero"`
 
 	// InputCostPerMillion is the prompt / input token rate (USD per 1M).
 	InputCostPerMillion float64 `json:"input_cost_per_million"`
@@ -53,6 +67,16 @@ type Price struct {
 	// only set when the upstream publishes a separate rate.
 	ReasoningCostPerMillion float64 `json:"reasoning_cost_per_million,omitempty"`
 
+	// Mode is the kind of model (ModeEmbedding, ModeImageGeneration, …).
+	// Empty for chat models and when the upstream does not say.
+	Mode string `json:"mode,omitempty"`
+	// CostPerImage is the USD price of one generated image at the model's
+	// default size and quality.
+	CostPerImage float64 `json:"cost_per_image,omitempty"`
+	// AudioCostPerMinute is the USD price of one minute of audio for
+	// models billed by duration (e.g. whisper-1).
+	AudioCostPerMinute float64 `json:"audio_cost_per_minute,omitempty"`
+
 	// Tiers carries the optional per-context-window rate overrides
 	// (>128k, >200k, >256k, >272k). nil rates mean "fall through to base".
 	Tiers TierOverrides `json:"tiers,omitempty"`
@@ -2,8 +2,10 @@ package alibaba_cloud
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/providers/providerbase"
@@ -56,6 +58,7 @@ type billingPeriod struct {
 
 type Provider struct {
 	providerbase.Base
+	clock core.Clock
 }
 
 func New() *Provider {
@@ -64,7 +67,7 @@ func New() *Provider {
 			ID: "alibaba_cloud",
 			Info: core.ProviderInfo{
 				Name:         "Alibaba Cloud Model Studios",
-				Capabilities: []string{"quotas_endpoint", "credits", "rate_limits", "daily_usage", "per_model_tracking"},
+				Capabilities: []string{"quotas_endpoint", "billing_api", "credits", "rate_limits", "daily_usage", "per_model_tracking"},
 				DocURL:       "https://dashscope.aliyun.com/",
 			},
 			Auth: core.ProviderAuthSpec{
@@ -76,6 +79,7 @@ func New() *Provider {
 				Quickstart: []string{
 					"Set ALIBABA_CLOUD_API_KEY to your DashScope API key.",
 					"Get your key from: https://dashscope.aliyun.com/",
+					"For real billing data, set ALIBABA_CLOUD_ACCESS_KEY_ID and ALIBABA_CLOUD_ACCESS_KEY_SECRET (RAM user with AliyunBSSReadOnlyAccess).",
 				},
 			},
 			Dashboard: dashboardWidget(),
@@ -87,10 +91,24 @@ func New() *Provider {
 				"spend_limit":       core.BalanceLimit,
 			},
 		}),
+		clock: core.SystemClock{},
 	}
 }
 
+func (p *Provider) now() time.Time {
+	if p != nil && p.clock != nil {
+		return p.clock.Now()
+	}
+	return time.Now()
+}
+
 func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.UsageSnapshot, error) {
+	// An AccessKey pair unlocks the billing OpenAPI, which is what real
+	// accounts expose; the API-key /quotas path is kept for compatible gateways.
+	if keys, ok := resolveAccessKeys(acct); ok {
+		return p.fetchBSS(ctx, acct, keys)
+	}
+
 	apiKey, authSnap := shared.RequireAPIKey(acct, p.ID())
 	if authSnap != nil {
 		return *authSnap, nil
@@ -104,7 +122,11 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 	statusCode, _, err := shared.FetchJSON(ctx, baseURL+"/quotas", apiKey, &quotasResp, p.Client())
 	if err != nil {
 		// FetchJSON returns an error for non-200 status codes; handle gracefully.
+		var maint *core.MaintenanceError
 		switch {
+		case errors.As(err, &maint):
+			snap.SetMaintenance(maint.Message, maint.Until)
+			return snap, nil
 		case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
 			snap.Status = core.StatusAuth
 			snap.Message = "Invalid or expired API key"
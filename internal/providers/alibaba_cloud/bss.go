@@ -0,0 +1,414 @@
+package alibaba_cloud
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+const (
+	// defaultBSSEndpoint is the Alibaba Cloud billing (BSS) OpenAPI endpoint
+	// for China-site accounts. International accounts use
+	// https://business.ap-southeast-1.aliyuncs.com via the bss_endpoint setting.
+	defaultBSSEndpoint = "https://business.aliyuncs.com"
+	bssAPIVersion      = "2017-12-14"
+
+	accessKeyIDEnv     = "ALIBABA_CLOUD_ACCESS_KEY_ID"
+	accessKeySecretEnv = "ALIBABA_CLOUD_ACCESS_KEY_SECRET"
+)
+
+// modelStudioProducts are the BSS product codes / names Model Studio
+// (Bailian, formerly DashScope) bills under.
+var modelStudioProducts = []string{"sfm", "bailian", "dashscope", "model studio", "百炼"}
+
+var modelSlugRe = regexp.MustCompile(`[^a-z0-9.]+`)
+
+type accessKeys struct {
+	ID     string
+	Secret string
+}
+
+// resolveAccessKeys returns the account's AccessKey pair. The env var names
+// default to the Alibaba Cloud SDK's and can be overridden per account with
+// the access_key_id_env / access_key_secret_env settings. Returns ok=false
+// unless both halves are set.
+func resolveAccessKeys(acct core.AccountConfig) (accessKeys, bool) {
+	keys := accessKeys{
+		ID:     strings.TrimSpace(os.Getenv(acct.Path("access_key_id_env", accessKeyIDEnv))),
+		Secret: strings.TrimSpace(os.Getenv(acct.Path("access_key_secret_env", accessKeySecretEnv))),
+	}
+	return keys, keys.ID != "" && keys.Secret != ""
+}
+
+type bssClient struct {
+	endpoint string
+	keys     accessKeys
+	http     *http.Client
+	now      func() time.Time
+}
+
+// bssError is returned for non-success BSS responses; Code is the Alibaba
+// error code (e.g. "InvalidAccessKeyId.NotFound").
+type bssError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *bssError) Error() string {
+	return fmt.Sprintf("HTTP %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func (e *bssError) isAuth() bool {
+	if e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden {
+		return true
+	}
+	for _, prefix := range []string{"InvalidAccessKeyId", "SignatureDoesNotMatch", "IncompleteSignature", "Forbidden", "NotAuthorized"} {
+		if strings.HasPrefix(e.Code, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+type bssEnvelope struct {
+	Code    string `json:"Code"`
+	Message string `json:"Message"`
+	Success *bool  `json:"Success"`
+}
+
+type accountBalanceResponse struct {
+	bssEnvelope
+	Data struct {
+		AvailableAmount     string `json:"AvailableAmount"`
+		AvailableCashAmount string `json:"AvailableCashAmount"`
+		CreditAmount        string `json:"CreditAmount"`
+		QuotaLimit          string `json:"QuotaLimit"`
+		Currency            string `json:"Currency"`
+	} `json:"Data"`
+}
+
+type billOverviewResponse struct {
+	bssEnvelope
+	Data struct {
+		BillingCycle string `json:"BillingCycle"`
+		Items        struct {
+			Item []billOverviewItem `json:"Item"`
+		} `json:"Items"`
+	} `json:"Data"`
+}
+
+type billOverviewItem struct {
+	ProductCode  string  `json:"ProductCode"`
+	ProductName  string  `json:"ProductName"`
+	PipCode      string  `json:"PipCode"`
+	PretaxAmount float64 `json:"PretaxAmount"`
+	Currency     string  `json:"Currency"`
+}
+
+type resourcePackagesResponse struct {
+	bssEnvelope
+	Data struct {
+		TotalCount int `json:"TotalCount"`
+		Instances  struct {
+			Instance []resourcePackage `json:"Instance"`
+		} `json:"Instances"`
+	} `json:"Data"`
+}
+
+type resourcePackage struct {
+	InstanceID          string `json:"InstanceId"`
+	PackageType         string `json:"PackageType"`
+	Remark              string `json:"Remark"`
+	Status              string `json:"Status"`
+	TotalAmount         string `json:"TotalAmount"`
+	TotalAmountUnit     string `json:"TotalAmountUnit"`
+	RemainingAmount     string `json:"RemainingAmount"`
+	RemainingAmountUnit string `json:"RemainingAmountUnit"`
+	ExpiryTime          string `json:"ExpiryTime"`
+	ApplicableProducts  struct {
+		Product []string `json:"Product"`
+	} `json:"ApplicableProducts"`
+}
+
+// call performs a signed BSS RPC request (signature v1, HMAC-SHA1) and
+// decodes the JSON response into out.
+func (c *bssClient) call(ctx context.Context, action string, params map[string]string, out any) error {
+	query := url.Values{}
+	for k, v := range params {
+		query.Set(k, v)
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("generating nonce: %w", err)
+	}
+	query.Set("Action", action)
+	query.Set("Version", bssAPIVersion)
+	query.Set("Format", "JSON")
+	query.Set("AccessKeyId", c.keys.ID)
+	query.Set("SignatureMethod", "HMAC-SHA1")
+	query.Set("SignatureVersion", "1.0")
+	query.Set("SignatureNonce", hex.EncodeToString(nonce))
+	query.Set("Timestamp", c.now().UTC().Format("2006-01-02T15:04:05Z"))
+	query.Set("Signature", signRPC(http.MethodGet, query, c.keys.Secret))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(c.endpoint, "/")+"/?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading body: %w", err)
+	}
+
+	var env bssEnvelope
+	_ = json.Unmarshal(body, &env)
+	if resp.StatusCode != http.StatusOK || (env.Success != nil && !*env.Success) {
+		return &bssError{StatusCode: resp.StatusCode, Code: env.Code, Message: env.Message}
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("parsing %s response: %w", action, err)
+	}
+	return nil
+}
+
+// signRPC computes the Alibaba Cloud RPC signature for the canonicalised
+// query (Signature excluded).
+func signRPC(method string, query url.Values, secret string) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		if k != "Signature" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, percentEncode(k)+"="+percentEncode(query.Get(k)))
+	}
+	stringToSign := method + "&" + percentEncode("/") + "&" + percentEncode(strings.Join(pairs, "&"))
+	mac := hmac.New(sha1.New, []byte(secret+"&"))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// percentEncode is RFC 3986 encoding as Alibaba's signer expects it: space
+// as %20, '*' escaped, '~' left alone.
+func percentEncode(s string) string {
+	s = url.QueryEscape(s)
+	s = strings.ReplaceAll(s, "+", "%20")
+	s = strings.ReplaceAll(s, "*", "%2A")
+	return strings.ReplaceAll(s, "%7E", "~")
+}
+
+// fetchBSS builds a snapshot from the billing OpenAPI: account balance,
+// this month's Model Studio spend and per-model resource-package usage.
+// Only the balance call is fatal; the other two land in diagnostics.
+func (p *Provider) fetchBSS(ctx context.Context, acct core.AccountConfig, keys accessKeys) (core.UsageSnapshot, error) {
+	snap := core.NewUsageSnapshot(p.ID(), acct.ID)
+	client := &bssClient{
+		endpoint: acct.Path("bss_endpoint", defaultBSSEndpoint),
+		keys:     keys,
+		http:     p.Client(),
+		now:      p.now,
+	}
+
+	var balance accountBalanceResponse
+	if err := client.call(ctx, "QueryAccountBalance", nil, &balance); err != nil {
+		var be *bssError
+		if errors.As(err, &be) {
+			switch {
+			case be.isAuth():
+				snap.Status = core.StatusAuth
+				snap.Message = "Invalid AccessKey or missing BSS permission (" + be.Code + ")"
+				return snap, nil
+			case be.StatusCode == http.StatusTooManyRequests || strings.HasPrefix(be.Code, "Throttling"):
+				snap.Status = core.StatusLimited
+				snap.Message = "Rate limited by billing API"
+				return snap, nil
+			default:
+				snap.Status = core.StatusError
+				snap.Message = be.Error()
+				return snap, nil
+			}
+		}
+		return core.UsageSnapshot{}, fmt.Errorf("alibaba_cloud: querying account balance: %w", err)
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(balance.Data.Currency))
+	if currency == "" {
+		currency = "CNY"
+	}
+	setAmount := func(key, raw string) {
+		if v, ok := parseAmount(raw); ok {
+			snap.Metrics[key] = core.Metric{Limit: &v, Unit: currency, Window: "current"}
+		}
+	}
+	setAmount("available_balance", balance.Data.AvailableAmount)
+	setAmount("credit_balance", balance.Data.CreditAmount)
+	if v, ok := parseAmount(balance.Data.QuotaLimit); ok && v > 0 {
+		snap.Metrics["spend_limit"] = core.Metric{Limit: &v, Unit: currency, Window: "current"}
+	}
+	snap.SetAttribute("currency", currency)
+
+	now := p.now()
+	cycle := now.Format("2006-01")
+	var overview billOverviewResponse
+	if err := client.call(ctx, "QueryBillOverview", map[string]string{"BillingCycle": cycle}, &overview); err != nil {
+		snap.SetDiagnostic("bss_bill_overview_error", err.Error())
+	} else {
+		applyBillOverview(&snap, overview.Data.Items.Item, currency)
+	}
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	snap.SetAttribute("billing_cycle_start", start.Format("2006-01-02"))
+	snap.SetAttribute("billing_cycle_end", start.AddDate(0, 1, 0).Format("2006-01-02"))
+
+	var packages resourcePackagesResponse
+	params := map[string]string{"PageNum": "1", "PageSize": "100"}
+	if err := client.call(ctx, "QueryResourcePackageInstances", params, &packages); err != nil {
+		snap.SetDiagnostic("bss_resource_packages_error", err.Error())
+	} else {
+		applyResourcePackages(&snap, packages.Data.Instances.Instance)
+	}
+
+	snap.Status = core.StatusOK
+	snap.Message = "OK"
+	return snap, nil
+}
+
+// applyBillOverview sums this billing cycle's Model Studio line items into
+// monthly_spend.
+func applyBillOverview(snap *core.UsageSnapshot, items []billOverviewItem, currency string) {
+	var total float64
+	var matched bool
+	for _, item := range items {
+		if !isModelStudioProduct(item.ProductCode, item.PipCode, item.ProductName) {
+			continue
+		}
+		total += item.PretaxAmount
+		matched = true
+	}
+	if !matched {
+		return
+	}
+	snap.Metrics["monthly_spend"] = core.Metric{Used: &total, Unit: currency, Window: "month"}
+}
+
+// applyResourcePackages turns active Model Studio token packages into
+// per-model model_<name>_used gauges and model_<name>_usage_pct metrics.
+// Packages for the same model are summed.
+func applyResourcePackages(snap *core.UsageSnapshot, packages []resourcePackage) {
+	type usage struct{ total, remaining float64 }
+	byModel := make(map[string]*usage)
+	var order []string
+	units := make(map[string]string)
+	for _, pkg := range packages {
+		if !isModelStudioProduct(pkg.ApplicableProducts.Product...) {
+			continue
+		}
+		if pkg.Status != "" && !strings.EqualFold(pkg.Status, "Available") {
+			continue
+		}
+		total, ok := parseAmount(pkg.TotalAmount)
+		if !ok || total <= 0 {
+			continue
+		}
+		remaining, _ := parseAmount(pkg.RemainingAmount)
+		name := packageModelName(pkg)
+		if name == "" {
+			continue
+		}
+		u, exists := byModel[name]
+		if !exists {
+			u = &usage{}
+			byModel[name] = u
+			order = append(order, name)
+			units[name] = packageUnit(pkg.TotalAmountUnit)
+		}
+		u.total += total
+		u.remaining += remaining
+	}
+	for _, name := range order {
+		u := byModel[name]
+		used := max(u.total-u.remaining, 0)
+		limit := u.total
+		remaining := u.remaining
+		pct := used / limit * 100
+		snap.Metrics[fmt.Sprintf("model_%s_usage_pct", name)] = core.Metric{Used: &pct, Unit: "%", Window: "current"}
+		snap.Metrics[fmt.Sprintf("model_%s_used", name)] = core.Metric{
+			Used: &used, Limit: &limit, Remaining: &remaining, Unit: units[name], Window: "current",
+		}
+	}
+}
+
+func isModelStudioProduct(fields ...string) bool {
+	for _, f := range fields {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" {
+			continue
+		}
+		for _, p := range modelStudioProducts {
+			if strings.Contains(f, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// packageModelName picks the model a resource package covers: the remark
+// when it names one (Model Studio fills in e.g. "qwen-plus"), otherwise the
+// package type.
+func packageModelName(pkg resourcePackage) string {
+	for _, candidate := range []string{pkg.Remark, pkg.PackageType} {
+		slug := strings.Trim(modelSlugRe.ReplaceAllString(strings.ToLower(strings.TrimSpace(candidate)), "-"), "-")
+		if slug != "" {
+			return slug
+		}
+	}
+	return ""
+}
+
+func packageUnit(raw string) string {
+	if strings.Contains(strings.ToLower(raw), "token") {
+		return "tokens"
+	}
+	return "units"
+}
+
+// parseAmount parses BSS money/quantity strings such as "10,000.00".
+func parseAmount(raw string) (float64, bool) {
+	raw = strings.ReplaceAll(strings.TrimSpace(raw), ",", "")
+	if raw == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
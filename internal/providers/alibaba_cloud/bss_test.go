@@ -0,0 +1,180 @@
+package alibaba_cloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+type fixedClock struct{ t time.Time }
+
+func (c fixedClock) Now() time.Time { return c.t }
+
+func TestSignRPC_MatchesDocumentedExample(t *testing.T) {
+	// Example from Alibaba Cloud's RPC signature documentation.
+	query := url.Values{
+		"AccessKeyId":      {"testid"},
+		"Action":           {"DescribeRegions"},
+		"Format":           {"XML"},
+		"SignatureMethod":  {"HMAC-SHA1"},
+		"SignatureNonce":   {"3ee8c1b8-83d3-44af-a94f-4e0ad82fd6cf"},
+		"SignatureVersion": {"1.0"},
+		"Timestamp":        {"2016-02-23T12:46:24Z"},
+		"Version":          {"2014-05-26"},
+	}
+	if got, want := signRPC(http.MethodGet, query, "testsecret"), "OLeaidS1JvxuMvnyHOwuJ+uX5qY="; got != want {
+		t.Errorf("signRPC = %q, want %q", got, want)
+	}
+}
+
+func TestFetch_AccessKeyUsesBillingAPI(t *testing.T) {
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("AccessKeyId") != "ak-id" {
+			t.Errorf("AccessKeyId = %q, want ak-id", q.Get("AccessKeyId"))
+		}
+		if got, want := q.Get("Signature"), signRPC(http.MethodGet, q, "ak-secret"); got != want {
+			t.Errorf("%s: signature = %q, want %q", q.Get("Action"), got, want)
+		}
+		actions = append(actions, q.Get("Action"))
+		w.Header().Set("Content-Type", "application/json")
+		switch q.Get("Action") {
+		case "QueryAccountBalance":
+			w.Write([]byte(`{"Success":true,"Code":"200","Data":{"AvailableAmount":"1,234.50","CreditAmount":"500.00","QuotaLimit":"0.00","Currency":"CNY"}}`))
+		case "QueryBillOverview":
+			if q.Get("BillingCycle") != "2026-03" {
+				t.Errorf("BillingCycle = %q, want 2026-03", q.Get("BillingCycle"))
+			}
+			w.Write([]byte(`{"Success":true,"Data":{"BillingCycle":"2026-03","Items":{"Item":[
+				{"ProductCode":"sfm","ProductName":"Model Studio","PretaxAmount":42.5},
+				{"ProductCode":"sfm","ProductName":"Model Studio","PretaxAmount":7.5},
+				{"ProductCode":"ecs","ProductName":"Elastic Compute Service","PretaxAmount":100}
+			]}}}`))
+		case "QueryResourcePackageInstances":
+			w.Write([]byte(`{"Success":true,"Data":{"Instances":{"Instance":[
+				{"Remark":"qwen-plus","Status":"Available","TotalAmount":"1000000","TotalAmountUnit":"Token","RemainingAmount":"250000","ApplicableProducts":{"Product":["sfm"]}},
+				{"Remark":"Qwen Max","Status":"Available","TotalAmount":"2,000,000","TotalAmountUnit":"Token","RemainingAmount":"2,000,000","ApplicableProducts":{"Product":["sfm"]}},
+				{"Remark":"qwen-turbo","Status":"Expired","TotalAmount":"100","RemainingAmount":"0","ApplicableProducts":{"Product":["sfm"]}},
+				{"Remark":"oss pack","Status":"Available","TotalAmount":"40","RemainingAmount":"10","ApplicableProducts":{"Product":["oss"]}}
+			]}}}`))
+		default:
+			t.Errorf("unexpected action %q", q.Get("Action"))
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv(accessKeyIDEnv, "ak-id")
+	t.Setenv(accessKeySecretEnv, "ak-secret")
+
+	p := New()
+	p.clock = fixedClock{t: time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)}
+	acct := core.AccountConfig{
+		ID:            "alibaba_cloud",
+		Provider:      "alibaba_cloud",
+		APIKeyEnv:     "TEST_ALIBABA_UNSET_KEY",
+		ProviderPaths: map[string]string{"bss_endpoint": server.URL},
+	}
+
+	snap, err := p.Fetch(context.Background(), acct)
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if snap.Status != core.StatusOK {
+		t.Fatalf("Status = %q (%s), want OK", snap.Status, snap.Message)
+	}
+	if len(actions) != 3 {
+		t.Errorf("actions = %v, want 3 BSS calls", actions)
+	}
+
+	if m := snap.Metrics["available_balance"]; m.Limit == nil || *m.Limit != 1234.5 || m.Unit != "CNY" {
+		t.Errorf("available_balance = %+v, want 1234.5 CNY", m)
+	}
+	if _, ok := snap.Metrics["spend_limit"]; ok {
+		t.Error("spend_limit should be omitted when QuotaLimit is zero")
+	}
+	if m := snap.Metrics["monthly_spend"]; m.Used == nil || *m.Used != 50 {
+		t.Errorf("monthly_spend = %+v, want 50 (Model Studio items only)", m)
+	}
+	if got := snap.Attributes["billing_cycle_start"]; got != "2026-03-01" {
+		t.Errorf("billing_cycle_start = %q, want 2026-03-01", got)
+	}
+
+	if m := snap.Metrics["model_qwen-plus_usage_pct"]; m.Used == nil || *m.Used != 75 {
+		t.Errorf("model_qwen-plus_usage_pct = %+v, want 75", m)
+	}
+	if m := snap.Metrics["model_qwen-plus_used"]; m.Used == nil || *m.Used != 750000 || m.Limit == nil || *m.Limit != 1000000 || m.Unit != "tokens" {
+		t.Errorf("model_qwen-plus_used = %+v, want 750000/1000000 tokens", m)
+	}
+	if m := snap.Metrics["model_qwen-max_usage_pct"]; m.Used == nil || *m.Used != 0 {
+		t.Errorf("model_qwen-max_usage_pct = %+v, want 0", m)
+	}
+	for _, key := range []string{"model_qwen-turbo_used", "model_oss-pack_used"} {
+		if _, ok := snap.Metrics[key]; ok {
+			t.Errorf("%s should be skipped", key)
+		}
+	}
+}
+
+func TestFetch_AccessKeyRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"Code":"SignatureDoesNotMatch","Message":"Specified signature is not matched with our calculation."}`))
+	}))
+	defer server.Close()
+
+	t.Setenv(accessKeyIDEnv, "ak-id")
+	t.Setenv(accessKeySecretEnv, "wrong")
+
+	snap, err := New().Fetch(context.Background(), core.AccountConfig{
+		ID:            "alibaba_cloud",
+		Provider:      "alibaba_cloud",
+		ProviderPaths: map[string]string{"bss_endpoint": server.URL},
+	})
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if snap.Status != core.StatusAuth {
+		t.Errorf("Status = %q, want %q", snap.Status, core.StatusAuth)
+	}
+}
+
+func TestFetch_PartialBillingFailureIsDiagnostic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("Action") == "QueryAccountBalance" {
+			w.Write([]byte(`{"Success":true,"Data":{"AvailableAmount":"10.00","Currency":"USD"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"Code":"NotAuthorized","Message":"no permission"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv(accessKeyIDEnv, "ak-id")
+	t.Setenv(accessKeySecretEnv, "ak-secret")
+
+	snap, err := New().Fetch(context.Background(), core.AccountConfig{
+		ID:            "alibaba_cloud",
+		Provider:      "alibaba_cloud",
+		ProviderPaths: map[string]string{"bss_endpoint": server.URL},
+	})
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if snap.Status != core.StatusOK {
+		t.Errorf("Status = %q, want OK", snap.Status)
+	}
+	if m := snap.Metrics["available_balance"]; m.Limit == nil || *m.Limit != 10 || m.Unit != "USD" {
+		t.Errorf("available_balance = %+v, want 10 USD", m)
+	}
+	for _, key := range []string{"bss_bill_overview_error", "bss_resource_packages_error"} {
+		if snap.Diagnostics[key] == "" {
+			t.Errorf("missing diagnostic %s", key)
+		}
+	}
+}
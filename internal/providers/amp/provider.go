@@ -45,6 +45,10 @@ func New() *Provider {
 			CreditMetrics: map[string]core.BalanceSemantics{
 				"total_cost": core.BalanceCumulative,
 			},
+			Actions: []core.ProviderAction{
+				{Label: "Open data directory", Kind: core.ProviderActionOpenPath, SnapshotKey: "data_dir"},
+				{Label: "Open ledger file", Kind: core.ProviderActionOpenPath, SnapshotKey: "ledger_path"},
+			},
 		}),
 	}
 }
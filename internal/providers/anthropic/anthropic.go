@@ -10,7 +10,10 @@ import (
 	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
 
-const defaultBaseURL = "https://api.anthropic.com/v1"
+const (
+	defaultBaseURL   = "https://api.anthropic.com/v1"
+	anthropicVersion = "2023-06-01"
+)
 
 type Provider struct {
 	providerbase.Base
@@ -22,7 +25,7 @@ func New() *Provider {
 			ID: "anthropic",
 			Info: core.ProviderInfo{
 				Name:         "Anthropic",
-				Capabilities: []string{"headers"},
+				Capabilities: []string{"headers", "billing_usage"},
 				DocURL:       "https://docs.anthropic.com/en/api/rate-limits",
 			},
 			Auth: core.ProviderAuthSpec{
@@ -31,23 +34,65 @@ func New() *Provider {
 				DefaultAccountID: "anthropic",
 			},
 			Setup: core.ProviderSetupSpec{
-				Quickstart: []string{"Set ANTHROPIC_API_KEY to a valid Anthropic API key."},
+				Quickstart: []string{
+					"Set ANTHROPIC_API_KEY to a valid Anthropic API key.",
+					"For per-workspace spend and tokens, set ANTHROPIC_ADMIN_KEY to an Admin API key and add workspace_id to the account's provider_paths.",
+				},
 			},
 			Dashboard: providerbase.DefaultDashboard(providerbase.WithColorRole(core.DashboardColorRolePeach)),
+			CreditMetrics: map[string]core.BalanceSemantics{
+				"monthly_spend": core.BalanceCumulative,
+			},
+			Actions: []core.ProviderAction{
+				{Label: "Open billing page", Kind: core.ProviderActionOpenURL, Target: "https://console.anthropic.com/settings/billing"},
+				{Label: "Open status page", Kind: core.ProviderActionOpenURL, Target: "https://status.anthropic.com"},
+				{Label: "Copy API base URL", Kind: core.ProviderActionCopy, Target: defaultBaseURL, SnapshotKey: "api_base_url"},
+			},
+			Reference: core.ProviderReference{
+				LimitsNote: "for Claude Sonnet",
+				Tiers: []core.ProviderTier{
+					{Name: "Tier 1", Qualification: "$5 credit purchase", Limits: map[string]float64{"rpm": 50}},
+					{Name: "Tier 2", Qualification: "$40 credit purchase", Limits: map[string]float64{"rpm": 1_000}},
+					{Name: "Tier 3", Qualification: "$200 credit purchase", Limits: map[string]float64{"rpm": 2_000}},
+					{Name: "Tier 4", Qualification: "$400 credit purchase", Limits: map[string]float64{"rpm": 4_000}},
+					{Name: "Custom", Qualification: "contact sales"},
+				},
+				Windows: []string{
+					"Limits replenish continuously (token bucket) rather than resetting on the minute; the reset time is when the bucket is full again.",
+					"tpm reports whichever token limit is most restrictive at the moment.",
+				},
+				Links: []core.ProviderLink{
+					{Label: "Rate limits", URL: "https://docs.anthropic.com/en/api/rate-limits"},
+					{Label: "Your limits", URL: "https://console.anthropic.com/settings/limits"},
+				},
+			},
 		}),
 	}
 }
 
 func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.UsageSnapshot, error) {
+	if wsID := workspaceID(acct); wsID != "" {
+		return p.fetchWorkspace(ctx, acct, wsID)
+	}
+
 	apiKey, authSnap := shared.RequireAPIKey(acct, p.ID())
 	if authSnap != nil {
 		return *authSnap, nil
 	}
 
-	baseURL := shared.ResolveBaseURL(acct, defaultBaseURL)
+	snap, err := p.probe(ctx, acct, shared.ResolveBaseURL(acct, defaultBaseURL), apiKey)
+	if err != nil {
+		return core.UsageSnapshot{}, err
+	}
+	shared.FinalizeStatus(&snap)
+	return snap, nil
+}
+
+// probe sends a minimal /messages request and reads the rate-limit headers.
+func (p *Provider) probe(ctx context.Context, acct core.AccountConfig, baseURL, apiKey string) (core.UsageSnapshot, error) {
 	headers := map[string]string{
 		"x-api-key":         apiKey,
-		"anthropic-version": "2023-06-01",
+		"anthropic-version": anthropicVersion,
 		"Content-Type":      "application/json",
 	}
 
@@ -75,7 +120,5 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 		"anthropic-ratelimit-tokens-limit",
 		"anthropic-ratelimit-tokens-remaining",
 		"anthropic-ratelimit-tokens-reset")
-
-	shared.FinalizeStatus(&snap)
 	return snap, nil
 }
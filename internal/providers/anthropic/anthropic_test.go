@@ -2,10 +2,13 @@ package anthropic
 
 import (
 	"context"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
@@ -85,3 +88,162 @@ func TestFetch_AuthRequired(t *testing.T) {
 		t.Errorf("Status = %v, want AUTH_REQUIRED", snap.Status)
 	}
 }
+
+func TestFetch_Workspace(t *testing.T) {
+	today := time.Now().UTC().Format("2006-01-02") + "T00:00:00Z"
+	var usageQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages" && r.Header.Get("x-api-key") != "admin-key" {
+			t.Errorf("%s: x-api-key = %q, want the admin key", r.URL.Path, r.Header.Get("x-api-key"))
+		}
+		switch r.URL.Path {
+		case "/messages":
+			w.Header().Set("anthropic-ratelimit-requests-limit", "50")
+			w.Header().Set("anthropic-ratelimit-requests-remaining", "49")
+			w.WriteHeader(http.StatusBadRequest)
+		case "/organizations/workspaces/wrkspc_prod":
+			w.Write([]byte(`{"id":"wrkspc_prod","name":"prod","archived_at":null}`))
+		case "/organizations/cost_report":
+			w.Write([]byte(`{"data":[{"starting_at":"` + today + `","results":[
+				{"workspace_id":"wrkspc_prod","model":"claude-sonnet-4","currency":"USD","amount":"1250.5"},
+				{"workspace_id":"wrkspc_research","model":"claude-sonnet-4","currency":"USD","amount":"9999"},
+				{"workspace_id":null,"model":"claude-sonnet-4","currency":"USD","amount":"500"}
+			]}],"has_more":false}`))
+		case "/organizations/usage_report/messages":
+			usageQuery = r.URL.RawQuery
+			w.Write([]byte(`{"data":[{"starting_at":"` + today + `","results":[
+				{"workspace_id":"wrkspc_prod","model":"claude-sonnet-4","uncached_input_tokens":1000,"cache_read_input_tokens":200,
+				 "cache_creation":{"ephemeral_5m_input_tokens":50,"ephemeral_1h_input_tokens":0},"output_tokens":300}
+			]}],"has_more":false}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_ANTHROPIC_KEY", "test-key")
+	t.Setenv("TEST_ANTHROPIC_ADMIN_KEY", "admin-key")
+
+	snap, err := New().Fetch(context.Background(), core.AccountConfig{
+		ID:        "anthropic-prod",
+		Provider:  "anthropic",
+		APIKeyEnv: "TEST_ANTHROPIC_KEY",
+		BaseURL:   server.URL,
+		ProviderPaths: map[string]string{
+			"workspace_id":  "wrkspc_prod",
+			"admin_key_env": "TEST_ANTHROPIC_ADMIN_KEY",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if snap.Status != core.StatusOK {
+		t.Fatalf("Status = %v (%s), want OK", snap.Status, snap.Message)
+	}
+	if got := snap.Attributes["workspace_name"]; got != "prod" {
+		t.Errorf("workspace_name = %q, want prod", got)
+	}
+	if !strings.Contains(usageQuery, "workspace_ids%5B%5D=wrkspc_prod") {
+		t.Errorf("usage report query %q does not filter by workspace", usageQuery)
+	}
+
+	wantUsed := map[string]float64{
+		"monthly_spend":                            12.505,
+		"today_cost":                               12.505,
+		"monthly_input_tokens":                     1250,
+		"monthly_output_tokens":                    300,
+		"model_claude_sonnet_4_input_tokens":       1000,
+		"model_claude_sonnet_4_cache_read_tokens":  200,
+		"model_claude_sonnet_4_cache_write_tokens": 50,
+		"model_claude_sonnet_4_output_tokens":      300,
+		"model_claude_sonnet_4_cost_usd":           12.505,
+	}
+	for key, want := range wantUsed {
+		m, ok := snap.Metrics[key]
+		if !ok || m.Used == nil {
+			t.Errorf("missing metric %s", key)
+			continue
+		}
+		if math.Abs(*m.Used-want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", key, *m.Used, want)
+		}
+	}
+	if rpm := snap.Metrics["rpm"]; rpm.Limit == nil || *rpm.Limit != 50 {
+		t.Errorf("rpm limit = %v, want 50 from the workspace key probe", rpm.Limit)
+	}
+	if series := snap.DailySeries["cost"]; len(series) != 1 {
+		t.Errorf("cost series = %v, want one day", series)
+	}
+}
+
+func TestFetch_DefaultWorkspaceMatchesNullRows(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/organizations/cost_report":
+			w.Write([]byte(`{"data":[{"starting_at":"2026-01-01T00:00:00Z","results":[
+				{"workspace_id":"wrkspc_prod","currency":"USD","amount":"9999"},
+				{"workspace_id":null,"currency":"USD","amount":"500"}
+			]}]}`))
+		case "/organizations/usage_report/messages":
+			if strings.Contains(r.URL.RawQuery, "workspace_ids") {
+				t.Errorf("default workspace must not be filtered by ID: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data":[]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_ANTHROPIC_ADMIN_KEY", "admin-key")
+	snap, err := New().Fetch(context.Background(), core.AccountConfig{
+		ID:            "anthropic-default",
+		Provider:      "anthropic",
+		APIKeyEnv:     "TEST_ANTHROPIC_UNSET",
+		BaseURL:       server.URL,
+		ProviderPaths: map[string]string{"workspace_id": "default", "admin_key_env": "TEST_ANTHROPIC_ADMIN_KEY"},
+	})
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if m := snap.Metrics["monthly_spend"]; m.Used == nil || *m.Used != 5 {
+		t.Errorf("monthly_spend = %v, want 5", m.Used)
+	}
+	if got := snap.Attributes["workspace_name"]; got != "Default" {
+		t.Errorf("workspace_name = %q, want Default", got)
+	}
+}
+
+func TestFetch_WorkspaceAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	acct := core.AccountConfig{
+		ID:            "anthropic-prod",
+		Provider:      "anthropic",
+		BaseURL:       server.URL,
+		ProviderPaths: map[string]string{"workspace_id": "wrkspc_prod", "admin_key_env": "TEST_ANTHROPIC_ADMIN_KEY"},
+	}
+
+	t.Setenv("TEST_ANTHROPIC_ADMIN_KEY", "")
+	snap, err := New().Fetch(context.Background(), acct)
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if snap.Status != core.StatusAuth || !strings.Contains(snap.Message, "TEST_ANTHROPIC_ADMIN_KEY") {
+		t.Errorf("missing admin key: status = %v, message = %q", snap.Status, snap.Message)
+	}
+
+	t.Setenv("TEST_ANTHROPIC_ADMIN_KEY", "not-an-admin-key")
+	snap, err = New().Fetch(context.Background(), acct)
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if snap.Status != core.StatusAuth {
+		t.Errorf("rejected admin key: status = %v, want AUTH_REQUIRED", snap.Status)
+	}
+}
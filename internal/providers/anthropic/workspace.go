@@ -0,0 +1,366 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
+)
+
+const (
+	// adminKeyEnv holds an Admin API key (sk-ant-admin…). Workspace usage and
+	// cost reports are only readable with one; regular API keys get 401.
+	adminKeyEnv = "ANTHROPIC_ADMIN_KEY"
+
+	// defaultWorkspaceID selects the organization's default workspace, which
+	// has no ID in the Admin API (workspace_id is null in reports).
+	defaultWorkspaceID = "default"
+
+	// maxReportPages bounds pagination of the usage and cost reports; a
+	// month of daily buckets fits in one or two pages.
+	maxReportPages = 5
+)
+
+// workspaceID returns the account's configured workspace (the workspace_id
+// setting), or "" for an organization-wide key probe.
+func workspaceID(acct core.AccountConfig) string {
+	return strings.TrimSpace(acct.Path("workspace_id", ""))
+}
+
+// resolveAdminKey returns the account's Admin API key. The env var name
+// defaults to ANTHROPIC_ADMIN_KEY and can be overridden per account with the
+// admin_key_env setting, so accounts of different organizations can coexist.
+func resolveAdminKey(acct core.AccountConfig) (key, envName string) {
+	envName = acct.Path("admin_key_env", adminKeyEnv)
+	return strings.TrimSpace(os.Getenv(envName)), envName
+}
+
+type workspaceResponse struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	ArchivedAt *string `json:"archived_at"`
+}
+
+type usageReportResponse struct {
+	Data     []usageReportBucket `json:"data"`
+	HasMore  bool                `json:"has_more"`
+	NextPage string              `json:"next_page"`
+}
+
+type usageReportBucket struct {
+	StartingAt string              `json:"starting_at"`
+	Results    []usageReportResult `json:"results"`
+}
+
+type usageReportResult struct {
+	WorkspaceID          *string `json:"workspace_id"`
+	Model                string  `json:"model"`
+	UncachedInputTokens  float64 `json:"uncached_input_tokens"`
+	CacheReadInputTokens float64 `json:"cache_read_input_tokens"`
+	OutputTokens         float64 `json:"output_tokens"`
+	CacheCreation        struct {
+		Ephemeral1hInputTokens float64 `json:"ephemeral_1h_input_tokens"`
+		Ephemeral5mInputTokens float64 `json:"ephemeral_5m_input_tokens"`
+	} `json:"cache_creation"`
+}
+
+type costReportResponse struct {
+	Data     []costReportBucket `json:"data"`
+	HasMore  bool               `json:"has_more"`
+	NextPage string             `json:"next_page"`
+}
+
+type costReportBucket struct {
+	StartingAt string             `json:"starting_at"`
+	Results    []costReportResult `json:"results"`
+}
+
+type costReportResult struct {
+	WorkspaceID *string `json:"workspace_id"`
+	Model       string  `json:"model"`
+	Currency    string  `json:"currency"`
+	// Amount is a decimal string in the currency's lowest unit (cents).
+	Amount string `json:"amount"`
+}
+
+type modelTotals struct {
+	input, output, cacheRead, cacheWrite, cost float64
+	hasCost                                    bool
+}
+
+// fetchWorkspace reports one workspace: month-to-date spend and tokens from
+// the Admin API's cost and usage reports, plus the rate-limit headers of the
+// regular probe when the account also has an API key from that workspace
+// (Anthropic reports the workspace's own limits when they are lower than the
+// organization's).
+func (p *Provider) fetchWorkspace(ctx context.Context, acct core.AccountConfig, wsID string) (core.UsageSnapshot, error) {
+	adminKey, envName := resolveAdminKey(acct)
+	if adminKey == "" {
+		return core.NewAuthSnapshot(p.ID(), acct.ID,
+			fmt.Sprintf("workspace accounts need an Admin API key (set %s)", envName)), nil
+	}
+	baseURL := shared.ResolveBaseURL(acct, defaultBaseURL)
+
+	snap := core.NewUsageSnapshot(p.ID(), acct.ID)
+	if apiKey := acct.ResolveAPIKey(); apiKey != "" {
+		probed, err := p.probe(ctx, acct, baseURL, apiKey)
+		if err != nil {
+			snap.SetDiagnostic("rate_limit_probe_error", err.Error())
+		} else {
+			snap = probed
+		}
+	}
+	snap.SetAttribute("workspace_id", wsID)
+
+	if wsID == defaultWorkspaceID {
+		snap.SetAttribute("workspace_name", "Default")
+	} else {
+		var ws workspaceResponse
+		status, err := p.adminGet(ctx, baseURL, "/organizations/workspaces/"+url.PathEscape(wsID), nil, adminKey, &ws)
+		if err != nil {
+			return p.adminFailure(snap, status, envName, wsID, err)
+		}
+		snap.SetAttribute("workspace_name", ws.Name)
+		if ws.ArchivedAt != nil && *ws.ArchivedAt != "" {
+			snap.SetAttribute("workspace_archived_at", *ws.ArchivedAt)
+		}
+	}
+
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	costs, status, err := p.fetchCostReport(ctx, baseURL, adminKey, monthStart)
+	if err != nil {
+		return p.adminFailure(snap, status, envName, wsID, err)
+	}
+	usage, status, err := p.fetchUsageReport(ctx, baseURL, adminKey, wsID, monthStart)
+	if err != nil {
+		return p.adminFailure(snap, status, envName, wsID, err)
+	}
+	applyWorkspaceReports(&snap, wsID, costs, usage, now)
+
+	shared.FinalizeStatus(&snap)
+	return snap, nil
+}
+
+// adminFailure maps an Admin API error onto the snapshot: auth problems show
+// as AUTH naming the admin key env var, anything else is returned as an error.
+func (p *Provider) adminFailure(snap core.UsageSnapshot, status int, envName, wsID string, err error) (core.UsageSnapshot, error) {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		shared.ApplyStatusFromCode(status, &snap, envName+" (an Admin API key)")
+		return snap, nil
+	case http.StatusNotFound:
+		snap.Status = core.StatusError
+		snap.Message = fmt.Sprintf("workspace %s not found", wsID)
+		return snap, nil
+	case http.StatusTooManyRequests:
+		shared.ApplyStatusFromCode(status, &snap, "")
+		return snap, nil
+	}
+	return snap, fmt.Errorf("anthropic: admin API: %w", err)
+}
+
+func (p *Provider) fetchCostReport(ctx context.Context, baseURL, adminKey string, since time.Time) ([]costReportBucket, int, error) {
+	query := url.Values{}
+	query.Set("starting_at", since.Format(time.RFC3339))
+	query.Add("group_by[]", "workspace_id")
+	query.Add("group_by[]", "description")
+	query.Set("limit", "31")
+
+	var buckets []costReportBucket
+	for page := 0; page < maxReportPages; page++ {
+		var resp costReportResponse
+		if status, err := p.adminGet(ctx, baseURL, "/organizations/cost_report", query, adminKey, &resp); err != nil {
+			return nil, status, err
+		}
+		buckets = append(buckets, resp.Data...)
+		if !resp.HasMore || resp.NextPage == "" {
+			break
+		}
+		query.Set("page", resp.NextPage)
+	}
+	return buckets, http.StatusOK, nil
+}
+
+func (p *Provider) fetchUsageReport(ctx context.Context, baseURL, adminKey, wsID string, since time.Time) ([]usageReportBucket, int, error) {
+	query := url.Values{}
+	query.Set("starting_at", since.Format(time.RFC3339))
+	query.Set("bucket_width", "1d")
+	query.Add("group_by[]", "workspace_id")
+	query.Add("group_by[]", "model")
+	query.Set("limit", "31")
+	if wsID != defaultWorkspaceID {
+		query.Add("workspace_ids[]", wsID)
+	}
+
+	var buckets []usageReportBucket
+	for page := 0; page < maxReportPages; page++ {
+		var resp usageReportResponse
+		if status, err := p.adminGet(ctx, baseURL, "/organizations/usage_report/messages", query, adminKey, &resp); err != nil {
+			return nil, status, err
+		}
+		buckets = append(buckets, resp.Data...)
+		if !resp.HasMore || resp.NextPage == "" {
+			break
+		}
+		query.Set("page", resp.NextPage)
+	}
+	return buckets, http.StatusOK, nil
+}
+
+func (p *Provider) adminGet(ctx context.Context, baseURL, endpoint string, query url.Values, adminKey string, out any) (int, error) {
+	target := baseURL + endpoint
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return 0, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("x-api-key", adminKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+
+	resp, err := p.Client().Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("%s: HTTP %d", endpoint, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("%s: parsing response: %w", endpoint, err)
+	}
+	return resp.StatusCode, nil
+}
+
+// matchesWorkspace reports whether a report row belongs to wsID. Rows of the
+// default workspace carry a null workspace_id.
+func matchesWorkspace(rowWorkspace *string, wsID string) bool {
+	if rowWorkspace == nil || *rowWorkspace == "" {
+		return wsID == defaultWorkspaceID
+	}
+	return *rowWorkspace == wsID
+}
+
+func applyWorkspaceReports(snap *core.UsageSnapshot, wsID string, costs []costReportBucket, usage []usageReportBucket, now time.Time) {
+	byModel := make(map[string]*modelTotals)
+	model := func(name string) *modelTotals {
+		key := shared.SanitizeMetricName(name)
+		if byModel[key] == nil {
+			byModel[key] = &modelTotals{}
+		}
+		return byModel[key]
+	}
+
+	today := now.Format("2006-01-02")
+	var monthCost, todayCost float64
+	costByDay := make(map[string]float64)
+	for _, bucket := range costs {
+		day := bucketDay(bucket.StartingAt)
+		for _, row := range bucket.Results {
+			if !matchesWorkspace(row.WorkspaceID, wsID) {
+				continue
+			}
+			if row.Currency != "" && !strings.EqualFold(row.Currency, "USD") {
+				continue
+			}
+			cents, err := strconv.ParseFloat(strings.TrimSpace(row.Amount), 64)
+			if err != nil {
+				continue
+			}
+			usd := cents / 100
+			monthCost += usd
+			if day != "" {
+				costByDay[day] += usd
+			}
+			if day == today {
+				todayCost += usd
+			}
+			if row.Model != "" {
+				m := model(row.Model)
+				m.cost += usd
+				m.hasCost = true
+			}
+		}
+	}
+
+	var monthInput, monthOutput float64
+	for _, bucket := range usage {
+		for _, row := range bucket.Results {
+			if !matchesWorkspace(row.WorkspaceID, wsID) {
+				continue
+			}
+			cacheWrite := row.CacheCreation.Ephemeral1hInputTokens + row.CacheCreation.Ephemeral5mInputTokens
+			monthInput += row.UncachedInputTokens + row.CacheReadInputTokens + cacheWrite
+			monthOutput += row.OutputTokens
+			if row.Model == "" {
+				continue
+			}
+			m := model(row.Model)
+			m.input += row.UncachedInputTokens
+			m.output += row.OutputTokens
+			m.cacheRead += row.CacheReadInputTokens
+			m.cacheWrite += cacheWrite
+		}
+	}
+
+	snap.Metrics["monthly_spend"] = core.Metric{Used: &monthCost, Unit: "USD", Window: "month"}
+	snap.Metrics["today_cost"] = core.Metric{Used: &todayCost, Unit: "USD", Window: "today"}
+	snap.Metrics["monthly_input_tokens"] = core.Metric{Used: &monthInput, Unit: "tokens", Window: "month"}
+	snap.Metrics["monthly_output_tokens"] = core.Metric{Used: &monthOutput, Unit: "tokens", Window: "month"}
+
+	for name, m := range byModel {
+		prefix := "model_" + name
+		setTokenMetric(snap, prefix+"_input_tokens", m.input)
+		setTokenMetric(snap, prefix+"_output_tokens", m.output)
+		setTokenMetric(snap, prefix+"_cache_read_tokens", m.cacheRead)
+		setTokenMetric(snap, prefix+"_cache_write_tokens", m.cacheWrite)
+		if m.hasCost {
+			cost := m.cost
+			snap.Metrics[prefix+"_cost_usd"] = core.Metric{Used: &cost, Unit: "USD", Window: "month"}
+		}
+	}
+
+	if len(costByDay) > 0 {
+		days := make([]string, 0, len(costByDay))
+		for day := range costByDay {
+			days = append(days, day)
+		}
+		sort.Strings(days)
+		series := make([]core.TimePoint, 0, len(days))
+		for _, day := range days {
+			series = append(series, core.TimePoint{Date: day, Value: costByDay[day]})
+		}
+		if snap.DailySeries == nil {
+			snap.DailySeries = make(map[string][]core.TimePoint)
+		}
+		snap.DailySeries["cost"] = series
+	}
+}
+
+func setTokenMetric(snap *core.UsageSnapshot, key string, value float64) {
+	if value <= 0 {
+		return
+	}
+	snap.Metrics[key] = core.Metric{Used: &value, Unit: "tokens", Window: "month"}
+}
+
+// bucketDay returns the UTC date of a report bucket's RFC3339 starting_at.
+func bucketDay(startingAt string) string {
+	t, err := time.Parse(time.RFC3339, startingAt)
+	if err != nil {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02")
+}
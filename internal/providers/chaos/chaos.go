@@ -0,0 +1,247 @@
+// Package chaos wraps providers with a failure-injection layer for
+// development. A wrapped provider's Fetch sometimes fails the way real
+// providers do — hanging until the poll times out, rejecting credentials,
+// rate limiting with Retry-After, returning a body that is not JSON — so the
+// daemon's backoff, stale-data handling and the dashboard's degraded states
+// can be exercised without breaking a real account.
+//
+// Faults are chosen from a hash of the seed, the account and how many times
+// that account has been fetched, so a seed replays the same sequence per
+// account however the fetches interleave.
+package chaos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// EnvVar enables chaos mode for the telemetry daemon, e.g.
+// OPENUSAGE_CHAOS="seed=42,rate=0.3,faults=timeout+limited".
+const EnvVar = "OPENUSAGE_CHAOS"
+
+// Fault is one kind of injected failure.
+type Fault string
+
+const (
+	// FaultTimeout blocks until the fetch context is done, as a provider
+	// that never answers does.
+	FaultTimeout Fault = "timeout"
+	// FaultAuth returns an AUTH_REQUIRED snapshot, as an HTTP 401 does.
+	FaultAuth Fault = "auth"
+	// FaultLimited returns a LIMITED snapshot carrying retry_after, as an
+	// HTTP 429 with a Retry-After header does.
+	FaultLimited Fault = "limited"
+	// FaultMalformed fails with the error a truncated JSON body produces.
+	FaultMalformed Fault = "malformed"
+)
+
+// AllFaults lists every fault in the order they are documented.
+var AllFaults = []Fault{FaultTimeout, FaultAuth, FaultLimited, FaultMalformed}
+
+const (
+	defaultSeed       = 1
+	defaultRate       = 0.25
+	defaultRetryAfter = time.Minute
+
+	// maxTimeoutWait bounds a timeout fault when the fetch context has no
+	// deadline, so a caller without one is not hung forever.
+	maxTimeoutWait = 30 * time.Second
+
+	injectedSuffix = " (injected by chaos mode)"
+)
+
+// Config controls what is injected and how often.
+type Config struct {
+	// Seed makes the fault sequence reproducible.
+	Seed int64
+	// Rate is the share of fetches that fail, between 0 and 1.
+	Rate float64
+	// Faults are the failures to choose from, uniformly.
+	Faults []Fault
+	// RetryAfter is the Retry-After a limited fault reports.
+	RetryAfter time.Duration
+}
+
+// DefaultConfig fails a quarter of fetches with any fault.
+func DefaultConfig() Config {
+	return Config{
+		Seed:       defaultSeed,
+		Rate:       defaultRate,
+		Faults:     append([]Fault(nil), AllFaults...),
+		RetryAfter: defaultRetryAfter,
+	}
+}
+
+// ParseSpec reads a comma-separated key=value spec: seed (integer), rate
+// (0..1), faults (names joined by "+") and retry_after (Go duration or
+// seconds). Missing keys keep their defaults; "1", "true" and "on" mean the
+// defaults as they are.
+func ParseSpec(spec string) (Config, error) {
+	cfg := DefaultConfig()
+	spec = strings.TrimSpace(spec)
+	switch strings.ToLower(spec) {
+	case "", "1", "true", "on":
+		return cfg, nil
+	}
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Config{}, fmt.Errorf("chaos: %q is not key=value", part)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "seed":
+			seed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return Config{}, fmt.Errorf("chaos: seed %q is not an integer", value)
+			}
+			cfg.Seed = seed
+		case "rate":
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil || rate < 0 || rate > 1 {
+				return Config{}, fmt.Errorf("chaos: rate %q is not between 0 and 1", value)
+			}
+			cfg.Rate = rate
+		case "faults":
+			faults, err := parseFaults(value)
+			if err != nil {
+				return Config{}, err
+			}
+			cfg.Faults = faults
+		case "retry_after":
+			d, err := parseDuration(value)
+			if err != nil || d <= 0 {
+				return Config{}, fmt.Errorf("chaos: retry_after %q is not a positive duration", value)
+			}
+			cfg.RetryAfter = d
+		default:
+			return Config{}, fmt.Errorf("chaos: unknown key %q (known: seed, rate, faults, retry_after)", key)
+		}
+	}
+	return cfg, nil
+}
+
+func parseFaults(value string) ([]Fault, error) {
+	var faults []Fault
+	for _, name := range strings.Split(value, "+") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if name == "all" {
+			return append([]Fault(nil), AllFaults...), nil
+		}
+		known := false
+		for _, f := range AllFaults {
+			if string(f) == name {
+				faults = append(faults, f)
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("chaos: unknown fault %q (known: timeout, auth, limited, malformed)", name)
+		}
+	}
+	if len(faults) == 0 {
+		return nil, fmt.Errorf("chaos: faults is empty")
+	}
+	return faults, nil
+}
+
+func parseDuration(value string) (time.Duration, error) {
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(secs) * time.Second, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// String renders the config in ParseSpec's format.
+func (c Config) String() string {
+	names := make([]string, len(c.Faults))
+	for i, f := range c.Faults {
+		names[i] = string(f)
+	}
+	return fmt.Sprintf("seed=%d,rate=%s,faults=%s,retry_after=%s",
+		c.Seed, strconv.FormatFloat(c.Rate, 'f', -1, 64), strings.Join(names, "+"), c.RetryAfter)
+}
+
+// injector decides, per fetch, whether and how to fail. It is shared by all
+// wrapped providers so each account has one call counter.
+type injector struct {
+	cfg Config
+
+	mu    sync.Mutex
+	calls map[string]uint64
+}
+
+func newInjector(cfg Config) *injector {
+	if cfg.RetryAfter <= 0 {
+		cfg.RetryAfter = defaultRetryAfter
+	}
+	return &injector{cfg: cfg, calls: make(map[string]uint64)}
+}
+
+// next returns the fault for the account's next fetch, if any.
+func (in *injector) next(providerID, accountID string) (Fault, bool) {
+	key := providerID + "/" + accountID
+	in.mu.Lock()
+	n := in.calls[key]
+	in.calls[key] = n + 1
+	in.mu.Unlock()
+	return decide(in.cfg, key, n)
+}
+
+func decide(cfg Config, key string, n uint64) (Fault, bool) {
+	if cfg.Rate <= 0 || len(cfg.Faults) == 0 {
+		return "", false
+	}
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	rng := rand.New(rand.NewPCG(uint64(cfg.Seed)^h.Sum64(), n))
+	if rng.Float64() >= cfg.Rate {
+		return "", false
+	}
+	return cfg.Faults[rng.IntN(len(cfg.Faults))], true
+}
+
+// inject produces the snapshot or error for a fault.
+func (in *injector) inject(ctx context.Context, fault Fault, providerID string, acct core.AccountConfig) (core.UsageSnapshot, error) {
+	switch fault {
+	case FaultTimeout:
+		timer := time.NewTimer(maxTimeoutWait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return core.UsageSnapshot{}, fmt.Errorf("%s: request timed out: %w"+injectedSuffix, providerID, ctx.Err())
+		case <-timer.C:
+			return core.UsageSnapshot{}, fmt.Errorf("%s: request timed out: %w"+injectedSuffix, providerID, context.DeadlineExceeded)
+		}
+	case FaultAuth:
+		return core.NewAuthSnapshot(providerID, acct.ID, "HTTP 401 – check API key"+injectedSuffix), nil
+	case FaultLimited:
+		snap := core.NewUsageSnapshot(providerID, acct.ID)
+		snap.Status = core.StatusLimited
+		snap.Message = "HTTP 429 – rate limited" + injectedSuffix
+		snap.Raw["retry_after"] = strconv.Itoa(int(in.cfg.RetryAfter.Seconds()))
+		return snap, nil
+	default:
+		var body map[string]any
+		err := json.Unmarshal([]byte(`{"data":[{"usage":`), &body)
+		return core.UsageSnapshot{}, fmt.Errorf("%s: parsing response: %w"+injectedSuffix, providerID, err)
+	}
+}
@@ -0,0 +1,156 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+type stubProvider struct {
+	id      string
+	fetches int
+}
+
+func (p *stubProvider) ID() string                            { return p.id }
+func (p *stubProvider) Describe() core.ProviderInfo           { return core.ProviderInfo{Name: p.id} }
+func (p *stubProvider) Spec() core.ProviderSpec               { return core.ProviderSpec{ID: p.id} }
+func (p *stubProvider) DashboardWidget() core.DashboardWidget { return core.DashboardWidget{} }
+func (p *stubProvider) DetailWidget() core.DetailWidget       { return core.DetailWidget{} }
+
+func (p *stubProvider) Fetch(_ context.Context, acct core.AccountConfig) (core.UsageSnapshot, error) {
+	p.fetches++
+	snap := core.NewUsageSnapshot(p.id, acct.ID)
+	snap.Status = core.StatusOK
+	return snap, nil
+}
+
+type detectingProvider struct{ stubProvider }
+
+func (p *detectingProvider) HasChanged(core.AccountConfig, time.Time) (bool, error) {
+	return false, nil
+}
+
+func TestParseSpec(t *testing.T) {
+	cfg, err := ParseSpec("seed=42, rate=0.5, faults=auth+limited, retry_after=90")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Seed != 42 || cfg.Rate != 0.5 || cfg.RetryAfter != 90*time.Second {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if len(cfg.Faults) != 2 || cfg.Faults[0] != FaultAuth || cfg.Faults[1] != FaultLimited {
+		t.Errorf("faults = %v, want auth+limited", cfg.Faults)
+	}
+	if got := cfg.String(); got != "seed=42,rate=0.5,faults=auth+limited,retry_after=1m30s" {
+		t.Errorf("String() = %q", got)
+	}
+
+	if cfg, err := ParseSpec("1"); err != nil || cfg.Rate != defaultRate || len(cfg.Faults) != len(AllFaults) {
+		t.Errorf("ParseSpec(1) = %+v, %v; want the defaults", cfg, err)
+	}
+
+	for _, bad := range []string{"rate=2", "seed=x", "faults=explode", "faults=", "speed=1", "rate"} {
+		if _, err := ParseSpec(bad); err == nil {
+			t.Errorf("ParseSpec(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestDecide_IsDeterministicPerAccount(t *testing.T) {
+	cfg := Config{Seed: 7, Rate: 0.5, Faults: AllFaults}
+	sequence := func(seed int64, key string) string {
+		cfg.Seed = seed
+		var b strings.Builder
+		for n := uint64(0); n < 64; n++ {
+			fault, ok := decide(cfg, key, n)
+			if !ok {
+				fault = "-"
+			}
+			b.WriteString(string(fault) + ",")
+		}
+		return b.String()
+	}
+
+	first := sequence(7, "openai/work")
+	if again := sequence(7, "openai/work"); again != first {
+		t.Fatal("same seed and account produced different fault sequences")
+	}
+	if other := sequence(8, "openai/work"); other == first {
+		t.Error("different seeds produced the same fault sequence")
+	}
+	for _, f := range AllFaults {
+		if !strings.Contains(first, string(f)+",") {
+			t.Errorf("64 fetches at rate 0.5 never injected %s", f)
+		}
+	}
+
+	if _, ok := decide(Config{Seed: 7, Rate: 0, Faults: AllFaults}, "openai/work", 0); ok {
+		t.Error("rate 0 injected a fault")
+	}
+}
+
+func TestWrap_InjectsFaults(t *testing.T) {
+	acct := core.AccountConfig{ID: "work", Provider: "stub"}
+	tests := []struct {
+		fault Fault
+		check func(t *testing.T, snap core.UsageSnapshot, err error)
+	}{
+		{FaultAuth, func(t *testing.T, snap core.UsageSnapshot, err error) {
+			if err != nil || snap.Status != core.StatusAuth {
+				t.Errorf("auth: status = %s, err = %v", snap.Status, err)
+			}
+		}},
+		{FaultLimited, func(t *testing.T, snap core.UsageSnapshot, err error) {
+			if err != nil || snap.Status != core.StatusLimited || snap.Raw["retry_after"] != "120" {
+				t.Errorf("limited: status = %s, retry_after = %q, err = %v", snap.Status, snap.Raw["retry_after"], err)
+			}
+		}},
+		{FaultMalformed, func(t *testing.T, _ core.UsageSnapshot, err error) {
+			if err == nil || !strings.Contains(err.Error(), "parsing response: unexpected end of JSON input") {
+				t.Errorf("malformed: err = %v", err)
+			}
+		}},
+		{FaultTimeout, func(t *testing.T, _ core.UsageSnapshot, err error) {
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("timeout: err = %v, want a deadline error", err)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.fault), func(t *testing.T) {
+			base := &stubProvider{id: "stub"}
+			wrapped := Wrap([]core.UsageProvider{base}, Config{Seed: 1, Rate: 1, Faults: []Fault{tt.fault}, RetryAfter: 2 * time.Minute})[0]
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			snap, err := wrapped.Fetch(ctx, acct)
+			tt.check(t, snap, err)
+			if base.fetches != 0 {
+				t.Errorf("injected fault still reached the provider")
+			}
+		})
+	}
+}
+
+func TestWrap_PassesThroughAndKeepsInterfaces(t *testing.T) {
+	plain := &stubProvider{id: "plain"}
+	detecting := &detectingProvider{stubProvider{id: "detecting"}}
+	engine := &stubProvider{id: core.EngineProviderID}
+	wrapped := Wrap([]core.UsageProvider{plain, detecting, engine}, Config{Seed: 1, Rate: 0, Faults: AllFaults})
+
+	if snap, err := wrapped[0].Fetch(context.Background(), core.AccountConfig{ID: "a"}); err != nil || snap.Status != core.StatusOK || plain.fetches != 1 {
+		t.Errorf("rate 0 fetch = %s, %v; want the provider's snapshot", snap.Status, err)
+	}
+	if _, ok := wrapped[0].(core.ChangeDetector); ok {
+		t.Error("wrapping added change detection to a provider without it")
+	}
+	if _, ok := wrapped[1].(core.ChangeDetector); !ok {
+		t.Error("wrapping dropped change detection")
+	}
+	if wrapped[2] != core.UsageProvider(engine) {
+		t.Error("the engine provider was wrapped")
+	}
+}
@@ -0,0 +1,82 @@
+package chaos
+
+import (
+	"context"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// Wrap returns the providers with failure injection added to Fetch. The
+// engine's own provider is left alone: it reports the poll loop's health and
+// never fetches. A wrapped provider keeps the optional interfaces the daemon
+// checks for, so change detection and spend limits behave as without chaos.
+func Wrap(providers []core.UsageProvider, cfg Config) []core.UsageProvider {
+	in := newInjector(cfg)
+	out := make([]core.UsageProvider, 0, len(providers))
+	for _, p := range providers {
+		if p.ID() == core.EngineProviderID {
+			out = append(out, p)
+			continue
+		}
+		out = append(out, wrapOne(p, in))
+	}
+	return out
+}
+
+func wrapOne(base core.UsageProvider, in *injector) core.UsageProvider {
+	p := &provider{base: base, in: in}
+	detector, isDetector := base.(core.ChangeDetector)
+	setter, isSetter := base.(core.SpendLimitSetter)
+	switch {
+	case isDetector && isSetter:
+		return &struct {
+			*provider
+			core.ChangeDetector
+			core.SpendLimitSetter
+		}{p, detector, setter}
+	case isDetector:
+		return &struct {
+			*provider
+			core.ChangeDetector
+		}{p, detector}
+	case isSetter:
+		return &struct {
+			*provider
+			core.SpendLimitSetter
+		}{p, setter}
+	}
+	return p
+}
+
+type provider struct {
+	base core.UsageProvider
+	in   *injector
+}
+
+func (p *provider) ID() string {
+	return p.base.ID()
+}
+
+func (p *provider) Describe() core.ProviderInfo {
+	return p.base.Describe()
+}
+
+func (p *provider) Spec() core.ProviderSpec {
+	return p.base.Spec()
+}
+
+func (p *provider) DashboardWidget() core.DashboardWidget {
+	return p.base.DashboardWidget()
+}
+
+func (p *provider) DetailWidget() core.DetailWidget {
+	return p.base.DetailWidget()
+}
+
+func (p *provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.UsageSnapshot, error) {
+	if fault, ok := p.in.next(p.base.ID(), acct.ID); ok {
+		core.Tracef("[chaos] %s/%s: injecting %s", p.base.ID(), acct.ID, fault)
+		return p.in.inject(ctx, fault, p.base.ID(), acct)
+	}
+	return p.base.Fetch(ctx, acct)
+}
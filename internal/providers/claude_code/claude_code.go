@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/janekbaraniewski/openusage/internal/audit"
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/pricing"
 	"github.com/janekbaraniewski/openusage/internal/providers/providerbase"
@@ -18,9 +19,11 @@ import (
 
 type Provider struct {
 	providerbase.Base
-	mu                  sync.Mutex
-	usageAPICache       *usageResponse // last successful Usage API response
-	lastUsageAuthSource string         // name of the usageAuthSource that last succeeded; empty until a first success
+	mu sync.Mutex
+	// Keyed by account ID, so separate installs (stable and nightly, each
+	// with its own CLAUDE_CONFIG_DIR) never serve each other's usage.
+	usageAPICache       map[string]*usageResponse // last successful Usage API response
+	lastUsageAuthSource map[string]string         // name of the usageAuthSource that last succeeded; absent until a first success
 
 	jsonlCacheMu sync.Mutex
 	jsonlCache   map[string]*jsonlCacheEntry // keyed by file path
@@ -70,6 +73,11 @@ func New() *Provider {
 				},
 			},
 			Dashboard: dashboardWidget(),
+			Actions: []core.ProviderAction{
+				{Label: "Open status page", Kind: core.ProviderActionOpenURL, Target: "https://status.anthropic.com"},
+				{Label: "Open stats file", Kind: core.ProviderActionOpenPath, SnapshotKey: "stats_path"},
+			},
+			LocalData: LocalData,
 		}),
 	}
 }
@@ -304,6 +312,18 @@ func estimateCost(model string, u *jsonlUsage) float64 {
 	return cost
 }
 
+// estimateCacheSavings returns what prompt caching saved on u: its cost with
+// the cache reads and writes billed as fresh input, minus its actual cost.
+func estimateCacheSavings(model string, u *jsonlUsage) float64 {
+	if u == nil || u.CacheReadInputTokens+u.CacheCreationInputTokens == 0 {
+		return 0
+	}
+	uncached := *u
+	uncached.InputTokens += u.CacheReadInputTokens + u.CacheCreationInputTokens
+	uncached.CacheReadInputTokens, uncached.CacheCreationInputTokens = 0, 0
+	return estimateCost(model, &uncached) - estimateCost(model, u)
+}
+
 type modelUsageTotals struct {
 	input       float64
 	output      float64
@@ -330,16 +350,17 @@ func (p *Provider) DetailWidget() core.DetailWidget {
 // HasChanged reports whether any of the local data sources have been modified since the given time.
 func (p *Provider) HasChanged(acct core.AccountConfig, since time.Time) (bool, error) {
 	home, _ := os.UserHomeDir()
-	claudeDir := filepath.Join(home, ".claude")
+	claudeDir := defaultClaudeDir(home)
 	if override := acct.Hint("claude_dir", ""); override != "" {
 		claudeDir = override
 		home = filepath.Dir(claudeDir)
 	}
 
 	normalizeLegacyPaths(&acct)
+	projectsDir, altProjectsDir := conversationDirs(acct, claudeDir, home)
 	return shared.AnyPathModifiedAfter([]string{
-		filepath.Join(claudeDir, "projects"),
-		filepath.Join(home, ".config", "claude", "projects"),
+		projectsDir,
+		altProjectsDir,
 		acct.Path("stats_cache", ""),
 		acct.Path("account_config", filepath.Join(home, ".claude.json")),
 		filepath.Join(claudeDir, "settings.json"),
@@ -362,7 +383,7 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 	}
 
 	home, _ := os.UserHomeDir()
-	claudeDir := filepath.Join(home, ".claude")
+	claudeDir := defaultClaudeDir(home)
 	if override := acct.Hint("claude_dir", ""); override != "" {
 		claudeDir = override
 		home = filepath.Dir(claudeDir) // derive "home" from the override
@@ -406,23 +427,30 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 		snap.Raw["settings_error"] = err.Error()
 	}
 
-	projectsDir := filepath.Join(claudeDir, "projects")
-	newProjectsDir := filepath.Join(home, ".config", "claude", "projects")
-
-	if err := p.readConversationJSONL(projectsDir, newProjectsDir, &snap); err != nil {
+	projectsDir, altProjectsDir := conversationDirs(acct, claudeDir, home)
+	blockStarts, err := p.readConversationJSONL(projectsDir, altProjectsDir, &snap)
+	if err != nil {
 		snap.Raw["jsonl_error"] = err.Error()
 	} else {
 		hasData = true
 	}
 
 	if orgUUID, ok := snap.Raw["organization_uuid"]; ok && orgUUID != "" {
-		if err := p.readUsageAPI(ctx, orgUUID, &snap); err != nil {
+		if err := p.readUsageAPI(ctx, orgUUID, claudeDir, &snap); err != nil {
 			snap.Raw["usage_api_error"] = err.Error()
 		} else {
 			hasData = true
 		}
 	}
 
+	plan, ok := parseClaudePlan(acct.Plan)
+	if !ok {
+		plan, ok = readClaudeCodePlan(ctx, claudeDir)
+	}
+	if ok {
+		applyPlanBudget(&snap, plan, blockStarts, snap.Timestamp)
+	}
+
 	normalizeModelUsage(&snap)
 
 	if !hasData {
@@ -439,19 +467,26 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 // prepare resolves credentials and, on success, returns the URL to call and
 // a closure that applies auth headers to the request; it errs without
 // making a request if the credential it needs (cookies, an OAuth token)
-// isn't available.
+// isn't available. credentials describes what prepare reads, for the
+// access audit log.
 type usageAuthSource struct {
-	name    string
-	prepare func() (url string, setAuth func(*http.Request), err error)
+	name        string
+	credentials []string
+	prepare     func() (url string, setAuth func(*http.Request), err error)
 }
 
 // usageAuthSources lists the auth sources in priority order: cookie/org
 // (macOS desktop app) first, then the CLI's own OAuth token as the fallback
 // used everywhere the desktop app's session cookies aren't available.
-func (p *Provider) usageAuthSources(orgUUID string) []usageAuthSource {
+func (p *Provider) usageAuthSources(orgUUID, claudeDir string) []usageAuthSource {
+	credsFile := "~/.claude/.credentials.json"
+	if claudeDir != "" {
+		credsFile = filepath.Join(claudeDir, ".credentials.json")
+	}
 	return []usageAuthSource{
 		{
-			name: "cookie",
+			name:        "cookie",
+			credentials: []string{"keychain:Claude Safe Storage", "file:~/Library/Application Support/Claude/Cookies"},
 			prepare: func() (string, func(*http.Request), error) {
 				cookies, err := getClaudeSessionCookies()
 				if err != nil {
@@ -462,9 +497,10 @@ func (p *Provider) usageAuthSources(orgUUID string) []usageAuthSource {
 			},
 		},
 		{
-			name: "oauth",
+			name:        "oauth",
+			credentials: []string{"file:" + credsFile},
 			prepare: func() (string, func(*http.Request), error) {
-				token, err := readClaudeCodeOAuthToken()
+				token, err := readClaudeCodeOAuthToken(claudeDir)
 				if err != nil {
 					return "", nil, err
 				}
@@ -506,17 +542,17 @@ func oauthAuthHeaders(token string) func(*http.Request) {
 // source has succeeded, it's pinned (p.lastUsageAuthSource) and later calls
 // go straight to it instead of re-probing every source on every poll; if the
 // pinned source stops working, the pin is cleared so the next call re-scans
-// all sources from scratch.
-func (p *Provider) readUsageAPI(ctx context.Context, orgUUID string, snap *core.UsageSnapshot) error {
-	sources := p.usageAuthSources(orgUUID)
+// all sources from scratch. Pins and cached responses are per account.
+func (p *Provider) readUsageAPI(ctx context.Context, orgUUID, claudeDir string, snap *core.UsageSnapshot) error {
+	sources := p.usageAuthSources(orgUUID, claudeDir)
 
-	if pinned := p.getLastUsageAuthSource(); pinned != "" {
+	if pinned := p.getLastUsageAuthSource(snap.AccountID); pinned != "" {
 		src, ok := findUsageAuthSource(sources, pinned)
 		if ok {
 			if err := p.tryUsageAuthSource(ctx, src, snap); err == nil {
 				return nil
 			}
-			p.setLastUsageAuthSource("")
+			p.setLastUsageAuthSource(snap.AccountID, "")
 		}
 		if p.applyCachedUsage(snap) {
 			return nil
@@ -555,12 +591,15 @@ func (p *Provider) tryUsageAuthSource(ctx context.Context, src usageAuthSource,
 	if err != nil {
 		return err
 	}
+	for _, credential := range src.credentials {
+		audit.Credential(ctx, credential)
+	}
 	usage, err := fetchUsageAPIWithAuth(ctx, url, setAuth)
 	if err != nil {
 		return err
 	}
 	p.applyFetchedUsage(usage, snap, src.name)
-	p.setLastUsageAuthSource(src.name)
+	p.setLastUsageAuthSource(snap.AccountID, src.name)
 	return nil
 }
 
@@ -568,7 +607,7 @@ func (p *Provider) tryUsageAuthSource(ctx context.Context, src usageAuthSource,
 // cache and applies it to snap. source names which auth source produced it
 // (e.g. "cookie", "oauth").
 func (p *Provider) applyFetchedUsage(usage *usageResponse, snap *core.UsageSnapshot, source string) {
-	p.setCachedUsage(usage)
+	p.setCachedUsage(snap.AccountID, usage)
 	applyUsageResponse(usage, snap, time.Now())
 	cacheFiveHourFromSnapshot(snap)
 	snap.Raw["usage_api_ok"] = "true"
@@ -578,7 +617,7 @@ func (p *Provider) applyFetchedUsage(usage *usageResponse, snap *core.UsageSnaps
 // applyCachedUsage applies the last cached usage response to snap, if any,
 // reporting whether a cached value was available.
 func (p *Provider) applyCachedUsage(snap *core.UsageSnapshot) bool {
-	cached := p.getCachedUsage()
+	cached := p.getCachedUsage(snap.AccountID)
 	if cached == nil {
 		return false
 	}
@@ -601,26 +640,36 @@ func cacheFiveHourFromSnapshot(snap *core.UsageSnapshot) {
 	}
 }
 
-func (p *Provider) getCachedUsage() *usageResponse {
+func (p *Provider) getCachedUsage(accountID string) *usageResponse {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	return p.usageAPICache
+	return p.usageAPICache[accountID]
 }
 
-func (p *Provider) setCachedUsage(u *usageResponse) {
+func (p *Provider) setCachedUsage(accountID string, u *usageResponse) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	p.usageAPICache = u
+	if p.usageAPICache == nil {
+		p.usageAPICache = make(map[string]*usageResponse)
+	}
+	p.usageAPICache[accountID] = u
 }
 
-func (p *Provider) getLastUsageAuthSource() string {
+func (p *Provider) getLastUsageAuthSource(accountID string) string {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	return p.lastUsageAuthSource
+	return p.lastUsageAuthSource[accountID]
 }
 
-func (p *Provider) setLastUsageAuthSource(name string) {
+func (p *Provider) setLastUsageAuthSource(accountID, name string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	p.lastUsageAuthSource = name
+	if name == "" {
+		delete(p.lastUsageAuthSource, accountID)
+		return
+	}
+	if p.lastUsageAuthSource == nil {
+		p.lastUsageAuthSource = make(map[string]string)
+	}
+	p.lastUsageAuthSource[accountID] = name
 }
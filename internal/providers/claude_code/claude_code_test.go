@@ -219,6 +219,18 @@ func TestEstimateCost_ResolverErrorFallsBackToLocalMap(t *testing.T) {
 	}
 }
 
+func TestEstimateCacheSavings(t *testing.T) {
+	// Local sonnet rates: input $3, cache read $0.30, cache create $3.75.
+	u := &jsonlUsage{InputTokens: 10_000, OutputTokens: 5_000, CacheReadInputTokens: 1_000_000, CacheCreationInputTokens: 100_000}
+	// reads save 2.70, creates cost 0.075 more than fresh input
+	if got, want := estimateCacheSavings("claude-sonnet-4-5", u), 2.625; math.Abs(got-want) > 1e-9 {
+		t.Errorf("estimateCacheSavings = %.4f, want %.4f", got, want)
+	}
+	if got := estimateCacheSavings("claude-sonnet-4-5", &jsonlUsage{InputTokens: 1_000}); got != 0 {
+		t.Errorf("estimateCacheSavings without cache tokens = %v, want 0", got)
+	}
+}
+
 func TestFindPricing_Fallback(t *testing.T) {
 	p := findPricing("claude-opus-9-9-20290101")
 	if p.InputPerMillion != 15.0 {
@@ -302,7 +314,7 @@ func TestProvider_Fetch_WithJSONL(t *testing.T) {
 		Resets:     make(map[string]time.Time),
 	}
 
-	err := p.readConversationJSONL(filepath.Join(tmpDir, "projects"), "/nonexistent", &snap)
+	_, err := p.readConversationJSONL(filepath.Join(tmpDir, "projects"), "/nonexistent", &snap)
 	if err != nil {
 		t.Fatalf("readConversationJSONL failed: %v", err)
 	}
@@ -720,7 +732,7 @@ func TestReadConversationJSONL_DedupesRequestUsageAndToolCalls(t *testing.T) {
 		Resets:      make(map[string]time.Time),
 		DailySeries: make(map[string][]core.TimePoint),
 	}
-	if err := p.readConversationJSONL(filepath.Join(tmpDir, "projects"), "", &snap); err != nil {
+	if _, err := p.readConversationJSONL(filepath.Join(tmpDir, "projects"), "", &snap); err != nil {
 		t.Fatalf("readConversationJSONL failed: %v", err)
 	}
 
@@ -755,6 +767,19 @@ func TestReadConversationJSONL_DedupesRequestUsageAndToolCalls(t *testing.T) {
 	if m := snap.Metrics["tool_bash"]; m.Used == nil || *m.Used != 1 {
 		t.Fatalf("expected tool_bash=1, got %+v", m)
 	}
+
+	// req-2 reads the Read call's result; req-1 follows no tool call.
+	wantRead := estimateCost("claude-opus-4-6", &jsonlUsage{InputTokens: 50, OutputTokens: 5})
+	if m := snap.Metrics[core.ToolCostMetricKey(core.ToolCategoryRead)]; m.Used == nil || math.Abs(*m.Used-wantRead) > 1e-9 {
+		t.Fatalf("expected read tool cost %.6f, got %+v", wantRead, m)
+	}
+	wantChat := estimateCost("claude-opus-4-6", &jsonlUsage{InputTokens: 100, OutputTokens: 10})
+	if m := snap.Metrics[core.ToolCostMetricKey(core.ToolCategoryChat)]; m.Used == nil || math.Abs(*m.Used-wantChat) > 1e-9 {
+		t.Fatalf("expected chat cost %.6f, got %+v", wantChat, m)
+	}
+	if _, ok := snap.Metrics[core.ToolCostMetricKey(core.ToolCategoryShell)]; ok {
+		t.Fatal("the last Bash call has no turn reading its result yet")
+	}
 }
 
 func TestReadConversationJSONL_ExtractsLanguageAndCodeStatsMetrics(t *testing.T) {
@@ -785,7 +810,7 @@ func TestReadConversationJSONL_ExtractsLanguageAndCodeStatsMetrics(t *testing.T)
 		Resets:      make(map[string]time.Time),
 		DailySeries: make(map[string][]core.TimePoint),
 	}
-	if err := p.readConversationJSONL(filepath.Join(tmpDir, "projects"), "", &snap); err != nil {
+	if _, err := p.readConversationJSONL(filepath.Join(tmpDir, "projects"), "", &snap); err != nil {
 		t.Fatalf("readConversationJSONL failed: %v", err)
 	}
 
@@ -11,11 +11,13 @@ import (
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
 
-func (p *Provider) readConversationJSONL(projectsDir, altProjectsDir string, snap *core.UsageSnapshot) error {
+// readConversationJSONL folds the conversation logs into snap and returns the
+// start of every 5h billing block they contain, oldest first.
+func (p *Provider) readConversationJSONL(projectsDir, altProjectsDir string, snap *core.UsageSnapshot) ([]time.Time, error) {
 	// Collect files with stat info for cache-aware parsing.
 	fileInfos, err := collectJSONLFilesWithStatAcross(projectsDir, altProjectsDir)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	jsonlFiles := make([]string, 0, len(fileInfos))
@@ -25,7 +27,7 @@ func (p *Provider) readConversationJSONL(projectsDir, altProjectsDir string, sna
 	sort.Strings(jsonlFiles)
 
 	if len(jsonlFiles) == 0 {
-		return fmt.Errorf("no JSONL conversation files found")
+		return nil, fmt.Errorf("no JSONL conversation files found")
 	}
 
 	snap.Raw["jsonl_files_found"] = fmt.Sprintf("%d", len(jsonlFiles))
@@ -59,8 +61,9 @@ func (p *Provider) readConversationJSONL(projectsDir, altProjectsDir string, sna
 		blockModels       = make(map[string]bool)
 		inCurrentBlock    bool
 
-		allTimeCostUSD float64
-		allTimeEntries int
+		allTimeCostUSD         float64
+		allTimeCacheSavingsUSD float64
+		allTimeEntries         int
 	)
 
 	blockStartCandidates := []time.Time{}
@@ -81,6 +84,13 @@ func (p *Provider) readConversationJSONL(projectsDir, altProjectsDir string, sna
 	agentSessions := make(map[string]map[string]bool)
 	seenUsageKeys := make(map[string]bool)
 	seenToolKeys := make(map[string]bool)
+	type conversationTurn struct {
+		session string
+		key     string
+		cost    float64
+	}
+	var turns []conversationTurn
+	turnTools := make(map[string][]string) // usage dedup key -> tool names
 	dailyClientTokens := make(map[string]map[string]float64)
 	dailyTokenTotals := make(map[string]int)
 	dailyMessages := make(map[string]int)
@@ -185,6 +195,9 @@ func (p *Provider) readConversationJSONL(projectsDir, altProjectsDir string, sna
 				continue
 			}
 			seenToolKeys[toolKey] = true
+			if turnKey := conversationUsageDedupKey(u); turnKey != "" {
+				turnTools[turnKey] = append(turnTools[turnKey], item.Name)
+			}
 			toolName := strings.ToLower(strings.TrimSpace(item.Name))
 			if toolName == "" {
 				toolName = "unknown"
@@ -255,6 +268,8 @@ func (p *Provider) readConversationJSONL(projectsDir, altProjectsDir string, sna
 
 		cost := estimateCost(u.model, u.usage)
 		allTimeCostUSD += cost
+		turns = append(turns, conversationTurn{session: core.FirstNonEmpty(u.sessionID, u.sourcePath), key: usageKey, cost: cost})
+		allTimeCacheSavingsUSD += estimateCacheSavings(u.model, u.usage)
 		allTimeEntries++
 		modelTotalsEntry.input += float64(u.usage.InputTokens)
 		modelTotalsEntry.output += float64(u.usage.OutputTokens)
@@ -376,6 +391,16 @@ func (p *Provider) readConversationJSONL(projectsDir, altProjectsDir string, sna
 		}
 	}
 
+	// Tool calls are read back by the session's next turn, so each turn is
+	// attributed before its own calls are recorded.
+	toolCosts := core.NewToolCostAttributor()
+	for _, turn := range turns {
+		toolCosts.Turn(turn.session, turn.cost)
+		for _, name := range turnTools[turn.key] {
+			toolCosts.Call(turn.session, name)
+		}
+	}
+
 	applyConversationUsageProjection(snap, conversationUsageProjection{
 		now:                  now,
 		inCurrentBlock:       inCurrentBlock,
@@ -417,6 +442,8 @@ func (p *Provider) readConversationJSONL(projectsDir, altProjectsDir string, sna
 		weeklyWebSearch:      weeklyWebSearch,
 		weeklyWebFetch:       weeklyWebFetch,
 		allTimeCostUSD:       allTimeCostUSD,
+		allTimeCacheSavings:  allTimeCacheSavingsUSD,
+		toolCosts:            toolCosts,
 		allTimeEntries:       allTimeEntries,
 		allTimeInputTokens:   allTimeInputTokens,
 		allTimeOutputTokens:  allTimeOutputTokens,
@@ -447,7 +474,7 @@ func (p *Provider) readConversationJSONL(projectsDir, altProjectsDir string, sna
 		dailyCost:            dailyCost,
 		dailyModelTokens:     dailyModelTokens,
 	})
-	return nil
+	return blockStartCandidates, nil
 }
 
 // cachedParseConversationRecords returns cached records for a file if the mtime and size
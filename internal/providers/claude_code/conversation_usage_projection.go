@@ -54,6 +54,8 @@ type conversationUsageProjection struct {
 	weeklyWebFetch      int
 
 	allTimeCostUSD       float64
+	allTimeCacheSavings  float64
+	toolCosts            *core.ToolCostAttributor
 	allTimeEntries       int
 	allTimeInputTokens   int
 	allTimeOutputTokens  int
@@ -335,6 +337,12 @@ func applyConversationUsageProjection(snap *core.UsageSnapshot, p conversationUs
 	if p.allTimeCostUSD > 0 {
 		snap.Metrics["all_time_api_cost"] = core.Metric{Used: core.Float64Ptr(p.allTimeCostUSD), Unit: "USD", Window: "all-time estimate"}
 	}
+	if m, ok := core.CacheSavingsMetric(p.allTimeCacheSavings, "all-time estimate"); ok {
+		snap.Metrics[core.CacheSavingsMetricKey] = m
+	}
+	if p.toolCosts != nil {
+		p.toolCosts.Emit(snap, "all-time estimate")
+	}
 	if p.allTimeInputTokens > 0 {
 		setMetricMax(snap, "all_time_input_tokens", float64(p.allTimeInputTokens), "tokens", "all-time estimate")
 	}
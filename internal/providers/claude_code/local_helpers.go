@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
 	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 	"github.com/samber/lo"
 )
@@ -666,21 +667,9 @@ func buildModelUsageSummaryRaw(snap *core.UsageSnapshot) {
 }
 
 func shortTokenCount(v float64) string {
-	switch {
-	case v >= 1_000_000_000:
-		return fmt.Sprintf("%.1fB", v/1_000_000_000)
-	case v >= 1_000_000:
-		return fmt.Sprintf("%.1fM", v/1_000_000)
-	case v >= 1_000:
-		return fmt.Sprintf("%.1fK", v/1_000)
-	default:
-		return fmt.Sprintf("%.0f", v)
-	}
+	return locale.Current().Quantity(v, "tokens")
 }
 
 func formatUSDSummary(v float64) string {
-	if v >= 1000 {
-		return fmt.Sprintf("$%.0f", v)
-	}
-	return fmt.Sprintf("$%.2f", v)
+	return locale.Current().Quantity(v, "USD")
 }
@@ -3,20 +3,55 @@ package claude_code
 import (
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
 )
 
+// LocalData is where Claude Code keeps its files under its config
+// directory. CLAUDE_CONFIG_DIR moves all of them, .claude.json included;
+// under the default ~/.claude that file sits in the home directory instead.
+var LocalData = core.LocalDataLayout{
+	EnvVar:  "CLAUDE_CONFIG_DIR",
+	RootKey: "claude_dir",
+	Paths: map[string][]string{
+		"stats_cache":      {"stats-cache.json", ".claude-backup/stats-cache.json"},
+		"account_config":   {".claude.json"},
+		"projects_dir":     {"projects"},
+		"alt_projects_dir": {"projects"},
+	},
+}
+
+// defaultClaudeDir is CLAUDE_CONFIG_DIR when set, else ~/.claude.
+func defaultClaudeDir(home string) string {
+	if dir := strings.TrimSpace(os.Getenv(LocalData.EnvVar)); dir != "" {
+		if expanded, err := core.ExpandDataPath(dir); err == nil && expanded != "" {
+			return expanded
+		}
+	}
+	return filepath.Join(home, ".claude")
+}
+
+// conversationDirs returns the two conversation roots the account reads.
+// A resolved data root (data_path or CLAUDE_CONFIG_DIR) supplies both, so a
+// second install never picks up the default install's ~/.config/claude.
+func conversationDirs(acct core.AccountConfig, claudeDir, home string) (string, string) {
+	return acct.Path("projects_dir", filepath.Join(claudeDir, "projects")),
+		acct.Path("alt_projects_dir", filepath.Join(home, ".config", "claude", "projects"))
+}
+
 // LocalSourcePaths returns the file system locations the provider reads on
 // each Fetch. Used by internal/tmux active-tool detection to gauge whether
 // Claude Code has had recent activity. The path resolution mirrors
-// Provider.Fetch (see claude_code.go) for the no-override case; a runtime
-// override via acct.Hint("claude_dir", ...) is not consulted here since
+// Provider.Fetch (see claude_code.go) for the no-override case, honoring
+// CLAUDE_CONFIG_DIR; a per-account data_path is not consulted here since
 // active-tool detection runs without an account context.
 func (p *Provider) LocalSourcePaths() []string {
 	home, err := os.UserHomeDir()
 	if err != nil || home == "" {
 		return nil
 	}
-	claudeDir := filepath.Join(home, ".claude")
+	claudeDir := defaultClaudeDir(home)
 	return []string{
 		filepath.Join(claudeDir, "projects"),
 		filepath.Join(home, ".config", "claude", "projects"),
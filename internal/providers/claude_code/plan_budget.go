@@ -0,0 +1,154 @@
+package claude_code
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/audit"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// claudePlan is a Claude subscription tier. Subscriptions are capped by 5h
+// and weekly usage windows rather than billed per token, so the budget is
+// expressed in plan value and sessions instead of dollars spent.
+type claudePlan struct {
+	ID         string
+	Label      string
+	MonthlyUSD float64
+}
+
+var claudePlans = map[string]claudePlan{
+	"pro":    {ID: "pro", Label: "Pro", MonthlyUSD: 20},
+	"max5x":  {ID: "max5x", Label: "Max 5x", MonthlyUSD: 100},
+	"max20x": {ID: "max20x", Label: "Max 20x", MonthlyUSD: 200},
+}
+
+// WeeklyUSD is the plan price prorated to one weekly window.
+func (p claudePlan) WeeklyUSD() float64 {
+	return p.MonthlyUSD * 12 / 52
+}
+
+// parseClaudePlan accepts the configured plan names ("max5x", "Max 5x",
+// "max_20x") and the rate-limit tiers Claude Code stores in its
+// credentials ("default_claude_max_20x"). A bare "max" is the 5x tier.
+func parseClaudePlan(raw string) (claudePlan, bool) {
+	s := strings.ToLower(raw)
+	s = strings.NewReplacer(" ", "", "_", "", "-", "").Replace(s)
+	switch {
+	case s == "":
+		return claudePlan{}, false
+	case strings.Contains(s, "max20x"):
+		return claudePlans["max20x"], true
+	case strings.Contains(s, "max5x"), s == "max":
+		return claudePlans["max5x"], true
+	case s == "pro":
+		return claudePlans["pro"], true
+	}
+	return claudePlan{}, false
+}
+
+// readClaudeCodePlan reads the subscription tier Claude Code records next
+// to its OAuth token. The rate-limit tier is more specific than the
+// subscription type, which does not tell Max 5x from Max 20x.
+func readClaudeCodePlan(ctx context.Context, claudeDir string) (claudePlan, bool) {
+	data, err := audit.ReadFile(ctx, filepath.Join(claudeDir, ".credentials.json"))
+	if err != nil {
+		return claudePlan{}, false
+	}
+	var creds struct {
+		ClaudeAiOauth struct {
+			SubscriptionType string `json:"subscriptionType"`
+			RateLimitTier    string `json:"rateLimitTier"`
+		} `json:"claudeAiOauth"`
+	}
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return claudePlan{}, false
+	}
+	if plan, ok := parseClaudePlan(creds.ClaudeAiOauth.RateLimitTier); ok {
+		return plan, true
+	}
+	return parseClaudePlan(creds.ClaudeAiOauth.SubscriptionType)
+}
+
+// applyPlanBudget turns the weekly and 5h utilization reported by the usage
+// API into plan-level budgeting: the unused share of the week's prorated
+// plan price, how many more 5h sessions fit at the pace of the sessions
+// already run this week, and when to start the next one so the remaining
+// sessions last until the weekly reset. blockStarts are the 5h block start
+// times seen in the conversation logs.
+func applyPlanBudget(snap *core.UsageSnapshot, plan claudePlan, blockStarts []time.Time, now time.Time) {
+	snap.SetAttribute("plan", plan.Label)
+
+	weekly, ok := snap.Metrics["usage_seven_day"]
+	if !ok || weekly.Used == nil {
+		return
+	}
+	used := math.Min(math.Max(*weekly.Used, 0), 100)
+	weeklyValue := plan.WeeklyUSD()
+	remainingValue := weeklyValue * (100 - used) / 100
+	usedValue := weeklyValue - remainingValue
+	snap.Metrics["plan_value_remaining"] = core.Metric{
+		Limit:     &weeklyValue,
+		Used:      &usedValue,
+		Remaining: &remainingValue,
+		Unit:      "USD",
+		Window:    "7d",
+	}
+
+	weeklyReset, ok := snap.Resets["usage_seven_day"]
+	if !ok || !weeklyReset.After(now) {
+		return
+	}
+	windowStart := weeklyReset.Add(-7 * 24 * time.Hour)
+	var sessions int
+	var lastStart time.Time
+	for _, start := range blockStarts {
+		if start.Before(windowStart) || start.After(now) {
+			continue
+		}
+		sessions++
+		if start.After(lastStart) {
+			lastStart = start
+		}
+	}
+	if sessions == 0 || used <= 0 {
+		return
+	}
+	left := math.Floor((100 - used) / (used / float64(sessions)))
+	snap.Metrics["plan_sessions_remaining"] = core.Metric{Used: &left, Unit: "sessions", Window: "7d"}
+
+	if next := nextBlockStart(snap, left, lastStart, weeklyReset, now); next.After(now) {
+		snap.Resets["plan_next_block"] = next
+	}
+}
+
+// nextBlockStart spaces the remaining sessions evenly over what is left of
+// the week, never earlier than the end of the running 5h block. With no
+// sessions left at the current pace the answer is the weekly reset.
+func nextBlockStart(snap *core.UsageSnapshot, sessionsLeft float64, lastStart, weeklyReset, now time.Time) time.Time {
+	if sessionsLeft < 1 {
+		return weeklyReset
+	}
+	earliest := now
+	if reset, ok := snap.Resets["usage_five_hour"]; ok && reset.After(now) {
+		earliest = reset
+		if start := reset.Add(-billingBlockDuration); start.After(lastStart) {
+			lastStart = start
+		}
+	}
+	next := earliest
+	if !lastStart.IsZero() {
+		gap := time.Duration(float64(weeklyReset.Sub(now)) / sessionsLeft)
+		if paced := lastStart.Add(gap); paced.After(next) {
+			next = paced
+		}
+	}
+	if next.After(weeklyReset) {
+		next = weeklyReset
+	}
+	return next
+}
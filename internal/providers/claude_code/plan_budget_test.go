@@ -0,0 +1,86 @@
+package claude_code
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestParseClaudePlan(t *testing.T) {
+	tests := map[string]string{
+		"pro":                    "pro",
+		"Max 5x":                 "max5x",
+		"max_20x":                "max20x",
+		"max":                    "max5x",
+		"default_claude_max_20x": "max20x",
+		"team":                   "",
+		"":                       "",
+	}
+	for raw, want := range tests {
+		plan, ok := parseClaudePlan(raw)
+		if ok != (want != "") || plan.ID != want {
+			t.Errorf("parseClaudePlan(%q) = %q, %v; want %q", raw, plan.ID, ok, want)
+		}
+	}
+}
+
+func TestReadClaudeCodePlan(t *testing.T) {
+	dir := t.TempDir()
+	if _, ok := readClaudeCodePlan(context.Background(), dir); ok {
+		t.Fatal("expected no plan without a credentials file")
+	}
+	creds := `{"claudeAiOauth":{"accessToken":"x","subscriptionType":"max","rateLimitTier":"default_claude_max_20x"}}`
+	if err := os.WriteFile(filepath.Join(dir, ".credentials.json"), []byte(creds), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if plan, ok := readClaudeCodePlan(context.Background(), dir); !ok || plan.ID != "max20x" {
+		t.Fatalf("readClaudeCodePlan = %q, %v; want max20x from the rate-limit tier", plan.ID, ok)
+	}
+}
+
+func TestApplyPlanBudget(t *testing.T) {
+	now := time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)
+	snap := core.NewUsageSnapshot("claude_code", "claude_code")
+	snap.Metrics["usage_seven_day"] = core.Metric{Used: core.Float64Ptr(40), Limit: core.Float64Ptr(100), Unit: "%"}
+	snap.Resets["usage_seven_day"] = now.Add(72 * time.Hour)
+	snap.Resets["usage_five_hour"] = now.Add(3 * time.Hour)
+
+	blockStarts := []time.Time{
+		now.Add(-5 * 24 * time.Hour), // previous weekly window
+		now.Add(-3 * 24 * time.Hour),
+		now.Add(-2 * 24 * time.Hour),
+		now.Add(-24 * time.Hour),
+		now.Add(-2 * time.Hour),
+	}
+	applyPlanBudget(&snap, claudePlans["max20x"], blockStarts, now)
+
+	if got := snap.Attributes["plan"]; got != "Max 20x" {
+		t.Errorf("plan attribute = %q, want Max 20x", got)
+	}
+	value := snap.Metrics["plan_value_remaining"]
+	if value.Remaining == nil || math.Abs(*value.Remaining-200.0*12/52*0.6) > 1e-9 {
+		t.Errorf("plan value remaining = %v, want 60%% of the weekly share", value.Remaining)
+	}
+	// Four sessions used 40%, so 10% each and six more fit.
+	if sessions := snap.Metrics["plan_sessions_remaining"]; sessions.Used == nil || *sessions.Used != 6 {
+		t.Fatalf("sessions remaining = %v, want 6", sessions.Used)
+	}
+	// 72h left over six sessions: one every 12h from the current block's start.
+	if got, want := snap.Resets["plan_next_block"], now.Add(10*time.Hour); !got.Equal(want) {
+		t.Errorf("next block = %v, want %v", got, want)
+	}
+}
+
+func TestNextBlockStart_ExhaustedAtPace(t *testing.T) {
+	now := time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)
+	snap := core.NewUsageSnapshot("claude_code", "claude_code")
+	reset := now.Add(30 * time.Hour)
+	if got := nextBlockStart(&snap, 0, now.Add(-time.Hour), reset, now); !got.Equal(reset) {
+		t.Fatalf("next block = %v, want the weekly reset %v", got, reset)
+	}
+}
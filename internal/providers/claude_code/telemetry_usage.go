@@ -91,13 +91,14 @@ func (p *Provider) ParseHookPayload(raw []byte, opts shared.TelemetryCollectOpti
 	return ParseTelemetryHookPayload(raw, opts)
 }
 
-// DefaultTelemetryProjectsDirs returns the default Claude Code conversation roots.
+// DefaultTelemetryProjectsDirs returns the default Claude Code conversation
+// roots, honoring CLAUDE_CONFIG_DIR.
 func DefaultTelemetryProjectsDirs() (string, string) {
 	home, _ := os.UserHomeDir()
 	if strings.TrimSpace(home) == "" {
 		return "", ""
 	}
-	return filepath.Join(home, ".claude", "projects"), filepath.Join(home, ".config", "claude", "projects")
+	return filepath.Join(defaultClaudeDir(home), "projects"), filepath.Join(home, ".config", "claude", "projects")
 }
 
 // parseTelemetryConversationFileFrom parses only the NEW lines in a JSONL file
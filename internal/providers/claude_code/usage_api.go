@@ -20,6 +20,8 @@ import (
 	_ "github.com/mattn/go-sqlite3" // already in go.mod for cursor provider
 
 	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
 
 type usageResponse struct {
@@ -194,7 +196,7 @@ func fetchUsageAPIWithAuth(ctx context.Context, url string, setAuth func(*http.R
 	setAuth(req)
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := shared.PooledHTTPClient(10 * time.Second)
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("API request failed: %w", err)
@@ -224,16 +226,21 @@ func fetchUsageAPIWithAuth(ctx context.Context, url string, setAuth func(*http.R
 var oauthUsageURL = "https://api.anthropic.com/api/oauth/usage"
 
 // readClaudeCodeOAuthToken loads the Claude Code CLI's OAuth access token from
-// ~/.claude/.credentials.json. It errors if the file is missing, the token is
-// absent, or the token has already expired (Claude Code refreshes it on next
-// use, so a stale value would only produce 401s).
-func readClaudeCodeOAuthToken() (string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("resolving home directory: %w", err)
+// .credentials.json in claudeDir, or in ~/.claude when claudeDir is empty.
+// Each install logs in separately, so the token follows the account's config
+// directory. It errors if the file is missing, the token is absent, or the
+// token has already expired (Claude Code refreshes it on next use, so a stale
+// value would only produce 401s).
+func readClaudeCodeOAuthToken(claudeDir string) (string, error) {
+	if claudeDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("resolving home directory: %w", err)
+		}
+		claudeDir = filepath.Join(home, ".claude")
 	}
 
-	credsPath := filepath.Join(home, ".claude", ".credentials.json")
+	credsPath := filepath.Join(claudeDir, ".credentials.json")
 	credsData, err := os.ReadFile(credsPath)
 	if err != nil {
 		return "", fmt.Errorf("reading Claude Code credentials: %w", err)
@@ -78,7 +78,7 @@ func TestReadClaudeCodeOAuthToken(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			writeCredentials(t, tt.body)
-			got, err := readClaudeCodeOAuthToken()
+			got, err := readClaudeCodeOAuthToken("")
 			if tt.wantErr {
 				if err == nil {
 					t.Fatalf("expected error, got token %q", got)
@@ -97,7 +97,7 @@ func TestReadClaudeCodeOAuthToken(t *testing.T) {
 
 func TestReadClaudeCodeOAuthToken_MissingFile(t *testing.T) {
 	setTempHome(t) // no .claude/.credentials.json
-	if _, err := readClaudeCodeOAuthToken(); err == nil {
+	if _, err := readClaudeCodeOAuthToken(""); err == nil {
 		t.Fatal("expected error for missing credentials file")
 	}
 }
@@ -207,7 +207,7 @@ func TestOAuthAuthHeaders(t *testing.T) {
 // priority documented on usageAuthSources.
 func TestUsageAuthSources_NamesAndOrder(t *testing.T) {
 	p := &Provider{}
-	sources := p.usageAuthSources("org-uuid")
+	sources := p.usageAuthSources("org-uuid", "")
 	if len(sources) != 2 {
 		t.Fatalf("len(sources) = %d, want 2", len(sources))
 	}
@@ -227,7 +227,7 @@ func TestUsageAuthSources_OAuthPrepare_UsesOAuthSchemeAndURL(t *testing.T) {
 	writeCredentials(t, `{"claudeAiOauth":{"accessToken":"tok-fixture","expiresAt":`+futureMs+`}}`)
 
 	p := &Provider{}
-	sources := p.usageAuthSources("org-uuid")
+	sources := p.usageAuthSources("org-uuid", "")
 	src, ok := findUsageAuthSource(sources, "oauth")
 	if !ok {
 		t.Fatal(`findUsageAuthSource(sources, "oauth") = false`)
@@ -255,7 +255,7 @@ func TestUsageAuthSources_OAuthPrepare_UsesOAuthSchemeAndURL(t *testing.T) {
 // to resume the previously successful source.
 func TestFindUsageAuthSource(t *testing.T) {
 	p := &Provider{}
-	sources := p.usageAuthSources("org-uuid")
+	sources := p.usageAuthSources("org-uuid", "")
 
 	if _, ok := findUsageAuthSource(sources, "oauth"); !ok {
 		t.Error(`findUsageAuthSource(sources, "oauth") = false, want true`)
@@ -294,10 +294,10 @@ func TestReadUsageAPI_PinsSuccessfulAuthSource(t *testing.T) {
 	p := &Provider{}
 
 	snap := core.NewUsageSnapshot("claude_code", "acct")
-	if err := p.readUsageAPI(context.Background(), "org-uuid", &snap); err != nil {
+	if err := p.readUsageAPI(context.Background(), "org-uuid", "", &snap); err != nil {
 		t.Fatalf("first call: unexpected error: %v", err)
 	}
-	if got := p.getLastUsageAuthSource(); got != "oauth" {
+	if got := p.getLastUsageAuthSource("acct"); got != "oauth" {
 		t.Fatalf("pinned source after first success = %q, want %q", got, "oauth")
 	}
 	if oauthCalls != 1 {
@@ -305,10 +305,10 @@ func TestReadUsageAPI_PinsSuccessfulAuthSource(t *testing.T) {
 	}
 
 	snap2 := core.NewUsageSnapshot("claude_code", "acct")
-	if err := p.readUsageAPI(context.Background(), "org-uuid", &snap2); err != nil {
+	if err := p.readUsageAPI(context.Background(), "org-uuid", "", &snap2); err != nil {
 		t.Fatalf("second call: unexpected error: %v", err)
 	}
-	if got := p.getLastUsageAuthSource(); got != "oauth" {
+	if got := p.getLastUsageAuthSource("acct"); got != "oauth" {
 		t.Fatalf("pinned source after second success = %q, want %q", got, "oauth")
 	}
 	if oauthCalls != 2 {
@@ -320,16 +320,58 @@ func TestReadUsageAPI_PinsSuccessfulAuthSource(t *testing.T) {
 	setTempHome(t)
 
 	snap3 := core.NewUsageSnapshot("claude_code", "acct")
-	if err := p.readUsageAPI(context.Background(), "org-uuid", &snap3); err != nil {
+	if err := p.readUsageAPI(context.Background(), "org-uuid", "", &snap3); err != nil {
 		t.Fatalf("third call: expected cache fallback, got error: %v", err)
 	}
 	if snap3.Raw["usage_api_cached"] != "true" {
 		t.Fatalf("third call: expected cached fallback, got Raw=%v", snap3.Raw)
 	}
-	if got := p.getLastUsageAuthSource(); got != "" {
+	if got := p.getLastUsageAuthSource("acct"); got != "" {
 		t.Fatalf("pin should clear once the pinned source fails, got %q", got)
 	}
 	if oauthCalls != 2 {
 		t.Fatalf("oauth server calls after credential removal = %d, want still 2 (prepare should fail before any request)", oauthCalls)
 	}
 }
+
+// TestReadUsageAPI_SeparateInstalls covers two installs with their own config
+// dirs: each reads its own login, and one install's cached usage never fills
+// in for the other's.
+func TestReadUsageAPI_SeparateInstalls(t *testing.T) {
+	var gotAuth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = append(gotAuth, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"five_hour":{"utilization":10,"resets_at":"2999-01-01T00:00:00Z"}}`))
+	}))
+	defer srv.Close()
+
+	old := oauthUsageURL
+	oauthUsageURL = srv.URL
+	defer func() { oauthUsageURL = old }()
+
+	setTempHome(t)
+	futureMs := strconv.FormatInt(time.Now().Add(time.Hour).UnixMilli(), 10)
+	nightly := t.TempDir()
+	if err := os.WriteFile(filepath.Join(nightly, ".credentials.json"),
+		[]byte(`{"claudeAiOauth":{"accessToken":"tok-nightly","expiresAt":`+futureMs+`}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &Provider{}
+	snap := core.NewUsageSnapshot("claude_code", "claude-code-nightly")
+	if err := p.readUsageAPI(context.Background(), "org-uuid", nightly, &snap); err != nil {
+		t.Fatalf("nightly: unexpected error: %v", err)
+	}
+	if len(gotAuth) != 1 || gotAuth[0] != "Bearer tok-nightly" {
+		t.Fatalf("Authorization = %v, want the nightly install's token", gotAuth)
+	}
+
+	// The default install has no credentials of its own.
+	stable := core.NewUsageSnapshot("claude_code", "claude-code")
+	if err := p.readUsageAPI(context.Background(), "org-uuid", "", &stable); err == nil {
+		t.Fatalf("stable: expected error, got Raw=%v", stable.Raw)
+	}
+	if stable.Raw["usage_api_cached"] == "true" {
+		t.Fatal("stable install served the nightly install's cached usage")
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
 )
 
 // The 5-hour usage utilization comes from the claude.ai usage API, which is
@@ -25,14 +27,14 @@ type usageCacheEntry struct {
 	TS  time.Time `json:"ts"`
 }
 
-// UsageCachePath returns the shared 5h-usage cache file, or "" when the home
+// UsageCachePath returns the shared 5h-usage cache file, or "" when the cache
 // directory cannot be resolved (in which case caching is silently skipped).
 func UsageCachePath() string {
-	home, err := os.UserHomeDir()
-	if err != nil || home == "" {
+	dir := config.CacheDir()
+	if !filepath.IsAbs(dir) {
 		return ""
 	}
-	return filepath.Join(home, ".cache", "openusage", "statusline-5h.json")
+	return filepath.Join(dir, "statusline-5h.json")
 }
 
 // WriteFiveHourCache overwrites the shared cache atomically (temp + rename) so
@@ -10,6 +10,7 @@ import (
 func TestFiveHourCacheRoundTrip(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", "")
 	t.Setenv("USERPROFILE", home) // os.UserHomeDir uses %USERPROFILE% on Windows
 
 	if _, _, ok := ReadFiveHourCache(); ok {
@@ -15,6 +15,7 @@ func dashboardWidget() core.DashboardWidget {
 		providerbase.WithCompactRows(
 			core.DashboardCompactRow{Label: "Credits", Keys: []string{"today_api_cost", "5h_block_cost", "7d_api_cost", "all_time_api_cost"}, MaxSegments: 5},
 			core.DashboardCompactRow{Label: "Usage", Keys: []string{"usage_five_hour", "usage_seven_day", "usage_seven_day_sonnet", "usage_seven_day_opus"}, MaxSegments: 4},
+			core.DashboardCompactRow{Label: "Plan", Keys: []string{"plan_value_remaining", "plan_sessions_remaining"}, MaxSegments: 2},
 			core.DashboardCompactRow{Label: "Activity", Keys: []string{"messages_today", "sessions_today", "tool_calls_today", "7d_tool_calls"}, MaxSegments: 4},
 			core.DashboardCompactRow{Label: "Tokens", Keys: []string{"today_input_tokens", "today_output_tokens", "7d_input_tokens", "7d_output_tokens"}, MaxSegments: 4},
 			core.DashboardCompactRow{Label: "Lines", Keys: []string{"composer_lines_added", "composer_lines_removed", "composer_files_changed", "scored_commits", "total_prompts"}, MaxSegments: 5},
@@ -29,33 +30,38 @@ func dashboardWidget() core.DashboardWidget {
 			"all_time_cache_create_5m_tokens", "all_time_cache_create_1h_tokens", "all_time_reasoning_tokens",
 		),
 		providerbase.WithMetricLabels(map[string]string{
-			"today_api_cost":         "Today Cost",
-			"5h_block_cost":          "5h Cost",
-			"7d_api_cost":            "7-Day Cost",
-			"all_time_api_cost":      "All-Time Cost",
-			"usage_five_hour":        "5-Hour Usage",
-			"usage_seven_day":        "7-Day Usage",
-			"usage_seven_day_sonnet": "7d Sonnet Usage",
-			"usage_seven_day_opus":   "7d Opus Usage",
-			"usage_seven_day_cowork": "7d Team Usage",
+			"today_api_cost":          "Today Cost",
+			"5h_block_cost":           "5h Cost",
+			"7d_api_cost":             "7-Day Cost",
+			"all_time_api_cost":       "All-Time Cost",
+			"usage_five_hour":         "5-Hour Usage",
+			"usage_seven_day":         "7-Day Usage",
+			"usage_seven_day_sonnet":  "7d Sonnet Usage",
+			"usage_seven_day_opus":    "7d Opus Usage",
+			"usage_seven_day_cowork":  "7d Team Usage",
+			"plan_value_remaining":    "Plan Value Left",
+			"plan_sessions_remaining": "Sessions Left",
+			"plan_next_block":         "Next 5h Block",
 		}),
 		providerbase.WithCompactLabels(map[string]string{
-			"today_api_cost":         "today",
-			"5h_block_cost":          "5h",
-			"7d_api_cost":            "7d",
-			"all_time_api_cost":      "all",
-			"usage_five_hour":        "5h",
-			"usage_seven_day":        "7d",
-			"usage_seven_day_sonnet": "sonnet",
-			"usage_seven_day_opus":   "opus",
-			"messages_today":         "msgs",
-			"sessions_today":         "sess",
-			"tool_calls_today":       "tools",
-			"7d_tool_calls":          "7d tools",
-			"today_input_tokens":     "in",
-			"today_output_tokens":    "out",
-			"7d_input_tokens":        "7d in",
-			"7d_output_tokens":       "7d out",
+			"today_api_cost":          "today",
+			"5h_block_cost":           "5h",
+			"7d_api_cost":             "7d",
+			"all_time_api_cost":       "all",
+			"usage_five_hour":         "5h",
+			"usage_seven_day":         "7d",
+			"usage_seven_day_sonnet":  "sonnet",
+			"usage_seven_day_opus":    "opus",
+			"plan_value_remaining":    "value",
+			"plan_sessions_remaining": "sessions",
+			"messages_today":          "msgs",
+			"sessions_today":          "sess",
+			"tool_calls_today":        "tools",
+			"7d_tool_calls":           "7d tools",
+			"today_input_tokens":      "in",
+			"today_output_tokens":     "out",
+			"7d_input_tokens":         "7d in",
+			"7d_output_tokens":        "7d out",
 		}),
 	)
 }
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/janekbaraniewski/openusage/internal/audit"
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
 
@@ -47,6 +48,8 @@ func (p *Provider) fetchCLIRateLimits(ctx context.Context, acct core.AccountConf
 		return false, nil
 	}
 
+	// The codex app-server authenticates with auth.json on our behalf.
+	audit.Credential(ctx, "file:"+authPath)
 	result, err := fetchCodexRateLimitsRPC(ctx, acct, configDir)
 	if err != nil {
 		return false, err
@@ -64,6 +64,9 @@ func New() *Provider {
 				},
 			},
 			Dashboard: dashboardWidget(),
+			Actions: []core.ProviderAction{
+				{Label: "Open status page", Kind: core.ProviderActionOpenURL, Target: "https://status.openai.com"},
+			},
 		}),
 		telemetryCache: make(map[string]*telemetryCacheEntry),
 		creditHistory:  make(map[string][]creditUsageObservation),
@@ -104,6 +107,7 @@ type authFile struct {
 type authTokens struct {
 	AccessToken string `json:"access_token"`
 	AccountID   string `json:"account_id,omitempty"`
+	IDToken     string `json:"id_token,omitempty"`
 }
 
 type usagePayload struct {
@@ -333,6 +337,7 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 		return snap, nil
 	}
 
+	applyPlanTier(ctx, &snap, acct, acct.Hint("auth_file", filepath.Join(configDir, "auth.json")))
 	p.applyCursorCompatibilityMetrics(&snap)
 	p.applyCreditForecast(&snap, acct.ID)
 	p.applyRateLimitStatus(&snap)
@@ -3,6 +3,7 @@ package codex
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/pricing"
 )
 
 func TestProviderID(t *testing.T) {
@@ -864,6 +866,52 @@ func TestFetchExtractsToolLanguageAndCodeStats(t *testing.T) {
 	}
 }
 
+func TestReadSessionUsageBreakdowns_AttributesCostToTools(t *testing.T) {
+	prev := priceLookup
+	priceLookup = func(_ context.Context, _ string, _ int) (*pricing.Price, error) {
+		return &pricing.Price{ModelID: "stub", Source: pricing.SourceHardcoded, InputCostPerMillion: 1}, nil
+	}
+	t.Cleanup(func() { priceLookup = prev })
+
+	sessionsRoot := t.TempDir()
+	ts := time.Now().UTC().Format(time.RFC3339)
+	// The first request searches and runs a command, the second reads both
+	// results, and the third reads nothing new.
+	content := strings.ReplaceAll(`{"timestamp":"TS","type":"turn_context","payload":{"model":"gpt-5-codex"}}
+{"timestamp":"TS","type":"response_item","payload":{"type":"web_search_call","status":"completed","action":{"type":"search"}}}
+{"timestamp":"TS","type":"response_item","payload":{"type":"function_call","name":"exec_command","call_id":"call-1","arguments":{"cmd":"ls"}}}
+{"timestamp":"TS","type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":1000,"total_tokens":1000}}}}
+{"timestamp":"TS","type":"response_item","payload":{"type":"function_call_output","call_id":"call-1","output":"Process exited with code 0"}}
+{"timestamp":"TS","type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":3000,"total_tokens":3000}}}}
+{"timestamp":"TS","type":"response_item","payload":{"type":"custom_tool_call","status":"completed","call_id":"call-2","name":"apply_patch","input":""}}
+{"timestamp":"TS","type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":3400,"total_tokens":3400}}}}
+`, "TS", ts)
+	if err := os.WriteFile(filepath.Join(sessionsRoot, "rollout-tools.jsonl"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	snap := core.UsageSnapshot{
+		Metrics:     make(map[string]core.Metric),
+		Raw:         make(map[string]string),
+		DailySeries: make(map[string][]core.TimePoint),
+	}
+	if err := New().readSessionUsageBreakdowns(sessionsRoot, &snap); err != nil {
+		t.Fatalf("readSessionUsageBreakdowns() error: %v", err)
+	}
+	for category, want := range map[string]float64{
+		core.ToolCategoryChat:  0.0014,
+		core.ToolCategoryWeb:   0.001,
+		core.ToolCategoryShell: 0.001,
+	} {
+		if got := metricUsed(t, snap, core.ToolCostMetricKey(category)); math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s cost = %.6f, want %.6f", category, got, want)
+		}
+	}
+	if _, ok := snap.Metrics[core.ToolCostMetricKey(core.ToolCategoryEdit)]; ok {
+		t.Error("no request has read the patch result yet")
+	}
+}
+
 func TestFormatWindow(t *testing.T) {
 	tests := []struct {
 		minutes  int
@@ -44,6 +44,28 @@ func estimateUsageCost(model string, delta tokenUsage) float64 {
 	})
 }
 
+// estimateCacheSavings returns what prompt caching saved on a delta usage
+// record: its cost with the cached input billed as fresh input, minus its
+// actual cost. Like estimateUsageCost it is 0 when no price resolves.
+func estimateCacheSavings(model string, delta tokenUsage) float64 {
+	if delta.CachedInputTokens <= 0 {
+		return 0
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), priceLookupTimeout)
+	defer cancel()
+	ctxLen := delta.InputTokens + delta.CachedInputTokens
+	p, err := priceLookup(ctx, model, ctxLen)
+	if err != nil || p == nil {
+		return 0
+	}
+	return pricing.CacheSavings(p, ctxLen, pricing.Usage{
+		InputTokens:     delta.InputTokens,
+		OutputTokens:    delta.OutputTokens,
+		CacheReadTokens: delta.CachedInputTokens,
+		ReasoningTokens: delta.ReasoningOutputTokens,
+	})
+}
+
 // emitCostMetrics publishes per-model and aggregate cost metrics onto the
 // snapshot when at least one model resolved a non-zero rate.
 func emitCostMetrics(modelCost, dailyCost map[string]float64, totalCostUSD, todayCostUSD float64, snap *core.UsageSnapshot) {
@@ -33,6 +33,24 @@ func TestEstimateUsageCost_UsesResolver(t *testing.T) {
 	}
 }
 
+func TestEstimateCacheSavings(t *testing.T) {
+	prev := priceLookup
+	priceLookup = func(_ context.Context, _ string, _ int) (*pricing.Price, error) {
+		return &pricing.Price{InputCostPerMillion: 1.25, OutputCostPerMillion: 10, CacheReadCostPerMillion: 0.125}, nil
+	}
+	t.Cleanup(func() { priceLookup = prev })
+
+	// 2M cached input billed at $0.125 instead of $1.25 saves 2.25.
+	delta := tokenUsage{InputTokens: 100_000, CachedInputTokens: 2_000_000, OutputTokens: 10_000, TotalTokens: 2_110_000}
+	if got := estimateCacheSavings("gpt-5-codex", delta); math.Abs(got-2.25) > 1e-9 {
+		t.Errorf("estimateCacheSavings = %.4f, want 2.25", got)
+	}
+	delta.CachedInputTokens = 0
+	if got := estimateCacheSavings("gpt-5-codex", delta); got != 0 {
+		t.Errorf("estimateCacheSavings without cached input = %v, want 0", got)
+	}
+}
+
 func TestEstimateUsageCost_ResolverErrorReturnsZero(t *testing.T) {
 	// TestMain installs an erroring stub already, so this just confirms the
 	// no-price path returns 0 instead of crashing.
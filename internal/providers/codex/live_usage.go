@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/janekbaraniewski/openusage/internal/audit"
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
@@ -23,7 +24,7 @@ func (p *Provider) fetchLiveUsage(ctx context.Context, acct core.AccountConfig,
 		authPath = override
 	}
 
-	data, err := os.ReadFile(authPath)
+	data, err := audit.ReadFile(ctx, authPath)
 	if err != nil {
 		return false, nil
 	}
@@ -0,0 +1,105 @@
+package codex
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/janekbaraniewski/openusage/internal/audit"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// codexPlanLabels are the display names of the ChatGPT plans Codex reports
+// in plan_type / chatgpt_plan_type.
+var codexPlanLabels = map[string]string{
+	"free":       "Free",
+	"go":         "Go",
+	"plus":       "Plus",
+	"pro":        "Pro",
+	"team":       "Team",
+	"business":   "Business",
+	"enterprise": "Enterprise",
+	"edu":        "Edu",
+}
+
+// codexPlanLabel returns the display name for a ChatGPT plan type. Unknown
+// tiers are shown as reported.
+func codexPlanLabel(planType string) string {
+	planType = strings.ToLower(strings.TrimSpace(planType))
+	if label, ok := codexPlanLabels[planType]; ok {
+		return label
+	}
+	return planType
+}
+
+// planFromAuthFile reads the ChatGPT plan from the id_token `codex login`
+// stores in auth.json. The token is only decoded, not verified: it is a
+// local file the CLI already trusts, and the plan is just a label.
+func planFromAuthFile(ctx context.Context, authPath string) string {
+	data, err := audit.ReadFile(ctx, authPath)
+	if err != nil {
+		return ""
+	}
+	var auth authFile
+	if err := json.Unmarshal(data, &auth); err != nil {
+		return ""
+	}
+	return planFromIDToken(auth.Tokens.IDToken)
+}
+
+func planFromIDToken(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		Auth struct {
+			PlanType string `json:"chatgpt_plan_type"`
+		} `json:"https://api.openai.com/auth"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(claims.Auth.PlanType)
+}
+
+// applyPlanTier resolves the account's ChatGPT plan and names the primary
+// and secondary rate-limit windows after it ("Pro 5h window", "Weekly").
+// The configured plan wins over the one the usage endpoints and session
+// logs report, which in turn win over the login token.
+func applyPlanTier(ctx context.Context, snap *core.UsageSnapshot, acct core.AccountConfig, authPath string) {
+	planType := core.FirstNonEmpty(strings.TrimSpace(acct.Plan), snap.Raw["plan_type"])
+	if planType == "" {
+		planType = planFromAuthFile(ctx, authPath)
+	}
+	if planType == "" {
+		return
+	}
+	snap.Raw["plan_type"] = planType
+	label := codexPlanLabel(planType)
+	snap.SetAttribute("plan", label)
+
+	for _, key := range []string{"rate_limit_primary", "rate_limit_secondary"} {
+		if met, ok := snap.Metrics[key]; ok {
+			snap.SetMetricLabel(key, codexWindowLabel(label, met.Window))
+		}
+	}
+}
+
+// codexWindowLabel names a rate-limit window for a plan. The weekly window
+// is the same across tiers, so only shorter windows carry the plan name.
+func codexWindowLabel(planLabel, window string) string {
+	switch window {
+	case "":
+		return planLabel + " window"
+	case "7d":
+		return "Weekly"
+	default:
+		return planLabel + " " + window + " window"
+	}
+}
@@ -0,0 +1,67 @@
+package codex
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func fakeIDToken(claims string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." + enc.EncodeToString([]byte(claims)) + ".sig"
+}
+
+func TestPlanFromIDToken(t *testing.T) {
+	token := fakeIDToken(`{"https://api.openai.com/auth":{"chatgpt_plan_type":"pro"}}`)
+	if got := planFromIDToken(token); got != "pro" {
+		t.Fatalf("planFromIDToken = %q, want pro", got)
+	}
+	for _, bad := range []string{"", "not-a-jwt", "a.!!!.c", fakeIDToken(`{}`)} {
+		if got := planFromIDToken(bad); got != "" {
+			t.Errorf("planFromIDToken(%q) = %q, want empty", bad, got)
+		}
+	}
+}
+
+func TestApplyPlanTier_LabelsWindowsFromAuthToken(t *testing.T) {
+	authPath := filepath.Join(t.TempDir(), "auth.json")
+	auth := `{"tokens":{"access_token":"x","id_token":"` + fakeIDToken(`{"https://api.openai.com/auth":{"chatgpt_plan_type":"pro"}}`) + `"}}`
+	if err := os.WriteFile(authPath, []byte(auth), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	snap := core.NewUsageSnapshot("codex", "codex")
+	snap.Metrics["rate_limit_primary"] = core.Metric{Used: core.Float64Ptr(40), Unit: "%", Window: "5h"}
+	snap.Metrics["rate_limit_secondary"] = core.Metric{Used: core.Float64Ptr(10), Unit: "%", Window: "7d"}
+	applyPlanTier(context.Background(), &snap, core.AccountConfig{}, authPath)
+
+	if got := snap.Attributes["plan"]; got != "Pro" {
+		t.Errorf("plan attribute = %q, want Pro", got)
+	}
+	if got := snap.MetricLabel("rate_limit_primary"); got != "Pro 5h window" {
+		t.Errorf("primary label = %q, want Pro 5h window", got)
+	}
+	if got := snap.MetricLabel("rate_limit_secondary"); got != "Weekly" {
+		t.Errorf("secondary label = %q, want Weekly", got)
+	}
+}
+
+func TestApplyPlanTier_PrefersConfiguredThenReportedPlan(t *testing.T) {
+	snap := core.NewUsageSnapshot("codex", "codex")
+	snap.Raw["plan_type"] = "plus"
+	snap.Metrics["rate_limit_primary"] = core.Metric{Used: core.Float64Ptr(40), Unit: "%", Window: "5h"}
+
+	applyPlanTier(context.Background(), &snap, core.AccountConfig{}, filepath.Join(t.TempDir(), "missing.json"))
+	if got := snap.MetricLabel("rate_limit_primary"); got != "Plus 5h window" {
+		t.Errorf("label from reported plan = %q, want Plus 5h window", got)
+	}
+
+	applyPlanTier(context.Background(), &snap, core.AccountConfig{Plan: "team"}, "")
+	if got := snap.Attributes["plan"]; got != "Team" {
+		t.Errorf("configured plan = %q, want Team", got)
+	}
+}
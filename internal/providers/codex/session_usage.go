@@ -32,12 +32,15 @@ func (p *Provider) readSessionUsageBreakdowns(sessionsDir string, snap *core.Usa
 	dailyCost := make(map[string]float64)
 	var totalCostUSD float64
 	var todayCostUSD float64
+	var cacheSavingsUSD float64
+	toolCosts := core.NewToolCostAttributor()
 	today := time.Now().UTC().Format("2006-01-02")
 	totalRequests := 0
 	requestsToday := 0
 	promptCount := 0
 	commits := 0
 	completedWithoutCallID := 0
+	exhaustion := newWindowExhaustionTracker()
 
 	sessionFiles, err := shared.CollectFilesByExt([]string{sessionsDir}, map[string]bool{".jsonl": true})
 	if err != nil {
@@ -55,6 +58,9 @@ func (p *Provider) readSessionUsageBreakdowns(sessionsDir string, snap *core.Usa
 		var previous tokenUsage
 		var hasPrevious bool
 		var countedSession bool
+		// issued holds the tool calls made by the request whose token_count
+		// hasn't arrived yet; the request after it reads their output.
+		var issued []string
 		if err := walkSessionFile(path, func(record sessionLine) error {
 			switch {
 			case record.SessionMeta != nil:
@@ -74,7 +80,11 @@ func (p *Provider) readSessionUsageBreakdowns(sessionsDir string, snap *core.Usa
 					promptCount++
 					return nil
 				}
-				if payload.Type != "token_count" || payload.Info == nil {
+				if payload.Type != "token_count" {
+					return nil
+				}
+				exhaustion.observe(record.Timestamp, payload.RateLimits)
+				if payload.Info == nil {
 					return nil
 				}
 				// Per-message override: when a token_count event carries its
@@ -122,6 +132,12 @@ func (p *Provider) readSessionUsageBreakdowns(sessionsDir string, snap *core.Usa
 				}
 
 				cost := estimateUsageCost(currentModel, delta)
+				cacheSavingsUSD += estimateCacheSavings(currentModel, delta)
+				toolCosts.Turn(path, cost)
+				for _, tool := range issued {
+					toolCosts.Call(path, tool)
+				}
+				issued = nil
 				if cost > 0 {
 					modelCost[modelName] += cost
 					totalCostUSD += cost
@@ -143,6 +159,7 @@ func (p *Provider) readSessionUsageBreakdowns(sessionsDir string, snap *core.Usa
 				case "function_call":
 					tool := normalizeToolName(item.Name)
 					recordToolCall(toolCalls, callTool, item.CallID, tool)
+					issued = append(issued, tool)
 					if strings.EqualFold(tool, "exec_command") {
 						var args commandArgs
 						if json.Unmarshal(item.Arguments, &args) == nil {
@@ -155,12 +172,14 @@ func (p *Provider) readSessionUsageBreakdowns(sessionsDir string, snap *core.Usa
 				case "custom_tool_call":
 					tool := normalizeToolName(item.Name)
 					recordToolCall(toolCalls, callTool, item.CallID, tool)
+					issued = append(issued, tool)
 					if strings.EqualFold(tool, "apply_patch") {
 						stats.PatchCalls++
 						accumulatePatchStats(item.Input, &stats, langRequests)
 					}
 				case "web_search_call":
 					recordToolCall(toolCalls, callTool, "", "web_search")
+					issued = append(issued, "web_search")
 					completedWithoutCallID++
 				case "function_call_output", "custom_tool_call_output":
 					setToolCallOutcome(item.CallID, item.Output, callOutcome)
@@ -182,6 +201,11 @@ func (p *Provider) readSessionUsageBreakdowns(sessionsDir string, snap *core.Usa
 	emitProductivityMetrics(stats, promptCount, commits, totalRequests, requestsToday, clientSessions, snap)
 	emitDailyUsageSeries(dailyTokenTotals, dailyRequestTotals, interfaceDaily, snap)
 	emitCostMetrics(modelCost, dailyCost, totalCostUSD, todayCostUSD, snap)
+	if m, ok := core.CacheSavingsMetric(cacheSavingsUSD, defaultUsageWindowLabel); ok {
+		snap.Metrics[core.CacheSavingsMetricKey] = m
+	}
+	toolCosts.Emit(snap, defaultUsageWindowLabel)
+	exhaustion.emit(snap, time.Now())
 
 	return nil
 }
@@ -16,6 +16,7 @@ func dashboardWidget() core.DashboardWidget {
 			core.DashboardCompactRow{Label: "Credits", Keys: []string{"codex_credit_percent_used", "codex_credit_limit", "codex_credit_burn_rate", "codex_credit_runout_hours", "credit_balance"}, MaxSegments: 5},
 			core.DashboardCompactRow{Label: "Team", Keys: []string{"team_size", "team_owners"}, MaxSegments: 4},
 			core.DashboardCompactRow{Label: "Usage", Keys: []string{"plan_percent_used", "plan_auto_percent_used", "plan_api_percent_used", "composer_context_pct"}, MaxSegments: 4},
+			core.DashboardCompactRow{Label: "Limits Hit", Keys: []string{"exhausted_5h_windows", "exhausted_7d_windows"}, MaxSegments: 2},
 			core.DashboardCompactRow{Label: "Activity", Keys: []string{"requests_today", "total_ai_requests", "composer_sessions", "composer_requests"}, MaxSegments: 4},
 			core.DashboardCompactRow{Label: "Lines", Keys: []string{"composer_lines_added", "composer_lines_removed", "scored_commits", "total_prompts"}, MaxSegments: 4},
 		),
@@ -50,6 +51,8 @@ func dashboardWidget() core.DashboardWidget {
 			"codex_credit_runout_hours":        "Credit Runout",
 			"ai_deleted_files":                 "AI Deleted",
 			"ai_tracked_files":                 "AI Tracked",
+			"exhausted_5h_windows":             "5h Limits Hit",
+			"exhausted_7d_windows":             "Weekly Limits Hit",
 		}),
 		providerbase.WithCompactLabels(map[string]string{
 			"rate_limit_primary":        "primary",
@@ -68,6 +71,8 @@ func dashboardWidget() core.DashboardWidget {
 			"composer_context_pct":      "ctx",
 			"ai_deleted_files":          "deleted",
 			"ai_tracked_files":          "tracked",
+			"exhausted_5h_windows":      "5h",
+			"exhausted_7d_windows":      "weekly",
 		}),
 	)
 
@@ -0,0 +1,107 @@
+package codex
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// exhaustionHistoryWeeks is how many rolling weeks of window exhaustion
+// history are kept in the snapshot.
+const exhaustionHistoryWeeks = 8
+
+// windowExhaustionTracker counts how often each rate-limit window ran out,
+// using the rate_limits every token_count event in the session logs
+// carries. A window instance is identified by its length and reset time,
+// so it counts once however many events report it at 100%.
+type windowExhaustionTracker struct {
+	seen map[string]bool                 // window lengths observed at all
+	hits map[string]map[string]time.Time // window -> instance -> first time at 100%
+}
+
+func newWindowExhaustionTracker() *windowExhaustionTracker {
+	return &windowExhaustionTracker{
+		seen: make(map[string]bool),
+		hits: make(map[string]map[string]time.Time),
+	}
+}
+
+func (t *windowExhaustionTracker) observe(timestamp string, rl *rateLimits) {
+	if rl == nil {
+		return
+	}
+	ts, ok := parseSessionTimestamp(timestamp)
+	if !ok {
+		return
+	}
+	for _, bucket := range []*rateLimitBucket{rl.Primary, rl.Secondary} {
+		if bucket == nil || bucket.WindowMinutes <= 0 {
+			continue
+		}
+		window := formatWindow(bucket.WindowMinutes)
+		t.seen[window] = true
+		if bucket.UsedPercent < 100 {
+			continue
+		}
+		instance := windowInstance(ts, bucket)
+		if t.hits[window] == nil {
+			t.hits[window] = make(map[string]time.Time)
+		}
+		if first, ok := t.hits[window][instance]; !ok || ts.Before(first) {
+			t.hits[window][instance] = ts
+		}
+	}
+}
+
+// windowInstance keys a window by its reset time, rounded to the minute
+// because Codex recomputes it per event. Logs without a reset time fall
+// back to the fixed-length slot the event falls into.
+func windowInstance(ts time.Time, bucket *rateLimitBucket) string {
+	if bucket.ResetsAt > 0 {
+		return strconv.FormatInt(bucket.ResetsAt/60, 10)
+	}
+	length := int64(bucket.WindowMinutes) * 60
+	return "slot:" + strconv.FormatInt(ts.Unix()/length, 10)
+}
+
+// emit records, per window length, the exhaustions in the last seven days
+// as exhausted_<window>_windows, their weekly average over the history,
+// and the per-week counts (oldest first) in Raw.
+func (t *windowExhaustionTracker) emit(snap *core.UsageSnapshot, now time.Time) {
+	for window := range t.seen {
+		weeks := make([]int, exhaustionHistoryWeeks)
+		for _, at := range t.hits[window] {
+			age := now.Sub(at)
+			if age < 0 {
+				age = 0
+			}
+			week := int(age / (7 * 24 * time.Hour))
+			if week >= exhaustionHistoryWeeks {
+				continue
+			}
+			weeks[exhaustionHistoryWeeks-1-week]++
+		}
+
+		total := 0
+		counts := make([]string, len(weeks))
+		for i, n := range weeks {
+			total += n
+			counts[i] = strconv.Itoa(n)
+		}
+		lastWeek := float64(weeks[exhaustionHistoryWeeks-1])
+		snap.Metrics["exhausted_"+window+"_windows"] = core.Metric{Used: &lastWeek, Unit: "windows", Window: "7d"}
+		snap.Raw["exhausted_"+window+"_weekly"] = strings.Join(counts, ",")
+		snap.Raw["exhausted_"+window+"_weekly_avg"] = strconv.FormatFloat(float64(total)/exhaustionHistoryWeeks, 'f', 1, 64)
+	}
+}
+
+func parseSessionTimestamp(timestamp string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, time.RFC3339} {
+		if parsed, err := time.Parse(layout, timestamp); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,43 @@
+package codex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestWindowExhaustionTracker(t *testing.T) {
+	now := time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) string { return now.Add(-d).Format(time.RFC3339) }
+	limits := func(primary float64, primaryReset int64, secondary float64) *rateLimits {
+		return &rateLimits{
+			Primary:   &rateLimitBucket{UsedPercent: primary, WindowMinutes: 300, ResetsAt: primaryReset},
+			Secondary: &rateLimitBucket{UsedPercent: secondary, WindowMinutes: 10080, ResetsAt: now.Add(48 * time.Hour).Unix()},
+		}
+	}
+
+	tracker := newWindowExhaustionTracker()
+	reset := now.Add(-20 * time.Hour).Unix()
+	tracker.observe(at(22*time.Hour), limits(100, reset, 30))
+	tracker.observe(at(21*time.Hour), limits(100, reset+5, 31)) // same window, jittered reset
+	tracker.observe(at(3*24*time.Hour), limits(100, 0, 20))     // no reset: fixed-length slot
+	tracker.observe(at(10*24*time.Hour), limits(100, now.Add(-239*time.Hour).Unix(), 10))
+	tracker.observe(at(time.Hour), limits(55, now.Add(4*time.Hour).Unix(), 40))
+
+	snap := core.NewUsageSnapshot("codex", "codex")
+	tracker.emit(&snap, now)
+
+	if m := snap.Metrics["exhausted_5h_windows"]; m.Used == nil || *m.Used != 2 || m.Window != "7d" {
+		t.Fatalf("exhausted_5h_windows = %+v, want 2 over 7d", m)
+	}
+	if m := snap.Metrics["exhausted_7d_windows"]; m.Used == nil || *m.Used != 0 {
+		t.Fatalf("exhausted_7d_windows = %+v, want 0", m)
+	}
+	if got := snap.Raw["exhausted_5h_weekly"]; got != "0,0,0,0,0,0,1,2" {
+		t.Errorf("weekly history = %q", got)
+	}
+	if got := snap.Raw["exhausted_5h_weekly_avg"]; got != "0.4" {
+		t.Errorf("weekly average = %q, want 0.4", got)
+	}
+}
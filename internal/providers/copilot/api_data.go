@@ -6,21 +6,26 @@ import (
 	"encoding/json"
 	"fmt"
 	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/janekbaraniewski/openusage/internal/audit"
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
 
-func (p *Provider) fetchUserInfo(ctx context.Context, binary string, snap *core.UsageSnapshot) {
+// fetchUserInfo is the first API call of every poll, so it doubles as the
+// token check: a rejected token comes back as a *shared.AuthError.
+func (p *Provider) fetchUserInfo(ctx context.Context, binary string, snap *core.UsageSnapshot) error {
 	userJSON, err := runGHAPI(ctx, binary, "/user")
 	if err != nil {
-		return
+		return ghAuthError(userJSON, err)
 	}
 	var user ghUser
 	if json.Unmarshal([]byte(userJSON), &user) != nil {
-		return
+		return nil
 	}
 	if user.Login != "" {
 		snap.Raw["github_login"] = user.Login
@@ -31,6 +36,22 @@ func (p *Provider) fetchUserInfo(ctx context.Context, binary string, snap *core.
 	if user.Plan.Name != "" {
 		snap.Raw["github_plan"] = user.Plan.Name
 	}
+	return nil
+}
+
+// ghAuthError classifies a failed `gh api` call. gh owns the GitHub token and
+// its grant cannot be refreshed non-interactively, so an expired or revoked
+// token becomes a *shared.AuthError asking the user to log in again; any
+// other failure is returned unchanged.
+func ghAuthError(output string, err error) error {
+	lower := strings.ToLower(output)
+	switch {
+	case strings.Contains(lower, "bad credentials"):
+		return &shared.AuthError{Reason: "GitHub token rejected (bad credentials)", Action: "run `gh auth login` to re-authenticate", Err: err}
+	case strings.Contains(lower, "http 401"):
+		return &shared.AuthError{Reason: "GitHub token rejected (HTTP 401)", Action: "run `gh auth login` to re-authenticate", Err: err}
+	}
+	return err
 }
 
 func (p *Provider) fetchCopilotInternalUser(ctx context.Context, binary string, snap *core.UsageSnapshot) {
@@ -231,6 +252,7 @@ func (p *Provider) fetchOrgData(ctx context.Context, binary string, snap *core.U
 			continue
 		}
 		p.fetchOrgBilling(ctx, binary, org, snap)
+		p.fetchOrgSeats(ctx, binary, org, snap)
 		p.fetchOrgMetrics(ctx, binary, org, snap)
 	}
 }
@@ -265,6 +287,113 @@ func (p *Provider) fetchOrgBilling(ctx context.Context, binary, org string, snap
 	}
 }
 
+const (
+	orgSeatsPerPage = 100
+	orgSeatsMaxPage = 50
+	// inactiveSeatAfter matches GitHub's own definition of an inactive seat
+	// in the Copilot usage report.
+	inactiveSeatAfter = 30 * 24 * time.Hour
+	// maxIdleSeatEntries caps the per-seat raw entries, so a large org
+	// does not add thousands of keys to every snapshot.
+	maxIdleSeatEntries = 15
+)
+
+// seatMonthlyPriceUSD is the list price of a Copilot seat per plan_type, used
+// to estimate what unassigning inactive seats would save.
+var seatMonthlyPriceUSD = map[string]float64{
+	"business":   19,
+	"enterprise": 39,
+}
+
+// fetchOrgSeats pages through the org seat assignments. It needs the
+// manage_billing:copilot (or read:org for owners) scope; without it the call
+// fails and nothing is recorded.
+func (p *Provider) fetchOrgSeats(ctx context.Context, binary, org string, snap *core.UsageSnapshot) {
+	var seats []orgSeat
+	for page := 1; page <= orgSeatsMaxPage; page++ {
+		body, err := runGHAPI(ctx, binary, fmt.Sprintf("/orgs/%s/copilot/billing/seats?per_page=%d&page=%d", org, orgSeatsPerPage, page))
+		if err != nil {
+			return
+		}
+		var resp orgSeatsPage
+		if json.Unmarshal([]byte(body), &resp) != nil {
+			return
+		}
+		seats = append(seats, resp.Seats...)
+		if len(resp.Seats) < orgSeatsPerPage || len(seats) >= resp.TotalSeats {
+			break
+		}
+	}
+	applyOrgSeats(org, seats, snap.Raw["org_"+org+"_billing_plan"], time.Now(), snap)
+}
+
+// applyOrgSeats records seat counts, the estimated monthly cost of inactive
+// seats, and a seat_activity_<org>/<login> raw entry for each of the
+// maxIdleSeatEntries least recently active seats, holding the last activity
+// time (RFC 3339, or "never") followed by the editor name.
+func applyOrgSeats(org string, seats []orgSeat, billingPlan string, now time.Time, snap *core.UsageSnapshot) {
+	if len(seats) == 0 {
+		return
+	}
+
+	type seatActivity struct {
+		login, value string
+		last         time.Time
+	}
+	prefix := "org_" + org + "_"
+	active, inactive := 0, 0
+	unusedCost := 0.0
+	var idle []seatActivity
+	for _, seat := range seats {
+		login := strings.TrimSpace(seat.Assignee.Login)
+		if login == "" {
+			continue
+		}
+		last := parseCopilotTime(seat.LastActivityAt)
+		if !last.IsZero() && now.Sub(last) < inactiveSeatAfter {
+			active++
+		} else {
+			inactive++
+			plan := strings.ToLower(seat.PlanType)
+			if _, ok := seatMonthlyPriceUSD[plan]; !ok {
+				plan = strings.ToLower(billingPlan)
+			}
+			unusedCost += seatMonthlyPriceUSD[plan]
+		}
+
+		value := "never"
+		if !last.IsZero() {
+			value = last.UTC().Format(time.RFC3339)
+		}
+		if editor, _, _ := strings.Cut(seat.LastActivityEditor, "/"); editor != "" {
+			value += " " + editor
+		}
+		idle = append(idle, seatActivity{login: login, value: value, last: last})
+	}
+
+	// Never-active seats (zero time) sort first.
+	sort.Slice(idle, func(i, j int) bool {
+		if !idle[i].last.Equal(idle[j].last) {
+			return idle[i].last.Before(idle[j].last)
+		}
+		return idle[i].login < idle[j].login
+	})
+	for i, seat := range idle {
+		if i == maxIdleSeatEntries {
+			break
+		}
+		snap.Raw["seat_activity_"+org+"/"+seat.login] = seat.value
+	}
+
+	total := float64(active + inactive)
+	snap.Metrics[prefix+"total_seats"] = core.Metric{Used: core.Float64Ptr(total), Unit: "seats", Window: "current"}
+	snap.Metrics[prefix+"active_seats"] = core.Metric{Limit: core.Float64Ptr(total), Used: core.Float64Ptr(float64(active)), Unit: "seats", Window: "30d"}
+	snap.Metrics[prefix+"inactive_seats"] = core.Metric{Limit: core.Float64Ptr(total), Used: core.Float64Ptr(float64(inactive)), Unit: "seats", Window: "30d"}
+	if unusedCost > 0 {
+		snap.Metrics[prefix+"unused_seat_cost"] = core.Metric{Used: core.Float64Ptr(unusedCost), Unit: "USD", Window: "month"}
+	}
+}
+
 func (p *Provider) fetchOrgMetrics(ctx context.Context, binary, org string, snap *core.UsageSnapshot) {
 	body, err := runGHAPI(ctx, binary, fmt.Sprintf("/orgs/%s/copilot/metrics", org))
 	if err != nil {
@@ -362,7 +491,10 @@ func runGH(ctx context.Context, binary string, args ...string) (string, error) {
 	return stdout.String(), nil
 }
 
+// runGHAPI calls the GitHub API through gh, which authenticates with the
+// token from the user's gh login; each call is recorded as a read of it.
 func runGHAPI(ctx context.Context, binary, endpoint string) (string, error) {
+	audit.Credential(ctx, "gh:github.com")
 	return runGH(
 		ctx,
 		binary,
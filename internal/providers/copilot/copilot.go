@@ -85,6 +85,10 @@ func New() *Provider {
 				},
 			},
 			Dashboard: dashboardWidget(),
+			Actions: []core.ProviderAction{
+				{Label: "Open Copilot settings", Kind: core.ProviderActionOpenURL, Target: "https://github.com/settings/copilot"},
+				{Label: "Open status page", Kind: core.ProviderActionOpenURL, Target: "https://www.githubstatus.com"},
+			},
 		}),
 	}
 }
@@ -185,6 +189,23 @@ type orgBilling struct {
 	CLI                   string `json:"cli"`
 }
 
+type orgSeatsPage struct {
+	TotalSeats int       `json:"total_seats"`
+	Seats      []orgSeat `json:"seats"`
+}
+
+type orgSeat struct {
+	CreatedAt               string `json:"created_at"`
+	PendingCancellationDate string `json:"pending_cancellation_date"`
+	LastActivityAt          string `json:"last_activity_at"`
+	LastActivityEditor      string `json:"last_activity_editor"`
+	PlanType                string `json:"plan_type"`
+	Assignee                struct {
+		Login string `json:"login"`
+		Type  string `json:"type"`
+	} `json:"assignee"`
+}
+
 type orgMetricsDay struct {
 	Date              string          `json:"date"`
 	TotalActiveUsers  int             `json:"total_active_users"`
@@ -347,11 +368,16 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 
 		if !authOK {
 			snap.Status = core.StatusAuth
-			snap.Message = "not authenticated with GitHub"
+			snap.Message = "not authenticated with GitHub — run `gh auth login`"
 			return snap, nil
 		}
 
-		p.fetchUserInfo(ctx, ghBinary, &snap)
+		if err := p.fetchUserInfo(ctx, ghBinary, &snap); shared.ApplyAuthError(&snap, err) {
+			// The cached `gh auth status` predates the expiry; re-check on
+			// the next poll so a fresh login is picked up immediately.
+			p.invalidateAuthCache()
+			return snap, nil
+		}
 
 		p.fetchCopilotInternalUser(ctx, ghBinary, &snap)
 
@@ -459,6 +485,15 @@ func (p *Provider) checkAndCacheAuth(ctx context.Context, ghBinary string) (stri
 	return authOut, authOK
 }
 
+func (p *Provider) invalidateAuthCache() {
+	p.cacheMu.Lock()
+	defer p.cacheMu.Unlock()
+	if p.apiCache != nil {
+		p.apiCache.authFetchedAt = time.Time{}
+		p.apiCache.lastSnapAt = time.Time{}
+	}
+}
+
 func resolveCopilotBinaries(configuredBinary string, acct core.AccountConfig) (string, string) {
 	ghBinary := ""
 	copilotBinary := ""
@@ -1,6 +1,8 @@
 package copilot
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -328,3 +330,75 @@ func TestSessionShutdownDataParsing_Empty(t *testing.T) {
 		t.Errorf("expected 0 model metrics, got %d", len(shutdown.ModelMetrics))
 	}
 }
+
+func TestApplyOrgSeats_CountsInactiveSeatsAndSavings(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	body := `{"total_seats":4,"seats":[
+		{"assignee":{"login":"alice"},"plan_type":"business","last_activity_at":"2026-03-30T09:00:00Z","last_activity_editor":"vscode/1.98.0/copilot/1.300.0"},
+		{"assignee":{"login":"bob"},"plan_type":"business","last_activity_at":"2026-01-02T09:00:00Z","last_activity_editor":"JetBrains-IU/243"},
+		{"assignee":{"login":"carol"},"plan_type":"enterprise","last_activity_at":null},
+		{"assignee":{"login":"dave"},"last_activity_at":null}
+	]}`
+	var page orgSeatsPage
+	if err := json.Unmarshal([]byte(body), &page); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	snap := core.NewUsageSnapshot("copilot", "copilot")
+	applyOrgSeats("acme", page.Seats, "business", now, &snap)
+
+	for key, want := range map[string]float64{
+		"org_acme_total_seats":      4,
+		"org_acme_active_seats":     1,
+		"org_acme_inactive_seats":   3,
+		"org_acme_unused_seat_cost": 19 + 39 + 19, // dave falls back to the org billing plan
+	} {
+		m, ok := snap.Metrics[key]
+		if !ok || m.Used == nil {
+			t.Fatalf("missing %s", key)
+		}
+		if *m.Used != want {
+			t.Errorf("%s = %v, want %v", key, *m.Used, want)
+		}
+	}
+
+	if got := snap.Raw["seat_activity_acme/alice"]; got != "2026-03-30T09:00:00Z vscode" {
+		t.Errorf("alice activity = %q", got)
+	}
+	if got := snap.Raw["seat_activity_acme/carol"]; got != "never" {
+		t.Errorf("carol activity = %q", got)
+	}
+}
+
+func TestApplyOrgSeats_KeepsOnlyTheMostIdleSeats(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	seats := make([]orgSeat, 0, 500)
+	for i := range 500 {
+		var seat orgSeat
+		seat.Assignee.Login = fmt.Sprintf("user%03d", i)
+		seat.LastActivityAt = now.Add(-time.Duration(i) * time.Hour).Format(time.RFC3339)
+		seats = append(seats, seat)
+	}
+
+	snap := core.NewUsageSnapshot("copilot", "copilot")
+	applyOrgSeats("acme", seats, "business", now, &snap)
+
+	entries := 0
+	for key := range snap.Raw {
+		if strings.HasPrefix(key, "seat_activity_") {
+			entries++
+		}
+	}
+	if entries != maxIdleSeatEntries {
+		t.Fatalf("seat activity entries = %d, want %d", entries, maxIdleSeatEntries)
+	}
+	if _, ok := snap.Raw["seat_activity_acme/user499"]; !ok {
+		t.Error("the least recently active seat is missing")
+	}
+	if _, ok := snap.Raw["seat_activity_acme/user000"]; ok {
+		t.Error("the most recently active seat should be dropped")
+	}
+	if m := snap.Metrics["org_acme_total_seats"]; m.Used == nil || *m.Used != 500 {
+		t.Errorf("total seats = %+v, want 500", m)
+	}
+}
@@ -2,11 +2,13 @@ package copilot
 
 import (
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
 
 func float64Ptr(v float64) *float64 { return &v }
@@ -812,3 +814,30 @@ func TestAssistantMsgDataParsing_EmptyTools(t *testing.T) {
 		t.Errorf("expected 0 tool requests, got %d", len(tools))
 	}
 }
+
+func TestGHAuthError(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+	tests := []struct {
+		name   string
+		output string
+		auth   bool
+	}{
+		{name: "bad credentials", output: `{"message":"Bad credentials"}` + "\ngh: Bad credentials (HTTP 401)", auth: true},
+		{name: "not found", output: "gh: Not Found (HTTP 404)", auth: false},
+		{name: "network", output: "error connecting to api.github.com", auth: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap := core.UsageSnapshot{}
+			if got := shared.ApplyAuthError(&snap, ghAuthError(tt.output, exitErr)); got != tt.auth {
+				t.Fatalf("auth = %v, want %v", got, tt.auth)
+			}
+			if tt.auth && (!strings.Contains(snap.Message, "gh auth login") || !strings.Contains(snap.Message, "bad credentials")) {
+				t.Fatalf("message = %q", snap.Message)
+			}
+			if strings.Contains(snap.Message, "OAuth token expired") {
+				t.Fatalf("message = %q, a rejected gh token is not an expired OAuth token", snap.Message)
+			}
+		})
+	}
+}
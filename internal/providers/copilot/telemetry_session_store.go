@@ -25,7 +25,7 @@ func parseCopilotTelemetrySessionStore(ctx context.Context, dbPath string, skipS
 		return nil, fmt.Errorf("copilot: stat session store db: %w", err)
 	}
 
-	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=ro", dbPath))
+	db, err := shared.OpenSQLiteReadOnly(ctx, dbPath)
 	if err != nil {
 		return nil, err
 	}
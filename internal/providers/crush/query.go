@@ -76,16 +76,12 @@ func hasMessagesProviderColumn(ctx context.Context, db *sql.DB) (bool, error) {
 //     summable metric. If multiple models were used in one session, the
 //     last one wins; the per-message breakdown is out of scope for v1.
 func querySessions(ctx context.Context, dbPath string) ([]crushSession, error) {
-	db, err := openReadOnly(dbPath)
+	db, err := openReadOnly(ctx, dbPath)
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
 
-	if err := pingContext(ctx, db); err != nil {
-		return nil, err
-	}
-
 	hasProvider, err := hasMessagesProviderColumn(ctx, db)
 	if err != nil {
 		return nil, err
@@ -9,6 +9,10 @@ import (
 	"net/http"
 )
 
+// DashboardService is a Connect RPC API, so every method is a POST, reads
+// included. The Get* methods called during Fetch only query usage and are
+// allowed in read-only mode; SetHardLimit, the one mutating call, is refused
+// by SetSpendLimit.
 func (p *Provider) callDashboardAPI(ctx context.Context, baseURL, token, method string, result interface{}) error {
 	url := fmt.Sprintf("%s/aiserver.v1.DashboardService/%s", baseURL, method)
 	return p.doPost(ctx, token, url, result)
@@ -94,6 +94,12 @@ func New() *Provider {
 				},
 			},
 			Dashboard: dashboardWidget(),
+			Actions: []core.ProviderAction{
+				{Label: "Open dashboard", Kind: core.ProviderActionOpenURL, Target: "https://cursor.com/dashboard"},
+				{Label: "Open status page", Kind: core.ProviderActionOpenURL, Target: "https://status.cursor.com"},
+				{Label: "Set spend limit", Kind: core.ProviderActionSetSpendLimit, Target: "spend_limit"},
+			},
+			LocalData: LocalData,
 		}),
 		clock:        core.SystemClock{},
 		accountCache: make(map[string]cachedAccountState),
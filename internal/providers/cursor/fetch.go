@@ -44,7 +44,7 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 
 	token := acct.Token
 	if token == "" && stateDBPath != "" {
-		token = extractTokenFromStateDB(stateDBPath)
+		token = extractTokenFromStateDB(ctx, stateDBPath)
 	}
 	baseURL := shared.ResolveBaseURL(acct, cursorAPIBase)
 
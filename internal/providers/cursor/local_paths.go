@@ -1,5 +1,19 @@
 package cursor
 
+import "github.com/janekbaraniewski/openusage/internal/core"
+
+// LocalData lays out Cursor's databases under a user data directory: the
+// --user-data-dir of a custom install, or the data folder of a portable one,
+// where VS Code-style builds keep user data under user-data/. The tracking
+// database lives outside the user data directory in a standard install, so
+// it only moves when the data root has its own ai-tracking folder.
+var LocalData = core.LocalDataLayout{
+	Paths: map[string][]string{
+		"state_db":    {"User/globalStorage/state.vscdb", "user-data/User/globalStorage/state.vscdb"},
+		"tracking_db": {"ai-tracking/ai-code-tracking.db"},
+	},
+}
+
 // LocalSourcePaths returns the on-disk locations the provider reads on each
 // Fetch. The path resolution mirrors defaultTrackingDBPath / defaultStateDBPath
 // in telemetry.go, with platform-specific roots resolved at call time.
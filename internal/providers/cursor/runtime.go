@@ -1,11 +1,13 @@
 package cursor
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/janekbaraniewski/openusage/internal/audit"
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
 
 func mergeAPIIntoSnapshot(dst, src *core.UsageSnapshot) {
@@ -71,16 +73,22 @@ func (p *Provider) buildLocalOnlyMessage(snap *core.UsageSnapshot) {
 	snap.Message = "Local Cursor IDE usage tracking (API unavailable)"
 }
 
-func extractTokenFromStateDB(dbPath string) string {
-	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=ro", dbPath))
+// extractTokenFromStateDB reads the session token Cursor keeps in its
+// state.vscdb, recording the read in the audit log.
+func extractTokenFromStateDB(ctx context.Context, dbPath string) string {
+	db, err := shared.OpenSQLiteReadOnly(ctx, dbPath)
 	if err != nil {
 		return ""
 	}
 	defer db.Close()
 
 	var token string
-	if db.QueryRow(`SELECT value FROM ItemTable WHERE key = 'cursorAuth/accessToken'`).Scan(&token) != nil {
+	if db.QueryRowContext(ctx, `SELECT value FROM ItemTable WHERE key = 'cursorAuth/accessToken'`).Scan(&token) != nil {
 		return ""
 	}
-	return strings.TrimSpace(token)
+	token = strings.TrimSpace(token)
+	if token != "" {
+		audit.Credential(ctx, "sqlite:"+dbPath+"#cursorAuth/accessToken")
+	}
+	return token
 }
@@ -0,0 +1,49 @@
+package cursor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
+)
+
+type setHardLimitReq struct {
+	HardLimit           float64 `json:"hardLimit"`
+	NoUsageBasedAllowed bool    `json:"noUsageBasedAllowed"`
+}
+
+// SetSpendLimit sets the usage-based pricing hard limit through the
+// dashboard API, the same call the cursor.com settings page makes. Cursor
+// only accepts whole dollars and has no "unlimited", so a nil limit is
+// rejected.
+func (p *Provider) SetSpendLimit(ctx context.Context, acct core.AccountConfig, limitUSD *float64) error {
+	if acct.ReadOnly() {
+		return fmt.Errorf("cursor: read-only mode is on; spend limits cannot be changed")
+	}
+	if limitUSD == nil {
+		return fmt.Errorf("cursor: a spend limit cannot be removed, only changed")
+	}
+	if *limitUSD < 0 {
+		return fmt.Errorf("cursor: limit must not be negative")
+	}
+	normalizeLegacyPaths(&acct)
+	token := acct.Token
+	if token == "" {
+		if stateDBPath := acct.Path("state_db", ""); stateDBPath != "" {
+			token = extractTokenFromStateDB(ctx, stateDBPath)
+		}
+	}
+	if token == "" {
+		return fmt.Errorf("cursor: no session token; sign in to Cursor first")
+	}
+
+	body, err := json.Marshal(setHardLimitReq{HardLimit: math.Round(*limitUSD)})
+	if err != nil {
+		return err
+	}
+	var resp struct{}
+	return p.callDashboardAPIWithBody(ctx, shared.ResolveBaseURL(acct, cursorAPIBase), token, "SetHardLimit", body, &resp)
+}
@@ -0,0 +1,63 @@
+package cursor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestSetSpendLimit_CallsSetHardLimit(t *testing.T) {
+	var got setHardLimitReq
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/aiserver.v1.DashboardService/SetHardLimit" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("authorization = %q", r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	acct := core.AccountConfig{ID: "cursor-ide", Provider: "cursor", Token: "test-token", BaseURL: server.URL}
+	limit := 49.6
+	if err := New().SetSpendLimit(context.Background(), acct, &limit); err != nil {
+		t.Fatalf("SetSpendLimit() error = %v", err)
+	}
+	if got.HardLimit != 50 || got.NoUsageBasedAllowed {
+		t.Fatalf("request = %+v, want hardLimit 50 with usage-based pricing allowed", got)
+	}
+}
+
+func TestSetSpendLimit_RejectsRemoval(t *testing.T) {
+	acct := core.AccountConfig{ID: "cursor-ide", Provider: "cursor", Token: "test-token", BaseURL: "http://127.0.0.1:0"}
+	if err := New().SetSpendLimit(context.Background(), acct, nil); err == nil {
+		t.Fatal("SetSpendLimit(nil) should fail: Cursor has no unlimited setting")
+	}
+}
+
+func TestSetSpendLimit_ReadOnlySendsNothing(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	acct := core.AccountConfig{ID: "cursor-ide", Provider: "cursor", Token: "test-token", BaseURL: server.URL}
+	acct.SetHint(core.ReadOnlyHint, "true")
+	limit := 50.0
+	if err := New().SetSpendLimit(context.Background(), acct, &limit); err == nil {
+		t.Fatal("SetSpendLimit() in read-only mode should fail")
+	}
+	if requests != 0 {
+		t.Fatalf("read-only SetSpendLimit sent %d requests", requests)
+	}
+}
@@ -16,26 +16,34 @@ import (
 )
 
 func (p *Provider) readStateDB(ctx context.Context, dbPath string, snap *core.UsageSnapshot) error {
-	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=ro", dbPath))
+	db, err := shared.OpenSQLiteReadOnly(ctx, dbPath)
 	if err != nil {
-		return fmt.Errorf("opening state DB: %w", err)
-	}
-	defer db.Close()
-
-	if err := db.PingContext(ctx); err != nil {
 		return fmt.Errorf("state DB not accessible: %w", err)
 	}
+	defer db.Close()
 
-	dailyStatsRecords, err := loadDailyStatsRecords(ctx, db)
-	if err != nil {
+	// Cursor writes to state.vscdb constantly while open; a load that loses
+	// the lock race is retried rather than leaving that part of the tile
+	// blank until the next poll.
+	var dailyStatsRecords []cursorDailyStatsRecord
+	if err := shared.RetrySQLiteBusy(ctx, func() (err error) {
+		dailyStatsRecords, err = loadDailyStatsRecords(ctx, db)
+		return err
+	}); err != nil {
 		dailyStatsRecords = nil
 	}
-	composerRecords, err := p.loadComposerRecordsCached(ctx, db)
-	if err != nil {
+	var composerRecords []cursorComposerSessionRecord
+	if err := shared.RetrySQLiteBusy(ctx, func() (err error) {
+		composerRecords, err = p.loadComposerRecordsCached(ctx, db)
+		return err
+	}); err != nil {
 		log.Printf("[cursor] composerData query error: %v", err)
 	}
-	bubbleRecords, err := p.loadBubbleRecordsCached(ctx, db)
-	if err != nil {
+	var bubbleRecords []cursorBubbleRecord
+	if err := shared.RetrySQLiteBusy(ctx, func() (err error) {
+		bubbleRecords, err = p.loadBubbleRecordsCached(ctx, db)
+		return err
+	}); err != nil {
 		log.Printf("[cursor] bubbleId query error: %v", err)
 	}
 
@@ -111,7 +111,7 @@ func collectTrackingDBEvents(ctx context.Context, dbPath string) ([]shared.Telem
 		return nil, nil, nil
 	}
 
-	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=ro", dbPath))
+	db, err := shared.OpenSQLiteReadOnly(ctx, dbPath)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -200,7 +200,7 @@ func collectStateDBEvents(ctx context.Context, dbPath string) ([]shared.Telemetr
 	}
 	dbMtime := fi.ModTime().UTC()
 
-	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=ro", dbPath))
+	db, err := shared.OpenSQLiteReadOnly(ctx, dbPath)
 	if err != nil {
 		return nil, err
 	}
@@ -9,10 +9,11 @@ import (
 	"strings"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
 
 func (p *Provider) readTrackingDB(ctx context.Context, dbPath string, snap *core.UsageSnapshot) error {
-	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=ro", dbPath))
+	db, err := shared.OpenSQLiteReadOnly(ctx, dbPath)
 	if err != nil {
 		return fmt.Errorf("opening tracking DB: %w", err)
 	}
@@ -22,8 +23,11 @@ func (p *Provider) readTrackingDB(ctx context.Context, dbPath string, snap *core
 		return nil
 	}
 
-	trackingRecords, err := p.loadTrackingRecordsCached(ctx, db)
-	if err != nil {
+	var trackingRecords []cursorTrackingRecord
+	if err := shared.RetrySQLiteBusy(ctx, func() (err error) {
+		trackingRecords, err = p.loadTrackingRecordsCached(ctx, db)
+		return err
+	}); err != nil {
 		return err
 	}
 	totalRequests := len(trackingRecords)
@@ -0,0 +1,240 @@
+package declarative
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+const acmeDefinition = `
+id: acme
+name: Acme AI
+api_key_env: TEST_ACME_KEY
+base_url: https://api.acme.example
+color: teal
+endpoints:
+  - name: credits
+    url: /v1/credits
+    metrics:
+      - key: credit_balance
+        label: Credits
+        remaining: data.credits.remaining
+        limit: data.credits.total
+        unit: USD
+        window: current
+      - key: spend_month
+        used: $.data.periods[-1].spend_cents
+        scale: 0.01
+        unit: USD
+        window: month
+    raw:
+      plan: data.plan
+  - name: quota
+    url: /v1/quota?key={{api_key}}
+    method: GET
+    headers:
+      X-Team: "{{env:TEST_ACME_TEAM}}"
+    metrics:
+      - key: rpm
+        used: used
+        limit: limit
+        unit: requests
+        window: 1m
+`
+
+func TestFetch_MapsEndpointsToMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/credits":
+			if got := r.Header.Get("Authorization"); got != "Bearer k&y" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"data": {"plan": "pro", "credits": {"remaining": "12.5", "total": 50},
+				"periods": [{"spend_cents": 100}, {"spend_cents": 2345}]}}`))
+		case "/v1/quota":
+			if r.URL.Query().Get("key") != "k&y" || r.Header.Get("X-Team") != "core" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Write([]byte(`{"used": 7, "limit": 60}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_ACME_KEY", "k&y")
+	t.Setenv("TEST_ACME_TEAM", "core")
+
+	def, err := Parse([]byte(acmeDefinition))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	p := New(def)
+	if p.ID() != "acme" || p.Spec().Auth.APIKeyEnv != "TEST_ACME_KEY" {
+		t.Fatalf("spec = %+v", p.Spec())
+	}
+
+	snap, err := p.Fetch(context.Background(), core.AccountConfig{ID: "acme", Provider: "acme", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if snap.Status != core.StatusOK {
+		t.Fatalf("status = %s (%s), raw = %v", snap.Status, snap.Message, snap.Raw)
+	}
+
+	credits := snap.Metrics["credit_balance"]
+	if credits.Remaining == nil || *credits.Remaining != 12.5 || credits.Limit == nil || *credits.Limit != 50 || credits.Unit != "USD" {
+		t.Errorf("credit_balance = %+v", credits)
+	}
+	if spend := snap.Metrics["spend_month"]; spend.Used == nil || *spend.Used != 23.45 {
+		t.Errorf("spend_month = %+v", spend)
+	}
+	if rpm := snap.Metrics["rpm"]; rpm.Used == nil || *rpm.Used != 7 || rpm.Limit == nil || *rpm.Limit != 60 {
+		t.Errorf("rpm = %+v", rpm)
+	}
+	if snap.Raw["plan"] != "pro" {
+		t.Errorf("raw plan = %q", snap.Raw["plan"])
+	}
+}
+
+func TestFetch_AllEndpointsFailing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	def, err := Parse([]byte(acmeDefinition))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := New(def)
+
+	t.Setenv("TEST_ACME_KEY", "")
+	snap, _ := p.Fetch(context.Background(), core.AccountConfig{ID: "acme", BaseURL: server.URL})
+	if snap.Status != core.StatusAuth {
+		t.Fatalf("missing key: status = %s", snap.Status)
+	}
+
+	t.Setenv("TEST_ACME_KEY", "wrong")
+	snap, _ = p.Fetch(context.Background(), core.AccountConfig{ID: "acme", BaseURL: server.URL})
+	if snap.Status != core.StatusAuth {
+		t.Fatalf("rejected key: status = %s (%s)", snap.Status, snap.Message)
+	}
+	if !strings.Contains(snap.Raw["credits_error"], "HTTP 401") {
+		t.Errorf("credits_error = %q", snap.Raw["credits_error"])
+	}
+}
+
+func TestFetch_ReadOnlySkipsPOST(t *testing.T) {
+	var posts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts++
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"used": 3}`))
+	}))
+	defer server.Close()
+
+	def, err := Parse([]byte(`
+id: acme
+name: Acme AI
+base_url: https://api.acme.example
+endpoints:
+  - name: usage
+    url: /v1/usage/query
+    method: POST
+    body: '{"period": "month"}'
+    metrics:
+      - key: spend_month
+        used: used
+        unit: USD
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	acct := core.AccountConfig{ID: "acme", BaseURL: server.URL}
+	acct.SetHint(core.ReadOnlyHint, "true")
+
+	snap, err := New(def).Fetch(context.Background(), acct)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if posts != 0 {
+		t.Fatalf("read-only fetch sent %d POST requests", posts)
+	}
+	if snap.Raw["usage"] != core.ReadOnlySkipped {
+		t.Errorf("raw usage = %q, want %q", snap.Raw["usage"], core.ReadOnlySkipped)
+	}
+	if snap.Status == core.StatusError {
+		t.Errorf("status = %s (%s), skipped endpoints are not failures", snap.Status, snap.Message)
+	}
+}
+
+func TestParse_Rejects(t *testing.T) {
+	cases := map[string]string{
+		"bad id":                "id: Acme!\nendpoints: [{url: 'https://x', raw: {a: b}}]",
+		"no endpoints":          "id: acme",
+		"relative without base": "id: acme\nendpoints: [{url: /v1, raw: {a: b}}]",
+		"nothing mapped":        "id: acme\nendpoints: [{url: 'https://x'}]",
+		"metric without path":   "id: acme\nendpoints: [{url: 'https://x', metrics: [{key: m}]}]",
+		"unknown placeholder":   "id: acme\nheaders: {Authorization: 'Bearer {{token}}'}\nendpoints: [{url: 'https://x', raw: {a: b}}]",
+		"unknown field":         "id: acme\nendpont: []",
+		"unknown color":         "id: acme\ncolor: neon\nendpoints: [{url: 'https://x', raw: {a: b}}]",
+	}
+	for name, src := range cases {
+		if _, err := Parse([]byte(src)); err == nil {
+			t.Errorf("%s: Parse succeeded", name)
+		}
+	}
+}
+
+func TestLoadDir_SkipsBrokenAndDuplicateFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a.yaml", acmeDefinition)
+	write("b.json", `{"id": "acme", "endpoints": [{"url": "https://x", "raw": {"a": "b"}}]}`)
+	write("c.yml", "id: broken")
+	write("d.json", `{"id": "other", "endpoints": [{"url": "https://x", "raw": {"a": "b"}}]}`)
+	write("notes.txt", "ignored")
+
+	defs, errs := LoadDir(dir)
+	if len(defs) != 2 || defs[0].ID != "acme" || defs[1].ID != "other" {
+		t.Fatalf("defs = %+v", defs)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("errs = %v", errs)
+	}
+
+	if defs, errs := LoadDir(filepath.Join(dir, "missing")); defs != nil || errs != nil {
+		t.Fatalf("missing dir: %v %v", defs, errs)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	doc := map[string]any{"a": map[string]any{"b": []any{map[string]any{"c": 1}, map[string]any{"c": 2}}}}
+	for path, want := range map[string]any{"a.b[0].c": 1, "$.a.b[-1].c": 2, "a.b[1].c": 2} {
+		if got, ok := lookup(doc, path); !ok || got != want {
+			t.Errorf("lookup(%q) = %v, %v", path, got, ok)
+		}
+	}
+	for _, path := range []string{"a.x", "a.b[2].c", "a.b.c", "a.b[x]"} {
+		if _, ok := lookup(doc, path); ok {
+			t.Errorf("lookup(%q) resolved", path)
+		}
+	}
+}
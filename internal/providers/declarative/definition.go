@@ -0,0 +1,221 @@
+// Package declarative builds providers from YAML definitions in the config
+// directory, for REST APIs that only need "GET this URL, map these JSON
+// fields to metrics". Each file describes one provider: its endpoints, the
+// headers to send (with the API key templated in), and JSON paths to the
+// numbers that become metrics.
+package declarative
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// Definition is one provider file.
+type Definition struct {
+	ID        string            `yaml:"id"`
+	Name      string            `yaml:"name"`
+	DocURL    string            `yaml:"doc_url"`
+	Color     string            `yaml:"color"`
+	BaseURL   string            `yaml:"base_url"`
+	APIKeyEnv string            `yaml:"api_key_env"`
+	Headers   map[string]string `yaml:"headers"`
+	Endpoints []Endpoint        `yaml:"endpoints"`
+
+	// Path is the file the definition was read from.
+	Path string `yaml:"-"`
+}
+
+// Endpoint is one request and the fields read from its JSON response.
+type Endpoint struct {
+	Name    string            `yaml:"name"`
+	URL     string            `yaml:"url"`
+	Method  string            `yaml:"method"`
+	Body    string            `yaml:"body"`
+	Headers map[string]string `yaml:"headers"`
+	Metrics []MetricMapping   `yaml:"metrics"`
+	Raw     map[string]string `yaml:"raw"`
+}
+
+// MetricMapping turns response fields into one core.Metric. Used, Limit and
+// Remaining are JSON paths; Scale multiplies every value read, for APIs that
+// report cents or micro-dollars.
+type MetricMapping struct {
+	Key       string  `yaml:"key"`
+	Label     string  `yaml:"label"`
+	Used      string  `yaml:"used"`
+	Limit     string  `yaml:"limit"`
+	Remaining string  `yaml:"remaining"`
+	Unit      string  `yaml:"unit"`
+	Window    string  `yaml:"window"`
+	Scale     float64 `yaml:"scale"`
+}
+
+var (
+	idPattern       = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+	templatePattern = regexp.MustCompile(`\{\{\s*([a-z_]+)(?::([A-Za-z_][A-Za-z0-9_]*))?\s*\}\}`)
+)
+
+// Parse decodes and validates one definition. JSON is accepted too, being a
+// subset of YAML.
+func Parse(data []byte) (Definition, error) {
+	var def Definition
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&def); err != nil {
+		return def, fmt.Errorf("decode: %w", err)
+	}
+	def.ID = strings.TrimSpace(def.ID)
+	if def.Name == "" {
+		def.Name = def.ID
+	}
+	for i := range def.Endpoints {
+		ep := &def.Endpoints[i]
+		ep.Method = strings.ToUpper(strings.TrimSpace(ep.Method))
+		if ep.Method == "" {
+			ep.Method = http.MethodGet
+		}
+		if ep.Name == "" {
+			ep.Name = fmt.Sprintf("endpoint_%d", i+1)
+		}
+		for j := range ep.Metrics {
+			if ep.Metrics[j].Scale == 0 {
+				ep.Metrics[j].Scale = 1
+			}
+		}
+	}
+	return def, def.validate()
+}
+
+func (d Definition) validate() error {
+	if !idPattern.MatchString(d.ID) {
+		return fmt.Errorf("id %q must be lowercase letters, digits, '-' or '_'", d.ID)
+	}
+	if d.Color != "" && !knownColor(d.Color) {
+		return fmt.Errorf("unknown color %q", d.Color)
+	}
+	if len(d.Endpoints) == 0 {
+		return errors.New("no endpoints")
+	}
+	if err := validateTemplates(d.BaseURL, d.Headers); err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	for _, ep := range d.Endpoints {
+		switch {
+		case ep.URL == "":
+			return fmt.Errorf("endpoint %s: url is required", ep.Name)
+		case !strings.HasPrefix(ep.URL, "/") && !strings.Contains(ep.URL, "://"):
+			return fmt.Errorf("endpoint %s: url must be absolute or start with /", ep.Name)
+		case strings.HasPrefix(ep.URL, "/") && d.BaseURL == "":
+			return fmt.Errorf("endpoint %s: relative url needs base_url", ep.Name)
+		case ep.Method != http.MethodGet && ep.Method != http.MethodPost:
+			return fmt.Errorf("endpoint %s: method must be GET or POST", ep.Name)
+		case len(ep.Metrics) == 0 && len(ep.Raw) == 0:
+			return fmt.Errorf("endpoint %s: map at least one metric or raw field", ep.Name)
+		}
+		if err := validateTemplates(ep.URL+ep.Body, ep.Headers); err != nil {
+			return fmt.Errorf("endpoint %s: %w", ep.Name, err)
+		}
+		for _, m := range ep.Metrics {
+			if m.Key == "" {
+				return fmt.Errorf("endpoint %s: metric without a key", ep.Name)
+			}
+			if seen[m.Key] {
+				return fmt.Errorf("metric %s is mapped twice", m.Key)
+			}
+			seen[m.Key] = true
+			if m.Used == "" && m.Limit == "" && m.Remaining == "" {
+				return fmt.Errorf("metric %s: set used, limit or remaining", m.Key)
+			}
+		}
+	}
+	return nil
+}
+
+func validateTemplates(text string, headers map[string]string) error {
+	texts := []string{text}
+	for _, v := range headers {
+		texts = append(texts, v)
+	}
+	for _, t := range texts {
+		for _, m := range templatePattern.FindAllStringSubmatch(t, -1) {
+			switch {
+			case m[1] == "api_key" && m[2] == "":
+			case m[1] == "env" && m[2] != "":
+			default:
+				return fmt.Errorf("unknown placeholder %s; use {{api_key}} or {{env:NAME}}", m[0])
+			}
+		}
+	}
+	return nil
+}
+
+func knownColor(color string) bool {
+	switch core.DashboardColorRole(color) {
+	case core.DashboardColorRoleAuto, core.DashboardColorRoleGreen, core.DashboardColorRolePeach,
+		core.DashboardColorRoleLavender, core.DashboardColorRoleBlue, core.DashboardColorRoleTeal,
+		core.DashboardColorRoleYellow, core.DashboardColorRoleSky, core.DashboardColorRoleSapphire,
+		core.DashboardColorRoleMaroon, core.DashboardColorRoleFlamingo, core.DashboardColorRoleRosewater,
+		core.DashboardColorRoleMauve:
+		return true
+	}
+	return false
+}
+
+// LoadDir reads every *.yaml, *.yml and *.json file in dir, sorted by name. A
+// missing directory is not an error. Files that fail to parse, and later
+// files reusing an ID, are reported in errs and skipped; the rest load.
+func LoadDir(dir string) (defs []Definition, errs []error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, []error{fmt.Errorf("reading %s: %w", dir, err)}
+	}
+	var names []string
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+			if !entry.IsDir() {
+				names = append(names, entry.Name())
+			}
+		}
+	}
+	sort.Strings(names)
+
+	seen := map[string]string{}
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		def, err := Parse(data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		if prev, dup := seen[def.ID]; dup {
+			errs = append(errs, fmt.Errorf("%s: id %q already defined in %s", path, def.ID, prev))
+			continue
+		}
+		seen[def.ID] = path
+		def.Path = path
+		defs = append(defs, def)
+	}
+	return defs, errs
+}
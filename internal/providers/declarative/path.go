@@ -0,0 +1,70 @@
+package declarative
+
+import (
+	"strconv"
+	"strings"
+)
+
+// lookup resolves a dotted JSON path such as "data.credits[0].remaining"
+// against a decoded response. A leading "$." is allowed and ignored.
+func lookup(root any, path string) (any, bool) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+	path = strings.TrimPrefix(path, ".")
+	if path == "" {
+		return root, true
+	}
+
+	current := root
+	for _, segment := range strings.Split(path, ".") {
+		key, indexes, ok := splitIndexes(segment)
+		if !ok {
+			return nil, false
+		}
+		if key != "" {
+			node, ok := current.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if current, ok = node[key]; !ok {
+				return nil, false
+			}
+		}
+		for _, idx := range indexes {
+			list, ok := current.([]any)
+			if !ok {
+				return nil, false
+			}
+			if idx < 0 {
+				idx += len(list)
+			}
+			if idx < 0 || idx >= len(list) {
+				return nil, false
+			}
+			current = list[idx]
+		}
+	}
+	return current, true
+}
+
+// splitIndexes splits "items[0][-1]" into "items" and [0, -1].
+func splitIndexes(segment string) (string, []int, bool) {
+	open := strings.IndexByte(segment, '[')
+	if open < 0 {
+		return segment, nil, segment != ""
+	}
+	key, rest := segment[:open], segment[open:]
+	var indexes []int
+	for rest != "" {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 {
+			return "", nil, false
+		}
+		idx, err := strconv.Atoi(rest[1:end])
+		if err != nil {
+			return "", nil, false
+		}
+		indexes = append(indexes, idx)
+		rest = rest[end+1:]
+	}
+	return key, indexes, true
+}
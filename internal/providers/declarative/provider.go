@@ -0,0 +1,262 @@
+package declarative
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/parsers"
+	"github.com/janekbaraniewski/openusage/internal/providers/providerbase"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
+)
+
+// maxResponseBytes bounds one endpoint's response body.
+const maxResponseBytes = 4 << 20
+
+// Provider fetches a Definition's endpoints and maps their fields.
+type Provider struct {
+	providerbase.Base
+	def     Definition
+	needKey bool
+}
+
+// New builds the provider for a parsed definition. A definition with
+// api_key_env and no headers sends "Authorization: Bearer {{api_key}}".
+func New(def Definition) *Provider {
+	if len(def.Headers) == 0 && def.APIKeyEnv != "" {
+		def.Headers = map[string]string{"Authorization": "Bearer {{api_key}}"}
+	}
+
+	auth := core.ProviderAuthSpec{Type: core.ProviderAuthTypeLocal, DefaultAccountID: def.ID}
+	quickstart := []string{fmt.Sprintf(`Add {"id": %q, "provider": %q} to "accounts" in settings.json.`, def.ID, def.ID)}
+	if def.APIKeyEnv != "" {
+		auth.Type = core.ProviderAuthTypeAPIKey
+		auth.APIKeyEnv = def.APIKeyEnv
+		quickstart = append([]string{fmt.Sprintf("Set %s to a valid API key.", def.APIKeyEnv)}, quickstart...)
+	}
+
+	labels := map[string]string{}
+	for _, ep := range def.Endpoints {
+		for _, m := range ep.Metrics {
+			if m.Label != "" {
+				labels[m.Key] = m.Label
+			}
+		}
+	}
+	options := []providerbase.DashboardOption{providerbase.WithMetricLabels(labels)}
+	if def.Color != "" {
+		options = append(options, providerbase.WithColorRole(core.DashboardColorRole(def.Color)))
+	}
+
+	return &Provider{
+		Base: providerbase.New(core.ProviderSpec{
+			ID: def.ID,
+			Info: core.ProviderInfo{
+				Name:         def.Name,
+				Capabilities: []string{"declarative"},
+				DocURL:       def.DocURL,
+			},
+			Auth:      auth,
+			Setup:     core.ProviderSetupSpec{Quickstart: quickstart},
+			Dashboard: providerbase.DefaultDashboard(options...),
+		}),
+		def:     def,
+		needKey: def.usesAPIKey(),
+	}
+}
+
+// Definition returns the definition the provider was built from.
+func (p *Provider) Definition() Definition { return p.def }
+
+func (d Definition) usesAPIKey() bool {
+	texts := []string{d.BaseURL}
+	for _, v := range d.Headers {
+		texts = append(texts, v)
+	}
+	for _, ep := range d.Endpoints {
+		texts = append(texts, ep.URL, ep.Body)
+		for _, v := range ep.Headers {
+			texts = append(texts, v)
+		}
+	}
+	for _, t := range texts {
+		for _, m := range templatePattern.FindAllStringSubmatch(t, -1) {
+			if m[1] == "api_key" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Fetch calls every endpoint in order. A failing endpoint is recorded in Raw
+// as <name>_error; the snapshot is only an error when all of them fail.
+func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.UsageSnapshot, error) {
+	if acct.APIKeyEnv == "" {
+		acct.APIKeyEnv = p.def.APIKeyEnv
+	}
+	var apiKey string
+	if p.needKey {
+		key, authSnap := shared.RequireAPIKey(acct, p.ID())
+		if authSnap != nil {
+			return *authSnap, nil
+		}
+		apiKey = key
+	}
+
+	baseURL := strings.TrimRight(shared.ResolveBaseURL(acct, p.def.BaseURL), "/")
+	snap := core.NewUsageSnapshot(p.ID(), acct.ID)
+
+	var firstErr error
+	failed, skipped := 0, 0
+	for _, ep := range p.def.Endpoints {
+		// A definition can point a POST anywhere, so it may cost money or
+		// change state; read-only mode only issues its GETs.
+		if acct.ReadOnly() && ep.Method != http.MethodGet {
+			snap.Raw[ep.Name] = core.ReadOnlySkipped
+			skipped++
+			continue
+		}
+		if err := p.fetchEndpoint(ctx, ep, baseURL, apiKey, &snap); err != nil {
+			snap.Raw[ep.Name+"_error"] = err.Error()
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+		}
+	}
+
+	if failed > 0 && failed == len(p.def.Endpoints)-skipped && snap.Status == "" {
+		snap.Status = core.StatusError
+		snap.Message = firstErr.Error()
+	}
+	shared.FinalizeStatus(&snap)
+	return snap, nil
+}
+
+func (p *Provider) fetchEndpoint(ctx context.Context, ep Endpoint, baseURL, apiKey string, snap *core.UsageSnapshot) error {
+	target := expand(ep.URL, apiKey, url.QueryEscape)
+	if strings.HasPrefix(target, "/") {
+		target = expand(baseURL, apiKey, url.QueryEscape) + target
+	}
+
+	var body io.Reader
+	if ep.Body != "" {
+		body = strings.NewReader(expand(ep.Body, apiKey, nil))
+	}
+	req, err := http.NewRequestWithContext(ctx, ep.Method, target, body)
+	if err != nil {
+		return fmt.Errorf("%s: creating request: %w", ep.Name, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range p.def.Headers {
+		req.Header.Set(k, expand(v, apiKey, nil))
+	}
+	for k, v := range ep.Headers {
+		req.Header.Set(k, expand(v, apiKey, nil))
+	}
+
+	resp, err := p.Client().Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: request failed: %w", ep.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		shared.ApplyStatusFromResponse(resp, snap)
+		return fmt.Errorf("%s: HTTP %d", ep.Name, resp.StatusCode)
+	}
+	for k, v := range parsers.RedactHeaders(resp.Header) {
+		if strings.HasPrefix(k, "x-ratelimit") || strings.HasPrefix(k, "ratelimit") {
+			snap.Raw[k] = v
+		}
+	}
+
+	dec := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("%s: parsing response: %w", ep.Name, err)
+	}
+
+	for _, m := range ep.Metrics {
+		metric := core.Metric{Unit: m.Unit, Window: m.Window}
+		metric.Used = number(doc, m.Used, m.Scale)
+		metric.Limit = number(doc, m.Limit, m.Scale)
+		metric.Remaining = number(doc, m.Remaining, m.Scale)
+		if metric.Used == nil && metric.Limit == nil && metric.Remaining == nil {
+			snap.Raw[m.Key+"_missing"] = "no value at mapped paths"
+			continue
+		}
+		snap.Metrics[m.Key] = metric
+	}
+	for key, path := range ep.Raw {
+		if v, ok := lookup(doc, path); ok {
+			if s := rawString(v); s != "" {
+				snap.Raw[key] = s
+			}
+		}
+	}
+	return nil
+}
+
+func number(doc any, path string, scale float64) *float64 {
+	if path == "" {
+		return nil
+	}
+	v, ok := lookup(doc, path)
+	if !ok {
+		return nil
+	}
+	f, ok := shared.NumberFromAny(v)
+	if !ok {
+		return nil
+	}
+	f *= scale
+	return &f
+}
+
+func rawString(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	case bool:
+		return strconv.FormatBool(t)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(bytes.TrimSpace(b))
+}
+
+// expand fills {{api_key}} and {{env:NAME}} placeholders. escape, when set,
+// is applied to substituted values so a key can sit in a query string.
+func expand(text, apiKey string, escape func(string) string) string {
+	return templatePattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := templatePattern.FindStringSubmatch(match)
+		value := apiKey
+		if m[1] == "env" {
+			value = os.Getenv(m[2])
+		}
+		if escape != nil {
+			value = escape(value)
+		}
+		return value
+	})
+}
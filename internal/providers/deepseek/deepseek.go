@@ -53,6 +53,11 @@ func New() *Provider {
 			CreditMetrics: map[string]core.BalanceSemantics{
 				"total_balance": core.BalancePoint,
 			},
+			Actions: []core.ProviderAction{
+				{Label: "Open top-up page", Kind: core.ProviderActionOpenURL, Target: "https://platform.deepseek.com/top_up"},
+				{Label: "Open status page", Kind: core.ProviderActionOpenURL, Target: "https://status.deepseek.com"},
+				{Label: "Copy API base URL", Kind: core.ProviderActionCopy, Target: defaultBaseURL, SnapshotKey: "api_base_url"},
+			},
 		}),
 	}
 }
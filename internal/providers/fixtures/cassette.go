@@ -0,0 +1,73 @@
+// Package fixtures records provider HTTP traffic into sanitized, replayable
+// cassettes. Contributors capture real API responses once with
+// `openusage export --record-fixtures DIR`, commit the cassette under the
+// provider's testdata/, and replay it in unit tests with the fixturetest
+// subpackage instead of hand-writing large JSON payloads.
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Interaction is one recorded request/response pair. Requests are keyed by
+// method, path and query only; request headers and bodies are never stored
+// because that is where credentials travel.
+type Interaction struct {
+	Method string            `json:"method"`
+	Host   string            `json:"host,omitempty"`
+	Path   string            `json:"path"`
+	Query  string            `json:"query,omitempty"`
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"`
+	// Body holds JSON responses verbatim so cassettes stay diffable; Text
+	// holds everything else.
+	Body json.RawMessage `json:"body,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+// ResponseBody returns the recorded response payload.
+func (i Interaction) ResponseBody() []byte {
+	if len(i.Body) > 0 {
+		return i.Body
+	}
+	return []byte(i.Text)
+}
+
+// Cassette is the sanitized traffic of one account's Fetch.
+type Cassette struct {
+	Provider     string        `json:"provider"`
+	RecordedAt   time.Time     `json:"recorded_at"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Load reads a cassette written by Save.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fixtures: reading %s: %w", path, err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("fixtures: parsing %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette as indented JSON, creating parent directories.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("fixtures: encoding cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("fixtures: creating %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("fixtures: writing %s: %w", path, err)
+	}
+	return nil
+}
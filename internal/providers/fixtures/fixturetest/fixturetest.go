@@ -0,0 +1,134 @@
+// Package fixturetest replays cassettes recorded by the fixtures package
+// behind an httptest server, so provider tests point acct.BaseURL at real
+// response shapes instead of hand-written payloads.
+package fixturetest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/providers/fixtures"
+)
+
+// Option customizes a replay server.
+type Option func(*replayer)
+
+// ShiftDatesTo moves every YYYY-MM-DD date in the replayed bodies forward by
+// the whole days between the recording and now, so windowed metrics ("today",
+// "7d") see the recorded activity as recent. Unix timestamps are not shifted.
+func ShiftDatesTo(now time.Time) Option {
+	return func(r *replayer) {
+		recorded := r.cassette.RecordedAt.UTC()
+		from := time.Date(recorded.Year(), recorded.Month(), recorded.Day(), 0, 0, 0, 0, time.UTC)
+		now = now.UTC()
+		to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		r.shiftDays = int(to.Sub(from).Hours() / 24)
+	}
+}
+
+// Serve loads the cassette at path and replays it. The server is closed when
+// the test ends.
+func Serve(t testing.TB, path string, opts ...Option) *httptest.Server {
+	t.Helper()
+	c, err := fixtures.Load(path)
+	if err != nil {
+		t.Fatalf("fixturetest: %v", err)
+	}
+	return ServeCassette(t, c, opts...)
+}
+
+// ServeCassette replays c. Requests match on method, path and query; repeated
+// requests consume recorded interactions in order and the last match is
+// replayed again once they run out. Unmatched requests get a 404 and are
+// logged, since they usually mean the provider's request flow changed and
+// the cassette needs re-recording.
+func ServeCassette(t testing.TB, c *fixtures.Cassette, opts ...Option) *httptest.Server {
+	t.Helper()
+	r := &replayer{t: t, cassette: c, used: make([]bool, len(c.Interactions))}
+	for _, opt := range opts {
+		opt(r)
+	}
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+type replayer struct {
+	t         testing.TB
+	cassette  *fixtures.Cassette
+	shiftDays int
+
+	mu   sync.Mutex
+	used []bool
+}
+
+func (r *replayer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	in, ok := r.match(req)
+	if !ok {
+		r.t.Logf("fixturetest: no recorded interaction for %s %s", req.Method, req.URL.RequestURI())
+		http.NotFound(w, req)
+		return
+	}
+	for k, v := range in.Header {
+		w.Header().Set(k, v)
+	}
+	w.WriteHeader(in.Status)
+	_, _ = w.Write(r.shift(in.ResponseBody()))
+}
+
+func (r *replayer) match(req *http.Request) (fixtures.Interaction, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	query := canonicalQuery(req.URL.RawQuery)
+	exact := func(in fixtures.Interaction) bool {
+		return in.Method == req.Method && in.Path == req.URL.Path && canonicalQuery(in.Query) == query
+	}
+	pathOnly := func(in fixtures.Interaction) bool {
+		return in.Method == req.Method && in.Path == req.URL.Path
+	}
+	for _, pred := range []func(fixtures.Interaction) bool{exact, pathOnly} {
+		last := -1
+		for i, in := range r.cassette.Interactions {
+			if !pred(in) {
+				continue
+			}
+			if !r.used[i] {
+				r.used[i] = true
+				return in, true
+			}
+			last = i
+		}
+		if last >= 0 {
+			return r.cassette.Interactions[last], true
+		}
+	}
+	return fixtures.Interaction{}, false
+}
+
+func canonicalQuery(raw string) string {
+	vals, err := url.ParseQuery(raw)
+	if err != nil {
+		return raw
+	}
+	return vals.Encode()
+}
+
+var isoDate = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+
+func (r *replayer) shift(body []byte) []byte {
+	if r.shiftDays == 0 {
+		return body
+	}
+	return isoDate.ReplaceAllFunc(body, func(m []byte) []byte {
+		d, err := time.Parse("2006-01-02", string(m))
+		if err != nil {
+			return m
+		}
+		return []byte(d.AddDate(0, 0, r.shiftDays).Format("2006-01-02"))
+	})
+}
@@ -0,0 +1,55 @@
+package fixturetest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/providers/fixtures"
+)
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestServeCassette(t *testing.T) {
+	c := &fixtures.Cassette{
+		Provider:   "openrouter",
+		RecordedAt: time.Date(2026, 10, 1, 18, 0, 0, 0, time.UTC),
+		Interactions: []fixtures.Interaction{
+			{Method: "GET", Path: "/generation", Query: "limit=2&offset=0", Status: 200, Body: json.RawMessage(`{"page":1}`)},
+			{Method: "GET", Path: "/generation", Query: "offset=2&limit=2", Status: 200, Body: json.RawMessage(`{"page":2}`)},
+			{Method: "GET", Path: "/activity", Status: 200, Body: json.RawMessage(`{"date":"2026-10-01","at":"2026-09-30T23:00:00Z"}`)},
+			{Method: "GET", Path: "/key", Status: 401, Text: "nope"},
+		},
+	}
+	srv := ServeCassette(t, c, ShiftDatesTo(time.Date(2026, 10, 14, 1, 0, 0, 0, time.UTC)))
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/generation?offset=2&limit=2", 200, `{"page":2}`}, // query order is irrelevant
+		{"/generation?limit=2&offset=0", 200, `{"page":1}`},
+		{"/generation?limit=2&offset=0", 200, `{"page":1}`}, // repeats replay the last match
+		{"/activity", 200, `{"date":"2026-10-14","at":"2026-10-13T23:00:00Z"}`},
+		{"/key", 401, "nope"},
+		{"/credits", 404, "404 page not found\n"},
+	}
+	for _, tt := range tests {
+		status, body := get(t, srv.URL+tt.path)
+		if status != tt.status || body != tt.body {
+			t.Errorf("GET %s = %d %q, want %d %q", tt.path, status, body, tt.status, tt.body)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package fixtures
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// unattributedProvider names the cassette for requests made without an
+// account in their context (e.g. a provider that drops the Fetch ctx).
+const unattributedProvider = "unattributed"
+
+type sourceKey struct{}
+
+type source struct {
+	provider string
+	account  string
+}
+
+// WithAccount tags ctx so requests made with it are recorded into that
+// account's cassette. It is a no-op unless a Recorder is installed.
+func WithAccount(ctx context.Context, providerID, accountID string) context.Context {
+	return context.WithValue(ctx, sourceKey{}, source{provider: providerID, account: accountID})
+}
+
+type exchange struct {
+	method string
+	host   string
+	path   string
+	query  string
+	status int
+	header http.Header
+	body   []byte
+}
+
+// Recorder is an http.RoundTripper that captures every response passing
+// through it. Nothing is sanitized until WriteDir, so the raw traffic only
+// ever lives in memory.
+type Recorder struct {
+	next http.RoundTripper
+	now  func() time.Time
+
+	mu      sync.Mutex
+	tapes   map[source][]exchange
+	secrets map[string]struct{}
+}
+
+// NewRecorder wraps next (http.DefaultTransport when nil).
+func NewRecorder(next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{
+		next:    next,
+		now:     time.Now,
+		tapes:   make(map[source][]exchange),
+		secrets: make(map[string]struct{}),
+	}
+}
+
+// RoundTrip forwards the request and records the response.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.noteSecrets(req)
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+
+	src, ok := req.Context().Value(sourceKey{}).(source)
+	if !ok {
+		src = source{provider: unattributedProvider}
+	}
+	r.mu.Lock()
+	r.tapes[src] = append(r.tapes[src], exchange{
+		method: req.Method,
+		host:   req.URL.Host,
+		path:   req.URL.Path,
+		query:  req.URL.RawQuery,
+		status: resp.StatusCode,
+		header: resp.Header.Clone(),
+		body:   body,
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// noteSecrets remembers credential values sent with the request so they can
+// be scrubbed wherever a response echoes them back.
+func (r *Recorder) noteSecrets(req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, vals := range req.Header {
+		if !secretKey(name) {
+			continue
+		}
+		for _, v := range vals {
+			for _, part := range strings.FieldsFunc(v, func(c rune) bool { return c == ' ' || c == ';' || c == '=' || c == ',' }) {
+				if len(part) >= minSecretLen && !strings.EqualFold(part, "bearer") && !strings.EqualFold(part, "basic") {
+					r.secrets[part] = struct{}{}
+				}
+			}
+		}
+	}
+	for name, vals := range req.URL.Query() {
+		if !secretKey(name) {
+			continue
+		}
+		for _, v := range vals {
+			if len(v) >= minSecretLen {
+				r.secrets[v] = struct{}{}
+			}
+		}
+	}
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// WriteDir sanitizes everything recorded so far and writes one cassette per
+// account as DIR/<provider>_<account>.json. It returns the written paths.
+func (r *Recorder) WriteDir(dir string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sources := make([]source, 0, len(r.tapes))
+	for src := range r.tapes {
+		sources = append(sources, src)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].provider != sources[j].provider {
+			return sources[i].provider < sources[j].provider
+		}
+		return sources[i].account < sources[j].account
+	})
+
+	secrets := make([]string, 0, len(r.secrets))
+	for s := range r.secrets {
+		secrets = append(secrets, s)
+	}
+	// One sanitizer for the whole run keeps pseudonyms and the numeric
+	// scale consistent across accounts.
+	san, err := newSanitizer(secrets)
+	if err != nil {
+		return nil, err
+	}
+	for _, src := range sources {
+		for _, ex := range r.tapes[src] {
+			san.collectIDs(ex.body)
+		}
+	}
+
+	recordedAt := r.now().UTC().Truncate(time.Second)
+	paths := make([]string, 0, len(sources))
+	for _, src := range sources {
+		c := &Cassette{Provider: src.provider, RecordedAt: recordedAt}
+		for _, ex := range r.tapes[src] {
+			c.Interactions = append(c.Interactions, san.interaction(ex))
+		}
+		name := src.provider
+		if src.account != "" {
+			name += "_" + src.account
+		}
+		path := filepath.Join(dir, unsafeFileChars.ReplaceAllString(name, "-")+".json")
+		if err := c.Save(path); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("fixtures: no provider traffic was recorded")
+	}
+	return paths, nil
+}
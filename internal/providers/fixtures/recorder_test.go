@@ -0,0 +1,116 @@
+package fixtures
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorder_WriteDirSanitizes(t *testing.T) {
+	const apiKey = "sk-or-v1-0123456789abcdef"
+	const orgID = "25fb0cf4-fb6f-41dc-964f-ec8a3857bdcd"
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abcdef0123456789")
+		w.Header().Set("X-Ratelimit-Remaining", "42")
+		switch r.URL.Path {
+		case "/orgs":
+			_, _ = w.Write([]byte(`{"data":[{"org_id":"` + orgID + `","owner_email":"jan@example.org","echo":"key ` + apiKey + `","usage":10.5,"requests":200,"used_percent":40,"created":1760000000,"date":"2026-10-14","free":0}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":{"name":"` + orgID + `"}}`))
+		}
+	}))
+	defer upstream.Close()
+
+	rec := NewRecorder(nil)
+	rec.now = func() time.Time { return time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC) }
+	client := &http.Client{Transport: rec}
+	ctx := WithAccount(context.Background(), "openrouter", "work")
+	for _, path := range []string{"/orgs", "/orgs/" + orgID + "?key=" + apiKey} {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, upstream.URL+path, nil)
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		// The caller still sees the untouched body.
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if !strings.Contains(string(body), orgID) {
+			t.Fatalf("recorder altered the live response: %s", body)
+		}
+	}
+
+	dir := t.TempDir()
+	paths, err := rec.WriteDir(dir)
+	if err != nil {
+		t.Fatalf("WriteDir: %v", err)
+	}
+	if want := filepath.Join(dir, "openrouter_work.json"); len(paths) != 1 || paths[0] != want {
+		t.Fatalf("paths = %v, want [%s]", paths, want)
+	}
+	raw, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leak := range []string{apiKey, orgID, "jan@example.org", "abcdef0123456789"} {
+		if strings.Contains(string(raw), leak) {
+			t.Errorf("cassette leaks %q:\n%s", leak, raw)
+		}
+	}
+
+	c, err := Load(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Provider != "openrouter" || !c.RecordedAt.Equal(rec.now()) || len(c.Interactions) != 2 {
+		t.Fatalf("cassette = %+v", c)
+	}
+	first, second := c.Interactions[0], c.Interactions[1]
+	if first.Header["X-Ratelimit-Remaining"] == "" || first.Header["Set-Cookie"] != "" {
+		t.Errorf("headers = %v, want rate-limit kept and cookies dropped", first.Header)
+	}
+
+	var body struct {
+		Data []map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(first.Body, &body); err != nil || len(body.Data) != 1 {
+		t.Fatalf("body = %s (%v)", first.Body, err)
+	}
+	row := body.Data[0]
+	pseudo, _ := row["org_id"].(string)
+	if !strings.HasPrefix(pseudo, "id-") {
+		t.Fatalf("org_id = %v, want a pseudonym", row["org_id"])
+	}
+	if second.Path != "/orgs/"+pseudo || second.Query != "key="+Redacted {
+		t.Errorf("second request = %s?%s, want the same pseudonym and a redacted key", second.Path, second.Query)
+	}
+	if row["owner_email"] != RedactedEmail || row["echo"] != "key "+Redacted {
+		t.Errorf("personal data not scrubbed: %v", row)
+	}
+	if row["used_percent"] != float64(40) || row["created"] != float64(1760000000) || row["date"] != "2026-10-14" || row["free"] != float64(0) {
+		t.Errorf("percentages, timestamps, dates and zeros must survive: %v", row)
+	}
+	usage, _ := row["usage"].(float64)
+	requests, _ := row["requests"].(float64)
+	if usage == 10.5 || usage < 10.5*0.7 || usage > 10.5*1.3 {
+		t.Errorf("usage = %v, want 10.5 fuzzed within ±30%%", usage)
+	}
+	if requests != float64(int(requests)) || requests/200 < usage/10.5-0.01 || requests/200 > usage/10.5+0.01 {
+		t.Errorf("requests = %v, want an integer scaled by the same factor as usage", requests)
+	}
+}
+
+func TestRecorder_WriteDirWithoutTraffic(t *testing.T) {
+	if _, err := NewRecorder(nil).WriteDir(t.TempDir()); err == nil {
+		t.Fatal("expected an error when nothing was recorded")
+	}
+}
@@ -0,0 +1,316 @@
+package fixtures
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Redacted replaces credential-like values.
+	Redacted = "REDACTED"
+	// RedactedEmail replaces email addresses.
+	RedactedEmail = "user@example.com"
+
+	// minSecretLen skips short header fragments that would over-match.
+	minSecretLen = 8
+	// minIDLen keeps short IDs ("1", "free") as-is; replacing them
+	// everywhere in paths and text would corrupt unrelated values.
+	minIDLen = 8
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	tokenPattern = regexp.MustCompile(`(?i)\b(?:bearer\s+[A-Za-z0-9._~+/=-]{8,}|sk-[A-Za-z0-9_-]{8,}|(?:ghp|gho|ghu|ghs|github_pat)_[A-Za-z0-9_]{8,}|AIza[0-9A-Za-z_-]{20,}|eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+)`)
+)
+
+// keptHeaders are the response headers providers actually parse; the rest
+// (cookies, request IDs, CDN metadata) are dropped.
+var keptHeaders = []string{"content-type", "retry-after"}
+
+var keptHeaderPrefixes = []string{"x-ratelimit-", "ratelimit-", "anthropic-ratelimit-"}
+
+// sanitizer strips secrets and personal data from recorded traffic and
+// fuzzes numbers. Every number is scaled by the same random factor so sums,
+// limits and ratios between fields survive; only the absolute figures
+// change.
+type sanitizer struct {
+	scale   float64
+	salt    []byte
+	secrets []string
+	ids     map[string]string
+}
+
+func newSanitizer(secrets []string) (*sanitizer, error) {
+	var seed [16]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		return nil, err
+	}
+	// Scale in [0.7, 1.3), never exactly 1 so real figures never leak.
+	frac := float64(binary.BigEndian.Uint64(seed[:8])>>11) / (1 << 53)
+	scale := 0.7 + 0.6*frac
+	if scale == 1 {
+		scale = 1.05
+	}
+	sorted := append([]string(nil), secrets...)
+	// Longest first so a secret that contains another is replaced whole.
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	return &sanitizer{scale: scale, salt: seed[8:], secrets: sorted, ids: make(map[string]string)}, nil
+}
+
+// normalizeKey lowercases a field name into snake_case words, so
+// "resetAt", "reset-at" and "reset_at" all compare equal.
+func normalizeKey(key string) string {
+	var b strings.Builder
+	for i, r := range key {
+		switch {
+		case r == '-' || r == ' ' || r == '.':
+			b.WriteByte('_')
+		case r >= 'A' && r <= 'Z':
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r + ('a' - 'A'))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// secretKey reports whether a field or header name carries credentials or
+// personal data.
+func secretKey(key string) bool {
+	k := normalizeKey(key)
+	for _, marker := range []string{"key", "token", "secret", "password", "passwd", "authorization", "auth", "cookie", "session", "credential", "email", "phone", "username", "user_name", "full_name", "first_name", "last_name", "display_name"} {
+		if strings.Contains(k, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// idKey reports whether a field holds an identifier worth pseudonymizing.
+func idKey(key string) bool {
+	k := normalizeKey(key)
+	return k == "id" || k == "uuid" || strings.HasSuffix(k, "_id") || strings.HasSuffix(k, "_uuid")
+}
+
+// keepNumberKey reports whether a numeric field carries meaning that scaling
+// would break: percentages, timestamps, codes and pagination.
+func keepNumberKey(key string) bool {
+	k := normalizeKey(key)
+	for _, marker := range []string{"percent", "utilization", "timestamp", "epoch", "version", "interval"} {
+		if strings.Contains(k, marker) {
+			return true
+		}
+	}
+	for _, word := range strings.Split(k, "_") {
+		switch word {
+		case "pct", "ratio", "time", "date", "reset", "resets", "created", "updated", "expires", "at",
+			"status", "code", "tier", "offset", "page", "year", "month", "day", "hour", "minute", "second", "seconds", "window":
+			return true
+		}
+	}
+	return false
+}
+
+// collectIDs walks a JSON body registering a pseudonym for every long ID, so
+// the same ID is replaced consistently in later bodies, paths and queries.
+func (s *sanitizer) collectIDs(body []byte) {
+	v, ok := decodeJSON(body)
+	if !ok {
+		return
+	}
+	var walk func(key string, v any)
+	walk = func(key string, v any) {
+		switch t := v.(type) {
+		case map[string]any:
+			for k, vv := range t {
+				walk(k, vv)
+			}
+		case []any:
+			for _, vv := range t {
+				walk(key, vv)
+			}
+		case string:
+			if idKey(key) && !secretKey(key) && len(t) >= minIDLen {
+				s.pseudonym(t)
+			}
+		}
+	}
+	walk("", v)
+}
+
+func (s *sanitizer) pseudonym(id string) string {
+	if p, ok := s.ids[id]; ok {
+		return p
+	}
+	sum := sha256.Sum256(append(append([]byte(nil), s.salt...), id...))
+	p := "id-" + hex.EncodeToString(sum[:6])
+	s.ids[id] = p
+	return p
+}
+
+func (s *sanitizer) interaction(ex exchange) Interaction {
+	out := Interaction{
+		Method: ex.method,
+		Host:   ex.host,
+		Path:   s.text(ex.path),
+		Query:  s.query(ex.query),
+		Status: ex.status,
+		Header: s.header(ex.header),
+	}
+	if v, ok := decodeJSON(ex.body); ok {
+		data, err := json.Marshal(s.value("", v))
+		if err == nil {
+			out.Body = data
+			return out
+		}
+	}
+	out.Text = s.text(string(ex.body))
+	return out
+}
+
+func decodeJSON(body []byte) (any, bool) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return nil, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
+func (s *sanitizer) header(h http.Header) map[string]string {
+	out := make(map[string]string)
+	for name, vals := range h {
+		lower := strings.ToLower(name)
+		keep := false
+		for _, k := range keptHeaders {
+			keep = keep || lower == k
+		}
+		for _, p := range keptHeaderPrefixes {
+			keep = keep || strings.HasPrefix(lower, p)
+		}
+		if keep {
+			out[name] = s.text(strings.Join(vals, ", "))
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func (s *sanitizer) query(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	vals, err := url.ParseQuery(raw)
+	if err != nil {
+		return s.text(raw)
+	}
+	for name, vs := range vals {
+		for i, v := range vs {
+			if secretKey(name) {
+				vs[i] = Redacted
+			} else {
+				vs[i] = s.text(v)
+			}
+		}
+	}
+	return vals.Encode()
+}
+
+func (s *sanitizer) value(key string, v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, vv := range t {
+			out[k] = s.value(k, vv)
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, vv := range t {
+			out[i] = s.value(key, vv)
+		}
+		return out
+	case string:
+		switch {
+		case t == "":
+			return t
+		case secretKey(key) && strings.Contains(normalizeKey(key), "email"):
+			return RedactedEmail
+		case secretKey(key):
+			return Redacted
+		}
+		return s.text(t)
+	case json.Number:
+		if keepNumberKey(key) {
+			return t
+		}
+		return s.number(t)
+	default:
+		return v
+	}
+}
+
+// number scales n, keeping zeros, integers and epoch-like values intact in
+// kind.
+func (s *sanitizer) number(n json.Number) json.Number {
+	f, err := n.Float64()
+	if err != nil || f == 0 {
+		return n
+	}
+	isInt := !strings.ContainsAny(n.String(), ".eE")
+	if isInt && math.Abs(f) >= 1e9 {
+		return n // unix timestamps in s/ms under an unhelpful key
+	}
+	scaled := f * s.scale
+	if isInt {
+		r := math.Round(scaled)
+		if r == 0 {
+			r = math.Copysign(1, f)
+		}
+		return json.Number(strconv.FormatInt(int64(r), 10))
+	}
+	return json.Number(strconv.FormatFloat(math.Round(scaled*1e6)/1e6, 'f', -1, 64))
+}
+
+// text scrubs free-form strings: known secrets, token-shaped values, email
+// addresses and IDs registered by collectIDs.
+func (s *sanitizer) text(v string) string {
+	if v == "" {
+		return v
+	}
+	for _, secret := range s.secrets {
+		v = strings.ReplaceAll(v, secret, Redacted)
+	}
+	v = tokenPattern.ReplaceAllString(v, Redacted)
+	v = emailPattern.ReplaceAllString(v, RedactedEmail)
+	if id, ok := s.ids[v]; ok {
+		return id
+	}
+	for orig, id := range s.ids {
+		if strings.Contains(v, orig) {
+			v = strings.ReplaceAll(v, orig, id)
+		}
+	}
+	return v
+}
@@ -57,6 +57,27 @@ func New() *Provider {
 				Quickstart: []string{"Set GEMINI_API_KEY to a valid Gemini API key."},
 			},
 			Dashboard: providerbase.DefaultDashboard(providerbase.WithColorRole(core.DashboardColorRoleBlue)),
+			Actions: []core.ProviderAction{
+				{Label: "Open status page", Kind: core.ProviderActionOpenURL, Target: "https://aistudio.google.com/status"},
+				{Label: "Copy API base URL", Kind: core.ProviderActionCopy, Target: defaultBaseURL, SnapshotKey: "api_base_url"},
+			},
+			Reference: core.ProviderReference{
+				LimitsNote: "limits differ per model; see the rate limits page",
+				Tiers: []core.ProviderTier{
+					{Name: "Free", Qualification: "no billing account"},
+					{Name: "Tier 1", Qualification: "billing account linked to the project"},
+					{Name: "Tier 2", Qualification: "$250+ spent and 30+ days since first payment"},
+					{Name: "Tier 3", Qualification: "$1,000+ spent and 30+ days since first payment"},
+				},
+				Windows: []string{
+					"Limits apply per Google Cloud project, not per API key.",
+					"Requests per day reset at midnight Pacific time.",
+				},
+				Links: []core.ProviderLink{
+					{Label: "Rate limits", URL: "https://ai.google.dev/gemini-api/docs/rate-limits"},
+					{Label: "Usage tiers", URL: "https://aistudio.google.com/usage"},
+				},
+			},
 		}),
 	}
 }
@@ -94,7 +115,7 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 	}
 	shared.ApplyStatusFromResponse(resp, &snap)
 	switch snap.Status {
-	case core.StatusAuth:
+	case core.StatusAuth, core.StatusMaintenance:
 		return snap, nil
 	case core.StatusLimited:
 		p.parseRetryInfo(resp.Body, &snap)
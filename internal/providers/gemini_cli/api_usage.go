@@ -19,13 +19,32 @@ import (
 
 func (p *Provider) fetchUsageFromAPI(ctx context.Context, snap *core.UsageSnapshot, creds oauthCreds, acct core.AccountConfig) error {
 	client := p.Client()
-	accessToken, err := refreshAccessToken(ctx, creds.RefreshToken, client)
-	if err != nil {
-		snap.Status = core.StatusAuth
-		snap.Message = "OAuth token refresh failed — run `gemini` to re-authenticate"
-		return fmt.Errorf("token refresh: %w", err)
+	var accessToken string
+	if acct.ReadOnly() {
+		// Refreshing rotates the OAuth grant the Gemini CLI owns; in
+		// read-only mode only an unexpired stored access token may be used.
+		if creds.AccessToken == "" || creds.ExpiryDate <= 0 || !time.Now().Before(time.UnixMilli(creds.ExpiryDate)) {
+			snap.Raw["oauth_refresh"] = core.ReadOnlySkipped
+			snap.Raw["quota_api"] = core.ReadOnlySkipped
+			return nil
+		}
+		accessToken = creds.AccessToken
+	} else {
+		tok, refreshed, err := p.tokenSource(acct.ID, creds, client).Token(ctx)
+		if err != nil {
+			// Only a rejected grant needs the user; network failures stay
+			// transient and surface as quota_api_error.
+			shared.ApplyAuthError(snap, err)
+			return fmt.Errorf("token refresh: %w", err)
+		}
+		accessToken = tok.AccessToken
+		if refreshed {
+			snap.Raw["oauth_status"] = "valid (refreshed)"
+		}
+		if !tok.Expiry.IsZero() {
+			snap.Raw["oauth_expires"] = tok.Expiry.Format(time.RFC3339)
+		}
 	}
-	snap.Raw["oauth_status"] = "valid (refreshed)"
 
 	projectID := ""
 	if v := os.Getenv("GOOGLE_CLOUD_PROJECT"); v != "" {
@@ -74,13 +93,46 @@ func (p *Provider) fetchUsageFromAPI(ctx context.Context, snap *core.UsageSnapsh
 	return nil
 }
 
-func refreshAccessToken(ctx context.Context, refreshToken string, client *http.Client) (string, error) {
-	return refreshAccessTokenWithEndpoint(ctx, refreshToken, tokenEndpoint, client)
+// tokenSource returns the account's cached TokenSource for
+// creds.RefreshToken, seeded with the access token the Gemini CLI last
+// stored.
+func (p *Provider) tokenSource(accountID string, creds oauthCreds, client *http.Client) *shared.TokenSource {
+	p.tokensMu.Lock()
+	defer p.tokensMu.Unlock()
+	if cached, ok := p.tokens[accountID]; ok && cached.refreshToken == creds.RefreshToken {
+		return cached.src
+	}
+	initial := shared.OAuthToken{AccessToken: creds.AccessToken}
+	if creds.ExpiryDate > 0 {
+		initial.Expiry = time.UnixMilli(creds.ExpiryDate)
+	} else {
+		initial.AccessToken = "" // unknown expiry: refresh rather than guess
+	}
+	src := shared.NewTokenSource(tokenRefresher{refreshToken: creds.RefreshToken, endpoint: tokenEndpoint, client: client}, initial)
+	// A new refresh token (the user logged in again) replaces the
+	// account's old grant; other accounts keep theirs.
+	if p.tokens == nil {
+		p.tokens = make(map[string]accountTokenSource)
+	}
+	p.tokens[accountID] = accountTokenSource{refreshToken: creds.RefreshToken, src: src}
+	return src
+}
+
+// tokenRefresher is the Gemini CLI's shared.TokenRefresher: a standard
+// refresh_token grant against Google's OAuth endpoint.
+type tokenRefresher struct {
+	refreshToken string
+	endpoint     string
+	client       *http.Client
+}
+
+func (r tokenRefresher) Refresh(ctx context.Context) (shared.OAuthToken, error) {
+	return refreshAccessTokenWithEndpoint(ctx, r.refreshToken, r.endpoint, r.client)
 }
 
-func refreshAccessTokenWithEndpoint(ctx context.Context, refreshToken, endpoint string, client *http.Client) (string, error) {
+func refreshAccessTokenWithEndpoint(ctx context.Context, refreshToken, endpoint string, client *http.Client) (shared.OAuthToken, error) {
 	if client == nil {
-		client = &http.Client{Timeout: 30 * time.Second}
+		client = shared.PooledHTTPClient(30 * time.Second)
 	}
 	data := url.Values{
 		"client_id":     {oauthClientID},
@@ -91,30 +143,41 @@ func refreshAccessTokenWithEndpoint(ctx context.Context, refreshToken, endpoint
 
 	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(data.Encode()))
 	if err != nil {
-		return "", err
+		return shared.OAuthToken{}, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return shared.OAuthToken{}, err
 	}
 	defer resp.Body.Close()
 
 	body, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("token refresh HTTP %d: %s", resp.StatusCode, string(body))
+	switch {
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized:
+		return shared.OAuthToken{}, &shared.AuthError{
+			Reason: refreshRejectionReason(resp.StatusCode, body),
+			Action: "run `gemini` to re-authenticate",
+			Err:    fmt.Errorf("token refresh HTTP %d: %s", resp.StatusCode, string(body)),
+		}
+	case resp.StatusCode != http.StatusOK:
+		return shared.OAuthToken{}, fmt.Errorf("token refresh HTTP %d: %s", resp.StatusCode, string(body))
 	}
 
 	var tokenResp tokenRefreshResponse
 	if err := json.Unmarshal(body, &tokenResp); err != nil {
-		return "", fmt.Errorf("parse token response: %w", err)
+		return shared.OAuthToken{}, fmt.Errorf("parse token response: %w", err)
 	}
 	if tokenResp.AccessToken == "" {
-		return "", fmt.Errorf("empty access_token in refresh response")
+		return shared.OAuthToken{}, fmt.Errorf("empty access_token in refresh response")
 	}
 
-	return tokenResp.AccessToken, nil
+	tok := shared.OAuthToken{AccessToken: tokenResp.AccessToken}
+	if tokenResp.ExpiresIn > 0 {
+		tok.Expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+	return tok, nil
 }
 
 func loadCodeAssistDetails(ctx context.Context, accessToken, existingProjectID string, client *http.Client) (*loadCodeAssistResponse, error) {
@@ -169,7 +232,7 @@ func retrieveUserQuotaWithEndpoint(ctx context.Context, accessToken, projectID,
 
 func codeAssistPostWithEndpoint(ctx context.Context, accessToken, method string, body interface{}, baseURL string, client *http.Client) ([]byte, error) {
 	if client == nil {
-		client = &http.Client{Timeout: 30 * time.Second}
+		client = shared.PooledHTTPClient(30 * time.Second)
 	}
 	apiURL := fmt.Sprintf("%s/%s:%s", baseURL, codeAssistAPIVersion, method)
 
@@ -574,12 +637,7 @@ func applyQuotaStatus(snap *core.UsageSnapshot, worstFraction float64) {
 		return
 	}
 
-	severity := map[core.Status]int{
-		core.StatusOK:        0,
-		core.StatusNearLimit: 1,
-		core.StatusLimited:   2,
-	}
-	if severity[desired] > severity[snap.Status] {
+	if core.StatusSeverity(desired) > core.StatusSeverity(snap.Status) {
 		snap.Status = desired
 	}
 }
@@ -646,3 +704,23 @@ func applyGeminiMCPMetadata(snap *core.UsageSnapshot, settings geminiSettings, e
 		snap.Raw["mcp_servers_disabled"] = summary
 	}
 }
+
+// refreshRejectionReason turns Google's OAuth error body into the snapshot
+// message: invalid_grant means the refresh token was revoked or expired,
+// invalid_client that the CLI's client credentials were rejected.
+func refreshRejectionReason(status int, body []byte) string {
+	var oauthErr struct {
+		Error string `json:"error"`
+	}
+	_ = json.Unmarshal(body, &oauthErr)
+	switch oauthErr.Error {
+	case "invalid_grant":
+		return "OAuth refresh token revoked or expired"
+	case "invalid_client", "unauthorized_client":
+		return "OAuth client rejected (" + oauthErr.Error + ")"
+	case "":
+		return fmt.Sprintf("OAuth token refresh rejected (HTTP %d)", status)
+	default:
+		return "OAuth token refresh rejected (" + oauthErr.Error + ")"
+	}
+}
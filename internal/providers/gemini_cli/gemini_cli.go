@@ -9,8 +9,10 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/janekbaraniewski/openusage/internal/audit"
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/providers/providerbase"
 	"github.com/janekbaraniewski/openusage/internal/providers/shared"
@@ -43,6 +45,19 @@ const (
 
 type Provider struct {
 	providerbase.Base
+
+	// tokens caches one TokenSource per account ID, so a fresh access
+	// token is reused across polls and a `gemini` re-login (new refresh
+	// token) starts clean.
+	tokensMu sync.Mutex
+	tokens   map[string]accountTokenSource
+}
+
+// accountTokenSource is an account's TokenSource and the refresh token it
+// was built for.
+type accountTokenSource struct {
+	refreshToken string
+	src          *shared.TokenSource
 }
 
 func New() *Provider {
@@ -288,7 +303,7 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 	var creds oauthCreds
 
 	oauthFile := filepath.Join(configDir, "oauth_creds.json")
-	if data, err := os.ReadFile(oauthFile); err == nil {
+	if data, err := audit.ReadFile(ctx, oauthFile); err == nil {
 		if json.Unmarshal(data, &creds) == nil {
 			hasData = true
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -13,6 +14,8 @@ import (
 	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/pricing"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
 
 func TestFetch_ReadsLocalData(t *testing.T) {
@@ -140,6 +143,35 @@ func TestFetch_ExpiredOAuth(t *testing.T) {
 	}
 }
 
+func TestFetch_ReadOnlySkipsTokenRefresh(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	creds := oauthCreds{
+		AccessToken:  "ya29.expired",
+		ExpiryDate:   1000000000000, // 2001 — expired, would normally refresh
+		RefreshToken: "1//refresh-token-test",
+	}
+	writeJSON(t, filepath.Join(tmpDir, "oauth_creds.json"), creds)
+
+	p := New()
+	acct := testGeminiCLIAccount("test-read-only", tmpDir)
+	acct.SetHint(core.ReadOnlyHint, "true")
+
+	snap, err := p.Fetch(context.Background(), acct)
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if snap.Raw["oauth_refresh"] != core.ReadOnlySkipped {
+		t.Errorf("oauth_refresh = %q, want %q", snap.Raw["oauth_refresh"], core.ReadOnlySkipped)
+	}
+	if snap.Raw["quota_api"] != core.ReadOnlySkipped {
+		t.Errorf("quota_api = %q, want %q", snap.Raw["quota_api"], core.ReadOnlySkipped)
+	}
+	if _, ok := snap.Raw["quota_api_error"]; ok {
+		t.Errorf("quota_api_error = %q, want no refresh attempt", snap.Raw["quota_api_error"])
+	}
+}
+
 func TestFetch_NoData(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -196,10 +228,11 @@ func TestFetch_UsageAPI(t *testing.T) {
 
 	ctx := context.Background()
 
-	accessToken, err := refreshAccessTokenWithEndpoint(ctx, creds.RefreshToken, server.URL+"/token", nil)
+	tok, err := refreshAccessTokenWithEndpoint(ctx, creds.RefreshToken, server.URL+"/token", nil)
 	if err != nil {
 		t.Fatalf("refreshAccessToken() error: %v", err)
 	}
+	accessToken := tok.AccessToken
 	if accessToken != "ya29.fresh" {
 		t.Errorf("accessToken = %q, want ya29.fresh", accessToken)
 	}
@@ -250,6 +283,47 @@ func TestFetch_UsageAPI(t *testing.T) {
 	}
 }
 
+func TestRefreshAccessToken_RevokedGrantIsAuthError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"invalid_grant","error_description":"Token has been expired or revoked."}`)
+	}))
+	defer server.Close()
+
+	_, err := refreshAccessTokenWithEndpoint(context.Background(), "1//revoked", server.URL, nil)
+	snap := core.NewUsageSnapshot("gemini_cli", "gemini-cli")
+	if !shared.ApplyAuthError(&snap, err) {
+		t.Fatalf("err = %v, want *shared.AuthError", err)
+	}
+	if snap.Message != "OAuth refresh token revoked or expired — run `gemini` to re-authenticate" {
+		t.Fatalf("message = %q", snap.Message)
+	}
+}
+
+func TestTokenSource_KeepsOneSourcePerAccount(t *testing.T) {
+	p := New()
+	first := oauthCreds{AccessToken: "a", RefreshToken: "1//first", ExpiryDate: time.Now().Add(time.Hour).UnixMilli()}
+	second := oauthCreds{AccessToken: "b", RefreshToken: "1//second", ExpiryDate: time.Now().Add(time.Hour).UnixMilli()}
+
+	firstSrc := p.tokenSource("gemini-work", first, nil)
+	secondSrc := p.tokenSource("gemini-personal", second, nil)
+	if p.tokenSource("gemini-work", first, nil) != firstSrc {
+		t.Fatal("polling another account evicted gemini-work's token source")
+	}
+	if p.tokenSource("gemini-personal", second, nil) != secondSrc {
+		t.Fatal("gemini-personal's token source was not reused")
+	}
+
+	relogin := first
+	relogin.RefreshToken = "1//first-relogin"
+	if p.tokenSource("gemini-work", relogin, nil) == firstSrc {
+		t.Fatal("a new refresh token should replace the account's token source")
+	}
+	if p.tokenSource("gemini-personal", second, nil) != secondSrc {
+		t.Fatal("re-login on one account replaced another account's token source")
+	}
+}
+
 func TestFetch_UsageAPI_DoesNotFallbackToLegacyMethod(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -603,6 +677,66 @@ func TestReadSessionUsageBreakdowns_ExtractsLanguageAndCodeStatsMetrics(t *testi
 	}
 }
 
+func TestReadSessionUsageBreakdowns_AttributesCostToTools(t *testing.T) {
+	prev := priceLookup
+	priceLookup = func(_ context.Context, _ string, _ int) (*pricing.Price, error) {
+		return &pricing.Price{ModelID: "stub", Source: pricing.SourceHardcoded, InputCostPerMillion: 1}, nil
+	}
+	t.Cleanup(func() { priceLookup = prev })
+
+	tmpDir := t.TempDir()
+	chatDir := filepath.Join(tmpDir, "project", "chats")
+	if err := os.MkdirAll(chatDir, 0o755); err != nil {
+		t.Fatalf("mkdir chat dir: %v", err)
+	}
+	turn := func(ts string, input int, tools ...string) map[string]any {
+		calls := make([]map[string]any, 0, len(tools))
+		for _, name := range tools {
+			calls = append(calls, map[string]any{"name": name, "status": "success"})
+		}
+		return map[string]any{
+			"type":      "gemini",
+			"timestamp": ts,
+			"model":     "gemini-2.5-pro",
+			"tokens":    map[string]any{"input": input, "total": input},
+			"toolCalls": calls,
+		}
+	}
+	// The first turn fetches a page and runs a command, the second reads
+	// both results, and the third reads nothing new.
+	writeJSON(t, filepath.Join(chatDir, "session-2026-02-24T10-00-tools.json"), map[string]any{
+		"sessionId":   "session-tools",
+		"startTime":   "2026-02-24T10:00:00Z",
+		"lastUpdated": "2026-02-24T10:03:00Z",
+		"messages": []map[string]any{
+			turn("2026-02-24T10:01:00Z", 1000, "web_fetch", "run_shell_command"),
+			turn("2026-02-24T10:02:00Z", 3000, "write_file"),
+			turn("2026-02-24T10:03:00Z", 3400),
+		},
+	})
+
+	snap := core.UsageSnapshot{
+		Metrics:     make(map[string]core.Metric),
+		Raw:         make(map[string]string),
+		Resets:      make(map[string]time.Time),
+		DailySeries: make(map[string][]core.TimePoint),
+	}
+	if _, err := New().readSessionUsageBreakdowns(tmpDir, &snap); err != nil {
+		t.Fatalf("readSessionUsageBreakdowns() error: %v", err)
+	}
+	for category, want := range map[string]float64{
+		core.ToolCategoryChat:  0.001,
+		core.ToolCategoryWeb:   0.001,
+		core.ToolCategoryShell: 0.001,
+		core.ToolCategoryEdit:  0.0004,
+	} {
+		m := snap.Metrics[core.ToolCostMetricKey(category)]
+		if m.Used == nil || math.Abs(*m.Used-want) > 1e-9 {
+			t.Errorf("%s cost = %v, want %.6f", category, m.Used, want)
+		}
+	}
+}
+
 func TestFetch_QuotaLimitMessageFallback(t *testing.T) {
 	tmpDir := t.TempDir()
 
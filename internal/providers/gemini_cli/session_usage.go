@@ -79,6 +79,7 @@ func (p *Provider) readSessionUsageBreakdowns(tmpDir string, snap *core.UsageSna
 	dailyToolTokens := make(map[string]float64)
 	modelCost := make(map[string]float64)
 	dailyCost := make(map[string]float64)
+	toolCosts := core.NewToolCostAttributor()
 	var totalCostUSD float64
 	var todayCostUSD float64
 	today := time.Now().UTC().Format("2006-01-02")
@@ -139,8 +140,16 @@ func (p *Provider) readSessionUsageBreakdowns(tmpDir string, snap *core.UsageSna
 		var hasPrevious bool
 		fileHasUsage := false
 		sessionModels := make(map[string]bool)
+		// issued holds the previous message's tool calls. A message carries
+		// its own calls and usage, so they become pending only once the next
+		// message, which reads their results, comes around.
+		var issued []string
 
 		for _, msg := range chat.Messages {
+			for _, name := range issued {
+				toolCosts.Call(sessionID, name)
+			}
+			issued = issued[:0]
 			day := dayFromTimestamp(msg.Timestamp)
 			if day == "" {
 				day = sessionDay
@@ -176,6 +185,7 @@ func (p *Provider) readSessionUsageBreakdowns(tmpDir string, snap *core.UsageSna
 					if toolName != "" {
 						toolTotals[toolName]++
 					}
+					issued = append(issued, toolName)
 
 					status := strings.ToLower(strings.TrimSpace(tc.Status))
 					switch {
@@ -278,6 +288,7 @@ func (p *Provider) readSessionUsageBreakdowns(tmpDir string, snap *core.UsageSna
 			}
 
 			cost := estimateUsageCost(msg.Model, delta)
+			toolCosts.Turn(sessionID, cost)
 			if cost > 0 {
 				modelCost[modelName] += cost
 				totalCostUSD += cost
@@ -326,6 +337,7 @@ func (p *Provider) readSessionUsageBreakdowns(tmpDir string, snap *core.UsageSna
 	emitModelRequestMetrics(modelRequests, modelSessions, snap)
 	emitToolMetrics(toolTotals, snap)
 	emitCostMetrics(modelCost, dailyCost, totalCostUSD, todayCostUSD, snap)
+	toolCosts.Emit(snap, defaultUsageWindowLabel)
 	if languageSummary := formatNamedCountMap(languageUsageCounts, "req"); languageSummary != "" {
 		snap.Raw["language_usage"] = languageSummary
 	}
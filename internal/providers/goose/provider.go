@@ -62,6 +62,9 @@ func New() *Provider {
 				},
 			},
 			Dashboard: dashboardWidget(),
+			Actions: []core.ProviderAction{
+				{Label: "Open session database", Kind: core.ProviderActionOpenPath, SnapshotKey: "db_path"},
+			},
 		}),
 		clock: core.SystemClock{},
 	}
@@ -100,16 +100,12 @@ type gooseQueryResult struct {
 // returns session IDs (useful for showing session counts).
 func queryGooseSessions(ctx context.Context, dbPath string) (gooseQueryResult, error) {
 	var result gooseQueryResult
-	db, err := openReadOnly(dbPath)
+	db, err := openReadOnly(ctx, dbPath)
 	if err != nil {
 		return result, err
 	}
 	defer db.Close()
 
-	if err := pingContext(ctx, db); err != nil {
-		return result, err
-	}
-
 	cols, err := detectColumns(ctx, db)
 	if err != nil {
 		return result, err
@@ -565,7 +565,7 @@ func TestOpenReadOnly_ManyOpens(t *testing.T) {
 	dbPath := makeTempDB(t, opts)
 
 	for i := 0; i < 64; i++ {
-		db, err := openReadOnly(dbPath)
+		db, err := openReadOnly(context.Background(), dbPath)
 		if err != nil {
 			t.Fatalf("open %d: %v", i, err)
 		}
@@ -576,7 +576,7 @@ func TestOpenReadOnly_ManyOpens(t *testing.T) {
 }
 
 func TestOpenReadOnly_EmptyPath(t *testing.T) {
-	if _, err := openReadOnly(""); err == nil {
+	if _, err := openReadOnly(context.Background(), ""); err == nil {
 		t.Fatal("expected error for empty path")
 	}
 }
@@ -594,7 +594,7 @@ func TestOpenReadOnly_ReadsExistingFile(t *testing.T) {
 	}
 	rw.Close()
 
-	db, err := openReadOnly(path)
+	db, err := openReadOnly(context.Background(), path)
 	if err != nil {
 		t.Fatalf("openReadOnly: %v", err)
 	}
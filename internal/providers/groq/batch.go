@@ -0,0 +1,90 @@
+package groq
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
+)
+
+var batchMetricLabels = map[string]string{
+	"batch_jobs_pending":       "Batch Jobs Pending",
+	"batch_requests_queued":    "Batch Requests Queued",
+	"batch_jobs_month":         "Batch Jobs This Month",
+	"batch_requests_completed": "Batch Requests Done",
+}
+
+// batchListResponse is the OpenAI-compatible GET /batches listing.
+type batchListResponse struct {
+	Data []batchJob `json:"data"`
+}
+
+type batchJob struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	CreatedAt     int64  `json:"created_at"`
+	RequestCounts struct {
+		Total     int64 `json:"total"`
+		Completed int64 `json:"completed"`
+		Failed    int64 `json:"failed"`
+	} `json:"request_counts"`
+}
+
+// pending reports whether the job still holds queued work. Batch requests
+// are discounted but run against their own queue, so they never show up in
+// the realtime rate-limit headers.
+func (b batchJob) pending() bool {
+	switch b.Status {
+	case "validating", "in_progress", "finalizing":
+		return true
+	}
+	return false
+}
+
+// fetchBatches adds the batch queue to the snapshot. Groq does not report
+// token counts or cost for batch work, so queued work is counted in
+// requests. Accounts without batch access get a 403/404 and are skipped.
+func (p *Provider) fetchBatches(ctx context.Context, baseURL, apiKey string, snap *core.UsageSnapshot, now time.Time) {
+	var list batchListResponse
+	status, _, err := shared.FetchJSON(ctx, baseURL+"/batches?limit=100", apiKey, &list, p.Client())
+	if status == http.StatusNotFound || status == http.StatusForbidden {
+		return
+	}
+	if err != nil {
+		snap.Raw["batch_error"] = err.Error()
+		return
+	}
+	if len(list.Data) == 0 {
+		return
+	}
+
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	var pendingJobs, queued, monthJobs, monthRequests int64
+	for _, job := range list.Data {
+		if job.pending() {
+			pendingJobs++
+			if left := job.RequestCounts.Total - job.RequestCounts.Completed - job.RequestCounts.Failed; left > 0 {
+				queued += left
+			}
+		}
+		if job.CreatedAt >= monthStart.Unix() {
+			monthJobs++
+			monthRequests += job.RequestCounts.Completed
+		}
+	}
+
+	setCount := func(key string, v int64, unit, window string) {
+		f := float64(v)
+		snap.Metrics[key] = core.Metric{Used: &f, Unit: unit, Window: window}
+	}
+	setCount("batch_jobs_pending", pendingJobs, "jobs", "current")
+	setCount("batch_requests_queued", queued, "requests", "current")
+	setCount("batch_jobs_month", monthJobs, "jobs", "1mo")
+	setCount("batch_requests_completed", monthRequests, "requests", "1mo")
+	if pendingJobs > 0 {
+		snap.Raw["batch_queue"] = fmt.Sprintf("%d jobs, %d requests queued", pendingJobs, queued)
+	}
+}
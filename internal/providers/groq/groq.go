@@ -3,6 +3,7 @@ package groq
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/parsers"
@@ -22,7 +23,7 @@ func New() *Provider {
 			ID: "groq",
 			Info: core.ProviderInfo{
 				Name:         "Groq",
-				Capabilities: []string{"headers", "daily_limits"},
+				Capabilities: []string{"headers", "daily_limits", "batch_jobs"},
 				DocURL:       "https://console.groq.com/docs/rate-limits",
 			},
 			Auth: core.ProviderAuthSpec{
@@ -33,7 +34,31 @@ func New() *Provider {
 			Setup: core.ProviderSetupSpec{
 				Quickstart: []string{"Set GROQ_API_KEY to a valid Groq API key."},
 			},
-			Dashboard: providerbase.DefaultDashboard(providerbase.WithColorRole(core.DashboardColorRoleYellow)),
+			Dashboard: providerbase.DefaultDashboard(
+				providerbase.WithColorRole(core.DashboardColorRoleYellow),
+				providerbase.WithMetricLabels(batchMetricLabels),
+				providerbase.WithRawGroups(core.DashboardRawGroup{Label: "Batch", Keys: []string{"batch_queue"}}),
+			),
+			Actions: []core.ProviderAction{
+				{Label: "Open billing page", Kind: core.ProviderActionOpenURL, Target: "https://console.groq.com/settings/billing"},
+				{Label: "Open status page", Kind: core.ProviderActionOpenURL, Target: "https://groqstatus.com"},
+				{Label: "Copy API base URL", Kind: core.ProviderActionCopy, Target: defaultBaseURL, SnapshotKey: "api_base_url"},
+			},
+			Reference: core.ProviderReference{
+				LimitsNote: "for llama-3.1-8b-instant",
+				Tiers: []core.ProviderTier{
+					{Name: "Free", Qualification: "no payment method", Limits: map[string]float64{"rpm": 30, "rpd": 14_400, "tpm": 6_000, "tpd": 500_000}},
+					{Name: "Developer", Qualification: "payment method added in billing settings"},
+				},
+				Windows: []string{
+					"rpm and tpm roll over a minute; rpd and tpd over a rolling day, not a calendar day.",
+					"Limits apply per organization, not per API key.",
+				},
+				Links: []core.ProviderLink{
+					{Label: "Rate limits", URL: "https://console.groq.com/docs/rate-limits"},
+					{Label: "Your limits", URL: "https://console.groq.com/settings/limits"},
+				},
+			},
 		}),
 	}
 }
@@ -65,6 +90,9 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 		"x-ratelimit-limit-requests-day", "x-ratelimit-remaining-requests-day", "x-ratelimit-reset-requests-day")
 	parsers.ApplyRateLimitGroup(resp.Header, &snap, "tpd", "tokens", "1d",
 		"x-ratelimit-limit-tokens-day", "x-ratelimit-remaining-tokens-day", "x-ratelimit-reset-tokens-day")
+	if snap.Status == "" {
+		p.fetchBatches(ctx, baseURL, apiKey, &snap, time.Now())
+	}
 
 	shared.FinalizeStatus(&snap)
 	if snap.Status == core.StatusOK {
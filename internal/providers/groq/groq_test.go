@@ -2,10 +2,12 @@ package groq
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
@@ -266,3 +268,73 @@ func TestBuildStatusMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestFetch_BatchQueue(t *testing.T) {
+	now := time.Now()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/models":
+			w.Write([]byte(`{"data": [{"id": "llama3-70b"}]}`))
+		case "/batches":
+			fmt.Fprintf(w, `{"object": "list", "data": [
+				{"id": "batch_1", "status": "in_progress", "created_at": %d, "request_counts": {"total": 1000, "completed": 400, "failed": 10}},
+				{"id": "batch_2", "status": "validating", "created_at": %d, "request_counts": {"total": 0, "completed": 0, "failed": 0}},
+				{"id": "batch_3", "status": "completed", "created_at": %d, "request_counts": {"total": 500, "completed": 500, "failed": 0}},
+				{"id": "batch_4", "status": "completed", "created_at": %d, "request_counts": {"total": 800, "completed": 800, "failed": 0}}
+			]}`, now.Unix(), now.Unix(), now.Unix(), now.AddDate(0, -2, 0).Unix())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	snap, err := New().Fetch(context.Background(), core.AccountConfig{
+		ID: "test-groq", Provider: "groq", Token: "test-key", BaseURL: server.URL,
+	})
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if snap.Status != core.StatusOK {
+		t.Errorf("Status = %v, want OK", snap.Status)
+	}
+	for key, want := range map[string]float64{
+		"batch_jobs_pending":       2,
+		"batch_requests_queued":    590,
+		"batch_jobs_month":         3,
+		"batch_requests_completed": 900,
+	} {
+		if m, ok := snap.Metrics[key]; !ok || m.Used == nil || *m.Used != want {
+			t.Errorf("%s = %+v, want %v", key, m, want)
+		}
+	}
+	if got := snap.Raw["batch_queue"]; got != "2 jobs, 590 requests queued" {
+		t.Errorf("Raw[batch_queue] = %q", got)
+	}
+}
+
+func TestFetch_BatchUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/batches" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"data": [{"id": "llama3-70b"}]}`))
+	}))
+	defer server.Close()
+
+	snap, err := New().Fetch(context.Background(), core.AccountConfig{
+		ID: "test-groq", Provider: "groq", Token: "test-key", BaseURL: server.URL,
+	})
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if snap.Status != core.StatusOK {
+		t.Errorf("Status = %v, want OK when the key has no batch access", snap.Status)
+	}
+	if _, ok := snap.Metrics["batch_jobs_pending"]; ok {
+		t.Error("batch metrics reported without batch access")
+	}
+	if errMsg, ok := snap.Raw["batch_error"]; ok {
+		t.Errorf("Raw[batch_error] = %q, want none for a 403", errMsg)
+	}
+}
@@ -89,16 +89,12 @@ func detectColumns(ctx context.Context, db *sql.DB) (columnPresence, error) {
 // A session is "empty" when every token category is zero or NULL and there
 // is no positive cost recorded.
 func queryHermesSessions(ctx context.Context, dbPath string) ([]hermesSession, error) {
-	db, err := openReadOnly(dbPath)
+	db, err := openReadOnly(ctx, dbPath)
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
 
-	if err := pingContext(ctx, db); err != nil {
-		return nil, err
-	}
-
 	cols, err := detectColumns(ctx, db)
 	if err != nil {
 		return nil, err
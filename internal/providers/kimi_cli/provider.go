@@ -58,6 +58,9 @@ func New() *Provider {
 				},
 			},
 			Dashboard: dashboardWidget(),
+			Actions: []core.ProviderAction{
+				{Label: "Open sessions directory", Kind: core.ProviderActionOpenPath, SnapshotKey: "sessions_dir"},
+			},
 		}),
 		clock: core.SystemClock{},
 	}
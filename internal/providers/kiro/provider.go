@@ -80,6 +80,10 @@ func New() *Provider {
 				},
 			},
 			Dashboard: dashboardWidget(),
+			Actions: []core.ProviderAction{
+				{Label: "Open sessions directory", Kind: core.ProviderActionOpenPath, SnapshotKey: "sessions_dir"},
+				{Label: "Open database", Kind: core.ProviderActionOpenPath, SnapshotKey: "db_path"},
+			},
 		}),
 		clock: core.SystemClock{},
 	}
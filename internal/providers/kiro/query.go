@@ -106,16 +106,12 @@ func readTableInfo(ctx context.Context, db *sql.DB, table string) (map[string]bo
 // recon snapshot; expect this parser to under-extract on newer or older
 // schemas until field samples are collected from real installs.
 func queryKiroConversations(ctx context.Context, dbPath string) ([]kiroConversation, error) {
-	db, err := openReadOnly(dbPath)
+	db, err := openReadOnly(ctx, dbPath)
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
 
-	if err := pingContext(ctx, db); err != nil {
-		return nil, err
-	}
-
 	choice, ok, err := detectConversationsTable(ctx, db)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,106 @@
+package mistral
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
+)
+
+var batchMetricLabels = map[string]string{
+	"batch_jobs_pending":    "Batch Jobs Pending",
+	"batch_requests_queued": "Batch Requests Queued",
+	"batch_spend":           "Batch Spend",
+	"realtime_spend":        "Realtime Spend",
+	"batch_input_tokens":    "Batch Input Tokens",
+	"batch_output_tokens":   "Batch Output Tokens",
+}
+
+type batchJobsResponse struct {
+	Data  []batchJob `json:"data"`
+	Total int        `json:"total"`
+}
+
+type batchJob struct {
+	ID                string `json:"id"`
+	Model             string `json:"model"`
+	Status            string `json:"status"`
+	CreatedAt         int64  `json:"created_at"`
+	TotalRequests     int64  `json:"total_requests"`
+	CompletedRequests int64  `json:"completed_requests"`
+}
+
+// pending reports whether the job is still waiting for or using the batch
+// queue, which is separate from the realtime rate limits.
+func (b batchJob) pending() bool {
+	return b.Status == "QUEUED" || b.Status == "RUNNING"
+}
+
+// fetchBatchJobs adds the batch queue to the snapshot. Mistral does not
+// report tokens for work that has not run yet, so queued work is counted in
+// requests. Keys without batch access get a 403/404 and are skipped.
+func (p *Provider) fetchBatchJobs(ctx context.Context, baseURL, apiKey string, snap *core.UsageSnapshot) error {
+	url := baseURL + "/batch/jobs?page=0&page_size=100&status=QUEUED&status=RUNNING"
+	var jobs batchJobsResponse
+	status, _, err := shared.FetchJSON(ctx, url, apiKey, &jobs, p.Client())
+	if status == http.StatusNotFound || status == http.StatusForbidden {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var pendingJobs, queued int64
+	for _, job := range jobs.Data {
+		if !job.pending() {
+			continue
+		}
+		pendingJobs++
+		if left := job.TotalRequests - job.CompletedRequests; left > 0 {
+			queued += left
+		}
+	}
+	if pendingJobs == 0 {
+		return nil
+	}
+
+	jobCount, queuedCount := float64(pendingJobs), float64(queued)
+	snap.Metrics["batch_jobs_pending"] = core.Metric{Used: &jobCount, Unit: "jobs", Window: "current"}
+	snap.Metrics["batch_requests_queued"] = core.Metric{Used: &queuedCount, Unit: "requests", Window: "current"}
+	snap.Raw["batch_queue"] = fmt.Sprintf("%d jobs, %d requests queued", pendingJobs, queued)
+	return nil
+}
+
+// applyBatchSplit splits the month's usage rows into batch and realtime
+// spend. Batch requests are billed at a discount and only show up as
+// flagged rows in the usage report, so without the split a heavy batch user
+// sees one total that hides how much of it went through the batch queue.
+func applyBatchSplit(rows []usageData, totalCost float64, snap *core.UsageSnapshot) {
+	var batchCost float64
+	var batchInput, batchOutput int64
+	hasBatch := false
+	for _, d := range rows {
+		if !d.Batch {
+			continue
+		}
+		hasBatch = true
+		batchCost += d.TotalCost
+		batchInput += d.InputTokens
+		batchOutput += d.OutputTokens
+	}
+	if !hasBatch {
+		return
+	}
+
+	realtimeCost := max(totalCost-batchCost, 0)
+	inp, out := float64(batchInput), float64(batchOutput)
+	snap.Metrics["batch_spend"] = core.Metric{Used: &batchCost, Unit: "EUR", Window: "1mo"}
+	snap.Metrics["realtime_spend"] = core.Metric{Used: &realtimeCost, Unit: "EUR", Window: "1mo"}
+	snap.Metrics["batch_input_tokens"] = core.Metric{Used: &inp, Unit: "tokens", Window: "1mo"}
+	snap.Metrics["batch_output_tokens"] = core.Metric{Used: &out, Unit: "tokens", Window: "1mo"}
+	if totalCost > 0 {
+		snap.Raw["batch_spend_share"] = fmt.Sprintf("%.0f%%", batchCost/totalCost*100)
+	}
+}
@@ -32,6 +32,8 @@ type usageData struct {
 	InputTokens  int64   `json:"input_tokens"`
 	OutputTokens int64   `json:"output_tokens"`
 	TotalCost    float64 `json:"total_cost"`
+	// Batch marks rows billed through the batch API at the discounted rate.
+	Batch bool `json:"batch"`
 }
 
 type Provider struct {
@@ -44,7 +46,7 @@ func New() *Provider {
 			ID: "mistral",
 			Info: core.ProviderInfo{
 				Name:         "Mistral AI",
-				Capabilities: []string{"headers", "billing_subscription", "billing_usage"},
+				Capabilities: []string{"headers", "billing_subscription", "billing_usage", "batch_jobs"},
 				DocURL:       "https://docs.mistral.ai/getting-started/models/",
 			},
 			Auth: core.ProviderAuthSpec{
@@ -55,12 +57,21 @@ func New() *Provider {
 			Setup: core.ProviderSetupSpec{
 				Quickstart: []string{"Set MISTRAL_API_KEY to a valid Mistral API key."},
 			},
-			Dashboard: providerbase.DefaultDashboard(providerbase.WithColorRole(core.DashboardColorRoleFlamingo)),
+			Dashboard: providerbase.DefaultDashboard(
+				providerbase.WithColorRole(core.DashboardColorRoleFlamingo),
+				providerbase.WithMetricLabels(batchMetricLabels),
+				providerbase.WithRawGroups(core.DashboardRawGroup{Label: "Batch", Keys: []string{"batch_queue", "batch_spend_share"}}),
+			),
 			CreditMetrics: map[string]core.BalanceSemantics{
 				"monthly_spend":  core.BalanceCumulative,
 				"credit_balance": core.BalancePoint,
 				"monthly_budget": core.BalanceLimit,
 			},
+			Actions: []core.ProviderAction{
+				{Label: "Open billing page", Kind: core.ProviderActionOpenURL, Target: "https://console.mistral.ai/billing"},
+				{Label: "Open status page", Kind: core.ProviderActionOpenURL, Target: "https://status.mistral.ai"},
+				{Label: "Copy API base URL", Kind: core.ProviderActionCopy, Target: defaultBaseURL, SnapshotKey: "api_base_url"},
+			},
 		}),
 	}
 }
@@ -82,6 +93,10 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 		snap.Raw["usage_error"] = err.Error()
 	}
 
+	if err := p.fetchBatchJobs(ctx, baseURL, apiKey, &snap); err != nil {
+		snap.Raw["batch_error"] = err.Error()
+	}
+
 	if err := p.fetchRateLimits(ctx, baseURL, apiKey, &snap); err != nil {
 		if snap.Status == core.StatusOK {
 			return snap, nil
@@ -164,6 +179,7 @@ func (p *Provider) fetchUsage(ctx context.Context, baseURL, apiKey string, snap
 		snap.Metrics["monthly_output_tokens"] = core.Metric{Used: &out, Unit: "tokens", Window: "1mo"}
 	}
 
+	applyBatchSplit(usage.Data, totalCost, snap)
 	snap.Raw["monthly_cost"] = fmt.Sprintf("%.4f EUR", totalCost)
 
 	return nil
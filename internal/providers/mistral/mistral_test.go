@@ -418,3 +418,60 @@ func TestFetch_RateLimited(t *testing.T) {
 		t.Errorf("credit_balance remaining = %v, want 10.00", balance.Remaining)
 	}
 }
+
+func TestFetch_BatchUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/billing/subscription":
+			w.Write([]byte(`{"plan": "scale"}`))
+		case "/billing/usage":
+			w.Write([]byte(`{
+				"object": "list",
+				"data": [
+					{"model": "mistral-large", "input_tokens": 100000, "output_tokens": 50000, "total_cost": 12.00},
+					{"model": "mistral-large", "input_tokens": 900000, "output_tokens": 300000, "total_cost": 18.00, "batch": true}
+				],
+				"total_cost": 30.00
+			}`))
+		case "/batch/jobs":
+			w.Write([]byte(`{"object": "list", "total": 2, "data": [
+				{"id": "job_1", "model": "mistral-large", "status": "RUNNING", "total_requests": 2000, "completed_requests": 1500},
+				{"id": "job_2", "model": "mistral-small", "status": "QUEUED", "total_requests": 300, "completed_requests": 0}
+			]}`))
+		case "/models":
+			w.Write([]byte(`{"data": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	snap, err := New().Fetch(context.Background(), core.AccountConfig{
+		ID: "test-mistral", Provider: "mistral", Token: "test-api-key", BaseURL: server.URL,
+	})
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if snap.Status != core.StatusOK {
+		t.Errorf("Status = %v, want OK", snap.Status)
+	}
+	for key, want := range map[string]float64{
+		"batch_jobs_pending":    2,
+		"batch_requests_queued": 800,
+		"batch_spend":           18,
+		"realtime_spend":        12,
+		"batch_input_tokens":    900000,
+		"batch_output_tokens":   300000,
+		"monthly_spend":         30,
+	} {
+		if m, ok := snap.Metrics[key]; !ok || m.Used == nil || *m.Used != want {
+			t.Errorf("%s = %+v, want %v", key, m, want)
+		}
+	}
+	if got := snap.Raw["batch_spend_share"]; got != "60%" {
+		t.Errorf("Raw[batch_spend_share] = %q, want 60%%", got)
+	}
+	if errMsg, ok := snap.Raw["batch_error"]; ok {
+		t.Errorf("unexpected batch_error: %s", errMsg)
+	}
+}
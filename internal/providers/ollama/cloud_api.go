@@ -11,12 +11,14 @@ import (
 
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/parsers"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
 
 func (p *Provider) fetchCloudAPI(ctx context.Context, acct core.AccountConfig, apiKey string, snap *core.UsageSnapshot) (hasData, authFailed, limited bool, err error) {
 	cloudBaseURL := resolveCloudBaseURL(acct)
 
 	var me map[string]any
+	// Like the local /api/me, a read of the account despite the POST.
 	status, headers, reqErr := doJSONRequest(ctx, http.MethodPost, cloudEndpointURL(cloudBaseURL, "/api/me"), apiKey, &me, p.Client())
 	if reqErr != nil {
 		return false, false, false, fmt.Errorf("ollama: cloud account request failed: %w", reqErr)
@@ -143,27 +145,48 @@ func extractCloudUsageWindows(payload map[string]any, snap *core.UsageSnapshot,
 	}
 
 	dayKeys := []string{
-		"weekly_usage", "weeklyusage", "usage_1d", "usageoneday", "one_day_usage", "daily_usage", "dailyusage",
+		"usage_1d", "usageoneday", "one_day_usage", "daily_usage", "dailyusage",
 	}
 	if metric, resetAt, ok := findUsageWindow(payload, dayKeys, "1d", now); ok {
-		snap.Metrics["usage_weekly"] = core.Metric{
-			Limit:     metric.Limit,
-			Remaining: metric.Remaining,
-			Used:      metric.Used,
-			Unit:      metric.Unit,
-			Window:    "1w",
-		}
 		snap.Metrics["usage_one_day"] = metric
 		if !resetAt.IsZero() {
-			snap.Resets["usage_weekly"] = resetAt
 			snap.Resets["usage_one_day"] = resetAt
 		}
 		found = true
 	}
 
+	weekKeys := []string{
+		"weekly_usage", "weeklyusage", "usage_7d", "usage_1w",
+	}
+	if metric, resetAt, ok := findUsageWindow(payload, weekKeys, "1w", now); ok {
+		setWeeklyUsage(snap, metric, resetAt)
+		found = true
+	}
+
 	return found
 }
 
+// setWeeklyUsage records the weekly window. Plans that only report a weekly
+// figure also get it as usage_one_day so the 1d gauge isn't left empty; a
+// real daily figure always wins.
+func setWeeklyUsage(snap *core.UsageSnapshot, metric core.Metric, resetAt time.Time) {
+	weekly := metric
+	weekly.Window = "1w"
+	snap.Metrics["usage_weekly"] = weekly
+	if !resetAt.IsZero() {
+		snap.Resets["usage_weekly"] = resetAt
+	}
+	if _, ok := snap.Metrics["usage_one_day"]; ok {
+		return
+	}
+	daily := metric
+	daily.Window = "1d"
+	snap.Metrics["usage_one_day"] = daily
+	if !resetAt.IsZero() {
+		snap.Resets["usage_one_day"] = resetAt
+	}
+}
+
 func findUsageWindow(payload map[string]any, keys []string, fallbackWindow string, now time.Time) (core.Metric, time.Time, bool) {
 	sources := []map[string]any{
 		payload,
@@ -242,6 +265,8 @@ func parseUsageWindowValue(v any, fallbackWindow string, now time.Time) (core.Me
 			if t, ok := parseAnyTime(resetRaw); ok {
 				resetAt = t
 			}
+		} else if epoch, ok := anyFloatCaseInsensitive(raw, "reset_at", "resets_at", "reset_time", "reset"); ok && epoch > 0 {
+			resetAt = shared.UnixAuto(int64(epoch))
 		}
 		if resetAt.IsZero() {
 			if seconds, ok := anyFloatCaseInsensitive(raw, "reset_in", "reset_in_seconds", "resets_in", "seconds_to_reset"); ok && seconds > 0 {
@@ -286,6 +311,46 @@ func finalizeUsageWindows(snap *core.UsageSnapshot, now time.Time) {
 	}
 }
 
+// cloudUsageLabels names the cloud usage windows in status messages, in the
+// order they are checked.
+var cloudUsageLabels = []struct{ key, label string }{
+	{"usage_five_hour", "5h"},
+	{"usage_one_day", "daily"},
+	{"usage_weekly", "weekly"},
+}
+
+// applyCloudUsageStatus raises the tile status from the cloud plan's usage
+// windows: LIMITED once any window is used up, NEAR_LIMIT from 90%.
+func applyCloudUsageStatus(snap *core.UsageSnapshot, now time.Time) {
+	if snap.Status != core.StatusOK {
+		return
+	}
+	var nearKey, nearLabel string
+	var nearUsed float64
+	for _, w := range cloudUsageLabels {
+		m, ok := snap.Metrics[w.key]
+		if !ok || m.Unit != "%" || m.Used == nil {
+			continue
+		}
+		used := *m.Used
+		if used >= 100 {
+			snap.Status = core.StatusLimited
+			snap.Message = fmt.Sprintf("Ollama cloud %s usage limit reached", w.label)
+			if reset, ok := snap.Resets[w.key]; ok && reset.After(now) {
+				snap.Message += fmt.Sprintf(" (resets %s)", reset.Local().Format("Jan 2 15:04"))
+			}
+			return
+		}
+		if used >= 90 && used > nearUsed {
+			nearKey, nearLabel, nearUsed = w.key, w.label, used
+		}
+	}
+	if nearKey != "" {
+		snap.Status = core.StatusNearLimit
+		snap.Message = fmt.Sprintf("Ollama cloud %s usage %.0f%%", nearLabel, nearUsed)
+	}
+}
+
 func currentFiveHourBlock(now time.Time) (time.Time, time.Time) {
 	startHour := (now.Hour() / 5) * 5
 	start := time.Date(now.Year(), now.Month(), now.Day(), startHour, 0, 0, 0, now.Location())
@@ -2,10 +2,10 @@ package ollama
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
 
 func (p *Provider) fetchDesktopDB(ctx context.Context, acct core.AccountConfig, snap *core.UsageSnapshot) (bool, error) {
@@ -14,16 +14,12 @@ func (p *Provider) fetchDesktopDB(ctx context.Context, acct core.AccountConfig,
 		return false, nil
 	}
 
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := shared.OpenSQLiteReadOnly(ctx, dbPath)
 	if err != nil {
 		return false, fmt.Errorf("ollama: opening desktop db: %w", err)
 	}
 	defer db.Close()
 
-	if err := db.PingContext(ctx); err != nil {
-		return false, fmt.Errorf("ollama: pinging desktop db: %w", err)
-	}
-
 	snap.Raw["desktop_db_path"] = dbPath
 
 	setCountMetric := func(key string, count int64, unit, window string) {
@@ -107,6 +107,8 @@ func (p *Provider) fetchLocalStatus(ctx context.Context, baseURL string, snap *c
 
 func (p *Provider) fetchLocalMe(ctx context.Context, baseURL string, snap *core.UsageSnapshot) (bool, error) {
 	var resp map[string]any
+	// /api/me only reports the signed-in user (or a sign-in URL); Ollama
+	// takes it as a POST but it changes nothing, so read-only mode allows it.
 	code, _, err := doJSONRequest(ctx, http.MethodPost, baseURL+"/api/me", "", &resp, p.Client())
 	if err != nil {
 		return false, nil
@@ -217,6 +219,20 @@ func (p *Provider) fetchLocalPS(ctx context.Context, baseURL string, snap *core.
 		if len(loadedNames) > 0 {
 			snap.Raw["loaded_models"] = strings.Join(loadedNames, ", ")
 		}
+
+		// size_vram below size means Ollama couldn't fit the model and
+		// offloaded the remaining layers to CPU.
+		var spilled []string
+		for _, m := range resp.Models {
+			if m.SizeVRAM < m.Size {
+				if name := normalizeModelName(m.Name); name != "" {
+					spilled = append(spilled, name)
+				}
+			}
+		}
+		if len(spilled) > 0 {
+			snap.Raw["vram_spilled_models"] = strings.Join(spilled, ", ")
+		}
 	}
 
 	return true, nil
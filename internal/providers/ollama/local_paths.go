@@ -10,6 +10,30 @@ import (
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
 
+// LocalData lays out Ollama's files under its home directory (~/.ollama,
+// or OLLAMA_HOME). The desktop app's database normally lives in the
+// platform's application data folder and only moves with a data root that
+// has its own db.sqlite.
+var LocalData = core.LocalDataLayout{
+	EnvVar:  "OLLAMA_HOME",
+	RootKey: "config_dir",
+	Paths: map[string][]string{
+		"server_config": {"server.json"},
+		"logs_dir":      {"logs"},
+		"db_path":       {"db.sqlite"},
+	},
+}
+
+// defaultConfigDir is OLLAMA_HOME when set, else ~/.ollama.
+func defaultConfigDir(home string) string {
+	if dir := strings.TrimSpace(os.Getenv(LocalData.EnvVar)); dir != "" {
+		if expanded, err := core.ExpandDataPath(dir); err == nil && expanded != "" {
+			return expanded
+		}
+	}
+	return filepath.Join(home, ".ollama")
+}
+
 func resolveDesktopDBPath(acct core.AccountConfig) string {
 	for _, key := range []string{"db_path", "app_db"} {
 		if v := strings.TrimSpace(acct.Hint(key, "")); v != "" {
@@ -59,7 +83,7 @@ func resolveServerConfigPath(acct core.AccountConfig) string {
 	if err != nil {
 		return ""
 	}
-	return filepath.Join(home, ".ollama", "server.json")
+	return filepath.Join(defaultConfigDir(home), "server.json")
 }
 
 func resolveServerLogFiles(acct core.AccountConfig) []string {
@@ -74,7 +98,7 @@ func resolveServerLogFiles(acct core.AccountConfig) []string {
 		if err != nil {
 			return nil
 		}
-		logDir = filepath.Join(home, ".ollama", "logs")
+		logDir = filepath.Join(defaultConfigDir(home), "logs")
 	}
 
 	pattern := filepath.Join(logDir, "server*.log")
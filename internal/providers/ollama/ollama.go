@@ -23,8 +23,8 @@ const (
 )
 
 var nonAlnumRe = regexp.MustCompile(`[^a-z0-9]+`)
-var settingsUsageRe = regexp.MustCompile(`(?is)(Session usage|Weekly usage)\s*</span>\s*<span[^>]*>\s*([0-9]+(?:\.[0-9]+)?)%\s*used\s*</span>`)
-var settingsResetRe = regexp.MustCompile(`(?is)(Session usage|Weekly usage).*?data-time="([^"]+)"`)
+var settingsUsageRe = regexp.MustCompile(`(?is)(Session usage|Daily usage|Weekly usage)\s*</span>\s*<span[^>]*>\s*([0-9]+(?:\.[0-9]+)?)%\s*used\s*</span>`)
+var settingsResetRe = regexp.MustCompile(`(?is)(Session usage|Daily usage|Weekly usage).*?data-time="([^"]+)"`)
 
 type Provider struct {
 	providerbase.Base
@@ -52,6 +52,11 @@ func New() *Provider {
 				},
 			},
 			Dashboard: dashboardWidget(),
+			Actions: []core.ProviderAction{
+				{Label: "Open server config", Kind: core.ProviderActionOpenPath, SnapshotKey: "server_config_path"},
+				{Label: "Open desktop database", Kind: core.ProviderActionOpenPath, SnapshotKey: "desktop_db_path"},
+			},
+			LocalData: LocalData,
 		}),
 		clock: core.SystemClock{},
 	}
@@ -159,6 +164,7 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 	case hasData:
 		snap.Status = core.StatusOK
 		snap.Message = buildStatusMessage(snap)
+		applyCloudUsageStatus(&snap, p.now())
 	case cloudOnly:
 		snap.Status = core.StatusAuth
 		snap.Message = "cloud account configured but no API key found"
@@ -167,6 +173,14 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 		snap.Message = "No Ollama data found (local API, DB, logs, or cloud API)"
 	}
 
+	if !cloudOnly {
+		capacity, err := resolveVRAMCapacity(acct)
+		if err != nil {
+			snap.SetDiagnostic("vram_capacity_error", err.Error())
+		}
+		applyVRAMCapacity(&snap, capacity)
+	}
+
 	return snap, nil
 }
 
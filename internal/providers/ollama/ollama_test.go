@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -559,3 +560,60 @@ func TestFetchServerLogs_CountsAnthropicMessagesPath(t *testing.T) {
 		t.Fatalf("chat_requests_today = %v, want 1", got)
 	}
 }
+
+func TestFetch_CloudUsageWindowsAndLimitStatus(t *testing.T) {
+	reset := time.Date(2026, 2, 22, 1, 0, 0, 0, time.UTC)
+	cloudServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/me":
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":   "acct-123",
+				"plan": "pro",
+				"usage": map[string]any{
+					"session_usage": map[string]any{"percent": 100.0, "reset_at": reset.Unix()},
+					"daily_usage":   map[string]any{"percent": 40.0},
+					"weekly_usage":  map[string]any{"percent": 12.0},
+				},
+			})
+		case "/api/tags":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"models":[]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer cloudServer.Close()
+
+	t.Setenv("TEST_OLLAMA_KEY", "test-key")
+	p := New()
+	p.clock = fixedClock{t: reset.Add(-30 * time.Minute)}
+	acct := core.AccountConfig{
+		ID:           "test-ollama-cloud",
+		Provider:     "ollama",
+		Auth:         "api_key",
+		APIKeyEnv:    "TEST_OLLAMA_KEY",
+		RuntimeHints: map[string]string{"cloud_base_url": cloudServer.URL},
+	}
+
+	snap, err := p.Fetch(context.Background(), acct)
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if m := snap.Metrics["usage_one_day"]; m.Used == nil || *m.Used != 40 || m.Window != "1d" {
+		t.Errorf("usage_one_day = %+v, want the daily figure, not the weekly alias", m)
+	}
+	if m := snap.Metrics["usage_weekly"]; m.Used == nil || *m.Used != 12 || m.Window != "1w" {
+		t.Errorf("usage_weekly = %+v, want 12%% over 1w", m)
+	}
+	if got := snap.Resets["usage_five_hour"]; !got.Equal(reset) {
+		t.Errorf("usage_five_hour reset = %s, want %s from the epoch reset_at", got, reset)
+	}
+	if snap.Status != core.StatusLimited || !strings.Contains(snap.Message, "5h usage limit reached") {
+		t.Errorf("status = %s %q, want LIMITED on the exhausted 5h window", snap.Status, snap.Message)
+	}
+}
+
+type fixedClock struct{ t time.Time }
+
+func (c fixedClock) Now() time.Time { return c.t }
@@ -192,15 +192,17 @@ func fetchCloudUsageFromSettingsPage(ctx context.Context, cloudBaseURL, apiKey s
 		}
 		found = true
 	}
-	if value, ok := pcts["weekly usage"]; ok {
-		snap.Metrics["usage_weekly"] = core.Metric{Used: core.Float64Ptr(value), Unit: "%", Window: "1w"}
+	if value, ok := pcts["daily usage"]; ok {
 		snap.Metrics["usage_one_day"] = core.Metric{Used: core.Float64Ptr(value), Unit: "%", Window: "1d"}
-		if t, ok := resets["weekly usage"]; ok {
-			snap.Resets["usage_weekly"] = t
+		if t, ok := resets["daily usage"]; ok {
 			snap.Resets["usage_one_day"] = t
 		}
 		found = true
 	}
+	if value, ok := pcts["weekly usage"]; ok {
+		setWeeklyUsage(snap, core.Metric{Used: core.Float64Ptr(value), Unit: "%", Window: "1w"}, resets["weekly usage"])
+		found = true
+	}
 	return found, nil
 }
 
@@ -93,16 +93,12 @@ func collectTelemetryFromSQLite(ctx context.Context, dbPath string) ([]shared.Te
 	}
 	dbMtime := fi.ModTime().UTC()
 
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := shared.OpenSQLiteReadOnly(ctx, dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("ollama: opening desktop db for telemetry: %w", err)
 	}
 	defer db.Close()
 
-	if err := db.PingContext(ctx); err != nil {
-		return nil, fmt.Errorf("ollama: pinging desktop db for telemetry: %w", err)
-	}
-
 	if !sqliteTableExists(ctx, db, "messages") {
 		return nil, nil
 	}
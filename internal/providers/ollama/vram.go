@@ -0,0 +1,95 @@
+package ollama
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// vramNearLimitPercent is the VRAM utilization at which the tile turns
+// NEAR_LIMIT; the gauge itself follows the UI warn/crit thresholds.
+const vramNearLimitPercent = 90
+
+// resolveVRAMCapacity reads the host's VRAM capacity from the account's
+// "vram_capacity" setting (provider_paths), e.g. "24GiB", "24GB", "8192M" or
+// a plain byte count. GPU memory is sized in binary units, so G/GB and M/MB
+// are read as GiB and MiB. Returns 0 when unset.
+func resolveVRAMCapacity(acct core.AccountConfig) (float64, error) {
+	raw := strings.TrimSpace(acct.Path("vram_capacity", ""))
+	if raw == "" {
+		return 0, nil
+	}
+	v, err := parseVRAMSize(raw)
+	if err != nil {
+		return 0, fmt.Errorf("ollama: invalid vram_capacity %q: %w", raw, err)
+	}
+	return v, nil
+}
+
+func parseVRAMSize(s string) (float64, error) {
+	s = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	mult := 1.0
+	for _, u := range []struct {
+		suffix string
+		mult   float64
+	}{
+		{"tib", 1 << 40}, {"tb", 1 << 40}, {"t", 1 << 40},
+		{"gib", 1 << 30}, {"gb", 1 << 30}, {"g", 1 << 30},
+		{"mib", 1 << 20}, {"mb", 1 << 20}, {"m", 1 << 20},
+		{"b", 1},
+	} {
+		if strings.HasSuffix(s, u.suffix) {
+			s = strings.TrimSuffix(s, u.suffix)
+			mult = u.mult
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if v <= 0 {
+		return 0, fmt.Errorf("must be positive")
+	}
+	return v * mult, nil
+}
+
+// applyVRAMCapacity turns loaded_vram_bytes into a vram_usage gauge against
+// the configured capacity and raises the tile status when the loaded models
+// don't fit: LIMITED when they need more memory than the host has (Ollama
+// spills the rest to CPU), NEAR_LIMIT past vramNearLimitPercent.
+func applyVRAMCapacity(snap *core.UsageSnapshot, capacity float64) {
+	loaded, ok := snap.Metrics["loaded_vram_bytes"]
+	if capacity <= 0 || !ok || loaded.Used == nil {
+		return
+	}
+	used := *loaded.Used
+	limit := capacity
+	remaining := max(capacity-used, 0)
+	snap.Metrics["vram_usage"] = core.Metric{Limit: &limit, Used: &used, Remaining: &remaining, Unit: "bytes", Window: "current"}
+
+	if snap.Status != core.StatusOK {
+		return
+	}
+	required := used
+	if m, ok := snap.Metrics["loaded_model_bytes"]; ok && m.Used != nil && *m.Used > required {
+		required = *m.Used
+	}
+	switch {
+	case required > capacity:
+		snap.Status = core.StatusLimited
+		snap.Message = fmt.Sprintf("loaded models need %s of %s VRAM", formatGiB(required), formatGiB(capacity))
+		if spilled := snap.Raw["vram_spilled_models"]; spilled != "" {
+			snap.Message += " (on CPU: " + spilled + ")"
+		}
+	case used/capacity*100 >= vramNearLimitPercent:
+		snap.Status = core.StatusNearLimit
+		snap.Message = fmt.Sprintf("VRAM %.0f%% used (%s of %s)", used/capacity*100, formatGiB(used), formatGiB(capacity))
+	}
+}
+
+func formatGiB(v float64) string {
+	return fmt.Sprintf("%.1f GiB", v/(1<<30))
+}
@@ -0,0 +1,78 @@
+package ollama
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestParseVRAMSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{in: "24GiB", want: 24 << 30},
+		{in: "24 GB", want: 24 << 30},
+		{in: "8192m", want: 8 << 30},
+		{in: "1.5T", want: 1.5 * (1 << 40)},
+		{in: "1073741824", want: 1 << 30},
+		{in: "0GB", wantErr: true},
+		{in: "lots", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseVRAMSize(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseVRAMSize(%q) = %v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseVRAMSize(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestApplyVRAMCapacity(t *testing.T) {
+	const gib = 1 << 30
+	newSnap := func(vram, size float64) core.UsageSnapshot {
+		snap := core.NewUsageSnapshot("ollama", "ollama")
+		snap.Status = core.StatusOK
+		snap.Message = "OK"
+		setValueMetric(&snap, "loaded_vram_bytes", vram, "bytes", "current")
+		setValueMetric(&snap, "loaded_model_bytes", size, "bytes", "current")
+		return snap
+	}
+
+	snap := newSnap(12*gib, 12*gib)
+	applyVRAMCapacity(&snap, 24*gib)
+	m, ok := snap.Metrics["vram_usage"]
+	if !ok || *m.Limit != 24*gib || *m.Used != 12*gib || *m.Remaining != 12*gib {
+		t.Fatalf("vram_usage = %+v, want 12 of 24 GiB", m)
+	}
+	if snap.Status != core.StatusOK {
+		t.Errorf("status at 50%% = %s, want OK", snap.Status)
+	}
+
+	snap = newSnap(22*gib, 22*gib)
+	applyVRAMCapacity(&snap, 24*gib)
+	if snap.Status != core.StatusNearLimit || !strings.Contains(snap.Message, "92%") {
+		t.Errorf("status at 92%% = %s %q, want NEAR_LIMIT", snap.Status, snap.Message)
+	}
+
+	// The model needs 30 GiB but only 23 GiB landed in VRAM: the rest runs on CPU.
+	snap = newSnap(23*gib, 30*gib)
+	snap.Raw["vram_spilled_models"] = "llama3.3:70b"
+	applyVRAMCapacity(&snap, 24*gib)
+	if snap.Status != core.StatusLimited || !strings.Contains(snap.Message, "30.0 GiB of 24.0 GiB") || !strings.Contains(snap.Message, "llama3.3:70b") {
+		t.Errorf("overcommitted = %s %q, want LIMITED naming the spilled model", snap.Status, snap.Message)
+	}
+
+	snap = newSnap(12*gib, 12*gib)
+	applyVRAMCapacity(&snap, 0)
+	if _, ok := snap.Metrics["vram_usage"]; ok {
+		t.Error("no capacity configured should leave the gauge out")
+	}
+}
@@ -8,9 +8,9 @@ import (
 func dashboardWidget() core.DashboardWidget {
 	cfg := providerbase.CodingToolDashboard(
 		providerbase.WithColorRole(core.DashboardColorRoleAuto),
-		providerbase.WithGaugeMaxLines(3),
+		providerbase.WithGaugeMaxLines(4),
 		providerbase.WithGaugePriority(
-			"usage_five_hour", "usage_weekly", "usage_one_day",
+			"vram_usage", "usage_five_hour", "usage_weekly", "usage_one_day",
 		),
 		providerbase.WithCompactRows(
 			core.DashboardCompactRow{
@@ -60,7 +60,7 @@ func dashboardWidget() core.DashboardWidget {
 			"tool_calls_5h", "tool_calls_1d",
 			"loaded_model_bytes", "loaded_vram_bytes", "context_window",
 			"recent_requests", "requests_7d", "7d_tokens",
-			"usage_five_hour", "usage_weekly", "usage_one_day",
+			"usage_five_hour", "usage_weekly", "usage_one_day", "vram_usage",
 			"models_with_tools", "models_with_vision", "models_with_thinking",
 			"max_context_length", "thinking_requests",
 			"avg_thinking_seconds", "total_thinking_seconds",
@@ -72,6 +72,7 @@ func dashboardWidget() core.DashboardWidget {
 			"models_cloud":            "Cloud Models",
 			"loaded_models":           "Loaded Models",
 			"loaded_vram_bytes":       "Loaded VRAM",
+			"vram_usage":              "VRAM",
 			"loaded_model_bytes":      "Loaded Size",
 			"model_storage_bytes":     "Local Storage",
 			"usage_five_hour":         "Usage 5h",
@@ -139,6 +140,7 @@ func dashboardWidget() core.DashboardWidget {
 				"account_email", "account_name", "plan_name",
 				"selected_model", "cloud_disabled", "cloud_source", "cli_version",
 				"models_usage_top", "model_tokens_estimated_top", "tool_usage", "token_estimation", "signin_url",
+				"vram_spilled_models",
 			},
 		}),
 	)
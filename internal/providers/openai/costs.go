@@ -0,0 +1,219 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+const (
+	// adminKeyEnv holds an Admin key (sk-admin-…). The organization costs
+	// endpoint is only readable with one; project keys get 401/403.
+	adminKeyEnv = "OPENAI_ADMIN_KEY"
+
+	// maxAdminPages bounds pagination of the costs and usage endpoints; a
+	// month of daily buckets fits in one page.
+	maxAdminPages = 5
+)
+
+// Spend categories of the cost breakdown. Batch, fine-tuning, storage and
+// embeddings are billed outside the realtime requests the rate-limit probe
+// sees, so without the breakdown they only surface on the invoice.
+const (
+	costRealtime   = "realtime"
+	costBatch      = "batch"
+	costFineTuning = "fine_tuning"
+	costStorage    = "storage"
+	costEmbeddings = "embeddings"
+)
+
+var adminMetricLabels = map[string]string{
+	"realtime_spend":    "Realtime Spend",
+	"batch_spend":       "Batch Spend",
+	"fine_tuning_spend": "Fine-tuning Spend",
+	"storage_spend":     "Storage Spend",
+	"embeddings_spend":  "Embeddings Spend",
+
+	core.MetricEmbeddingTokens:  "Embedding Tokens",
+	core.MetricImageGenerations: "Images Generated",
+	core.MetricAudioMinutes:     "Audio Transcribed",
+}
+
+// resolveAdminKey returns the account's Admin key. The env var name defaults
+// to OPENAI_ADMIN_KEY and can be overridden with the admin_key_env option, so
+// accounts of different organizations can coexist.
+func resolveAdminKey(acct core.AccountConfig) (key, envName string) {
+	envName = acct.OptionString("admin_key_env", adminKeyEnv)
+	return strings.TrimSpace(os.Getenv(envName)), envName
+}
+
+// adminPage is one page of an organization costs or usage report.
+type adminPage[B any] struct {
+	Data     []B    `json:"data"`
+	HasMore  bool   `json:"has_more"`
+	NextPage string `json:"next_page"`
+}
+
+type costsBucket struct {
+	StartTime int64         `json:"start_time"`
+	Results   []costsResult `json:"results"`
+}
+
+type costsResult struct {
+	LineItem string `json:"line_item"`
+	Amount   struct {
+		Value    float64 `json:"value"`
+		Currency string  `json:"currency"`
+	} `json:"amount"`
+}
+
+// costCategory files a costs line item ("gpt-4o-2024-08-06, input",
+// "batch: gpt-4o, output", "fine-tuning training", "vector store storage",
+// …) under a spend category. Inference on a fine-tuned model ("ft:…") is
+// regular usage; only training counts as fine-tuning.
+func costCategory(lineItem string) string {
+	item := strings.ToLower(lineItem)
+	switch {
+	case strings.Contains(item, "batch"):
+		return costBatch
+	case strings.Contains(item, "fine-tun"), strings.Contains(item, "fine tun"), strings.Contains(item, "training"):
+		return costFineTuning
+	case strings.Contains(item, "storage"):
+		return costStorage
+	case strings.Contains(item, "embedding"):
+		return costEmbeddings
+	}
+	return costRealtime
+}
+
+// fetchCosts adds month-to-date spend from the organization costs endpoint,
+// split by category. Failures land in Raw["costs_error"] so the rate-limit
+// probe still shows.
+func (p *Provider) fetchCosts(ctx context.Context, acct core.AccountConfig, baseURL, adminKey, envName string, snap *core.UsageSnapshot, now time.Time) {
+	now = now.UTC()
+	query := monthReportQuery(acct, now)
+	query.Add("group_by", "line_item")
+
+	buckets, err := fetchAdminPages[costsBucket](ctx, p, baseURL, "/organization/costs", query, adminKey, envName)
+	if err != nil {
+		snap.Raw["costs_error"] = err.Error()
+		return
+	}
+	applyCosts(snap, buckets, now)
+}
+
+// monthReportQuery is the query shared by the costs and usage reports: daily
+// buckets since the first of now's UTC month, scoped to the account's
+// project when one is configured.
+func monthReportQuery(acct core.AccountConfig, now time.Time) url.Values {
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	query := url.Values{}
+	query.Set("start_time", strconv.FormatInt(monthStart.Unix(), 10))
+	query.Set("bucket_width", "1d")
+	query.Set("limit", "31")
+	if project := acct.OptionString("project", ""); project != "" {
+		query.Add("project_ids", project)
+	}
+	return query
+}
+
+// fetchAdminPages reads every page of an organization report. A rejected
+// key's error names the env var it came from.
+func fetchAdminPages[B any](ctx context.Context, p *Provider, baseURL, endpoint string, query url.Values, adminKey, envName string) ([]B, error) {
+	var buckets []B
+	for page := 0; page < maxAdminPages; page++ {
+		var resp adminPage[B]
+		status, err := p.adminGet(ctx, baseURL, endpoint, query, adminKey, &resp)
+		if err != nil {
+			if status == http.StatusUnauthorized || status == http.StatusForbidden {
+				err = fmt.Errorf("%w – check %s (an Admin key)", err, envName)
+			}
+			return nil, err
+		}
+		buckets = append(buckets, resp.Data...)
+		if !resp.HasMore || resp.NextPage == "" {
+			break
+		}
+		query.Set("page", resp.NextPage)
+	}
+	return buckets, nil
+}
+
+func (p *Provider) adminGet(ctx context.Context, baseURL, endpoint string, query url.Values, adminKey string, out any) (int, error) {
+	target := baseURL + endpoint
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return 0, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+adminKey)
+
+	resp, err := p.Client().Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("%s: HTTP %d", endpoint, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("%s: parsing response: %w", endpoint, err)
+	}
+	return resp.StatusCode, nil
+}
+
+func applyCosts(snap *core.UsageSnapshot, buckets []costsBucket, now time.Time) {
+	today := now.Format("2006-01-02")
+	var monthCost, todayCost float64
+	byCategory := make(map[string]float64)
+	costByDay := make(map[string]float64)
+	for _, bucket := range buckets {
+		day := time.Unix(bucket.StartTime, 0).UTC().Format("2006-01-02")
+		for _, row := range bucket.Results {
+			if row.Amount.Currency != "" && !strings.EqualFold(row.Amount.Currency, "usd") {
+				continue
+			}
+			usd := row.Amount.Value
+			monthCost += usd
+			costByDay[day] += usd
+			if day == today {
+				todayCost += usd
+			}
+			byCategory[costCategory(row.LineItem)] += usd
+		}
+	}
+
+	snap.Metrics["monthly_spend"] = core.Metric{Used: &monthCost, Unit: "USD", Window: "month"}
+	snap.Metrics["today_cost"] = core.Metric{Used: &todayCost, Unit: "USD", Window: "today"}
+	for category, usd := range byCategory {
+		cost := usd
+		snap.Metrics[category+"_spend"] = core.Metric{Used: &cost, Unit: "USD", Window: "month"}
+	}
+
+	if len(costByDay) > 0 {
+		days := make([]string, 0, len(costByDay))
+		for day := range costByDay {
+			days = append(days, day)
+		}
+		sort.Strings(days)
+		series := make([]core.TimePoint, 0, len(days))
+		for _, day := range days {
+			series = append(series, core.TimePoint{Date: day, Value: costByDay[day]})
+		}
+		if snap.DailySeries == nil {
+			snap.DailySeries = make(map[string][]core.TimePoint)
+		}
+		snap.DailySeries["cost"] = series
+	}
+}
@@ -3,6 +3,7 @@ package openai
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/providers/providerbase"
@@ -24,7 +25,7 @@ func New() *Provider {
 			ID: "openai",
 			Info: core.ProviderInfo{
 				Name:         "OpenAI",
-				Capabilities: []string{"headers"},
+				Capabilities: []string{"headers", "organization_costs"},
 				DocURL:       "https://platform.openai.com/docs/guides/rate-limits",
 			},
 			Auth: core.ProviderAuthSpec{
@@ -33,9 +34,47 @@ func New() *Provider {
 				DefaultAccountID: "openai",
 			},
 			Setup: core.ProviderSetupSpec{
-				Quickstart: []string{"Set OPENAI_API_KEY to a valid OpenAI API key."},
+				Quickstart: []string{
+					"Set OPENAI_API_KEY to a valid OpenAI API key.",
+					"For month-to-date spend split into realtime, batch, fine-tuning, storage and embeddings, also set OPENAI_ADMIN_KEY to an Admin key.",
+				},
+			},
+			Dashboard: providerbase.DefaultDashboard(
+				providerbase.WithColorRole(core.DashboardColorRoleGreen),
+				providerbase.WithMetricLabels(adminMetricLabels),
+			),
+			CreditMetrics: map[string]core.BalanceSemantics{
+				"monthly_spend": core.BalanceCumulative,
+			},
+			Actions: []core.ProviderAction{
+				{Label: "Open billing page", Kind: core.ProviderActionOpenURL, Target: "https://platform.openai.com/settings/organization/billing/overview"},
+				{Label: "Open status page", Kind: core.ProviderActionOpenURL, Target: "https://status.openai.com"},
+				{Label: "Copy API base URL", Kind: core.ProviderActionCopy, Target: defaultBaseURL, SnapshotKey: "api_base_url"},
+			},
+			Reference: core.ProviderReference{
+				LimitsNote: "for gpt-4.1-mini, the default probe model",
+				Tiers: []core.ProviderTier{
+					{Name: "Tier 1", Qualification: "$5 paid", Limits: map[string]float64{"rpm": 500, "tpm": 200_000}},
+					{Name: "Tier 2", Qualification: "$50 paid and 7+ days since first payment", Limits: map[string]float64{"rpm": 5_000, "tpm": 2_000_000}},
+					{Name: "Tier 3", Qualification: "$100 paid and 7+ days since first payment", Limits: map[string]float64{"rpm": 5_000, "tpm": 4_000_000}},
+					{Name: "Tier 4", Qualification: "$250 paid and 14+ days since first payment", Limits: map[string]float64{"rpm": 10_000, "tpm": 10_000_000}},
+					{Name: "Tier 5", Qualification: "$1,000 paid and 30+ days since first payment", Limits: map[string]float64{"rpm": 30_000, "tpm": 150_000_000}},
+				},
+				Windows: []string{
+					"rpm and tpm are per organization and per model, measured over a rolling minute.",
+					"Tiers are promoted automatically once the payment threshold is met.",
+				},
+				Links: []core.ProviderLink{
+					{Label: "Rate limits", URL: "https://platform.openai.com/docs/guides/rate-limits"},
+					{Label: "Your limits", URL: "https://platform.openai.com/settings/organization/limits"},
+				},
+			},
+			Options: []core.ProviderOption{
+				{Key: "probe_model", Type: core.ProviderOptionString, Default: defaultModel, Description: "Model whose rate-limit headers are read."},
+				{Key: "organization", Type: core.ProviderOptionString, Description: "Organization ID sent as OpenAI-Organization, for keys in several orgs."},
+				{Key: "project", Type: core.ProviderOptionString, Description: "Project ID sent as OpenAI-Project; also scopes the admin-key cost breakdown."},
+				{Key: "admin_key_env", Type: core.ProviderOptionString, Default: adminKeyEnv, Description: "Env var holding an Admin key for the organization cost breakdown."},
 			},
-			Dashboard: providerbase.DefaultDashboard(providerbase.WithColorRole(core.DashboardColorRoleGreen)),
 		}),
 	}
 }
@@ -47,14 +86,17 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 	}
 
 	baseURL := shared.ResolveBaseURL(acct, defaultBaseURL)
-	model := acct.ProbeModel
-	if model == "" {
-		model = defaultModel
-	}
+	model := acct.OptionString("probe_model", core.FirstNonEmpty(acct.ProbeModel, defaultModel))
 
 	headers := map[string]string{
 		"Authorization": "Bearer " + apiKey,
 	}
+	if org := acct.OptionString("organization", ""); org != "" {
+		headers["OpenAI-Organization"] = org
+	}
+	if project := acct.OptionString("project", ""); project != "" {
+		headers["OpenAI-Project"] = project
+	}
 
 	req, err := shared.CreateStandardRequest(ctx, baseURL, "/models/"+model, apiKey, headers)
 	if err != nil {
@@ -73,6 +115,11 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 	}
 
 	shared.ApplyStandardRateLimits(resp, &snap)
+	if adminKey, envName := resolveAdminKey(acct); adminKey != "" {
+		now := time.Now()
+		p.fetchCosts(ctx, acct, baseURL, adminKey, envName, &snap, now)
+		p.fetchMultimodalUsage(ctx, acct, baseURL, adminKey, envName, &snap, now)
+	}
 	shared.FinalizeStatus(&snap)
 	return snap, nil
 }
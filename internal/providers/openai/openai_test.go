@@ -2,10 +2,13 @@ package openai
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
@@ -116,3 +119,106 @@ func TestFetch_RateLimited(t *testing.T) {
 		t.Errorf("Status = %v, want LIMITED", snap.Status)
 	}
 }
+
+func TestFetch_AdminCostBreakdown(t *testing.T) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	var costsQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/organization/costs":
+			if got := r.Header.Get("Authorization"); got != "Bearer admin-key" {
+				t.Errorf("costs Authorization = %q, want the admin key", got)
+			}
+			costsQuery = r.URL.RawQuery
+			fmt.Fprintf(w, `{"object": "page", "has_more": false, "data": [{"start_time": %d, "results": [
+				{"line_item": "gpt-4o-2024-08-06, input", "amount": {"value": 10.5, "currency": "usd"}},
+				{"line_item": "batch: gpt-4o-2024-08-06, input", "amount": {"value": 4, "currency": "usd"}},
+				{"line_item": "fine-tuning training gpt-4o-mini", "amount": {"value": 25, "currency": "usd"}},
+				{"line_item": "ft:gpt-4o-mini:acme, output", "amount": {"value": 1.5, "currency": "usd"}},
+				{"line_item": "vector store storage", "amount": {"value": 0.75, "currency": "usd"}},
+				{"line_item": "text-embedding-3-small", "amount": {"value": 0.25, "currency": "usd"}}
+			]}]}`, today.Unix())
+		case "/organization/usage/embeddings":
+			fmt.Fprintf(w, `{"data": [{"start_time": %d, "results": [{"input_tokens": 1200000, "num_model_requests": 40}]}]}`, today.Unix())
+		case "/organization/usage/images":
+			fmt.Fprintf(w, `{"data": [{"start_time": %d, "results": [{"images": 12, "num_model_requests": 6}]}]}`, today.Unix())
+		case "/organization/usage/audio_transcriptions":
+			fmt.Fprintf(w, `{"data": [{"start_time": %d, "results": [{"seconds": 5400, "num_model_requests": 3}]}]}`, today.Unix())
+		default:
+			w.Header().Set("x-ratelimit-limit-requests", "200")
+			w.Header().Set("x-ratelimit-remaining-requests", "150")
+			w.Write([]byte(`{"id": "gpt-4.1-mini"}`))
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_OPENAI_ADMIN_KEY", "admin-key")
+	snap, err := New().Fetch(context.Background(), core.AccountConfig{
+		ID:              "test-openai",
+		Provider:        "openai",
+		Token:           "test-key",
+		BaseURL:         server.URL,
+		ProviderOptions: map[string]any{"admin_key_env": "TEST_OPENAI_ADMIN_KEY", "project": "proj_abc"},
+	})
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if snap.Status != core.StatusOK {
+		t.Errorf("Status = %v, want OK", snap.Status)
+	}
+	if !strings.Contains(costsQuery, "group_by=line_item") || !strings.Contains(costsQuery, "project_ids=proj_abc") {
+		t.Errorf("costs query = %q, want line_item grouping scoped to the project", costsQuery)
+	}
+	for key, want := range map[string]float64{
+		"monthly_spend":     42,
+		"today_cost":        42,
+		"realtime_spend":    12,
+		"batch_spend":       4,
+		"fine_tuning_spend": 25,
+		"storage_spend":     0.75,
+		"embeddings_spend":  0.25,
+		"embedding_tokens":  1_200_000,
+		"image_generations": 12,
+		"audio_minutes":     90,
+	} {
+		if m, ok := snap.Metrics[key]; !ok || m.Used == nil || *m.Used != want {
+			t.Errorf("%s = %+v, want %v", key, m, want)
+		}
+	}
+	if got := snap.Metrics["image_generations"].Unit; got != core.UnitImages {
+		t.Errorf("image_generations unit = %q, want %q", got, core.UnitImages)
+	}
+	if _, ok := snap.Metrics["rpm"]; !ok {
+		t.Error("rate-limit probe metrics missing alongside the cost breakdown")
+	}
+}
+
+func TestFetch_AdminKeyRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/organization/costs" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"id": "gpt-4.1-mini"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_OPENAI_ADMIN_KEY", "sk-proj-not-admin")
+	snap, err := New().Fetch(context.Background(), core.AccountConfig{
+		ID:              "test-openai",
+		Provider:        "openai",
+		Token:           "test-key",
+		BaseURL:         server.URL,
+		ProviderOptions: map[string]any{"admin_key_env": "TEST_OPENAI_ADMIN_KEY"},
+	})
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if got := snap.Raw["costs_error"]; !strings.Contains(got, "TEST_OPENAI_ADMIN_KEY") {
+		t.Errorf("Raw[costs_error] = %q, want it to name the admin key env var", got)
+	}
+	if _, ok := snap.Metrics["monthly_spend"]; ok {
+		t.Error("monthly_spend reported although the costs request failed")
+	}
+}
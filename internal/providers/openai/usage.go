@@ -0,0 +1,62 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// usageBucket is one day of an organization usage report. The embeddings,
+// images and audio transcription reports share the bucket shape and differ
+// only in which counter their results carry.
+type usageBucket struct {
+	StartTime int64         `json:"start_time"`
+	Results   []usageResult `json:"results"`
+}
+
+type usageResult struct {
+	InputTokens int64   `json:"input_tokens"`
+	Images      int64   `json:"images"`
+	Seconds     float64 `json:"seconds"`
+}
+
+// fetchMultimodalUsage adds this month's embedding tokens, generated images
+// and transcribed audio minutes from the organization usage reports. They
+// are billed per input token, per image and per minute rather than as chat
+// tokens, so they are reported under their own metrics and units.
+// Failures land in Raw["usage_error"].
+func (p *Provider) fetchMultimodalUsage(ctx context.Context, acct core.AccountConfig, baseURL, adminKey, envName string, snap *core.UsageSnapshot, now time.Time) {
+	reports := []struct {
+		endpoint string
+		key      string
+		unit     string
+		value    func(usageResult) float64
+	}{
+		{"/organization/usage/embeddings", core.MetricEmbeddingTokens, "tokens", func(r usageResult) float64 { return float64(r.InputTokens) }},
+		{"/organization/usage/images", core.MetricImageGenerations, core.UnitImages, func(r usageResult) float64 { return float64(r.Images) }},
+		{"/organization/usage/audio_transcriptions", core.MetricAudioMinutes, core.UnitMinutes, func(r usageResult) float64 { return r.Seconds / 60 }},
+	}
+
+	var errs []error
+	for _, report := range reports {
+		buckets, err := fetchAdminPages[usageBucket](ctx, p, baseURL, report.endpoint, monthReportQuery(acct, now.UTC()), adminKey, envName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var total float64
+		for _, bucket := range buckets {
+			for _, row := range bucket.Results {
+				total += report.value(row)
+			}
+		}
+		if total > 0 {
+			snap.Metrics[report.key] = core.Metric{Used: &total, Unit: report.unit, Window: "month"}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		snap.Raw["usage_error"] = err.Error()
+	}
+}
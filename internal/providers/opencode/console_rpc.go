@@ -12,6 +12,8 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
 
 // OpenCode console exposes data behind SolidStart server functions reachable
@@ -65,7 +67,7 @@ type ConsoleClient struct {
 // pointing at https://opencode.ai. Tests can override baseURL.
 func NewConsoleClient(cookieValue, cookieName, workspaceID string) *ConsoleClient {
 	return &ConsoleClient{
-		httpClient:  &http.Client{Timeout: 15 * time.Second},
+		httpClient:  shared.NewHTTPClient(15 * time.Second),
 		baseURL:     consoleBaseURL,
 		Cookie:      cookieValue,
 		CookieName:  cookieName,
@@ -148,6 +150,8 @@ func (c *ConsoleClient) callGET(ctx context.Context, fnID string, args ...any) (
 
 // callPOST invokes a POST-style action (queryUsageMonth). The args payload
 // is JSON-encoded as the request body; ID goes in the `x-server-id` header.
+// queryUsageMonth only reads the month's usage, so it is allowed in
+// read-only mode despite the POST.
 func (c *ConsoleClient) callPOST(ctx context.Context, fnID string, args ...any) ([]byte, error) {
 	if c.Cookie == "" || c.CookieName == "" {
 		return nil, errors.New("console: missing session cookie")
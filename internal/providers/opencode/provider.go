@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/providers/providerbase"
@@ -20,12 +22,11 @@ var (
 // OpenCode Zen exposes only OpenAI-compatible chat/messages/models endpoints
 // behind its API-key auth (verified via reverse-engineering against the
 // upstream source at github.com/anomalyco/opencode). Billing, usage history,
-// and key management live behind session-cookie SolidStart RPCs that this
-// provider does not (yet) authenticate against — those would need a separate
-// cookie-based code path.
+// and key management live behind session-cookie SolidStart RPCs, which
+// enrichFromConsole reads when the user has imported a browser session.
 //
-// As a result, the only signal we get from a poll is "is this key valid?".
-// Tile metrics (token spend, model burn, project breakdown, tool usage,
+// Without that session the only signal we get from a poll is "is this key
+// valid?". Tile metrics (token spend, model burn, project breakdown, tool usage,
 // activity totals) come from the OpenCode telemetry plugin and flow in via
 // the telemetry pipeline once an account with provider_id=opencode exists.
 const (
@@ -46,6 +47,7 @@ func New() *Provider {
 				Capabilities: []string{"zen_models_endpoint", "telemetry_driven_metrics"},
 				DocURL:       "https://opencode.ai/docs/",
 			},
+			Dashboard: dashboardWidget(),
 			Auth: core.ProviderAuthSpec{
 				Type:                core.ProviderAuthTypeAPIKey,
 				APIKeyEnv:           "OPENCODE_API_KEY",
@@ -62,6 +64,14 @@ func New() *Provider {
 					"Tile spend / model / activity metrics are populated from the OpenCode telemetry plugin; see Settings → 7 INTEG.",
 				},
 			},
+			Actions: []core.ProviderAction{
+				{Label: "Open console (billing)", Kind: core.ProviderActionOpenURL, Target: "https://opencode.ai/auth"},
+				{Label: "Copy API base URL", Kind: core.ProviderActionCopy, Target: defaultBaseURL + "/zen/v1"},
+			},
+			Options: []core.ProviderOption{
+				{Key: "zen_probe", Type: core.ProviderOptionBool, Default: "true", Description: "Probe the Zen models endpoint with the API key. Turn off for console-only accounts."},
+				{Key: "workspace_id", Type: core.ProviderOptionString, Description: "Console workspace to read billing from. Defaults to the first workspace of the session."},
+			},
 		}),
 	}
 }
@@ -76,7 +86,8 @@ type modelsResponse struct {
 
 func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.UsageSnapshot, error) {
 	apiKey, authSnap := shared.RequireAPIKey(acct, p.ID())
-	if authSnap != nil {
+	probe := !zenProbeDisabled(acct)
+	if authSnap != nil && probe {
 		return *authSnap, nil
 	}
 
@@ -86,30 +97,34 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 	snap.SetAttribute("api_base_url", baseURL)
 
 	var models modelsResponse
-	statusCode, _, err := shared.FetchJSON(ctx, baseURL+modelsPath, apiKey, &models, p.Client())
-	if err != nil {
-		switch statusCode {
-		case http.StatusUnauthorized, http.StatusForbidden:
-			snap.Status = core.StatusAuth
-			snap.Message = fmt.Sprintf("HTTP %d – check OPENCODE_API_KEY", statusCode)
-			return snap, nil
-		case http.StatusTooManyRequests:
-			snap.Status = core.StatusLimited
-			snap.Message = "rate limited (HTTP 429)"
-			return snap, nil
+	if probe {
+		statusCode, _, err := shared.FetchJSON(ctx, baseURL+modelsPath, apiKey, &models, p.Client())
+		if err != nil {
+			switch statusCode {
+			case http.StatusUnauthorized, http.StatusForbidden:
+				snap.Status = core.StatusAuth
+				snap.Message = fmt.Sprintf("HTTP %d – check OPENCODE_API_KEY", statusCode)
+				return snap, nil
+			case http.StatusTooManyRequests:
+				snap.Status = core.StatusLimited
+				snap.Message = "rate limited (HTTP 429)"
+				return snap, nil
+			}
+			return snap, fmt.Errorf("opencode zen models: %w", err)
 		}
-		return snap, fmt.Errorf("opencode zen models: %w", err)
-	}
 
-	if len(models.Data) > 0 {
-		ids := make([]string, 0, len(models.Data))
-		for _, m := range models.Data {
-			if id := strings.TrimSpace(m.ID); id != "" {
-				ids = append(ids, id)
+		if len(models.Data) > 0 {
+			ids := make([]string, 0, len(models.Data))
+			for _, m := range models.Data {
+				if id := strings.TrimSpace(m.ID); id != "" {
+					ids = append(ids, id)
+				}
 			}
+			snap.SetAttribute("available_models", strings.Join(ids, ", "))
+			snap.SetAttribute("available_models_count", fmt.Sprintf("%d", len(ids)))
 		}
-		snap.SetAttribute("available_models", strings.Join(ids, ", "))
-		snap.SetAttribute("available_models_count", fmt.Sprintf("%d", len(ids)))
+	} else {
+		snap.SetAttribute("zen_probe", "disabled")
 	}
 
 	// Optional: enrich the snapshot with console-side data (balance,
@@ -117,32 +132,63 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 	// configured for this account. Failures are non-fatal — the
 	// API-key probe already succeeded above, the snapshot is in a good
 	// state, we just skip the enrichment and surface a hint.
-	if err := p.enrichFromConsole(ctx, acct, &snap); err != nil {
+	consoleErr := p.enrichFromConsole(ctx, acct, &snap)
+	if consoleErr != nil {
 		// Distinguish "no cookie configured" (silent) from "cookie
 		// rejected" (loud diagnostic for the tile).
 		var authErr *ConsoleAuthError
 		switch {
-		case errors.As(err, &authErr):
+		case errors.As(consoleErr, &authErr):
 			snap.SetDiagnostic("opencode_console_auth_error", authErr.Error())
 			snap.Raw["console_auth_status"] = fmt.Sprintf("%d", authErr.StatusCode)
-		case errors.Is(err, errNoCookieConfigured):
+		case errors.Is(consoleErr, errNoCookieConfigured):
 			// expected when user hasn't connected a browser session
 		default:
-			snap.Raw["console_error"] = err.Error()
+			snap.Raw["console_error"] = consoleErr.Error()
 		}
 	}
 
+	// With the probe off the console is the only source; without it there
+	// is nothing to show.
+	if !probe && consoleErr != nil {
+		snap.Status = core.StatusAuth
+		snap.Message = "zen_probe is off and no console session – import the browser session cookie"
+		return snap, nil
+	}
+
 	shared.FinalizeStatus(&snap)
 	if snap.Status == core.StatusOK {
-		if bal, ok := snap.Metrics["console_balance"]; ok && bal.Remaining != nil {
+		switch bal, ok := snap.Metrics["console_balance"]; {
+		case ok && bal.Remaining != nil && probe:
 			snap.Message = fmt.Sprintf("$%.2f balance · %d Zen models", *bal.Remaining, len(models.Data))
-		} else {
+		case ok && bal.Remaining != nil:
+			snap.Message = fmt.Sprintf("$%.2f balance", *bal.Remaining)
+			if usage, ok := snap.Metrics["monthly_usage"]; ok && usage.Used != nil {
+				snap.Message += fmt.Sprintf(" · $%.2f this month", *usage.Used)
+			}
+		default:
 			snap.Message = fmt.Sprintf("Auth OK · %d Zen models", len(models.Data))
 		}
 	}
 	return snap, nil
 }
 
+// zenProbeDisabled reports whether the account opted out of the Zen models
+// probe ("zen_probe": "off" in provider_paths). Accounts with a console
+// session get balance and spend from the console alone, so the API key and
+// the probe become optional.
+func zenProbeDisabled(acct core.AccountConfig) bool {
+	if !acct.OptionBool("zen_probe", true) {
+		return true
+	}
+	// Legacy: zen_probe used to be set through provider_paths.
+	switch strings.ToLower(strings.TrimSpace(acct.Path("zen_probe", ""))) {
+	case "off", "false", "0", "disabled":
+		return true
+	}
+	return false
+}
+
 var errNoCookieConfigured = errors.New("opencode: no browser session configured")
 
 // enrichFromConsole loads the stored browser session for the account, calls
@@ -151,12 +197,15 @@ var errNoCookieConfigured = errors.New("opencode: no browser session configured"
 // opted in to browser-session auth.
 func (p *Provider) enrichFromConsole(ctx context.Context, acct core.AccountConfig, snap *core.UsageSnapshot) error {
 	session, ok, err := loadBrowserSession(ctx, acct, nil)
+	if shared.BrowserSessionRefreshSkipped(acct, session, ok) {
+		snap.Raw["browser_session_refresh"] = core.ReadOnlySkipped
+	}
 	if err != nil || !ok || session.Value == "" {
 		return errNoCookieConfigured
 	}
 
 	client := newConsoleClient(session.Value, session.CookieName, "")
-	workspaceID := strings.TrimSpace(acct.Hint("opencode_workspace_id", ""))
+	workspaceID := acct.OptionString("workspace_id", strings.TrimSpace(acct.Hint("opencode_workspace_id", "")))
 	if workspaceID == "" {
 		workspaceID, err = client.DiscoverWorkspaceID(ctx)
 		if err != nil {
@@ -220,5 +269,82 @@ func (p *Provider) enrichFromConsole(ctx context.Context, acct core.AccountConfi
 	snap.SetAttribute("auth_scope", "zen+console")
 	snap.SetAttribute("console_session_browser", session.SourceBrowser)
 
+	now := time.Now()
+	month, err := client.QueryUsageMonth(ctx, now.Year(), int(now.Month()), now.Format("-07:00"))
+	if err != nil {
+		snap.SetDiagnostic("opencode_console_usage_error", err.Error())
+		return nil
+	}
+	applyMemberSpend(snap, month)
+
 	return nil
 }
+
+// applyMemberSpend rolls the month's per-key spend up to workspace members.
+// Zen key display names read "<member email> - <key name>", so keys sharing
+// an owner are summed; keys without that shape count under their own name.
+// Costs use the same 1e-8 USD unit as the billing RPC.
+func applyMemberSpend(snap *core.UsageSnapshot, month MonthUsage) {
+	owner := make(map[string]string, len(month.Keys))
+	for _, k := range month.Keys {
+		name := strings.TrimSpace(k.DisplayName)
+		if i := strings.Index(name, " - "); i > 0 {
+			name = strings.TrimSpace(name[:i])
+		}
+		if name == "" {
+			name = k.ID
+		}
+		owner[k.ID] = name
+	}
+
+	spend := make(map[string]float64)
+	for _, d := range month.Days {
+		name := owner[d.KeyID]
+		if name == "" {
+			name = core.FirstNonEmpty(d.KeyID, "unknown")
+		}
+		spend[name] += d.TotalCost / 1e8
+	}
+	if len(spend) == 0 {
+		return
+	}
+
+	members := make([]string, 0, len(spend))
+	for name, cost := range spend {
+		members = append(members, name)
+		v := cost
+		snap.Metrics["member_"+sanitizeMetricPart(name)+"_cost"] = core.Metric{Used: &v, Unit: "USD", Window: "month"}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if spend[members[i]] != spend[members[j]] {
+			return spend[members[i]] > spend[members[j]]
+		}
+		return members[i] < members[j]
+	})
+	parts := make([]string, 0, len(members))
+	for _, name := range members {
+		parts = append(parts, fmt.Sprintf("%s $%.2f", name, spend[name]))
+	}
+	count := float64(len(members))
+	snap.Metrics["active_members"] = core.Metric{Used: &count, Unit: "members", Window: "month"}
+	snap.Raw["member_spend"] = strings.Join(parts, " · ")
+}
+
+// sanitizeMetricPart lowercases s and folds every run of non-alphanumerics
+// into a single underscore, e.g. "jan@example.com" → "jan_example_com".
+func sanitizeMetricPart(s string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
@@ -150,7 +150,7 @@ func TestFetch_ConsoleEnrichmentAutoDiscoversWorkspaceID(t *testing.T) {
 			_, _ = w.Write([]byte(zenModelsBody()))
 		case r.URL.Path == "/auth":
 			http.Redirect(w, r, "/workspace/wrk_DISCOVERED", http.StatusFound)
-		case r.URL.Path == "/_server":
+		case r.URL.Path == "/_server" && r.Header.Get("x-server-id") == rpcBillingInfoID:
 			discoveredWorkspaceID = r.URL.Query().Get("args")
 			w.Header().Set("Content-Type", "text/javascript")
 			_, _ = w.Write(billing)
@@ -193,3 +193,84 @@ func TestFetch_ConsoleEnrichmentAutoDiscoversWorkspaceID(t *testing.T) {
 		t.Fatalf("unexpected workspace discovery diagnostic: %+v", snap.Diagnostics)
 	}
 }
+
+func TestFetch_ZenProbeDisabledUsesConsoleOnly(t *testing.T) {
+	origLoadBrowserSession := loadBrowserSession
+	origNewConsoleClient := newConsoleClient
+	t.Cleanup(func() {
+		loadBrowserSession = origLoadBrowserSession
+		newConsoleClient = origNewConsoleClient
+	})
+	loadBrowserSession = func(context.Context, core.AccountConfig, browsercookies.Reader) (config.BrowserSession, bool, error) {
+		return config.BrowserSession{Value: "test-cookie-value", CookieName: "auth"}, true, nil
+	}
+
+	billing := loadFixture(t, "seroval_c83b78a61468.txt")
+	month := `;0x00000001;((self.$R=self.$R||{})["server-fn:1"]=[],($R=>$R[0]={usage:$R[1]=[` +
+		`$R[2]={date:"2026-04-29",model:"glm-5",totalCost:150000000,keyId:"key_A",plan:null},` +
+		`$R[3]={date:"2026-04-30",model:"kimi-k2.6",totalCost:250000000,keyId:"key_B",plan:null},` +
+		`$R[4]={date:"2026-04-30",model:"glm-5",totalCost:100000000,keyId:"key_C",plan:null}],` +
+		`keys:$R[5]=[$R[6]={id:"key_A",displayName:"ann@example.com - Default API Key",deleted:!1},` +
+		`$R[7]={id:"key_B",displayName:"ann@example.com - ci",deleted:!1},` +
+		`$R[8]={id:"key_C",displayName:"bob@example.com - Default API Key",deleted:!1}]})($R["server-fn:1"]))`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/javascript")
+		switch {
+		case r.URL.Path == modelsPath:
+			t.Error("models probe should not run with zen_probe off")
+		case r.URL.Path == "/_server" && r.Header.Get("x-server-id") == rpcBillingInfoID:
+			_, _ = w.Write(billing)
+		case r.URL.Path == "/_server" && r.Header.Get("x-server-id") == rpcUsageMonthID:
+			_, _ = w.Write([]byte(month))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	newConsoleClient = func(cookieValue, cookieName, workspaceID string) *ConsoleClient {
+		client := NewConsoleClient(cookieValue, cookieName, workspaceID)
+		client.baseURL = server.URL
+		return client
+	}
+
+	// No API key: the console session alone carries the account.
+	acct := core.AccountConfig{
+		ID:            "opencode",
+		Provider:      "opencode",
+		APIKeyEnv:     "TEST_OPENCODE_MISSING",
+		BaseURL:       server.URL,
+		ProviderPaths: map[string]string{"zen_probe": "off"},
+		RuntimeHints:  map[string]string{"opencode_workspace_id": "wrk_X"},
+	}
+	snap, err := New().Fetch(context.Background(), acct)
+	if err != nil {
+		t.Fatalf("Fetch error: %v", err)
+	}
+	if snap.Status != core.StatusOK {
+		t.Fatalf("status = %s (msg=%q), want OK", snap.Status, snap.Message)
+	}
+	if got := snap.Attributes["zen_probe"]; got != "disabled" {
+		t.Errorf("zen_probe attribute = %q, want disabled", got)
+	}
+	if m := snap.Metrics["member_ann_example_com_cost"]; m.Used == nil || *m.Used != 4 {
+		t.Errorf("ann's spend = %+v, want $4 across both keys", m)
+	}
+	if m := snap.Metrics["member_bob_example_com_cost"]; m.Used == nil || *m.Used != 1 {
+		t.Errorf("bob's spend = %+v, want $1", m)
+	}
+	if got := snap.Raw["member_spend"]; got != "ann@example.com $4.00 · bob@example.com $1.00" {
+		t.Errorf("member_spend = %q", got)
+	}
+
+	// Probe off without a console session leaves nothing to report.
+	loadBrowserSession = func(context.Context, core.AccountConfig, browsercookies.Reader) (config.BrowserSession, bool, error) {
+		return config.BrowserSession{}, false, nil
+	}
+	snap, err = New().Fetch(context.Background(), acct)
+	if err != nil {
+		t.Fatalf("Fetch error: %v", err)
+	}
+	if snap.Status != core.StatusAuth {
+		t.Errorf("status without session = %s, want AUTH", snap.Status)
+	}
+}
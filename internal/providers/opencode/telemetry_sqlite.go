@@ -25,7 +25,7 @@ func CollectTelemetryFromSQLite(ctx context.Context, dbPath string) ([]shared.Te
 		return nil, fmt.Errorf("stat opencode sqlite db: %w", err)
 	}
 
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := shared.OpenSQLiteReadOnly(ctx, dbPath)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,40 @@
+package opencode
+
+import (
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/providerbase"
+)
+
+// dashboardWidget is the default layout plus the live session the telemetry
+// read model attaches from the OpenCode plugin's events.
+func dashboardWidget() core.DashboardWidget {
+	cfg := providerbase.DefaultDashboard(
+		providerbase.WithRawGroups(
+			core.DashboardRawGroup{
+				Label: "Live Session",
+				Keys: []string{
+					"live_session_status", "live_session_models", "live_session_started",
+					"live_session_last_active", "live_session_id",
+				},
+			},
+		),
+		providerbase.WithMetricLabels(map[string]string{
+			"live_session_cost_usd":   "Session Cost",
+			"live_session_messages":   "Session Messages",
+			"live_session_tool_calls": "Session Tool Calls",
+			"live_session_tokens":     "Session Tokens",
+		}),
+		providerbase.WithCompactLabels(map[string]string{
+			"live_session_cost_usd":   "cost",
+			"live_session_messages":   "msgs",
+			"live_session_tool_calls": "tools",
+			"live_session_tokens":     "tok",
+		}),
+	)
+	cfg.CompactRows = append([]core.DashboardCompactRow{{
+		Label:       "Session",
+		Keys:        []string{"live_session_cost_usd", "live_session_messages", "live_session_tool_calls", "live_session_tokens"},
+		MaxSegments: 4,
+	}}, cfg.CompactRows...)
+	return cfg
+}
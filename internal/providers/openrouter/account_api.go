@@ -3,6 +3,7 @@ package openrouter
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -226,50 +227,12 @@ func (p *Provider) fetchCreditsDetail(ctx context.Context, baseURL, apiKey strin
 }
 
 func (p *Provider) fetchKeysMeta(ctx context.Context, baseURL, apiKey string, snap *core.UsageSnapshot) error {
-	const (
-		pageSizeHint = 100
-		maxPages     = 20
-	)
-
-	var allKeys []keyListEntry
-	offset := 0
-	for page := 0; page < maxPages; page++ {
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/keys?include_disabled=true&offset=%d", baseURL, offset), nil)
-		if err != nil {
-			return err
-		}
-		req.Header.Set("Authorization", "Bearer "+apiKey)
-
-		resp, err := p.Client().Do(req)
-		if err != nil {
-			return err
-		}
-
-		body, err := io.ReadAll(resp.Body)
-		resp.Body.Close()
-		if err != nil {
-			return err
-		}
-		if resp.StatusCode == http.StatusForbidden {
-			return nil
-		}
-		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("HTTP %d", resp.StatusCode)
-		}
-
-		var pageResp keysResponse
-		if err := json.Unmarshal(body, &pageResp); err != nil {
-			return fmt.Errorf("openrouter: parsing keys list: %w", err)
-		}
-		if len(pageResp.Data) == 0 {
-			break
-		}
-
-		allKeys = append(allKeys, pageResp.Data...)
-		offset += len(pageResp.Data)
-		if len(pageResp.Data) < pageSizeHint {
-			break
-		}
+	allKeys, err := p.listKeys(ctx, baseURL, apiKey)
+	if errors.Is(err, errKeysForbidden) {
+		return nil
+	}
+	if err != nil {
+		return err
 	}
 
 	snap.Raw["keys_total"] = fmt.Sprintf("%d", len(allKeys))
@@ -355,3 +318,57 @@ func (p *Provider) fetchKeysMeta(ctx context.Context, baseURL, apiKey string, sn
 
 	return nil
 }
+
+// errKeysForbidden means the key may not list or manage keys; only
+// management keys can.
+var errKeysForbidden = errors.New("openrouter: listing keys needs a management key")
+
+// listKeys pages through /keys, including disabled keys.
+func (p *Provider) listKeys(ctx context.Context, baseURL, apiKey string) ([]keyListEntry, error) {
+	const (
+		pageSizeHint = 100
+		maxPages     = 20
+	)
+
+	var allKeys []keyListEntry
+	offset := 0
+	for page := 0; page < maxPages; page++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/keys?include_disabled=true&offset=%d", baseURL, offset), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+
+		resp, err := p.Client().Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusForbidden {
+			return nil, errKeysForbidden
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+		}
+
+		var pageResp keysResponse
+		if err := json.Unmarshal(body, &pageResp); err != nil {
+			return nil, fmt.Errorf("openrouter: parsing keys list: %w", err)
+		}
+		if len(pageResp.Data) == 0 {
+			break
+		}
+
+		allKeys = append(allKeys, pageResp.Data...)
+		offset += len(pageResp.Data)
+		if len(pageResp.Data) < pageSizeHint {
+			break
+		}
+	}
+	return allKeys, nil
+}
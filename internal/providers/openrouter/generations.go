@@ -76,7 +76,7 @@ func (p *Provider) fetchGenerationStats(ctx context.Context, baseURL, apiKey str
 	allGenerations, err := p.fetchAllGenerations(ctx, baseURL, apiKey)
 	if err != nil {
 		if errors.Is(err, errGenerationListUnsupported) {
-			snap.Raw["generation_note"] = "generation list endpoint unavailable without IDs"
+			snap.AddDeprecation(generationListDeprecation)
 			snap.Raw["generations_fetched"] = "0"
 			return nil
 		}
@@ -85,11 +85,12 @@ func (p *Provider) fetchGenerationStats(ctx context.Context, baseURL, apiKey str
 
 	if len(allGenerations) == 0 {
 		snap.Raw["generations_fetched"] = "0"
+		applyFreeModelAllowance(snap, 0, p.now().UTC())
 		return nil
 	}
 
 	detailLookups, detailHits := p.enrichGenerationProviderMetadata(ctx, baseURL, apiKey, allGenerations)
-	if detailLookups > 0 {
+	if detailLookups > 0 || detailHits > 0 {
 		snap.Raw["generation_provider_detail_lookups"] = fmt.Sprintf("%d", detailLookups)
 		snap.Raw["generation_provider_detail_hits"] = fmt.Sprintf("%d", detailHits)
 	}
@@ -104,7 +105,7 @@ func (p *Provider) fetchGenerationStats(ctx context.Context, baseURL, apiKey str
 	modelStatsMap := make(map[string]*modelStats)
 	providerStatsMap := make(map[string]*providerStats)
 
-	var todayPrompt, todayCompletion, todayRequests int
+	var todayPrompt, todayCompletion, todayRequests, todayFreeRequests int
 	var todayNativePrompt, todayNativeCompletion int
 	var todayReasoning, todayCached, todayImageTokens int
 	var todayMediaPrompt, todayMediaCompletion, todayAudioInputs, todaySearchResults, todayCancelled int
@@ -122,6 +123,7 @@ func (p *Provider) fetchGenerationStats(ctx context.Context, baseURL, apiKey str
 
 	var cost7d, cost30d, burnCost float64
 	var todayByokCost, cost7dByok, cost30dByok float64
+	var cacheSavings30d float64
 
 	dailyCost := make(map[string]float64)
 	dailyRequests := make(map[string]float64)
@@ -211,8 +213,13 @@ func (p *Provider) fetchGenerationStats(ctx context.Context, baseURL, apiKey str
 			ms.TotalModeration += *generation.ModerationLatency
 			ms.ModerationCount++
 		}
-		if generation.CacheDiscount != nil && *generation.CacheDiscount > 0 {
-			ms.CacheDiscountUSD += *generation.CacheDiscount
+		if generation.CacheDiscount != nil {
+			// Negative for cache writes priced above fresh input; the net
+			// is what caching saved.
+			cacheSavings30d += *generation.CacheDiscount
+			if *generation.CacheDiscount > 0 {
+				ms.CacheDiscountUSD += *generation.CacheDiscount
+			}
 		}
 		hostingProvider, source := resolveGenerationHostingProviderWithSource(generation)
 		providerResolutionCounts[source]++
@@ -258,6 +265,9 @@ func (p *Provider) fetchGenerationStats(ctx context.Context, baseURL, apiKey str
 		}
 
 		todayRequests++
+		if strings.HasSuffix(generation.Model, freeModelSuffix) {
+			todayFreeRequests++
+		}
 		todayPrompt += generation.PromptTokens
 		todayCompletion += generation.CompletionTokens
 		if generation.NativePromptTokens != nil {
@@ -321,6 +331,8 @@ func (p *Provider) fetchGenerationStats(ctx context.Context, baseURL, apiKey str
 		}
 	}
 
+	applyFreeModelAllowance(snap, todayFreeRequests, now)
+
 	if todayRequests > 0 {
 		reqs := float64(todayRequests)
 		snap.Metrics["today_requests"] = core.Metric{Used: &reqs, Unit: "requests", Window: "today"}
@@ -427,6 +439,9 @@ func (p *Provider) fetchGenerationStats(ctx context.Context, baseURL, apiKey str
 	snap.Metrics["recent_requests"] = core.Metric{Used: &reqs, Unit: "requests", Window: "recent"}
 	snap.Metrics["7d_api_cost"] = core.Metric{Used: &cost7d, Unit: "USD", Window: "7d"}
 	snap.Metrics["30d_api_cost"] = core.Metric{Used: &cost30d, Unit: "USD", Window: "30d"}
+	if m, ok := core.CacheSavingsMetric(cacheSavings30d, "30d"); ok {
+		snap.Metrics[core.CacheSavingsMetricKey] = m
+	}
 	if cost7dByok > 0 {
 		snap.Metrics["7d_byok_cost"] = core.Metric{Used: &cost7dByok, Unit: "USD", Window: "7d"}
 		snap.Raw["byok_in_use"] = "true"
@@ -601,13 +616,13 @@ func (p *Provider) fetchAllGenerations(ctx context.Context, baseURL, apiKey stri
 	return all, nil
 }
 
+// enrichGenerationProviderMetadata recovers the hosting provider of rows
+// that lack one from their generation details. Cached details are free;
+// at most maxGenerationProviderDetailLookups are fetched per call.
 func (p *Provider) enrichGenerationProviderMetadata(ctx context.Context, baseURL, apiKey string, rows []generationEntry) (int, int) {
 	attempts := 0
 	hits := 0
 	for i := range rows {
-		if attempts >= maxGenerationProviderDetailLookups {
-			break
-		}
 		if rows[i].ID == "" {
 			continue
 		}
@@ -618,10 +633,20 @@ func (p *Provider) enrichGenerationProviderMetadata(ctx context.Context, baseURL
 			continue
 		}
 
-		attempts++
-		detail, err := p.fetchGenerationDetail(ctx, baseURL, apiKey, rows[i].ID)
-		if err != nil {
-			continue
+		cacheKey := baseURL + "\x00" + rows[i].ID
+		detail, cached := p.generationDetails.Peek(cacheKey)
+		if !cached {
+			if attempts >= maxGenerationProviderDetailLookups {
+				continue
+			}
+			attempts++
+			var err error
+			detail, err = p.generationDetails.Get(ctx, cacheKey, func(ctx context.Context) (generationEntry, error) {
+				return p.fetchGenerationDetail(ctx, baseURL, apiKey, rows[i].ID)
+			})
+			if err != nil {
+				continue
+			}
 		}
 		resolvedBefore := resolveGenerationHostingProvider(rows[i])
 		if len(detail.ProviderResponses) > 0 {
@@ -670,3 +695,26 @@ func (p *Provider) fetchGenerationDetail(ctx context.Context, baseURL, apiKey, g
 	}
 	return detail.Data, nil
 }
+
+// OpenRouter caps requests to ":free" model variants per UTC day: accounts
+// that have bought fewer than $10 of credits get the low cap.
+const (
+	freeModelSuffix         = ":free"
+	freeModelDailyLimitLow  = 50
+	freeModelDailyLimitHigh = 1000
+	freeModelCreditsForHigh = 10
+)
+
+// applyFreeModelAllowance records today's free-model requests against the
+// daily cap as free_rpd. The count comes from the generation list, so it is
+// a lower bound on busy days that exceed one page.
+func applyFreeModelAllowance(snap *core.UsageSnapshot, freeRequests int, now time.Time) {
+	limit := float64(freeModelDailyLimitLow)
+	if m, ok := snap.Metrics["credit_balance"]; ok && m.Limit != nil && *m.Limit >= freeModelCreditsForHigh {
+		limit = freeModelDailyLimitHigh
+	}
+	used := float64(freeRequests)
+	remaining := max(limit-used, 0)
+	snap.Metrics["free_rpd"] = core.Metric{Limit: &limit, Used: &used, Remaining: &remaining, Unit: "requests", Window: "1d"}
+	snap.Resets["free_rpd_reset"] = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+}
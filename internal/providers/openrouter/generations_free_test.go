@@ -0,0 +1,31 @@
+package openrouter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestApplyFreeModelAllowance(t *testing.T) {
+	now := time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+
+	snap := &core.UsageSnapshot{Metrics: map[string]core.Metric{}, Resets: map[string]time.Time{}}
+	applyFreeModelAllowance(snap, 13, now)
+	m := snap.Metrics["free_rpd"]
+	if m.Limit == nil || *m.Limit != freeModelDailyLimitLow || m.Remaining == nil || *m.Remaining != 37 {
+		t.Fatalf("free_rpd = %+v, want 37 of the %d low cap left", m, freeModelDailyLimitLow)
+	}
+	if want := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC); !snap.Resets["free_rpd_reset"].Equal(want) {
+		t.Errorf("reset = %s, want %s", snap.Resets["free_rpd_reset"], want)
+	}
+
+	// Accounts that bought enough credits get the higher cap.
+	limit := 25.0
+	snap = &core.UsageSnapshot{Metrics: map[string]core.Metric{"credit_balance": {Limit: &limit}}, Resets: map[string]time.Time{}}
+	applyFreeModelAllowance(snap, 2000, now)
+	m = snap.Metrics["free_rpd"]
+	if *m.Limit != freeModelDailyLimitHigh || *m.Remaining != 0 {
+		t.Errorf("free_rpd = limit %v remaining %v, want %d and 0", *m.Limit, *m.Remaining, freeModelDailyLimitHigh)
+	}
+}
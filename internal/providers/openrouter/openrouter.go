@@ -21,10 +21,20 @@ const (
 	// Keep enrichment bounded: only a subset of ambiguous rows are upgraded
 	// via /generation?id=<id> to recover upstream hosting providers.
 	maxGenerationProviderDetailLookups = 20
+	generationDetailTTL                = 24 * time.Hour
+	maxCachedGenerationDetails         = 4096
 )
 
 var errGenerationListUnsupported = errors.New("generation list endpoint unsupported")
 
+// generationListDeprecation is shown when /generation rejects listing
+// without an ID, which OpenRouter has done since retiring the list form.
+var generationListDeprecation = core.Deprecation{
+	Feature:   "generation_list",
+	Message:   "OpenRouter no longer lists generations; today's per-request stats are unavailable",
+	Migration: "Spend, per-model and per-provider totals still come from activity analytics. Finish reasons, origins and routers need a release that reads them elsewhere (openusage update).",
+}
+
 type keyResponse struct {
 	Data keyData `json:"data"`
 }
@@ -203,6 +213,10 @@ type endpointStats struct {
 type Provider struct {
 	providerbase.Base
 	clock core.Clock
+	// generationDetails caches /generation?id= lookups across accounts and
+	// polls: a finished generation never changes, and the same generations
+	// show up for a key and for the management key of its account.
+	generationDetails *shared.SharedCache[generationEntry]
 }
 
 func New() *Provider {
@@ -226,8 +240,34 @@ func New() *Provider {
 			CreditMetrics: map[string]core.BalanceSemantics{
 				"credit_balance": core.BalanceCumulative,
 			},
+			Actions: []core.ProviderAction{
+				{Label: "Open credits page", Kind: core.ProviderActionOpenURL, Target: "https://openrouter.ai/settings/credits"},
+				{Label: "Open status page", Kind: core.ProviderActionOpenURL, Target: "https://status.openrouter.ai"},
+				{Label: "Copy API base URL", Kind: core.ProviderActionCopy, Target: defaultBaseURL, SnapshotKey: "api_base_url"},
+				{Label: "Set key spend limit", Kind: core.ProviderActionSetSpendLimit, Target: "credits"},
+			},
+			Reference: core.ProviderReference{
+				TierKey: "tier",
+				Tiers: []core.ProviderTier{
+					{Name: "Free", Match: "free", Qualification: "no credits purchased; free models capped at 50 requests a day"},
+					{Name: "Paid", Match: "paid", Qualification: "$10+ in credits purchased; free models capped at 1,000 requests a day"},
+				},
+				Windows: []string{
+					"Free models (\":free\" variants) allow 20 requests per minute on every tier.",
+					"Daily free-model caps reset at midnight UTC.",
+					"Paid models have no fixed request cap beyond the key's own rate limit and credit limit.",
+				},
+				Links: []core.ProviderLink{
+					{Label: "Rate limits", URL: "https://openrouter.ai/docs/api-reference/limits"},
+					{Label: "Credits", URL: "https://openrouter.ai/settings/credits"},
+				},
+			},
+			Options: []core.ProviderOption{
+				{Key: "include_byok", Type: core.ProviderOptionBool, Default: "true", Description: "Show bring-your-own-key usage. Turn off to hide BYOK spend that is billed by the upstream provider."},
+			},
 		}),
-		clock: core.SystemClock{},
+		clock:             core.SystemClock{},
+		generationDetails: shared.NewSharedCache[generationEntry](generationDetailTTL, maxCachedGenerationDetails),
 	}
 }
 
@@ -286,6 +326,9 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 	if err := p.fetchGenerationStats(ctx, baseURL, apiKey, &snap); err != nil {
 		snap.Raw["generation_error"] = err.Error()
 	}
+	if !acct.OptionBool("include_byok", true) {
+		dropBYOKUsage(&snap)
+	}
 	enrichDashboardRepresentations(&snap)
 
 	return snap, nil
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -346,6 +347,7 @@ func TestResolveGenerationHostingProvider_UsesAlternativeEntryFields(t *testing.
 
 func TestFetch_GenerationProviderDetailEnrichmentForGenericProviderLabel(t *testing.T) {
 	now := time.Now().UTC().Format(time.RFC3339)
+	var detailRequests atomic.Int32
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -362,6 +364,7 @@ func TestFetch_GenerationProviderDetailEnrichmentForGenericProviderLabel(t *test
 			w.Write([]byte(`{"data":[]}`))
 		case "/generation":
 			if r.URL.Query().Get("id") == "gen-1" {
+				detailRequests.Add(1)
 				w.WriteHeader(http.StatusOK)
 				w.Write([]byte(`{"data":{
 					"id":"gen-1",
@@ -423,6 +426,22 @@ func TestFetch_GenerationProviderDetailEnrichmentForGenericProviderLabel(t *test
 	if _, ok := snap.Metrics["provider_openusage_requests"]; ok {
 		t.Fatal("provider_openusage_requests should not be emitted after detail enrichment")
 	}
+
+	// A second account on the same provider reuses the cached detail.
+	acct.ID = "test-gen-detail-enrich-2"
+	snap, err = p.Fetch(context.Background(), acct)
+	if err != nil {
+		t.Fatalf("second Fetch() error: %v", err)
+	}
+	if got := snap.Raw["generation_provider_detail_lookups"]; got != "0" {
+		t.Fatalf("second generation_provider_detail_lookups = %q, want 0", got)
+	}
+	if got := snap.Raw["provider_novita_requests"]; got != "1" {
+		t.Fatalf("second provider_novita_requests = %q, want 1", got)
+	}
+	if got := detailRequests.Load(); got != 1 {
+		t.Fatalf("generation detail requests = %d, want 1", got)
+	}
 }
 
 func TestFetch_GenerationExtendedMetrics(t *testing.T) {
@@ -949,8 +968,8 @@ func TestFetch_GenerationListUnsupported_Graceful(t *testing.T) {
 		t.Fatalf("Fetch() error: %v", err)
 	}
 
-	if got := snap.Raw["generation_note"]; got == "" {
-		t.Fatal("missing generation_note for unsupported generation listing")
+	if got := snap.Deprecations(); len(got) != 1 || got[0].Feature != "generation_list" || got[0].Migration == "" {
+		t.Fatalf("deprecations = %+v, want the generation_list notice with a migration hint", got)
 	}
 	if got := snap.Raw["generations_fetched"]; got != "0" {
 		t.Fatalf("generations_fetched = %q, want 0", got)
@@ -0,0 +1,70 @@
+package openrouter
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/fixtures/fixturetest"
+)
+
+// TestFetch_RecordedCassette replays a sanitized capture of a regular
+// (non-management) key. Figures are fuzzed at record time, so the test checks
+// the relationships the parsers must preserve rather than exact amounts.
+// Re-record with `openusage export --output - --record-fixtures DIR`.
+func TestFetch_RecordedCassette(t *testing.T) {
+	srv := fixturetest.Serve(t, "testdata/cassette_openrouter.json", fixturetest.ShiftDatesTo(time.Now()))
+	t.Setenv("TEST_OR_KEY_CASSETTE", "test-key")
+
+	snap, err := New().Fetch(context.Background(), core.AccountConfig{
+		ID:        "openrouter",
+		Provider:  "openrouter",
+		APIKeyEnv: "TEST_OR_KEY_CASSETTE",
+		BaseURL:   srv.URL + "/api/v1",
+	})
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if snap.Status != core.StatusOK {
+		t.Fatalf("Status = %v (%s), want OK", snap.Status, snap.Message)
+	}
+	for _, key := range []string{"analytics_error", "generation_error", "credits_detail_error"} {
+		if msg := snap.Raw[key]; msg != "" {
+			t.Errorf("%s = %q", key, msg)
+		}
+	}
+
+	credits := snap.Metrics["credit_balance"]
+	if credits.Limit == nil || credits.Used == nil || credits.Remaining == nil {
+		t.Fatalf("credit_balance = %+v, want limit/used/remaining", credits)
+	}
+	if math.Abs(*credits.Limit-*credits.Used-*credits.Remaining) > 1e-6 {
+		t.Errorf("credit_balance remaining = %v, want limit-used = %v", *credits.Remaining, *credits.Limit-*credits.Used)
+	}
+
+	if got := snap.Raw["activity_endpoint"]; got != "/activity" {
+		t.Errorf("activity_endpoint = %q, want /activity", got)
+	}
+	if got := snap.Raw["activity_rows"]; got != "4" {
+		t.Errorf("activity_rows = %q, want 4", got)
+	}
+	today := time.Now().UTC().Format("2006-01-02")
+	if got := seriesValueByDate(snap.DailySeries["analytics_requests"], today); got <= 0 {
+		t.Errorf("analytics_requests[%s] = %v, want today's recorded requests", today, got)
+	}
+	for _, key := range []string{
+		"model_anthropic_claude-sonnet-4.5_cost_usd",
+		"model_openai_gpt-5-mini_cost_usd",
+		"analytics_7d_cost",
+		"analytics_30d_cost",
+	} {
+		if m, ok := snap.Metrics[key]; !ok || m.Used == nil || *m.Used <= 0 {
+			t.Errorf("%s = %+v, want a positive amount", key, m)
+		}
+	}
+	if d7, d30 := snap.Metrics["analytics_7d_cost"], snap.Metrics["analytics_30d_cost"]; d7.Used != nil && d30.Used != nil && *d7.Used > *d30.Used {
+		t.Errorf("7d cost %v exceeds 30d cost %v", *d7.Used, *d30.Used)
+	}
+}
@@ -281,6 +281,10 @@ func TestFetch_PerModelBreakdown(t *testing.T) {
 		t.Errorf("today_cost = %v, want ~%v", todayCost.Used, expectedCost)
 	}
 
+	if savings := snap.Metrics[core.CacheSavingsMetricKey]; savings.Used == nil || *savings.Used != 0.001 || savings.Window != "30d" {
+		t.Errorf("cache_savings_usd = %+v, want 0.001 over 30d", savings)
+	}
+
 	todayLatency, ok := snap.Metrics["today_avg_latency"]
 	if !ok {
 		t.Fatal("missing today_avg_latency metric")
@@ -615,3 +619,33 @@ func TestFetch_FreeTier(t *testing.T) {
 		t.Errorf("message = %q, want to contain $0.0000", snap.Message)
 	}
 }
+
+func TestDropBYOKUsage(t *testing.T) {
+	snap := core.NewUsageSnapshot("openrouter", "or")
+	snap.Metrics["byok_daily"] = core.Metric{Used: core.Float64Ptr(1), Unit: "USD"}
+	snap.Metrics["model_gpt-4o_byok_cost"] = core.Metric{Used: core.Float64Ptr(2), Unit: "USD"}
+	snap.Metrics["credits"] = core.Metric{Used: core.Float64Ptr(3), Unit: "USD"}
+	snap.Raw["byok_in_use"] = "true"
+	snap.Raw["include_byok_in_limit"] = "false"
+	snap.DailySeries = map[string][]core.TimePoint{"analytics_byok_cost": {{Date: "2026-06-01", Value: 1}}}
+
+	dropBYOKUsage(&snap)
+
+	for _, key := range []string{"byok_daily", "model_gpt-4o_byok_cost"} {
+		if _, ok := snap.Metrics[key]; ok {
+			t.Errorf("metric %s should be dropped", key)
+		}
+	}
+	if _, ok := snap.Metrics["credits"]; !ok {
+		t.Error("non-BYOK metric dropped")
+	}
+	if _, ok := snap.Raw["byok_in_use"]; ok {
+		t.Error("byok_in_use should be dropped")
+	}
+	if snap.Raw["include_byok_in_limit"] != "false" {
+		t.Error("include_byok_in_limit is key metadata and should stay")
+	}
+	if _, ok := snap.DailySeries["analytics_byok_cost"]; ok {
+		t.Error("BYOK daily series should be dropped")
+	}
+}
@@ -193,6 +193,30 @@ type modelUsageCount struct {
 	count float64
 }
 
+// dropBYOKUsage removes bring-your-own-key spend and request counts for
+// accounts that set include_byok=false. The key's include_byok_in_limit
+// setting is account metadata, not usage, and stays.
+func dropBYOKUsage(snap *core.UsageSnapshot) {
+	isBYOK := func(key string) bool {
+		return strings.Contains(key, "byok") && key != "include_byok_in_limit"
+	}
+	for key := range snap.Metrics {
+		if isBYOK(key) {
+			delete(snap.Metrics, key)
+		}
+	}
+	for key := range snap.Raw {
+		if isBYOK(key) {
+			delete(snap.Raw, key)
+		}
+	}
+	for key := range snap.DailySeries {
+		if isBYOK(key) {
+			delete(snap.DailySeries, key)
+		}
+	}
+}
+
 func enrichDashboardRepresentations(snap *core.UsageSnapshot) {
 	if snap == nil || len(snap.Metrics) == 0 {
 		return
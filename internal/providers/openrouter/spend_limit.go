@@ -0,0 +1,165 @@
+package openrouter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
+)
+
+// managementKeyEnv holds an OpenRouter management key used to change the
+// limit of the account's (inference) key. Without it the account key itself
+// must be a management key.
+const managementKeyEnv = "OPENROUTER_MANAGEMENT_KEY"
+
+// SetSpendLimit changes the credit limit of the account's API key via
+// PATCH /keys/{hash}. A nil limit removes it.
+func (p *Provider) SetSpendLimit(ctx context.Context, acct core.AccountConfig, limitUSD *float64) error {
+	if limitUSD != nil && *limitUSD < 0 {
+		return fmt.Errorf("openrouter: limit must not be negative")
+	}
+	target, err := p.spendLimitKey(ctx, acct)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(struct {
+		Limit *float64 `json:"limit"`
+	}{Limit: limitUSD})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, target.baseURL+"/keys/"+url.PathEscape(target.key.Hash), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("openrouter: creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+target.managementKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.Client().Do(req)
+	if err != nil {
+		return fmt.Errorf("openrouter: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("openrouter: HTTP %d – changing key limits needs a management key (%s)", resp.StatusCode, managementKeyEnv)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("openrouter: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+}
+
+// SpendLimitTarget names the key SetSpendLimit would change, as
+// "<name> · <hash prefix>".
+func (p *Provider) SpendLimitTarget(ctx context.Context, acct core.AccountConfig) (string, error) {
+	target, err := p.spendLimitKey(ctx, acct)
+	if err != nil {
+		return "", err
+	}
+	name := target.key.Name
+	if name == "" {
+		name = target.key.Label
+	}
+	hash := target.key.Hash
+	if len(hash) > 12 {
+		hash = hash[:12]
+	}
+	return name + " · " + hash, nil
+}
+
+// spendLimitKey is the /keys entry whose limit a change applies to, and how
+// to reach it.
+type spendLimitKey struct {
+	key           keyListEntry
+	baseURL       string
+	managementKey string
+}
+
+// spendLimitKey finds the account key in the /keys list. /keys only
+// carries the key's truncated label, so a label shared by several keys is
+// refused rather than guessed.
+func (p *Provider) spendLimitKey(ctx context.Context, acct core.AccountConfig) (spendLimitKey, error) {
+	apiKey := acct.ResolveAPIKey()
+	if apiKey == "" {
+		return spendLimitKey{}, fmt.Errorf("openrouter: no API key configured")
+	}
+	managementKey := strings.TrimSpace(os.Getenv(managementKeyEnv))
+	if managementKey == "" {
+		managementKey = apiKey
+	}
+	baseURL := shared.ResolveBaseURL(acct, defaultBaseURL)
+
+	current, err := p.currentKey(ctx, baseURL, apiKey)
+	if err != nil {
+		return spendLimitKey{}, err
+	}
+	label := current.Label
+	if label == "" {
+		return spendLimitKey{}, fmt.Errorf("openrouter: the API key has no label to look it up by")
+	}
+
+	keys, err := p.listKeys(ctx, baseURL, managementKey)
+	if errors.Is(err, errKeysForbidden) {
+		return spendLimitKey{}, fmt.Errorf("%w; set %s", err, managementKeyEnv)
+	}
+	if err != nil {
+		return spendLimitKey{}, fmt.Errorf("openrouter: listing keys: %w", err)
+	}
+	var matches []keyListEntry
+	for _, key := range keys {
+		if key.Label == label {
+			matches = append(matches, key)
+		}
+	}
+	switch {
+	case len(matches) == 0 || matches[0].Hash == "":
+		return spendLimitKey{}, fmt.Errorf("openrouter: key %q not found in the keys list", label)
+	case len(matches) > 1:
+		return spendLimitKey{}, fmt.Errorf("openrouter: %d keys share the label %q; change the limit on openrouter.ai", len(matches), label)
+	}
+	return spendLimitKey{key: matches[0], baseURL: baseURL, managementKey: managementKey}, nil
+}
+
+// currentKey reads the account key's own metadata, trying /key before the
+// legacy /auth/key like fetchAuthKey.
+func (p *Provider) currentKey(ctx context.Context, baseURL, apiKey string) (keyData, error) {
+	for _, endpoint := range []string{"/key", "/auth/key"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+endpoint, nil)
+		if err != nil {
+			return keyData{}, fmt.Errorf("openrouter: creating request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+
+		resp, err := p.Client().Do(req)
+		if err != nil {
+			return keyData{}, fmt.Errorf("openrouter: request failed: %w", err)
+		}
+		if resp.StatusCode == http.StatusNotFound && endpoint == "/key" {
+			resp.Body.Close()
+			continue
+		}
+		var keyResp keyResponse
+		err = json.NewDecoder(resp.Body).Decode(&keyResp)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return keyData{}, fmt.Errorf("openrouter: reading current key: HTTP %d", resp.StatusCode)
+		}
+		if err != nil {
+			return keyData{}, fmt.Errorf("openrouter: parsing key response: %w", err)
+		}
+		return keyResp.Data, nil
+	}
+	return keyData{}, fmt.Errorf("openrouter: key endpoint not available (HTTP 404)")
+}
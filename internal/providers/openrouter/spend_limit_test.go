@@ -0,0 +1,118 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestSetSpendLimit_PatchesCurrentKey(t *testing.T) {
+	var patched map[string]any
+	var patchAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/key":
+			w.Write([]byte(`{"data": {"label": "sk-or-v1-abc...xyz", "usage": 12.5, "limit": 50}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/keys":
+			if r.Header.Get("Authorization") != "Bearer mgmt-key" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Write([]byte(`{"data": [
+				{"hash": "other", "label": "sk-or-v1-zzz...zzz"},
+				{"hash": "abc123", "name": "Primary", "label": "sk-or-v1-abc...xyz"}
+			]}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/keys/abc123":
+			patchAuth = r.Header.Get("Authorization")
+			if err := json.NewDecoder(r.Body).Decode(&patched); err != nil {
+				t.Errorf("decode patch body: %v", err)
+			}
+			w.Write([]byte(`{"data": {}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv(managementKeyEnv, "mgmt-key")
+	acct := core.AccountConfig{ID: "openrouter", Provider: "openrouter", Token: "inference-key", BaseURL: server.URL}
+	if got, err := New().SpendLimitTarget(context.Background(), acct); err != nil || got != "Primary · abc123" {
+		t.Fatalf("SpendLimitTarget() = %q, %v; want the matched key's name and hash", got, err)
+	}
+	limit := 20.0
+	if err := New().SetSpendLimit(context.Background(), acct, &limit); err != nil {
+		t.Fatalf("SetSpendLimit() error = %v", err)
+	}
+	if patchAuth != "Bearer mgmt-key" {
+		t.Fatalf("PATCH authorization = %q, want the management key", patchAuth)
+	}
+	if patched["limit"] != 20.0 {
+		t.Fatalf("PATCH body = %v, want limit 20", patched)
+	}
+
+	if err := New().SetSpendLimit(context.Background(), acct, nil); err != nil {
+		t.Fatalf("SetSpendLimit(nil) error = %v", err)
+	}
+	if v, ok := patched["limit"]; !ok || v != nil {
+		t.Fatalf("PATCH body = %v, want limit null to remove it", patched)
+	}
+}
+
+func TestSetSpendLimit_NeedsManagementKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/key":
+			w.Write([]byte(`{"data": {"label": "sk-or-v1-abc...xyz"}}`))
+		case "/keys":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv(managementKeyEnv, "")
+	acct := core.AccountConfig{ID: "openrouter", Provider: "openrouter", Token: "inference-key", BaseURL: server.URL}
+	limit := 5.0
+	err := New().SetSpendLimit(context.Background(), acct, &limit)
+	if err == nil || !strings.Contains(err.Error(), managementKeyEnv) {
+		t.Fatalf("SetSpendLimit() error = %v, want a hint to set %s", err, managementKeyEnv)
+	}
+}
+
+func TestSetSpendLimit_RefusesAmbiguousLabel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/key":
+			w.Write([]byte(`{"data": {"label": "sk-or-v1-abc...xyz"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/keys":
+			w.Write([]byte(`{"data": [
+				{"hash": "abc123", "name": "Primary", "label": "sk-or-v1-abc...xyz"},
+				{"hash": "abc999", "name": "CI", "label": "sk-or-v1-abc...xyz"}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv(managementKeyEnv, "mgmt-key")
+	acct := core.AccountConfig{ID: "openrouter", Provider: "openrouter", Token: "inference-key", BaseURL: server.URL}
+	limit := 5.0
+	if err := New().SetSpendLimit(context.Background(), acct, &limit); err == nil || !strings.Contains(err.Error(), "2 keys share the label") {
+		t.Fatalf("SetSpendLimit() error = %v, want a refusal for the shared label", err)
+	}
+	if _, err := New().SpendLimitTarget(context.Background(), acct); err == nil {
+		t.Fatal("SpendLimitTarget() should refuse a shared label too")
+	}
+}
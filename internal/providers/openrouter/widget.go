@@ -21,7 +21,7 @@ func dashboardWidget() core.DashboardWidget {
 		providerbase.WithCompactRows(
 			core.DashboardCompactRow{Label: "Credits", Keys: []string{"credit_balance", "usage_daily", "usage_weekly", "usage_monthly", "limit_remaining"}, MaxSegments: 5},
 			core.DashboardCompactRow{Label: "Spend", Keys: []string{"today_cost", "7d_api_cost", "30d_api_cost", "today_byok_cost", "7d_byok_cost", "30d_byok_cost"}, MaxSegments: 5},
-			core.DashboardCompactRow{Label: "Activity", Keys: []string{"today_requests", "analytics_7d_requests", "analytics_30d_requests", "recent_requests", "keys_active", "keys_disabled"}, MaxSegments: 6},
+			core.DashboardCompactRow{Label: "Activity", Keys: []string{"today_requests", "free_rpd", "analytics_7d_requests", "analytics_30d_requests", "recent_requests", "keys_active", "keys_disabled"}, MaxSegments: 6},
 			core.DashboardCompactRow{Label: "Tokens", Keys: []string{"today_input_tokens", "today_output_tokens", "today_reasoning_tokens", "today_cached_tokens", "analytics_7d_tokens"}, MaxSegments: 5},
 			core.DashboardCompactRow{Label: "Perf", Keys: []string{"today_avg_latency", "today_avg_generation_time", "today_avg_moderation_latency", "today_streamed_percent", "burn_rate"}, MaxSegments: 5},
 		),
@@ -75,7 +75,7 @@ func dashboardWidget() core.DashboardWidget {
 			core.DashboardRawGroup{
 				Label: "Generation",
 				Keys: []string{
-					"generation_note", "today_finish_reasons", "today_origins", "today_routers", "generations_fetched",
+					"today_finish_reasons", "today_origins", "today_routers", "generations_fetched",
 					"generation_provider_detail_lookups", "generation_provider_detail_hits", "provider_resolution",
 				},
 			},
@@ -130,9 +130,11 @@ func dashboardWidget() core.DashboardWidget {
 			"daily_projected":                "Daily Projected",
 			"limit_remaining":                "Limit Remaining",
 			"recent_requests":                "Recent Requests",
+			"free_rpd":                       "Free Model Requests",
 		}),
 		providerbase.WithCompactLabels(map[string]string{
 			"today_cost":                   "today",
+			"free_rpd":                     "free",
 			"7d_api_cost":                  "7d",
 			"30d_api_cost":                 "30d",
 			"today_byok_cost":              "today byok",
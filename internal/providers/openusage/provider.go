@@ -0,0 +1,139 @@
+// Package openusage implements the engine self-observability tile: a
+// provider whose "usage" is the poll engine's own health — accounts polled,
+// fetch error rate and latency, read-model cache hit rate, memory, and the
+// pricing catalog and custom providers it runs with. The
+// numbers come from core.Engine, which the daemon records into, so the tile
+// is meaningful where the engine runs long-lived.
+package openusage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/providerbase"
+)
+
+// ID is the canonical provider identifier.
+const ID = core.EngineProviderID
+
+// errorRateNearLimit is the recent fetch error rate from which the tile
+// reports the engine as degraded.
+const errorRateNearLimit = 0.5
+
+// Provider reports core.Engine as a usage snapshot.
+type Provider struct {
+	providerbase.Base
+	stats *core.EngineStats
+	clock core.Clock
+}
+
+// New constructs the provider over the process-wide engine stats.
+func New() *Provider {
+	return &Provider{
+		Base: providerbase.New(core.ProviderSpec{
+			ID: ID,
+			Info: core.ProviderInfo{
+				Name:         "OpenUsage engine",
+				Capabilities: []string{"self_observability"},
+				DocURL:       "https://github.com/janekbaraniewski/openusage",
+			},
+			Auth: core.ProviderAuthSpec{
+				Type:             core.ProviderAuthTypeLocal,
+				DefaultAccountID: ID,
+			},
+			Setup: core.ProviderSetupSpec{
+				Quickstart: []string{
+					`Add {"id": "openusage", "provider": "openusage"} to "accounts" in settings.json.`,
+					"The tile reports the daemon that polls it: run `openusage telemetry daemon install` for meaningful numbers.",
+				},
+			},
+			Dashboard: dashboardWidget(),
+		}),
+		stats: core.Engine,
+		clock: core.SystemClock{},
+	}
+}
+
+// Fetch snapshots the engine stats. It never fails: an engine that has not
+// polled yet reports StatusUnknown.
+func (p *Provider) Fetch(_ context.Context, acct core.AccountConfig) (core.UsageSnapshot, error) {
+	now := p.clock.Now()
+	stats := p.stats.Snapshot()
+
+	snap := core.NewUsageSnapshot(p.ID(), acct.ID)
+	snap.Timestamp = now
+	snap.Status = core.StatusOK
+
+	set := func(key string, v float64, unit, window string) {
+		snap.Metrics[key] = core.Metric{Used: core.Float64Ptr(v), Unit: unit, Window: window}
+	}
+	set("accounts_polled", float64(stats.AccountsPolled), "accounts", "poll")
+	set("poll_cycles", float64(stats.PollCycles), "polls", "uptime")
+	set("fetches_total", float64(stats.Fetches), "fetches", "uptime")
+	if stats.RecentFetches > 0 {
+		snap.Metrics["fetch_errors"] = core.Metric{
+			Used:   core.Float64Ptr(float64(stats.RecentErrors)),
+			Limit:  core.Float64Ptr(float64(stats.RecentFetches)),
+			Unit:   "fetches",
+			Window: "recent",
+		}
+		set("fetch_error_rate", round1(stats.ErrorRate()*100), "%", "recent")
+		set("fetch_latency_avg_ms", float64(stats.AvgLatency.Milliseconds()), "ms", "recent")
+		set("fetch_latency_max_ms", float64(stats.MaxLatency.Milliseconds()), "ms", "recent")
+	}
+	if stats.CacheLookups > 0 {
+		set("cache_hit_rate", round1(stats.CacheHitRate()*100), "%", "uptime")
+	}
+	set("memory_heap_mb", round1(float64(stats.HeapBytes)/(1<<20)), "MB", "now")
+	set("memory_sys_mb", round1(float64(stats.SysBytes)/(1<<20)), "MB", "now")
+	set("goroutines", float64(stats.Goroutines), "count", "now")
+
+	catalog := stats.Catalog
+	if catalog.PricingVersion != "" {
+		set("pricing_models", float64(catalog.PricingModels), "models", "now")
+		set("pricing_overrides", float64(catalog.PricingOverrides), "models", "now")
+		snap.SetAttribute("pricing_catalog_version", catalog.PricingVersion)
+		if !catalog.PricingUpdatedAt.IsZero() {
+			snap.SetAttribute("pricing_catalog_updated_at", catalog.PricingUpdatedAt.UTC().Format(time.RFC3339))
+		}
+	}
+	set("custom_providers", float64(catalog.CustomProviders), "providers", "now")
+	set("catalog_reloads", float64(stats.CatalogReloads), "reloads", "uptime")
+	if !stats.CatalogReloadedAt.IsZero() {
+		snap.SetAttribute("catalog_reloaded_at", stats.CatalogReloadedAt.UTC().Format(time.RFC3339))
+	}
+
+	uptime := now.Sub(stats.StartedAt).Round(time.Second)
+	snap.SetAttribute("engine_started_at", stats.StartedAt.UTC().Format(time.RFC3339))
+	snap.SetAttribute("engine_uptime", uptime.String())
+	snap.SetAttribute("go_version", runtime.Version())
+	if !stats.LastPollAt.IsZero() {
+		snap.SetAttribute("last_poll_at", stats.LastPollAt.UTC().Format(time.RFC3339))
+	}
+
+	switch {
+	case stats.PollCycles == 0:
+		snap.Status = core.StatusUnknown
+		snap.Message = "No poll cycle recorded in this process yet"
+	case stats.ErrorRate() >= errorRateNearLimit:
+		snap.Status = core.StatusNearLimit
+		snap.Message = fmt.Sprintf("%d of the last %d fetches failed", stats.RecentErrors, stats.RecentFetches)
+	default:
+		snap.Message = fmt.Sprintf("%d accounts · %.0f%% fetch errors · up %s", stats.AccountsPolled, stats.ErrorRate()*100, uptime)
+		if catalog.PricingVersion != "" {
+			snap.Message += " · pricing " + catalog.PricingVersion
+			if !catalog.PricingUpdatedAt.IsZero() {
+				snap.Message += " (" + catalog.PricingUpdatedAt.UTC().Format("2006-01-02") + ")"
+			}
+		}
+	}
+	return snap, nil
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,123 @@
+package openusage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+type fixedClock struct{ t time.Time }
+
+func (f fixedClock) Now() time.Time { return f.t }
+
+func newTestProvider(stats *core.EngineStats, now time.Time) *Provider {
+	p := New()
+	p.stats = stats
+	p.clock = fixedClock{t: now}
+	return p
+}
+
+func TestFetch_ReportsEngineStats(t *testing.T) {
+	started := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	now := started.Add(90 * time.Minute)
+	stats := core.NewEngineStats(started)
+	stats.RecordPoll(12, now.Add(-time.Minute))
+	for i := range 10 {
+		stats.RecordFetch(time.Duration(100*(i+1))*time.Millisecond, i == 0)
+	}
+	stats.RecordCacheLookup(true)
+	stats.RecordCacheLookup(false)
+
+	snap, err := newTestProvider(stats, now).Fetch(context.Background(), core.AccountConfig{ID: "engine", Provider: ID})
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if snap.Status != core.StatusOK || snap.AccountID != "engine" {
+		t.Fatalf("status %s account %q", snap.Status, snap.AccountID)
+	}
+	want := map[string]float64{
+		"accounts_polled":      12,
+		"poll_cycles":          1,
+		"fetches_total":        10,
+		"fetch_errors":         1,
+		"fetch_error_rate":     10,
+		"fetch_latency_avg_ms": 550,
+		"fetch_latency_max_ms": 1000,
+		"cache_hit_rate":       50,
+	}
+	for key, v := range want {
+		m, ok := snap.Metrics[key]
+		if !ok || m.Used == nil || *m.Used != v {
+			t.Errorf("%s = %+v, want %v", key, m.Used, v)
+		}
+	}
+	if m := snap.Metrics["fetch_errors"]; m.Limit == nil || *m.Limit != 10 {
+		t.Errorf("fetch_errors limit = %v, want 10 recent fetches", m.Limit)
+	}
+	for _, key := range []string{"memory_heap_mb", "memory_sys_mb", "goroutines"} {
+		if m, ok := snap.Metrics[key]; !ok || m.Used == nil || *m.Used <= 0 {
+			t.Errorf("%s missing or zero", key)
+		}
+	}
+	if got := snap.Attributes["engine_uptime"]; got != "1h30m0s" {
+		t.Errorf("engine_uptime = %q, want 1h30m0s", got)
+	}
+	if !strings.Contains(snap.Message, "12 accounts") {
+		t.Errorf("message %q should mention the polled accounts", snap.Message)
+	}
+}
+
+func TestFetch_Status(t *testing.T) {
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+
+	idle := core.NewEngineStats(now)
+	snap, _ := newTestProvider(idle, now).Fetch(context.Background(), core.AccountConfig{ID: ID})
+	if snap.Status != core.StatusUnknown {
+		t.Errorf("idle engine status = %s, want %s", snap.Status, core.StatusUnknown)
+	}
+	if _, ok := snap.Metrics["fetch_error_rate"]; ok {
+		t.Error("no fetch metrics expected before the first fetch")
+	}
+
+	failing := core.NewEngineStats(now)
+	failing.RecordPoll(2, now)
+	failing.RecordFetch(time.Second, true)
+	failing.RecordFetch(time.Second, false)
+	snap, _ = newTestProvider(failing, now).Fetch(context.Background(), core.AccountConfig{ID: ID})
+	if snap.Status != core.StatusNearLimit {
+		t.Errorf("failing engine status = %s, want %s", snap.Status, core.StatusNearLimit)
+	}
+}
+
+func TestFetch_ReportsCatalog(t *testing.T) {
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	stats := core.NewEngineStats(now.Add(-time.Hour))
+	stats.RecordPoll(3, now)
+	stats.RecordCatalog(core.EngineCatalog{
+		PricingVersion:   "1a2b3c4d",
+		PricingUpdatedAt: time.Date(2026, 10, 15, 22, 0, 0, 0, time.UTC),
+		PricingModels:    2400,
+		PricingOverrides: 2,
+		CustomProviders:  1,
+	})
+	stats.RecordCatalogReload(now.Add(-5 * time.Minute))
+
+	snap, _ := newTestProvider(stats, now).Fetch(context.Background(), core.AccountConfig{ID: ID})
+	for key, want := range map[string]float64{"pricing_models": 2400, "pricing_overrides": 2, "custom_providers": 1, "catalog_reloads": 1} {
+		if m := snap.Metrics[key]; m.Used == nil || *m.Used != want {
+			t.Errorf("%s = %v, want %v", key, m.Used, want)
+		}
+	}
+	if got := snap.Attributes["pricing_catalog_version"]; got != "1a2b3c4d" {
+		t.Errorf("pricing_catalog_version = %q", got)
+	}
+	if got := snap.Attributes["catalog_reloaded_at"]; got != "2026-10-16T07:55:00Z" {
+		t.Errorf("catalog_reloaded_at = %q", got)
+	}
+	if !strings.Contains(snap.Message, "pricing 1a2b3c4d (2026-10-15)") {
+		t.Errorf("message %q should name the pricing catalog version and date", snap.Message)
+	}
+}
@@ -0,0 +1,72 @@
+package openusage
+
+import (
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/providerbase"
+)
+
+func dashboardWidget() core.DashboardWidget {
+	return providerbase.DefaultDashboard(
+		providerbase.WithColorRole(core.DashboardColorRoleLavender),
+		providerbase.WithGaugePriority("fetch_errors"),
+		providerbase.WithCompactRows(
+			core.DashboardCompactRow{
+				Label:       "Polling",
+				Keys:        []string{"accounts_polled", "poll_cycles", "fetches_total"},
+				MaxSegments: 3,
+			},
+			core.DashboardCompactRow{
+				Label:       "Fetches",
+				Keys:        []string{"fetch_error_rate", "fetch_latency_avg_ms", "fetch_latency_max_ms"},
+				MaxSegments: 3,
+			},
+			core.DashboardCompactRow{
+				Label:       "Cache",
+				Keys:        []string{"cache_hit_rate"},
+				MaxSegments: 1,
+			},
+			core.DashboardCompactRow{
+				Label:       "Catalogs",
+				Keys:        []string{"pricing_models", "custom_providers", "catalog_reloads"},
+				MaxSegments: 3,
+			},
+			core.DashboardCompactRow{
+				Label:       "Memory",
+				Keys:        []string{"memory_heap_mb", "memory_sys_mb", "goroutines"},
+				MaxSegments: 3,
+			},
+		),
+		providerbase.WithMetricLabels(map[string]string{
+			"accounts_polled":      "Accounts Polled",
+			"poll_cycles":          "Poll Cycles",
+			"fetches_total":        "Fetches",
+			"fetch_errors":         "Recent Fetch Errors",
+			"fetch_error_rate":     "Fetch Error Rate",
+			"fetch_latency_avg_ms": "Avg Fetch Latency",
+			"fetch_latency_max_ms": "Max Fetch Latency",
+			"cache_hit_rate":       "Cache Hit Rate",
+			"memory_heap_mb":       "Heap",
+			"memory_sys_mb":        "Memory (sys)",
+			"goroutines":           "Goroutines",
+			"pricing_models":       "Priced Models",
+			"pricing_overrides":    "Custom Prices",
+			"custom_providers":     "Custom Providers",
+			"catalog_reloads":      "Catalog Reloads",
+		}),
+		providerbase.WithCompactLabels(map[string]string{
+			"accounts_polled":      "accounts",
+			"poll_cycles":          "polls",
+			"fetches_total":        "fetches",
+			"fetch_error_rate":     "errors",
+			"fetch_latency_avg_ms": "avg",
+			"fetch_latency_max_ms": "max",
+			"cache_hit_rate":       "hits",
+			"memory_heap_mb":       "heap",
+			"memory_sys_mb":        "sys",
+			"goroutines":           "goroutines",
+			"pricing_models":       "priced",
+			"custom_providers":     "custom",
+			"catalog_reloads":      "reloads",
+		}),
+	)
+}
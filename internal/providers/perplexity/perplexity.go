@@ -68,6 +68,27 @@ func New() *Provider {
 				"total_spend":       core.BalanceCumulative,
 				"available_balance": core.BalancePoint,
 			},
+			Reference: core.ProviderReference{
+				TierKey: "usage_tier",
+				Tiers: []core.ProviderTier{
+					{Name: "Tier 0", Match: "0", Qualification: "new account"},
+					{Name: "Tier 1", Match: "1", Qualification: "$50+ in credits purchased"},
+					{Name: "Tier 2", Match: "2", Qualification: "$250+ in credits purchased"},
+					{Name: "Tier 3", Match: "3", Qualification: "$500+ in credits purchased"},
+					{Name: "Tier 4", Match: "4", Qualification: "$1,000+ in credits purchased"},
+					{Name: "Tier 5", Match: "5", Qualification: "$5,000+ in credits purchased"},
+				},
+				LimitsNote: "differ per Sonar model; see the rate limits page",
+				Windows: []string{
+					"Tiers count lifetime credit purchases and are promoted automatically.",
+				},
+				Links: []core.ProviderLink{
+					{Label: "Rate limits", URL: "https://docs.perplexity.ai/docs/admin/rate-limits-usage-tiers"},
+				},
+			},
+			Options: []core.ProviderOption{
+				{Key: "org_id", Type: core.ProviderOptionString, Description: "API org to report on. Defaults to the account's default org."},
+			},
 		}),
 	}
 }
@@ -78,6 +99,9 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 	snap := core.NewUsageSnapshot(p.ID(), acct.ID)
 
 	session, ok, err := shared.LoadOrRefreshBrowserSession(ctx, acct, nil)
+	if shared.BrowserSessionRefreshSkipped(acct, session, ok) {
+		snap.Raw["browser_session_refresh"] = core.ReadOnlySkipped
+	}
 	if err != nil || !ok || session.Value == "" {
 		snap.Status = core.StatusAuth
 		snap.Message = "browser session not configured — Settings → 5 KEYS → perplexity → Enter"
@@ -105,8 +129,8 @@ func (p *Provider) Fetch(ctx context.Context, acct core.AccountConfig) (core.Usa
 		return snap, nil
 	}
 	// First org is the default (per their UI ordering); user can override
-	// via account.extra_data.perplexity_org_id.
-	orgID := strings.TrimSpace(acct.Hint("perplexity_org_id", ""))
+	// via provider_options.org_id.
+	orgID := acct.OptionString("org_id", strings.TrimSpace(acct.Hint("perplexity_org_id", "")))
 	if orgID == "" {
 		for _, o := range orgs.Orgs {
 			if o.IsDefaultOrg {
@@ -264,7 +288,7 @@ func newConsoleClient(cookieValue, cookieName string) *consoleClient {
 		base = override
 	}
 	return &consoleClient{
-		httpClient:  &http.Client{Timeout: 15 * time.Second},
+		httpClient:  shared.NewHTTPClient(15 * time.Second),
 		baseURL:     base,
 		cookieName:  cookieName,
 		cookieValue: cookieValue,
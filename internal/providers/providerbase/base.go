@@ -15,13 +15,14 @@ type Base struct {
 	HTTPClient *http.Client
 }
 
-// Client returns the configured HTTP client, or a default client with a
-// 30-second timeout if none was set.
+// Client returns the configured HTTP client, or the pooled 30-second
+// shared client if none was set, which every account of every provider
+// shares.
 func (b Base) Client() *http.Client {
 	if b.HTTPClient != nil {
 		return b.HTTPClient
 	}
-	return &http.Client{Timeout: 30 * time.Second}
+	return shared.PooledHTTPClient(30 * time.Second)
 }
 
 func New(spec core.ProviderSpec) Base {
@@ -165,6 +166,14 @@ func CodingToolDashboard(options ...DashboardOption) core.DashboardWidget {
 	cfg.CodeStatsMetrics = shared.DefaultCodeStatsConfig()
 	cfg.StandardSectionOrder = shared.CodingToolSectionOrder()
 	cfg.HideMetricPrefixes = append(cfg.HideMetricPrefixes, shared.CodingToolHidePrefixes()...)
+	// The live session shows as the tile's ticker; the detail view lists it.
+	cfg.RawGroups = append(cfg.RawGroups, core.DashboardRawGroup{
+		Label: "Live Session",
+		Keys: []string{
+			"live_session_status", "live_session_models", "live_session_started",
+			"live_session_last_active", "live_session_id",
+		},
+	})
 
 	// Merge shared code-stats labels.
 	for k, v := range shared.CodeStatsMetricLabels {
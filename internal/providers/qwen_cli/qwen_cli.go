@@ -56,6 +56,9 @@ func New() *Provider {
 				},
 			},
 			Dashboard: dashboardWidget(),
+			Actions: []core.ProviderAction{
+				{Label: "Open projects directory", Kind: core.ProviderActionOpenPath, SnapshotKey: "projects_dir"},
+			},
 		}),
 		clock: core.SystemClock{},
 	}
@@ -1,8 +1,14 @@
 package providers
 
 import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 
+	"github.com/janekbaraniewski/openusage/internal/config"
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/providers/alibaba_cloud"
 	"github.com/janekbaraniewski/openusage/internal/providers/amp"
@@ -14,6 +20,7 @@ import (
 	"github.com/janekbaraniewski/openusage/internal/providers/copilot"
 	"github.com/janekbaraniewski/openusage/internal/providers/crush"
 	"github.com/janekbaraniewski/openusage/internal/providers/cursor"
+	"github.com/janekbaraniewski/openusage/internal/providers/declarative"
 	"github.com/janekbaraniewski/openusage/internal/providers/deepseek"
 	"github.com/janekbaraniewski/openusage/internal/providers/droid"
 	"github.com/janekbaraniewski/openusage/internal/providers/gemini_api"
@@ -32,17 +39,122 @@ import (
 	"github.com/janekbaraniewski/openusage/internal/providers/openclaw"
 	"github.com/janekbaraniewski/openusage/internal/providers/opencode"
 	"github.com/janekbaraniewski/openusage/internal/providers/openrouter"
+	"github.com/janekbaraniewski/openusage/internal/providers/openusage"
 	"github.com/janekbaraniewski/openusage/internal/providers/perplexity"
 	"github.com/janekbaraniewski/openusage/internal/providers/pi"
 	"github.com/janekbaraniewski/openusage/internal/providers/qwen_cli"
 	"github.com/janekbaraniewski/openusage/internal/providers/roocode"
 	"github.com/janekbaraniewski/openusage/internal/providers/shared"
+	"github.com/janekbaraniewski/openusage/internal/providers/webhook"
 	"github.com/janekbaraniewski/openusage/internal/providers/xai"
 	"github.com/janekbaraniewski/openusage/internal/providers/zai"
 	"github.com/janekbaraniewski/openusage/internal/providers/zed"
 )
 
+// AllProviders returns the built-in providers followed by the declarative
+// ones defined under ConfigDir()/providers.
 func AllProviders() []core.UsageProvider {
+	all := builtinProviders()
+	for _, def := range customDefinitions() {
+		all = append(all, declarative.New(def))
+	}
+	return all
+}
+
+// customProviders caches the declarative provider files between
+// ReloadCustomProviders calls, so every AllProviders call doesn't re-read
+// them.
+var customProviders customProviderCache
+
+type customProviderCache struct {
+	mu     sync.Mutex
+	loaded bool
+	stamp  string // directory listing with sizes and mtimes at load
+	defs   []declarative.Definition
+}
+
+func customProvidersDir() string {
+	return filepath.Join(config.ConfigDir(), "providers")
+}
+
+// customDefinitions returns the declarative provider definitions, reading
+// them on first use. Broken files and IDs that clash with a built-in
+// provider are logged and skipped.
+func customDefinitions() []declarative.Definition {
+	customProviders.mu.Lock()
+	defer customProviders.mu.Unlock()
+	if !customProviders.loaded {
+		dir := customProvidersDir()
+		customProviders.stamp = dirStamp(dir)
+		customProviders.defs = loadCustomDefinitions(dir)
+		customProviders.loaded = true
+	}
+	return customProviders.defs
+}
+
+// ReloadCustomProviders re-reads the declarative provider definitions when
+// a file under ConfigDir()/providers was added, removed or edited since they
+// were last read, and reports whether it did. Callers holding providers from
+// AllProviders should fetch them again afterwards.
+func ReloadCustomProviders() bool {
+	dir := customProvidersDir()
+	stamp := dirStamp(dir)
+
+	customProviders.mu.Lock()
+	defer customProviders.mu.Unlock()
+	if customProviders.loaded && stamp == customProviders.stamp {
+		return false
+	}
+	customProviders.stamp = stamp
+	customProviders.defs = loadCustomDefinitions(dir)
+	customProviders.loaded = true
+	return true
+}
+
+// CustomProviderCount is how many declarative providers are registered.
+func CustomProviderCount() int {
+	return len(customDefinitions())
+}
+
+func loadCustomDefinitions(dir string) []declarative.Definition {
+	defs, errs := declarative.LoadDir(dir)
+	for _, err := range errs {
+		log.Printf("[providers] skipping custom provider: %v", err)
+	}
+	builtin := map[string]bool{}
+	for _, p := range builtinProviders() {
+		builtin[p.ID()] = true
+	}
+	kept := defs[:0]
+	for _, def := range defs {
+		if builtin[def.ID] {
+			log.Printf("[providers] skipping custom provider %s: id %q is a built-in provider", def.Path, def.ID)
+			continue
+		}
+		kept = append(kept, def)
+	}
+	return kept
+}
+
+// dirStamp summarises the directory's entries so a change to any of them
+// changes the result. A missing directory stamps as empty.
+func dirStamp(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "%s:%d:%d\n", entry.Name(), info.Size(), info.ModTime().UnixNano())
+	}
+	return b.String()
+}
+
+func builtinProviders() []core.UsageProvider {
 	return []core.UsageProvider{
 		openai.New(),
 		anthropic.New(),
@@ -79,6 +191,8 @@ func AllProviders() []core.UsageProvider {
 		openclaw.New(),
 		pi.New(),
 		qwen_cli.New(),
+		openusage.New(),
+		webhook.New(),
 	}
 }
 
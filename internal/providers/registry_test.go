@@ -1,7 +1,10 @@
 package providers
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
@@ -82,3 +85,78 @@ func TestAllProviders_DashboardSectionsAreKnownAndUnique(t *testing.T) {
 		}
 	}
 }
+
+func TestAllProviders_ActionsAreWellFormed(t *testing.T) {
+	for _, p := range AllProviders() {
+		for _, action := range p.Spec().Actions {
+			if action.Label == "" {
+				t.Errorf("provider %q has an action without a label", p.ID())
+			}
+			switch action.Kind {
+			case core.ProviderActionOpenURL, core.ProviderActionCopy, core.ProviderActionOpenPath:
+				if action.Target == "" && action.SnapshotKey == "" {
+					t.Errorf("provider %q action %q has neither Target nor SnapshotKey", p.ID(), action.Label)
+				}
+			case core.ProviderActionSetSpendLimit:
+				if _, ok := p.(core.SpendLimitSetter); !ok {
+					t.Errorf("provider %q declares %q but does not implement core.SpendLimitSetter", p.ID(), action.Label)
+				}
+				if action.Target == "" {
+					t.Errorf("provider %q action %q should name the metric holding the current limit", p.ID(), action.Label)
+				}
+			case core.ProviderActionRefresh:
+				t.Errorf("provider %q declares %q; refresh is offered for every tile", p.ID(), action.Label)
+			default:
+				t.Errorf("provider %q action %q has unknown kind %q", p.ID(), action.Label, action.Kind)
+			}
+		}
+	}
+}
+
+func TestReloadCustomProviders(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	customProviders = customProviderCache{}
+	t.Cleanup(func() { customProviders = customProviderCache{} })
+
+	has := func(id string) bool {
+		for _, p := range AllProviders() {
+			if p.ID() == id {
+				return true
+			}
+		}
+		return false
+	}
+	if has("acme") {
+		t.Fatal("acme registered before its file exists")
+	}
+	if ReloadCustomProviders() {
+		t.Error("ReloadCustomProviders with no provider files = true, want false")
+	}
+
+	dir := filepath.Join(configHome, "openusage", "providers")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "acme.yaml")
+	if err := os.WriteFile(path, []byte("id: acme\nendpoints: [{url: 'https://x', raw: {a: b}}]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !ReloadCustomProviders() || !has("acme") {
+		t.Fatal("new provider file not picked up by ReloadCustomProviders")
+	}
+	if ReloadCustomProviders() {
+		t.Error("second ReloadCustomProviders without edits = true, want false")
+	}
+
+	if err := os.WriteFile(path, []byte("id: acme2\nendpoints: [{url: 'https://x', raw: {a: b}}]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if !ReloadCustomProviders() || has("acme") || !has("acme2") {
+		t.Error("edited provider file not reloaded")
+	}
+}
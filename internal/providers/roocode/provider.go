@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
 	"github.com/janekbaraniewski/openusage/internal/providers/providerbase"
 )
 
@@ -323,10 +324,10 @@ func buildStatusMessage(displayName string, snap core.UsageSnapshot) string {
 		parts = append(parts, formatCount(*m.Used, "task"))
 	}
 	if m, ok := snap.Metrics["total_tokens"]; ok && m.Used != nil && *m.Used > 0 {
-		parts = append(parts, formatTokens(*m.Used))
+		parts = append(parts, locale.Current().Quantity(*m.Used, "tokens")+" tokens")
 	}
 	if m, ok := snap.Metrics["total_cost_usd"]; ok && m.Used != nil && *m.Used > 0 {
-		parts = append(parts, formatCostUSD(*m.Used))
+		parts = append(parts, locale.Current().Quantity(*m.Used, "USD"))
 	}
 	if len(parts) == 0 {
 		return displayName + " OK"
@@ -341,24 +342,6 @@ func formatCount(v float64, noun string) string {
 	return fmt.Sprintf("%d %ss", int64(v), noun)
 }
 
-func formatTokens(v float64) string {
-	switch {
-	case v >= 1_000_000:
-		return fmt.Sprintf("%.1fM tokens", v/1_000_000)
-	case v >= 1_000:
-		return fmt.Sprintf("%.1fk tokens", v/1_000)
-	default:
-		return fmt.Sprintf("%d tokens", int64(v))
-	}
-}
-
-func formatCostUSD(v float64) string {
-	if v >= 1 {
-		return fmt.Sprintf("$%.2f", v)
-	}
-	return fmt.Sprintf("$%.4f", v)
-}
-
 // ExtensionChanged returns true if any VS Code globalStorage location
 // holding the named extension subdir has been modified after `since`.
 // Used by HasChanged hooks for both Roo Code and Kilo Code.
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/janekbaraniewski/openusage/internal/audit"
 	"github.com/janekbaraniewski/openusage/internal/browsercookies"
 	"github.com/janekbaraniewski/openusage/internal/config"
 	"github.com/janekbaraniewski/openusage/internal/core"
@@ -15,6 +16,8 @@ import (
 // user's chosen browser when possible, falling back to the last stored session
 // when browser access is unavailable. This is what lets "log in again in the
 // browser" repair a provider on the next poll without another TUI round-trip.
+// In read-only mode the browser is not read and the stored session is not
+// rewritten; only the last stored session is returned.
 func LoadOrRefreshBrowserSession(ctx context.Context, acct core.AccountConfig, reader browsercookies.Reader) (config.BrowserSession, bool, error) {
 	return loadOrRefreshBrowserSessionFrom(config.CredentialsPath(), ctx, acct, reader)
 }
@@ -25,15 +28,8 @@ func loadOrRefreshBrowserSessionFrom(path string, ctx context.Context, acct core
 		return config.BrowserSession{}, false, err
 	}
 
-	ref := acct.BrowserCookie
-	if ref == nil && ok {
-		ref = &core.BrowserCookieRef{
-			Domain:        stored.Domain,
-			CookieName:    stored.CookieName,
-			SourceBrowser: stored.SourceBrowser,
-		}
-	}
-	if ref == nil || strings.TrimSpace(ref.Domain) == "" || strings.TrimSpace(ref.CookieName) == "" {
+	ref := browserCookieRef(acct, stored, ok)
+	if acct.ReadOnly() || ref == nil {
 		return stored, ok && strings.TrimSpace(stored.Value) != "", nil
 	}
 
@@ -42,6 +38,7 @@ func loadOrRefreshBrowserSessionFrom(path string, ctx context.Context, acct core
 	}
 	cookie, err := reader.ReadCookie(ctx, ref.Domain, ref.CookieName, ref.SourceBrowser)
 	if err == nil {
+		audit.Credential(ctx, "browser_cookie:"+core.FirstNonEmpty(cookie.Source, ref.SourceBrowser)+":"+ref.Domain+":"+ref.CookieName)
 		fresh := config.BrowserSession{
 			Domain:        cookie.Domain,
 			CookieName:    cookie.Name,
@@ -71,3 +68,28 @@ func loadOrRefreshBrowserSessionFrom(path string, ctx context.Context, acct core
 	}
 	return config.BrowserSession{}, false, err
 }
+
+// BrowserSessionRefreshSkipped reports whether LoadOrRefreshBrowserSession,
+// having returned session and ok, would have re-read the browser but did not
+// because the account is read-only.
+func BrowserSessionRefreshSkipped(acct core.AccountConfig, session config.BrowserSession, ok bool) bool {
+	return acct.ReadOnly() && browserCookieRef(acct, session, ok) != nil
+}
+
+// browserCookieRef is the cookie to re-read from the browser: the account's
+// own reference, else the one the stored session was captured from. Nil when
+// neither names a domain and cookie.
+func browserCookieRef(acct core.AccountConfig, stored config.BrowserSession, ok bool) *core.BrowserCookieRef {
+	ref := acct.BrowserCookie
+	if ref == nil && ok {
+		ref = &core.BrowserCookieRef{
+			Domain:        stored.Domain,
+			CookieName:    stored.CookieName,
+			SourceBrowser: stored.SourceBrowser,
+		}
+	}
+	if ref == nil || strings.TrimSpace(ref.Domain) == "" || strings.TrimSpace(ref.CookieName) == "" {
+		return nil
+	}
+	return ref
+}
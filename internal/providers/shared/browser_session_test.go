@@ -158,3 +158,65 @@ func TestLoadOrRefreshBrowserSessionFrom_PropagatesReadErrorWithoutStoredFallbac
 		t.Fatal("ok = true, want false")
 	}
 }
+
+func TestLoadOrRefreshBrowserSessionFrom_ReadOnlyUsesStoredSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	stored := config.BrowserSession{
+		Domain:        ".perplexity.ai",
+		CookieName:    "__Secure-next-auth.session-token",
+		Value:         "old-cookie",
+		SourceBrowser: "firefox",
+		CapturedAt:    "2026-04-30T00:00:00Z",
+	}
+	if err := config.SaveSessionTo(path, "perplexity", stored); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := &browsercookies.FakeReader{
+		Cookies: []browsercookies.Cookie{{Name: stored.CookieName, Value: "fresh-cookie", Domain: stored.Domain, Source: "chrome"}},
+	}
+	acct := core.AccountConfig{
+		ID:       "perplexity",
+		Provider: "perplexity",
+		BrowserCookie: &core.BrowserCookieRef{
+			Domain:        stored.Domain,
+			CookieName:    stored.CookieName,
+			SourceBrowser: "chrome",
+		},
+	}
+	acct.SetHint(core.ReadOnlyHint, "true")
+
+	session, ok, err := loadOrRefreshBrowserSessionFrom(path, context.Background(), acct, reader)
+	if err != nil || !ok {
+		t.Fatalf("session = %v, %v", ok, err)
+	}
+	if reader.Calls() != 0 {
+		t.Fatalf("read-only refresh read the browser %d times", reader.Calls())
+	}
+	if session != stored {
+		t.Fatalf("session = %+v, want the stored one", session)
+	}
+	if !BrowserSessionRefreshSkipped(acct, session, ok) {
+		t.Fatal("a skipped refresh should be reported")
+	}
+}
+
+func TestBrowserSessionRefreshSkipped_OnlyWhenARefreshWasPossible(t *testing.T) {
+	acct := core.AccountConfig{ID: "perplexity", Provider: "perplexity"}
+	acct.SetHint(core.ReadOnlyHint, "true")
+	if BrowserSessionRefreshSkipped(acct, config.BrowserSession{}, false) {
+		t.Fatal("no session and no cookie reference: nothing was skipped")
+	}
+	manual := config.BrowserSession{Value: "pasted-cookie"}
+	if BrowserSessionRefreshSkipped(acct, manual, true) {
+		t.Fatal("a pasted session without a cookie reference cannot be refreshed")
+	}
+	captured := config.BrowserSession{Domain: ".perplexity.ai", CookieName: "session", Value: "cookie"}
+	if !BrowserSessionRefreshSkipped(acct, captured, true) {
+		t.Fatal("a captured session would have been refreshed")
+	}
+	writable := core.AccountConfig{ID: "perplexity", Provider: "perplexity"}
+	if BrowserSessionRefreshSkipped(writable, captured, true) {
+		t.Fatal("outside read-only mode nothing is skipped")
+	}
+}
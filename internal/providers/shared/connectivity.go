@@ -0,0 +1,138 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrOffline is returned instead of sending a provider request while the
+// process considers the network unreachable.
+var ErrOffline = errors.New("offline: provider request not sent")
+
+// Offline detection: after offlineThreshold consecutive unreachable-network
+// errors the process goes offline and only lets one probe request through
+// every offlineProbeInterval until one gets a response.
+const (
+	offlineThreshold     = 3
+	offlineProbeInterval = time.Minute
+)
+
+// ConnectivityState is a point-in-time view of ConnectivityTracker.
+type ConnectivityState struct {
+	Offline bool
+	// Since is when the process went offline.
+	Since time.Time
+	// LastOnline is when a provider last answered; data fetched before it
+	// is what an offline process is showing.
+	LastOnline time.Time
+}
+
+// ConnectivityTracker watches provider requests for signs the network is
+// gone, so pollers stop hammering unreachable endpoints and keep showing
+// what they last fetched. Requests to loopback hosts (local runtimes such
+// as Ollama) are never blocked and never counted.
+type ConnectivityTracker struct {
+	mu         sync.Mutex
+	now        func() time.Time
+	failures   int
+	since      time.Time
+	lastOnline time.Time
+	lastProbe  time.Time
+}
+
+// Connectivity is the process-wide tracker RetryTransport reports into.
+var Connectivity = NewConnectivityTracker(time.Now)
+
+// NewConnectivityTracker returns an online tracker reading time from now.
+func NewConnectivityTracker(now func() time.Time) *ConnectivityTracker {
+	return &ConnectivityTracker{now: now}
+}
+
+// State returns the current connectivity state.
+func (c *ConnectivityTracker) State() ConnectivityState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ConnectivityState{
+		Offline:    c.failures >= offlineThreshold,
+		Since:      c.since,
+		LastOnline: c.lastOnline,
+	}
+}
+
+// allow reports whether a request to host may be sent. While offline it
+// returns ErrOffline, except for one probe per offlineProbeInterval.
+func (c *ConnectivityTracker) allow(host string) error {
+	if isLoopbackHost(host) {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failures < offlineThreshold {
+		return nil
+	}
+	if now := c.now(); now.Sub(c.lastProbe) >= offlineProbeInterval {
+		c.lastProbe = now
+		return nil
+	}
+	return fmt.Errorf("%w (no network since %s)", ErrOffline, c.since.Local().Format("15:04"))
+}
+
+// record notes the outcome of a request to host. Any HTTP response means
+// the network is up; only errors that point at the network itself count
+// toward going offline.
+func (c *ConnectivityTracker) record(host string, err error) {
+	if isLoopbackHost(host) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case err == nil:
+		c.failures = 0
+		c.lastOnline = c.now()
+		c.since = time.Time{}
+	case isNetworkUnreachable(err):
+		c.failures++
+		if c.failures == offlineThreshold && c.since.IsZero() {
+			c.since = c.now()
+			c.lastProbe = c.since
+		}
+	}
+}
+
+// isNetworkUnreachable matches the errors a machine without connectivity
+// produces: failed DNS lookups, unreachable networks and hosts, and dial
+// timeouts. Refused connections and TLS or HTTP failures mean the network
+// works, so they don't count.
+func isNetworkUnreachable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	if errors.Is(err, syscall.ENETUNREACH) || errors.Is(err, syscall.EHOSTUNREACH) || errors.Is(err, syscall.ENETDOWN) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial" && opErr.Timeout()
+}
+
+func isLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,60 @@
+package shared
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestConnectivityTracker_GoesOfflineAndProbes(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	conn := NewConnectivityTracker(func() time.Time { return now })
+	unreachable := &net.DNSError{Err: "no such host", Name: "api.example.com", IsNotFound: true}
+
+	conn.record("api.example.com", nil)
+	lastOnline := now
+	now = now.Add(time.Minute)
+	for range offlineThreshold {
+		if err := conn.allow("api.example.com"); err != nil {
+			t.Fatalf("allow before going offline: %v", err)
+		}
+		conn.record("api.example.com", unreachable)
+	}
+
+	state := conn.State()
+	if !state.Offline || !state.Since.Equal(now) || !state.LastOnline.Equal(lastOnline) {
+		t.Fatalf("state = %+v, want offline since %s, last online %s", state, now, lastOnline)
+	}
+	if err := conn.allow("api.example.com"); !errors.Is(err, ErrOffline) {
+		t.Fatalf("allow while offline = %v, want ErrOffline", err)
+	}
+	if err := conn.allow("127.0.0.1:11434"); err != nil {
+		t.Fatalf("loopback requests must not be blocked: %v", err)
+	}
+
+	now = now.Add(offlineProbeInterval)
+	if err := conn.allow("api.example.com"); err != nil {
+		t.Fatalf("probe after interval: %v", err)
+	}
+	if err := conn.allow("api.example.com"); !errors.Is(err, ErrOffline) {
+		t.Fatalf("second request in the same interval = %v, want ErrOffline", err)
+	}
+
+	conn.record("api.example.com", nil)
+	if state := conn.State(); state.Offline || !state.LastOnline.Equal(now) {
+		t.Fatalf("state after a response = %+v, want online", state)
+	}
+}
+
+func TestConnectivityTracker_IgnoresNonNetworkErrors(t *testing.T) {
+	conn := NewConnectivityTracker(time.Now)
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	for range offlineThreshold + 1 {
+		conn.record("api.example.com", refused)
+		conn.record("localhost:8080", &net.DNSError{Err: "no such host", IsNotFound: true})
+	}
+	if conn.State().Offline {
+		t.Fatal("refused connections and loopback failures must not mark the network offline")
+	}
+}
@@ -0,0 +1,167 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// EndpointRecorder collects per-endpoint latency and error counts for the
+// HTTP calls made during one provider fetch. Attach it to the fetch context
+// with WithEndpointRecorder; the instrumented transport feeds it.
+type EndpointRecorder struct {
+	mu    sync.Mutex
+	stats map[string]*core.EndpointStat
+	order []string
+}
+
+func NewEndpointRecorder() *EndpointRecorder {
+	return &EndpointRecorder{stats: make(map[string]*core.EndpointStat)}
+}
+
+// Record adds one call. A transport error or a status >= 400 counts as an
+// error.
+func (r *EndpointRecorder) Record(req *http.Request, status int, latency time.Duration, err error) {
+	if r == nil || req == nil || req.URL == nil {
+		return
+	}
+	label := req.Method + " " + req.URL.Host + normalizeEndpointPath(req.URL.Path)
+	key := SanitizeMetricName(label)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.stats[key]
+	if !ok {
+		s = &core.EndpointStat{Key: key, Label: label}
+		r.stats[key] = s
+		r.order = append(r.order, key)
+	}
+	s.Requests++
+	s.TotalLatency += latency
+	if latency > s.MaxLatency {
+		s.MaxLatency = latency
+	}
+	switch {
+	case err != nil:
+		s.Errors++
+		s.LastError = endpointErrorText(err)
+	case status >= http.StatusBadRequest:
+		s.Errors++
+		s.LastError = fmt.Sprintf("HTTP %d", status)
+	}
+}
+
+// Stats returns a copy of the recorded stats in first-call order.
+func (r *EndpointRecorder) Stats() []core.EndpointStat {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]core.EndpointStat, 0, len(r.order))
+	for _, key := range r.order {
+		out = append(out, *r.stats[key])
+	}
+	return out
+}
+
+type endpointRecorderKey struct{}
+
+// WithEndpointRecorder returns a context whose HTTP calls are recorded into r
+// when they go through an instrumented transport.
+func WithEndpointRecorder(ctx context.Context, r *EndpointRecorder) context.Context {
+	return context.WithValue(ctx, endpointRecorderKey{}, r)
+}
+
+// EndpointRecorderFromContext returns the recorder attached to ctx, or nil.
+func EndpointRecorderFromContext(ctx context.Context) *EndpointRecorder {
+	r, _ := ctx.Value(endpointRecorderKey{}).(*EndpointRecorder)
+	return r
+}
+
+type instrumentedTransport struct {
+	next http.RoundTripper
+}
+
+// InstrumentTransport wraps next so that requests whose context carries an
+// EndpointRecorder are timed and recorded, and those carrying an HTTPTrace
+// are captured in full. Other requests pass straight through.
+func InstrumentTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if _, ok := next.(*instrumentedTransport); ok {
+		return next
+	}
+	return &instrumentedTransport{next: next}
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := EndpointRecorderFromContext(req.Context())
+	trace := HTTPTraceFromContext(req.Context())
+	if rec == nil && trace == nil {
+		return t.next.RoundTrip(req)
+	}
+	start := time.Now()
+	var resp *http.Response
+	var err error
+	if trace != nil {
+		resp, err = traceRoundTrip(trace, t.next, req)
+	} else {
+		resp, err = t.next.RoundTrip(req)
+	}
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	rec.Record(req, status, time.Since(start), err)
+	return resp, err
+}
+
+var installEndpointInstrumentation sync.Once
+
+// InstallEndpointInstrumentation wraps http.DefaultTransport once per
+// process. Provider clients that don't set their own Transport — nearly all
+// of them — then report into the fetch context's EndpointRecorder.
+func InstallEndpointInstrumentation() {
+	installEndpointInstrumentation.Do(func() {
+		http.DefaultTransport = InstrumentTransport(http.DefaultTransport)
+	})
+}
+
+var endpointIDSegmentRe = regexp.MustCompile(`^(?:[0-9]+|[0-9a-fA-F-]{16,}|[A-Za-z0-9_-]*[0-9][A-Za-z0-9_-]{15,})$`)
+
+// normalizeEndpointPath replaces ID-like path segments (numbers, UUIDs, long
+// tokens) with ":id" so one endpoint doesn't fan out into a metric per
+// resource.
+func normalizeEndpointPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if seg != "" && endpointIDSegmentRe.MatchString(seg) {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func endpointErrorText(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case strings.Contains(err.Error(), context.DeadlineExceeded.Error()), strings.Contains(err.Error(), "Client.Timeout"):
+		return "timeout"
+	case strings.Contains(err.Error(), context.Canceled.Error()):
+		return "canceled"
+	default:
+		return err.Error()
+	}
+}
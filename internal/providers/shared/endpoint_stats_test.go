@@ -0,0 +1,104 @@
+package shared
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInstrumentTransport_RecordsPerEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: InstrumentTransport(nil)}
+	rec := NewEndpointRecorder()
+	ctx := WithEndpointRecorder(context.Background(), rec)
+
+	for _, path := range []string{"/v1/users/12345/usage", "/v1/users/67890/usage", "/v1/fail"} {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("request %s: %v", path, err)
+		}
+		resp.Body.Close()
+	}
+
+	// Requests without a recorder pass through unrecorded.
+	resp, err := client.Get(server.URL + "/v1/other")
+	if err != nil {
+		t.Fatalf("unrecorded request: %v", err)
+	}
+	resp.Body.Close()
+
+	stats := rec.Stats()
+	if len(stats) != 2 {
+		t.Fatalf("stats = %+v, want 2 endpoints", stats)
+	}
+	usage, fail := stats[0], stats[1]
+	host := strings.TrimPrefix(server.URL, "http://")
+	if usage.Label != "GET "+host+"/v1/users/:id/usage" || usage.Requests != 2 || usage.Errors != 0 {
+		t.Errorf("usage endpoint = %+v", usage)
+	}
+	if fail.Requests != 1 || fail.Errors != 1 || fail.LastError != "HTTP 502" {
+		t.Errorf("failing endpoint = %+v", fail)
+	}
+}
+
+func TestNormalizeEndpointPath(t *testing.T) {
+	tests := map[string]string{
+		"":                 "/",
+		"/api/v1/usage":    "/api/v1/usage",
+		"/orgs/42/members": "/orgs/:id/members",
+		"/runs/3f2b1c9e-8a7d-4e6f-9b0a-1c2d3e4f5a6b": "/runs/:id",
+		"/v1/chat/completions":                       "/v1/chat/completions",
+	}
+	for in, want := range tests {
+		if got := normalizeEndpointPath(in); got != want {
+			t.Errorf("normalizeEndpointPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestInstrumentTransport_TracesExchanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"used":12}`))
+	}))
+	defer server.Close()
+
+	trace := NewHTTPTrace()
+	ctx := WithHTTPTrace(context.Background(), trace)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v1/usage", strings.NewReader(`{"q":1}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := (&http.Client{Transport: InstrumentTransport(nil)}).Do(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != `{"used":12}` {
+		t.Fatalf("caller read %q, want the full body", body)
+	}
+
+	exchanges := trace.Exchanges()
+	if len(exchanges) != 1 {
+		t.Fatalf("exchanges = %d, want 1", len(exchanges))
+	}
+	ex := exchanges[0]
+	if ex.Method != http.MethodPost || ex.Status != http.StatusCreated || ex.RequestBody != `{"q":1}` || ex.ResponseBody != `{"used":12}` {
+		t.Fatalf("exchange = %+v", ex)
+	}
+	if ex.RequestHeader.Get("Authorization") != "Bearer secret" || ex.ResponseHeader.Get("X-Request-Id") != "req-1" {
+		t.Fatalf("headers not recorded verbatim: %+v", ex)
+	}
+}
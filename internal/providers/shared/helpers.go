@@ -39,7 +39,12 @@ func ProcessStandardResponse(resp *http.Response, acct core.AccountConfig, provi
 // mapping that providers with custom response handling (mistral, gemini_api,
 // alibaba_cloud, moonshot, zai) used to hand-roll. Call this first, then add
 // provider-specific cases on top if needed. Reads Retry-After when present.
+// A 503 announcing planned maintenance sets StatusMaintenance instead.
 func ApplyStatusFromResponse(resp *http.Response, snap *core.UsageSnapshot) {
+	if maint := MaintenanceFromResponse(resp); maint != nil {
+		snap.SetMaintenance(maint.Message, maint.Until)
+		return
+	}
 	ApplyStatusFromCode(resp.StatusCode, snap, "")
 	if snap.Status == core.StatusLimited {
 		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
@@ -101,10 +106,10 @@ func ResolveBaseURL(acct core.AccountConfig, defaultURL string) string {
 // FetchJSON performs an authenticated GET request and decodes the JSON response
 // body into out. Returns the HTTP status code and response headers on success.
 // For non-200 responses, returns an error with the status code.
-// If client is nil a default client with a 30-second timeout is used.
+// If client is nil the pooled 30-second client is used.
 func FetchJSON(ctx context.Context, url, apiKey string, out any, client *http.Client) (int, http.Header, error) {
 	if client == nil {
-		client = &http.Client{Timeout: 30 * time.Second}
+		client = PooledHTTPClient(30 * time.Second)
 	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -119,6 +124,9 @@ func FetchJSON(ctx context.Context, url, apiKey string, out any, client *http.Cl
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		if maint := MaintenanceFromResponse(resp); maint != nil {
+			return resp.StatusCode, resp.Header, maint
+		}
 		return resp.StatusCode, resp.Header, fmt.Errorf("HTTP %d", resp.StatusCode)
 	}
 
@@ -139,11 +147,11 @@ func FetchJSON(ctx context.Context, url, apiKey string, out any, client *http.Cl
 // ProbeRateLimits performs a GET request to the given URL with Bearer auth,
 // copies redacted headers to snap.Raw, applies standard status code handling
 // (401/403 → StatusAuth, 429 → StatusLimited), and parses standard RPM/TPM
-// rate-limit headers. If client is nil a default client with a 30-second
-// timeout is used.
+// rate-limit headers. If client is nil the pooled 30-second client is
+// used.
 func ProbeRateLimits(ctx context.Context, url, apiKey string, snap *core.UsageSnapshot, client *http.Client) error {
 	if client == nil {
-		client = &http.Client{Timeout: 30 * time.Second}
+		client = PooledHTTPClient(30 * time.Second)
 	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
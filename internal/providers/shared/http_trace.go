@@ -0,0 +1,128 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// httpTraceBodyLimit caps how much of each request and response body an
+// HTTPTrace keeps.
+const httpTraceBodyLimit = 1 << 20
+
+// HTTPExchange is one request and its response as seen on the wire. Headers
+// and bodies are recorded verbatim, credentials included; callers that write
+// them anywhere must redact them first.
+type HTTPExchange struct {
+	Method         string        `json:"method"`
+	URL            string        `json:"url"`
+	RequestHeader  http.Header   `json:"request_header,omitempty"`
+	RequestBody    string        `json:"request_body,omitempty"`
+	Status         int           `json:"status,omitempty"`
+	ResponseHeader http.Header   `json:"response_header,omitempty"`
+	ResponseBody   string        `json:"response_body,omitempty"`
+	BodyTruncated  bool          `json:"body_truncated,omitempty"`
+	Duration       time.Duration `json:"duration_ns"`
+	Error          string        `json:"error,omitempty"`
+	StartedAt      time.Time     `json:"started_at"`
+}
+
+// HTTPTrace records full request/response pairs for one fetch, for bug
+// reports. Attach it with WithHTTPTrace; the instrumented transport feeds it.
+type HTTPTrace struct {
+	mu        sync.Mutex
+	exchanges []HTTPExchange
+}
+
+func NewHTTPTrace() *HTTPTrace {
+	return &HTTPTrace{}
+}
+
+// Exchanges returns the recorded exchanges in the order they started.
+func (t *HTTPTrace) Exchanges() []HTTPExchange {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]HTTPExchange(nil), t.exchanges...)
+}
+
+func (t *HTTPTrace) add(ex HTTPExchange) {
+	t.mu.Lock()
+	t.exchanges = append(t.exchanges, ex)
+	t.mu.Unlock()
+}
+
+type httpTraceKey struct{}
+
+// WithHTTPTrace returns a context whose HTTP calls are recorded into t when
+// they go through an instrumented transport.
+func WithHTTPTrace(ctx context.Context, t *HTTPTrace) context.Context {
+	return context.WithValue(ctx, httpTraceKey{}, t)
+}
+
+// HTTPTraceFromContext returns the trace attached to ctx, or nil.
+func HTTPTraceFromContext(ctx context.Context) *HTTPTrace {
+	t, _ := ctx.Value(httpTraceKey{}).(*HTTPTrace)
+	return t
+}
+
+// traceRoundTrip sends req through next and records the exchange. The
+// response body is read up to the limit and handed back to the caller
+// unchanged.
+func traceRoundTrip(trace *HTTPTrace, next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	ex := HTTPExchange{
+		Method:        req.Method,
+		URL:           req.URL.String(),
+		RequestHeader: req.Header.Clone(),
+		StartedAt:     time.Now(),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, truncated := readTraceBody(body)
+			body.Close()
+			ex.RequestBody = data
+			ex.BodyTruncated = truncated
+		}
+	}
+
+	resp, err := next.RoundTrip(req)
+	ex.Duration = time.Since(ex.StartedAt)
+	if err != nil {
+		ex.Error = err.Error()
+		trace.add(ex)
+		return resp, err
+	}
+	ex.Status = resp.StatusCode
+	ex.ResponseHeader = resp.Header.Clone()
+	if resp.Body != nil {
+		buf, readErr := io.ReadAll(io.LimitReader(resp.Body, httpTraceBodyLimit+1))
+		if len(buf) > httpTraceBodyLimit {
+			ex.ResponseBody = string(buf[:httpTraceBodyLimit])
+			ex.BodyTruncated = true
+		} else {
+			ex.ResponseBody = string(buf)
+		}
+		if readErr != nil {
+			ex.Error = readErr.Error()
+		}
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(buf), resp.Body), resp.Body}
+	}
+	trace.add(ex)
+	return resp, nil
+}
+
+func readTraceBody(r io.Reader) (string, bool) {
+	buf, _ := io.ReadAll(io.LimitReader(r, httpTraceBodyLimit+1))
+	if len(buf) > httpTraceBodyLimit {
+		return string(buf[:httpTraceBodyLimit]), true
+	}
+	return string(buf), false
+}
@@ -0,0 +1,239 @@
+package shared
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RetryPolicy bounds how RetryTransport retries a request.
+type RetryPolicy struct {
+	// MaxRetries is how many times a request is retried after the first
+	// attempt.
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry when the server gives
+	// no Retry-After; it doubles on each further retry, up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// MaxRetryAfter is the longest Retry-After the transport waits out. A
+	// longer one is returned to the provider as-is, so it can report the
+	// account as limited or under maintenance instead of stalling the poll.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy retries twice, waiting at most ten seconds for a
+// Retry-After, which keeps a retried fetch well inside the poll timeout.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:    2,
+	BaseDelay:     500 * time.Millisecond,
+	MaxDelay:      5 * time.Second,
+	MaxRetryAfter: 10 * time.Second,
+}
+
+// DefaultHostConcurrency is how many requests provider clients send to one
+// host at a time, across all accounts and providers.
+const DefaultHostConcurrency = 4
+
+// providerHosts is shared by every RetryTransport, so accounts of the same
+// provider queue behind each other instead of hitting its API all at once.
+var providerHosts = newHostLimiter(DefaultHostConcurrency)
+
+// NewHTTPClient returns the client providers should use: RetryTransport over
+// the default transport, with the given overall timeout. The timeout covers
+// retries and their waits.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: RetryTransport(nil, DefaultRetryPolicy)}
+}
+
+var (
+	pooledClientsMu sync.Mutex
+	pooledClients   = map[time.Duration]*http.Client{}
+)
+
+// PooledHTTPClient returns the process-wide NewHTTPClient for timeout, so
+// code that needs a client on every fetch reuses one instead of building
+// a new one per account. Callers must not modify the returned client.
+func PooledHTTPClient(timeout time.Duration) *http.Client {
+	pooledClientsMu.Lock()
+	defer pooledClientsMu.Unlock()
+	client, ok := pooledClients[timeout]
+	if !ok {
+		client = NewHTTPClient(timeout)
+		pooledClients[timeout] = client
+	}
+	return client
+}
+
+type retryTransport struct {
+	next   http.RoundTripper
+	policy RetryPolicy
+	hosts  *hostLimiter
+	conn   *ConnectivityTracker
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// RetryTransport wraps next so that requests are limited per host, held
+// back while Connectivity reports the network gone, and retried when the
+// server asks for it: 429 and 503 with a short Retry-After,
+// 502/503/504 with jittered exponential backoff, and connection errors on
+// idempotent requests. A nil next means http.DefaultTransport, or the
+// transport for the network settings attached with WithNetwork, resolved per
+// request so endpoint instrumentation installed later still applies.
+func RetryTransport(next http.RoundTripper, policy RetryPolicy) http.RoundTripper {
+	return &retryTransport{
+		next:   next,
+		policy: policy,
+		hosts:  providerHosts,
+		conn:   Connectivity,
+		now:    time.Now,
+		sleep:  sleepContext,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	next := t.next
+	if next == nil {
+		rt, err := transportForContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		next = rt
+	}
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		if err := t.conn.allow(req.URL.Host); err != nil {
+			return nil, err
+		}
+		release, err := t.hosts.acquire(ctx, req.URL.Host)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := next.RoundTrip(req)
+		release()
+		t.conn.record(req.URL.Host, err)
+
+		if attempt >= t.policy.MaxRetries || !replayable(req) {
+			return resp, explainTLSError(err)
+		}
+		wait, retry := t.retryDelay(req, resp, err, attempt)
+		if !retry {
+			return resp, explainTLSError(err)
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		if err := t.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryDelay decides whether an attempt is retried and after how long.
+func (t *retryTransport) retryDelay(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil {
+		if req.Context().Err() != nil || !idempotent(req.Method) || isCertificateError(err) {
+			return 0, false
+		}
+		return t.backoff(attempt), true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+	default:
+		return 0, false
+	}
+	if until := parseRetryAfter(resp.Header.Get("Retry-After"), t.now()); !until.IsZero() {
+		wait := max(until.Sub(t.now()), 0)
+		return wait, wait <= t.policy.MaxRetryAfter
+	}
+	// A 429 without Retry-After is usually a quota the provider reports on,
+	// not a transient blip; retrying would only spend more of it.
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return 0, false
+	}
+	return t.backoff(attempt), true
+}
+
+// backoff is BaseDelay doubled per attempt, capped at MaxDelay, with the
+// upper half jittered so clients that failed together don't retry together.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	d := t.policy.BaseDelay << attempt
+	if d <= 0 || d > t.policy.MaxDelay {
+		d = t.policy.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// hostLimiter caps concurrent requests per host.
+type hostLimiter struct {
+	limit int
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}
+
+func newHostLimiter(limit int) *hostLimiter {
+	return &hostLimiter{limit: limit, slots: make(map[string]chan struct{})}
+}
+
+// acquire waits for a slot on host and returns the function that frees it.
+// The slot is held until the response headers arrive.
+func (l *hostLimiter) acquire(ctx context.Context, host string) (func(), error) {
+	if l == nil || l.limit <= 0 {
+		return func() {}, nil
+	}
+	l.mu.Lock()
+	slot, ok := l.slots[host]
+	if !ok {
+		slot = make(chan struct{}, l.limit)
+		l.slots[host] = slot
+	}
+	l.mu.Unlock()
+	select {
+	case slot <- struct{}{}:
+		return func() { <-slot }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
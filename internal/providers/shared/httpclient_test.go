@@ -0,0 +1,182 @@
+package shared
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestRetryTransport(sleeps *[]time.Duration) *retryTransport {
+	return &retryTransport{
+		policy: DefaultRetryPolicy,
+		hosts:  newHostLimiter(DefaultHostConcurrency),
+		conn:   NewConnectivityTracker(time.Now),
+		now:    time.Now,
+		sleep: func(_ context.Context, d time.Duration) error {
+			*sleeps = append(*sleeps, d)
+			return nil
+		},
+	}
+}
+
+func TestRetryTransport_Statuses(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		wantCalls  int
+		wantStatus int
+	}{
+		{name: "short retry-after is waited out", status: http.StatusTooManyRequests, retryAfter: "2", wantCalls: 2, wantStatus: http.StatusOK},
+		{name: "long retry-after is returned", status: http.StatusTooManyRequests, retryAfter: "3600", wantCalls: 1, wantStatus: http.StatusTooManyRequests},
+		{name: "429 without retry-after is returned", status: http.StatusTooManyRequests, wantCalls: 1, wantStatus: http.StatusTooManyRequests},
+		{name: "gateway errors back off", status: http.StatusBadGateway, wantCalls: 2, wantStatus: http.StatusOK},
+		{name: "client errors are not retried", status: http.StatusUnauthorized, wantCalls: 1, wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if calls.Add(1) == 1 {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			var sleeps []time.Duration
+			client := &http.Client{Transport: newTestRetryTransport(&sleeps)}
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatalf("get: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus || int(calls.Load()) != tt.wantCalls {
+				t.Fatalf("status %d after %d calls, want %d after %d", resp.StatusCode, calls.Load(), tt.wantStatus, tt.wantCalls)
+			}
+			if tt.retryAfter == "2" && (len(sleeps) != 1 || sleeps[0] < time.Second || sleeps[0] > 2*time.Second) {
+				t.Fatalf("sleeps = %v, want one wait of about 2s", sleeps)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	client := &http.Client{Transport: newTestRetryTransport(&sleeps)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || calls.Load() != 3 {
+		t.Fatalf("status %d after %d calls, want 503 after 3", resp.StatusCode, calls.Load())
+	}
+	if len(sleeps) != 2 || sleeps[0] > DefaultRetryPolicy.BaseDelay || sleeps[1] < DefaultRetryPolicy.BaseDelay {
+		t.Fatalf("sleeps = %v, want jittered exponential backoff", sleeps)
+	}
+}
+
+func TestRetryTransport_ReplaysBody(t *testing.T) {
+	var bodies []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(b))
+		n := len(bodies)
+		mu.Unlock()
+		if n == 1 {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	client := &http.Client{Transport: newTestRetryTransport(&sleeps)}
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"q":1}`))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	resp.Body.Close()
+	if len(bodies) != 2 || bodies[0] != `{"q":1}` || bodies[1] != `{"q":1}` {
+		t.Fatalf("bodies = %q, want the same body twice", bodies)
+	}
+}
+
+func TestRetryTransport_StopsOnCanceledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	rt := RetryTransport(nil, DefaultRetryPolicy)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	started := time.Now()
+	_, err := (&http.Client{Transport: rt}).Do(req)
+	if err == nil {
+		t.Fatal("expected the context deadline to end the backoff")
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("backoff ignored the context, took %s", elapsed)
+	}
+}
+
+func TestHostLimiter_CapsConcurrency(t *testing.T) {
+	limiter := newHostLimiter(2)
+	var inFlight, peak atomic.Int32
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := limiter.acquire(context.Background(), "api.example.com")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			n := inFlight.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			inFlight.Add(-1)
+			release()
+		}()
+	}
+	wg.Wait()
+	if peak.Load() > 2 {
+		t.Fatalf("peak concurrency = %d, want at most 2", peak.Load())
+	}
+
+	// Other hosts have their own slots.
+	release, err := limiter.acquire(context.Background(), "other.example.com")
+	if err != nil {
+		t.Fatalf("acquire other host: %v", err)
+	}
+	release()
+}
@@ -40,7 +40,7 @@ var CodeStatsCompactLabels = map[string]string{
 func CodingToolHidePrefixes() []string {
 	return []string{
 		"model_", "source_", "client_", "mode_", "interface_",
-		"subagent_", "lang_", "tool_",
+		"subagent_", "lang_", "tool_", "live_session_",
 	}
 }
 
@@ -0,0 +1,74 @@
+package shared
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// maintenanceBodyLimit caps how much of a 503 body is inspected for a
+// maintenance notice; status pages can be large HTML documents.
+const maintenanceBodyLimit = 64 << 10
+
+var maintenanceMarkers = []string{
+	"maintenance",
+	"scheduled downtime",
+	"planned downtime",
+}
+
+// IsMaintenanceResponse reports whether a status code and body announce
+// planned downtime rather than an outage: a 503 whose body mentions
+// maintenance.
+func IsMaintenanceResponse(statusCode int, body []byte) bool {
+	if statusCode != http.StatusServiceUnavailable {
+		return false
+	}
+	lower := strings.ToLower(string(body))
+	for _, marker := range maintenanceMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaintenanceFromResponse returns a *core.MaintenanceError when resp is a
+// maintenance 503, nil otherwise. The inspected body is put back, so callers
+// can still read it. Retry-After, in seconds or as an HTTP date, becomes the
+// window's end.
+func MaintenanceFromResponse(resp *http.Response) *core.MaintenanceError {
+	if resp == nil || resp.StatusCode != http.StatusServiceUnavailable || resp.Body == nil {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maintenanceBodyLimit))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+	if !IsMaintenanceResponse(resp.StatusCode, body) {
+		return nil
+	}
+	return &core.MaintenanceError{
+		Message: "provider under scheduled maintenance (HTTP 503)",
+		Until:   parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+func parseRetryAfter(value string, now time.Time) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs > 0 {
+		return now.Add(time.Duration(secs) * time.Second)
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return at
+	}
+	return time.Time{}
+}
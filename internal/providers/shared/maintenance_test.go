@@ -0,0 +1,75 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestIsMaintenanceResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   bool
+	}{
+		{"503 maintenance", http.StatusServiceUnavailable, `{"error":"Service under Maintenance"}`, true},
+		{"503 planned downtime", http.StatusServiceUnavailable, "<h1>Planned downtime</h1>", true},
+		{"503 overloaded", http.StatusServiceUnavailable, `{"error":"overloaded"}`, false},
+		{"500 maintenance", http.StatusInternalServerError, "maintenance", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsMaintenanceResponse(tt.status, []byte(tt.body)); got != tt.want {
+				t.Errorf("IsMaintenanceResponse = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyStatusFromResponse_Maintenance(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Retry-After": []string{"1800"}},
+		Body:       io.NopCloser(strings.NewReader("scheduled maintenance in progress")),
+	}
+	snap := core.NewUsageSnapshot("test", "acct-1")
+	ApplyStatusFromResponse(resp, &snap)
+
+	if snap.Status != core.StatusMaintenance {
+		t.Fatalf("Status = %q, want %q", snap.Status, core.StatusMaintenance)
+	}
+	until := snap.Resets[core.MaintenanceResetKey]
+	if d := time.Until(until); d < 29*time.Minute || d > 31*time.Minute {
+		t.Fatalf("maintenance end = %v, want ~30m from now", until)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "scheduled maintenance in progress" {
+		t.Fatalf("body not restored: %q", body)
+	}
+}
+
+func TestFetchJSON_MaintenanceError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"message":"We are performing scheduled maintenance"}`))
+	}))
+	defer srv.Close()
+
+	_, _, err := FetchJSON(context.Background(), srv.URL, "key", nil, srv.Client())
+	var maint *core.MaintenanceError
+	if !errors.As(err, &maint) {
+		t.Fatalf("err = %v, want *core.MaintenanceError", err)
+	}
+	snap := core.FetchErrorSnapshot("test", "acct-1", time.Now(), err)
+	if snap.Status != core.StatusMaintenance {
+		t.Fatalf("Status = %q, want %q", snap.Status, core.StatusMaintenance)
+	}
+}
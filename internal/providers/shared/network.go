@@ -0,0 +1,140 @@
+package shared
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+type networkKey struct{}
+
+// WithNetwork returns a context whose provider requests go through the proxy
+// and TLS settings in n. Poll loops attach the account's effective settings
+// (see core.ApplyNetwork) to each fetch context.
+func WithNetwork(ctx context.Context, n *core.NetworkConfig) context.Context {
+	if n == nil || n.IsZero() {
+		return ctx
+	}
+	return context.WithValue(ctx, networkKey{}, *n)
+}
+
+// NetworkFromContext returns the network settings attached to ctx.
+func NetworkFromContext(ctx context.Context) (core.NetworkConfig, bool) {
+	n, ok := ctx.Value(networkKey{}).(core.NetworkConfig)
+	return n, ok
+}
+
+// baseTransport is a pristine copy of the standard transport, taken before
+// endpoint instrumentation wraps http.DefaultTransport, for building
+// per-network transports.
+var baseTransport = func() *http.Transport {
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		return t.Clone()
+	}
+	return &http.Transport{Proxy: http.ProxyFromEnvironment}
+}()
+
+var (
+	networkTransportsMu sync.Mutex
+	networkTransports   = make(map[core.NetworkConfig]http.RoundTripper)
+	warnSkipVerify      sync.Once
+)
+
+// transportForContext is the transport for a request: http.DefaultTransport,
+// or an instrumented transport built from the network settings on ctx.
+// Transports are cached per settings so connections are reused across polls.
+func transportForContext(ctx context.Context) (http.RoundTripper, error) {
+	n, ok := NetworkFromContext(ctx)
+	if !ok {
+		return http.DefaultTransport, nil
+	}
+	networkTransportsMu.Lock()
+	defer networkTransportsMu.Unlock()
+	if rt, ok := networkTransports[n]; ok {
+		return rt, nil
+	}
+	t, err := NewNetworkTransport(n)
+	if err != nil {
+		return nil, err
+	}
+	rt := InstrumentTransport(t)
+	networkTransports[n] = rt
+	return rt, nil
+}
+
+// NewNetworkTransport builds a transport honouring n: its proxy (none for
+// core.ProxyDirect, the proxy environment when unset), its CA bundle on top
+// of the system roots, and skip-verify, which is logged as a warning once
+// per process. SOCKS5 proxies are sent hostnames, so names only resolvable
+// on the proxy's side of the network still work.
+func NewNetworkTransport(n core.NetworkConfig) (*http.Transport, error) {
+	t := baseTransport.Clone()
+	switch proxy := strings.TrimSpace(n.Proxy); proxy {
+	case "":
+	case core.ProxyDirect:
+		t.Proxy = nil
+	default:
+		u, err := url.Parse(proxy)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("network: invalid proxy %q: want a URL such as http://proxy.corp:3128 or %q", proxy, core.ProxyDirect)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("network: unsupported proxy scheme %q (use http, https or socks5)", u.Scheme)
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if t.TLSClientConfig != nil {
+		tlsConfig = t.TLSClientConfig.Clone()
+	}
+	if bundle := strings.TrimSpace(n.CABundle); bundle != "" {
+		pem, err := os.ReadFile(ExpandHome(bundle))
+		if err != nil {
+			return nil, fmt.Errorf("network: reading ca_bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("network: ca_bundle %s holds no PEM certificates", bundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if n.InsecureSkipVerify {
+		warnSkipVerify.Do(func() {
+			log.Printf("WARNING: network.insecure_skip_verify is on — TLS certificates are NOT verified and API keys are exposed to anyone on the network path. Set network.ca_bundle to your proxy's CA instead.")
+		})
+		tlsConfig.InsecureSkipVerify = true
+	}
+	t.TLSClientConfig = tlsConfig
+	return t, nil
+}
+
+// explainTLSError adds a hint to certificate errors, which behind a
+// TLS-intercepting proxy are otherwise opaque.
+func explainTLSError(err error) error {
+	if isCertificateError(err) {
+		return fmt.Errorf("%w (if a corporate proxy intercepts TLS, set network.ca_bundle to its CA certificate)", err)
+	}
+	return err
+}
+
+func isCertificateError(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var verify *tls.CertificateVerificationError
+	return errors.As(err, &unknownAuthority) || errors.As(err, &verify)
+}
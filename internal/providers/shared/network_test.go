@@ -0,0 +1,219 @@
+package shared
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestNetwork_RoutesThroughProxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		_, _ = io.WriteString(w, "via proxy")
+	}))
+	defer proxy.Close()
+
+	ctx := WithNetwork(context.Background(), &core.NetworkConfig{Proxy: proxy.URL})
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://api.provider.test/v1/usage", nil)
+	resp, err := NewHTTPClient(5 * time.Second).Do(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "via proxy" || proxiedHost != "api.provider.test" {
+		t.Fatalf("body %q, proxied host %q; want the request to reach the proxy", body, proxiedHost)
+	}
+}
+
+func TestNetwork_RoutesThroughSOCKS5(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "hello from "+r.Host)
+	}))
+	defer backend.Close()
+	socks := startSOCKS5(t, backend.Listener.Addr().String())
+
+	for _, scheme := range []string{"socks5", "socks5h"} {
+		t.Run(scheme, func(t *testing.T) {
+			ctx := WithNetwork(context.Background(), &core.NetworkConfig{Proxy: scheme + "://" + socks.addr})
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://api.moonshot.test/v1/users/me/balance", nil)
+			resp, err := NewHTTPClient(5 * time.Second).Do(req)
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != "hello from api.moonshot.test" {
+				t.Fatalf("body = %q", body)
+			}
+			if got := socks.lastTarget(); got != "api.moonshot.test:80" {
+				t.Fatalf("SOCKS CONNECT target = %q, want the unresolved hostname", got)
+			}
+		})
+	}
+}
+
+func TestNewNetworkTransport_Direct(t *testing.T) {
+	tr, err := NewNetworkTransport(core.NetworkConfig{Proxy: core.ProxyDirect})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Proxy != nil {
+		t.Fatal("direct should bypass the proxy environment")
+	}
+}
+
+type socks5Server struct {
+	addr    string
+	mu      sync.Mutex
+	targets []string
+}
+
+func (s *socks5Server) lastTarget() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.targets) == 0 {
+		return ""
+	}
+	return s.targets[len(s.targets)-1]
+}
+
+// startSOCKS5 runs a minimal no-auth SOCKS5 server that records each CONNECT
+// target and tunnels it to backend.
+func startSOCKS5(t *testing.T, backend string) *socks5Server {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	s := &socks5Server{addr: ln.Addr().String()}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn, backend)
+		}
+	}()
+	return s
+}
+
+func (s *socks5Server) serve(conn net.Conn, backend string) {
+	defer conn.Close()
+	buf := make([]byte, 256)
+	if _, err := io.ReadFull(conn, buf[:2]); err != nil {
+		return
+	}
+	if _, err := io.ReadFull(conn, buf[:buf[1]]); err != nil {
+		return
+	}
+	_, _ = conn.Write([]byte{5, 0})
+	if _, err := io.ReadFull(conn, buf[:4]); err != nil {
+		return
+	}
+	var host string
+	switch buf[3] {
+	case 1:
+		_, _ = io.ReadFull(conn, buf[:4])
+		host = net.IP(buf[:4]).String()
+	case 3:
+		_, _ = io.ReadFull(conn, buf[:1])
+		n := int(buf[0])
+		_, _ = io.ReadFull(conn, buf[:n])
+		host = string(buf[:n])
+	default:
+		return
+	}
+	if _, err := io.ReadFull(conn, buf[:2]); err != nil {
+		return
+	}
+	port := binary.BigEndian.Uint16(buf[:2])
+	s.mu.Lock()
+	s.targets = append(s.targets, net.JoinHostPort(host, strconv.Itoa(int(port))))
+	s.mu.Unlock()
+
+	upstream, err := net.Dial("tcp", backend)
+	if err != nil {
+		_, _ = conn.Write([]byte{5, 1, 0, 1, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	defer upstream.Close()
+	_, _ = conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+	go func() { _, _ = io.Copy(upstream, conn) }()
+	_, _ = io.Copy(conn, upstream)
+}
+
+func TestNetwork_CABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	get := func(n *core.NetworkConfig) error {
+		ctx := WithNetwork(context.Background(), n)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		resp, err := NewHTTPClient(5 * time.Second).Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	err := get(nil)
+	if err == nil || !strings.Contains(err.Error(), "network.ca_bundle") {
+		t.Fatalf("untrusted certificate error = %v, want a ca_bundle hint", err)
+	}
+
+	bundle := filepath.Join(t.TempDir(), "corp-ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := get(&core.NetworkConfig{CABundle: bundle}); err != nil {
+		t.Fatalf("with ca_bundle: %v", err)
+	}
+	if err := get(&core.NetworkConfig{InsecureSkipVerify: true}); err != nil {
+		t.Fatalf("with insecure_skip_verify: %v", err)
+	}
+}
+
+func TestNewNetworkTransport_Errors(t *testing.T) {
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		network core.NetworkConfig
+		want    string
+	}{
+		{name: "proxy without host", network: core.NetworkConfig{Proxy: "proxy.corp"}, want: "invalid proxy"},
+		{name: "unsupported scheme", network: core.NetworkConfig{Proxy: "ftp://proxy.corp:21"}, want: "unsupported proxy scheme"},
+		{name: "missing bundle", network: core.NetworkConfig{CABundle: filepath.Join(t.TempDir(), "missing.pem")}, want: "reading ca_bundle"},
+		{name: "bundle without certificates", network: core.NetworkConfig{CABundle: notPEM}, want: "no PEM certificates"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNetworkTransport(tt.network)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
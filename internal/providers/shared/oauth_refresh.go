@@ -0,0 +1,109 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// DefaultRefreshSkew is how long before expiry a cached access token is
+// treated as expired, so a token never lapses between the check and the
+// request that uses it.
+const DefaultRefreshSkew = 5 * time.Minute
+
+// OAuthToken is an access token and the moment it stops working. A zero
+// Expiry means the issuer did not say.
+type OAuthToken struct {
+	AccessToken string
+	Expiry      time.Time
+}
+
+// ValidAt reports whether the token is usable at now with skew to spare.
+func (t OAuthToken) ValidAt(now time.Time, skew time.Duration) bool {
+	if t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || now.Add(skew).Before(t.Expiry)
+}
+
+// TokenRefresher is implemented per provider: it exchanges whatever
+// long-lived grant the provider owns (a refresh token, a CLI session) for a
+// fresh access token. Return an *AuthError when the grant itself is no
+// longer accepted, so the snapshot reports StatusAuth instead of a transient
+// error.
+type TokenRefresher interface {
+	Refresh(ctx context.Context) (OAuthToken, error)
+}
+
+// AuthError is a refresh failure only the user can fix. Reason says what
+// went wrong, e.g. "OAuth refresh token revoked or expired"; Action is the
+// instruction shown after it in the snapshot message, e.g. "run `gemini` to
+// re-authenticate".
+type AuthError struct {
+	Reason string
+	Action string
+	Err    error
+}
+
+func (e *AuthError) Error() string {
+	if e.Err == nil {
+		return "re-authentication required"
+	}
+	return e.Err.Error()
+}
+
+func (e *AuthError) Unwrap() error { return e.Err }
+
+// ApplyAuthError marks snap as StatusAuth with the error's action when err
+// is (or wraps) an *AuthError. It reports whether it did.
+func ApplyAuthError(snap *core.UsageSnapshot, err error) bool {
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		return false
+	}
+	snap.Status = core.StatusAuth
+	snap.Message = authErr.Reason
+	if snap.Message == "" {
+		snap.Message = "re-authentication required"
+	}
+	if authErr.Action != "" {
+		snap.Message += " — " + authErr.Action
+	}
+	return true
+}
+
+// TokenSource hands out an access token, calling its TokenRefresher only
+// when the current token is missing or inside the refresh skew. Safe for
+// concurrent use; concurrent callers share one refresh.
+type TokenSource struct {
+	mu        sync.Mutex
+	refresher TokenRefresher
+	token     OAuthToken
+	skew      time.Duration
+	now       func() time.Time
+}
+
+// NewTokenSource seeds the source with the token the external tool last
+// stored, which is used as-is while it stays valid.
+func NewTokenSource(refresher TokenRefresher, initial OAuthToken) *TokenSource {
+	return &TokenSource{refresher: refresher, token: initial, skew: DefaultRefreshSkew, now: time.Now}
+}
+
+// Token returns a valid access token and whether a refresh was needed to
+// get it.
+func (s *TokenSource) Token(ctx context.Context) (OAuthToken, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token.ValidAt(s.now(), s.skew) {
+		return s.token, false, nil
+	}
+	tok, err := s.refresher.Refresh(ctx)
+	if err != nil {
+		return OAuthToken{}, false, err
+	}
+	s.token = tok
+	return tok, true, nil
+}
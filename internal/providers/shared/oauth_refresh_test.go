@@ -0,0 +1,71 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+type fakeRefresher struct {
+	calls int
+	tok   OAuthToken
+	err   error
+}
+
+func (f *fakeRefresher) Refresh(context.Context) (OAuthToken, error) {
+	f.calls++
+	return f.tok, f.err
+}
+
+func TestTokenSource_RefreshesOnlyNearExpiry(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	ref := &fakeRefresher{tok: OAuthToken{AccessToken: "fresh", Expiry: now.Add(time.Hour)}}
+	src := NewTokenSource(ref, OAuthToken{AccessToken: "stored", Expiry: now.Add(30 * time.Minute)})
+	src.now = func() time.Time { return now }
+
+	tok, refreshed, err := src.Token(context.Background())
+	if err != nil || refreshed || tok.AccessToken != "stored" || ref.calls != 0 {
+		t.Fatalf("valid stored token: tok=%q refreshed=%v calls=%d err=%v", tok.AccessToken, refreshed, ref.calls, err)
+	}
+
+	now = now.Add(27 * time.Minute) // inside the 5m skew
+	tok, refreshed, err = src.Token(context.Background())
+	if err != nil || !refreshed || tok.AccessToken != "fresh" || ref.calls != 1 {
+		t.Fatalf("near expiry: tok=%q refreshed=%v calls=%d err=%v", tok.AccessToken, refreshed, ref.calls, err)
+	}
+
+	if _, refreshed, _ = src.Token(context.Background()); refreshed || ref.calls != 1 {
+		t.Fatalf("refreshed token was not reused (calls=%d)", ref.calls)
+	}
+}
+
+func TestApplyAuthError(t *testing.T) {
+	snap := core.NewUsageSnapshot("gemini_cli", "gemini")
+	if ApplyAuthError(&snap, errors.New("connection reset")) {
+		t.Fatal("plain errors must not mark the snapshot as auth-required")
+	}
+	if snap.Status == core.StatusAuth {
+		t.Fatalf("status = %q after plain error", snap.Status)
+	}
+
+	err := fmt.Errorf("token refresh: %w", &AuthError{
+		Reason: "OAuth refresh token revoked or expired",
+		Action: "run `gemini` to re-authenticate",
+		Err:    errors.New("invalid_grant"),
+	})
+	if !ApplyAuthError(&snap, err) {
+		t.Fatal("wrapped AuthError not recognised")
+	}
+	if snap.Status != core.StatusAuth || snap.Message != "OAuth refresh token revoked or expired — run `gemini` to re-authenticate" {
+		t.Fatalf("status=%q message=%q", snap.Status, snap.Message)
+	}
+
+	ApplyAuthError(&snap, &AuthError{Action: "run `gh auth login` to re-authenticate"})
+	if snap.Message != "re-authentication required — run `gh auth login` to re-authenticate" {
+		t.Fatalf("message without a reason = %q", snap.Message)
+	}
+}
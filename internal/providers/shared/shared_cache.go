@@ -0,0 +1,131 @@
+package shared
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SharedCache holds values that are the same for every account of a
+// provider — model catalogs, pricing tables, records that never change once
+// written — so that accounts polled together fetch them once. Concurrent
+// Gets of a missing key share a single load. Failed loads are not cached.
+type SharedCache[V any] struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu       sync.Mutex
+	entries  map[string]sharedCacheEntry[V]
+	inflight map[string]*sharedCacheCall[V]
+}
+
+type sharedCacheEntry[V any] struct {
+	value    V
+	storedAt time.Time
+}
+
+type sharedCacheCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// NewSharedCache returns a cache whose entries expire after ttl (never when
+// ttl <= 0). Once maxEntries are held, expired entries are dropped and then
+// the oldest; maxEntries <= 0 means unbounded.
+func NewSharedCache[V any](ttl time.Duration, maxEntries int) *SharedCache[V] {
+	return &SharedCache[V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]sharedCacheEntry[V]),
+		inflight:   make(map[string]*sharedCacheCall[V]),
+	}
+}
+
+// Get returns the cached value for key, calling load when it is missing or
+// expired. Callers that arrive while another caller's load is running wait
+// for it and get its result, including its error.
+func (c *SharedCache[V]) Get(ctx context.Context, key string, load func(context.Context) (V, error)) (V, error) {
+	c.mu.Lock()
+	if value, ok := c.lookupLocked(key); ok {
+		c.mu.Unlock()
+		return value, nil
+	}
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.value, call.err
+		case <-ctx.Done():
+			var zero V
+			return zero, ctx.Err()
+		}
+	}
+	call := &sharedCacheCall[V]{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+
+	call.value, call.err = load(ctx)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if call.err == nil {
+		c.storeLocked(key, call.value)
+	}
+	c.mu.Unlock()
+	close(call.done)
+	return call.value, call.err
+}
+
+// Peek returns the cached value for key without loading it.
+func (c *SharedCache[V]) Peek(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lookupLocked(key)
+}
+
+// Len reports how many entries are held, expired or not.
+func (c *SharedCache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func (c *SharedCache[V]) lookupLocked(key string) (V, bool) {
+	entry, ok := c.entries[key]
+	if !ok || c.expired(entry) {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (c *SharedCache[V]) storeLocked(key string, value V) {
+	if _, ok := c.entries[key]; !ok && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		c.evictLocked()
+	}
+	c.entries[key] = sharedCacheEntry[V]{value: value, storedAt: c.now()}
+}
+
+func (c *SharedCache[V]) evictLocked() {
+	oldestKey := ""
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if c.expired(entry) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.storedAt.Before(oldest) {
+			oldestKey, oldest = key, entry.storedAt
+		}
+	}
+	if len(c.entries) >= c.maxEntries && oldestKey != "" {
+		delete(c.entries, oldestKey)
+	}
+}
+
+func (c *SharedCache[V]) expired(entry sharedCacheEntry[V]) bool {
+	return c.ttl > 0 && c.now().Sub(entry.storedAt) >= c.ttl
+}
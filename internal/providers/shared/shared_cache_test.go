@@ -0,0 +1,82 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSharedCache_ConcurrentGetsShareOneLoad(t *testing.T) {
+	cache := NewSharedCache[string](time.Minute, 0)
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(context.Context) (string, error) {
+		loads.Add(1)
+		<-release
+		return "catalog", nil
+	}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got, err := cache.Get(context.Background(), "models", load); err != nil || got != "catalog" {
+				t.Errorf("Get = %q, %v", got, err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := loads.Load(); got != 1 {
+		t.Fatalf("loads = %d, want 1", got)
+	}
+}
+
+func TestSharedCache_ExpiresAndSkipsErrors(t *testing.T) {
+	now := time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)
+	cache := NewSharedCache[int](time.Minute, 0)
+	cache.now = func() time.Time { return now }
+
+	if _, err := cache.Get(context.Background(), "k", func(context.Context) (int, error) { return 0, errors.New("down") }); err == nil {
+		t.Fatal("expected the load error")
+	}
+	if _, ok := cache.Peek("k"); ok {
+		t.Fatal("failed load should not be cached")
+	}
+
+	calls := 0
+	load := func(context.Context) (int, error) { calls++; return calls, nil }
+	if got, _ := cache.Get(context.Background(), "k", load); got != 1 {
+		t.Fatalf("first Get = %d, want 1", got)
+	}
+	if got, _ := cache.Get(context.Background(), "k", load); got != 1 {
+		t.Fatalf("cached Get = %d, want 1", got)
+	}
+	now = now.Add(time.Minute)
+	if got, _ := cache.Get(context.Background(), "k", load); got != 2 {
+		t.Fatalf("Get after expiry = %d, want 2", got)
+	}
+}
+
+func TestSharedCache_EvictsOldestWhenFull(t *testing.T) {
+	now := time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)
+	cache := NewSharedCache[string](0, 2)
+	cache.now = func() time.Time { now = now.Add(time.Second); return now }
+	for _, key := range []string{"a", "b", "c"} {
+		if _, err := cache.Get(context.Background(), key, func(context.Context) (string, error) { return key, nil }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", cache.Len())
+	}
+	if _, ok := cache.Peek("a"); ok {
+		t.Fatal("oldest entry should have been evicted")
+	}
+}
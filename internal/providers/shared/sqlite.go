@@ -0,0 +1,277 @@
+package shared
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteBusyTimeoutMS is how long SQLite's own busy handler waits on a lock
+// held by the host tool before a statement fails with SQLITE_BUSY.
+var sqliteBusyTimeoutMS = 5000
+
+// sqliteRetryBackoff is the wait before each retry of a read that failed
+// with SQLITE_BUSY or SQLITE_LOCKED. Its length is the number of retries.
+var sqliteRetryBackoff = []time.Duration{100 * time.Millisecond, 250 * time.Millisecond, 600 * time.Millisecond}
+
+// OpenSQLiteReadOnly opens a database another process may be writing to.
+//
+// The database is opened in place with mode=ro and a busy timeout. That
+// connection is WAL-aware: unlike immutable=1 it reads commits that still
+// sit in the -wal file. Only when it can't read the schema — the host tool
+// still holds its lock after RetrySQLiteBusy gives up, or a WAL database's
+// shared-memory index is unusable read-only (e.g. a read-only directory
+// without a -shm file) — is the database read from a snapshot copy with
+// the WAL folded in. Snapshots are cached per path until the source
+// changes.
+//
+// The returned handle has already read the schema, and is capped at one
+// connection since provider queries are short and serial.
+func OpenSQLiteReadOnly(ctx context.Context, path string) (*sql.DB, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("empty sqlite path")
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	db, err := openSQLiteDSN(ctx, sqliteReadOnlyDSN(path, false))
+	if err == nil || ctx.Err() != nil {
+		return db, err
+	}
+	if IsSQLiteBusy(err) {
+		return openSQLiteSnapshot(ctx, path)
+	}
+	if _, walErr := os.Stat(path + "-wal"); walErr == nil {
+		return openSQLiteSnapshot(ctx, path)
+	}
+	return nil, err
+}
+
+// IsSQLiteBusy reports whether err is SQLite lock contention — SQLITE_BUSY or
+// SQLITE_LOCKED — which clears once the writer commits.
+func IsSQLiteBusy(err error) bool {
+	if err == nil {
+		return false
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "database is locked") || strings.Contains(msg, "database table is locked")
+}
+
+// RetrySQLiteBusy runs fn, retrying with backoff while it fails with
+// IsSQLiteBusy. The busy timeout already covers ordinary lock waits; this
+// covers the cases SQLite reports immediately, such as a WAL being
+// recovered or a checkpoint holding the index. fn must be safe to re-run.
+func RetrySQLiteBusy(ctx context.Context, fn func() error) error {
+	err := fn()
+	for _, wait := range sqliteRetryBackoff {
+		if !IsSQLiteBusy(err) {
+			return err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		err = fn()
+	}
+	return err
+}
+
+func sqliteReadOnlyDSN(path string, immutable bool) string {
+	encoded := (&url.URL{Path: filepath.ToSlash(path)}).EscapedPath()
+	dsn := fmt.Sprintf("file:%s?mode=ro&_busy_timeout=%d", encoded, sqliteBusyTimeoutMS)
+	if immutable {
+		dsn += "&immutable=1"
+	}
+	return dsn
+}
+
+func openSQLiteDSN(ctx context.Context, dsn string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	// Opening takes no lock; reading the schema does, so contention and an
+	// unusable WAL index surface here rather than in the caller's query.
+	probe := func() error {
+		var n int
+		return db.QueryRowContext(ctx, `SELECT COUNT(*) FROM sqlite_master`).Scan(&n)
+	}
+	if err := RetrySQLiteBusy(ctx, probe); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+type sqliteSnapshot struct {
+	path   string
+	db     FileSignature
+	wal    FileSignature
+	hasWAL bool
+}
+
+var (
+	sqliteSnapshotMu sync.Mutex
+	sqliteSnapshots  = map[string]sqliteSnapshot{}
+)
+
+// openSQLiteSnapshot opens a private copy of the database at path, refreshing
+// the cached copy when the source or its WAL changed since it was taken.
+// The copy is nobody else's, so it is opened immutable and never waits on a
+// lock.
+func openSQLiteSnapshot(ctx context.Context, path string) (*sql.DB, error) {
+	sqliteSnapshotMu.Lock()
+	snapPath, err := refreshSQLiteSnapshot(ctx, path)
+	sqliteSnapshotMu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("snapshot %s: %w", filepath.Base(path), err)
+	}
+	return openSQLiteDSN(ctx, sqliteReadOnlyDSN(snapPath, true))
+}
+
+func refreshSQLiteSnapshot(ctx context.Context, path string) (string, error) {
+	dbSig, err := StatSignature(path)
+	if err != nil {
+		return "", err
+	}
+	walSig, walErr := StatSignature(path + "-wal")
+	hasWAL := walErr == nil
+	if cached, ok := sqliteSnapshots[path]; ok && cached.db.Equal(dbSig) && cached.hasWAL == hasWAL && (!hasWAL || cached.wal.Equal(walSig)) {
+		if _, err := os.Stat(cached.path); err == nil {
+			return cached.path, nil
+		}
+	}
+
+	dir := filepath.Join(os.TempDir(), "openusage-sqlite")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(path))
+	target := filepath.Join(dir, hex.EncodeToString(sum[:8])+".db")
+
+	// A file copy is only consistent if nothing committed while it ran, so
+	// copy until the source signature holds still across one pass.
+	var tmp string
+	err = RetrySQLiteBusy(ctx, func() error {
+		if tmp != "" {
+			removeSQLiteFiles(tmp)
+			tmp = ""
+		}
+		copied, copyErr := copySQLiteFiles(path, dir, hasWAL)
+		if copyErr != nil {
+			return copyErr
+		}
+		tmp = copied
+		after, statErr := StatSignature(path)
+		if statErr != nil {
+			return statErr
+		}
+		afterWAL, _ := StatSignature(path + "-wal")
+		if !after.Equal(dbSig) || (hasWAL && !afterWAL.Equal(walSig)) {
+			dbSig, walSig = after, afterWAL
+			return sqlite3.Error{Code: sqlite3.ErrBusy}
+		}
+		return nil
+	})
+	if err != nil {
+		if tmp != "" {
+			removeSQLiteFiles(tmp)
+		}
+		return "", err
+	}
+	if hasWAL {
+		if err := foldSQLiteWAL(ctx, tmp); err != nil {
+			removeSQLiteFiles(tmp)
+			return "", err
+		}
+	}
+	if err := os.Rename(tmp, target); err != nil {
+		removeSQLiteFiles(tmp)
+		return "", err
+	}
+	sqliteSnapshots[path] = sqliteSnapshot{path: target, db: dbSig, wal: walSig, hasWAL: hasWAL}
+	return target, nil
+}
+
+// copySQLiteFiles copies the database, and its WAL when present, to a fresh
+// temp file in dir and returns the copy's path.
+func copySQLiteFiles(path, dir string, withWAL bool) (string, error) {
+	out, err := os.CreateTemp(dir, "snapshot-*.db")
+	if err != nil {
+		return "", err
+	}
+	tmp := out.Name()
+	out.Close()
+	if err := copyFile(path, tmp); err != nil {
+		removeSQLiteFiles(tmp)
+		return "", err
+	}
+	if withWAL {
+		if err := copyFile(path+"-wal", tmp+"-wal"); err != nil && !os.IsNotExist(err) {
+			removeSQLiteFiles(tmp)
+			return "", err
+		}
+	}
+	return tmp, nil
+}
+
+// foldSQLiteWAL checkpoints a copied WAL into the copied database and drops
+// it, leaving a single self-contained file that is safe to open immutable.
+func foldSQLiteWAL(ctx context.Context, path string) error {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, `PRAGMA wal_checkpoint(TRUNCATE)`); err != nil {
+		return fmt.Errorf("checkpoint: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, `PRAGMA journal_mode=DELETE`); err != nil {
+		return fmt.Errorf("leave wal mode: %w", err)
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func removeSQLiteFiles(path string) {
+	for _, suffix := range []string{"", "-wal", "-shm", "-journal"} {
+		_ = os.Remove(path + suffix)
+	}
+}
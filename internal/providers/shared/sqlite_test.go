@@ -0,0 +1,140 @@
+package shared
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// liveWALDB creates a WAL-mode database and returns a writer that keeps it
+// open, so commits stay in the -wal file the way they do under a live IDE.
+func liveWALDB(t *testing.T) (string, *sql.DB) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "state.vscdb")
+	writer, err := sql.Open("sqlite3", path+"?_journal_mode=WAL")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer.SetMaxOpenConns(1)
+	t.Cleanup(func() { writer.Close() })
+	for _, stmt := range []string{
+		`PRAGMA wal_autocheckpoint = 0`,
+		`CREATE TABLE ItemTable (key TEXT PRIMARY KEY, value TEXT)`,
+		`INSERT INTO ItemTable VALUES ('a', '1'), ('b', '2')`,
+	} {
+		if _, err := writer.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	return path, writer
+}
+
+func countItems(t *testing.T, path string) int {
+	t.Helper()
+	db, err := OpenSQLiteReadOnly(context.Background(), path)
+	if err != nil {
+		t.Fatalf("OpenSQLiteReadOnly: %v", err)
+	}
+	defer db.Close()
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM ItemTable`).Scan(&n); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO ItemTable VALUES ('x', 'y')`); err == nil {
+		t.Error("write succeeded on a read-only handle")
+	}
+	return n
+}
+
+func TestOpenSQLiteReadOnly_ReadsUncheckpointedWAL(t *testing.T) {
+	path, writer := liveWALDB(t)
+	if got := countItems(t, path); got != 2 {
+		t.Fatalf("count = %d, want 2 (rows still in the WAL)", got)
+	}
+	if _, err := writer.Exec(`INSERT INTO ItemTable VALUES ('c', '3')`); err != nil {
+		t.Fatal(err)
+	}
+	if got := countItems(t, path); got != 3 {
+		t.Fatalf("count after commit = %d, want 3", got)
+	}
+}
+
+func TestOpenSQLiteReadOnly_SnapshotsWhileLocked(t *testing.T) {
+	prevTimeout, prevBackoff := sqliteBusyTimeoutMS, sqliteRetryBackoff
+	sqliteBusyTimeoutMS = 1
+	sqliteRetryBackoff = []time.Duration{time.Millisecond}
+	t.Cleanup(func() { sqliteBusyTimeoutMS, sqliteRetryBackoff = prevTimeout, prevBackoff })
+
+	path := filepath.Join(t.TempDir(), "state.vscdb")
+	writer, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer.SetMaxOpenConns(1)
+	t.Cleanup(func() { writer.Close() })
+	for _, stmt := range []string{
+		`CREATE TABLE ItemTable (key TEXT PRIMARY KEY, value TEXT)`,
+		`INSERT INTO ItemTable VALUES ('a', '1'), ('b', '2')`,
+	} {
+		if _, err := writer.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if got := countItems(t, path); got != 2 {
+		t.Fatalf("unlocked count = %d, want 2", got)
+	}
+	if _, ok := sqliteSnapshots[path]; ok {
+		t.Fatal("an unlocked database was snapshotted")
+	}
+
+	// An exclusive lock outlasts the busy timeout and every retry, so the
+	// read falls back to a copy.
+	if _, err := writer.Exec(`BEGIN EXCLUSIVE`); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _, _ = writer.Exec(`ROLLBACK`) })
+	if got := countItems(t, path); got != 2 {
+		t.Fatalf("snapshot count = %d, want 2", got)
+	}
+	snap := sqliteSnapshots[path].path
+	if snap == "" || snap == path {
+		t.Fatalf("read did not go through a snapshot: %q", snap)
+	}
+	t.Cleanup(func() { removeSQLiteFiles(snap) })
+}
+
+func TestRetrySQLiteBusy(t *testing.T) {
+	prev := sqliteRetryBackoff
+	sqliteRetryBackoff = []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond}
+	t.Cleanup(func() { sqliteRetryBackoff = prev })
+
+	calls := 0
+	err := RetrySQLiteBusy(context.Background(), func() error {
+		calls++
+		if calls < 3 {
+			return sqlite3.Error{Code: sqlite3.ErrBusy}
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("busy then ok: err = %v, calls = %d", err, calls)
+	}
+
+	calls = 0
+	other := errors.New("no such table: ItemTable")
+	if err := RetrySQLiteBusy(context.Background(), func() error { calls++; return other }); err != other || calls != 1 {
+		t.Fatalf("non-busy error: err = %v, calls = %d", err, calls)
+	}
+
+	calls = 0
+	err = RetrySQLiteBusy(context.Background(), func() error { calls++; return errors.New("database is locked") })
+	if !IsSQLiteBusy(err) || calls != len(sqliteRetryBackoff)+1 {
+		t.Fatalf("persistent lock: err = %v, calls = %d", err, calls)
+	}
+}
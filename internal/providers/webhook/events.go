@@ -0,0 +1,280 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
+)
+
+// maxEventsPerPayload bounds one POST; gateways batching more should split.
+const maxEventsPerPayload = 1000
+
+// Field aliases, first match wins. The canonical names come first; the rest
+// let common gateway payloads (LiteLLM's, OpenAI-style usage blocks) be
+// posted without reshaping.
+var (
+	idFields          = []string{"id", "request_id", "litellm_call_id"}
+	timestampFields   = []string{"timestamp", "occurred_at", "start_time", "startTime"}
+	modelFields       = []string{"model"}
+	providerFields    = []string{"provider", "custom_llm_provider"}
+	inputFields       = []string{"input_tokens", "prompt_tokens"}
+	outputFields      = []string{"output_tokens", "completion_tokens"}
+	reasoningFields   = []string{"reasoning_tokens"}
+	cacheReadFields   = []string{"cache_read_tokens", "cached_tokens"}
+	cacheWriteFields  = []string{"cache_write_tokens", "cache_creation_tokens"}
+	totalFields       = []string{"total_tokens"}
+	costFields        = []string{"cost_usd", "cost", "response_cost"}
+	requestsFields    = []string{"requests"}
+	sessionFields     = []string{"session_id"}
+	projectFields     = []string{"project", "workspace"}
+	clientFields      = []string{"client", "app"}
+	userFields        = []string{"user", "end_user"}
+	statusFields      = []string{"status"}
+	accountFields     = []string{"account_id"}
+	nestedUsageFields = []string{"usage"}
+)
+
+// ParseEvents decodes a pushed payload: one event object, an array of them,
+// or {"events": [...]}. Each event needs a model or some usage — tokens,
+// cost, or a request count. Events without a timestamp are stamped with now;
+// events without an id are deduplicated by content, so retrying a POST is
+// only idempotent when ids are sent.
+func ParseEvents(raw []byte, now time.Time) ([]shared.TelemetryEvent, error) {
+	objects, err := decodeEventObjects(raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) > maxEventsPerPayload {
+		return nil, fmt.Errorf("%d events in one payload; the limit is %d", len(objects), maxEventsPerPayload)
+	}
+
+	out := make([]shared.TelemetryEvent, 0, len(objects))
+	for i, obj := range objects {
+		ev, err := eventFromObject(obj, now)
+		if err != nil {
+			if len(objects) == 1 {
+				return nil, err
+			}
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
+		out = append(out, ev)
+	}
+	return out, nil
+}
+
+func decodeEventObjects(raw []byte) ([]map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var body any
+	if err := dec.Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode events: %w", err)
+	}
+
+	var items []any
+	switch v := body.(type) {
+	case []any:
+		items = v
+	case map[string]any:
+		if events, ok := v["events"]; ok {
+			list, ok := events.([]any)
+			if !ok {
+				return nil, fmt.Errorf(`"events" must be an array`)
+			}
+			items = list
+		} else {
+			items = []any{v}
+		}
+	default:
+		return nil, fmt.Errorf("events must be a JSON object or array")
+	}
+
+	objects := make([]map[string]any, 0, len(items))
+	for i, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("event %d is not a JSON object", i)
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+func eventFromObject(obj map[string]any, now time.Time) (shared.TelemetryEvent, error) {
+	// An OpenAI-style "usage" block supplies token counts the top level
+	// doesn't set.
+	for _, key := range nestedUsageFields {
+		if usage, ok := obj[key].(map[string]any); ok {
+			for k, v := range usage {
+				if _, exists := obj[k]; !exists {
+					obj[k] = v
+				}
+			}
+		}
+	}
+
+	ev := shared.TelemetryEvent{
+		SchemaVersion: "webhook_v1",
+		Channel:       shared.TelemetryChannelHook,
+		OccurredAt:    now.UTC(),
+		ProviderID:    ID,
+		EventType:     shared.TelemetryEventTypeMessageUsage,
+		MessageID:     stringField(obj, idFields),
+		SessionID:     stringField(obj, sessionFields),
+		WorkspaceID:   stringField(obj, projectFields),
+		AccountID:     stringField(obj, accountFields),
+		ModelRaw:      stringField(obj, modelFields),
+		Status:        shared.TelemetryStatusOK,
+	}
+
+	if raw, ok := firstField(obj, timestampFields); ok {
+		ts, err := parseTimestamp(raw)
+		if err != nil {
+			return ev, err
+		}
+		ev.OccurredAt = ts
+	}
+
+	var err error
+	fields := []struct {
+		names []string
+		dst   **int64
+	}{
+		{inputFields, &ev.InputTokens},
+		{outputFields, &ev.OutputTokens},
+		{reasoningFields, &ev.ReasoningTokens},
+		{cacheReadFields, &ev.CacheReadTokens},
+		{cacheWriteFields, &ev.CacheWriteTokens},
+		{totalFields, &ev.TotalTokens},
+		{requestsFields, &ev.Requests},
+	}
+	for _, f := range fields {
+		if *f.dst, err = countField(obj, f.names); err != nil {
+			return ev, err
+		}
+	}
+	if ev.CostUSD, err = costField(obj); err != nil {
+		return ev, err
+	}
+
+	hasUsage := ev.InputTokens != nil || ev.OutputTokens != nil || ev.ReasoningTokens != nil ||
+		ev.CacheReadTokens != nil || ev.CacheWriteTokens != nil || ev.TotalTokens != nil ||
+		ev.CostUSD != nil || ev.Requests != nil
+	if ev.ModelRaw == "" && !hasUsage {
+		return ev, fmt.Errorf("event needs a model or usage (tokens, cost, or requests)")
+	}
+	if ev.Requests == nil {
+		ev.Requests = core.Int64Ptr(1)
+	}
+	ev.SumTotalTokens()
+
+	switch strings.ToLower(stringField(obj, statusFields)) {
+	case "", "ok", "success", "succeeded":
+	case "error", "failure", "failed":
+		ev.Status = shared.TelemetryStatusError
+	case "aborted", "cancelled", "canceled":
+		ev.Status = shared.TelemetryStatusAborted
+	default:
+		ev.Status = shared.TelemetryStatusUnknown
+	}
+
+	payload := map[string]any{}
+	if v := stringField(obj, providerFields); v != "" {
+		payload["upstream_provider"] = strings.ToLower(v)
+	}
+	if v := stringField(obj, clientFields); v != "" {
+		payload["client"] = v
+	}
+	if v := stringField(obj, userFields); v != "" {
+		payload["user"] = v
+	}
+	if len(payload) > 0 {
+		ev.Payload = payload
+	}
+	return ev, nil
+}
+
+func firstField(obj map[string]any, names []string) (any, bool) {
+	for _, name := range names {
+		if v, ok := obj[name]; ok && v != nil {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func stringField(obj map[string]any, names []string) string {
+	v, ok := firstField(obj, names)
+	if !ok {
+		return ""
+	}
+	switch s := v.(type) {
+	case string:
+		return strings.TrimSpace(s)
+	case json.Number:
+		return s.String()
+	}
+	return ""
+}
+
+func countField(obj map[string]any, names []string) (*int64, error) {
+	v, ok := firstField(obj, names)
+	if !ok {
+		return nil, nil
+	}
+	f, err := numberValue(v)
+	if err != nil || f < 0 || f != math.Trunc(f) {
+		return nil, fmt.Errorf("%s must be a non-negative integer", names[0])
+	}
+	n := int64(f)
+	return &n, nil
+}
+
+func costField(obj map[string]any) (*float64, error) {
+	v, ok := firstField(obj, costFields)
+	if !ok {
+		return nil, nil
+	}
+	f, err := numberValue(v)
+	if err != nil || f < 0 || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("cost_usd must be a non-negative number")
+	}
+	return &f, nil
+}
+
+func numberValue(v any) (float64, error) {
+	switch n := v.(type) {
+	case json.Number:
+		return n.Float64()
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(n), 64)
+	}
+	return 0, fmt.Errorf("not a number")
+}
+
+// parseTimestamp accepts RFC 3339 and the other layouts shared understands,
+// plus Unix time in seconds (fractional, as LiteLLM sends), milliseconds, or
+// microseconds.
+func parseTimestamp(v any) (time.Time, error) {
+	switch t := v.(type) {
+	case json.Number:
+		if n, err := t.Int64(); err == nil {
+			return shared.UnixAuto(n), nil
+		}
+		if f, err := t.Float64(); err == nil && f > 0 {
+			sec, frac := math.Modf(f)
+			return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+		}
+	case string:
+		if ts, err := shared.ParseTimestampString(t); err == nil {
+			return ts, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("timestamp %v is not RFC 3339 or Unix time", v)
+}
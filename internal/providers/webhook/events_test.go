@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
+)
+
+var testNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func TestParseEvents_CanonicalSchema(t *testing.T) {
+	events, err := ParseEvents([]byte(`{
+		"id": "req-1",
+		"timestamp": "2026-10-16T10:00:00Z",
+		"model": "gpt-4o",
+		"provider": "OpenAI",
+		"input_tokens": 1200,
+		"output_tokens": 300,
+		"cost_usd": 0.0123,
+		"project": "billing-api",
+		"client": "checkout"
+	}`), testNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("events = %d, want 1", len(events))
+	}
+	ev := events[0]
+	if ev.ProviderID != ID || ev.MessageID != "req-1" || ev.ModelRaw != "gpt-4o" || ev.WorkspaceID != "billing-api" {
+		t.Errorf("identity fields = %+v", ev)
+	}
+	if !ev.OccurredAt.Equal(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("OccurredAt = %v", ev.OccurredAt)
+	}
+	if *ev.InputTokens != 1200 || *ev.OutputTokens != 300 || *ev.TotalTokens != 1500 || *ev.Requests != 1 || *ev.CostUSD != 0.0123 {
+		t.Errorf("usage = %+v", ev.TokenUsage)
+	}
+	if ev.Payload["upstream_provider"] != "openai" || ev.Payload["client"] != "checkout" {
+		t.Errorf("payload = %v", ev.Payload)
+	}
+}
+
+func TestParseEvents_GatewayAliases(t *testing.T) {
+	// LiteLLM-style: epoch start time, prompt/completion tokens, response_cost.
+	events, err := ParseEvents([]byte(`[
+		{"litellm_call_id": "c-1", "startTime": 1760608800.5, "model": "claude-sonnet-4", "custom_llm_provider": "anthropic",
+		 "prompt_tokens": 10, "completion_tokens": 5, "response_cost": 0.001, "status": "failure"},
+		{"model": "gpt-4o-mini", "usage": {"prompt_tokens": 7, "completion_tokens": 3}}
+	]`), testNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, second := events[0], events[1]
+	if first.MessageID != "c-1" || first.OccurredAt.Unix() != 1760608800 || first.Status != shared.TelemetryStatusError {
+		t.Errorf("first = %+v", first)
+	}
+	if *first.InputTokens != 10 || *first.OutputTokens != 5 || *first.CostUSD != 0.001 {
+		t.Errorf("first usage = %+v", first.TokenUsage)
+	}
+	if *second.InputTokens != 7 || *second.OutputTokens != 3 || !second.OccurredAt.Equal(testNow) {
+		t.Errorf("second = %+v", second)
+	}
+}
+
+func TestParseEvents_Rejects(t *testing.T) {
+	for name, body := range map[string]string{
+		"not json":       `usage`,
+		"scalar":         `42`,
+		"empty event":    `{"id": "x"}`,
+		"negative":       `{"model": "m", "input_tokens": -1}`,
+		"fractional":     `{"model": "m", "output_tokens": 1.5}`,
+		"bad timestamp":  `{"model": "m", "timestamp": "yesterday"}`,
+		"events object":  `{"events": {"model": "m"}}`,
+		"non-object row": `[{"model": "m"}, "x"]`,
+	} {
+		if _, err := ParseEvents([]byte(body), testNow); err == nil {
+			t.Errorf("%s: accepted %s", name, body)
+		}
+	}
+
+	_, err := ParseEvents([]byte(`[{"model": "m"}, {"model": "m", "cost_usd": "abc"}]`), testNow)
+	if err == nil || !strings.HasPrefix(err.Error(), "event 1:") {
+		t.Errorf("batch error should name the event: %v", err)
+	}
+}
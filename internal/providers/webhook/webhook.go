@@ -0,0 +1,78 @@
+// Package webhook implements the push-based provider: gateways and proxies
+// (LiteLLM, Helicone, in-house routers) POST per-request usage events to the
+// daemon's /v1/ingest endpoint, and the events fold into this provider's
+// tile through the telemetry read model like any other telemetry source.
+// Fetch itself reads nothing.
+package webhook
+
+import (
+	"context"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/providerbase"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
+)
+
+// ID is the provider identifier and the telemetry source name events are
+// ingested under.
+const ID = "webhook"
+
+// Provider is the synthetic provider pushed events are attributed to.
+type Provider struct {
+	providerbase.Base
+	clock core.Clock
+}
+
+// New constructs the provider.
+func New() *Provider {
+	return &Provider{
+		Base: providerbase.New(core.ProviderSpec{
+			ID: ID,
+			Info: core.ProviderInfo{
+				Name:         "Webhook",
+				Capabilities: []string{"telemetry_ingest", "per_model_breakdown"},
+				DocURL:       "https://github.com/janekbaraniewski/openusage",
+			},
+			Auth: core.ProviderAuthSpec{
+				Type:             core.ProviderAuthTypeLocal,
+				DefaultAccountID: ID,
+			},
+			Setup: core.ProviderSetupSpec{
+				Quickstart: []string{
+					`Add {"id": "webhook", "provider": "webhook"} to "accounts" in settings.json.`,
+					`Set "telemetry": {"ingest_listen": "127.0.0.1:9310"} and run the daemon.`,
+					"POST usage events to http://127.0.0.1:9310/v1/ingest.",
+				},
+			},
+			Dashboard: dashboardWidget(),
+		}),
+		clock: core.SystemClock{},
+	}
+}
+
+// Fetch returns an empty OK snapshot; the metrics come from ingested events,
+// which the daemon's read model applies on top.
+func (p *Provider) Fetch(_ context.Context, acct core.AccountConfig) (core.UsageSnapshot, error) {
+	snap := core.NewUsageSnapshot(p.ID(), acct.ID)
+	snap.Status = core.StatusOK
+	return snap, nil
+}
+
+// System implements shared.TelemetrySource.
+func (p *Provider) System() string { return ID }
+
+// DefaultCollectOptions implements shared.TelemetrySource.
+func (p *Provider) DefaultCollectOptions() shared.TelemetryCollectOptions {
+	return shared.TelemetryCollectOptions{}
+}
+
+// Collect implements shared.TelemetrySource. Events only arrive by push, so
+// there is nothing to collect.
+func (p *Provider) Collect(_ context.Context, _ shared.TelemetryCollectOptions) ([]shared.TelemetryEvent, error) {
+	return nil, nil
+}
+
+// ParseHookPayload implements shared.TelemetrySource.
+func (p *Provider) ParseHookPayload(raw []byte, _ shared.TelemetryCollectOptions) ([]shared.TelemetryEvent, error) {
+	return ParseEvents(raw, p.clock.Now())
+}
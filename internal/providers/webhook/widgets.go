@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/providerbase"
+)
+
+func dashboardWidget() core.DashboardWidget {
+	return providerbase.DefaultDashboard(
+		providerbase.WithColorRole(core.DashboardColorRoleTeal),
+		providerbase.WithCompactRows(
+			core.DashboardCompactRow{
+				Label:       "Window",
+				Keys:        []string{"window_requests", "window_tokens", "window_cost"},
+				MaxSegments: 3,
+			},
+			core.DashboardCompactRow{
+				Label:       "Today",
+				Keys:        []string{"today_api_cost", "today_input_tokens", "today_output_tokens"},
+				MaxSegments: 3,
+			},
+		),
+		providerbase.WithMetricLabels(map[string]string{
+			"window_requests":     "Requests",
+			"window_tokens":       "Tokens",
+			"window_cost":         "Cost",
+			"today_api_cost":      "Cost Today",
+			"today_input_tokens":  "Input Today",
+			"today_output_tokens": "Output Today",
+		}),
+		providerbase.WithCompactLabels(map[string]string{
+			"window_requests":     "req",
+			"window_tokens":       "tok",
+			"window_cost":         "cost",
+			"today_api_cost":      "cost",
+			"today_input_tokens":  "in",
+			"today_output_tokens": "out",
+		}),
+	)
+}
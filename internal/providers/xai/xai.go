@@ -50,6 +50,11 @@ func New() *Provider {
 			CreditMetrics: map[string]core.BalanceSemantics{
 				"credits": core.BalancePoint,
 			},
+			Actions: []core.ProviderAction{
+				{Label: "Open console", Kind: core.ProviderActionOpenURL, Target: "https://console.x.ai"},
+				{Label: "Open status page", Kind: core.ProviderActionOpenURL, Target: "https://status.x.ai"},
+				{Label: "Copy API base URL", Kind: core.ProviderActionCopy, Target: defaultBaseURL, SnapshotKey: "api_base_url"},
+			},
 		}),
 	}
 }
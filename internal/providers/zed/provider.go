@@ -59,6 +59,9 @@ func New() *Provider {
 				},
 			},
 			Dashboard: dashboardWidget(),
+			Actions: []core.ProviderAction{
+				{Label: "Open database", Kind: core.ProviderActionOpenPath, SnapshotKey: "db_path"},
+			},
 		}),
 		clock: core.SystemClock{},
 	}
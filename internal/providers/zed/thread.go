@@ -7,14 +7,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/klauspost/compress/zstd"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
 )
 
 // maxDecompressedBytes caps the inflated thread payload at 32 MB. Anything
@@ -87,19 +86,16 @@ func detectColumns(ctx context.Context, db *sql.DB) (threadColumns, error) {
 	}, nil
 }
 
-// openReadOnly opens threads.db using SQLite's read-only, immutable URI so we
-// never compete for the lock with the live Zed process.
-func openReadOnly(dbPath string) (*sql.DB, error) {
+// openReadOnly opens threads.db, which a running Zed holds open; see
+// shared.OpenSQLiteReadOnly.
+func openReadOnly(ctx context.Context, dbPath string) (*sql.DB, error) {
 	if dbPath == "" {
 		return nil, fmt.Errorf("zed: empty db path")
 	}
-	encoded := (&url.URL{Path: dbPath}).EscapedPath()
-	dsn := fmt.Sprintf("file:%s?mode=ro&immutable=1", encoded)
-	db, err := sql.Open("sqlite3", dsn)
+	db, err := shared.OpenSQLiteReadOnly(ctx, dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("zed: opening threads db: %w", err)
 	}
-	db.SetMaxOpenConns(1)
 	return db, nil
 }
 
@@ -108,16 +104,12 @@ func openReadOnly(dbPath string) (*sql.DB, error) {
 // (Ollama, vLLM, ...) are intentionally skipped; they have no billing
 // implication for the user.
 func queryZedThreads(ctx context.Context, dbPath string) ([]zedThread, error) {
-	db, err := openReadOnly(dbPath)
+	db, err := openReadOnly(ctx, dbPath)
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
 
-	if err := db.PingContext(ctx); err != nil {
-		return nil, fmt.Errorf("zed: pinging threads db: %w", err)
-	}
-
 	cols, err := detectColumns(ctx, db)
 	if err != nil {
 		return nil, err
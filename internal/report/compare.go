@@ -0,0 +1,327 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
+)
+
+// Units used by comparison rows.
+const (
+	compareUSD       = "USD"
+	compareTokens    = "tokens"
+	compareRequests  = "requests"
+	comparePercent   = "%"
+	compareRemaining = "% left"
+)
+
+// Comparison lines up the canonical metrics of two accounts so they can be
+// read side by side, whatever metric keys each provider uses.
+type Comparison struct {
+	Accounts [2]CompareAccount
+	Rows     []CompareRow
+}
+
+// CompareAccount identifies one side of a Comparison.
+type CompareAccount struct {
+	AccountID  string
+	ProviderID string
+	Status     core.Status
+	Timestamp  time.Time
+}
+
+// CompareRow is one metric for both accounts. A nil value means that account
+// doesn't report the metric.
+type CompareRow struct {
+	Key    string // stable id, e.g. "cost_today" or "limit:rpm"
+	Label  string
+	Unit   string
+	Values [2]*float64
+	Resets [2]time.Time // remaining-limit rows only
+}
+
+// BuildComparison aligns usage (cost and tokens today and over 7 days,
+// requests over 7 days), token efficiency, and remaining limits. Rows neither
+// account reports are left out. Limits are matched by metric key, those both
+// accounts report first.
+func BuildComparison(a, b core.UsageSnapshot) Comparison {
+	snaps := [2]core.UsageSnapshot{a, b}
+	var c Comparison
+	for i, s := range snaps {
+		c.Accounts[i] = CompareAccount{AccountID: s.AccountID, ProviderID: s.ProviderID, Status: s.Status, Timestamp: s.Timestamp}
+	}
+
+	add := func(key, label, unit string, fn func(core.UsageSnapshot) *float64) {
+		row := CompareRow{Key: key, Label: label, Unit: unit, Values: [2]*float64{fn(a), fn(b)}}
+		if row.Values[0] != nil || row.Values[1] != nil {
+			c.Rows = append(c.Rows, row)
+		}
+	}
+	add("cost_today", "Cost today", compareUSD, func(s core.UsageSnapshot) *float64 {
+		return positive(core.ExtractAnalyticsCostSummary(s).TodayCostUSD)
+	})
+	add("cost_7d", "Cost 7d", compareUSD, func(s core.UsageSnapshot) *float64 {
+		if v := core.ExtractAnalyticsCostSummary(s).WeekCostUSD; v > 0 {
+			return &v
+		}
+		return positive(lastDaysSum(s, 7, "cost_usd", "cost", "analytics_cost"))
+	})
+	add("tokens_today", "Tokens today", compareTokens, func(s core.UsageSnapshot) *float64 {
+		if v := metricSum(s, "today_tokens"); v > 0 {
+			return &v
+		}
+		if v := metricSum(s, "today_input_tokens", "today_output_tokens"); v > 0 {
+			return &v
+		}
+		return positive(lastDaysSum(s, 1, "tokens_total", "tokens", "analytics_tokens"))
+	})
+	add("tokens_7d", "Tokens 7d", compareTokens, func(s core.UsageSnapshot) *float64 {
+		if v := metricSum(s, "7d_tokens"); v > 0 {
+			return &v
+		}
+		if v := metricSum(s, "7d_input_tokens", "7d_output_tokens"); v > 0 {
+			return &v
+		}
+		return positive(lastDaysSum(s, 7, "tokens_total", "tokens", "analytics_tokens"))
+	})
+	add("requests_7d", "Requests 7d", compareRequests, func(s core.UsageSnapshot) *float64 {
+		return positive(lastDaysSum(s, 7, "requests", "analytics_requests"))
+	})
+	add("cost_per_1k_output", "$/1k output", compareUSD, func(s core.UsageSnapshot) *float64 {
+		e, _ := core.ComputeTokenEfficiency(s)
+		return e.CostPer1kOutput
+	})
+	add("cache_read_percent", "Cache hits", comparePercent, func(s core.UsageSnapshot) *float64 {
+		e, _ := core.ComputeTokenEfficiency(s)
+		return e.CacheReadPercent
+	})
+
+	c.Rows = append(c.Rows, compareLimits(snaps)...)
+	return c
+}
+
+// compareLimits builds one remaining-percentage row per quota gauge.
+// Per-session context windows are not quotas and are skipped, as are
+// metrics snapshot validation flagged as implausible.
+func compareLimits(snaps [2]core.UsageSnapshot) []CompareRow {
+	byKey := map[string]*CompareRow{}
+	for i, s := range snaps {
+		for key, met := range s.Metrics {
+			if met.Window == "session" {
+				continue
+			}
+			if _, suspect := s.Diagnostics[core.AnomalyDiagnosticPrefix+key]; suspect {
+				continue
+			}
+			used := core.MetricUsedPercent(key, met)
+			if used < 0 {
+				continue
+			}
+			row := byKey[key]
+			if row == nil {
+				row = &CompareRow{
+					Key:   "limit:" + key,
+					Label: core.NormalizeMetricLabel(core.PrettifyMetricKey(key)),
+					Unit:  compareRemaining,
+				}
+				byKey[key] = row
+			}
+			left := math.Max(0, 100-used)
+			row.Values[i] = &left
+			for _, resetKey := range []string{key + "_reset", key} {
+				if at, ok := s.Resets[resetKey]; ok && !at.IsZero() {
+					row.Resets[i] = at
+					break
+				}
+			}
+		}
+	}
+	rows := make([]CompareRow, 0, len(byKey))
+	for _, row := range byKey {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		bi := rows[i].Values[0] != nil && rows[i].Values[1] != nil
+		bj := rows[j].Values[0] != nil && rows[j].Values[1] != nil
+		if bi != bj {
+			return bi
+		}
+		return rows[i].Key < rows[j].Key
+	})
+	return rows
+}
+
+func positive(v float64) *float64 {
+	if v <= 0 {
+		return nil
+	}
+	return &v
+}
+
+func metricSum(s core.UsageSnapshot, keys ...string) float64 {
+	total := 0.0
+	for _, key := range keys {
+		if m, ok := s.Metrics[key]; ok && m.Used != nil {
+			total += *m.Used
+		}
+	}
+	return total
+}
+
+// lastDaysSum totals the first matching daily series over the n days ending
+// on the snapshot's date.
+func lastDaysSum(s core.UsageSnapshot, n int, keys ...string) float64 {
+	series := firstSeries(s, keys...)
+	if len(series) == 0 {
+		return 0
+	}
+	ts := s.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	end := ts.Format("2006-01-02")
+	start := ts.AddDate(0, 0, -(n - 1)).Format("2006-01-02")
+	total := 0.0
+	for _, p := range series {
+		if p.Date >= start && p.Date <= end {
+			total += p.Value
+		}
+	}
+	return total
+}
+
+// WriteText renders the comparison as a table with a column per account and
+// a DIFF column giving the second account relative to the first.
+func (c Comparison) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "METRIC\t%s\t%s\tDIFF\n", c.Accounts[0].label(), c.Accounts[1].label())
+	fmt.Fprintf(tw, "Status\t%s\t%s\t\n", c.Accounts[0].Status, c.Accounts[1].Status)
+	limitsHeader := false
+	for _, r := range c.Rows {
+		if r.Unit == compareRemaining && !limitsHeader {
+			fmt.Fprintln(tw, "Remaining limits\t\t\t")
+			limitsHeader = true
+		}
+		label := r.Label
+		if limitsHeader {
+			label = "  " + label
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", label, r.cell(0), r.cell(1), r.diff())
+	}
+	if len(c.Rows) == 0 {
+		fmt.Fprintln(tw, "(no comparable metrics)\t\t\t")
+	}
+	return tw.Flush()
+}
+
+func (a CompareAccount) label() string {
+	if a.ProviderID == "" || a.ProviderID == a.AccountID {
+		return a.AccountID
+	}
+	return a.AccountID + " (" + a.ProviderID + ")"
+}
+
+func (r CompareRow) cell(i int) string {
+	v := r.Values[i]
+	if v == nil {
+		return "—"
+	}
+	var s string
+	switch r.Unit {
+	case compareUSD:
+		s = fmtCost(*v)
+	case comparePercent:
+		s = fmt.Sprintf("%.0f%%", *v)
+	case compareRemaining:
+		s = fmt.Sprintf("%.0f%% left", *v)
+	default:
+		s = locale.Current().Compact(*v)
+	}
+	if at := r.Resets[i]; !at.IsZero() {
+		s += " · resets " + locale.Current().DateTime(at)
+	}
+	return s
+}
+
+// diff is the second value relative to the first: a percentage change for
+// amounts, a point difference for percentages.
+func (r CompareRow) diff() string {
+	a, b := r.Values[0], r.Values[1]
+	if a == nil || b == nil {
+		return ""
+	}
+	switch r.Unit {
+	case comparePercent, compareRemaining:
+		return fmt.Sprintf("%+.0f pts", *b-*a)
+	}
+	if *a == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%+.0f%%", (*b-*a) / *a * 100)
+}
+
+// WriteJSON encodes the comparison with both accounts' values per row.
+func (c Comparison) WriteJSON(w io.Writer) error {
+	view := compareView{Rows: make([]compareRowView, 0, len(c.Rows))}
+	for i, a := range c.Accounts {
+		view.Accounts[i] = compareAccountView{AccountID: a.AccountID, ProviderID: a.ProviderID, Status: string(a.Status)}
+		if !a.Timestamp.IsZero() {
+			view.Accounts[i].Timestamp = a.Timestamp.UTC().Format(time.RFC3339)
+		}
+	}
+	for _, r := range c.Rows {
+		rv := compareRowView{Key: r.Key, Label: r.Label, Unit: r.Unit, Values: r.Values}
+		if r.Resets != [2]time.Time{} {
+			rv.ResetsAt = make([]*string, 2)
+			for i, at := range r.Resets {
+				if !at.IsZero() {
+					s := at.UTC().Format(time.RFC3339)
+					rv.ResetsAt[i] = &s
+				}
+			}
+		}
+		view.Rows = append(view.Rows, rv)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(view)
+}
+
+type compareView struct {
+	Accounts [2]compareAccountView `json:"accounts"`
+	Rows     []compareRowView      `json:"rows"`
+}
+
+type compareAccountView struct {
+	AccountID  string `json:"account_id"`
+	ProviderID string `json:"provider_id"`
+	Status     string `json:"status"`
+	Timestamp  string `json:"timestamp,omitempty"`
+}
+
+type compareRowView struct {
+	Key      string      `json:"key"`
+	Label    string      `json:"label"`
+	Unit     string      `json:"unit"`
+	Values   [2]*float64 `json:"values"`
+	ResetsAt []*string   `json:"resets_at,omitempty"` // limit rows with a known reset
+}
+
+// FindSnapshot returns the snapshot for accountID, matching case-insensitively.
+func FindSnapshot(snaps []core.UsageSnapshot, accountID string) (core.UsageSnapshot, bool) {
+	accountID = strings.TrimSpace(accountID)
+	for _, s := range snaps {
+		if strings.EqualFold(s.AccountID, accountID) {
+			return s, true
+		}
+	}
+	return core.UsageSnapshot{}, false
+}
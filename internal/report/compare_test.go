@@ -0,0 +1,96 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestBuildComparison(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	reset := now.Add(3 * time.Hour)
+	personal := core.UsageSnapshot{
+		AccountID: "personal", ProviderID: "anthropic", Status: core.StatusOK, Timestamp: now,
+		Metrics: map[string]core.Metric{
+			"today_api_cost":   {Used: f(2)},
+			"7d_input_tokens":  {Used: f(900)},
+			"7d_output_tokens": {Used: f(100)},
+			"rpm":              {Limit: f(50), Remaining: f(40), Window: "1m"},
+			"context_window":   {Used: f(10), Limit: f(100), Window: "session"},
+			"usage_five_hour":  {Used: f(30), Unit: "%", Window: "5h"},
+		},
+		Resets: map[string]time.Time{"usage_five_hour": reset},
+	}
+	work := core.UsageSnapshot{
+		AccountID: "work", ProviderID: "anthropic", Status: core.StatusNearLimit, Timestamp: now,
+		Metrics: map[string]core.Metric{
+			"today_api_cost": {Used: f(3)},
+			"rpm":            {Limit: f(50), Remaining: f(5), Window: "1m"},
+			"tpm":            {Limit: f(1000), Remaining: f(500), Window: "1m"},
+		},
+		DailySeries: map[string][]core.TimePoint{
+			"tokens": {{Date: "2026-10-01", Value: 5000}, {Date: "2026-10-12", Value: 300}, {Date: "2026-10-16", Value: 200}},
+		},
+	}
+
+	c := BuildComparison(personal, work)
+	rows := map[string]CompareRow{}
+	var keys []string
+	for _, r := range c.Rows {
+		rows[r.Key] = r
+		keys = append(keys, r.Key)
+	}
+	if got := strings.Join(keys, ","); got != "cost_today,tokens_today,tokens_7d,limit:rpm,limit:tpm,limit:usage_five_hour" {
+		t.Fatalf("row keys = %s", got)
+	}
+	if r := rows["cost_today"]; *r.Values[0] != 2 || *r.Values[1] != 3 || r.diff() != "+50%" {
+		t.Errorf("cost_today = %v/%v diff %q", *r.Values[0], *r.Values[1], r.diff())
+	}
+	if r := rows["tokens_7d"]; *r.Values[0] != 1000 || *r.Values[1] != 500 {
+		t.Errorf("tokens_7d = %v/%v, want metric sum vs last-7-days series", *r.Values[0], *r.Values[1])
+	}
+	if r := rows["tokens_today"]; r.Values[0] != nil || *r.Values[1] != 200 {
+		t.Errorf("tokens_today = %v/%v, want —/200", r.Values[0], r.Values[1])
+	}
+	if r := rows["limit:rpm"]; *r.Values[0] != 80 || *r.Values[1] != 10 || r.diff() != "-70 pts" {
+		t.Errorf("rpm = %v/%v diff %q", *r.Values[0], *r.Values[1], r.diff())
+	}
+	if r := rows["limit:usage_five_hour"]; !r.Resets[0].Equal(reset) || r.Values[1] != nil {
+		t.Errorf("usage_five_hour = %+v, want personal only with reset", r)
+	}
+
+	var text bytes.Buffer
+	if err := c.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"personal (anthropic)", "NEAR_LIMIT", "Remaining limits", "80% left", "—"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text missing %q:\n%s", want, text.String())
+		}
+	}
+
+	var js bytes.Buffer
+	if err := c.WriteJSON(&js); err != nil {
+		t.Fatal(err)
+	}
+	var view compareView
+	if err := json.Unmarshal(js.Bytes(), &view); err != nil {
+		t.Fatal(err)
+	}
+	if view.Accounts[1].AccountID != "work" || len(view.Rows) != len(c.Rows) {
+		t.Errorf("json = %+v", view)
+	}
+	for _, r := range view.Rows {
+		if r.Key == "limit:usage_five_hour" && (len(r.ResetsAt) != 2 || r.ResetsAt[0] == nil || r.ResetsAt[1] != nil) {
+			t.Errorf("usage_five_hour resets_at = %v", r.ResetsAt)
+		}
+		if r.Key == "cost_today" && r.ResetsAt != nil {
+			t.Errorf("cost_today resets_at = %v, want omitted", r.ResetsAt)
+		}
+	}
+}
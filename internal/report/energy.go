@@ -0,0 +1,65 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// cacheReadEnergyWeight discounts cache reads against fresh input: a cached
+// prefix skips most of the prefill compute, which is also why vendors bill
+// it at about a tenth of the input rate.
+const cacheReadEnergyWeight = 0.1
+
+// Energy is the approximate energy use and emissions behind a report's
+// token usage. It is attached only when the energy estimator is enabled.
+type Energy struct {
+	Wh            float64       `json:"wh"`
+	CO2Grams      float64       `json:"co2_grams"`
+	GridIntensity float64       `json:"grid_g_co2_per_kwh"`
+	Models        []ModelEnergy `json:"models,omitempty"` // largest first
+}
+
+// ModelEnergy is one model's share of a report's Energy.
+type ModelEnergy struct {
+	Model    string  `json:"model"`
+	Wh       float64 `json:"wh"`
+	CO2Grams float64 `json:"co2_grams"`
+}
+
+// EstimateEnergy estimates the footprint of the events a report built with
+// opts covers. Cache writes count as input and reasoning as output. It
+// returns nil when the estimator is disabled or nothing was used.
+func EstimateEnergy(events []Event, opts Options, cfg core.EnergyConfig) *Energy {
+	if !cfg.Enabled {
+		return nil
+	}
+	requireReal := opts.Kind == KindSession || opts.Kind == KindBlocks
+	byModel := map[string]core.EnergyEstimate{}
+	for _, e := range filterEvents(events, opts, requireReal) {
+		input := float64(e.Input+e.CacheCreate) + float64(e.CacheRead)*cacheReadEnergyWeight
+		output := float64(e.Output + e.Reasoning)
+		est := cfg.Estimate(e.Model, input, output)
+		if est.Wh <= 0 {
+			continue
+		}
+		byModel[e.Model] = byModel[e.Model].Add(est)
+	}
+	if len(byModel) == 0 {
+		return nil
+	}
+
+	out := &Energy{GridIntensity: cfg.Grid()}
+	for model, est := range byModel {
+		out.Wh += est.Wh
+		out.CO2Grams += est.CO2Grams
+		out.Models = append(out.Models, ModelEnergy{Model: model, Wh: est.Wh, CO2Grams: est.CO2Grams})
+	}
+	sort.Slice(out.Models, func(i, j int) bool {
+		if out.Models[i].Wh != out.Models[j].Wh {
+			return out.Models[i].Wh > out.Models[j].Wh
+		}
+		return out.Models[i].Model < out.Models[j].Model
+	})
+	return out
+}
@@ -0,0 +1,49 @@
+package report
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestEstimateEnergy(t *testing.T) {
+	cfg := core.EnergyConfig{
+		Enabled: true,
+		Models:  map[string]core.EnergyFactor{"*": {InputWhPer1K: 0.1, OutputWhPer1K: 1}},
+	}
+	events := []Event{
+		ev("2026-06-01T10:00:00Z", "claude_code", "opus", 1.0, 1_000, 1_000),
+		ev("2026-06-01T11:00:00Z", "claude_code", "sonnet", 1.0, 10_000, 0),
+		ev("2026-06-02T11:00:00Z", "codex", "gpt-5", 1.0, 10_000, 0),
+	}
+	events[1].CacheRead = 10_000 // counted at a tenth of fresh input
+
+	if got := EstimateEnergy(events, Options{Kind: KindDaily}, core.EnergyConfig{}); got != nil {
+		t.Fatalf("disabled estimator returned %+v", got)
+	}
+
+	got := EstimateEnergy(events, Options{Kind: KindDaily, Provider: "claude_code"}, cfg)
+	if got == nil {
+		t.Fatal("EstimateEnergy returned nil")
+	}
+	// opus 0.1+1 = 1.1 Wh; sonnet (10k + 1k) × 0.1 = 1.1 Wh.
+	if math.Abs(got.Wh-2.2) > 1e-9 || math.Abs(got.CO2Grams-0.88) > 1e-9 {
+		t.Fatalf("energy = %.3f Wh / %.3f g, want 2.2 Wh / 0.88 g", got.Wh, got.CO2Grams)
+	}
+	if len(got.Models) != 2 || got.Models[0].Model != "opus" {
+		t.Fatalf("models = %+v, want opus and sonnet", got.Models)
+	}
+
+	rep := Build(events, Options{Kind: KindDaily})
+	rep.Energy = got
+	var buf bytes.Buffer
+	if err := rep.WriteTable(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "energy: ≈ 2.2 Wh · 0.9 g CO2e") {
+		t.Errorf("table missing energy line:\n%s", buf.String())
+	}
+}
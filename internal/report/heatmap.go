@@ -0,0 +1,271 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/locale"
+)
+
+// HeatmapMetric selects the value a heatmap plots.
+type HeatmapMetric string
+
+const (
+	HeatmapCost     HeatmapMetric = "cost"
+	HeatmapTokens   HeatmapMetric = "tokens"
+	HeatmapRequests HeatmapMetric = "requests"
+)
+
+// DefaultHeatmapWeeks is the trailing span of the calendar heatmap.
+const DefaultHeatmapWeeks = 12
+
+// heatmapShades are the intensity levels, empty first.
+var heatmapShades = []string{"·", "░", "▒", "▓", "█"}
+
+// HeatmapDay is one provider's value for a calendar day (YYYY-MM-DD).
+type HeatmapDay struct {
+	Day      string
+	Provider string
+	Value    float64
+}
+
+// HeatmapOptions controls BuildHeatmap.
+type HeatmapOptions struct {
+	Metric       HeatmapMetric
+	Weeks        int
+	FirstWeekday time.Weekday
+	Provider     string // limit to one provider id
+	ByProvider   bool   // one grid per provider after the aggregate
+	Now          time.Time
+}
+
+// Heatmap is a GitHub-style calendar of daily values: columns are weeks
+// starting on FirstWeekday, rows are weekdays, ending with the current week.
+type Heatmap struct {
+	Metric       HeatmapMetric
+	Start        time.Time // first cell (week-aligned)
+	End          time.Time // today
+	Weeks        int
+	FirstWeekday time.Weekday
+	Series       []HeatmapSeries
+}
+
+// HeatmapSeries is one grid: the aggregate or a single provider.
+type HeatmapSeries struct {
+	Name       string
+	Values     map[string]float64
+	Total      float64
+	Peak       float64
+	PeakDay    string
+	ActiveDays int
+}
+
+// BuildHeatmap lays out the trailing opts.Weeks of days. The aggregate series
+// comes first; with ByProvider each provider follows, largest total first.
+func BuildHeatmap(days []HeatmapDay, opts HeatmapOptions) Heatmap {
+	if opts.Weeks <= 0 {
+		opts.Weeks = DefaultHeatmapWeeks
+	}
+	if opts.Metric == "" {
+		opts.Metric = HeatmapCost
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := end
+	for start.Weekday() != opts.FirstWeekday {
+		start = start.AddDate(0, 0, -1)
+	}
+	start = start.AddDate(0, 0, -7*(opts.Weeks-1))
+
+	h := Heatmap{Metric: opts.Metric, Start: start, End: end, Weeks: opts.Weeks, FirstWeekday: opts.FirstWeekday}
+	total := HeatmapSeries{Name: "all providers", Values: map[string]float64{}}
+	perProvider := map[string]*HeatmapSeries{}
+	lo, hi := start.Format("2006-01-02"), end.Format("2006-01-02")
+	for _, d := range days {
+		if d.Day < lo || d.Day > hi || d.Value <= 0 {
+			continue
+		}
+		if opts.Provider != "" && d.Provider != opts.Provider {
+			continue
+		}
+		total.Values[d.Day] += d.Value
+		if opts.ByProvider {
+			ps := perProvider[d.Provider]
+			if ps == nil {
+				ps = &HeatmapSeries{Name: d.Provider, Values: map[string]float64{}}
+				perProvider[d.Provider] = ps
+			}
+			ps.Values[d.Day] += d.Value
+		}
+	}
+	if opts.Provider != "" {
+		total.Name = opts.Provider
+	}
+	h.Series = append(h.Series, summarizeHeatmapSeries(total))
+
+	if opts.ByProvider && opts.Provider == "" {
+		var providers []HeatmapSeries
+		for _, ps := range perProvider {
+			providers = append(providers, summarizeHeatmapSeries(*ps))
+		}
+		sort.Slice(providers, func(i, j int) bool {
+			if providers[i].Total != providers[j].Total {
+				return providers[i].Total > providers[j].Total
+			}
+			return providers[i].Name < providers[j].Name
+		})
+		h.Series = append(h.Series, providers...)
+	}
+	return h
+}
+
+func summarizeHeatmapSeries(s HeatmapSeries) HeatmapSeries {
+	for day, v := range s.Values {
+		s.Total += v
+		s.ActiveDays++
+		if v > s.Peak || (v == s.Peak && day < s.PeakDay) {
+			s.Peak = v
+			s.PeakDay = day
+		}
+	}
+	return s
+}
+
+// WriteText renders each series as a weekday × week grid of shade glyphs,
+// followed by its total, peak and active-day count.
+func (h Heatmap) WriteText(w io.Writer) error {
+	var sb strings.Builder
+	for i, s := range h.Series {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		h.writeSeries(&sb, s)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (h Heatmap) writeSeries(sb *strings.Builder, s HeatmapSeries) {
+	fmt.Fprintf(sb, "%s · %s · %d weeks to %s\n", s.Name, h.Metric, h.Weeks, h.End.Format("Jan 2"))
+
+	// Month labels above the first week column that starts in a new month.
+	months := []byte(strings.Repeat(" ", h.Weeks*2))
+	lastMonth, free := time.Month(0), 0
+	for wk := 0; wk < h.Weeks; wk++ {
+		day := h.Start.AddDate(0, 0, wk*7)
+		if day.Month() == lastMonth {
+			continue
+		}
+		lastMonth = day.Month()
+		label := day.Format("Jan")
+		if at := wk * 2; at >= free && at+len(label) <= len(months) {
+			copy(months[at:], label)
+			free = at + len(label) + 1
+		}
+	}
+	sb.WriteString("    " + strings.TrimRight(string(months), " ") + "\n")
+
+	for row := 0; row < 7; row++ {
+		weekday := (h.FirstWeekday + time.Weekday(row)) % 7
+		cells := make([]string, 0, h.Weeks)
+		for wk := 0; wk < h.Weeks; wk++ {
+			day := h.Start.AddDate(0, 0, wk*7+row)
+			if day.After(h.End) {
+				break
+			}
+			cells = append(cells, heatmapShade(s.Values[day.Format("2006-01-02")], s.Peak))
+		}
+		sb.WriteString(weekday.String()[:3] + " " + strings.Join(cells, " ") + "\n")
+	}
+
+	sb.WriteString("    less " + strings.Join(heatmapShades, " ") + " more\n")
+	if s.ActiveDays == 0 {
+		sb.WriteString("    no usage recorded\n")
+		return
+	}
+	peak := s.PeakDay
+	if t, err := time.Parse("2006-01-02", s.PeakDay); err == nil {
+		peak = locale.Current().Date(t)
+	}
+	fmt.Fprintf(sb, "    total %s · peak %s on %s · %d active days\n",
+		h.formatValue(s.Total), h.formatValue(s.Peak), peak, s.ActiveDays)
+}
+
+// heatmapShade buckets v into quartiles of peak; zero is the empty shade.
+func heatmapShade(v, peak float64) string {
+	if v <= 0 || peak <= 0 {
+		return heatmapShades[0]
+	}
+	levels := len(heatmapShades) - 1
+	i := 1 + int(v/peak*float64(levels)-1e-9)
+	return heatmapShades[max(1, min(i, levels))]
+}
+
+func (h Heatmap) formatValue(v float64) string {
+	return formatHeatmapValue(h.Metric, v)
+}
+
+func formatHeatmapValue(m HeatmapMetric, v float64) string {
+	if m == HeatmapCost {
+		return fmtCost(v)
+	}
+	return locale.Current().Compact(v)
+}
+
+// WriteJSON encodes the heatmap as one object per series with a dense,
+// date-ordered day list.
+func (h Heatmap) WriteJSON(w io.Writer) error {
+	view := heatmapView{
+		Metric: string(h.Metric),
+		Start:  h.Start.Format("2006-01-02"),
+		End:    h.End.Format("2006-01-02"),
+		Weeks:  h.Weeks,
+		Series: make([]heatmapSeriesView, 0, len(h.Series)),
+	}
+	for _, s := range h.Series {
+		sv := heatmapSeriesView{
+			Name:       s.Name,
+			Total:      s.Total,
+			Peak:       s.Peak,
+			PeakDay:    s.PeakDay,
+			ActiveDays: s.ActiveDays,
+		}
+		for day := h.Start; !day.After(h.End); day = day.AddDate(0, 0, 1) {
+			key := day.Format("2006-01-02")
+			sv.Days = append(sv.Days, heatmapDayView{Day: key, Value: s.Values[key]})
+		}
+		view.Series = append(view.Series, sv)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(view)
+}
+
+type heatmapView struct {
+	Metric string              `json:"metric"`
+	Start  string              `json:"start"`
+	End    string              `json:"end"`
+	Weeks  int                 `json:"weeks"`
+	Series []heatmapSeriesView `json:"series"`
+}
+
+type heatmapSeriesView struct {
+	Name       string           `json:"name"`
+	Total      float64          `json:"total"`
+	Peak       float64          `json:"peak"`
+	PeakDay    string           `json:"peak_day,omitempty"`
+	ActiveDays int              `json:"active_days"`
+	Days       []heatmapDayView `json:"days"`
+}
+
+type heatmapDayView struct {
+	Day   string  `json:"day"`
+	Value float64 `json:"value"`
+}
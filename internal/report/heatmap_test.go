@@ -0,0 +1,91 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildHeatmap(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC) // Wednesday
+	days := []HeatmapDay{
+		{Day: "2026-10-14", Provider: "claude_code", Value: 4},
+		{Day: "2026-10-14", Provider: "openai", Value: 1},
+		{Day: "2026-10-12", Provider: "openai", Value: 2},
+		{Day: "2026-06-01", Provider: "openai", Value: 99}, // outside 12 weeks
+	}
+
+	h := BuildHeatmap(days, HeatmapOptions{Metric: HeatmapCost, FirstWeekday: time.Monday, ByProvider: true, Now: now})
+	if h.Weeks != DefaultHeatmapWeeks {
+		t.Errorf("weeks = %d, want %d", h.Weeks, DefaultHeatmapWeeks)
+	}
+	if h.Start.Weekday() != time.Monday || h.Start.Format("2006-01-02") != "2026-07-27" {
+		t.Errorf("start = %s (%s), want Monday 2026-07-27", h.Start.Format("2006-01-02"), h.Start.Weekday())
+	}
+	if len(h.Series) != 3 {
+		t.Fatalf("series = %d, want aggregate + 2 providers", len(h.Series))
+	}
+	agg := h.Series[0]
+	if agg.Total != 7 || agg.Peak != 5 || agg.PeakDay != "2026-10-14" || agg.ActiveDays != 2 {
+		t.Errorf("aggregate = %+v, want total 7, peak 5 on 10-14, 2 active days", agg)
+	}
+	if h.Series[1].Name != "claude_code" || h.Series[2].Name != "openai" {
+		t.Errorf("provider order = %s, %s; want claude_code, openai", h.Series[1].Name, h.Series[2].Name)
+	}
+
+	single := BuildHeatmap(days, HeatmapOptions{Metric: HeatmapCost, Provider: "openai", ByProvider: true, Now: now})
+	if len(single.Series) != 1 || single.Series[0].Name != "openai" || single.Series[0].Total != 3 {
+		t.Errorf("provider-scoped heatmap = %+v, want one openai series totalling 3", single.Series)
+	}
+}
+
+func TestHeatmapShade(t *testing.T) {
+	tests := []struct {
+		v, peak float64
+		want    string
+	}{
+		{0, 10, "·"},
+		{1, 10, "░"},
+		{2.5, 10, "░"},
+		{5, 10, "▒"},
+		{7.5, 10, "▓"},
+		{10, 10, "█"},
+	}
+	for _, tt := range tests {
+		if got := heatmapShade(tt.v, tt.peak); got != tt.want {
+			t.Errorf("heatmapShade(%v, %v) = %q, want %q", tt.v, tt.peak, got, tt.want)
+		}
+	}
+}
+
+func TestHeatmapWriters(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)
+	h := BuildHeatmap([]HeatmapDay{{Day: "2026-10-14", Provider: "openai", Value: 1200}},
+		HeatmapOptions{Metric: HeatmapTokens, Weeks: 2, FirstWeekday: time.Sunday, Now: now})
+
+	var text bytes.Buffer
+	if err := h.WriteText(&text); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	out := text.String()
+	for _, want := range []string{"all providers · tokens · 2 weeks", "Sun ", "Wed · █", "total 1.2k"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("text output missing %q:\n%s", want, out)
+		}
+	}
+
+	var js bytes.Buffer
+	if err := h.WriteJSON(&js); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var view heatmapView
+	if err := json.Unmarshal(js.Bytes(), &view); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	// Sun Oct 4 through Wed Oct 14.
+	if view.Start != "2026-10-04" || len(view.Series) != 1 || len(view.Series[0].Days) != 11 {
+		t.Errorf("json = start %s, %d series, %d days; want 2026-10-04, 1, 11", view.Start, len(view.Series), len(view.Series[0].Days))
+	}
+}
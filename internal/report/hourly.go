@@ -0,0 +1,276 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Working hours used for the after-hours share: Monday to Friday,
+// workdayStart:00 to workdayEnd:00 local time.
+const (
+	workdayStart = 9
+	workdayEnd   = 18
+)
+
+// HourlyValue is one provider's usage within one hour.
+type HourlyValue struct {
+	Hour     time.Time // start of the hour, any zone
+	Provider string
+	Cost     float64
+	Tokens   float64
+	Requests float64
+}
+
+func (v HourlyValue) value(m HeatmapMetric) float64 {
+	switch m {
+	case HeatmapTokens:
+		return v.Tokens
+	case HeatmapRequests:
+		return v.Requests
+	default:
+		return v.Cost
+	}
+}
+
+// HourlyOptions controls BuildHourlyHeatmap.
+type HourlyOptions struct {
+	Metric       HeatmapMetric
+	FirstWeekday time.Weekday
+	Provider     string // limit to one provider id
+	ByProvider   bool   // one grid per provider after the aggregate
+	Location     *time.Location
+	Since        time.Time
+	Until        time.Time
+}
+
+// HourlyHeatmap is a weekday × hour-of-day grid in local time, summed over
+// the [Since, Until) span, for spotting scheduled jobs and after-hours agents.
+type HourlyHeatmap struct {
+	Metric       HeatmapMetric
+	FirstWeekday time.Weekday
+	Location     *time.Location
+	Since        time.Time
+	Until        time.Time
+	Series       []HourlySeries
+}
+
+// HourlySeries is one grid: the aggregate or a single provider. Values is
+// indexed by time.Weekday, then hour.
+type HourlySeries struct {
+	Name        string
+	Values      [7][24]float64
+	Total       float64
+	Peak        float64
+	PeakWeekday time.Weekday
+	PeakHour    int
+	AfterHours  float64 // total outside working hours
+}
+
+// AfterHoursShare is the fraction of Total outside working hours.
+func (s HourlySeries) AfterHoursShare() float64 {
+	if s.Total <= 0 {
+		return 0
+	}
+	return s.AfterHours / s.Total
+}
+
+// BuildHourlyHeatmap buckets values by local weekday and hour. The aggregate
+// series comes first; with ByProvider each provider follows, largest total
+// first.
+func BuildHourlyHeatmap(values []HourlyValue, opts HourlyOptions) HourlyHeatmap {
+	if opts.Metric == "" {
+		opts.Metric = HeatmapCost
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	h := HourlyHeatmap{
+		Metric:       opts.Metric,
+		FirstWeekday: opts.FirstWeekday,
+		Location:     opts.Location,
+		Since:        opts.Since,
+		Until:        opts.Until,
+	}
+	total := &HourlySeries{Name: "all providers"}
+	perProvider := map[string]*HourlySeries{}
+	for _, v := range values {
+		val := v.value(opts.Metric)
+		if val <= 0 {
+			continue
+		}
+		if opts.Provider != "" && v.Provider != opts.Provider {
+			continue
+		}
+		if !opts.Since.IsZero() && v.Hour.Before(opts.Since.Truncate(time.Hour)) {
+			continue
+		}
+		if !opts.Until.IsZero() && !v.Hour.Before(opts.Until) {
+			continue
+		}
+		local := v.Hour.In(opts.Location)
+		total.add(local, val)
+		if opts.ByProvider {
+			ps := perProvider[v.Provider]
+			if ps == nil {
+				ps = &HourlySeries{Name: v.Provider}
+				perProvider[v.Provider] = ps
+			}
+			ps.add(local, val)
+		}
+	}
+	if opts.Provider != "" {
+		total.Name = opts.Provider
+	}
+	h.Series = append(h.Series, total.summarize(opts.FirstWeekday))
+
+	if opts.ByProvider && opts.Provider == "" {
+		var providers []HourlySeries
+		for _, ps := range perProvider {
+			providers = append(providers, ps.summarize(opts.FirstWeekday))
+		}
+		sort.Slice(providers, func(i, j int) bool {
+			if providers[i].Total != providers[j].Total {
+				return providers[i].Total > providers[j].Total
+			}
+			return providers[i].Name < providers[j].Name
+		})
+		h.Series = append(h.Series, providers...)
+	}
+	return h
+}
+
+func (s *HourlySeries) add(local time.Time, v float64) {
+	wd, hr := local.Weekday(), local.Hour()
+	s.Values[wd][hr] += v
+	s.Total += v
+	if wd == time.Saturday || wd == time.Sunday || hr < workdayStart || hr >= workdayEnd {
+		s.AfterHours += v
+	}
+}
+
+// summarize finds the peak cell, scanning in display order so ties go to the
+// earliest row and hour.
+func (s *HourlySeries) summarize(first time.Weekday) HourlySeries {
+	for row := 0; row < 7; row++ {
+		wd := (first + time.Weekday(row)) % 7
+		for hr, v := range s.Values[wd] {
+			if v > s.Peak {
+				s.Peak, s.PeakWeekday, s.PeakHour = v, wd, hr
+			}
+		}
+	}
+	return *s
+}
+
+// WriteText renders each series as a weekday × hour grid of shade glyphs,
+// followed by its total, peak slot, and after-hours share.
+func (h HourlyHeatmap) WriteText(w io.Writer) error {
+	var sb strings.Builder
+	for i, s := range h.Series {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		h.writeSeries(&sb, s)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (h HourlyHeatmap) writeSeries(sb *strings.Builder, s HourlySeries) {
+	span := ""
+	if !h.Since.IsZero() && !h.Until.IsZero() {
+		span = fmt.Sprintf(" · %s – %s", h.Since.In(h.Location).Format("Jan 2"), h.Until.In(h.Location).Format("Jan 2"))
+	}
+	fmt.Fprintf(sb, "%s · %s by hour%s (%s)\n", s.Name, h.Metric, span, h.Location)
+
+	labels := []byte(strings.Repeat(" ", 24*2))
+	for hr := 0; hr < 24; hr += 3 {
+		copy(labels[hr*2:], fmt.Sprintf("%d", hr))
+	}
+	sb.WriteString("    " + strings.TrimRight(string(labels), " ") + "\n")
+
+	for row := 0; row < 7; row++ {
+		wd := (h.FirstWeekday + time.Weekday(row)) % 7
+		cells := make([]string, 24)
+		for hr := range cells {
+			cells[hr] = heatmapShade(s.Values[wd][hr], s.Peak)
+		}
+		sb.WriteString(wd.String()[:3] + " " + strings.Join(cells, " ") + "\n")
+	}
+
+	sb.WriteString("    less " + strings.Join(heatmapShades, " ") + " more\n")
+	if s.Total <= 0 {
+		sb.WriteString("    no usage recorded\n")
+		return
+	}
+	fmt.Fprintf(sb, "    total %s · peak %s %s %02d:00 · %.0f%% after hours (outside Mon–Fri %02d:00–%02d:00)\n",
+		formatHeatmapValue(h.Metric, s.Total), formatHeatmapValue(h.Metric, s.Peak),
+		s.PeakWeekday.String()[:3], s.PeakHour, s.AfterHoursShare()*100, workdayStart, workdayEnd)
+}
+
+// WriteJSON encodes the heatmap as one object per series with a 7×24 grid
+// whose rows follow the weekdays list.
+func (h HourlyHeatmap) WriteJSON(w io.Writer) error {
+	view := hourlyView{
+		Metric:   string(h.Metric),
+		By:       "hour",
+		Timezone: h.Location.String(),
+		Series:   make([]hourlySeriesView, 0, len(h.Series)),
+	}
+	if !h.Since.IsZero() {
+		view.Since = h.Since.UTC().Format(time.RFC3339)
+	}
+	if !h.Until.IsZero() {
+		view.Until = h.Until.UTC().Format(time.RFC3339)
+	}
+	for row := 0; row < 7; row++ {
+		view.Weekdays = append(view.Weekdays, ((h.FirstWeekday + time.Weekday(row)) % 7).String()[:3])
+	}
+	for _, s := range h.Series {
+		sv := hourlySeriesView{
+			Name:            s.Name,
+			Total:           s.Total,
+			Peak:            s.Peak,
+			AfterHours:      s.AfterHours,
+			AfterHoursShare: s.AfterHoursShare(),
+			Grid:            make([][]float64, 0, 7),
+		}
+		if s.Peak > 0 {
+			sv.PeakWeekday = s.PeakWeekday.String()[:3]
+			sv.PeakHour = &s.PeakHour
+		}
+		for row := 0; row < 7; row++ {
+			wd := (h.FirstWeekday + time.Weekday(row)) % 7
+			sv.Grid = append(sv.Grid, append([]float64(nil), s.Values[wd][:]...))
+		}
+		view.Series = append(view.Series, sv)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(view)
+}
+
+type hourlyView struct {
+	Metric   string             `json:"metric"`
+	By       string             `json:"by"`
+	Since    string             `json:"since,omitempty"`
+	Until    string             `json:"until,omitempty"`
+	Timezone string             `json:"timezone"`
+	Weekdays []string           `json:"weekdays"`
+	Series   []hourlySeriesView `json:"series"`
+}
+
+type hourlySeriesView struct {
+	Name            string      `json:"name"`
+	Total           float64     `json:"total"`
+	Peak            float64     `json:"peak"`
+	PeakWeekday     string      `json:"peak_weekday,omitempty"`
+	PeakHour        *int        `json:"peak_hour,omitempty"`
+	AfterHours      float64     `json:"after_hours"`
+	AfterHoursShare float64     `json:"after_hours_share"`
+	Grid            [][]float64 `json:"grid"`
+}
@@ -0,0 +1,96 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildHourlyHeatmap(t *testing.T) {
+	tz := time.FixedZone("CET", 3600)
+	at := func(s string) time.Time {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			panic(err)
+		}
+		return t
+	}
+	values := []HourlyValue{
+		{Hour: at("2026-10-14T01:00:00Z"), Provider: "openai", Cost: 3, Requests: 40},      // Wed 02:00 CET, nightly job
+		{Hour: at("2026-10-07T01:00:00Z"), Provider: "openai", Cost: 3, Requests: 40},      // previous Wed 02:00
+		{Hour: at("2026-10-13T09:00:00Z"), Provider: "claude_code", Cost: 2, Requests: 5},  // Tue 10:00, working hours
+		{Hour: at("2026-10-10T14:00:00Z"), Provider: "claude_code", Cost: 1, Requests: 2},  // Sat 15:00, weekend
+		{Hour: at("2026-08-01T10:00:00Z"), Provider: "claude_code", Cost: 50, Requests: 1}, // before Since
+	}
+	opts := HourlyOptions{
+		Metric:       HeatmapCost,
+		FirstWeekday: time.Monday,
+		ByProvider:   true,
+		Location:     tz,
+		Since:        at("2026-10-01T00:00:00Z"),
+		Until:        at("2026-10-15T00:00:00Z"),
+	}
+	h := BuildHourlyHeatmap(values, opts)
+	if len(h.Series) != 3 {
+		t.Fatalf("series = %d, want aggregate + 2 providers", len(h.Series))
+	}
+	agg := h.Series[0]
+	if agg.Total != 9 || agg.Values[time.Wednesday][2] != 6 || agg.Values[time.Tuesday][10] != 2 {
+		t.Errorf("aggregate = total %v, Wed 02:00 %v, Tue 10:00 %v; want 9, 6, 2", agg.Total, agg.Values[time.Wednesday][2], agg.Values[time.Tuesday][10])
+	}
+	if agg.Peak != 6 || agg.PeakWeekday != time.Wednesday || agg.PeakHour != 2 {
+		t.Errorf("peak = %v on %s %02d:00, want 6 on Wednesday 02:00", agg.Peak, agg.PeakWeekday, agg.PeakHour)
+	}
+	if agg.AfterHours != 7 {
+		t.Errorf("after hours = %v, want 7 (nightly job + weekend)", agg.AfterHours)
+	}
+	if h.Series[1].Name != "openai" || h.Series[2].Name != "claude_code" {
+		t.Errorf("provider order = %s, %s; want openai, claude_code", h.Series[1].Name, h.Series[2].Name)
+	}
+
+	opts.Metric, opts.Provider = HeatmapRequests, "claude_code"
+	single := BuildHourlyHeatmap(values, opts)
+	if len(single.Series) != 1 || single.Series[0].Name != "claude_code" || single.Series[0].Total != 7 {
+		t.Errorf("provider-scoped requests heatmap = %+v, want one claude_code series totalling 7", single.Series)
+	}
+}
+
+func TestHourlyHeatmapWriters(t *testing.T) {
+	h := BuildHourlyHeatmap([]HourlyValue{
+		{Hour: time.Date(2026, 10, 14, 2, 0, 0, 0, time.UTC), Provider: "openai", Requests: 1200},
+	}, HourlyOptions{Metric: HeatmapRequests, FirstWeekday: time.Sunday, Location: time.UTC})
+
+	var text bytes.Buffer
+	if err := h.WriteText(&text); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	out := text.String()
+	for _, want := range []string{
+		"all providers · requests by hour (UTC)",
+		"    0     3     6",
+		"Wed · · █ ·",
+		"total 1.2k · peak 1.2k Wed 02:00 · 100% after hours",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("text output missing %q:\n%s", want, out)
+		}
+	}
+
+	var js bytes.Buffer
+	if err := h.WriteJSON(&js); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var view hourlyView
+	if err := json.Unmarshal(js.Bytes(), &view); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if view.By != "hour" || view.Weekdays[0] != "Sun" || len(view.Series) != 1 {
+		t.Fatalf("json = %+v", view)
+	}
+	s := view.Series[0]
+	if len(s.Grid) != 7 || len(s.Grid[3]) != 24 || s.Grid[3][2] != 1200 || s.PeakWeekday != "Wed" || s.PeakHour == nil || *s.PeakHour != 2 {
+		t.Errorf("json series = %+v", s)
+	}
+}
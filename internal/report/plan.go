@@ -0,0 +1,478 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
+	"github.com/janekbaraniewski/openusage/internal/pricing"
+)
+
+// Plan defaults.
+const (
+	DefaultPlanHorizonDays = 30
+	DefaultPlanOutputShare = 0.2
+)
+
+// multiVendorProviders bill usage of models from any vendor against their
+// own credits or plan spend.
+var multiVendorProviders = map[string]bool{
+	"openrouter": true,
+	"opencode":   true,
+	"kilo_code":  true,
+	"cursor":     true,
+}
+
+// vendorProviders maps a model vendor to the providers that serve its
+// models first-party.
+var vendorProviders = map[string][]string{
+	"anthropic": {"anthropic", "claude_code"},
+	"openai":    {"openai", "codex", "azure_openai"},
+	"google":    {"gemini_api", "gemini_cli"},
+	"xai":       {"xai"},
+	"mistral":   {"mistral"},
+	"deepseek":  {"deepseek"},
+	"moonshot":  {"moonshot", "kimi_cli"},
+	"zai":       {"zai"},
+	"alibaba":   {"alibaba_cloud", "qwen_cli"},
+}
+
+// rateWindows are metric windows that describe a throughput ceiling rather
+// than a budget that drains.
+var rateWindows = map[string]bool{
+	"1s": true, "1m": true, "1h": true,
+	"second": true, "minute": true, "hour": true,
+}
+
+// PlanOptions controls BuildPlan.
+type PlanOptions struct {
+	Model        string
+	Price        *pricing.Price
+	TokensPerDay float64
+	OutputShare  float64 // fraction of TokensPerDay billed as output
+	HorizonDays  int     // span the allocation has to last
+}
+
+// PlanBudget is the binding remaining budget of one account: the metric
+// that runs out first at the planned rate.
+type PlanBudget struct {
+	ProviderID  string
+	AccountID   string
+	Metric      string
+	Unit        string // "USD" or "tokens"
+	Remaining   float64
+	Window      string
+	ResetsAt    time.Time
+	ServesModel bool
+	// RunwayDays is how long the budget lasts if it carries the whole load.
+	RunwayDays float64
+}
+
+// PlanAllocation is one account's share of the daily load.
+type PlanAllocation struct {
+	ProviderID   string
+	AccountID    string
+	TokensPerDay float64
+	CostPerDay   float64
+}
+
+// Plan is a what-if estimate of how far the current budgets stretch at a
+// steady daily token rate for one model.
+type Plan struct {
+	Model         string
+	ResolvedModel string
+	TokensPerDay  float64
+	OutputShare   float64
+	HorizonDays   int
+	// CostPerMillion is the input/output blended rate for the model.
+	CostPerMillion float64
+	CostPerDay     float64
+	Budgets        []PlanBudget
+	// RunwayDays is how long every budget that serves the model lasts
+	// together at the planned rate.
+	RunwayDays float64
+	Allocation []PlanAllocation
+	// AllocatedCostPerDay is the spend drawn from USD budgets; quota
+	// budgets are already paid for.
+	AllocatedCostPerDay float64
+	// ShortfallTokensPerDay is the load no budget covers over the horizon,
+	// billed pay-as-you-go at CostPerDay's rate.
+	ShortfallTokensPerDay float64
+}
+
+// BuildPlan estimates runway for every account budget found in snaps and
+// the cheapest way to spread opts.TokensPerDay across them for
+// opts.HorizonDays: prepaid token quotas first, then USD budgets, largest
+// first so the load touches as few accounts as possible.
+func BuildPlan(snaps []core.UsageSnapshot, opts PlanOptions) Plan {
+	if opts.OutputShare <= 0 || opts.OutputShare > 1 {
+		opts.OutputShare = DefaultPlanOutputShare
+	}
+	if opts.HorizonDays <= 0 {
+		opts.HorizonDays = DefaultPlanHorizonDays
+	}
+
+	p := Plan{
+		Model:        opts.Model,
+		TokensPerDay: opts.TokensPerDay,
+		OutputShare:  opts.OutputShare,
+		HorizonDays:  opts.HorizonDays,
+	}
+	vendor := modelVendor(opts.Model)
+	if opts.Price != nil {
+		p.ResolvedModel = opts.Price.ModelID
+		p.CostPerMillion = (1-opts.OutputShare)*opts.Price.InputCostPerMillion + opts.OutputShare*opts.Price.OutputCostPerMillion
+		if v := modelVendor(opts.Price.ModelID); v != "" {
+			vendor = v
+		}
+	}
+	p.CostPerDay = opts.TokensPerDay * p.CostPerMillion / 1_000_000
+
+	for _, snap := range snaps {
+		b, ok := bindingBudget(snap, opts.TokensPerDay, p.CostPerDay)
+		if !ok {
+			continue
+		}
+		b.ServesModel = servesVendor(snap.ProviderID, vendor)
+		p.Budgets = append(p.Budgets, b)
+	}
+	sort.SliceStable(p.Budgets, func(i, j int) bool {
+		a, b := p.Budgets[i], p.Budgets[j]
+		if a.ServesModel != b.ServesModel {
+			return a.ServesModel
+		}
+		if a.RunwayDays != b.RunwayDays {
+			return a.RunwayDays > b.RunwayDays
+		}
+		return a.AccountID < b.AccountID
+	})
+
+	p.allocate()
+	return p
+}
+
+// allocate fills the daily load from budgets that serve the model: token
+// quotas cost nothing extra, so they go first; USD budgets all bill the same
+// list price, so the largest goes next.
+func (p *Plan) allocate() {
+	if p.TokensPerDay <= 0 {
+		return
+	}
+	usable := make([]PlanBudget, 0, len(p.Budgets))
+	pooledTokens := 0.0
+	for _, b := range p.Budgets {
+		if !b.ServesModel {
+			continue
+		}
+		tokens := budgetTokens(b, p.CostPerMillion)
+		if tokens <= 0 {
+			continue
+		}
+		pooledTokens += tokens
+		usable = append(usable, b)
+	}
+	p.RunwayDays = pooledTokens / p.TokensPerDay
+
+	sort.SliceStable(usable, func(i, j int) bool {
+		if (usable[i].Unit == "tokens") != (usable[j].Unit == "tokens") {
+			return usable[i].Unit == "tokens"
+		}
+		return budgetTokens(usable[i], p.CostPerMillion) > budgetTokens(usable[j], p.CostPerMillion)
+	})
+
+	need := p.TokensPerDay
+	for _, b := range usable {
+		if need <= 0 {
+			break
+		}
+		perDay := math.Min(need, budgetTokens(b, p.CostPerMillion)/float64(p.HorizonDays))
+		a := PlanAllocation{ProviderID: b.ProviderID, AccountID: b.AccountID, TokensPerDay: perDay}
+		if b.Unit == "USD" {
+			a.CostPerDay = perDay * p.CostPerMillion / 1_000_000
+			p.AllocatedCostPerDay += a.CostPerDay
+		}
+		p.Allocation = append(p.Allocation, a)
+		need -= perDay
+	}
+	if need > 1e-6 {
+		p.ShortfallTokensPerDay = need
+	}
+}
+
+// budgetTokens converts a budget into the number of tokens it buys.
+func budgetTokens(b PlanBudget, costPerMillion float64) float64 {
+	if b.Unit == "tokens" {
+		return b.Remaining
+	}
+	if costPerMillion <= 0 {
+		return 0
+	}
+	return b.Remaining / costPerMillion * 1_000_000
+}
+
+// bindingBudget picks the snapshot's budget that runs out first at the given
+// daily token and dollar rates. Percent-only plan windows can't be converted
+// to tokens and are skipped.
+func bindingBudget(snap core.UsageSnapshot, tokensPerDay, costPerDay float64) (PlanBudget, bool) {
+	var best PlanBudget
+	found := false
+	for _, key := range sortedMetricKeys(snap.Metrics) {
+		m := snap.Metrics[key]
+		unit := strings.ToLower(m.Unit)
+		if unit == "usd" {
+			unit = "USD"
+		} else if unit != "tokens" || key == "context_window" {
+			continue
+		}
+		if rateWindows[strings.ToLower(m.Window)] {
+			continue
+		}
+		remaining, ok := metricRemaining(m)
+		if !ok {
+			continue
+		}
+		rate := tokensPerDay
+		if unit == "USD" {
+			rate = costPerDay
+		}
+		runway := math.Inf(1)
+		if rate > 0 {
+			runway = remaining / rate
+		}
+		if found && runway >= best.RunwayDays {
+			continue
+		}
+		best = PlanBudget{
+			ProviderID: snap.ProviderID,
+			AccountID:  snap.AccountID,
+			Metric:     key,
+			Unit:       unit,
+			Remaining:  remaining,
+			Window:     m.Window,
+			ResetsAt:   metricReset(snap, key),
+			RunwayDays: runway,
+		}
+		found = true
+	}
+	return best, found
+}
+
+// metricRemaining reports what is left of a capped metric. Counters without
+// a limit or remaining value aren't budgets.
+func metricRemaining(m core.Metric) (float64, bool) {
+	switch {
+	case m.Remaining != nil:
+		return math.Max(*m.Remaining, 0), true
+	case m.Limit != nil && *m.Limit > 0 && m.Used != nil:
+		return math.Max(*m.Limit-*m.Used, 0), true
+	}
+	return 0, false
+}
+
+func metricReset(snap core.UsageSnapshot, key string) time.Time {
+	if t, ok := snap.Resets[key]; ok {
+		return t
+	}
+	return snap.Resets[key+"_reset"]
+}
+
+func sortedMetricKeys(metrics map[string]core.Metric) []string {
+	keys := make([]string, 0, len(metrics))
+	for k := range metrics {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// modelVendor guesses the vendor of a model ID from its family name.
+func modelVendor(model string) string {
+	m := strings.ToLower(model)
+	if i := strings.LastIndex(m, "/"); i >= 0 {
+		m = m[i+1:]
+	}
+	switch {
+	case strings.Contains(m, "claude"):
+		return "anthropic"
+	case strings.HasPrefix(m, "gpt"), strings.HasPrefix(m, "o1"), strings.HasPrefix(m, "o3"),
+		strings.HasPrefix(m, "o4"), strings.Contains(m, "codex"):
+		return "openai"
+	case strings.Contains(m, "gemini"):
+		return "google"
+	case strings.Contains(m, "grok"):
+		return "xai"
+	case strings.Contains(m, "mistral"), strings.Contains(m, "codestral"), strings.Contains(m, "devstral"):
+		return "mistral"
+	case strings.Contains(m, "deepseek"):
+		return "deepseek"
+	case strings.Contains(m, "kimi"), strings.Contains(m, "moonshot"):
+		return "moonshot"
+	case strings.Contains(m, "glm"):
+		return "zai"
+	case strings.Contains(m, "qwen"):
+		return "alibaba"
+	}
+	return ""
+}
+
+func servesVendor(providerID, vendor string) bool {
+	if multiVendorProviders[providerID] {
+		return true
+	}
+	for _, id := range vendorProviders[vendor] {
+		if id == providerID {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteText renders the plan as a budget table followed by the allocation.
+func (p Plan) WriteText(w io.Writer) error {
+	loc := locale.Current()
+	model := p.Model
+	if p.ResolvedModel != "" && p.ResolvedModel != p.Model {
+		model += " (" + p.ResolvedModel + ")"
+	}
+	fmt.Fprintf(w, "%s · %s tokens/day · %.0f%% output · %s per 1M blended · %s/day at list price\n\n",
+		model, fmtTokens(int(p.TokensPerDay)), p.OutputShare*100, fmtCost(p.CostPerMillion), fmtCost(p.CostPerDay))
+
+	if len(p.Budgets) == 0 {
+		fmt.Fprintln(w, "No account reports a remaining USD or token budget.")
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ACCOUNT\tPROVIDER\tBUDGET\tREMAINING\tRESETS\tRUNWAY")
+	for _, b := range p.Budgets {
+		remaining := fmtTokens(int(b.Remaining)) + " tokens"
+		if b.Unit == "USD" {
+			remaining = fmtCost(b.Remaining)
+		}
+		resets := "-"
+		if !b.ResetsAt.IsZero() {
+			resets = loc.DateTime(b.ResetsAt)
+		}
+		runway := "doesn't serve model"
+		if b.ServesModel {
+			runway = fmtRunway(b.RunwayDays)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", b.AccountID, b.ProviderID, b.Metric, remaining, resets, runway)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if p.TokensPerDay <= 0 {
+		return nil
+	}
+	fmt.Fprintf(w, "\nCombined runway: %s\n", fmtRunway(p.RunwayDays))
+	fmt.Fprintf(w, "Cheapest allocation over %d days:\n", p.HorizonDays)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, a := range p.Allocation {
+		fmt.Fprintf(tw, "  %s\t%s tokens/day\t%s/day\n", a.AccountID, fmtTokens(int(a.TokensPerDay)), fmtCost(a.CostPerDay))
+	}
+	if p.ShortfallTokensPerDay > 0 {
+		shortfallCost := p.ShortfallTokensPerDay * p.CostPerMillion / 1_000_000
+		fmt.Fprintf(tw, "  pay-as-you-go\t%s tokens/day\t%s/day\n", fmtTokens(int(p.ShortfallTokensPerDay)), fmtCost(shortfallCost))
+	}
+	return tw.Flush()
+}
+
+func fmtRunway(days float64) string {
+	switch {
+	case math.IsInf(days, 1):
+		return "unlimited"
+	case days < 1:
+		return fmt.Sprintf("%.0fh", days*24)
+	default:
+		return fmt.Sprintf("%.1f days", days)
+	}
+}
+
+type planView struct {
+	Model                 string               `json:"model"`
+	ResolvedModel         string               `json:"resolved_model,omitempty"`
+	TokensPerDay          float64              `json:"tokens_per_day"`
+	OutputShare           float64              `json:"output_share"`
+	HorizonDays           int                  `json:"horizon_days"`
+	CostPerMillion        float64              `json:"cost_per_million"`
+	CostPerDay            float64              `json:"cost_per_day"`
+	RunwayDays            *float64             `json:"runway_days"`
+	Budgets               []planBudgetView     `json:"budgets"`
+	Allocation            []planAllocationView `json:"allocation"`
+	AllocatedCostPerDay   float64              `json:"allocated_cost_per_day"`
+	ShortfallTokensPerDay float64              `json:"shortfall_tokens_per_day"`
+}
+
+type planBudgetView struct {
+	ProviderID  string   `json:"provider_id"`
+	AccountID   string   `json:"account_id"`
+	Metric      string   `json:"metric"`
+	Unit        string   `json:"unit"`
+	Remaining   float64  `json:"remaining"`
+	Window      string   `json:"window,omitempty"`
+	ResetsAt    string   `json:"resets_at,omitempty"`
+	ServesModel bool     `json:"serves_model"`
+	RunwayDays  *float64 `json:"runway_days"`
+}
+
+type planAllocationView struct {
+	ProviderID   string  `json:"provider_id"`
+	AccountID    string  `json:"account_id"`
+	TokensPerDay float64 `json:"tokens_per_day"`
+	CostPerDay   float64 `json:"cost_per_day"`
+}
+
+// WriteJSON renders the plan as JSON. Unlimited runways are null.
+func (p Plan) WriteJSON(w io.Writer) error {
+	view := planView{
+		Model:                 p.Model,
+		ResolvedModel:         p.ResolvedModel,
+		TokensPerDay:          p.TokensPerDay,
+		OutputShare:           p.OutputShare,
+		HorizonDays:           p.HorizonDays,
+		CostPerMillion:        p.CostPerMillion,
+		CostPerDay:            p.CostPerDay,
+		RunwayDays:            finiteOrNil(p.RunwayDays),
+		Budgets:               make([]planBudgetView, 0, len(p.Budgets)),
+		Allocation:            make([]planAllocationView, 0, len(p.Allocation)),
+		AllocatedCostPerDay:   p.AllocatedCostPerDay,
+		ShortfallTokensPerDay: p.ShortfallTokensPerDay,
+	}
+	for _, b := range p.Budgets {
+		bv := planBudgetView{
+			ProviderID:  b.ProviderID,
+			AccountID:   b.AccountID,
+			Metric:      b.Metric,
+			Unit:        b.Unit,
+			Remaining:   b.Remaining,
+			Window:      b.Window,
+			ServesModel: b.ServesModel,
+			RunwayDays:  finiteOrNil(b.RunwayDays),
+		}
+		if !b.ResetsAt.IsZero() {
+			bv.ResetsAt = b.ResetsAt.UTC().Format(time.RFC3339)
+		}
+		view.Budgets = append(view.Budgets, bv)
+	}
+	for _, a := range p.Allocation {
+		view.Allocation = append(view.Allocation, planAllocationView(a))
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(view)
+}
+
+func finiteOrNil(v float64) *float64 {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil
+	}
+	return &v
+}
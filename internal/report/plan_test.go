@@ -0,0 +1,147 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/pricing"
+)
+
+func planSnap(provider, account string, metrics map[string]core.Metric) core.UsageSnapshot {
+	return core.UsageSnapshot{ProviderID: provider, AccountID: account, Metrics: metrics}
+}
+
+func TestBuildPlan(t *testing.T) {
+	// $4 in / $8 out with 25% output blends to $5 per 1M tokens, so 5M
+	// tokens/day cost $25/day at list price.
+	price := &pricing.Price{ModelID: "claude-sonnet-4-5", InputCostPerMillion: 4, OutputCostPerMillion: 8}
+	snaps := []core.UsageSnapshot{
+		planSnap("openrouter", "openrouter", map[string]core.Metric{
+			"credit_balance": {Remaining: core.Float64Ptr(300), Unit: "USD", Window: "current"},
+			"today_cost":     {Used: core.Float64Ptr(4), Unit: "USD", Window: "today"},
+		}),
+		planSnap("anthropic", "anthropic", map[string]core.Metric{
+			// The spend limit binds before the larger credit balance.
+			"spend_limit":    {Limit: core.Float64Ptr(100), Used: core.Float64Ptr(50), Unit: "USD", Window: "month"},
+			"credit_balance": {Remaining: core.Float64Ptr(500), Unit: "USD"},
+			"tpm":            {Limit: core.Float64Ptr(400000), Remaining: core.Float64Ptr(1), Unit: "tokens", Window: "1m"},
+		}),
+		planSnap("claude_code", "claude-pro", map[string]core.Metric{
+			"token_quota":  {Limit: core.Float64Ptr(60e6), Used: core.Float64Ptr(0), Unit: "tokens", Window: "month"},
+			"usage_weekly": {Limit: core.Float64Ptr(100), Used: core.Float64Ptr(40), Unit: "%", Window: "7d"},
+		}),
+		planSnap("openai", "openai", map[string]core.Metric{
+			"credit_balance": {Remaining: core.Float64Ptr(1000), Unit: "USD"},
+		}),
+		planSnap("groq", "groq", map[string]core.Metric{
+			"rpd": {Limit: core.Float64Ptr(1000), Remaining: core.Float64Ptr(900), Unit: "requests", Window: "1d"},
+		}),
+	}
+
+	p := BuildPlan(snaps, PlanOptions{Model: "claude-sonnet", Price: price, TokensPerDay: 5e6, OutputShare: 0.25, HorizonDays: 30})
+
+	if math.Abs(p.CostPerMillion-5) > 1e-9 || math.Abs(p.CostPerDay-25) > 1e-9 {
+		t.Fatalf("blended rate = %v/1M, %v/day; want 5 and 25", p.CostPerMillion, p.CostPerDay)
+	}
+	if len(p.Budgets) != 4 {
+		t.Fatalf("budgets = %+v, want openrouter, anthropic, claude-pro, openai", p.Budgets)
+	}
+	byAccount := map[string]PlanBudget{}
+	for _, b := range p.Budgets {
+		byAccount[b.AccountID] = b
+	}
+	if b := byAccount["anthropic"]; b.Metric != "spend_limit" || b.Remaining != 50 || b.RunwayDays != 2 {
+		t.Errorf("anthropic binding budget = %+v, want spend_limit with 50 left and 2 days", b)
+	}
+	if b := byAccount["claude-pro"]; b.Unit != "tokens" || b.RunwayDays != 12 {
+		t.Errorf("claude-pro budget = %+v, want 60M tokens lasting 12 days", b)
+	}
+	if b := byAccount["openai"]; b.ServesModel {
+		t.Errorf("openai credits shouldn't serve a Claude model")
+	}
+	if last := p.Budgets[len(p.Budgets)-1]; last.AccountID != "openai" {
+		t.Errorf("budgets that can't serve the model should sort last, got %s", last.AccountID)
+	}
+
+	// Pool: 60M quota tokens + $300 OpenRouter (60M) + $50 Anthropic (10M) = 130M.
+	if math.Abs(p.RunwayDays-26) > 1e-9 {
+		t.Errorf("combined runway = %v, want 26 days", p.RunwayDays)
+	}
+	// Over 30 days: quota gives 2M/day free, OpenRouter 2M/day, Anthropic
+	// 1/3M/day; the rest is pay-as-you-go.
+	if len(p.Allocation) != 3 || p.Allocation[0].AccountID != "claude-pro" || p.Allocation[0].CostPerDay != 0 ||
+		p.Allocation[1].AccountID != "openrouter" || p.Allocation[2].AccountID != "anthropic" {
+		t.Fatalf("allocation = %+v", p.Allocation)
+	}
+	if want := 5e6 - 2e6 - 2e6 - 1e7/30; math.Abs(p.ShortfallTokensPerDay-want) > 1 {
+		t.Errorf("shortfall = %v, want %v", p.ShortfallTokensPerDay, want)
+	}
+	if want := (2e6 + 1e7/30) * 5 / 1e6; math.Abs(p.AllocatedCostPerDay-want) > 1e-6 {
+		t.Errorf("allocated cost = %v, want %v", p.AllocatedCostPerDay, want)
+	}
+}
+
+func TestBuildPlan_CoveredWithoutShortfall(t *testing.T) {
+	price := &pricing.Price{ModelID: "gpt-4o", InputCostPerMillion: 2.5, OutputCostPerMillion: 10}
+	snaps := []core.UsageSnapshot{
+		planSnap("openai", "openai", map[string]core.Metric{
+			"credit_balance": {Remaining: core.Float64Ptr(10000), Unit: "USD"},
+		}),
+	}
+	p := BuildPlan(snaps, PlanOptions{Model: "gpt-4o", Price: price, TokensPerDay: 1e6})
+	if p.HorizonDays != DefaultPlanHorizonDays || p.OutputShare != DefaultPlanOutputShare {
+		t.Errorf("defaults not applied: %+v", p)
+	}
+	if len(p.Allocation) != 1 || p.Allocation[0].TokensPerDay != 1e6 || p.ShortfallTokensPerDay != 0 {
+		t.Errorf("allocation = %+v shortfall = %v", p.Allocation, p.ShortfallTokensPerDay)
+	}
+}
+
+func TestPlanWriters(t *testing.T) {
+	price := &pricing.Price{ModelID: "claude-sonnet-4-5", InputCostPerMillion: 3, OutputCostPerMillion: 15}
+	snaps := []core.UsageSnapshot{
+		planSnap("openrouter", "openrouter", map[string]core.Metric{
+			"credit_balance": {Remaining: core.Float64Ptr(20), Unit: "USD"},
+		}),
+		planSnap("xai", "xai", map[string]core.Metric{
+			"credit_balance": {Remaining: core.Float64Ptr(20), Unit: "USD"},
+		}),
+	}
+	p := BuildPlan(snaps, PlanOptions{Model: "claude-sonnet", Price: price, TokensPerDay: 5e6})
+
+	var text bytes.Buffer
+	if err := p.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"claude-sonnet (claude-sonnet-4-5)", "credit_balance", "doesn't serve model", "Combined runway", "pay-as-you-go"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text missing %q:\n%s", want, text.String())
+		}
+	}
+
+	var raw bytes.Buffer
+	if err := p.WriteJSON(&raw); err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Budgets []struct {
+			AccountID   string   `json:"account_id"`
+			ServesModel bool     `json:"serves_model"`
+			RunwayDays  *float64 `json:"runway_days"`
+		} `json:"budgets"`
+		ShortfallTokensPerDay float64 `json:"shortfall_tokens_per_day"`
+	}
+	if err := json.Unmarshal(raw.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode: %v\n%s", err, raw.String())
+	}
+	if len(decoded.Budgets) != 2 || !decoded.Budgets[0].ServesModel || decoded.Budgets[0].RunwayDays == nil {
+		t.Errorf("budgets = %+v", decoded.Budgets)
+	}
+	if decoded.ShortfallTokensPerDay <= 0 {
+		t.Errorf("expected a shortfall, got %v", decoded.ShortfallTokensPerDay)
+	}
+}
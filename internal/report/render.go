@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"strconv"
+	"math"
 	"strings"
 	"text/tabwriter"
 	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/locale"
 )
 
 // WriteJSON encodes the report as indented JSON via a stable view that omits
@@ -18,6 +20,7 @@ func (rep Report) WriteJSON(w io.Writer) error {
 		Rows:   make([]rowView, 0, len(rep.Rows)),
 		Totals: toRowView(rep.Totals),
 		Note:   rep.Note,
+		Energy: rep.Energy,
 	}
 	for _, r := range rep.Rows {
 		view.Rows = append(view.Rows, toRowView(r))
@@ -32,6 +35,7 @@ type reportView struct {
 	Rows   []rowView `json:"rows"`
 	Totals rowView   `json:"totals"`
 	Note   string    `json:"note,omitempty"`
+	Energy *Energy   `json:"energy,omitempty"`
 }
 
 type rowView struct {
@@ -104,6 +108,11 @@ func (rep Report) WriteTable(w io.Writer) error {
 	if err := tw.Flush(); err != nil {
 		return err
 	}
+	if rep.Energy != nil {
+		loc := locale.Current()
+		fmt.Fprintf(w, "\nenergy: ≈ %s · %s CO2e (rough estimate at %s gCO2e/kWh)\n",
+			loc.Energy(rep.Energy.Wh), loc.CO2(rep.Energy.CO2Grams), loc.Float(rep.Energy.GridIntensity, 0))
+	}
 	if rep.Note != "" {
 		fmt.Fprintf(w, "\nnote: %s\n", rep.Note)
 	}
@@ -154,8 +163,12 @@ func writeBlocksTable(tw *tabwriter.Writer, rep Report) {
 		if r.BurnRateUSDPerHour > 0 {
 			burn = fmtCost(r.BurnRateUSDPerHour)
 		}
+		label := r.Label
+		if !r.Start.IsZero() {
+			label = fmtTime(r.Start)
+		}
 		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			r.Label, state, fmtTokens(r.Input), fmtTokens(r.Output),
+			label, state, fmtTokens(r.Input), fmtTokens(r.Output),
 			fmtTokens(r.TotalTokens), fmtCost(r.Cost), burn, projected)
 	}
 	writeTotalsSeparator(tw, 8)
@@ -183,32 +196,24 @@ func writeTotalsSeparator(tw *tabwriter.Writer, cols int) {
 // --- formatting helpers ---
 
 func fmtTokens(n int) string {
-	switch {
-	case n >= 1_000_000:
-		return fmt.Sprintf("%.1fM", float64(n)/1e6)
-	case n >= 1_000:
-		return fmt.Sprintf("%.1fk", float64(n)/1e3)
-	default:
-		return strconv.Itoa(n)
-	}
+	return locale.Current().Quantity(float64(n), "tokens")
 }
 
+// fmtCost renders report costs exactly, cents included; reports are where
+// totals get reconciled against invoices, so they are never compacted.
 func fmtCost(c float64) string {
-	switch {
-	case c == 0:
-		return "$0.00"
-	case c < 0.01:
-		return fmt.Sprintf("$%.4f", c)
-	default:
-		return fmt.Sprintf("$%.2f", c)
+	loc := locale.Current()
+	if c != 0 && math.Abs(c) < 0.01 {
+		return loc.Quantity(c, "USD")
 	}
+	return loc.USD(c, 2)
 }
 
 func fmtTime(t time.Time) string {
 	if t.IsZero() {
 		return ""
 	}
-	return t.Format("2006-01-02 15:04")
+	return locale.Current().DateTime(t)
 }
 
 func fmtDurationShort(d time.Duration) string {
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/locale"
 )
 
 func TestWriteJSON_OmitsZeroTimestamps(t *testing.T) {
@@ -82,3 +84,25 @@ func TestShortModel(t *testing.T) {
 		}
 	}
 }
+
+func TestWriteTable_UsesActiveLocale(t *testing.T) {
+	de, _ := locale.Lookup("de_DE")
+	locale.Set(de)
+	t.Cleanup(func() { locale.Set(locale.Default) })
+
+	rep := Build([]Event{
+		ev("2026-06-01T10:00:00Z", "claude_code", "opus", 1234.5, 1_500_000, 10),
+	}, Options{Kind: KindDaily})
+
+	var buf bytes.Buffer
+	if err := rep.WriteTable(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "1,5M") {
+		t.Errorf("table missing locale-formatted tokens (1,5M):\n%s", out)
+	}
+	if !strings.Contains(out, "1.234,50 $") {
+		t.Errorf("table missing locale-formatted cost:\n%s", out)
+	}
+}
@@ -103,6 +103,9 @@ type Report struct {
 	Rows   []Row  `json:"rows"`
 	Totals Row    `json:"totals"`
 	Note   string `json:"note,omitempty"`
+	// Energy is set by the caller from EstimateEnergy when the energy
+	// estimator is enabled.
+	Energy *Energy `json:"energy,omitempty"`
 }
 
 // Options configures aggregation.
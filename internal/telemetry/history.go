@@ -0,0 +1,297 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// UsageHistoryDays is the trailing span of persisted daily history attached to
+// snapshots: the calendar heatmap plots the trailing 12 weeks of it, and the
+// detail charts reach back this far when the 90-day range is selected.
+const UsageHistoryDays = 90
+
+// DailyUsage is one provider's settled usage for a UTC calendar day.
+type DailyUsage struct {
+	Day        string // YYYY-MM-DD
+	ProviderID string
+	CostUSD    float64
+	Tokens     float64
+	Requests   float64
+}
+
+// DailyUsageHistory returns per-day, per-provider usage since `since`, built on
+// persisted history: days up to the rollup watermark come from
+// usage_rollup_daily (which survives raw-event pruning), later days from the
+// deduped raw events. An empty providerIDs means every provider; an empty
+// accountID means every account.
+func (s *Store) DailyUsageHistory(ctx context.Context, providerIDs []string, accountID string, since time.Time) ([]DailyUsage, error) {
+	if s == nil || s.db == nil {
+		return nil, nil
+	}
+	return queryDailyUsageHistory(ctx, s.db, providerIDs, accountID, since)
+}
+
+// LoadDailyUsageHistory opens the telemetry database at dbPath read-only and
+// returns its daily usage history. A missing database yields no rows.
+func LoadDailyUsageHistory(ctx context.Context, dbPath string, providerIDs []string, since time.Time) ([]DailyUsage, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("telemetry: history db: %w", err)
+	}
+	db, err := openReadOnlyDB(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: open history db: %w", err)
+	}
+	defer db.Close()
+	return queryDailyUsageHistory(ctx, db, providerIDs, "", since)
+}
+
+func queryDailyUsageHistory(ctx context.Context, db *sql.DB, providerIDs []string, account string, since time.Time) ([]DailyUsage, error) {
+	providerIDs = normalizeProviderIDs(providerIDs)
+	account = strings.TrimSpace(account)
+	since = since.UTC().Truncate(24 * time.Hour)
+	sinceDay := since.Format("2006-01-02")
+
+	var wm string
+	err := db.QueryRowContext(ctx, `SELECT value FROM daemon_meta WHERE key = ?`, rollupWatermarkKey).Scan(&wm)
+	if err != nil && err != sql.ErrNoRows && !isMissingTableErr(err) {
+		return nil, fmt.Errorf("telemetry: history watermark: %w", err)
+	}
+
+	var out []DailyUsage
+
+	// Settled days: the rollup. tool_name = '' keeps message rows only,
+	// matching the message_usage filter applied to raw events below.
+	if wm != "" && wm >= sinceDay {
+		where := []string{"day >= ?", "day <= ?", "tool_name = ''", "status != 'error'"}
+		args := []any{sinceDay, wm}
+		where, args = appendHistoryScope(where, args, "", providerIDs, account)
+		rows, err := db.QueryContext(ctx, `
+			SELECT day, provider_id,
+			       SUM(cost_usd),
+			       SUM(CASE WHEN total_tokens > 0 THEN total_tokens
+			                ELSE input_tokens + output_tokens + reasoning_tokens + cache_read_tokens + cache_write_tokens END),
+			       SUM(CASE WHEN requests > 0 THEN requests ELSE event_count END)
+			FROM usage_rollup_daily
+			WHERE `+strings.Join(where, " AND ")+`
+			GROUP BY day, provider_id`, args...)
+		if err != nil {
+			return nil, fmt.Errorf("telemetry: history rollup query: %w", err)
+		}
+		out, err = scanDailyUsage(rows, out)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Unsettled days (after the watermark, or everything if the rollup has
+	// never run): the deduped raw events.
+	rawSince := since
+	if wm != "" {
+		if t, err := time.Parse("2006-01-02", wm); err == nil && !t.AddDate(0, 0, 1).Before(rawSince) {
+			rawSince = t.AddDate(0, 0, 1)
+		}
+	}
+	where := []string{"e.occurred_at >= ?"}
+	args := []any{rawSince.Format(time.RFC3339Nano)}
+	where, args = appendHistoryScope(where, args, "e.", providerIDs, account)
+	cte, cteArgs := dedupedUsageCTEWhere(strings.Join(where, " AND "), args)
+	rows, err := db.QueryContext(ctx, cte+`
+		SELECT date(occurred_at) AS day, provider_id,
+		       SUM(COALESCE(cost_usd, 0)),
+		       SUM(COALESCE(total_tokens,
+		           COALESCE(input_tokens, 0) +
+		           COALESCE(output_tokens, 0) +
+		           COALESCE(reasoning_tokens, 0) +
+		           COALESCE(cache_read_tokens, 0) +
+		           COALESCE(cache_write_tokens, 0))),
+		       SUM(COALESCE(requests, 1))
+		FROM deduped_usage
+		WHERE event_type = 'message_usage'
+		  AND status != 'error'
+		GROUP BY day, provider_id`, cteArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: history raw query: %w", err)
+	}
+	return scanDailyUsage(rows, out)
+}
+
+// HourlyUsage is one provider's usage within one UTC hour.
+type HourlyUsage struct {
+	Hour       time.Time // start of the hour, UTC
+	ProviderID string
+	CostUSD    float64
+	Tokens     float64
+	Requests   float64
+}
+
+// LoadHourlyUsage opens the telemetry database at dbPath read-only and returns
+// per-hour, per-provider usage since `since`. Only raw events carry the time
+// of day, so history is limited to data.retention_days. A missing database
+// yields no rows.
+func LoadHourlyUsage(ctx context.Context, dbPath string, providerIDs []string, since time.Time) ([]HourlyUsage, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("telemetry: history db: %w", err)
+	}
+	db, err := openReadOnlyDB(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: open history db: %w", err)
+	}
+	defer db.Close()
+	return queryHourlyUsage(ctx, db, normalizeProviderIDs(providerIDs), since)
+}
+
+func queryHourlyUsage(ctx context.Context, db *sql.DB, providerIDs []string, since time.Time) ([]HourlyUsage, error) {
+	where := []string{"e.occurred_at >= ?"}
+	args := []any{since.UTC().Format(time.RFC3339Nano)}
+	where, args = appendHistoryScope(where, args, "e.", providerIDs, "")
+	cte, cteArgs := dedupedUsageCTEWhere(strings.Join(where, " AND "), args)
+	rows, err := db.QueryContext(ctx, cte+`
+		SELECT substr(occurred_at, 1, 13) AS hour, provider_id,
+		       SUM(COALESCE(cost_usd, 0)),
+		       SUM(COALESCE(total_tokens,
+		           COALESCE(input_tokens, 0) +
+		           COALESCE(output_tokens, 0) +
+		           COALESCE(reasoning_tokens, 0) +
+		           COALESCE(cache_read_tokens, 0) +
+		           COALESCE(cache_write_tokens, 0))),
+		       SUM(COALESCE(requests, 1))
+		FROM deduped_usage
+		WHERE event_type = 'message_usage'
+		  AND status != 'error'
+		GROUP BY hour, provider_id`, cteArgs...)
+	if err != nil {
+		if isMissingTableErr(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("telemetry: hourly history query: %w", err)
+	}
+	defer rows.Close()
+	var out []HourlyUsage
+	for rows.Next() {
+		var hour string
+		var h HourlyUsage
+		if err := rows.Scan(&hour, &h.ProviderID, &h.CostUSD, &h.Tokens, &h.Requests); err != nil {
+			return nil, fmt.Errorf("telemetry: scan hourly row: %w", err)
+		}
+		t, err := time.Parse("2006-01-02T15", hour)
+		if err != nil {
+			continue
+		}
+		h.Hour = t
+		out = append(out, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("telemetry: iterate hourly rows: %w", err)
+	}
+	return out, nil
+}
+
+func appendHistoryScope(where []string, args []any, prefix string, providerIDs []string, account string) ([]string, []any) {
+	if len(providerIDs) > 0 {
+		placeholders := make([]string, len(providerIDs))
+		for i, id := range providerIDs {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		where = append(where, prefix+"provider_id IN ("+strings.Join(placeholders, ",")+")")
+	}
+	if account != "" {
+		where = append(where, prefix+"account_id = ?")
+		args = append(args, account)
+	}
+	return where, args
+}
+
+func scanDailyUsage(rows *sql.Rows, out []DailyUsage) ([]DailyUsage, error) {
+	defer rows.Close()
+	for rows.Next() {
+		var d DailyUsage
+		if err := rows.Scan(&d.Day, &d.ProviderID, &d.CostUSD, &d.Tokens, &d.Requests); err != nil {
+			return nil, fmt.Errorf("telemetry: scan history row: %w", err)
+		}
+		out = append(out, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("telemetry: iterate history rows: %w", err)
+	}
+	return out, nil
+}
+
+func isMissingTableErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "no such table")
+}
+
+// applyUsageHistory attaches the trailing UsageHistoryDays of persisted daily
+// cost and tokens to each telemetry-backed snapshot as the `history_cost` and
+// `history_tokens` series. Unlike the analytics series these are independent
+// of the selected time window, so the detail heatmap always spans 12 weeks.
+func applyUsageHistory(ctx context.Context, db *sql.DB, snaps map[string]core.UsageSnapshot, providerLinks map[string]string, now time.Time) map[string]core.UsageSnapshot {
+	if db == nil || len(snaps) == 0 {
+		return snaps
+	}
+	since := now.UTC().AddDate(0, 0, -(UsageHistoryDays - 1))
+	cache := make(map[string][]DailyUsage)
+	for id, snap := range snaps {
+		sources := telemetrySourceProvidersForTarget(snap.ProviderID, providerLinks)
+		if len(sources) == 0 {
+			continue
+		}
+		account := strings.TrimSpace(snap.AccountID)
+		if account == "" {
+			account = strings.TrimSpace(id)
+		}
+		key := strings.Join(sources, ",") + "|" + account
+		days, ok := cache[key]
+		if !ok {
+			var err error
+			days, err = queryDailyUsageHistory(ctx, db, sources, account, since)
+			if err == nil && len(days) == 0 {
+				// Mirror the usage view: fall back to provider scope when the
+				// account has no events of its own.
+				days, err = queryDailyUsageHistory(ctx, db, sources, "", since)
+			}
+			if err != nil {
+				core.Tracef("[read_model] usage history %s: %v", snap.ProviderID, err)
+				continue
+			}
+			cache[key] = days
+		}
+		if len(days) == 0 {
+			continue
+		}
+		cost, tokens := dailyUsageSeries(days)
+		series := make(map[string][]core.TimePoint, len(snap.DailySeries)+2)
+		for k, v := range snap.DailySeries {
+			series[k] = v
+		}
+		series["history_cost"] = cost
+		series["history_tokens"] = tokens
+		snap.DailySeries = series
+		snaps[id] = snap
+	}
+	return snaps
+}
+
+// dailyUsageSeries folds per-provider rows into date-ordered cost and token
+// series.
+func dailyUsageSeries(days []DailyUsage) (cost, tokens []core.TimePoint) {
+	costByDay := make(map[string]float64)
+	tokensByDay := make(map[string]float64)
+	for _, d := range days {
+		costByDay[d.Day] += d.CostUSD
+		tokensByDay[d.Day] += d.Tokens
+	}
+	return core.SortedTimePoints(costByDay), core.SortedTimePoints(tokensByDay)
+}
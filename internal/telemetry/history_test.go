@@ -0,0 +1,119 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestDailyUsageHistory_MergesRollupAndRawEvents(t *testing.T) {
+	_, store := openUsageViewTestStore(t)
+	ctx := context.Background()
+	now := time.Date(2026, 6, 11, 12, 0, 0, 0, time.UTC)
+
+	mk := func(at time.Time, id string, in int64, cost float64) IngestRequest {
+		return IngestRequest{
+			SourceSystem:  "codex",
+			SourceChannel: SourceChannelHook,
+			OccurredAt:    at,
+			ProviderID:    "openai",
+			AccountID:     "acct",
+			SessionID:     "s1",
+			MessageID:     id,
+			EventType:     EventTypeMessageUsage,
+			ModelRaw:      "gpt-5",
+			TokenUsage:    core.TokenUsage{InputTokens: i64(in), CostUSD: f64p(cost)},
+		}
+	}
+	d40 := now.AddDate(0, 0, -40)
+	d3 := now.AddDate(0, 0, -3)
+	mustIngestUsageEvent(t, store, mk(d40, "m1", 100, 1.0), "d40")
+	mustIngestUsageEvent(t, store, mk(d3, "m2", 50, 0.5), "d3")
+	if _, err := store.RollupDaily(ctx, now); err != nil {
+		t.Fatalf("rollup: %v", err)
+	}
+
+	// Prune the old raw event: the rollup must still carry that day.
+	if _, err := store.db.ExecContext(ctx, `DELETE FROM usage_events WHERE occurred_at < ?`,
+		now.AddDate(0, 0, -30).Format(time.RFC3339Nano)); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	// An event after the watermark only exists as a raw row.
+	mustIngestUsageEvent(t, store, mk(now, "m3", 10, 0.25), "today")
+
+	got, err := store.DailyUsageHistory(ctx, []string{"openai"}, "", now.AddDate(0, 0, -(UsageHistoryDays-1)))
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	byDay := map[string]DailyUsage{}
+	for _, d := range got {
+		byDay[d.Day] = d
+	}
+	if len(byDay) != 3 {
+		t.Fatalf("history days = %v, want 3 distinct days", got)
+	}
+	for _, tc := range []struct {
+		day    time.Time
+		cost   float64
+		tokens float64
+	}{
+		{d40, 1.0, 100},
+		{d3, 0.5, 50},
+		{now, 0.25, 10},
+	} {
+		d := byDay[tc.day.Format("2006-01-02")]
+		if d.CostUSD != tc.cost || d.Tokens != tc.tokens {
+			t.Errorf("%s = cost %.2f tokens %.0f, want %.2f/%.0f", d.Day, d.CostUSD, d.Tokens, tc.cost, tc.tokens)
+		}
+	}
+
+	other, err := store.DailyUsageHistory(ctx, []string{"anthropic"}, "", now.AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("history other: %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("history for unrelated provider = %v, want none", other)
+	}
+}
+
+func TestHourlyUsage(t *testing.T) {
+	_, store := openUsageViewTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 6, 11, 2, 0, 0, 0, time.UTC)
+
+	mk := func(at time.Time, id string, in int64, cost float64) IngestRequest {
+		return IngestRequest{
+			SourceSystem:  "codex",
+			SourceChannel: SourceChannelHook,
+			OccurredAt:    at,
+			ProviderID:    "openai",
+			AccountID:     "acct",
+			SessionID:     "s1",
+			MessageID:     id,
+			EventType:     EventTypeMessageUsage,
+			ModelRaw:      "gpt-5",
+			TokenUsage:    core.TokenUsage{InputTokens: i64(in), CostUSD: f64p(cost)},
+		}
+	}
+	mustIngestUsageEvent(t, store, mk(base.Add(5*time.Minute), "m1", 100, 1.0), "a")
+	mustIngestUsageEvent(t, store, mk(base.Add(50*time.Minute), "m2", 50, 0.5), "b")
+	mustIngestUsageEvent(t, store, mk(base.Add(3*time.Hour), "m3", 10, 0.25), "c")
+	mustIngestUsageEvent(t, store, mk(base.Add(-48*time.Hour), "m0", 999, 9), "old")
+
+	got, err := queryHourlyUsage(ctx, store.db, []string{"openai"}, base.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("hourly: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("hourly = %+v, want 2 hour buckets", got)
+	}
+	first, second := got[0], got[1]
+	if !first.Hour.Equal(base) || first.CostUSD != 1.5 || first.Tokens != 150 || first.Requests != 2 {
+		t.Errorf("first bucket = %+v, want 02:00 with cost 1.5, 150 tokens, 2 requests", first)
+	}
+	if !second.Hour.Equal(base.Add(3*time.Hour)) || second.Requests != 1 || second.ProviderID != "openai" {
+		t.Errorf("second bucket = %+v, want 05:00 with 1 request", second)
+	}
+}
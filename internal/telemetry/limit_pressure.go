@@ -0,0 +1,85 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// queryLimitPressure counts, per limit metric of one account, the UTC days
+// with a stored limit snapshot since `since` and the days on which at least
+// one poll found the limit at or below core.LimitPressureThreshold of it
+// remaining.
+func queryLimitPressure(ctx context.Context, db *sql.DB, providerID, accountID string, since time.Time) (map[string]core.LimitPressure, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT day, metric_key, MAX(hit)
+		FROM (
+			SELECT substr(e.occurred_at, 1, 10) AS day,
+				m.key AS metric_key,
+				CASE WHEN COALESCE(
+					CAST(json_extract(m.value, '$.remaining') AS REAL),
+					CAST(json_extract(m.value, '$.limit') AS REAL) - CAST(json_extract(m.value, '$.used') AS REAL)
+				) <= CAST(json_extract(m.value, '$.limit') AS REAL) * ? THEN 1 ELSE 0 END AS hit
+			FROM usage_events e
+			JOIN usage_raw_events r ON r.raw_event_id = e.raw_event_id,
+				json_each(r.source_payload, '$.snapshot.metrics') m
+			WHERE e.event_type = 'limit_snapshot'
+			  AND e.provider_id = ?
+			  AND e.account_id = ?
+			  AND r.source_system = ?
+			  AND e.occurred_at >= ?
+			  AND CAST(json_extract(m.value, '$.limit') AS REAL) > 0
+		)
+		GROUP BY day, metric_key
+	`, core.LimitPressureThreshold, providerID, accountID, string(SourceSystemPoller), since.UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return nil, fmt.Errorf("query limit pressure (%s/%s): %w", providerID, accountID, err)
+	}
+	defer rows.Close()
+
+	out := make(map[string]core.LimitPressure)
+	for rows.Next() {
+		var (
+			day, key string
+			hit      int
+		)
+		if err := rows.Scan(&day, &key, &hit); err != nil {
+			return nil, fmt.Errorf("scan limit pressure: %w", err)
+		}
+		p := out[key]
+		p.Days++
+		p.HitDays += hit
+		out[key] = p
+	}
+	return out, rows.Err()
+}
+
+// applyLimitPressure records, as core.LimitPressureAttrPrefix attributes,
+// how often each of a snapshot's limits ran nearly dry over the last
+// core.LimitPressureDays days. Limits never hit are left out.
+func applyLimitPressure(ctx context.Context, db *sql.DB, snaps map[string]core.UsageSnapshot, now time.Time) map[string]core.UsageSnapshot {
+	if db == nil || len(snaps) == 0 {
+		return snaps
+	}
+	since := now.UTC().AddDate(0, 0, -(core.LimitPressureDays - 1)).Truncate(24 * time.Hour)
+	for id, snap := range snaps {
+		account := core.FirstNonEmpty(strings.TrimSpace(snap.AccountID), strings.TrimSpace(id))
+		pressure, err := queryLimitPressure(ctx, db, snap.ProviderID, account, since)
+		if err != nil {
+			core.Tracef("[read_model] limit pressure %s: %v", snap.ProviderID, err)
+			continue
+		}
+		for key, p := range pressure {
+			if p.HitDays == 0 {
+				continue
+			}
+			snap.SetAttribute(core.LimitPressureAttrPrefix+key, p.String())
+		}
+		snaps[id] = snap
+	}
+	return snaps
+}
@@ -0,0 +1,63 @@
+package telemetry
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestApplyLimitPressure(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "telemetry.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+	now := time.Date(2026, 10, 14, 18, 0, 0, 0, time.UTC)
+
+	ingestor := NewQuotaSnapshotIngestor(store)
+	poll := func(at time.Time, rpmRemaining, tpdUsed float64) {
+		t.Helper()
+		snap := core.UsageSnapshot{
+			ProviderID: "groq", AccountID: "groq", Timestamp: at, Status: core.StatusOK,
+			Metrics: map[string]core.Metric{
+				"rpm":  {Limit: core.Float64Ptr(30), Remaining: core.Float64Ptr(rpmRemaining), Unit: "requests"},
+				"tpd":  {Limit: core.Float64Ptr(500000), Used: core.Float64Ptr(tpdUsed), Unit: "tokens"},
+				"cost": {Used: core.Float64Ptr(1), Unit: "USD"},
+			},
+		}
+		if err := ingestor.Ingest(ctx, map[string]core.UsageSnapshot{"groq": snap}); err != nil {
+			t.Fatalf("ingest: %v", err)
+		}
+	}
+	// rpm runs dry on three of four days; tpd once, by used alone.
+	for day := 0; day < 4; day++ {
+		at := now.AddDate(0, 0, -day)
+		remaining := 2.0
+		if day == 1 {
+			remaining = 20
+		}
+		poll(at.Add(-2*time.Hour), 25, 1000)
+		poll(at, remaining, 1000)
+	}
+	poll(now.Add(time.Minute), 25, 480000)
+	// Outside the 7-day window.
+	poll(now.AddDate(0, 0, -10), 0, 500000)
+
+	snaps := applyLimitPressure(ctx, store.db, map[string]core.UsageSnapshot{
+		"groq": {ProviderID: "groq", AccountID: "groq"},
+	}, now)
+	attrs := snaps["groq"].Attributes
+	if got := attrs["limit_pressure_rpm"]; got != "3/4" {
+		t.Errorf("rpm pressure = %q, want 3/4", got)
+	}
+	if got := attrs["limit_pressure_tpd"]; got != "1/4" {
+		t.Errorf("tpd pressure = %q, want 1/4", got)
+	}
+	if _, ok := attrs["limit_pressure_cost"]; ok {
+		t.Error("metrics without a limit must not get a pressure attribute")
+	}
+}
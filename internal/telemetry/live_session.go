@@ -0,0 +1,226 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// liveSessionSources maps a provider to the telemetry source whose sessions
+// its tile shows live. OpenCode turns are stored under the upstream provider
+// that served them (anthropic, openai, ...), so without this the OpenCode
+// tile never sees its own plugin's events. The local tools are read from
+// their session files, so a session in progress shows up as those files are
+// written.
+var liveSessionSources = map[string]SourceSystem{
+	"opencode":    "opencode",
+	"claude_code": "claude_code",
+	"codex":       "codex",
+	"gemini_cli":  "gemini_cli",
+	"copilot":     "copilot",
+}
+
+const (
+	// liveSessionMaxAge is how long after its last event a session is still
+	// shown on the tile.
+	liveSessionMaxAge = 24 * time.Hour
+	// liveSessionIdleAfter is how long a session may go without events
+	// before it is reported idle rather than active.
+	liveSessionIdleAfter = 15 * time.Minute
+	// liveSessionRunningWithin is how recent the last event must be for the
+	// session to count as an agent run in progress. The dashboard shows a
+	// running session as a ticker and the daemon refreshes faster meanwhile.
+	liveSessionRunningWithin = 2 * time.Minute
+)
+
+type liveSessionModel struct {
+	Model    string
+	CostUSD  float64
+	Messages float64
+}
+
+type liveSessionAgg struct {
+	SessionID  string
+	StartedAt  time.Time
+	LastActive time.Time
+	CostUSD    float64
+	Tokens     float64
+	Messages   float64
+	ToolCalls  float64
+	Models     []liveSessionModel
+}
+
+// applyLiveSessions attaches the most recent session of each tile's live
+// session source as live_session_* metrics and raw values. Events recorded
+// under the snapshot's account are preferred; otherwise any event from the
+// source counts, since the plugin only tags an account when
+// OPENUSAGE_TELEMETRY_ACCOUNT_ID is set.
+func applyLiveSessions(ctx context.Context, db *sql.DB, snaps map[string]core.UsageSnapshot, now time.Time) map[string]core.UsageSnapshot {
+	if db == nil || len(snaps) == 0 {
+		return snaps
+	}
+	for id, snap := range snaps {
+		source, ok := liveSessionSources[strings.ToLower(strings.TrimSpace(snap.ProviderID))]
+		if !ok {
+			continue
+		}
+		account := core.FirstNonEmpty(strings.TrimSpace(snap.AccountID), strings.TrimSpace(id))
+		session, err := queryLiveSession(ctx, db, source, account, now)
+		if err == nil && session == nil {
+			session, err = queryLiveSession(ctx, db, source, "", now)
+		}
+		if err != nil {
+			core.Tracef("[read_model] live session %s: %v", snap.ProviderID, err)
+			continue
+		}
+		if session == nil {
+			continue
+		}
+		applyLiveSessionToSnapshot(&snap, session, now)
+		snaps[id] = snap
+	}
+	return snaps
+}
+
+// queryLiveSession returns the source's session with the latest event in the
+// last liveSessionMaxAge, or nil when there is none. An empty accountID
+// matches every account.
+func queryLiveSession(ctx context.Context, db *sql.DB, source SourceSystem, accountID string, now time.Time) (*liveSessionAgg, error) {
+	var sessionID, lastActive string
+	err := db.QueryRowContext(ctx, `
+		SELECT e.session_id, MAX(e.occurred_at) AS last_active
+		FROM usage_events e
+		JOIN usage_raw_events r ON r.raw_event_id = e.raw_event_id
+		WHERE r.source_system = ?
+		  AND e.event_type IN ('message_usage', 'tool_usage')
+		  AND TRIM(COALESCE(e.session_id, '')) != ''
+		  AND e.occurred_at >= ?
+		  AND (? = '' OR e.account_id = ?)
+		GROUP BY e.session_id
+		ORDER BY last_active DESC
+		LIMIT 1
+	`, string(source), now.Add(-liveSessionMaxAge).UTC().Format(time.RFC3339Nano), accountID, accountID).Scan(&sessionID, &lastActive)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query live session (%s): %w", source, err)
+	}
+
+	usageCTE, args := dedupedUsageCTEWhere("r.source_system = ? AND e.session_id = ?", []any{string(source), sessionID})
+	rows, err := db.QueryContext(ctx, usageCTE+`
+		SELECT
+			COALESCE(NULLIF(TRIM(COALESCE(model_canonical, model_raw)), ''), 'unknown') AS model_key,
+			SUM(CASE WHEN event_type = 'message_usage' THEN COALESCE(cost_usd, 0) ELSE 0 END) AS cost_usd,
+			SUM(CASE WHEN event_type = 'message_usage' THEN COALESCE(total_tokens,
+				COALESCE(input_tokens, 0) +
+				COALESCE(output_tokens, 0) +
+				COALESCE(reasoning_tokens, 0) +
+				COALESCE(cache_read_tokens, 0) +
+				COALESCE(cache_write_tokens, 0)) ELSE 0 END) AS total_tokens,
+			SUM(CASE WHEN event_type = 'message_usage' THEN 1 ELSE 0 END) AS messages,
+			SUM(CASE WHEN event_type = 'tool_usage' THEN 1 ELSE 0 END) AS tool_calls,
+			MIN(occurred_at) AS started_at
+		FROM deduped_usage
+		GROUP BY model_key
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query live session usage (%s): %w", source, err)
+	}
+	defer rows.Close()
+
+	agg := &liveSessionAgg{SessionID: sessionID, LastActive: parseLiveSessionTime(lastActive)}
+	for rows.Next() {
+		var (
+			row       liveSessionModel
+			tokens    float64
+			toolCalls float64
+			startedAt string
+		)
+		if err := rows.Scan(&row.Model, &row.CostUSD, &tokens, &row.Messages, &toolCalls, &startedAt); err != nil {
+			return nil, fmt.Errorf("scan live session usage: %w", err)
+		}
+		agg.CostUSD += row.CostUSD
+		agg.Tokens += tokens
+		agg.Messages += row.Messages
+		agg.ToolCalls += toolCalls
+		if started := parseLiveSessionTime(startedAt); !started.IsZero() && (agg.StartedAt.IsZero() || started.Before(agg.StartedAt)) {
+			agg.StartedAt = started
+		}
+		if row.Messages > 0 {
+			agg.Models = append(agg.Models, row)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate live session usage: %w", err)
+	}
+	sort.Slice(agg.Models, func(i, j int) bool {
+		if agg.Models[i].CostUSD != agg.Models[j].CostUSD {
+			return agg.Models[i].CostUSD > agg.Models[j].CostUSD
+		}
+		if agg.Models[i].Messages != agg.Models[j].Messages {
+			return agg.Models[i].Messages > agg.Models[j].Messages
+		}
+		return agg.Models[i].Model < agg.Models[j].Model
+	})
+	return agg, nil
+}
+
+func applyLiveSessionToSnapshot(snap *core.UsageSnapshot, session *liveSessionAgg, now time.Time) {
+	snap.EnsureMaps()
+	snap.Metrics["live_session_cost_usd"] = core.Metric{Used: core.Float64Ptr(session.CostUSD), Unit: "USD", Window: "session"}
+	snap.Metrics["live_session_messages"] = core.Metric{Used: core.Float64Ptr(session.Messages), Unit: "messages", Window: "session"}
+	snap.Metrics["live_session_tool_calls"] = core.Metric{Used: core.Float64Ptr(session.ToolCalls), Unit: "calls", Window: "session"}
+	snap.Metrics["live_session_tokens"] = core.Metric{Used: core.Float64Ptr(session.Tokens), Unit: "tokens", Window: "session"}
+
+	status := "active"
+	switch since := now.Sub(session.LastActive); {
+	case since <= liveSessionRunningWithin:
+		status = core.LiveSessionRunning
+	case since > liveSessionIdleAfter:
+		status = "idle"
+	}
+	snap.Raw["live_session_status"] = status
+	snap.Raw["live_session_id"] = session.SessionID
+	if mix := liveSessionModelMix(session); mix != "" {
+		snap.Raw["live_session_models"] = mix
+	}
+	if !session.StartedAt.IsZero() {
+		snap.Raw["live_session_started"] = session.StartedAt.Format(time.RFC3339)
+	}
+	if !session.LastActive.IsZero() {
+		snap.Raw["live_session_last_active"] = session.LastActive.Format(time.RFC3339)
+	}
+}
+
+// liveSessionModelMix renders each model's share of the session, by cost
+// when the session has any and by message count otherwise.
+func liveSessionModelMix(session *liveSessionAgg) string {
+	total := session.CostUSD
+	share := func(m liveSessionModel) float64 { return m.CostUSD }
+	if total <= 0 {
+		total = session.Messages
+		share = func(m liveSessionModel) float64 { return m.Messages }
+	}
+	if total <= 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(session.Models))
+	for _, m := range session.Models {
+		parts = append(parts, fmt.Sprintf("%s %.0f%%", m.Model, share(m)/total*100))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func parseLiveSessionTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
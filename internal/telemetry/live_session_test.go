@@ -0,0 +1,146 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestApplyLiveSessions(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "telemetry.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	message := func(session, id, provider, model string, at time.Time, cost float64, tokens int64) {
+		t.Helper()
+		if _, err := store.Ingest(ctx, IngestRequest{
+			SourceSystem:  SourceSystem("opencode"),
+			SourceChannel: SourceChannelHook,
+			OccurredAt:    at,
+			ProviderID:    provider,
+			AgentName:     "opencode",
+			EventType:     EventTypeMessageUsage,
+			SessionID:     session,
+			MessageID:     id,
+			ModelRaw:      model,
+			TokenUsage:    core.TokenUsage{TotalTokens: &tokens, CostUSD: &cost},
+		}); err != nil {
+			t.Fatalf("ingest message: %v", err)
+		}
+	}
+	message("ses-old", "m0", "anthropic", "claude-sonnet-4", now.Add(-3*time.Hour), 5, 9000)
+	message("ses-live", "m1", "anthropic", "claude-sonnet-4", now.Add(-40*time.Minute), 0.30, 1000)
+	message("ses-live", "m2", "openai", "gpt-4o", now.Add(-30*time.Minute), 0.10, 500)
+	// The plugin can deliver a message more than once; it counts once.
+	message("ses-live", "m2", "openai", "gpt-4o", now.Add(-30*time.Minute), 0.10, 500)
+	if _, err := store.Ingest(ctx, IngestRequest{
+		SourceSystem:  SourceSystem("opencode"),
+		SourceChannel: SourceChannelHook,
+		OccurredAt:    now.Add(-25 * time.Minute),
+		ProviderID:    "anthropic",
+		AgentName:     "opencode",
+		EventType:     EventTypeToolUsage,
+		SessionID:     "ses-live",
+		ToolCallID:    "call-1",
+		ToolName:      "bash",
+	}); err != nil {
+		t.Fatalf("ingest tool: %v", err)
+	}
+
+	snaps := applyLiveSessions(ctx, store.db, map[string]core.UsageSnapshot{
+		"opencode": {ProviderID: "opencode", AccountID: "opencode"},
+		"openai":   {ProviderID: "openai", AccountID: "openai"},
+	}, now)
+
+	got := snaps["opencode"]
+	wantMetrics := map[string]float64{
+		"live_session_cost_usd":   0.40,
+		"live_session_messages":   2,
+		"live_session_tool_calls": 1,
+		"live_session_tokens":     1500,
+	}
+	for key, want := range wantMetrics {
+		m, ok := got.Metrics[key]
+		if !ok || m.Used == nil || *m.Used < want-1e-9 || *m.Used > want+1e-9 {
+			t.Errorf("%s = %+v, want %v", key, m, want)
+		}
+	}
+	wantRaw := map[string]string{
+		"live_session_id":          "ses-live",
+		"live_session_status":      "idle",
+		"live_session_models":      "claude-sonnet-4 75% · gpt-4o 25%",
+		"live_session_started":     "2026-10-16T11:20:00Z",
+		"live_session_last_active": "2026-10-16T11:35:00Z",
+	}
+	for key, want := range wantRaw {
+		if got.Raw[key] != want {
+			t.Errorf("Raw[%s] = %q, want %q", key, got.Raw[key], want)
+		}
+	}
+	if len(snaps["openai"].Metrics) != 0 {
+		t.Errorf("openai tile got live session metrics: %+v", snaps["openai"].Metrics)
+	}
+
+	// Within a couple of minutes of its last event the session is running.
+	snaps = applyLiveSessions(ctx, store.db, map[string]core.UsageSnapshot{
+		"opencode": {ProviderID: "opencode", AccountID: "opencode"},
+	}, now.Add(-24*time.Minute))
+	if got := snaps["opencode"].Raw["live_session_status"]; got != core.LiveSessionRunning {
+		t.Errorf("live_session_status right after the last event = %q, want %q", got, core.LiveSessionRunning)
+	}
+
+	// A day later the session is no longer shown.
+	snaps = applyLiveSessions(ctx, store.db, map[string]core.UsageSnapshot{
+		"opencode": {ProviderID: "opencode", AccountID: "opencode"},
+	}, now.Add(liveSessionMaxAge))
+	if _, ok := snaps["opencode"].Metrics["live_session_cost_usd"]; ok {
+		t.Error("stale session still shown")
+	}
+}
+
+func TestApplyLiveSessions_LocalTool(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "telemetry.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	for i, at := range []time.Time{now.Add(-20 * time.Minute), now.Add(-30 * time.Second)} {
+		cost, tokens := 1.5, int64(40000)
+		if _, err := store.Ingest(ctx, IngestRequest{
+			SourceSystem:  SourceSystem("claude_code"),
+			SourceChannel: SourceChannelJSONL,
+			OccurredAt:    at,
+			ProviderID:    "anthropic",
+			AccountID:     "claude-code",
+			AgentName:     "claude_code",
+			EventType:     EventTypeMessageUsage,
+			SessionID:     "ses-agent",
+			MessageID:     fmt.Sprintf("m%d", i),
+			ModelRaw:      "claude-opus-4",
+			TokenUsage:    core.TokenUsage{TotalTokens: &tokens, CostUSD: &cost},
+		}); err != nil {
+			t.Fatalf("ingest message: %v", err)
+		}
+	}
+
+	got := applyLiveSessions(ctx, store.db, map[string]core.UsageSnapshot{
+		"claude-code": {ProviderID: "claude_code", AccountID: "claude-code"},
+	}, now)["claude-code"]
+	if !core.HasRunningSession(got) {
+		t.Fatalf("live_session_status = %q, want %q", got.Raw["live_session_status"], core.LiveSessionRunning)
+	}
+	if m := got.Metrics["live_session_cost_usd"]; m.Used == nil || *m.Used != 3 {
+		t.Errorf("live_session_cost_usd = %+v, want 3", m)
+	}
+}
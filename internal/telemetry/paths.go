@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/janekbaraniewski/openusage/internal/audit"
 )
 
 // DefaultStateDir resolves the OpenUsage telemetry state directory (db, socket,
@@ -25,6 +27,16 @@ func DefaultDBPath() (string, error) {
 	return filepath.Join(stateDir, "telemetry.db"), nil
 }
 
+// DefaultAuditLogPath is the access audit log, or "" when
+// OPENUSAGE_AUDIT_LOG=off.
+func DefaultAuditLogPath() (string, error) {
+	stateDir, err := DefaultStateDir()
+	if err != nil {
+		return "", err
+	}
+	return audit.Path(stateDir), nil
+}
+
 func DefaultSocketPath() (string, error) {
 	stateDir, err := DefaultStateDir()
 	if err != nil {
@@ -125,6 +125,18 @@ func ApplyCanonicalTelemetryViewWithOptions(
 	done = trace("applyWindowedCreditSpend")
 	result = applyWindowedCreditSpend(ctx, db, result, options)
 	done()
+
+	done = trace("applyUsageHistory")
+	result = applyUsageHistory(ctx, db, result, links, time.Now())
+	done()
+
+	done = trace("applyLimitPressure")
+	result = applyLimitPressure(ctx, db, result, time.Now())
+	done()
+
+	done = trace("applyLiveSessions")
+	result = applyLiveSessions(ctx, db, result, time.Now())
+	done()
 	return result, nil
 }
 
@@ -518,6 +530,14 @@ func mapCoreStatus(raw string) core.Status {
 		return core.StatusUnsupported
 	case string(core.StatusError):
 		return core.StatusError
+	case string(core.StatusMaintenance):
+		return core.StatusMaintenance
+	case string(core.StatusDegraded):
+		return core.StatusDegraded
+	case string(core.StatusStale):
+		return core.StatusStale
+	case string(core.StatusExhausted):
+		return core.StatusExhausted
 	default:
 		return core.StatusUnknown
 	}
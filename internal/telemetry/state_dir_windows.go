@@ -8,8 +8,9 @@ import (
 	"github.com/janekbaraniewski/openusage/internal/config"
 )
 
-// platformStateDir keeps telemetry state beside settings.json under
-// %APPDATA%\openusage\state on Windows, which has no ~/.local/state convention.
+// platformStateDir keeps telemetry state under %APPDATA%\openusage\state on
+// Windows, which has no ~/.local/state convention. It stays there when
+// XDG_CONFIG_HOME moves settings.json; XDG_STATE_HOME moves it instead.
 func platformStateDir() (string, error) {
-	return filepath.Join(config.ConfigDir(), "state"), nil
+	return filepath.Join(config.PlatformConfigDir(), "state"), nil
 }
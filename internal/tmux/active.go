@@ -13,6 +13,7 @@ import (
 
 	"github.com/samber/lo"
 
+	"github.com/janekbaraniewski/openusage/internal/config"
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/providers"
 )
@@ -416,11 +417,11 @@ func detectCacheKey(strategies []string, opts DetectOptions) string {
 }
 
 func defaultCachePath() string {
-	home, _ := os.UserHomeDir()
-	if home == "" {
+	dir := config.CacheDir()
+	if !filepath.IsAbs(dir) {
 		return ""
 	}
-	return filepath.Join(home, ".cache", "openusage", "tmux-active.json")
+	return filepath.Join(dir, "tmux-active.json")
 }
 
 func readCache(path string, now time.Time, ttl time.Duration, key string) (DetectResult, bool) {
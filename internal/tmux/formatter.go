@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
 )
 
 // Render evaluates a template against ctx and returns the rendered string.
@@ -703,14 +704,7 @@ func modTokens(v string) string {
 	if !ok {
 		return v
 	}
-	switch {
-	case f >= 1_000_000:
-		return fmt.Sprintf("%.1fM", f/1e6)
-	case f >= 1_000:
-		return fmt.Sprintf("%.0fk", f/1e3)
-	default:
-		return fmt.Sprintf("%.0f", f)
-	}
+	return locale.Current().Compact(f)
 }
 
 func modDuration(v string) string {
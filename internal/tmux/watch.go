@@ -225,11 +225,11 @@ func formatMinutes(d time.Duration) string {
 // DefaultPIDFile returns the path used by --background to coordinate single
 // ownership. Exposed for the cobra wiring and the doctor command.
 func DefaultPIDFile() string {
-	home, err := os.UserHomeDir()
-	if err != nil || home == "" {
+	dir := config.CacheDir()
+	if !filepath.IsAbs(dir) {
 		return ""
 	}
-	return filepath.Join(home, ".cache", "openusage", "tmux-watch.pid")
+	return filepath.Join(dir, "tmux-watch.pid")
 }
 
 // WritePIDFile records the current pid to path, replacing whatever was
@@ -189,7 +189,8 @@ func extractCostData(snapshots map[string]core.UsageSnapshot, filter string, tim
 		}
 
 		data.providerCount++
-		if snap.Status == core.StatusOK || snap.Status == core.StatusNearLimit {
+		switch snap.Status {
+		case core.StatusOK, core.StatusNearLimit, core.StatusExhausted, core.StatusDegraded, core.StatusStale:
 			data.activeCount++
 		}
 		if snap.Timestamp.After(data.referenceTime) {
@@ -445,7 +446,7 @@ func extractUsageGauges(snap core.UsageSnapshot, color lipgloss.Color) []usageGa
 
 		result = append(result, usageGaugeEntry{
 			provider: snap.AccountID,
-			name:     gaugeLabel(dashboardWidget(snap.ProviderID), key, m.Window),
+			name:     snapshotGaugeLabel(snap, dashboardWidget(snap.ProviderID), key, m.Window),
 			pctUsed:  pctUsed,
 			window:   window,
 			color:    color,
@@ -27,9 +27,11 @@ func analyticsComparisonWindowDays(window core.TimeWindow) int {
 		return 7
 	case core.TimeWindow30d:
 		return 30
-	default:
-		return 14
 	}
+	if window.IsCustom() {
+		return window.Days()
+	}
+	return 14
 }
 
 func analyticsComparisonLabel(window core.TimeWindow) string {
@@ -84,7 +86,7 @@ func analyticsModelEfficiencyLabel(model modelCostEntry) string {
 	if model.cost <= 0 || totalTokens <= 0 {
 		return "no efficiency signal"
 	}
-	return fmt.Sprintf("$%.3f / 1K tok", model.cost/totalTokens*1000)
+	return fmt.Sprintf("%s / 1K tok", formatMoney(model.cost/totalTokens*1000, 3))
 }
 
 func analyticsSparkline(points []core.TimePoint, width int, color lipgloss.Color) string {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
 )
 
 type chartItem struct {
@@ -76,13 +77,13 @@ func RenderBudgetGauge(label string, used, limit float64, barW, labelW int, colo
 			icon := "⚠"
 			if daysLeft < 3 {
 				icon = "🔴"
-				projStr = fmt.Sprintf("%.0f hours until limit at $%.2f/h", hoursLeft, burnRate)
+				projStr = fmt.Sprintf("%.0f hours until limit at %s/h", hoursLeft, formatMoney(burnRate, 2))
 			} else if daysLeft < 14 {
 				icon = "🟡"
-				projStr = fmt.Sprintf("~%.0f days until limit at $%.2f/h", daysLeft, burnRate)
+				projStr = fmt.Sprintf("~%.0f days until limit at %s/h", daysLeft, formatMoney(burnRate, 2))
 			} else {
 				icon = "🟢"
-				projStr = fmt.Sprintf("~%.0f days remaining at $%.2f/h", daysLeft, burnRate)
+				projStr = fmt.Sprintf("~%.0f days remaining at %s/h", daysLeft, formatMoney(burnRate, 2))
 			}
 			projection := fmt.Sprintf("  %s %s %s",
 				strings.Repeat(" ", labelW),
@@ -125,16 +126,17 @@ func RenderTokenBreakdown(input, output float64, w int) string {
 }
 
 func formatChartValue(v float64) string {
+	loc := locale.Current()
 	if v >= 1_000_000 {
-		return fmt.Sprintf("%.1fM", v/1_000_000)
+		return loc.Float(v/1_000_000, 1) + "M"
 	}
 	if v >= 1_000 {
-		return fmt.Sprintf("%.1fK", v/1_000)
+		return loc.Float(v/1_000, 1) + "K"
 	}
 	if v == float64(int(v)) {
-		return fmt.Sprintf("%d", int(v))
+		return loc.Float(v, 0)
 	}
-	return fmt.Sprintf("%.1f", v)
+	return loc.Float(v, 1)
 }
 
 func formatDateLabel(d string) string {
@@ -158,22 +160,23 @@ func formatDateLabel(d string) string {
 }
 
 func formatCostAxis(v float64) string {
+	loc := locale.Current()
 	if v == 0 {
-		return "$0"
+		return loc.Money("0")
 	}
 	if v >= 10000 {
-		return fmt.Sprintf("$%.0fK", v/1000)
+		return loc.Money(loc.Float(v/1000, 0) + "K")
 	}
 	if v >= 1000 {
-		return fmt.Sprintf("$%.1fK", v/1000)
+		return loc.Money(loc.Float(v/1000, 1) + "K")
 	}
 	if v >= 100 {
-		return fmt.Sprintf("$%.0f", v)
+		return formatMoney(v, 0)
 	}
 	if v >= 1 {
-		return fmt.Sprintf("$%.1f", v)
+		return formatMoney(v, 1)
 	}
-	return fmt.Sprintf("$%.2f", v)
+	return formatMoney(v, 2)
 }
 
 type BrailleSeries struct {
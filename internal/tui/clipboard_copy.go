@@ -0,0 +1,317 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// copyFormat is how "y" / "Y" render the focused tile or detail for the
+// clipboard.
+type copyFormat int
+
+const (
+	copyPlainText copyFormat = iota
+	copyMarkdown
+)
+
+// plainCopyTileWidth and plainCopyDetailWidth are the widths the view is
+// re-rendered at for a plain-text copy, independent of the terminal, so a
+// pasted block wraps the same way wherever it was copied from.
+const (
+	plainCopyTileWidth   = 64
+	plainCopyDetailWidth = 100
+)
+
+// copyFocusedView copies the focused tile, or the detail view when one is
+// open or the pinned pane has focus. Snapshots go through privacy mode
+// first, so a copy never carries more than the screen shows.
+func (m Model) copyFocusedView(format copyFormat) (tea.Model, tea.Cmd) {
+	id := m.selectedTileID(m.filteredIDs())
+	if id == "" {
+		return m, nil
+	}
+	snap, ok := m.privacySnapshots()[id]
+	if !ok {
+		return m, nil
+	}
+	detail := m.mode == modeDetail || m.pinnedDetailFocused()
+
+	what := "tile"
+	if detail {
+		what = "detail"
+	}
+	var text, label string
+	switch format {
+	case copyMarkdown:
+		text = m.markdownCopy(snap, detail)
+		label = "Copy " + what + " as Markdown"
+	default:
+		text = m.plainTextCopy(snap, detail)
+		label = "Copy " + what + " as text"
+	}
+	return m, m.copyTextCmd(label, text)
+}
+
+func (m Model) copyTextCmd(label, text string) tea.Cmd {
+	return func() tea.Msg {
+		if m.services == nil {
+			return tileActionDoneMsg{Label: label, Err: fmt.Errorf("clipboard unavailable")}
+		}
+		err := m.services.CopyToClipboard(text)
+		if err != nil {
+			log.Printf("%s: %v", strings.ToLower(label), err)
+		}
+		return tileActionDoneMsg{Label: label, Err: err}
+	}
+}
+
+// plainTextCopy is the view as rendered, without colors or the tile border.
+func (m Model) plainTextCopy(snap core.UsageSnapshot, detail bool) string {
+	var rendered string
+	if detail {
+		activeTab := 0
+		if m.mode == modeDetail {
+			activeTab = m.detailTab
+		}
+		rendered = RenderDetailContentWithRange(snap, m.viewNow(), plainCopyDetailWidth, m.warnThreshold, m.critThreshold, activeTab, m.timeWindow, m.resolveHideCosts(snap), m.detailChartDays)
+	} else {
+		rendered = m.buildTile(snap, false, true, plainCopyTileWidth, 0, 0, m.tileFooterText(snap, m.viewNow()))
+	}
+	return stripRenderedBlock(rendered)
+}
+
+// stripRenderedBlock drops ANSI styling, rounded box borders, and trailing
+// padding from a rendered block.
+func stripRenderedBlock(rendered string) string {
+	var out []string
+	for _, line := range strings.Split(ansi.Strip(rendered), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "╭") || strings.HasPrefix(trimmed, "╰") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "│") && strings.HasSuffix(trimmed, "│") && len(trimmed) > len("│") {
+			line = strings.TrimSuffix(strings.TrimPrefix(trimmed, "│"), "│")
+			line = strings.TrimPrefix(line, " ")
+		}
+		out = append(out, strings.TrimRight(line, " "))
+	}
+	for len(out) > 0 && out[0] == "" {
+		out = out[1:]
+	}
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+// markdownCopy summarizes snap as Markdown for chat: a header line, the
+// message if any, then a table of gauges and metrics. The detail variant
+// lists every visible metric plus the account's attributes and top models;
+// the tile variant only what the tile shows.
+func (m Model) markdownCopy(snap core.UsageSnapshot, detail bool) string {
+	widget := dashboardWidget(snap.ProviderID)
+	hideCosts := m.resolveHideCosts(snap)
+	status := m.tileStatus(snap)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**%s** · `%s` — %s %s", providerDisplayName(snap.ProviderID), snap.AccountID, StatusIcon(status), statusText(status))
+	if plan := strings.TrimSpace(snap.Attributes["plan"]); plan != "" {
+		fmt.Fprintf(&sb, " · %s", plan)
+	}
+	sb.WriteString("\n")
+	if msg := strings.TrimSpace(snap.Message); msg != "" {
+		fmt.Fprintf(&sb, "> %s\n", markdownCell(msg))
+	}
+
+	rows := markdownGaugeRows(snap, widget, m.viewNow(), detail)
+	gauged := make(map[string]bool, len(rows))
+	for _, r := range rows {
+		gauged[r.key] = true
+	}
+	for _, key := range core.SortedStringKeys(snap.Metrics) {
+		met := snap.Metrics[key]
+		if gauged[key] || !copyableMetric(widget, snap, key, met, hideCosts) {
+			continue
+		}
+		if !detail && metricHasGauge(key, met) {
+			continue
+		}
+		if value := formatTileMetricValue(key, met); value != "" {
+			rows = append(rows, markdownRow{key: key, label: snapshotGaugeLabel(snap, widget, key, ""), value: value})
+		}
+	}
+	if len(rows) > 0 {
+		sb.WriteString("\n| Metric | Value |\n| --- | --- |\n")
+		for _, r := range rows {
+			fmt.Fprintf(&sb, "| %s | %s |\n", markdownCell(r.label), markdownCell(r.value))
+		}
+	}
+
+	if detail {
+		writeMarkdownAttributes(&sb, snap)
+		writeMarkdownModels(&sb, snap, hideCosts)
+	}
+
+	if updated := m.tileFooterText(snap, m.viewNow()); updated != "" {
+		fmt.Fprintf(&sb, "\n_updated %s_\n", updated)
+	}
+	return sb.String()
+}
+
+type markdownRow struct {
+	key, label, value string
+}
+
+// markdownGaugeRows lists the gauge metrics first, in the widget's priority
+// order, with their percentage and reset countdown. The tile variant stops
+// at the widget's gauge line cap.
+func markdownGaugeRows(snap core.UsageSnapshot, widget core.DashboardWidget, now time.Time, detail bool) []markdownRow {
+	keys := prioritizeMetricKeys(core.SortedStringKeys(snap.Metrics), widget.GaugePriority)
+	maxLines := widget.GaugeMaxLines
+	if maxLines <= 0 {
+		maxLines = 2
+	}
+
+	var rows []markdownRow
+	for _, key := range keys {
+		if core.IsEndpointMetricKey(key) {
+			continue
+		}
+		met := snap.Metrics[key]
+		pct := metricUsedPercent(key, met)
+		if pct < 0 {
+			continue
+		}
+		value := fmt.Sprintf("%.0f%%", pct)
+		if v := formatTileMetricValue(key, met); v != "" && v != value {
+			value = v + " (" + value + ")"
+		}
+		if resetAt, ok := snap.Resets[key]; ok && resetAt.After(now) {
+			value += " · resets in " + formatDuration(resetAt.Sub(now))
+		}
+		rows = append(rows, markdownRow{key: key, label: snapshotGaugeLabel(snap, widget, key, met.Window), value: value})
+		if !detail && len(rows) >= maxLines {
+			break
+		}
+	}
+	return rows
+}
+
+// copyableMetric applies the tile's hiding rules: widget hide lists,
+// suppressed zero rows, endpoint metrics, and costs when they're hidden.
+func copyableMetric(widget core.DashboardWidget, snap core.UsageSnapshot, key string, met core.Metric, hideCosts bool) bool {
+	if hasAnyPrefix(key, widget.HideMetricPrefixes) || slices.Contains(widget.HideMetricKeys, key) || core.IsEndpointMetricKey(key) {
+		return false
+	}
+	if shouldSuppressMetricLine(widget, key, met, snap.Metrics) {
+		return false
+	}
+	return !(hideCosts && isMonetaryMetricKey(key, met))
+}
+
+func writeMarkdownAttributes(sb *strings.Builder, snap core.UsageSnapshot) {
+	var lines []string
+	for _, key := range core.SortedStringKeys(snap.Attributes) {
+		if key == "plan" {
+			continue // in the header line
+		}
+		if v := strings.TrimSpace(snap.Attributes[key]); v != "" {
+			lines = append(lines, fmt.Sprintf("- %s: %s", core.PrettifyMetricKey(key), v))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	sb.WriteString("\n**Details**\n")
+	sb.WriteString(strings.Join(lines, "\n") + "\n")
+}
+
+// markdownModelLimit caps the model table; the detail view has the rest.
+const markdownModelLimit = 10
+
+func writeMarkdownModels(sb *strings.Builder, snap core.UsageSnapshot, hideCosts bool) {
+	if len(snap.ModelUsage) == 0 {
+		return
+	}
+	records := slices.Clone(snap.ModelUsage)
+	slices.SortStableFunc(records, func(a, b core.ModelUsageRecord) int {
+		return compareFloatPtrDesc(modelSortValue(a, hideCosts), modelSortValue(b, hideCosts))
+	})
+	if len(records) > markdownModelLimit {
+		records = records[:markdownModelLimit]
+	}
+
+	sb.WriteString("\n| Model | Tokens |")
+	if !hideCosts {
+		sb.WriteString(" Cost |")
+	}
+	sb.WriteString("\n| --- | --- |")
+	if !hideCosts {
+		sb.WriteString(" --- |")
+	}
+	sb.WriteString("\n")
+	for _, rec := range records {
+		tokens := ""
+		if rec.TotalTokens != nil {
+			tokens = formatNumber(*rec.TotalTokens)
+		}
+		fmt.Fprintf(sb, "| %s | %s |", markdownCell(core.FirstNonEmpty(rec.Canonical, rec.RawModelID)), tokens)
+		if !hideCosts {
+			cost := ""
+			if rec.CostUSD != nil {
+				cost = formatUSD(*rec.CostUSD)
+			}
+			fmt.Fprintf(sb, " %s |", cost)
+		}
+		sb.WriteString("\n")
+	}
+}
+
+func modelSortValue(rec core.ModelUsageRecord, hideCosts bool) *float64 {
+	if !hideCosts && rec.CostUSD != nil {
+		return rec.CostUSD
+	}
+	return rec.TotalTokens
+}
+
+func compareFloatPtrDesc(a, b *float64) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	case *a > *b:
+		return -1
+	case *a < *b:
+		return 1
+	}
+	return 0
+}
+
+// markdownCell keeps a value on one table row.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func providerDisplayName(providerID string) string {
+	loadProviderSpecs()
+	if name := providerSpecs[providerID].Info.Name; name != "" {
+		return name
+	}
+	return providerID
+}
+
+func statusText(s core.Status) string {
+	return strings.ReplaceAll(string(s), "_", " ")
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func copyFocused(t *testing.T, m Model, key string) (Model, string) {
+	t.Helper()
+	fake := &tileActionFakeServices{fakeServices: &fakeServices{}}
+	m.services = fake
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	if cmd == nil {
+		t.Fatalf("%q returned no command", key)
+	}
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if len(fake.copied) != 1 {
+		t.Fatalf("copied = %v, want one copy", fake.copied)
+	}
+	return m, fake.copied[0]
+}
+
+func TestCopyFocusedTile_PlainText(t *testing.T) {
+	m := layoutTestModel(t, 120, 30, 2)
+
+	m, text := copyFocused(t, m, "y")
+	if strings.Contains(text, "\x1b[") || strings.ContainsAny(text, "╭╰") {
+		t.Fatalf("plain copy kept styling or borders:\n%s", text)
+	}
+	if !strings.Contains(text, "openai-00") || strings.Contains(text, "openai-01") {
+		t.Fatalf("plain copy should be the focused tile only:\n%s", text)
+	}
+	if m.actionNotice != "Copy tile as text ✓" {
+		t.Fatalf("actionNotice = %q", m.actionNotice)
+	}
+}
+
+func TestCopyFocusedTile_Markdown(t *testing.T) {
+	m := layoutTestModel(t, 120, 30, 1)
+	snap := m.snapshots["openai-00"]
+	snap.Message = "ok | fine"
+	snap.Metrics["spend_usd"] = core.Metric{Used: float64Ptr(12.5), Unit: "USD"}
+	m.snapshots["openai-00"] = snap
+
+	_, text := copyFocused(t, m, "Y")
+	for _, want := range []string{
+		"**OpenAI** · `openai-00` — ● OK",
+		`> ok \| fine`,
+		"| Metric | Value |",
+		"40 / 100 req (40%)",
+		"$12.50",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("markdown copy missing %q:\n%s", want, text)
+		}
+	}
+
+	hide := true
+	m.hideCostsGlobal = &hide
+	_, text = copyFocused(t, m, "Y")
+	if strings.Contains(text, "$12.50") {
+		t.Errorf("markdown copy leaked a hidden cost:\n%s", text)
+	}
+}
+
+func TestCopyFocusedDetail_PrivacyMode(t *testing.T) {
+	m := layoutTestModel(t, 120, 30, 1)
+	snap := m.snapshots["openai-00"]
+	snap.Attributes = map[string]string{"account_email": "jane@example.com", "plan": "Tier 3"}
+	m.snapshots["openai-00"] = snap
+	m.mode = modeDetail
+	m.setPrivacyMode(true)
+	t.Cleanup(func() { m.setPrivacyMode(false) })
+
+	m, text := copyFocused(t, m, "Y")
+	if strings.Contains(text, "jane@example.com") {
+		t.Fatalf("privacy mode copy leaked an email:\n%s", text)
+	}
+	if !strings.Contains(text, "**Details**") || !strings.Contains(text, "Tier 3") {
+		t.Fatalf("detail copy should list attributes:\n%s", text)
+	}
+	if m.actionNotice != "Copy detail as Markdown ✓" {
+		t.Fatalf("actionNotice = %q", m.actionNotice)
+	}
+}
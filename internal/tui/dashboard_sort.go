@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"sort"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// dashboardSortMode orders the dashboard's tiles. The order is recomputed
+// from the live snapshots on every frame, so under any mode but the
+// configured one tiles move as their status, spend or usage changes.
+type dashboardSortMode int
+
+const (
+	dashboardSortConfigured dashboardSortMode = iota // provider order from settings
+	dashboardSortSeverity                            // worst status first
+	dashboardSortSpend                               // highest spend today first
+	dashboardSortUsage                               // most-used limit first
+	dashboardSortUpdated                             // most recently updated first
+	dashboardSortCount
+)
+
+func dashboardSortLabel(mode dashboardSortMode) string {
+	switch mode {
+	case dashboardSortSeverity:
+		return "severity"
+	case dashboardSortSpend:
+		return "spend today"
+	case dashboardSortUsage:
+		return "% of limit"
+	case dashboardSortUpdated:
+		return "last update"
+	default:
+		return "configured"
+	}
+}
+
+func (m *Model) cycleDashboardSort() {
+	selected := m.selectedTileID(m.filteredIDs())
+	m.dashboardSort = (m.dashboardSort + 1) % dashboardSortCount
+	m.tileOffset = 0
+	m.followSelection(selected)
+}
+
+// followSelection moves the cursor to id's position after the tile order
+// changed, so the selection stays on the same account.
+func (m *Model) followSelection(id string) {
+	if id == "" {
+		return
+	}
+	for i, candidate := range m.filteredIDs() {
+		if candidate == id {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// sortDashboardIDs returns ids in the active sort order. Ties keep the
+// configured order.
+func (m Model) sortDashboardIDs(ids []string) []string {
+	if m.dashboardSort == dashboardSortConfigured || len(ids) < 2 {
+		return ids
+	}
+	keys := make(map[string]float64, len(ids))
+	for _, id := range ids {
+		keys[id] = m.dashboardSortKey(m.snapshots[id])
+	}
+	sorted := append([]string(nil), ids...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return keys[sorted[i]] > keys[sorted[j]]
+	})
+	return sorted
+}
+
+// dashboardSortKey is the value tiles are sorted on, highest first.
+func (m Model) dashboardSortKey(snap core.UsageSnapshot) float64 {
+	switch m.dashboardSort {
+	case dashboardSortSeverity:
+		return float64(core.StatusSeverity(m.tileStatus(snap)))
+	case dashboardSortSpend:
+		if m.resolveHideCosts(snap) {
+			return -1
+		}
+		return extractTodayCost(snap)
+	case dashboardSortUsage:
+		remaining := accountCapacityPercent(snap)
+		if remaining < 0 {
+			return -1
+		}
+		return 100 - remaining
+	case dashboardSortUpdated:
+		if snap.Timestamp.IsZero() {
+			return 0
+		}
+		return float64(snap.Timestamp.UnixMilli())
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestDashboardSort(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	percent := func(used float64) map[string]core.Metric {
+		return map[string]core.Metric{"usage_seven_day": {Used: core.Float64Ptr(used), Unit: "%"}}
+	}
+	snaps := map[string]core.UsageSnapshot{
+		"claude": {ProviderID: "claude_code", AccountID: "claude", Status: core.StatusExhausted, Timestamp: at.Add(-time.Hour), Metrics: percent(96)},
+		"codex":  {ProviderID: "codex", AccountID: "codex", Status: core.StatusOK, Timestamp: at, Metrics: percent(5)},
+		"openai": {ProviderID: "openai", AccountID: "openai", Status: core.StatusError, Timestamp: at.Add(-2 * time.Hour)},
+		"openrouter": {ProviderID: "openrouter", AccountID: "openrouter", Status: core.StatusNearLimit, Timestamp: at.Add(-time.Minute),
+			Metrics: map[string]core.Metric{"today_cost": {Used: core.Float64Ptr(12), Unit: "USD", Window: "1d"}}},
+	}
+	m := NewModel(0.2, 0.05, false, config.DashboardConfig{}, nil, core.TimeWindow7d)
+	m.snapshots = snaps
+	m.sortedIDs = []string{"codex", "openai", "claude", "openrouter"}
+
+	tests := []struct {
+		mode dashboardSortMode
+		want []string
+	}{
+		{dashboardSortConfigured, []string{"codex", "openai", "claude", "openrouter"}},
+		{dashboardSortSeverity, []string{"openai", "claude", "openrouter", "codex"}},
+		{dashboardSortSpend, []string{"openrouter", "codex", "openai", "claude"}},
+		{dashboardSortUsage, []string{"claude", "codex", "openai", "openrouter"}},
+		{dashboardSortUpdated, []string{"codex", "openrouter", "claude", "openai"}},
+	}
+	for _, tt := range tests {
+		t.Run(dashboardSortLabel(tt.mode), func(t *testing.T) {
+			m.dashboardSort = tt.mode
+			if got := m.filteredIDs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if !reflect.DeepEqual(m.sortedIDs, []string{"codex", "openai", "claude", "openrouter"}) {
+		t.Errorf("sorting changed the configured order: %v", m.sortedIDs)
+	}
+}
+
+func TestCycleDashboardSort_KeepsSelection(t *testing.T) {
+	m := NewModel(0.2, 0.05, false, config.DashboardConfig{}, nil, core.TimeWindow7d)
+	m.snapshots = map[string]core.UsageSnapshot{
+		"codex":  {ProviderID: "codex", AccountID: "codex", Status: core.StatusOK},
+		"openai": {ProviderID: "openai", AccountID: "openai", Status: core.StatusError},
+	}
+	m.sortedIDs = []string{"codex", "openai"}
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	m = updated.(Model)
+	if m.dashboardSort != dashboardSortSeverity {
+		t.Fatalf("sort after o = %s, want severity", dashboardSortLabel(m.dashboardSort))
+	}
+	if got := m.selectedTileID(m.filteredIDs()); got != "codex" {
+		t.Errorf("selected = %q after re-sorting, want codex", got)
+	}
+
+	for range dashboardSortCount - 1 {
+		m.cycleDashboardSort()
+	}
+	if m.dashboardSort != dashboardSortConfigured {
+		t.Errorf("sort after a full cycle = %s, want configured", dashboardSortLabel(m.dashboardSort))
+	}
+}
@@ -14,6 +14,19 @@ const (
 	dashboardViewTabs    dashboardViewMode = dashboardViewMode(config.DashboardViewTabs)
 	dashboardViewSplit   dashboardViewMode = dashboardViewMode(config.DashboardViewSplit)
 	dashboardViewCompare dashboardViewMode = dashboardViewMode(config.DashboardViewCompare)
+
+	// dashboardViewCompact can't be configured: it replaces every view on
+	// terminals narrower than compactLayoutWidth, where tiles stop being
+	// readable.
+	dashboardViewCompact dashboardViewMode = "compact"
+)
+
+// compactLayoutWidth and compactLayoutHeight are the terminal sizes below
+// which the dashboard switches to the compact list and collapses the header
+// and footer to a single line each.
+const (
+	compactLayoutWidth  = 70
+	compactLayoutHeight = 20
 )
 
 type dashboardViewOption struct {
@@ -70,6 +83,9 @@ func normalizeDashboardViewMode(raw string) dashboardViewMode {
 }
 
 func dashboardViewLabel(mode dashboardViewMode) string {
+	if mode == dashboardViewCompact {
+		return "Compact"
+	}
 	for _, option := range dashboardViewOptions {
 		if option.ID == mode {
 			return option.Label
@@ -115,10 +131,37 @@ func (m Model) shouldForceStackedDashboardView() bool {
 	if len(m.filteredIDs()) <= 1 {
 		return false
 	}
-	return m.width < minTwoColumnDashboardWidth()
+	return m.dashboardGridWidth() < minTwoColumnDashboardWidth()
+}
+
+// useCompactLayout reports whether the terminal is too narrow for tiles.
+func (m Model) useCompactLayout() bool {
+	return m.width > 0 && m.width < compactLayoutWidth
+}
+
+// collapseChrome reports whether the header and footer should shrink to one
+// line each to leave room for content.
+func (m Model) collapseChrome() bool {
+	return m.useCompactLayout() || (m.height > 0 && m.height < compactLayoutHeight)
+}
+
+// usesListNavigation reports whether up/down move the selection through a
+// list of accounts rather than scrolling tiles.
+func (m Model) usesListNavigation() bool {
+	switch m.activeDashboardView() {
+	case dashboardViewSplit:
+		return true
+	case dashboardViewCompact:
+		return len(m.filteredIDs()) > 1
+	default:
+		return false
+	}
 }
 
 func (m Model) activeDashboardView() dashboardViewMode {
+	if m.useCompactLayout() {
+		return dashboardViewCompact
+	}
 	if m.shouldForceStackedDashboardView() {
 		return dashboardViewStacked
 	}
@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// deprecationTileLine is the tile's one-line notice that the provider is
+// running on a deprecated upstream endpoint. Empty when there is none.
+func deprecationTileLine(snap core.UsageSnapshot, maxW int) string {
+	notices := snap.Deprecations()
+	if len(notices) == 0 {
+		return ""
+	}
+	text := "⚠ Deprecated: " + notices[0].Message
+	if extra := len(notices) - 1; extra > 0 {
+		text = fmt.Sprintf("⚠ Deprecated (+%d): %s", extra, notices[0].Message)
+	}
+	return lipgloss.NewStyle().Foreground(colorPeach).Render(truncateToWidth(text, maxW))
+}
+
+// deprecationDetailLines lists every deprecation notice with its migration
+// hint, for the detail header.
+func deprecationDetailLines(snap core.UsageSnapshot, maxW int) []string {
+	var lines []string
+	for _, d := range snap.Deprecations() {
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorPeach).Bold(true).Render(truncateToWidth("⚠ Deprecated: "+d.Message, maxW)))
+		if d.Migration != "" {
+			lines = append(lines, dimStyle.Render(truncateToWidth("  → "+d.Migration, maxW)))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestDeprecationLines(t *testing.T) {
+	var snap core.UsageSnapshot
+	if line := deprecationTileLine(snap, 80); line != "" {
+		t.Fatalf("tile line without notices = %q, want empty", line)
+	}
+
+	snap.AddDeprecation(core.Deprecation{Feature: "generation_list", Message: "generation list retired", Migration: "use activity analytics"})
+	snap.AddDeprecation(core.Deprecation{Feature: "usage_v1", Message: "v1 usage retired"})
+
+	if line := deprecationTileLine(snap, 80); !strings.Contains(line, "Deprecated (+1): generation list retired") {
+		t.Fatalf("tile line = %q", line)
+	}
+	detail := strings.Join(deprecationDetailLines(snap, 80), "\n")
+	for _, want := range []string{"generation list retired", "→ use activity analytics", "v1 usage retired"} {
+		if !strings.Contains(detail, want) {
+			t.Fatalf("detail lines missing %q: %q", want, detail)
+		}
+	}
+}
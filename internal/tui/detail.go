@@ -40,11 +40,32 @@ func DetailTabs(snap core.UsageSnapshot) []string {
 // gauges, forecasts). Token counts, quota percentages, and usage gauges
 // remain regardless.
 func RenderDetailContent(snap core.UsageSnapshot, now time.Time, w int, warnThresh, critThresh float64, activeTab int, timeWindow core.TimeWindow, hideCosts bool) string {
+	return RenderDetailContentWithRange(snap, now, w, warnThresh, critThresh, activeTab, timeWindow, hideCosts, 0)
+}
+
+// RenderDetailContentWithRange is RenderDetailContent with the daily charts
+// spanning chartDays instead of the time window; 0 follows the window.
+func RenderDetailContentWithRange(snap core.UsageSnapshot, now time.Time, w int, warnThresh, critThresh float64, activeTab int, timeWindow core.TimeWindow, hideCosts bool, chartDays int) string {
 	var sb strings.Builder
 	widget := dashboardWidget(snap.ProviderID)
 
 	// ── Compact top bar ──
 	renderDetailCompactHeader(&sb, snap, now, w, hideCosts)
+	if line := geminiQuotaBucketAlertLine(snap, warnThresh, critThresh, w-2); line != "" {
+		sb.WriteString("  " + line + "\n")
+	}
+	if line := tierSuggestionLine(snap, w-2); line != "" {
+		sb.WriteString("  " + line + "\n")
+	}
+	for _, line := range deprecationDetailLines(snap, w-2) {
+		sb.WriteString("  " + line + "\n")
+	}
+	if line := limitPoolTileLine(snap, w-2); line != "" {
+		sb.WriteString("  " + line + "\n")
+	}
+	for _, line := range modelRetirementDetailLines(snap, now, w-2) {
+		sb.WriteString("  " + line + "\n")
+	}
 
 	if len(snap.Metrics) == 0 && len(snap.ModelUsage) == 0 {
 		if snap.Message != "" {
@@ -56,7 +77,7 @@ func RenderDetailContent(snap core.UsageSnapshot, now time.Time, w int, warnThre
 	}
 
 	// Build and render all sections as bordered cards.
-	sections := buildDetailSections(snap, widget, w, warnThresh, critThresh, timeWindow, hideCosts, now)
+	sections := buildDetailSections(snap, widget, w, warnThresh, critThresh, timeWindow, chartDays, hideCosts, now)
 	for _, sec := range sections {
 		renderDetailCard(&sb, sec, w)
 	}
@@ -110,10 +131,10 @@ func renderDetailCompactHeader(sb *strings.Builder, snap core.UsageSnapshot, now
 	}
 	summaryLeft := "  " + strings.Join(summaryParts, dimStyle.Render("  ·  "))
 
-	timeStr := snap.Timestamp.Format("15:04:05")
+	timeStr := formatClock(snap.Timestamp, true)
 	age := now.Sub(snap.Timestamp)
 	if age > 60*time.Second {
-		timeStr = fmt.Sprintf("%s (%s ago)", snap.Timestamp.Format("15:04:05"), formatDuration(age))
+		timeStr = fmt.Sprintf("%s (%s ago)", formatClock(snap.Timestamp, true), formatDuration(age))
 	}
 	summaryRight := dimStyle.Render("⏱ " + timeStr)
 	sLeftW := lipgloss.Width(summaryLeft)
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// detailChartRanges are the spans, in days, the detail view's daily charts
+// cycle through with "d". 0 follows the dashboard time window.
+var detailChartRanges = []int{0, 7, 14, 30, 90}
+
+// historySeriesKeys maps a chart's snapshot series to the persisted daily
+// history that can extend it past what the provider returned.
+var historySeriesKeys = map[string]string{
+	"analytics_cost":   "history_cost",
+	"cost":             "history_cost",
+	"analytics_tokens": "history_tokens",
+	"tokens_total":     "history_tokens",
+}
+
+func nextDetailChartRange(days int) int {
+	for i, d := range detailChartRanges {
+		if d == days {
+			return detailChartRanges[(i+1)%len(detailChartRanges)]
+		}
+	}
+	return detailChartRanges[0]
+}
+
+// detailChartRangeLabel is the range shown above the Trends charts.
+func detailChartRangeLabel(days int, window core.TimeWindow) string {
+	if days <= 0 {
+		return "Range: " + window.Label() + " (time window)"
+	}
+	return fmt.Sprintf("Range: %dd", days)
+}
+
+// cropSeriesToChartRange crops a chart series to an explicit range, or to the
+// time window when none is selected.
+func cropSeriesToChartRange(pts []core.TimePoint, days int, window core.TimeWindow, now time.Time) []core.TimePoint {
+	if days <= 0 {
+		return cropSeriesToWindow(pts, window)
+	}
+	return clipAndPadPointsByRecentDays(pts, days, now)
+}
+
+// rangeSeries returns the trailing days of a snapshot series, split into the
+// points the provider reported and the persisted history that fills the
+// earlier part of the range the provider didn't cover. Days before the
+// provider's first point are left out rather than drawn as zeros when there
+// is no history for them.
+func rangeSeries(reported, history []core.TimePoint, days int, now time.Time) (provider, local []core.TimePoint) {
+	first := ""
+	for _, p := range reported {
+		if first == "" || p.Date < first {
+			first = p.Date
+		}
+	}
+	if first == "" {
+		return nil, nil
+	}
+	provider = clipAndPadPointsByRecentDays(reported, days, now)
+	for len(provider) > 0 && provider[0].Date < first {
+		provider = provider[1:]
+	}
+
+	today := now.UTC()
+	start := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -(days - 1)).Format("2006-01-02")
+	var older []core.TimePoint
+	oldest := ""
+	for _, p := range history {
+		if p.Date < start || p.Date >= first {
+			continue
+		}
+		older = append(older, p)
+		if oldest == "" || p.Date < oldest {
+			oldest = p.Date
+		}
+	}
+	for _, p := range clipAndPadPointsByRecentDays(older, days, now) {
+		if p.Date >= oldest && p.Date < first {
+			local = append(local, p)
+		}
+	}
+	return provider, local
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+const (
+	// slowEndpointLatency is the peak latency at which an endpoint row turns
+	// warn-coloured; the daemon's per-fetch timeout is 8s.
+	slowEndpointLatency = 2 * time.Second
+	maxEndpointRows     = 10
+)
+
+// buildDetailEndpointSection renders the per-endpoint HTTP health the poll
+// engine recorded on the last fetch: calls, errors, mean and peak latency,
+// slowest first, with the last error under failing endpoints.
+func buildDetailEndpointSection(snap core.UsageSnapshot, innerW int) []string {
+	stats := core.ExtractEndpointStats(snap)
+	if len(stats) == 0 {
+		return nil
+	}
+
+	const numW = 6
+	nameW := innerW - 4*(numW+2) - 2
+	if nameW < 12 {
+		nameW = 12
+	}
+	header := fmt.Sprintf("%-*s  %*s  %*s  %*s  %*s", nameW, "Endpoint", numW, "Calls", numW, "Errors", numW, "Avg", numW, "Max")
+	lines := []string{"  " + dimStyle.Render(header)}
+
+	for i, s := range stats {
+		if i == maxEndpointRows {
+			lines = append(lines, "  "+dimStyle.Render(fmt.Sprintf("+%d more endpoints", len(stats)-maxEndpointRows)))
+			break
+		}
+		name := truncateToWidth(s.Label, nameW)
+		rowStyle := valueStyle
+		switch {
+		case s.Errors > 0:
+			rowStyle = lipgloss.NewStyle().Foreground(colorCrit)
+		case s.MaxLatency >= slowEndpointLatency:
+			rowStyle = lipgloss.NewStyle().Foreground(colorWarn)
+		}
+		lines = append(lines, fmt.Sprintf("  %s  %s",
+			labelStyle.Render(name+strings.Repeat(" ", nameW-lipgloss.Width(name))),
+			rowStyle.Render(fmt.Sprintf("%*d  %*d  %*s  %*s",
+				numW, s.Requests, numW, s.Errors,
+				numW, formatEndpointLatency(s.AvgLatency()), numW, formatEndpointLatency(s.MaxLatency))),
+		))
+		if s.Errors > 0 && s.LastError != "" {
+			lines = append(lines, "    "+dimStyle.Render("└ "+truncateToWidth(s.LastError, innerW-6)))
+		}
+	}
+	return lines
+}
+
+func formatEndpointLatency(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
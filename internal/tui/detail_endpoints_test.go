@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestBuildDetailEndpointSection(t *testing.T) {
+	snap := core.NewUsageSnapshot("openai", "openai")
+	if lines := buildDetailEndpointSection(snap, 80); lines != nil {
+		t.Fatalf("expected no section without endpoint stats, got %v", lines)
+	}
+
+	core.ApplyEndpointStats(&snap, []core.EndpointStat{
+		{Key: "get_api_example_com_v1_usage", Label: "GET api.example.com/v1/usage", Requests: 3, TotalLatency: 750 * time.Millisecond, MaxLatency: 400 * time.Millisecond},
+		{Key: "get_api_example_com_v1_billing", Label: "GET api.example.com/v1/billing", Requests: 1, Errors: 1, TotalLatency: 8 * time.Second, MaxLatency: 8 * time.Second, LastError: "timeout"},
+	})
+
+	out := stripANSI(strings.Join(buildDetailEndpointSection(snap, 80), "\n"))
+	for _, want := range []string{"Endpoint", "GET api.example.com/v1/usage", "250ms", "8.0s", "└ timeout"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("endpoint section missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "/v1/billing") > strings.Index(out, "/v1/usage") {
+		t.Errorf("slowest endpoint should come first:\n%s", out)
+	}
+
+	tile := stripANSI(strings.Join(Model{}.buildTileMetricLines(snap, core.DefaultDashboardWidget(), 80, nil), "\n"))
+	if strings.Contains(tile, "endpoint") || strings.Contains(tile, "Endpoint") {
+		t.Errorf("endpoint metrics leaked into tile metrics:\n%s", tile)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
 )
 
 func titleCase(s string) string {
@@ -87,22 +88,21 @@ func sectionColor(title string) lipgloss.Color {
 	}
 }
 
+// formatNumber keeps exact digits below 10k, where a compacted "2.6k"
+// would hide the progress a detail line is showing, and compacts above.
 func formatNumber(n float64) string {
 	if n == 0 {
 		return "0"
 	}
+	loc := locale.Current()
 	abs := math.Abs(n)
 	switch {
-	case abs >= 1_000_000:
-		return fmt.Sprintf("%.1fM", n/1_000_000)
 	case abs >= 10_000:
-		return fmt.Sprintf("%.1fK", n/1_000)
-	case abs >= 1_000:
-		return fmt.Sprintf("%.0f", n)
-	case abs == math.Floor(abs):
-		return fmt.Sprintf("%.0f", n)
+		return loc.Quantity(n, "")
+	case abs >= 1_000, abs == math.Floor(abs):
+		return loc.Float(n, 0)
 	default:
-		return fmt.Sprintf("%.2f", n)
+		return loc.Float(n, 2)
 	}
 }
 
@@ -110,17 +110,35 @@ func formatTokens(n float64) string {
 	if n == 0 {
 		return "-"
 	}
-	return formatNumber(n)
+	return locale.Current().Quantity(n, "tokens")
 }
 
 func formatUSD(n float64) string {
 	if n == 0 {
 		return "-"
 	}
-	if n >= 1000 {
-		return fmt.Sprintf("$%.0f", n)
+	return locale.Current().Quantity(n, "USD")
+}
+
+// formatMoney renders a USD amount with prec fractional digits using the
+// active locale's separators and "$" placement.
+func formatMoney(n float64, prec int) string {
+	return locale.Current().USD(n, prec)
+}
+
+// formatCurrency is formatMoney for amounts whose symbol is not always "$"
+// (e.g. CNY balances); non-USD symbols stay as a prefix.
+func formatCurrency(sym string, n float64, prec int) string {
+	if sym == "$" {
+		return formatMoney(n, prec)
 	}
-	return fmt.Sprintf("$%.2f", n)
+	return sym + locale.Current().Float(n, prec)
+}
+
+// formatClock renders a wall-clock time in the active locale's 12/24-hour
+// convention.
+func formatClock(t time.Time, seconds bool) string {
+	return locale.Current().Clock(t, seconds)
 }
 
 func formatDuration(d time.Duration) string {
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/locale"
+)
+
+func TestFormattingFollowsLocale(t *testing.T) {
+	t.Cleanup(func() { locale.Set(locale.Default) })
+	ts := time.Date(2026, 3, 4, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		tag       string
+		number    string
+		usd       string
+		clock     string
+		costAxis  string
+		otherYear string
+	}{
+		{tag: "", number: "1234", usd: "$12.50", clock: "15:04:05", costAxis: "$1.5K", otherYear: "2026-03-04 15:04"},
+		{tag: "en_US", number: "1,234", usd: "$12.50", clock: "3:04:05 PM", costAxis: "$1.5K", otherYear: "03/04/2026 3:04 PM"},
+		{tag: "de_DE", number: "1.234", usd: "12,50 $", clock: "15:04:05", costAxis: "1,5K $", otherYear: "04.03.2026 15:04"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			loc, _ := locale.Lookup(tt.tag)
+			locale.Set(loc)
+
+			if got := formatNumber(1234); got != tt.number {
+				t.Errorf("formatNumber = %q, want %q", got, tt.number)
+			}
+			if got := formatUSD(12.5); got != tt.usd {
+				t.Errorf("formatUSD = %q, want %q", got, tt.usd)
+			}
+			if got := formatClock(ts, true); got != tt.clock {
+				t.Errorf("formatClock = %q, want %q", got, tt.clock)
+			}
+			if got := formatCostAxis(1500); got != tt.costAxis {
+				t.Errorf("formatCostAxis = %q, want %q", got, tt.costAxis)
+			}
+			if got := formatTileTimestamp(ts, ts.AddDate(1, 0, 0)); got != tt.otherYear {
+				t.Errorf("formatTileTimestamp = %q, want %q", got, tt.otherYear)
+			}
+		})
+	}
+}
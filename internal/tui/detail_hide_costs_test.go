@@ -186,3 +186,65 @@ func TestResolveHideCosts_ModelIntegration(t *testing.T) {
 		t.Errorf("global=true should hide costs for openai")
 	}
 }
+
+func TestBuildDetailEfficiencyRow(t *testing.T) {
+	snap := core.UsageSnapshot{Metrics: map[string]core.Metric{
+		"model_claude_sonnet_input_tokens":      {Used: core.Float64Ptr(2_000)},
+		"model_claude_sonnet_cache_read_tokens": {Used: core.Float64Ptr(6_000)},
+		"model_claude_sonnet_output_tokens":     {Used: core.Float64Ptr(1_000)},
+		"model_claude_sonnet_cost_usd":          {Used: core.Float64Ptr(0.015)},
+	}}
+
+	row := stripANSI(buildDetailEfficiencyRow(snap, 80, false))
+	for _, want := range []string{"Efficiency", "$0.015/1k out", "75% cached"} {
+		if !strings.Contains(row, want) {
+			t.Errorf("row %q missing %q", row, want)
+		}
+	}
+	if hidden := stripANSI(buildDetailEfficiencyRow(snap, 80, true)); strings.Contains(hidden, "$") || !strings.Contains(hidden, "75% cached") {
+		t.Errorf("hide-costs row = %q, want shares without dollars", hidden)
+	}
+	if row := buildDetailEfficiencyRow(core.UsageSnapshot{}, 80, false); row != "" {
+		t.Errorf("row for empty snapshot = %q, want none", row)
+	}
+}
+
+func TestBuildDetailCostSection_CacheSavings(t *testing.T) {
+	snap := snapshotWithCosts()
+	saved := 12.5
+	snap.Metrics[core.CacheSavingsMetricKey] = core.Metric{Used: &saved, Unit: "USD", Window: "30d"}
+	out := stripANSI(strings.Join(buildDetailCostSection(snap, dashboardWidget(snap.ProviderID), 80), "\n"))
+	if !strings.Contains(out, "Cache Savings") || !strings.Contains(out, "$12.50") {
+		t.Fatalf("cost section missing cache savings row:\n%s", out)
+	}
+	other := stripANSI(strings.Join(buildDetailOtherMetrics(snap, dashboardWidget(snap.ProviderID), 80, false), "\n"))
+	if strings.Contains(other, "Cache Savings") {
+		t.Errorf("cache savings repeated in other metrics:\n%s", other)
+	}
+}
+
+func TestBuildToolCostLines(t *testing.T) {
+	snap := snapshotWithCosts()
+	web, chat := 3.0, 1.0
+	snap.Metrics[core.ToolCostMetricKey(core.ToolCategoryWeb)] = core.Metric{Used: &web, Unit: "USD", Window: "all-time"}
+	snap.Metrics[core.ToolCostMetricKey(core.ToolCategoryChat)] = core.Metric{Used: &chat, Unit: "USD", Window: "all-time"}
+
+	lines, used := buildToolCostLines(snap, 80, true, false)
+	out := stripANSI(strings.Join(lines, "\n"))
+	for _, want := range []string{"Cost by Tool", "$4.00 · all-time", "Web search & fetch", "75% $3.00", "No tool (chat)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("cost by tool missing %q:\n%s", want, out)
+		}
+	}
+	if len(used) != 2 {
+		t.Errorf("usedKeys = %v", used)
+	}
+
+	if hidden, used := buildToolCostLines(snap, 80, true, true); len(hidden) != 0 || len(used) != 2 {
+		t.Errorf("hide-costs: lines = %q, usedKeys = %v", hidden, used)
+	}
+	other := stripANSI(strings.Join(buildDetailOtherMetrics(snap, dashboardWidget(snap.ProviderID), 80, false), "\n"))
+	if strings.Contains(other, "Toolcost") || strings.Contains(other, "$3.00") {
+		t.Errorf("tool costs repeated in other metrics:\n%s", other)
+	}
+}
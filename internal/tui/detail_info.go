@@ -93,7 +93,7 @@ func renderRawData(sb *strings.Builder, raw map[string]string, widget core.Dashb
 	}
 
 	for _, key := range core.SortedStringKeys(raw) {
-		if rendered[key] || strings.HasSuffix(key, "_error") {
+		if rendered[key] || strings.HasSuffix(key, "_error") || strings.HasPrefix(key, seatActivityRawPrefix) || core.IsEndpointRawKey(key) {
 			continue
 		}
 		value := smartFormatValue(raw[key])
@@ -107,13 +107,17 @@ func renderRawData(sb *strings.Builder, raw map[string]string, widget core.Dashb
 	}
 }
 
+func formatLongTimestamp(t time.Time) string {
+	return t.Format("Jan 02, 2006") + " " + formatClock(t, false)
+}
+
 func smartFormatValue(v string) string {
 	trimmed := strings.TrimSpace(v)
 	if n, err := strconv.ParseInt(trimmed, 10, 64); err == nil && n > 1e12 && n < 2e13 {
-		return time.Unix(n/1000, 0).Format("Jan 02, 2006 15:04")
+		return formatLongTimestamp(time.Unix(n/1000, 0))
 	}
 	if n, err := strconv.ParseInt(trimmed, 10, 64); err == nil && n > 1e9 && n < 2e10 {
-		return time.Unix(n, 0).Format("Jan 02, 2006 15:04")
+		return formatLongTimestamp(time.Unix(n, 0))
 	}
 	return v
 }
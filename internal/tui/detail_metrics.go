@@ -21,7 +21,7 @@ func renderTimersSection(sb *strings.Builder, resets map[string]time.Time, widge
 		resetAt := resets[key]
 		label := metricLabel(widget, key)
 		remaining := time.Until(resetAt)
-		dateStr := resetAt.Format("Jan 02 15:04")
+		dateStr := resetAt.Format("Jan 02") + " " + formatClock(resetAt, false)
 
 		if remaining <= 0 {
 			sb.WriteString(fmt.Sprintf("  %s  %s  %s (expired)\n",
@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// providerReference returns the documented tier and limit data the provider
+// declares in its spec.
+func providerReference(providerID string) core.ProviderReference {
+	loadProviderSpecs()
+	return providerSpecs[providerID].Reference
+}
+
+// RenderDetailReference renders the detail view's reference pane: the
+// limits the account reports next to the provider's documented tiers, with
+// the matching tier marked and the next one spelled out, then how the limit
+// windows roll and where the documentation lives.
+func RenderDetailReference(snap core.UsageSnapshot, ref core.ProviderReference, w int) string {
+	var sb strings.Builder
+	sb.WriteString("  " + lipgloss.NewStyle().Bold(true).Foreground(colorText).Render(snap.AccountID) +
+		dimStyle.Render(" · "+snap.ProviderID+" · reference") + "\n")
+
+	if ref.IsZero() {
+		sb.WriteString("\n" + dimStyle.Render("  No documented tiers or limits for this provider.") + "\n")
+		return sb.String()
+	}
+	for _, sec := range buildDetailReferenceSections(snap, ref, max(30, w-8)) {
+		renderDetailCard(&sb, sec, w)
+	}
+	return sb.String()
+}
+
+func buildDetailReferenceSections(snap core.UsageSnapshot, ref core.ProviderReference, innerW int) []detailSection {
+	var sections []detailSection
+	matched, ok := ref.MatchTier(snap)
+
+	if limits := referenceLimitText(snap, referenceLimitKeys(ref)); limits != "" || ok {
+		var lines []string
+		if limits != "" {
+			lines = append(lines, renderDotLeaderRow("Reported", limits, innerW))
+		}
+		if ok {
+			lines = append(lines, renderDotLeaderRow("Matches", lipgloss.NewStyle().Foreground(colorGreen).Bold(true).Render(ref.Tiers[matched].Name), innerW))
+			if matched+1 < len(ref.Tiers) {
+				next := ref.Tiers[matched+1]
+				value := next.Name
+				if next.Qualification != "" {
+					value += dimStyle.Render(" · " + next.Qualification)
+				}
+				lines = append(lines, renderDotLeaderRow("Next tier", value, innerW))
+			}
+		} else {
+			lines = append(lines, renderDotLeaderRow("Matches", dimStyle.Render("no documented tier"), innerW))
+		}
+		if text := tierSuggestionText(snap, ref); text != "" {
+			wrap := lipgloss.NewStyle().Width(max(20, innerW-4))
+			for i, line := range strings.Split(wrap.Render(text), "\n") {
+				prefix := "⬆ "
+				if i > 0 {
+					prefix = "  "
+				}
+				lines = append(lines, prefix+lipgloss.NewStyle().Foreground(colorPeach).Render(strings.TrimRight(line, " ")))
+			}
+		}
+		sections = append(sections, detailSection{id: "reference_limits", title: "Your limits", icon: "⚡", color: colorYellow, lines: lines})
+	}
+
+	if len(ref.Tiers) > 0 {
+		var lines []string
+		if ref.LimitsNote != "" {
+			lines = append(lines, "  "+dimStyle.Render("Limits "+ref.LimitsNote))
+		}
+		for i, tier := range ref.Tiers {
+			marker, nameStyle := "  ", labelStyle
+			if ok && i == matched {
+				marker, nameStyle = "▸ ", lipgloss.NewStyle().Foreground(colorGreen).Bold(true)
+			}
+			lines = append(lines, marker+nameStyle.Render(tier.Name))
+			if tier.Qualification != "" {
+				lines = append(lines, "    "+dimStyle.Render(tier.Qualification))
+			}
+			if len(tier.Limits) > 0 {
+				lines = append(lines, "    "+valueStyle.Render(referenceTierLimits(tier.Limits)))
+			}
+		}
+		sections = append(sections, detailSection{id: "reference_tiers", title: "Tiers", icon: "🏷", color: colorLavender, lines: lines})
+	}
+
+	if len(ref.Windows) > 0 {
+		var lines []string
+		wrap := lipgloss.NewStyle().Width(max(20, innerW-4))
+		for _, note := range ref.Windows {
+			for i, line := range strings.Split(wrap.Render(note), "\n") {
+				prefix := "  • "
+				if i > 0 {
+					prefix = "    "
+				}
+				lines = append(lines, prefix+dimStyle.Render(strings.TrimRight(line, " ")))
+			}
+		}
+		sections = append(sections, detailSection{id: "reference_windows", title: "Windows", icon: "⏰", color: colorMaroon, lines: lines})
+	}
+
+	if len(ref.Links) > 0 {
+		var lines []string
+		for _, link := range ref.Links {
+			lines = append(lines, renderDotLeaderRow(link.Label, lipgloss.NewStyle().Foreground(colorSapphire).Render(link.URL), innerW))
+		}
+		sections = append(sections, detailSection{id: "reference_links", title: "Links", icon: "🔗", color: colorBlue, lines: lines})
+	}
+	return sections
+}
+
+// referenceLimitKeys returns the metric keys any tier documents, sorted.
+func referenceLimitKeys(ref core.ProviderReference) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, tier := range ref.Tiers {
+		for key := range tier.Limits {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// referenceLimitText lists the snapshot's limits for keys, e.g.
+// "rpm 500 · tpm 200K".
+func referenceLimitText(snap core.UsageSnapshot, keys []string) string {
+	var parts []string
+	for _, key := range keys {
+		if m, ok := snap.Metrics[key]; ok && m.Limit != nil {
+			parts = append(parts, key+" "+formatNumber(*m.Limit))
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+func referenceTierLimits(limits map[string]float64) string {
+	keys := make([]string, 0, len(limits))
+	for key := range limits {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+" "+formatNumber(limits[key]))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// tierSuggestionText describes the upgrade core.SuggestTierUpgrade
+// recommends for snap, or "" when there is none.
+func tierSuggestionText(snap core.UsageSnapshot, ref core.ProviderReference) string {
+	sug, ok := core.SuggestTierUpgrade(ref, snap)
+	if !ok {
+		return ""
+	}
+	text := fmt.Sprintf("%s near its limit on %d of the last %d days · %s", sug.Metric, sug.Pressure.HitDays, sug.Pressure.Days, sug.Next.Name)
+	if sug.CurrentLimit != nil && sug.NextLimit != nil {
+		text += fmt.Sprintf(" raises it %s → %s", formatNumber(*sug.CurrentLimit), formatNumber(*sug.NextLimit))
+	}
+	if sug.Next.Qualification != "" {
+		text += " (" + sug.Next.Qualification + ")"
+	}
+	return text
+}
+
+// tierSuggestionLine is the detail header's one-line upgrade hint.
+func tierSuggestionLine(snap core.UsageSnapshot, maxW int) string {
+	text := tierSuggestionText(snap, providerReference(snap.ProviderID))
+	if text == "" {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(colorPeach).Bold(true).Render(truncateToWidth("⬆ "+text+" · i for tiers", maxW))
+}
@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestRenderDetailReference(t *testing.T) {
+	snap := core.NewUsageSnapshot("groq", "groq")
+	snap.Metrics["rpd"] = core.Metric{Limit: core.Float64Ptr(14400), Remaining: core.Float64Ptr(14000), Window: "1d"}
+	snap.Metrics["tpd"] = core.Metric{Limit: core.Float64Ptr(500000), Remaining: core.Float64Ptr(420000), Window: "1d"}
+
+	out := stripANSI(RenderDetailReference(snap, providerReference("groq"), 100))
+	for _, want := range []string{"Your limits", "rpd 14.4k", "Matches", "Free", "Next tier", "Developer", "rolling day", "console.groq.com/docs/rate-limits"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("reference pane missing %q:\n%s", want, out)
+		}
+	}
+
+	snap.Metrics["rpd"] = core.Metric{Limit: core.Float64Ptr(1000)}
+	out = stripANSI(RenderDetailReference(snap, providerReference("groq"), 100))
+	if !strings.Contains(out, "no documented tier") {
+		t.Errorf("mismatched limits should match no tier:\n%s", out)
+	}
+
+	out = stripANSI(RenderDetailReference(core.NewUsageSnapshot("x", "x"), core.ProviderReference{}, 100))
+	if !strings.Contains(out, "No documented tiers") {
+		t.Errorf("empty reference should say so:\n%s", out)
+	}
+}
+
+func TestDetailReferenceToggle(t *testing.T) {
+	m := NewModel(0.2, 0.1, false, config.DashboardConfig{}, []core.AccountConfig{{ID: "groq", Provider: "groq"}}, core.TimeWindow7d)
+	m.snapshots = map[string]core.UsageSnapshot{"groq": core.NewUsageSnapshot("groq", "groq")}
+	m.sortedIDs = []string{"groq"}
+	m.mode = modeDetail
+
+	key := func(s string) tea.KeyMsg {
+		if s == "esc" {
+			return tea.KeyMsg{Type: tea.KeyEsc}
+		}
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+	updated, _ := m.handleDetailKey(key("i"))
+	m = updated.(Model)
+	if !m.detailReference {
+		t.Fatal("i should open the reference pane")
+	}
+	updated, _ = m.handleDetailKey(key("esc"))
+	m = updated.(Model)
+	if m.detailReference || m.mode != modeDetail {
+		t.Fatalf("esc should close the pane and stay in detail: reference=%v mode=%v", m.detailReference, m.mode)
+	}
+	updated, _ = m.handleDetailKey(key("i"))
+	m = updated.(Model).exitDetailMode()
+	if m.detailReference {
+		t.Error("leaving detail should close the reference pane")
+	}
+}
+
+func TestTierSuggestion(t *testing.T) {
+	snap := core.NewUsageSnapshot("openai", "openai")
+	snap.Metrics["rpm"] = core.Metric{Limit: core.Float64Ptr(500), Remaining: core.Float64Ptr(480), Unit: "requests", Window: "1m"}
+	snap.Metrics["tpm"] = core.Metric{Limit: core.Float64Ptr(200000), Remaining: core.Float64Ptr(190000), Unit: "tokens", Window: "1m"}
+	if line := tierSuggestionLine(snap, 200); line != "" {
+		t.Fatalf("no pressure should mean no suggestion, got %q", stripANSI(line))
+	}
+
+	snap.SetAttribute(core.LimitPressureAttrPrefix+"rpm", "5/7")
+	line := stripANSI(tierSuggestionLine(snap, 200))
+	for _, want := range []string{"rpm near its limit on 5 of the last 7 days", "Tier 2 raises it 500 → " + formatNumber(5000), "$50 paid"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("suggestion %q missing %q", line, want)
+		}
+	}
+	out := stripANSI(RenderDetailContent(snap, snap.Timestamp, 200, 0.3, 0.1, 0, core.TimeWindow7d, false))
+	if !strings.Contains(out, "⬆ rpm near its limit") {
+		t.Errorf("detail header missing the suggestion:\n%s", out)
+	}
+	pane := stripANSI(RenderDetailReference(snap, providerReference("openai"), 200))
+	if !strings.Contains(pane, "Matches") || !strings.Contains(pane, "⬆ rpm near its limit") {
+		t.Errorf("reference pane missing tier match or suggestion:\n%s", pane)
+	}
+}
+
+func TestReferenceReportedTier(t *testing.T) {
+	snap := core.NewUsageSnapshot("perplexity", "perplexity")
+	snap.SetAttribute("usage_tier", "2")
+	pane := stripANSI(RenderDetailReference(snap, providerReference("perplexity"), 120))
+	for _, want := range []string{"Tier 2", "Next tier", "Tier 3", "$500+ in credits purchased"} {
+		if !strings.Contains(pane, want) {
+			t.Errorf("reference pane missing %q:\n%s", want, pane)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
+)
+
+const (
+	seatActivityRawPrefix = "seat_activity_"
+	// seatInactiveAfter mirrors the copilot provider's inactive-seat cutoff.
+	seatInactiveAfter = 30 * 24 * time.Hour
+	maxSeatRows       = 15
+)
+
+type seatActivityRow struct {
+	org    string
+	login  string
+	last   time.Time // zero = never active
+	editor string
+}
+
+// collectSeatActivity parses the seat_activity_<org>/<login> raw entries
+// written by providers that can list org seats.
+func collectSeatActivity(raw map[string]string) []seatActivityRow {
+	var rows []seatActivityRow
+	for key, value := range raw {
+		rest, ok := strings.CutPrefix(key, seatActivityRawPrefix)
+		if !ok {
+			continue
+		}
+		org, login, ok := strings.Cut(rest, "/")
+		if !ok || login == "" {
+			continue
+		}
+		stamp, editor, _ := strings.Cut(strings.TrimSpace(value), " ")
+		row := seatActivityRow{org: org, login: login, editor: editor}
+		if t, err := time.Parse(time.RFC3339, stamp); err == nil {
+			row.last = t
+		}
+		rows = append(rows, row)
+	}
+	// Least recently active first: those are the seats worth reclaiming.
+	sort.Slice(rows, func(i, j int) bool {
+		if !rows[i].last.Equal(rows[j].last) {
+			return rows[i].last.Before(rows[j].last)
+		}
+		if rows[i].org != rows[j].org {
+			return rows[i].org < rows[j].org
+		}
+		return rows[i].login < rows[j].login
+	})
+	return rows
+}
+
+// buildDetailSeatSection renders per-org seat totals, the estimated monthly
+// cost of inactive seats, and a last-activity table of the least recently
+// active seats. Providers only report the most idle seats, so the table ends
+// with a count of the seats left out.
+func buildDetailSeatSection(snap core.UsageSnapshot, innerW int, hideCosts bool, now time.Time) []string {
+	rows := collectSeatActivity(snap.Raw)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	orgs := seatOrgs(rows)
+	totalSeats := 0
+	var lines []string
+	for _, org := range orgs {
+		prefix := "org_" + org + "_"
+		label, idleLabel := "Seats", "Inactive"
+		if len(orgs) > 1 {
+			label, idleLabel = "Seats · "+org, "Inactive · "+org
+		}
+		if total, ok := snap.Metrics[prefix+"total_seats"]; ok && total.Used != nil {
+			totalSeats += int(*total.Used)
+			active := 0.0
+			if m, ok := snap.Metrics[prefix+"active_seats"]; ok && m.Used != nil {
+				active = *m.Used
+			}
+			lines = append(lines, renderDotLeaderRow(label,
+				fmt.Sprintf("%s active / %s total", formatNumber(active), formatNumber(*total.Used)), innerW))
+		}
+		if m, ok := snap.Metrics[prefix+"inactive_seats"]; ok && m.Used != nil && *m.Used > 0 {
+			value := lipgloss.NewStyle().Foreground(colorWarn).Render(formatNumber(*m.Used) + " idle 30d+")
+			if cost, ok := snap.Metrics[prefix+"unused_seat_cost"]; ok && cost.Used != nil && !hideCosts {
+				value += dimStyle.Render(" · save " + formatMoney(*cost.Used, 0) + "/mo")
+			}
+			lines = append(lines, renderDotLeaderRow(idleLabel, value, innerW))
+		}
+	}
+
+	lines = append(lines, "")
+	loginW := 0
+	for _, row := range rows {
+		if w := lipgloss.Width(seatRowName(row, len(orgs) > 1)); w > loginW {
+			loginW = w
+		}
+	}
+	if maxW := innerW / 2; loginW > maxW {
+		loginW = maxW
+	}
+	lines = append(lines, "  "+dimStyle.Render(fmt.Sprintf("%-*s  %-13s  %s", loginW, "Seat", "Last activity", "Idle")))
+
+	if len(rows) > maxSeatRows {
+		rows = rows[:maxSeatRows]
+	}
+	for _, row := range rows {
+		name := truncateToWidth(seatRowName(row, len(orgs) > 1), loginW)
+		lastStr, idleStr := "never", "—"
+		idleStyle := lipgloss.NewStyle().Foreground(colorWarn)
+		if !row.last.IsZero() {
+			lastStr = locale.Current().Date(row.last.Local())
+			idle := now.Sub(row.last)
+			idleStr = formatDurationShort(idle)
+			if idle < seatInactiveAfter {
+				idleStyle = dimStyle
+			}
+		}
+		editor := ""
+		if row.editor != "" {
+			editor = dimStyle.Render(" · " + row.editor)
+		}
+		lines = append(lines, fmt.Sprintf("  %s  %s  %s%s",
+			labelStyle.Render(name+strings.Repeat(" ", loginW-lipgloss.Width(name))),
+			valueStyle.Render(fmt.Sprintf("%-13s", lastStr)),
+			idleStyle.Render(idleStr),
+			editor,
+		))
+	}
+	if more := totalSeats - len(rows); more > 0 {
+		lines = append(lines, "  "+dimStyle.Render(fmt.Sprintf("+%d more seats", more)))
+	}
+	return lines
+}
+
+func seatRowName(row seatActivityRow, withOrg bool) string {
+	if withOrg {
+		return row.org + "/" + row.login
+	}
+	return row.login
+}
+
+func seatOrgs(rows []seatActivityRow) []string {
+	seen := make(map[string]bool)
+	var orgs []string
+	for _, row := range rows {
+		if !seen[row.org] {
+			seen[row.org] = true
+			orgs = append(orgs, row.org)
+		}
+	}
+	sort.Strings(orgs)
+	return orgs
+}
@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestBuildDetailSeatSection(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	snap := core.NewUsageSnapshot("copilot", "copilot")
+	snap.Metrics["org_acme_total_seats"] = core.Metric{Used: core.Float64Ptr(3), Unit: "seats"}
+	snap.Metrics["org_acme_active_seats"] = core.Metric{Used: core.Float64Ptr(1), Unit: "seats"}
+	snap.Metrics["org_acme_inactive_seats"] = core.Metric{Used: core.Float64Ptr(2), Unit: "seats"}
+	snap.Metrics["org_acme_unused_seat_cost"] = core.Metric{Used: core.Float64Ptr(38), Unit: "USD"}
+	snap.Raw["seat_activity_acme/alice"] = "2026-03-31T10:00:00Z vscode"
+	snap.Raw["seat_activity_acme/bob"] = "2026-02-01T10:00:00Z"
+	snap.Raw["seat_activity_acme/carol"] = "never"
+
+	lines := buildDetailSeatSection(snap, 80, false, now)
+	out := stripANSI(strings.Join(lines, "\n"))
+	for _, want := range []string{"1 active / 3 total", "2 idle 30d+", "save $38/mo", "alice", "vscode"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("seat section missing %q:\n%s", want, out)
+		}
+	}
+	// Never-active seats sort first, the most recent last.
+	if c, b, a := strings.Index(out, "carol"), strings.Index(out, "bob"), strings.Index(out, "alice"); !(c < b && b < a) {
+		t.Errorf("rows not ordered by last activity:\n%s", out)
+	}
+
+	snap.Metrics["org_acme_total_seats"] = core.Metric{Used: core.Float64Ptr(40), Unit: "seats"}
+	if out := stripANSI(strings.Join(buildDetailSeatSection(snap, 80, false, now), "\n")); !strings.Contains(out, "+37 more seats") {
+		t.Errorf("seats the provider left out are not counted:\n%s", out)
+	}
+
+	hidden := stripANSI(strings.Join(buildDetailSeatSection(snap, 80, true, now), "\n"))
+	if strings.Contains(hidden, "$") {
+		t.Errorf("hide-costs still renders savings:\n%s", hidden)
+	}
+}
@@ -14,7 +14,7 @@ import (
 // Sections are filtered and ordered according to effectiveDetailSectionOrder().
 //
 // hideCosts suppresses the Spending and Forecast cards entirely.
-func buildDetailSections(snap core.UsageSnapshot, widget core.DashboardWidget, w int, warnThresh, critThresh float64, timeWindow core.TimeWindow, hideCosts bool, now time.Time) []detailSection {
+func buildDetailSections(snap core.UsageSnapshot, widget core.DashboardWidget, w int, warnThresh, critThresh float64, timeWindow core.TimeWindow, chartDays int, hideCosts bool, now time.Time) []detailSection {
 	innerW := w - 8 // card borders + margins + padding
 	if innerW < 30 {
 		innerW = 30
@@ -41,6 +41,9 @@ func buildDetailSections(snap core.UsageSnapshot, widget core.DashboardWidget, w
 
 	// 3. Model Burn — composition bar with per-model breakdown + token detail.
 	if modelLines, _ := buildProviderModelCompositionLinesWithHide(snap, innerW, true, hideCosts); len(modelLines) > 0 {
+		if row := buildDetailEfficiencyRow(snap, innerW, hideCosts); row != "" {
+			modelLines = append(modelLines, "", row)
+		}
 		// Add per-model token breakdown if available.
 		models := core.ExtractAnalyticsModelUsage(snap)
 		for _, model := range models {
@@ -77,6 +80,10 @@ func buildDetailSections(snap core.UsageSnapshot, widget core.DashboardWidget, w
 		candidates[core.DetailSectionTools] = append(candidates[core.DetailSectionTools],
 			detailSection{id: "Tools", title: "Tools", lines: toolLines, hasOwnHeader: true})
 	}
+	if costLines, _ := buildToolCostLines(snap, innerW, true, hideCosts); len(costLines) > 0 {
+		candidates[core.DetailSectionTools] = append(candidates[core.DetailSectionTools],
+			detailSection{id: "Tools", title: "Cost by Tool", lines: costLines, hasOwnHeader: true})
+	}
 
 	// 7. MCP Usage.
 	if hasMCPMetrics(snap) {
@@ -103,7 +110,7 @@ func buildDetailSections(snap core.UsageSnapshot, widget core.DashboardWidget, w
 	}
 
 	// 10. Daily Usage & Trends (with zoom support).
-	if trendLines := buildDetailTrendsSectionWithHide(snap, widget, innerW, timeWindow, hideCosts); len(trendLines) > 0 {
+	if trendLines := buildDetailTrendsSectionWithRange(snap, widget, innerW, timeWindow, chartDays, hideCosts, now); len(trendLines) > 0 {
 		candidates[core.DetailSectionTrends] = append(candidates[core.DetailSectionTrends],
 			detailSection{id: "Trends", title: "Trends", lines: trendLines, hasOwnHeader: true})
 	}
@@ -111,14 +118,14 @@ func buildDetailSections(snap core.UsageSnapshot, widget core.DashboardWidget, w
 	// 10b. Dual-axis cost + requests overlay (detail-only). Skipped entirely
 	// when hide-costs is on — the cost axis is the whole point.
 	if !hideCosts {
-		if dualLines := buildDetailDualAxisChart(snap, widget, innerW, timeWindow); len(dualLines) > 0 {
+		if dualLines := buildDetailDualAxisChart(snap, widget, innerW, timeWindow, chartDays, now); len(dualLines) > 0 {
 			candidates[core.DetailSectionCostRequests] = append(candidates[core.DetailSectionCostRequests],
 				detailSection{id: "Trends", title: "Overview", lines: dualLines, hasOwnHeader: true})
 		}
 	}
 
 	// 10c. Activity Heatmap.
-	if heatLines := buildDetailActivityHeatmap(snap, innerW); len(heatLines) > 0 {
+	if heatLines := buildDetailActivityHeatmap(snap, innerW, hideCosts, now); len(heatLines) > 0 {
 		candidates[core.DetailSectionActivityHeatmap] = append(candidates[core.DetailSectionActivityHeatmap],
 			detailSection{id: "Trends", title: "Activity", icon: "📅", color: colorGreen, lines: heatLines})
 	}
@@ -144,12 +151,30 @@ func buildDetailSections(snap core.UsageSnapshot, widget core.DashboardWidget, w
 		}
 	}
 
+	// 13a. Energy & CO2 estimate (opt-in via the top-level energy config).
+	if energyLines := buildDetailEnergySection(snap, innerW); len(energyLines) > 0 {
+		candidates[core.DetailSectionEnergy] = append(candidates[core.DetailSectionEnergy],
+			detailSection{id: "Usage", title: "Energy", icon: "🌱", color: colorGreen, lines: energyLines})
+	}
+
+	// 13b. Org seat activity (Copilot org admins).
+	if seatLines := buildDetailSeatSection(snap, innerW, hideCosts, now); len(seatLines) > 0 {
+		candidates[core.DetailSectionSeats] = append(candidates[core.DetailSectionSeats],
+			detailSection{id: "Usage", title: "Seats", icon: "👥", color: colorLavender, lines: seatLines})
+	}
+
 	// 14. Other metrics as dot-leader rows.
 	if otherLines := buildDetailOtherMetrics(snap, widget, innerW, hideCosts); len(otherLines) > 0 {
 		candidates[core.DetailSectionOtherData] = append(candidates[core.DetailSectionOtherData],
 			detailSection{id: "Usage", title: "Other Data", icon: "›", color: colorDim, lines: otherLines})
 	}
 
+	// 14b. Per-endpoint HTTP health from the poll engine.
+	if endpointLines := buildDetailEndpointSection(snap, innerW); len(endpointLines) > 0 {
+		candidates[core.DetailSectionEndpoints] = append(candidates[core.DetailSectionEndpoints],
+			detailSection{id: "Info", title: "Endpoints", icon: "⏱", color: colorSapphire, lines: endpointLines})
+	}
+
 	// 15. Timers.
 	if len(snap.Resets) > 0 {
 		var timerSB strings.Builder
@@ -157,6 +182,9 @@ func buildDetailSections(snap core.UsageSnapshot, widget core.DashboardWidget, w
 		if timerStr := timerSB.String(); strings.TrimSpace(timerStr) != "" {
 			lines := strings.Split(strings.TrimRight(timerStr, "\n"), "\n")
 			filtered := filterOutSectionHeader(lines)
+			if timeline := buildResetTimelineLines(snap.Resets, widget, innerW, now); len(timeline) > 0 {
+				filtered = append(append(timeline, ""), filtered...)
+			}
 			candidates[core.DetailSectionTimers] = append(candidates[core.DetailSectionTimers],
 				detailSection{id: "Timers", title: "Timers", icon: "⏰", color: colorMaroon, lines: filtered})
 		}
@@ -233,7 +261,7 @@ func buildDetailGaugeLines(snap core.UsageSnapshot, widget core.DashboardWidget,
 
 	var lines []string
 	for _, key := range keys {
-		if gaugeAllowSet != nil && !gaugeAllowSet[key] {
+		if gaugeAllowSet != nil && !gaugeAllowSet[key] || core.IsEndpointMetricKey(key) {
 			continue
 		}
 		met := snap.Metrics[key]
@@ -241,7 +269,7 @@ func buildDetailGaugeLines(snap core.UsageSnapshot, widget core.DashboardWidget,
 		if usedPct < 0 {
 			continue
 		}
-		label := gaugeLabel(widget, key, met.Window)
+		label := snapshotGaugeLabel(snap, widget, key, met.Window)
 		if len(label) > maxLabelW {
 			label = label[:maxLabelW-1] + "…"
 		}
@@ -252,7 +280,9 @@ func buildDetailGaugeLines(snap core.UsageSnapshot, widget core.DashboardWidget,
 		gauge := RenderUsageGauge(usedPct, gaugeW, warnThresh, critThresh)
 		windowDur, hasWindow := gaugeWindowDuration(met.Window)
 		resetAt, hasReset := snap.Resets[key]
-		if hasWindow && hasReset {
+		if _, suspect := snap.MetricAnomaly(key); suspect {
+			gauge = RenderSuspectGauge(gaugeW)
+		} else if hasWindow && hasReset {
 			resetIn := resetAt.Sub(now)
 			elapsed := windowDur - resetIn
 			var paceFraction float64
@@ -291,6 +321,7 @@ func buildDetailCostSection(snap core.UsageSnapshot, widget core.DashboardWidget
 		{"total_cost_usd", "Total Cost"},
 		{"window_cost", "Window Cost"},
 		{"monthly_spend", "Monthly Spend"},
+		{core.CacheSavingsMetricKey, "Cache Savings"},
 	}
 
 	for _, ck := range costKeys {
@@ -311,7 +342,7 @@ func buildDetailCostSection(snap core.UsageSnapshot, widget core.DashboardWidget
 
 	// Burn rate.
 	if costSummary.BurnRateUSD > 0 {
-		lines = append(lines, renderDotLeaderRow("Burn Rate", fmt.Sprintf("$%.2f/h", costSummary.BurnRateUSD), innerW))
+		lines = append(lines, renderDotLeaderRow("Burn Rate", fmt.Sprintf("%s/h", formatMoney(costSummary.BurnRateUSD, 2)), innerW))
 	}
 
 	// Credit balance.
@@ -365,6 +396,31 @@ func buildDetailCostSection(snap core.UsageSnapshot, widget core.DashboardWidget
 	return lines
 }
 
+// buildDetailEfficiencyRow renders the token-efficiency KPIs as one
+// dot-leader row: $/1k output tokens, cache-read share of prompt tokens, and
+// reasoning share of generated tokens. The dollar figure is dropped when
+// hide-costs is on.
+func buildDetailEfficiencyRow(snap core.UsageSnapshot, innerW int, hideCosts bool) string {
+	eff, ok := core.ComputeTokenEfficiency(snap)
+	if !ok {
+		return ""
+	}
+	var parts []string
+	if eff.CostPer1kOutput != nil && !hideCosts {
+		parts = append(parts, formatMoney(*eff.CostPer1kOutput, 3)+"/1k out")
+	}
+	if eff.CacheReadPercent != nil {
+		parts = append(parts, fmt.Sprintf("%.0f%% cached", *eff.CacheReadPercent))
+	}
+	if eff.ReasoningPercent != nil {
+		parts = append(parts, fmt.Sprintf("%.0f%% reasoning", *eff.ReasoningPercent))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return renderDotLeaderRow("Efficiency", strings.Join(parts, " · "), innerW)
+}
+
 // buildDetailProjectionSection builds budget forecast projections (detail-only data).
 func buildDetailProjectionSection(snap core.UsageSnapshot, innerW int) []string {
 	lines := buildDetailCodexCreditForecastSection(snap, innerW)
@@ -388,9 +444,9 @@ func buildDetailProjectionSection(snap core.UsageSnapshot, innerW int) []string
 			daysLeft := hoursLeft / 24
 			var projStr string
 			if daysLeft < 1 {
-				projStr = fmt.Sprintf("%.0fh left at $%.2f/h", hoursLeft, costSummary.BurnRateUSD)
+				projStr = fmt.Sprintf("%.0fh left at %s/h", hoursLeft, formatMoney(costSummary.BurnRateUSD, 2))
 			} else {
-				projStr = fmt.Sprintf("%.1f days left at $%.2f/h", daysLeft, costSummary.BurnRateUSD)
+				projStr = fmt.Sprintf("%.1f days left at %s/h", daysLeft, formatMoney(costSummary.BurnRateUSD, 2))
 			}
 			urgencyColor := colorGreen
 			if daysLeft < 3 {
@@ -527,7 +583,8 @@ func buildDetailOtherMetrics(snap core.UsageSnapshot, widget core.DashboardWidge
 		"all_time_api_cost", "total_cost_usd", "window_cost", "monthly_spend",
 		"credit_balance", "spend_limit", "plan_spend", "plan_total_spend_usd",
 		"plan_limit_usd", "plan_percent_used", "individual_spend", "burn_rate",
-		"codex_credit_limit", "codex_credit_percent_used", "codex_credit_burn_rate", "codex_credit_runout_hours"} {
+		"codex_credit_limit", "codex_credit_percent_used", "codex_credit_burn_rate", "codex_credit_runout_hours",
+		core.CacheSavingsMetricKey} {
 		skipKeys[ck] = true
 	}
 
@@ -547,6 +604,10 @@ func buildDetailOtherMetrics(snap core.UsageSnapshot, widget core.DashboardWidge
 	for k := range toolKeys {
 		skipKeys[k] = true
 	}
+	_, toolCostKeys := buildToolCostLines(snap, innerW, true, hideCosts)
+	for k := range toolCostKeys {
+		skipKeys[k] = true
+	}
 
 	keys := core.SortedStringKeys(snap.Metrics)
 	var lines []string
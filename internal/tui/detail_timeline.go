@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// resetTimelineHorizon is how far ahead the reset timeline looks. Resets
+// further out are counted in the legend but not plotted.
+const resetTimelineHorizon = 7 * 24 * time.Hour
+
+type resetTimelineMarker struct {
+	label string
+	at    time.Time
+}
+
+// buildResetTimelineLines plots upcoming resets on a horizontal axis spanning
+// the next seven days, with a tick at each local midnight. Markers are
+// numbered in reset order and explained in the legend underneath. Returns nil
+// when nothing resets inside the horizon.
+func buildResetTimelineLines(resets map[string]time.Time, widget core.DashboardWidget, innerW int, now time.Time) []string {
+	end := now.Add(resetTimelineHorizon)
+	var markers []resetTimelineMarker
+	later := 0
+	for key, at := range resets {
+		switch {
+		case !at.After(now):
+			continue
+		case at.After(end):
+			later++
+			continue
+		}
+		markers = append(markers, resetTimelineMarker{label: metricLabel(widget, key), at: at})
+	}
+	if len(markers) == 0 {
+		return nil
+	}
+	sort.Slice(markers, func(i, j int) bool {
+		if !markers[i].at.Equal(markers[j].at) {
+			return markers[i].at.Before(markers[j].at)
+		}
+		return markers[i].label < markers[j].label
+	})
+
+	axisW := innerW - 2
+	if axisW < 14 {
+		axisW = 14
+	}
+	col := func(t time.Time) int {
+		c := int(float64(t.Sub(now)) / float64(resetTimelineHorizon) * float64(axisW-1))
+		return max(0, min(c, axisW-1))
+	}
+
+	axis := make([]string, axisW)
+	for i := range axis {
+		axis[i] = dimStyle.Render("─")
+	}
+	labels := []rune(strings.Repeat(" ", axisW))
+	putLabel := func(at int, text string) {
+		if at+len(text) > axisW {
+			return
+		}
+		for i := max(0, at-1); i < at+len(text)+1 && i < axisW; i++ {
+			if labels[i] != ' ' {
+				return
+			}
+		}
+		copy(labels[at:], []rune(text))
+	}
+	putLabel(0, "now")
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		c := col(day)
+		axis[c] = dimStyle.Render("┼")
+		putLabel(c, day.Format("Mon"))
+	}
+
+	taken := make(map[int]bool, len(markers))
+	for i, mk := range markers {
+		c := col(mk.at)
+		glyph := markerGlyph(i)
+		if taken[c] {
+			glyph = "*"
+		}
+		taken[c] = true
+		axis[c] = lipgloss.NewStyle().Foreground(resetUrgencyColor(mk.at.Sub(now))).Bold(true).Render(glyph)
+	}
+
+	lines := []string{
+		"  " + strings.Join(axis, ""),
+		"  " + dimStyle.Render(strings.TrimRight(string(labels), " ")),
+	}
+
+	var legend []string
+	for i, mk := range markers {
+		entry := lipgloss.NewStyle().Foreground(resetUrgencyColor(mk.at.Sub(now))).Bold(true).Render(markerGlyph(i)) +
+			" " + labelStyle.Render(mk.label) + " " + tealStyle.Render(formatDuration(mk.at.Sub(now)))
+		legend = append(legend, entry)
+	}
+	if later > 0 {
+		legend = append(legend, dimStyle.Render("+"+strconv.Itoa(later)+" after 7d"))
+	}
+	lines = append(lines, wrapLegendEntries(legend, innerW-2)...)
+	return lines
+}
+
+// markerGlyph numbers markers 1–9, then letters, so every legend entry maps
+// to a single axis cell.
+func markerGlyph(i int) string {
+	if i < 9 {
+		return strconv.Itoa(i + 1)
+	}
+	return string(rune('a' + (i-9)%26))
+}
+
+func resetUrgencyColor(remaining time.Duration) lipgloss.Color {
+	switch {
+	case remaining < 15*time.Minute:
+		return colorCrit
+	case remaining < time.Hour:
+		return colorWarn
+	default:
+		return colorOK
+	}
+}
+
+func wrapLegendEntries(entries []string, w int) []string {
+	const sep = "   "
+	var lines []string
+	line := ""
+	for _, entry := range entries {
+		if line != "" && lipgloss.Width(line)+len(sep)+lipgloss.Width(entry) > w {
+			lines = append(lines, "  "+line)
+			line = ""
+		}
+		if line != "" {
+			line += sep
+		}
+		line += entry
+	}
+	if line != "" {
+		lines = append(lines, "  "+line)
+	}
+	return lines
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestBuildResetTimelineLines(t *testing.T) {
+	now := time.Date(2026, 3, 30, 12, 0, 0, 0, time.UTC) // Monday
+	resets := map[string]time.Time{
+		"usage_five_hour": now.Add(2 * time.Hour),
+		"usage_seven_day": now.Add(80 * time.Hour),
+		"billing_cycle":   now.Add(20 * 24 * time.Hour),
+		"expired":         now.Add(-time.Hour),
+	}
+
+	lines := buildResetTimelineLines(resets, core.DefaultDashboardWidget(), 60, now)
+	if len(lines) < 3 {
+		t.Fatalf("lines = %d, want axis, day labels, and legend", len(lines))
+	}
+	axis := []rune(stripANSI(lines[0]))[2:]
+	if len(axis) != 58 {
+		t.Fatalf("axis width = %d, want 58", len(axis))
+	}
+	first, second := strings.IndexRune(string(axis), '1'), strings.IndexRune(string(axis), '2')
+	if first < 0 || second <= first {
+		t.Fatalf("markers out of order on axis %q", string(axis))
+	}
+	if got := strings.Count(string(axis), "┼"); got != 7 {
+		t.Fatalf("day ticks = %d, want 7 in %q", got, string(axis))
+	}
+	if labels := stripANSI(lines[1]); !strings.Contains(labels, "now") || !strings.Contains(labels, "Tue") {
+		t.Fatalf("day labels = %q", labels)
+	}
+	legend := stripANSI(strings.Join(lines[2:], "\n"))
+	if !strings.Contains(legend, "1 ") || !strings.Contains(legend, "2h0m") || !strings.Contains(legend, "+1 after 7d") {
+		t.Fatalf("legend = %q", legend)
+	}
+}
+
+func TestBuildResetTimelineLines_NothingUpcoming(t *testing.T) {
+	now := time.Now()
+	resets := map[string]time.Time{"billing_cycle": now.Add(30 * 24 * time.Hour)}
+	if lines := buildResetTimelineLines(resets, core.DefaultDashboardWidget(), 60, now); lines != nil {
+		t.Fatalf("expected no timeline, got %q", lines)
+	}
+}
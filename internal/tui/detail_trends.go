@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
 )
 
 // cropSeriesToWindow normalizes chart series to the selected detail window.
@@ -27,11 +28,20 @@ func buildDetailTrendsSection(snap core.UsageSnapshot, widget core.DashboardWidg
 
 // The Cost sparkline and Cost full chart are suppressed when hideCosts is true.
 func buildDetailTrendsSectionWithHide(snap core.UsageSnapshot, widget core.DashboardWidget, innerW int, timeWindow core.TimeWindow, hideCosts bool) []string {
+	return buildDetailTrendsSectionWithRange(snap, widget, innerW, timeWindow, 0, hideCosts, time.Now())
+}
+
+// buildDetailTrendsSectionWithRange spans the charts over chartDays instead
+// of the time window when it is set. Cost and token charts then reach past
+// what the provider returned into persisted history, drawn as a separate
+// "local history" series.
+func buildDetailTrendsSectionWithRange(snap core.UsageSnapshot, widget core.DashboardWidget, innerW int, timeWindow core.TimeWindow, chartDays int, hideCosts bool, now time.Time) []string {
 	var lines []string
 
 	// Daily usage sparkline summary (compact overview).
 	dailyLines := buildProviderDailyTrendLinesWithHide(snap, innerW, hideCosts)
 	lines = append(lines, dailyLines...)
+	chartsStart := len(lines)
 
 	// Render a separate chart for each available series.
 	seriesCandidates := []struct {
@@ -74,17 +84,31 @@ func buildDetailTrendsSectionWithHide(snap core.UsageSnapshot, widget core.Dashb
 			continue
 		}
 
-		// Apply zoom.
-		pts = cropSeriesToWindow(pts, timeWindow)
-		if len(pts) < 2 {
+		label := metricLabel(widget, matchedKey)
+		var local []core.TimePoint
+		if chartDays > 0 {
+			pts, local = rangeSeries(pts, snap.DailySeries[historySeriesKeys[matchedKey]], chartDays, now)
+		} else {
+			// Apply zoom.
+			pts = cropSeriesToWindow(pts, timeWindow)
+		}
+		if len(pts)+len(local) < 2 {
 			continue
 		}
 
 		series := []BrailleSeries{{
-			Label:  metricLabel(widget, matchedKey),
+			Label:  label,
 			Color:  candidate.color,
 			Points: pts,
 		}}
+		if len(local) > 0 {
+			series[0].Label = label + " (provider)"
+			series = append([]BrailleSeries{{
+				Label:  label + " (local history)",
+				Color:  colorOverlay,
+				Points: local,
+			}}, series...)
+		}
 
 		chart := RenderBrailleChart(candidate.label, series, chartW, chartH, candidate.yFmt)
 		if chart != "" {
@@ -92,13 +116,16 @@ func buildDetailTrendsSectionWithHide(snap core.UsageSnapshot, widget core.Dashb
 				lines = append(lines, "")
 			}
 			lines = append(lines, strings.Split(strings.TrimRight(chart, "\n"), "\n")...)
+			if chartDays > 0 && len(local)+len(pts) < chartDays {
+				lines = append(lines, "  "+dimStyle.Render(fmt.Sprintf("%d of %d days available", len(local)+len(pts), chartDays)))
+			}
 		}
 	}
 
 	for _, breakdown := range buildDetailBreakdownTrendCharts(snap, widget) {
 		// Apply zoom to breakdown series.
 		for i := range breakdown.series {
-			breakdown.series[i].Points = cropSeriesToWindow(breakdown.series[i].Points, timeWindow)
+			breakdown.series[i].Points = cropSeriesToChartRange(breakdown.series[i].Points, chartDays, timeWindow, now)
 		}
 		chart := RenderBrailleChart(breakdown.title, breakdown.series, chartW, chartH, breakdown.yFmt)
 		if chart == "" {
@@ -113,6 +140,16 @@ func buildDetailTrendsSectionWithHide(snap core.UsageSnapshot, widget core.Dashb
 		}
 	}
 
+	if len(lines) > chartsStart {
+		// Caption the charts below the blank line that separates them from
+		// the sparklines.
+		at := chartsStart
+		if at > 0 {
+			at++
+		}
+		rangeLine := dimStyle.Render(detailChartRangeLabel(chartDays, timeWindow) + " · d to change")
+		lines = append(lines[:at], append([]string{rangeLine}, lines[at:]...)...)
+	}
 	return lines
 }
 
@@ -124,17 +161,41 @@ type detailTrendBreakdownChart struct {
 	hiddenLabel string
 }
 
+// heatmapHistoryWeeks caps the detail heatmap to the trailing weeks of
+// persisted history the read model attaches.
+const heatmapHistoryWeeks = 12
+
 // buildDetailActivityHeatmap builds a compact GitHub-contribution-graph style heatmap.
 // Each cell is a single "▪" character. Rows = Mon-Sun, columns = weeks.
-func buildDetailActivityHeatmap(snap core.UsageSnapshot, innerW int) []string {
-	candidates := []string{"analytics_requests", "requests", "analytics_cost", "cost"}
+//
+// It prefers the window-independent persisted history (daily cost, then
+// tokens) over the windowed analytics series; cost series are skipped when
+// hideCosts is set.
+func buildDetailActivityHeatmap(snap core.UsageSnapshot, innerW int, hideCosts bool, now time.Time) []string {
+	candidates := []string{"history_cost", "history_tokens", "analytics_requests", "requests", "analytics_cost", "cost"}
 	var pts []core.TimePoint
+	metric := ""
 	for _, key := range candidates {
+		if hideCosts && strings.HasSuffix(key, "cost") {
+			continue
+		}
 		if p, ok := snap.DailySeries[key]; ok && len(p) >= 7 {
 			pts = p
+			metric = key
 			break
 		}
 	}
+	if strings.HasPrefix(metric, "history_") {
+		// History ends today even when the latest days were idle.
+		cutoff := now.AddDate(0, 0, -(heatmapHistoryWeeks*7 - 1)).Format("2006-01-02")
+		trimmed := make([]core.TimePoint, 0, len(pts)+1)
+		for _, p := range pts {
+			if p.Date >= cutoff {
+				trimmed = append(trimmed, p)
+			}
+		}
+		pts = append(trimmed, core.TimePoint{Date: now.Format("2006-01-02")})
+	}
 	if len(pts) < 7 {
 		return nil
 	}
@@ -165,11 +226,13 @@ func buildDetailActivityHeatmap(snap core.UsageSnapshot, innerW int) []string {
 		return nil
 	}
 
-	// Align to week boundaries.
-	for minDate.Weekday() != time.Monday {
+	// Align to week boundaries in the locale's first day of the week.
+	firstDay := locale.Current().FirstWeekday
+	lastDay := (firstDay + 6) % 7
+	for minDate.Weekday() != firstDay {
 		minDate = minDate.AddDate(0, 0, -1)
 	}
-	for maxDate.Weekday() != time.Sunday {
+	for maxDate.Weekday() != lastDay {
 		maxDate = maxDate.AddDate(0, 0, 1)
 	}
 
@@ -185,9 +248,12 @@ func buildDetailActivityHeatmap(snap core.UsageSnapshot, innerW int) []string {
 	if maxWeeks < 4 {
 		maxWeeks = 4
 	}
+	if strings.HasPrefix(metric, "history_") {
+		maxWeeks = min(maxWeeks, heatmapHistoryWeeks)
+	}
 	if numWeeks > maxWeeks {
 		minDate = maxDate.AddDate(0, 0, -(maxWeeks*7 - 1))
-		for minDate.Weekday() != time.Monday {
+		for minDate.Weekday() != firstDay {
 			minDate = minDate.AddDate(0, 0, -1)
 		}
 		numWeeks = maxWeeks
@@ -214,16 +280,15 @@ func buildDetailActivityHeatmap(snap core.UsageSnapshot, innerW int) []string {
 	// Color palette: 5 levels from empty to intense (GitHub-style).
 	palette := []lipgloss.Color{colorSurface0, colorGreen, colorTeal, colorYellow, colorPeach}
 
-	dayLabels := []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
-
 	// Build the heatmap grid as a string block.
 	var gridSB strings.Builder
 	for dow := 0; dow < 7; dow++ {
+		weekday := (firstDay + time.Weekday(dow)) % 7
 		labelColor := colorDim
-		if dow < 5 {
+		if weekday != time.Saturday && weekday != time.Sunday {
 			labelColor = colorSubtext
 		}
-		gridSB.WriteString(lipgloss.NewStyle().Foreground(labelColor).Width(labelW).Render(dayLabels[dow]))
+		gridSB.WriteString(lipgloss.NewStyle().Foreground(labelColor).Width(labelW).Render(weekday.String()[:3]))
 
 		for w := 0; w < numWeeks; w++ {
 			val := grid[dow][w]
@@ -276,7 +341,11 @@ func buildDetailActivityHeatmap(snap core.UsageSnapshot, innerW int) []string {
 	activeDays := 0
 	peakVal := 0.0
 	peakDate := ""
+	firstDate := minDate.Format("2006-01-02")
 	for _, p := range pts {
+		if p.Date < firstDate {
+			continue
+		}
 		v := p.Value
 		if v < 0 {
 			v = 0
@@ -295,15 +364,24 @@ func buildDetailActivityHeatmap(snap core.UsageSnapshot, innerW int) []string {
 		avgPerDay = totalVal / float64(activeDays)
 	}
 
-	statsSB.WriteString(lipgloss.NewStyle().Bold(true).Foreground(colorSubtext).Render("Summary") + "\n\n")
+	formatVal := shortCompact
+	title := "Summary"
+	switch metric {
+	case "history_cost":
+		formatVal = formatUSD
+		title = "Daily cost · 12w"
+	case "history_tokens":
+		title = "Daily tokens · 12w"
+	}
+	statsSB.WriteString(lipgloss.NewStyle().Bold(true).Foreground(colorSubtext).Render(title) + "\n\n")
 	statsSB.WriteString(renderDotLeaderRow("Active days", fmt.Sprintf("%d", activeDays), 28) + "\n")
 	statsSB.WriteString(renderDotLeaderRow("Total days", fmt.Sprintf("%d", numWeeks*7), 28) + "\n")
 	if activeDays > 0 {
 		pct := float64(activeDays) / float64(numWeeks*7) * 100
 		statsSB.WriteString(renderDotLeaderRow("Activity rate", fmt.Sprintf("%.0f%%", pct), 28) + "\n")
 	}
-	statsSB.WriteString(renderDotLeaderRow("Avg/active day", shortCompact(avgPerDay), 28) + "\n")
-	statsSB.WriteString(renderDotLeaderRow("Total", shortCompact(totalVal), 28) + "\n")
+	statsSB.WriteString(renderDotLeaderRow("Avg/active day", formatVal(avgPerDay), 28) + "\n")
+	statsSB.WriteString(renderDotLeaderRow("Total", formatVal(totalVal), 28) + "\n")
 	if peakDate != "" {
 		if t, err := time.Parse("2006-01-02", peakDate); err == nil {
 			statsSB.WriteString(renderDotLeaderRow("Peak", t.Format("Jan 2"), 28) + "\n")
@@ -318,7 +396,7 @@ func buildDetailActivityHeatmap(snap core.UsageSnapshot, innerW int) []string {
 
 // buildDetailDualAxisChart builds an overlay chart showing cost and requests
 // together on a single chart. Uses left Y-axis for cost and colors to distinguish.
-func buildDetailDualAxisChart(snap core.UsageSnapshot, widget core.DashboardWidget, innerW int, timeWindow core.TimeWindow) []string {
+func buildDetailDualAxisChart(snap core.UsageSnapshot, widget core.DashboardWidget, innerW int, timeWindow core.TimeWindow, chartDays int, now time.Time) []string {
 	var costPts, reqPts []core.TimePoint
 	for _, key := range []string{"analytics_cost", "cost"} {
 		if p, ok := snap.DailySeries[key]; ok && len(p) >= 2 {
@@ -337,8 +415,8 @@ func buildDetailDualAxisChart(snap core.UsageSnapshot, widget core.DashboardWidg
 		return nil
 	}
 
-	costPts = cropSeriesToWindow(costPts, timeWindow)
-	reqPts = cropSeriesToWindow(reqPts, timeWindow)
+	costPts = cropSeriesToChartRange(costPts, chartDays, timeWindow, now)
+	reqPts = cropSeriesToChartRange(reqPts, chartDays, timeWindow, now)
 	if len(costPts) < 2 || len(reqPts) < 2 {
 		return nil
 	}
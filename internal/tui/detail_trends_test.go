@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestBuildDetailActivityHeatmap_PrefersPersistedHistory(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	history := func(days int, value float64) []core.TimePoint {
+		var pts []core.TimePoint
+		for i := days - 1; i >= 0; i-- {
+			pts = append(pts, core.TimePoint{Date: now.AddDate(0, 0, -i).Format("2006-01-02"), Value: value})
+		}
+		return pts
+	}
+	snap := core.UsageSnapshot{
+		ProviderID: "claude_code",
+		DailySeries: map[string][]core.TimePoint{
+			// 20 weeks of history; only the trailing 12 are plotted.
+			"history_cost":   history(140, 2),
+			"history_tokens": history(140, 1000),
+			"requests":       history(7, 5),
+		},
+	}
+
+	plain := ansi.Strip(strings.Join(buildDetailActivityHeatmap(snap, 120, false, now), "\n"))
+	if !strings.Contains(plain, "Daily cost · 12w") {
+		t.Fatalf("heatmap should plot history_cost:\n%s", plain)
+	}
+	spansTwelveWeeks := false
+	for _, line := range strings.Split(plain, "\n") {
+		if strings.Contains(line, "Total days") && strings.HasSuffix(strings.TrimSpace(line), " 84") {
+			spansTwelveWeeks = true
+		}
+	}
+	if !spansTwelveWeeks {
+		t.Errorf("heatmap should span 12 weeks (84 days):\n%s", plain)
+	}
+
+	hidden := ansi.Strip(strings.Join(buildDetailActivityHeatmap(snap, 120, true, now), "\n"))
+	if !strings.Contains(hidden, "Daily tokens · 12w") || strings.Contains(hidden, "$") {
+		t.Errorf("hide-costs heatmap should fall back to tokens without dollar amounts:\n%s", hidden)
+	}
+}
+
+func TestRangeSeries_ExtendsProviderSeriesWithHistory(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	day := func(back int) string { return now.AddDate(0, 0, -back).Format("2006-01-02") }
+	reported := []core.TimePoint{{Date: day(6), Value: 1}, {Date: day(3), Value: 2}, {Date: day(0), Value: 3}}
+	history := []core.TimePoint{{Date: day(40), Value: 9}, {Date: day(20), Value: 4}, {Date: day(6), Value: 5}}
+
+	provider, local := rangeSeries(reported, history, 30, now)
+	if len(provider) != 7 || provider[0].Date != day(6) || provider[6].Value != 3 {
+		t.Fatalf("provider = %+v, want the 7 reported days", provider)
+	}
+	if len(local) != 14 || local[0].Date != day(20) || local[0].Value != 4 || local[13].Date != day(7) {
+		t.Fatalf("local = %+v, want history from 20 days back to the day before the provider's first", local)
+	}
+
+	provider, local = rangeSeries(reported, nil, 90, now)
+	if len(provider) != 7 || local != nil {
+		t.Fatalf("without history: provider=%d local=%v, want 7 provider days and no padding", len(provider), local)
+	}
+}
+
+func TestBuildDetailTrendsSectionWithRange(t *testing.T) {
+	now := time.Now().UTC()
+	var reported, history []core.TimePoint
+	for i := 59; i >= 0; i-- {
+		point := core.TimePoint{Date: now.AddDate(0, 0, -i).Format("2006-01-02"), Value: float64(i + 1)}
+		history = append(history, point)
+		if i < 7 {
+			reported = append(reported, point)
+		}
+	}
+	snap := core.UsageSnapshot{
+		ProviderID:  "openai",
+		DailySeries: map[string][]core.TimePoint{"cost": reported, "history_cost": history},
+	}
+	widget := dashboardWidget(snap.ProviderID)
+
+	windowed := ansi.Strip(strings.Join(buildDetailTrendsSectionWithRange(snap, widget, 120, core.TimeWindow7d, 0, false, now), "\n"))
+	if !strings.Contains(windowed, "Range: 7 Days (time window)") || strings.Contains(windowed, "local history") {
+		t.Fatalf("window-following charts:\n%s", windowed)
+	}
+
+	ranged := ansi.Strip(strings.Join(buildDetailTrendsSectionWithRange(snap, widget, 120, core.TimeWindow7d, 90, false, now), "\n"))
+	for _, want := range []string{"Range: 90d", "(local history)", "(provider)", "60 of 90 days available"} {
+		if !strings.Contains(ranged, want) {
+			t.Errorf("90d charts missing %q:\n%s", want, ranged)
+		}
+	}
+}
+
+func TestNextDetailChartRange(t *testing.T) {
+	got := []int{}
+	days := 0
+	for range detailChartRanges {
+		days = nextDetailChartRange(days)
+		got = append(got, days)
+	}
+	if want := []int{7, 14, 30, 90, 0}; !slices.Equal(got, want) {
+		t.Fatalf("cycle = %v, want %v", got, want)
+	}
+}
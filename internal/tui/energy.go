@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
+)
+
+// maxEnergyModelRows caps the per-model rows under the energy total.
+const maxEnergyModelRows = 6
+
+var (
+	energyConfigMu sync.RWMutex
+	energyConfig   core.EnergyConfig
+)
+
+// setEnergyConfig installs the estimator behind the detail view's Energy
+// section. A disabled config hides the section.
+func setEnergyConfig(cfg core.EnergyConfig) {
+	energyConfigMu.Lock()
+	defer energyConfigMu.Unlock()
+	energyConfig = cfg
+}
+
+func currentEnergyConfig() core.EnergyConfig {
+	energyConfigMu.RLock()
+	defer energyConfigMu.RUnlock()
+	return energyConfig
+}
+
+// buildDetailEnergySection converts the snapshot's per-model token counts
+// into approximate energy and emissions. Empty unless the estimator is
+// enabled and the provider reports tokens per model.
+func buildDetailEnergySection(snap core.UsageSnapshot, innerW int) []string {
+	cfg := currentEnergyConfig()
+	if !cfg.Enabled {
+		return nil
+	}
+
+	type modelEnergy struct {
+		name string
+		est  core.EnergyEstimate
+	}
+	var (
+		rows  []modelEnergy
+		total core.EnergyEstimate
+	)
+	for _, model := range core.ExtractAnalyticsModelUsage(snap) {
+		est := cfg.Estimate(model.Name, model.InputTokens, model.OutputTokens)
+		if est.Wh <= 0 {
+			continue
+		}
+		rows = append(rows, modelEnergy{name: model.Name, est: est})
+		total = total.Add(est)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].est.Wh > rows[j].est.Wh })
+
+	loc := locale.Current()
+	lines := []string{
+		renderDotLeaderRow("Total", lipgloss.NewStyle().Foreground(colorGreen).Bold(true).Render(
+			"≈ "+loc.Energy(total.Wh)+" · "+loc.CO2(total.CO2Grams)+" CO2e"), innerW),
+	}
+	for i, row := range rows {
+		if i == maxEnergyModelRows {
+			lines = append(lines, "  "+dimStyle.Render("+"+loc.Int(int64(len(rows)-maxEnergyModelRows))+" more models"))
+			break
+		}
+		lines = append(lines, renderDotLeaderRow(prettifyModelName(row.name),
+			loc.Energy(row.est.Wh)+" · "+loc.CO2(row.est.CO2Grams), innerW))
+	}
+	lines = append(lines, "  "+dimStyle.Render("Rough estimate at "+loc.Float(cfg.Grid(), 0)+" gCO2e/kWh"))
+	return lines
+}
@@ -0,0 +1,33 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestBuildDetailEnergySection(t *testing.T) {
+	snap := core.NewUsageSnapshot("claude_code", "claude_code")
+	snap.ModelUsage = []core.ModelUsageRecord{
+		{RawModelID: "claude-opus-4", InputTokens: core.Float64Ptr(100_000), OutputTokens: core.Float64Ptr(50_000)},
+		{RawModelID: "claude-haiku-4", InputTokens: core.Float64Ptr(10_000), OutputTokens: core.Float64Ptr(1_000)},
+	}
+
+	t.Cleanup(func() { setEnergyConfig(core.EnergyConfig{}) })
+	if lines := buildDetailEnergySection(snap, 80); lines != nil {
+		t.Fatalf("disabled estimator rendered %q", lines)
+	}
+
+	setEnergyConfig(core.EnergyConfig{Enabled: true})
+	out := stripANSI(strings.Join(buildDetailEnergySection(snap, 80), "\n"))
+	// opus: 100k×0.2 + 50k×2 = 120 Wh; haiku: 10k×0.015 + 1k×0.15 = 0.3 Wh.
+	for _, want := range []string{"≈ 120.3 Wh · 48.1 g CO2e", "120 Wh · 48 g", "400 gCO2e/kWh"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("energy section missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "Opus") > strings.Index(out, "Haiku") {
+		t.Errorf("models not ordered by energy:\n%s", out)
+	}
+}
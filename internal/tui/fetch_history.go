@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// uptimeStrip renders the account's recent fetch outcomes, oldest first, as
+// ▪ for a success and ▫ for a failure. Empty until the daemon has recorded
+// a fetch.
+func uptimeStrip(snap core.UsageSnapshot) string {
+	history := snap.FetchHistory()
+	if len(history) == 0 {
+		return ""
+	}
+	ok := lipgloss.NewStyle().Foreground(colorGreen)
+	failed := lipgloss.NewStyle().Foreground(colorRed)
+	var b strings.Builder
+	for _, success := range history {
+		if success {
+			b.WriteString(ok.Render("▪"))
+		} else {
+			b.WriteString(failed.Render("▫"))
+		}
+	}
+	return b.String()
+}
+
+// tileFooterLine puts the uptime strip at the right edge of the tile's
+// "updated" line when there is room for both.
+func tileFooterLine(snap core.UsageSnapshot, timeStr string, innerW int) string {
+	left := tileTimestampStyle.Render(timeStr)
+	strip := uptimeStrip(snap)
+	if strip == "" {
+		return left
+	}
+	gap := innerW - lipgloss.Width(left) - lipgloss.Width(strip)
+	if gap < 2 {
+		return left
+	}
+	return left + strings.Repeat(" ", gap) + strip
+}
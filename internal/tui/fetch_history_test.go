@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestTileFooterLine_UptimeStrip(t *testing.T) {
+	var snap core.UsageSnapshot
+	if got := tileFooterLine(snap, "updated 1m ago", 40); ansi.Strip(got) != "updated 1m ago" {
+		t.Fatalf("footer without history = %q", ansi.Strip(got))
+	}
+
+	snap.SetFetchHistory(core.FetchHistory{true, true, false, true})
+	got := ansi.Strip(tileFooterLine(snap, "updated 1m ago", 40))
+	if !strings.HasSuffix(got, "▪▪▫▪") || !strings.HasPrefix(got, "updated 1m ago") {
+		t.Fatalf("footer = %q", got)
+	}
+
+	if got := ansi.Strip(tileFooterLine(snap, "updated 1m ago", 16)); got != "updated 1m ago" {
+		t.Fatalf("narrow footer = %q, want strip dropped", got)
+	}
+}
+
+func TestNextPollText(t *testing.T) {
+	now := time.Date(2026, time.May, 14, 12, 0, 0, 0, time.UTC)
+	var snap core.UsageSnapshot
+	if got := nextPollText(snap, now); got != "" {
+		t.Fatalf("without schedule = %q", got)
+	}
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{now.Add(-time.Second), "next poll due"},
+		{now.Add(20 * time.Second), "next in <1m"},
+		{now.Add(4*time.Minute + 30*time.Second), "next in 4m"},
+		{now.Add(90 * time.Minute), "next in 1h 30m"},
+	}
+	for _, tt := range tests {
+		snap.SetNextPollAt(tt.at)
+		if got := nextPollText(snap, now); got != tt.want {
+			t.Errorf("next poll at %s = %q, want %q", tt.at.Sub(now), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package tui
+
+import "github.com/janekbaraniewski/openusage/internal/core"
+
+// retryingFetch reports whether the daemon is re-fetching the account after
+// its first failed fetch. Until the retry lands the tile shows a dim
+// "retrying…" badge instead of an error.
+func (m Model) retryingFetch(snap core.UsageSnapshot) bool {
+	return snap.RetryPending(m.viewNow())
+}
+
+// retryingTileView is snap as the tile draws it: an error awaiting its retry
+// renders like a tile still loading.
+func (m Model) retryingTileView(snap core.UsageSnapshot) core.UsageSnapshot {
+	if snap.Status != core.StatusError || !m.retryingFetch(snap) {
+		return snap
+	}
+	snap.Status = core.StatusUnknown
+	snap.Message = "Retrying…"
+	return snap
+}
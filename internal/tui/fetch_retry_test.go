@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestTileStatus_FirstFailureShowsRetrying(t *testing.T) {
+	accounts := []core.AccountConfig{{ID: "openai", Provider: "openai"}}
+	m := NewModel(0.2, 0.1, false, config.DashboardConfig{}, accounts, core.TimeWindow7d)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.referenceTime = now
+
+	snap := core.UsageSnapshot{ProviderID: "openai", AccountID: "openai", Status: core.StatusError, Message: "HTTP 502"}
+	snap.SetRetryAt(now.Add(10 * time.Second))
+
+	if got := m.tileStatus(snap); got != core.StatusUnknown {
+		t.Errorf("status while retrying = %s, want UNKNOWN", got)
+	}
+	if badge := ansi.Strip(m.tileBadge(snap, m.tileStatus(snap))); !strings.Contains(badge, "retrying") {
+		t.Errorf("badge while retrying = %q", badge)
+	}
+	if tile := ansi.Strip(m.buildTile(snap, false, false, 40, 0, 0, "")); strings.Contains(tile, "HTTP 502") || !strings.Contains(tile, "Retrying") {
+		t.Errorf("tile while retrying shows the error:\n%s", tile)
+	}
+
+	// A retry that never reported back stops hiding the error.
+	m.referenceTime = now.Add(time.Minute)
+	if got := m.tileStatus(snap); got != core.StatusError {
+		t.Errorf("status after the retry window = %s, want ERROR", got)
+	}
+	if badge := ansi.Strip(m.tileBadge(snap, m.tileStatus(snap))); badge != "ERR" {
+		t.Errorf("badge after the retry window = %q, want ERR", badge)
+	}
+}
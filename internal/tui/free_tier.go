@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// resolveFreeTier reports whether the snapshot is shown in free-tier tracking
+// mode, honouring the per-account override before the provider's tier signal.
+func (m Model) resolveFreeTier(snap core.UsageSnapshot) bool {
+	var perAccount *bool
+	if m.freeTierByAccount != nil {
+		perAccount = m.freeTierByAccount[snap.AccountID]
+	}
+	return core.ResolveFreeTier(snap, perAccount)
+}
+
+// cycleFreeTierOverride advances the per-account free_tier override through
+// auto (nil) → on (true) → off (false) → auto. Returns the new override.
+func (m *Model) cycleFreeTierOverride(accountID string) *bool {
+	if m.freeTierByAccount == nil {
+		m.freeTierByAccount = make(map[string]*bool)
+	}
+	current := m.freeTierByAccount[accountID]
+	var next *bool
+	switch {
+	case current == nil:
+		t := true
+		next = &t
+	case *current:
+		f := false
+		next = &f
+	}
+	if next == nil {
+		delete(m.freeTierByAccount, accountID)
+	} else {
+		m.freeTierByAccount[accountID] = next
+	}
+	return next
+}
+
+// toggleFreeTierOverride cycles the focused account's free_tier override.
+// Returns handled=false when no tile is focused.
+func (m Model) toggleFreeTierOverride() (Model, tea.Cmd, bool) {
+	accountID := m.selectedTileID(m.filteredIDs())
+	if accountID == "" {
+		return m, nil, false
+	}
+	next := m.cycleFreeTierOverride(accountID)
+	m.invalidateRenderCaches()
+	return m, m.persistDashboardProviderFreeTierCmd(accountID, next), true
+}
+
+// freeTierTileLine renders the "requests left today" header line for a tile
+// in free-tier mode, e.g. "🆓 37/50 req left today ▕███░░▏ resets 4h 12m".
+// Returns "" when the account isn't in free-tier mode or reports no daily
+// allowance.
+func (m Model) freeTierTileLine(snap core.UsageSnapshot, innerW int) string {
+	if !m.resolveFreeTier(snap) {
+		return ""
+	}
+	c, ok := core.FreeDailyCapacity(snap)
+	if !ok {
+		return ""
+	}
+	pct := c.RemainingPercent()
+	color := usageGaugeColor(100-pct, m.warnThreshold, m.critThreshold)
+
+	label := fmt.Sprintf("%s/%s req left today", formatNumber(c.Remaining), formatNumber(c.Limit))
+	if c.Unit == "%" {
+		label = fmt.Sprintf("%.0f%% left today", pct)
+	}
+	left := lipgloss.NewStyle().Foreground(color).Bold(true).Render("🆓 " + label)
+
+	var reset string
+	if !c.ResetsAt.IsZero() {
+		reset = dimStyle.Render("resets " + formatDurationShort(c.ResetsAt.Sub(m.viewNow())))
+	}
+
+	gaugeW := innerW - lipgloss.Width(left) - lipgloss.Width(reset) - 4
+	if gaugeW > 16 {
+		gaugeW = 16
+	}
+	line := left
+	if gaugeW >= 4 {
+		line += " " + renderGaugeBar(pct, gaugeW, color)
+	}
+	if reset != "" {
+		if gap := innerW - lipgloss.Width(line) - lipgloss.Width(reset); gap >= 1 {
+			line += strings.Repeat(" ", gap) + reset
+		}
+	}
+	return line
+}
+
+// freeCapacityFooter summarises what is left of today's free allowance
+// across visible free-tier accounts, roomiest first, e.g.
+// "free today: groq 900/1000 · openrouter 37/50". Returns "" when no account
+// is in free-tier mode.
+func (m Model) freeCapacityFooter(w int) string {
+	caps := core.SummarizeFreeCapacity(m.visibleSnapshots(), m.resolveFreeTier)
+	if len(caps) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(caps))
+	for _, c := range caps {
+		amount := fmt.Sprintf("%s/%s", formatNumber(c.Remaining), formatNumber(c.Limit))
+		if c.Unit == "%" {
+			amount = fmt.Sprintf("%.0f%%", c.RemainingPercent())
+		}
+		parts = append(parts, c.AccountID+" "+amount)
+	}
+	msg := "free today: " + strings.Join(parts, " · ")
+	if w > 2 {
+		msg = truncateToWidth(msg, w-2)
+	}
+	return msg
+}
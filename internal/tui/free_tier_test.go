@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestToggleFreeTierOverride(t *testing.T) {
+	accounts := []core.AccountConfig{{ID: "groq", Provider: "groq"}}
+	m := NewModel(0.2, 0.1, false, config.DashboardConfig{}, accounts, core.TimeWindow7d)
+	m.services = &fakeServices{}
+	now := time.Date(2026, 3, 1, 19, 48, 0, 0, time.UTC)
+	m.referenceTime = now
+	snap := core.UsageSnapshot{
+		ProviderID: "groq",
+		AccountID:  "groq",
+		Metrics: map[string]core.Metric{
+			"rpd": {Limit: core.Float64Ptr(1000), Remaining: core.Float64Ptr(900), Unit: "requests", Window: "1d"},
+		},
+		Resets: map[string]time.Time{"rpd_reset": now.Add(4*time.Hour + 12*time.Minute)},
+	}
+	m.snapshots = map[string]core.UsageSnapshot{"groq": snap}
+	m.sortedIDs = []string{"groq"}
+
+	if line := m.freeTierTileLine(snap, 60); line != "" {
+		t.Fatalf("groq has no tier signal, want no free-tier line, got %q", line)
+	}
+
+	updated, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("toggle should persist via a command")
+	}
+	if got := m.freeTierByAccount["groq"]; got == nil || !*got {
+		t.Fatalf("override after one press = %v, want on", got)
+	}
+
+	line := ansi.Strip(m.freeTierTileLine(snap, 60))
+	for _, want := range []string{"900/1000 req left today", "resets 4h 12m"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("tile line %q missing %q", line, want)
+		}
+	}
+	if footer := ansi.Strip(m.renderFooterStatusLine(120)); !strings.Contains(footer, "free today: groq 900/1000") {
+		t.Errorf("footer = %q, want free capacity summary", footer)
+	}
+
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	m = updated.(Model)
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	m = updated.(Model)
+	if _, ok := m.freeTierByAccount["groq"]; ok {
+		t.Errorf("third press should return to auto, got %v", *m.freeTierByAccount["groq"])
+	}
+}
@@ -89,6 +89,17 @@ func RenderUsageGauge(usedPercent float64, width int, warnThresh, critThresh flo
 	return renderGaugeWithLabel(usedPercent, width, color)
 }
 
+// RenderSuspectGauge stands in for a usage gauge whose value failed snapshot
+// validation (see core.ValidateSnapshot): an empty track marked "suspect"
+// instead of an absurd percentage.
+func RenderSuspectGauge(width int) string {
+	if width < 5 {
+		width = 5
+	}
+	track := lipgloss.NewStyle().Foreground(colorSurface1).Render(strings.Repeat("░", width))
+	return track + lipgloss.NewStyle().Foreground(colorYellow).Bold(true).Render(" suspect")
+}
+
 // RenderUsageGaugeWithProjection renders a usage gauge with an optional dim
 // annotation line below it showing time-until-reset and/or projected time to
 // 100% based on the supplied pace.
@@ -7,7 +7,9 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-func (m Model) renderHelpOverlay(screenW, screenH int) string {
+// helpContentLines builds the "?" overlay: every shortcut first, then a
+// legend for the status bar, themes, billing tags, badges and gauges.
+func (m Model) helpContentLines() []string {
 	headingStyle := lipgloss.NewStyle().Bold(true).Foreground(colorBlue)
 	keyStyle := lipgloss.NewStyle().Bold(true).Foreground(colorSapphire)
 	descStyle := lipgloss.NewStyle().Foreground(colorSubtext)
@@ -27,6 +29,89 @@ func (m Model) renderHelpOverlay(screenW, screenH int) string {
 	lines = append(lines, subtitle)
 	lines = append(lines, "")
 
+	lines = append(lines, headingStyle.Render("  Keybindings"))
+	lines = append(lines, "")
+
+	type keyGroup struct {
+		title string
+		keys  []struct{ key, desc string }
+	}
+
+	navKeys := []struct{ key, desc string }{
+		{"↑↓ / j k", "Move cursor"},
+		{"← → / h l", "Navigate tiles/panels"},
+		{"⏎ Enter", "Open detail"},
+		{"Esc", "Back"},
+		{"Home/End g/G", "Jump to first/last"},
+	}
+	navKeys = append(navKeys, struct{ key, desc string }{"Tab / Shift+Tab", "Switch screen"})
+	navKeys = append(navKeys, struct{ key, desc string }{"m", "Models: spend per model across accounts"})
+
+	actionKeys := []struct{ key, desc string }{
+		{", / Shift+S", "Open settings modal"},
+		{"/", "Filter providers"},
+		{"v / Shift+V", "Cycle dashboard view"},
+		{"o", "Cycle tile order: configured, severity, spend today, % of limit, last update"},
+		{"p", "Pin detail beside tiles (Tab moves focus)"},
+		{"a", "Quick actions for the focused tile"},
+		{"y / Shift+Y", "Copy focused tile or detail as text / Markdown"},
+		{"Mouse wheel", "Scroll panels/details/widgets"},
+		{"PgUp/PgDn", "Scroll panel or selected widget"},
+		{"Ctrl+U / Ctrl+D", "Fast tile scroll"},
+		{"Ctrl+O", "Expand/collapse usage breakdowns"},
+		{"[ ]", "Switch detail tabs"},
+		{"i", "Provider tiers and limit reference (detail view)"},
+		{"d", "Cycle daily chart range: window, 7d, 14d, 30d, 90d (detail view)"},
+		{fmt.Sprintf("1-%d / ←→", settingsTabCount), "Switch settings tabs"},
+		{"Space / Enter", "Apply setting in modal"},
+		{"Shift+J/K", "Reorder providers (order tab)"},
+	}
+	if m.experimentalAnalytics {
+		actionKeys = append(actionKeys,
+			struct{ key, desc string }{"s", "Cycle sort (analytics)"},
+			struct{ key, desc string }{"1-4", "Jump to analytics tab"},
+			struct{ key, desc string }{"Enter/Space", "Expand model (Models tab)"},
+		)
+	}
+	actionKeys = append(actionKeys,
+		struct{ key, desc string }{"r", "Refresh focused account now"},
+		struct{ key, desc string }{"R", "Refresh all accounts now"},
+		struct{ key, desc string }{"L", "Show the log (OPENUSAGE_DEBUG=1)"},
+		struct{ key, desc string }{"P", "Privacy mode: mask emails, names, and $ amounts"},
+		struct{ key, desc string }{"t", "Cycle theme"},
+		struct{ key, desc string }{"w", "Cycle time window"},
+		struct{ key, desc string }{"c", "toggle hide-costs for focused account (auto/hide/show)"},
+		struct{ key, desc string }{"z", "snooze or unsnooze the focused account's LIMIT/WARN warning"},
+		struct{ key, desc string }{"F", "toggle free-tier tracking for focused account (auto/on/off)"},
+	)
+
+	groups := []keyGroup{
+		{title: "Navigation", keys: navKeys},
+		{title: "Actions", keys: actionKeys},
+		{
+			title: "Global",
+			keys: []struct{ key, desc string }{
+				{"?", "Toggle help"},
+				{"q", "Quit"},
+			},
+		},
+	}
+
+	for _, g := range groups {
+		lines = append(lines, "    "+lipgloss.NewStyle().Foreground(colorTeal).Bold(true).Render(g.title))
+		for _, k := range g.keys {
+			kStr := keyStyle.Render(padRight(k.key, 14))
+			lines = append(lines, "      "+kStr+descStyle.Render(k.desc))
+		}
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, headingStyle.Render("  Status Bar"))
+	lines = append(lines, "")
+	lines = append(lines, "    "+tagDescStyle.Render("Left: key hints for the current view, or the search prompt while filtering."))
+	lines = append(lines, "    "+tagDescStyle.Render("Right: active filter · sort order (analytics) · ⟳ time to next refresh · ? help"))
+	lines = append(lines, "")
+
 	lines = append(lines, headingStyle.Render("  Themes")+"  "+
 		dimHintStyle.Render("press t to cycle"))
 	lines = append(lines, "")
@@ -106,72 +191,23 @@ func (m Model) renderHelpOverlay(screenW, screenH int) string {
 	lines = append(lines, "    "+RenderGauge(8, 16, 0.30, 0.15)+"  "+tagDescStyle.Render("critical"))
 	lines = append(lines, "")
 
-	lines = append(lines, headingStyle.Render("  Keybindings"))
-	lines = append(lines, "")
-
-	type keyGroup struct {
-		title string
-		keys  []struct{ key, desc string }
-	}
-
-	navKeys := []struct{ key, desc string }{
-		{"↑↓ / j k", "Move cursor"},
-		{"← → / h l", "Navigate tiles/panels"},
-		{"⏎ Enter", "Open detail"},
-		{"Esc", "Back"},
-	}
-	navKeys = append(navKeys, struct{ key, desc string }{"Tab / Shift+Tab", "Switch screen"})
-
-	actionKeys := []struct{ key, desc string }{
-		{", / Shift+S", "Open settings modal"},
-		{"/", "Filter providers"},
-		{"v / Shift+V", "Cycle dashboard view"},
-		{"Mouse wheel", "Scroll panels/details/widgets"},
-		{"PgUp/PgDn", "Scroll panel or selected widget"},
-		{"Ctrl+U / Ctrl+D", "Fast tile scroll"},
-		{"Ctrl+O", "Expand/collapse usage breakdowns"},
-		{"[ ]", "Switch detail tabs"},
-		{fmt.Sprintf("1-%d / ←→", settingsTabCount), "Switch settings tabs"},
-		{"Space / Enter", "Apply setting in modal"},
-		{"Shift+J/K", "Reorder providers (order tab)"},
-	}
-	if m.experimentalAnalytics {
-		actionKeys = append(actionKeys,
-			struct{ key, desc string }{"s", "Cycle sort (analytics)"},
-			struct{ key, desc string }{"1-4", "Jump to analytics tab"},
-			struct{ key, desc string }{"Enter/Space", "Expand model (Models tab)"},
-		)
-	}
-	actionKeys = append(actionKeys,
-		struct{ key, desc string }{"r", "Refresh"},
-		struct{ key, desc string }{"t", "Cycle theme"},
-		struct{ key, desc string }{"w", "Cycle time window"},
-		struct{ key, desc string }{"c", "toggle hide-costs for focused account (auto/hide/show)"},
-	)
+	return lines
+}
 
-	groups := []keyGroup{
-		{title: "Navigation", keys: navKeys},
-		{title: "Actions", keys: actionKeys},
-		{
-			title: "Global",
-			keys: []struct{ key, desc string }{
-				{"?", "Toggle help"},
-				{"q", "Quit"},
-			},
-		},
-	}
+func (m Model) renderHelpOverlay(screenW, screenH int) string {
+	dimHintStyle := lipgloss.NewStyle().Foreground(colorDim).Italic(true)
 
-	for _, g := range groups {
-		lines = append(lines, "    "+lipgloss.NewStyle().Foreground(colorTeal).Bold(true).Render(g.title))
-		for _, k := range g.keys {
-			kStr := keyStyle.Render(padRight(k.key, 14))
-			lines = append(lines, "      "+kStr+descStyle.Render(k.desc))
-		}
-		lines = append(lines, "")
+	lines := m.helpContentLines()
+	total := len(lines)
+	avail := helpVisibleLines(screenH)
+	offset := clamp(m.helpOffset, 0, max(0, total-avail))
+	if total > avail {
+		lines = lines[offset : offset+avail]
+		lines = append(lines, "  "+dimHintStyle.Render(fmt.Sprintf("j/k scroll (%d-%d of %d) · any other key to dismiss", offset+1, offset+avail, total)))
+	} else {
+		lines = append(lines, "  "+dimHintStyle.Render("Press any key to dismiss"))
 	}
 
-	lines = append(lines, "  "+dimHintStyle.Render("Press any key to dismiss"))
-
 	content := strings.Join(lines, "\n")
 
 	contentW := 0
@@ -433,6 +469,19 @@ func (m Model) brandedLoaderLines(maxWidth int, message, fallback string) []stri
 	return lines
 }
 
+// helpVisibleLines is how many help lines fit between the overlay's border,
+// padding, scroll hint and credit line.
+func helpVisibleLines(screenH int) int {
+	return max(3, screenH-2-2-1-2)
+}
+
+// scrollHelp moves the help overlay by delta lines, clamped to its content.
+func (m Model) scrollHelp(delta int) Model {
+	maxOffset := max(0, len(m.helpContentLines())-helpVisibleLines(m.height))
+	m.helpOffset = clamp(m.helpOffset+delta, 0, maxOffset)
+	return m
+}
+
 func padRight(s string, width int) string {
 	vw := lipgloss.Width(s)
 	if vw >= width {
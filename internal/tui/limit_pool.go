@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// limitPoolTileLine says how the account takes part in a shared limit pool.
+// The owner's tile, which keeps the shared gauges, splits the pool's
+// consumption across its accounts; the other members point at the owner.
+// Empty when the account is not pooled.
+func limitPoolTileLine(snap core.UsageSnapshot, maxW int) string {
+	pool, ok := snap.LimitPool()
+	if !ok {
+		return ""
+	}
+	var text string
+	if pool.IsOwner(snap.AccountID) {
+		text = "⇄ Shared · " + pool.Name
+		if shares := limitPoolShareText(pool.Shares); shares != "" {
+			text += ": " + shares
+		}
+	} else {
+		text = fmt.Sprintf("⇄ %s shared in %s · see %s", strings.Join(pool.Metrics, ", "), pool.Name, pool.Owner)
+	}
+	return lipgloss.NewStyle().Foreground(colorTeal).Render(truncateToWidth(text, maxW))
+}
+
+// limitPoolShareText renders each account's percentage of the pool's
+// attributed consumption, largest first.
+func limitPoolShareText(shares []core.LimitPoolShare) string {
+	total := 0.0
+	for _, share := range shares {
+		total += share.Used
+	}
+	if total <= 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(shares))
+	for _, share := range shares {
+		parts = append(parts, fmt.Sprintf("%s %.0f%%", share.AccountID, share.Used/total*100))
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestLimitPoolTileLine(t *testing.T) {
+	limit, remaining := 500.0, 400.0
+	workUsed, personalUsed := 25.0, 75.0
+	snaps := map[string]core.UsageSnapshot{
+		"work": {AccountID: "work", Metrics: map[string]core.Metric{
+			"rpm":             {Limit: &limit, Remaining: &remaining},
+			"window_requests": {Used: &workUsed},
+		}},
+		"personal": {AccountID: "personal", Metrics: map[string]core.Metric{
+			"rpm":             {Limit: &limit, Remaining: &remaining},
+			"window_requests": {Used: &personalUsed},
+		}},
+	}
+	if line := limitPoolTileLine(snaps["work"], 80); line != "" {
+		t.Fatalf("line before pooling = %q, want empty", line)
+	}
+
+	core.ApplyLimitPools(snaps, []core.LimitPoolConfig{{ID: "acme", Name: "Acme org", Accounts: []string{"work", "personal"}}})
+
+	if got := ansi.Strip(limitPoolTileLine(snaps["work"], 80)); got != "⇄ Shared · Acme org: personal 75% · work 25%" {
+		t.Fatalf("owner line = %q", got)
+	}
+	if got := ansi.Strip(limitPoolTileLine(snaps["personal"], 80)); !strings.Contains(got, "rpm shared in Acme org · see work") {
+		t.Fatalf("member line = %q", got)
+	}
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// liveSessionTileLine is the tile's session ticker while an agent run is in
+// progress, e.g. "● LIVE $4.12 · 1.3M tok · 18m · $0.23/min": what the
+// session has cost so far, how long it has been running, and its average
+// burn. The daemon refreshes every few seconds meanwhile, so the figures
+// climb as the agent works. Empty when no session is running.
+func (m Model) liveSessionTileLine(snap core.UsageSnapshot, innerW int) string {
+	if !core.HasRunningSession(snap) {
+		return ""
+	}
+	started := parseLiveSessionTime(snap.Raw["live_session_started"])
+	lastActive := parseLiveSessionTime(snap.Raw["live_session_last_active"])
+	hideCosts := m.resolveHideCosts(snap)
+
+	var parts []string
+	cost, hasCost := liveSessionMetric(snap, "live_session_cost_usd")
+	if hasCost && !hideCosts {
+		parts = append(parts, lipgloss.NewStyle().Foreground(colorTeal).Bold(true).Render(formatMoney(cost, 2)))
+	}
+	if tokens, ok := liveSessionMetric(snap, "live_session_tokens"); ok && tokens > 0 {
+		parts = append(parts, formatNumber(tokens)+" tok")
+	}
+	if !started.IsZero() {
+		parts = append(parts, formatDurationShort(m.viewNow().Sub(started)))
+	}
+	if span := lastActive.Sub(started); hasCost && cost > 0 && !hideCosts && !started.IsZero() && span >= time.Minute {
+		parts = append(parts, formatMoney(cost/span.Minutes(), 2)+"/min")
+	}
+
+	live := lipgloss.NewStyle().Foreground(colorRed).Bold(true)
+	line := live.Render(PulseChar("●", "○", m.animFrame) + " LIVE")
+	sep := dimStyle.Render(" · ")
+	// Drop the least important figures first rather than cut one in half.
+	for len(parts) > 0 {
+		candidate := line + " " + strings.Join(parts, sep)
+		if lipgloss.Width(candidate) <= innerW {
+			return candidate
+		}
+		parts = parts[:len(parts)-1]
+	}
+	return line
+}
+
+func liveSessionMetric(snap core.UsageSnapshot, key string) (float64, bool) {
+	met, ok := snap.Metrics[key]
+	if !ok || met.Used == nil {
+		return 0, false
+	}
+	return *met.Used, true
+}
+
+func parseLiveSessionTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
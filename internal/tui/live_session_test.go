@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestLiveSessionTileLine(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	m := NewModel(0.2, 0.05, false, config.DashboardConfig{}, nil, core.TimeWindow7d)
+	m.referenceTime = now
+	snap := core.UsageSnapshot{
+		ProviderID: "codex",
+		AccountID:  "codex",
+		Metrics: map[string]core.Metric{
+			"live_session_cost_usd": {Used: core.Float64Ptr(4.5), Unit: "USD", Window: "session"},
+			"live_session_tokens":   {Used: core.Float64Ptr(1_340_000), Unit: "tokens", Window: "session"},
+		},
+		Raw: map[string]string{
+			"live_session_status":      core.LiveSessionRunning,
+			"live_session_started":     now.Add(-20 * time.Minute).Format(time.RFC3339),
+			"live_session_last_active": now.Add(-time.Minute).Format(time.RFC3339),
+		},
+	}
+
+	if got, want := ansi.Strip(m.liveSessionTileLine(snap, 80)), "● LIVE $4.50 · 1.3M tok · 20m · $0.24/min"; got != want {
+		t.Errorf("ticker = %q, want %q", got, want)
+	}
+	if got, want := ansi.Strip(m.liveSessionTileLine(snap, 24)), "● LIVE $4.50 · 1.3M tok"; got != want {
+		t.Errorf("narrow ticker = %q, want %q", got, want)
+	}
+
+	hide := true
+	m.hideCostsGlobal = &hide
+	if got, want := ansi.Strip(m.liveSessionTileLine(snap, 80)), "● LIVE 1.3M tok · 20m"; got != want {
+		t.Errorf("ticker with costs hidden = %q, want %q", got, want)
+	}
+
+	snap.Raw["live_session_status"] = "active"
+	if got := m.liveSessionTileLine(snap, 80); got != "" {
+		t.Errorf("ticker for a session that is no longer running = %q, want none", ansi.Strip(got))
+	}
+}
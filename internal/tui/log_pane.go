@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SetLogSource hands the model the process's buffered log (see
+// core.DebugLog) for the log pane toggled with "L". Without one the pane
+// explains how to turn logging on.
+func (m *Model) SetLogSource(fn func() []string) {
+	m.logSource = fn
+}
+
+func (m Model) logLines() []string {
+	if m.logSource == nil {
+		return nil
+	}
+	return m.logSource()
+}
+
+// logVisibleLines is how many log lines fit between the overlay's border,
+// padding, title and hint.
+func logVisibleLines(screenH int) int {
+	return max(3, screenH-2-2-2-2)
+}
+
+// handleLogPaneKey scrolls the log pane. logOffset counts lines back from the
+// newest, so 0 follows the tail as lines arrive.
+func (m Model) handleLogPaneKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	total := len(m.logLines())
+	maxOffset := max(0, total-logVisibleLines(m.height))
+	page := max(1, logVisibleLines(m.height)-1)
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "L", "q":
+		m.showLogs = false
+	case "up", "k":
+		m.logOffset++
+	case "down", "j":
+		m.logOffset--
+	case "pgup", "ctrl+u":
+		m.logOffset += page
+	case "pgdown", "ctrl+d", " ":
+		m.logOffset -= page
+	case "home", "g":
+		m.logOffset = maxOffset
+	case "end", "G":
+		m.logOffset = 0
+	}
+	m.logOffset = clamp(m.logOffset, 0, maxOffset)
+	return m, nil
+}
+
+func (m Model) renderLogPaneOverlay(screenW, screenH int) string {
+	boxW := max(20, screenW-4)
+	innerW := max(10, boxW-4)
+	avail := logVisibleLines(screenH)
+
+	all := m.visibleLogLines()
+	total := len(all)
+	offset := clamp(m.logOffset, 0, max(0, total-avail))
+	end := total - offset
+	start := max(0, end-avail)
+
+	state := "following"
+	if offset > 0 {
+		state = fmt.Sprintf("%d lines back", offset)
+	}
+	lines := []string{
+		accentBoldStyle.Render("Log") + "  " + dimStyle.Render(fmt.Sprintf("%d lines · %s", total, state)),
+		"",
+	}
+	switch {
+	case m.logSource == nil:
+		lines = append(lines, dimStyle.Render("Logging is off. Start openusage with OPENUSAGE_DEBUG=1 to capture it here."))
+	case total == 0:
+		lines = append(lines, dimStyle.Render("Nothing logged yet."))
+	default:
+		for _, line := range all[start:end] {
+			lines = append(lines, truncateToWidth(line, innerW))
+		}
+	}
+	for len(lines) < avail+2 {
+		lines = append(lines, "")
+	}
+	hint := "j/k scroll · PgUp/PgDn page · g/G oldest/newest · Esc/L close · openusage logs for the daemon"
+	lines = append(lines, "", dimStyle.Render(truncateToWidth(hint, innerW)))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(0, 1).
+		Width(boxW).
+		Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(screenW, screenH, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func logPaneTestModel(t *testing.T, lines int) Model {
+	t.Helper()
+	m := layoutTestModel(t, 120, 30, 2)
+	var log []string
+	for i := 1; i <= lines; i++ {
+		log = append(log, fmt.Sprintf("log line %03d", i))
+	}
+	m.SetLogSource(func() []string { return log })
+	return m
+}
+
+func TestLogPane_ToggleFollowsTail(t *testing.T) {
+	m := logPaneTestModel(t, 100)
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	if !m.showLogs {
+		t.Fatal("L should open the log pane")
+	}
+	view := m.View()
+	if !strings.Contains(view, "log line 100") || strings.Contains(view, "log line 001") {
+		t.Fatal("log pane should open on the newest lines")
+	}
+	if !strings.Contains(view, "following") {
+		t.Fatal("log pane should report that it follows the tail")
+	}
+	if got := m.nextTickInterval(); got == 0 {
+		t.Fatal("open log pane should keep the tick running")
+	}
+
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.showLogs {
+		t.Fatal("esc should close the log pane")
+	}
+}
+
+func TestLogPane_Scroll(t *testing.T) {
+	m := logPaneTestModel(t, 100)
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	if m.logOffset != 1 {
+		t.Fatalf("logOffset = %d after k, want 1", m.logOffset)
+	}
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if !strings.Contains(m.View(), "log line 001") {
+		t.Fatal("g should jump to the oldest line")
+	}
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	if m.logOffset != 0 {
+		t.Fatalf("logOffset = %d after G, want 0", m.logOffset)
+	}
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if m.logOffset != 0 {
+		t.Fatalf("logOffset = %d, should not scroll past the newest line", m.logOffset)
+	}
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if !m.showLogs {
+		t.Fatal("unrelated keys should not close the log pane")
+	}
+}
+
+func TestLogPane_WithoutSourceExplainsDebug(t *testing.T) {
+	m := layoutTestModel(t, 120, 30, 2)
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	if !strings.Contains(m.View(), "OPENUSAGE_DEBUG=1") {
+		t.Fatal("log pane without a source should explain how to enable logging")
+	}
+}
@@ -39,11 +39,13 @@ type screenTab int
 const (
 	screenDashboard screenTab = iota // tiles grid overview
 	screenAnalytics                  // spend analysis dashboard
+	screenModels                     // per-model spend across every account
 )
 
 var screenLabelByTab = map[screenTab]string{
 	screenDashboard: "Dashboard",
 	screenAnalytics: "Analytics",
+	screenModels:    "Models",
 }
 
 type viewMode int
@@ -62,6 +64,17 @@ type SnapshotsMsg struct {
 	Snapshots  map[string]core.UsageSnapshot
 	TimeWindow core.TimeWindow
 	RequestID  uint64
+	// Cached marks snapshots restored from the previous session's on-disk
+	// cache rather than delivered by the daemon.
+	Cached bool
+}
+
+// AccountsRefreshedMsg reports that a forced poll requested through
+// SetOnRefreshAccounts finished. AccountIDs echoes the request; nil means
+// every account.
+type AccountsRefreshedMsg struct {
+	AccountIDs []string
+	Err        error
 }
 
 type DaemonStatus string
@@ -164,10 +177,13 @@ type Services interface {
 	SaveTheme(themeName string) error
 	SaveDashboardProviders(providers []config.DashboardProviderConfig) error
 	SaveDashboardProviderHideCosts(accountID string, hide *bool) error
+	SaveDashboardProviderFreeTier(accountID string, free *bool) error
 	SaveDashboardView(view string) error
 	SaveDashboardWidgetSections(sections []config.DashboardWidgetSection) error
 	SaveDetailWidgetSections(sections []config.DetailWidgetSection) error
 	SaveDashboardHideSectionsWithNoData(hide bool) error
+	SaveDashboardPrivacyMode(on bool) error
+	SaveDashboardSnoozes(snoozes []config.WarningSnooze) error
 	SaveTimeWindow(window string) error
 	SaveProviderLink(source, target string) error
 	DeleteProviderLink(source string) error
@@ -175,6 +191,8 @@ type Services interface {
 	DisconnectBrowserSession(accountID string) error
 	LoadBrowserSessionInfo(accountID string) core.BrowserSessionInfo
 	OpenProviderConsole(url string) error
+	OpenExternal(target string) error
+	CopyToClipboard(text string) error
 	AvailableBrowsers() ([]string, error)
 	ValidateAPIKey(accountID, providerID, apiKey string) (bool, string)
 	SaveCredential(accountID, apiKey string) error
@@ -189,14 +207,24 @@ type Model struct {
 	mode      viewMode
 	filter    filterState
 	showHelp  bool
-	width     int
-	height    int
-
-	detailOffset          int // vertical scroll offset for the detail panel
-	detailTab             int // active tab index in the detail panel (0=All)
-	tileOffset            int // vertical scroll offset for selected dashboard tile row
+	// helpOffset scrolls the help overlay when it's taller than the terminal.
+	helpOffset int
+	width      int
+	height     int
+
+	showLogs  bool
+	logOffset int // lines back from the newest; 0 follows the tail
+	logSource func() []string
+
+	detailOffset          int  // vertical scroll offset for the detail panel
+	detailTab             int  // active tab index in the detail panel (0=All)
+	detailReference       bool // the provider reference pane replaces the detail sections
+	detailChartDays       int  // span of the detail view's daily charts; 0 follows the time window
+	tileOffset            int  // vertical scroll offset for selected dashboard tile row
 	expandedModelMixTiles map[string]bool
-	tileBodyCache         map[string][]string
+	tileBodyCache         map[string]tileBodyCacheEntry
+	tileRenderCache       map[string]tileRenderCacheEntry
+	snapshotHashes        map[string]uint64 // render fingerprint per account, see snapshotRenderHash
 	analyticsCache        analyticsRenderCacheEntry
 	detailCache           detailRenderCacheEntry
 
@@ -206,6 +234,17 @@ type Model struct {
 	screen screenTab
 
 	dashboardView dashboardViewMode
+	dashboardSort dashboardSortMode
+	// pinnedDetail keeps the selected account's detail open beside the
+	// tiles; pinnedFocus routes keys to that pane instead of the tiles.
+	pinnedDetail bool
+	pinnedFocus  bool
+
+	actionMenu actionMenuState
+	spendLimit spendLimitPromptState
+	// actionNotice reports the last quick action's outcome in the status
+	// bar until the next keypress.
+	actionNotice string
 
 	analyticsFilter      filterState
 	analyticsSortBy      int             // 0=cost↓, 1=name↑, 2=tokens↓
@@ -213,21 +252,35 @@ type Model struct {
 	analyticsModelCursor int             // selected model index in the Models tab
 	analyticsModelExpand map[string]bool // expanded models in the Models tab
 	analyticsScrollY     int             // vertical scroll offset for analytics content
+	modelsScrollY        int             // vertical scroll offset for the models screen
 
 	animFrame  int // monotonically increasing frame counter
 	refreshing bool
 	hasData    bool
+	// staleAccounts holds the accounts still showing a cached snapshot from
+	// the previous session; live frames clear them one by one.
+	staleAccounts map[string]bool
 
 	tickRunning     bool      // true while the tick chain is active
 	lastInteraction time.Time // last user keypress/mouse event
 	lastDataUpdate  time.Time // last SnapshotsMsg with new data
+	// refreshInterval is how often the daemon pushes frames; it drives the
+	// status bar's refresh countdown. Zero hides the countdown.
+	refreshInterval time.Duration
 	// referenceTime is the wall-clock View() will use for "X ago" labels.
 	// Set once at the top of each View() / renderDashboard() so the same
 	// frame uses a single consistent timestamp (fixes test flakiness, gives
 	// future render-cache work a stable cache key, and keeps View() pure).
 	referenceTime time.Time
 
-	experimentalAnalytics bool // when false, only the Dashboard screen is available
+	experimentalAnalytics bool   // when false, only the Dashboard screen is available
+	readOnly              bool   // providers skip billable/mutating probes; shown in the header
+	noWrite               bool   // settings changes are not written to disk; shown in the header
+	focusAccount          string // account to open in detail once its snapshot arrives (--account)
+
+	// offline is set while the daemon reports the network gone, or for the
+	// whole session with --offline; the header then says how old the data is.
+	offline offlineState
 
 	daemon daemonState
 
@@ -239,6 +292,10 @@ type Model struct {
 	widgetSections         []config.DashboardWidgetSection
 	detailWidgetSections   []config.DetailWidgetSection
 	hideSectionsWithNoData bool
+	// privacyMode masks identities and dollar amounts for screen sharing;
+	// redactedSnapshots caches the masked copies, see privacySnapshots.
+	privacyMode       bool
+	redactedSnapshots map[string]redactedSnapshotEntry
 
 	// hideCostsGlobal mirrors DashboardConfig.HideCosts; nil means "fall
 	// through to plan-aware auto".
@@ -246,15 +303,35 @@ type Model struct {
 	// hideCostsByAccount mirrors DashboardProviderConfig.HideCosts entries;
 	// missing key or nil pointer means "fall through to global / auto".
 	hideCostsByAccount map[string]*bool
+	// freeTierByAccount mirrors DashboardProviderConfig.FreeTier entries;
+	// missing key or nil pointer means "use the provider's tier signal".
+	freeTierByAccount map[string]*bool
+	// limitPools mirrors DashboardConfig.LimitPools.
+	limitPools []core.LimitPoolConfig
+	// retirementWarnWindow is how far ahead model shutdowns are flagged;
+	// 0 turns the warning off.
+	retirementWarnWindow time.Duration
+
+	// warningSnoozes mirrors DashboardConfig.Snoozes; snoozeHours mirrors
+	// DashboardConfig.SnoozeHours.
+	warningSnoozes []config.WarningSnooze
+	snoozeHours    map[string]int
 
 	timeWindow            core.TimeWindow
 	lastSnapshotRequestID uint64
 
-	services           Services
-	onAddAccount       func(core.AccountConfig)
-	onRefresh          func(core.TimeWindow)
-	onInstallDaemon    func() error
-	onTimeWindowChange func(core.TimeWindow)
+	// pollingAccounts holds the accounts with a forced poll in flight; their
+	// tiles show a spinner until AccountsRefreshedMsg arrives.
+	pollingAccounts map[string]bool
+
+	services            Services
+	onAddAccount        func(core.AccountConfig)
+	onRefresh           func(core.TimeWindow)
+	onRefreshAccounts   func([]string)
+	onSetSpendLimit     func(string, *float64) error
+	onPreviewSpendLimit func(string) (string, error)
+	onInstallDaemon     func() error
+	onTimeWindowChange  func(core.TimeWindow)
 }
 
 func NewModel(
@@ -272,7 +349,8 @@ func NewModel(
 		providerEnabled:       make(map[string]bool),
 		accountProviders:      make(map[string]string),
 		expandedModelMixTiles: make(map[string]bool),
-		tileBodyCache:         make(map[string][]string),
+		tileBodyCache:         make(map[string]tileBodyCacheEntry),
+		tileRenderCache:       make(map[string]tileRenderCacheEntry),
 		analyticsModelExpand:  make(map[string]bool),
 		analyticsCache:        analyticsRenderCacheEntry{},
 		detailCache:           detailRenderCacheEntry{},
@@ -285,6 +363,14 @@ func NewModel(
 	return model
 }
 
+// SetCachedSnapshots seeds the dashboard with snapshots persisted by the
+// previous session. They render as stale until the daemon delivers fresh
+// data for each account.
+func (m *Model) SetCachedSnapshots(snaps map[string]core.UsageSnapshot) {
+	next, _ := m.applyCachedSnapshots(snaps)
+	*m = next.(Model)
+}
+
 func (m *Model) SetOnInstallDaemon(fn func() error) {
 	m.onInstallDaemon = fn
 }
@@ -293,6 +379,42 @@ func (m *Model) SetServices(services Services) {
 	m.services = services
 }
 
+// SetRefreshInterval tells the status bar how often fresh frames arrive so
+// it can count down to the next one.
+func (m *Model) SetRefreshInterval(interval time.Duration) {
+	m.refreshInterval = interval
+}
+
+// SetReadOnly flags the session as read-only so the header makes it obvious
+// that some provider values were intentionally skipped.
+func (m *Model) SetReadOnly(readOnly bool) {
+	m.readOnly = readOnly
+}
+
+// SetNoWrite flags the session as --no-write so the header makes it obvious
+// that settings changes won't survive a restart.
+func (m *Model) SetNoWrite(noWrite bool) {
+	m.noWrite = noWrite
+}
+
+// SetEnergyConfig enables the detail view's approximate energy and CO2
+// section when cfg.Enabled is set.
+func (m *Model) SetEnergyConfig(cfg core.EnergyConfig) {
+	setEnergyConfig(cfg)
+}
+
+// SetOffline pins the session offline: the dashboard shows only the cached
+// snapshots, saved at dataAsOf, and the header says so.
+func (m *Model) SetOffline(dataAsOf time.Time) {
+	m.offline = offlineState{active: true, forced: true, dataAsOf: dataAsOf}
+}
+
+// SetFocusAccount opens the detail view for accountID as soon as its first
+// snapshot arrives. Unknown IDs are ignored.
+func (m *Model) SetFocusAccount(accountID string) {
+	m.focusAccount = strings.TrimSpace(accountID)
+}
+
 func (m *Model) ensureProviderTracking() {
 	if m.providerEnabled == nil {
 		m.providerEnabled = make(map[string]bool)
@@ -312,6 +434,13 @@ func (m *Model) SetOnRefresh(fn func(core.TimeWindow)) {
 	m.onRefresh = fn
 }
 
+// SetOnRefreshAccounts sets a callback that makes the daemon fetch the given
+// accounts immediately (all of them when the slice is nil). The caller must
+// answer with an AccountsRefreshedMsg once the poll is done.
+func (m *Model) SetOnRefreshAccounts(fn func([]string)) {
+	m.onRefreshAccounts = fn
+}
+
 func (m *Model) SetOnTimeWindowChange(fn func(core.TimeWindow)) {
 	m.onTimeWindowChange = fn
 }
@@ -326,6 +455,13 @@ type dashboardProviderHideCostsPersistedMsg struct {
 	accountID string
 	err       error
 }
+type dashboardProviderFreeTierPersistedMsg struct {
+	accountID string
+	err       error
+}
+type warningSnoozesPersistedMsg struct {
+	err error
+}
 type dashboardViewPersistedMsg struct {
 	err error
 }
@@ -338,6 +474,9 @@ type detailWidgetSectionsPersistedMsg struct {
 type dashboardHideSectionsWithNoDataPersistedMsg struct {
 	err error
 }
+type dashboardPrivacyModePersistedMsg struct {
+	err error
+}
 type timeWindowPersistedMsg struct {
 	err error
 }
@@ -411,7 +550,7 @@ func (m Model) Init() tea.Cmd { return tickCmd() }
 // Returns 0 when the tick chain should stop (fully idle).
 func (m Model) nextTickInterval() time.Duration {
 	// Loading state: fast tick for spinner/shimmer animations.
-	if !m.hasData || m.refreshing {
+	if !m.hasData || m.refreshing || len(m.pollingAccounts) > 0 {
 		return tickFast
 	}
 
@@ -427,6 +566,16 @@ func (m Model) nextTickInterval() time.Duration {
 		return tickSlow
 	}
 
+	// The open log pane keeps ticking so new lines show up.
+	if m.showLogs {
+		return tickNormal
+	}
+
+	// A visible refresh countdown keeps a slow tick so it doesn't freeze.
+	if m.refreshInterval > 0 && m.screen == screenDashboard && !m.showHelp && !m.settings.show {
+		return tickSlow
+	}
+
 	// Fully idle: stop ticking. The chain restarts on the next message.
 	return 0
 }
@@ -490,6 +639,8 @@ func (m Model) shouldUseWidgetScroll() bool {
 	switch m.activeDashboardView() {
 	case dashboardViewTabs, dashboardViewCompare, dashboardViewSplit:
 		return true
+	case dashboardViewCompact:
+		return !m.usesListNavigation()
 	case dashboardViewGrid:
 		return m.tileCols() > 1
 	default:
@@ -504,7 +655,7 @@ func (m Model) shouldUsePanelScroll() bool {
 	if m.shouldUseWidgetScroll() {
 		return false
 	}
-	if m.activeDashboardView() == dashboardViewSplit {
+	if m.usesListNavigation() {
 		return false
 	}
 	return m.tileCols() == 1
@@ -548,18 +699,29 @@ func (m *Model) applyDashboardConfig(dashboardCfg config.DashboardConfig, accoun
 	}
 
 	m.providerOrder = order
+	setProviderWidgetOverrides(dashboardCfg.ProviderWidgets)
 	m.setWidgetSections(dashboardCfg.WidgetSections)
 	m.setDetailWidgetSections(dashboardCfg.DetailSections)
 	m.hideSectionsWithNoData = dashboardCfg.HideSectionsWithNoData
+	m.setPrivacyMode(dashboardCfg.PrivacyMode)
 
 	m.hideCostsGlobal = dashboardCfg.HideCosts
 	m.hideCostsByAccount = make(map[string]*bool, len(dashboardCfg.Providers))
+	m.freeTierByAccount = make(map[string]*bool, len(dashboardCfg.Providers))
 	for _, pref := range dashboardCfg.Providers {
 		if pref.AccountID == "" {
 			continue
 		}
 		m.hideCostsByAccount[pref.AccountID] = pref.HideCosts
+		if pref.FreeTier != nil {
+			m.freeTierByAccount[pref.AccountID] = pref.FreeTier
+		}
 	}
+
+	m.warningSnoozes = append([]config.WarningSnooze(nil), dashboardCfg.Snoozes...)
+	m.snoozeHours = dashboardCfg.SnoozeHours
+	m.limitPools = dashboardCfg.LimitPools
+	m.retirementWarnWindow = dashboardCfg.ModelRetirementWarnWindow()
 }
 
 // resolveHideCosts returns whether monetary metrics should be suppressed for
@@ -926,6 +1088,8 @@ func (m Model) dashboardConfigProviders() []config.DashboardProviderConfig {
 		out = append(out, config.DashboardProviderConfig{
 			AccountID: id,
 			Enabled:   m.isProviderEnabled(id),
+			HideCosts: m.hideCostsByAccount[id],
+			FreeTier:  m.freeTierByAccount[id],
 		})
 	}
 	return out
@@ -1010,15 +1174,15 @@ func (m *Model) rebuildSortedIDs() {
 
 func (m Model) filteredIDs() []string {
 	if m.filter.text == "" {
-		return m.sortedIDs
+		return m.sortDashboardIDs(m.sortedIDs)
 	}
 	lower := strings.ToLower(m.filter.text)
-	return lo.Filter(m.sortedIDs, func(id string, _ int) bool {
+	return m.sortDashboardIDs(lo.Filter(m.sortedIDs, func(id string, _ int) bool {
 		snap := m.snapshots[id]
 		return strings.Contains(strings.ToLower(id), lower) ||
 			strings.Contains(strings.ToLower(snap.ProviderID), lower) ||
 			strings.Contains(strings.ToLower(string(snap.Status)), lower)
-	})
+	}))
 }
 
 func padToSize(content string, w, h int) string {
@@ -50,6 +50,19 @@ func (m Model) persistDashboardProviderHideCostsCmd(accountID string, hide *bool
 	}
 }
 
+func (m Model) persistDashboardProviderFreeTierCmd(accountID string, free *bool) tea.Cmd {
+	return func() tea.Msg {
+		if m.services == nil {
+			return dashboardProviderFreeTierPersistedMsg{accountID: accountID, err: fmt.Errorf("free_tier service unavailable")}
+		}
+		err := m.services.SaveDashboardProviderFreeTier(accountID, free)
+		if err != nil {
+			log.Printf("dashboard provider free_tier persist (%s): %v", accountID, err)
+		}
+		return dashboardProviderFreeTierPersistedMsg{accountID: accountID, err: err}
+	}
+}
+
 func (m Model) persistDashboardViewCmd() tea.Cmd {
 	view := string(m.configuredDashboardView())
 	return func() tea.Msg {
@@ -106,6 +119,20 @@ func (m Model) persistDashboardHideSectionsWithNoDataCmd() tea.Cmd {
 	}
 }
 
+func (m Model) persistDashboardPrivacyModeCmd() tea.Cmd {
+	on := m.privacyMode
+	return func() tea.Msg {
+		if m.services == nil {
+			return dashboardPrivacyModePersistedMsg{err: fmt.Errorf("privacy mode service unavailable")}
+		}
+		err := m.services.SaveDashboardPrivacyMode(on)
+		if err != nil {
+			log.Printf("dashboard privacy mode persist: %v", err)
+		}
+		return dashboardPrivacyModePersistedMsg{err: err}
+	}
+}
+
 func (m Model) persistTimeWindowCmd(window string) tea.Cmd {
 	return func() tea.Msg {
 		if m.services == nil {
@@ -270,6 +297,58 @@ func (m Model) requestRefresh() Model {
 	return m
 }
 
+// refreshAccounts asks the daemon to fetch ids right away, bypassing the poll
+// interval; nil means every account. Without a daemon hook it falls back to
+// re-reading the current data.
+func (m Model) refreshAccounts(ids []string) Model {
+	if m.onRefreshAccounts == nil {
+		return m.requestRefresh()
+	}
+	targets := ids
+	if targets == nil {
+		targets = m.sortedIDs
+	}
+	polling := make(map[string]bool, len(m.pollingAccounts)+len(targets))
+	for id := range m.pollingAccounts {
+		polling[id] = true
+	}
+	for _, id := range targets {
+		polling[id] = true
+	}
+	m.pollingAccounts = polling
+	m.onRefreshAccounts(ids)
+	return m
+}
+
+// refreshSelectedAccount force-refreshes the focused dashboard account, or
+// every account when nothing is focused.
+func (m Model) refreshSelectedAccount() Model {
+	id := m.selectedTileID(m.filteredIDs())
+	if id == "" {
+		return m.refreshAccounts(nil)
+	}
+	return m.refreshAccounts([]string{id})
+}
+
+func (m Model) handleAccountsRefreshedMsg(msg AccountsRefreshedMsg) (tea.Model, tea.Cmd) {
+	if msg.AccountIDs == nil {
+		m.pollingAccounts = nil
+	} else {
+		polling := make(map[string]bool, len(m.pollingAccounts))
+		for id := range m.pollingAccounts {
+			polling[id] = true
+		}
+		for _, id := range msg.AccountIDs {
+			delete(polling, id)
+		}
+		m.pollingAccounts = polling
+	}
+	if msg.Err != nil {
+		m.actionNotice = "refresh failed: " + msg.Err.Error()
+	}
+	return m, nil
+}
+
 // enterDetailMode switches to detail view while preserving the selected time window.
 func (m Model) enterDetailMode() Model {
 	m.mode = modeDetail
@@ -280,11 +359,13 @@ func (m Model) enterDetailMode() Model {
 // exitDetailMode returns to list view.
 func (m Model) exitDetailMode() Model {
 	m.mode = modeList
+	m.detailReference = false
 	return m
 }
 
 func (m Model) beginTimeWindowRefresh(window core.TimeWindow) Model {
 	m.timeWindow = window
+	m.clearStaleSnapshots()
 	m.invalidateRenderCaches()
 	if m.onTimeWindowChange != nil {
 		m.onTimeWindowChange(window)
@@ -307,17 +388,8 @@ func (m Model) installDaemonCmd() tea.Cmd {
 }
 
 func snapshotsReady(snaps map[string]core.UsageSnapshot) bool {
-	if len(snaps) == 0 {
-		return false
-	}
 	for _, snap := range snaps {
-		if snap.Status != core.StatusUnknown {
-			return true
-		}
-		if len(snap.Metrics) > 0 ||
-			len(snap.Resets) > 0 ||
-			len(snap.DailySeries) > 0 ||
-			len(snap.ModelUsage) > 0 {
+		if snap.HasData() {
 			return true
 		}
 	}
@@ -343,6 +415,8 @@ func (m Model) renderDashboard() string {
 	switch m.screen {
 	case screenAnalytics:
 		content = m.renderAnalyticsContent(w, contentH)
+	case screenModels:
+		content = m.renderModelsContent(w, contentH)
 	default:
 		content = m.renderDashboardContent(w, contentH)
 	}
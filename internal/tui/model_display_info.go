@@ -28,7 +28,7 @@ func normalizeProviderDisplayInfoType(info providerDisplayInfo) providerDisplayI
 		info.tagEmoji = "💰"
 	case "Usage":
 		info.tagEmoji = "⚡"
-	case "Error", "Auth", "N/A", "":
+	case "Error", "Auth", "Maint", "N/A", "":
 	default:
 		info.tagLabel = "Usage"
 		info.tagEmoji = "⚡"
@@ -68,6 +68,23 @@ func computeDisplayInfoRaw(snap core.UsageSnapshot, widget core.DashboardWidget,
 		info.summary = "Authentication required"
 		core.Tracef("[display] %s: branch=status_auth", snap.ProviderID)
 		return info
+	case core.StatusMaintenance:
+		info.tagEmoji = "🛠"
+		info.tagLabel = "Maint"
+		info.reason = "status_maintenance"
+		msg := snap.Message
+		if len(msg) > 50 {
+			msg = msg[:47] + "..."
+		}
+		if msg == "" {
+			msg = "Scheduled maintenance"
+		}
+		info.summary = msg
+		if until, ok := snap.Resets[core.MaintenanceResetKey]; ok && until.After(time.Now()) {
+			info.detail = "back in " + formatDuration(time.Until(until))
+		}
+		core.Tracef("[display] %s: branch=status_maintenance", snap.ProviderID)
+		return info
 	case core.StatusUnsupported:
 		info.tagEmoji = "◇"
 		info.tagLabel = "N/A"
@@ -108,8 +125,8 @@ func computeDisplayInfoRaw(snap core.UsageSnapshot, widget core.DashboardWidget,
 		info.tagEmoji = "💰"
 		info.tagLabel = "Credits"
 		info.reason = "available_balance"
-		info.summary = fmt.Sprintf("%s%.2f / %s%.2f spent", sym, *m.Used, sym, *m.Limit)
-		detailParts := []string{fmt.Sprintf("%s%.2f remaining", sym, remaining)}
+		info.summary = fmt.Sprintf("%s / %s spent", formatCurrency(sym, *m.Used, 2), formatCurrency(sym, *m.Limit, 2))
+		detailParts := []string{formatCurrency(sym, remaining, 2) + " remaining"}
 		// Lead with the authoritative windowed credit-spend figure when present
 		// so the detail line reports spend within the selected time window
 		// rather than only the cumulative remaining balance.
@@ -135,14 +152,14 @@ func computeDisplayInfoRaw(snap core.UsageSnapshot, widget core.DashboardWidget,
 		info.tagEmoji = "💰"
 		info.tagLabel = "Credits"
 		info.reason = "spend_limit"
-		info.summary = fmt.Sprintf("$%.0f / $%.0f spent", *m.Used, *m.Limit)
-		info.detail = fmt.Sprintf("$%.0f remaining", remaining)
+		info.summary = fmt.Sprintf("%s / %s spent", formatMoney(*m.Used, 0), formatMoney(*m.Limit, 0))
+		info.detail = fmt.Sprintf("%s remaining", formatMoney(remaining, 0))
 		if indiv, ok2 := snap.Metrics["individual_spend"]; ok2 && indiv.Used != nil {
 			otherSpend := *m.Used - *indiv.Used
 			if otherSpend < 0 {
 				otherSpend = 0
 			}
-			info.detail = fmt.Sprintf("you $%.0f · team $%.0f · $%.0f remaining", *indiv.Used, otherSpend, remaining)
+			info.detail = fmt.Sprintf("you %s · team %s · %s remaining", formatMoney(*indiv.Used, 0), formatMoney(otherSpend, 0), formatMoney(remaining, 0))
 		}
 		if pct := m.Percent(); pct >= 0 {
 			info.gaugePercent = 100 - pct
@@ -154,7 +171,7 @@ func computeDisplayInfoRaw(snap core.UsageSnapshot, widget core.DashboardWidget,
 	if m, ok := snap.Metrics["plan_spend"]; ok && m.Used != nil && m.Limit != nil {
 		info.tagEmoji = "💰"
 		info.tagLabel = "Credits"
-		info.summary = fmt.Sprintf("$%.0f / $%.0f plan", *m.Used, *m.Limit)
+		info.summary = fmt.Sprintf("%s / %s plan", formatMoney(*m.Used, 0), formatMoney(*m.Limit, 0))
 		if pct := m.Percent(); pct >= 0 {
 			info.gaugePercent = 100 - pct
 		}
@@ -168,9 +185,9 @@ func computeDisplayInfoRaw(snap core.UsageSnapshot, widget core.DashboardWidget,
 		info.tagEmoji = "💰"
 		info.tagLabel = "Credits"
 		if lm, ok2 := snap.Metrics["plan_limit_usd"]; ok2 && lm.Limit != nil {
-			info.summary = fmt.Sprintf("$%.2f / $%.0f plan", *m.Used, *lm.Limit)
+			info.summary = fmt.Sprintf("%s / %s plan", formatMoney(*m.Used, 2), formatMoney(*lm.Limit, 0))
 		} else {
-			info.summary = fmt.Sprintf("$%.2f spent", *m.Used)
+			info.summary = fmt.Sprintf("%s spent", formatMoney(*m.Used, 2))
 		}
 		return info
 	}
@@ -183,12 +200,12 @@ func computeDisplayInfoRaw(snap core.UsageSnapshot, widget core.DashboardWidget,
 		info.tagEmoji = "💰"
 		info.tagLabel = "Credits"
 		if m.Remaining != nil && m.Limit != nil {
-			info.summary = fmt.Sprintf("$%.2f / $%.2f credits", *m.Remaining, *m.Limit)
+			info.summary = fmt.Sprintf("%s / %s credits", formatMoney(*m.Remaining, 2), formatMoney(*m.Limit, 2))
 			if pct := m.Percent(); pct >= 0 {
 				info.gaugePercent = 100 - pct
 			}
 		} else if m.Used != nil {
-			info.summary = fmt.Sprintf("$%.4f used", *m.Used)
+			info.summary = fmt.Sprintf("%s used", formatMoney(*m.Used, 4))
 		} else {
 			info.summary = "Credits available"
 		}
@@ -198,12 +215,12 @@ func computeDisplayInfoRaw(snap core.UsageSnapshot, widget core.DashboardWidget,
 		info.tagEmoji = "💰"
 		info.tagLabel = "Credits"
 		if m.Limit != nil {
-			info.summary = fmt.Sprintf("$%.2f / $%.2f", *m.Remaining, *m.Limit)
+			info.summary = fmt.Sprintf("%s / %s", formatMoney(*m.Remaining, 2), formatMoney(*m.Limit, 2))
 			if pct := m.Percent(); pct >= 0 {
 				info.gaugePercent = 100 - pct
 			}
 		} else {
-			info.summary = fmt.Sprintf("$%.2f balance", *m.Remaining)
+			info.summary = fmt.Sprintf("%s balance", formatMoney(*m.Remaining, 2))
 		}
 		return info
 	}
@@ -290,13 +307,13 @@ func computeDisplayInfoRaw(snap core.UsageSnapshot, widget core.DashboardWidget,
 		if dc, ok2 := snap.Metrics["today_api_cost"]; ok2 && dc.Used != nil && !hideCosts {
 			tag := metricWindowTag(dc)
 			if tag != "" {
-				detailParts = append(detailParts, fmt.Sprintf("~$%.2f %s", *dc.Used, tag))
+				detailParts = append(detailParts, fmt.Sprintf("~%s %s", formatMoney(*dc.Used, 2), tag))
 			} else {
-				detailParts = append(detailParts, fmt.Sprintf("~$%.2f", *dc.Used))
+				detailParts = append(detailParts, fmt.Sprintf("~%s", formatMoney(*dc.Used, 2)))
 			}
 		}
 		if costSummary.BurnRateUSD > 0 {
-			detailParts = append(detailParts, fmt.Sprintf("$%.2f/h", costSummary.BurnRateUSD))
+			detailParts = append(detailParts, fmt.Sprintf("%s/h", formatMoney(costSummary.BurnRateUSD, 2)))
 		}
 		info.detail = strings.Join(detailParts, " · ")
 		core.Tracef("[display] %s: branch=usage_five_hour used=%.1f gauge=%.1f -> tag=Usage", snap.ProviderID, *fh.Used, info.gaugePercent)
@@ -312,26 +329,26 @@ func computeDisplayInfoRaw(snap core.UsageSnapshot, widget core.DashboardWidget,
 		if dc, ok2 := snap.Metrics["today_api_cost"]; ok2 && dc.Used != nil && !hideCosts {
 			tag := metricWindowTag(dc)
 			if tag != "" {
-				parts = append(parts, fmt.Sprintf("~$%.2f %s", *dc.Used, tag))
+				parts = append(parts, fmt.Sprintf("~%s %s", formatMoney(*dc.Used, 2), tag))
 			} else {
-				parts = append(parts, fmt.Sprintf("~$%.2f", *dc.Used))
+				parts = append(parts, fmt.Sprintf("~%s", formatMoney(*dc.Used, 2)))
 			}
 		}
 		if costSummary.BurnRateUSD > 0 {
-			parts = append(parts, fmt.Sprintf("$%.2f/h", costSummary.BurnRateUSD))
+			parts = append(parts, fmt.Sprintf("%s/h", formatMoney(costSummary.BurnRateUSD, 2)))
 		}
 		info.summary = strings.Join(parts, " · ")
 
 		var detailParts []string
 		if bc, ok2 := snap.Metrics["5h_block_cost"]; ok2 && bc.Used != nil && !hideCosts {
-			detailParts = append(detailParts, fmt.Sprintf("~$%.2f 5h block", *bc.Used))
+			detailParts = append(detailParts, fmt.Sprintf("~%s 5h block", formatMoney(*bc.Used, 2)))
 		}
 		if wc, ok2 := snap.Metrics["7d_api_cost"]; ok2 && wc.Used != nil && !hideCosts {
 			tag := metricWindowTag(wc)
 			if tag != "" {
-				detailParts = append(detailParts, fmt.Sprintf("~$%.2f/%s", *wc.Used, tag))
+				detailParts = append(detailParts, fmt.Sprintf("~%s/%s", formatMoney(*wc.Used, 2), tag))
 			} else {
-				detailParts = append(detailParts, fmt.Sprintf("~$%.2f", *wc.Used))
+				detailParts = append(detailParts, fmt.Sprintf("~%s", formatMoney(*wc.Used, 2)))
 			}
 		}
 		if msgs, ok2 := snap.Metrics["messages_today"]; ok2 && msgs.Used != nil {
@@ -351,26 +368,26 @@ func computeDisplayInfoRaw(snap core.UsageSnapshot, widget core.DashboardWidget,
 		info.reason = "today_api_cost"
 		core.Tracef("[display] %s: branch=today_api_cost used=%.2f -> tag=Credits", snap.ProviderID, *m.Used)
 		tag := metricWindowTag(m)
-		costLabel := fmt.Sprintf("~$%.2f", *m.Used)
+		costLabel := fmt.Sprintf("~%s", formatMoney(*m.Used, 2))
 		if tag != "" {
-			costLabel = fmt.Sprintf("~$%.2f %s", *m.Used, tag)
+			costLabel = fmt.Sprintf("~%s %s", formatMoney(*m.Used, 2), tag)
 		}
 		parts := []string{costLabel}
 		if costSummary.BurnRateUSD > 0 {
-			parts = append(parts, fmt.Sprintf("$%.2f/h", costSummary.BurnRateUSD))
+			parts = append(parts, fmt.Sprintf("%s/h", formatMoney(costSummary.BurnRateUSD, 2)))
 		}
 		info.summary = strings.Join(parts, " · ")
 
 		var detailParts []string
 		if bc, ok2 := snap.Metrics["5h_block_cost"]; ok2 && bc.Used != nil && !hideCosts {
-			detailParts = append(detailParts, fmt.Sprintf("~$%.2f 5h block", *bc.Used))
+			detailParts = append(detailParts, fmt.Sprintf("~%s 5h block", formatMoney(*bc.Used, 2)))
 		}
 		if wc, ok2 := snap.Metrics["7d_api_cost"]; ok2 && wc.Used != nil && !hideCosts {
 			wcTag := metricWindowTag(wc)
 			if wcTag != "" {
-				detailParts = append(detailParts, fmt.Sprintf("~$%.2f/%s", *wc.Used, wcTag))
+				detailParts = append(detailParts, fmt.Sprintf("~%s/%s", formatMoney(*wc.Used, 2), wcTag))
 			} else {
-				detailParts = append(detailParts, fmt.Sprintf("~$%.2f", *wc.Used))
+				detailParts = append(detailParts, fmt.Sprintf("~%s", formatMoney(*wc.Used, 2)))
 			}
 		}
 		if msgs, ok2 := snap.Metrics["messages_today"]; ok2 && msgs.Used != nil {
@@ -386,9 +403,9 @@ func computeDisplayInfoRaw(snap core.UsageSnapshot, widget core.DashboardWidget,
 	if m, ok := snap.Metrics["5h_block_cost"]; ok && m.Used != nil && !hideCosts {
 		info.tagEmoji = "⚡"
 		info.tagLabel = "Usage"
-		info.summary = fmt.Sprintf("~$%.2f / 5h block", *m.Used)
+		info.summary = fmt.Sprintf("~%s / 5h block", formatMoney(*m.Used, 2))
 		if costSummary.BurnRateUSD > 0 {
-			info.detail = fmt.Sprintf("$%.2f/h burn rate", costSummary.BurnRateUSD)
+			info.detail = fmt.Sprintf("%s/h burn rate", formatMoney(costSummary.BurnRateUSD, 2))
 		}
 		return info
 	}
@@ -437,13 +454,13 @@ func computeDisplayInfoRaw(snap core.UsageSnapshot, widget core.DashboardWidget,
 	if m, ok := snap.Metrics["total_cost_usd"]; ok && m.Used != nil && !hideCosts {
 		info.tagEmoji = "💰"
 		info.tagLabel = "Credits"
-		info.summary = fmt.Sprintf("$%.2f total", *m.Used)
+		info.summary = fmt.Sprintf("%s total", formatMoney(*m.Used, 2))
 		return info
 	}
 	if m, ok := snap.Metrics["all_time_api_cost"]; ok && m.Used != nil && !hideCosts {
 		info.tagEmoji = "💰"
 		info.tagLabel = "Credits"
-		info.summary = fmt.Sprintf("~$%.2f total (API est.)", *m.Used)
+		info.summary = fmt.Sprintf("~%s total (API est.)", formatMoney(*m.Used, 2))
 		return info
 	}
 
@@ -522,9 +539,9 @@ func computeDetailedCreditsDisplayInfo(snap core.UsageSnapshot, info providerDis
 		// scope explicitly so the headline can't be misread as spend within
 		// the dashboard's selected time window (issue #175).
 		if scope := creditScopeTag(m.Window); scope != "" {
-			info.summary = fmt.Sprintf("$%.2f / $%.2f spent · %s", spent, *m.Limit, scope)
+			info.summary = fmt.Sprintf("%s / %s spent · %s", formatMoney(spent, 2), formatMoney(*m.Limit, 2), scope)
 		} else {
-			info.summary = fmt.Sprintf("$%.2f / $%.2f spent", spent, *m.Limit)
+			info.summary = fmt.Sprintf("%s / %s spent", formatMoney(spent, 2), formatMoney(*m.Limit, 2))
 		}
 		if pct := m.Percent(); pct >= 0 {
 			info.gaugePercent = 100 - pct
@@ -534,7 +551,7 @@ func computeDetailedCreditsDisplayInfo(snap core.UsageSnapshot, info providerDis
 		// spend-in-the-selected-window figure (the daemon picks the provider's
 		// own windowed metric when it has one, else derives it from the
 		// observed balance series). One window, not a 1d/7d/30d dump.
-		detailParts := []string{fmt.Sprintf("$%.2f left", *m.Remaining)}
+		detailParts := []string{fmt.Sprintf("%s left", formatMoney(*m.Remaining, 2))}
 		if windowSpendPart, ok := windowCreditSpendPart(snap); ok {
 			detailParts = append(detailParts, windowSpendPart)
 		}
@@ -548,22 +565,22 @@ func computeDetailedCreditsDisplayInfo(snap core.UsageSnapshot, info providerDis
 	if m, ok := snap.Metrics["credits"]; ok && m.Used != nil {
 		info.tagEmoji = "💰"
 		info.tagLabel = "Credits"
-		info.summary = fmt.Sprintf("$%.4f used", *m.Used)
+		info.summary = fmt.Sprintf("%s used", formatMoney(*m.Used, 4))
 
 		var detailParts []string
 		if daily, ok := snap.Metrics["usage_daily"]; ok && daily.Used != nil {
 			tag := metricWindowTag(daily)
 			if tag != "" {
-				detailParts = append(detailParts, fmt.Sprintf("%s $%.2f", tag, *daily.Used))
+				detailParts = append(detailParts, fmt.Sprintf("%s %s", tag, formatMoney(*daily.Used, 2)))
 			} else {
-				detailParts = append(detailParts, fmt.Sprintf("$%.2f", *daily.Used))
+				detailParts = append(detailParts, formatMoney(*daily.Used, 2))
 			}
 		}
 		if byok, ok := snap.Metrics["byok_daily"]; ok && byok.Used != nil && *byok.Used > 0 {
-			detailParts = append(detailParts, fmt.Sprintf("BYOK $%.2f", *byok.Used))
+			detailParts = append(detailParts, fmt.Sprintf("BYOK %s", formatMoney(*byok.Used, 2)))
 		}
 		if costSummary.BurnRateUSD > 0 {
-			detailParts = append(detailParts, fmt.Sprintf("$%.2f/h", costSummary.BurnRateUSD))
+			detailParts = append(detailParts, fmt.Sprintf("%s/h", formatMoney(costSummary.BurnRateUSD, 2)))
 		}
 		if models := snapshotMeta(snap, "activity_models"); models != "" {
 			detailParts = append(detailParts, fmt.Sprintf("%s models", models))
@@ -592,7 +609,7 @@ func windowActivityLineWithHide(snap core.UsageSnapshot, tw core.TimeWindow, hid
 	}
 	if !hideCosts {
 		if m, ok := snap.Metrics["window_cost"]; ok && m.Used != nil && *m.Used > 0.001 {
-			parts = append(parts, fmt.Sprintf("$%.2f", *m.Used))
+			parts = append(parts, formatMoney(*m.Used, 2))
 		}
 	}
 	if m, ok := snap.Metrics["window_tokens"]; ok && m.Used != nil && *m.Used > 0 {
@@ -640,7 +657,7 @@ func windowCreditSpendPart(snap core.UsageSnapshot) (part string, ok bool) {
 	if window == "" {
 		window = "window"
 	}
-	part = fmt.Sprintf("%s $%.2f", window, *m.Used)
+	part = fmt.Sprintf("%s %s", window, formatMoney(*m.Used, 2))
 	if snapshotMeta(snap, "window_credit_spend_partial") == "true" {
 		if since := snapshotMeta(snap, "window_credit_spend_since"); since != "" {
 			if t, err := time.Parse(time.RFC3339, since); err == nil {
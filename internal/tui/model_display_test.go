@@ -31,6 +31,22 @@ func TestComputeDisplayInfo_MapsActivityFallbackToUsage(t *testing.T) {
 	}
 }
 
+func TestComputeDisplayInfo_Maintenance(t *testing.T) {
+	snap := core.UsageSnapshot{ProviderID: "openai"}
+	snap.SetMaintenance("provider under scheduled maintenance (HTTP 503)", time.Now().Add(2*time.Hour))
+
+	got := computeDisplayInfo(snap, core.DefaultDashboardWidget(), false)
+	if got.tagLabel != "Maint" || got.reason != "status_maintenance" {
+		t.Fatalf("info = %+v, want Maint tag", got)
+	}
+	if !strings.HasPrefix(got.detail, "back in ") {
+		t.Fatalf("detail = %q, want time until the window ends", got.detail)
+	}
+	if StatusBorderColor(core.StatusMaintenance) == StatusBorderColor(core.StatusError) {
+		t.Fatal("maintenance must not share the error border color")
+	}
+}
+
 func TestComputeDisplayInfo_MapsGenericMetricsFallbackToUsage(t *testing.T) {
 	custom := 7.0
 	snap := core.UsageSnapshot{
@@ -514,3 +530,87 @@ func TestComputeDisplayInfo_BillingBlockFallbackClassifiesAsUsage(t *testing.T)
 		t.Fatalf("detail = %q, want '$94.93 5h block'", got.detail)
 	}
 }
+
+func TestUpdate_SnapshotsMsgOpensFocusAccountOnce(t *testing.T) {
+	m := NewModel(0.2, 0.1, false, config.DashboardConfig{}, nil, core.TimeWindow30d)
+	m.SetFocusAccount("work")
+	msg := SnapshotsMsg{
+		Snapshots: map[string]core.UsageSnapshot{
+			"personal": {ProviderID: "openai", AccountID: "personal", Status: core.StatusOK},
+			"work":     {ProviderID: "openai", AccountID: "work", Status: core.StatusOK},
+		},
+		TimeWindow: core.TimeWindow30d,
+		RequestID:  1,
+	}
+
+	updated, _ := m.Update(msg)
+	got := updated.(Model)
+	if got.mode != modeDetail || got.sortedIDs[got.cursor] != "work" {
+		t.Fatalf("mode=%v selected=%q, want detail view of work", got.mode, got.sortedIDs[got.cursor])
+	}
+
+	got = got.exitDetailMode()
+	msg.RequestID = 2
+	updated, _ = got.Update(msg)
+	if updated.(Model).mode == modeDetail {
+		t.Fatal("focus account reopened detail on a later frame")
+	}
+}
+
+func TestSetCachedSnapshots_StaleUntilLiveData(t *testing.T) {
+	m := NewModel(0.2, 0.1, false, config.DashboardConfig{}, nil, core.TimeWindow30d)
+	cachedUsed := 10.0
+	m.SetCachedSnapshots(map[string]core.UsageSnapshot{
+		"openai": {
+			ProviderID: "openai",
+			AccountID:  "openai",
+			Status:     core.StatusOK,
+			Metrics:    map[string]core.Metric{"spend": {Used: &cachedUsed, Unit: "USD"}},
+		},
+		"anthropic": {ProviderID: "anthropic", AccountID: "anthropic", Status: core.StatusOK},
+	})
+	if !m.hasData {
+		t.Fatal("expected cached snapshots to set hasData")
+	}
+	if m.daemon.status == DaemonRunning {
+		t.Fatal("cached snapshots must not mark the daemon as running")
+	}
+	if !m.staleAccounts["openai"] || !m.staleAccounts["anthropic"] {
+		t.Fatalf("staleAccounts = %v, want both accounts", m.staleAccounts)
+	}
+
+	freshUsed := 12.0
+	updated, _ := m.Update(SnapshotsMsg{
+		Snapshots: map[string]core.UsageSnapshot{
+			"openai": {
+				ProviderID: "openai",
+				AccountID:  "openai",
+				Status:     core.StatusOK,
+				Metrics:    map[string]core.Metric{"spend": {Used: &freshUsed, Unit: "USD"}},
+			},
+			"anthropic": {ProviderID: "anthropic", AccountID: "anthropic", Status: core.StatusUnknown},
+		},
+		TimeWindow: core.TimeWindow30d,
+		RequestID:  1,
+	})
+	got := updated.(Model)
+	if metric := got.snapshots["openai"].Metrics["spend"]; metric.Used == nil || *metric.Used != 12 {
+		t.Fatalf("openai spend = %+v, want fresh 12", metric)
+	}
+	if got.staleAccounts["openai"] {
+		t.Fatal("openai should no longer be stale")
+	}
+	if got.snapshots["anthropic"].Status != core.StatusOK || !got.staleAccounts["anthropic"] {
+		t.Fatalf("anthropic = %q stale=%v, want cached snapshot kept as stale", got.snapshots["anthropic"].Status, got.staleAccounts["anthropic"])
+	}
+
+	updated, _ = got.Update(SnapshotsMsg{
+		Snapshots:  map[string]core.UsageSnapshot{"openai": {AccountID: "openai", Status: core.StatusOK}},
+		TimeWindow: core.TimeWindow30d,
+		RequestID:  2,
+		Cached:     true,
+	})
+	if updated.(Model).snapshots["openai"].Metrics["spend"].Used == nil {
+		t.Fatal("cached frame must not overwrite live data")
+	}
+}
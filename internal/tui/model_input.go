@@ -1,11 +1,13 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janekbaraniewski/openusage/internal/config"
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
 
@@ -14,9 +16,13 @@ import (
 // thing that varies is the status label. Set m.settings.status to either
 // failureLabel or successLabel and return the updated model.
 func (m Model) applyPersisted(err error, failureLabel, successLabel string) Model {
-	if err != nil {
+	switch {
+	case errors.Is(err, config.ErrNoWrite):
+		// --no-write: the change already lives in the model for the session.
+		m.settings.status = successLabel + " for this session"
+	case err != nil:
 		m.settings.status = failureLabel
-	} else {
+	default:
 		m.settings.status = successLabel
 	}
 	return m
@@ -57,14 +63,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.applyPersisted(msg.err, "save failed", "saved"), nil
 	case dashboardProviderHideCostsPersistedMsg:
 		return m.applyPersisted(msg.err, "hide_costs save failed", "hide_costs saved"), nil
+	case dashboardProviderFreeTierPersistedMsg:
+		return m.applyPersisted(msg.err, "free_tier save failed", "free_tier saved"), nil
 	case dashboardViewPersistedMsg:
 		return m.applyPersisted(msg.err, "view save failed", "view saved"), nil
+	case warningSnoozesPersistedMsg:
+		return m.applyPersisted(msg.err, "snooze save failed", "snooze saved"), nil
 	case dashboardWidgetSectionsPersistedMsg:
 		return m.applyPersisted(msg.err, "section save failed", "sections saved"), nil
 	case detailWidgetSectionsPersistedMsg:
 		return m.applyPersisted(msg.err, "detail section save failed", "detail sections saved"), nil
 	case dashboardHideSectionsWithNoDataPersistedMsg:
 		return m.applyPersisted(msg.err, "empty-state save failed", "empty-state saved"), nil
+	case dashboardPrivacyModePersistedMsg:
+		return m.applyPersisted(msg.err, "privacy mode save failed", "privacy mode saved"), nil
 	case themePersistedMsg:
 		return m.applyPersisted(msg.err, "theme save failed", "theme saved"), nil
 	case timeWindowPersistedMsg:
@@ -172,6 +184,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m = m.requestRefresh()
 		return m, nil
 
+	case tileActionDoneMsg:
+		return m.handleTileActionDoneMsg(msg)
+	case AccountsRefreshedMsg:
+		return m.handleAccountsRefreshedMsg(msg)
+	case spendLimitSetMsg:
+		return m.handleSpendLimitSetMsg(msg)
+	case spendLimitPreviewMsg:
+		return m.handleSpendLimitPreviewMsg(msg), nil
+
 	case providerConsoleOpenedMsg:
 		if msg.Err != nil {
 			m.settings.apiKeyStatus = "open browser failed: " + msg.Err.Error()
@@ -248,13 +269,22 @@ func (m Model) handleSnapshotsMsg(msg SnapshotsMsg) (tea.Model, tea.Cmd) {
 	if msg.RequestID > 0 && msg.RequestID < m.lastSnapshotRequestID {
 		return m, nil
 	}
+	if msg.Cached {
+		return m.applyCachedSnapshots(msg.Snapshots)
+	}
 	if m.refreshing && m.hasData && !snapshotsReady(msg.Snapshots) {
 		return m, nil
 	}
-	m.snapshots = msg.Snapshots
+	selected := m.selectedTileID(m.filteredIDs())
+	m.snapshots = m.mergeStaleSnapshots(msg.Snapshots)
+	core.ApplyLimitPools(m.snapshots, m.limitPools)
+	m.offline = m.offline.observe(msg.Snapshots)
 	m.refreshing = false
 	m.lastDataUpdate = time.Now()
-	m.invalidateRenderCaches()
+	// Tile caches survive: they're keyed by each snapshot's render hash, so
+	// only accounts whose data changed re-render.
+	m.invalidateAnalyticsCache()
+	m.invalidateDetailCache()
 	if msg.RequestID > m.lastSnapshotRequestID {
 		m.lastSnapshotRequestID = msg.RequestID
 	}
@@ -270,11 +300,101 @@ func (m Model) handleSnapshotsMsg(msg SnapshotsMsg) (tea.Model, tea.Cmd) {
 			m.snapshots[id] = snap
 		}
 	}
+	m.recordSnapshotHashes()
+	m.pruneTileCaches()
 	m.ensureSnapshotProvidersKnown()
 	m.rebuildSortedIDs()
+	if m.dashboardSort != dashboardSortConfigured {
+		m.followSelection(selected)
+	}
+	m = m.applyFocusAccount()
 	return m, m.restartTickIfNeeded()
 }
 
+// applyCachedSnapshots paints the snapshots persisted by the previous
+// session so the dashboard isn't blank while the daemon re-fetches. They're
+// flagged stale until a live frame replaces them and never mark the daemon
+// as running.
+func (m Model) applyCachedSnapshots(snaps map[string]core.UsageSnapshot) (tea.Model, tea.Cmd) {
+	if m.hasData || !snapshotsReady(snaps) {
+		return m, nil
+	}
+	m.snapshots = make(map[string]core.UsageSnapshot, len(snaps))
+	m.staleAccounts = make(map[string]bool, len(snaps))
+	for id, snap := range snaps {
+		m.snapshots[id] = snap
+		m.staleAccounts[id] = true
+	}
+	core.ApplyLimitPools(m.snapshots, m.limitPools)
+	m.hasData = true
+	m.invalidateRenderCaches()
+	m.recordSnapshotHashes()
+	m.ensureSnapshotProvidersKnown()
+	m.rebuildSortedIDs()
+	return m, nil
+}
+
+// mergeStaleSnapshots keeps a cached snapshot on screen while the daemon
+// still reports a placeholder for that account, and drops the stale flag as
+// soon as real data arrives.
+func (m *Model) mergeStaleSnapshots(fresh map[string]core.UsageSnapshot) map[string]core.UsageSnapshot {
+	if len(m.staleAccounts) == 0 {
+		return fresh
+	}
+	merged := make(map[string]core.UsageSnapshot, len(fresh))
+	for id, snap := range fresh {
+		merged[id] = snap
+	}
+	for id := range m.staleAccounts {
+		if snap, ok := fresh[id]; ok && snap.HasData() {
+			delete(m.staleAccounts, id)
+			continue
+		}
+		if cached, ok := m.snapshots[id]; ok {
+			merged[id] = cached
+		}
+	}
+	return merged
+}
+
+// clearStaleSnapshots drops cached snapshots, e.g. when the time window
+// changes and they no longer describe what's being asked for.
+func (m *Model) clearStaleSnapshots() {
+	if len(m.staleAccounts) == 0 {
+		return
+	}
+	snaps := make(map[string]core.UsageSnapshot, len(m.snapshots))
+	for id, snap := range m.snapshots {
+		if !m.staleAccounts[id] {
+			snaps[id] = snap
+		}
+	}
+	m.snapshots = snaps
+	m.staleAccounts = nil
+	if len(snaps) == 0 {
+		m.hasData = false
+	}
+	m.rebuildSortedIDs()
+}
+
+// applyFocusAccount selects the --account target and opens its detail view
+// the first time it shows up in a frame.
+func (m Model) applyFocusAccount() Model {
+	if m.focusAccount == "" {
+		return m
+	}
+	for i, id := range m.filteredIDs() {
+		if id != m.focusAccount {
+			continue
+		}
+		m.focusAccount = ""
+		m.screen = screenDashboard
+		m.cursor = i
+		return m.enterDetailMode()
+	}
+	return m
+}
+
 func (m Model) handleValidateKeyResultMsg(msg validateKeyResultMsg) (tea.Model, tea.Cmd) {
 	if msg.Valid {
 		m.settings.apiKeyStatus = "valid ✓ — saving..."
@@ -351,12 +471,31 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	if m.settings.show {
 		return m.handleSettingsMouse(msg)
 	}
-	if m.showHelp || m.filter.active || m.analyticsFilter.active {
+	if m.filter.active || m.analyticsFilter.active {
 		return m, nil
 	}
 	if msg.Action != tea.MouseActionPress {
 		return m, nil
 	}
+	if m.showLogs {
+		maxOffset := max(0, len(m.logLines())-logVisibleLines(m.height))
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.logOffset = clamp(m.logOffset+m.mouseScrollStep(), 0, maxOffset)
+		case tea.MouseButtonWheelDown:
+			m.logOffset = clamp(m.logOffset-m.mouseScrollStep(), 0, maxOffset)
+		}
+		return m, nil
+	}
+	if m.showHelp {
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			return m.scrollHelp(-m.mouseScrollStep()), nil
+		case tea.MouseButtonWheelDown:
+			return m.scrollHelp(m.mouseScrollStep()), nil
+		}
+		return m, nil
+	}
 
 	scroll := 0
 	switch msg.Button {
@@ -371,6 +510,12 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	if m.screen != screenDashboard {
 		return m, nil
 	}
+	if m.pinnedDetailActive() {
+		if tilesW, _ := pinnedPaneWidths(m.width); msg.X > tilesW {
+			m.detailOffset = max(0, m.detailOffset+scroll)
+			return m, nil
+		}
+	}
 	if m.mode == modeDetail {
 		// Detail view uses plain content scrolling only.
 		m.detailOffset += scroll
@@ -386,7 +531,7 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	}
-	if m.mode == modeList && m.activeDashboardView() == dashboardViewSplit {
+	if m.mode == modeList && m.usesListNavigation() {
 		step := 1
 		if scroll < 0 {
 			step = -1
@@ -430,11 +575,37 @@ func (m Model) handleSettingsMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.actionNotice = ""
+	if m.actionMenu.active {
+		return m.handleActionMenuKey(msg)
+	}
+	if m.spendLimit.active {
+		return m.handleSpendLimitKey(msg)
+	}
+	if m.showLogs {
+		return m.handleLogPaneKey(msg)
+	}
 	if msg.String() == "?" && !m.filter.active && !m.analyticsFilter.active && !m.settings.show {
 		m.showHelp = !m.showHelp
+		m.helpOffset = 0
+		return m, nil
+	}
+	if msg.String() == "L" && !m.filter.active && !m.analyticsFilter.active && !m.settings.show {
+		m.showLogs = true
+		m.logOffset = 0
 		return m, nil
 	}
 	if m.showHelp {
+		switch msg.String() {
+		case "j", "down":
+			return m.scrollHelp(1), nil
+		case "k", "up":
+			return m.scrollHelp(-1), nil
+		case "pgdown", "ctrl+d", " ":
+			return m.scrollHelp(m.height / 2), nil
+		case "pgup", "ctrl+u":
+			return m.scrollHelp(-m.height / 2), nil
+		}
 		m.showHelp = false
 		return m, nil
 	}
@@ -449,6 +620,10 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m.handleDetailKey(msg)
 			}
 		}
+		if msg.String() == "tab" && m.pinnedDetailActive() {
+			m.pinnedFocus = !m.pinnedFocus
+			return m, nil
+		}
 		switch msg.String() {
 		case ",", "S":
 			m.openSettingsModal()
@@ -465,6 +640,15 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.detailOffset = 0
 			m.tileOffset = 0
 			return m, nil
+		case "m":
+			if m.screen == screenModels {
+				m.screen = screenDashboard
+			} else {
+				m.screen = screenModels
+				m.modelsScrollY = 0
+			}
+			m.mode = modeList
+			return m, nil
 		case "t":
 			m.invalidateRenderCaches()
 			return m, m.persistThemeCmd(CycleTheme())
@@ -474,8 +658,40 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					return mdl, cmd
 				}
 			}
+		case "z":
+			if m.screen == screenDashboard {
+				if mdl, cmd, handled := m.toggleWarningSnooze(); handled {
+					return mdl, cmd
+				}
+			}
+		case "F":
+			if m.screen == screenDashboard {
+				if mdl, cmd, handled := m.toggleFreeTierOverride(); handled {
+					return mdl, cmd
+				}
+			}
 		case "w":
 			return m.cycleTimeWindow()
+		case "R":
+			return m.refreshAccounts(nil), nil
+		case "P":
+			return m.togglePrivacyMode()
+		case "a":
+			if m.screen == screenDashboard {
+				return m.openActionMenu(), nil
+			}
+		case "y", "Y":
+			if m.screen == screenDashboard {
+				format := copyPlainText
+				if msg.String() == "Y" {
+					format = copyMarkdown
+				}
+				return m.copyFocusedView(format)
+			}
+		case "p":
+			if m.screen == screenDashboard && m.mode == modeList {
+				return m.togglePinnedDetail(), nil
+			}
 		case "v":
 			if m.screen == screenDashboard {
 				m.setDashboardView(m.nextDashboardView(1))
@@ -486,11 +702,19 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.setDashboardView(m.nextDashboardView(-1))
 				return m, m.persistDashboardViewCmd()
 			}
+		case "o":
+			if m.screen == screenDashboard && m.mode == modeList {
+				m.cycleDashboardSort()
+				return m, nil
+			}
 		}
 	}
 
-	if m.screen == screenAnalytics {
+	switch m.screen {
+	case screenAnalytics:
 		return m.handleAnalyticsKey(msg)
+	case screenModels:
+		return m.handleModelsKey(msg)
 	}
 	return m.handleDashboardTilesKey(msg)
 }
@@ -517,10 +741,24 @@ func (m Model) handleDashboardTilesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.mode == modeDetail {
 		return m.handleDetailKey(msg)
 	}
-	if m.activeDashboardView() == dashboardViewSplit {
-		return m.handleListKey(msg)
+	if m.pinnedDetailFocused() {
+		return m.handlePinnedDetailKey(msg)
 	}
-	return m.handleTilesKey(msg)
+
+	var updated tea.Model
+	var cmd tea.Cmd
+	if m.usesListNavigation() {
+		updated, cmd = m.handleListKey(msg)
+	} else {
+		updated, cmd = m.handleTilesKey(msg)
+	}
+	// The pinned pane follows the selection; start each account at the top.
+	if next, ok := updated.(Model); ok && next.cursor != m.cursor && next.pinnedDetailActive() {
+		next.detailOffset = 0
+		next.detailTab = 0
+		return next, cmd
+	}
+	return updated, cmd
 }
 
 func (m Model) handleAnalyticsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -568,6 +806,32 @@ func (m Model) handleAnalyticsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m Model) handleModelsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.screen = screenDashboard
+	case "r":
+		m = m.requestRefresh()
+	case "j", "down":
+		m.modelsScrollY++
+	case "k", "up":
+		if m.modelsScrollY > 0 {
+			m.modelsScrollY--
+		}
+	case "pgdown", "ctrl+d":
+		m.modelsScrollY += 10
+	case "pgup", "ctrl+u":
+		m.modelsScrollY = max(0, m.modelsScrollY-10)
+	case "home", "g":
+		m.modelsScrollY = 0
+	case "end", "G":
+		m.modelsScrollY = 9999
+	}
+	return m, nil
+}
+
 func (m Model) handleAnalyticsFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
@@ -594,9 +858,9 @@ func (m Model) handleAnalyticsFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 func (m Model) availableScreens() []screenTab {
 	if !m.experimentalAnalytics {
-		return []screenTab{screenDashboard}
+		return []screenTab{screenDashboard, screenModels}
 	}
-	return []screenTab{screenDashboard, screenAnalytics}
+	return []screenTab{screenDashboard, screenAnalytics, screenModels}
 }
 
 func (m Model) nextScreen(step int) screenTab {
@@ -654,7 +918,7 @@ func (m Model) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.filter.active = true
 		m.filter.text = ""
 	case "r":
-		m = m.requestRefresh()
+		m = m.refreshSelectedAccount()
 	}
 	return m, nil
 }
@@ -664,10 +928,26 @@ func (m Model) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "q", "ctrl+c":
 		return m, tea.Quit
 	case "esc", "backspace":
+		if m.detailReference {
+			m.detailReference = false
+			m.detailOffset = 0
+			break
+		}
 		m = m.exitDetailMode()
+	case "i":
+		m.detailReference = !m.detailReference
+		m.detailOffset = 0
+	case "d":
+		m.detailChartDays = nextDetailChartRange(m.detailChartDays)
 	case "shift+tab", "left", "h":
+		if m.detailReference {
+			break
+		}
 		m = m.navigateDetailSection(-1)
 	case "tab", "right", "l":
+		if m.detailReference {
+			break
+		}
 		m = m.navigateDetailSection(1)
 	case "up", "k":
 		if m.detailOffset > 0 {
@@ -695,7 +975,7 @@ func (m Model) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.detailOffset = 0
 		}
 	case "r":
-		m = m.requestRefresh()
+		m = m.refreshSelectedAccount()
 	}
 	return m, nil
 }
@@ -744,12 +1024,20 @@ func (m Model) detailSectionStarts() []int {
 	if width < 30 {
 		width = 30
 	}
-	sections := buildDetailSections(snap, dashboardWidget(snap.ProviderID), width, m.warnThreshold, m.critThreshold, m.timeWindow, m.resolveHideCosts(snap), m.viewNow())
+	sections := buildDetailSections(snap, dashboardWidget(snap.ProviderID), width, m.warnThreshold, m.critThreshold, m.timeWindow, m.detailChartDays, m.resolveHideCosts(snap), m.viewNow())
 	if len(sections) == 0 {
 		return nil
 	}
 
 	line := 3 // compact detail header lines
+	if tierSuggestionLine(snap, width-2) != "" {
+		line++
+	}
+	line += len(deprecationDetailLines(snap, width-2))
+	if limitPoolTileLine(snap, width-2) != "" {
+		line++
+	}
+	line += len(modelRetirementDetailLines(snap, m.viewNow(), width-2))
 	starts := make([]int, 0, len(sections))
 	for _, sec := range sections {
 		if len(sec.lines) == 0 {
@@ -855,7 +1143,7 @@ func (m Model) handleTilesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.tileOffset = 0
 		}
 	case "r":
-		m = m.requestRefresh()
+		m = m.refreshSelectedAccount()
 	}
 	return m, nil
 }
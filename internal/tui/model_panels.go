@@ -8,6 +8,10 @@ import (
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
 
+// compactListItemWidth is the list width below which items shorten their
+// plan tag to just its emoji.
+const compactListItemWidth = 40
+
 func (m Model) renderList(w, h int) string {
 	ids := m.filteredIDs()
 	if len(ids) == 0 {
@@ -121,6 +125,15 @@ func (m Model) renderComparePanes(w, h int) string {
 	return padToSize(lipgloss.JoinHorizontal(lipgloss.Top, left, strings.Repeat(" ", gapW), right), w, h)
 }
 
+// renderCompactList is the small-terminal dashboard: one list row per
+// account, or the lone account's widget when there's only one.
+func (m Model) renderCompactList(w, h int) string {
+	if len(m.filteredIDs()) == 1 {
+		return m.renderWidgetPanelByIndex(0, w, h, m.tileOffset, true)
+	}
+	return m.renderList(w, h)
+}
+
 func (m Model) renderWidgetPanelByIndex(index, w, h, bodyOffset int, selected bool) string {
 	ids := m.filteredIDs()
 	if len(ids) == 0 || index < 0 || index >= len(ids) {
@@ -132,7 +145,7 @@ func (m Model) renderWidgetPanelByIndex(index, w, h, bodyOffset int, selected bo
 	modelMixExpanded := index == m.cursor && m.expandedModelMixTiles[id]
 
 	tileW := w - 2 - tileBorderH
-	if tileW < tileMinWidth {
+	if tileW < tileMinWidth && !m.useCompactLayout() {
 		tileW = tileMinWidth
 	}
 	contentH := h - tileBorderV
@@ -147,16 +160,21 @@ func (m Model) renderWidgetPanelByIndex(index, w, h, bodyOffset int, selected bo
 func (m Model) renderListItem(snap core.UsageSnapshot, selected bool, w int) string {
 	di := computeDisplayInfo(snap, dashboardWidget(snap.ProviderID), m.resolveHideCosts(snap))
 
-	iconStr := lipgloss.NewStyle().Foreground(StatusColor(snap.Status)).Render(StatusIcon(snap.Status))
+	status := m.tileStatus(snap)
+	iconStr := lipgloss.NewStyle().Foreground(StatusColor(status)).Render(StatusIcon(status))
 	nameStyle := lipgloss.NewStyle().Foreground(colorText)
 	if selected {
 		nameStyle = nameStyle.Bold(true).Foreground(colorLavender)
 	}
 
-	badge := StatusBadge(snap.Status)
+	badge := m.tileBadge(snap, status)
 	tagRendered := ""
 	if di.tagEmoji != "" && di.tagLabel != "" {
-		tagRendered = lipgloss.NewStyle().Foreground(tagColor(di.tagLabel)).Render(di.tagEmoji+" "+di.tagLabel) + " "
+		tag := di.tagEmoji + " " + di.tagLabel
+		if w < compactListItemWidth {
+			tag = di.tagEmoji
+		}
+		tagRendered = lipgloss.NewStyle().Foreground(tagColor(di.tagLabel)).Render(tag) + " "
 	}
 	rightPart := tagRendered + badge
 	rightW := lipgloss.Width(rightPart)
@@ -221,6 +239,9 @@ func (m Model) renderDetailPanel(w, h int) string {
 	snap := m.snapshots[ids[m.cursor]]
 	activeTab := clamp(m.detailTab, 0, len(DetailTabs(snap))-1)
 	content := m.cachedDetailContent(ids[m.cursor], snap, w-2, activeTab)
+	if m.detailReference && m.mode == modeDetail {
+		content = RenderDetailReference(snap, providerReference(snap.ProviderID), w-2)
+	}
 
 	lines := strings.Split(content, "\n")
 	totalLines := len(lines)
@@ -232,7 +253,7 @@ func (m Model) renderDetailPanel(w, h int) string {
 	}
 
 	result := strings.Join(visible, "\n")
-	if m.mode == modeDetail {
+	if m.mode == modeDetail || m.pinnedDetailFocused() {
 		rendered := strings.Split(result, "\n")
 		if offset > 0 && len(rendered) > 0 {
 			rendered[0] = lipgloss.NewStyle().Foreground(colorAccent).Render("  ▲ scroll up")
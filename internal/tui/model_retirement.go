@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
+)
+
+// dueModelRetirements returns the snapshot's model shutdowns dated within
+// window of now, including ones already past. A zero window turns the
+// warning off.
+func dueModelRetirements(snap core.UsageSnapshot, now time.Time, window time.Duration) []core.ModelRetirement {
+	if window <= 0 {
+		return nil
+	}
+	var due []core.ModelRetirement
+	for _, r := range snap.ModelRetirements() {
+		if r.Date.Before(now.Add(window)) {
+			due = append(due, r)
+		}
+	}
+	return due
+}
+
+// modelRetirementText reads "claude-3-opus retires in 12d · 42% of spend".
+func modelRetirementText(r core.ModelRetirement, now time.Time) string {
+	days := int(math.Ceil(r.Date.Sub(now).Hours() / 24))
+	var when string
+	switch {
+	case days > 1:
+		when = fmt.Sprintf("retires in %dd", days)
+	case days == 1:
+		when = "retires tomorrow"
+	case days == 0:
+		when = "retires today"
+	default:
+		when = fmt.Sprintf("retired %dd ago", -days)
+	}
+	return fmt.Sprintf("%s %s · %.0f%% of spend", r.Model, when, r.SpendShare*100)
+}
+
+// modelRetirementTileLine flags the soonest shutdown of a model the account
+// is spending on. Empty when none is due within window.
+func modelRetirementTileLine(snap core.UsageSnapshot, now time.Time, window time.Duration, maxW int) string {
+	due := dueModelRetirements(snap, now, window)
+	if len(due) == 0 {
+		return ""
+	}
+	text := "⚠ " + modelRetirementText(due[0], now)
+	if extra := len(due) - 1; extra > 0 {
+		text = fmt.Sprintf("⚠ (+%d) %s", extra, modelRetirementText(due[0], now))
+	}
+	return lipgloss.NewStyle().Foreground(colorPeach).Render(truncateToWidth(text, maxW))
+}
+
+// modelRetirementDetailLines lists every scheduled shutdown with its date,
+// for the detail header. Unlike the tile it ignores the warn window.
+func modelRetirementDetailLines(snap core.UsageSnapshot, now time.Time, maxW int) []string {
+	var lines []string
+	for _, r := range snap.ModelRetirements() {
+		text := fmt.Sprintf("⚠ %s (%s)", modelRetirementText(r, now), locale.Current().Date(r.Date))
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorPeach).Render(truncateToWidth(text, maxW)))
+	}
+	return lines
+}
@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestModelRetirementTileLine(t *testing.T) {
+	now := time.Date(2025, 12, 24, 12, 0, 0, 0, time.UTC)
+	window := 30 * 24 * time.Hour
+	var snap core.UsageSnapshot
+	snap.AddModelRetirement(core.ModelRetirement{Model: "gemini-1.5-pro", Date: time.Date(2026, 9, 24, 0, 0, 0, 0, time.UTC), SpendShare: 0.2})
+	if line := modelRetirementTileLine(snap, now, window, 80); line != "" {
+		t.Fatalf("line for a shutdown outside the window = %q, want empty", line)
+	}
+
+	snap.AddModelRetirement(core.ModelRetirement{Model: "claude-3-opus", Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), SpendShare: 0.42})
+	if got := ansi.Strip(modelRetirementTileLine(snap, now, window, 80)); got != "⚠ claude-3-opus retires in 12d · 42% of spend" {
+		t.Fatalf("tile line = %q", got)
+	}
+	if line := modelRetirementTileLine(snap, now, 0, 80); line != "" {
+		t.Fatalf("tile line with the warning off = %q", line)
+	}
+
+	detail := ansi.Strip(strings.Join(modelRetirementDetailLines(snap, now, 120), "\n"))
+	for _, want := range []string{"claude-3-opus retires in 12d", "gemini-1.5-pro retires in 274d · 20% of spend"} {
+		if !strings.Contains(detail, want) {
+			t.Fatalf("detail lines missing %q: %q", want, detail)
+		}
+	}
+}
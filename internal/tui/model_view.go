@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
 
@@ -22,12 +23,22 @@ func (m Model) View() string {
 	if m.referenceTime.IsZero() {
 		m.referenceTime = time.Now()
 	}
+	m.snapshots = m.privacySnapshots()
 	if !m.hasData {
 		return m.renderSplash(m.width, m.height)
 	}
 	if m.showHelp {
 		return m.renderHelpOverlay(m.width, m.height)
 	}
+	if m.actionMenu.active {
+		return m.renderActionMenuOverlay(m.width, m.height)
+	}
+	if m.spendLimit.active {
+		return m.renderSpendLimitOverlay(m.width, m.height)
+	}
+	if m.showLogs {
+		return m.renderLogPaneOverlay(m.width, m.height)
+	}
 	view := m.renderDashboard()
 	if m.settings.show {
 		return m.renderSettingsModalOverlay()
@@ -39,6 +50,13 @@ func (m Model) renderDashboardContent(w, contentH int) string {
 	if m.mode == modeDetail {
 		return m.renderDetailPanel(w, contentH)
 	}
+	if m.pinnedDetailActive() {
+		return m.renderPinnedDetail(w, contentH)
+	}
+	return m.renderDashboardView(w, contentH)
+}
+
+func (m Model) renderDashboardView(w, contentH int) string {
 	switch m.activeDashboardView() {
 	case dashboardViewTabs:
 		return m.renderTilesTabs(w, contentH)
@@ -46,6 +64,8 @@ func (m Model) renderDashboardContent(w, contentH int) string {
 		return m.renderSplitPanes(w, contentH)
 	case dashboardViewCompare:
 		return m.renderComparePanes(w, contentH)
+	case dashboardViewCompact:
+		return m.renderCompactList(w, contentH)
 	case dashboardViewStacked:
 		return m.renderTilesSingleColumn(w, contentH)
 	default:
@@ -78,12 +98,12 @@ func (m Model) renderHeader(w int) string {
 		if !ok {
 			continue
 		}
-		switch snap.Status {
+		switch m.tileStatus(snap) {
 		case core.StatusOK:
 			okCount++
-		case core.StatusNearLimit:
+		case core.StatusNearLimit, core.StatusDegraded, core.StatusStale:
 			warnCount++
-		case core.StatusLimited, core.StatusError:
+		case core.StatusExhausted, core.StatusLimited, core.StatusError:
 			errCount++
 		}
 	}
@@ -99,6 +119,8 @@ func (m Model) renderHeader(w int) string {
 			if m.analyticsFilter.text != "" {
 				info += " (filtered)"
 			}
+		case screenModels:
+			info = dimStyle.Render("models")
 		default:
 			info = fmt.Sprintf("⊞ %d providers", len(ids))
 			if m.filter.text != "" {
@@ -113,6 +135,12 @@ func (m Model) renderHeader(w int) string {
 	if !m.settings.show && len(unmappedProviders) > 0 {
 		info += " · " + m.unmappedHeaderPhrase()
 	}
+	if !m.settings.show && m.readOnly {
+		info += " · read-only"
+	}
+	if !m.settings.show && m.noWrite {
+		info += " · no-write"
+	}
 
 	statusInfo := ""
 	if okCount > 0 {
@@ -132,6 +160,16 @@ func (m Model) renderHeader(w int) string {
 			Foreground(colorPeach).
 			Render(fmt.Sprintf(" ⚠ %d unmapped", len(unmappedProviders)))
 	}
+	if m.offline.active {
+		statusInfo += lipgloss.NewStyle().
+			Foreground(colorPeach).
+			Bold(true).
+			Render(" " + m.offline.label(m.collapseChrome(), m.referenceTime))
+	}
+
+	if m.collapseChrome() {
+		return m.renderCollapsedHeader(w, bolt+statusInfo+spinnerStr)
+	}
 
 	infoRendered := labelStyle.Render(info)
 
@@ -145,6 +183,28 @@ func (m Model) renderHeader(w int) string {
 	return line + "\n" + m.renderGradientSeparator(w)
 }
 
+// renderCollapsedHeader is the one-line header for small terminals: status
+// counts on the left, screen and time window on the right, no separator.
+// The right side is dropped first when both don't fit.
+func (m Model) renderCollapsedHeader(w int, left string) string {
+	right := m.timeWindow.Label()
+	if m.screen == screenDashboard && m.filter.text != "" {
+		right = "filtered · " + right
+	}
+	if len(m.availableScreens()) > 1 {
+		right = screenLabelByTab[m.screen] + " · " + right
+	}
+	if m.readOnly {
+		right += " · ro"
+	}
+	rightRendered := labelStyle.Render(right)
+	gap := w - lipgloss.Width(left) - lipgloss.Width(rightRendered)
+	if gap < 1 {
+		return ansi.Truncate(left, w, "…")
+	}
+	return left + strings.Repeat(" ", gap) + rightRendered
+}
+
 // unmappedHeaderPhrase returns context-sensitive header text. When every
 // unmapped source has no account configured and no suggestion to offer, soften
 // to a passive observation. When at least one source has an actionable hint
@@ -197,39 +257,77 @@ func (m Model) renderScreenTabs() string {
 }
 
 func (m Model) renderFooter(w int) string {
-	sep := surface1Style.Render(strings.Repeat("━", w))
 	statusLine := m.renderFooterStatusLine(w)
+	if m.collapseChrome() {
+		return ansi.Truncate(statusLine, w, "…")
+	}
+	sep := surface1Style.Render(strings.Repeat("━", w))
 	return sep + "\n" + statusLine
 }
 
+// renderFooterStatusLine is the status bar: context-sensitive key hints (or a
+// notice) on the left, and the active filter, sort order, refresh countdown
+// and help key on the right. Hints are truncated first when space runs out.
 func (m Model) renderFooterStatusLine(w int) string {
-	searchStyle := sapphireStyle
-
-	switch {
-	case m.settings.show:
+	if m.settings.show {
 		if m.settings.status != "" {
 			return " " + dimStyle.Render(m.settings.status)
 		}
 		return " " + helpStyle.Render("? help")
+	}
+
+	right := m.footerStatusSegments()
+	rightW := lipgloss.Width(right)
+	if rightW+2 > w {
+		right = helpStyle.Render("? help")
+		rightW = lipgloss.Width(right)
+	}
+	leftMax := w - rightW - 2
+	left := m.footerContextLine(leftMax)
+	if lipgloss.Width(left) > leftMax {
+		left = ansi.Truncate(left, max(leftMax, 0), "…")
+	}
+	gap := w - lipgloss.Width(left) - rightW - 1
+	if gap < 1 {
+		gap = 1
+	}
+	return left + strings.Repeat(" ", gap) + right
+}
+
+// footerContextLine is the left half of the status bar: the search prompt
+// while filtering, then scroll-mode or view-specific hints, then notices
+// (app update, free capacity), falling back to the screen's key hints.
+func (m Model) footerContextLine(w int) string {
+	searchStyle := sapphireStyle
+
+	switch {
 	case m.screen == screenAnalytics:
 		if m.analyticsFilter.active {
 			cursor := PulseChar("█", "▌", m.animFrame)
 			return " " + dimStyle.Render("search: ") + searchStyle.Render(m.analyticsFilter.text+cursor)
 		}
-		if m.analyticsFilter.text != "" {
-			return " " + dimStyle.Render("filter: ") + searchStyle.Render(m.analyticsFilter.text)
-		}
 		return " " + dimStyle.Render("j/k scroll · PgUp/PgDn page · Home/End jump · s sort · / filter · r refresh")
+	case m.screen == screenModels:
+		return " " + dimStyle.Render("j/k scroll · PgUp/PgDn page · Home/End jump · w window · r refresh · m/Esc back")
 	default:
 		if m.mode == modeDetail && m.screen == screenDashboard {
-			return " " + dimStyle.Render("Tab/Shift+Tab sections · ←/→ sections · j/k scroll · PgUp/PgDn page · r refresh · Esc back")
+			if m.detailReference {
+				return " " + dimStyle.Render("provider reference · j/k scroll · PgUp/PgDn page · i/Esc back to detail")
+			}
+			return " " + dimStyle.Render("Tab/Shift+Tab sections · ←/→ sections · j/k scroll · PgUp/PgDn page · i reference · r refresh · Esc back")
 		}
 		if m.filter.active {
 			cursor := PulseChar("█", "▌", m.animFrame)
 			return " " + dimStyle.Render("search: ") + searchStyle.Render(m.filter.text+cursor)
 		}
-		if m.filter.text != "" {
-			return " " + dimStyle.Render("filter: ") + searchStyle.Render(m.filter.text)
+		if m.actionNotice != "" {
+			return " " + sapphireStyle.Render(m.actionNotice)
+		}
+		if m.pinnedDetailFocused() {
+			return " " + dimStyle.Render("pinned detail · j/k scroll · [ ] tabs · Enter open · Tab/Esc tiles · p unpin")
+		}
+		if m.pinnedDetailActive() {
+			return " " + dimStyle.Render("arrows move · Tab focus detail · Enter open · p unpin · r refresh")
 		}
 		if m.activeDashboardView() == dashboardViewTabs && m.mode == modeList {
 			return " " + dimStyle.Render("tabs view · ←/→ switch tab · PgUp/PgDn scroll widget · Enter detail")
@@ -259,7 +357,68 @@ func (m Model) renderFooterStatusLine(w int) string {
 		return " " + yellowStyle.Render(msg)
 	}
 
-	return " " + helpStyle.Render("? help")
+	if m.screen == screenDashboard {
+		if msg := m.freeCapacityFooter(w); msg != "" {
+			return " " + greenStyle.Render(msg)
+		}
+	}
+
+	if m.usesListNavigation() {
+		return " " + dimStyle.Render("↑/↓ select · Enter detail · / filter · w window · v view · o sort · r/R refresh")
+	}
+	hints := "arrows move · Enter detail · / filter · w window · v view · o sort · r/R refresh"
+	if m.screen == screenDashboard && m.width >= minPinnedDetailWidth {
+		hints += " · p pin"
+	}
+	return " " + dimStyle.Render(hints)
+}
+
+// footerStatusSegments is the right half of the status bar: the applied
+// filter, the analytics or dashboard sort order, the refresh countdown and
+// the help key.
+func (m Model) footerStatusSegments() string {
+	var parts []string
+	switch m.screen {
+	case screenAnalytics:
+		if m.analyticsFilter.text != "" && !m.analyticsFilter.active {
+			parts = append(parts, dimStyle.Render("filter: ")+sapphireStyle.Render(m.analyticsFilter.text))
+		}
+		if m.analyticsSortBy >= 0 && m.analyticsSortBy < len(sortByLabels) {
+			parts = append(parts, dimStyle.Render("sort: ")+labelStyle.Render(sortByLabels[m.analyticsSortBy]))
+		}
+	case screenDashboard:
+		if m.filter.text != "" && !m.filter.active {
+			parts = append(parts, dimStyle.Render("filter: ")+sapphireStyle.Render(m.filter.text))
+		}
+		if m.dashboardSort != dashboardSortConfigured {
+			parts = append(parts, dimStyle.Render("sort: ")+labelStyle.Render(dashboardSortLabel(m.dashboardSort)))
+		}
+	}
+	if m.privacyMode {
+		parts = append(parts, yellowStyle.Render("privacy"))
+	}
+	if countdown := m.refreshCountdownLabel(); countdown != "" {
+		parts = append(parts, dimStyle.Render(countdown))
+	}
+	parts = append(parts, helpStyle.Render("? help"))
+	return strings.Join(parts, dimStyle.Render(" · "))
+}
+
+// refreshCountdownLabel estimates when the next daemon frame lands from the
+// last one and the configured refresh interval. Empty when the interval is
+// unknown or no frame has arrived yet.
+func (m Model) refreshCountdownLabel() string {
+	if m.refreshing || len(m.pollingAccounts) > 0 {
+		return "⟳ refreshing"
+	}
+	if m.refreshInterval <= 0 || m.lastDataUpdate.IsZero() {
+		return ""
+	}
+	remaining := m.refreshInterval - m.viewNow().Sub(m.lastDataUpdate)
+	if remaining <= 0 {
+		return "⟳ due"
+	}
+	return "⟳ " + formatDuration(remaining.Round(time.Second))
 }
 
 func (m Model) hasAppUpdateNotice() bool {
@@ -0,0 +1,241 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// modelSourceEntry is one account that served a canonical model in the
+// current time window.
+type modelSourceEntry struct {
+	account string
+	cost    float64
+	tokens  float64
+	// perMTok is the observed blended price in USD per million tokens, or -1
+	// when the account reported no tokens for the model.
+	perMTok float64
+	// capacityPct is the remaining share of the account's tightest quota
+	// (0–100), or -1 when the account reports no quota.
+	capacityPct float64
+	available   bool
+}
+
+// unifiedModelEntry aggregates one canonical model across every account.
+type unifiedModelEntry struct {
+	name     string
+	cost     float64
+	tokens   float64
+	sources  []modelSourceEntry
+	cheapest int // index into sources, -1 when no source is available
+}
+
+// buildUnifiedModels groups model usage from every snapshot by canonical
+// identity (see core.NormalizeUsageSnapshotWithConfig) and ranks each model's
+// sources by observed price, so "how much did Sonnet cost me" has one answer
+// regardless of which tool or router served it.
+func buildUnifiedModels(snapshots map[string]core.UsageSnapshot, timeWindow core.TimeWindow) []unifiedModelEntry {
+	data := extractCostData(snapshots, "", timeWindow)
+
+	capacity := make(map[string]float64, len(snapshots))
+	available := make(map[string]bool, len(snapshots))
+	for _, snap := range snapshots {
+		pct := accountCapacityPercent(snap)
+		capacity[snap.AccountID] = pct
+		available[snap.AccountID] = pct != 0 && snap.Status != core.StatusLimited &&
+			snap.Status != core.StatusError && snap.Status != core.StatusAuth &&
+			snap.Status != core.StatusMaintenance
+	}
+
+	out := make([]unifiedModelEntry, 0, len(data.models))
+	for _, model := range data.models {
+		entry := unifiedModelEntry{
+			name:     model.name,
+			cost:     model.cost,
+			tokens:   model.inputTokens + model.outputTokens,
+			cheapest: -1,
+		}
+		for _, split := range model.providers {
+			src := modelSourceEntry{
+				account:     split.provider,
+				cost:        split.cost,
+				tokens:      split.inputTokens + split.outputTokens,
+				perMTok:     -1,
+				capacityPct: capacity[split.provider],
+				available:   available[split.provider],
+			}
+			if src.tokens > 0 {
+				src.perMTok = src.cost / src.tokens * 1_000_000
+			}
+			entry.sources = append(entry.sources, src)
+		}
+		sort.SliceStable(entry.sources, func(i, j int) bool {
+			return sourcePriceLess(entry.sources[i], entry.sources[j])
+		})
+		for i, src := range entry.sources {
+			if src.available && src.perMTok >= 0 {
+				entry.cheapest = i
+				break
+			}
+		}
+		out = append(out, entry)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].cost != out[j].cost {
+			return out[i].cost > out[j].cost
+		}
+		if out[i].tokens != out[j].tokens {
+			return out[i].tokens > out[j].tokens
+		}
+		return out[i].name < out[j].name
+	})
+	return out
+}
+
+// sourcePriceLess orders sources cheapest first; sources without a known
+// price sort last.
+func sourcePriceLess(a, b modelSourceEntry) bool {
+	switch {
+	case a.perMTok < 0 && b.perMTok < 0:
+		return a.account < b.account
+	case a.perMTok < 0:
+		return false
+	case b.perMTok < 0:
+		return true
+	case a.perMTok != b.perMTok:
+		return a.perMTok < b.perMTok
+	default:
+		return a.account < b.account
+	}
+}
+
+// accountCapacityPercent returns the remaining percentage of the account's
+// most-used quota, or -1 when it reports none. Per-session context windows
+// are not quotas and are ignored.
+func accountCapacityPercent(snap core.UsageSnapshot) float64 {
+	worst := -1.0
+	for key, met := range snap.Metrics {
+		if key == "context_window" || met.Window == "session" {
+			continue
+		}
+		used := metricUsedPercent(key, met)
+		if used < 0 {
+			continue
+		}
+		worst = math.Max(worst, used)
+	}
+	if worst < 0 {
+		return -1
+	}
+	return math.Max(0, 100-worst)
+}
+
+func (m Model) renderModelsContent(w, h int) string {
+	label := analyticsSubTabActiveStyle.Render(" Models ")
+	hints := dimStyle.Render("j/k scroll  w:window  r:refresh  m/esc:back")
+	gap := w - lipgloss.Width("  "+label) - lipgloss.Width(hints) - 2
+	if gap < 1 {
+		gap = 1
+	}
+	header := "  " + label + strings.Repeat(" ", gap) + hints
+
+	models := buildUnifiedModels(m.visibleSnapshots(), m.timeWindow)
+	if len(models) == 0 {
+		empty := "\n" + dimStyle.Render("  No per-model usage reported in this window.")
+		empty += "\n" + dimStyle.Render("  Models appear once a provider reports model-level tokens or cost.")
+		return header + "\n" + empty
+	}
+
+	lines := renderUnifiedModelLines(models, w)
+	contentH := h - 1
+	if contentH < 3 {
+		contentH = 3
+	}
+	if maxScroll := len(lines) - contentH; maxScroll > 0 {
+		start := min(max(m.modelsScrollY, 0), maxScroll)
+		lines = lines[start:]
+	}
+	if len(lines) > contentH {
+		lines = lines[:contentH]
+	}
+	for i := range lines {
+		lines[i] = analyticsPadLine(lines[i], w)
+	}
+	return analyticsPadLine(header, w) + "\n" + strings.Join(lines, "\n")
+}
+
+func renderUnifiedModelLines(models []unifiedModelEntry, w int) []string {
+	nameW := w - 2 - 10 - 2 - 8 - 2 - 34
+	if nameW > 40 {
+		nameW = 40
+	}
+	if nameW < 16 {
+		nameW = 16
+	}
+
+	pad := func(s string, width int) string {
+		s = truncateToWidth(s, width)
+		return s + strings.Repeat(" ", max(0, width-lipgloss.Width(s)))
+	}
+
+	var lines []string
+	lines = append(lines, "")
+	lines = append(lines, "  "+dimStyle.Render(pad("Model", nameW)+"  "+fmt.Sprintf("%10s  %8s  ", "Cost", "Tokens")+"Cheapest now"))
+
+	for _, model := range models {
+		cheapest := dimStyle.Render("no source available")
+		if model.cheapest >= 0 {
+			src := model.sources[model.cheapest]
+			cheapest = lipgloss.NewStyle().Foreground(colorGreen).Render(src.account) +
+				dimStyle.Render(" · "+formatSourcePrice(src)+" · "+formatSourceCapacity(src))
+		}
+		lines = append(lines, "  "+
+			lipgloss.NewStyle().Foreground(stableModelColor(model.name, "all")).Bold(true).Render(pad(model.name, nameW))+"  "+
+			valueStyle.Render(fmt.Sprintf("%10s  %8s", formatUSD(model.cost), formatTokens(model.tokens)))+"  "+
+			cheapest)
+
+		if len(model.sources) < 2 && model.cheapest == 0 {
+			continue
+		}
+		for _, src := range model.sources {
+			style := dimStyle
+			if !src.available {
+				style = lipgloss.NewStyle().Foreground(colorRed)
+			}
+			lines = append(lines, "    "+
+				style.Render(pad("└ "+src.account, nameW-2))+"  "+
+				dimStyle.Render(fmt.Sprintf("%10s  %8s", formatUSD(src.cost), formatTokens(src.tokens)))+"  "+
+				dimStyle.Render(formatSourcePrice(src)+" · "+formatSourceCapacity(src)))
+		}
+	}
+	return lines
+}
+
+func formatSourcePrice(src modelSourceEntry) string {
+	switch {
+	case src.perMTok < 0:
+		return "price n/a"
+	case src.perMTok == 0:
+		return "included"
+	default:
+		return formatMoney(src.perMTok, 2) + "/Mtok"
+	}
+}
+
+func formatSourceCapacity(src modelSourceEntry) string {
+	switch {
+	case !src.available && src.capacityPct == 0:
+		return "exhausted"
+	case !src.available:
+		return "unavailable"
+	case src.capacityPct < 0:
+		return "no quota"
+	default:
+		return fmt.Sprintf("%.0f%% left", src.capacityPct)
+	}
+}
@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestBuildUnifiedModels_MergesSourcesAndPicksCheapest(t *testing.T) {
+	now := time.Date(2026, 4, 14, 12, 0, 0, 0, time.UTC)
+	cfg := core.DefaultModelNormalizationConfig()
+	claude := core.NormalizeUsageSnapshotWithConfig(core.UsageSnapshot{
+		ProviderID: "claude_code",
+		AccountID:  "claude-code",
+		Status:     core.StatusOK,
+		Timestamp:  now,
+		Metrics: map[string]core.Metric{
+			"usage_five_hour": {Limit: core.Float64Ptr(100), Used: core.Float64Ptr(40), Unit: "%", Window: "5h"},
+		},
+		ModelUsage: []core.ModelUsageRecord{
+			{RawModelID: "claude-sonnet-4-5-20250929", InputTokens: core.Float64Ptr(800_000), OutputTokens: core.Float64Ptr(200_000), CostUSD: core.Float64Ptr(0)},
+		},
+	}, cfg)
+	router := core.NormalizeUsageSnapshotWithConfig(core.UsageSnapshot{
+		ProviderID: "openrouter",
+		AccountID:  "openrouter",
+		Status:     core.StatusOK,
+		Timestamp:  now,
+		ModelUsage: []core.ModelUsageRecord{
+			{RawModelID: "anthropic/claude-sonnet-4.5", InputTokens: core.Float64Ptr(500_000), OutputTokens: core.Float64Ptr(500_000), CostUSD: core.Float64Ptr(9)},
+		},
+	}, cfg)
+
+	models := buildUnifiedModels(map[string]core.UsageSnapshot{
+		claude.AccountID: claude,
+		router.AccountID: router,
+	}, core.TimeWindow7d)
+
+	if len(models) != 1 {
+		t.Fatalf("models = %d, want 1 merged entry: %+v", len(models), models)
+	}
+	got := models[0]
+	if got.cost != 9 || got.tokens != 2_000_000 {
+		t.Fatalf("totals = $%v / %v tokens, want $9 / 2M", got.cost, got.tokens)
+	}
+	if len(got.sources) != 2 || got.cheapest < 0 {
+		t.Fatalf("sources = %+v, cheapest = %d", got.sources, got.cheapest)
+	}
+	cheapest := got.sources[got.cheapest]
+	if cheapest.account != "claude-code" || cheapest.perMTok != 0 || cheapest.capacityPct != 60 {
+		t.Fatalf("cheapest = %+v, want claude-code included with 60%% left", cheapest)
+	}
+
+	out := stripANSI(strings.Join(renderUnifiedModelLines(models, 120), "\n"))
+	for _, want := range []string{"claude-code · included · 60% left", "openrouter", "$9.00/Mtok"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rendered models missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestBuildUnifiedModels_SkipsExhaustedSource(t *testing.T) {
+	cfg := core.DefaultModelNormalizationConfig()
+	limited := core.NormalizeUsageSnapshotWithConfig(core.UsageSnapshot{
+		ProviderID: "claude_code",
+		AccountID:  "claude-code",
+		Status:     core.StatusLimited,
+		Metrics: map[string]core.Metric{
+			"usage_five_hour": {Limit: core.Float64Ptr(100), Used: core.Float64Ptr(100), Unit: "%", Window: "5h"},
+		},
+		ModelUsage: []core.ModelUsageRecord{
+			{RawModelID: "claude-sonnet-4-5", InputTokens: core.Float64Ptr(1000), CostUSD: core.Float64Ptr(0)},
+		},
+	}, cfg)
+	router := core.NormalizeUsageSnapshotWithConfig(core.UsageSnapshot{
+		ProviderID: "openrouter",
+		AccountID:  "openrouter",
+		Status:     core.StatusOK,
+		ModelUsage: []core.ModelUsageRecord{
+			{RawModelID: "anthropic/claude-sonnet-4.5", InputTokens: core.Float64Ptr(1000), CostUSD: core.Float64Ptr(0.01)},
+		},
+	}, cfg)
+
+	models := buildUnifiedModels(map[string]core.UsageSnapshot{"a": limited, "b": router}, core.TimeWindow7d)
+	if len(models) != 1 || models[0].cheapest < 0 {
+		t.Fatalf("models = %+v", models)
+	}
+	if got := models[0].sources[models[0].cheapest].account; got != "openrouter" {
+		t.Fatalf("cheapest = %q, want openrouter while claude-code is exhausted", got)
+	}
+}
+
+func TestModelsKeyTogglesScreen(t *testing.T) {
+	m := Model{screen: screenDashboard}
+	mdl, _ := m.handleKey(keyOf("m"))
+	m = mdl.(Model)
+	if m.screen != screenModels {
+		t.Fatalf("screen = %v after m, want models", m.screen)
+	}
+	mdl, _ = m.handleKey(keyOf("m"))
+	if mdl.(Model).screen != screenDashboard {
+		t.Fatalf("second m should return to the dashboard")
+	}
+}
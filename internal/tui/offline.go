@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
+)
+
+// offlineState tracks whether the dashboard is showing data it cannot
+// refresh because the network is gone.
+type offlineState struct {
+	active bool
+	// forced is set by --offline; live frames then never clear it.
+	forced bool
+	// dataAsOf is when the data on screen was last fetched. Zero when the
+	// daemon could not tell.
+	dataAsOf time.Time
+}
+
+// observe updates the state from a live frame. The daemon marks every
+// snapshot while it is offline, so one marked snapshot is enough.
+func (o offlineState) observe(snaps map[string]core.UsageSnapshot) offlineState {
+	if o.forced {
+		return o
+	}
+	for _, snap := range snaps {
+		if asOf, ok := core.OfflineDataAsOf(snap); ok {
+			return offlineState{active: true, dataAsOf: asOf}
+		}
+	}
+	return offlineState{}
+}
+
+// label is the header banner, shortened for the collapsed header.
+func (o offlineState) label(short bool, now time.Time) string {
+	if short {
+		return "⊘ offline"
+	}
+	if o.dataAsOf.IsZero() {
+		return "⊘ offline — data age unknown"
+	}
+	return "⊘ offline — data as of " + offlineAsOf(o.dataAsOf, now)
+}
+
+// offlineAsOf is a clock time for data from today and a date and time
+// otherwise, since offline data can easily be days old.
+func offlineAsOf(t, now time.Time) string {
+	t = t.Local()
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if y, mo, d := now.Local().Date(); t.Year() == y && t.Month() == mo && t.Day() == d {
+		return formatClock(t, false)
+	}
+	return locale.Current().DateTime(t)
+}
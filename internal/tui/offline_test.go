@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestOfflineState_Observe(t *testing.T) {
+	asOf := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	offline := core.UsageSnapshot{AccountID: "openai", Status: core.StatusOK}
+	core.MarkOffline(&offline, asOf)
+	online := core.UsageSnapshot{AccountID: "openai", Status: core.StatusOK}
+
+	state := offlineState{}.observe(map[string]core.UsageSnapshot{"openai": offline})
+	if !state.active || !state.dataAsOf.Equal(asOf) {
+		t.Fatalf("state = %+v, want offline as of %s", state, asOf)
+	}
+	if state = state.observe(map[string]core.UsageSnapshot{"openai": online}); state.active {
+		t.Fatal("a frame without the offline marker should clear the state")
+	}
+
+	forced := offlineState{active: true, forced: true, dataAsOf: asOf}
+	if got := forced.observe(map[string]core.UsageSnapshot{"openai": online}); got != forced {
+		t.Fatalf("--offline state changed by a frame: %+v", got)
+	}
+}
+
+func TestRenderHeader_ShowsOfflineBanner(t *testing.T) {
+	asOf := time.Now().Add(-time.Minute)
+	m := Model{
+		snapshots: map[string]core.UsageSnapshot{"openai": {Status: core.StatusOK}},
+		sortedIDs: []string{"openai"},
+	}
+	m.SetOffline(asOf)
+
+	header := m.renderHeader(160)
+	if want := "offline — data as of " + formatClock(asOf, false); !strings.Contains(header, want) {
+		t.Fatalf("header missing %q: %q", want, header)
+	}
+}
+
+func TestOfflineAsOf_IncludesDateForOlderData(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.Local)
+	if got := offlineAsOf(now.Add(-time.Hour), now); got != formatClock(now.Add(-time.Hour), false) {
+		t.Fatalf("same-day data = %q, want a clock time", got)
+	}
+	yesterday := now.Add(-24 * time.Hour)
+	if got := offlineAsOf(yesterday, now); !strings.Contains(got, formatClock(yesterday, false)) || got == formatClock(yesterday, false) {
+		t.Fatalf("older data = %q, want a date and time", got)
+	}
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The pinned detail pane keeps the selected account's detail view open to
+// the right of the tiles, so one provider can be watched without entering
+// and leaving the detail screen. It only fits on wide terminals.
+const (
+	minPinnedDetailWidth = 100
+	minPinnedPaneWidth   = 44
+	maxPinnedPaneWidth   = 90
+)
+
+// pinnedDetailActive reports whether the pinned pane is toggled on and
+// currently shown: dashboard list mode on a terminal wide enough for both
+// panes.
+func (m Model) pinnedDetailActive() bool {
+	return m.pinnedDetail &&
+		m.screen == screenDashboard &&
+		m.mode == modeList &&
+		m.width >= minPinnedDetailWidth &&
+		!m.useCompactLayout() &&
+		len(m.filteredIDs()) > 0
+}
+
+// pinnedDetailFocused reports whether keys go to the pinned pane rather than
+// the tiles.
+func (m Model) pinnedDetailFocused() bool {
+	return m.pinnedFocus && m.pinnedDetailActive()
+}
+
+// pinnedPaneWidths splits w into the tiles pane and the pinned detail pane,
+// leaving one column for the separator.
+func pinnedPaneWidths(w int) (tilesW, detailW int) {
+	detailW = clamp(w*2/5, minPinnedPaneWidth, maxPinnedPaneWidth)
+	return w - detailW - 1, detailW
+}
+
+// dashboardGridWidth is the width the tiles are laid out in: the whole
+// terminal, or the left pane when the detail is pinned.
+func (m Model) dashboardGridWidth() int {
+	if m.pinnedDetailActive() {
+		tilesW, _ := pinnedPaneWidths(m.width)
+		return tilesW
+	}
+	return m.width
+}
+
+func (m Model) renderPinnedDetail(w, h int) string {
+	tilesW, detailW := pinnedPaneWidths(w)
+	sep := renderVerticalSep(h)
+	if m.pinnedFocus {
+		lines := make([]string, h)
+		for i := range lines {
+			lines[i] = lipgloss.NewStyle().Foreground(colorAccent).Render("┃")
+		}
+		sep = strings.Join(lines, "\n")
+	}
+	left := padToSize(m.renderDashboardView(tilesW, h), tilesW, h)
+	right := padToSize(m.renderDetailPanel(detailW, h), detailW, h)
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, sep, right)
+}
+
+// togglePinnedDetail shows or hides the pinned detail pane. Focus always
+// starts on the tiles.
+func (m Model) togglePinnedDetail() Model {
+	m.pinnedDetail = !m.pinnedDetail
+	m.pinnedFocus = false
+	m.detailOffset = 0
+	m.tileOffset = 0
+	m.invalidateTileBodyCache()
+	return m
+}
+
+// handlePinnedDetailKey scrolls the pinned pane while it has focus. Esc
+// hands focus back to the tiles.
+func (m Model) handlePinnedDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.pinnedFocus = false
+	case "up", "k":
+		if m.detailOffset > 0 {
+			m.detailOffset--
+		}
+	case "down", "j":
+		m.detailOffset++
+	case "home", "g":
+		m.detailOffset = 0
+	case "end", "G":
+		m.detailOffset = 9999
+	case "[":
+		if m.detailTab > 0 {
+			m.detailTab--
+			m.detailOffset = 0
+		}
+	case "]":
+		m.detailTab++
+		m.detailOffset = 0
+	case "d":
+		m.detailChartDays = nextDetailChartRange(m.detailChartDays)
+	case "pgdown", "ctrl+d":
+		m.detailOffset += m.detailPageStep()
+	case "pgup", "ctrl+u":
+		m.detailOffset = max(0, m.detailOffset-m.detailPageStep())
+	case "enter":
+		m.pinnedFocus = false
+		m = m.enterDetailMode()
+	case "r":
+		m = m.refreshSelectedAccount()
+	}
+	return m, nil
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func pressKey(t *testing.T, m Model, msg tea.KeyMsg) Model {
+	t.Helper()
+	updated, _ := m.Update(msg)
+	return updated.(Model)
+}
+
+func TestPinnedDetail_ToggleAndFocus(t *testing.T) {
+	m := layoutTestModel(t, 140, 30, 3)
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if !m.pinnedDetailActive() {
+		t.Fatal("p should pin the detail pane")
+	}
+	if m.pinnedDetailFocused() {
+		t.Fatal("pinning should leave focus on the tiles")
+	}
+
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	if !m.pinnedDetailFocused() || m.screen != screenDashboard {
+		t.Fatalf("tab should focus the pinned pane, focused=%v screen=%v", m.pinnedDetailFocused(), m.screen)
+	}
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	if m.detailOffset != 1 || m.cursor != 0 {
+		t.Fatalf("down in pinned pane: detailOffset=%d cursor=%d, want 1 and 0", m.detailOffset, m.cursor)
+	}
+
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.pinnedDetailFocused() || !m.pinnedDetailActive() {
+		t.Fatal("esc should return focus to the tiles and keep the pane pinned")
+	}
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	if m.cursor != 1 || m.detailOffset != 0 {
+		t.Fatalf("moving selection: cursor=%d detailOffset=%d, want 1 and 0", m.cursor, m.detailOffset)
+	}
+
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if m.pinnedDetailActive() {
+		t.Fatal("p again should unpin")
+	}
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	if m.screen == screenDashboard {
+		t.Fatal("without a pinned pane tab should switch screens")
+	}
+}
+
+func TestPinnedDetail_RendersBothPanes(t *testing.T) {
+	m := layoutTestModel(t, 140, 30, 3)
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+
+	view := m.View()
+	for i, line := range strings.Split(view, "\n") {
+		if got := lipgloss.Width(line); got > m.width {
+			t.Fatalf("line %d width %d exceeds terminal width %d", i, got, m.width)
+		}
+	}
+	if !strings.Contains(view, "┃") {
+		t.Fatal("expected a separator between tiles and pinned detail")
+	}
+	if got := m.tileCols(); got != 1 {
+		t.Fatalf("tileCols() = %d, want tiles laid out in the narrower left pane", got)
+	}
+}
+
+func TestPinnedDetail_HiddenOnNarrowTerminals(t *testing.T) {
+	m := layoutTestModel(t, 90, 30, 3)
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if !m.pinnedDetail {
+		t.Fatal("p should still record the pin")
+	}
+	if m.pinnedDetailActive() {
+		t.Fatalf("pinned pane should not show below %d columns", minPinnedDetailWidth)
+	}
+
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 30})
+	if !updated.(Model).pinnedDetailActive() {
+		t.Fatal("pinned pane should appear once the terminal is wide enough")
+	}
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
+)
+
+type redactedSnapshotEntry struct {
+	hash uint64
+	snap core.UsageSnapshot
+}
+
+// setPrivacyMode switches privacy mode. Dollar amounts are hidden at the
+// locale level so every formatter picks it up; identities are masked per
+// snapshot in privacySnapshots.
+func (m *Model) setPrivacyMode(on bool) {
+	m.privacyMode = on
+	locale.Set(locale.Current().WithHiddenAmounts(on))
+	m.redactedSnapshots = make(map[string]redactedSnapshotEntry)
+	m.invalidateRenderCaches()
+}
+
+func (m Model) togglePrivacyMode() (tea.Model, tea.Cmd) {
+	m.setPrivacyMode(!m.privacyMode)
+	if m.privacyMode {
+		m.actionNotice = "privacy mode on"
+	} else {
+		m.actionNotice = "privacy mode off"
+	}
+	return m, m.persistDashboardPrivacyModeCmd()
+}
+
+// privacySnapshots returns the snapshots to render: as-is normally, with
+// emails, key labels, and org names masked in privacy mode. Redacted copies
+// are cached per account until its snapshot hash changes.
+func (m Model) privacySnapshots() map[string]core.UsageSnapshot {
+	if !m.privacyMode {
+		return m.snapshots
+	}
+	out := make(map[string]core.UsageSnapshot, len(m.snapshots))
+	for id, snap := range m.snapshots {
+		hash, hashed := m.snapshotHashes[id]
+		if entry, ok := m.redactedSnapshots[id]; ok && hashed && entry.hash == hash {
+			out[id] = entry.snap
+			continue
+		}
+		redacted := core.RedactSnapshot(snap)
+		if hashed && m.redactedSnapshots != nil {
+			m.redactedSnapshots[id] = redactedSnapshotEntry{hash: hash, snap: redacted}
+		}
+		out[id] = redacted
+	}
+	return out
+}
+
+// visibleLogLines masks emails and dollar amounts in the log pane while
+// privacy mode is on.
+func (m Model) visibleLogLines() []string {
+	lines := m.logLines()
+	if !m.privacyMode {
+		return lines
+	}
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = core.RedactText(line)
+	}
+	return out
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
+)
+
+func privacyTestModel(t *testing.T, cfg config.DashboardConfig) Model {
+	t.Helper()
+	prev := locale.Current()
+	t.Cleanup(func() { locale.Set(prev) })
+
+	snap := core.NewUsageSnapshot("openai", "openai-work")
+	snap.Status = core.StatusOK
+	snap.Timestamp = time.Now()
+	snap.Attributes["account_email"] = "jane.doe@example.com"
+	snap.Attributes["org_display_name"] = "Acme Research"
+	used, limit := 12.34, 100.0
+	snap.Metrics["spend_limit"] = core.Metric{Used: &used, Limit: &limit, Unit: "USD", Window: "month"}
+
+	m := NewModel(0.2, 0.1, false, cfg, nil, core.TimeWindow30d)
+	m.SetServices(&fakeServices{})
+	updated, _ := m.Update(SnapshotsMsg{Snapshots: map[string]core.UsageSnapshot{snap.AccountID: snap}, TimeWindow: core.TimeWindow30d, RequestID: 1})
+	updated, _ = updated.(Model).Update(tea.WindowSizeMsg{Width: 160, Height: 48})
+	return updated.(Model)
+}
+
+func TestPrivacyMode_ToggleMasksIdentitiesAndAmounts(t *testing.T) {
+	m := privacyTestModel(t, config.DashboardConfig{})
+	before := m.View()
+	for _, want := range []string{"jane.doe@example.com", "$12"} {
+		if !strings.Contains(before, want) {
+			t.Fatalf("view without privacy mode should contain %q", want)
+		}
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	m = updated.(Model)
+	if !m.privacyMode {
+		t.Fatal("P should turn privacy mode on")
+	}
+	if cmd == nil {
+		t.Fatal("toggling privacy mode should persist it")
+	}
+	if _, ok := cmd().(dashboardPrivacyModePersistedMsg); !ok {
+		t.Fatal("persist cmd should report dashboardPrivacyModePersistedMsg")
+	}
+
+	view := m.View()
+	for _, leak := range []string{"jane.doe@example.com", "Acme Research", "$12", "$100"} {
+		if strings.Contains(view, leak) {
+			t.Errorf("privacy mode view still shows %q", leak)
+		}
+	}
+	if !strings.Contains(view, "j•••@e•••.com") {
+		t.Error("privacy mode should show the masked email")
+	}
+	if !strings.Contains(view, "privacy") {
+		t.Error("status bar should flag privacy mode")
+	}
+	if m.snapshots["openai-work"].Attributes["account_email"] != "jane.doe@example.com" {
+		t.Fatal("privacy mode must not modify the stored snapshot")
+	}
+
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	if m.privacyMode || locale.Current().HideAmounts {
+		t.Fatal("second P should turn privacy mode off")
+	}
+	if !strings.Contains(m.View(), "jane.doe@example.com") {
+		t.Fatal("turning privacy mode off should restore the email")
+	}
+}
+
+func TestPrivacyMode_FromConfig(t *testing.T) {
+	m := privacyTestModel(t, config.DashboardConfig{PrivacyMode: true})
+	if !m.privacyMode || !locale.Current().HideAmounts {
+		t.Fatal("dashboard.privacy_mode should start the model in privacy mode")
+	}
+	if got := formatMoney(42.5, 2); got != "$•••" {
+		t.Fatalf("formatMoney in privacy mode = %q, want $•••", got)
+	}
+}
+
+func TestPrivacyMode_MasksLogPane(t *testing.T) {
+	m := privacyTestModel(t, config.DashboardConfig{PrivacyMode: true})
+	m.SetLogSource(func() []string { return []string{"poll ok for jane.doe@example.com balance $4.20"} })
+	lines := m.visibleLogLines()
+	if len(lines) != 1 || lines[0] != "poll ok for j•••@e•••.com balance $•••" {
+		t.Fatalf("visibleLogLines() = %q", lines)
+	}
+}
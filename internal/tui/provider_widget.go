@@ -5,6 +5,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/janekbaraniewski/openusage/internal/config"
 	"github.com/janekbaraniewski/openusage/internal/core"
 	"github.com/janekbaraniewski/openusage/internal/providers"
 )
@@ -18,6 +19,7 @@ var (
 	providerWidgetOverridesMu    sync.RWMutex
 	providerSectionOrderOverride []core.DashboardStandardSection
 	providerSectionOverrideSet   bool
+	providerWidgetConfigs        map[string]config.ProviderWidgetConfig
 
 	detailSectionOverridesMu   sync.RWMutex
 	detailSectionOrderOverride []core.DetailStandardSection
@@ -44,10 +46,11 @@ func loadProviderSpecs() {
 func dashboardWidget(providerID string) core.DashboardWidget {
 	loadProviderSpecs()
 
-	if cfg, ok := providerWidgets[providerID]; ok {
-		return applyDashboardSectionOverride(cfg)
+	cfg, ok := providerWidgets[providerID]
+	if !ok {
+		cfg = core.DefaultDashboardWidget()
 	}
-	return applyDashboardSectionOverride(core.DefaultDashboardWidget())
+	return applyProviderWidgetConfig(applyDashboardSectionOverride(cfg), providerID)
 }
 
 type apiKeyProviderEntry struct {
@@ -265,6 +268,43 @@ func setDashboardWidgetSectionOverrides(sections []core.DashboardStandardSection
 	providerSectionOverrideSet = true
 }
 
+// setProviderWidgetOverrides installs dashboard.provider_widgets, which
+// replace a provider's gauge priority and compact rows.
+func setProviderWidgetOverrides(widgets map[string]config.ProviderWidgetConfig) {
+	providerWidgetOverridesMu.Lock()
+	defer providerWidgetOverridesMu.Unlock()
+	providerWidgetConfigs = widgets
+}
+
+func applyProviderWidgetConfig(cfg core.DashboardWidget, providerID string) core.DashboardWidget {
+	providerWidgetOverridesMu.RLock()
+	override, ok := providerWidgetConfigs[providerID]
+	providerWidgetOverridesMu.RUnlock()
+	if !ok {
+		return cfg
+	}
+
+	if len(override.GaugePriority) > 0 {
+		cfg.GaugePriority = append([]string(nil), override.GaugePriority...)
+	}
+	if len(override.CompactRows) > 0 {
+		rows := make([]core.DashboardCompactRow, 0, len(override.CompactRows))
+		for _, row := range override.CompactRows {
+			maxSegments := row.MaxSegments
+			if maxSegments <= 0 {
+				maxSegments = 4
+			}
+			rows = append(rows, core.DashboardCompactRow{
+				Label:       row.Label,
+				Keys:        append([]string(nil), row.Keys...),
+				MaxSegments: maxSegments,
+			})
+		}
+		cfg.CompactRows = rows
+	}
+	return cfg
+}
+
 func setDetailSectionOverrides(sections []core.DetailStandardSection) {
 	detailSectionOverridesMu.Lock()
 	defer detailSectionOverridesMu.Unlock()
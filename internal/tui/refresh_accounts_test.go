@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestRefreshKeys_ForcePollAccounts(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		wantAll     bool
+		wantPolling int
+	}{
+		{name: "r refreshes the focused account", key: "r", wantPolling: 1},
+		{name: "R refreshes every account", key: "R", wantAll: true, wantPolling: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := layoutTestModel(t, 160, 48, 3)
+			var calls [][]string
+			m.SetOnRefreshAccounts(func(ids []string) { calls = append(calls, ids) })
+			selected := m.selectedTileID(m.filteredIDs())
+
+			m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+			if len(calls) != 1 {
+				t.Fatalf("onRefreshAccounts calls = %d, want 1", len(calls))
+			}
+			if tt.wantAll {
+				if calls[0] != nil {
+					t.Fatalf("ids = %v, want nil (all accounts)", calls[0])
+				}
+			} else if !reflect.DeepEqual(calls[0], []string{selected}) {
+				t.Fatalf("ids = %v, want [%s]", calls[0], selected)
+			}
+			if len(m.pollingAccounts) != tt.wantPolling {
+				t.Fatalf("pollingAccounts = %v, want %d entries", m.pollingAccounts, tt.wantPolling)
+			}
+			if m.refreshing {
+				t.Fatal("a forced poll should not mark the whole dashboard refreshing")
+			}
+			if got := m.nextTickInterval(); got != tickFast {
+				t.Fatalf("nextTickInterval() = %v while polling, want tickFast", got)
+			}
+		})
+	}
+}
+
+func TestRefreshKeys_FallBackWithoutDaemonHook(t *testing.T) {
+	m := layoutTestModel(t, 160, 48, 3)
+	refreshed := 0
+	m.SetOnRefresh(func(_ core.TimeWindow) { refreshed++ })
+
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if refreshed != 1 || !m.refreshing {
+		t.Fatalf("refreshed=%d refreshing=%v, want a plain re-read", refreshed, m.refreshing)
+	}
+	if len(m.pollingAccounts) != 0 {
+		t.Fatalf("pollingAccounts = %v, want none", m.pollingAccounts)
+	}
+}
+
+func TestAccountsRefreshedMsg_ClearsSpinners(t *testing.T) {
+	m := layoutTestModel(t, 160, 48, 3)
+	m.SetOnRefreshAccounts(func([]string) {})
+	selected := m.selectedTileID(m.filteredIDs())
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+
+	updated, _ := m.Update(AccountsRefreshedMsg{AccountIDs: []string{selected}})
+	m = updated.(Model)
+	if m.pollingAccounts[selected] || len(m.pollingAccounts) != 2 {
+		t.Fatalf("pollingAccounts = %v, want the other two accounts", m.pollingAccounts)
+	}
+
+	updated, _ = m.Update(AccountsRefreshedMsg{Err: errors.New("daemon unavailable")})
+	m = updated.(Model)
+	if len(m.pollingAccounts) != 0 {
+		t.Fatalf("pollingAccounts = %v, want none after refresh-all completes", m.pollingAccounts)
+	}
+	if !strings.Contains(m.actionNotice, "refresh failed: daemon unavailable") {
+		t.Fatalf("actionNotice = %q, want the refresh error", m.actionNotice)
+	}
+}
+
+func TestTileRenderCacheKey_AnimatesPollingTile(t *testing.T) {
+	m := layoutTestModel(t, 160, 48, 2)
+	m.SetOnRefreshAccounts(func([]string) {})
+	m = m.refreshAccounts([]string{m.sortedIDs[0]})
+
+	polling := m.snapshots[m.sortedIDs[0]]
+	idle := m.snapshots[m.sortedIDs[1]]
+	keys := func(frame int) (string, string) {
+		m.animFrame = frame
+		return m.tileRenderCacheKey(polling, false, false, 40, 10, 0, "", ""),
+			m.tileRenderCacheKey(idle, false, false, 40, 10, 0, "", "")
+	}
+	pollA, idleA := keys(1)
+	pollB, idleB := keys(2)
+	if pollA == pollB {
+		t.Fatal("polling tile cache key should change with the spinner frame")
+	}
+	if idleA != idleB {
+		t.Fatal("idle tile cache key should not change with the spinner frame")
+	}
+}
@@ -12,8 +12,36 @@ type detailRenderCacheEntry struct {
 	content string
 }
 
+// invalidateTileBodyCache drops every cached tile body and rendered tile.
 func (m *Model) invalidateTileBodyCache() {
-	m.tileBodyCache = make(map[string][]string)
+	m.tileBodyCache = make(map[string]tileBodyCacheEntry)
+	m.tileRenderCache = make(map[string]tileRenderCacheEntry)
+}
+
+// pruneTileCaches drops cached tiles for accounts that left the snapshot
+// set. Changed snapshots need no explicit eviction: their hash no longer
+// matches the cache key.
+func (m *Model) pruneTileCaches() {
+	for id := range m.tileBodyCache {
+		if _, ok := m.snapshots[id]; !ok {
+			delete(m.tileBodyCache, id)
+		}
+	}
+	for id := range m.tileRenderCache {
+		if _, ok := m.snapshots[id]; !ok {
+			delete(m.tileRenderCache, id)
+		}
+	}
+}
+
+// recordSnapshotHashes fingerprints the current snapshots so tile caches can
+// tell which accounts actually changed between frames.
+func (m *Model) recordSnapshotHashes() {
+	hashes := make(map[string]uint64, len(m.snapshots))
+	for _, snap := range m.snapshots {
+		hashes[snap.AccountID] = snapshotRenderHash(snap)
+	}
+	m.snapshotHashes = hashes
 }
 
 func (m *Model) invalidateDetailCache() {
@@ -42,6 +70,7 @@ func (m *Model) cachedDetailContent(id string, snap core.UsageSnapshot, w int, a
 		strconv.Itoa(len(snap.Diagnostics)),
 		strconv.Itoa(len(snap.Raw)),
 		string(m.timeWindow),
+		strconv.Itoa(m.detailChartDays),
 		strconv.FormatFloat(m.warnThreshold, 'f', 4, 64),
 		strconv.FormatFloat(m.critThreshold, 'f', 4, 64),
 		strconv.FormatBool(hideCosts),
@@ -50,7 +79,7 @@ func (m *Model) cachedDetailContent(id string, snap core.UsageSnapshot, w int, a
 		return m.detailCache.content
 	}
 
-	content := RenderDetailContent(snap, m.viewNow(), w, m.warnThreshold, m.critThreshold, activeTab, m.timeWindow, hideCosts)
+	content := RenderDetailContentWithRange(snap, m.viewNow(), w, m.warnThreshold, m.critThreshold, activeTab, m.timeWindow, hideCosts, m.detailChartDays)
 	m.detailCache = detailRenderCacheEntry{
 		key:     key,
 		content: content,
@@ -236,7 +236,7 @@ func (m Model) applyTimeWindowAtCursor(rows []telemetryRow) (Model, tea.Cmd, boo
 	if rows[cursor].kind != telemetryRowKindTimeWindow {
 		return m, nil, false
 	}
-	tws := core.ValidTimeWindows
+	tws := core.TimeWindows()
 	idx := clamp(rows[cursor].index, 0, len(tws)-1)
 	selected := tws[idx]
 	m.settings.status = "saving time window..."
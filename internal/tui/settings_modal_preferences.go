@@ -332,7 +332,7 @@ func (m Model) renderSettingsTelemetryBody(w, h int) string {
 	rows := m.telemetryRows()
 	cursor := m.telemetryRowCursor()
 
-	for i, tw := range core.ValidTimeWindows {
+	for i, tw := range core.TimeWindows() {
 		prefix := "  "
 		if isTelemetryCursorOn(rows, cursor, telemetryRowKindTimeWindow, i) {
 			prefix = lipgloss.NewStyle().Foreground(colorAccent).Bold(true).Render("➤ ")
@@ -425,15 +425,16 @@ const (
 
 type telemetryRow struct {
 	kind  telemetryRowKind
-	index int // index into ValidTimeWindows OR telemetryUnmappedDetails
+	index int // index into core.TimeWindows() OR telemetryUnmappedDetails
 }
 
 func (m Model) telemetryRows() []telemetryRow {
 	// One call to telemetryUnmappedDetails() per row computation; the
 	// previous code called it twice (once for cap, once for the loop).
 	unmapped := m.telemetryUnmappedDetails()
-	rows := make([]telemetryRow, 0, len(core.ValidTimeWindows)+len(unmapped))
-	for i := range core.ValidTimeWindows {
+	windows := core.TimeWindows()
+	rows := make([]telemetryRow, 0, len(windows)+len(unmapped))
+	for i := range windows {
 		rows = append(rows, telemetryRow{kind: telemetryRowKindTimeWindow, index: i})
 	}
 	for i := range unmapped {
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func layoutTestModel(t *testing.T, w, h, accounts int) Model {
+	t.Helper()
+	m := NewModel(0.2, 0.1, false, config.DashboardConfig{}, nil, core.TimeWindow30d)
+	updated, _ := m.Update(SnapshotsMsg{Snapshots: tileCacheTestSnapshots(accounts, 40), TimeWindow: core.TimeWindow30d, RequestID: 1})
+	updated, _ = updated.(Model).Update(tea.WindowSizeMsg{Width: w, Height: h})
+	return updated.(Model)
+}
+
+func TestDashboardLayoutsFitTerminal(t *testing.T) {
+	tests := []struct {
+		name          string
+		w, h          int
+		accounts      int
+		wantView      dashboardViewMode
+		wantCollapsed bool
+	}{
+		{name: "minimum size", w: 30, h: 8, accounts: 5, wantView: dashboardViewCompact, wantCollapsed: true},
+		{name: "phone-sized", w: 40, h: 12, accounts: 5, wantView: dashboardViewCompact, wantCollapsed: true},
+		{name: "narrow split pane", w: 60, h: 24, accounts: 5, wantView: dashboardViewCompact, wantCollapsed: true},
+		{name: "just below threshold", w: 69, h: 30, accounts: 5, wantView: dashboardViewCompact, wantCollapsed: true},
+		{name: "single account narrow", w: 40, h: 20, accounts: 1, wantView: dashboardViewCompact, wantCollapsed: true},
+		{name: "wide but short", w: 100, h: 16, accounts: 5, wantView: dashboardViewStacked, wantCollapsed: true},
+		{name: "standard 80x24", w: 80, h: 24, accounts: 5, wantView: dashboardViewStacked, wantCollapsed: false},
+		{name: "large", w: 160, h: 48, accounts: 5, wantView: dashboardViewGrid, wantCollapsed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := layoutTestModel(t, tt.w, tt.h, tt.accounts)
+			if got := m.activeDashboardView(); got != tt.wantView {
+				t.Fatalf("activeDashboardView() = %q, want %q", got, tt.wantView)
+			}
+			if got := m.collapseChrome(); got != tt.wantCollapsed {
+				t.Fatalf("collapseChrome() = %v, want %v", got, tt.wantCollapsed)
+			}
+
+			lines := strings.Split(m.View(), "\n")
+			if len(lines) != tt.h {
+				t.Fatalf("view has %d lines, want %d", len(lines), tt.h)
+			}
+			for i, line := range lines {
+				if lw := lipgloss.Width(line); lw > tt.w {
+					t.Fatalf("line %d is %d columns wide, terminal is %d:\n%q", i, lw, tt.w, line)
+				}
+			}
+
+			header := lines[0]
+			if tt.wantCollapsed {
+				if strings.Contains(header, "OpenUsage") || strings.HasPrefix(lines[1], "━") {
+					t.Fatalf("expected a collapsed one-line header, got:\n%s\n%s", lines[0], lines[1])
+				}
+			} else if !strings.Contains(header, "OpenUsage") {
+				t.Fatalf("expected the full header, got %q", header)
+			}
+		})
+	}
+}
+
+func TestCompactListNavigatesAccounts(t *testing.T) {
+	m := layoutTestModel(t, 50, 20, 4)
+
+	view := m.View()
+	if !strings.Contains(view, "openai-00") || !strings.Contains(view, "openai-01") {
+		t.Fatalf("compact list should show one row per account, got:\n%s", view)
+	}
+	if !strings.Contains(view, "░") {
+		t.Fatalf("compact list should show a mini gauge per account, got:\n%s", view)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(Model)
+	if m.cursor != 1 {
+		t.Fatalf("cursor = %d after down, want 1", m.cursor)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if updated.(Model).mode != modeDetail {
+		t.Fatal("enter should open the selected account's detail view")
+	}
+}
+
+func TestTileGaugeLabelWidthAbbreviatesOnNarrowTiles(t *testing.T) {
+	if got := tileGaugeLabelWidth(30); got >= tileGaugeLabelWidth(80) {
+		t.Fatalf("narrow label width %d should be below wide %d", got, tileGaugeLabelWidth(80))
+	}
+}
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// snoozeRuleForStatus maps a tile status to the warning rule that can silence
+// it, or "" when the status is not a snoozable warning. Errors, auth and
+// maintenance stay loud: they need action, not acknowledgement.
+func snoozeRuleForStatus(s core.Status) string {
+	switch s {
+	case core.StatusLimited, core.StatusExhausted:
+		return config.SnoozeRuleLimit
+	case core.StatusNearLimit:
+		return config.SnoozeRuleNearLimit
+	default:
+		return ""
+	}
+}
+
+// activeSnooze returns when the snooze for accountID/rule ends, if one is
+// still running at now.
+func (m Model) activeSnooze(accountID, rule string, now time.Time) (time.Time, bool) {
+	if rule == "" {
+		return time.Time{}, false
+	}
+	for _, sn := range m.warningSnoozes {
+		if sn.AccountID == accountID && sn.Rule == rule && sn.Until.After(now) {
+			return sn.Until, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// snoozedWarning reports the warning status a snooze is currently hiding on
+// the tile, and when it ends.
+func (m Model) snoozedWarning(snap core.UsageSnapshot) (core.Status, time.Time, bool) {
+	raw := snap.Status
+	until, ok := m.activeSnooze(snap.AccountID, snoozeRuleForStatus(raw), m.viewNow())
+	return raw, until, ok
+}
+
+// tileBadge renders the status badge, a dim "zz LIMIT 5h" badge when the
+// warning has been acknowledged, or a dim "retrying…" one while a failed
+// fetch is retried.
+func (m Model) tileBadge(snap core.UsageSnapshot, status core.Status) string {
+	if m.retryingFetch(snap) {
+		return dimStyle.Render("↻ retrying…")
+	}
+	raw, until, ok := m.snoozedWarning(snap)
+	if !ok {
+		return StatusBadge(status)
+	}
+	label := "WARN"
+	switch raw {
+	case core.StatusLimited:
+		label = "LIMIT"
+	case core.StatusExhausted:
+		label = "FULL"
+	}
+	return dimStyle.Render(fmt.Sprintf("💤 %s %s", label, formatDuration(until.Sub(m.viewNow()))))
+}
+
+// toggleWarningSnooze acknowledges the focused tile's current warning for the
+// rule's configured period, or lifts an active snooze. Returns handled=false
+// when no tile is focused or it has nothing to snooze.
+func (m Model) toggleWarningSnooze() (Model, tea.Cmd, bool) {
+	accountID := m.selectedTileID(m.filteredIDs())
+	snap, ok := m.snapshots[accountID]
+	if accountID == "" || !ok {
+		return m, nil, false
+	}
+	now := m.viewNow()
+	raw := snap.Status
+	rule := snoozeRuleForStatus(raw)
+	if rule == "" {
+		return m, nil, false
+	}
+
+	kept := make([]config.WarningSnooze, 0, len(m.warningSnoozes)+1)
+	for _, sn := range m.warningSnoozes {
+		if sn.AccountID == accountID && sn.Rule == rule {
+			continue
+		}
+		if sn.Until.After(now) {
+			kept = append(kept, sn)
+		}
+	}
+	if _, active := m.activeSnooze(accountID, rule, now); !active {
+		dur := config.DashboardConfig{SnoozeHours: m.snoozeHours}.SnoozeDuration(rule)
+		kept = append(kept, config.WarningSnooze{AccountID: accountID, Rule: rule, Until: now.Add(dur)})
+	}
+	m.warningSnoozes = kept
+	m.invalidateRenderCaches()
+	return m, m.persistWarningSnoozesCmd(), true
+}
+
+func (m Model) persistWarningSnoozesCmd() tea.Cmd {
+	snoozes := append([]config.WarningSnooze(nil), m.warningSnoozes...)
+	return func() tea.Msg {
+		if m.services == nil {
+			return warningSnoozesPersistedMsg{err: fmt.Errorf("snooze service unavailable")}
+		}
+		err := m.services.SaveDashboardSnoozes(snoozes)
+		if err != nil {
+			log.Printf("warning snooze persist: %v", err)
+		}
+		return warningSnoozesPersistedMsg{err: err}
+	}
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func TestToggleWarningSnooze(t *testing.T) {
+	accounts := []core.AccountConfig{{ID: "codex", Provider: "codex"}}
+	m := NewModel(0.2, 0.1, false, config.DashboardConfig{SnoozeHours: map[string]int{config.SnoozeRuleLimit: 72}}, accounts, core.TimeWindow7d)
+	m.services = &fakeServices{}
+	snap := core.UsageSnapshot{ProviderID: "codex", AccountID: "codex", Status: core.StatusLimited}
+	m.snapshots = map[string]core.UsageSnapshot{"codex": snap}
+	m.sortedIDs = []string{"codex"}
+
+	if got := m.tileStatus(snap); got != core.StatusLimited {
+		t.Fatalf("status before snooze = %s, want LIMITED", got)
+	}
+
+	updated, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("snooze should persist via a command")
+	}
+	if len(m.warningSnoozes) != 1 || m.warningSnoozes[0].Rule != config.SnoozeRuleLimit {
+		t.Fatalf("snoozes = %#v, want one limit snooze", m.warningSnoozes)
+	}
+	if d := m.warningSnoozes[0].Until.Sub(m.viewNow()); d < 71*time.Hour || d > 72*time.Hour {
+		t.Errorf("snooze length = %s, want the configured 72h", d)
+	}
+	if got := m.tileStatus(snap); got != core.StatusOK {
+		t.Errorf("status while snoozed = %s, want OK", got)
+	}
+	if badge := ansi.Strip(m.tileBadge(snap, m.tileStatus(snap))); !strings.Contains(badge, "💤 LIMIT") {
+		t.Errorf("badge while snoozed = %q, want a 💤 LIMIT countdown", badge)
+	}
+
+	// A snooze only silences its own rule.
+	near := snap
+	near.Status = core.StatusNearLimit
+	if got := m.tileStatus(near); got != core.StatusNearLimit {
+		t.Errorf("near-limit status under a limit snooze = %s, want NEAR_LIMIT", got)
+	}
+
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	m = updated.(Model)
+	if len(m.warningSnoozes) != 0 || m.tileStatus(snap) != core.StatusLimited {
+		t.Errorf("second z should lift the snooze, got %#v", m.warningSnoozes)
+	}
+}
+
+func TestToggleWarningSnooze_IgnoresNonWarnings(t *testing.T) {
+	accounts := []core.AccountConfig{{ID: "codex", Provider: "codex"}}
+	m := NewModel(0.2, 0.1, false, config.DashboardConfig{}, accounts, core.TimeWindow7d)
+	m.snapshots = map[string]core.UsageSnapshot{"codex": {ProviderID: "codex", AccountID: "codex", Status: core.StatusError}}
+	m.sortedIDs = []string{"codex"}
+
+	if _, _, handled := m.toggleWarningSnooze(); handled {
+		t.Error("errors must not be snoozable")
+	}
+}
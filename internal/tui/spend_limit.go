@@ -0,0 +1,252 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// spendLimitPromptState drives the "Set spend limit" quick action: type a
+// new cap, review it, then confirm before anything is sent to the provider.
+type spendLimitPromptState struct {
+	active     bool
+	accountID  string
+	metricKey  string // metric whose Limit is the current cap
+	input      string
+	confirming bool
+	limit      *float64 // parsed input under review; nil removes the cap
+	resolving  bool     // waiting for the provider to name the target
+	target     string   // key or object the change applies to, if named
+	err        string
+}
+
+// spendLimitSetMsg reports the outcome of a spend limit change.
+type spendLimitSetMsg struct {
+	AccountID string
+	LimitUSD  *float64
+	Err       error
+}
+
+// spendLimitPreviewMsg carries what a spend limit change would apply to.
+type spendLimitPreviewMsg struct {
+	AccountID string
+	Target    string
+	Err       error
+}
+
+// SetOnPreviewSpendLimit sets the callback that asks the provider which key
+// or object a spend limit change would apply to, shown in the confirmation.
+func (m *Model) SetOnPreviewSpendLimit(fn func(accountID string) (string, error)) {
+	m.onPreviewSpendLimit = fn
+}
+
+// SetOnSetSpendLimit sets the callback that changes an account's spending
+// cap through its provider (nil removes the cap). Without it the "Set spend
+// limit" quick action explains that the daemon is needed.
+func (m *Model) SetOnSetSpendLimit(fn func(accountID string, limitUSD *float64) error) {
+	m.onSetSpendLimit = fn
+}
+
+func (m Model) openSpendLimitPrompt(accountID, metricKey string) Model {
+	if m.onSetSpendLimit == nil {
+		m.actionNotice = "changing spend limits needs the telemetry daemon"
+		return m
+	}
+	m.spendLimit = spendLimitPromptState{active: true, accountID: accountID, metricKey: metricKey}
+	if met, ok := m.snapshots[accountID].Metrics[metricKey]; ok && met.Limit != nil && !m.privacyMode {
+		m.spendLimit.input = strconv.FormatFloat(*met.Limit, 'f', -1, 64)
+	}
+	return m
+}
+
+func (m Model) handleSpendLimitKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := &m.spendLimit
+	key := msg.String()
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+	if p.confirming {
+		switch key {
+		case "y", "Y":
+			if p.resolving {
+				return m, nil
+			}
+			accountID, limit := p.accountID, p.limit
+			m.spendLimit = spendLimitPromptState{}
+			m.actionNotice = "setting spend limit for " + accountID + "…"
+			return m, m.setSpendLimitCmd(accountID, limit)
+		case "n", "N", "esc":
+			p.confirming, p.resolving, p.target = false, false, ""
+		}
+		return m, nil
+	}
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.spendLimit = spendLimitPromptState{}
+	case tea.KeyEnter:
+		limit, err := parseSpendLimitInput(p.input)
+		if err != nil {
+			p.err = err.Error()
+			return m, nil
+		}
+		p.err = ""
+		p.limit = limit
+		p.confirming = true
+		if m.onPreviewSpendLimit != nil {
+			p.resolving = true
+			return m, m.previewSpendLimitCmd(p.accountID)
+		}
+	case tea.KeyBackspace:
+		if p.input != "" {
+			p.input = p.input[:len(p.input)-1]
+		}
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			if (r >= '0' && r <= '9' || r == '.') && len(p.input) < 12 {
+				p.input += string(r)
+			}
+		}
+	}
+	return m, nil
+}
+
+// parseSpendLimitInput reads the prompt's dollar amount; empty input means
+// "remove the limit".
+func parseSpendLimitInput(input string) (*float64, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(input, 64)
+	if err != nil || v < 0 {
+		return nil, fmt.Errorf("enter a dollar amount, e.g. 25 or 12.50")
+	}
+	return &v, nil
+}
+
+func (m Model) setSpendLimitCmd(accountID string, limit *float64) tea.Cmd {
+	fn := m.onSetSpendLimit
+	return func() tea.Msg {
+		if fn == nil {
+			return spendLimitSetMsg{AccountID: accountID, LimitUSD: limit, Err: fmt.Errorf("spend limit callback not configured")}
+		}
+		return spendLimitSetMsg{AccountID: accountID, LimitUSD: limit, Err: fn(accountID, limit)}
+	}
+}
+
+func (m Model) previewSpendLimitCmd(accountID string) tea.Cmd {
+	fn := m.onPreviewSpendLimit
+	return func() tea.Msg {
+		target, err := fn(accountID)
+		return spendLimitPreviewMsg{AccountID: accountID, Target: target, Err: err}
+	}
+}
+
+// handleSpendLimitPreviewMsg fills in the confirmation's target. A provider
+// that cannot tell which key it would change sends the prompt back to
+// editing with the reason, so nothing can be confirmed blind.
+func (m Model) handleSpendLimitPreviewMsg(msg spendLimitPreviewMsg) Model {
+	p := &m.spendLimit
+	if !p.active || !p.confirming || !p.resolving || p.accountID != msg.AccountID {
+		return m
+	}
+	p.resolving = false
+	if msg.Err != nil {
+		p.confirming = false
+		p.err = msg.Err.Error()
+		return m
+	}
+	p.target = msg.Target
+	return m
+}
+
+// handleSpendLimitSetMsg reports the change and re-polls the account so the
+// tile shows the new cap.
+func (m Model) handleSpendLimitSetMsg(msg spendLimitSetMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.actionNotice = "spend limit failed: " + msg.Err.Error()
+		return m, nil
+	}
+	m = m.refreshAccounts([]string{msg.AccountID})
+	m.actionNotice = fmt.Sprintf("spend limit for %s set to %s ✓", msg.AccountID, spendLimitLabel(msg.LimitUSD))
+	return m, nil
+}
+
+func spendLimitLabel(limit *float64) string {
+	if limit == nil {
+		return "no limit"
+	}
+	return formatMoney(*limit, 2)
+}
+
+func (m Model) renderSpendLimitOverlay(screenW, screenH int) string {
+	p := m.spendLimit
+	snap := m.snapshots[p.accountID]
+	met, hasMetric := snap.Metrics[p.metricKey]
+
+	current := "unknown"
+	if hasMetric {
+		current = spendLimitLabel(met.Limit)
+		if met.Used != nil {
+			current += " · used " + formatMoney(*met.Used, 2)
+		}
+	}
+
+	lines := []string{
+		accentBoldStyle.Render("Set spend limit") + "  " + dimStyle.Render(snap.AccountID+" · "+snap.ProviderID),
+		"",
+		labelStyle.Render("Current  ") + current,
+	}
+	var hint string
+	if p.confirming {
+		from := "unknown"
+		if hasMetric {
+			from = spendLimitLabel(met.Limit)
+		}
+		switch {
+		case p.resolving:
+			lines = append(lines, labelStyle.Render("Key      ")+dimStyle.Render("looking up…"))
+		case p.target != "":
+			lines = append(lines, labelStyle.Render("Key      ")+valueStyle.Render(p.target))
+		}
+		lines = append(lines,
+			"",
+			yellowStyle.Render(fmt.Sprintf("Change the limit from %s to %s?", from, spendLimitLabel(p.limit))),
+			dimStyle.Render("This updates the limit at the provider immediately."),
+		)
+		hint = "y confirm · n/Esc edit"
+		if p.resolving {
+			hint = "n/Esc edit"
+		}
+	} else {
+		cursor := PulseChar("█", "▌", m.animFrame)
+		lines = append(lines, labelStyle.Render("New      ")+sapphireStyle.Render(p.input+cursor)+dimStyle.Render(" USD"))
+		if p.err != "" {
+			lines = append(lines, redStyle.Render(p.err))
+		}
+		hint = "type dollars · Enter review · empty removes the limit · Esc cancel"
+	}
+	lines = append(lines, "", dimStyle.Render(hint))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(screenW, screenH, lipgloss.Center, lipgloss.Center, box)
+}
+
+// spendLimitActionDetail is the menu's second line for a spend limit action:
+// the current cap instead of a URL or path.
+func spendLimitActionDetail(snap core.UsageSnapshot, metricKey string) string {
+	met, ok := snap.Metrics[metricKey]
+	if !ok {
+		return "current limit unknown"
+	}
+	return "current: " + spendLimitLabel(met.Limit)
+}
@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func spendLimitTestModel(t *testing.T) Model {
+	t.Helper()
+	snap := core.NewUsageSnapshot("openrouter", "openrouter")
+	snap.Status = core.StatusOK
+	snap.Timestamp = time.Now()
+	used, limit := 12.5, 50.0
+	snap.Metrics["credits"] = core.Metric{Used: &used, Limit: &limit, Unit: "USD", Window: "lifetime"}
+
+	m := NewModel(0.2, 0.1, false, config.DashboardConfig{}, nil, core.TimeWindow30d)
+	updated, _ := m.Update(SnapshotsMsg{Snapshots: map[string]core.UsageSnapshot{snap.AccountID: snap}, TimeWindow: core.TimeWindow30d, RequestID: 1})
+	updated, _ = updated.(Model).Update(tea.WindowSizeMsg{Width: 160, Height: 48})
+	return updated.(Model)
+}
+
+func typeRunes(t *testing.T, m Model, s string) Model {
+	t.Helper()
+	return pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+}
+
+func spendLimitMenuIndex(t *testing.T, m Model) string {
+	t.Helper()
+	for i, item := range m.actionMenuItems() {
+		if item.Kind == core.ProviderActionSetSpendLimit {
+			return string(rune('1' + i))
+		}
+	}
+	t.Fatal("action menu has no spend limit action")
+	return ""
+}
+
+func TestSpendLimit_ConfirmBeforeChanging(t *testing.T) {
+	m := spendLimitTestModel(t)
+	type call struct {
+		id    string
+		limit *float64
+	}
+	var calls []call
+	m.SetOnSetSpendLimit(func(id string, limit *float64) error {
+		calls = append(calls, call{id, limit})
+		return nil
+	})
+	var polled [][]string
+	m.SetOnRefreshAccounts(func(ids []string) { polled = append(polled, ids) })
+
+	m = typeRunes(t, m, "a")
+	m = typeRunes(t, m, spendLimitMenuIndex(t, m))
+	if !m.spendLimit.active || m.spendLimit.input != "50" {
+		t.Fatalf("prompt = %+v, want it open and prefilled with the current limit", m.spendLimit)
+	}
+	if view := m.View(); !strings.Contains(view, "Set spend limit") || !strings.Contains(view, "$50.00") {
+		t.Fatal("prompt should show the current limit")
+	}
+
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyBackspace})
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyBackspace})
+	m = typeRunes(t, m, "2x0")
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.spendLimit.confirming || m.spendLimit.limit == nil || *m.spendLimit.limit != 20 {
+		t.Fatalf("enter should review a $20 limit, got %+v", m.spendLimit)
+	}
+	if !strings.Contains(m.View(), "from $50.00 to $20.00") {
+		t.Fatal("confirmation should spell out the change")
+	}
+	if len(calls) != 0 {
+		t.Fatal("nothing may be sent before confirming")
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(Model)
+	if m.spendLimit.active || cmd == nil {
+		t.Fatal("y should close the prompt and send the change")
+	}
+	msg := cmd()
+	if len(calls) != 1 || calls[0].id != "openrouter" || calls[0].limit == nil || *calls[0].limit != 20 {
+		t.Fatalf("calls = %+v, want one $20 change for openrouter", calls)
+	}
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if len(polled) != 1 || !m.pollingAccounts["openrouter"] {
+		t.Fatal("a successful change should re-poll the account")
+	}
+	if !strings.Contains(m.actionNotice, "set to $20.00") {
+		t.Fatalf("actionNotice = %q", m.actionNotice)
+	}
+}
+
+func TestSpendLimit_ConfirmationNamesTheKey(t *testing.T) {
+	m := spendLimitTestModel(t)
+	var calls int
+	m.SetOnSetSpendLimit(func(string, *float64) error { calls++; return nil })
+	target, targetErr := "Primary · abc123", error(nil)
+	m.SetOnPreviewSpendLimit(func(string) (string, error) { return target, targetErr })
+
+	m = typeRunes(t, m, "a")
+	m = typeRunes(t, m, spendLimitMenuIndex(t, m))
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if !m.spendLimit.resolving || cmd == nil {
+		t.Fatalf("enter should look up the key first, got %+v", m.spendLimit)
+	}
+	m = typeRunes(t, m, "y")
+	if calls != 0 || !m.spendLimit.active {
+		t.Fatal("y must wait until the key is known")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if !strings.Contains(m.View(), "Primary · abc123") {
+		t.Fatal("confirmation should name the key being changed")
+	}
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("y should send the change once the key is known")
+	}
+	cmd()
+	if calls != 1 {
+		t.Fatalf("calls = %d, want the change sent after confirming", calls)
+	}
+
+	targetErr = errors.New("openrouter: 2 keys share the label")
+	m = typeRunes(t, m, "a")
+	m = typeRunes(t, m, spendLimitMenuIndex(t, m))
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if m.spendLimit.confirming || !strings.Contains(m.spendLimit.err, "share the label") {
+		t.Fatalf("an ambiguous key should send the prompt back with the reason, got %+v", m.spendLimit)
+	}
+}
+
+func TestSpendLimit_CancelAndErrors(t *testing.T) {
+	m := spendLimitTestModel(t)
+	m.SetOnSetSpendLimit(func(string, *float64) error { return errors.New("HTTP 403") })
+
+	m = typeRunes(t, m, "a")
+	m = typeRunes(t, m, spendLimitMenuIndex(t, m))
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = typeRunes(t, m, "n")
+	if !m.spendLimit.active || m.spendLimit.confirming {
+		t.Fatal("n should go back to editing")
+	}
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.spendLimit.active {
+		t.Fatal("esc should cancel the prompt")
+	}
+
+	updated, _ := m.Update(spendLimitSetMsg{AccountID: "openrouter", Err: errors.New("HTTP 403")})
+	m = updated.(Model)
+	if m.actionNotice != "spend limit failed: HTTP 403" {
+		t.Fatalf("actionNotice = %q", m.actionNotice)
+	}
+}
+
+func TestSpendLimit_HiddenWhenReadOnly(t *testing.T) {
+	m := spendLimitTestModel(t)
+	m.SetReadOnly(true)
+	m = typeRunes(t, m, "a")
+	for _, item := range m.actionMenuItems() {
+		if item.Kind == core.ProviderActionSetSpendLimit {
+			t.Fatal("read-only sessions must not offer spend limit changes")
+		}
+	}
+}
+
+func TestParseSpendLimitInput(t *testing.T) {
+	if limit, err := parseSpendLimitInput(""); err != nil || limit != nil {
+		t.Fatalf("empty input = %v, %v; want nil limit (remove)", limit, err)
+	}
+	if limit, err := parseSpendLimitInput("12.50"); err != nil || limit == nil || *limit != 12.5 {
+		t.Fatalf("12.50 = %v, %v", limit, err)
+	}
+	if _, err := parseSpendLimitInput("1.2.3"); err == nil {
+		t.Fatal("1.2.3 should be rejected")
+	}
+}
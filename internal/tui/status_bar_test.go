@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestFooterStatusLine_ShowsFilterCountdownAndHelp(t *testing.T) {
+	m := layoutTestModel(t, 120, 30, 3)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.referenceTime = now
+	m.lastDataUpdate = now.Add(-10 * time.Second)
+	m.refreshInterval = 30 * time.Second
+	m.filter.text = "openai"
+
+	line := ansi.Strip(m.renderFooterStatusLine(120))
+	for _, want := range []string{"filter: openai", "⟳ 20s", "? help", "/ filter"} {
+		if !strings.Contains(line, want) {
+			t.Fatalf("status line missing %q: %q", want, line)
+		}
+	}
+	if got := lipgloss.Width(line); got > 120 {
+		t.Fatalf("status line width = %d, want <= 120", got)
+	}
+}
+
+func TestFooterStatusLine_AnalyticsSortOrder(t *testing.T) {
+	m := layoutTestModel(t, 120, 30, 3)
+	m.screen = screenAnalytics
+	m.analyticsSortBy = 0
+
+	line := ansi.Strip(m.renderFooterStatusLine(120))
+	if want := "sort: " + sortByLabels[0]; !strings.Contains(line, want) {
+		t.Fatalf("status line missing %q: %q", want, line)
+	}
+}
+
+func TestFooterStatusLine_NarrowKeepsHelpKey(t *testing.T) {
+	m := layoutTestModel(t, 120, 30, 3)
+	m.filter.text = "a-rather-long-provider-filter"
+
+	for _, w := range []int{30, 50, 80} {
+		line := ansi.Strip(m.renderFooterStatusLine(w))
+		if got := lipgloss.Width(line); got > w {
+			t.Fatalf("w=%d: status line width = %d: %q", w, got, line)
+		}
+		if !strings.Contains(line, "? help") {
+			t.Fatalf("w=%d: status line dropped help key: %q", w, line)
+		}
+	}
+}
+
+func TestRefreshCountdownLabel(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		m    Model
+		want string
+	}{
+		{name: "no interval", m: Model{referenceTime: now, lastDataUpdate: now}, want: ""},
+		{name: "no data yet", m: Model{referenceTime: now, refreshInterval: time.Minute}, want: ""},
+		{name: "refreshing", m: Model{refreshing: true}, want: "⟳ refreshing"},
+		{name: "counting down", m: Model{referenceTime: now, lastDataUpdate: now.Add(-15 * time.Second), refreshInterval: time.Minute}, want: "⟳ 45s"},
+		{name: "overdue", m: Model{referenceTime: now, lastDataUpdate: now.Add(-2 * time.Minute), refreshInterval: time.Minute}, want: "⟳ due"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.refreshCountdownLabel(); got != tt.want {
+				t.Fatalf("refreshCountdownLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextTickInterval_IdleWithRefreshCountdown(t *testing.T) {
+	m := Model{
+		hasData:         true,
+		lastInteraction: time.Now().Add(-idleAfterInteraction - time.Second),
+		lastDataUpdate:  time.Now().Add(-idleAfterData - time.Second),
+		refreshInterval: time.Minute,
+	}
+	if got := m.nextTickInterval(); got != tickSlow {
+		t.Fatalf("idle with countdown: got %v, want %v", got, tickSlow)
+	}
+	m.showHelp = true
+	if got := m.nextTickInterval(); got != 0 {
+		t.Fatalf("idle with help open: got %v, want 0", got)
+	}
+}
+
+func TestHelpOverlay_ScrollsAndDismisses(t *testing.T) {
+	m := layoutTestModel(t, 100, 20, 3)
+	press := func(m Model, msg tea.KeyMsg) Model {
+		updated, _ := m.Update(msg)
+		return updated.(Model)
+	}
+
+	m = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	if !m.showHelp {
+		t.Fatal("? should open help")
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if !m.showHelp || m.helpOffset != 1 {
+		t.Fatalf("j should scroll help: show=%v offset=%d", m.showHelp, m.helpOffset)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "j/k scroll (2-") {
+		t.Fatalf("help overlay missing scroll hint:\n%s", view)
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m.showHelp {
+		t.Fatal("other keys should dismiss help")
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	if m.helpOffset != 0 {
+		t.Fatalf("reopening help should reset offset, got %d", m.helpOffset)
+	}
+}
@@ -86,6 +86,8 @@ var (
 	colorWarn     lipgloss.Color
 	colorCrit     lipgloss.Color
 	colorAuth     lipgloss.Color
+	colorMaint    lipgloss.Color
+	colorDegraded lipgloss.Color
 	colorUnknown  lipgloss.Color
 	colorBorder   lipgloss.Color
 	colorSelected lipgloss.Color
@@ -106,10 +108,13 @@ var (
 	cardNormalStyle   lipgloss.Style
 	cardSelectedStyle lipgloss.Style
 
-	badgeOKStyle   lipgloss.Style
-	badgeWarnStyle lipgloss.Style
-	badgeCritStyle lipgloss.Style
-	badgeAuthStyle lipgloss.Style
+	badgeOKStyle        lipgloss.Style
+	badgeWarnStyle      lipgloss.Style
+	badgeCritStyle      lipgloss.Style
+	badgeAuthStyle      lipgloss.Style
+	badgeMaintStyle     lipgloss.Style
+	badgeDegradedStyle  lipgloss.Style
+	badgeExhaustedStyle lipgloss.Style
 
 	detailTitleStyle      lipgloss.Style
 	detailHeroNameStyle   lipgloss.Style
@@ -208,6 +213,8 @@ func applyTheme(t Theme) {
 	colorWarn = colorYellow
 	colorCrit = colorRed
 	colorAuth = colorPeach
+	colorMaint = colorSapphire
+	colorDegraded = colorFlamingo
 	colorUnknown = colorDim
 	colorBorder = colorDim
 	colorSelected = colorAccent
@@ -240,6 +247,9 @@ func applyTheme(t Theme) {
 	badgeWarnStyle = lipgloss.NewStyle().Foreground(colorYellow).Bold(true)
 	badgeCritStyle = lipgloss.NewStyle().Foreground(colorRed).Bold(true)
 	badgeAuthStyle = lipgloss.NewStyle().Foreground(colorPeach).Bold(true)
+	badgeMaintStyle = lipgloss.NewStyle().Foreground(colorMaint).Bold(true)
+	badgeDegradedStyle = lipgloss.NewStyle().Foreground(colorDegraded).Bold(true)
+	badgeExhaustedStyle = lipgloss.NewStyle().Foreground(colorMaroon).Bold(true)
 
 	detailTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(colorLavender)
 	detailHeroNameStyle = lipgloss.NewStyle().Bold(true).Foreground(colorText)
@@ -396,13 +406,19 @@ func StatusColor(s core.Status) lipgloss.Color {
 		return colorOK
 	case core.StatusNearLimit:
 		return colorWarn
+	case core.StatusExhausted:
+		return colorMaroon
 	case core.StatusLimited:
 		return colorCrit
 	case core.StatusAuth:
 		return colorAuth
 	case core.StatusError:
 		return colorCrit
-	case core.StatusUnsupported, core.StatusUnknown:
+	case core.StatusMaintenance:
+		return colorMaint
+	case core.StatusDegraded:
+		return colorDegraded
+	case core.StatusStale, core.StatusUnsupported, core.StatusUnknown:
 		return colorUnknown
 	default:
 		return colorUnknown
@@ -415,12 +431,20 @@ func StatusIcon(s core.Status) string {
 		return "●"
 	case core.StatusNearLimit:
 		return "◐"
+	case core.StatusExhausted:
+		return "◕"
 	case core.StatusLimited:
 		return "◌"
 	case core.StatusAuth:
 		return "◈"
 	case core.StatusError:
 		return "✗"
+	case core.StatusMaintenance:
+		return "◫"
+	case core.StatusDegraded:
+		return "◒"
+	case core.StatusStale:
+		return "◷"
 	case core.StatusUnsupported:
 		return "◇"
 	default:
@@ -438,6 +462,9 @@ func StatusBadge(s core.Status) string {
 	case core.StatusNearLimit:
 		style = badgeWarnStyle
 		text = "WARN"
+	case core.StatusExhausted:
+		style = badgeExhaustedStyle
+		text = "FULL"
 	case core.StatusLimited:
 		style = badgeCritStyle
 		text = "LIMIT"
@@ -447,6 +474,15 @@ func StatusBadge(s core.Status) string {
 	case core.StatusError:
 		style = badgeCritStyle
 		text = "ERR"
+	case core.StatusMaintenance:
+		style = badgeMaintStyle
+		text = "MAINT"
+	case core.StatusDegraded:
+		style = badgeDegradedStyle
+		text = "PART"
+	case core.StatusStale:
+		style = dimStyle
+		text = "STALE"
 	default:
 		style = dimStyle
 		text = "…"
@@ -460,10 +496,16 @@ func StatusBorderColor(s core.Status) lipgloss.Color {
 		return colorGreen
 	case core.StatusNearLimit:
 		return colorYellow
+	case core.StatusExhausted:
+		return colorMaroon
 	case core.StatusLimited, core.StatusError:
 		return colorRed
 	case core.StatusAuth:
 		return colorPeach
+	case core.StatusMaintenance:
+		return colorMaint
+	case core.StatusDegraded:
+		return colorDegraded
 	default:
 		return colorSurface1
 	}
@@ -22,10 +22,13 @@ func (f *fakeServices) SaveDashboardProviders([]config.DashboardProviderConfig)
 	return nil
 }
 func (f *fakeServices) SaveDashboardProviderHideCosts(string, *bool) error                { return nil }
+func (f *fakeServices) SaveDashboardProviderFreeTier(string, *bool) error                 { return nil }
 func (f *fakeServices) SaveDashboardView(string) error                                    { return nil }
 func (f *fakeServices) SaveDashboardWidgetSections([]config.DashboardWidgetSection) error { return nil }
 func (f *fakeServices) SaveDetailWidgetSections([]config.DetailWidgetSection) error       { return nil }
 func (f *fakeServices) SaveDashboardHideSectionsWithNoData(bool) error                    { return nil }
+func (f *fakeServices) SaveDashboardPrivacyMode(bool) error                               { return nil }
+func (f *fakeServices) SaveDashboardSnoozes([]config.WarningSnooze) error                 { return nil }
 func (f *fakeServices) SaveTimeWindow(string) error                                       { return nil }
 func (f *fakeServices) SaveProviderLink(source, target string) error {
 	f.savedSource = source
@@ -50,6 +53,8 @@ func (f *fakeServices) LoadBrowserSessionInfo(string) core.BrowserSessionInfo {
 	return core.BrowserSessionInfo{}
 }
 func (f *fakeServices) OpenProviderConsole(string) error     { return nil }
+func (f *fakeServices) OpenExternal(string) error            { return nil }
+func (f *fakeServices) CopyToClipboard(string) error         { return nil }
 func (f *fakeServices) AvailableBrowsers() ([]string, error) { return nil, nil }
 
 func telemetryFixtureModel() Model {
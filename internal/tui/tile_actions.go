@@ -0,0 +1,181 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+// actionMenuState drives the per-tile quick-action menu opened with "a".
+type actionMenuState struct {
+	active    bool
+	accountID string
+	cursor    int
+}
+
+// tileActionDoneMsg reports the outcome of a quick action that ran outside
+// the UI (browser, clipboard).
+type tileActionDoneMsg struct {
+	Label string
+	Err   error
+}
+
+// tileActions lists the quick actions for snap: the provider's declared
+// actions that resolve to a target for this account, then refresh.
+func tileActions(snap core.UsageSnapshot) []core.ProviderAction {
+	loadProviderSpecs()
+	var actions []core.ProviderAction
+	for _, action := range providerSpecs[snap.ProviderID].Actions {
+		if action.Kind == core.ProviderActionRefresh {
+			continue
+		}
+		target := action.ResolveTarget(snap)
+		if target == "" {
+			continue
+		}
+		action.Target = target
+		action.SnapshotKey = ""
+		actions = append(actions, action)
+	}
+	return append(actions, core.ProviderAction{Label: "Refresh now", Kind: core.ProviderActionRefresh})
+}
+
+func (m Model) openActionMenu() Model {
+	id := m.selectedTileID(m.filteredIDs())
+	if id == "" {
+		return m
+	}
+	m.actionMenu = actionMenuState{active: true, accountID: id}
+	return m
+}
+
+// actionMenuItems is tileActions for the menu's account, minus the
+// actions that change provider state when the session is read-only.
+func (m Model) actionMenuItems() []core.ProviderAction {
+	snap, ok := m.snapshots[m.actionMenu.accountID]
+	if !ok {
+		return nil
+	}
+	actions := tileActions(snap)
+	if !m.readOnly {
+		return actions
+	}
+	out := actions[:0]
+	for _, action := range actions {
+		if action.Kind != core.ProviderActionSetSpendLimit {
+			out = append(out, action)
+		}
+	}
+	return out
+}
+
+func (m Model) handleActionMenuKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	items := m.actionMenuItems()
+	if len(items) == 0 {
+		m.actionMenu = actionMenuState{}
+		return m, nil
+	}
+	key := msg.String()
+	switch key {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "esc", "a":
+		m.actionMenu = actionMenuState{}
+	case "up", "k":
+		m.actionMenu.cursor = clamp(m.actionMenu.cursor-1, 0, len(items)-1)
+	case "down", "j":
+		m.actionMenu.cursor = clamp(m.actionMenu.cursor+1, 0, len(items)-1)
+	case "enter":
+		return m.runTileAction(items[clamp(m.actionMenu.cursor, 0, len(items)-1)])
+	default:
+		if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+			if idx := int(key[0] - '1'); idx < len(items) {
+				return m.runTileAction(items[idx])
+			}
+		}
+	}
+	return m, nil
+}
+
+// runTileAction closes the menu and performs action. Refresh force-polls
+// the menu's account, a spend limit change opens its prompt; everything else
+// is handed to Services and reports back with a tileActionDoneMsg.
+func (m Model) runTileAction(action core.ProviderAction) (tea.Model, tea.Cmd) {
+	accountID := m.actionMenu.accountID
+	m.actionMenu = actionMenuState{}
+	switch action.Kind {
+	case core.ProviderActionRefresh:
+		m = m.refreshAccounts([]string{accountID})
+		m.actionNotice = "refreshing " + accountID + "…"
+		return m, nil
+	case core.ProviderActionSetSpendLimit:
+		return m.openSpendLimitPrompt(accountID, action.Target), nil
+	case core.ProviderActionOpenURL, core.ProviderActionOpenPath, core.ProviderActionCopy:
+		return m, m.tileActionCmd(action)
+	}
+	return m, nil
+}
+
+func (m Model) tileActionCmd(action core.ProviderAction) tea.Cmd {
+	return func() tea.Msg {
+		if m.services == nil {
+			return tileActionDoneMsg{Label: action.Label, Err: fmt.Errorf("actions unavailable")}
+		}
+		var err error
+		if action.Kind == core.ProviderActionCopy {
+			err = m.services.CopyToClipboard(action.Target)
+		} else {
+			err = m.services.OpenExternal(action.Target)
+		}
+		if err != nil {
+			log.Printf("tile action %q (%s): %v", action.Label, action.Target, err)
+		}
+		return tileActionDoneMsg{Label: action.Label, Err: err}
+	}
+}
+
+func (m Model) handleTileActionDoneMsg(msg tileActionDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.actionNotice = msg.Label + " failed: " + msg.Err.Error()
+		return m, nil
+	}
+	m.actionNotice = msg.Label + " ✓"
+	return m, nil
+}
+
+func (m Model) renderActionMenuOverlay(screenW, screenH int) string {
+	items := m.actionMenuItems()
+	snap := m.snapshots[m.actionMenu.accountID]
+
+	lines := []string{
+		accentBoldStyle.Render(snap.AccountID) + "  " + dimStyle.Render(snap.ProviderID),
+		"",
+	}
+	for i, item := range items {
+		label := fmt.Sprintf("%d  %s", i+1, item.Label)
+		if i == m.actionMenu.cursor {
+			lines = append(lines, sapphireStyle.Render("▸ "+label))
+		} else {
+			lines = append(lines, "  "+labelStyle.Render(label))
+		}
+		switch item.Kind {
+		case core.ProviderActionRefresh:
+		case core.ProviderActionSetSpendLimit:
+			lines = append(lines, "     "+dimStyle.Render(spendLimitActionDetail(snap, item.Target)))
+		default:
+			lines = append(lines, "     "+dimStyle.Render(truncateToWidth(item.Target, max(10, screenW-16))))
+		}
+	}
+	lines = append(lines, "", dimStyle.Render("↑/↓ select · Enter/1-9 run · Esc close"))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(screenW, screenH, lipgloss.Center, lipgloss.Center, box)
+}
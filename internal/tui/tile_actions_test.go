@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+type tileActionFakeServices struct {
+	*fakeServices
+	opened  []string
+	copied  []string
+	copyErr error
+}
+
+func (f *tileActionFakeServices) OpenExternal(target string) error {
+	f.opened = append(f.opened, target)
+	return nil
+}
+
+func (f *tileActionFakeServices) CopyToClipboard(text string) error {
+	f.copied = append(f.copied, text)
+	return f.copyErr
+}
+
+func TestTileActions_ResolvesProviderSpecActions(t *testing.T) {
+	snap := core.UsageSnapshot{ProviderID: "openai", AccountID: "openai"}
+	actions := tileActions(snap)
+
+	var labels []string
+	for _, action := range actions {
+		labels = append(labels, action.Label)
+	}
+	got := strings.Join(labels, ", ")
+	want := "Open billing page, Open status page, Copy API base URL, Refresh now"
+	if got != want {
+		t.Fatalf("actions = %q, want %q", got, want)
+	}
+	if actions[2].Target != "https://api.openai.com/v1" {
+		t.Fatalf("copy target = %q, want the default base URL", actions[2].Target)
+	}
+}
+
+func TestTileActions_HidesPathActionsWithoutData(t *testing.T) {
+	snap := core.UsageSnapshot{ProviderID: "amp", AccountID: "amp"}
+	if actions := tileActions(snap); len(actions) != 1 || actions[0].Kind != core.ProviderActionRefresh {
+		t.Fatalf("expected only refresh without data paths, got %+v", actions)
+	}
+
+	snap.Raw = map[string]string{"ledger_path": "/home/me/.amp/ledger.jsonl"}
+	actions := tileActions(snap)
+	if len(actions) != 2 || actions[0].Target != "/home/me/.amp/ledger.jsonl" {
+		t.Fatalf("expected ledger action from snapshot, got %+v", actions)
+	}
+}
+
+func TestActionMenu_RunsSelectedAction(t *testing.T) {
+	fake := &tileActionFakeServices{fakeServices: &fakeServices{}}
+	m := layoutTestModel(t, 120, 30, 2)
+	m.services = fake
+
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if !m.actionMenu.active || m.actionMenu.accountID != "openai-00" {
+		t.Fatalf("a should open the menu for the focused tile, got %+v", m.actionMenu)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "Copy API base URL") {
+		t.Fatalf("menu overlay missing actions:\n%s", view)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	m = updated.(Model)
+	if m.actionMenu.active || cmd == nil {
+		t.Fatal("choosing an action should close the menu and return a command")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if len(fake.copied) != 1 || fake.copied[0] != "https://api.openai.com/v1" {
+		t.Fatalf("copied = %v", fake.copied)
+	}
+	if !strings.Contains(ansi.Strip(m.renderFooterStatusLine(120)), "Copy API base URL ✓") {
+		t.Fatalf("status bar should confirm the action: %q", m.actionNotice)
+	}
+
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	if m.actionNotice != "" {
+		t.Fatal("the next key should clear the notice")
+	}
+}
+
+func TestActionMenu_ReportsFailureAndRefresh(t *testing.T) {
+	fake := &tileActionFakeServices{fakeServices: &fakeServices{}, copyErr: errors.New("no clipboard tool found")}
+	m := layoutTestModel(t, 120, 30, 1)
+	m.services = fake
+
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	updated, _ = updated.(Model).Update(cmd())
+	m = updated.(Model)
+	if !strings.Contains(m.actionNotice, "failed: no clipboard tool found") {
+		t.Fatalf("actionNotice = %q", m.actionNotice)
+	}
+
+	refreshed := 0
+	m.onRefresh = func(core.TimeWindow) { refreshed++ }
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("4")})
+	if refreshed != 1 || !m.refreshing {
+		t.Fatalf("refresh action: refreshed=%d refreshing=%v", refreshed, m.refreshing)
+	}
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -85,7 +86,7 @@ func (m Model) tileGrid(contentW, contentH, n int) (cols, tileW, tileMaxHeight i
 
 func (m Model) tileCols() int {
 	switch m.activeDashboardView() {
-	case dashboardViewStacked, dashboardViewTabs, dashboardViewSplit, dashboardViewCompare:
+	case dashboardViewStacked, dashboardViewTabs, dashboardViewSplit, dashboardViewCompare, dashboardViewCompact:
 		return 1
 	}
 
@@ -94,7 +95,7 @@ func (m Model) tileCols() int {
 	if contentH < 5 {
 		contentH = 5
 	}
-	cols, _, _ := m.tileGrid(m.width, contentH, n)
+	cols, _, _ := m.tileGrid(m.dashboardGridWidth(), contentH, n)
 	return cols
 }
 
@@ -151,64 +152,61 @@ func (m Model) renderTilesWithColumns(w, h, forcedCols int) string {
 		tiles = append(tiles, strings.Split(rendered, "\n"))
 	}
 
-	var rows []string
-	var rowHeights []int
-	gap := strings.Repeat("\n", tileGapV)
-
-	for _, rowTiles := range lo.Chunk(tiles, cols) {
-		for len(rowTiles) < cols {
-			rowTiles = append(rowTiles, []string{strings.Repeat(" ", tileW+tileBorderH)})
-		}
-
-		maxLines := 0
+	gridRows := lo.Chunk(tiles, cols)
+	rowHeights := make([]int, len(gridRows))
+	rowOffsets := make([]int, len(gridRows))
+	totalLines := 0
+	for idx, rowTiles := range gridRows {
+		maxLines := tileMinHeight
 		for _, tile := range rowTiles {
 			if len(tile) > maxLines {
 				maxLines = len(tile)
 			}
 		}
-		if maxLines < tileMinHeight {
-			maxLines = tileMinHeight
+		rowHeights[idx] = maxLines
+		rowOffsets[idx] = totalLines
+		totalLines += maxLines
+		if idx < len(gridRows)-1 {
+			totalLines += tileGapV
 		}
+	}
 
-		var padded []string
-		for _, tile := range rowTiles {
-			lines := append([]string(nil), tile...)
-			for len(lines) < maxLines {
-				lines = append(lines, strings.Repeat(" ", tileW+tileBorderH))
+	// renderRows joins rows [from, to) into screen lines. Only the rows that
+	// intersect the viewport go through it, so scrolling a long grid doesn't
+	// lay out every tile row each frame.
+	renderRows := func(from, to int) []string {
+		var lines []string
+		for idx := from; idx < to; idx++ {
+			rowTiles := gridRows[idx]
+			for len(rowTiles) < cols {
+				rowTiles = append(rowTiles, []string{strings.Repeat(" ", tileW+tileBorderH)})
+			}
+			padded := make([]string, 0, len(rowTiles))
+			for _, tile := range rowTiles {
+				tileLines := append([]string(nil), tile...)
+				for len(tileLines) < rowHeights[idx] {
+					tileLines = append(tileLines, strings.Repeat(" ", tileW+tileBorderH))
+				}
+				padded = append(padded, strings.Join(tileLines, "\n"))
+			}
+			row := lipgloss.JoinHorizontal(lipgloss.Top, intersperse(padded, strings.Repeat(" ", tileGapH))...)
+			for _, line := range strings.Split(row, "\n") {
+				lines = append(lines, " "+line)
+			}
+			if idx < len(gridRows)-1 {
+				for g := 0; g < tileGapV; g++ {
+					lines = append(lines, " ")
+				}
 			}
-			padded = append(padded, strings.Join(lines, "\n"))
 		}
-
-		row := lipgloss.JoinHorizontal(lipgloss.Top, intersperse(padded, strings.Repeat(" ", tileGapH))...)
-		rows = append(rows, row)
-		rowHeights = append(rowHeights, maxLines)
-	}
-
-	joined := strings.Join(rows, "\n"+gap)
-	joinedLines := strings.Split(joined, "\n")
-	for i, line := range joinedLines {
-		joinedLines[i] = " " + line
+		return lines
 	}
-	content := strings.Join(joinedLines, "\n")
-
-	contentLines := strings.Split(content, "\n")
-	totalLines := len(contentLines)
 
 	if totalLines <= h {
-		return padToSize(content, w, h)
-	}
-
-	totalRows := len(rowHeights)
-	rowOffsets := make([]int, totalRows)
-	acc := 0
-	for idx, cnt := range rowHeights {
-		rowOffsets[idx] = acc
-		acc += cnt
-		if idx < totalRows-1 {
-			acc += tileGapV
-		}
+		return padToSize(strings.Join(renderRows(0, len(gridRows)), "\n"), w, h)
 	}
 
+	totalRows := len(gridRows)
 	cursorRow := m.cursor / cols
 	if cursorRow >= totalRows {
 		cursorRow = totalRows - 1
@@ -234,7 +232,15 @@ func (m Model) renderTilesWithColumns(w, h, forcedCols int) string {
 		endLine = totalLines
 	}
 
-	visible := contentLines[scrollLine:endLine]
+	firstRow := sort.Search(totalRows, func(i int) bool {
+		return rowOffsets[i]+rowHeights[i]+tileGapV > scrollLine
+	})
+	lastRow := sort.Search(totalRows, func(i int) bool {
+		return rowOffsets[i] >= endLine
+	})
+	rendered := renderRows(firstRow, lastRow)
+	skip := scrollLine - rowOffsets[firstRow]
+	visible := rendered[skip : skip+(endLine-scrollLine)]
 
 	if scrollLine > 0 {
 		visible[0] = dimStyle.Render("  ▲ more above")
@@ -361,7 +367,60 @@ func cropAnsiLine(line string, left, width int) string {
 	return cut
 }
 
+// renderTile returns the bordered tile for snap, reusing the previous render
+// when nothing it depends on has changed since.
 func (m Model) renderTile(snap core.UsageSnapshot, selected, modelMixExpanded bool, tileW, tileContentH, bodyOffset int) string {
+	now := time.Now()
+	timeStr := m.tileFooterText(snap, now)
+	if next := nextPollText(snap, now); next != "" && timeStr != "" {
+		timeStr += " · " + next
+	}
+	key := m.tileRenderCacheKey(snap, selected, modelMixExpanded, tileW, tileContentH, bodyOffset, timeStr, m.tileClock(snap, now))
+	if entry, ok := m.tileRenderCache[snap.AccountID]; ok && entry.key == key {
+		return entry.rendered
+	}
+	rendered := m.buildTile(snap, selected, modelMixExpanded, tileW, tileContentH, bodyOffset, timeStr)
+	if m.tileRenderCache != nil {
+		m.tileRenderCache[snap.AccountID] = tileRenderCacheEntry{key: key, rendered: rendered}
+	}
+	return rendered
+}
+
+// tileFooterText is the "updated" stamp under each tile: clock time for
+// fresh snapshots, "X ago" after a minute, prefixed while still cached.
+func (m Model) tileFooterText(snap core.UsageSnapshot, now time.Time) string {
+	age := now.Sub(snap.Timestamp)
+	var timeStr string
+	if age > 60*time.Second {
+		timeStr = formatDuration(age) + " ago"
+	} else if !snap.Timestamp.IsZero() {
+		timeStr = formatClock(snap.Timestamp, true)
+	}
+	if m.staleAccounts[snap.AccountID] && timeStr != "" {
+		timeStr = "cached · " + timeStr
+	}
+	return timeStr
+}
+
+// nextPollText is the ETA of the account's next daemon poll, in whole
+// minutes so the tile isn't redrawn every second.
+func nextPollText(snap core.UsageSnapshot, now time.Time) string {
+	at, ok := snap.NextPollAt()
+	if !ok {
+		return ""
+	}
+	switch wait := at.Sub(now); {
+	case wait <= 0:
+		return "next poll due"
+	case wait < time.Minute:
+		return "next in <1m"
+	default:
+		return "next in " + formatDurationShort(wait)
+	}
+}
+
+func (m Model) buildTile(snap core.UsageSnapshot, selected, modelMixExpanded bool, tileW, tileContentH, bodyOffset int, timeStr string) string {
+	snap = m.retryingTileView(snap)
 	innerW := tileW - 2*tilePadH
 	if innerW < 10 {
 		innerW = 10
@@ -379,18 +438,19 @@ func (m Model) renderTile(snap core.UsageSnapshot, selected, modelMixExpanded bo
 	accentSep := lipgloss.NewStyle().Foreground(provColor).Render(strings.Repeat("━", innerW))
 	dimSep := surface1Style.Render(strings.Repeat("─", innerW))
 
-	icon := StatusIcon(snap.Status)
-	iconStr := lipgloss.NewStyle().Foreground(StatusColor(snap.Status)).Render(icon)
+	status := m.tileStatus(snap)
+	icon := StatusIcon(status)
+	iconStr := lipgloss.NewStyle().Foreground(StatusColor(status)).Render(icon)
 	nameStyle := tileNameStyle
 	if selected {
 		nameStyle = tileNameSelectedStyle
 	}
-	badge := StatusBadge(snap.Status)
+	badge := m.tileBadge(snap, status)
 	badgeW := lipgloss.Width(badge)
 
 	// Time window pill for top-right corner (next to status badge).
 	twPill := lipgloss.NewStyle().Foreground(colorBlue).Bold(true).Render("⏱ " + m.timeWindow.Label())
-	if m.refreshing {
+	if m.refreshing || m.pollingAccounts[snap.AccountID] {
 		frame := m.animFrame % len(SpinnerFrames)
 		twPill += " " + lipgloss.NewStyle().Foreground(colorAccent).Render(SpinnerFrames[frame])
 	}
@@ -428,23 +488,26 @@ func (m Model) renderTile(snap core.UsageSnapshot, selected, modelMixExpanded bo
 	} else {
 		hdrLine2 = dimStyle.Render(truncate(provID))
 	}
+	if pace := tilePaceIndicator(snap); pace != "" {
+		if gap := innerW - lipgloss.Width(hdrLine2) - lipgloss.Width(pace); gap >= 2 {
+			hdrLine2 += strings.Repeat(" ", gap) + pace
+		}
+	}
 	headerMeta := buildTileHeaderMetaLines(snap, widget, innerW, m.animFrame)
 
 	header := []string{hdrLine1, hdrLine2}
+	if free := m.freeTierTileLine(snap, innerW); free != "" {
+		header = append(header, free)
+	}
+	if live := m.liveSessionTileLine(snap, innerW); live != "" {
+		header = append(header, live)
+	}
 	if len(headerMeta) > 0 {
 		header = append(header, headerMeta...)
 	}
 	header = append(header, accentSep)
 
-	age := time.Since(snap.Timestamp)
-	var timeStr string
-	if age > 60*time.Second {
-		timeStr = formatDuration(age) + " ago"
-	} else if !snap.Timestamp.IsZero() {
-		timeStr = snap.Timestamp.Format("15:04:05")
-	}
-	footerLine := tileTimestampStyle.Render(timeStr)
-	footer := []string{dimSep, footerLine}
+	footer := []string{dimSep, tileFooterLine(snap, timeStr, innerW)}
 
 	bodyBudget := -1
 	if tileContentH > 0 {
@@ -525,14 +588,16 @@ func (m Model) renderTile(snap core.UsageSnapshot, selected, modelMixExpanded bo
 	return renderWithBody(body)
 }
 
-func buildEmptyTileSectionLines(sectionID core.DashboardStandardSection, widget core.DashboardWidget) []string {
+func buildEmptyTileSectionLines(sectionID core.DashboardStandardSection, widget core.DashboardWidget, innerW int) []string {
 	heading, message := emptyTileSectionContent(sectionID, widget)
 	if heading == "" || message == "" {
 		return nil
 	}
+	// Truncate rather than let the border wrap it: a wrapped line pushes the
+	// tile past its height budget on narrow terminals.
 	return []string{
-		subtextBoldStyle.Render(heading),
-		dimStyle.Render("  " + message),
+		subtextBoldStyle.Render(truncateToWidth(heading, innerW)),
+		dimStyle.Render(truncateToWidth("  "+message, innerW)),
 	}
 }
 
@@ -577,7 +642,7 @@ func emptyTileSectionContent(sectionID core.DashboardStandardSection, widget cor
 
 func (m Model) tileShouldRenderLoading(snap core.UsageSnapshot) bool {
 	switch snap.Status {
-	case core.StatusError, core.StatusAuth, core.StatusLimited:
+	case core.StatusError, core.StatusAuth, core.StatusLimited, core.StatusMaintenance:
 		return false
 	}
 	if len(snap.Metrics) > 0 || len(snap.ModelUsage) > 0 || len(snap.DailySeries) > 0 || len(snap.Resets) > 0 {
@@ -2,13 +2,28 @@ package tui
 
 import (
 	"fmt"
+	"hash/fnv"
+	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/janekbaraniewski/openusage/internal/core"
 )
 
+type tileBodyCacheEntry struct {
+	key   string
+	lines []string
+}
+
+// tileRenderCacheEntry holds a fully rendered tile (header, body, footer and
+// border) for one account.
+type tileRenderCacheEntry struct {
+	key      string
+	rendered string
+}
+
 func (m *Model) cachedTileBodyLines(
 	snap core.UsageSnapshot,
 	widget core.DashboardWidget,
@@ -17,21 +32,21 @@ func (m *Model) cachedTileBodyLines(
 	modelMixExpanded bool,
 ) []string {
 	hideCosts := m.resolveHideCosts(snap)
-	key := tileBodyCacheKey(snap, widget, m.timeWindow, innerW, modelMixExpanded, m.hideSectionsWithNoData, hideCosts)
-	if lines, ok := m.tileBodyCache[key]; ok {
-		return lines
+	key := tileBodyCacheKey(m.snapshotHash(snap), widget, m.timeWindow, innerW, modelMixExpanded, m.hideSectionsWithNoData, hideCosts)
+	if entry, ok := m.tileBodyCache[snap.AccountID]; ok && entry.key == key {
+		return entry.lines
 	}
 
 	lines := m.buildTileBodyLines(snap, widget, di, innerW, modelMixExpanded, hideCosts)
 	if m.tileBodyCache == nil {
-		m.tileBodyCache = make(map[string][]string)
+		m.tileBodyCache = make(map[string]tileBodyCacheEntry)
 	}
-	m.tileBodyCache[key] = lines
+	m.tileBodyCache[snap.AccountID] = tileBodyCacheEntry{key: key, lines: lines}
 	return lines
 }
 
 func tileBodyCacheKey(
-	snap core.UsageSnapshot,
+	snapHash uint64,
 	widget core.DashboardWidget,
 	window core.TimeWindow,
 	innerW int,
@@ -40,14 +55,7 @@ func tileBodyCacheKey(
 	hideCosts bool,
 ) string {
 	return strings.Join([]string{
-		snap.ProviderID,
-		snap.AccountID,
-		string(snap.Status),
-		strconv.FormatInt(snap.Timestamp.Unix(), 10),
-		strconv.Itoa(len(snap.Metrics)),
-		strconv.Itoa(len(snap.Raw)),
-		strconv.Itoa(len(snap.DailySeries)),
-		strconv.Itoa(len(snap.Resets)),
+		strconv.FormatUint(snapHash, 16),
 		string(window),
 		strconv.Itoa(innerW),
 		strconv.FormatBool(modelMixExpanded),
@@ -57,6 +65,134 @@ func tileBodyCacheKey(
 	}, "|")
 }
 
+// tileRenderCacheKey covers everything renderTile reads besides the
+// snapshot itself and state whose changes already call
+// invalidateRenderCaches (theme, config, snoozes, window size). Time-derived
+// text enters through footer and clock so a tile only re-renders when what
+// it shows actually changes.
+func (m Model) tileRenderCacheKey(snap core.UsageSnapshot, selected, modelMixExpanded bool, tileW, tileContentH, bodyOffset int, footer, clock string) string {
+	anim := ""
+	if m.refreshing || m.pollingAccounts[snap.AccountID] || m.tileShouldRenderLoading(m.retryingTileView(snap)) {
+		anim = strconv.Itoa(m.animFrame)
+	}
+	return strings.Join([]string{
+		strconv.FormatUint(m.snapshotHash(snap), 16),
+		strconv.FormatBool(selected),
+		strconv.FormatBool(modelMixExpanded),
+		strconv.Itoa(tileW),
+		strconv.Itoa(tileContentH),
+		strconv.Itoa(bodyOffset),
+		string(m.timeWindow),
+		strconv.FormatFloat(m.warnThreshold, 'f', 4, 64),
+		strconv.FormatFloat(m.critThreshold, 'f', 4, 64),
+		anim,
+		footer,
+		clock,
+	}, "|")
+}
+
+// tileClock returns the wall-clock second when the tile shows countdowns
+// (resets, snooze expiry, a pending retry) and "" otherwise, so tiles
+// without live timers stay cached between frames.
+func (m Model) tileClock(snap core.UsageSnapshot, now time.Time) string {
+	if len(snap.Resets) == 0 && !m.retryingFetch(snap) {
+		if _, _, snoozed := m.snoozedWarning(snap); !snoozed {
+			return ""
+		}
+	}
+	return strconv.FormatInt(now.Unix(), 10)
+}
+
+// snapshotHash returns the fingerprint recorded when the snapshot arrived,
+// falling back to hashing it on the spot (settings previews, tests).
+func (m Model) snapshotHash(snap core.UsageSnapshot) uint64 {
+	if h, ok := m.snapshotHashes[snap.AccountID]; ok {
+		return h
+	}
+	return snapshotRenderHash(snap)
+}
+
+// snapshotRenderHash fingerprints every snapshot field a tile can render, so
+// accounts whose data didn't change keep their rendered tile across daemon
+// frames.
+func snapshotRenderHash(snap core.UsageSnapshot) uint64 {
+	h := fnv.New64a()
+	str := func(s string) {
+		_, _ = h.Write([]byte(s))
+		_, _ = h.Write([]byte{0})
+	}
+	num := func(p *float64) {
+		if p == nil {
+			str("-")
+			return
+		}
+		str(strconv.FormatUint(math.Float64bits(*p), 16))
+	}
+	strMap := func(values map[string]string) {
+		str(strconv.Itoa(len(values)))
+		for _, k := range core.SortedStringKeys(values) {
+			str(k)
+			str(values[k])
+		}
+	}
+
+	str(snap.ProviderID)
+	str(snap.AccountID)
+	str(strconv.FormatInt(snap.Timestamp.UnixNano(), 10))
+	str(string(snap.Status))
+	str(snap.Message)
+
+	str(strconv.Itoa(len(snap.Metrics)))
+	for _, k := range core.SortedStringKeys(snap.Metrics) {
+		metric := snap.Metrics[k]
+		str(k)
+		num(metric.Used)
+		num(metric.Limit)
+		num(metric.Remaining)
+		str(metric.Unit)
+		str(metric.Window)
+	}
+	str(strconv.Itoa(len(snap.Resets)))
+	for _, k := range core.SortedStringKeys(snap.Resets) {
+		str(k)
+		str(strconv.FormatInt(snap.Resets[k].UnixNano(), 10))
+	}
+	strMap(snap.Attributes)
+	strMap(snap.Diagnostics)
+	strMap(snap.Raw)
+
+	str(strconv.Itoa(len(snap.DailySeries)))
+	for _, k := range core.SortedStringKeys(snap.DailySeries) {
+		str(k)
+		for _, point := range snap.DailySeries[k] {
+			str(point.Date)
+			str(strconv.FormatUint(math.Float64bits(point.Value), 16))
+		}
+	}
+
+	str(strconv.Itoa(len(snap.ModelUsage)))
+	for _, rec := range snap.ModelUsage {
+		str(rec.RawModelID)
+		str(rec.RawSource)
+		str(rec.Canonical)
+		str(rec.CanonicalLineageID)
+		str(rec.CanonicalReleaseID)
+		str(rec.CanonicalVendor)
+		str(rec.CanonicalFamily)
+		str(rec.CanonicalVariant)
+		str(rec.Window)
+		strMap(rec.Dimensions)
+		num(rec.InputTokens)
+		num(rec.OutputTokens)
+		num(rec.CachedTokens)
+		num(rec.ReasoningTokens)
+		num(rec.TotalTokens)
+		num(rec.CostUSD)
+		num(rec.Requests)
+	}
+	return h.Sum64()
+}
+
 func tileWidgetCacheKey(widget core.DashboardWidget) string {
 	parts := make([]string, 0, len(widget.EffectiveStandardSectionOrder())+10)
 	for _, section := range widget.EffectiveStandardSectionOrder() {
@@ -128,6 +264,18 @@ func (m *Model) buildTileBodyLines(
 	}
 
 	topUsageLines := m.buildTileGaugeLines(snap, widget, innerW)
+	if line := geminiQuotaBucketAlertLine(snap, m.warnThreshold, m.critThreshold, innerW); line != "" {
+		topUsageLines = append(topUsageLines, line)
+	}
+	if line := deprecationTileLine(snap, innerW); line != "" {
+		topUsageLines = append(topUsageLines, line)
+	}
+	if line := limitPoolTileLine(snap, innerW); line != "" {
+		topUsageLines = append(topUsageLines, line)
+	}
+	if line := modelRetirementTileLine(snap, m.viewNow(), m.retirementWarnWindow, innerW); line != "" {
+		topUsageLines = append(topUsageLines, line)
+	}
 	if di.summary != "" {
 		topUsageLines = append(topUsageLines, tileHeroStyle.Render(truncate(di.summary)))
 	}
@@ -172,10 +320,12 @@ func (m *Model) buildTileBodyLines(
 
 	actualToolLines, actualToolKeys := buildActualToolUsageLines(snap, innerW, modelMixExpanded)
 	compactMetricKeys = addUsedKeys(compactMetricKeys, actualToolKeys)
+	toolCostLines, toolCostKeys := buildToolCostLines(snap, innerW, modelMixExpanded, hideCosts)
+	compactMetricKeys = addUsedKeys(compactMetricKeys, toolCostKeys)
 	if len(actualToolLines) > 0 {
-		sectionsByID[core.DashboardSectionToolUsage] = section{withSectionPadding(actualToolLines)}
+		sectionsByID[core.DashboardSectionToolUsage] = section{withSectionPadding(appendOtherGroup(actualToolLines, toolCostLines))}
 	} else if len(toolBurnLines) > 0 {
-		sectionsByID[core.DashboardSectionToolUsage] = section{withSectionPadding(toolBurnLines)}
+		sectionsByID[core.DashboardSectionToolUsage] = section{withSectionPadding(appendOtherGroup(toolBurnLines, toolCostLines))}
 	}
 
 	if widget.ShowMCPUsage {
@@ -258,7 +408,7 @@ func (m *Model) buildTileBodyLines(
 		if m.hideSectionsWithNoData {
 			continue
 		}
-		emptyLines := buildEmptyTileSectionLines(sectionID, widget)
+		emptyLines := buildEmptyTileSectionLines(sectionID, widget, innerW)
 		if len(emptyLines) == 0 {
 			continue
 		}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func tileCacheTestSnapshots(n int, used float64) map[string]core.UsageSnapshot {
+	ts := time.Now()
+	snaps := make(map[string]core.UsageSnapshot, n)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("openai-%02d", i)
+		snaps[id] = core.UsageSnapshot{
+			ProviderID: "openai",
+			AccountID:  id,
+			Timestamp:  ts,
+			Status:     core.StatusOK,
+			Metrics: map[string]core.Metric{
+				"rpm": {Used: float64Ptr(used), Limit: float64Ptr(100), Unit: "requests", Window: "1m"},
+			},
+		}
+	}
+	return snaps
+}
+
+func TestSnapshotRenderHash(t *testing.T) {
+	base := tileCacheTestSnapshots(1, 10)["openai-00"]
+	same := tileCacheTestSnapshots(1, 10)["openai-00"]
+	same.Timestamp = base.Timestamp
+	if snapshotRenderHash(base) != snapshotRenderHash(same) {
+		t.Fatal("equal snapshots with distinct pointers should hash the same")
+	}
+
+	changed := tileCacheTestSnapshots(1, 11)["openai-00"]
+	changed.Timestamp = base.Timestamp
+	if snapshotRenderHash(base) == snapshotRenderHash(changed) {
+		t.Fatal("a changed metric value should change the hash")
+	}
+
+	withAttr := base.DeepClone()
+	withAttr.SetAttribute("plan", "pro")
+	if snapshotRenderHash(base) == snapshotRenderHash(withAttr) {
+		t.Fatal("a new attribute should change the hash")
+	}
+}
+
+func TestRenderTile_ReusesRenderUntilSnapshotChanges(t *testing.T) {
+	m := NewModel(0.2, 0.1, false, config.DashboardConfig{}, nil, core.TimeWindow30d)
+	snaps := tileCacheTestSnapshots(2, 10)
+	updated, _ := m.Update(SnapshotsMsg{Snapshots: snaps, TimeWindow: core.TimeWindow30d, RequestID: 1})
+	m = updated.(Model)
+
+	for _, snap := range m.snapshots {
+		m.renderTile(snap, false, false, 60, 0, 0)
+	}
+	before := m.tileRenderCache["openai-01"].key
+
+	next := tileCacheTestSnapshots(2, 10)
+	for id, snap := range next {
+		snap.Timestamp = snaps[id].Timestamp
+		next[id] = snap
+	}
+	bumped := next["openai-00"]
+	bumped.Metrics["rpm"] = core.Metric{Used: float64Ptr(55), Limit: float64Ptr(100), Unit: "requests", Window: "1m"}
+	next["openai-00"] = bumped
+
+	updated, _ = m.Update(SnapshotsMsg{Snapshots: next, TimeWindow: core.TimeWindow30d, RequestID: 2})
+	m = updated.(Model)
+
+	if _, ok := m.tileRenderCache["openai-01"]; !ok {
+		t.Fatal("unchanged account lost its cached tile on a new frame")
+	}
+	if got := m.tileRenderCache["openai-01"].key; got != before {
+		t.Fatalf("unchanged account cache key changed: %q -> %q", before, got)
+	}
+
+	tile := m.renderTile(m.snapshots["openai-00"], false, false, 60, 0, 0)
+	if !strings.Contains(tile, "55") {
+		t.Fatalf("changed account should re-render with the new value, got:\n%s", tile)
+	}
+}
+
+func TestRenderTiles_ScrolledViewportMatchesFullRender(t *testing.T) {
+	m := NewModel(0.2, 0.1, false, config.DashboardConfig{}, nil, core.TimeWindow30d)
+	updated, _ := m.Update(SnapshotsMsg{Snapshots: tileCacheTestSnapshots(25, 10), TimeWindow: core.TimeWindow30d, RequestID: 1})
+	m = updated.(Model)
+
+	const w, h = 100, 30
+	full := strings.Split(m.renderTilesSingleColumn(w, 2000), "\n")
+	viewport := strings.Split(m.renderTilesSingleColumn(w, h), "\n")
+	if len(viewport) != h {
+		t.Fatalf("viewport has %d lines, want %d", len(viewport), h)
+	}
+	// The last line is replaced by the scroll indicator.
+	for i := 0; i < h-1; i++ {
+		if viewport[i] != full[i] {
+			t.Fatalf("line %d differs:\nviewport: %q\nfull:     %q", i, viewport[i], full[i])
+		}
+	}
+
+	m.cursor = 12
+	scrolled := m.renderTilesSingleColumn(w, h)
+	if !strings.Contains(scrolled, "openai-12") || !strings.Contains(scrolled, "more above") {
+		t.Fatalf("scrolled viewport should show the selected tile and the top indicator, got:\n%s", scrolled)
+	}
+}
@@ -125,7 +125,7 @@ func buildProviderModelCompositionLinesWithHide(snap core.UsageSnapshot, innerW
 		headingName = "Model Activity"
 		headerSuffix = shortCompact(total) + " req"
 	case "cost":
-		headerSuffix = fmt.Sprintf("$%.2f", total)
+		headerSuffix = formatMoney(total, 2)
 	default:
 		headerSuffix = shortCompact(total) + " tok"
 	}
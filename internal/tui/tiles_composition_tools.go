@@ -352,6 +352,73 @@ func buildActualToolUsageLines(snap core.UsageSnapshot, innerW int, expanded boo
 	return lines, usedKeys
 }
 
+// toolCostCategoryLabels names the categories core.ToolCostAttributor
+// splits model-turn cost into.
+var toolCostCategoryLabels = map[string]string{
+	core.ToolCategoryWeb:   "Web search & fetch",
+	core.ToolCategoryShell: "Shell",
+	core.ToolCategoryEdit:  "File edits",
+	core.ToolCategoryRead:  "File reads",
+	core.ToolCategoryAgent: "Subagents",
+	core.ToolCategoryMCP:   "MCP tools",
+	core.ToolCategoryOther: "Other tools",
+	core.ToolCategoryChat:  "No tool (chat)",
+}
+
+// buildToolCostLines renders the "Cost by Tool" composition: estimated cost
+// per tool category, each turn charged to the tool results it read. It is
+// all dollars, so hide-costs drops it while still claiming its keys.
+func buildToolCostLines(snap core.UsageSnapshot, innerW int, expanded bool, hideCosts bool) ([]string, map[string]bool) {
+	rawCosts, usedKeys := core.ExtractToolCostBreakdown(snap)
+	if len(rawCosts) == 0 || hideCosts {
+		return nil, usedKeys
+	}
+	entries := make([]toolMixEntry, 0, len(rawCosts))
+	totalCost := float64(0)
+	for _, raw := range rawCosts {
+		label := toolCostCategoryLabels[raw.Category]
+		if label == "" {
+			label = raw.Category
+		}
+		entries = append(entries, toolMixEntry{name: label, count: raw.CostUSD})
+		totalCost += raw.CostUSD
+	}
+	displayLimit := 6
+	if expanded {
+		displayLimit = len(entries)
+	}
+	visible := entries
+	hiddenCount := 0
+	if len(entries) > displayLimit {
+		visible = entries[:displayLimit]
+		hiddenCount = len(entries) - displayLimit
+	}
+	toolColors := buildToolColorMap(entries, snap.AccountID)
+	barW := innerW - 2
+	if barW < 12 {
+		barW = 12
+	}
+	if barW > 40 {
+		barW = 40
+	}
+	headerSuffix := formatUSD(totalCost)
+	if window := rawCosts[0].Window; window != "" {
+		headerSuffix += " · " + window
+	}
+	lines := []string{
+		lipgloss.NewStyle().Foreground(colorSubtext).Bold(true).Render("Cost by Tool") + "  " + dimStyle.Render(headerSuffix),
+		"  " + renderToolMixBar(entries, totalCost, barW, toolColors),
+	}
+	for idx, entry := range visible {
+		colorDot := lipgloss.NewStyle().Foreground(colorForTool(toolColors, entry.name)).Render("■")
+		lines = append(lines, renderDotLeaderRow(fmt.Sprintf("%s %d %s", colorDot, idx+1, entry.name), fmt.Sprintf("%2.0f%% %s", entry.count/totalCost*100, formatUSD(entry.count)), innerW))
+	}
+	if hiddenCount > 0 {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("+ %d more categories (Ctrl+O)", hiddenCount)))
+	}
+	return lines, usedKeys
+}
+
 func buildMCPUsageLines(snap core.UsageSnapshot, innerW int, expanded bool) ([]string, map[string]bool) {
 	type funcEntry struct {
 		name  string
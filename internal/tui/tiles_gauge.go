@@ -12,7 +12,7 @@ import (
 )
 
 func (m Model) buildTileGaugeLines(snap core.UsageSnapshot, widget core.DashboardWidget, innerW int) []string {
-	maxLabelW := 14
+	maxLabelW := tileGaugeLabelWidth(innerW)
 	gaugeW := innerW - maxLabelW - 10 // label + gauge + " XX.X%" + spaces
 	if gaugeW < 6 {
 		gaugeW = 6
@@ -45,7 +45,7 @@ func (m Model) buildTileGaugeLines(snap core.UsageSnapshot, widget core.Dashboar
 	var lines []string
 	renderedGauges := 0
 	for _, key := range keys {
-		if gaugeAllowSet != nil && !gaugeAllowSet[key] {
+		if gaugeAllowSet != nil && !gaugeAllowSet[key] || core.IsEndpointMetricKey(key) {
 			continue
 		}
 		met := snap.Metrics[key]
@@ -54,15 +54,19 @@ func (m Model) buildTileGaugeLines(snap core.UsageSnapshot, widget core.Dashboar
 			continue
 		}
 
-		label := gaugeLabel(widget, key, met.Window)
+		label := snapshotGaugeLabel(snap, widget, key, met.Window)
 		if len(label) > maxLabelW {
 			label = label[:maxLabelW-1] + "…"
 		}
 
+		// Values that failed snapshot validation get a marked, empty gauge
+		// rather than whatever percentage they work out to.
+		_, suspect := snap.MetricAnomaly(key)
 		gauge := RenderUsageGauge(usedPct, gaugeW, m.warnThreshold, m.critThreshold)
-
-		// Check for stacked gauge configuration
-		if sgCfg, ok := widget.StackedGaugeKeys[key]; ok && len(sgCfg.SegmentMetricKeys) > 0 {
+		if suspect {
+			gauge = RenderSuspectGauge(gaugeW)
+		} else if sgCfg, ok := widget.StackedGaugeKeys[key]; ok && len(sgCfg.SegmentMetricKeys) > 0 {
+			// Stacked gauge configuration
 			segments := buildStackedSegments(snap, sgCfg, met)
 			if len(segments) > 0 {
 				gauge = RenderStackedUsageGauge(segments, usedPct, gaugeW)
@@ -75,7 +79,7 @@ func (m Model) buildTileGaugeLines(snap core.UsageSnapshot, widget core.Dashboar
 		// Append a dim projection annotation when the metric has a
 		// recognized window + a reset timestamp. Pace mirrors the detail
 		// view computation (current% / elapsed minutes / 100).
-		if annot := tileGaugeProjectionAnnotation(snap, key, met, usedPct, now); annot != "" {
+		if annot := tileGaugeProjectionAnnotation(snap, key, met, usedPct, now); annot != "" && !suspect {
 			lines = append(lines, annotationIndent+dimStyle.Render(annot))
 		}
 
@@ -107,6 +111,15 @@ func (m Model) buildTileGaugeLines(snap core.UsageSnapshot, widget core.Dashboar
 	return lines
 }
 
+// tileGaugeLabelWidth abbreviates gauge labels on narrow tiles so the bar
+// keeps a usable width.
+func tileGaugeLabelWidth(innerW int) int {
+	if innerW < 44 {
+		return 8
+	}
+	return 14
+}
+
 // buildGaugeShimmerLines renders animated placeholder gauge tracks while
 // waiting for gauge-eligible metric data.
 func (m Model) buildGaugeShimmerLines(widget core.DashboardWidget, maxLabelW, gaugeW, maxLines int) []string {
@@ -174,6 +187,15 @@ func resolveSegmentColor(cfg core.StackedGaugeConfig, idx int) lipgloss.Color {
 	}
 }
 
+// snapshotGaugeLabel prefers a label the provider set on the snapshot (see
+// core.UsageSnapshot.SetMetricLabel) over the widget's static one.
+func snapshotGaugeLabel(snap core.UsageSnapshot, widget core.DashboardWidget, key, window string) string {
+	if label := snap.MetricLabel(key); label != "" {
+		return label
+	}
+	return gaugeLabel(widget, key, window)
+}
+
 func gaugeLabel(widget core.DashboardWidget, key string, window ...string) string {
 	overrides := map[string]string{
 		"plan_percent_used":    "Plan Used",
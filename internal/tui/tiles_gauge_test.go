@@ -363,3 +363,22 @@ func TestTileGaugeProjectionAnnotation_PaceOnlyWhenResetInPast(t *testing.T) {
 		t.Errorf("expected pace projection when reset has passed but pace is meaningful, got %q", out)
 	}
 }
+
+func TestBuildTileGaugeLines_SuspectMetricShowsNoPercent(t *testing.T) {
+	now := time.Date(2026, 5, 18, 12, 0, 0, 0, time.UTC)
+	snap := core.UsageSnapshot{
+		ProviderID: "test",
+		Timestamp:  now,
+		Metrics:    map[string]core.Metric{"rate_limit_5h": makeUsageMetric(4000, 100, "5h")},
+		Resets:     map[string]time.Time{"rate_limit_5h": now.Add(2 * time.Hour)},
+	}
+	snap, _ = core.ApplySnapshotValidation(snap, nil)
+
+	lines := tileGaugeTestModel(now).buildTileGaugeLines(snap, tileGaugeTestWidget(), 60)
+	if len(lines) != 1 {
+		t.Fatalf("expected only the gauge line, got %d: %v", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], "suspect") || strings.Contains(lines[0], "100.0%") {
+		t.Errorf("expected a suspect gauge without a percentage, got %q", lines[0])
+	}
+}
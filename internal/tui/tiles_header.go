@@ -10,6 +10,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
 )
 
 func buildTileHeaderMetaLines(snap core.UsageSnapshot, widget core.DashboardWidget, innerW int, animFrame int) []string {
@@ -91,12 +92,12 @@ func formatTileTimestamp(t, referenceTime time.Time) string {
 		if t.Year() == now.Year() {
 			return t.Format("Jan 02")
 		}
-		return t.Format("2006-01-02")
+		return locale.Current().Date(t)
 	}
 	if t.Year() == now.Year() {
-		return t.Format("Jan 02 15:04")
+		return t.Format("Jan 02") + " " + formatClock(t, false)
 	}
-	return t.Format("2006-01-02 15:04")
+	return locale.Current().DateTime(t)
 }
 
 func wrapTilePills(pills []string, innerW int) []string {
@@ -155,6 +156,7 @@ var resetLabelMap = map[string]string{
 	"rpm":                  "RPM",
 	"tpm":                  "TPM",
 	"rpd":                  "RPD",
+	"free_rpd":             "Free",
 	"tpd":                  "TPD",
 	"rpm_headers":          "Req",
 	"tpm_headers":          "Tok",
@@ -227,6 +229,7 @@ func resetSortPriority(key string) int {
 		"rpm":                              50,
 		"tpm":                              51,
 		"rpd":                              52,
+		"free_rpd":                         52,
 		"tpd":                              53,
 		"rpm_headers":                      54,
 		"tpm_headers":                      55,
@@ -240,11 +243,11 @@ func resetSortPriority(key string) int {
 func resetLabelForKey(snap core.UsageSnapshot, widget core.DashboardWidget, key string) string {
 	if strings.HasPrefix(key, "rate_limit_") {
 		if met, ok := snap.Metrics[key]; ok && strings.TrimSpace(met.Window) != "" {
-			return gaugeLabel(widget, key, met.Window)
+			return snapshotGaugeLabel(snap, widget, key, met.Window)
 		}
 		trimmed := strings.TrimSuffix(key, "_reset")
 		if met, ok := snap.Metrics[trimmed]; ok && strings.TrimSpace(met.Window) != "" {
-			return gaugeLabel(widget, trimmed, met.Window)
+			return snapshotGaugeLabel(snap, widget, trimmed, met.Window)
 		}
 	}
 	if widget.ResetStyle == core.DashboardResetStyleCompactModelResets {
@@ -455,6 +458,46 @@ func collectGeminiQuotaEntries(snap core.UsageSnapshot) []geminiQuotaEntry {
 	return entries
 }
 
+// geminiQuotaBucketAlertLine renders the "buckets nearly exhausted" summary
+// row for the buckets core.QuotaBucketsAtRisk reports, colored by the worst
+// one. Empty when every bucket is healthy.
+func geminiQuotaBucketAlertLine(snap core.UsageSnapshot, warnThresh, critThresh float64, maxW int) string {
+	if snap.ProviderID != "gemini_cli" {
+		return ""
+	}
+	alerts := core.QuotaBucketsAtRisk(snap, core.StatusThresholds{Warn: warnThresh, Crit: critThresh})
+	if len(alerts) == 0 {
+		return ""
+	}
+	const maxShown = 2
+	parts := make([]string, 0, maxShown)
+	for i, entry := range alerts {
+		if i == maxShown {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s %.0f%%", geminiQuotaLabelFromMetricKey(entry.Key), entry.UsedPercent))
+	}
+	text := "⚠ Near limit: " + strings.Join(parts, " · ")
+	if extra := len(alerts) - maxShown; extra > 0 {
+		text += fmt.Sprintf(" (+%d)", extra)
+	}
+	color := usageGaugeColor(alerts[0].UsedPercent, warnThresh, critThresh)
+	return lipgloss.NewStyle().Foreground(color).Bold(true).Render(truncateToWidth(text, maxW))
+}
+
+// tileStatus is the status the dashboard highlights: the snapshot's status,
+// settled to OK while its warning is snoozed and held back from ERROR while
+// a first failure is being retried.
+func (m Model) tileStatus(snap core.UsageSnapshot) core.Status {
+	if snap.Status == core.StatusError && m.retryingFetch(snap) {
+		return core.StatusUnknown
+	}
+	if _, _, snoozed := m.snoozedWarning(snap); snoozed {
+		return core.StatusOK
+	}
+	return snap.Status
+}
+
 func geminiQuotaLabelFromMetricKey(metricKey string) string {
 	base := strings.TrimPrefix(metricKey, "quota_model_")
 	if base == "" {
@@ -631,6 +674,7 @@ func buildTileMetaLines(snap core.UsageSnapshot, innerW int) []string {
 		{"Role", "membership_type"},
 		{"Team", "team_membership"},
 		{"Org", "organization_name"},
+		{"Workspace", "workspace_name"},
 		{"Model", "active_model"},
 		{"Version", "cli_version"},
 		{"Price", "plan_price"},
@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
 )
 
 type compactMetricRowSpec struct {
@@ -225,6 +226,14 @@ func compactMetricLabel(widget core.DashboardWidget, key string) string {
 
 	if strings.HasPrefix(key, "org_") && strings.HasSuffix(key, "_seats") {
 		org := strings.TrimSuffix(strings.TrimPrefix(key, "org_"), "_seats")
+		for _, kind := range []string{"total", "active", "inactive"} {
+			if name, ok := strings.CutSuffix(org, "_"+kind); ok {
+				if kind == "inactive" {
+					kind = "idle"
+				}
+				return truncateToWidth(name, 8) + " " + kind
+			}
+		}
 		if org != "" {
 			return truncateToWidth(org, 8)
 		}
@@ -325,14 +334,7 @@ func isTileUSDMetric(key string, met core.Metric) bool {
 }
 
 func compactMetricAmount(v float64, unit string) string {
-	switch unit {
-	case "tokens", "requests", "messages", "completions", "conversations", "seats", "quota", "lines":
-		return shortCompact(v)
-	case "":
-		return shortCompact(v)
-	default:
-		return fmt.Sprintf("%s %s", shortCompact(v), unit)
-	}
+	return locale.Current().Quantity(v, unit)
 }
 
 func (m Model) buildTileMetricLines(snap core.UsageSnapshot, widget core.DashboardWidget, innerW int, skipKeys map[string]bool) []string {
@@ -356,7 +358,7 @@ func (m Model) buildTileMetricLinesWithHide(snap core.UsageSnapshot, widget core
 		if skipKeys != nil && skipKeys[key] {
 			continue
 		}
-		if hasAnyPrefix(key, widget.HideMetricPrefixes) || slices.Contains(widget.HideMetricKeys, key) {
+		if hasAnyPrefix(key, widget.HideMetricPrefixes) || slices.Contains(widget.HideMetricKeys, key) || core.IsEndpointMetricKey(key) {
 			continue
 		}
 		met := snap.Metrics[key]
@@ -369,6 +371,9 @@ func (m Model) buildTileMetricLinesWithHide(snap core.UsageSnapshot, widget core
 		if hideCosts && isMonetaryMetricKey(key, met) {
 			continue
 		}
+		if key == core.TodayPaceMetricKey {
+			continue // shown in the tile header
+		}
 		value := formatTileMetricValue(key, met)
 		if value == "" {
 			continue
@@ -416,6 +421,9 @@ func hasAnyPrefix(value string, prefixes []string) bool {
 }
 
 func formatTileMetricValue(key string, met core.Metric) string {
+	if key == core.TodayPaceMetricKey && met.Used != nil {
+		return paceLabel(*met.Used) + " vs typical day"
+	}
 	isUSD := met.Unit == "USD" || strings.HasSuffix(key, "_usd") ||
 		strings.Contains(key, "cost") || strings.Contains(key, "spend") ||
 		strings.Contains(key, "price")
@@ -423,7 +431,7 @@ func formatTileMetricValue(key string, met core.Metric) string {
 
 	if met.Limit != nil && met.Used != nil {
 		if isUSD {
-			return fmt.Sprintf("$%s / $%s", formatNumber(*met.Used), formatNumber(*met.Limit))
+			return fmt.Sprintf("%s / %s", locale.Current().Money(formatNumber(*met.Used)), locale.Current().Money(formatNumber(*met.Limit)))
 		}
 		if isPct {
 			return fmt.Sprintf("%.0f%%", *met.Used)
@@ -436,6 +444,8 @@ func formatTileMetricValue(key string, met core.Metric) string {
 			unit = "req"
 		case "messages":
 			unit = "messages"
+		case "bytes", "ms":
+			return fmt.Sprintf("%s / %s", locale.Current().Quantity(*met.Used, unit), locale.Current().Quantity(*met.Limit, unit))
 		}
 		if unit != "" {
 			return fmt.Sprintf("%s / %s %s", formatNumber(*met.Used), formatNumber(*met.Limit), unit)
@@ -449,7 +459,7 @@ func formatTileMetricValue(key string, met core.Metric) string {
 	}
 	if met.Used != nil {
 		if isUSD {
-			return fmt.Sprintf("$%s", formatNumber(*met.Used))
+			return locale.Current().Money(formatNumber(*met.Used))
 		}
 		if isPct {
 			return fmt.Sprintf("%.0f%%", *met.Used)
@@ -460,6 +470,8 @@ func formatTileMetricValue(key string, met core.Metric) string {
 			unit = "tok"
 		case "requests":
 			unit = "req"
+		case "bytes", "ms":
+			return locale.Current().Quantity(*met.Used, unit)
 		}
 		if unit == "" {
 			return formatNumber(*met.Used)
@@ -510,13 +522,7 @@ func prioritizeMetricKeys(keys, priority []string) []string {
 }
 
 func shortCompact(v float64) string {
-	if v >= 1_000_000 {
-		return fmt.Sprintf("%.1fM", v/1_000_000)
-	}
-	if v >= 1_000 {
-		return fmt.Sprintf("%.1fk", v/1_000)
-	}
-	return fmt.Sprintf("%.0f", v)
+	return locale.Current().Compact(v)
 }
 
 func truncateToWidth(s string, maxW int) string {
@@ -546,3 +552,42 @@ func intersperse(items []string, sep string) []string {
 	}
 	return result
 }
+
+// paceLabel renders a today_pace ratio as an arrow and how far today runs
+// from a typical day: "▲ 3.1×" when at least double, otherwise a signed
+// percentage, and "≈ usual" within ±10%.
+func paceLabel(ratio float64) string {
+	switch {
+	case ratio >= 2:
+		return fmt.Sprintf("▲ %.1f×", ratio)
+	case ratio >= 1.1:
+		return fmt.Sprintf("▲ +%.0f%%", (ratio-1)*100)
+	case ratio <= 0.9:
+		return fmt.Sprintf("▼ −%.0f%%", (1-ratio)*100)
+	default:
+		return "≈ usual"
+	}
+}
+
+func paceColor(ratio float64) lipgloss.Color {
+	switch {
+	case ratio >= 3:
+		return colorCrit
+	case ratio >= 1.5:
+		return colorWarn
+	case ratio <= 0.9:
+		return colorOK
+	default:
+		return colorSubtext
+	}
+}
+
+// tilePaceIndicator is the styled today_pace badge for the tile header, or ""
+// when the snapshot has no pace.
+func tilePaceIndicator(snap core.UsageSnapshot) string {
+	m, ok := snap.Metrics[core.TodayPaceMetricKey]
+	if !ok || m.Used == nil {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(paceColor(*m.Used)).Bold(true).Render(paceLabel(*m.Used) + " pace")
+}
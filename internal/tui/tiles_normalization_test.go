@@ -863,3 +863,46 @@ func TestCollectActiveResetEntries_PrefersRateLimitWindowLabels(t *testing.T) {
 		t.Fatalf("entries[1].label = %q, want Usage 7d", entries[1].label)
 	}
 }
+
+func TestGeminiQuotaBucketAlertLine(t *testing.T) {
+	snap := core.UsageSnapshot{
+		ProviderID: "gemini_cli",
+		Status:     core.StatusNearLimit,
+		Metrics: map[string]core.Metric{
+			"quota_model_gemini_2_5_pro_requests":   quotaMetricForTest(98),
+			"quota_model_gemini_2_5_flash_requests": quotaMetricForTest(1),
+			"quota_model_gemini_3_pro_requests":     quotaMetricForTest(85),
+		},
+	}
+
+	line := stripANSI(geminiQuotaBucketAlertLine(snap, 0.20, 0.05, 80))
+	if !strings.Contains(line, "Near limit") || !strings.Contains(line, "98%") || strings.Contains(line, "Flash") {
+		t.Fatalf("alert line = %q", line)
+	}
+
+	healthy := core.UsageSnapshot{
+		ProviderID: "gemini_cli",
+		Status:     core.StatusOK,
+		Metrics:    map[string]core.Metric{"quota_model_gemini_2_5_flash_requests": quotaMetricForTest(10)},
+	}
+	if line := geminiQuotaBucketAlertLine(healthy, 0.20, 0.05, 80); line != "" {
+		t.Fatalf("healthy buckets should render no alert line, got %q", line)
+	}
+}
+
+func TestPaceLabel(t *testing.T) {
+	tests := []struct {
+		ratio float64
+		want  string
+	}{
+		{3.14, "▲ 3.1×"},
+		{1.45, "▲ +45%"},
+		{1.0, "≈ usual"},
+		{0.4, "▼ −60%"},
+	}
+	for _, tt := range tests {
+		if got := paceLabel(tt.ratio); got != tt.want {
+			t.Errorf("paceLabel(%v) = %q, want %q", tt.ratio, got, tt.want)
+		}
+	}
+}
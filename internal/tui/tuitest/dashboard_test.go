@@ -0,0 +1,85 @@
+package tuitest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+)
+
+func ptr(v float64) *float64 { return &v }
+
+// dashboard returns a harness with three accounts across two providers.
+func dashboard(t *testing.T, width, height int) *Harness {
+	t.Helper()
+	openai := NewFakeProvider("openai", map[string]core.UsageSnapshot{
+		"openai-work": {
+			Status: core.StatusOK,
+			Metrics: map[string]core.Metric{
+				"rpm": {Used: ptr(120), Limit: ptr(500), Remaining: ptr(380), Unit: "requests", Window: "1m"},
+				"tpm": {Used: ptr(40000), Limit: ptr(200000), Remaining: ptr(160000), Unit: "tokens", Window: "1m"},
+			},
+		},
+		"openai-home": {
+			Status: core.StatusNearLimit,
+			Metrics: map[string]core.Metric{
+				"rpm": {Used: ptr(470), Limit: ptr(500), Remaining: ptr(30), Unit: "requests", Window: "1m"},
+			},
+		},
+	})
+	openrouter := NewFakeProvider("openrouter", map[string]core.UsageSnapshot{
+		"openrouter": {
+			Status: core.StatusOK,
+			Metrics: map[string]core.Metric{
+				"credit_balance": {Remaining: ptr(37.5), Limit: ptr(50), Unit: "USD"},
+			},
+		},
+	})
+	return New(t, Options{
+		Width:  width,
+		Height: height,
+		Accounts: []core.AccountConfig{
+			{ID: "openai-work", Provider: "openai"},
+			{ID: "openai-home", Provider: "openai"},
+			{ID: "openrouter", Provider: "openrouter"},
+		},
+		Providers: []core.UsageProvider{openai, openrouter},
+	})
+}
+
+func TestDashboardLayout(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+	}{
+		{"stacked 120x36", 120, 36},
+		{"compact 60x20", 60, 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := dashboard(t, tt.width, tt.height)
+			h.AssertGolden("layout_" + strings.ReplaceAll(tt.name, " ", "_"))
+		})
+	}
+}
+
+func TestDashboardFilter(t *testing.T) {
+	h := dashboard(t, 120, 36)
+	h.Press("/")
+	h.Type("home")
+	h.AssertContains("search: home")
+	h.Press("enter")
+	h.AssertGolden("filter_home")
+
+	h.Press("/", "ctrl+u", "esc")
+	h.AssertContains("3 providers")
+}
+
+func TestDetailNavigation(t *testing.T) {
+	h := dashboard(t, 120, 36)
+	h.Press("down", "enter")
+	h.AssertGolden("detail_openai_home")
+
+	h.Press("esc")
+	h.AssertContains("Enter detail")
+}
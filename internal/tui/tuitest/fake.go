@@ -0,0 +1,43 @@
+package tuitest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers"
+)
+
+// FakeProvider stands in for a registered provider: it keeps the real
+// provider's spec and widgets, so tiles render as they do in the app, but
+// Fetch returns canned snapshots instead of calling an API.
+type FakeProvider struct {
+	core.UsageProvider
+	// Snapshots are keyed by account ID. Accounts without one get an
+	// auth-required snapshot.
+	Snapshots map[string]core.UsageSnapshot
+}
+
+// NewFakeProvider wraps the registered provider with the given ID.
+func NewFakeProvider(id string, snaps map[string]core.UsageSnapshot) *FakeProvider {
+	for _, p := range providers.AllProviders() {
+		if p.ID() == id {
+			return &FakeProvider{UsageProvider: p, Snapshots: snaps}
+		}
+	}
+	panic(fmt.Sprintf("tuitest: no registered provider %q", id))
+}
+
+// Fetch returns the account's canned snapshot, stamped with the current
+// time so it renders as fresh.
+func (p *FakeProvider) Fetch(_ context.Context, acct core.AccountConfig) (core.UsageSnapshot, error) {
+	snap, ok := p.Snapshots[acct.ID]
+	if !ok {
+		return core.NewAuthSnapshot(p.ID(), acct.ID, "no API key"), nil
+	}
+	snap.ProviderID = p.ID()
+	snap.AccountID = acct.ID
+	snap.Timestamp = time.Now()
+	return snap, nil
+}
@@ -0,0 +1,45 @@
+package tuitest
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// namedKeys maps the names bubbletea gives special keys back to their types.
+var namedKeys = map[string]tea.KeyType{
+	"enter":     tea.KeyEnter,
+	"esc":       tea.KeyEsc,
+	"tab":       tea.KeyTab,
+	"shift+tab": tea.KeyShiftTab,
+	"backspace": tea.KeyBackspace,
+	"delete":    tea.KeyDelete,
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"left":      tea.KeyLeft,
+	"right":     tea.KeyRight,
+	"home":      tea.KeyHome,
+	"end":       tea.KeyEnd,
+	"pgup":      tea.KeyPgUp,
+	"pgdown":    tea.KeyPgDown,
+	" ":         tea.KeySpace,
+	"space":     tea.KeySpace,
+	"ctrl+c":    tea.KeyCtrlC,
+	"ctrl+d":    tea.KeyCtrlD,
+	"ctrl+u":    tea.KeyCtrlU,
+	"ctrl+r":    tea.KeyCtrlR,
+}
+
+// KeyMsg returns the key message whose String() is name, so scripts can
+// use the same names the model's key handling switches on.
+func KeyMsg(name string) tea.KeyMsg {
+	if t, ok := namedKeys[name]; ok {
+		return tea.KeyMsg{Type: t}
+	}
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && rest != "" {
+		msg := KeyMsg(rest)
+		msg.Alt = true
+		return msg
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name)}
+}
@@ -0,0 +1,229 @@
+// Package tuitest runs the dashboard headlessly for tests: it feeds the
+// bubbletea model window sizes, snapshots from fake providers and scripted
+// key presses, and compares the rendered frames against golden files.
+//
+// Frames are plain text. Colors and other escape sequences are stripped, so
+// goldens pin layout and content rather than the theme.
+package tuitest
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/tui"
+)
+
+// updateGoldens is true when the test binary is invoked with `-update`. It
+// rewrites the golden files instead of comparing against them.
+var updateGoldens = flag.Bool("update", false, "rewrite TUI golden files")
+
+// cmdTimeout bounds how long a command returned by Update may take to
+// produce its message. Commands that take longer (ticks, fetches) are
+// dropped, which keeps frames independent of wall-clock timing.
+const cmdTimeout = 20 * time.Millisecond
+
+// clockPattern matches wall-clock times, which the detail header shows for
+// the snapshot timestamp. Frame replaces them so goldens don't depend on
+// when the test ran.
+var clockPattern = regexp.MustCompile(`\b\d{2}:\d{2}:\d{2}\b`)
+
+// Options configure a Harness.
+type Options struct {
+	// Width and Height are the terminal size; 120x36 when zero.
+	Width, Height int
+	// Accounts are the configured accounts, in dashboard order.
+	Accounts []core.AccountConfig
+	// Providers serve the accounts' snapshots on Poll, keyed by provider
+	// ID. Use FakeProvider to return canned data.
+	Providers []core.UsageProvider
+	Dashboard config.DashboardConfig
+	// TimeWindow defaults to 30d.
+	TimeWindow core.TimeWindow
+}
+
+// Harness drives one dashboard model.
+type Harness struct {
+	t         testing.TB
+	model     tea.Model
+	opts      Options
+	providers map[string]core.UsageProvider
+	requestID uint64
+}
+
+// New builds a dashboard model sized to the terminal, polls every account
+// once and returns the harness ready for key presses.
+func New(t testing.TB, opts Options) *Harness {
+	t.Helper()
+	if opts.Width == 0 {
+		opts.Width = 120
+	}
+	if opts.Height == 0 {
+		opts.Height = 36
+	}
+	if opts.TimeWindow == "" {
+		opts.TimeWindow = core.TimeWindow30d
+	}
+	h := &Harness{
+		t:         t,
+		opts:      opts,
+		providers: make(map[string]core.UsageProvider, len(opts.Providers)),
+	}
+	for _, p := range opts.Providers {
+		h.providers[p.ID()] = p
+	}
+	h.model = tui.NewModel(0.2, 0.1, false, opts.Dashboard, opts.Accounts, opts.TimeWindow)
+	h.Send(tea.WindowSizeMsg{Width: opts.Width, Height: opts.Height})
+	h.Poll()
+	return h
+}
+
+// Poll fetches every account through its provider and delivers the
+// snapshots the way the daemon does.
+func (h *Harness) Poll() {
+	h.t.Helper()
+	snaps := make(map[string]core.UsageSnapshot, len(h.opts.Accounts))
+	for _, acct := range h.opts.Accounts {
+		p, ok := h.providers[acct.Provider]
+		if !ok {
+			h.t.Fatalf("tuitest: no provider %q for account %q", acct.Provider, acct.ID)
+		}
+		snap, err := p.Fetch(context.Background(), acct)
+		if err != nil {
+			snap = core.UsageSnapshot{
+				ProviderID: acct.Provider,
+				AccountID:  acct.ID,
+				Timestamp:  time.Now(),
+				Status:     core.StatusError,
+				Message:    err.Error(),
+			}
+		}
+		snaps[acct.ID] = snap
+	}
+	h.requestID++
+	h.Send(tui.SnapshotsMsg{Snapshots: snaps, TimeWindow: h.opts.TimeWindow, RequestID: h.requestID})
+}
+
+// Send delivers messages to the model, then any messages the commands it
+// returns produce straight away.
+func (h *Harness) Send(msgs ...tea.Msg) {
+	h.t.Helper()
+	queue := append([]tea.Msg(nil), msgs...)
+	for len(queue) > 0 {
+		msg := queue[0]
+		queue = queue[1:]
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for _, cmd := range batch {
+				queue = append(queue, runCmd(cmd)...)
+			}
+			continue
+		}
+		var cmd tea.Cmd
+		h.model, cmd = h.model.Update(msg)
+		queue = append(queue, runCmd(cmd)...)
+	}
+}
+
+// Press sends key presses, each written the way bubbletea names them:
+// "j", "enter", "esc", "ctrl+c", "shift+tab".
+func (h *Harness) Press(keys ...string) {
+	h.t.Helper()
+	for _, k := range keys {
+		h.Send(KeyMsg(k))
+	}
+}
+
+// Type sends text one rune at a time, e.g. into the filter prompt.
+func (h *Harness) Type(text string) {
+	h.t.Helper()
+	for _, r := range text {
+		h.Send(KeyMsg(string(r)))
+	}
+}
+
+// Resize changes the terminal size.
+func (h *Harness) Resize(width, height int) {
+	h.t.Helper()
+	h.opts.Width, h.opts.Height = width, height
+	h.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Model returns the current model.
+func (h *Harness) Model() tea.Model { return h.model }
+
+// Frame renders the current view as plain text with trailing spaces
+// trimmed from every line and clock times replaced by hh:mm:ss.
+func (h *Harness) Frame() string {
+	view := clockPattern.ReplaceAllString(ansi.Strip(h.model.View()), "hh:mm:ss")
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AssertContains fails the test unless the frame contains every want.
+func (h *Harness) AssertContains(want ...string) {
+	h.t.Helper()
+	frame := h.Frame()
+	for _, w := range want {
+		if !strings.Contains(frame, w) {
+			h.t.Fatalf("frame does not contain %q:\n%s", w, frame)
+		}
+	}
+}
+
+// AssertGolden compares the frame with testdata/<name>.golden, or rewrites
+// the file when the test runs with -update.
+func (h *Harness) AssertGolden(name string) {
+	h.t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	frame := h.Frame() + "\n"
+	if *updateGoldens {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			h.t.Fatalf("tuitest: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(frame), 0o644); err != nil {
+			h.t.Fatalf("tuitest: write golden: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		h.t.Fatalf("tuitest: read golden %s: %v (run `go test -update` to create)", path, err)
+	}
+	if string(want) != frame {
+		h.t.Fatalf("frame differs from %s (run `go test -update` to accept):\n--- want\n%s\n--- got\n%s", path, want, frame)
+	}
+}
+
+// runCmd runs cmd and returns its message if it arrives within cmdTimeout.
+func runCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	select {
+	case msg := <-done:
+		if msg == nil {
+			return nil
+		}
+		if _, quit := msg.(tea.QuitMsg); quit {
+			return nil
+		}
+		return []tea.Msg{msg}
+	case <-time.After(cmdTimeout):
+		return nil
+	}
+}
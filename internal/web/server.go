@@ -0,0 +1,160 @@
+// Package web serves a read-only browser dashboard: the same tiles and
+// details as the TUI, rendered by a small embedded page from snapshots the
+// telemetry daemon already holds. It is for teammates who want to check
+// shared quota without running a terminal UI; nothing in it can change
+// provider or daemon state.
+package web
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
+)
+
+//go:embed static
+var staticFiles embed.FS
+
+// sourceTimeout caps one read of the snapshot source per page refresh.
+const sourceTimeout = 10 * time.Second
+
+// Source returns the snapshots to show, e.g. the daemon's read model.
+type Source func(ctx context.Context) ([]core.UsageSnapshot, error)
+
+// Options configures a Server.
+type Options struct {
+	Addr      string
+	Source    Source
+	Providers []core.UsageProvider
+	Locale    locale.Locale
+	// Privacy masks emails, org and key names, and dollar amounts, as the
+	// dashboard's privacy mode does.
+	Privacy bool
+	// RefreshInterval is how often the page re-reads snapshots.
+	RefreshInterval time.Duration
+	// AllowPublic accepts requests for any Host. Without it only the listen
+	// address, localhost and the loopback IPs are served, so a page that
+	// DNS-rebinds its own name to 127.0.0.1 can't read the tiles.
+	AllowPublic bool
+}
+
+// Server serves the page on / and its data on /api/tiles.
+type Server struct {
+	addr    string
+	source  Source
+	views   map[string]providerView
+	loc     locale.Locale
+	privacy bool
+	refresh time.Duration
+	public  bool
+}
+
+func NewServer(opts Options) *Server {
+	refresh := opts.RefreshInterval
+	if refresh <= 0 {
+		refresh = 30 * time.Second
+	}
+	return &Server{
+		addr:    opts.Addr,
+		source:  opts.Source,
+		views:   providerViews(opts.Providers),
+		loc:     opts.Locale,
+		privacy: opts.Privacy,
+		refresh: refresh,
+		public:  opts.AllowPublic,
+	}
+}
+
+// Handler returns the server's routes, for tests and for mounting
+// elsewhere.
+func (s *Server) Handler() http.Handler {
+	static, _ := fs.Sub(staticFiles, "static")
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(static))
+	mux.HandleFunc("GET /api/tiles", s.handleTiles)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	if s.public {
+		return mux
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !core.IsLocalHostHeader(r.Host, s.addr) {
+			http.Error(w, "web: unexpected Host header", http.StatusForbidden)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("web: listen %s: %w", s.addr, err)
+	}
+
+	srv := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      sourceTimeout + 5*time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(ln)
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		return err
+	}
+}
+
+type tilesResponse struct {
+	GeneratedAt    time.Time  `json:"generated_at"`
+	RefreshSeconds int        `json:"refresh_seconds"`
+	Privacy        bool       `json:"privacy"`
+	Tiles          []tileView `json:"tiles"`
+	Error          string     `json:"error,omitempty"`
+}
+
+func (s *Server) handleTiles(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), sourceTimeout)
+	defer cancel()
+
+	resp := tilesResponse{
+		GeneratedAt:    time.Now().UTC(),
+		RefreshSeconds: int(s.refresh.Seconds()),
+		Privacy:        s.privacy,
+		Tiles:          []tileView{},
+	}
+	snaps, err := s.source(ctx)
+	if err != nil {
+		// The page keeps showing the last tiles it had and reports the
+		// error, so a daemon restart doesn't blank it.
+		resp.Error = err.Error()
+		writeJSON(w, http.StatusBadGateway, resp)
+		return
+	}
+	resp.Tiles = buildTiles(snaps, s.views, s.loc, s.privacy)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
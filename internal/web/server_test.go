@@ -0,0 +1,170 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
+)
+
+type widgetProvider struct {
+	id     string
+	widget core.DashboardWidget
+}
+
+func (p widgetProvider) ID() string                            { return p.id }
+func (p widgetProvider) Describe() core.ProviderInfo           { return core.ProviderInfo{Name: "Open AI"} }
+func (p widgetProvider) Spec() core.ProviderSpec               { return core.ProviderSpec{ID: p.id} }
+func (p widgetProvider) DashboardWidget() core.DashboardWidget { return p.widget }
+func (p widgetProvider) DetailWidget() core.DetailWidget       { return core.DetailWidget{} }
+func (p widgetProvider) Fetch(context.Context, core.AccountConfig) (core.UsageSnapshot, error) {
+	return core.UsageSnapshot{}, nil
+}
+
+func testSnapshot() core.UsageSnapshot {
+	reset := time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+	snap := core.NewUsageSnapshot("openai", "work")
+	snap.Status = core.StatusNearLimit
+	snap.Message = "billing contact ops@example.com"
+	snap.Metrics["rpm"] = core.Metric{Used: core.Float64Ptr(90), Limit: core.Float64Ptr(100), Unit: "requests", Window: "1m"}
+	snap.Metrics["spend"] = core.Metric{Used: core.Float64Ptr(12.5), Unit: "USD", Window: "month"}
+	snap.Metrics["internal_counter"] = core.Metric{Used: core.Float64Ptr(3)}
+	snap.Resets["rpm"] = reset
+	snap.Attributes["account_email"] = "jane@example.com"
+	snap.Attributes["plan"] = "Tier 3"
+	snap.SetMetricLabel("rpm", "Requests / min")
+	return snap
+}
+
+func newTestServer(source Source, privacy bool) http.Handler {
+	return NewServer(Options{
+		Source: source,
+		Providers: []core.UsageProvider{widgetProvider{id: "openai", widget: core.DashboardWidget{
+			GaugePriority:  []string{"rpm", "spend"},
+			HideMetricKeys: []string{"internal_counter"},
+		}}},
+		Locale:  locale.Default,
+		Privacy: privacy,
+	}).Handler()
+}
+
+func getTiles(t *testing.T, h http.Handler) (int, tilesResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/tiles", nil))
+	var resp tilesResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decoding %q: %v", rec.Body.String(), err)
+	}
+	return rec.Code, resp
+}
+
+func TestHandleTiles(t *testing.T) {
+	h := newTestServer(func(context.Context) ([]core.UsageSnapshot, error) {
+		return []core.UsageSnapshot{testSnapshot()}, nil
+	}, false)
+
+	code, resp := getTiles(t, h)
+	if code != http.StatusOK || len(resp.Tiles) != 1 {
+		t.Fatalf("code = %d, tiles = %d", code, len(resp.Tiles))
+	}
+	tile := resp.Tiles[0]
+	if tile.ProviderName != "Open AI" || tile.Plan != "Tier 3" || tile.Status != core.StatusNearLimit {
+		t.Errorf("tile header = %+v", tile)
+	}
+	// spend has no limit, so only rpm is a gauge.
+	if len(tile.Gauges) != 1 {
+		t.Fatalf("gauges = %+v, want rpm only", tile.Gauges)
+	}
+	g := tile.Gauges[0]
+	if g.Label != "Requests / min" || g.Percent != 90 || g.Value != "90 / 100" || g.ResetsAt == nil {
+		t.Errorf("gauge = %+v", g)
+	}
+	for _, m := range tile.Metrics {
+		if m.Key == "internal_counter" {
+			t.Error("hidden metric was served")
+		}
+		if m.Key == "spend" && m.Value != "$12.50" {
+			t.Errorf("spend = %q, want $12.50", m.Value)
+		}
+	}
+	if !strings.Contains(tile.Message, "ops@example.com") {
+		t.Errorf("message = %q, want it unmasked outside privacy mode", tile.Message)
+	}
+}
+
+func TestHandleTiles_Privacy(t *testing.T) {
+	h := newTestServer(func(context.Context) ([]core.UsageSnapshot, error) {
+		return []core.UsageSnapshot{testSnapshot()}, nil
+	}, true)
+
+	_, resp := getTiles(t, h)
+	body, _ := json.Marshal(resp)
+	for _, leak := range []string{"jane@example.com", "ops@example.com", "$12.50"} {
+		if strings.Contains(string(body), leak) {
+			t.Errorf("privacy mode served %q", leak)
+		}
+	}
+}
+
+func TestHandleTiles_SourceError(t *testing.T) {
+	h := newTestServer(func(context.Context) ([]core.UsageSnapshot, error) {
+		return nil, errors.New("telemetry daemon unreachable")
+	}, false)
+
+	code, resp := getTiles(t, h)
+	if code != http.StatusBadGateway || resp.Error != "telemetry daemon unreachable" {
+		t.Errorf("code = %d, error = %q", code, resp.Error)
+	}
+}
+
+func TestServesPage(t *testing.T) {
+	h := newTestServer(nil, false)
+	for _, path := range []string{"/", "/app.js", "/app.css"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080"+path, nil))
+		if rec.Code != http.StatusOK || rec.Body.Len() == 0 {
+			t.Errorf("GET %s = %d", path, rec.Code)
+		}
+	}
+}
+
+func TestHandlerRejectsForeignHost(t *testing.T) {
+	source := func(context.Context) ([]core.UsageSnapshot, error) {
+		return []core.UsageSnapshot{testSnapshot()}, nil
+	}
+	opts := Options{Addr: "192.168.1.20:8080", Source: source, Locale: locale.Default}
+
+	tests := []struct {
+		host   string
+		public bool
+		want   int
+	}{
+		{host: "localhost:8080", want: http.StatusOK},
+		{host: "127.0.0.1:8080", want: http.StatusOK},
+		{host: "[::1]:8080", want: http.StatusOK},
+		{host: "192.168.1.20:8080", want: http.StatusOK},
+		{host: "attacker.example:8080", want: http.StatusForbidden},
+		{host: "attacker.example:8080", public: true, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		opts.AllowPublic = tt.public
+		req := httptest.NewRequest(http.MethodGet, "/api/tiles", nil)
+		req.Host = tt.host
+		rec := httptest.NewRecorder()
+		NewServer(opts).Handler().ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("Host %s (public=%t) = %d, want %d", tt.host, tt.public, rec.Code, tt.want)
+		}
+		if tt.want == http.StatusForbidden && strings.Contains(rec.Body.String(), "example.com") {
+			t.Errorf("rejected request leaked tile data: %s", rec.Body.String())
+		}
+	}
+}
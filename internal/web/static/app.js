@@ -0,0 +1,138 @@
+// Read-only OpenUsage web dashboard. Everything shown comes from
+// /api/tiles, already formatted by the server.
+(function () {
+  "use strict";
+
+  const WARN = 70;
+  const CRIT = 90;
+
+  const tilesEl = document.getElementById("tiles");
+  const summaryEl = document.getElementById("summary");
+  const errorEl = document.getElementById("error");
+  const filterEl = document.getElementById("filter");
+  const detailEl = document.getElementById("detail");
+  const detailBody = document.getElementById("detail-body");
+
+  let tiles = [];
+  let refreshMs = 30000;
+  let openKey = null;
+
+  function el(tag, attrs, ...children) {
+    const node = document.createElement(tag);
+    for (const [k, v] of Object.entries(attrs || {})) {
+      if (k === "class") node.className = v;
+      else node.setAttribute(k, v);
+    }
+    for (const child of children) {
+      if (child == null) continue;
+      node.append(child instanceof Node ? child : String(child));
+    }
+    return node;
+  }
+
+  function tileKey(t) {
+    return t.provider_id + "/" + t.account_id;
+  }
+
+  function relative(iso) {
+    const ms = new Date(iso).getTime() - Date.now();
+    const abs = Math.abs(ms);
+    const mins = Math.round(abs / 60000);
+    let text;
+    if (mins < 1) text = "now";
+    else if (mins < 60) text = mins + "m";
+    else if (mins < 48 * 60) text = Math.floor(mins / 60) + "h " + (mins % 60) + "m";
+    else text = Math.round(mins / 1440) + "d";
+    if (text === "now") return text;
+    return ms >= 0 ? "in " + text : text + " ago";
+  }
+
+  function gauge(g) {
+    const level = g.percent >= CRIT ? "crit" : g.percent >= WARN ? "warn" : "";
+    const fill = el("span", { class: level });
+    fill.style.width = Math.max(0, Math.min(100, g.percent)) + "%";
+    const value = g.value + (g.resets_at ? " · resets " + relative(g.resets_at) : "");
+    return el("div", { class: "gauge" },
+      el("div", { class: "row" }, el("span", {}, g.label), el("span", { class: "value" }, value)),
+      el("div", { class: "bar" }, fill));
+  }
+
+  function renderTile(t) {
+    const node = el("article", { class: "tile", tabindex: "0" },
+      el("h2", {},
+        t.provider_name,
+        el("span", { class: "account" }, t.account_id),
+        el("span", { class: "status " + t.status }, t.status.replace("_", " "))));
+    if (t.plan) node.append(el("p", { class: "message" }, t.plan));
+    if (t.message) node.append(el("p", { class: "message" }, t.message));
+    for (const g of t.gauges || []) node.append(gauge(g));
+    const open = () => showDetail(t);
+    node.addEventListener("click", open);
+    node.addEventListener("keydown", (e) => { if (e.key === "Enter") open(); });
+    return node;
+  }
+
+  function table(rows) {
+    return el("table", {}, ...rows.map((cells) => el("tr", {}, ...cells.map((c, i) =>
+      el("td", i === 1 ? { class: "value" } : {}, c)))));
+  }
+
+  function showDetail(t) {
+    openKey = tileKey(t);
+    detailBody.replaceChildren(
+      el("h2", {}, t.provider_name + " · " + t.account_id),
+      el("p", { class: "status " + t.status }, t.status + (t.message ? " — " + t.message : "")),
+      el("p", { class: "message" }, "Updated " + relative(t.updated_at)));
+    for (const g of t.gauges || []) detailBody.append(gauge(g));
+    if (t.metrics && t.metrics.length) {
+      detailBody.append(el("h3", {}, "Metrics"),
+        table(t.metrics.map((m) => [m.label, m.value, m.window || ""])));
+    }
+    if (t.models && t.models.length) {
+      detailBody.append(el("h3", {}, "Models"),
+        table(t.models.map((m) => [m.model, m.cost || "", m.tokens ? m.tokens + " tok" : ""])));
+    }
+    if (t.resets && t.resets.length) {
+      detailBody.append(el("h3", {}, "Resets"),
+        table(t.resets.map((r) => [r.label, relative(r.at), new Date(r.at).toLocaleString()])));
+    }
+    if (t.attributes && t.attributes.length) {
+      detailBody.append(el("h3", {}, "Attributes"),
+        table(t.attributes.map((a) => [a.key, a.value, ""])));
+    }
+    if (!detailEl.open) detailEl.showModal();
+  }
+
+  function render() {
+    const q = filterEl.value.trim().toLowerCase();
+    const shown = tiles.filter((t) => !q ||
+      (t.provider_name + " " + t.provider_id + " " + t.account_id).toLowerCase().includes(q));
+    tilesEl.replaceChildren(...shown.map(renderTile));
+    const attention = tiles.filter((t) => t.status !== "OK").length;
+    summaryEl.textContent = tiles.length + " accounts" + (attention ? " · " + attention + " need attention" : "");
+    if (detailEl.open && openKey) {
+      const current = tiles.find((t) => tileKey(t) === openKey);
+      if (current) showDetail(current);
+    }
+  }
+
+  async function refresh() {
+    try {
+      const resp = await fetch("api/tiles", { cache: "no-store" });
+      const body = await resp.json();
+      if (body.refresh_seconds > 0) refreshMs = body.refresh_seconds * 1000;
+      if (body.error) throw new Error(body.error);
+      tiles = body.tiles || [];
+      errorEl.hidden = true;
+      render();
+    } catch (err) {
+      errorEl.textContent = "Could not refresh: " + err.message;
+      errorEl.hidden = false;
+    }
+    setTimeout(refresh, refreshMs);
+  }
+
+  filterEl.addEventListener("input", render);
+  detailEl.addEventListener("close", () => { openKey = null; });
+  refresh();
+})();
@@ -0,0 +1,278 @@
+package web
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/locale"
+)
+
+// defaultGaugeLines is how many gauges a tile shows when the provider's
+// widget does not say.
+const defaultGaugeLines = 4
+
+// tileView is one account as the browser renders it. Values are formatted
+// server-side with the configured locale so the page needs no number or
+// currency logic of its own.
+type tileView struct {
+	ProviderID   string       `json:"provider_id"`
+	ProviderName string       `json:"provider_name"`
+	AccountID    string       `json:"account_id"`
+	Status       core.Status  `json:"status"`
+	Message      string       `json:"message,omitempty"`
+	Plan         string       `json:"plan,omitempty"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	Gauges       []gaugeView  `json:"gauges,omitempty"`
+	Metrics      []metricView `json:"metrics,omitempty"`
+	Resets       []resetView  `json:"resets,omitempty"`
+	Attributes   []keyValue   `json:"attributes,omitempty"`
+	Models       []modelView  `json:"models,omitempty"`
+}
+
+type gaugeView struct {
+	Key      string     `json:"key"`
+	Label    string     `json:"label"`
+	Percent  float64    `json:"percent"`
+	Value    string     `json:"value"`
+	ResetsAt *time.Time `json:"resets_at,omitempty"`
+}
+
+type metricView struct {
+	Key    string `json:"key"`
+	Label  string `json:"label"`
+	Value  string `json:"value"`
+	Window string `json:"window,omitempty"`
+}
+
+type resetView struct {
+	Label string    `json:"label"`
+	At    time.Time `json:"at"`
+}
+
+type keyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type modelView struct {
+	Model  string `json:"model"`
+	Cost   string `json:"cost,omitempty"`
+	Tokens string `json:"tokens,omitempty"`
+}
+
+// providerView is what the page needs from a provider besides its snapshot.
+type providerView struct {
+	name   string
+	widget core.DashboardWidget
+}
+
+func providerViews(providers []core.UsageProvider) map[string]providerView {
+	out := make(map[string]providerView, len(providers))
+	for _, p := range providers {
+		name := p.Describe().Name
+		if name == "" {
+			name = p.ID()
+		}
+		out[p.ID()] = providerView{name: name, widget: p.DashboardWidget()}
+	}
+	return out
+}
+
+// buildTiles turns snapshots into tiles sorted by provider and account.
+// In privacy mode identifying metadata is masked and dollar amounts hidden,
+// as in the dashboard.
+func buildTiles(snaps []core.UsageSnapshot, views map[string]providerView, loc locale.Locale, privacy bool) []tileView {
+	if privacy {
+		loc = loc.WithHiddenAmounts(true)
+	}
+	tiles := make([]tileView, 0, len(snaps))
+	for _, snap := range snaps {
+		if privacy {
+			snap = core.RedactSnapshot(snap)
+		}
+		tiles = append(tiles, buildTile(snap, views[snap.ProviderID], loc))
+	}
+	slices.SortFunc(tiles, func(a, b tileView) int {
+		if c := strings.Compare(a.ProviderID, b.ProviderID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.AccountID, b.AccountID)
+	})
+	return tiles
+}
+
+func buildTile(snap core.UsageSnapshot, view providerView, loc locale.Locale) tileView {
+	name := view.name
+	if name == "" {
+		name = snap.ProviderID
+	}
+	tile := tileView{
+		ProviderID:   snap.ProviderID,
+		ProviderName: name,
+		AccountID:    snap.AccountID,
+		Status:       snap.Status,
+		Message:      snap.Message,
+		Plan:         snap.Attributes["plan"],
+		UpdatedAt:    snap.Timestamp,
+	}
+
+	label := func(key string) string {
+		if l := snap.MetricLabel(key); l != "" {
+			return l
+		}
+		return core.MetricLabel(view.widget, key)
+	}
+
+	for _, key := range gaugeKeys(snap, view.widget) {
+		m := snap.Metrics[key]
+		g := gaugeView{
+			Key:     key,
+			Label:   label(key),
+			Percent: usedPercent(key, m),
+			Value:   formatGaugeValue(m, loc),
+		}
+		if at, ok := resetFor(snap, key); ok {
+			g.ResetsAt = &at
+		}
+		tile.Gauges = append(tile.Gauges, g)
+	}
+
+	for _, key := range core.SortedStringKeys(snap.Metrics) {
+		if hiddenMetric(view.widget, key) {
+			continue
+		}
+		m := snap.Metrics[key]
+		value := formatMetricValue(m, loc)
+		if value == "" {
+			continue
+		}
+		tile.Metrics = append(tile.Metrics, metricView{Key: key, Label: label(key), Value: value, Window: m.Window})
+	}
+
+	for _, key := range core.SortedStringKeys(snap.Resets) {
+		at := snap.Resets[key]
+		if at.IsZero() {
+			continue
+		}
+		tile.Resets = append(tile.Resets, resetView{Label: label(strings.TrimSuffix(key, "_reset")), At: at})
+	}
+
+	for _, key := range core.SortedStringKeys(snap.Attributes) {
+		if v := strings.TrimSpace(snap.Attributes[key]); v != "" {
+			tile.Attributes = append(tile.Attributes, keyValue{Key: core.PrettifyMetricKey(key), Value: v})
+		}
+	}
+
+	for _, rec := range snap.ModelUsage {
+		mv := modelView{Model: core.FirstNonEmpty(rec.Canonical, rec.CanonicalLineageID, rec.RawModelID)}
+		if rec.CostUSD != nil {
+			mv.Cost = loc.Quantity(*rec.CostUSD, "USD")
+		}
+		if rec.TotalTokens != nil {
+			mv.Tokens = loc.Compact(*rec.TotalTokens)
+		}
+		tile.Models = append(tile.Models, mv)
+	}
+	return tile
+}
+
+// gaugeKeys picks the metrics drawn as gauges: the widget's priority list
+// when any of it is present, otherwise every rate-limit style metric.
+func gaugeKeys(snap core.UsageSnapshot, widget core.DashboardWidget) []string {
+	limit := widget.GaugeMaxLines
+	if limit <= 0 {
+		limit = defaultGaugeLines
+	}
+	var keys []string
+	for _, key := range widget.GaugePriority {
+		if m, ok := snap.Metrics[key]; ok && usedPercent(key, m) >= 0 && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		for _, rl := range core.ExtractRateLimitDisplayMetrics(snap.Metrics) {
+			keys = append(keys, rl.Key)
+		}
+	}
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys
+}
+
+func hiddenMetric(widget core.DashboardWidget, key string) bool {
+	if slices.Contains(widget.HideMetricKeys, key) {
+		return true
+	}
+	for _, prefix := range widget.HideMetricPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func resetFor(snap core.UsageSnapshot, key string) (time.Time, bool) {
+	for _, k := range []string{key, key + "_reset"} {
+		if at, ok := snap.Resets[k]; ok && !at.IsZero() {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// usedPercent is core.MetricUsedPercent, also reading percent metrics that
+// only report what remains; -1 when unknown.
+func usedPercent(key string, m core.Metric) float64 {
+	p := core.MetricUsedPercent(key, m)
+	if p < 0 && m.Unit == "%" && m.Remaining != nil {
+		p = 100 - *m.Remaining
+	}
+	if p < 0 {
+		return -1
+	}
+	return min(p, 100)
+}
+
+// formatGaugeValue reads "used / limit" in the metric's unit, or just the
+// percentage for percent metrics.
+func formatGaugeValue(m core.Metric, loc locale.Locale) string {
+	if m.Unit == "%" {
+		if m.Used != nil {
+			return loc.Quantity(*m.Used, "%")
+		}
+		if m.Remaining != nil {
+			return loc.Quantity(100-*m.Remaining, "%")
+		}
+		return ""
+	}
+	used := m.Used
+	if used == nil && m.Limit != nil && m.Remaining != nil {
+		u := *m.Limit - *m.Remaining
+		used = &u
+	}
+	if used == nil || m.Limit == nil {
+		return formatMetricValue(m, loc)
+	}
+	return loc.Quantity(*used, m.Unit) + " / " + loc.Quantity(*m.Limit, m.Unit)
+}
+
+// formatMetricValue renders whichever of used, remaining and limit the
+// metric carries.
+func formatMetricValue(m core.Metric, loc locale.Locale) string {
+	switch {
+	case m.Used != nil && m.Limit != nil:
+		return loc.Quantity(*m.Used, m.Unit) + " of " + loc.Quantity(*m.Limit, m.Unit)
+	case m.Used != nil:
+		return loc.Quantity(*m.Used, m.Unit)
+	case m.Remaining != nil && m.Limit != nil:
+		return loc.Quantity(*m.Remaining, m.Unit) + " left of " + loc.Quantity(*m.Limit, m.Unit)
+	case m.Remaining != nil:
+		return loc.Quantity(*m.Remaining, m.Unit) + " left"
+	case m.Limit != nil:
+		return "limit " + loc.Quantity(*m.Limit, m.Unit)
+	}
+	return ""
+}
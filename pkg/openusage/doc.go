@@ -0,0 +1,29 @@
+// Package openusage embeds OpenUsage's provider polling in other Go
+// programs — agent orchestrators, internal dashboards, budget guards — so
+// they can read usage, limits and spend without shelling out to the CLI or
+// running the telemetry daemon.
+//
+// A program lists the built-in providers, resolves accounts (its own, or
+// the user's OpenUsage setup via LoadAccounts) and polls them:
+//
+//	accounts, err := openusage.LoadAccounts()
+//	if err != nil {
+//		return err
+//	}
+//	poller := openusage.NewPoller(openusage.Options{})
+//	for _, snap := range poller.FetchAll(ctx, accounts) {
+//		fmt.Println(snap.ProviderID, snap.AccountID, snap.Status)
+//	}
+//
+// Snapshots are the same ones the dashboard renders and `openusage export`
+// prints: provider errors become a snapshot with StatusError rather than a
+// Go error, so one failing account never hides the rest.
+//
+// # Stability
+//
+// This package is experimental and makes no compatibility promise yet. Its
+// types are aliases of the engine's internal ones, so they change whenever
+// the engine does, in any release; pin the module version you build
+// against. Metric keys are provider-defined and documented per provider;
+// treat unknown keys as optional.
+package openusage
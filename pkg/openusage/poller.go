@@ -0,0 +1,118 @@
+package openusage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/internal/core"
+	"github.com/janekbaraniewski/openusage/internal/providers/shared"
+)
+
+// DefaultFetchTimeout caps one account's fetch, matching the daemon.
+const DefaultFetchTimeout = 8 * time.Second
+
+// Options configures a Poller. The zero value polls the built-in providers
+// with the daemon's timeout and model normalization.
+type Options struct {
+	// Providers to poll with; nil means Providers(). Accounts whose
+	// provider is not among them get an error snapshot.
+	Providers []Provider
+	// FetchTimeout caps each account's fetch; zero means
+	// DefaultFetchTimeout.
+	FetchTimeout time.Duration
+	// ModelNormalization controls model name canonicalization; the zero
+	// value uses the defaults.
+	ModelNormalization ModelNormalizationConfig
+	// Now overrides the clock used for snapshot timestamps and pace.
+	Now func() time.Time
+}
+
+// Poller fetches snapshots for accounts. It is safe for concurrent use and
+// meant to be kept for the life of the program, so that providers' caches
+// are shared across polls.
+type Poller struct {
+	byID         map[string]Provider
+	fetchTimeout time.Duration
+	modelNorm    ModelNormalizationConfig
+	now          func() time.Time
+}
+
+// NewPoller returns a Poller for opts.
+func NewPoller(opts Options) *Poller {
+	provs := opts.Providers
+	if provs == nil {
+		provs = Providers()
+	}
+	byID := make(map[string]Provider, len(provs))
+	for _, p := range provs {
+		byID[p.ID()] = p
+	}
+	p := &Poller{
+		byID:         byID,
+		fetchTimeout: opts.FetchTimeout,
+		modelNorm:    opts.ModelNormalization,
+		now:          opts.Now,
+	}
+	if p.fetchTimeout <= 0 {
+		p.fetchTimeout = DefaultFetchTimeout
+	}
+	if p.now == nil {
+		p.now = time.Now
+	}
+	return p
+}
+
+// Fetch polls one account. Failures — an unknown provider, invalid
+// provider_options, a data_path that matches nothing, a provider error or
+// timeout — come back as a snapshot with StatusError and the reason in
+// Message.
+func (p *Poller) Fetch(ctx context.Context, acct AccountConfig) UsageSnapshot {
+	provider, ok := p.byID[acct.Provider]
+	if !ok {
+		return core.FetchErrorSnapshot(acct.Provider, acct.ID, p.now().UTC(), fmt.Errorf("no provider registered for %q", acct.Provider))
+	}
+	if err := core.ValidateProviderOptions(acct.Provider, provider.Spec().Options, acct.ProviderOptions); err != nil {
+		return core.FetchErrorSnapshot(acct.Provider, acct.ID, p.now().UTC(), err)
+	}
+	acct, err := core.ResolveLocalData(acct, provider.Spec().LocalData)
+	if err != nil {
+		return core.FetchErrorSnapshot(acct.Provider, acct.ID, p.now().UTC(), err)
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, p.fetchTimeout)
+	defer cancel()
+	fetchCtx = shared.WithNetwork(fetchCtx, acct.Network)
+
+	snap, err := provider.Fetch(fetchCtx, acct)
+	if err != nil {
+		snap = core.FetchErrorSnapshot(acct.Provider, acct.ID, p.now().UTC(), err)
+	}
+	snap = core.NormalizeUsageSnapshotWithConfig(snap, p.modelNorm)
+	return core.ApplyTodayPace(snap, p.now())
+}
+
+// FetchAll polls every account concurrently and returns their snapshots
+// sorted by provider and account ID.
+func (p *Poller) FetchAll(ctx context.Context, accounts []AccountConfig) []UsageSnapshot {
+	out := make([]UsageSnapshot, len(accounts))
+	var wg sync.WaitGroup
+	for i, acct := range accounts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out[i] = p.Fetch(ctx, acct)
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ProviderID != out[j].ProviderID {
+			return out[i].ProviderID < out[j].ProviderID
+		}
+		return out[i].AccountID < out[j].AccountID
+	})
+	return out
+}
@@ -0,0 +1,82 @@
+package openusage_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janekbaraniewski/openusage/pkg/openusage"
+)
+
+// fakeProvider is written against the public API only, as an embedding
+// program would.
+type fakeProvider struct {
+	id  string
+	err error
+}
+
+func (p fakeProvider) ID() string                           { return p.id }
+func (p fakeProvider) Describe() openusage.ProviderInfo     { return openusage.ProviderInfo{Name: p.id} }
+func (p fakeProvider) Spec() openusage.ProviderSpec         { return openusage.ProviderSpec{ID: p.id} }
+func (p fakeProvider) DetailWidget() openusage.DetailWidget { return openusage.DetailWidget{} }
+func (p fakeProvider) DashboardWidget() openusage.DashboardWidget {
+	return openusage.DashboardWidget{}
+}
+
+func (p fakeProvider) Fetch(_ context.Context, acct openusage.AccountConfig) (openusage.UsageSnapshot, error) {
+	if p.err != nil {
+		return openusage.UsageSnapshot{}, p.err
+	}
+	used := 12.5
+	return openusage.UsageSnapshot{
+		ProviderID: p.id,
+		AccountID:  acct.ID,
+		Status:     openusage.StatusOK,
+		Metrics:    map[string]openusage.Metric{"spend": {Used: &used, Unit: "USD"}},
+	}, nil
+}
+
+func TestPollerFetchAll(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	poller := openusage.NewPoller(openusage.Options{
+		Providers: []openusage.Provider{fakeProvider{id: "alpha"}, fakeProvider{id: "beta", err: errors.New("boom")}},
+		Now:       func() time.Time { return now },
+	})
+
+	snaps := poller.FetchAll(context.Background(), []openusage.AccountConfig{
+		{ID: "b", Provider: "beta"},
+		{ID: "a2", Provider: "alpha"},
+		{ID: "a1", Provider: "alpha"},
+		{ID: "x", Provider: "missing"},
+		{ID: "a3", Provider: "alpha", ProviderOptions: map[string]any{"nope": true}},
+	})
+
+	var got []string
+	for _, s := range snaps {
+		got = append(got, s.ProviderID+"/"+s.AccountID+"="+string(s.Status))
+	}
+	want := "alpha/a1=OK alpha/a2=OK alpha/a3=ERROR beta/b=ERROR missing/x=ERROR"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("snapshots = %v, want %s", got, want)
+	}
+	if m := snaps[0].Metrics["spend"]; m.Used == nil || *m.Used != 12.5 {
+		t.Errorf("spend = %v, want 12.5", m.Used)
+	}
+	if snaps[3].Message != "boom" {
+		t.Errorf("provider error message = %q, want boom", snaps[3].Message)
+	}
+}
+
+func TestLookupProvider(t *testing.T) {
+	if p, ok := openusage.LookupProvider("openai"); !ok || p.ID() != "openai" {
+		t.Fatalf("LookupProvider(openai) = %v, %v", p, ok)
+	}
+	if _, ok := openusage.LookupProvider("nope"); ok {
+		t.Error("LookupProvider found an unknown provider")
+	}
+	if len(openusage.Providers()) == 0 {
+		t.Error("no built-in providers")
+	}
+}
@@ -0,0 +1,39 @@
+package openusage
+
+import (
+	"fmt"
+
+	"github.com/janekbaraniewski/openusage/internal/config"
+	"github.com/janekbaraniewski/openusage/internal/detect"
+	"github.com/janekbaraniewski/openusage/internal/providers"
+)
+
+// Providers returns a fresh instance of every built-in provider, in
+// registry order. Instances keep per-provider caches, so reuse them across
+// polls rather than calling Providers each time.
+func Providers() []Provider {
+	return providers.AllProviders()
+}
+
+// LookupProvider returns the built-in provider with the given ID, such as
+// "openai" or "claude_code".
+func LookupProvider(id string) (Provider, bool) {
+	for _, p := range Providers() {
+		if p.ID() == id {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// LoadAccounts resolves the accounts OpenUsage itself would poll: those in
+// the user's settings.json, plus auto-detected tools and keys when
+// auto-detection is on, with stored credentials, read-only mode and network
+// settings applied. It reads the user's setup without changing it.
+func LoadAccounts() ([]AccountConfig, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("openusage: loading config: %w", err)
+	}
+	return detect.ResolveAccountsWithoutSaving(&cfg), nil
+}
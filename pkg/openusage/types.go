@@ -0,0 +1,52 @@
+package openusage
+
+import "github.com/janekbaraniewski/openusage/internal/core"
+
+type (
+	// UsageSnapshot is one account's usage at one point in time.
+	UsageSnapshot = core.UsageSnapshot
+	// Metric is a single quota, counter or amount in a snapshot.
+	Metric = core.Metric
+	// TimePoint is one day of a snapshot's daily series.
+	TimePoint = core.TimePoint
+	// ModelUsageRecord is per-model usage with a canonical model ID.
+	ModelUsageRecord = core.ModelUsageRecord
+	// Status summarizes an account's state.
+	Status = core.Status
+
+	// AccountConfig is one account to poll: which provider, and how to
+	// authenticate and find its data.
+	AccountConfig = core.AccountConfig
+
+	// Provider fetches snapshots for accounts of one provider. Programs
+	// may implement it to poll their own sources alongside the built-in
+	// providers.
+	Provider = core.UsageProvider
+	// ProviderInfo is a provider's name and capabilities.
+	ProviderInfo = core.ProviderInfo
+	// ProviderSpec is a provider's auth, setup and presentation metadata.
+	ProviderSpec = core.ProviderSpec
+	// ProviderOption is one key a provider accepts in provider_options.
+	ProviderOption = core.ProviderOption
+	// DashboardWidget describes a provider's dashboard tile.
+	DashboardWidget = core.DashboardWidget
+	// DetailWidget describes a provider's detail view.
+	DetailWidget = core.DetailWidget
+
+	// ModelNormalizationConfig controls how model names are canonicalized.
+	ModelNormalizationConfig = core.ModelNormalizationConfig
+)
+
+const (
+	StatusOK          = core.StatusOK
+	StatusNearLimit   = core.StatusNearLimit
+	StatusExhausted   = core.StatusExhausted
+	StatusDegraded    = core.StatusDegraded
+	StatusStale       = core.StatusStale
+	StatusLimited     = core.StatusLimited
+	StatusAuth        = core.StatusAuth
+	StatusUnsupported = core.StatusUnsupported
+	StatusError       = core.StatusError
+	StatusMaintenance = core.StatusMaintenance
+	StatusUnknown     = core.StatusUnknown
+)